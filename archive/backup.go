@@ -1,13 +1,16 @@
 package archive
 
 import (
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
-	"os"
+	"path/filepath"
+	"time"
 
-	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/server/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
@@ -17,60 +20,86 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+var (
+	errBaseNotInChain  = errors.New("the last block of the base backup isn't in the chain of the node")
+	errNothingToBackup = errors.New("the node has no blocks after the base backup")
+)
+
+// BackupParams are the parameters of the backup
+type BackupParams struct {
+	From    uint64
+	To      *uint64
+	OutPath string
+
+	// Compress gzips the backup file
+	Compress bool
+
+	// BasePath is the previous backup, if set the backup is incremental and holds only the blocks after it
+	BasePath string
+}
+
 // CreateBackup fetches blockchain data with the specific range via gRPC
-// and save this data as binary archive to given path
+// and save this data as binary archive to given path, along with the manifest holding its checksum
 func CreateBackup(
 	conn *grpc.ClientConn,
 	logger hclog.Logger,
-	from uint64,
-	to *uint64,
-	outPath string,
-) (uint64, uint64, error) {
-	// always create new file, throw error if the file exists
-	fs, err := os.OpenFile(outPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
-	if err != nil {
-		return 0, 0, err
-	}
+	params *BackupParams,
+) (*Manifest, error) {
+	ctx, cancelFn := withTerminationSignal(logger)
+	defer cancelFn()
 
-	closeFile := func() error {
-		if err := fs.Close(); err != nil {
-			logger.Error("an error occurred while closing file", "err", err)
+	return createBackup(ctx, proto.NewSystemClient(conn), logger, params)
+}
 
-			return err
-		}
+func createBackup(
+	ctx context.Context,
+	clt proto.SystemClient,
+	logger hclog.Logger,
+	params *BackupParams,
+) (*Manifest, error) {
+	from := params.From
 
-		return nil
-	}
-	removeFile := func() {
-		if err := os.Remove(outPath); err != nil {
-			logger.Error("an error occurred while removing file", "err", err)
+	var base *ManifestBase
+
+	if params.BasePath != "" {
+		var err error
+
+		if base, err = readBase(ctx, clt, params.BasePath); err != nil {
+			return nil, err
 		}
+
+		from = base.To + 1
 	}
-	// clean up function for the file when error occurs in the middle of function
-	closeAndRemoveFile := func() {
-		if err := closeFile(); err == nil {
-			removeFile()
-		}
+
+	storage, err := storageFor(params.OutPath)
+	if err != nil {
+		return nil, err
 	}
 
-	signalCh := common.GetTerminationSignalCh()
-	ctx, cancelFn := context.WithCancel(context.Background())
+	// always create new backup, throw error if the backup exists
+	out, err := storage.create(ctx, params.OutPath)
+	if err != nil {
+		return nil, err
+	}
 
-	defer cancelFn()
+	// clean up function for the backup when error occurs in the middle of function
+	abort := func() {
+		if err := out.abort(); err != nil {
+			logger.Error("an error occurred while removing backup", "err", err)
+		}
+	}
 
-	go func() {
-		<-signalCh
-		logger.Info("Caught termination signal, shutting down...")
-		cancelFn()
-	}()
+	reqTo, reqToHash, err := determineTo(ctx, clt, params.To)
+	if err != nil {
+		abort()
 
-	clt := proto.NewSystemClient(conn)
+		return nil, err
+	}
 
-	reqTo, reqToHash, err := determineTo(ctx, clt, to)
-	if err != nil {
-		closeAndRemoveFile()
+	if base != nil && reqTo <= base.To {
+		abort()
 
-		return 0, 0, err
+		return nil, fmt.Errorf("%w (latest %d, base %d)", errNothingToBackup, reqTo, base.To)
 	}
 
 	stream, err := clt.Export(ctx, &proto.ExportRequest{
@@ -78,31 +107,112 @@ func CreateBackup(
 		To:   reqTo,
 	})
 	if err != nil {
-		closeAndRemoveFile()
+		abort()
 
-		return 0, 0, err
+		return nil, err
 	}
 
-	if err := writeMetadata(fs, logger, reqTo, reqToHash); err != nil {
-		closeAndRemoveFile()
+	// the checksum covers the bytes of the file, so it is verified without decompressing
+	var (
+		hasher               = sha256.New()
+		counter              = &countingWriter{}
+		writer     io.Writer = io.MultiWriter(out, hasher, counter)
+		gzipWriter *gzip.Writer
+	)
+
+	if params.Compress {
+		gzipWriter = gzip.NewWriter(writer)
+		writer = gzipWriter
+	}
 
-		return 0, 0, err
+	if err := writeMetadata(writer, logger, reqTo, reqToHash); err != nil {
+		abort()
+
+		return nil, err
 	}
 
-	resFrom, resTo, err := processExportStream(stream, logger, fs, from, reqTo)
+	resFrom, resTo, err := processExportStream(stream, logger, writer, from, reqTo)
 	if err != nil {
-		closeAndRemoveFile()
+		abort()
+
+		return nil, err
+	}
+
+	if gzipWriter != nil {
+		if err := gzipWriter.Close(); err != nil {
+			abort()
+
+			return nil, err
+		}
+	}
 
-		return 0, 0, err
+	if err := out.commit(); err != nil {
+		return nil, err
 	}
 
-	if err := closeFile(); err != nil {
-		removeFile()
+	manifest := &Manifest{
+		Version:    manifestVersion,
+		File:       filepath.Base(params.OutPath),
+		From:       *resFrom,
+		To:         *resTo,
+		LatestHash: reqToHash,
+		Compressed: params.Compress,
+		Size:       counter.size,
+		SHA256:     hex.EncodeToString(hasher.Sum(nil)),
+		CreatedAt:  time.Now().UTC(),
+		Base:       base,
+	}
+
+	if err := writeManifest(params.OutPath, manifest); err != nil {
+		if err := storage.remove(ctx, params.OutPath); err != nil {
+			logger.Error("an error occurred while removing backup", "err", err)
+		}
+
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// countingWriter counts the written bytes
+type countingWriter struct {
+	size int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.size += int64(len(p))
+
+	return len(p), nil
+}
+
+// readBase reads the manifest of the base backup and checks that the node has its last block,
+// so the incremental backup continues the same chain
+func readBase(ctx context.Context, clt proto.SystemClient, basePath string) (*ManifestBase, error) {
+	manifest, err := ReadManifest(basePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the manifest of the base backup: %w", err)
+	}
+
+	resp, err := clt.BlockByNumber(ctx, &proto.BlockByNumberRequest{Number: manifest.To})
+	if err != nil {
+		return nil, fmt.Errorf("%w: block %d: %v", errBaseNotInChain, manifest.To, err)
+	}
+
+	block := &types.Block{}
+	if err := block.UnmarshalRLP(resp.Data); err != nil {
+		return nil, err
+	}
 
-		return 0, 0, err
+	if block.Hash() != manifest.LatestHash {
+		return nil, fmt.Errorf("%w: block %d is %s, expected %s",
+			errBaseNotInChain, manifest.To, block.Hash(), manifest.LatestHash)
 	}
 
-	return *resFrom, *resTo, nil
+	return &ManifestBase{
+		File:       manifest.File,
+		To:         manifest.To,
+		LatestHash: manifest.LatestHash,
+	}, nil
 }
 
 func determineTo(ctx context.Context, clt proto.SystemClient, to *uint64) (uint64, types.Hash, error) {
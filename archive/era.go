@@ -0,0 +1,558 @@
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// DefaultEraBlocks is the default number of the blocks in one era file
+	DefaultEraBlocks uint64 = 8192
+
+	// eraFileFormat is the name of the era file holding the blocks from the first to the last one,
+	// the numbers are padded so the files are sorted by their names
+	eraFileFormat = "era-%010d-%010d.rlp.gz"
+
+	// maxImportPayloadSize is the max size of the blocks sent to the node in one request
+	maxImportPayloadSize = 512 * 1024
+)
+
+var (
+	errEraHeaderNotFound = errors.New("expected header in era file but doesn't exist")
+	errEraIncomplete     = errors.New("era file doesn't hold all the blocks of its range")
+)
+
+// EraExportResult is the summary of the exported era files
+type EraExportResult struct {
+	From    uint64
+	To      uint64
+	Written int
+	Skipped int
+}
+
+// EraImportResult is the summary of the imported era files
+type EraImportResult struct {
+	Files    int
+	Skipped  int
+	Imported uint64
+	Latest   uint64
+}
+
+// eraFile is the era file found in the directory
+type eraFile struct {
+	first uint64
+	last  uint64
+	path  string
+}
+
+// ExportEra fetches the blocks with the specific range via gRPC and saves them into the gzip compressed era files
+// in the directory, each of them holding up to blocksPerFile blocks aligned to the multiples of blocksPerFile.
+// The era files are written to the temporary files first, so the existing era files are complete
+// and they are skipped when the interrupted export is run again
+func ExportEra(
+	conn *grpc.ClientConn,
+	logger hclog.Logger,
+	from uint64,
+	to *uint64,
+	dir string,
+	blocksPerFile uint64,
+	withReceipts bool,
+) (*EraExportResult, error) {
+	ctx, cancelFn := withTerminationSignal(logger)
+	defer cancelFn()
+
+	return exportEra(ctx, proto.NewSystemClient(conn), logger, from, to, dir, blocksPerFile, withReceipts)
+}
+
+func exportEra(
+	ctx context.Context,
+	clt proto.SystemClient,
+	logger hclog.Logger,
+	from uint64,
+	to *uint64,
+	dir string,
+	blocksPerFile uint64,
+	withReceipts bool,
+) (*EraExportResult, error) {
+	if blocksPerFile == 0 {
+		return nil, errors.New("number of blocks per era file must be positive")
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	existing, err := readEraDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	existingByFirst := make(map[uint64]eraFile, len(existing))
+	for _, file := range existing {
+		existingByFirst[file.first] = file
+	}
+
+	last, _, err := determineTo(ctx, clt, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if from > last {
+		return nil, fmt.Errorf("the beginning height %d is above the latest block %d", from, last)
+	}
+
+	result := &EraExportResult{From: from, To: last}
+
+	for first := from; first <= last; {
+		fileLast := common.Min((first/blocksPerFile+1)*blocksPerFile-1, last)
+
+		if file, ok := existingByFirst[first]; ok && file.last == fileLast {
+			result.Skipped++
+		} else {
+			path := filepath.Join(dir, fmt.Sprintf(eraFileFormat, first, fileLast))
+
+			if err := writeEraFile(ctx, clt, path, first, fileLast, withReceipts); err != nil {
+				return nil, err
+			}
+
+			// the era file written by the export of the shorter range is replaced by the complete one
+			if ok {
+				if err := os.Remove(file.path); err != nil {
+					return nil, err
+				}
+			}
+
+			result.Written++
+		}
+
+		logger.Info(
+			"Exported era file",
+			"from", first,
+			"to", fileLast,
+			"progress", fmt.Sprintf("%.2f%%", 100*float64(fileLast-from+1)/float64(last-from+1)),
+		)
+
+		first = fileLast + 1
+	}
+
+	return result, nil
+}
+
+// writeEraFile writes the blocks of the range to the era file via the temporary file
+func writeEraFile(
+	ctx context.Context,
+	clt proto.SystemClient,
+	path string,
+	first, last uint64,
+	withReceipts bool,
+) error {
+	resp, err := clt.BlockByNumber(ctx, &proto.BlockByNumberRequest{Number: last})
+	if err != nil {
+		return err
+	}
+
+	lastBlock := &types.Block{}
+	if err := lastBlock.UnmarshalRLP(resp.Data); err != nil {
+		return err
+	}
+
+	tmpPath := path + ".tmp"
+
+	fs, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = fs.Close()
+		_ = os.Remove(tmpPath)
+	}()
+
+	writer := gzip.NewWriter(fs)
+
+	header := &EraHeader{
+		First:    first,
+		Last:     last,
+		LastHash: lastBlock.Hash(),
+		Receipts: withReceipts,
+	}
+
+	if _, err := writer.Write(header.MarshalRLP()); err != nil {
+		return err
+	}
+
+	if err := writeEraBlocks(ctx, clt, writer, header); err != nil {
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	if err := fs.Sync(); err != nil {
+		return err
+	}
+
+	if err := fs.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// writeEraBlocks streams the blocks of the range from the node to the writer,
+// each block is followed by its receipts if they are exported
+func writeEraBlocks(ctx context.Context, clt proto.SystemClient, writer io.Writer, header *EraHeader) error {
+	stream, err := clt.Export(ctx, &proto.ExportRequest{
+		From:     header.First,
+		To:       header.Last,
+		Receipts: header.Receipts,
+	})
+	if err != nil {
+		return err
+	}
+
+	next := header.First
+
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		blocks := newBlockStream(bytes.NewReader(event.Data))
+		receipts := newBlockStream(bytes.NewReader(event.Receipts))
+
+		for {
+			block, err := blocks.nextRLP()
+			if err != nil {
+				return err
+			}
+
+			if block == nil {
+				break
+			}
+
+			if _, err := writer.Write(block); err != nil {
+				return err
+			}
+
+			if header.Receipts {
+				blockReceipts, err := receipts.nextRLP()
+				if err != nil {
+					return err
+				}
+
+				if blockReceipts == nil {
+					return fmt.Errorf("receipts of block #%d not received", next)
+				}
+
+				if _, err := writer.Write(blockReceipts); err != nil {
+					return err
+				}
+			}
+
+			next++
+		}
+	}
+
+	if next != header.Last+1 {
+		return fmt.Errorf("%w, expected blocks from %d to %d but received until %d",
+			errEraIncomplete, header.First, header.Last, next-1)
+	}
+
+	return nil
+}
+
+// ImportEra reads the blocks from the era files in the directory and sends them to the node via gRPC,
+// which verifies and writes them. The era files whose blocks are in the chain already are skipped,
+// so the interrupted import continues from the latest block of the node when it is run again
+func ImportEra(conn *grpc.ClientConn, logger hclog.Logger, dir string) (*EraImportResult, error) {
+	ctx, cancelFn := withTerminationSignal(logger)
+	defer cancelFn()
+
+	return importEra(ctx, proto.NewSystemClient(conn), logger, dir)
+}
+
+func importEra(ctx context.Context, clt proto.SystemClient, logger hclog.Logger, dir string) (*EraImportResult, error) {
+	files, err := readEraDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no era files found in %s", dir)
+	}
+
+	result := &EraImportResult{Files: len(files)}
+
+	for i, file := range files {
+		imported, skipped, latest, err := importEraFile(ctx, clt, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import era file %s: %w", file.path, err)
+		}
+
+		if skipped {
+			result.Skipped++
+		}
+
+		result.Imported += imported
+		result.Latest = latest
+
+		logger.Info(
+			"Imported era file",
+			"from", file.first,
+			"to", file.last,
+			"imported", imported,
+			"latest", latest,
+			"progress", fmt.Sprintf("%.2f%%", 100*float64(i+1)/float64(len(files))),
+		)
+	}
+
+	return result, nil
+}
+
+// importEraFile sends the blocks of the era file to the node in the batches,
+// it returns the number of the written blocks and whether the file is skipped
+func importEraFile(ctx context.Context, clt proto.SystemClient, file eraFile) (uint64, bool, uint64, error) {
+	status, err := clt.GetStatus(ctx, &emptypb.Empty{})
+	if err != nil {
+		return 0, false, 0, err
+	}
+
+	latest := uint64(status.Current.Number)
+
+	fs, err := os.Open(file.path)
+	if err != nil {
+		return 0, false, latest, err
+	}
+
+	defer fs.Close()
+
+	reader, err := newEraReader(fs)
+	if err != nil {
+		return 0, false, latest, err
+	}
+
+	header := reader.header
+
+	// check whether the local chain has the last block of the file already
+	if header.Last <= latest {
+		resp, err := clt.BlockByNumber(ctx, &proto.BlockByNumberRequest{Number: header.Last})
+		if err == nil {
+			block := &types.Block{}
+			if err := block.UnmarshalRLP(resp.Data); err == nil && block.Hash() == header.LastHash {
+				return 0, true, latest, nil
+			}
+		}
+	}
+
+	var (
+		batch    bytes.Buffer
+		imported uint64
+		next     = header.First
+	)
+
+	flush := func() error {
+		if batch.Len() == 0 {
+			return nil
+		}
+
+		resp, err := clt.ImportBlocks(ctx, &proto.ImportBlocksRequest{Data: batch.Bytes()})
+		if err != nil {
+			return err
+		}
+
+		imported += resp.Imported
+		latest = resp.Latest
+
+		batch.Reset()
+
+		return nil
+	}
+
+	for {
+		block, err := reader.nextBlock()
+		if err != nil {
+			return imported, false, latest, err
+		}
+
+		if block == nil {
+			break
+		}
+
+		if batch.Len()+len(block) > maxImportPayloadSize {
+			if err := flush(); err != nil {
+				return imported, false, latest, err
+			}
+		}
+
+		batch.Write(block)
+
+		next++
+	}
+
+	if next != header.Last+1 {
+		return imported, false, latest, errEraIncomplete
+	}
+
+	if err := flush(); err != nil {
+		return imported, false, latest, err
+	}
+
+	return imported, false, latest, nil
+}
+
+// eraReader reads the blocks from the gzip compressed era file
+type eraReader struct {
+	header *EraHeader
+	stream *blockStream
+}
+
+func newEraReader(input io.Reader) (*eraReader, error) {
+	gz, err := gzip.NewReader(input)
+	if err != nil {
+		return nil, err
+	}
+
+	stream := newBlockStream(fullReader{gz})
+
+	data, err := stream.nextRLP()
+	if err != nil {
+		return nil, err
+	}
+
+	if data == nil {
+		return nil, errEraHeaderNotFound
+	}
+
+	header := &EraHeader{}
+	if err := header.UnmarshalRLP(data); err != nil {
+		return nil, err
+	}
+
+	return &eraReader{
+		header: header,
+		stream: stream,
+	}, nil
+}
+
+// nextBlock returns the next RLP encoded block, skipping its receipts.
+// The returned data is valid until the next call
+func (r *eraReader) nextBlock() ([]byte, error) {
+	block, _, err := r.nextBlockWithReceipts()
+
+	return block, err
+}
+
+// nextBlockWithReceipts returns the next block and its receipts, which are nil if the era file has no receipts
+func (r *eraReader) nextBlockWithReceipts() ([]byte, types.Receipts, error) {
+	data, err := r.stream.nextRLP()
+	if err != nil || data == nil {
+		return nil, nil, err
+	}
+
+	block := append([]byte(nil), data...)
+
+	if !r.header.Receipts {
+		return block, nil, nil
+	}
+
+	data, err = r.stream.nextRLP()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if data == nil {
+		return nil, nil, errEraIncomplete
+	}
+
+	var receipts types.Receipts
+	if err := receipts.UnmarshalStoreRLP(data); err != nil {
+		return nil, nil, err
+	}
+
+	return block, receipts, nil
+}
+
+// readEraDir returns the era files in the directory sorted by their first blocks
+func readEraDir(dir string) ([]eraFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]eraFile, 0, len(entries))
+
+	for _, entry := range entries {
+		var file eraFile
+
+		if entry.IsDir() {
+			continue
+		}
+
+		if n, err := fmt.Sscanf(entry.Name(), eraFileFormat, &file.first, &file.last); err != nil || n != 2 {
+			continue
+		}
+
+		// skip the temporary files left by the interrupted export
+		if entry.Name() != fmt.Sprintf(eraFileFormat, file.first, file.last) {
+			continue
+		}
+
+		file.path = filepath.Join(dir, entry.Name())
+		files = append(files, file)
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].first < files[j].first
+	})
+
+	return files, nil
+}
+
+// withTerminationSignal returns the context canceled on the termination signal
+func withTerminationSignal(logger hclog.Logger) (context.Context, context.CancelFunc) {
+	signalCh := common.GetTerminationSignalCh()
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	go func() {
+		select {
+		case <-signalCh:
+			logger.Info("Caught termination signal, shutting down...")
+			cancelFn()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancelFn
+}
+
+// fullReader fills the whole buffer on every read, since the block stream
+// expects the complete reads which the decompressing reader doesn't guarantee
+type fullReader struct {
+	io.Reader
+}
+
+func (r fullReader) Read(p []byte) (int, error) {
+	return io.ReadFull(r.Reader, p)
+}
@@ -0,0 +1,283 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// eraClientMock serves the export and the import of the era files from the mock chain
+type eraClientMock struct {
+	proto.SystemClient
+	chain    *mockChain
+	receipts map[types.Hash]types.Receipts
+}
+
+func (m *eraClientMock) GetStatus(context.Context, *emptypb.Empty, ...grpc.CallOption) (*proto.ServerStatus, error) {
+	latest := getLatestBlockFromMockChain(m.chain)
+
+	return &proto.ServerStatus{
+		Current: &proto.ServerStatus_Block{
+			Number: int64(latest.Number()),
+			Hash:   latest.Hash().String(),
+		},
+	}, nil
+}
+
+func (m *eraClientMock) BlockByNumber(
+	_ context.Context,
+	req *proto.BlockByNumberRequest,
+	_ ...grpc.CallOption,
+) (*proto.BlockResponse, error) {
+	block, ok := m.chain.GetBlockByNumber(req.Number, true)
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+
+	return &proto.BlockResponse{Data: block.MarshalRLP()}, nil
+}
+
+func (m *eraClientMock) Export(
+	_ context.Context,
+	req *proto.ExportRequest,
+	_ ...grpc.CallOption,
+) (proto.System_ExportClient, error) {
+	stream := &mockSystemExportClient{}
+
+	// every event holds up to 3 blocks
+	for from := req.From; from <= req.To; from += 3 {
+		event := &proto.ExportEvent{From: from}
+
+		for num := from; num <= req.To && num < from+3; num++ {
+			block, ok := m.chain.GetBlockByNumber(num, true)
+			if !ok {
+				break
+			}
+
+			event.To = num
+			event.Data = append(event.Data, block.MarshalRLP()...)
+
+			if req.Receipts {
+				event.Receipts = append(event.Receipts, m.receipts[block.Hash()].MarshalStoreRLPTo(nil)...)
+			}
+		}
+
+		stream.recvs = append(stream.recvs, recvData{event: event})
+	}
+
+	return stream, nil
+}
+
+func (m *eraClientMock) ImportBlocks(
+	_ context.Context,
+	req *proto.ImportBlocksRequest,
+	_ ...grpc.CallOption,
+) (*proto.ImportBlocksResponse, error) {
+	imported, err := ImportBlocks(m.chain, req.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.ImportBlocksResponse{
+		Imported: imported,
+		Latest:   getLatestBlockFromMockChain(m.chain).Number(),
+	}, nil
+}
+
+// newEraSourceClient returns the client of the chain with the given number of the blocks,
+// every other block has a transaction and its receipt
+func newEraSourceClient(num uint64) *eraClientMock {
+	genesis := (&types.Header{Number: 0}).ComputeHash()
+	chain := &mockChain{
+		genesis: &types.Block{Header: genesis},
+		blocks:  []*types.Block{{Header: genesis}},
+	}
+	receipts := make(map[types.Hash]types.Receipts)
+	parent := genesis
+
+	for i := uint64(1); i <= num; i++ {
+		block := &types.Block{
+			Header: &types.Header{Number: i, ParentHash: parent.Hash},
+		}
+
+		if i%2 == 0 {
+			block.Transactions = []*types.Transaction{{
+				Nonce:    i,
+				GasPrice: big.NewInt(1),
+				Value:    big.NewInt(1),
+				V:        big.NewInt(1),
+				R:        big.NewInt(1),
+				S:        big.NewInt(1),
+			}}
+		}
+
+		block.Header.ComputeHash()
+		chain.blocks = append(chain.blocks, block)
+
+		if len(block.Transactions) > 0 {
+			receipts[block.Hash()] = types.Receipts{{CumulativeGasUsed: i, Logs: []*types.Log{}}}
+		}
+
+		parent = block.Header
+	}
+
+	return &eraClientMock{chain: chain, receipts: receipts}
+}
+
+func eraFileNames(t *testing.T, dir string) []string {
+	t.Helper()
+
+	files, err := readEraDir(dir)
+	require.NoError(t, err)
+
+	names := make([]string, len(files))
+	for i, file := range files {
+		names[i] = filepath.Base(file.path)
+	}
+
+	return names
+}
+
+func TestEraHeader_RLP(t *testing.T) {
+	t.Parallel()
+
+	header := &EraHeader{
+		First:    8192,
+		Last:     16383,
+		LastHash: types.StringToHash("1"),
+		Receipts: true,
+	}
+
+	decoded := &EraHeader{}
+	require.NoError(t, decoded.UnmarshalRLP(header.MarshalRLP()))
+	require.Equal(t, header, decoded)
+}
+
+func Test_exportEra(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx    = context.Background()
+		logger = hclog.NewNullLogger()
+		dir    = t.TempDir()
+		source = newEraSourceClient(10)
+		to     = uint64(5)
+	)
+
+	// the export of the shorter range writes the partial era file
+	result, err := exportEra(ctx, source, logger, 0, &to, dir, 4, true)
+	require.NoError(t, err)
+	require.Equal(t, &EraExportResult{From: 0, To: 5, Written: 2}, result)
+	require.Equal(t, []string{"era-0000000000-0000000003.rlp.gz", "era-0000000004-0000000005.rlp.gz"},
+		eraFileNames(t, dir))
+
+	// the complete files are skipped and the partial one is replaced
+	result, err = exportEra(ctx, source, logger, 0, nil, dir, 4, true)
+	require.NoError(t, err)
+	require.Equal(t, &EraExportResult{From: 0, To: 10, Written: 2, Skipped: 1}, result)
+	require.Equal(t, []string{
+		"era-0000000000-0000000003.rlp.gz",
+		"era-0000000004-0000000007.rlp.gz",
+		"era-0000000008-0000000010.rlp.gz",
+	}, eraFileNames(t, dir))
+
+	fs, err := os.Open(filepath.Join(dir, "era-0000000004-0000000007.rlp.gz"))
+	require.NoError(t, err)
+
+	defer fs.Close()
+
+	reader, err := newEraReader(fs)
+	require.NoError(t, err)
+	require.Equal(t, source.chain.blocks[7].Hash(), reader.header.LastHash)
+	require.True(t, reader.header.Receipts)
+
+	for num := uint64(4); num <= 7; num++ {
+		data, receipts, err := reader.nextBlockWithReceipts()
+		require.NoError(t, err)
+
+		block := &types.Block{}
+		require.NoError(t, block.UnmarshalRLP(data))
+		require.Equal(t, source.chain.blocks[num].Hash(), block.Hash())
+		require.Len(t, receipts, len(block.Transactions))
+	}
+
+	data, _, err := reader.nextBlockWithReceipts()
+	require.NoError(t, err)
+	require.Nil(t, data)
+}
+
+func Test_importEra(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx    = context.Background()
+		logger = hclog.NewNullLogger()
+		dir    = t.TempDir()
+		source = newEraSourceClient(10)
+	)
+
+	_, err := exportEra(ctx, source, logger, 0, nil, dir, 4, false)
+	require.NoError(t, err)
+
+	// the temporary file left by the interrupted export is ignored
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "era-0000000012-0000000015.rlp.gz.tmp"), nil, 0600))
+
+	target := &eraClientMock{
+		chain: &mockChain{
+			genesis: source.chain.genesis,
+			blocks:  append([]*types.Block(nil), source.chain.blocks[:6]...),
+		},
+	}
+
+	result, err := importEra(ctx, target, logger, dir)
+	require.NoError(t, err)
+	require.Equal(t, &EraImportResult{Files: 3, Skipped: 1, Imported: 5, Latest: 10}, result)
+	require.Len(t, target.chain.blocks, 11)
+
+	for num, block := range target.chain.blocks {
+		require.Equal(t, source.chain.blocks[num].Hash(), block.Hash())
+	}
+
+	// the imported files are skipped
+	result, err = importEra(ctx, target, logger, dir)
+	require.NoError(t, err)
+	require.Equal(t, &EraImportResult{Files: 3, Skipped: 3, Latest: 10}, result)
+}
+
+func TestImportBlocks(t *testing.T) {
+	t.Parallel()
+
+	source := newEraSourceClient(3)
+
+	var data bytes.Buffer
+	for _, block := range source.chain.blocks {
+		data.Write(block.MarshalRLP())
+	}
+
+	chain := &mockChain{
+		genesis: source.chain.genesis,
+		blocks:  append([]*types.Block(nil), source.chain.blocks[:2]...),
+	}
+
+	imported, err := ImportBlocks(chain, data.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), imported)
+	require.Len(t, chain.blocks, 4)
+
+	// the blocks of the other chain are rejected
+	chain.genesis = &types.Block{Header: (&types.Header{Number: 0, ExtraData: []byte{1}}).ComputeHash()}
+
+	_, err = ImportBlocks(chain, data.Bytes())
+	require.ErrorContains(t, err, "does not match blockchain genesis")
+}
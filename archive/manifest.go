@@ -0,0 +1,128 @@
+package archive
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// manifestVersion is the version of the manifest format
+	manifestVersion = 1
+
+	// manifestSuffix is appended to the path of the backup to get the path of its manifest
+	manifestSuffix = ".manifest.json"
+)
+
+var (
+	errChecksumMismatch = errors.New("the checksum of the backup doesn't match its manifest")
+	errSizeMismatch     = errors.New("the size of the backup doesn't match its manifest")
+)
+
+// ManifestBase identifies the backup which the incremental backup continues
+type ManifestBase struct {
+	File       string     `json:"file"`
+	To         uint64     `json:"to"`
+	LatestHash types.Hash `json:"latestHash"`
+}
+
+// Manifest describes the backup file, it is stored next to the backup
+// so the integrity of the backup can be verified before it is restored
+type Manifest struct {
+	Version    int        `json:"version"`
+	File       string     `json:"file"`
+	From       uint64     `json:"from"`
+	To         uint64     `json:"to"`
+	LatestHash types.Hash `json:"latestHash"`
+	Compressed bool       `json:"compressed"`
+	Size       int64      `json:"size"`
+	SHA256     string     `json:"sha256"`
+	CreatedAt  time.Time  `json:"createdAt"`
+
+	// Base is the backup continued by the incremental backup, nil for the full backup
+	Base *ManifestBase `json:"base,omitempty"`
+}
+
+// ManifestPath returns the path of the manifest of the backup
+func ManifestPath(backupPath string) string {
+	return backupPath + manifestSuffix
+}
+
+// ReadManifest reads the manifest of the backup, it returns the error satisfying os.IsNotExist
+// if the backup has no manifest
+func ReadManifest(backupPath string) (*Manifest, error) {
+	storage, err := storageFor(backupPath)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := storage.readFile(context.Background(), ManifestPath(backupPath))
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest of the backup %s: %w", backupPath, err)
+	}
+
+	if manifest.Version > manifestVersion {
+		return nil, fmt.Errorf("unsupported manifest version %d of the backup %s", manifest.Version, backupPath)
+	}
+
+	return manifest, nil
+}
+
+// writeManifest writes the manifest next to the backup
+func writeManifest(backupPath string, manifest *Manifest) error {
+	storage, err := storageFor(backupPath)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return storage.writeFile(context.Background(), ManifestPath(backupPath), data)
+}
+
+// VerifyChecksum checks the size and the checksum of the backup file against the manifest
+func (m *Manifest) VerifyChecksum(backupPath string) error {
+	storage, err := storageFor(backupPath)
+	if err != nil {
+		return err
+	}
+
+	fs, err := storage.open(context.Background(), backupPath)
+	if err != nil {
+		return err
+	}
+
+	defer fs.Close()
+
+	hasher := sha256.New()
+
+	size, err := io.Copy(hasher, fs)
+	if err != nil {
+		return err
+	}
+
+	if size != m.Size {
+		return fmt.Errorf("%w: %d bytes, expected %d", errSizeMismatch, size, m.Size)
+	}
+
+	if checksum := hex.EncodeToString(hasher.Sum(nil)); checksum != m.SHA256 {
+		return fmt.Errorf("%w: %s, expected %s", errChecksumMismatch, checksum, m.SHA256)
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package archive
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -28,15 +29,20 @@ type blockchainInterface interface {
 }
 
 // RestoreChain reads blocks from the archive and write to the chain
+// the archive is either plain or gzip compressed, and it is checked against its manifest if it has one
 func RestoreChain(chain blockchainInterface, filePath string, progression *progress.ProgressionWrapper) error {
-	fp, err := os.Open(filePath)
+	if _, err := verifyManifest(filePath); err != nil {
+		return err
+	}
+
+	reader, err := openBackup(filePath)
 	if err != nil {
 		return err
 	}
 
-	blockStream := newBlockStream(fp)
+	defer reader.Close()
 
-	return importBlocks(chain, blockStream, progression)
+	return importBlocks(chain, reader.stream, progression)
 }
 
 // import blocks scans all blocks from stream and write them to chain
@@ -49,7 +55,7 @@ func importBlocks(chain blockchainInterface, blockStream *blockStream, progressi
 	}
 
 	if metadata == nil {
-		return errors.New("expected metadata in archive but doesn't exist")
+		return errMetadataNotFound
 	}
 
 	// check whether the local chain has the latest block already
@@ -130,12 +136,8 @@ func consumeCommonBlocks(
 		}
 
 		if block.Number() == 0 {
-			if block.Hash() != chain.Genesis() {
-				return nil, fmt.Errorf(
-					"the hash of genesis block (%s) does not match blockchain genesis (%s)",
-					block.Hash(),
-					chain.Genesis(),
-				)
+			if err := checkGenesis(chain, block); err != nil {
+				return nil, err
 			}
 
 			continue
@@ -153,6 +155,60 @@ func consumeCommonBlocks(
 	}
 }
 
+// ImportBlocks verifies and writes the RLP encoded blocks to the chain, skipping the blocks in the chain already.
+// It returns the number of the written blocks
+func ImportBlocks(chain blockchainInterface, data []byte) (uint64, error) {
+	blockStream := newBlockStream(bytes.NewReader(data))
+
+	var imported uint64
+
+	for {
+		block, err := blockStream.nextBlock()
+		if err != nil {
+			return imported, err
+		}
+
+		if block == nil {
+			return imported, nil
+		}
+
+		if block.Number() == 0 {
+			if err := checkGenesis(chain, block); err != nil {
+				return imported, err
+			}
+
+			continue
+		}
+
+		if hash := chain.GetHashByNumber(block.Number()); hash == block.Hash() {
+			continue
+		}
+
+		if _, err := chain.VerifyFinalizedBlock(block); err != nil {
+			return imported, err
+		}
+
+		if err := chain.WriteBlock(block, restore); err != nil {
+			return imported, err
+		}
+
+		imported++
+	}
+}
+
+// checkGenesis returns an error if the genesis block doesn't match the genesis of the chain
+func checkGenesis(chain blockchainInterface, block *types.Block) error {
+	if block.Hash() != chain.Genesis() {
+		return fmt.Errorf(
+			"the hash of genesis block (%s) does not match blockchain genesis (%s)",
+			block.Hash(),
+			chain.Genesis(),
+		)
+	}
+
+	return nil
+}
+
 // blockStream parse RLP-encoded block from stream and consumed the used bytes
 type blockStream struct {
 	input  io.Reader
@@ -199,6 +255,21 @@ func (b *blockStream) nextBlock() (*types.Block, error) {
 	return block, nil
 }
 
+// nextRLP consumes some bytes from input and returns the RLP encoded array,
+// which is valid until the next read from the stream
+func (b *blockStream) nextRLP() ([]byte, error) {
+	size, err := b.loadRLPArray()
+	if err != nil {
+		return nil, err
+	}
+
+	if size == 0 {
+		return nil, nil
+	}
+
+	return b.buffer[:size], nil
+}
+
 // loadRLPArray loads RLP encoded array from input to buffer
 func (b *blockStream) loadRLPArray() (uint64, error) {
 	prefix, err := b.loadRLPPrefix()
@@ -268,6 +339,12 @@ func (b *blockStream) loadPrefixSize(offset uint64, prefix byte) (uint64, uint64
 // loadPayload loads payload data from stream and store to buffer
 func (b *blockStream) loadPayload(offset uint64, size uint64) error {
 	b.reserveCap(offset + size)
+
+	// the empty array has no payload, while the reader may return EOF for the empty read
+	if size == 0 {
+		return nil
+	}
+
 	buf := b.buffer[offset : offset+size]
 
 	if _, err := b.input.Read(buf); err != nil {
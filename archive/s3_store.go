@@ -0,0 +1,161 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// S3Config is the configuration of the S3-compatible storage of the backups.
+// The credentials are taken from the environment or the shared AWS configuration
+type S3Config struct {
+	Bucket string
+	// Prefix is prepended to the object keys of the backups
+	Prefix string
+	Region string
+	// Endpoint is the URL of the S3-compatible storage, the AWS S3 if empty
+	Endpoint string
+}
+
+// s3Store uploads the backups to the S3-compatible storage, deleting their local files once uploaded
+type s3Store struct {
+	bucket string
+	prefix string
+
+	client   *s3.S3
+	uploader *s3manager.Uploader
+}
+
+// NewS3Store creates the store uploading the backups to the S3-compatible storage
+func NewS3Store(config *S3Config) (BackupStore, error) {
+	sess, err := newS3Session(config.Region, config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3Store{
+		bucket:   config.Bucket,
+		prefix:   config.Prefix,
+		client:   s3.New(sess),
+		uploader: s3manager.NewUploader(sess),
+	}, nil
+}
+
+// newS3Session creates the session of the S3-compatible storage, the AWS S3 if the endpoint is empty.
+// The region is taken from the environment or the shared AWS configuration if empty
+func newS3Session(region, endpoint string) (*session.Session, error) {
+	cfg := aws.NewConfig().WithRegion(region)
+	if endpoint != "" {
+		// the S3-compatible storages mostly don't support the virtual hosted buckets
+		cfg = cfg.WithEndpoint(endpoint).WithS3ForcePathStyle(true)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *cfg,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize S3 client: %w", err)
+	}
+
+	return sess, nil
+}
+
+// Put uploads the backup file and its manifest, and deletes them locally
+func (s *s3Store) Put(ctx context.Context, backupPath string) error {
+	// the manifest is uploaded last, so the listed backups are complete
+	for _, file := range []string{backupPath, ManifestPath(backupPath)} {
+		if err := s.upload(ctx, file); err != nil {
+			return err
+		}
+	}
+
+	for _, file := range []string{ManifestPath(backupPath), backupPath} {
+		if err := os.Remove(file); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *s3Store) upload(ctx context.Context, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	_, err = s.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(filepath.Base(file))),
+		Body:   f,
+	})
+
+	return err
+}
+
+// List returns the names of the uploaded backups, which have the manifest
+func (s *s3Store) List(ctx context.Context) ([]string, error) {
+	keys := make(map[string]struct{})
+
+	err := s.client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(s.key("")),
+	}, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, object := range page.Contents {
+			keys[path.Base(aws.StringValue(object.Key))] = struct{}{}
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(keys))
+
+	for name := range keys {
+		if strings.HasSuffix(name, manifestSuffix) {
+			continue
+		}
+
+		if _, ok := keys[ManifestPath(name)]; ok {
+			names = append(names, name)
+		}
+	}
+
+	return names, nil
+}
+
+// Delete deletes the uploaded backup and its manifest
+func (s *s3Store) Delete(ctx context.Context, name string) error {
+	for _, file := range []string{ManifestPath(name), name} {
+		if _, err := s.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+			Bucket: aws.String(s.bucket),
+			Key:    aws.String(s.key(file)),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// key returns the object key of the file
+func (s *s3Store) key(name string) string {
+	if s.prefix == "" {
+		return name
+	}
+
+	return strings.TrimSuffix(s.prefix, "/") + "/" + name
+}
@@ -0,0 +1,183 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errInvalidSchedule = errors.New("invalid backup schedule")
+
+// scheduleMacros are the shorthands of the common schedules
+var scheduleMacros = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// scheduleField is the range of the values of the schedule field
+type scheduleField struct {
+	name     string
+	min, max int
+}
+
+var scheduleFields = []scheduleField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 6},
+}
+
+// Schedule is the cron-like schedule of the backups, holding the minutes, hours, days of month, months
+// and days of week of the backups. The expression has the standard five fields, each of them being *,
+// a value, a range (1-5) or a list of them (1,3,5), optionally with a step (*/15 or 0-30/10).
+// As in cron, a backup runs on either of the days if both the day of month and the day of week are restricted
+type Schedule struct {
+	expr string
+
+	minutes, hours, days, months, weekdays uint64
+
+	// anyDay and anyWeekday tell whether the day of month and the day of week are unrestricted
+	anyDay, anyWeekday bool
+}
+
+// ParseSchedule parses the five field cron expression, or one of the @hourly, @daily, @weekly and @monthly macros
+func ParseSchedule(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+
+	fields := strings.Fields(expr)
+	if macro, ok := scheduleMacros[expr]; ok {
+		fields = strings.Fields(macro)
+	}
+
+	if len(fields) != len(scheduleFields) {
+		return nil, fmt.Errorf("%w %q: expected %d fields", errInvalidSchedule, expr, len(scheduleFields))
+	}
+
+	values := make([]uint64, len(fields))
+
+	for i, field := range fields {
+		bits, err := parseScheduleField(field, scheduleFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %s: %w", errInvalidSchedule, expr, scheduleFields[i].name, err)
+		}
+
+		values[i] = bits
+	}
+
+	return &Schedule{
+		expr:       expr,
+		minutes:    values[0],
+		hours:      values[1],
+		days:       values[2],
+		months:     values[3],
+		weekdays:   values[4],
+		anyDay:     strings.HasPrefix(fields[2], "*"),
+		anyWeekday: strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// parseScheduleField returns the bit set of the values of the field
+func parseScheduleField(field string, bounds scheduleField) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		rng, step := part, 1
+
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+
+			rng = part[:i]
+
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", part[i+1:])
+			}
+		}
+
+		from, to := bounds.min, bounds.max
+
+		if rng != "*" {
+			var err error
+
+			values := strings.SplitN(rng, "-", 2)
+
+			if from, err = strconv.Atoi(values[0]); err != nil {
+				return 0, fmt.Errorf("invalid value %q", values[0])
+			}
+
+			to = from
+
+			if len(values) == 2 {
+				if to, err = strconv.Atoi(values[1]); err != nil {
+					return 0, fmt.Errorf("invalid value %q", values[1])
+				}
+			} else if step != 1 {
+				// a single value with a step runs until the end of the range
+				to = bounds.max
+			}
+		}
+
+		if from < bounds.min || to > bounds.max || from > to {
+			return 0, fmt.Errorf("value %q out of range %d-%d", rng, bounds.min, bounds.max)
+		}
+
+		for v := from; v <= to; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+// String returns the expression of the schedule
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// maxScheduleSearch bounds the search of the next time of the schedule, which never matches
+// if it holds a day missing from all of its months (e.g. the 30th of February)
+const maxScheduleSearch = 5 * 366 * 24 * time.Hour
+
+// Next returns the first time of the schedule after the given time, the zero time if there is none
+func (s *Schedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxScheduleSearch)
+
+	for t.Before(limit) {
+		switch {
+		case s.months&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case s.hours&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case s.minutes&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// matchesDay tells whether the day of the time matches the schedule
+func (s *Schedule) matchesDay(t time.Time) bool {
+	day := s.days&(1<<uint(t.Day())) != 0
+	weekday := s.weekdays&(1<<uint(t.Weekday())) != 0
+
+	switch {
+	case s.anyDay && s.anyWeekday:
+		return true
+	case s.anyDay:
+		return weekday
+	case s.anyWeekday:
+		return day
+	default:
+		return day || weekday
+	}
+}
@@ -0,0 +1,84 @@
+package archive
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	t.Parallel()
+
+	valid := []string{
+		"* * * * *",
+		"*/15 * * * *",
+		"0 3 * * *",
+		"0,30 8-18 * * 1-5",
+		"0 0-12/4 1 1,7 *",
+		"@hourly",
+		"@daily",
+		"@weekly",
+		"@monthly",
+	}
+
+	for _, expr := range valid {
+		schedule, err := ParseSchedule(expr)
+		require.NoError(t, err, expr)
+		require.Equal(t, expr, schedule.String())
+	}
+
+	invalid := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 7",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"@yearly",
+	}
+
+	for _, expr := range invalid {
+		_, err := ParseSchedule(expr)
+		require.ErrorIs(t, err, errInvalidSchedule, expr)
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	t.Parallel()
+
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2024, month, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	cases := []struct {
+		expr  string
+		after time.Time
+		next  time.Time
+	}{
+		{"*/15 * * * *", at(3, 10, 12, 7), at(3, 10, 12, 15)},
+		{"*/15 * * * *", at(3, 10, 12, 45), at(3, 10, 13, 0)},
+		{"0 3 * * *", at(3, 10, 3, 0), at(3, 11, 3, 0)},
+		{"@daily", at(12, 31, 23, 59), time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", at(2, 15, 0, 0), at(3, 1, 0, 0)},
+		// 2024-03-10 is a Sunday
+		{"@weekly", at(3, 11, 0, 0), at(3, 17, 0, 0)},
+		{"0 9 * * 1-5", at(3, 8, 10, 0), at(3, 11, 9, 0)},
+		// either the 20th or the Monday
+		{"0 0 20 * 1", at(3, 12, 0, 0), at(3, 18, 0, 0)},
+		{"0 0 20 * 1", at(3, 18, 0, 0), at(3, 20, 0, 0)},
+		{"0 0 29 2 *", at(3, 1, 0, 0), time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", at(3, 1, 0, 0), time.Time{}},
+	}
+
+	for _, c := range cases {
+		schedule, err := ParseSchedule(c.expr)
+		require.NoError(t, err)
+		require.Equal(t, c.next, schedule.Next(c.after), c.expr)
+	}
+}
@@ -0,0 +1,284 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+
+	"github.com/0xPolygon/polygon-edge/server/proto"
+)
+
+const (
+	// scheduledBackupPrefix is the prefix of the names of the scheduled backups
+	scheduledBackupPrefix = "backup-"
+
+	// scheduledBackupTimeFormat formats the creation time in the names of the scheduled backups,
+	// so that the names are sorted by the creation time
+	scheduledBackupTimeFormat = "20060102T150405Z"
+
+	// backupMetrics is the prefix of the scheduled backups metrics
+	backupMetrics = "backup"
+)
+
+var errNoScheduledBackup = errors.New("the schedule has no next backup")
+
+// BackupStore keeps the scheduled backups
+type BackupStore interface {
+	// Put stores the backup file along with its manifest
+	Put(ctx context.Context, path string) error
+	// List returns the names of the stored backups
+	List(ctx context.Context) ([]string, error)
+	// Delete deletes the stored backup along with its manifest
+	Delete(ctx context.Context, name string) error
+}
+
+// SchedulerConfig is the configuration of the scheduled backups
+type SchedulerConfig struct {
+	Schedule *Schedule
+
+	// Dir is the directory the backups are written to, and kept in unless they are uploaded to the remote store
+	Dir string
+
+	// Retention is the number of the most recent backups kept, 0 keeps all of them
+	Retention uint64
+
+	// Compress gzips the backups
+	Compress bool
+
+	// Store is the remote store the backups are uploaded to, nil if they are kept in the directory
+	Store BackupStore
+}
+
+// BackupScheduler creates the full backups of the chain of the node on the schedule,
+// and deletes the backups which are no longer retained
+type BackupScheduler struct {
+	logger hclog.Logger
+	config *SchedulerConfig
+	store  BackupStore
+
+	// createBackup creates the backup with the given params
+	createBackup func(ctx context.Context, params *BackupParams) (*Manifest, error)
+
+	// now returns the current time
+	now func() time.Time
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewBackupScheduler creates the scheduler of the backups created over the gRPC connection to the node
+func NewBackupScheduler(conn *grpc.ClientConn, logger hclog.Logger, config *SchedulerConfig) (*BackupScheduler, error) {
+	if err := os.MkdirAll(config.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create the backup directory: %w", err)
+	}
+
+	logger = logger.Named("backup")
+	clt := proto.NewSystemClient(conn)
+
+	store := config.Store
+	if store == nil {
+		store = &dirStore{dir: config.Dir}
+	}
+
+	return &BackupScheduler{
+		logger: logger,
+		config: config,
+		store:  store,
+		createBackup: func(ctx context.Context, params *BackupParams) (*Manifest, error) {
+			return createBackup(ctx, clt, logger, params)
+		},
+		now: time.Now,
+	}, nil
+}
+
+// Start starts creating the backups on the schedule
+func (s *BackupScheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s.cancel = cancel
+
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+
+		s.run(ctx)
+	}()
+
+	s.logger.Info("backups scheduled", "schedule", s.config.Schedule, "dir", s.config.Dir,
+		"retention", s.config.Retention)
+}
+
+// Close stops the scheduler, cancelling the backup in progress
+func (s *BackupScheduler) Close() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+
+	s.wg.Wait()
+}
+
+func (s *BackupScheduler) run(ctx context.Context) {
+	for {
+		next := s.config.Schedule.Next(s.now())
+		if next.IsZero() {
+			s.logger.Error("backups stopped", "schedule", s.config.Schedule, "err", errNoScheduledBackup)
+
+			return
+		}
+
+		timer := time.NewTimer(next.Sub(s.now()))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return
+		case <-timer.C:
+		}
+
+		if err := s.backup(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			metrics.IncrCounter([]string{backupMetrics, "failed"}, 1)
+			s.logger.Error("scheduled backup failed", "err", err)
+		}
+	}
+}
+
+// backup creates the backup, stores it and deletes the backups which are no longer retained
+func (s *BackupScheduler) backup(ctx context.Context) error {
+	start := s.now()
+
+	out := filepath.Join(s.config.Dir, scheduledBackupName(start.UTC(), s.config.Compress))
+
+	manifest, err := s.createBackup(ctx, &BackupParams{OutPath: out, Compress: s.config.Compress})
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.Put(ctx, out); err != nil {
+		return fmt.Errorf("failed to store the backup: %w", err)
+	}
+
+	metrics.IncrCounter([]string{backupMetrics, "succeeded"}, 1)
+	metrics.SetGauge([]string{backupMetrics, "last_success"}, float32(s.now().Unix()))
+	metrics.SetGauge([]string{backupMetrics, "last_block"}, float32(manifest.To))
+	metrics.SetGauge([]string{backupMetrics, "last_size"}, float32(manifest.Size))
+
+	s.logger.Info("scheduled backup created", "file", manifest.File, "from", manifest.From, "to", manifest.To,
+		"size", manifest.Size, "elapsed", s.now().Sub(start))
+
+	// the failed retention doesn't fail the backup, the backups are deleted on the next one
+	if err := s.enforceRetention(ctx); err != nil {
+		s.logger.Error("failed to delete the backups no longer retained", "err", err)
+	}
+
+	return nil
+}
+
+// enforceRetention deletes the oldest scheduled backups, so that only the retained number of them is kept
+func (s *BackupScheduler) enforceRetention(ctx context.Context) error {
+	if s.config.Retention == 0 {
+		return nil
+	}
+
+	names, err := s.store.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	backups := make([]string, 0, len(names))
+
+	for _, name := range names {
+		if strings.HasPrefix(name, scheduledBackupPrefix) {
+			backups = append(backups, name)
+		}
+	}
+
+	if uint64(len(backups)) <= s.config.Retention {
+		return nil
+	}
+
+	sort.Strings(backups)
+
+	for _, name := range backups[:uint64(len(backups))-s.config.Retention] {
+		if err := s.store.Delete(ctx, name); err != nil {
+			return err
+		}
+
+		s.logger.Info("deleted the backup no longer retained", "file", name)
+	}
+
+	return nil
+}
+
+// scheduledBackupName returns the name of the backup created at the given time
+func scheduledBackupName(createdAt time.Time, compress bool) string {
+	name := scheduledBackupPrefix + createdAt.Format(scheduledBackupTimeFormat) + ".dat"
+	if compress {
+		name += ".gz"
+	}
+
+	return name
+}
+
+// dirStore keeps the backups in the directory they are written to
+type dirStore struct {
+	dir string
+}
+
+// Put keeps the backup written to the directory
+func (d *dirStore) Put(context.Context, string) error {
+	return nil
+}
+
+// List returns the names of the backups in the directory, which have the manifest
+func (d *dirStore) List(context.Context) ([]string, error) {
+	entries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasSuffix(name, manifestSuffix) {
+			continue
+		}
+
+		if _, err := os.Stat(ManifestPath(filepath.Join(d.dir, name))); err == nil {
+			names = append(names, name)
+		}
+	}
+
+	return names, nil
+}
+
+// Delete deletes the backup and its manifest from the directory
+func (d *dirStore) Delete(_ context.Context, name string) error {
+	path := filepath.Join(d.dir, name)
+
+	if err := os.Remove(ManifestPath(path)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupScheduler_Retention(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx    = context.Background()
+		logger = hclog.NewNullLogger()
+		dir    = t.TempDir()
+		source = newEraSourceClient(10)
+		now    = time.Date(2024, time.March, 10, 3, 0, 0, 0, time.UTC)
+	)
+
+	schedule, err := ParseSchedule("@daily")
+	require.NoError(t, err)
+
+	// a backup not created by the scheduler is never deleted
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "manual.dat"), []byte{1}, 0600))
+	require.NoError(t, writeManifest(filepath.Join(dir, "manual.dat"), &Manifest{File: "manual.dat"}))
+
+	scheduler := &BackupScheduler{
+		logger: logger,
+		config: &SchedulerConfig{
+			Schedule:  schedule,
+			Dir:       dir,
+			Retention: 2,
+			Compress:  true,
+		},
+		store: &dirStore{dir: dir},
+		createBackup: func(ctx context.Context, params *BackupParams) (*Manifest, error) {
+			return createBackup(ctx, source, logger, params)
+		},
+		now: func() time.Time {
+			return now
+		},
+	}
+
+	for i := 0; i < 4; i++ {
+		require.NoError(t, scheduler.backup(ctx))
+
+		now = now.Add(24 * time.Hour)
+	}
+
+	names, err := scheduler.store.List(ctx)
+	require.NoError(t, err)
+
+	sort.Strings(names)
+	require.Equal(t, []string{
+		"backup-20240312T030000Z.dat.gz",
+		"backup-20240313T030000Z.dat.gz",
+		"manual.dat",
+	}, names)
+
+	// the retained backups are complete
+	for _, name := range names[:2] {
+		manifest, err := ReadManifest(filepath.Join(dir, name))
+		require.NoError(t, err)
+		require.NoError(t, manifest.VerifyChecksum(filepath.Join(dir, name)))
+		require.Equal(t, uint64(10), manifest.To)
+	}
+
+	// the deleted backups leave no manifests behind
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 6)
+}
+
+func TestBackupScheduler_StoreFailure(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	store := &failingStore{}
+
+	scheduler := &BackupScheduler{
+		logger: hclog.NewNullLogger(),
+		config: &SchedulerConfig{Dir: dir, Retention: 1},
+		store:  store,
+		createBackup: func(ctx context.Context, params *BackupParams) (*Manifest, error) {
+			return createBackup(ctx, newEraSourceClient(3), hclog.NewNullLogger(), params)
+		},
+		now: time.Now,
+	}
+
+	require.ErrorIs(t, scheduler.backup(context.Background()), errStoreFailed)
+	require.False(t, store.listed)
+}
+
+var errStoreFailed = errors.New("store failed")
+
+type failingStore struct {
+	listed bool
+}
+
+func (f *failingStore) Put(context.Context, string) error {
+	return errStoreFailed
+}
+
+func (f *failingStore) List(context.Context) ([]string, error) {
+	f.listed = true
+
+	return nil, nil
+}
+
+func (f *failingStore) Delete(context.Context, string) error {
+	return nil
+}
@@ -0,0 +1,381 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+const (
+	s3Scheme  = "s3://"
+	gcsScheme = "gs://"
+
+	// gcsEndpoint is the S3-compatible XML API of the Google Cloud Storage, authenticated by the HMAC keys
+	gcsEndpoint = "https://storage.googleapis.com"
+
+	// objectEndpointEnv is the URL of the S3-compatible storage of the s3:// backups, the AWS S3 if not set
+	objectEndpointEnv = "AWS_ENDPOINT_URL"
+
+	// objectPartSize is the size of the parts of the multipart upload of the backup,
+	// which is at most s3manager.MaxUploadParts times larger
+	objectPartSize = 64 * 1024 * 1024
+
+	// objectUploadConcurrency is the number of the parts uploaded at once, each of them is buffered in memory
+	objectUploadConcurrency = 2
+)
+
+var (
+	errInvalidObjectURL = errors.New("invalid object URL, expected s3://<bucket>/<key> or gs://<bucket>/<key>")
+	errBackupAborted    = errors.New("the backup is aborted")
+)
+
+// backupStorage reads and writes the backups and their manifests,
+// either the local files or the objects of the S3-compatible and the GCS buckets
+type backupStorage interface {
+	// create creates the backup written by the returned writer, it fails if the backup exists already
+	create(ctx context.Context, path string) (backupWriter, error)
+	// open opens the backup for reading
+	open(ctx context.Context, path string) (io.ReadCloser, error)
+	// readFile reads the whole file, it returns the error satisfying os.IsNotExist if there is none
+	readFile(ctx context.Context, path string) ([]byte, error)
+	// writeFile writes the whole file, replacing the existing one
+	writeFile(ctx context.Context, path string, data []byte) error
+	// remove removes the file
+	remove(ctx context.Context, path string) error
+}
+
+// backupWriter writes the created backup
+type backupWriter interface {
+	io.Writer
+	// commit completes the backup, the backup is discarded if it fails
+	commit() error
+	// abort discards the partially written backup
+	abort() error
+}
+
+// IsObjectURL tells whether the backup path is the s3://<bucket>/<key> or gs://<bucket>/<key> object
+func IsObjectURL(path string) bool {
+	return strings.HasPrefix(path, s3Scheme) || strings.HasPrefix(path, gcsScheme)
+}
+
+// parseObjectURL returns the scheme, the bucket and the key of the object
+func parseObjectURL(path string) (string, string, string, error) {
+	scheme := s3Scheme
+	if strings.HasPrefix(path, gcsScheme) {
+		scheme = gcsScheme
+	}
+
+	bucket, key, ok := strings.Cut(strings.TrimPrefix(path, scheme), "/")
+	if !ok || bucket == "" || key == "" || strings.HasSuffix(key, "/") {
+		return "", "", "", fmt.Errorf("%w: %s", errInvalidObjectURL, path)
+	}
+
+	return scheme, bucket, key, nil
+}
+
+var (
+	objectStoragesLock sync.Mutex
+	// objectStorages are the storages of the s3:// and gs:// backups, created on the first use
+	objectStorages = make(map[string]*objectStorage)
+)
+
+// storageFor returns the storage of the backup path
+func storageFor(path string) (backupStorage, error) {
+	if !IsObjectURL(path) {
+		return fileStorage{}, nil
+	}
+
+	scheme, _, _, err := parseObjectURL(path)
+	if err != nil {
+		return nil, err
+	}
+
+	objectStoragesLock.Lock()
+	defer objectStoragesLock.Unlock()
+
+	if storage, ok := objectStorages[scheme]; ok {
+		return storage, nil
+	}
+
+	// the GCS buckets are accessed over its S3-compatible API, with the HMAC keys as the AWS credentials
+	region, endpoint := "", os.Getenv(objectEndpointEnv)
+	if scheme == gcsScheme {
+		region, endpoint = "auto", gcsEndpoint
+	}
+
+	sess, err := newS3Session(region, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	storage := newObjectStorage(sess)
+	objectStorages[scheme] = storage
+
+	return storage, nil
+}
+
+// fileStorage keeps the backups in the local files
+type fileStorage struct{}
+
+func (fileStorage) create(_ context.Context, path string) (backupWriter, error) {
+	// always create new file, throw error if the file exists
+	fs, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileWriter{File: fs}, nil
+}
+
+func (fileStorage) open(_ context.Context, path string) (io.ReadCloser, error) {
+	return os.Open(path)
+}
+
+func (fileStorage) readFile(_ context.Context, path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
+
+func (fileStorage) writeFile(_ context.Context, path string, data []byte) error {
+	return os.WriteFile(path, data, 0644)
+}
+
+func (fileStorage) remove(_ context.Context, path string) error {
+	return os.Remove(path)
+}
+
+// fileWriter writes the backup to the local file
+type fileWriter struct {
+	*os.File
+}
+
+func (w *fileWriter) commit() error {
+	if err := w.File.Close(); err != nil {
+		return errors.Join(err, os.Remove(w.Name()))
+	}
+
+	return nil
+}
+
+func (w *fileWriter) abort() error {
+	// the file may be closed already by the failed commit
+	if err := w.File.Close(); err != nil && !errors.Is(err, os.ErrClosed) {
+		return err
+	}
+
+	if err := os.Remove(w.Name()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// objectStorage streams the backups to and from the bucket objects, without the local copy.
+// The backups are uploaded in the parts checked against their MD5 digests by the storage
+type objectStorage struct {
+	client   *s3.S3
+	uploader *s3manager.Uploader
+}
+
+func newObjectStorage(sess *session.Session) *objectStorage {
+	return &objectStorage{
+		client: s3.New(sess),
+		uploader: s3manager.NewUploader(sess, func(u *s3manager.Uploader) {
+			u.PartSize = objectPartSize
+			u.Concurrency = objectUploadConcurrency
+		}),
+	}
+}
+
+func (o *objectStorage) create(ctx context.Context, path string) (backupWriter, error) {
+	_, bucket, key, err := parseObjectURL(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := o.client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}); err == nil {
+		return nil, &os.PathError{Op: "create", Path: path, Err: os.ErrExist}
+	} else if !isObjectNotFound(err) {
+		return nil, err
+	}
+
+	reader, writer := io.Pipe()
+
+	w := &objectWriter{
+		storage: o,
+		path:    path,
+		bucket:  bucket,
+		key:     key,
+		pipe:    writer,
+		done:    make(chan error, 1),
+	}
+
+	go func() {
+		_, err := o.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+			Body:   reader,
+		})
+
+		// unblocks the writer if the upload fails
+		reader.CloseWithError(err)
+
+		w.done <- err
+	}()
+
+	return w, nil
+}
+
+func (o *objectStorage) open(ctx context.Context, path string) (io.ReadCloser, error) {
+	_, bucket, key, err := parseObjectURL(path)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := o.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if isObjectNotFound(err) {
+			return nil, &os.PathError{Op: "open", Path: path, Err: os.ErrNotExist}
+		}
+
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+func (o *objectStorage) readFile(ctx context.Context, path string) ([]byte, error) {
+	body, err := o.open(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer body.Close()
+
+	return io.ReadAll(body)
+}
+
+func (o *objectStorage) writeFile(ctx context.Context, path string, data []byte) error {
+	_, bucket, key, err := parseObjectURL(path)
+	if err != nil {
+		return err
+	}
+
+	_, err = o.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(data),
+	})
+
+	return err
+}
+
+func (o *objectStorage) remove(ctx context.Context, path string) error {
+	_, bucket, key, err := parseObjectURL(path)
+	if err != nil {
+		return err
+	}
+
+	_, err = o.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+
+	return err
+}
+
+// objectWriter streams the backup to the upload of the bucket object
+type objectWriter struct {
+	storage *objectStorage
+	path    string
+	bucket  string
+	key     string
+
+	pipe    *io.PipeWriter
+	written int64
+
+	// done receives the result of the upload
+	done    chan error
+	doneErr error
+	once    sync.Once
+}
+
+func (w *objectWriter) Write(p []byte) (int, error) {
+	n, err := w.pipe.Write(p)
+	w.written += int64(n)
+
+	return n, err
+}
+
+// wait waits until the upload is completed or aborted
+func (w *objectWriter) wait() error {
+	w.once.Do(func() {
+		w.doneErr = <-w.done
+	})
+
+	return w.doneErr
+}
+
+func (w *objectWriter) commit() error {
+	if err := w.pipe.Close(); err != nil {
+		return err
+	}
+
+	if err := w.wait(); err != nil {
+		return err
+	}
+
+	// the parts are checked by the storage, the size of the assembled object is checked as well
+	head, err := w.storage.client.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(w.bucket),
+		Key:    aws.String(w.key),
+	})
+	if err != nil {
+		return errors.Join(err, w.abort())
+	}
+
+	if size := aws.Int64Value(head.ContentLength); size != w.written {
+		return errors.Join(
+			fmt.Errorf("%w: %d bytes uploaded, expected %d", errSizeMismatch, size, w.written),
+			w.abort(),
+		)
+	}
+
+	return nil
+}
+
+func (w *objectWriter) abort() error {
+	// the multipart upload is aborted by the uploader once its body fails
+	w.pipe.CloseWithError(errBackupAborted)
+
+	if w.wait() != nil {
+		// the upload failed, so there is no object
+		return nil
+	}
+
+	// the upload has been completed already
+	return w.storage.remove(context.Background(), w.path)
+}
+
+// isObjectNotFound tells whether the error is returned for the missing object
+func isObjectNotFound(err error) bool {
+	var awsErr awserr.Error
+
+	return errors.As(err, &awsErr) && (awsErr.Code() == s3.ErrCodeNoSuchKey || awsErr.Code() == "NotFound")
+}
@@ -0,0 +1,292 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" //nolint:gosec
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeObjectServer is the S3-compatible storage keeping the objects in memory,
+// it rejects the uploads whose body doesn't match the Content-MD5 header
+type fakeObjectServer struct {
+	lock    sync.Mutex
+	objects map[string][]byte
+	uploads map[string]map[int][]byte
+	parts   int
+}
+
+func newFakeObjectServer(t *testing.T) (*fakeObjectServer, *session.Session) {
+	t.Helper()
+
+	f := &fakeObjectServer{
+		objects: make(map[string][]byte),
+		uploads: make(map[string]map[int][]byte),
+	}
+
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+
+	sess, err := session.NewSession(aws.NewConfig().
+		WithRegion("us-east-1").
+		WithEndpoint(srv.URL).
+		WithS3ForcePathStyle(true).
+		WithCredentials(credentials.NewStaticCredentials("id", "secret", "")))
+	require.NoError(t, err)
+
+	return f, sess
+}
+
+func (f *fakeObjectServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	path, query := r.URL.Path, r.URL.Query()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	if digest := r.Header.Get("Content-Md5"); digest != "" {
+		sum := md5.Sum(body) //nolint:gosec
+		if digest != base64.StdEncoding.EncodeToString(sum[:]) {
+			writeObjectError(w, http.StatusBadRequest, "BadDigest")
+
+			return
+		}
+	}
+
+	switch {
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		f.uploads[path] = make(map[int][]byte)
+
+		fmt.Fprintf(w, "<InitiateMultipartUploadResult><UploadId>%s</UploadId></InitiateMultipartUploadResult>", path)
+	case r.Method == http.MethodPut && query.Has("uploadId"):
+		number, _ := strconv.Atoi(query.Get("partNumber"))
+		f.uploads[path][number] = body
+		f.parts++
+
+		w.Header().Set("ETag", strconv.Itoa(number))
+	case r.Method == http.MethodPost && query.Has("uploadId"):
+		numbers := make([]int, 0, len(f.uploads[path]))
+		for number := range f.uploads[path] {
+			numbers = append(numbers, number)
+		}
+
+		sort.Ints(numbers)
+
+		var object []byte
+		for _, number := range numbers {
+			object = append(object, f.uploads[path][number]...)
+		}
+
+		f.objects[path] = object
+		delete(f.uploads, path)
+
+		fmt.Fprint(w, "<CompleteMultipartUploadResult></CompleteMultipartUploadResult>")
+	case r.Method == http.MethodDelete && query.Has("uploadId"):
+		delete(f.uploads, path)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut:
+		f.objects[path] = body
+	case r.Method == http.MethodHead, r.Method == http.MethodGet:
+		object, ok := f.objects[path]
+		if !ok {
+			writeObjectError(w, http.StatusNotFound, "NoSuchKey")
+
+			return
+		}
+
+		w.Header().Set("Content-Length", strconv.Itoa(len(object)))
+
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(object)
+		}
+	case r.Method == http.MethodDelete:
+		delete(f.objects, path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func writeObjectError(w http.ResponseWriter, status int, code string) {
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", code, code)
+}
+
+func (f *fakeObjectServer) object(path string) ([]byte, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	object, ok := f.objects[path]
+
+	return object, ok
+}
+
+func TestParseObjectURL(t *testing.T) {
+	t.Parallel()
+
+	scheme, bucket, key, err := parseObjectURL("s3://bucket/backups/full.dat")
+	require.NoError(t, err)
+	require.Equal(t, s3Scheme, scheme)
+	require.Equal(t, "bucket", bucket)
+	require.Equal(t, "backups/full.dat", key)
+
+	scheme, bucket, key, err = parseObjectURL("gs://bucket/full.dat.gz")
+	require.NoError(t, err)
+	require.Equal(t, gcsScheme, scheme)
+	require.Equal(t, "bucket", bucket)
+	require.Equal(t, "full.dat.gz", key)
+
+	for _, path := range []string{"s3://bucket", "s3://bucket/", "s3:///full.dat", "gs://bucket/backups/"} {
+		_, _, _, err := parseObjectURL(path)
+		require.ErrorIs(t, err, errInvalidObjectURL, path)
+	}
+
+	require.False(t, IsObjectURL("/data/backups/full.dat"))
+	require.True(t, IsObjectURL("gs://bucket/full.dat"))
+}
+
+func TestObjectStorage_Backup(t *testing.T) {
+	fake, sess := newFakeObjectServer(t)
+
+	objectStoragesLock.Lock()
+	objectStorages[s3Scheme] = newObjectStorage(sess)
+	objectStoragesLock.Unlock()
+
+	t.Cleanup(func() {
+		objectStoragesLock.Lock()
+		delete(objectStorages, s3Scheme)
+		objectStoragesLock.Unlock()
+	})
+
+	var (
+		ctx    = context.Background()
+		logger = hclog.NewNullLogger()
+		source = newEraSourceClient(10)
+		to     = uint64(5)
+
+		fullURL        = "s3://bucket/backups/full.dat.gz"
+		incrementalURL = "s3://bucket/backups/incremental.dat"
+	)
+
+	full, err := createBackup(ctx, source, logger, &BackupParams{To: &to, OutPath: fullURL, Compress: true})
+	require.NoError(t, err)
+	require.Equal(t, "full.dat.gz", full.File)
+
+	object, ok := fake.object("/bucket/backups/full.dat.gz")
+	require.True(t, ok)
+	require.Equal(t, full.Size, int64(len(object)))
+
+	_, ok = fake.object("/bucket/backups/full.dat.gz" + manifestSuffix)
+	require.True(t, ok)
+
+	// the existing backup isn't overwritten
+	_, err = createBackup(ctx, source, logger, &BackupParams{OutPath: fullURL})
+	require.True(t, os.IsExist(err))
+
+	incremental, err := createBackup(ctx, source, logger, &BackupParams{OutPath: incrementalURL, BasePath: fullURL})
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), incremental.From)
+	require.Equal(t, uint64(10), incremental.To)
+
+	summaries, err := VerifyBackups([]string{fullURL, incrementalURL})
+	require.NoError(t, err)
+	require.Len(t, summaries, 2)
+	require.NotNil(t, summaries[1].Manifest)
+
+	_, err = ReadManifest("s3://bucket/backups/missing.dat")
+	require.True(t, os.IsNotExist(err))
+
+	// the corrupted object doesn't match the checksum of its manifest
+	object[len(object)/2] ^= 0xff
+	require.NoError(t, objectStorages[s3Scheme].writeFile(ctx, fullURL, object))
+
+	_, err = VerifyBackup(fullURL)
+	require.ErrorIs(t, err, errChecksumMismatch)
+}
+
+func TestObjectStorage_Multipart(t *testing.T) {
+	t.Parallel()
+
+	fake, sess := newFakeObjectServer(t)
+
+	storage := &objectStorage{
+		client: s3.New(sess),
+		uploader: s3manager.NewUploader(sess, func(u *s3manager.Uploader) {
+			u.PartSize = s3manager.MinUploadPartSize
+		}),
+	}
+
+	data := make([]byte, 2*s3manager.MinUploadPartSize+1024)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	writer, err := storage.create(ctx, "s3://bucket/full.dat")
+	require.NoError(t, err)
+
+	// the backup is streamed in the small chunks, as it is exported
+	for chunk := bytes.NewReader(data); chunk.Len() > 0; {
+		_, err := io.CopyN(writer, chunk, 64*1024)
+		if err != io.EOF {
+			require.NoError(t, err)
+		}
+	}
+
+	require.NoError(t, writer.commit())
+	require.Equal(t, 3, fake.parts)
+
+	object, ok := fake.object("/bucket/full.dat")
+	require.True(t, ok)
+	require.True(t, bytes.Equal(data, object))
+
+	body, err := storage.open(ctx, "s3://bucket/full.dat")
+	require.NoError(t, err)
+
+	read, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+	require.True(t, bytes.Equal(data, read))
+
+	// the aborted backup leaves neither the object nor the upload behind
+	writer, err = storage.create(ctx, "s3://bucket/aborted.dat")
+	require.NoError(t, err)
+
+	_, err = writer.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, writer.abort())
+
+	_, ok = fake.object("/bucket/aborted.dat")
+	require.False(t, ok)
+
+	fake.lock.Lock()
+	require.Empty(t, fake.uploads)
+	fake.lock.Unlock()
+
+	_, err = storage.open(ctx, "s3://bucket/aborted.dat")
+	require.True(t, os.IsNotExist(err))
+}
@@ -59,3 +59,69 @@ func (m *Metadata) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 
 	return nil
 }
+
+// EraHeader is the data stored in the beginning of the era file
+type EraHeader struct {
+	First    uint64
+	Last     uint64
+	LastHash types.Hash
+	// Receipts is true if every block in the file is followed by its receipts
+	Receipts bool
+}
+
+// MarshalRLP returns RLP encoded bytes
+func (h *EraHeader) MarshalRLP() []byte {
+	return h.MarshalRLPTo(nil)
+}
+
+// MarshalRLPTo sets RLP encoded bytes to given byte slice
+func (h *EraHeader) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(h.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith appends own field into arena for encode
+func (h *EraHeader) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewUint(h.First))
+	vv.Set(arena.NewUint(h.Last))
+	vv.Set(arena.NewBytes(h.LastHash.Bytes()))
+	vv.Set(arena.NewBool(h.Receipts))
+
+	return vv
+}
+
+// UnmarshalRLP unmarshals and sets the fields from RLP encoded bytes
+func (h *EraHeader) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(h.UnmarshalRLPFrom, input)
+}
+
+// UnmarshalRLPFrom sets the fields from parsed RLP encoded value
+func (h *EraHeader) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) < 4 {
+		return fmt.Errorf("incorrect number of elements to decode EraHeader, expected 4 but found %d", len(elems))
+	}
+
+	if h.First, err = elems[0].GetUint64(); err != nil {
+		return err
+	}
+
+	if h.Last, err = elems[1].GetUint64(); err != nil {
+		return err
+	}
+
+	if err = elems[2].GetHash(h.LastHash[:]); err != nil {
+		return err
+	}
+
+	if h.Receipts, err = elems[3].GetBool(); err != nil {
+		return err
+	}
+
+	return nil
+}
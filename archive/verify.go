@@ -0,0 +1,344 @@
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	errMetadataNotFound = errors.New("expected metadata in archive but doesn't exist")
+	errNoBlocks         = errors.New("the backup has no blocks")
+	errBrokenChain      = errors.New("the blocks of the backup don't form a chain")
+	errLatestMismatch   = errors.New("the last block of the backup doesn't match its metadata")
+	errManifestMismatch = errors.New("the blocks of the backup don't match its manifest")
+	errBaseMismatch     = errors.New("the backup doesn't continue the previous backup")
+)
+
+// gzipMagic is the header of the gzip compressed backup
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// BackupSummary is the result of the verification of the backup
+type BackupSummary struct {
+	Path       string
+	From       uint64
+	To         uint64
+	LatestHash types.Hash
+	Blocks     uint64
+	Compressed bool
+
+	// Manifest is the manifest of the backup, nil if the backup has none
+	Manifest *Manifest
+
+	// parentHash is the parent hash of the first block
+	parentHash types.Hash
+}
+
+// BackupRestoreResult is the result of the restore of the backups via gRPC
+type BackupRestoreResult struct {
+	Files    int
+	Imported uint64
+	Latest   uint64
+}
+
+// backupReader reads the backup file, which is either plain or gzip compressed
+type backupReader struct {
+	file       io.ReadCloser
+	stream     *blockStream
+	compressed bool
+}
+
+// openBackup opens the backup file, its stream starts with the metadata
+func openBackup(path string) (*backupReader, error) {
+	storage, err := storageFor(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fs, err := storage.open(context.Background(), path)
+	if err != nil {
+		return nil, err
+	}
+
+	input := bufio.NewReader(fs)
+
+	var reader io.Reader = input
+
+	magic, err := input.Peek(len(gzipMagic))
+	compressed := err == nil && bytes.Equal(magic, gzipMagic)
+
+	if compressed {
+		gz, err := gzip.NewReader(input)
+		if err != nil {
+			fs.Close()
+
+			return nil, err
+		}
+
+		reader = fullReader{gz}
+	}
+
+	return &backupReader{
+		file:       fs,
+		stream:     newBlockStream(reader),
+		compressed: compressed,
+	}, nil
+}
+
+// readMetadata reads the metadata in the beginning of the backup
+func (r *backupReader) readMetadata() (*Metadata, error) {
+	metadata, err := r.stream.getMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata == nil {
+		return nil, errMetadataNotFound
+	}
+
+	return metadata, nil
+}
+
+func (r *backupReader) Close() error {
+	return r.file.Close()
+}
+
+// verifyManifest checks the backup file against its manifest, it returns nil if the backup has no manifest
+func verifyManifest(path string) (*Manifest, error) {
+	manifest, err := ReadManifest(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := manifest.VerifyChecksum(path); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// VerifyBackup checks the integrity of the backup without writing it anywhere:
+// the checksum of the manifest if there is one, and the blocks are decoded and checked
+// to be sequential, linked by their parent hashes and to end at the block of the metadata
+func VerifyBackup(path string) (*BackupSummary, error) {
+	manifest, err := verifyManifest(path)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := openBackup(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.Close()
+
+	metadata, err := reader.readMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &BackupSummary{
+		Path:       path,
+		LatestHash: metadata.LatestHash,
+		Compressed: reader.compressed,
+		Manifest:   manifest,
+	}
+
+	var parent *types.Block
+
+	for {
+		block, err := reader.stream.nextBlock()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the block after %d: %w", summary.To, err)
+		}
+
+		if block == nil {
+			break
+		}
+
+		if parent == nil {
+			summary.From = block.Number()
+			summary.parentHash = block.ParentHash()
+		} else if block.Number() != parent.Number()+1 || block.ParentHash() != parent.Hash() {
+			return nil, fmt.Errorf("%w: block %d follows block %d", errBrokenChain, block.Number(), parent.Number())
+		}
+
+		summary.To = block.Number()
+		summary.Blocks++
+		parent = block
+	}
+
+	if parent == nil {
+		return nil, errNoBlocks
+	}
+
+	if parent.Number() != metadata.Latest || parent.Hash() != metadata.LatestHash {
+		return nil, fmt.Errorf("%w: block %d (%s), expected %d (%s)", errLatestMismatch,
+			parent.Number(), parent.Hash(), metadata.Latest, metadata.LatestHash)
+	}
+
+	if manifest != nil && (manifest.From != summary.From || manifest.To != summary.To ||
+		manifest.LatestHash != summary.LatestHash || manifest.Compressed != summary.Compressed) {
+		return nil, errManifestMismatch
+	}
+
+	return summary, nil
+}
+
+// VerifyBackups verifies the backups given in the restore order,
+// every backup has to continue the chain of the previous one
+func VerifyBackups(paths []string) ([]*BackupSummary, error) {
+	summaries := make([]*BackupSummary, 0, len(paths))
+
+	for i, path := range paths {
+		summary, err := VerifyBackup(path)
+		if err != nil {
+			return nil, fmt.Errorf("backup %s is invalid: %w", path, err)
+		}
+
+		if i > 0 {
+			if err := checkContinuation(summaries[i-1], summary); err != nil {
+				return nil, fmt.Errorf("backup %s is invalid: %w", path, err)
+			}
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, nil
+}
+
+// checkContinuation checks that the backup continues the chain of the previous backup,
+// the backups may overlap since the blocks the node has already are skipped
+func checkContinuation(prev, next *BackupSummary) error {
+	if next.From > prev.To+1 || next.To <= prev.To {
+		return fmt.Errorf("%w: blocks %d-%d follow blocks %d-%d", errBaseMismatch, next.From, next.To, prev.From, prev.To)
+	}
+
+	if next.From == prev.To+1 && next.parentHash != prev.LatestHash {
+		return fmt.Errorf("%w: the parent of block %d is %s, previous ends with %s", errBaseMismatch,
+			next.From, next.parentHash, prev.LatestHash)
+	}
+
+	if base := next.Manifest; base != nil && base.Base != nil &&
+		(base.Base.To != prev.To || base.Base.LatestHash != prev.LatestHash) {
+		return fmt.Errorf("%w: based on block %d (%s), previous ends at %d (%s)", errBaseMismatch,
+			base.Base.To, base.Base.LatestHash, prev.To, prev.LatestHash)
+	}
+
+	return nil
+}
+
+// RestoreBackup verifies the backups and sends their blocks to the running node via gRPC in the given order,
+// the node verifies and writes them skipping the blocks it has already
+func RestoreBackup(conn *grpc.ClientConn, logger hclog.Logger, paths []string) (*BackupRestoreResult, error) {
+	ctx, cancelFn := withTerminationSignal(logger)
+	defer cancelFn()
+
+	return restoreBackup(ctx, proto.NewSystemClient(conn), logger, paths)
+}
+
+func restoreBackup(
+	ctx context.Context,
+	clt proto.SystemClient,
+	logger hclog.Logger,
+	paths []string,
+) (*BackupRestoreResult, error) {
+	if _, err := VerifyBackups(paths); err != nil {
+		return nil, err
+	}
+
+	result := &BackupRestoreResult{Files: len(paths)}
+
+	for _, path := range paths {
+		imported, latest, err := restoreBackupFile(ctx, clt, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore backup %s: %w", path, err)
+		}
+
+		result.Imported += imported
+		result.Latest = latest
+
+		logger.Info("Restored backup", "file", path, "imported", imported, "latest", latest)
+	}
+
+	return result, nil
+}
+
+// restoreBackupFile sends the blocks of the backup to the node in the batches,
+// it returns the number of the written blocks and the latest block of the node
+func restoreBackupFile(ctx context.Context, clt proto.SystemClient, path string) (uint64, uint64, error) {
+	reader, err := openBackup(path)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	defer reader.Close()
+
+	if _, err := reader.readMetadata(); err != nil {
+		return 0, 0, err
+	}
+
+	var (
+		batch    bytes.Buffer
+		imported uint64
+		latest   uint64
+	)
+
+	flush := func() error {
+		if batch.Len() == 0 {
+			return nil
+		}
+
+		resp, err := clt.ImportBlocks(ctx, &proto.ImportBlocksRequest{Data: batch.Bytes()})
+		if err != nil {
+			return err
+		}
+
+		imported += resp.Imported
+		latest = resp.Latest
+
+		batch.Reset()
+
+		return nil
+	}
+
+	for {
+		block, err := reader.stream.nextRLP()
+		if err != nil {
+			return imported, latest, err
+		}
+
+		if block == nil {
+			break
+		}
+
+		if batch.Len()+len(block) > maxImportPayloadSize {
+			if err := flush(); err != nil {
+				return imported, latest, err
+			}
+		}
+
+		batch.Write(block)
+	}
+
+	if err := flush(); err != nil {
+		return imported, latest, err
+	}
+
+	return imported, latest, nil
+}
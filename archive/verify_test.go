@@ -0,0 +1,144 @@
+package archive
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func Test_createBackup_Incremental(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx    = context.Background()
+		logger = hclog.NewNullLogger()
+		dir    = t.TempDir()
+		source = newEraSourceClient(10)
+		to     = uint64(5)
+
+		fullPath        = filepath.Join(dir, "full.dat")
+		incrementalPath = filepath.Join(dir, "incremental.dat.gz")
+	)
+
+	full, err := createBackup(ctx, source, logger, &BackupParams{To: &to, OutPath: fullPath})
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), full.From)
+	require.Equal(t, uint64(5), full.To)
+	require.Equal(t, source.chain.blocks[5].Hash(), full.LatestHash)
+	require.False(t, full.Compressed)
+	require.Nil(t, full.Base)
+
+	incremental, err := createBackup(ctx, source, logger, &BackupParams{
+		OutPath:  incrementalPath,
+		Compress: true,
+		BasePath: fullPath,
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), incremental.From)
+	require.Equal(t, uint64(10), incremental.To)
+	require.True(t, incremental.Compressed)
+	require.Equal(t, &ManifestBase{File: "full.dat", To: 5, LatestHash: full.LatestHash}, incremental.Base)
+
+	manifest, err := ReadManifest(incrementalPath)
+	require.NoError(t, err)
+	require.Equal(t, incremental.SHA256, manifest.SHA256)
+
+	summaries, err := VerifyBackups([]string{fullPath, incrementalPath})
+	require.NoError(t, err)
+	require.Len(t, summaries, 2)
+	require.Equal(t, uint64(6), summaries[0].Blocks)
+	require.Equal(t, uint64(5), summaries[1].Blocks)
+	require.True(t, summaries[1].Compressed)
+
+	// the backups are restored in order
+	_, err = VerifyBackups([]string{incrementalPath, fullPath})
+	require.ErrorIs(t, err, errBaseMismatch)
+
+	// there are no new blocks after the incremental backup
+	_, err = createBackup(ctx, source, logger, &BackupParams{
+		OutPath:  filepath.Join(dir, "empty.dat"),
+		BasePath: incrementalPath,
+	})
+	require.ErrorIs(t, err, errNothingToBackup)
+	require.NoFileExists(t, filepath.Join(dir, "empty.dat"))
+
+	// the base backup of the other chain is rejected
+	other := newEraSourceClient(10)
+	other.chain.blocks[5] = &types.Block{Header: (&types.Header{Number: 5, ExtraData: []byte{1}}).ComputeHash()}
+
+	_, err = createBackup(ctx, other, logger, &BackupParams{
+		OutPath:  filepath.Join(dir, "other.dat"),
+		BasePath: fullPath,
+	})
+	require.ErrorIs(t, err, errBaseNotInChain)
+}
+
+func TestVerifyBackup_Corrupted(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx    = context.Background()
+		logger = hclog.NewNullLogger()
+		path   = filepath.Join(t.TempDir(), "backup.dat")
+	)
+
+	_, err := createBackup(ctx, newEraSourceClient(4), logger, &BackupParams{OutPath: path})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	data[len(data)-1] ^= 0xff
+	require.NoError(t, os.WriteFile(path, data, 0600))
+
+	_, err = VerifyBackup(path)
+	require.ErrorIs(t, err, errChecksumMismatch)
+
+	// without the manifest the decoding of the blocks fails
+	require.NoError(t, os.Remove(ManifestPath(path)))
+
+	_, err = VerifyBackup(path)
+	require.Error(t, err)
+}
+
+func Test_restoreBackup(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx    = context.Background()
+		logger = hclog.NewNullLogger()
+		dir    = t.TempDir()
+		source = newEraSourceClient(10)
+		to     = uint64(3)
+
+		fullPath        = filepath.Join(dir, "full.dat.gz")
+		incrementalPath = filepath.Join(dir, "incremental.dat")
+	)
+
+	_, err := createBackup(ctx, source, logger, &BackupParams{To: &to, OutPath: fullPath, Compress: true})
+	require.NoError(t, err)
+
+	_, err = createBackup(ctx, source, logger, &BackupParams{OutPath: incrementalPath, BasePath: fullPath})
+	require.NoError(t, err)
+
+	target := &eraClientMock{
+		chain: &mockChain{
+			genesis: source.chain.genesis,
+			blocks:  append([]*types.Block(nil), source.chain.blocks[:2]...),
+		},
+	}
+
+	result, err := restoreBackup(ctx, target, logger, []string{fullPath, incrementalPath})
+	require.NoError(t, err)
+	require.Equal(t, &BackupRestoreResult{Files: 2, Imported: 9, Latest: 10}, result)
+
+	for num, block := range target.chain.blocks {
+		require.Equal(t, source.chain.blocks[num].Hash(), block.Hash())
+	}
+}
@@ -10,12 +10,15 @@ import (
 	"github.com/0xPolygon/polygon-edge/blockchain/storage"
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/tracing"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/types/buildroot"
 
+	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
 	lru "github.com/hashicorp/golang-lru"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const (
@@ -38,6 +41,9 @@ var (
 	ErrInvalidStateRoot     = errors.New("invalid block state root")
 	ErrInvalidGasUsed       = errors.New("invalid block gas used")
 	ErrInvalidReceiptsRoot  = errors.New("invalid block receipts root")
+	ErrNoFeeProposer        = errors.New("block proposer receiving the fee share is unknown")
+	ErrFinalizedReorg       = errors.New("block conflicts with the finalized chain")
+	ErrReorgTooDeep         = errors.New("reorg exceeds the max reorg depth")
 )
 
 // Blockchain is a blockchain reference
@@ -66,6 +72,9 @@ type Blockchain struct {
 	// any new fields from being added
 	receiptsCache *lru.Cache // LRU cache for the block receipts
 
+	// stateDiffCache holds the state diffs of the recently executed blocks, nil if they are not collected
+	stateDiffCache *lru.Cache
+
 	currentHeader     atomic.Pointer[types.Header] // The current header
 	currentDifficulty atomic.Pointer[big.Int]      // The current difficulty of the chain (total difficulty)
 
@@ -73,9 +82,30 @@ type Blockchain struct {
 
 	gpAverage *gasPriceAverage // A reference to the average gas price
 
+	// paramsOverrides are the chain parameters changed on-chain, nil if they can't be changed
+	paramsOverrides ParamsOverrides
+
+	// reorgLimits bound the reorganizations of the canonical chain
+	reorgLimits ReorgLimits
+
 	writeLock sync.Mutex
 }
 
+// ReorgLimits bound the reorganizations of the canonical chain
+type ReorgLimits struct {
+	// Finalizing rejects the competing chains, since the consensus finalizes the blocks once they are written
+	Finalizing bool
+	// MaxDepth is the maximum number of the canonical blocks replaced by a reorganization, 0 if unlimited
+	MaxDepth uint64
+}
+
+// ParamsOverrides are the chain parameters which can be changed on-chain, e.g. by the governance.
+// The values are read from the state of the given header, false is returned if the parameter isn't changed
+type ParamsOverrides interface {
+	BlockGasTarget(header *types.Header) (uint64, bool)
+	BaseFeeChangeDenom(header *types.Header) (uint64, bool)
+}
+
 // gasPriceAverage keeps track of the average gas price (rolling average)
 type gasPriceAverage struct {
 	sync.RWMutex
@@ -193,6 +223,12 @@ func NewBlockchain(
 	executor Executor,
 	txSigner TxSigner,
 ) (*Blockchain, error) {
+	if config.Params != nil && config.Params.FeeDistribution != nil {
+		if err := config.Params.FeeDistribution.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid fee distribution: %w", err)
+		}
+	}
+
 	b := &Blockchain{
 		logger:    logger.Named("blockchain"),
 		config:    config,
@@ -342,6 +378,29 @@ func (b *Blockchain) Genesis() types.Hash {
 	return b.genesis
 }
 
+// EnableStateDiffCache makes the blockchain collect the state diffs of the executed blocks,
+// and keep the given number of the most recent ones
+func (b *Blockchain) EnableStateDiffCache(size int) error {
+	cache, err := lru.New(size)
+	if err != nil {
+		return fmt.Errorf("unable to create state diff cache, %w", err)
+	}
+
+	b.stateDiffCache = cache
+
+	return nil
+}
+
+// SetParamsOverrides sets the source of the chain parameters changed on-chain
+func (b *Blockchain) SetParamsOverrides(overrides ParamsOverrides) {
+	b.paramsOverrides = overrides
+}
+
+// SetReorgLimits sets the limits of the reorganizations of the canonical chain
+func (b *Blockchain) SetReorgLimits(limits ReorgLimits) {
+	b.reorgLimits = limits
+}
+
 // CalculateGasLimit returns the gas limit of the next block after parent
 func (b *Blockchain) CalculateGasLimit(number uint64) (uint64, error) {
 	parent, ok := b.GetHeaderByNumber(number - 1)
@@ -349,14 +408,21 @@ func (b *Blockchain) CalculateGasLimit(number uint64) (uint64, error) {
 		return 0, fmt.Errorf("parent of block %d not found", number)
 	}
 
-	return b.calculateGasLimit(parent.GasLimit), nil
+	blockGasTarget := b.Config().BlockGasTarget
+
+	if b.paramsOverrides != nil {
+		if target, ok := b.paramsOverrides.BlockGasTarget(parent); ok {
+			blockGasTarget = target
+		}
+	}
+
+	return b.calculateGasLimit(parent.GasLimit, blockGasTarget), nil
 }
 
 // calculateGasLimit calculates gas limit in reference to the block gas target
-func (b *Blockchain) calculateGasLimit(parentGasLimit uint64) uint64 {
+func (b *Blockchain) calculateGasLimit(parentGasLimit, blockGasTarget uint64) uint64 {
 	// The gas limit cannot move more than 1/1024 * parentGasLimit
 	// in either direction per block
-	blockGasTarget := b.Config().BlockGasTarget
 
 	// Check if the gas limit target has been set
 	if blockGasTarget == 0 {
@@ -617,6 +683,49 @@ func (b *Blockchain) VerifyFinalizedBlock(block *types.Block) (*types.FullBlock,
 	return &types.FullBlock{Block: block, Receipts: receipts}, nil
 }
 
+// VerifyFinalizedBlockWithReceipts verifies the sealed block along with the given receipts without executing
+// its transactions, so the block is verified against its parent header only.
+// It is used by the fast sync for the blocks whose parent states are not available
+func (b *Blockchain) VerifyFinalizedBlockWithReceipts(
+	block *types.Block,
+	receipts []*types.Receipt,
+) (*types.FullBlock, error) {
+	if block == nil {
+		return nil, ErrNoBlock
+	}
+
+	if err := b.consensus.VerifyHeader(block.Header); err != nil {
+		return nil, fmt.Errorf("failed to verify the header: %w", err)
+	}
+
+	if err := b.verifyBlockParent(block); err != nil {
+		return nil, err
+	}
+
+	if err := b.verifyBlockRoots(block); err != nil {
+		return nil, err
+	}
+
+	if len(receipts) != len(block.Transactions) {
+		return nil, ErrInvalidReceiptsSize
+	}
+
+	var gasUsed uint64
+	if len(receipts) > 0 {
+		gasUsed = receipts[len(receipts)-1].CumulativeGasUsed
+	}
+
+	if gasUsed != block.Header.GasUsed {
+		return nil, ErrInvalidGasUsed
+	}
+
+	if buildroot.CalculateReceiptsRoot(receipts) != block.Header.ReceiptsRoot {
+		return nil, ErrInvalidReceiptsRoot
+	}
+
+	return &types.FullBlock{Block: block, Receipts: receipts}, nil
+}
+
 // verifyBlock does the base (common) block verification steps by
 // verifying the block body as well as the parent information
 func (b *Blockchain) verifyBlock(block *types.Block) ([]*types.Receipt, error) {
@@ -630,10 +739,39 @@ func (b *Blockchain) verifyBlock(block *types.Block) ([]*types.Receipt, error) {
 		return nil, err
 	}
 
+	// Make sure the block proposer receiving its share of the fees is known
+	if err := b.verifyFeeDistribution(block.Header); err != nil {
+		return nil, err
+	}
+
 	// Make sure the block body data is valid
 	return b.verifyBlockBody(block)
 }
 
+// verifyFeeDistribution makes sure that the proposer receiving its share of the fees
+// can be determined from the header, when the fees are shared
+func (b *Blockchain) verifyFeeDistribution(header *types.Header) error {
+	if b.config.Params == nil {
+		return nil
+	}
+
+	distribution := b.config.Params.FeeDistributionAt(header.Number)
+	if distribution == nil || distribution.ProposerShare == 0 {
+		return nil
+	}
+
+	proposer, err := b.consensus.GetBlockCreator(header)
+	if err != nil {
+		return fmt.Errorf("unable to get the block proposer, %w", err)
+	}
+
+	if proposer == types.ZeroAddress {
+		return ErrNoFeeProposer
+	}
+
+	return nil
+}
+
 // verifyBlockParent makes sure that the child block is in line
 // with the locally saved parent block. This means checking:
 // - The parent exists
@@ -690,6 +828,26 @@ func (b *Blockchain) verifyBlockParent(childBlock *types.Block) error {
 // - The receipts match up
 // - The execution result matches up
 func (b *Blockchain) verifyBlockBody(block *types.Block) ([]*types.Receipt, error) {
+	if err := b.verifyBlockRoots(block); err != nil {
+		return nil, err
+	}
+
+	// Execute the transactions in the block and grab the result
+	blockResult, executeErr := b.executeBlockTransactions(block)
+	if executeErr != nil {
+		return nil, fmt.Errorf("unable to execute block transactions, %w", executeErr)
+	}
+
+	// Verify the local execution result with the proposed block data
+	if err := blockResult.verifyBlockResult(block); err != nil {
+		return nil, fmt.Errorf("unable to verify block execution result, %w", err)
+	}
+
+	return blockResult.Receipts, nil
+}
+
+// verifyBlockRoots verifies that the uncles and the transactions of the block match up its header
+func (b *Blockchain) verifyBlockRoots(block *types.Block) error {
 	// Make sure the Uncles root matches up
 	if hash := buildroot.CalculateUncleRoot(block.Uncles); hash != block.Header.Sha3Uncles {
 		b.logger.Error(fmt.Sprintf(
@@ -698,7 +856,7 @@ func (b *Blockchain) verifyBlockBody(block *types.Block) ([]*types.Receipt, erro
 			block.Header.Sha3Uncles,
 		))
 
-		return nil, ErrInvalidSha3Uncles
+		return ErrInvalidSha3Uncles
 	}
 
 	// Make sure the transactions root matches up
@@ -709,21 +867,10 @@ func (b *Blockchain) verifyBlockBody(block *types.Block) ([]*types.Receipt, erro
 			block.Header.TxRoot,
 		))
 
-		return nil, ErrInvalidTxRoot
-	}
-
-	// Execute the transactions in the block and grab the result
-	blockResult, executeErr := b.executeBlockTransactions(block)
-	if executeErr != nil {
-		return nil, fmt.Errorf("unable to execute block transactions, %w", executeErr)
+		return ErrInvalidTxRoot
 	}
 
-	// Verify the local execution result with the proposed block data
-	if err := blockResult.verifyBlockResult(block); err != nil {
-		return nil, fmt.Errorf("unable to verify block execution result, %w", err)
-	}
-
-	return blockResult.Receipts, nil
+	return nil
 }
 
 // verifyBlockResult verifies that the block transaction execution result
@@ -755,7 +902,13 @@ func (br *BlockResult) verifyBlockResult(referenceBlock *types.Block) error {
 
 // executeBlockTransactions executes the transactions in the block locally,
 // and reports back the block execution result
-func (b *Blockchain) executeBlockTransactions(block *types.Block) (*BlockResult, error) {
+func (b *Blockchain) executeBlockTransactions(block *types.Block) (_ *BlockResult, err error) {
+	_, span := tracing.Start(tracing.BlockContext(block.Number()), "blockchain.execute",
+		attribute.Int("block.transactions", len(block.Transactions)))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
 	header := block.Header
 
 	parent, ok := b.readHeader(header.ParentHash)
@@ -777,6 +930,10 @@ func (b *Blockchain) executeBlockTransactions(block *types.Block) (*BlockResult,
 		return nil, err
 	}
 
+	if b.stateDiffCache != nil {
+		txn.CollectStateDiff()
+	}
+
 	_, root, err := txn.Commit()
 	if err != nil {
 		return nil, fmt.Errorf("failed to commit the state changes: %w", err)
@@ -785,6 +942,10 @@ func (b *Blockchain) executeBlockTransactions(block *types.Block) (*BlockResult,
 	// Append the receipts to the receipts cache
 	b.receiptsCache.Add(header.Hash, txn.Receipts())
 
+	if diff := txn.StateDiff(); diff != nil {
+		b.stateDiffCache.Add(header.Hash, diff)
+	}
+
 	return &BlockResult{
 		Root:     root,
 		Receipts: txn.Receipts(),
@@ -796,12 +957,20 @@ func (b *Blockchain) executeBlockTransactions(block *types.Block) (*BlockResult,
 // It doesn't do any kind of verification, only commits the block to the DB
 // This function is a copy of WriteBlock but with a full block which does not
 // require to compute again the Receipts.
-func (b *Blockchain) WriteFullBlock(fblock *types.FullBlock, source string) error {
+func (b *Blockchain) WriteFullBlock(fblock *types.FullBlock, source string) (err error) {
+	block := fblock.Block
+
+	ctx, span := tracing.Start(tracing.BlockContext(block.Number()), "blockchain.write_block",
+		attribute.Int64("block.number", int64(block.Number())),
+		attribute.Int("block.transactions", len(block.Transactions)),
+		attribute.String("block.source", source))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
 	b.writeLock.Lock()
 	defer b.writeLock.Unlock()
 
-	block := fblock.Block
-
 	if block.Number() <= b.Header().Number {
 		b.logger.Info("block already inserted", "block", block.Number(), "source", source)
 
@@ -837,7 +1006,12 @@ func (b *Blockchain) WriteFullBlock(fblock *types.FullBlock, source string) erro
 	// Update the average gas price
 	b.updateGasPriceAvgWithBlock(block)
 
-	if err := b.writeBatchAndUpdate(batchWriter, header, newTD, isCanonical); err != nil {
+	_, commitSpan := tracing.Start(ctx, "storage.commit")
+
+	err = b.writeBatchAndUpdate(batchWriter, header, newTD, isCanonical)
+	tracing.EndSpan(commitSpan, err)
+
+	if err != nil {
 		return err
 	}
 
@@ -863,7 +1037,15 @@ func (b *Blockchain) WriteFullBlock(fblock *types.FullBlock, source string) erro
 
 // WriteBlock writes a single block to the local blockchain.
 // It doesn't do any kind of verification, only commits the block to the DB
-func (b *Blockchain) WriteBlock(block *types.Block, source string) error {
+func (b *Blockchain) WriteBlock(block *types.Block, source string) (err error) {
+	ctx, span := tracing.Start(tracing.BlockContext(block.Number()), "blockchain.write_block",
+		attribute.Int64("block.number", int64(block.Number())),
+		attribute.Int("block.transactions", len(block.Transactions)),
+		attribute.String("block.source", source))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
 	b.writeLock.Lock()
 	defer b.writeLock.Unlock()
 
@@ -908,7 +1090,12 @@ func (b *Blockchain) WriteBlock(block *types.Block, source string) error {
 	// Update the average gas price
 	b.updateGasPriceAvgWithBlock(block)
 
-	if err := b.writeBatchAndUpdate(batchWriter, header, newTD, isCanonical); err != nil {
+	_, commitSpan := tracing.Start(ctx, "storage.commit")
+
+	err = b.writeBatchAndUpdate(batchWriter, header, newTD, isCanonical)
+	tracing.EndSpan(commitSpan, err)
+
+	if err != nil {
 		return err
 	}
 
@@ -947,6 +1134,57 @@ func (b *Blockchain) GetCachedReceipts(headerHash types.Hash) ([]*types.Receipt,
 	return extractedReceipts, nil
 }
 
+// GetStateDiff returns the change of the state made by the block. The diff is taken from the cache,
+// or computed by executing the block again on top of the state of its parent
+func (b *Blockchain) GetStateDiff(hash types.Hash) (*state.StateDiff, error) {
+	if b.stateDiffCache != nil {
+		if diff, ok := b.stateDiffCache.Get(hash); ok {
+			if stateDiff, ok := diff.(*state.StateDiff); ok {
+				return stateDiff, nil
+			}
+		}
+	}
+
+	block, ok := b.GetBlockByHash(hash, true)
+	if !ok {
+		return nil, fmt.Errorf("block %s not found", hash)
+	}
+
+	if block.Number() == 0 {
+		return nil, errors.New("genesis block has no state diff")
+	}
+
+	parent, ok := b.readHeader(block.ParentHash())
+	if !ok {
+		return nil, ErrParentNotFound
+	}
+
+	blockCreator, err := b.consensus.GetBlockCreator(block.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	txn, err := b.executor.ProcessBlock(parent.StateRoot, block, blockCreator)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.consensus.PreCommitState(block, txn); err != nil {
+		return nil, err
+	}
+
+	diff, err := txn.ComputeStateDiff()
+	if err != nil {
+		return nil, err
+	}
+
+	if b.stateDiffCache != nil {
+		b.stateDiffCache.Add(hash, diff)
+	}
+
+	return diff, nil
+}
+
 // extractBlockReceipts extracts the receipts from the passed in block
 func (b *Blockchain) extractBlockReceipts(block *types.Block) ([]*types.Receipt, error) {
 	// Check the cache for the block receipts
@@ -1015,6 +1253,12 @@ func (b *Blockchain) ReadTxLookup(hash types.Hash) (types.Hash, bool) {
 	return v, ok
 }
 
+// RecoverSenders recovers the senders of the transactions of the given block,
+// so they are not recovered again while the block is executed and written
+func (b *Blockchain) RecoverSenders(block *types.Block) error {
+	return b.recoverFromFieldsInBlock(block)
+}
+
 // recoverFromFieldsInBlock recovers 'from' fields in the transactions of the given block
 // return error if the invalid signature found
 func (b *Blockchain) recoverFromFieldsInBlock(block *types.Block) error {
@@ -1132,6 +1376,8 @@ func (b *Blockchain) GetHashByNumber(blockNumber uint64) types.Hash {
 
 // dispatchEvent pushes a new event to the stream
 func (b *Blockchain) dispatchEvent(evnt *Event) {
+	evnt.Finalized = b.Header().Number
+
 	b.stream.push(evnt)
 }
 
@@ -1164,6 +1410,11 @@ func (b *Blockchain) writeHeaderImpl(
 		return true, incomingTD, nil
 	}
 
+	// every written block is final, so the chains competing with the canonical one are never written
+	if b.reorgLimits.Finalizing {
+		return false, nil, b.rejectReorg(header, currentHeader, ErrFinalizedReorg)
+	}
+
 	currentTD, ok := b.readTotalDifficulty(currentHeader.Hash)
 	if !ok {
 		return false, nil, errors.New("failed to get header difficulty")
@@ -1226,11 +1477,11 @@ func (b *Blockchain) handleReorg(
 	newHeader *types.Header,
 	newTD *big.Int,
 ) error {
-	newChainHead := newHeader
 	oldChainHead := oldHeader
 
-	oldChain := []*types.Header{}
-	newChain := []*types.Header{}
+	// both chains are walked back until their common ancestor, which is the last header of both lists
+	oldChain := []*types.Header{oldHeader}
+	newChain := []*types.Header{newHeader}
 
 	var ok bool
 
@@ -1266,6 +1517,16 @@ func (b *Blockchain) handleReorg(
 		}
 
 		oldChain = append(oldChain, oldHeader)
+		newChain = append(newChain, newHeader)
+	}
+
+	// the old chain holds the replaced canonical headers and their common ancestor
+	if depth := uint64(len(oldChain) - 1); b.reorgLimits.MaxDepth != 0 && depth > b.reorgLimits.MaxDepth {
+		return b.rejectReorg(
+			newChain[0],
+			oldChainHead,
+			fmt.Errorf("%w: %d > %d", ErrReorgTooDeep, depth, b.reorgLimits.MaxDepth),
+		)
 	}
 
 	forks, err := b.getForksToWrite(oldChainHead)
@@ -1275,20 +1536,15 @@ func (b *Blockchain) handleReorg(
 
 	batchWriter.PutForks(forks)
 
-	// Update canonical chain numbers
-	for _, h := range newChain {
-		batchWriter.PutCanonicalHash(h.Number, h.Hash)
+	// the headers are passed to the event in the ascending order, skipping the common ancestor
+	for i := len(oldChain) - 2; i >= 0; i-- {
+		evnt.AddOldHeader(oldChain[i])
 	}
 
-	for _, b := range oldChain[:len(oldChain)-1] {
-		evnt.AddOldHeader(b)
-	}
-
-	evnt.AddOldHeader(oldChainHead)
-	evnt.AddNewHeader(newChainHead)
-
-	for _, b := range newChain {
-		evnt.AddNewHeader(b)
+	for i := len(newChain) - 2; i >= 0; i-- {
+		// Update canonical chain numbers
+		batchWriter.PutCanonicalHash(newChain[i].Number, newChain[i].Hash)
+		evnt.AddNewHeader(newChain[i])
 	}
 
 	// Set the event type and difficulty
@@ -1298,6 +1554,21 @@ func (b *Blockchain) handleReorg(
 	return nil
 }
 
+// rejectReorg logs and reports the rejected reorganization of the canonical chain by the given header
+func (b *Blockchain) rejectReorg(header, head *types.Header, err error) error {
+	metrics.IncrCounter([]string{"blockchain", "rejected_reorgs"}, 1)
+
+	b.logger.Error(
+		"rejected chain reorganization",
+		"number", header.Number,
+		"hash", header.Hash,
+		"head", head.Number,
+		"err", err,
+	)
+
+	return err
+}
+
 // GetForks returns the forks
 func (b *Blockchain) GetForks() ([]types.Hash, error) {
 	return b.db.ReadForks()
@@ -1358,42 +1629,53 @@ func (b *Blockchain) CalculateBaseFee(parent *types.Header) uint64 {
 		return 0
 	}
 
+	fee := b.config.FeeParamsAt(parent.Number + 1)
+
 	// Check if this is the first London hardfork block.
 	// Should return chain.GenesisBaseFee ins this case.
 	if parent.BaseFee == 0 {
 		if b.config.Genesis.BaseFee > 0 {
-			return b.config.Genesis.BaseFee
+			return common.Max(b.config.Genesis.BaseFee, fee.MinBaseFee)
 		}
 
-		return chain.GenesisBaseFee
+		return common.Max(chain.GenesisBaseFee, fee.MinBaseFee)
 	}
 
-	parentGasTarget := parent.GasLimit / b.config.Genesis.BaseFeeEM
+	parentGasTarget := parent.GasLimit / fee.BaseFeeEM
 
 	// If the parent gasUsed is the same as the target, the baseFee remains unchanged.
 	if parent.GasUsed == parentGasTarget {
-		return parent.BaseFee
+		return common.Max(parent.BaseFee, fee.MinBaseFee)
+	}
+
+	changeDenom := fee.BaseFeeChangeDenom
+
+	if b.paramsOverrides != nil {
+		if denom, ok := b.paramsOverrides.BaseFeeChangeDenom(parent); ok {
+			changeDenom = denom
+		}
 	}
 
 	// If the parent block used more gas than its target, the baseFee should increase.
 	if parent.GasUsed > parentGasTarget {
 		gasUsedDelta := parent.GasUsed - parentGasTarget
-		baseFeeDelta := b.calcBaseFeeDelta(gasUsedDelta, parentGasTarget, parent.BaseFee)
+		baseFeeDelta := b.calcBaseFeeDelta(gasUsedDelta, parentGasTarget, parent.BaseFee, changeDenom)
 
-		return parent.BaseFee + common.Max(baseFeeDelta, 1)
+		return common.Max(parent.BaseFee+common.Max(baseFeeDelta, 1), fee.MinBaseFee)
 	}
 
-	// Otherwise, if the parent block used less gas than its target, the baseFee should decrease.
+	// Otherwise, if the parent block used less gas than its target, the baseFee should decrease,
+	// but not below the floor
 	gasUsedDelta := parentGasTarget - parent.GasUsed
-	baseFeeDelta := b.calcBaseFeeDelta(gasUsedDelta, parentGasTarget, parent.BaseFee)
+	baseFeeDelta := b.calcBaseFeeDelta(gasUsedDelta, parentGasTarget, parent.BaseFee, changeDenom)
 
-	return common.Max(parent.BaseFee-baseFeeDelta, 0)
+	return common.Max(parent.BaseFee-baseFeeDelta, fee.MinBaseFee)
 }
 
-func (b *Blockchain) calcBaseFeeDelta(gasUsedDelta, parentGasTarget, baseFee uint64) uint64 {
+func (b *Blockchain) calcBaseFeeDelta(gasUsedDelta, parentGasTarget, baseFee, changeDenom uint64) uint64 {
 	y := baseFee * gasUsedDelta / parentGasTarget
 
-	return y / b.config.Genesis.BaseFeeChangeDenom
+	return y / changeDenom
 }
 
 func (b *Blockchain) writeBatchAndUpdate(
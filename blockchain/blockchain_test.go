@@ -14,12 +14,14 @@ import (
 	lru "github.com/hashicorp/golang-lru"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/forkmanager"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/0xPolygon/polygon-edge/blockchain/storage"
 	"github.com/0xPolygon/polygon-edge/blockchain/storage/memory"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
 )
 
 func TestGenesis(t *testing.T) {
@@ -407,9 +409,9 @@ func TestInsertHeaders(t *testing.T) {
 					header: mock(0x4).Parent(0x2).Diff(10),
 					event: &evnt{
 						NewChain: []*header{
-							mock(0x4).Parent(0x2).Diff(10),
-							mock(0x2),
 							mock(0x1),
+							mock(0x2),
+							mock(0x4).Parent(0x2).Diff(10),
 						},
 						OldChain: []*header{
 							mock(0x3).Parent(0x0).Diff(5),
@@ -551,6 +553,47 @@ func TestForkUnknownParents(t *testing.T) {
 	assert.Error(t, b.WriteHeadersWithBodies([]*types.Header{h1[12]}))
 }
 
+func TestBlockchain_ReorgLimits(t *testing.T) {
+	t.Parallel()
+
+	canonical := NewTestHeaders(10)
+	// the competing chain forks off after the block 4, replacing 5 canonical blocks once its difficulty is higher
+	competing := AppendNewTestheadersWithSeed(canonical[:5], 10, 1)
+
+	cases := []struct {
+		name   string
+		limits ReorgLimits
+		err    error
+	}{
+		{"unlimited", ReorgLimits{}, nil},
+		{"within the max depth", ReorgLimits{MaxDepth: 5}, nil},
+		{"deeper than the max depth", ReorgLimits{MaxDepth: 4}, ErrReorgTooDeep},
+		{"finalizing", ReorgLimits{Finalizing: true}, ErrFinalizedReorg},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			b := NewTestBlockchain(t, canonical)
+			b.SetReorgLimits(c.limits)
+
+			err := b.WriteHeadersWithBodies(competing[5:])
+			if c.err == nil {
+				require.NoError(t, err)
+				assert.Equal(t, competing[len(competing)-1].Hash, b.Header().Hash)
+
+				return
+			}
+
+			require.ErrorIs(t, err, c.err)
+			assert.Equal(t, canonical[len(canonical)-1].Hash, b.Header().Hash)
+		})
+	}
+}
+
 func TestBlockchainWriteBody(t *testing.T) {
 	t.Parallel()
 
@@ -1361,6 +1404,72 @@ func TestBlockchain_VerifyBlockBody(t *testing.T) {
 	})
 }
 
+func TestBlockchain_VerifyFinalizedBlockWithReceipts(t *testing.T) {
+	t.Parallel()
+
+	var (
+		parent  = (&types.Header{Number: 1, GasLimit: 30000000}).ComputeHash()
+		success = types.ReceiptSuccess
+		failed  = types.ReceiptFailed
+	)
+
+	receipts := []*types.Receipt{
+		{CumulativeGasUsed: 21000, Status: &success},
+		{CumulativeGasUsed: 42000, Status: &success},
+	}
+
+	newBlock := func() *types.Block {
+		txs := []*types.Transaction{{Nonce: 0, Value: big.NewInt(1)}, {Nonce: 1, Value: big.NewInt(1)}}
+
+		return &types.Block{
+			Header: (&types.Header{
+				Number:       2,
+				ParentHash:   parent.Hash,
+				GasLimit:     30000000,
+				GasUsed:      42000,
+				Sha3Uncles:   types.EmptyUncleHash,
+				TxRoot:       buildroot.CalculateTransactionsRoot(txs, 2),
+				ReceiptsRoot: buildroot.CalculateReceiptsRoot(receipts),
+			}).ComputeHash(),
+			Transactions: txs,
+		}
+	}
+
+	blockchain, err := NewMockBlockchain(map[TestCallbackType]interface{}{
+		StorageCallback: func(storage *storage.MockStorage) {
+			storage.HookReadHeader(func(hash types.Hash) (*types.Header, error) {
+				return parent.Copy(), nil
+			})
+		},
+	})
+	require.NoError(t, err)
+
+	fullBlock, err := blockchain.VerifyFinalizedBlockWithReceipts(newBlock(), receipts)
+	require.NoError(t, err)
+	require.Equal(t, receipts, fullBlock.Receipts)
+
+	_, err = blockchain.VerifyFinalizedBlockWithReceipts(newBlock(), receipts[:1])
+	require.ErrorIs(t, err, ErrInvalidReceiptsSize)
+
+	block := newBlock()
+	block.Header.GasUsed = 21000
+
+	_, err = blockchain.VerifyFinalizedBlockWithReceipts(block, receipts)
+	require.ErrorIs(t, err, ErrInvalidGasUsed)
+
+	_, err = blockchain.VerifyFinalizedBlockWithReceipts(newBlock(), []*types.Receipt{
+		receipts[0],
+		{CumulativeGasUsed: 42000, Status: &failed},
+	})
+	require.ErrorIs(t, err, ErrInvalidReceiptsRoot)
+
+	block = newBlock()
+	block.Transactions = block.Transactions[:1]
+
+	_, err = blockchain.VerifyFinalizedBlockWithReceipts(block, receipts[:1])
+	require.ErrorIs(t, err, ErrInvalidTxRoot)
+}
+
 func TestBlockchain_CalculateBaseFee(t *testing.T) {
 	t.Parallel()
 
@@ -1418,6 +1527,149 @@ func TestBlockchain_CalculateBaseFee(t *testing.T) {
 	}
 }
 
+func TestBlockchain_CalculateBaseFee_FeeParams(t *testing.T) {
+	t.Parallel()
+
+	baseFeeEM, minBaseFee := uint64(4), uint64(950000000)
+
+	blockchain := &Blockchain{
+		config: &chain.Chain{
+			Params: &chain.Params{
+				Forks: &chain.Forks{
+					chain.London: chain.NewFork(5),
+					"feeParams": chain.Fork{
+						Block: 10,
+						Params: &forkmanager.ForkParams{
+							BaseFeeEM:  &baseFeeEM,
+							MinBaseFee: &minBaseFee,
+						},
+					},
+				},
+			},
+			Genesis: &chain.Genesis{
+				BaseFeeEM:          2,
+				BaseFeeChangeDenom: chain.BaseFeeChangeDenom,
+				MinBaseFee:         900000000,
+			},
+		},
+	}
+
+	parent := &types.Header{
+		Number:   6,
+		GasLimit: 20000000,
+		BaseFee:  chain.GenesisBaseFee,
+	}
+
+	// the base fee doesn't drop below the genesis floor
+	require.Equal(t, uint64(900000000), blockchain.CalculateBaseFee(parent))
+
+	// the params of the fork override the genesis ones from its block
+	parent.Number = 9
+	parent.GasUsed = 5000000
+	require.Equal(t, chain.GenesisBaseFee, blockchain.CalculateBaseFee(parent))
+
+	parent.GasUsed = 0
+	require.Equal(t, minBaseFee, blockchain.CalculateBaseFee(parent))
+}
+
+func TestBlockchain_VerifyFeeDistribution(t *testing.T) {
+	t.Parallel()
+
+	distribution := &chain.FeeDistribution{
+		Block:           10,
+		ProposerShare:   5000,
+		StakerPoolShare: 5000,
+		StakerPool:      types.StringToAddress("0x1"),
+	}
+
+	_, err := NewBlockchain(hclog.NewNullLogger(), nil,
+		&chain.Chain{Params: &chain.Params{FeeDistribution: &chain.FeeDistribution{ProposerShare: 1}}},
+		nil, nil, nil)
+	require.ErrorContains(t, err, "invalid fee distribution")
+
+	proposer := types.ZeroAddress
+	verifier := &MockVerifier{}
+	verifier.HookGetBlockCreator(func(*types.Header) (types.Address, error) {
+		return proposer, nil
+	})
+
+	blockchain := &Blockchain{
+		config:    &chain.Chain{Params: &chain.Params{FeeDistribution: distribution}},
+		consensus: verifier,
+	}
+
+	// the fees aren't shared before the block
+	require.NoError(t, blockchain.verifyFeeDistribution(&types.Header{Number: 9}))
+	require.ErrorIs(t, blockchain.verifyFeeDistribution(&types.Header{Number: 10}), ErrNoFeeProposer)
+
+	proposer = types.StringToAddress("0x2")
+	require.NoError(t, blockchain.verifyFeeDistribution(&types.Header{Number: 10}))
+}
+
+type mockParamsOverrides struct {
+	blockGasTarget     uint64
+	baseFeeChangeDenom uint64
+}
+
+func (m *mockParamsOverrides) BlockGasTarget(*types.Header) (uint64, bool) {
+	return m.blockGasTarget, m.blockGasTarget != 0
+}
+
+func (m *mockParamsOverrides) BaseFeeChangeDenom(*types.Header) (uint64, bool) {
+	return m.baseFeeChangeDenom, m.baseFeeChangeDenom != 0
+}
+
+func TestBlockchain_ParamsOverrides(t *testing.T) {
+	t.Parallel()
+
+	parent := &types.Header{
+		Number:   6,
+		GasLimit: 20000000,
+		GasUsed:  20000000,
+		BaseFee:  chain.GenesisBaseFee,
+	}
+
+	b, err := NewMockBlockchain(map[TestCallbackType]interface{}{
+		StorageCallback: func(storage *storage.MockStorage) {
+			storage.HookReadHeader(func(hash types.Hash) (*types.Header, error) {
+				return parent, nil
+			})
+		},
+	})
+	require.NoError(t, err)
+
+	b.config = &chain.Chain{
+		Params: &chain.Params{
+			Forks:          &chain.Forks{chain.London: chain.NewFork(5)},
+			BlockGasTarget: 25000000,
+		},
+		Genesis: &chain.Genesis{
+			BaseFeeEM:          2,
+			BaseFeeChangeDenom: chain.BaseFeeChangeDenom,
+		},
+	}
+
+	gasLimit, err := b.CalculateGasLimit(7)
+	require.NoError(t, err)
+	require.Equal(t, uint64(20000000+20000000/1024), gasLimit)
+	require.Equal(t, uint64(1125000000), b.CalculateBaseFee(parent))
+
+	// the parameters changed on-chain take precedence over the chain params
+	b.SetParamsOverrides(&mockParamsOverrides{blockGasTarget: 15000000, baseFeeChangeDenom: 4})
+
+	gasLimit, err = b.CalculateGasLimit(7)
+	require.NoError(t, err)
+	require.Equal(t, uint64(20000000-20000000/1024), gasLimit)
+	require.Equal(t, uint64(1250000000), b.CalculateBaseFee(parent))
+
+	// the parameters which are not changed fall back to the chain params
+	b.SetParamsOverrides(&mockParamsOverrides{baseFeeChangeDenom: 4})
+
+	gasLimit, err = b.CalculateGasLimit(7)
+	require.NoError(t, err)
+	require.Equal(t, uint64(20000000+20000000/1024), gasLimit)
+}
+
 func TestBlockchain_WriteFullBlock(t *testing.T) {
 	t.Parallel()
 
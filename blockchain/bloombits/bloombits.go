@@ -0,0 +1,154 @@
+package bloombits
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// SectionSize is the number of the blocks in the section of the index
+	SectionSize = 4096
+
+	// BloomBitLength is the number of the bits in the log bloom
+	BloomBitLength = types.BloomByteLength * 8
+
+	// vectorLength is the length in bytes of the bit vector of the section
+	vectorLength = SectionSize / 8
+
+	// bitmapLength is the length in bytes of the bitmap of the non-zero bytes of the compressed vector
+	bitmapLength = vectorLength / 8
+)
+
+var (
+	errBlockOutOfOrder    = errors.New("blocks have to be added in order")
+	errSectionIncomplete  = errors.New("section is not complete")
+	errInvalidVectorBytes = errors.New("invalid compressed bit vector")
+)
+
+// Generator builds the bit vectors of the section from the log blooms of its blocks.
+// The vector of the bloom bit has the bit of the block set if the bloom bit is set in the log bloom of the block
+type Generator struct {
+	vectors [BloomBitLength][vectorLength]byte
+	next    uint
+}
+
+// AddBloom adds the log bloom of the block with the given index within the section
+func (g *Generator) AddBloom(index uint, bloom types.Bloom) error {
+	if index != g.next {
+		return fmt.Errorf("%w: expected %d, got %d", errBlockOutOfOrder, g.next, index)
+	}
+
+	if index >= SectionSize {
+		return fmt.Errorf("block index %d out of the section", index)
+	}
+
+	blockByte, blockMask := index/8, byte(1)<<(7-index%8)
+
+	for i, b := range bloom {
+		if b == 0 {
+			continue
+		}
+
+		for j := uint(0); j < 8; j++ {
+			if b&(1<<(7-j)) != 0 {
+				g.vectors[uint(i)*8+j][blockByte] |= blockMask
+			}
+		}
+	}
+
+	g.next++
+
+	return nil
+}
+
+// Vector returns the bit vector of the bloom bit, once the blooms of all blocks of the section are added
+func (g *Generator) Vector(bit uint) ([]byte, error) {
+	if g.next != SectionSize {
+		return nil, errSectionIncomplete
+	}
+
+	return g.vectors[bit][:], nil
+}
+
+// bloomBits returns the bloom bits set for the value in the log bloom,
+// the bits are numbered from the most significant bit of the first byte of the bloom
+func bloomBits(data []byte) [3]uint {
+	var (
+		hash = crypto.Keccak256(data)
+		bits [3]uint
+	)
+
+	for i := 0; i < 3; i++ {
+		bit := (uint(hash[2*i+1]) + (uint(hash[2*i]) << 8)) & (BloomBitLength - 1)
+
+		byteLocation := types.BloomByteLength - 1 - bit/8
+		bits[i] = byteLocation*8 + (7 - bit%8)
+	}
+
+	return bits
+}
+
+// compressVector encodes the bit vector as the bitmap of its non-zero bytes followed by these bytes.
+// The empty vector is encoded as nil, and the dense vector is kept as is
+func compressVector(vector []byte) []byte {
+	var (
+		bitmap = make([]byte, bitmapLength)
+		data   = make([]byte, 0, vectorLength)
+	)
+
+	for i, b := range vector {
+		if b != 0 {
+			bitmap[i/8] |= 1 << (7 - i%8)
+			data = append(data, b)
+		}
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	if bitmapLength+len(data) >= vectorLength {
+		return append([]byte{}, vector...)
+	}
+
+	return append(bitmap, data...)
+}
+
+// decompressVector decodes the bit vector encoded by compressVector
+func decompressVector(data []byte) ([]byte, error) {
+	vector := make([]byte, vectorLength)
+
+	switch {
+	case len(data) == 0:
+		return vector, nil
+	case len(data) == vectorLength:
+		copy(vector, data)
+
+		return vector, nil
+	case len(data) < bitmapLength:
+		return nil, errInvalidVectorBytes
+	}
+
+	bitmap, values := data[:bitmapLength], data[bitmapLength:]
+
+	for i := range vector {
+		if bitmap[i/8]&(1<<(7-i%8)) == 0 {
+			continue
+		}
+
+		if len(values) == 0 {
+			return nil, errInvalidVectorBytes
+		}
+
+		vector[i], values = values[0], values[1:]
+	}
+
+	if len(values) != 0 {
+		return nil, errInvalidVectorBytes
+	}
+
+	return vector, nil
+}
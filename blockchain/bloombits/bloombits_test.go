@@ -0,0 +1,107 @@
+package bloombits
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestBloomBits(t *testing.T) {
+	t.Parallel()
+
+	addr := types.StringToAddress("0x1000")
+
+	bloom := types.CreateBloom([]*types.Receipt{
+		{Logs: []*types.Log{{Address: addr}}},
+	})
+
+	// the bits of the value are the ones set in the log bloom
+	set := make(map[uint]struct{})
+
+	for i, b := range bloom {
+		for j := uint(0); j < 8; j++ {
+			if b&(1<<(7-j)) != 0 {
+				set[uint(i)*8+j] = struct{}{}
+			}
+		}
+	}
+
+	for _, bit := range bloomBits(addr.Bytes()) {
+		require.Contains(t, set, bit)
+	}
+
+	require.LessOrEqual(t, len(set), 3)
+}
+
+func TestGenerator(t *testing.T) {
+	t.Parallel()
+
+	var bloom types.Bloom
+
+	bloom[0] = 0x80 // bit 0
+	bloom[255] = 0x01
+
+	generator := &Generator{}
+
+	for index := uint(0); index < SectionSize; index++ {
+		if index == 10 {
+			require.NoError(t, generator.AddBloom(index, bloom))
+		} else {
+			require.NoError(t, generator.AddBloom(index, types.Bloom{}))
+		}
+	}
+
+	require.ErrorIs(t, generator.AddBloom(0, bloom), errBlockOutOfOrder)
+
+	vector, err := generator.Vector(0)
+	require.NoError(t, err)
+	require.Equal(t, byte(0x20), vector[1])
+
+	vector, err = generator.Vector(BloomBitLength - 1)
+	require.NoError(t, err)
+	require.Equal(t, byte(0x20), vector[1])
+
+	vector, err = generator.Vector(1)
+	require.NoError(t, err)
+	require.Equal(t, make([]byte, vectorLength), vector)
+}
+
+func TestGenerator_SectionIncomplete(t *testing.T) {
+	t.Parallel()
+
+	generator := &Generator{}
+	require.NoError(t, generator.AddBloom(0, types.Bloom{}))
+
+	_, err := generator.Vector(0)
+	require.ErrorIs(t, err, errSectionIncomplete)
+}
+
+func TestCompressVector(t *testing.T) {
+	t.Parallel()
+
+	sparse := make([]byte, vectorLength)
+	sparse[3] = 0x11
+	sparse[400] = 0xff
+
+	dense := make([]byte, vectorLength)
+	for i := range dense {
+		dense[i] = byte(i) | 1
+	}
+
+	for _, vector := range [][]byte{make([]byte, vectorLength), sparse, dense} {
+		data := compressVector(vector)
+		require.LessOrEqual(t, len(data), vectorLength)
+
+		decompressed, err := decompressVector(data)
+		require.NoError(t, err)
+		require.Equal(t, vector, decompressed)
+	}
+
+	require.Nil(t, compressVector(make([]byte, vectorLength)))
+	require.Len(t, compressVector(sparse), bitmapLength+2)
+
+	_, err := decompressVector(compressVector(sparse)[:bitmapLength+1])
+	require.ErrorIs(t, err, errInvalidVectorBytes)
+}
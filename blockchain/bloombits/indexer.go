@@ -0,0 +1,351 @@
+package bloombits
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Confirmations is the number of the blocks on top of the section required before the section is indexed,
+// so the indexed blocks aren't reorganized
+const Confirmations = 256
+
+// blockchainReader is the blockchain interface required by the indexer
+type blockchainReader interface {
+	Header() *types.Header
+	GetHeaderByNumber(uint64) (*types.Header, bool)
+	SubscribeEvents() blockchain.Subscription
+	UnsubscribeEvents(blockchain.Subscription)
+}
+
+// Status is the progress of the index build
+type Status struct {
+	// Sections is the number of the indexed sections
+	Sections uint64
+	// IndexedBlocks is the number of the indexed blocks, starting from the genesis
+	IndexedBlocks uint64
+	// HeadBlock is the number of the head of the chain
+	HeadBlock uint64
+	// Building is true while the sections are being indexed
+	Building bool
+}
+
+// Indexer maintains the bloom bits index of the canonical chain in the background.
+// The index is built in sections of SectionSize blocks, once the section is confirmed
+type Indexer struct {
+	logger     hclog.Logger
+	storage    storage.Storage
+	blockchain blockchainReader
+
+	sections atomic.Uint64
+	building atomic.Bool
+
+	subscription blockchain.Subscription
+	notifyCh     chan struct{}
+	ctx          context.Context
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+}
+
+// NewIndexer creates the indexer, resuming the index held by the storage
+func NewIndexer(logger hclog.Logger, storage storage.Storage, blockchain blockchainReader) *Indexer {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	i := &Indexer{
+		logger:     logger.Named("bloombits"),
+		storage:    storage,
+		blockchain: blockchain,
+		notifyCh:   make(chan struct{}, 1),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+
+	if sections, ok := storage.ReadBloomBitsSections(); ok {
+		i.sections.Store(sections)
+	}
+
+	return i
+}
+
+// Start indexes the confirmed sections of the chain, and then the new sections as they get confirmed
+func (i *Indexer) Start() {
+	// the sections past the confirmed blocks are left by the rollback of the chain, so they are indexed again
+	if confirmed := i.confirmedSections(); i.sections.Load() > confirmed {
+		i.logger.Warn("indexed sections are past the confirmed blocks", "sections", i.sections.Load(), "confirmed", confirmed)
+		i.sections.Store(confirmed)
+	}
+
+	i.subscription = i.blockchain.SubscribeEvents()
+	i.notify()
+
+	i.wg.Add(2)
+
+	// the events are only forwarded, so the blockchain isn't blocked while the sections are indexed
+	go func() {
+		defer i.wg.Done()
+
+		for {
+			select {
+			case <-i.ctx.Done():
+				return
+			case ev := <-i.subscription.GetEventCh():
+				if ev.Type != blockchain.EventFork {
+					i.notify()
+				}
+			}
+		}
+	}()
+
+	go func() {
+		defer i.wg.Done()
+
+		for {
+			select {
+			case <-i.ctx.Done():
+				return
+			case <-i.notifyCh:
+			}
+
+			if err := i.indexSections(); err != nil && i.ctx.Err() == nil {
+				i.logger.Error("failed to index the log blooms", "err", err)
+			}
+		}
+	}()
+}
+
+func (i *Indexer) notify() {
+	select {
+	case i.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+// indexSections indexes the confirmed sections which aren't indexed yet
+func (i *Indexer) indexSections() error {
+	i.building.Store(true)
+	defer i.building.Store(false)
+
+	for section := i.sections.Load(); section < i.confirmedSections(); section++ {
+		start := time.Now()
+
+		if err := i.indexSection(section); err != nil {
+			return fmt.Errorf("section %d: %w", section, err)
+		}
+
+		i.sections.Store(section + 1)
+
+		metrics.SetGauge([]string{"bloombits", "sections"}, float32(section+1))
+
+		i.logger.Debug("section indexed", "section", section, "elapsed", time.Since(start))
+	}
+
+	return nil
+}
+
+// confirmedSections returns the number of the sections which have enough confirmations to be indexed
+func (i *Indexer) confirmedSections() uint64 {
+	head := i.blockchain.Header().Number
+	if head+1 < Confirmations {
+		return 0
+	}
+
+	return (head + 1 - Confirmations) / SectionSize
+}
+
+func (i *Indexer) indexSection(section uint64) error {
+	generator := &Generator{}
+
+	for index := uint(0); index < SectionSize; index++ {
+		if index%256 == 0 {
+			if err := i.ctx.Err(); err != nil {
+				return err
+			}
+		}
+
+		number := section*SectionSize + uint64(index)
+
+		header, ok := i.blockchain.GetHeaderByNumber(number)
+		if !ok {
+			return fmt.Errorf("header %d not found", number)
+		}
+
+		if err := generator.AddBloom(index, header.LogsBloom); err != nil {
+			return err
+		}
+	}
+
+	batchWriter := storage.NewBatchWriter(i.storage)
+
+	for bit := uint(0); bit < BloomBitLength; bit++ {
+		vector, err := generator.Vector(bit)
+		if err != nil {
+			return err
+		}
+
+		// the empty vectors aren't stored, they are read as the zero vectors
+		if data := compressVector(vector); data != nil {
+			batchWriter.PutBloomBits(bit, section, data)
+		}
+	}
+
+	batchWriter.PutBloomBitsSections(section + 1)
+
+	return batchWriter.WriteBatch()
+}
+
+// IndexedBlocks returns the number of the indexed blocks, starting from the genesis
+func (i *Indexer) IndexedBlocks() uint64 {
+	return i.sections.Load() * SectionSize
+}
+
+// Status returns the progress of the index build
+func (i *Indexer) Status() *Status {
+	sections := i.sections.Load()
+
+	return &Status{
+		Sections:      sections,
+		IndexedBlocks: sections * SectionSize,
+		HeadBlock:     i.blockchain.Header().Number,
+		Building:      i.building.Load(),
+	}
+}
+
+// Match returns the numbers of the indexed blocks in the range whose log blooms may contain the logs
+// emitted by any of the addresses and with any of the topics on each position. Empty addresses or topics
+// on the position match any log. The blocks past the indexed ones are not returned
+func (i *Indexer) Match(from, to uint64, addresses []types.Address, topics [][]types.Hash) ([]uint64, error) {
+	if indexed := i.IndexedBlocks(); to >= indexed {
+		if indexed == 0 {
+			return nil, nil
+		}
+
+		to = indexed - 1
+	}
+
+	groups := make([][][3]uint, 0, len(topics)+1)
+
+	if len(addresses) > 0 {
+		group := make([][3]uint, len(addresses))
+		for j, addr := range addresses {
+			group[j] = bloomBits(addr.Bytes())
+		}
+
+		groups = append(groups, group)
+	}
+
+	for _, position := range topics {
+		if len(position) == 0 {
+			continue
+		}
+
+		group := make([][3]uint, len(position))
+		for j, topic := range position {
+			group[j] = bloomBits(topic.Bytes())
+		}
+
+		groups = append(groups, group)
+	}
+
+	var numbers []uint64
+
+	for section := from / SectionSize; section <= to/SectionSize; section++ {
+		vector, err := i.matchSection(section, groups)
+		if err != nil {
+			return nil, err
+		}
+
+		first := section * SectionSize
+
+		for index := uint64(0); index < SectionSize; index++ {
+			number := first + index
+			if number < from || number > to {
+				continue
+			}
+
+			if vector[index/8]&(1<<(7-index%8)) != 0 {
+				numbers = append(numbers, number)
+			}
+		}
+	}
+
+	return numbers, nil
+}
+
+// matchSection returns the vector of the blocks of the section matching all the groups,
+// where the block matches the group if its bloom contains all bits of any value of the group
+func (i *Indexer) matchSection(section uint64, groups [][][3]uint) ([]byte, error) {
+	result := make([]byte, vectorLength)
+	for j := range result {
+		result[j] = 0xff
+	}
+
+	vectors := make(map[uint][]byte)
+
+	readVector := func(bit uint) ([]byte, error) {
+		if vector, ok := vectors[bit]; ok {
+			return vector, nil
+		}
+
+		data, _ := i.storage.ReadBloomBits(bit, section)
+
+		vector, err := decompressVector(data)
+		if err != nil {
+			return nil, fmt.Errorf("bit %d of section %d: %w", bit, section, err)
+		}
+
+		vectors[bit] = vector
+
+		return vector, nil
+	}
+
+	for _, group := range groups {
+		groupVector := make([]byte, vectorLength)
+
+		for _, bits := range group {
+			valueVector := make([]byte, vectorLength)
+			for j := range valueVector {
+				valueVector[j] = 0xff
+			}
+
+			for _, bit := range bits {
+				vector, err := readVector(bit)
+				if err != nil {
+					return nil, err
+				}
+
+				for j := range valueVector {
+					valueVector[j] &= vector[j]
+				}
+			}
+
+			for j := range groupVector {
+				groupVector[j] |= valueVector[j]
+			}
+		}
+
+		for j := range result {
+			result[j] &= groupVector[j]
+		}
+	}
+
+	return result, nil
+}
+
+// Close stops the indexer, interrupting the section being indexed
+func (i *Indexer) Close() {
+	if i.subscription != nil {
+		i.blockchain.UnsubscribeEvents(i.subscription)
+	}
+
+	i.cancel()
+	i.wg.Wait()
+}
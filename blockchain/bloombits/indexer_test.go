@@ -0,0 +1,115 @@
+package bloombits
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/memory"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type mockBlockchain struct {
+	headers      []*types.Header
+	subscription *blockchain.MockSubscription
+}
+
+func (m *mockBlockchain) Header() *types.Header {
+	return m.headers[len(m.headers)-1]
+}
+
+func (m *mockBlockchain) GetHeaderByNumber(number uint64) (*types.Header, bool) {
+	if number >= uint64(len(m.headers)) {
+		return nil, false
+	}
+
+	return m.headers[number], true
+}
+
+func (m *mockBlockchain) SubscribeEvents() blockchain.Subscription {
+	return m.subscription
+}
+
+func (m *mockBlockchain) UnsubscribeEvents(blockchain.Subscription) {
+}
+
+func newMockBlockchain(blocks uint64, logs map[uint64]*types.Log) *mockBlockchain {
+	headers := make([]*types.Header, blocks)
+
+	for number := range headers {
+		headers[number] = &types.Header{Number: uint64(number)}
+
+		if log, ok := logs[uint64(number)]; ok {
+			headers[number].LogsBloom = types.CreateBloom([]*types.Receipt{{Logs: []*types.Log{log}}})
+		}
+	}
+
+	return &mockBlockchain{headers: headers, subscription: blockchain.NewMockSubscription()}
+}
+
+func TestIndexer(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1  = types.StringToAddress("0x1000")
+		addr2  = types.StringToAddress("0x2000")
+		topic1 = types.StringToHash("0x1")
+		topic2 = types.StringToHash("0x2")
+	)
+
+	// two sections are confirmed, the blocks of the third one are not indexed
+	chain := newMockBlockchain(2*SectionSize+Confirmations+10, map[uint64]*types.Log{
+		5:                 {Address: addr1, Topics: []types.Hash{topic1}},
+		SectionSize + 7:   {Address: addr2, Topics: []types.Hash{topic1, topic2}},
+		SectionSize + 10:  {Address: addr1, Topics: []types.Hash{topic2}},
+		2*SectionSize + 1: {Address: addr1},
+	})
+
+	db, err := memory.NewMemoryStorage(nil)
+	require.NoError(t, err)
+
+	indexer := NewIndexer(hclog.NewNullLogger(), db, chain)
+	indexer.Start()
+
+	require.Eventually(t, func() bool {
+		return indexer.Status().Sections == 2
+	}, 10*time.Second, 10*time.Millisecond)
+
+	indexer.Close()
+
+	require.Equal(t, uint64(2*SectionSize), indexer.IndexedBlocks())
+
+	matches := func(from, to uint64, addresses []types.Address, topics [][]types.Hash) []uint64 {
+		t.Helper()
+
+		numbers, err := indexer.Match(from, to, addresses, topics)
+		require.NoError(t, err)
+
+		return numbers
+	}
+
+	require.Equal(t, []uint64{5, SectionSize + 10}, matches(0, 3*SectionSize, []types.Address{addr1}, nil))
+	require.Equal(t, []uint64{SectionSize + 10}, matches(6, 3*SectionSize, []types.Address{addr1}, nil))
+	require.Equal(t,
+		[]uint64{5, SectionSize + 7, SectionSize + 10},
+		matches(0, 3*SectionSize, []types.Address{addr1, addr2}, nil),
+	)
+	// the blooms don't hold the positions of the topics, so the candidates are filtered by the caller
+	require.Equal(t,
+		[]uint64{SectionSize + 7, SectionSize + 10},
+		matches(0, 3*SectionSize, nil, [][]types.Hash{{}, {topic2}}),
+	)
+	require.Equal(t,
+		[]uint64{SectionSize + 7},
+		matches(0, 3*SectionSize, []types.Address{addr2}, [][]types.Hash{{topic1, topic2}}),
+	)
+	require.Empty(t, matches(0, SectionSize-1, []types.Address{addr2}, nil))
+
+	// the index is resumed from the storage
+	indexer = NewIndexer(hclog.NewNullLogger(), db, chain)
+	require.Equal(t, uint64(2), indexer.Status().Sections)
+	require.Equal(t, []uint64{5}, matches(0, 10, []types.Address{addr1}, nil))
+}
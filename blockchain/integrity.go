@@ -0,0 +1,227 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+)
+
+var (
+	ErrGenesisInconsistent  = errors.New("genesis block is inconsistent")
+	ErrRollbackIntoAncients = errors.New("can't roll back into the blocks moved to the ancient store")
+)
+
+// Inconsistency is the inconsistent data of the canonical block found by the integrity check
+type Inconsistency struct {
+	Number uint64 `json:"number"`
+	Reason string `json:"reason"`
+}
+
+// IntegrityReport is the result of the integrity check of the most recent canonical blocks
+type IntegrityReport struct {
+	// Head is the number of the head block
+	Head uint64 `json:"head"`
+	// From is the number of the lowest checked block
+	From uint64 `json:"from"`
+	// Inconsistencies are sorted from the head down
+	Inconsistencies []Inconsistency `json:"inconsistencies"`
+}
+
+// Consistent returns true if no inconsistency was found
+func (r *IntegrityReport) Consistent() bool {
+	return len(r.Inconsistencies) == 0
+}
+
+// LastConsistent returns the number of the highest block below all inconsistent blocks,
+// the bool is false if the genesis block is inconsistent
+func (r *IntegrityReport) LastConsistent() (uint64, bool) {
+	if r.Consistent() {
+		return r.Head, true
+	}
+
+	lowest := r.Inconsistencies[len(r.Inconsistencies)-1].Number
+	if lowest == 0 {
+		return 0, false
+	}
+
+	return lowest - 1, true
+}
+
+// CheckIntegrity verifies the header-body-receipts consistency and the canonical hash continuity
+// of the given number of the most recent canonical blocks, 0 checks the blocks down to the genesis.
+// If hasState is set, the state of the head has to be stored, otherwise the blocks are inconsistent
+// down to the highest block whose state is stored. The report is nil if the storage holds no chain
+func CheckIntegrity(db storage.Storage, depth uint64, hasState func(types.Hash) bool) (*IntegrityReport, error) {
+	headHash, ok := db.ReadHeadHash()
+	if !ok {
+		return nil, nil
+	}
+
+	report := &IntegrityReport{}
+
+	head, ok := findHead(db, headHash)
+	if !ok {
+		return nil, fmt.Errorf("head %s not found", headHash)
+	}
+
+	report.Head = head
+
+	if canonical, ok := db.ReadCanonicalHash(head); ok && canonical != headHash {
+		report.Inconsistencies = append(report.Inconsistencies, Inconsistency{
+			Number: head,
+			Reason: fmt.Sprintf("head hash %s doesn't match canonical hash %s", headHash, canonical),
+		})
+	}
+
+	if depth != 0 && head >= depth {
+		report.From = head - depth + 1
+	}
+
+	receiptsTail, _ := db.ReadReceiptsTail()
+	checkState := hasState != nil
+
+	for n := head; ; n-- {
+		header, reason := checkBlock(db, n, receiptsTail)
+
+		if reason == "" && checkState {
+			if hasState(header.StateRoot) {
+				checkState = false
+			} else {
+				reason = fmt.Sprintf("state %s missing", header.StateRoot)
+			}
+		}
+
+		if reason != "" {
+			report.Inconsistencies = append(report.Inconsistencies, Inconsistency{Number: n, Reason: reason})
+		}
+
+		if n == report.From {
+			break
+		}
+	}
+
+	return report, nil
+}
+
+// findHead returns the number of the head block, which is read from the header
+// if the head number is not stored
+func findHead(db storage.Storage, headHash types.Hash) (uint64, bool) {
+	if head, ok := db.ReadHeadNumber(); ok {
+		return head, true
+	}
+
+	header, err := db.ReadHeader(headHash)
+	if err != nil {
+		return 0, false
+	}
+
+	return header.Number, true
+}
+
+// checkBlock verifies the data of the canonical block with the given number,
+// it returns the reason of the inconsistency, which is empty if the block is consistent
+func checkBlock(db storage.Storage, number, receiptsTail uint64) (*types.Header, string) {
+	hash, ok := db.ReadCanonicalHash(number)
+	if !ok {
+		return nil, "canonical hash missing"
+	}
+
+	header, err := db.ReadHeader(hash)
+	if err != nil {
+		return nil, fmt.Sprintf("header %s missing", hash)
+	}
+
+	if header.Number != number {
+		return nil, fmt.Sprintf("header %s has number %d", hash, header.Number)
+	}
+
+	if number > 0 {
+		parent, ok := db.ReadCanonicalHash(number - 1)
+		if ok && parent != header.ParentHash {
+			return nil, fmt.Sprintf("parent hash %s doesn't match canonical hash %s", header.ParentHash, parent)
+		}
+	}
+
+	if _, ok := db.ReadTotalDifficulty(hash); !ok {
+		return nil, "total difficulty missing"
+	}
+
+	body, err := db.ReadBody(hash)
+	if err != nil {
+		// the genesis and the other blocks without the transactions may have no body
+		if header.TxRoot != types.EmptyRootHash {
+			return nil, "body missing"
+		}
+
+		return header, ""
+	}
+
+	if root := buildroot.CalculateTransactionsRoot(body.Transactions, number); root != header.TxRoot {
+		return nil, fmt.Sprintf("transactions root %s doesn't match header %s", root, header.TxRoot)
+	}
+
+	// the receipts of the blocks below the tail are pruned
+	if len(body.Transactions) == 0 || number < receiptsTail {
+		return header, ""
+	}
+
+	receipts, err := db.ReadReceipts(hash)
+	if err != nil {
+		return nil, "receipts missing"
+	}
+
+	if len(receipts) != len(body.Transactions) {
+		return nil, fmt.Sprintf("%d receipts for %d transactions", len(receipts), len(body.Transactions))
+	}
+
+	return header, ""
+}
+
+// Rollback moves the head of the chain back to the canonical block with the given number,
+// the canonical hashes and the transaction lookups of the blocks above it are deleted.
+// It returns the number of the removed blocks
+func Rollback(db storage.Storage, number uint64) (uint64, error) {
+	if frozen, _ := db.ReadFrozenBlocks(); number+1 < frozen {
+		return 0, fmt.Errorf("%w: %d blocks are frozen", ErrRollbackIntoAncients, frozen)
+	}
+
+	hash, ok := db.ReadCanonicalHash(number)
+	if !ok {
+		return 0, fmt.Errorf("canonical hash of block %d not found", number)
+	}
+
+	head, _ := db.ReadHeadNumber()
+
+	batchWriter := storage.NewBatchWriter(db)
+	batchWriter.PutHeadHash(hash)
+	batchWriter.PutHeadNumber(number)
+
+	var removed uint64
+
+	// the canonical hashes above the head are left by the interrupted writes, so they are deleted as well
+	for n := number + 1; ; n++ {
+		blockHash, ok := db.ReadCanonicalHash(n)
+		if !ok {
+			if n > head {
+				break
+			}
+
+			continue
+		}
+
+		if body, err := db.ReadBody(blockHash); err == nil {
+			for _, tx := range body.Transactions {
+				batchWriter.DeleteTxLookup(tx.Hash)
+			}
+		}
+
+		batchWriter.DeleteCanonicalHash(n)
+
+		removed++
+	}
+
+	return removed, batchWriter.WriteBatch()
+}
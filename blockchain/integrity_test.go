@@ -0,0 +1,169 @@
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/memory"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+)
+
+// newIntegrityTestStorage writes the canonical chain with the given number of the blocks,
+// every other block has a transaction and its receipt
+func newIntegrityTestStorage(t *testing.T, num uint64) (storage.Storage, []*types.Block) {
+	t.Helper()
+
+	db, err := memory.NewMemoryStorage(nil)
+	require.NoError(t, err)
+
+	blocks := make([]*types.Block, 0, num)
+	parentHash := types.ZeroHash
+
+	for n := uint64(0); n < num; n++ {
+		block := &types.Block{
+			Header: &types.Header{
+				Number:     n,
+				ParentHash: parentHash,
+				TxRoot:     types.EmptyRootHash,
+				StateRoot:  types.BytesToHash([]byte{byte(n + 1)}),
+			},
+		}
+
+		if n%2 == 1 {
+			block.Transactions = []*types.Transaction{{Nonce: n, Value: big.NewInt(1)}}
+			block.Header.TxRoot = buildroot.CalculateTransactionsRoot(block.Transactions, n)
+		}
+
+		block.Header.ComputeHash()
+
+		batchWriter := storage.NewBatchWriter(db)
+		batchWriter.PutCanonicalHeader(block.Header, big.NewInt(int64(n)))
+
+		if len(block.Transactions) > 0 {
+			batchWriter.PutBody(block.Hash(), block.Body())
+			batchWriter.PutReceipts(block.Hash(), []*types.Receipt{{CumulativeGasUsed: n, Logs: []*types.Log{}}})
+
+			for _, tx := range block.Transactions {
+				batchWriter.PutTxLookup(tx.ComputeHash(n).Hash, block.Hash())
+			}
+		}
+
+		require.NoError(t, batchWriter.WriteBatch())
+
+		blocks = append(blocks, block)
+		parentHash = block.Hash()
+	}
+
+	return db, blocks
+}
+
+func TestCheckIntegrity(t *testing.T) {
+	t.Parallel()
+
+	db, blocks := newIntegrityTestStorage(t, 10)
+
+	report, err := CheckIntegrity(db, 0, nil)
+	require.NoError(t, err)
+	require.True(t, report.Consistent())
+	require.Equal(t, &IntegrityReport{Head: 9, From: 0}, report)
+
+	// the receipts lost by the unclean shutdown
+	batchWriter := storage.NewBatchWriter(db)
+	batchWriter.DeleteReceipts(blocks[5].Hash())
+	require.NoError(t, batchWriter.WriteBatch())
+
+	report, err = CheckIntegrity(db, 0, nil)
+	require.NoError(t, err)
+	require.Equal(t, []Inconsistency{{Number: 5, Reason: "receipts missing"}}, report.Inconsistencies)
+
+	last, ok := report.LastConsistent()
+	require.True(t, ok)
+	require.Equal(t, uint64(4), last)
+
+	// the block below the depth isn't checked
+	report, err = CheckIntegrity(db, 4, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), report.From)
+	require.True(t, report.Consistent())
+
+	// the blocks above the highest block whose state is stored are inconsistent
+	report, err = CheckIntegrity(db, 4, func(root types.Hash) bool {
+		return root == blocks[7].Header.StateRoot
+	})
+	require.NoError(t, err)
+	require.Len(t, report.Inconsistencies, 2)
+	require.Equal(t, uint64(9), report.Inconsistencies[0].Number)
+	require.Equal(t, uint64(8), report.Inconsistencies[1].Number)
+}
+
+func TestCheckIntegrity_CanonicalHashContinuity(t *testing.T) {
+	t.Parallel()
+
+	db, _ := newIntegrityTestStorage(t, 5)
+
+	// the canonical hash of the block not written completely
+	batchWriter := storage.NewBatchWriter(db)
+	batchWriter.PutCanonicalHash(2, types.StringToHash("1"))
+	require.NoError(t, batchWriter.WriteBatch())
+
+	report, err := CheckIntegrity(db, 0, nil)
+	require.NoError(t, err)
+	require.Len(t, report.Inconsistencies, 2)
+	require.Equal(t, uint64(3), report.Inconsistencies[0].Number)
+	require.Contains(t, report.Inconsistencies[0].Reason, "parent hash")
+	require.Equal(t, uint64(2), report.Inconsistencies[1].Number)
+	require.Contains(t, report.Inconsistencies[1].Reason, "header")
+
+	// the empty storage isn't checked
+	empty, err := memory.NewMemoryStorage(nil)
+	require.NoError(t, err)
+
+	report, err = CheckIntegrity(empty, 0, nil)
+	require.NoError(t, err)
+	require.Nil(t, report)
+}
+
+func TestRollback(t *testing.T) {
+	t.Parallel()
+
+	db, blocks := newIntegrityTestStorage(t, 10)
+
+	removed, err := Rollback(db, 6)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), removed)
+
+	head, ok := db.ReadHeadHash()
+	require.True(t, ok)
+	require.Equal(t, blocks[6].Hash(), head)
+
+	number, ok := db.ReadHeadNumber()
+	require.True(t, ok)
+	require.Equal(t, uint64(6), number)
+
+	_, ok = db.ReadCanonicalHash(7)
+	require.False(t, ok)
+
+	// the transactions of the removed blocks aren't looked up anymore
+	_, ok = db.ReadTxLookup(blocks[7].Transactions[0].Hash)
+	require.False(t, ok)
+
+	_, ok = db.ReadTxLookup(blocks[5].Transactions[0].Hash)
+	require.True(t, ok)
+
+	report, err := CheckIntegrity(db, 0, nil)
+	require.NoError(t, err)
+	require.True(t, report.Consistent())
+	require.Equal(t, uint64(6), report.Head)
+
+	// the frozen blocks can't be removed
+	batchWriter := storage.NewBatchWriter(db)
+	batchWriter.PutFrozenBlocks(5)
+	require.NoError(t, batchWriter.WriteBatch())
+
+	_, err = Rollback(db, 2)
+	require.ErrorIs(t, err, ErrRollbackIntoAncients)
+}
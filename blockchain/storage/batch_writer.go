@@ -63,6 +63,10 @@ func (b *BatchWriter) PutCanonicalHash(n uint64, hash types.Hash) {
 	b.putWithPrefix(CANONICAL, common.EncodeUint64ToBytes(n), hash.Bytes())
 }
 
+func (b *BatchWriter) DeleteCanonicalHash(n uint64) {
+	b.deleteWithPrefix(CANONICAL, common.EncodeUint64ToBytes(n))
+}
+
 func (b *BatchWriter) PutTotalDifficulty(hash types.Hash, diff *big.Int) {
 	b.putWithPrefix(DIFFICULTY, hash.Bytes(), diff.Bytes())
 }
@@ -73,16 +77,57 @@ func (b *BatchWriter) PutForks(forks []types.Hash) {
 	b.putRlp(FORK, EMPTY, &ff)
 }
 
+func (b *BatchWriter) DeleteReceipts(hash types.Hash) {
+	b.deleteWithPrefix(RECEIPTS, hash.Bytes())
+}
+
+func (b *BatchWriter) DeleteTxLookup(hash types.Hash) {
+	b.deleteWithPrefix(TX_LOOKUP_PREFIX, hash.Bytes())
+}
+
+func (b *BatchWriter) PutReceiptsTail(n uint64) {
+	b.putWithPrefix(TAIL, RECEIPTS, common.EncodeUint64ToBytes(n))
+}
+
+func (b *BatchWriter) PutTxLookupTail(n uint64) {
+	b.putWithPrefix(TAIL, TX_LOOKUP_PREFIX, common.EncodeUint64ToBytes(n))
+}
+
+func (b *BatchWriter) PutBloomBits(bit uint, section uint64, bits []byte) {
+	b.putWithPrefix(BLOOM_BITS, BloomBitsKey(bit, section), bits)
+}
+
+func (b *BatchWriter) PutBloomBitsSections(sections uint64) {
+	b.putWithPrefix(BLOOM_BITS, SECTIONS, common.EncodeUint64ToBytes(sections))
+}
+
+func (b *BatchWriter) DeleteHeader(hash types.Hash) {
+	b.deleteWithPrefix(HEADER, hash.Bytes())
+}
+
+func (b *BatchWriter) DeleteBody(hash types.Hash) {
+	b.deleteWithPrefix(BODY, hash.Bytes())
+}
+
+func (b *BatchWriter) PutAncientNumber(hash types.Hash, n uint64) {
+	b.putWithPrefix(ANCIENT, hash.Bytes(), common.EncodeUint64ToBytes(n))
+}
+
+func (b *BatchWriter) PutFrozenBlocks(n uint64) {
+	b.putWithPrefix(ANCIENT, FROZEN, common.EncodeUint64ToBytes(n))
+}
+
 func (b *BatchWriter) putRlp(p, k []byte, raw types.RLPMarshaler) {
-	var data []byte
+	b.putWithPrefix(p, k, EncodeRLP(raw))
+}
 
+// EncodeRLP encodes the object in the format it is kept in the storage
+func EncodeRLP(raw types.RLPMarshaler) []byte {
 	if obj, ok := raw.(types.RLPStoreMarshaler); ok {
-		data = obj.MarshalStoreRLPTo(nil)
-	} else {
-		data = raw.MarshalRLPTo(nil)
+		return obj.MarshalStoreRLPTo(nil)
 	}
 
-	b.putWithPrefix(p, k, data)
+	return raw.MarshalRLPTo(nil)
 }
 
 func (b *BatchWriter) putWithPrefix(p, k, data []byte) {
@@ -91,6 +136,12 @@ func (b *BatchWriter) putWithPrefix(p, k, data []byte) {
 	b.batch.Put(fullKey, data)
 }
 
+func (b *BatchWriter) deleteWithPrefix(p, k []byte) {
+	fullKey := append(append(make([]byte, 0, len(p)+len(k)), p...), k...)
+
+	b.batch.Delete(fullKey)
+}
+
 func (b *BatchWriter) WriteBatch() error {
 	return b.batch.Write()
 }
@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/helper/kvdb"
+)
+
+// NewDatabaseStorage creates the storage on top of the key-value database of any backend
+func NewDatabaseStorage(logger hclog.Logger, db kvdb.Database) Storage {
+	return NewKeyValueStorage(logger, &databaseKV{db})
+}
+
+// NewDatabaseStorageWithAncients creates the storage on top of the key-value database,
+// reading the data of the frozen blocks from the ancient store
+func NewDatabaseStorageWithAncients(logger hclog.Logger, db kvdb.Database, ancients AncientStore) Storage {
+	return NewKeyValueStorageWithAncients(logger, &databaseKV{db}, ancients)
+}
+
+// databaseKV adapts the key-value database to the kv storage
+type databaseKV struct {
+	kvdb.Database
+}
+
+func (d *databaseKV) NewBatch() Batch {
+	return d.Database.NewBatch()
+}
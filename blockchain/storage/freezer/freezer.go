@@ -0,0 +1,149 @@
+package freezer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+var (
+	ErrUnknownTable    = errors.New("unknown freezer table")
+	ErrBlockOutOfOrder = errors.New("blocks have to be frozen in order")
+)
+
+// Freezer is the append-only store of the immutable data of the ancient blocks, kept in the flat files
+// out of the key-value database. Each table holds the items of one kind, numbered by the block numbers
+type Freezer struct {
+	lock   sync.RWMutex
+	tables map[string]*table
+	frozen uint64
+}
+
+// Open opens the freezer in the directory, creating the given tables if they don't exist.
+// The tables are truncated to the blocks frozen in all of them, dropping the interrupted appends
+func Open(dir string, tables []string) (*Freezer, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	f := &Freezer{tables: make(map[string]*table, len(tables))}
+
+	for _, name := range tables {
+		t, err := openTable(dir, name)
+		if err != nil {
+			_ = f.Close()
+
+			return nil, err
+		}
+
+		f.tables[name] = t
+	}
+
+	if len(tables) == 0 {
+		return f, nil
+	}
+
+	frozen := f.tables[tables[0]].items
+	for _, t := range f.tables {
+		if t.items < frozen {
+			frozen = t.items
+		}
+	}
+
+	for name, t := range f.tables {
+		if err := t.truncate(frozen); err != nil {
+			_ = f.Close()
+
+			return nil, fmt.Errorf("table %s: %w", name, err)
+		}
+	}
+
+	f.frozen = frozen
+
+	return f, nil
+}
+
+// Frozen returns the number of the frozen blocks, starting from the genesis
+func (f *Freezer) Frozen() uint64 {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	return f.frozen
+}
+
+// Append freezes the items of the next block, the items of all tables have to be given
+func (f *Freezer) Append(number uint64, items map[string][]byte) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if number != f.frozen {
+		return fmt.Errorf("%w: expected %d, got %d", ErrBlockOutOfOrder, f.frozen, number)
+	}
+
+	if len(items) != len(f.tables) {
+		return fmt.Errorf("expected the items of %d tables, got %d", len(f.tables), len(items))
+	}
+
+	for name := range items {
+		if _, ok := f.tables[name]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownTable, name)
+		}
+	}
+
+	for name, item := range items {
+		if err := f.tables[name].append(item); err != nil {
+			return fmt.Errorf("table %s: %w", name, err)
+		}
+	}
+
+	f.frozen++
+
+	return nil
+}
+
+// Ancient returns the item of the frozen block from the table,
+// the bool is false if the block isn't frozen or its item is empty
+func (f *Freezer) Ancient(name string, number uint64) ([]byte, bool) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	t, ok := f.tables[name]
+	if !ok || number >= f.frozen {
+		return nil, false
+	}
+
+	item, err := t.retrieve(number)
+	if err != nil || len(item) == 0 {
+		return nil, false
+	}
+
+	return item, true
+}
+
+// Sync flushes the frozen blocks to the disk
+func (f *Freezer) Sync() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for name, t := range f.tables {
+		if err := t.sync(); err != nil {
+			return fmt.Errorf("table %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// Close closes the files of the tables
+func (f *Freezer) Close() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	errs := make([]error, 0, len(f.tables))
+	for _, t := range f.tables {
+		errs = append(errs, t.close())
+	}
+
+	return errors.Join(errs...)
+}
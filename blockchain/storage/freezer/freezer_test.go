@@ -0,0 +1,169 @@
+package freezer
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/helper/kvdb"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var testTables = []string{"a", "b"}
+
+func TestFreezer(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	f, err := Open(dir, testTables)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), f.Frozen())
+
+	require.NoError(t, f.Append(0, map[string][]byte{"a": []byte("a0"), "b": []byte("b0")}))
+	require.NoError(t, f.Append(1, map[string][]byte{"a": []byte("a1"), "b": nil}))
+
+	require.ErrorIs(t, f.Append(5, map[string][]byte{"a": nil, "b": nil}), ErrBlockOutOfOrder)
+	require.ErrorIs(t, f.Append(2, map[string][]byte{"a": nil, "c": nil}), ErrUnknownTable)
+
+	require.NoError(t, f.Sync())
+	require.NoError(t, f.Close())
+
+	// the frozen blocks are read after reopening
+	f, err = Open(dir, testTables)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), f.Frozen())
+
+	item, ok := f.Ancient("a", 1)
+	require.True(t, ok)
+	require.Equal(t, []byte("a1"), item)
+
+	item, ok = f.Ancient("b", 0)
+	require.True(t, ok)
+	require.Equal(t, []byte("b0"), item)
+
+	// the empty items and the blocks which aren't frozen are not found
+	_, ok = f.Ancient("b", 1)
+	require.False(t, ok)
+
+	_, ok = f.Ancient("a", 2)
+	require.False(t, ok)
+
+	_, ok = f.Ancient("c", 0)
+	require.False(t, ok)
+
+	require.NoError(t, f.Close())
+}
+
+func TestFreezer_InterruptedAppend(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	f, err := Open(dir, testTables)
+	require.NoError(t, err)
+	require.NoError(t, f.Append(0, map[string][]byte{"a": []byte("a0"), "b": []byte("b0")}))
+
+	// the item of the second block is written to one table only
+	require.NoError(t, f.tables["a"].append([]byte("a1")))
+	require.NoError(t, f.Close())
+
+	// the index entry of the item in another table points past its data
+	index, err := os.OpenFile(filepath.Join(dir, "b.idx"), os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+
+	_, err = index.Write([]byte{0, 0, 0, 0, 0, 0, 0, 100})
+	require.NoError(t, err)
+	require.NoError(t, index.Close())
+
+	f, err = Open(dir, testTables)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), f.Frozen())
+
+	_, ok := f.Ancient("a", 1)
+	require.False(t, ok)
+
+	require.NoError(t, f.Append(1, map[string][]byte{"a": []byte("a1'"), "b": []byte("b1")}))
+
+	item, ok := f.Ancient("a", 1)
+	require.True(t, ok)
+	require.Equal(t, []byte("a1'"), item)
+
+	require.NoError(t, f.Close())
+}
+
+func TestFreezer_Storage(t *testing.T) {
+	t.Parallel()
+
+	f, err := Open(t.TempDir(), storage.AncientTables)
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	db := storage.NewDatabaseStorageWithAncients(hclog.NewNullLogger(), kvdb.NewMemoryDatabase(), f)
+
+	header := &types.Header{Number: 0, ExtraData: []byte{1}}
+	header.ComputeHash()
+
+	to := types.StringToAddress("11")
+	tx := &types.Transaction{
+		Nonce:    1,
+		To:       &to,
+		Value:    big.NewInt(1),
+		Gas:      21000,
+		GasPrice: big.NewInt(1),
+		V:        big.NewInt(1),
+	}
+	tx.ComputeHash(0)
+
+	body := &types.Body{Transactions: []*types.Transaction{tx}}
+	receipts := types.Receipts{{CumulativeGasUsed: 21000, Logs: []*types.Log{}}}
+
+	batchWriter := storage.NewBatchWriter(db)
+	batchWriter.PutHeader(header)
+	batchWriter.PutBody(header.Hash, body)
+	batchWriter.PutReceipts(header.Hash, receipts)
+	require.NoError(t, batchWriter.WriteBatch())
+
+	// the block is moved to the freezer
+	require.NoError(t, f.Append(0, map[string][]byte{
+		storage.AncientHeaders:  storage.EncodeRLP(header),
+		storage.AncientBodies:   storage.EncodeRLP(body),
+		storage.AncientReceipts: storage.EncodeRLP(&receipts),
+	}))
+
+	batchWriter = storage.NewBatchWriter(db)
+	batchWriter.PutAncientNumber(header.Hash, 0)
+	batchWriter.DeleteHeader(header.Hash)
+	batchWriter.DeleteBody(header.Hash)
+	batchWriter.DeleteReceipts(header.Hash)
+	batchWriter.PutFrozenBlocks(1)
+	require.NoError(t, batchWriter.WriteBatch())
+
+	frozen, ok := db.ReadFrozenBlocks()
+	require.True(t, ok)
+	require.Equal(t, uint64(1), frozen)
+
+	readHeader, err := db.ReadHeader(header.Hash)
+	require.NoError(t, err)
+	require.Equal(t, header.Hash, readHeader.ComputeHash().Hash)
+
+	readBody, err := db.ReadBody(header.Hash)
+	require.NoError(t, err)
+	require.Len(t, readBody.Transactions, 1)
+	require.Equal(t, tx.Hash, readBody.Transactions[0].Hash)
+
+	readReceipts, err := db.ReadReceipts(header.Hash)
+	require.NoError(t, err)
+	require.Len(t, readReceipts, 1)
+	require.Equal(t, uint64(21000), readReceipts[0].CumulativeGasUsed)
+
+	// the blocks which were never stored are still not found
+	_, err = db.ReadHeader(types.StringToHash("0x1"))
+	require.ErrorIs(t, err, storage.ErrNotFound)
+}
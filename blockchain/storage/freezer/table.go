@@ -0,0 +1,169 @@
+package freezer
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// indexEntrySize is the size of the index entry holding the end offset of the item in the data file
+const indexEntrySize = 8
+
+var errItemOutOfBounds = errors.New("item out of bounds")
+
+// table is the append-only store of the items of one kind, numbered from zero.
+// The items are kept in the data file, and the index file holds the end offset of each item
+type table struct {
+	data  *os.File
+	index *os.File
+
+	items uint64
+	// size is the size of the data file holding the items
+	size uint64
+}
+
+func openTable(dir, name string) (*table, error) {
+	data, err := os.OpenFile(filepath.Join(dir, name+".dat"), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := os.OpenFile(filepath.Join(dir, name+".idx"), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		_ = data.Close()
+
+		return nil, err
+	}
+
+	t := &table{data: data, index: index}
+
+	if err := t.repair(); err != nil {
+		_ = t.close()
+
+		return nil, fmt.Errorf("table %s: %w", name, err)
+	}
+
+	return t, nil
+}
+
+// repair drops the partially written item left by the interrupted append
+func (t *table) repair() error {
+	indexStat, err := t.index.Stat()
+	if err != nil {
+		return err
+	}
+
+	dataStat, err := t.data.Stat()
+	if err != nil {
+		return err
+	}
+
+	items := uint64(indexStat.Size()) / indexEntrySize
+
+	// the items whose data wasn't written entirely are dropped
+	for ; items > 0; items-- {
+		end, err := t.offset(items)
+		if err != nil {
+			return err
+		}
+
+		if end <= uint64(dataStat.Size()) {
+			break
+		}
+	}
+
+	return t.truncate(items)
+}
+
+// offset returns the end offset of the given number of the items
+func (t *table) offset(items uint64) (uint64, error) {
+	if items == 0 {
+		return 0, nil
+	}
+
+	var entry [indexEntrySize]byte
+	if _, err := t.index.ReadAt(entry[:], int64((items-1)*indexEntrySize)); err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint64(entry[:]), nil
+}
+
+// truncate drops the items past the given number of the items
+func (t *table) truncate(items uint64) error {
+	size, err := t.offset(items)
+	if err != nil {
+		return err
+	}
+
+	if err := t.index.Truncate(int64(items * indexEntrySize)); err != nil {
+		return err
+	}
+
+	if err := t.data.Truncate(int64(size)); err != nil {
+		return err
+	}
+
+	t.items, t.size = items, size
+
+	return nil
+}
+
+// append writes the next item, which is readable once it is written
+func (t *table) append(item []byte) error {
+	if _, err := t.data.WriteAt(item, int64(t.size)); err != nil {
+		return err
+	}
+
+	var entry [indexEntrySize]byte
+
+	binary.BigEndian.PutUint64(entry[:], t.size+uint64(len(item)))
+
+	if _, err := t.index.WriteAt(entry[:], int64(t.items*indexEntrySize)); err != nil {
+		return err
+	}
+
+	t.items++
+	t.size += uint64(len(item))
+
+	return nil
+}
+
+// retrieve reads the item with the given number
+func (t *table) retrieve(number uint64) ([]byte, error) {
+	if number >= t.items {
+		return nil, errItemOutOfBounds
+	}
+
+	start, err := t.offset(number)
+	if err != nil {
+		return nil, err
+	}
+
+	end, err := t.offset(number + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	item := make([]byte, end-start)
+	if _, err := t.data.ReadAt(item, int64(start)); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (t *table) sync() error {
+	if err := t.data.Sync(); err != nil {
+		return err
+	}
+
+	return t.index.Sync()
+}
+
+func (t *table) close() error {
+	return errors.Join(t.data.Close(), t.index.Close())
+}
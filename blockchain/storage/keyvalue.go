@@ -39,6 +39,15 @@ var (
 
 	// TX_LOOKUP_PREFIX is the prefix for transaction lookups
 	TX_LOOKUP_PREFIX = []byte("l")
+
+	// BLOOM_BITS is the prefix for the bloom bits index of the log blooms
+	BLOOM_BITS = []byte("i")
+
+	// TAIL is the prefix for the first blocks whose pruned data is retained
+	TAIL = []byte("t")
+
+	// ANCIENT is the prefix for the numbers of the blocks moved to the ancient store
+	ANCIENT = []byte("a")
 )
 
 // Sub-prefixes
@@ -46,8 +55,36 @@ var (
 	HASH   = []byte("hash")
 	NUMBER = []byte("number")
 	EMPTY  = []byte("empty")
+
+	SECTIONS = []byte("sections")
+	FROZEN   = []byte("frozen")
 )
 
+// Tables of the ancient store
+const (
+	AncientHeaders  = "headers"
+	AncientBodies   = "bodies"
+	AncientReceipts = "receipts"
+)
+
+// AncientTables are the tables of the ancient store, holding the data of the frozen blocks
+var AncientTables = []string{AncientHeaders, AncientBodies, AncientReceipts}
+
+// ancientTablesByPrefix are the ancient tables holding the data of the key-value store prefixes
+var ancientTablesByPrefix = map[string]string{
+	string(HEADER):   AncientHeaders,
+	string(BODY):     AncientBodies,
+	string(RECEIPTS): AncientReceipts,
+}
+
+// AncientStore is the append-only store of the immutable data of the ancient blocks
+type AncientStore interface {
+	// Ancient returns the item of the frozen block from the table
+	Ancient(table string, number uint64) ([]byte, bool)
+	// Frozen returns the number of the frozen blocks
+	Frozen() uint64
+}
+
 // KV is a key value storage interface.
 //
 // KV = Key-Value
@@ -62,12 +99,21 @@ type KeyValueStorage struct {
 	logger hclog.Logger
 	db     KV
 	Db     KV
+
+	// ancients holds the headers, the bodies and the receipts of the frozen blocks, if set
+	ancients AncientStore
 }
 
 func NewKeyValueStorage(logger hclog.Logger, db KV) Storage {
 	return &KeyValueStorage{logger: logger, db: db}
 }
 
+// NewKeyValueStorageWithAncients creates the storage which reads the data of the frozen blocks
+// from the ancient store
+func NewKeyValueStorageWithAncients(logger hclog.Logger, db KV, ancients AncientStore) Storage {
+	return &KeyValueStorage{logger: logger, db: db, ancients: ancients}
+}
+
 // -- canonical hash --
 
 // ReadCanonicalHash gets the hash from the number of the canonical chain
@@ -191,18 +237,99 @@ func (s *KeyValueStorage) ReadTxLookup(hash types.Hash) (types.Hash, bool) {
 	return types.BytesToHash(blockHash), true
 }
 
+// TAIL //
+
+// ReadReceiptsTail returns the number of the first block whose receipts are retained
+func (s *KeyValueStorage) ReadReceiptsTail() (uint64, bool) {
+	return s.readTail(RECEIPTS)
+}
+
+// ReadTxLookupTail returns the number of the first block whose transaction lookups are retained
+func (s *KeyValueStorage) ReadTxLookupTail() (uint64, bool) {
+	return s.readTail(TX_LOOKUP_PREFIX)
+}
+
+func (s *KeyValueStorage) readTail(k []byte) (uint64, bool) {
+	data, ok := s.get(TAIL, k)
+	if !ok || len(data) != 8 {
+		return 0, false
+	}
+
+	return common.EncodeBytesToUint64(data), true
+}
+
+// ANCIENT //
+
+// ReadFrozenBlocks returns the number of the blocks whose data was moved to the ancient store
+func (s *KeyValueStorage) ReadFrozenBlocks() (uint64, bool) {
+	data, ok := s.get(ANCIENT, FROZEN)
+	if !ok || len(data) != 8 {
+		return 0, false
+	}
+
+	return common.EncodeBytesToUint64(data), true
+}
+
+// readAncient reads the data of the frozen block from the ancient store
+func (s *KeyValueStorage) readAncient(p, k []byte) ([]byte, bool) {
+	if s.ancients == nil {
+		return nil, false
+	}
+
+	table, ok := ancientTablesByPrefix[string(p)]
+	if !ok {
+		return nil, false
+	}
+
+	data, ok := s.get(ANCIENT, k)
+	if !ok || len(data) != 8 {
+		return nil, false
+	}
+
+	return s.ancients.Ancient(table, common.EncodeBytesToUint64(data))
+}
+
+// BLOOM BITS //
+
+// ReadBloomBits reads the bit vector of the bloom bit in the section of the bloom bits index
+func (s *KeyValueStorage) ReadBloomBits(bit uint, section uint64) ([]byte, bool) {
+	return s.get(BLOOM_BITS, BloomBitsKey(bit, section))
+}
+
+// ReadBloomBitsSections reads the number of the sections of the bloom bits index
+func (s *KeyValueStorage) ReadBloomBitsSections() (uint64, bool) {
+	data, ok := s.get(BLOOM_BITS, SECTIONS)
+	if !ok || len(data) != 8 {
+		return 0, false
+	}
+
+	return common.EncodeBytesToUint64(data), true
+}
+
+// BloomBitsKey returns the key of the bit vector of the bloom bit in the section
+func BloomBitsKey(bit uint, section uint64) []byte {
+	key := make([]byte, 10)
+	key[0] = byte(bit >> 8)
+	key[1] = byte(bit)
+	copy(key[2:], common.EncodeUint64ToBytes(section))
+
+	return key
+}
+
 var ErrNotFound = fmt.Errorf("not found")
 
 func (s *KeyValueStorage) readRLP(p, k []byte, raw types.RLPUnmarshaler) error {
-	p = append(p, k...)
-	data, ok, err := s.db.Get(p)
+	data, ok, err := s.db.Get(append(append(make([]byte, 0, len(p)+len(k)), p...), k...))
 
 	if err != nil {
 		return err
 	}
 
 	if !ok {
-		return ErrNotFound
+		// the data of the frozen blocks is deleted from the key-value store
+		if data, ok = s.readAncient(p, k); !ok {
+			return ErrNotFound
+		}
 	}
 
 	if obj, ok := raw.(types.RLPStoreUnmarshaler); ok {
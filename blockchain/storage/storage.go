@@ -26,6 +26,14 @@ type Storage interface {
 
 	ReadTxLookup(hash types.Hash) (types.Hash, bool)
 
+	ReadBloomBits(bit uint, section uint64) ([]byte, bool)
+	ReadBloomBitsSections() (uint64, bool)
+
+	ReadReceiptsTail() (uint64, bool)
+	ReadTxLookupTail() (uint64, bool)
+
+	ReadFrozenBlocks() (uint64, bool)
+
 	NewBatch() Batch
 
 	Close() error
@@ -49,6 +49,9 @@ func TestStorage(t *testing.T, m PlaceholderStorage) {
 	t.Run("testReceipts", func(t *testing.T) {
 		testReceipts(t, m)
 	})
+	t.Run("testPruneHistory", func(t *testing.T) {
+		testPruneHistory(t, m)
+	})
 }
 
 func testCanonicalChain(t *testing.T, m PlaceholderStorage) {
@@ -394,6 +397,52 @@ func testReceipts(t *testing.T, m PlaceholderStorage) {
 	assert.True(t, reflect.DeepEqual(receipts, found))
 }
 
+func testPruneHistory(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	var (
+		blockHash = types.StringToHash("1")
+		txHash    = types.StringToHash("2")
+	)
+
+	_, ok := s.ReadReceiptsTail()
+	require.False(t, ok)
+
+	_, ok = s.ReadTxLookupTail()
+	require.False(t, ok)
+
+	batch := NewBatchWriter(s)
+	batch.PutReceipts(blockHash, []*types.Receipt{{TxHash: txHash, Logs: []*types.Log{}}})
+	batch.PutTxLookup(txHash, blockHash)
+
+	require.NoError(t, batch.WriteBatch())
+
+	batch = NewBatchWriter(s)
+	batch.DeleteReceipts(blockHash)
+	batch.DeleteTxLookup(txHash)
+	batch.PutReceiptsTail(2)
+	batch.PutTxLookupTail(3)
+
+	require.NoError(t, batch.WriteBatch())
+
+	_, err := s.ReadReceipts(blockHash)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, ok = s.ReadTxLookup(txHash)
+	require.False(t, ok)
+
+	tail, ok := s.ReadReceiptsTail()
+	require.True(t, ok)
+	require.Equal(t, uint64(2), tail)
+
+	tail, ok = s.ReadTxLookupTail()
+	require.True(t, ok)
+	require.Equal(t, uint64(3), tail)
+}
+
 func testWriteCanonicalHeader(t *testing.T, m PlaceholderStorage) {
 	t.Helper()
 
@@ -460,21 +509,29 @@ type readBodyDelegate func(types.Hash) (*types.Body, error)
 type readSnapshotDelegate func(types.Hash) ([]byte, bool)
 type readReceiptsDelegate func(types.Hash) ([]*types.Receipt, error)
 type readTxLookupDelegate func(types.Hash) (types.Hash, bool)
+type readBloomBitsDelegate func(uint, uint64) ([]byte, bool)
+type readBloomBitsSectionsDelegate func() (uint64, bool)
+type readTailDelegate func() (uint64, bool)
 type closeDelegate func() error
 type newBatchDelegate func() Batch
 
 type MockStorage struct {
-	readCanonicalHashFn   readCanonicalHashDelegate
-	readHeadHashFn        readHeadHashDelegate
-	readHeadNumberFn      readHeadNumberDelegate
-	readForksFn           readForksDelegate
-	readTotalDifficultyFn readTotalDifficultyDelegate
-	readHeaderFn          readHeaderDelegate
-	readBodyFn            readBodyDelegate
-	readReceiptsFn        readReceiptsDelegate
-	readTxLookupFn        readTxLookupDelegate
-	closeFn               closeDelegate
-	newBatchFn            newBatchDelegate
+	readCanonicalHashFn     readCanonicalHashDelegate
+	readHeadHashFn          readHeadHashDelegate
+	readHeadNumberFn        readHeadNumberDelegate
+	readForksFn             readForksDelegate
+	readTotalDifficultyFn   readTotalDifficultyDelegate
+	readHeaderFn            readHeaderDelegate
+	readBodyFn              readBodyDelegate
+	readReceiptsFn          readReceiptsDelegate
+	readTxLookupFn          readTxLookupDelegate
+	readBloomBitsFn         readBloomBitsDelegate
+	readBloomBitsSectionsFn readBloomBitsSectionsDelegate
+	readReceiptsTailFn      readTailDelegate
+	readTxLookupTailFn      readTailDelegate
+	readFrozenBlocksFn      readTailDelegate
+	closeFn                 closeDelegate
+	newBatchFn              newBatchDelegate
 }
 
 func NewMockStorage() *MockStorage {
@@ -589,6 +646,66 @@ func (m *MockStorage) HookReadTxLookup(fn readTxLookupDelegate) {
 	m.readTxLookupFn = fn
 }
 
+func (m *MockStorage) ReadBloomBits(bit uint, section uint64) ([]byte, bool) {
+	if m.readBloomBitsFn != nil {
+		return m.readBloomBitsFn(bit, section)
+	}
+
+	return nil, false
+}
+
+func (m *MockStorage) HookReadBloomBits(fn readBloomBitsDelegate) {
+	m.readBloomBitsFn = fn
+}
+
+func (m *MockStorage) ReadBloomBitsSections() (uint64, bool) {
+	if m.readBloomBitsSectionsFn != nil {
+		return m.readBloomBitsSectionsFn()
+	}
+
+	return 0, false
+}
+
+func (m *MockStorage) HookReadBloomBitsSections(fn readBloomBitsSectionsDelegate) {
+	m.readBloomBitsSectionsFn = fn
+}
+
+func (m *MockStorage) ReadReceiptsTail() (uint64, bool) {
+	if m.readReceiptsTailFn != nil {
+		return m.readReceiptsTailFn()
+	}
+
+	return 0, false
+}
+
+func (m *MockStorage) HookReadReceiptsTail(fn readTailDelegate) {
+	m.readReceiptsTailFn = fn
+}
+
+func (m *MockStorage) ReadTxLookupTail() (uint64, bool) {
+	if m.readTxLookupTailFn != nil {
+		return m.readTxLookupTailFn()
+	}
+
+	return 0, false
+}
+
+func (m *MockStorage) HookReadTxLookupTail(fn readTailDelegate) {
+	m.readTxLookupTailFn = fn
+}
+
+func (m *MockStorage) ReadFrozenBlocks() (uint64, bool) {
+	if m.readFrozenBlocksFn != nil {
+		return m.readFrozenBlocksFn()
+	}
+
+	return 0, false
+}
+
+func (m *MockStorage) HookReadFrozenBlocks(fn readTailDelegate) {
+	m.readFrozenBlocksFn = fn
+}
+
 func (m *MockStorage) Close() error {
 	if m.closeFn != nil {
 		return m.closeFn()
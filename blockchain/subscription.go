@@ -33,10 +33,95 @@ func (m *MockSubscription) Push(e *Event) {
 	m.updateCh <- e
 }
 
-// subscription is the Blockchain event subscription object
+// subscription is the Blockchain event subscription object.
+// The events are queued by the subscription and delivered in the order they are pushed,
+// so the slow subscriber doesn't block the chain writes or the other subscribers and misses no events
 type subscription struct {
 	updateCh chan *Event // Channel for update information
 	closeCh  chan void   // Channel for close signals
+
+	queueLock sync.Mutex
+	queue     []*Event  // events not delivered yet
+	queueCh   chan void // signals the new event in the queue
+	doneCh    chan void // closed once the events are not delivered to updateCh anymore
+}
+
+func newSubscription() *subscription {
+	sub := &subscription{
+		updateCh: make(chan *Event),
+		closeCh:  make(chan void),
+		queueCh:  make(chan void, 1),
+		doneCh:   make(chan void),
+	}
+
+	go sub.run()
+
+	return sub
+}
+
+// enqueue adds the event to the queue of the subscription without blocking
+func (s *subscription) enqueue(event *Event) {
+	s.queueLock.Lock()
+	s.queue = append(s.queue, event)
+	s.queueLock.Unlock()
+
+	select {
+	case s.queueCh <- void{}:
+	default:
+	}
+}
+
+// run delivers the queued events one by one until the subscription is closed
+func (s *subscription) run() {
+	defer close(s.doneCh)
+
+	for {
+		s.queueLock.Lock()
+
+		if len(s.queue) == 0 {
+			s.queueLock.Unlock()
+
+			select {
+			case <-s.queueCh:
+				continue
+			case <-s.closeCh:
+				return
+			}
+		}
+
+		event := s.queue[0]
+		s.queue[0] = nil
+		s.queue = s.queue[1:]
+
+		s.queueLock.Unlock()
+
+		select {
+		case s.updateCh <- event:
+		case <-s.closeCh:
+			// the undelivered event is put back, so it is returned by GetEvent in the right order
+			s.queueLock.Lock()
+			s.queue = append([]*Event{event}, s.queue...)
+			s.queueLock.Unlock()
+
+			return
+		}
+	}
+}
+
+// dequeue removes the first event from the queue, it returns nil if the queue is empty
+func (s *subscription) dequeue() *Event {
+	s.queueLock.Lock()
+	defer s.queueLock.Unlock()
+
+	if len(s.queue) == 0 {
+		return nil
+	}
+
+	event := s.queue[0]
+	s.queue[0] = nil
+	s.queue = s.queue[1:]
+
+	return event
 }
 
 // GetEventCh creates a new event channel, and returns it
@@ -44,14 +129,21 @@ func (s *subscription) GetEventCh() chan *Event {
 	return s.updateCh
 }
 
-// GetEvent returns the event from the subscription (BLOCKING)
+// GetEvent returns the event from the subscription (BLOCKING).
+// Once the subscription is closed, it returns the events pushed before and then nil
 func (s *subscription) GetEvent() *Event {
 	// Wait for an update
 	select {
 	case ev := <-s.updateCh:
 		return ev
 	case <-s.closeCh:
-		return nil
+		if s.doneCh == nil {
+			return nil
+		}
+
+		<-s.doneCh
+
+		return s.dequeue()
 	}
 }
 
@@ -65,12 +157,17 @@ const (
 
 // Event is the blockchain event that gets passed to the listeners
 type Event struct {
-	// Old chain (removed headers) if there was a reorg
+	// Old chain (removed headers) if there was a reorg, in the ascending order.
+	// For the fork event, it holds the header of the fork
 	OldChain []*types.Header
 
-	// New part of the chain (or a fork)
+	// New part of the canonical chain in the ascending order, the last header is the new head
 	NewChain []*types.Header
 
+	// Finalized is the number of the latest finalized block. The supported consensus engines have
+	// the instant finality, so every block of the canonical chain is finalized once it is written
+	Finalized uint64
+
 	// Difficulty is the new difficulty created with this event
 	Difficulty *big.Int
 
@@ -146,10 +243,7 @@ func newEventStream() *eventStream {
 
 // subscribe creates a new blockchain event subscription
 func (e *eventStream) subscribe() *subscription {
-	sub := &subscription{
-		updateCh: make(chan *Event, 5),
-		closeCh:  make(chan void),
-	}
+	sub := newSubscription()
 
 	e.Lock()
 	e.subscriptions[sub] = struct{}{}
@@ -166,13 +260,14 @@ func (e *eventStream) unsubscribe(sub *subscription) {
 	close(sub.closeCh)
 }
 
-// push adds a new Event, and notifies listeners
+// push adds a new Event, and notifies listeners.
+// The events are pushed by the chain writes holding the write lock, so they are queued in the order of the writes
 func (e *eventStream) push(event *Event) {
 	e.RLock()
 	defer e.RUnlock()
 
 	// Notify the listeners
 	for sub := range e.subscriptions {
-		sub.updateCh <- event
+		sub.enqueue(event)
 	}
 }
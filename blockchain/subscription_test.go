@@ -206,3 +206,31 @@ func TestSubscription_NilEventAfterClosingSubscription(t *testing.T) {
 
 	assert.Equal(t, 2, receivedEvtCount)
 }
+
+func TestSubscription_OrderedDeliveryToSlowSubscriber(t *testing.T) {
+	t.Parallel()
+
+	var (
+		e           = newEventStream()
+		sub         = e.subscribe()
+		numOfEvents = 100
+	)
+
+	// the events are queued without blocking the stream while nobody reads them
+	for i := 0; i < numOfEvents; i++ {
+		e.push(&Event{NewChain: []*types.Header{{Number: uint64(i)}}})
+	}
+
+	for i := 0; i < numOfEvents/2; i++ {
+		assert.Equal(t, uint64(i), sub.GetEvent().Header().Number)
+	}
+
+	// the events pushed before closing the subscription are still returned
+	e.unsubscribe(sub)
+
+	for i := numOfEvents / 2; i < numOfEvents; i++ {
+		assert.Equal(t, uint64(i), sub.GetEvent().Header().Number)
+	}
+
+	assert.Nil(t, sub.GetEvent())
+}
@@ -42,6 +42,46 @@ type Chain struct {
 	Bootnodes []string `json:"bootnodes,omitempty"`
 }
 
+// FeeParams are the EIP-1559 parameters of the block
+type FeeParams struct {
+	// BaseFeeEM is the elasticity multiplier, i.e. the ratio of the block gas limit to the gas target
+	BaseFeeEM uint64
+	// BaseFeeChangeDenom bounds the amount the base fee can change between the blocks
+	BaseFeeChangeDenom uint64
+	// MinBaseFee is the floor of the base fee
+	MinBaseFee uint64
+}
+
+// FeeParamsAt returns the EIP-1559 parameters of the block set in the genesis,
+// unless they are overridden by the forks active at the block
+func (c *Chain) FeeParamsAt(block uint64) FeeParams {
+	fee := FeeParams{
+		BaseFeeEM:          c.Genesis.BaseFeeEM,
+		BaseFeeChangeDenom: c.Genesis.BaseFeeChangeDenom,
+		MinBaseFee:         c.Genesis.MinBaseFee,
+	}
+
+	if c.Params == nil || c.Params.Forks == nil {
+		return fee
+	}
+
+	params := c.Params.Forks.ParamsAt(block)
+
+	if params.BaseFeeEM != nil {
+		fee.BaseFeeEM = *params.BaseFeeEM
+	}
+
+	if params.BaseFeeChangeDenom != nil {
+		fee.BaseFeeChangeDenom = *params.BaseFeeChangeDenom
+	}
+
+	if params.MinBaseFee != nil {
+		fee.MinBaseFee = *params.MinBaseFee
+	}
+
+	return fee
+}
+
 // Genesis specifies the header fields, state of a genesis block
 type Genesis struct {
 	Nonce      [8]byte                           `json:"nonce"`
@@ -58,6 +98,9 @@ type Genesis struct {
 	// BaseFeeChangeDenom is the value to bound the amount the base fee can change between blocks
 	BaseFeeChangeDenom uint64 `json:"baseFeeChangeDenom,omitempty"`
 
+	// MinBaseFee is the floor of the base fee
+	MinBaseFee uint64 `json:"minBaseFee,omitempty"`
+
 	// Override
 	StateRoot types.Hash
 
@@ -130,6 +173,7 @@ func (g *Genesis) MarshalJSON() ([]byte, error) {
 		BaseFee            *string                     `json:"baseFee"`
 		BaseFeeEM          *string                     `json:"baseFeeEM"`
 		BaseFeeChangeDenom *string                     `json:"baseFeeChangeDenom"`
+		MinBaseFee         *string                     `json:"minBaseFee,omitempty"`
 	}
 
 	var enc Genesis
@@ -144,6 +188,10 @@ func (g *Genesis) MarshalJSON() ([]byte, error) {
 	enc.BaseFeeEM = common.EncodeUint64(g.BaseFeeEM)
 	enc.BaseFeeChangeDenom = common.EncodeUint64(g.BaseFeeChangeDenom)
 
+	if g.MinBaseFee != 0 {
+		enc.MinBaseFee = common.EncodeUint64(g.MinBaseFee)
+	}
+
 	enc.Mixhash = g.Mixhash
 	enc.Coinbase = g.Coinbase
 
@@ -180,6 +228,7 @@ func (g *Genesis) UnmarshalJSON(data []byte) error {
 		BaseFee            *string                    `json:"baseFee"`
 		BaseFeeEM          *string                    `json:"baseFeeEM"`
 		BaseFeeChangeDenom *string                    `json:"baseFeeChangeDenom"`
+		MinBaseFee         *string                    `json:"minBaseFee"`
 	}
 
 	var dec Genesis
@@ -241,6 +290,11 @@ func (g *Genesis) UnmarshalJSON(data []byte) error {
 		parseError("baseFeeChangeDenom", subErr)
 	}
 
+	g.MinBaseFee, subErr = common.ParseUint64orHex(dec.MinBaseFee)
+	if subErr != nil {
+		parseError("minBaseFee", subErr)
+	}
+
 	if dec.Mixhash != nil {
 		g.Mixhash = *dec.Mixhash
 	}
@@ -131,6 +131,18 @@ func TestGenesisX(t *testing.T) {
 				},
 			},
 		},
+		{
+			input: `{
+				"gasLimit": "0x11",
+				"baseFee": "0x64",
+				"minBaseFee": "0x0a"
+			}`,
+			output: &Genesis{
+				GasLimit:   17,
+				BaseFee:    100,
+				MinBaseFee: 10,
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -1,8 +1,13 @@
 package chain
 
 import (
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math/big"
 	"sort"
+	"strings"
 
 	"github.com/0xPolygon/polygon-edge/forkmanager"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -13,6 +18,21 @@ var (
 	ErrBurnContractAddressMissing = errors.New("burn contract address missing")
 )
 
+const (
+	// DefaultMaxCodeSize is the mainnet limit of the deployed contract code size (EIP-170)
+	DefaultMaxCodeSize = 24576
+	// DefaultMaxInitCodeSize is the mainnet limit of the init code size (EIP-3860)
+	DefaultMaxInitCodeSize = 2 * DefaultMaxCodeSize
+)
+
+// CodeSizeLimits are the contract code size limits of the block
+type CodeSizeLimits struct {
+	// MaxCodeSize is the maximum size of the deployed contract code
+	MaxCodeSize uint64
+	// MaxInitCodeSize is the maximum size of the init code of the contract creation transaction
+	MaxInitCodeSize uint64
+}
+
 // Params are all the set of params for the chain
 type Params struct {
 	Forks          *Forks                 `json:"forks"`
@@ -28,10 +48,22 @@ type Params struct {
 	BridgeAllowList           *AddressListConfig `json:"bridgeAllowList,omitempty"`
 	BridgeBlockList           *AddressListConfig `json:"bridgeBlockList,omitempty"`
 
+	// PaymasterAllowList lists the paymaster contracts sponsoring the transactions with zero gas price
+	PaymasterAllowList *AddressListConfig `json:"paymasterAllowList,omitempty"`
+
+	// TxPolicy denies the transactions calling the listed function selectors or transferring too much value
+	TxPolicy *TxPolicyConfig `json:"txPolicy,omitempty"`
+
+	// StatefulPrecompiles are the custom precompiled contracts with the access to their own state
+	StatefulPrecompiles []*StatefulPrecompileConfig `json:"statefulPrecompiles,omitempty"`
+
 	// Governance contract where the token will be sent to and burn in london fork
 	BurnContract map[uint64]types.Address `json:"burnContract"`
 	// Destination address to initialize default burn contract with
 	BurnContractDestinationAddress types.Address `json:"burnContractDestinationAddress,omitempty"`
+
+	// FeeDistribution splits the transaction fees between the block proposer, the staker reward pool and the treasury
+	FeeDistribution *FeeDistribution `json:"feeDistribution,omitempty"`
 }
 
 type AddressListConfig struct {
@@ -42,6 +74,124 @@ type AddressListConfig struct {
 	EnabledAddresses []types.Address `json:"enabledAddresses,omitempty"`
 }
 
+// TxPolicyConfig is the initial config of the transaction policy contract
+type TxPolicyConfig struct {
+	// AdminAddresses is the list of the admins updating the policy
+	AdminAddresses []types.Address `json:"adminAddresses,omitempty"`
+
+	// DeniedSelectors is the list of the initially denied function selectors
+	DeniedSelectors []*DeniedSelector `json:"deniedSelectors,omitempty"`
+
+	// MaxValue is the maximum value transferred by a transaction, unlimited if zero
+	MaxValue *big.Int `json:"maxValue,omitempty"`
+}
+
+// DeniedSelector is the function selector denied on the target contract,
+// or on every contract if the target is the zero address
+type DeniedSelector struct {
+	Target   types.Address    `json:"target"`
+	Selector FunctionSelector `json:"selector"`
+}
+
+// FunctionSelector is the first 4 bytes of the call input identifying the contract function
+type FunctionSelector [4]byte
+
+func (s FunctionSelector) MarshalText() ([]byte, error) {
+	return []byte("0x" + hex.EncodeToString(s[:])), nil
+}
+
+func (s *FunctionSelector) UnmarshalText(input []byte) error {
+	raw, err := hex.DecodeString(strings.TrimPrefix(string(input), "0x"))
+	if err != nil {
+		return err
+	}
+
+	if len(raw) != len(s) {
+		return fmt.Errorf("invalid function selector %s, expected 4 bytes", input)
+	}
+
+	copy(s[:], raw)
+
+	return nil
+}
+
+// StatefulPrecompileConfig is the config of the custom precompiled contract
+type StatefulPrecompileConfig struct {
+	// Name is the name of the contract implementation
+	Name string `json:"name"`
+
+	// Address is the address the contract is placed at
+	Address types.Address `json:"address"`
+
+	// Fork is the name of the fork activating the contract
+	Fork string `json:"fork"`
+
+	// Config is the config specific to the contract implementation
+	Config json.RawMessage `json:"config,omitempty"`
+}
+
+// FeeShareDenominator is the denominator of the fee shares, i.e. the shares are set in basis points
+const FeeShareDenominator = 10000
+
+// FeeDistribution is the config of the fee sharing between the block proposer,
+// the staker reward pool and the treasury
+type FeeDistribution struct {
+	// Block is the block number from which the fees are shared
+	Block uint64 `json:"block"`
+
+	// ProposerShare is the share of the fees paid to the block proposer
+	ProposerShare uint64 `json:"proposerShare"`
+
+	// StakerPoolShare is the share of the fees paid to the staker reward pool
+	StakerPoolShare uint64 `json:"stakerPoolShare"`
+
+	// TreasuryShare is the share of the fees paid to the treasury
+	TreasuryShare uint64 `json:"treasuryShare"`
+
+	// StakerPool is the address of the staker reward pool
+	StakerPool types.Address `json:"stakerPool"`
+
+	// Treasury is the address of the treasury
+	Treasury types.Address `json:"treasury"`
+
+	// IncludeBaseFee shares the base fees as well, instead of sending them to the burn contract
+	IncludeBaseFee bool `json:"includeBaseFee,omitempty"`
+}
+
+// Validate checks that the shares add up to the denominator and that the addresses receiving them are set
+func (d *FeeDistribution) Validate() error {
+	if total := d.ProposerShare + d.StakerPoolShare + d.TreasuryShare; total != FeeShareDenominator {
+		return fmt.Errorf("fee shares have to add up to %d, got %d", FeeShareDenominator, total)
+	}
+
+	if d.StakerPoolShare > 0 && d.StakerPool == types.ZeroAddress {
+		return errors.New("staker reward pool address is not set")
+	}
+
+	if d.TreasuryShare > 0 && d.Treasury == types.ZeroAddress {
+		return errors.New("treasury address is not set")
+	}
+
+	return nil
+}
+
+// Split splits the fee into the shares of the proposer, the staker reward pool and the treasury.
+// The remainder of the rounding goes to the proposer, so that the shares always add up to the fee
+func (d *FeeDistribution) Split(fee *big.Int) (proposer, stakerPool, treasury *big.Int) {
+	denominator := big.NewInt(FeeShareDenominator)
+
+	stakerPool = new(big.Int).Mul(fee, new(big.Int).SetUint64(d.StakerPoolShare))
+	stakerPool.Div(stakerPool, denominator)
+
+	treasury = new(big.Int).Mul(fee, new(big.Int).SetUint64(d.TreasuryShare))
+	treasury.Div(treasury, denominator)
+
+	proposer = new(big.Int).Sub(fee, stakerPool)
+	proposer.Sub(proposer, treasury)
+
+	return proposer, stakerPool, treasury
+}
+
 // CalculateBurnContract calculates burn contract address for the given block number
 func (p *Params) CalculateBurnContract(block uint64) (types.Address, error) {
 	blocks := make([]uint64, 0, len(p.BurnContract))
@@ -67,6 +217,16 @@ func (p *Params) CalculateBurnContract(block uint64) (types.Address, error) {
 	return p.BurnContract[blocks[len(blocks)-1]], nil
 }
 
+// FeeDistributionAt returns the fee distribution active at the given block number,
+// or nil if the fees are paid to the block proposer
+func (p *Params) FeeDistributionAt(block uint64) *FeeDistribution {
+	if p.FeeDistribution == nil || block < p.FeeDistribution.Block {
+		return nil
+	}
+
+	return p.FeeDistribution
+}
+
 func (p *Params) GetEngine() string {
 	// We know there is already one
 	for k := range p.Engine {
@@ -90,6 +250,12 @@ const (
 	QuorumCalcAlignment = "quorumcalcalignment"
 	TxHashWithType      = "txHashWithType"
 	LondonFix           = "londonfix"
+	EIP3855             = "EIP3855"
+	EIP1153             = "EIP1153"
+	EIP5656             = "EIP5656"
+	StateSyncProof      = "stateSyncProof"
+	EIP2537             = "EIP2537"
+	RIP7212             = "RIP7212"
 )
 
 // Forks is map which contains all forks and their starting blocks from genesis
@@ -128,9 +294,63 @@ func (f *Forks) At(block uint64) ForksInTime {
 		QuorumCalcAlignment: f.IsActive(QuorumCalcAlignment, block),
 		TxHashWithType:      f.IsActive(TxHashWithType, block),
 		LondonFix:           f.IsActive(LondonFix, block),
+		EIP3855:             f.IsActive(EIP3855, block),
+		EIP1153:             f.IsActive(EIP1153, block),
+		EIP5656:             f.IsActive(EIP5656, block),
+		StateSyncProof:      f.IsActive(StateSyncProof, block),
+		EIP2537:             f.IsActive(EIP2537, block),
+		RIP7212:             f.IsActive(RIP7212, block),
 	}
 }
 
+// ParamsAt returns the params of the forks active at the block,
+// the params of the later forks overriding the ones of the earlier forks
+func (f *Forks) ParamsAt(block uint64) *forkmanager.ForkParams {
+	names := make([]string, 0, len(*f))
+
+	for name, fork := range *f {
+		if fork.Params != nil && fork.Active(block) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		if (*f)[names[i]].Block != (*f)[names[j]].Block {
+			return (*f)[names[i]].Block < (*f)[names[j]].Block
+		}
+
+		return names[i] < names[j]
+	})
+
+	params := &forkmanager.ForkParams{}
+	for _, name := range names {
+		params.Merge((*f)[name].Params)
+	}
+
+	return params
+}
+
+// CodeSizeLimitsAt returns the contract code size limits of the block, the mainnet ones
+// unless they are overridden by the forks active at the block
+func (f *Forks) CodeSizeLimitsAt(block uint64) CodeSizeLimits {
+	limits := CodeSizeLimits{
+		MaxCodeSize:     DefaultMaxCodeSize,
+		MaxInitCodeSize: DefaultMaxInitCodeSize,
+	}
+
+	params := f.ParamsAt(block)
+
+	if params.MaxCodeSize != nil {
+		limits.MaxCodeSize = *params.MaxCodeSize
+	}
+
+	if params.MaxInitCodeSize != nil {
+		limits.MaxInitCodeSize = *params.MaxInitCodeSize
+	}
+
+	return limits
+}
+
 // Copy creates a deep copy of Forks map
 func (f Forks) Copy() *Forks {
 	copiedForks := make(Forks, len(f))
@@ -180,7 +400,13 @@ type ForksInTime struct {
 	EIP155,
 	QuorumCalcAlignment,
 	TxHashWithType,
-	LondonFix bool
+	LondonFix,
+	EIP3855,
+	EIP1153,
+	EIP5656,
+	StateSyncProof,
+	EIP2537,
+	RIP7212 bool
 }
 
 // AllForksEnabled should contain all supported forks by current edge version
@@ -197,4 +423,10 @@ var AllForksEnabled = &Forks{
 	QuorumCalcAlignment: NewFork(0),
 	TxHashWithType:      NewFork(0),
 	LondonFix:           NewFork(0),
+	EIP3855:             NewFork(0),
+	EIP1153:             NewFork(0),
+	EIP5656:             NewFork(0),
+	StateSyncProof:      NewFork(0),
+	EIP2537:             NewFork(0),
+	RIP7212:             NewFork(0),
 }
@@ -2,11 +2,13 @@ package chain
 
 import (
 	"encoding/json"
+	"math/big"
 	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/0xPolygon/polygon-edge/forkmanager"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -128,3 +130,87 @@ func TestParams_CalculateBurnContract(t *testing.T) {
 		})
 	}
 }
+
+func TestChain_FeeParamsAt(t *testing.T) {
+	t.Parallel()
+
+	ptr := func(v uint64) *uint64 { return &v }
+
+	chain := &Chain{
+		Genesis: &Genesis{
+			BaseFeeEM:          2,
+			BaseFeeChangeDenom: 8,
+			MinBaseFee:         100,
+		},
+		Params: &Params{
+			Forks: &Forks{
+				London: NewFork(0),
+				"feeA": Fork{Block: 10, Params: &forkmanager.ForkParams{
+					BaseFeeEM:  ptr(4),
+					MinBaseFee: ptr(200),
+				}},
+				"feeB": Fork{Block: 20, Params: &forkmanager.ForkParams{
+					MinBaseFee: ptr(300),
+				}},
+				// the params of the fork activated at the same block as feeB are applied after its params
+				"feeC": Fork{Block: 20, Params: &forkmanager.ForkParams{
+					BaseFeeChangeDenom: ptr(16),
+				}},
+			},
+		},
+	}
+
+	require.Equal(t, FeeParams{BaseFeeEM: 2, BaseFeeChangeDenom: 8, MinBaseFee: 100}, chain.FeeParamsAt(9))
+	require.Equal(t, FeeParams{BaseFeeEM: 4, BaseFeeChangeDenom: 8, MinBaseFee: 200}, chain.FeeParamsAt(10))
+	require.Equal(t, FeeParams{BaseFeeEM: 4, BaseFeeChangeDenom: 16, MinBaseFee: 300}, chain.FeeParamsAt(25))
+}
+
+func TestForks_CodeSizeLimitsAt(t *testing.T) {
+	t.Parallel()
+
+	ptr := func(v uint64) *uint64 { return &v }
+
+	forks := &Forks{
+		London: NewFork(0),
+		"codeA": Fork{Block: 10, Params: &forkmanager.ForkParams{
+			MaxCodeSize: ptr(49152),
+		}},
+		"codeB": Fork{Block: 20, Params: &forkmanager.ForkParams{
+			MaxInitCodeSize: ptr(131072),
+		}},
+	}
+
+	require.Equal(t, CodeSizeLimits{MaxCodeSize: 24576, MaxInitCodeSize: 49152}, forks.CodeSizeLimitsAt(9))
+	require.Equal(t, CodeSizeLimits{MaxCodeSize: 49152, MaxInitCodeSize: 49152}, forks.CodeSizeLimitsAt(10))
+	require.Equal(t, CodeSizeLimits{MaxCodeSize: 49152, MaxInitCodeSize: 131072}, forks.CodeSizeLimitsAt(20))
+}
+
+func TestFeeDistribution(t *testing.T) {
+	t.Parallel()
+
+	distribution := &FeeDistribution{
+		Block:           10,
+		ProposerShare:   3334,
+		StakerPoolShare: 3333,
+		TreasuryShare:   3333,
+		StakerPool:      types.StringToAddress("0x1"),
+		Treasury:        types.StringToAddress("0x2"),
+	}
+	require.NoError(t, distribution.Validate())
+
+	// the rounding remainder goes to the proposer
+	proposer, stakerPool, treasury := distribution.Split(big.NewInt(100))
+	require.Equal(t, big.NewInt(34), proposer)
+	require.Equal(t, big.NewInt(33), stakerPool)
+	require.Equal(t, big.NewInt(33), treasury)
+
+	params := &Params{FeeDistribution: distribution}
+	require.Nil(t, params.FeeDistributionAt(9))
+	require.Equal(t, distribution, params.FeeDistributionAt(10))
+
+	distribution.TreasuryShare = 3334
+	require.ErrorContains(t, distribution.Validate(), "fee shares have to add up to 10000, got 10001")
+
+	distribution.TreasuryShare, distribution.Treasury = 3333, types.ZeroAddress
+	require.ErrorContains(t, distribution.Validate(), "treasury address is not set")
+}
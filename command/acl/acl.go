@@ -0,0 +1,34 @@
+package acl
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command/acl/freeze"
+	"github.com/0xPolygon/polygon-edge/command/acl/role"
+	"github.com/0xPolygon/polygon-edge/command/acl/schedule"
+)
+
+// GetCommand creates "acl" helper command
+func GetCommand() *cobra.Command {
+	aclCmd := &cobra.Command{
+		Use:   "acl",
+		Short: "Top level command for managing the allow and block lists. Only accepts subcommands.",
+	}
+
+	registerSubcommands(aclCmd)
+
+	return aclCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// acl set-role
+		role.GetCommand(),
+		// acl schedule-role
+		schedule.GetCommand(),
+		// acl freeze
+		freeze.GetFreezeCommand(),
+		// acl unfreeze
+		freeze.GetUnfreezeCommand(),
+	)
+}
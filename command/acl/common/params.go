@@ -0,0 +1,199 @@
+package common
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/umbracle/ethgo"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/command/polybftsecrets"
+	rootHelper "github.com/0xPolygon/polygon-edge/command/rootchain/helper"
+	sidechainHelper "github.com/0xPolygon/polygon-edge/command/sidechain"
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/state/runtime/addresslist"
+	"github.com/0xPolygon/polygon-edge/txrelayer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	ListFlag = "list"
+	RoleFlag = "role"
+)
+
+var (
+	// Lists are the address lists by their names
+	Lists = map[string]types.Address{
+		"contract-deployer-allow": contracts.AllowListContractsAddr,
+		"contract-deployer-block": contracts.BlockListContractsAddr,
+		"transactions-allow":      contracts.AllowListTransactionsAddr,
+		"transactions-block":      contracts.BlockListTransactionsAddr,
+		"bridge-allow":            contracts.AllowListBridgeAddr,
+		"bridge-block":            contracts.BlockListBridgeAddr,
+		"paymaster-allow":         contracts.AllowListPaymasterAddr,
+	}
+
+	// Roles are the roles of the address lists by their names
+	Roles = map[string]addresslist.Role{
+		"none":    addresslist.NoRole,
+		"enabled": addresslist.EnabledRole,
+		"admin":   addresslist.AdminRole,
+		"freezer": addresslist.FreezerRole,
+	}
+
+	errUnknownList = errors.New("unknown address list")
+	errUnknownRole = errors.New("unknown role")
+)
+
+// ACLParams are the params shared by the acl commands
+type ACLParams struct {
+	AccountDir    string
+	AccountConfig string
+	PrivateKey    string
+	JSONRPC       string
+	List          string
+}
+
+// RegisterCommonFlags registers the flags shared by the acl commands
+func (p *ACLParams) RegisterCommonFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&p.AccountDir,
+		polybftsecrets.AccountDirFlag,
+		"",
+		polybftsecrets.AccountDirFlagDesc,
+	)
+
+	cmd.Flags().StringVar(
+		&p.AccountConfig,
+		polybftsecrets.AccountConfigFlag,
+		"",
+		polybftsecrets.AccountConfigFlagDesc,
+	)
+
+	cmd.Flags().StringVar(
+		&p.PrivateKey,
+		polybftsecrets.PrivateKeyFlag,
+		"",
+		polybftsecrets.PrivateKeyFlagDesc,
+	)
+
+	cmd.Flags().StringVar(
+		&p.List,
+		ListFlag,
+		"",
+		fmt.Sprintf("name of the address list (%s)", strings.Join(names(Lists), ", ")),
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(polybftsecrets.AccountDirFlag, polybftsecrets.AccountConfigFlag)
+	cmd.MarkFlagsMutuallyExclusive(polybftsecrets.PrivateKeyFlag, polybftsecrets.AccountConfigFlag)
+	cmd.MarkFlagsMutuallyExclusive(polybftsecrets.PrivateKeyFlag, polybftsecrets.AccountDirFlag)
+
+	helper.RegisterJSONRPCFlag(cmd)
+}
+
+// ValidateFlags validates the flags shared by the acl commands
+func (p *ACLParams) ValidateFlags() error {
+	if _, ok := Lists[p.List]; !ok {
+		return fmt.Errorf("%w: %s", errUnknownList, p.List)
+	}
+
+	if p.PrivateKey == "" {
+		if err := sidechainHelper.ValidateSecretFlags(p.AccountDir, p.AccountConfig); err != nil {
+			return err
+		}
+	}
+
+	// validate jsonrpc address
+	_, err := helper.ParseJSONRPCAddress(p.JSONRPC)
+
+	return err
+}
+
+// SendTransaction sends the transaction calling the address list with the given input
+func (p *ACLParams) SendTransaction(input []byte) (*ethgo.Receipt, error) {
+	ecdsaKey, err := rootHelper.GetECDSAKey(p.PrivateKey, p.AccountDir, p.AccountConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	txRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithIPAddress(p.JSONRPC),
+		txrelayer.WithReceiptTimeout(150*time.Millisecond))
+	if err != nil {
+		return nil, fmt.Errorf("could not create tx relayer: %w", err)
+	}
+
+	listAddr := ethgo.Address(Lists[p.List])
+	txn := rootHelper.CreateTransaction(ecdsaKey.Address(), &listAddr, input, nil, true)
+
+	receipt, err := txRelayer.SendTransaction(txn, ecdsaKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if receipt.Status == uint64(types.ReceiptFailed) {
+		return nil, fmt.Errorf("transaction failed on block %d", receipt.BlockNumber)
+	}
+
+	return receipt, nil
+}
+
+// ParseRole returns the role by its name
+func ParseRole(name string) (addresslist.Role, error) {
+	role, ok := Roles[name]
+	if !ok {
+		return addresslist.NoRole, fmt.Errorf("%w: %s, expected one of %s",
+			errUnknownRole, name, strings.Join(names(Roles), ", "))
+	}
+
+	return role, nil
+}
+
+// RoleFlagDesc is the description of the role flag
+func RoleFlagDesc() string {
+	return fmt.Sprintf("role to set (%s)", strings.Join(names(Roles), ", "))
+}
+
+// ACLResult is the result of the acl command
+type ACLResult struct {
+	List        string   `json:"list"`
+	Operation   string   `json:"operation"`
+	Addresses   []string `json:"addresses,omitempty"`
+	BlockNumber uint64   `json:"blockNumber"`
+}
+
+func (r *ACLResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[ACL]\n")
+
+	vals := make([]string, 0, 3+len(r.Addresses))
+	vals = append(vals, fmt.Sprintf("List|%s", r.List))
+	vals = append(vals, fmt.Sprintf("Operation|%s", r.Operation))
+
+	for _, addr := range r.Addresses {
+		vals = append(vals, fmt.Sprintf("Address|%s", addr))
+	}
+
+	vals = append(vals, fmt.Sprintf("Inclusion Block Number|%d", r.BlockNumber))
+
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
+
+func names[T any](m map[string]T) []string {
+	res := make([]string, 0, len(m))
+	for name := range m {
+		res = append(res, name)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
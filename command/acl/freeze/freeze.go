@@ -0,0 +1,65 @@
+package freeze
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	aclCommon "github.com/0xPolygon/polygon-edge/command/acl/common"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/state/runtime/addresslist"
+)
+
+// GetFreezeCommand returns the command freezing the address list,
+// which then authorizes only its admins
+func GetFreezeCommand() *cobra.Command {
+	return newCommand("freeze", "Freezes the address list, so that it authorizes only its admins",
+		addresslist.FreezeFunc)
+}
+
+// GetUnfreezeCommand returns the command unfreezing the address list
+func GetUnfreezeCommand() *cobra.Command {
+	return newCommand("unfreeze", "Unfreezes the address list", addresslist.UnfreezeFunc)
+}
+
+func newCommand(use, short string, method *abi.Method) *cobra.Command {
+	params := &aclCommon.ACLParams{}
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			params.JSONRPC = helper.GetJSONRPCAddress(cmd)
+
+			return params.ValidateFlags()
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			outputter := command.InitializeOutputter(cmd)
+			defer outputter.WriteOutput()
+
+			encoded, err := method.Encode([]interface{}{})
+			if err != nil {
+				return err
+			}
+
+			receipt, err := params.SendTransaction(encoded)
+			if err != nil {
+				return fmt.Errorf("%s failed: %w", use, err)
+			}
+
+			outputter.WriteCommandResult(&aclCommon.ACLResult{
+				List:        params.List,
+				Operation:   use,
+				BlockNumber: receipt.BlockNumber,
+			})
+
+			return nil
+		},
+	}
+
+	params.RegisterCommonFlags(cmd)
+
+	return cmd
+}
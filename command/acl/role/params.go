@@ -0,0 +1,41 @@
+package role
+
+import (
+	"errors"
+
+	aclCommon "github.com/0xPolygon/polygon-edge/command/acl/common"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	addressesFlag = "addresses"
+)
+
+var (
+	errNoAddressesProvided = errors.New("no addresses provided")
+)
+
+type roleParams struct {
+	aclCommon.ACLParams
+
+	addresses []string
+	role      string
+}
+
+func (p *roleParams) validateFlags() error {
+	if len(p.addresses) == 0 {
+		return errNoAddressesProvided
+	}
+
+	for _, addr := range p.addresses {
+		if err := types.IsValidAddress(addr); err != nil {
+			return err
+		}
+	}
+
+	if _, err := aclCommon.ParseRole(p.role); err != nil {
+		return err
+	}
+
+	return p.ValidateFlags()
+}
@@ -0,0 +1,98 @@
+package role
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	aclCommon "github.com/0xPolygon/polygon-edge/command/acl/common"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/state/runtime/addresslist"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	params roleParams
+
+	// batchFuncs are the functions setting the role of the batch of addresses
+	batchFuncs = map[addresslist.Role]*abi.Method{
+		addresslist.NoRole:      addresslist.SetNoneBatchFunc,
+		addresslist.EnabledRole: addresslist.SetEnabledBatchFunc,
+		addresslist.AdminRole:   addresslist.SetAdminBatchFunc,
+		addresslist.FreezerRole: addresslist.SetFreezerBatchFunc,
+	}
+)
+
+func GetCommand() *cobra.Command {
+	roleCmd := &cobra.Command{
+		Use:     "set-role",
+		Short:   "Sets the role of the batch of addresses in the address list",
+		PreRunE: runPreRun,
+		RunE:    runCommand,
+	}
+
+	setFlags(roleCmd)
+
+	return roleCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	params.RegisterCommonFlags(cmd)
+
+	cmd.Flags().StringSliceVar(
+		&params.addresses,
+		addressesFlag,
+		[]string{},
+		"addresses whose role is set",
+	)
+
+	cmd.Flags().StringVar(
+		&params.role,
+		aclCommon.RoleFlag,
+		"",
+		aclCommon.RoleFlagDesc(),
+	)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	params.JSONRPC = helper.GetJSONRPCAddress(cmd)
+
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) error {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	role, err := aclCommon.ParseRole(params.role)
+	if err != nil {
+		return err
+	}
+
+	addrs := make([]ethgo.Address, len(params.addresses))
+	for i, addr := range params.addresses {
+		addrs[i] = ethgo.Address(types.StringToAddress(addr))
+	}
+
+	encoded, err := batchFuncs[role].Encode([]interface{}{addrs})
+	if err != nil {
+		return fmt.Errorf("set role failed. Could not abi encode the batch: %w", err)
+	}
+
+	receipt, err := params.SendTransaction(encoded)
+	if err != nil {
+		return fmt.Errorf("set role failed: %w", err)
+	}
+
+	outputter.WriteCommandResult(&aclCommon.ACLResult{
+		List:        params.List,
+		Operation:   fmt.Sprintf("set role %s", params.role),
+		Addresses:   params.addresses,
+		BlockNumber: receipt.BlockNumber,
+	})
+
+	return nil
+}
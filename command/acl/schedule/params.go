@@ -0,0 +1,41 @@
+package schedule
+
+import (
+	"errors"
+
+	aclCommon "github.com/0xPolygon/polygon-edge/command/acl/common"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	addressFlag    = "address"
+	activationFlag = "activation"
+)
+
+var (
+	errNoActivation = errors.New("activation time of the role is not provided")
+)
+
+type scheduleParams struct {
+	aclCommon.ACLParams
+
+	address    string
+	role       string
+	activation uint64
+}
+
+func (p *scheduleParams) validateFlags() error {
+	if err := types.IsValidAddress(p.address); err != nil {
+		return err
+	}
+
+	if _, err := aclCommon.ParseRole(p.role); err != nil {
+		return err
+	}
+
+	if p.activation == 0 {
+		return errNoActivation
+	}
+
+	return p.ValidateFlags()
+}
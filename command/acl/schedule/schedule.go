@@ -0,0 +1,93 @@
+package schedule
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/umbracle/ethgo"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	aclCommon "github.com/0xPolygon/polygon-edge/command/acl/common"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/state/runtime/addresslist"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var params scheduleParams
+
+func GetCommand() *cobra.Command {
+	scheduleCmd := &cobra.Command{
+		Use:     "schedule-role",
+		Short:   "Schedules the role of the address in the address list to change at the activation time",
+		PreRunE: runPreRun,
+		RunE:    runCommand,
+	}
+
+	setFlags(scheduleCmd)
+
+	return scheduleCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	params.RegisterCommonFlags(cmd)
+
+	cmd.Flags().StringVar(
+		&params.address,
+		addressFlag,
+		"",
+		"address whose role is scheduled",
+	)
+
+	cmd.Flags().StringVar(
+		&params.role,
+		aclCommon.RoleFlag,
+		"",
+		aclCommon.RoleFlagDesc(),
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.activation,
+		activationFlag,
+		0,
+		"unix timestamp (in seconds) of the block from which the role is in effect",
+	)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	params.JSONRPC = helper.GetJSONRPCAddress(cmd)
+
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) error {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	role, err := aclCommon.ParseRole(params.role)
+	if err != nil {
+		return err
+	}
+
+	encoded, err := addresslist.ScheduleRoleFunc.Encode([]interface{}{
+		ethgo.Address(types.StringToAddress(params.address)),
+		uint8(role.Uint64()),
+		params.activation,
+	})
+	if err != nil {
+		return fmt.Errorf("schedule role failed. Could not abi encode the role: %w", err)
+	}
+
+	receipt, err := params.SendTransaction(encoded)
+	if err != nil {
+		return fmt.Errorf("schedule role failed: %w", err)
+	}
+
+	outputter.WriteCommandResult(&aclCommon.ACLResult{
+		List:        params.List,
+		Operation:   fmt.Sprintf("schedule role %s at %d", params.role, params.activation),
+		Addresses:   []string{params.address},
+		BlockNumber: receipt.BlockNumber,
+	})
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+)
+
+// adminCall calls the admin method of the node with the authorized context
+type adminCall func(ctx context.Context, clt proto.SystemClient) (command.CommandResult, error)
+
+func GetCommand() *cobra.Command {
+	adminCmd := &cobra.Command{
+		Use: "admin",
+		Short: "Top level command for the operational controls of the running node, " +
+			"which require the admin token of the node. Only accepts subcommands.",
+	}
+
+	helper.RegisterGRPCAddressFlag(adminCmd)
+	helper.RegisterGRPCTLSFlags(adminCmd)
+
+	adminCmd.PersistentFlags().StringVar(
+		&params.adminTokenFile,
+		adminTokenFileFlag,
+		"",
+		"the path to the file with the admin token of the node",
+	)
+
+	_ = adminCmd.MarkPersistentFlagRequired(adminTokenFileFlag)
+
+	registerSubcommands(adminCmd)
+
+	return adminCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	logLevelCmd := newSubcommand(
+		"log-level",
+		"Sets the log level of the node, or of a single module (e.g. network or polybft.consensus). "+
+			"The level \"default\" removes the override of the module",
+		func(ctx context.Context, clt proto.SystemClient) (command.CommandResult, error) {
+			resp, err := clt.AdminSetLogLevel(ctx, &proto.AdminSetLogLevelRequest{
+				Module: params.module,
+				Level:  params.level,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return newAdminStatusResult(resp), nil
+		},
+	)
+
+	logLevelCmd.Flags().StringVar(
+		&params.level,
+		levelFlag,
+		"",
+		"the log level (trace, debug, info, warn, error or default)",
+	)
+
+	logLevelCmd.Flags().StringVar(
+		&params.module,
+		moduleFlag,
+		"",
+		"the module whose log level is set, the level of the whole node is set if not given",
+	)
+
+	helper.SetRequiredFlags(logLevelCmd, []string{levelFlag})
+
+	baseCmd.AddCommand(
+		// admin status
+		newSubcommand(
+			"status",
+			"Returns the state of the operational controls of the node",
+			func(ctx context.Context, clt proto.SystemClient) (command.CommandResult, error) {
+				resp, err := clt.AdminStatus(ctx, &empty.Empty{})
+				if err != nil {
+					return nil, err
+				}
+
+				return newAdminStatusResult(resp), nil
+			},
+		),
+		// admin pause-proposing
+		newSubcommand(
+			"pause-proposing",
+			"Pauses the block proposals of the node (maintenance mode), the node keeps validating the blocks",
+			setProposingPaused(true),
+		),
+		// admin resume-proposing
+		newSubcommand(
+			"resume-proposing",
+			"Resumes the block proposals of the node",
+			setProposingPaused(false),
+		),
+		// admin pause-txpool
+		newSubcommand(
+			"pause-txpool",
+			"Stops accepting the new transactions into the pool",
+			setTxPoolPaused(true),
+		),
+		// admin resume-txpool
+		newSubcommand(
+			"resume-txpool",
+			"Resumes accepting the new transactions into the pool",
+			setTxPoolPaused(false),
+		),
+		// admin flush-txpool
+		newSubcommand(
+			"flush-txpool",
+			"Drops all the transactions of the pool",
+			func(ctx context.Context, clt proto.SystemClient) (command.CommandResult, error) {
+				resp, err := clt.AdminFlushTxPool(ctx, &empty.Empty{})
+				if err != nil {
+					return nil, err
+				}
+
+				return &FlushTxPoolResult{Dropped: resp.Dropped}, nil
+			},
+		),
+		// admin log-level
+		logLevelCmd,
+		// admin regenerate-snapshot
+		newSubcommand(
+			"regenerate-snapshot",
+			"Regenerates the flat state snapshot at the head of the chain in the background",
+			func(ctx context.Context, clt proto.SystemClient) (command.CommandResult, error) {
+				resp, err := clt.AdminRegenerateStateSnapshot(ctx, &empty.Empty{})
+				if err != nil {
+					return nil, err
+				}
+
+				return &RegenerateSnapshotResult{Root: resp.Root}, nil
+			},
+		),
+	)
+}
+
+func setProposingPaused(paused bool) adminCall {
+	return func(ctx context.Context, clt proto.SystemClient) (command.CommandResult, error) {
+		resp, err := clt.AdminSetProposingPaused(ctx, &proto.AdminPauseRequest{Paused: paused})
+		if err != nil {
+			return nil, err
+		}
+
+		return newAdminStatusResult(resp), nil
+	}
+}
+
+func setTxPoolPaused(paused bool) adminCall {
+	return func(ctx context.Context, clt proto.SystemClient) (command.CommandResult, error) {
+		resp, err := clt.AdminSetTxPoolPaused(ctx, &proto.AdminPauseRequest{Paused: paused})
+		if err != nil {
+			return nil, err
+		}
+
+		return newAdminStatusResult(resp), nil
+	}
+}
+
+func newSubcommand(use, short string, call adminCall) *cobra.Command {
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Run: func(cmd *cobra.Command, _ []string) {
+			outputter := command.InitializeOutputter(cmd)
+			defer outputter.WriteOutput()
+
+			if err := params.initSystemClient(helper.GetGRPCAddress(cmd)); err != nil {
+				outputter.SetError(err)
+
+				return
+			}
+
+			ctx, err := params.authorizedContext()
+			if err != nil {
+				outputter.SetError(err)
+
+				return
+			}
+
+			result, err := call(ctx, params.systemClient)
+			if err != nil {
+				outputter.SetError(err)
+
+				return
+			}
+
+			outputter.SetCommandResult(result)
+		},
+	}
+}
@@ -0,0 +1,63 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+)
+
+const (
+	adminTokenFileFlag = "admin-token-file"
+	levelFlag          = "level"
+	moduleFlag         = "module"
+)
+
+var (
+	errEmptyAdminToken = errors.New("the admin token file is empty")
+)
+
+var (
+	params = &adminParams{}
+)
+
+type adminParams struct {
+	adminTokenFile string
+
+	level  string
+	module string
+
+	systemClient proto.SystemClient
+}
+
+func (p *adminParams) initSystemClient(grpcAddress string) error {
+	systemClient, err := helper.GetSystemClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	p.systemClient = systemClient
+
+	return nil
+}
+
+// authorizedContext returns the context carrying the admin token in the request metadata
+func (p *adminParams) authorizedContext() (context.Context, error) {
+	raw, err := os.ReadFile(p.adminTokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the admin token file: %w", err)
+	}
+
+	token := strings.TrimSpace(string(raw))
+	if token == "" {
+		return nil, errEmptyAdminToken
+	}
+
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token), nil
+}
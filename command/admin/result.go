@@ -0,0 +1,90 @@
+package admin
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+)
+
+type AdminStatusResult struct {
+	ProposingPaused bool              `json:"proposing_paused"`
+	TxPoolPaused    bool              `json:"txpool_paused"`
+	LogLevel        string            `json:"log_level"`
+	ModuleLogLevels map[string]string `json:"module_log_levels"`
+}
+
+func newAdminStatusResult(resp *proto.AdminStatusResponse) *AdminStatusResult {
+	return &AdminStatusResult{
+		ProposingPaused: resp.ProposingPaused,
+		TxPoolPaused:    resp.TxPoolPaused,
+		LogLevel:        resp.LogLevel,
+		ModuleLogLevels: resp.ModuleLogLevels,
+	}
+}
+
+func (r *AdminStatusResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[ADMIN STATUS]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Proposing paused|%t", r.ProposingPaused),
+		fmt.Sprintf("TxPool paused|%t", r.TxPoolPaused),
+		fmt.Sprintf("Log level|%s", r.LogLevel),
+	}))
+
+	if len(r.ModuleLogLevels) > 0 {
+		modules := make([]string, 0, len(r.ModuleLogLevels))
+		for module := range r.ModuleLogLevels {
+			modules = append(modules, module)
+		}
+
+		sort.Strings(modules)
+
+		levels := make([]string, 0, len(modules))
+		for _, module := range modules {
+			levels = append(levels, fmt.Sprintf("%s|%s", module, r.ModuleLogLevels[module]))
+		}
+
+		buffer.WriteString("\n\n[MODULE LOG LEVELS]\n")
+		buffer.WriteString(helper.FormatKV(levels))
+	}
+
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
+
+type FlushTxPoolResult struct {
+	Dropped uint64 `json:"dropped"`
+}
+
+func (r *FlushTxPoolResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[TXPOOL FLUSHED]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Dropped transactions|%d", r.Dropped),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
+
+type RegenerateSnapshotResult struct {
+	Root string `json:"root"`
+}
+
+func (r *RegenerateSnapshotResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[STATE SNAPSHOT REGENERATION STARTED]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("State root|%s", r.Root),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -16,6 +16,7 @@ func GetCommand() *cobra.Command {
 	}
 
 	helper.RegisterGRPCAddressFlag(backupCmd)
+	helper.RegisterGRPCTLSFlags(backupCmd)
 
 	setFlags(backupCmd)
 	helper.SetRequiredFlags(backupCmd, params.getRequiredFlags())
@@ -28,7 +29,8 @@ func setFlags(cmd *cobra.Command) {
 		&params.out,
 		outFlag,
 		"",
-		"the export path for the backup",
+		"the export path for the backup, or the s3://<bucket>/<key> or gs://<bucket>/<key> object "+
+			"the backup is streamed to without the local copy",
 	)
 
 	cmd.Flags().StringVar(
@@ -44,6 +46,22 @@ func setFlags(cmd *cobra.Command) {
 		"",
 		"the end height of the chain in backup",
 	)
+
+	cmd.Flags().StringVar(
+		&params.incremental,
+		incrementalFlag,
+		"",
+		"the path or the object URL of the previous backup, the incremental backup holds only the blocks after it",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.compress,
+		compressFlag,
+		false,
+		"compress the backup with gzip",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(incrementalFlag, fromFlag)
 }
 
 func runPreRun(_ *cobra.Command, _ []string) error {
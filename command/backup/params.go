@@ -2,6 +2,8 @@ package backup
 
 import (
 	"errors"
+	"fmt"
+	"os"
 
 	"github.com/0xPolygon/polygon-edge/archive"
 	"github.com/0xPolygon/polygon-edge/command"
@@ -11,9 +13,11 @@ import (
 )
 
 const (
-	outFlag  = "out"
-	fromFlag = "from"
-	toFlag   = "to"
+	outFlag         = "out"
+	fromFlag        = "from"
+	toFlag          = "to"
+	incrementalFlag = "incremental"
+	compressFlag    = "compress"
 )
 
 var (
@@ -23,6 +27,7 @@ var (
 var (
 	errDecodeRange  = errors.New("unable to decode range value")
 	errInvalidRange = errors.New(`invalid "to" value; must be >= "from"`)
+	errBaseNotFound = errors.New("the base backup has no manifest")
 )
 
 type backupParams struct {
@@ -34,8 +39,10 @@ type backupParams struct {
 	from uint64
 	to   *uint64
 
-	resFrom uint64
-	resTo   uint64
+	incremental string
+	compress    bool
+
+	manifest *archive.Manifest
 }
 
 func (p *backupParams) validateFlags() error {
@@ -59,6 +66,16 @@ func (p *backupParams) validateFlags() error {
 		p.to = &parsedTo
 	}
 
+	if p.incremental != "" {
+		if _, err := archive.ReadManifest(p.incremental); err != nil {
+			if os.IsNotExist(err) {
+				return fmt.Errorf("%w: %s", errBaseNotFound, p.incremental)
+			}
+
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -76,31 +93,38 @@ func (p *backupParams) createBackup(grpcAddress string) error {
 		return err
 	}
 
-	// resFrom and resTo represents the range of blocks that can be included in the file
-	resFrom, resTo, err := archive.CreateBackup(
+	p.manifest, err = archive.CreateBackup(
 		connection,
 		hclog.New(&hclog.LoggerOptions{
 			Name:  "backup",
 			Level: hclog.LevelFromString("INFO"),
 		}),
-		p.from,
-		p.to,
-		p.out,
+		&archive.BackupParams{
+			From:     p.from,
+			To:       p.to,
+			OutPath:  p.out,
+			Compress: p.compress,
+			BasePath: p.incremental,
+		},
 	)
-	if err != nil {
-		return err
-	}
-
-	p.resFrom = resFrom
-	p.resTo = resTo
 
-	return nil
+	return err
 }
 
 func (p *backupParams) getResult() command.CommandResult {
-	return &BackupResult{
-		From: p.resFrom,
-		To:   p.resTo,
-		Out:  p.out,
+	result := &BackupResult{
+		From:       p.manifest.From,
+		To:         p.manifest.To,
+		Out:        p.out,
+		Manifest:   archive.ManifestPath(p.out),
+		SHA256:     p.manifest.SHA256,
+		Size:       p.manifest.Size,
+		Compressed: p.manifest.Compressed,
 	}
+
+	if p.manifest.Base != nil {
+		result.Base = p.manifest.Base.File
+	}
+
+	return result
 }
@@ -8,9 +8,14 @@ import (
 )
 
 type BackupResult struct {
-	From uint64 `json:"from"`
-	To   uint64 `json:"to"`
-	Out  string `json:"out"`
+	From       uint64 `json:"from"`
+	To         uint64 `json:"to"`
+	Out        string `json:"out"`
+	Manifest   string `json:"manifest"`
+	SHA256     string `json:"sha256"`
+	Size       int64  `json:"size"`
+	Compressed bool   `json:"compressed"`
+	Base       string `json:"base,omitempty"`
 }
 
 func (r *BackupResult) GetOutput() string {
@@ -18,11 +23,21 @@ func (r *BackupResult) GetOutput() string {
 
 	buffer.WriteString("\n[BACKUP]\n")
 	buffer.WriteString("Exported backup file successfully:\n")
-	buffer.WriteString(helper.FormatKV([]string{
+	vals := []string{
 		fmt.Sprintf("File|%s", r.Out),
 		fmt.Sprintf("From|%d", r.From),
 		fmt.Sprintf("To|%d", r.To),
-	}))
+		fmt.Sprintf("Compressed|%t", r.Compressed),
+		fmt.Sprintf("Size|%d", r.Size),
+		fmt.Sprintf("SHA256|%s", r.SHA256),
+		fmt.Sprintf("Manifest|%s", r.Manifest),
+	}
+
+	if r.Base != "" {
+		vals = append(vals, fmt.Sprintf("Base Backup|%s", r.Base))
+	}
+
+	buffer.WriteString(helper.FormatKV(vals))
 
 	return buffer.String()
 }
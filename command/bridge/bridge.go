@@ -7,7 +7,10 @@ import (
 	depositERC20 "github.com/0xPolygon/polygon-edge/command/bridge/deposit/erc20"
 	depositERC721 "github.com/0xPolygon/polygon-edge/command/bridge/deposit/erc721"
 	"github.com/0xPolygon/polygon-edge/command/bridge/exit"
+	"github.com/0xPolygon/polygon-edge/command/bridge/listmappings"
+	"github.com/0xPolygon/polygon-edge/command/bridge/maptoken"
 	"github.com/0xPolygon/polygon-edge/command/bridge/mint"
+	"github.com/0xPolygon/polygon-edge/command/bridge/trackerdb"
 	withdrawERC1155 "github.com/0xPolygon/polygon-edge/command/bridge/withdraw/erc1155"
 	withdrawERC20 "github.com/0xPolygon/polygon-edge/command/bridge/withdraw/erc20"
 	withdrawERC721 "github.com/0xPolygon/polygon-edge/command/bridge/withdraw/erc721"
@@ -43,5 +46,11 @@ func registerSubcommands(baseCmd *cobra.Command) {
 		exit.GetCommand(),
 		// bridge mint erc-20
 		mint.GetCommand(),
+		// bridge map-token
+		maptoken.GetCommand(),
+		// bridge list-mappings
+		listmappings.GetCommand(),
+		// bridge tracker-db
+		trackerdb.GetCommand(),
 	)
 }
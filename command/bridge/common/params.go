@@ -236,6 +236,7 @@ type BridgeTxResult struct {
 	Amounts        []string       `json:"amounts"`
 	TokenIDs       []string       `json:"tokenIds"`
 	BlockNumbers   []uint64       `json:"blockNumbers"`
+	TxHashes       []types.Hash   `json:"txHashes"`
 	ChildTokenAddr *types.Address `json:"childTokenAddr"`
 
 	Title string `json:"title"`
@@ -244,7 +245,7 @@ type BridgeTxResult struct {
 func (r *BridgeTxResult) GetOutput() string {
 	var buffer bytes.Buffer
 
-	vals := make([]string, 0, 7)
+	vals := make([]string, 0, 8)
 	vals = append(vals, fmt.Sprintf("Sender|%s", r.Sender))
 	vals = append(vals, fmt.Sprintf("Receivers|%s", strings.Join(r.Receivers, ", ")))
 
@@ -284,6 +285,15 @@ func (r *BridgeTxResult) GetOutput() string {
 		vals = append(vals, fmt.Sprintf("Inclusion Block Numbers|%s", buf.String()))
 	}
 
+	if len(r.TxHashes) > 0 {
+		txHashes := make([]string, len(r.TxHashes))
+		for i, txHash := range r.TxHashes {
+			txHashes[i] = txHash.String()
+		}
+
+		vals = append(vals, fmt.Sprintf("Transaction Hashes|%s", strings.Join(txHashes, ", ")))
+	}
+
 	if r.ChildTokenAddr != nil {
 		vals = append(vals, fmt.Sprintf("Child Token Address|%s", (*r.ChildTokenAddr).String()))
 	}
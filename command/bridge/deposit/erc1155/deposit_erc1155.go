@@ -217,6 +217,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 		Amounts:      dp.Amounts,
 		TokenIDs:     dp.TokenIDs,
 		BlockNumbers: []uint64{receipt.BlockNumber},
+		TxHashes:     []types.Hash{types.Hash(receipt.TransactionHash)},
 		Title:        "DEPOSIT ERC 1155",
 	}
 
@@ -172,6 +172,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 	type bridgeTxData struct {
 		exitEventIDs   []*big.Int
 		blockNumber    uint64
+		txHash         types.Hash
 		childTokenAddr *types.Address
 	}
 
@@ -179,6 +180,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 	bridgeTxCh := make(chan bridgeTxData, len(dp.Receivers))
 	exitEventIDs := make([]*big.Int, 0, len(dp.Receivers))
 	blockNumbers := make([]uint64, 0, len(dp.Receivers))
+	txHashes := make([]types.Hash, 0, len(dp.Receivers))
 
 	for i := range dp.Receivers {
 		receiver := dp.Receivers[i]
@@ -223,6 +225,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 				// send aggregated data to channel if everything went ok
 				bridgeTxCh <- bridgeTxData{
 					blockNumber:    receipt.BlockNumber,
+					txHash:         types.Hash(receipt.TransactionHash),
 					exitEventIDs:   exitEventIDs,
 					childTokenAddr: childToken,
 				}
@@ -248,6 +251,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 		}
 
 		blockNumbers = append(blockNumbers, x.blockNumber)
+		txHashes = append(txHashes, x.txHash)
 
 		if x.childTokenAddr != nil {
 			childToken = x.childTokenAddr
@@ -262,6 +266,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 			ExitEventIDs:   exitEventIDs,
 			ChildTokenAddr: childToken,
 			BlockNumbers:   blockNumbers,
+			TxHashes:       txHashes,
 			Title:          "DEPOSIT ERC 20",
 		})
 }
@@ -191,6 +191,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 		Receivers:    dp.Receivers,
 		TokenIDs:     dp.TokenIDs,
 		BlockNumbers: []uint64{receipt.BlockNumber},
+		TxHashes:     []types.Hash{types.Hash(receipt.TransactionHash)},
 		Title:        "DEPOSIT ERC 721",
 	}
 
@@ -0,0 +1,220 @@
+package listmappings
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/bridge/common"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
+	"github.com/0xPolygon/polygon-edge/txrelayer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// getTokenMappingsFn is JSON RPC endpoint which returns the child tokens the root token is mapped to
+	getTokenMappingsFn = "bridge_getTokenMappings"
+
+	// logsBatchSize is the number of blocks the logs are queried for at once, within the default block range limit
+	logsBatchSize = 1000
+)
+
+var (
+	lp = &listMappingsParams{}
+)
+
+// GetCommand returns the bridge list mappings command
+func GetCommand() *cobra.Command {
+	listMappingsCmd := &cobra.Command{
+		Use:     "list-mappings",
+		Short:   "Lists the tokens mapped by the predicates on the origin chain",
+		PreRunE: preRunCommand,
+		Run:     runCommand,
+	}
+
+	listMappingsCmd.Flags().StringSliceVar(
+		&lp.predicateAddrs,
+		common.RootPredicateFlag,
+		nil,
+		"addresses of the predicates on the origin chain to list the token mappings of",
+	)
+
+	listMappingsCmd.Flags().StringVar(
+		&lp.jsonRPCAddr,
+		common.JSONRPCFlag,
+		txrelayer.DefaultRPCAddress,
+		"the JSON RPC endpoint of the origin chain",
+	)
+
+	listMappingsCmd.Flags().StringVar(
+		&lp.childJSONRPCAddr,
+		childJSONRPCFlag,
+		"",
+		"the JSON RPC child chain endpoint, if set it's checked whether the mappings reached the child predicates",
+	)
+
+	listMappingsCmd.Flags().Uint64Var(
+		&lp.fromBlock,
+		fromBlockFlag,
+		0,
+		"the block of the origin chain to list the token mappings from",
+	)
+
+	listMappingsCmd.Flags().BoolVar(
+		&lp.childChainMintable,
+		common.ChildChainMintableFlag,
+		false,
+		"flag indicating whether the tokens originate from child chain",
+	)
+
+	_ = listMappingsCmd.MarkFlagRequired(common.RootPredicateFlag)
+
+	return listMappingsCmd
+}
+
+func preRunCommand(_ *cobra.Command, _ []string) error {
+	return lp.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	client, err := jsonrpc.NewClient(lp.jsonRPCAddr)
+	if err != nil {
+		outputter.SetError(fmt.Errorf("could not create JSON RPC client: %w", err))
+
+		return
+	}
+
+	mappings, err := getMappings(client.Eth())
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if lp.childJSONRPCAddr != "" && !lp.childChainMintable {
+		if err := checkChildMappings(mappings); err != nil {
+			outputter.SetError(err)
+
+			return
+		}
+	}
+
+	outputter.SetCommandResult(&listMappingsResult{Mappings: mappings})
+}
+
+// getMappings reads the token mapped events emitted by the predicates
+func getMappings(eth *jsonrpc.Eth) ([]*tokenMappingResult, error) {
+	latest, err := eth.BlockNumber()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the latest block: %w", err)
+	}
+
+	var topic ethgo.Hash
+	if lp.childChainMintable {
+		topic = new(contractsapi.L2MintableTokenMappedEvent).Sig()
+	} else {
+		topic = new(contractsapi.TokenMappedEvent).Sig()
+	}
+
+	predicates := make([]ethgo.Address, len(lp.predicateAddrs))
+	for i, addr := range lp.predicateAddrs {
+		predicates[i] = ethgo.Address(types.StringToAddress(addr))
+	}
+
+	mappings := []*tokenMappingResult{}
+
+	for from := lp.fromBlock; from <= latest; from += logsBatchSize {
+		to := from + logsBatchSize - 1
+		if to > latest {
+			to = latest
+		}
+
+		filter := &ethgo.LogFilter{
+			Address: predicates,
+			Topics:  [][]*ethgo.Hash{{&topic}},
+		}
+		filter.SetFromUint64(from)
+		filter.SetToUint64(to)
+
+		logs, err := eth.GetLogs(filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the token mapped events of blocks %d-%d: %w", from, to, err)
+		}
+
+		for _, log := range logs {
+			mapping, err := parseTokenMapped(log)
+			if err != nil {
+				return nil, err
+			}
+
+			mappings = append(mappings, mapping)
+		}
+	}
+
+	return mappings, nil
+}
+
+// parseTokenMapped parses the token mapped event of the predicate
+func parseTokenMapped(log *ethgo.Log) (*tokenMappingResult, error) {
+	var rootToken, childToken types.Address
+
+	if lp.childChainMintable {
+		var event contractsapi.L2MintableTokenMappedEvent
+		if _, err := event.ParseLog(log); err != nil {
+			return nil, fmt.Errorf("failed to parse the token mapped event: %w", err)
+		}
+
+		rootToken, childToken = event.RootToken, event.ChildToken
+	} else {
+		var event contractsapi.TokenMappedEvent
+		if _, err := event.ParseLog(log); err != nil {
+			return nil, fmt.Errorf("failed to parse the token mapped event: %w", err)
+		}
+
+		rootToken, childToken = event.RootToken, event.ChildToken
+	}
+
+	return &tokenMappingResult{
+		Predicate:   types.Address(log.Address),
+		RootToken:   rootToken,
+		ChildToken:  childToken,
+		BlockNumber: log.BlockNumber,
+	}, nil
+}
+
+// checkChildMappings checks whether the mappings reached the child predicates,
+// as the root token is mapped on the child chain once the state sync of the mapping is executed
+func checkChildMappings(mappings []*tokenMappingResult) error {
+	childClient, err := jsonrpc.NewClient(lp.childJSONRPCAddr)
+	if err != nil {
+		return fmt.Errorf("could not create child chain JSON RPC client: %w", err)
+	}
+
+	for _, m := range mappings {
+		var childMappings []*types.BridgeTokenMapping
+
+		if err := childClient.Call(getTokenMappingsFn, &childMappings, m.RootToken); err != nil {
+			return fmt.Errorf("failed to get child chain mappings of %s: %w", m.RootToken, err)
+		}
+
+		mappedOnChild := false
+
+		for _, childMapping := range childMappings {
+			if childMapping.ChildToken == m.ChildToken {
+				mappedOnChild = true
+
+				break
+			}
+		}
+
+		m.MappedOnChild = &mappedOnChild
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package listmappings
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/bridge/common"
+	cmdHelper "github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	fromBlockFlag    = "from-block"
+	childJSONRPCFlag = "child-json-rpc"
+)
+
+type listMappingsParams struct {
+	predicateAddrs     []string
+	jsonRPCAddr        string
+	childJSONRPCAddr   string
+	fromBlock          uint64
+	childChainMintable bool
+}
+
+func (p *listMappingsParams) validateFlags() error {
+	if _, err := cmdHelper.ParseJSONRPCAddress(p.jsonRPCAddr); err != nil {
+		return fmt.Errorf("failed to parse json rpc address. Error: %w", err)
+	}
+
+	if p.childJSONRPCAddr != "" {
+		if _, err := cmdHelper.ParseJSONRPCAddress(p.childJSONRPCAddr); err != nil {
+			return fmt.Errorf("failed to parse child json rpc address. Error: %w", err)
+		}
+	}
+
+	if len(p.predicateAddrs) == 0 {
+		return fmt.Errorf("at least one predicate address must be provided with --%s", common.RootPredicateFlag)
+	}
+
+	for _, addr := range p.predicateAddrs {
+		if err := types.IsValidAddress(addr); err != nil {
+			return fmt.Errorf("invalid predicate address: %w", err)
+		}
+	}
+
+	return nil
+}
+
+type tokenMappingResult struct {
+	Predicate   types.Address `json:"predicate"`
+	RootToken   types.Address `json:"rootToken"`
+	ChildToken  types.Address `json:"childToken"`
+	BlockNumber uint64        `json:"blockNumber"`
+	// MappedOnChild is set if the child chain was queried, and reports if the mapping reached the child predicate
+	MappedOnChild *bool `json:"mappedOnChild,omitempty"`
+}
+
+type listMappingsResult struct {
+	Mappings []*tokenMappingResult `json:"mappings"`
+}
+
+func (r *listMappingsResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[TOKEN MAPPINGS]\n")
+
+	if len(r.Mappings) == 0 {
+		buffer.WriteString("No token mappings found\n")
+
+		return buffer.String()
+	}
+
+	rows := make([]string, 0, len(r.Mappings)+1)
+	rows = append(rows, "Predicate|Root Token|Child Token|Block|Mapped On Child")
+
+	for _, m := range r.Mappings {
+		mappedOnChild := "-"
+		if m.MappedOnChild != nil {
+			mappedOnChild = fmt.Sprintf("%t", *m.MappedOnChild)
+		}
+
+		rows = append(rows, fmt.Sprintf("%s|%s|%s|%d|%s",
+			m.Predicate, m.RootToken, m.ChildToken, m.BlockNumber, mappedOnChild))
+	}
+
+	buffer.WriteString(cmdHelper.FormatList(rows))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,144 @@
+package maptoken
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/umbracle/ethgo"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/bridge/common"
+	"github.com/0xPolygon/polygon-edge/command/rootchain/helper"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
+	"github.com/0xPolygon/polygon-edge/txrelayer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// mp represents map token command parameters
+	mp = &mapTokenParams{BridgeParams: &common.BridgeParams{}}
+)
+
+// GetCommand returns the bridge map token command
+func GetCommand() *cobra.Command {
+	mapTokenCmd := &cobra.Command{
+		Use: "map-token",
+		Short: "Maps the token to the destination chain through the predicate on the origin chain, " +
+			"without depositing it",
+		PreRunE: preRunCommand,
+		Run:     runCommand,
+	}
+
+	mapTokenCmd.Flags().StringVar(
+		&mp.SenderKey,
+		common.SenderKeyFlag,
+		"",
+		"hex encoded private key of the account which sends the map token transaction",
+	)
+
+	mapTokenCmd.Flags().StringVar(
+		&mp.TokenAddr,
+		common.RootTokenFlag,
+		"",
+		"address of the token to map",
+	)
+
+	mapTokenCmd.Flags().StringVar(
+		&mp.PredicateAddr,
+		common.RootPredicateFlag,
+		"",
+		"address of the predicate of the token (ERC 20, ERC 721 or ERC 1155) on the origin chain",
+	)
+
+	mapTokenCmd.Flags().StringVar(
+		&mp.JSONRPCAddr,
+		common.JSONRPCFlag,
+		txrelayer.DefaultRPCAddress,
+		"the JSON RPC endpoint",
+	)
+
+	mapTokenCmd.Flags().BoolVar(
+		&mp.ChildChainMintable,
+		common.ChildChainMintableFlag,
+		false,
+		"flag indicating whether the token originates from child chain",
+	)
+
+	_ = mapTokenCmd.MarkFlagRequired(common.SenderKeyFlag)
+	_ = mapTokenCmd.MarkFlagRequired(common.RootTokenFlag)
+	_ = mapTokenCmd.MarkFlagRequired(common.RootPredicateFlag)
+
+	return mapTokenCmd
+}
+
+func preRunCommand(_ *cobra.Command, _ []string) error {
+	return mp.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	senderKey, err := helper.DecodePrivateKey(mp.SenderKey)
+	if err != nil {
+		outputter.SetError(fmt.Errorf("failed to initialize sender private key: %w", err))
+
+		return
+	}
+
+	txRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithIPAddress(mp.JSONRPCAddr))
+	if err != nil {
+		outputter.SetError(fmt.Errorf("failed to initialize tx relayer: %w", err))
+
+		return
+	}
+
+	// the predicates of all the token standards share the mapToken function
+	mapTokenFn := &contractsapi.MapTokenRootERC20PredicateFn{
+		RootToken: types.StringToAddress(mp.TokenAddr),
+	}
+
+	input, err := mapTokenFn.EncodeAbi()
+	if err != nil {
+		outputter.SetError(fmt.Errorf("failed to encode map token parameters: %w", err))
+
+		return
+	}
+
+	predicateAddr := ethgo.Address(types.StringToAddress(mp.PredicateAddr))
+	txn := helper.CreateTransaction(senderKey.Address(), &predicateAddr, input, nil, !mp.ChildChainMintable)
+
+	receipt, err := txRelayer.SendTransaction(txn, senderKey)
+	if err != nil {
+		outputter.SetError(fmt.Errorf("failed to send map token transaction: %w", err))
+
+		return
+	}
+
+	if receipt.Status == uint64(types.ReceiptFailed) {
+		outputter.SetError(fmt.Errorf("map token transaction failed, the token %s may already be mapped", mp.TokenAddr))
+
+		return
+	}
+
+	childToken, err := common.ExtractChildTokenAddr(receipt, mp.ChildChainMintable)
+	if err != nil {
+		outputter.SetError(fmt.Errorf("failed to extract child token address: %w", err))
+
+		return
+	}
+
+	if childToken == nil {
+		outputter.SetError(errChildTokenNotMapped)
+
+		return
+	}
+
+	outputter.SetCommandResult(&mapTokenResult{
+		RootToken:   types.StringToAddress(mp.TokenAddr),
+		ChildToken:  *childToken,
+		Predicate:   types.Address(predicateAddr),
+		BlockNumber: receipt.BlockNumber,
+		TxHash:      types.Hash(receipt.TransactionHash),
+	})
+}
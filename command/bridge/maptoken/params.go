@@ -0,0 +1,60 @@
+package maptoken
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/bridge/common"
+	cmdHelper "github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	errChildTokenNotMapped = errors.New("the token mapped event is missing from the receipt")
+)
+
+type mapTokenParams struct {
+	*common.BridgeParams
+}
+
+func (p *mapTokenParams) validateFlags() error {
+	if err := p.BridgeParams.Validate(); err != nil {
+		return err
+	}
+
+	if err := types.IsValidAddress(p.TokenAddr); err != nil {
+		return fmt.Errorf("invalid root token address: %w", err)
+	}
+
+	if err := types.IsValidAddress(p.PredicateAddr); err != nil {
+		return fmt.Errorf("invalid root predicate address: %w", err)
+	}
+
+	return nil
+}
+
+type mapTokenResult struct {
+	RootToken   types.Address `json:"rootToken"`
+	ChildToken  types.Address `json:"childToken"`
+	Predicate   types.Address `json:"predicate"`
+	BlockNumber uint64        `json:"blockNumber"`
+	TxHash      types.Hash    `json:"txHash"`
+}
+
+func (r *mapTokenResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	vals := make([]string, 0, 5)
+	vals = append(vals, fmt.Sprintf("Root Token|%s", r.RootToken))
+	vals = append(vals, fmt.Sprintf("Child Token|%s", r.ChildToken))
+	vals = append(vals, fmt.Sprintf("Predicate|%s", r.Predicate))
+	vals = append(vals, fmt.Sprintf("Inclusion Block Number|%d", r.BlockNumber))
+	vals = append(vals, fmt.Sprintf("Transaction (hash)|%s", r.TxHash))
+
+	buffer.WriteString("\n[MAP TOKEN]\n")
+	buffer.WriteString(cmdHelper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
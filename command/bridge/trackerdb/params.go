@@ -0,0 +1,115 @@
+package trackerdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	cmdHelper "github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/tracker"
+)
+
+const (
+	dataDirFlag        = "data-dir"
+	pathFlag           = "path"
+	pruneProcessedFlag = "prune-processed"
+
+	// depositDBPath is the path of the store of the state sync events tracker, relative to the data directory
+	depositDBPath = "consensus/polybft/deposit.db"
+)
+
+var (
+	errNoTrackerDB = errors.New("either the data directory or the path of the tracker store must be provided")
+)
+
+type trackerDBParams struct {
+	dataDir        string
+	path           string
+	pruneProcessed bool
+}
+
+func (p *trackerDBParams) validateFlags() error {
+	if p.dataDir == "" && p.path == "" {
+		return errNoTrackerDB
+	}
+
+	return nil
+}
+
+// dbPath returns the path of the tracker store, the state sync events store of the data directory by default
+func (p *trackerDBParams) dbPath() string {
+	if p.path != "" {
+		return p.path
+	}
+
+	return filepath.Join(p.dataDir, depositDBPath)
+}
+
+type statsResult struct {
+	Path string `json:"path"`
+	*tracker.StoreStats
+}
+
+func (r *statsResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[TRACKER DB]\n")
+	buffer.WriteString(cmdHelper.FormatKV([]string{
+		fmt.Sprintf("Path|%s", r.Path),
+		fmt.Sprintf("File Size|%d", r.FileSize),
+	}))
+	buffer.WriteString("\n")
+
+	buffer.WriteString("\n[BUCKETS]\n")
+
+	vals := make([]string, 0, len(r.Buckets)+1)
+	vals = append(vals, "Name|Keys|Size")
+
+	for _, bucket := range r.Buckets {
+		vals = append(vals, fmt.Sprintf("%s|%d|%d", bucket.Name, bucket.Keys, bucket.Size))
+	}
+
+	buffer.WriteString(cmdHelper.FormatList(vals))
+	buffer.WriteString("\n")
+
+	buffer.WriteString("\n[LOGS]\n")
+
+	if len(r.Contracts) == 0 {
+		buffer.WriteString("No logs stored\n")
+
+		return buffer.String()
+	}
+
+	vals = make([]string, 0, len(r.Contracts)+1)
+	vals = append(vals, "Contract|Logs|Processed")
+
+	for _, contract := range r.Contracts {
+		vals = append(vals, fmt.Sprintf("%s|%d|%d", contract.Address, contract.Logs, contract.Processed))
+	}
+
+	buffer.WriteString(cmdHelper.FormatList(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
+
+type compactResult struct {
+	Path string `json:"path"`
+	*tracker.CompactionResult
+}
+
+func (r *compactResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[TRACKER DB COMPACTION]\n")
+	buffer.WriteString(cmdHelper.FormatKV([]string{
+		fmt.Sprintf("Path|%s", r.Path),
+		fmt.Sprintf("Size Before|%d", r.SizeBefore),
+		fmt.Sprintf("Size After|%d", r.SizeAfter),
+		fmt.Sprintf("Pruned Logs|%d", r.PrunedLogs),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
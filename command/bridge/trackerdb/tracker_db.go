@@ -0,0 +1,115 @@
+package trackerdb
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/tracker"
+)
+
+var (
+	params = &trackerDBParams{}
+)
+
+// GetCommand returns the bridge tracker-db command
+func GetCommand() *cobra.Command {
+	trackerDBCmd := &cobra.Command{
+		Use: "tracker-db",
+		Short: "Maintains the store of the rootchain events tracker of a stopped node. " +
+			"Only accepts subcommands.",
+	}
+
+	trackerDBCmd.AddCommand(
+		// bridge tracker-db stats
+		getStatsCommand(),
+		// bridge tracker-db compact
+		getCompactCommand(),
+	)
+
+	return trackerDBCmd
+}
+
+func getStatsCommand() *cobra.Command {
+	statsCmd := &cobra.Command{
+		Use:     "stats",
+		Short:   "Reports the sizes of the buckets and the number of the logs stored per contract by the events tracker",
+		PreRunE: runPreRun,
+		Run:     runStatsCommand,
+	}
+
+	setFlags(statsCmd)
+
+	return statsCmd
+}
+
+func getCompactCommand() *cobra.Command {
+	compactCmd := &cobra.Command{
+		Use: "compact",
+		Short: "Compacts the store of the events tracker, releasing the space of the deleted data. " +
+			"The node must be stopped while the store is compacted",
+		PreRunE: runPreRun,
+		Run:     runCompactCommand,
+	}
+
+	setFlags(compactCmd)
+
+	compactCmd.Flags().BoolVar(
+		&params.pruneProcessed,
+		pruneProcessedFlag,
+		false,
+		"delete the logs already processed by the node before the compaction, "+
+			"their deposits are no longer found by bridge_getDepositStatus",
+	)
+
+	return compactCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the node, whose state sync events tracker store is used",
+	)
+
+	cmd.Flags().StringVar(
+		&params.path,
+		pathFlag,
+		"",
+		"the path of the tracker store, overriding the one of the data directory",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(dataDirFlag, pathFlag)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runStatsCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	stats, err := tracker.GetStoreStats(params.dbPath())
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(&statsResult{Path: params.dbPath(), StoreStats: stats})
+}
+
+func runCompactCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	result, err := tracker.CompactStore(params.dbPath(), params.pruneProcessed)
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(&compactResult{Path: params.dbPath(), CompactionResult: result})
+}
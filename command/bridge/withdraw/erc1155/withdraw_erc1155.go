@@ -155,6 +155,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 		Amounts:      wp.Amounts,
 		TokenIDs:     wp.TokenIDs,
 		BlockNumbers: []uint64{receipt.BlockNumber},
+		TxHashes:     []types.Hash{types.Hash(receipt.TransactionHash)},
 		Title:        "WITHDRAW ERC 1155",
 	}
 
@@ -92,6 +92,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 
 	exitEventIDs := make([]*big.Int, 0, len(wp.Receivers))
 	blockNumbers := make([]uint64, len(wp.Receivers))
+	txHashes := make([]types.Hash, len(wp.Receivers))
 
 	for i := range wp.Receivers {
 		receiver := wp.Receivers[i]
@@ -138,6 +139,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 		}
 
 		blockNumbers[i] = receipt.BlockNumber
+		txHashes[i] = types.Hash(receipt.TransactionHash)
 	}
 
 	outputter.SetCommandResult(
@@ -147,6 +149,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 			Amounts:      wp.Amounts,
 			ExitEventIDs: exitEventIDs,
 			BlockNumbers: blockNumbers,
+			TxHashes:     txHashes,
 			Title:        "WITHDRAW ERC 20",
 		})
 }
@@ -134,6 +134,7 @@ func run(cmd *cobra.Command, _ []string) {
 		Receivers:    wp.Receivers,
 		TokenIDs:     wp.TokenIDs,
 		BlockNumbers: []uint64{receipt.BlockNumber},
+		TxHashes:     []types.Hash{types.Hash(receipt.TransactionHash)},
 		Title:        "WITHDRAW ERC 721",
 	}
 
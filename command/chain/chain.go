@@ -0,0 +1,32 @@
+package chain
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command/chain/export"
+	"github.com/0xPolygon/polygon-edge/command/chain/importer"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	chainCmd := &cobra.Command{
+		Use:   "chain",
+		Short: "Top level command for exporting and importing the blockchain data. Only accepts subcommands.",
+	}
+
+	helper.RegisterGRPCAddressFlag(chainCmd)
+	helper.RegisterGRPCTLSFlags(chainCmd)
+
+	registerSubcommands(chainCmd)
+
+	return chainCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// chain export
+		export.GetCommand(),
+		// chain import
+		importer.GetCommand(),
+	)
+}
@@ -0,0 +1,113 @@
+package export
+
+import (
+	"errors"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/archive"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+)
+
+const (
+	dirFlag           = "dir"
+	fromFlag          = "from"
+	toFlag            = "to"
+	blocksPerFileFlag = "blocks-per-file"
+	receiptsFlag      = "receipts"
+)
+
+var (
+	params = &exportParams{}
+)
+
+var (
+	errDecodeRange         = errors.New("unable to decode range value")
+	errInvalidRange        = errors.New(`invalid "to" value; must be >= "from"`)
+	errInvalidBlocksInFile = errors.New("number of blocks per file must be positive")
+)
+
+type exportParams struct {
+	dir           string
+	blocksPerFile uint64
+	receipts      bool
+
+	fromRaw string
+	toRaw   string
+
+	from uint64
+	to   *uint64
+
+	result *archive.EraExportResult
+}
+
+func (p *exportParams) validateFlags() error {
+	var parseErr error
+
+	if p.blocksPerFile == 0 {
+		return errInvalidBlocksInFile
+	}
+
+	if p.from, parseErr = common.ParseUint64orHex(&p.fromRaw); parseErr != nil {
+		return errDecodeRange
+	}
+
+	if p.toRaw != "" {
+		var parsedTo uint64
+
+		if parsedTo, parseErr = common.ParseUint64orHex(&p.toRaw); parseErr != nil {
+			return errDecodeRange
+		}
+
+		if p.from > parsedTo {
+			return errInvalidRange
+		}
+
+		p.to = &parsedTo
+	}
+
+	return nil
+}
+
+func (p *exportParams) getRequiredFlags() []string {
+	return []string{
+		dirFlag,
+	}
+}
+
+func (p *exportParams) exportChain(grpcAddress string) error {
+	connection, err := helper.GetGRPCConnection(
+		grpcAddress,
+	)
+	if err != nil {
+		return err
+	}
+
+	p.result, err = archive.ExportEra(
+		connection,
+		hclog.New(&hclog.LoggerOptions{
+			Name:  "chain-export",
+			Level: hclog.LevelFromString("INFO"),
+		}),
+		p.from,
+		p.to,
+		p.dir,
+		p.blocksPerFile,
+		p.receipts,
+	)
+
+	return err
+}
+
+func (p *exportParams) getResult() command.CommandResult {
+	return &ExportResult{
+		Dir:      p.dir,
+		From:     p.result.From,
+		To:       p.result.To,
+		Written:  p.result.Written,
+		Skipped:  p.result.Skipped,
+		Receipts: p.receipts,
+	}
+}
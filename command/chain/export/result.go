@@ -0,0 +1,34 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type ExportResult struct {
+	Dir      string `json:"dir"`
+	From     uint64 `json:"from"`
+	To       uint64 `json:"to"`
+	Written  int    `json:"written"`
+	Skipped  int    `json:"skipped"`
+	Receipts bool   `json:"receipts"`
+}
+
+func (r *ExportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[CHAIN EXPORT]\n")
+	buffer.WriteString("Exported era files successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Directory|%s", r.Dir),
+		fmt.Sprintf("From|%d", r.From),
+		fmt.Sprintf("To|%d", r.To),
+		fmt.Sprintf("Written files|%d", r.Written),
+		fmt.Sprintf("Skipped files|%d", r.Skipped),
+		fmt.Sprintf("Receipts|%t", r.Receipts),
+	}))
+
+	return buffer.String()
+}
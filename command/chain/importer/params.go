@@ -0,0 +1,59 @@
+package importer
+
+import (
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/archive"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+const (
+	dirFlag = "dir"
+)
+
+var (
+	params = &importParams{}
+)
+
+type importParams struct {
+	dir string
+
+	result *archive.EraImportResult
+}
+
+func (p *importParams) getRequiredFlags() []string {
+	return []string{
+		dirFlag,
+	}
+}
+
+func (p *importParams) importChain(grpcAddress string) error {
+	connection, err := helper.GetGRPCConnection(
+		grpcAddress,
+	)
+	if err != nil {
+		return err
+	}
+
+	p.result, err = archive.ImportEra(
+		connection,
+		hclog.New(&hclog.LoggerOptions{
+			Name:  "chain-import",
+			Level: hclog.LevelFromString("INFO"),
+		}),
+		p.dir,
+	)
+
+	return err
+}
+
+func (p *importParams) getResult() command.CommandResult {
+	return &ImportResult{
+		Dir:      p.dir,
+		Files:    p.result.Files,
+		Skipped:  p.result.Skipped,
+		Imported: p.result.Imported,
+		Latest:   p.result.Latest,
+	}
+}
@@ -0,0 +1,32 @@
+package importer
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type ImportResult struct {
+	Dir      string `json:"dir"`
+	Files    int    `json:"files"`
+	Skipped  int    `json:"skipped"`
+	Imported uint64 `json:"imported"`
+	Latest   uint64 `json:"latest"`
+}
+
+func (r *ImportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[CHAIN IMPORT]\n")
+	buffer.WriteString("Imported era files successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Directory|%s", r.Dir),
+		fmt.Sprintf("Files|%d", r.Files),
+		fmt.Sprintf("Skipped files|%d", r.Skipped),
+		fmt.Sprintf("Imported blocks|%d", r.Imported),
+		fmt.Sprintf("Latest block|%d", r.Latest),
+	}))
+
+	return buffer.String()
+}
@@ -36,6 +36,11 @@ const (
 	JSONOutputFlag  = "json"
 	GRPCAddressFlag = "grpc-address"
 	JSONRPCFlag     = "jsonrpc"
+
+	GRPCTLSFlag           = "grpc-tls"
+	GRPCTLSCACertFlag     = "grpc-tls-ca-cert"
+	GRPCTLSClientCertFlag = "grpc-tls-client-cert"
+	GRPCTLSClientKeyFlag  = "grpc-tls-client-key"
 )
 
 // GRPCAddressFlagLEGACY Legacy flag that needs to be present to preserve backwards
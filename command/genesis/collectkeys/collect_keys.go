@@ -0,0 +1,86 @@
+package collectkeys
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/genesis/keybundle"
+	"github.com/0xPolygon/polygon-edge/command/polybftsecrets"
+)
+
+func GetCommand() *cobra.Command {
+	collectKeysCmd := &cobra.Command{
+		Use: "collect-keys",
+		Short: "Verifies the key bundles of the genesis validators, created by each of them with " +
+			"'genesis key-bundle', and aggregates them into the validators file of the genesis",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(collectKeysCmd)
+
+	return collectKeysCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(
+		&params.bundlePaths,
+		bundleFlag,
+		[]string{},
+		"the key bundle file of a validator, can be given multiple times",
+	)
+
+	cmd.Flags().StringVar(
+		&params.bundlesDir,
+		bundlesDirFlag,
+		"",
+		"the directory the JSON key bundle files of the validators are collected from",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.chainID,
+		polybftsecrets.ChainIDFlag,
+		command.DefaultChainID,
+		"the ID of the chain the bundles have to be signed for",
+	)
+
+	cmd.Flags().StringVar(
+		&params.output,
+		outputFlag,
+		defaultOutput,
+		"the validators file the verified bundles are written to, "+
+			"which is passed to the genesis command with --validators-file",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.collectBundles(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := keybundle.WriteKeyBundles(params.output, params.bundles); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	result := &CollectKeysResult{
+		Path:       params.output,
+		Validators: make([]string, len(params.bundles)),
+	}
+
+	for i, bundle := range params.bundles {
+		result.Validators[i] = bundle.MultiAddr + ":" + bundle.Address.String() + ":" + bundle.BLSKey
+	}
+
+	outputter.SetCommandResult(result)
+}
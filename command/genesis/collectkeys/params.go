@@ -0,0 +1,81 @@
+package collectkeys
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/command/genesis/keybundle"
+)
+
+const (
+	bundleFlag     = "bundle"
+	bundlesDirFlag = "bundles-dir"
+	outputFlag     = "output"
+
+	defaultOutput = "./validators.json"
+)
+
+var (
+	params = &collectKeysParams{}
+
+	errNoBundles = errors.New("no key bundles given")
+)
+
+type collectKeysParams struct {
+	bundlePaths []string
+	bundlesDir  string
+	chainID     uint64
+	output      string
+
+	bundles []*keybundle.KeyBundle
+}
+
+func (p *collectKeysParams) validateFlags() error {
+	if len(p.bundlePaths) == 0 && p.bundlesDir == "" {
+		return errNoBundles
+	}
+
+	return nil
+}
+
+// collectBundles reads the given bundle files and the JSON files of the bundles directory,
+// in the lexical order of their names
+func (p *collectKeysParams) collectBundles() error {
+	paths := append([]string{}, p.bundlePaths...)
+
+	if p.bundlesDir != "" {
+		entries, err := os.ReadDir(p.bundlesDir)
+		if err != nil {
+			return err
+		}
+
+		dirPaths := make([]string, 0, len(entries))
+
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+				dirPaths = append(dirPaths, filepath.Join(p.bundlesDir, entry.Name()))
+			}
+		}
+
+		sort.Strings(dirPaths)
+		paths = append(paths, dirPaths...)
+	}
+
+	for _, path := range paths {
+		bundles, err := keybundle.ReadKeyBundles(path)
+		if err != nil {
+			return err
+		}
+
+		p.bundles = append(p.bundles, bundles...)
+	}
+
+	if len(p.bundles) == 0 {
+		return errNoBundles
+	}
+
+	return keybundle.VerifyKeyBundles(p.bundles, p.chainID)
+}
@@ -0,0 +1,32 @@
+package collectkeys
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type CollectKeysResult struct {
+	Path       string   `json:"path"`
+	Validators []string `json:"validators"`
+}
+
+func (r *CollectKeysResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[COLLECTED KEYS]\n")
+	buffer.WriteString(fmt.Sprintf("Verified %d key bundle(s), written to %s\n\n", len(r.Validators), r.Path))
+
+	rows := make([]string, len(r.Validators)+1)
+	rows[0] = "#|P2P multi address:ECDSA address:public BLS key"
+
+	for i, validator := range r.Validators {
+		rows[i+1] = fmt.Sprintf("%d|%s", i+1, validator)
+	}
+
+	buffer.WriteString(helper.FormatList(rows))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,95 @@
+package fromstate
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+)
+
+func GetCommand() *cobra.Command {
+	genesisFromStateCmd := &cobra.Command{
+		Use: "from-state",
+		Short: "Generates the genesis whose allocation is the state of a block of an existing chain, " +
+			"pulled from a running node or from the data directory of a stopped node",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(genesisFromStateCmd)
+
+	return genesisFromStateCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.chainPath,
+		chainFlag,
+		fmt.Sprintf("./%s", command.DefaultGenesisFileName),
+		"the genesis of the existing chain, whose configuration is kept in the generated genesis",
+	)
+
+	cmd.Flags().StringVar(
+		&params.outputPath,
+		dirFlag,
+		"./genesis-from-state.json",
+		"the path of the generated genesis",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rpc,
+		rpcFlag,
+		"",
+		"the JSON-RPC address of the running node the state is pulled from (the debug endpoint has to be enabled)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the stopped node the state is read from",
+	)
+
+	cmd.Flags().StringVar(
+		&params.freezerDir,
+		freezerDirFlag,
+		"",
+		"the directory of the freezer (default <data-dir>/ancient)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.block,
+		blockFlag,
+		0,
+		"the number of the block whose state is dumped (default the head of the chain)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.pageSize,
+		pageSizeFlag,
+		defaultPageSize,
+		"the number of the accounts dumped at once",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(rpcFlag, dataDirFlag)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	params.latest = !cmd.Flags().Changed(blockFlag)
+
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.generateGenesis(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
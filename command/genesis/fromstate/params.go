@@ -0,0 +1,228 @@
+package fromstate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/umbracle/ethgo/jsonrpc"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	storageHelper "github.com/0xPolygon/polygon-edge/command/storage/helper"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	chainFlag      = "chain"
+	dirFlag        = "dir"
+	rpcFlag        = "rpc"
+	dataDirFlag    = "data-dir"
+	freezerDirFlag = "freezer-dir"
+	blockFlag      = "block"
+	pageSizeFlag   = "page-size"
+
+	defaultPageSize = 256
+)
+
+var (
+	params = &fromStateParams{}
+)
+
+var (
+	errNoSource      = errors.New("either the JSON-RPC address or the data directory of the chain has to be set")
+	errHeadNotFound  = errors.New("can't read the head of the chain")
+	errGenesisExists = errors.New("the genesis file already exists")
+)
+
+type fromStateParams struct {
+	chainPath  string
+	outputPath string
+	rpc        string
+	dataDir    string
+	freezerDir string
+	block      uint64
+	latest     bool
+	pageSize   uint64
+
+	genesisConfig *chain.Chain
+	root          types.Hash
+}
+
+func (p *fromStateParams) validateFlags() error {
+	if p.rpc == "" && p.dataDir == "" {
+		return errNoSource
+	}
+
+	if _, err := os.Stat(p.outputPath); err == nil {
+		return fmt.Errorf("%w: %s", errGenesisExists, p.outputPath)
+	}
+
+	if p.pageSize == 0 {
+		p.pageSize = defaultPageSize
+	}
+
+	cc, err := chain.Import(p.chainPath)
+	if err != nil {
+		return fmt.Errorf("failed to load chain config from %s: %w", p.chainPath, err)
+	}
+
+	p.genesisConfig = cc
+
+	return nil
+}
+
+// dumpFunc returns a range of the accounts of the state, starting at the given address hash
+type dumpFunc func(start types.Hash) (*itrie.StateDump, error)
+
+// generateGenesis dumps the state of the block and writes the genesis whose allocation is the dumped state,
+// the rest of the genesis is taken from the chain config
+func (p *fromStateParams) generateGenesis() error {
+	var (
+		dump    dumpFunc
+		closeFn func()
+		err     error
+	)
+
+	if p.rpc != "" {
+		dump, closeFn, err = p.rpcDump()
+	} else {
+		dump, closeFn, err = p.localDump()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	defer closeFn()
+
+	alloc, err := dumpAll(dump)
+	if err != nil {
+		return err
+	}
+
+	p.genesisConfig.Genesis.Alloc = alloc
+
+	return helper.WriteGenesisConfigToDisk(p.genesisConfig, p.outputPath)
+}
+
+// dumpAll dumps all the accounts of the state range by range
+func dumpAll(dump dumpFunc) (map[types.Address]*chain.GenesisAccount, error) {
+	var (
+		alloc = make(map[types.Address]*chain.GenesisAccount)
+		start = types.ZeroHash
+	)
+
+	for {
+		res, err := dump(start)
+		if err != nil {
+			return nil, err
+		}
+
+		for addr, account := range res.Accounts {
+			alloc[addr] = account
+		}
+
+		if res.Next == nil {
+			return alloc, nil
+		}
+
+		start = *res.Next
+	}
+}
+
+// rpcDump dumps the state over the debug_dumpState endpoint of the running node
+func (p *fromStateParams) rpcDump() (dumpFunc, func(), error) {
+	client, err := jsonrpc.NewClient(p.rpc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to %s: %w", p.rpc, err)
+	}
+
+	// the head is pinned, so all the ranges are dumped from the same state
+	if p.latest {
+		if p.block, err = client.Eth().BlockNumber(); err != nil {
+			_ = client.Close()
+
+			return nil, nil, fmt.Errorf("failed to get the head of the chain: %w", err)
+		}
+	}
+
+	block := hex.EncodeUint64(p.block)
+
+	dump := func(start types.Hash) (*itrie.StateDump, error) {
+		res := &itrie.StateDump{}
+
+		if err := client.Call("debug_dumpState", res, block, start, hex.EncodeUint64(p.pageSize)); err != nil {
+			return nil, fmt.Errorf("failed to dump the state: %w", err)
+		}
+
+		p.root = res.Root
+
+		return res, nil
+	}
+
+	return dump, func() { _ = client.Close() }, nil
+}
+
+// localDump dumps the state from the databases of the stopped node
+func (p *fromStateParams) localDump() (dumpFunc, func(), error) {
+	db, err := storageHelper.OpenChainStorage(p.dataDir, p.freezerDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if p.latest {
+		head, ok := db.ReadHeadNumber()
+		if !ok {
+			_ = db.Close()
+
+			return nil, nil, errHeadNotFound
+		}
+
+		p.block = head
+	}
+
+	hash, ok := db.ReadCanonicalHash(p.block)
+	if !ok {
+		_ = db.Close()
+
+		return nil, nil, fmt.Errorf("block %d not found", p.block)
+	}
+
+	header, err := db.ReadHeader(hash)
+	if err != nil {
+		_ = db.Close()
+
+		return nil, nil, fmt.Errorf("can't read the header of block %d: %w", p.block, err)
+	}
+
+	p.root = header.StateRoot
+
+	stateStorage, err := storageHelper.OpenStateStorage(p.dataDir, db.Backend)
+	if err != nil {
+		_ = db.Close()
+
+		return nil, nil, err
+	}
+
+	dump := func(start types.Hash) (*itrie.StateDump, error) {
+		return itrie.DumpState(stateStorage, p.root, start, int(p.pageSize))
+	}
+
+	return dump, func() {
+		_ = stateStorage.Close()
+		_ = db.Close()
+	}, nil
+}
+
+func (p *fromStateParams) getResult() command.CommandResult {
+	return &GenesisFromStateResult{
+		Block:    p.block,
+		Root:     p.root.String(),
+		Accounts: len(p.genesisConfig.Genesis.Alloc),
+		Path:     p.outputPath,
+	}
+}
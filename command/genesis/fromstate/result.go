@@ -0,0 +1,33 @@
+package fromstate
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type GenesisFromStateResult struct {
+	Block    uint64 `json:"block"`
+	Root     string `json:"root"`
+	Accounts int    `json:"accounts"`
+	Path     string `json:"path"`
+}
+
+func (r *GenesisFromStateResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[GENESIS FROM STATE]\n")
+
+	outputs := []string{
+		fmt.Sprintf("Block|%d", r.Block),
+		fmt.Sprintf("State root|%s", r.Root),
+		fmt.Sprintf("Accounts|%d", r.Accounts),
+		fmt.Sprintf("Genesis|%s", r.Path),
+	}
+
+	buffer.WriteString(helper.FormatKV(outputs))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
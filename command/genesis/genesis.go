@@ -6,10 +6,15 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/genesis/collectkeys"
+	"github.com/0xPolygon/polygon-edge/command/genesis/fromstate"
+	"github.com/0xPolygon/polygon-edge/command/genesis/keybundle"
 	"github.com/0xPolygon/polygon-edge/command/genesis/predeploy"
+	"github.com/0xPolygon/polygon-edge/command/genesis/validate"
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/consensus/ibft"
 	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful/nativesupply"
 	"github.com/0xPolygon/polygon-edge/validators"
 )
 
@@ -27,6 +32,14 @@ func GetCommand() *cobra.Command {
 	genesisCmd.AddCommand(
 		// genesis predeploy
 		predeploy.GetCommand(),
+		// genesis from-state
+		fromstate.GetCommand(),
+		// genesis validate
+		validate.GetCommand(),
+		// genesis key-bundle
+		keybundle.GetCommand(),
+		// genesis collect-keys
+		collectkeys.GetCommand(),
 	)
 
 	return genesisCmd
@@ -82,10 +95,11 @@ func setFlags(cmd *cobra.Command) {
 		&params.baseFeeConfig,
 		genesisBaseFeeConfigFlag,
 		command.DefaultGenesisBaseFeeConfig,
-		`initial base fee(in wei), base fee elasticity multiplier, and base fee change denominator
-		(provided in the following format: [<baseFee>][:<baseFeeEM>][:<baseFeeChangeDenom>]). 
+		`initial base fee(in wei), base fee elasticity multiplier, base fee change denominator and minimum base fee
+		(provided in the following format: [<baseFee>][:<baseFeeEM>][:<baseFeeChangeDenom>][:<minBaseFee>]). 
 		BaseFeeChangeDenom represents the value to bound the amount the base fee can change between blocks.
-		Default BaseFee is 1 Gwei, BaseFeeEM is 2 and BaseFeeChangeDenom is 8.
+		MinBaseFee is the floor the base fee can't decrease below.
+		Default BaseFee is 1 Gwei, BaseFeeEM is 2, BaseFeeChangeDenom is 8 and MinBaseFee is 0.
 		Note: BaseFee, BaseFeeEM, and BaseFeeChangeDenom should be greater than 0.`,
 	)
 
@@ -117,6 +131,30 @@ func setFlags(cmd *cobra.Command) {
 		"admin for proxy contracts",
 	)
 
+	cmd.Flags().StringArrayVar(
+		&params.statefulPrecompiles,
+		statefulPrecompileFlag,
+		[]string{},
+		"the stateful precompile activated at the block (format: <name>:<address>[:<block>]). "+
+			"This flag can be used multiple times",
+	)
+
+	cmd.Flags().StringVar(
+		&params.nativeSupplyManager,
+		nativeSupplyManagerFlag,
+		"",
+		"the contract allowed to mint and burn the native token through the "+nativesupply.Name+" stateful precompile",
+	)
+
+	cmd.Flags().StringVar(
+		&params.feeDistribution,
+		feeDistributionFlag,
+		"",
+		"the sharing of the transaction fees between the block proposer, the staker reward pool and the treasury, "+
+			"with the shares in basis points (format: "+
+			"<proposerShare>:<stakerPoolShare>:<treasuryShare>:<stakerPool>:<treasury>[:<block>])",
+	)
+
 	// PoS
 	{
 		cmd.Flags().BoolVar(
@@ -162,8 +200,19 @@ func setFlags(cmd *cobra.Command) {
 			"validators defined by user (polybft format: <P2P multi address>:<ECDSA address>:<public BLS key>)",
 		)
 
+		cmd.Flags().StringVar(
+			&params.validatorsFile,
+			validatorsFileFlag,
+			"",
+			"the validators file aggregated from the key bundles of the validators by 'genesis collect-keys' "+
+				"(polybft only), the bundles are verified again",
+		)
+
 		cmd.MarkFlagsMutuallyExclusive(command.ValidatorFlag, command.ValidatorRootFlag)
 		cmd.MarkFlagsMutuallyExclusive(command.ValidatorFlag, command.ValidatorPrefixFlag)
+		cmd.MarkFlagsMutuallyExclusive(validatorsFileFlag, command.ValidatorFlag)
+		cmd.MarkFlagsMutuallyExclusive(validatorsFileFlag, command.ValidatorRootFlag)
+		cmd.MarkFlagsMutuallyExclusive(validatorsFileFlag, command.ValidatorPrefixFlag)
 	}
 
 	// IBFT Validators
@@ -242,6 +291,14 @@ func setFlags(cmd *cobra.Command) {
 			defaultBlockTrackerPollInterval,
 			"interval (number of seconds) at which block tracker polls for latest block at rootchain",
 		)
+
+		cmd.Flags().DurationVar(
+			&params.stateCommitmentChallengeWindow,
+			stateCommitmentWindowFlag,
+			0,
+			"challenge window of the state root committed in the checkpoints of the epoch ending blocks "+
+				"(the state commitments are disabled if not set)",
+		)
 	}
 
 	// Access Control Lists
@@ -329,6 +386,36 @@ func setFlags(cmd *cobra.Command) {
 			[]string{},
 			"list of addresses to enable by default in the bridge block list",
 		)
+
+		cmd.Flags().StringArrayVar(
+			&params.paymasterAllowListAdmin,
+			paymasterAllowListAdminFlag,
+			[]string{},
+			"list of addresses to use as admin accounts in the paymaster allow list",
+		)
+
+		cmd.Flags().StringArrayVar(
+			&params.paymasterAllowListEnabled,
+			paymasterAllowListEnabledFlag,
+			[]string{},
+			"list of paymaster contracts to enable by default in the paymaster allow list, "+
+				"which sponsor the transactions with zero gas price",
+		)
+
+		cmd.Flags().StringArrayVar(
+			&params.txPolicyAdmin,
+			txPolicyAdminFlag,
+			[]string{},
+			"list of addresses to use as admin accounts in the transaction policy, "+
+				"which denies the transactions by their function selector or value",
+		)
+
+		cmd.Flags().StringVar(
+			&params.txPolicyMaxValue,
+			txPolicyMaxValueFlag,
+			"",
+			"maximum value transferred by a transaction under the transaction policy (unlimited if not set)",
+		)
 	}
 }
 
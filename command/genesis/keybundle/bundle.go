@@ -0,0 +1,255 @@
+package keybundle
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/multiformats/go-multiaddr"
+
+	"github.com/0xPolygon/polygon-edge/bls"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// DomainKeyBundleString is the domain of the BLS possession proofs of the key bundles
+	DomainKeyBundleString = "DOMAIN_GENESIS_KEY_BUNDLE"
+)
+
+var (
+	// DomainKeyBundle is the Keccak256 hash of DomainKeyBundleString
+	DomainKeyBundle = crypto.Keccak256([]byte(DomainKeyBundleString))
+
+	errInvalidPossessionProof = errors.New("invalid BLS possession proof")
+	errInvalidSignature       = errors.New("the bundle is not signed by the validator")
+	errNoNodeID               = errors.New("the P2P multi address has to end with the /p2p/<node ID> component")
+)
+
+// KeyBundle holds the public keys of a genesis validator. The bundle is signed by the validator ECDSA key,
+// and proves the possession of the BLS key, so the keys can't be mixed up or forged on the way to the genesis
+type KeyBundle struct {
+	Address         types.Address `json:"address"`
+	BLSKey          string        `json:"blsKey"`
+	MultiAddr       string        `json:"multiAddr"`
+	ChainID         uint64        `json:"chainID"`
+	PossessionProof string        `json:"possessionProof"`
+	Signature       string        `json:"signature"`
+}
+
+// NewKeyBundle creates the key bundle of the account, signed by both of its keys
+func NewKeyBundle(account *wallet.Account, multiAddr string, chainID uint64) (*KeyBundle, error) {
+	bundle := &KeyBundle{
+		Address:   account.Address(),
+		BLSKey:    hex.EncodeToString(account.Bls.PublicKey().Marshal()),
+		MultiAddr: multiAddr,
+		ChainID:   chainID,
+	}
+
+	payload, err := bundle.payload()
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := account.Bls.Sign(payload, DomainKeyBundle)
+	if err != nil {
+		return nil, err
+	}
+
+	proofRaw, err := proof.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := account.Ecdsa.Sign(crypto.Keccak256(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	bundle.PossessionProof = hex.EncodeToHex(proofRaw)
+	bundle.Signature = hex.EncodeToHex(signature)
+
+	return bundle, nil
+}
+
+// Verify checks the BLS possession proof and the ECDSA signature of the bundle
+func (b *KeyBundle) Verify() error {
+	if _, err := b.nodeID(); err != nil {
+		return err
+	}
+
+	payload, err := b.payload()
+	if err != nil {
+		return err
+	}
+
+	blsKey, err := b.blsPublicKey()
+	if err != nil {
+		return err
+	}
+
+	proofRaw, err := hex.DecodeHex(b.PossessionProof)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidPossessionProof, err)
+	}
+
+	proof, err := bls.UnmarshalSignature(proofRaw)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidPossessionProof, err)
+	}
+
+	if !proof.Verify(blsKey, payload, DomainKeyBundle) {
+		return errInvalidPossessionProof
+	}
+
+	signature, err := hex.DecodeHex(b.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidSignature, err)
+	}
+
+	signer, err := wallet.RecoverAddressFromSignature(signature, payload)
+	if err != nil || signer != b.Address {
+		return errInvalidSignature
+	}
+
+	return nil
+}
+
+// ToGenesisValidator converts the bundle to the genesis validator without stake
+func (b *KeyBundle) ToGenesisValidator() *validator.GenesisValidator {
+	return &validator.GenesisValidator{
+		Address:   b.Address,
+		BlsKey:    strings.TrimPrefix(b.BLSKey, "0x"),
+		MultiAddr: b.MultiAddr,
+		Stake:     big.NewInt(0),
+	}
+}
+
+// NodeID returns the libp2p node ID of the bundle multi address
+func (b *KeyBundle) NodeID() string {
+	nodeID, _ := b.nodeID()
+
+	return nodeID
+}
+
+func (b *KeyBundle) nodeID() (string, error) {
+	addr, err := multiaddr.NewMultiaddr(b.MultiAddr)
+	if err != nil {
+		return "", fmt.Errorf("invalid P2P multi address '%s': %w", b.MultiAddr, err)
+	}
+
+	nodeID, err := addr.ValueForProtocol(multiaddr.P_P2P)
+	if err != nil {
+		return "", errNoNodeID
+	}
+
+	return nodeID, nil
+}
+
+func (b *KeyBundle) blsPublicKey() (*bls.PublicKey, error) {
+	raw, err := hex.DecodeHex(b.BLSKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid BLS key: %w", err)
+	}
+
+	return bls.UnmarshalPublicKey(raw)
+}
+
+// payload returns the signed content of the bundle,
+// address || BLS public key || chain ID || multi address
+func (b *KeyBundle) payload() ([]byte, error) {
+	blsKey, err := hex.DecodeHex(b.BLSKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid BLS key: %w", err)
+	}
+
+	payload := make([]byte, 0, types.AddressLength+len(blsKey)+8+len(b.MultiAddr))
+	payload = append(payload, b.Address.Bytes()...)
+	payload = append(payload, blsKey...)
+	payload = binary.BigEndian.AppendUint64(payload, b.ChainID)
+	payload = append(payload, b.MultiAddr...)
+
+	return payload, nil
+}
+
+// WriteFile writes the bundle to the JSON file
+func (b *KeyBundle) WriteFile(path string) error {
+	raw, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, raw, 0600)
+}
+
+// WriteKeyBundles writes the list of the bundles to the JSON file
+func WriteKeyBundles(path string, bundles []*KeyBundle) error {
+	raw, err := json.MarshalIndent(bundles, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, raw, 0600)
+}
+
+// ReadKeyBundles reads the bundles of the JSON file, which holds either a single bundle or a list of them
+func ReadKeyBundles(path string) ([]*KeyBundle, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the key bundles from %s: %w", path, err)
+	}
+
+	var bundles []*KeyBundle
+
+	if strings.HasPrefix(strings.TrimSpace(string(raw)), "[") {
+		err = json.Unmarshal(raw, &bundles)
+	} else {
+		bundle := &KeyBundle{}
+		err = json.Unmarshal(raw, bundle)
+		bundles = []*KeyBundle{bundle}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the key bundles of %s: %w", path, err)
+	}
+
+	return bundles, nil
+}
+
+// VerifyKeyBundles verifies the bundles of the chain, and checks that none of the keys or nodes is repeated
+func VerifyKeyBundles(bundles []*KeyBundle, chainID uint64) error {
+	seen := make(map[string]int, 3*len(bundles))
+
+	for i, bundle := range bundles {
+		if err := bundle.Verify(); err != nil {
+			return fmt.Errorf("key bundle of %s: %w", bundle.Address, err)
+		}
+
+		if bundle.ChainID != chainID {
+			return fmt.Errorf("key bundle of %s is signed for the chain %d instead of %d",
+				bundle.Address, bundle.ChainID, chainID)
+		}
+
+		for _, key := range []string{
+			bundle.Address.String(),
+			strings.ToLower(strings.TrimPrefix(bundle.BLSKey, "0x")),
+			bundle.NodeID(),
+		} {
+			if j, ok := seen[key]; ok {
+				return fmt.Errorf("key bundles of %s and %s share the key %s",
+					bundles[j].Address, bundle.Address, key)
+			}
+
+			seen[key] = i
+		}
+	}
+
+	return nil
+}
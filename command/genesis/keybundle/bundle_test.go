@@ -0,0 +1,89 @@
+package keybundle
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func newTestBundle(t *testing.T, chainID uint64) *KeyBundle {
+	t.Helper()
+
+	account, err := wallet.GenerateAccount()
+	require.NoError(t, err)
+
+	libp2pKey, _, err := network.GenerateAndEncodeLibp2pKey()
+	require.NoError(t, err)
+
+	nodeID, err := peer.IDFromPrivateKey(libp2pKey)
+	require.NoError(t, err)
+
+	bundle, err := NewKeyBundle(account, "/dns4/validator/tcp/1478/p2p/"+nodeID.String(), chainID)
+	require.NoError(t, err)
+
+	return bundle
+}
+
+func TestKeyBundle_Verify(t *testing.T) {
+	t.Parallel()
+
+	bundle := newTestBundle(t, 100)
+	require.NoError(t, bundle.Verify())
+	require.Equal(t, bundle.BLSKey, bundle.ToGenesisValidator().BlsKey)
+
+	// any change of the signed content invalidates the bundle
+	tampered := *bundle
+	tampered.MultiAddr = "/dns4/attacker/tcp/1478/p2p/" + bundle.NodeID()
+	require.ErrorIs(t, tampered.Verify(), errInvalidPossessionProof)
+
+	// the BLS key of another validator can't be claimed
+	other := newTestBundle(t, 100)
+	tampered = *bundle
+	tampered.BLSKey = other.BLSKey
+	require.ErrorIs(t, tampered.Verify(), errInvalidPossessionProof)
+
+	tampered.PossessionProof = other.PossessionProof
+	require.Error(t, tampered.Verify())
+
+	tampered = *bundle
+	tampered.Address = types.StringToAddress("0x1")
+	require.Error(t, tampered.Verify())
+
+	tampered = *bundle
+	tampered.MultiAddr = "/dns4/validator/tcp/1478"
+	require.ErrorIs(t, tampered.Verify(), errNoNodeID)
+}
+
+func TestVerifyKeyBundles(t *testing.T) {
+	t.Parallel()
+
+	first, second := newTestBundle(t, 100), newTestBundle(t, 100)
+
+	path := filepath.Join(t.TempDir(), "validators.json")
+	require.NoError(t, WriteKeyBundles(path, []*KeyBundle{first, second}))
+
+	bundles, err := ReadKeyBundles(path)
+	require.NoError(t, err)
+	require.Equal(t, []*KeyBundle{first, second}, bundles)
+	require.NoError(t, VerifyKeyBundles(bundles, 100))
+
+	// the bundles are bound to the chain
+	require.ErrorContains(t, VerifyKeyBundles(bundles, 101), "is signed for the chain 100 instead of 101")
+
+	// the same validator can't be added twice
+	require.ErrorContains(t, VerifyKeyBundles([]*KeyBundle{first, second, first}, 100), "share the key")
+
+	// a single bundle file is read as well
+	path = filepath.Join(t.TempDir(), "bundle.json")
+	require.NoError(t, first.WriteFile(path))
+
+	bundles, err = ReadKeyBundles(path)
+	require.NoError(t, err)
+	require.Equal(t, []*KeyBundle{first}, bundles)
+}
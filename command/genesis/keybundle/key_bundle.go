@@ -0,0 +1,92 @@
+package keybundle
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/polybftsecrets"
+)
+
+func GetCommand() *cobra.Command {
+	keyBundleCmd := &cobra.Command{
+		Use: "key-bundle",
+		Short: "Creates the signed public key bundle of the validator, which proves the possession of its keys. " +
+			"The bundles of all the validators are aggregated by the genesis coordinator with 'genesis collect-keys'",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(keyBundleCmd)
+
+	return keyBundleCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.accountDir,
+		polybftsecrets.AccountDirFlag,
+		"",
+		polybftsecrets.AccountDirFlagDesc,
+	)
+
+	cmd.Flags().StringVar(
+		&params.accountConfig,
+		polybftsecrets.AccountConfigFlag,
+		"",
+		polybftsecrets.AccountConfigFlagDesc,
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(polybftsecrets.AccountDirFlag, polybftsecrets.AccountConfigFlag)
+
+	cmd.Flags().StringVar(
+		&params.p2pAddr,
+		p2pAddrFlag,
+		"",
+		"the P2P multi address the validator is reachable on (e.g. /dns4/validator-1/tcp/1478), "+
+			"the node ID of the network key is appended to it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.chainID,
+		polybftsecrets.ChainIDFlag,
+		command.DefaultChainID,
+		"the ID of the chain the bundle is signed for",
+	)
+
+	cmd.Flags().StringVar(
+		&params.output,
+		outputFlag,
+		defaultOutput,
+		"the file the key bundle is written to",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	bundle, err := params.createBundle()
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := bundle.WriteFile(params.output); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(&KeyBundleResult{
+		Address:   bundle.Address.String(),
+		BLSKey:    bundle.BLSKey,
+		MultiAddr: bundle.MultiAddr,
+		ChainID:   bundle.ChainID,
+		Path:      params.output,
+	})
+}
@@ -0,0 +1,89 @@
+package keybundle
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/multiformats/go-multiaddr"
+
+	"github.com/0xPolygon/polygon-edge/command/polybftsecrets"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
+	"github.com/0xPolygon/polygon-edge/secrets/helper"
+)
+
+const (
+	p2pAddrFlag = "p2p-addr"
+	outputFlag  = "output"
+
+	defaultOutput = "./key-bundle.json"
+)
+
+var (
+	params = &keyBundleParams{}
+
+	errNoP2PAddr    = errors.New("the P2P address of the validator has to be set")
+	errNoNetworkKey = errors.New("the network key is not initialized, set the /p2p/<node ID> component of the address")
+)
+
+type keyBundleParams struct {
+	accountDir    string
+	accountConfig string
+	p2pAddr       string
+	chainID       uint64
+	output        string
+}
+
+func (p *keyBundleParams) validateFlags() error {
+	if p.accountDir == "" && p.accountConfig == "" {
+		return polybftsecrets.ErrInvalidParams
+	}
+
+	if p.p2pAddr == "" {
+		return errNoP2PAddr
+	}
+
+	if _, err := multiaddr.NewMultiaddr(p.p2pAddr); err != nil {
+		return fmt.Errorf("invalid P2P address '%s': %w", p.p2pAddr, err)
+	}
+
+	return nil
+}
+
+// createBundle signs the bundle of the validator keys,
+// the node ID of the network key is appended to the P2P address, unless it is already set
+func (p *keyBundleParams) createBundle() (*KeyBundle, error) {
+	secretsManager, err := polybftsecrets.GetSecretsManager(p.accountDir, p.accountConfig, true)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := wallet.NewAccountFromSecret(secretsManager)
+	if err != nil {
+		return nil, err
+	}
+
+	addr, err := multiaddr.NewMultiaddr(p.p2pAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := addr.ValueForProtocol(multiaddr.P_P2P); err != nil {
+		nodeID, err := helper.LoadNodeID(secretsManager)
+		if err != nil {
+			return nil, err
+		}
+
+		if nodeID == "" {
+			return nil, errNoNetworkKey
+		}
+
+		p2pComponent, err := multiaddr.NewComponent("p2p", nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		addr = addr.Encapsulate(p2pComponent)
+	}
+
+	return NewKeyBundle(account, addr.String(), p.chainID)
+}
@@ -0,0 +1,32 @@
+package keybundle
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type KeyBundleResult struct {
+	Address   string `json:"address"`
+	BLSKey    string `json:"blsKey"`
+	MultiAddr string `json:"multiAddr"`
+	ChainID   uint64 `json:"chainID"`
+	Path      string `json:"path"`
+}
+
+func (r *KeyBundleResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[KEY BUNDLE]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Address|%s", r.Address),
+		fmt.Sprintf("BLS Public key|%s", r.BLSKey),
+		fmt.Sprintf("P2P address|%s", r.MultiAddr),
+		fmt.Sprintf("Chain ID|%d", r.ChainID),
+		fmt.Sprintf("Written to|%s", r.Path),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
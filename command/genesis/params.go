@@ -1,6 +1,7 @@
 package genesis
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -19,6 +20,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/contracts/staking"
 	stakingHelper "github.com/0xPolygon/polygon-edge/helper/staking"
 	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful/nativesupply"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validators"
 )
@@ -39,6 +41,11 @@ const (
 	rewardWalletFlag             = "reward-wallet"
 	blockTrackerPollIntervalFlag = "block-tracker-poll-interval"
 	proxyContractsAdminFlag      = "proxy-contracts-admin"
+	statefulPrecompileFlag       = "stateful-precompile"
+	nativeSupplyManagerFlag      = "native-supply-manager"
+	feeDistributionFlag          = "fee-distribution"
+	validatorsFileFlag           = "validators-file"
+	stateCommitmentWindowFlag    = "state-commitment-challenge-window"
 )
 
 // Legacy flags that need to be preserved for running clients
@@ -56,10 +63,13 @@ var (
 	errInvalidEpochSize         = errors.New("epoch size must be greater than 1")
 	errRewardWalletAmountZero   = errors.New("reward wallet amount can not be zero or negative")
 	errReserveAccMustBePremined = errors.New("it is mandatory to premine reserve account (0x0 address)")
+	errNativeSupplyNotSet       = fmt.Errorf("the native supply manager requires the %s stateful precompile", nativesupply.Name)
 	errBlockTrackerPollInterval = errors.New("block tracker poll interval must be greater than 0")
 	errBaseFeeChangeDenomZero   = errors.New("base fee change denominator must be greater than 0")
 	errBaseFeeEMZero            = errors.New("base fee elasticity multiplier must be greater than 0")
+	errMinBaseFeeTooHigh        = errors.New("minimum base fee must not be greater than the base fee")
 	errBaseFeeZero              = errors.New("base fee  must be greater than 0")
+	errStateCommitmentWindow    = errors.New("state commitment challenge window must be at least a second")
 	errRewardWalletNotDefined   = errors.New("reward wallet address must be defined")
 	errRewardTokenOnNonMintable = errors.New("a custom reward token must be defined when " +
 		"native ERC20 token is non-mintable")
@@ -89,6 +99,7 @@ type genesisParams struct {
 	validatorsPath       string
 	validatorsPrefixPath string
 	validators           []string
+	validatorsFile       string
 
 	// IBFT
 	rawIBFTValidatorType string
@@ -123,6 +134,10 @@ type genesisParams struct {
 	bridgeAllowListEnabled           []string
 	bridgeBlockListAdmin             []string
 	bridgeBlockListEnabled           []string
+	paymasterAllowListAdmin          []string
+	paymasterAllowListEnabled        []string
+	txPolicyAdmin                    []string
+	txPolicyMaxValue                 string
 
 	nativeTokenConfigRaw string
 	nativeTokenConfig    *polybft.TokenConfig
@@ -136,6 +151,13 @@ type genesisParams struct {
 	blockTrackerPollInterval time.Duration
 
 	proxyContractsAdmin string
+
+	statefulPrecompiles []string
+	nativeSupplyManager string
+
+	feeDistribution string
+
+	stateCommitmentChallengeWindow time.Duration
 }
 
 func (p *genesisParams) validateFlags() error {
@@ -159,6 +181,11 @@ func (p *genesisParams) validateFlags() error {
 		return err
 	}
 
+	// the stateful precompiles are applied to the copy of the forks, so they are validated up front
+	if err := p.setStatefulPrecompiles(&chain.Params{Forks: chain.AllForksEnabled}); err != nil {
+		return err
+	}
+
 	if p.isPolyBFTConsensus() {
 		if err := p.extractNativeTokenMetadata(); err != nil {
 			return err
@@ -179,6 +206,10 @@ func (p *genesisParams) validateFlags() error {
 		if err := p.validateProxyContractsAdmin(); err != nil {
 			return err
 		}
+
+		if err := p.validateStateCommitmentChallengeWindow(); err != nil {
+			return err
+		}
 	}
 
 	// Check if the genesis file already exists
@@ -194,8 +225,8 @@ func (p *genesisParams) validateFlags() error {
 		return errInvalidEpochSize
 	}
 
-	// Validate validatorsPath only if validators information were not provided via CLI flag
-	if len(p.validators) == 0 {
+	// Validate validatorsPath only if validators information were not provided via CLI flag or file
+	if len(p.validators) == 0 && p.validatorsFile == "" {
 		if _, err := os.Stat(p.validatorsPath); err != nil {
 			return fmt.Errorf("invalid validators path ('%s') provided. Error: %w", p.validatorsPath, err)
 		}
@@ -424,6 +455,7 @@ func (p *genesisParams) initGenesisConfig() error {
 		chainConfig.Genesis.BaseFee = p.parsedBaseFeeConfig.baseFee
 		chainConfig.Genesis.BaseFeeEM = p.parsedBaseFeeConfig.baseFeeEM
 		chainConfig.Genesis.BaseFeeChangeDenom = p.parsedBaseFeeConfig.baseFeeChangeDenom
+		chainConfig.Genesis.MinBaseFee = p.parsedBaseFeeConfig.minBaseFee
 		chainConfig.Params.BurnContract = make(map[uint64]types.Address, 1)
 
 		burnContractInfo, err := parseBurnContractInfo(p.burnContract)
@@ -435,6 +467,14 @@ func (p *genesisParams) initGenesisConfig() error {
 		chainConfig.Params.BurnContractDestinationAddress = burnContractInfo.DestinationAddress
 	}
 
+	if err := p.setStatefulPrecompiles(chainConfig.Params); err != nil {
+		return err
+	}
+
+	if err := p.setFeeDistribution(chainConfig.Params); err != nil {
+		return err
+	}
+
 	// Predeploy staking smart contract if needed
 	if p.shouldPredeployStakingSC() {
 		stakingAccount, err := p.predeployStakingSC()
@@ -541,6 +581,16 @@ func (p *genesisParams) validateBlockTrackerPollInterval() error {
 	return nil
 }
 
+// validateStateCommitmentChallengeWindow validates the challenge window of the state commitments,
+// which is committed in seconds, so it can't be shorter than a second if set
+func (p *genesisParams) validateStateCommitmentChallengeWindow() error {
+	if p.stateCommitmentChallengeWindow != 0 && p.stateCommitmentChallengeWindow < time.Second {
+		return errStateCommitmentWindow
+	}
+
+	return nil
+}
+
 // validateBurnContract validates burn contract. If native token is mintable,
 // burn contract flag must not be set. If native token is non mintable only one burn contract
 // can be set and the specified address will be used to predeploy default EIP1559 burn contract.
@@ -589,6 +639,10 @@ func (p *genesisParams) validateGenesisBaseFeeConfig() error {
 		return errBaseFeeChangeDenomZero
 	}
 
+	if baseFeeInfo.minBaseFee > baseFeeInfo.baseFee {
+		return errMinBaseFeeTooHigh
+	}
+
 	return nil
 }
 
@@ -631,3 +685,84 @@ func (p *genesisParams) getResult() command.CommandResult {
 		Message: fmt.Sprintf("\nGenesis written to %s\n", p.genesisPath),
 	}
 }
+
+// setStatefulPrecompiles adds the stateful precompiles to the chain params,
+// each of them activated by the fork named after the precompile
+func (p *genesisParams) setStatefulPrecompiles(chainParams *chain.Params) error {
+	if len(p.statefulPrecompiles) == 0 {
+		if p.nativeSupplyManager != "" {
+			return errNativeSupplyNotSet
+		}
+
+		return nil
+	}
+
+	// the forks are shared with the default ones, so the custom forks are added to the copy
+	forks := chainParams.Forks.Copy()
+
+	for _, raw := range p.statefulPrecompiles {
+		config, block, err := parseStatefulPrecompile(raw)
+		if err != nil {
+			return fmt.Errorf("invalid stateful precompile %s: %w", raw, err)
+		}
+
+		if _, exists := (*forks)[config.Fork]; exists {
+			return fmt.Errorf("invalid stateful precompile %s: fork %s is already defined", raw, config.Fork)
+		}
+
+		forks.SetFork(config.Fork, chain.NewFork(block))
+		chainParams.StatefulPrecompiles = append(chainParams.StatefulPrecompiles, config)
+	}
+
+	if err := p.setNativeSupplyManager(chainParams.StatefulPrecompiles); err != nil {
+		return err
+	}
+
+	chainParams.Forks = forks
+
+	return nil
+}
+
+// setNativeSupplyManager sets the manager of the native token supply in the config of the supply hook
+func (p *genesisParams) setNativeSupplyManager(configs []*chain.StatefulPrecompileConfig) error {
+	if p.nativeSupplyManager == "" {
+		return nil
+	}
+
+	if err := types.IsValidAddress(p.nativeSupplyManager); err != nil {
+		return fmt.Errorf("invalid native supply manager %s: %w", p.nativeSupplyManager, err)
+	}
+
+	for _, config := range configs {
+		if config.Name != nativesupply.Name {
+			continue
+		}
+
+		raw, err := json.Marshal(&nativesupply.Config{Manager: types.StringToAddress(p.nativeSupplyManager)})
+		if err != nil {
+			return err
+		}
+
+		config.Config = raw
+
+		return nil
+	}
+
+	return errNativeSupplyNotSet
+}
+
+// setFeeDistribution sets the sharing of the transaction fees in the chain params
+func (p *genesisParams) setFeeDistribution(chainParams *chain.Params) error {
+	if p.feeDistribution == "" {
+		return nil
+	}
+
+	distribution, err := parseFeeDistribution(p.feeDistribution)
+	if err != nil {
+		return fmt.Errorf("invalid fee distribution %s: %w", p.feeDistribution, err)
+	}
+
+	chainParams.FeeDistribution = distribution
+
+	return nil
+}
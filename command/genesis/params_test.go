@@ -1,15 +1,18 @@
 package genesis
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"github.com/umbracle/ethgo"
 
+	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command"
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful/nativesupply"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -242,3 +245,150 @@ func Test_validateRewardWallet(t *testing.T) {
 		})
 	}
 }
+
+func Test_setStatefulPrecompiles(t *testing.T) {
+	t.Parallel()
+
+	address := types.StringToAddress("0x1000").String()
+
+	cases := []struct {
+		name                string
+		statefulPrecompiles []string
+		expectedBlock       uint64
+		expectErr           bool
+	}{
+		{
+			name:                "active from genesis",
+			statefulPrecompiles: []string{"kvstore:" + address},
+			expectedBlock:       0,
+		},
+		{
+			name:                "active from block",
+			statefulPrecompiles: []string{"kvstore:" + address + ":100"},
+			expectedBlock:       100,
+		},
+		{
+			name:                "invalid address",
+			statefulPrecompiles: []string{"kvstore:0x10"},
+			expectErr:           true,
+		},
+		{
+			name:                "invalid block",
+			statefulPrecompiles: []string{"kvstore:" + address + ":block"},
+			expectErr:           true,
+		},
+		{
+			name:                "predefined fork",
+			statefulPrecompiles: []string{chain.London + ":" + address},
+			expectErr:           true,
+		},
+		{
+			name:                "duplicate fork",
+			statefulPrecompiles: []string{"kvstore:" + address, "kvstore:" + address + ":10"},
+			expectErr:           true,
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := &genesisParams{statefulPrecompiles: c.statefulPrecompiles}
+			chainParams := &chain.Params{Forks: chain.AllForksEnabled}
+
+			err := p.setStatefulPrecompiles(chainParams)
+			if c.expectErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, chainParams.StatefulPrecompiles, 1)
+			require.Equal(t, "kvstore", chainParams.StatefulPrecompiles[0].Fork)
+			require.Equal(t, c.expectedBlock, (*chainParams.Forks)["kvstore"].Block)
+
+			// the default forks are left intact
+			require.NotContains(t, *chain.AllForksEnabled, "kvstore")
+		})
+	}
+}
+
+func Test_setNativeSupplyManager(t *testing.T) {
+	t.Parallel()
+
+	address := types.StringToAddress("0x1000").String()
+	manager := types.StringToAddress("0x2000")
+
+	p := &genesisParams{nativeSupplyManager: manager.String()}
+	require.ErrorIs(t, p.setStatefulPrecompiles(&chain.Params{Forks: chain.AllForksEnabled}), errNativeSupplyNotSet)
+
+	p.statefulPrecompiles = []string{"kvstore:" + address}
+	require.ErrorIs(t, p.setStatefulPrecompiles(&chain.Params{Forks: chain.AllForksEnabled}), errNativeSupplyNotSet)
+
+	p.statefulPrecompiles = []string{nativesupply.Name + ":" + address}
+	chainParams := &chain.Params{Forks: chain.AllForksEnabled}
+	require.NoError(t, p.setStatefulPrecompiles(chainParams))
+
+	var config nativesupply.Config
+
+	require.NoError(t, json.Unmarshal(chainParams.StatefulPrecompiles[0].Config, &config))
+	require.Equal(t, manager, config.Manager)
+}
+
+func Test_setFeeDistribution(t *testing.T) {
+	t.Parallel()
+
+	stakerPool := types.StringToAddress("0x1000")
+	treasury := types.StringToAddress("0x2000")
+
+	cases := []struct {
+		raw      string
+		expected *chain.FeeDistribution
+		err      string
+	}{
+		{
+			raw: fmt.Sprintf("5000:3000:2000:%s:%s", stakerPool, treasury),
+			expected: &chain.FeeDistribution{
+				ProposerShare:   5000,
+				StakerPoolShare: 3000,
+				TreasuryShare:   2000,
+				StakerPool:      stakerPool,
+				Treasury:        treasury,
+			},
+		},
+		{
+			raw: fmt.Sprintf("10000:0:0:%s:%s:100", types.ZeroAddress, types.ZeroAddress),
+			expected: &chain.FeeDistribution{
+				Block:         100,
+				ProposerShare: 10000,
+			},
+		},
+		{
+			raw: "5000:5000:0",
+			err: "expected format",
+		},
+		{
+			raw: fmt.Sprintf("5000:3000:3000:%s:%s", stakerPool, treasury),
+			err: "fee shares have to add up to 10000, got 11000",
+		},
+		{
+			raw: fmt.Sprintf("5000:3000:2000:%s:0x12", stakerPool),
+			err: "failed to parse fee receiver address 0x12",
+		},
+	}
+
+	for _, c := range cases {
+		chainParams := &chain.Params{}
+
+		err := (&genesisParams{feeDistribution: c.raw}).setFeeDistribution(chainParams)
+		if c.err != "" {
+			require.ErrorContains(t, err, c.err)
+
+			continue
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, c.expected, chainParams.FeeDistribution)
+	}
+}
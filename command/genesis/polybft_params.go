@@ -13,6 +13,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/genesis/keybundle"
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
@@ -51,6 +52,10 @@ const (
 	bridgeAllowListEnabledFlag           = "bridge-allow-list-enabled"
 	bridgeBlockListAdminFlag             = "bridge-block-list-admin"
 	bridgeBlockListEnabledFlag           = "bridge-block-list-enabled"
+	paymasterAllowListAdminFlag          = "paymaster-allow-list-admin"
+	paymasterAllowListEnabledFlag        = "paymaster-allow-list-enabled"
+	txPolicyAdminFlag                    = "tx-policy-admin"
+	txPolicyMaxValueFlag                 = "tx-policy-max-value"
 
 	bootnodePortStart = 30301
 
@@ -155,6 +160,12 @@ func (p *genesisParams) generatePolyBftChainConfig(o command.OutputFormatter) er
 		ProxyContractsAdmin:      types.StringToAddress(p.proxyContractsAdmin),
 	}
 
+	if p.stateCommitmentChallengeWindow > 0 {
+		polyBftConfig.StateCommitment = &polybft.StateCommitmentConfig{
+			ChallengeWindow: common.Duration{Duration: p.stateCommitmentChallengeWindow},
+		}
+	}
+
 	// Disable london hardfork if burn contract address is not provided
 	enabledForks := chain.AllForksEnabled
 	if !p.isBurnContractEnabled() {
@@ -173,6 +184,14 @@ func (p *genesisParams) generatePolyBftChainConfig(o command.OutputFormatter) er
 		Bootnodes: p.bootnodes,
 	}
 
+	if err := p.setStatefulPrecompiles(chainConfig.Params); err != nil {
+		return err
+	}
+
+	if err := p.setFeeDistribution(chainConfig.Params); err != nil {
+		return err
+	}
+
 	burnContractAddr := types.ZeroAddress
 
 	if p.isBurnContractEnabled() {
@@ -298,12 +317,39 @@ func (p *genesisParams) generatePolyBftChainConfig(o command.OutputFormatter) er
 		}
 	}
 
+	if len(p.paymasterAllowListAdmin) != 0 {
+		// only enable allow list if there is at least one address as **admin**, otherwise
+		// the allow list could never be updated
+		chainConfig.Params.PaymasterAllowList = &chain.AddressListConfig{
+			AdminAddresses:   stringSliceToAddressSlice(p.paymasterAllowListAdmin),
+			EnabledAddresses: stringSliceToAddressSlice(p.paymasterAllowListEnabled),
+		}
+	}
+
+	if len(p.txPolicyAdmin) != 0 {
+		// only enable transaction policy if there is at least one address as **admin**, otherwise
+		// the policy could never be updated
+		chainConfig.Params.TxPolicy = &chain.TxPolicyConfig{
+			AdminAddresses: stringSliceToAddressSlice(p.txPolicyAdmin),
+		}
+
+		if p.txPolicyMaxValue != "" {
+			maxValue, err := common.ParseUint256orHex(&p.txPolicyMaxValue)
+			if err != nil {
+				return fmt.Errorf("invalid %s value: %w", txPolicyMaxValueFlag, err)
+			}
+
+			chainConfig.Params.TxPolicy.MaxValue = maxValue
+		}
+	}
+
 	if p.isBurnContractEnabled() {
 		// only populate base fee and base fee multiplier values if burn contract(s)
 		// is provided
 		chainConfig.Genesis.BaseFee = p.parsedBaseFeeConfig.baseFee
 		chainConfig.Genesis.BaseFeeEM = p.parsedBaseFeeConfig.baseFeeEM
 		chainConfig.Genesis.BaseFeeChangeDenom = p.parsedBaseFeeConfig.baseFeeChangeDenom
+		chainConfig.Genesis.MinBaseFee = p.parsedBaseFeeConfig.minBaseFee
 	}
 
 	return helper.WriteGenesisConfigToDisk(chainConfig, params.genesisPath)
@@ -498,6 +544,25 @@ func (p *genesisParams) deployContracts(
 
 // getValidatorAccounts gathers validator accounts info either from CLI or from provided local storage
 func (p *genesisParams) getValidatorAccounts() ([]*validator.GenesisValidator, error) {
+	// the validators collected from the key bundles
+	if p.validatorsFile != "" {
+		bundles, err := keybundle.ReadKeyBundles(p.validatorsFile)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := keybundle.VerifyKeyBundles(bundles, p.chainID); err != nil {
+			return nil, err
+		}
+
+		validators := make([]*validator.GenesisValidator, len(bundles))
+		for i, bundle := range bundles {
+			validators[i] = bundle.ToGenesisValidator()
+		}
+
+		return validators, nil
+	}
+
 	// populate validators premine info
 	if len(p.validators) > 0 {
 		validators := make([]*validator.GenesisValidator, len(p.validators))
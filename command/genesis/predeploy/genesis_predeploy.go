@@ -4,7 +4,7 @@ import (
 	"fmt"
 
 	"github.com/0xPolygon/polygon-edge/command"
-	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/helper/predeployment"
 	"github.com/spf13/cobra"
 )
 
@@ -17,7 +17,6 @@ func GetCommand() *cobra.Command {
 	}
 
 	setFlags(genesisPredeployCmd)
-	helper.SetRequiredFlags(genesisPredeployCmd, params.getRequiredFlags())
 
 	return genesisPredeployCmd
 }
@@ -33,7 +32,7 @@ func setFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(
 		&params.addressRaw,
 		predeployAddressFlag,
-		predeployAddressMin.String(),
+		"",
 		fmt.Sprintf("the address to predeploy to. Must be >= %s", predeployAddressMin.String()),
 	)
 
@@ -50,6 +49,50 @@ func setFlags(cmd *cobra.Command) {
 		[]string{},
 		"the constructor arguments, if any",
 	)
+
+	cmd.Flags().StringVar(
+		&params.saltRaw,
+		saltFlag,
+		"",
+		"the salt to predeploy the contract with through the deterministic deployment proxy, "+
+			"to the same address it gets on the other chains",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.storageRaw,
+		storageFlag,
+		[]string{},
+		"the storage slot set on the contract once it's created, in the format <slot>:<value>",
+	)
+
+	cmd.Flags().StringVar(
+		&params.proxyImplRaw,
+		proxyImplFlag,
+		"",
+		"the implementation address set in the EIP-1967 implementation slot of the proxy contract",
+	)
+
+	cmd.Flags().StringVar(
+		&params.proxyAdminRaw,
+		proxyAdminFlag,
+		"",
+		"the admin address set in the EIP-1967 admin slot of the proxy contract",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.initCallsRaw,
+		initCallFlag,
+		[]string{},
+		"the hex encoded call made to the contract from the zero address once the storage is set, "+
+			"such as the initializer of the proxy",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.deterministicDeployer,
+		deployerFlag,
+		false,
+		fmt.Sprintf("predeploy the deterministic deployment proxy to %s", predeployment.DeterministicDeployerAddr),
+	)
 }
 
 func runPreRun(_ *cobra.Command, _ []string) error {
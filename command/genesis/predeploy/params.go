@@ -3,7 +3,9 @@ package predeploy
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"os"
+	"strings"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command"
@@ -19,13 +21,23 @@ const (
 	predeployAddressFlag = "predeploy-address"
 	artifactsPathFlag    = "artifacts-path"
 	constructorArgsPath  = "constructor-args"
+	saltFlag             = "salt"
+	storageFlag          = "storage"
+	proxyImplFlag        = "proxy-implementation"
+	proxyAdminFlag       = "proxy-admin"
+	initCallFlag         = "init-call"
+	deployerFlag         = "deterministic-deployer"
 )
 
 var (
 	errInvalidPredeployAddress  = errors.New("invalid predeploy address provided")
 	errAddressTaken             = errors.New("the provided predeploy address is taken")
 	errReservedPredeployAddress = errors.New("the provided predeploy address is reserved")
-	errInvalidAddress           = fmt.Errorf(
+	errAddressAndSalt           = errors.New("the predeploy address and the salt are mutually exclusive")
+	errArtifactsRequired        = errors.New("the contract artifacts path is required")
+	errDeployerOnly             = errors.New("the deterministic deployer is predeployed on its own, " +
+		"without the contract flags")
+	errInvalidAddress = fmt.Errorf(
 		"the provided predeploy address must be >= %s", predeployAddressMin.String(),
 	)
 )
@@ -49,17 +61,52 @@ type predeployParams struct {
 	artifactsPath   string
 	constructorArgs []string
 
+	saltRaw               string
+	storageRaw            []string
+	proxyImplRaw          string
+	proxyAdminRaw         string
+	initCallsRaw          []string
+	deterministicDeployer bool
+
+	deployment *predeployment.Deployment
+
 	genesisConfig *chain.Chain
 }
 
-func (p *predeployParams) getRequiredFlags() []string {
-	return []string{
-		predeployAddressFlag,
-		artifactsPathFlag,
+func (p *predeployParams) initRawParams() error {
+	if err := p.initChain(); err != nil {
+		return err
+	}
+
+	if p.deterministicDeployer {
+		if p.addressRaw != "" || p.artifactsPath != "" || p.saltRaw != "" || len(p.storageRaw) != 0 ||
+			p.proxyImplRaw != "" || p.proxyAdminRaw != "" || len(p.initCallsRaw) != 0 {
+			return errDeployerOnly
+		}
+
+		p.address = predeployment.DeterministicDeployerAddr
+
+		return nil
+	}
+
+	if p.artifactsPath == "" {
+		return errArtifactsRequired
+	}
+
+	if err := p.initDeployment(); err != nil {
+		return err
+	}
+
+	if p.deployment.Salt != nil {
+		p.address = p.deployment.DeploymentAddress()
+
+		if isReservedAddress(p.address) {
+			return errReservedPredeployAddress
+		}
+
+		return nil
 	}
-}
 
-func (p *predeployParams) initRawParams() error {
 	if err := p.initPredeployAddress(); err != nil {
 		return err
 	}
@@ -68,10 +115,80 @@ func (p *predeployParams) initRawParams() error {
 		return err
 	}
 
-	if err := p.initChain(); err != nil {
+	p.deployment.Address = p.address
+
+	return nil
+}
+
+// initDeployment loads the creation code of the contract and parses the storage and the init calls
+func (p *predeployParams) initDeployment() error {
+	initCode, err := predeployment.LoadInitCode(p.artifactsPath, p.constructorArgs)
+	if err != nil {
 		return err
 	}
 
+	p.deployment = &predeployment.Deployment{
+		InitCode: initCode,
+		Storage:  make(map[types.Hash]types.Hash),
+	}
+
+	if p.saltRaw != "" {
+		if p.addressRaw != "" {
+			return errAddressAndSalt
+		}
+
+		saltRaw, err := hex.DecodeHex(p.saltRaw)
+		if err != nil || len(saltRaw) > types.HashLength {
+			return fmt.Errorf("invalid salt: %s", p.saltRaw)
+		}
+
+		salt := types.BytesToHash(saltRaw)
+		p.deployment.Salt = &salt
+	}
+
+	for _, raw := range p.storageRaw {
+		slot, value, found := strings.Cut(raw, ":")
+		if !found {
+			return fmt.Errorf("invalid storage %s, expected <slot>:<value>", raw)
+		}
+
+		slotRaw, err := hex.DecodeHex(slot)
+		if err != nil || len(slotRaw) > types.HashLength {
+			return fmt.Errorf("invalid storage slot: %s", slot)
+		}
+
+		valueRaw, err := hex.DecodeHex(value)
+		if err != nil || len(valueRaw) > types.HashLength {
+			return fmt.Errorf("invalid storage value: %s", value)
+		}
+
+		p.deployment.Storage[types.BytesToHash(slotRaw)] = types.BytesToHash(valueRaw)
+	}
+
+	for slot, raw := range map[types.Hash]string{
+		predeployment.ProxyImplementationSlot: p.proxyImplRaw,
+		predeployment.ProxyAdminSlot:          p.proxyAdminRaw,
+	} {
+		if raw == "" {
+			continue
+		}
+
+		if err := types.IsValidAddress(raw); err != nil {
+			return fmt.Errorf("invalid proxy address %s: %w", raw, err)
+		}
+
+		p.deployment.Storage[slot] = types.BytesToHash(types.StringToAddress(raw).Bytes())
+	}
+
+	for _, raw := range p.initCallsRaw {
+		input, err := hex.DecodeHex(raw)
+		if err != nil {
+			return fmt.Errorf("invalid init call %s: %w", raw, err)
+		}
+
+		p.deployment.InitCalls = append(p.deployment.InitCalls, input)
+	}
+
 	return nil
 }
 
@@ -138,10 +255,15 @@ func (p *predeployParams) updateGenesisConfig() error {
 		return errAddressTaken
 	}
 
-	predeployAccount, err := predeployment.GenerateGenesisAccountFromFile(
-		p.artifactsPath,
-		p.constructorArgs,
-		p.address,
+	if p.deterministicDeployer {
+		p.genesisConfig.Genesis.Alloc[p.address] = deterministicDeployerAccount()
+
+		return nil
+	}
+
+	predeployAccount, err := predeployment.GenerateGenesisAccount(
+		p.deployment,
+		p.genesisConfig.Genesis.Alloc,
 		p.genesisConfig.Params.ChainID,
 	)
 	if err != nil {
@@ -150,9 +272,23 @@ func (p *predeployParams) updateGenesisConfig() error {
 
 	p.genesisConfig.Genesis.Alloc[p.address] = predeployAccount
 
+	// the deterministically deployed contracts come with the deployment proxy,
+	// so that they can be deployed to the same addresses on the other chains
+	if p.deployment.Salt != nil && p.genesisConfig.Genesis.Alloc[predeployment.DeterministicDeployerAddr] == nil {
+		p.genesisConfig.Genesis.Alloc[predeployment.DeterministicDeployerAddr] = deterministicDeployerAccount()
+	}
+
 	return nil
 }
 
+// deterministicDeployerAccount returns the genesis account of the deterministic deployment proxy
+func deterministicDeployerAccount() *chain.GenesisAccount {
+	return &chain.GenesisAccount{
+		Balance: big.NewInt(0),
+		Code:    predeployment.DeterministicDeployerCode,
+	}
+}
+
 func (p *predeployParams) overrideGenesisConfig() error {
 	// Remove the current genesis configuration from disk
 	if err := os.Remove(p.genesisPath); err != nil {
@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/bitmap"
@@ -132,10 +133,92 @@ func parseBurnContractInfo(burnContractInfoRaw string) (*polybft.BurnContractInf
 	}, nil
 }
 
+// parseFeeDistribution parses provided fee distribution information and returns the fee distribution config
+func parseFeeDistribution(feeDistributionRaw string) (*chain.FeeDistribution, error) {
+	// <proposer share>:<staker pool share>:<treasury share>:<staker pool>:<treasury>[:<block>]
+	parts := strings.Split(feeDistributionRaw, ":")
+	if len(parts) < 5 || len(parts) > 6 {
+		return nil, fmt.Errorf("expected format: " +
+			"<proposer share>:<staker pool share>:<treasury share>:<staker pool>:<treasury>[:<block>]")
+	}
+
+	shares := make([]uint64, 3)
+
+	for i := range shares {
+		share, err := common.ParseUint64orHex(&parts[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse fee share %s: %w", parts[i], err)
+		}
+
+		shares[i] = share
+	}
+
+	for _, address := range parts[3:5] {
+		if err := types.IsValidAddress(address); err != nil {
+			return nil, fmt.Errorf("failed to parse fee receiver address %s: %w", address, err)
+		}
+	}
+
+	distribution := &chain.FeeDistribution{
+		ProposerShare:   shares[0],
+		StakerPoolShare: shares[1],
+		TreasuryShare:   shares[2],
+		StakerPool:      types.StringToAddress(parts[3]),
+		Treasury:        types.StringToAddress(parts[4]),
+	}
+
+	if len(parts) == 6 {
+		block, err := common.ParseUint64orHex(&parts[5])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse block number %s: %w", parts[5], err)
+		}
+
+		distribution.Block = block
+	}
+
+	if err := distribution.Validate(); err != nil {
+		return nil, err
+	}
+
+	return distribution, nil
+}
+
+// parseStatefulPrecompile parses provided stateful precompile information
+// and returns the precompile config along with the block activating it
+func parseStatefulPrecompile(statefulPrecompileRaw string) (*chain.StatefulPrecompileConfig, uint64, error) {
+	// <name>:<address>[:<block>]
+	parts := strings.Split(statefulPrecompileRaw, ":")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" {
+		return nil, 0, fmt.Errorf("expected format: <name>:<address>[:<block>]")
+	}
+
+	if err := types.IsValidAddress(parts[1]); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse contract address %s: %w", parts[1], err)
+	}
+
+	var block uint64
+
+	if len(parts) == 3 {
+		blockNum, err := common.ParseUint64orHex(&parts[2])
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to parse block number %s: %w", parts[2], err)
+		}
+
+		block = blockNum
+	}
+
+	return &chain.StatefulPrecompileConfig{
+		Name:    parts[0],
+		Address: types.StringToAddress(parts[1]),
+		Fork:    parts[0],
+	}, block, nil
+}
+
 type baseFeeInfo struct {
 	baseFee            uint64
 	baseFeeEM          uint64
 	baseFeeChangeDenom uint64
+	minBaseFee         uint64
 }
 
 // parseBaseFeeConfig parses provided base fee configuration and returns baseFeeInfo
@@ -144,10 +227,11 @@ func parseBaseFeeConfig(baseFeeConfigRaw string) (*baseFeeInfo, error) {
 		command.DefaultGenesisBaseFee,
 		command.DefaultGenesisBaseFeeEM,
 		command.DefaultGenesisBaseFeeChangeDenom,
+		0,
 	}
 
 	baseFeeConfig := strings.Split(baseFeeConfigRaw, ":")
-	if len(baseFeeConfig) > 3 {
+	if len(baseFeeConfig) > 4 {
 		return baseFeeInfo, errors.New("invalid number of arguments for base fee configuration")
 	}
 
@@ -169,7 +253,7 @@ func parseBaseFeeConfig(baseFeeConfigRaw string) (*baseFeeInfo, error) {
 		baseFeeInfo.baseFeeEM = baseFeeEM
 	}
 
-	if len(baseFeeConfig) == 3 && baseFeeConfig[2] != "" {
+	if len(baseFeeConfig) >= 3 && baseFeeConfig[2] != "" {
 		baseFeeChangeDenom, err := common.ParseUint64orHex(&baseFeeConfig[2])
 		if err != nil {
 			return baseFeeInfo, err
@@ -178,6 +262,15 @@ func parseBaseFeeConfig(baseFeeConfigRaw string) (*baseFeeInfo, error) {
 		baseFeeInfo.baseFeeChangeDenom = baseFeeChangeDenom
 	}
 
+	if len(baseFeeConfig) == 4 && baseFeeConfig[3] != "" {
+		minBaseFee, err := common.ParseUint64orHex(&baseFeeConfig[3])
+		if err != nil {
+			return baseFeeInfo, err
+		}
+
+		baseFeeInfo.minBaseFee = minBaseFee
+	}
+
 	return baseFeeInfo, nil
 }
 
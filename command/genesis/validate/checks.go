@@ -0,0 +1,444 @@
+package validate
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/multiformats/go-multiaddr"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/consensus/ibft"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft"
+	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Issue is an inconsistency found in the genesis
+type Issue struct {
+	Severity string `json:"severity"`
+	Field    string `json:"field"`
+	Message  string `json:"message"`
+}
+
+// forkOrder lists the forks which have to be activated in order, each one depends on all the previous ones
+var forkOrder = []string{
+	chain.Homestead,
+	chain.EIP150,
+	chain.EIP155,
+	chain.EIP158,
+	chain.Byzantium,
+	chain.Constantinople,
+	chain.Petersburg,
+	chain.Istanbul,
+	chain.London,
+	chain.LondonFix,
+}
+
+// maxUint256 is the maximal balance of an account
+var maxUint256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+// genesisChecker collects the issues of the genesis
+type genesisChecker struct {
+	config *chain.Chain
+	issues []*Issue
+}
+
+// validateGenesis statically checks the genesis and returns the issues found, ordered by their severity
+func validateGenesis(config *chain.Chain) []*Issue {
+	c := &genesisChecker{config: config, issues: make([]*Issue, 0)}
+
+	if config.Genesis == nil {
+		c.errorf("genesis", "the genesis section is missing")
+	}
+
+	if config.Params == nil {
+		c.errorf("params", "the params section is missing")
+	}
+
+	if len(c.issues) > 0 {
+		return c.issues
+	}
+
+	c.checkParams()
+	c.checkForks()
+	c.checkBaseFee()
+	c.checkCodeSizeLimits()
+	c.checkFeeDistribution()
+	c.checkPremine()
+
+	switch config.Params.GetEngine() {
+	case string(server.PolyBFTConsensus):
+		c.checkPolyBFT()
+	case string(server.IBFTConsensus):
+		c.checkIBFT()
+	}
+
+	sort.SliceStable(c.issues, func(i, j int) bool {
+		return c.issues[i].Severity == SeverityError && c.issues[j].Severity != SeverityError
+	})
+
+	return c.issues
+}
+
+func (c *genesisChecker) errorf(field, format string, args ...interface{}) {
+	c.issues = append(c.issues, &Issue{Severity: SeverityError, Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *genesisChecker) warnf(field, format string, args ...interface{}) {
+	c.issues = append(c.issues, &Issue{Severity: SeverityWarning, Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *genesisChecker) checkParams() {
+	if c.config.Params.ChainID <= 0 {
+		c.errorf("params.chainID", "the chain ID has to be positive, got %d", c.config.Params.ChainID)
+	}
+
+	if len(c.config.Params.Engine) != 1 {
+		c.errorf("params.engine", "exactly one consensus engine has to be configured, got %d", len(c.config.Params.Engine))
+	}
+
+	if c.config.Genesis.GasLimit == 0 {
+		c.warnf("genesis.gasLimit", "the gas limit isn't set, the default of %d is used", chain.GenesisGasLimit)
+	}
+
+	if c.config.Genesis.GasUsed > c.config.Genesis.GasLimit && c.config.Genesis.GasLimit != 0 {
+		c.errorf("genesis.gasUsed", "the gas used %d exceeds the gas limit %d",
+			c.config.Genesis.GasUsed, c.config.Genesis.GasLimit)
+	}
+}
+
+func (c *genesisChecker) checkForks() {
+	forks := c.config.Params.Forks
+	if forks == nil || len(*forks) == 0 {
+		c.warnf("params.forks", "no fork is enabled")
+
+		return
+	}
+
+	for name := range *forks {
+		if _, ok := (*chain.AllForksEnabled)[name]; !ok {
+			c.errorf("params.forks."+name, "unknown fork %s is ignored by the client", name)
+		}
+	}
+
+	// each fork has to be enabled, and no earlier than all the forks it depends on
+	var (
+		prevName  string
+		prevBlock uint64
+	)
+
+	for i, name := range forkOrder {
+		fork, ok := (*forks)[name]
+		if !ok {
+			for _, later := range forkOrder[i+1:] {
+				if _, ok := (*forks)[later]; ok {
+					c.errorf("params.forks."+name, "the fork isn't enabled, but the %s fork depending on it is", later)
+
+					break
+				}
+			}
+
+			continue
+		}
+
+		if prevName != "" && fork.Block < prevBlock {
+			c.errorf("params.forks."+name, "the fork is enabled at block %d, before the %s fork it depends on (block %d)",
+				fork.Block, prevName, prevBlock)
+		}
+
+		prevName, prevBlock = name, fork.Block
+	}
+}
+
+func (c *genesisChecker) checkBaseFee() {
+	if c.config.Params.Forks == nil {
+		return
+	}
+
+	if _, ok := (*c.config.Params.Forks)[chain.London]; !ok {
+		return
+	}
+
+	if c.config.Genesis.BaseFeeEM == 0 {
+		c.errorf("genesis.baseFeeEM", "the base fee elasticity multiplier has to be set when the london fork is enabled")
+	}
+
+	if c.config.Genesis.BaseFeeChangeDenom == 0 {
+		c.errorf("genesis.baseFeeChangeDenom",
+			"the base fee change denominator has to be set when the london fork is enabled")
+	}
+
+	if len(c.config.Params.BurnContract) == 0 {
+		c.errorf("params.burnContract", "the burn contract has to be set when the london fork is enabled")
+	}
+
+	for name, fork := range *c.config.Params.Forks {
+		if fork.Params == nil {
+			continue
+		}
+
+		if fork.Params.BaseFeeEM != nil && *fork.Params.BaseFeeEM == 0 {
+			c.errorf("params.forks."+name+".params.baseFeeEM", "the base fee elasticity multiplier has to be positive")
+		}
+
+		if fork.Params.BaseFeeChangeDenom != nil && *fork.Params.BaseFeeChangeDenom == 0 {
+			c.errorf("params.forks."+name+".params.baseFeeChangeDenom",
+				"the base fee change denominator has to be positive")
+		}
+	}
+}
+
+func (c *genesisChecker) checkCodeSizeLimits() {
+	if c.config.Params.Forks == nil {
+		return
+	}
+
+	for name, fork := range *c.config.Params.Forks {
+		if fork.Params == nil {
+			continue
+		}
+
+		if fork.Params.MaxCodeSize != nil && *fork.Params.MaxCodeSize == 0 {
+			c.errorf("params.forks."+name+".params.maxCodeSize", "the max contract code size has to be positive")
+		}
+
+		if fork.Params.MaxInitCodeSize != nil && *fork.Params.MaxInitCodeSize == 0 {
+			c.errorf("params.forks."+name+".params.maxInitCodeSize", "the max init code size has to be positive")
+		}
+	}
+}
+
+func (c *genesisChecker) checkFeeDistribution() {
+	distribution := c.config.Params.FeeDistribution
+	if distribution == nil {
+		return
+	}
+
+	if err := distribution.Validate(); err != nil {
+		c.errorf("params.feeDistribution", "%v", err)
+	}
+
+	if distribution.IncludeBaseFee &&
+		(c.config.Params.Forks == nil || !c.config.Params.Forks.IsActive(chain.London, distribution.Block)) {
+		c.warnf("params.feeDistribution.includeBaseFee",
+			"the base fees are shared only once the london fork is enabled")
+	}
+}
+
+func (c *genesisChecker) checkPremine() {
+	total := new(big.Int)
+
+	for addr, account := range c.config.Genesis.Alloc {
+		if account == nil || account.Balance == nil {
+			continue
+		}
+
+		field := fmt.Sprintf("genesis.alloc.%s.balance", addr)
+
+		if account.Balance.Sign() < 0 {
+			c.errorf(field, "the balance is negative")
+
+			continue
+		}
+
+		if account.Balance.Cmp(maxUint256) > 0 {
+			c.errorf(field, "the balance %s overflows uint256", account.Balance)
+
+			continue
+		}
+
+		total.Add(total, account.Balance)
+	}
+
+	if total.Cmp(maxUint256) > 0 {
+		c.errorf("genesis.alloc", "the total premined balance %s overflows uint256", total)
+	}
+}
+
+func (c *genesisChecker) checkIBFT() {
+	engine, ok := c.config.Params.Engine[string(server.IBFTConsensus)].(map[string]interface{})
+	if !ok {
+		c.errorf("params.engine.ibft", "the ibft config isn't an object")
+
+		return
+	}
+
+	if rawEpochSize, ok := engine[ibft.KeyEpochSize]; ok {
+		epochSize, ok := rawEpochSize.(float64)
+		if !ok || epochSize < 1 {
+			c.errorf("params.engine.ibft.epochSize", "the epoch size has to be a positive number, got %v", rawEpochSize)
+		}
+	}
+}
+
+func (c *genesisChecker) checkPolyBFT() {
+	config, err := polybft.GetPolyBFTConfig(c.config)
+	if err != nil {
+		c.errorf("params.engine.polybft", "invalid polybft config: %v", err)
+
+		return
+	}
+
+	if config.EpochSize == 0 {
+		c.errorf("params.engine.polybft.epochSize", "the epoch size has to be positive")
+	}
+
+	if config.SprintSize == 0 {
+		c.errorf("params.engine.polybft.sprintSize", "the sprint size has to be positive")
+	}
+
+	if config.EpochSize != 0 && config.SprintSize != 0 && config.EpochSize%config.SprintSize != 0 {
+		c.errorf("params.engine.polybft.sprintSize", "the epoch size %d isn't divisible by the sprint size %d",
+			config.EpochSize, config.SprintSize)
+	}
+
+	if config.BlockTime.Duration <= 0 {
+		c.errorf("params.engine.polybft.blockTime", "the block time has to be positive")
+	}
+
+	if config.MaxValidatorSetSize != 0 && config.MinValidatorSetSize > config.MaxValidatorSetSize {
+		c.errorf("params.engine.polybft.minValidatorSetSize",
+			"the minimal validator set size %d exceeds the maximal one %d",
+			config.MinValidatorSetSize, config.MaxValidatorSetSize)
+	}
+
+	if config.NativeTokenConfig == nil {
+		c.errorf("params.engine.polybft.nativeTokenConfig", "the native token config is missing")
+	}
+
+	c.checkPolyBFTValidators(config)
+
+	if config.IsBridgeEnabled() {
+		c.checkBridgeAddresses(config.Bridge)
+	}
+}
+
+func (c *genesisChecker) checkPolyBFTValidators(config polybft.PolyBFTConfig) {
+	const field = "params.engine.polybft.initialValidatorSet"
+
+	validators := config.InitialValidatorSet
+	count := uint64(len(validators))
+
+	if count == 0 {
+		c.errorf(field, "the initial validator set is empty")
+
+		return
+	}
+
+	if count < config.MinValidatorSetSize {
+		c.errorf(field, "the initial validator set has %d validators, less than the minimum of %d",
+			count, config.MinValidatorSetSize)
+	}
+
+	if config.MaxValidatorSetSize != 0 && count > config.MaxValidatorSetSize {
+		c.errorf(field, "the initial validator set has %d validators, more than the maximum of %d",
+			count, config.MaxValidatorSetSize)
+	}
+
+	addresses := make(map[types.Address]int, len(validators))
+	blsKeys := make(map[string]int, len(validators))
+
+	for i, v := range validators {
+		vField := fmt.Sprintf("%s[%d]", field, i)
+
+		if v.Address == types.ZeroAddress {
+			c.errorf(vField+".address", "the validator address is zero")
+		} else if j, ok := addresses[v.Address]; ok {
+			c.errorf(vField+".address", "the validator %s is duplicated at index %d", v.Address, j)
+		} else {
+			addresses[v.Address] = i
+		}
+
+		if _, err := v.UnmarshalBLSPublicKey(); err != nil {
+			c.errorf(vField+".blsKey", "invalid BLS public key of the validator %s: %v", v.Address, err)
+		} else if j, ok := blsKeys[v.BlsKey]; ok {
+			c.errorf(vField+".blsKey", "the BLS key of the validator %s is duplicated at index %d", v.Address, j)
+		} else {
+			blsKeys[v.BlsKey] = i
+		}
+
+		if v.Stake == nil || v.Stake.Sign() <= 0 {
+			c.errorf(vField+".stake", "the stake of the validator %s has to be positive", v.Address)
+		}
+
+		if v.MultiAddr == "" {
+			c.warnf(vField+".multiAddr", "the validator %s has no multiaddr, it can't be used as a bootnode", v.Address)
+		} else if _, err := multiaddr.NewMultiaddr(v.MultiAddr); err != nil {
+			c.errorf(vField+".multiAddr", "invalid multiaddr of the validator %s: %v", v.Address, err)
+		}
+	}
+}
+
+// checkBridgeAddresses reports the zero and the colliding rootchain contract addresses of the bridge
+func (c *genesisChecker) checkBridgeAddresses(bridge *polybft.BridgeConfig) {
+	const field = "params.engine.polybft.bridge"
+
+	addresses := []struct {
+		name     string
+		addr     types.Address
+		required bool
+	}{
+		{"stateSenderAddress", bridge.StateSenderAddr, true},
+		{"checkpointManagerAddress", bridge.CheckpointManagerAddr, true},
+		{"exitHelperAddress", bridge.ExitHelperAddr, true},
+		{"erc20PredicateAddress", bridge.RootERC20PredicateAddr, false},
+		{"erc20ChildMintablePredicateAddress", bridge.ChildMintableERC20PredicateAddr, false},
+		{"nativeERC20Address", bridge.RootNativeERC20Addr, false},
+		{"erc721PredicateAddress", bridge.RootERC721PredicateAddr, false},
+		{"erc721ChildMintablePredicateAddress", bridge.ChildMintableERC721PredicateAddr, false},
+		{"erc1155PredicateAddress", bridge.RootERC1155PredicateAddr, false},
+		{"erc1155ChildMintablePredicateAddress", bridge.ChildMintableERC1155PredicateAddr, false},
+		{"childERC20Address", bridge.ChildERC20Addr, false},
+		{"childERC721Address", bridge.ChildERC721Addr, false},
+		{"childERC1155Address", bridge.ChildERC1155Addr, false},
+		{"customSupernetManagerAddr", bridge.CustomSupernetManagerAddr, false},
+		{"stakeManagerAddr", bridge.StakeManagerAddr, false},
+		{"stakeTokenAddr", bridge.StakeTokenAddr, false},
+		{"blsAddr", bridge.BLSAddress, false},
+		{"bn256G2Addr", bridge.BN256G2Address, false},
+	}
+
+	seen := make(map[types.Address]string, len(addresses))
+
+	for _, a := range addresses {
+		if a.addr == types.ZeroAddress {
+			if a.required {
+				c.errorf(field+"."+a.name, "the contract address is zero")
+			}
+
+			continue
+		}
+
+		if other, ok := seen[a.addr]; ok {
+			c.errorf(field+"."+a.name, "the contract address %s collides with %s", a.addr, other)
+
+			continue
+		}
+
+		seen[a.addr] = a.name
+	}
+
+	if bridge.JSONRPCEndpoint == "" {
+		c.errorf(field+".jsonRPCEndpoint", "the JSON-RPC endpoint of the rootchain is missing")
+	}
+
+	for addr := range bridge.EventTrackerStartBlocks {
+		if _, ok := seen[addr]; !ok {
+			c.warnf(field+".eventTrackerStartBlocks",
+				"the start block is set for %s, which isn't a bridge contract", addr)
+		}
+	}
+
+	for addr := range bridge.EventTrackerNumBlockConfirmations {
+		if _, ok := seen[addr]; !ok {
+			c.warnf(field+".eventTrackerNumBlockConfirmations",
+				"the number of block confirmations is set for %s, which isn't a bridge contract", addr)
+		}
+	}
+}
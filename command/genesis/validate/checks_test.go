@@ -0,0 +1,228 @@
+package validate
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/bls"
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/forkmanager"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func newTestGenesis(t *testing.T) (*chain.Chain, *polybft.PolyBFTConfig) {
+	t.Helper()
+
+	validators := make([]*validator.GenesisValidator, 2)
+
+	for i := range validators {
+		key, err := bls.GenerateBlsKey()
+		require.NoError(t, err)
+
+		validators[i] = &validator.GenesisValidator{
+			Address:   types.BytesToAddress([]byte{byte(i + 1)}),
+			BlsKey:    hex.EncodeToString(key.PublicKey().Marshal()),
+			Stake:     big.NewInt(1000),
+			MultiAddr: "/ip4/127.0.0.1/tcp/30301/p2p/16Uiu2HAmJxxH1tScDX2rLGSU9exnuvZKNM9SoK3v315azp68DLPW",
+		}
+	}
+
+	polyBFTConfig := &polybft.PolyBFTConfig{
+		InitialValidatorSet: validators,
+		EpochSize:           10,
+		SprintSize:          5,
+		BlockTime:           common.Duration{Duration: 2 * time.Second},
+		NativeTokenConfig:   &polybft.TokenConfig{Name: "Polygon", Symbol: "MATIC", Decimals: 18},
+		MinValidatorSetSize: 1,
+		MaxValidatorSetSize: 100,
+		Bridge: &polybft.BridgeConfig{
+			StateSenderAddr:       types.StringToAddress("0x10"),
+			CheckpointManagerAddr: types.StringToAddress("0x11"),
+			ExitHelperAddr:        types.StringToAddress("0x12"),
+			JSONRPCEndpoint:       "http://127.0.0.1:8545",
+		},
+	}
+
+	return &chain.Chain{
+		Genesis: &chain.Genesis{
+			GasLimit:           5242880,
+			BaseFeeEM:          chain.GenesisBaseFeeEM,
+			BaseFeeChangeDenom: chain.BaseFeeChangeDenom,
+			Alloc: map[types.Address]*chain.GenesisAccount{
+				types.StringToAddress("0x20"): {Balance: big.NewInt(100)},
+			},
+		},
+		Params: &chain.Params{
+			ChainID:      100,
+			Forks:        chain.AllForksEnabled.Copy(),
+			Engine:       map[string]interface{}{polybft.ConsensusName: polyBFTConfig},
+			BurnContract: map[uint64]types.Address{0: types.StringToAddress("0x30")},
+		},
+	}, polyBFTConfig
+}
+
+func issueFields(issues []*Issue) []string {
+	fields := make([]string, len(issues))
+	for i, issue := range issues {
+		fields[i] = issue.Field
+	}
+
+	return fields
+}
+
+func TestValidateGenesis(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid genesis", func(t *testing.T) {
+		t.Parallel()
+
+		config, _ := newTestGenesis(t)
+
+		require.Empty(t, validateGenesis(config))
+	})
+
+	t.Run("fork ordering", func(t *testing.T) {
+		t.Parallel()
+
+		config, _ := newTestGenesis(t)
+		config.Params.Forks.SetFork(chain.Byzantium, chain.NewFork(100))
+		config.Params.Forks.RemoveFork(chain.Homestead)
+		config.Params.Forks.SetFork("shanghai", chain.NewFork(0))
+
+		require.ElementsMatch(t, []string{
+			"params.forks.shanghai",
+			"params.forks.homestead",
+			"params.forks.constantinople",
+		}, issueFields(validateGenesis(config)))
+	})
+
+	t.Run("london without burn contract", func(t *testing.T) {
+		t.Parallel()
+
+		config, _ := newTestGenesis(t)
+		config.Params.BurnContract = nil
+		config.Genesis.BaseFeeEM = 0
+
+		require.ElementsMatch(t, []string{"genesis.baseFeeEM", "params.burnContract"},
+			issueFields(validateGenesis(config)))
+	})
+
+	t.Run("fork with zero base fee params", func(t *testing.T) {
+		t.Parallel()
+
+		zero := uint64(0)
+
+		config, _ := newTestGenesis(t)
+		config.Params.Forks.SetFork(chain.London, chain.Fork{
+			Params: &forkmanager.ForkParams{BaseFeeEM: &zero, BaseFeeChangeDenom: &zero},
+		})
+
+		require.ElementsMatch(t, []string{
+			"params.forks.london.params.baseFeeEM",
+			"params.forks.london.params.baseFeeChangeDenom",
+		}, issueFields(validateGenesis(config)))
+	})
+
+	t.Run("fork with zero code size limits", func(t *testing.T) {
+		t.Parallel()
+
+		zero := uint64(0)
+
+		config, _ := newTestGenesis(t)
+		config.Params.Forks.SetFork(chain.London, chain.Fork{
+			Params: &forkmanager.ForkParams{MaxCodeSize: &zero, MaxInitCodeSize: &zero},
+		})
+
+		require.ElementsMatch(t, []string{
+			"params.forks.london.params.maxCodeSize",
+			"params.forks.london.params.maxInitCodeSize",
+		}, issueFields(validateGenesis(config)))
+	})
+
+	t.Run("invalid fee distribution", func(t *testing.T) {
+		t.Parallel()
+
+		config, _ := newTestGenesis(t)
+		config.Params.FeeDistribution = &chain.FeeDistribution{
+			ProposerShare:   5000,
+			StakerPoolShare: 5000,
+		}
+
+		require.Equal(t, []string{"params.feeDistribution"}, issueFields(validateGenesis(config)))
+	})
+
+	t.Run("premine overflow", func(t *testing.T) {
+		t.Parallel()
+
+		config, _ := newTestGenesis(t)
+		config.Genesis.Alloc[types.StringToAddress("0x21")] = &chain.GenesisAccount{Balance: new(big.Int).Set(maxUint256)}
+
+		require.Equal(t, []string{"genesis.alloc"}, issueFields(validateGenesis(config)))
+
+		config.Genesis.Alloc[types.StringToAddress("0x21")].Balance.Add(maxUint256, big.NewInt(1))
+
+		require.Equal(t, []string{"genesis.alloc.0x0000000000000000000000000000000000000021.balance"},
+			issueFields(validateGenesis(config)))
+	})
+
+	t.Run("validators", func(t *testing.T) {
+		t.Parallel()
+
+		config, polyBFTConfig := newTestGenesis(t)
+		polyBFTConfig.InitialValidatorSet[1].Address = polyBFTConfig.InitialValidatorSet[0].Address
+		polyBFTConfig.InitialValidatorSet[1].BlsKey = "0xinvalid"
+		polyBFTConfig.InitialValidatorSet[1].MultiAddr = "not a multiaddr"
+		polyBFTConfig.MinValidatorSetSize = 4
+
+		require.ElementsMatch(t, []string{
+			"params.engine.polybft.initialValidatorSet",
+			"params.engine.polybft.initialValidatorSet[1].address",
+			"params.engine.polybft.initialValidatorSet[1].blsKey",
+			"params.engine.polybft.initialValidatorSet[1].multiAddr",
+		}, issueFields(validateGenesis(config)))
+	})
+
+	t.Run("epoch and sprint sizes", func(t *testing.T) {
+		t.Parallel()
+
+		config, polyBFTConfig := newTestGenesis(t)
+		polyBFTConfig.SprintSize = 3
+
+		issues := validateGenesis(config)
+		require.Equal(t, []string{"params.engine.polybft.sprintSize"}, issueFields(issues))
+		require.Contains(t, issues[0].Message, "isn't divisible")
+	})
+
+	t.Run("bridge address collisions", func(t *testing.T) {
+		t.Parallel()
+
+		config, polyBFTConfig := newTestGenesis(t)
+		polyBFTConfig.Bridge.RootERC20PredicateAddr = polyBFTConfig.Bridge.StateSenderAddr
+		polyBFTConfig.Bridge.ExitHelperAddr = types.ZeroAddress
+
+		require.ElementsMatch(t, []string{
+			"params.engine.polybft.bridge.erc20PredicateAddress",
+			"params.engine.polybft.bridge.exitHelperAddress",
+		}, issueFields(validateGenesis(config)))
+	})
+	t.Run("event tracker configuration of non bridge contracts", func(t *testing.T) {
+		t.Parallel()
+
+		config, polyBFTConfig := newTestGenesis(t)
+		polyBFTConfig.Bridge.EventTrackerNumBlockConfirmations = map[types.Address]uint64{
+			polyBFTConfig.Bridge.StateSenderAddr: 10,
+			types.StringToAddress("0x99"):        20,
+		}
+
+		issues := validateGenesis(config)
+		require.Equal(t, []string{"params.engine.polybft.bridge.eventTrackerNumBlockConfirmations"}, issueFields(issues))
+		require.Equal(t, SeverityWarning, issues[0].Severity)
+	})
+}
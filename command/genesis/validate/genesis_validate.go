@@ -0,0 +1,56 @@
+package validate
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+)
+
+func GetCommand() *cobra.Command {
+	genesisValidateCmd := &cobra.Command{
+		Use: "validate",
+		Short: "Statically checks the genesis for inconsistencies, such as the fork ordering, the validator keys, " +
+			"the premine overflow, the bridge address collisions and the epoch and sprint sizes",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(genesisValidateCmd)
+
+	return genesisValidateCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		chainFlag,
+		fmt.Sprintf("./%s", command.DefaultGenesisFileName),
+		"the genesis file to validate",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.initRawParams()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	result := &GenesisValidateResult{
+		Path:   params.genesisPath,
+		Issues: validateGenesis(params.genesisConfig),
+	}
+
+	if !result.hasErrors() {
+		outputter.SetCommandResult(result)
+
+		return
+	}
+
+	// the issues are printed before the error, which sets the exit code
+	outputter.WriteCommandResult(result)
+	outputter.SetError(errGenesisInvalid)
+}
@@ -0,0 +1,37 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+)
+
+const (
+	chainFlag = "chain"
+)
+
+var (
+	params = &validateParams{}
+)
+
+var (
+	errGenesisInvalid = errors.New("the genesis has errors")
+)
+
+type validateParams struct {
+	genesisPath string
+
+	genesisConfig *chain.Chain
+}
+
+func (p *validateParams) initRawParams() error {
+	cc, err := chain.Import(p.genesisPath)
+	if err != nil {
+		return fmt.Errorf("failed to load the genesis from %s: %w", p.genesisPath, err)
+	}
+
+	p.genesisConfig = cc
+
+	return nil
+}
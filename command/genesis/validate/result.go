@@ -0,0 +1,49 @@
+package validate
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type GenesisValidateResult struct {
+	Path   string   `json:"path"`
+	Issues []*Issue `json:"issues"`
+}
+
+func (r *GenesisValidateResult) hasErrors() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *GenesisValidateResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[GENESIS VALIDATION]\n")
+
+	if len(r.Issues) == 0 {
+		buffer.WriteString(fmt.Sprintf("No issues found in %s\n", r.Path))
+
+		return buffer.String()
+	}
+
+	buffer.WriteString(fmt.Sprintf("Found %d issue(s) in %s\n\n", len(r.Issues), r.Path))
+
+	rows := make([]string, len(r.Issues)+1)
+	rows[0] = "Severity|Field|Issue"
+
+	for i, issue := range r.Issues {
+		rows[i+1] = fmt.Sprintf("%s|%s|%s", issue.Severity, issue.Field, issue.Message)
+	}
+
+	buffer.WriteString(helper.FormatList(rows))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
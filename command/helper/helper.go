@@ -7,13 +7,17 @@ import (
 	"math/big"
 	"net"
 	"net/url"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command"
 	ibftOp "github.com/0xPolygon/polygon-edge/consensus/ibft/proto"
 	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/tlsconfig"
 	"github.com/0xPolygon/polygon-edge/server"
 	"github.com/0xPolygon/polygon-edge/server/proto"
 	txpoolOp "github.com/0xPolygon/polygon-edge/txpool/proto"
@@ -21,11 +25,20 @@ import (
 	"github.com/ryanuber/columnize"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 var ErrBlockTrackerPollInterval = errors.New("block tracker poll interval must be greater than 0")
 
+// grpcClientTLS holds the TLS settings of the GRPC client connections
+var grpcClientTLS struct {
+	enabled    bool
+	caCert     string
+	clientCert string
+	clientKey  string
+}
+
 type ClientCloseResult struct {
 	Message string `json:"message"`
 }
@@ -47,7 +60,46 @@ func HandleSignals(
 	closeFn func(),
 	outputter command.OutputFormatter,
 ) error {
-	signalCh := common.GetTerminationSignalCh()
+	return handleSignals(common.GetTerminationSignalCh(), closeFn, outputter)
+}
+
+// HandleSignalsWithReload handles the signals as HandleSignals does,
+// except for SIGHUP which calls the reload callback instead of shutting down
+func HandleSignalsWithReload(
+	closeFn func(),
+	reloadFn func(),
+	outputter command.OutputFormatter,
+) error {
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
+
+	reloadCh := make(chan os.Signal, 1)
+	signal.Notify(reloadCh, syscall.SIGHUP)
+
+	defer signal.Stop(reloadCh)
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	go func() {
+		for {
+			select {
+			case <-reloadCh:
+				reloadFn()
+			case <-doneCh:
+				return
+			}
+		}
+	}()
+
+	return handleSignals(signalCh, closeFn, outputter)
+}
+
+func handleSignals(
+	signalCh <-chan os.Signal,
+	closeFn func(),
+	outputter command.OutputFormatter,
+) error {
 	sig := <-signalCh
 
 	closeMessage := fmt.Sprintf("\n[SIGNAL] Caught signal: %v\n", sig)
@@ -141,9 +193,20 @@ func GetIBFTOperatorClientConnection(address string) (
 	return ibftOp.NewIbftOperatorClient(conn), nil
 }
 
-// GetGRPCConnection returns a grpc client connection
+// GetGRPCConnection returns a grpc client connection, which is secured by TLS if the TLS flags are set
 func GetGRPCConnection(address string) (*grpc.ClientConn, error) {
-	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	creds := insecure.NewCredentials()
+
+	if grpcClientTLS.enabled || grpcClientTLS.caCert != "" || grpcClientTLS.clientCert != "" {
+		tlsConfig, err := tlsconfig.ClientTLSConfig(grpcClientTLS.caCert, grpcClientTLS.clientCert, grpcClientTLS.clientKey)
+		if err != nil {
+			return nil, err
+		}
+
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(creds))
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to server: %w", err)
 	}
@@ -189,6 +252,38 @@ func RegisterGRPCAddressFlag(cmd *cobra.Command) {
 	)
 }
 
+// RegisterGRPCTLSFlags registers the GRPC client TLS flags for all child commands
+func RegisterGRPCTLSFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().BoolVar(
+		&grpcClientTLS.enabled,
+		command.GRPCTLSFlag,
+		false,
+		"connect to the GRPC interface over TLS, verified by the system CA certificates "+
+			"unless the CA certificate is set (default false)",
+	)
+
+	cmd.PersistentFlags().StringVar(
+		&grpcClientTLS.caCert,
+		command.GRPCTLSCACertFlag,
+		"",
+		"the PEM CA certificates the GRPC server certificate is verified by, implies --"+command.GRPCTLSFlag,
+	)
+
+	cmd.PersistentFlags().StringVar(
+		&grpcClientTLS.clientCert,
+		command.GRPCTLSClientCertFlag,
+		"",
+		"the PEM client certificate presented to the GRPC server requiring mutual TLS, implies --"+command.GRPCTLSFlag,
+	)
+
+	cmd.PersistentFlags().StringVar(
+		&grpcClientTLS.clientKey,
+		command.GRPCTLSClientKeyFlag,
+		"",
+		"the PEM private key of the GRPC client certificate",
+	)
+}
+
 // RegisterLegacyGRPCAddressFlag registers the legacy GRPC address flag for all child commands
 func RegisterLegacyGRPCAddressFlag(cmd *cobra.Command) {
 	cmd.PersistentFlags().String(
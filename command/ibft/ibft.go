@@ -18,6 +18,7 @@ func GetCommand() *cobra.Command {
 	}
 
 	helper.RegisterGRPCAddressFlag(ibftCmd)
+	helper.RegisterGRPCTLSFlags(ibftCmd)
 
 	registerSubcommands(ibftCmd)
 
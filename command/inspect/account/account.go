@@ -0,0 +1,63 @@
+package account
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	accountCmd := &cobra.Command{
+		Use:     "account",
+		Short:   "Prints the balance, the nonce and the code of the account",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(accountCmd)
+
+	helper.SetRequiredFlags(accountCmd, []string{addressFlag})
+
+	return accountCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.address,
+		addressFlag,
+		"",
+		"the address of the account",
+	)
+
+	cmd.Flags().StringVar(
+		&params.block,
+		blockFlag,
+		"",
+		"the number of the block whose state is queried, decimal or hex (default latest)",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	account, err := params.getAccount(helper.GetJSONRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(&AccountResult{
+		Address:    params.accountAddress.String(),
+		Balance:    account.balance.String(),
+		Nonce:      account.nonce,
+		CodeSize:   len(account.code),
+		IsContract: len(account.code) > 0,
+	})
+}
@@ -0,0 +1,80 @@
+package account
+
+import (
+	"math/big"
+
+	"github.com/umbracle/ethgo"
+
+	inspectHelper "github.com/0xPolygon/polygon-edge/command/inspect/helper"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	addressFlag = "address"
+	blockFlag   = "block"
+)
+
+var (
+	params = &accountParams{}
+)
+
+type accountParams struct {
+	address string
+	block   string
+
+	accountAddress ethgo.Address
+	blockNumber    ethgo.BlockNumber
+}
+
+type accountState struct {
+	balance *big.Int
+	nonce   uint64
+	code    []byte
+}
+
+func (p *accountParams) validateFlags() error {
+	if err := types.IsValidAddress(p.address); err != nil {
+		return err
+	}
+
+	p.accountAddress = ethgo.Address(types.StringToAddress(p.address))
+
+	blockNumber, err := inspectHelper.ParseBlockNumber(p.block)
+	if err != nil {
+		return err
+	}
+
+	p.blockNumber = blockNumber
+
+	return nil
+}
+
+func (p *accountParams) getAccount(jsonRPCAddress string) (*accountState, error) {
+	eth, err := inspectHelper.NewEthClient(jsonRPCAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := eth.GetBalance(p.accountAddress, p.blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := eth.GetNonce(p.accountAddress, p.blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	rawCode, err := eth.GetCode(p.accountAddress, p.blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := hex.DecodeHex(rawCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountState{balance: balance, nonce: nonce, code: code}, nil
+}
@@ -0,0 +1,32 @@
+package account
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type AccountResult struct {
+	Address    string `json:"address"`
+	Balance    string `json:"balance"`
+	Nonce      uint64 `json:"nonce"`
+	CodeSize   int    `json:"code_size"`
+	IsContract bool   `json:"is_contract"`
+}
+
+func (r *AccountResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[ACCOUNT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Address|%s", r.Address),
+		fmt.Sprintf("Balance|%s", r.Balance),
+		fmt.Sprintf("Nonce|%d", r.Nonce),
+		fmt.Sprintf("Code Size|%d", r.CodeSize),
+		fmt.Sprintf("Contract|%t", r.IsContract),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,64 @@
+package block
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	blockCmd := &cobra.Command{
+		Use:     "block",
+		Short:   "Prints the header and the transactions of the block",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(blockCmd)
+
+	return blockCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.number,
+		numberFlag,
+		"",
+		"the number of the block, decimal or hex (default latest)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.hash,
+		hashFlag,
+		"",
+		"the hash of the block",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.full,
+		fullFlag,
+		false,
+		"print the transactions of the block instead of their hashes",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(numberFlag, hashFlag)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	block, err := params.getBlock(helper.GetJSONRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(newBlockResult(block))
+}
@@ -0,0 +1,79 @@
+package block
+
+import (
+	"errors"
+
+	"github.com/umbracle/ethgo"
+
+	inspectHelper "github.com/0xPolygon/polygon-edge/command/inspect/helper"
+)
+
+const (
+	numberFlag = "number"
+	hashFlag   = "hash"
+	fullFlag   = "full"
+)
+
+var (
+	params = &blockParams{}
+)
+
+var (
+	errBlockNotFound = errors.New("block not found")
+)
+
+type blockParams struct {
+	number string
+	hash   string
+	full   bool
+
+	blockNumber ethgo.BlockNumber
+	blockHash   *ethgo.Hash
+}
+
+func (p *blockParams) validateFlags() error {
+	if p.hash != "" {
+		hash, err := inspectHelper.ParseHash(p.hash)
+		if err != nil {
+			return err
+		}
+
+		p.blockHash = &hash
+
+		return nil
+	}
+
+	blockNumber, err := inspectHelper.ParseBlockNumber(p.number)
+	if err != nil {
+		return err
+	}
+
+	p.blockNumber = blockNumber
+
+	return nil
+}
+
+func (p *blockParams) getBlock(jsonRPCAddress string) (*ethgo.Block, error) {
+	eth, err := inspectHelper.NewEthClient(jsonRPCAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	var block *ethgo.Block
+
+	if p.blockHash != nil {
+		block, err = eth.GetBlockByHash(*p.blockHash, p.full)
+	} else {
+		block, err = eth.GetBlockByNumber(p.blockNumber, p.full)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if block == nil {
+		return nil, errBlockNotFound
+	}
+
+	return block, nil
+}
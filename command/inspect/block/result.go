@@ -0,0 +1,141 @@
+package block
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/umbracle/ethgo"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+)
+
+type BlockTxResult struct {
+	Hash  string `json:"hash"`
+	From  string `json:"from"`
+	To    string `json:"to,omitempty"`
+	Value string `json:"value"`
+	Nonce uint64 `json:"nonce"`
+	Gas   uint64 `json:"gas"`
+}
+
+type BlockResult struct {
+	Number       uint64           `json:"number"`
+	Hash         string           `json:"hash"`
+	ParentHash   string           `json:"parent_hash"`
+	StateRoot    string           `json:"state_root"`
+	Timestamp    uint64           `json:"timestamp"`
+	Miner        string           `json:"miner"`
+	GasLimit     uint64           `json:"gas_limit"`
+	GasUsed      uint64           `json:"gas_used"`
+	BaseFee      string           `json:"base_fee,omitempty"`
+	ExtraData    string           `json:"extra_data"`
+	TxHashes     []string         `json:"tx_hashes,omitempty"`
+	Transactions []*BlockTxResult `json:"transactions,omitempty"`
+}
+
+func newBlockResult(block *ethgo.Block) *BlockResult {
+	res := &BlockResult{
+		Number:     block.Number,
+		Hash:       block.Hash.String(),
+		ParentHash: block.ParentHash.String(),
+		StateRoot:  block.StateRoot.String(),
+		Timestamp:  block.Timestamp,
+		Miner:      block.Miner.String(),
+		GasLimit:   block.GasLimit,
+		GasUsed:    block.GasUsed,
+		ExtraData:  hex.EncodeToHex(block.ExtraData),
+	}
+
+	if block.BaseFee != nil {
+		res.BaseFee = block.BaseFee.String()
+	}
+
+	for _, hash := range block.TransactionsHashes {
+		res.TxHashes = append(res.TxHashes, hash.String())
+	}
+
+	for _, tx := range block.Transactions {
+		txRes := &BlockTxResult{
+			Hash:  tx.Hash.String(),
+			From:  tx.From.String(),
+			Value: "0",
+			Nonce: tx.Nonce,
+			Gas:   tx.Gas,
+		}
+
+		if tx.To != nil {
+			txRes.To = tx.To.String()
+		}
+
+		if tx.Value != nil {
+			txRes.Value = tx.Value.String()
+		}
+
+		res.Transactions = append(res.Transactions, txRes)
+	}
+
+	return res
+}
+
+func (r *BlockResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[BLOCK]\n")
+
+	vals := []string{
+		fmt.Sprintf("Number|%d", r.Number),
+		fmt.Sprintf("Hash|%s", r.Hash),
+		fmt.Sprintf("Parent Hash|%s", r.ParentHash),
+		fmt.Sprintf("State Root|%s", r.StateRoot),
+		fmt.Sprintf("Timestamp|%d", r.Timestamp),
+		fmt.Sprintf("Miner|%s", r.Miner),
+		fmt.Sprintf("Gas Limit|%d", r.GasLimit),
+		fmt.Sprintf("Gas Used|%d", r.GasUsed),
+	}
+
+	if r.BaseFee != "" {
+		vals = append(vals, fmt.Sprintf("Base Fee|%s", r.BaseFee))
+	}
+
+	vals = append(vals,
+		fmt.Sprintf("Extra Data|%s", r.ExtraData),
+		fmt.Sprintf("Transactions|%d", len(r.TxHashes)+len(r.Transactions)),
+	)
+
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	if len(r.TxHashes) > 0 {
+		buffer.WriteString("\n[TRANSACTIONS]\n")
+
+		rows := make([]string, len(r.TxHashes))
+		for i, hash := range r.TxHashes {
+			rows[i] = fmt.Sprintf("[%d]|%s", i, hash)
+		}
+
+		buffer.WriteString(helper.FormatKV(rows))
+		buffer.WriteString("\n")
+	}
+
+	if len(r.Transactions) > 0 {
+		buffer.WriteString("\n[TRANSACTIONS]\n")
+
+		rows := make([]string, len(r.Transactions)+1)
+		rows[0] = "Hash|From|To|Value|Nonce|Gas"
+
+		for i, tx := range r.Transactions {
+			to := tx.To
+			if to == "" {
+				to = "(contract creation)"
+			}
+
+			rows[i+1] = fmt.Sprintf("%s|%s|%s|%s|%d|%d", tx.Hash, tx.From, to, tx.Value, tx.Nonce, tx.Gas)
+		}
+
+		buffer.WriteString(helper.FormatList(rows))
+		buffer.WriteString("\n")
+	}
+
+	return buffer.String()
+}
@@ -0,0 +1,48 @@
+package checkpoint
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	checkpointCmd := &cobra.Command{
+		Use:     "checkpoint",
+		Short:   "Prints the checkpoint of the polybft block along with its state commitment and challenge window",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(checkpointCmd)
+
+	return checkpointCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.number,
+		numberFlag,
+		"",
+		"the number of the block, decimal or hex (default latest)",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	result, err := params.getCheckpoint(helper.GetJSONRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(result)
+}
@@ -0,0 +1,67 @@
+package checkpoint
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/umbracle/ethgo"
+
+	inspectHelper "github.com/0xPolygon/polygon-edge/command/inspect/helper"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft"
+)
+
+const (
+	numberFlag = "number"
+)
+
+var (
+	params = &checkpointParams{}
+)
+
+var (
+	errBlockNotFound = errors.New("block not found")
+)
+
+type checkpointParams struct {
+	number string
+
+	blockNumber ethgo.BlockNumber
+}
+
+func (p *checkpointParams) validateFlags() error {
+	blockNumber, err := inspectHelper.ParseBlockNumber(p.number)
+	if err != nil {
+		return err
+	}
+
+	p.blockNumber = blockNumber
+
+	return nil
+}
+
+func (p *checkpointParams) getCheckpoint(jsonRPCAddress string) (*CheckpointResult, error) {
+	eth, err := inspectHelper.NewEthClient(jsonRPCAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := eth.GetBlockByNumber(p.blockNumber, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if block == nil {
+		return nil, errBlockNotFound
+	}
+
+	extra, err := polybft.GetIbftExtra(block.ExtraData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the extra of the block %d: %w", block.Number, err)
+	}
+
+	if extra.Checkpoint == nil {
+		return nil, fmt.Errorf("block %d doesn't have a checkpoint", block.Number)
+	}
+
+	return newCheckpointResult(block, extra.Checkpoint), nil
+}
@@ -0,0 +1,90 @@
+package checkpoint
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/umbracle/ethgo"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft"
+)
+
+type StateCommitmentResult struct {
+	StateRoot       string `json:"state_root"`
+	ChallengeWindow uint64 `json:"challenge_window"`
+	// ChallengeWindowEnd is the earliest end of the challenge window, as the window starts
+	// once the checkpoint is submitted to the rootchain, which is after the block is finalized
+	ChallengeWindowEnd uint64 `json:"challenge_window_end"`
+}
+
+type CheckpointResult struct {
+	BlockNumber           uint64                 `json:"block_number"`
+	BlockHash             string                 `json:"block_hash"`
+	Timestamp             uint64                 `json:"timestamp"`
+	BlockRound            uint64                 `json:"block_round"`
+	EpochNumber           uint64                 `json:"epoch_number"`
+	CurrentValidatorsHash string                 `json:"current_validators_hash"`
+	NextValidatorsHash    string                 `json:"next_validators_hash"`
+	EventRoot             string                 `json:"event_root"`
+	StateCommitment       *StateCommitmentResult `json:"state_commitment,omitempty"`
+}
+
+func newCheckpointResult(block *ethgo.Block, checkpoint *polybft.CheckpointData) *CheckpointResult {
+	res := &CheckpointResult{
+		BlockNumber:           block.Number,
+		BlockHash:             block.Hash.String(),
+		Timestamp:             block.Timestamp,
+		BlockRound:            checkpoint.BlockRound,
+		EpochNumber:           checkpoint.EpochNumber,
+		CurrentValidatorsHash: checkpoint.CurrentValidatorsHash.String(),
+		NextValidatorsHash:    checkpoint.NextValidatorsHash.String(),
+		EventRoot:             checkpoint.EventRoot.String(),
+	}
+
+	if commitment := checkpoint.StateCommitment; commitment != nil {
+		res.StateCommitment = &StateCommitmentResult{
+			StateRoot:          commitment.StateRoot.String(),
+			ChallengeWindow:    commitment.ChallengeWindow,
+			ChallengeWindowEnd: block.Timestamp + commitment.ChallengeWindow,
+		}
+	}
+
+	return res
+}
+
+func (r *CheckpointResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[CHECKPOINT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Block Number|%d", r.BlockNumber),
+		fmt.Sprintf("Block Hash|%s", r.BlockHash),
+		fmt.Sprintf("Timestamp|%d", r.Timestamp),
+		fmt.Sprintf("Block Round|%d", r.BlockRound),
+		fmt.Sprintf("Epoch Number|%d", r.EpochNumber),
+		fmt.Sprintf("Current Validators Hash|%s", r.CurrentValidatorsHash),
+		fmt.Sprintf("Next Validators Hash|%s", r.NextValidatorsHash),
+		fmt.Sprintf("Event Root|%s", r.EventRoot),
+	}))
+	buffer.WriteString("\n")
+
+	buffer.WriteString("\n[STATE COMMITMENT]\n")
+
+	if r.StateCommitment == nil {
+		buffer.WriteString("No state commitment in the checkpoint\n")
+
+		return buffer.String()
+	}
+
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("State Root|%s", r.StateCommitment.StateRoot),
+		fmt.Sprintf("Challenge Window|%s", time.Duration(r.StateCommitment.ChallengeWindow)*time.Second),
+		fmt.Sprintf("Challenge Window End (earliest)|%s",
+			time.Unix(int64(r.StateCommitment.ChallengeWindowEnd), 0).UTC().Format(time.RFC3339)),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
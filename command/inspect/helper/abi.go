@@ -0,0 +1,168 @@
+package helper
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+)
+
+// ABIDecoder decodes the calls and the event logs of the contracts whose ABIs it is loaded with
+type ABIDecoder struct {
+	events  map[ethgo.Hash]*abi.Event
+	methods map[string]*abi.Method
+}
+
+// LoadABIDir loads all the .json files of the directory, which are either the plain ABIs
+// or the compiler artifacts holding the ABI in the "abi" field
+func LoadABIDir(dir string) (*ABIDecoder, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := &ABIDecoder{
+		events:  make(map[ethgo.Hash]*abi.Event),
+		methods: make(map[string]*abi.Method),
+	}
+
+	for _, file := range files {
+		contractABI, err := readABIFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the ABI %s: %w", file, err)
+		}
+
+		for _, event := range contractABI.Events {
+			decoder.events[event.ID()] = event
+		}
+
+		for _, method := range contractABI.Methods {
+			decoder.methods[string(method.ID())] = method
+		}
+	}
+
+	return decoder, nil
+}
+
+func readABIFile(path string) (*abi.ABI, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "{") {
+		var artifact struct {
+			ABI json.RawMessage `json:"abi"`
+		}
+
+		if err := json.Unmarshal(data, &artifact); err != nil {
+			return nil, err
+		}
+
+		if len(artifact.ABI) == 0 {
+			return nil, fmt.Errorf("no abi field")
+		}
+
+		data = artifact.ABI
+	}
+
+	return abi.NewABI(string(data))
+}
+
+// DecodedLog is the event log along with its decoded event, if it is known
+type DecodedLog struct {
+	Address string   `json:"address"`
+	Topics  []string `json:"topics"`
+	Data    string   `json:"data"`
+
+	Event string                 `json:"event,omitempty"`
+	Args  map[string]interface{} `json:"args,omitempty"`
+}
+
+// DecodeLogs decodes the event logs, the decoder can be nil
+func (d *ABIDecoder) DecodeLogs(logs []*ethgo.Log) []*DecodedLog {
+	decoded := make([]*DecodedLog, len(logs))
+
+	for i, log := range logs {
+		decoded[i] = &DecodedLog{
+			Address: log.Address.String(),
+			Topics:  make([]string, len(log.Topics)),
+			Data:    hex.EncodeToHex(log.Data),
+		}
+
+		for j, topic := range log.Topics {
+			decoded[i].Topics[j] = topic.String()
+		}
+
+		if d == nil || len(log.Topics) == 0 {
+			continue
+		}
+
+		event, ok := d.events[log.Topics[0]]
+		if !ok {
+			continue
+		}
+
+		if args, err := event.ParseLog(log); err == nil {
+			decoded[i].Event = event.Sig()
+			decoded[i].Args = args
+		}
+	}
+
+	return decoded
+}
+
+// DecodedCall is the method called by the transaction input and its arguments
+type DecodedCall struct {
+	Method string                 `json:"method"`
+	Args   map[string]interface{} `json:"args"`
+}
+
+// DecodeInput decodes the transaction input, returns nil if the method is not known
+// or the decoder is nil
+func (d *ABIDecoder) DecodeInput(input []byte) *DecodedCall {
+	if d == nil || len(input) < 4 {
+		return nil
+	}
+
+	method, ok := d.methods[string(input[:4])]
+	if !ok {
+		return nil
+	}
+
+	args, err := abi.Decode(method.Inputs, input[4:])
+	if err != nil {
+		return nil
+	}
+
+	argsMap, ok := args.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	return &DecodedCall{Method: method.Sig(), Args: argsMap}
+}
+
+// FormatArgs formats the decoded arguments as the name=value pairs ordered by the names
+func FormatArgs(args map[string]interface{}) string {
+	names := make([]string, 0, len(args))
+	for name := range args {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = fmt.Sprintf("%s=%v", name, args[name])
+	}
+
+	return strings.Join(pairs, ", ")
+}
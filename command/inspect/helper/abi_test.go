@@ -0,0 +1,89 @@
+package helper
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+)
+
+const testABI = `[
+	{"type":"event","name":"Transfer","anonymous":false,"inputs":[
+		{"name":"from","type":"address","indexed":true},
+		{"name":"to","type":"address","indexed":true},
+		{"name":"value","type":"uint256","indexed":false}]},
+	{"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[
+		{"name":"to","type":"address"},
+		{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]}
+]`
+
+func TestABIDecoder(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	// one plain ABI and one compiler artifact
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Token.json"), []byte(testABI), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Artifact.json"),
+		[]byte(`{"contractName":"Empty","abi":[]}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not an ABI"), 0600))
+
+	decoder, err := LoadABIDir(dir)
+	require.NoError(t, err)
+
+	tokenABI := abi.MustNewABI(testABI)
+	from := ethgo.Address{0x1}
+	to := ethgo.Address{0x2}
+
+	data, err := abi.MustNewType("uint256").Encode(big.NewInt(100))
+	require.NoError(t, err)
+
+	logs := decoder.DecodeLogs([]*ethgo.Log{
+		{
+			Address: ethgo.Address{0x3},
+			Topics: []ethgo.Hash{
+				tokenABI.Events["Transfer"].ID(),
+				ethgo.BytesToHash(from.Bytes()),
+				ethgo.BytesToHash(to.Bytes()),
+			},
+			Data: data,
+		},
+		{
+			Address: ethgo.Address{0x3},
+			Topics:  []ethgo.Hash{{0x4}},
+		},
+	})
+
+	require.Len(t, logs, 2)
+	require.Equal(t, "Transfer(address,address,uint256)", logs[0].Event)
+	require.Equal(t, from, logs[0].Args["from"])
+	require.Equal(t, to, logs[0].Args["to"])
+	require.Equal(t, big.NewInt(100), logs[0].Args["value"])
+	require.Equal(t, "from=0x0100000000000000000000000000000000000000, "+
+		"to=0x0200000000000000000000000000000000000000, value=100", FormatArgs(logs[0].Args))
+
+	// the unknown event is left undecoded
+	require.Empty(t, logs[1].Event)
+	require.Len(t, logs[1].Topics, 1)
+
+	input, err := tokenABI.Methods["transfer"].Encode([]interface{}{to, big.NewInt(5)})
+	require.NoError(t, err)
+
+	call := decoder.DecodeInput(input)
+	require.NotNil(t, call)
+	require.Equal(t, "transfer(address,uint256)", call.Method)
+	require.Equal(t, big.NewInt(5), call.Args["value"])
+
+	require.Nil(t, decoder.DecodeInput([]byte{0x1, 0x2, 0x3, 0x4}))
+
+	// the nil decoder returns the raw logs
+	var nilDecoder *ABIDecoder
+
+	logs = nilDecoder.DecodeLogs([]*ethgo.Log{{Topics: []ethgo.Hash{tokenABI.Events["Transfer"].ID()}}})
+	require.Empty(t, logs[0].Event)
+	require.Nil(t, nilDecoder.DecodeInput(input))
+}
@@ -0,0 +1,67 @@
+package helper
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// ABIDirFlag is the flag of the directory of the contract ABIs used to decode the calls and the logs
+	ABIDirFlag = "abi-dir"
+
+	latestBlock = "latest"
+)
+
+// NewEthClient returns the client of the eth namespace of the JSON-RPC
+func NewEthClient(jsonRPCAddress string) (*jsonrpc.Eth, error) {
+	client, err := jsonrpc.NewClient(jsonRPCAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the JSON-RPC %s: %w", jsonRPCAddress, err)
+	}
+
+	return client.Eth(), nil
+}
+
+// ParseBlockNumber parses the block number, which is either "latest", decimal or hex
+func ParseBlockNumber(raw string) (ethgo.BlockNumber, error) {
+	if raw == "" || raw == latestBlock {
+		return ethgo.Latest, nil
+	}
+
+	num, err := common.ParseUint64orHex(&raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid block number %s: %w", raw, err)
+	}
+
+	if num > math.MaxInt64 {
+		return 0, fmt.Errorf("block number %s is out of range", raw)
+	}
+
+	return ethgo.BlockNumber(num), nil
+}
+
+// ParseHash parses the hex encoded hash of the block or the transaction
+func ParseHash(raw string) (ethgo.Hash, error) {
+	decoded, err := hex.DecodeHex(raw)
+	if err != nil || len(decoded) != types.HashLength {
+		return ethgo.ZeroHash, fmt.Errorf("invalid hash %s", raw)
+	}
+
+	return ethgo.BytesToHash(decoded), nil
+}
+
+// LoadDecoder loads the ABI decoder from the directory, returns nil if the directory is not set
+func LoadDecoder(abiDir string) (*ABIDecoder, error) {
+	if abiDir == "" {
+		return nil, nil
+	}
+
+	return LoadABIDir(abiDir)
+}
@@ -0,0 +1,89 @@
+package helper
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/umbracle/ethgo"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+// ReceiptResult is the receipt of the transaction along with its decoded logs
+type ReceiptResult struct {
+	TxHash            string        `json:"tx_hash"`
+	BlockNumber       uint64        `json:"block_number"`
+	BlockHash         string        `json:"block_hash"`
+	Status            uint64        `json:"status"`
+	GasUsed           uint64        `json:"gas_used"`
+	CumulativeGasUsed uint64        `json:"cumulative_gas_used"`
+	ContractAddress   string        `json:"contract_address,omitempty"`
+	Logs              []*DecodedLog `json:"logs"`
+}
+
+// NewReceiptResult returns the receipt result, the logs are decoded if the decoder is not nil
+func NewReceiptResult(receipt *ethgo.Receipt, decoder *ABIDecoder) *ReceiptResult {
+	res := &ReceiptResult{
+		TxHash:            receipt.TransactionHash.String(),
+		BlockNumber:       receipt.BlockNumber,
+		BlockHash:         receipt.BlockHash.String(),
+		Status:            receipt.Status,
+		GasUsed:           receipt.GasUsed,
+		CumulativeGasUsed: receipt.CumulativeGasUsed,
+		Logs:              decoder.DecodeLogs(receipt.Logs),
+	}
+
+	if receipt.ContractAddress != ethgo.ZeroAddress {
+		res.ContractAddress = receipt.ContractAddress.String()
+	}
+
+	return res
+}
+
+// WriteOutput writes the receipt and its logs to the buffer
+func (r *ReceiptResult) WriteOutput(buffer *bytes.Buffer) {
+	status := "failed"
+	if r.Status == 1 {
+		status = "success"
+	}
+
+	buffer.WriteString("\n[RECEIPT]\n")
+
+	vals := []string{
+		fmt.Sprintf("Tx Hash|%s", r.TxHash),
+		fmt.Sprintf("Block Number|%d", r.BlockNumber),
+		fmt.Sprintf("Block Hash|%s", r.BlockHash),
+		fmt.Sprintf("Status|%s", status),
+		fmt.Sprintf("Gas Used|%d", r.GasUsed),
+		fmt.Sprintf("Cumulative Gas Used|%d", r.CumulativeGasUsed),
+	}
+
+	if r.ContractAddress != "" {
+		vals = append(vals, fmt.Sprintf("Contract Address|%s", r.ContractAddress))
+	}
+
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	for i, log := range r.Logs {
+		buffer.WriteString(fmt.Sprintf("\n[LOG %d]\n", i))
+
+		vals := []string{fmt.Sprintf("Address|%s", log.Address)}
+
+		if log.Event != "" {
+			vals = append(vals,
+				fmt.Sprintf("Event|%s", log.Event),
+				fmt.Sprintf("Args|%s", FormatArgs(log.Args)),
+			)
+		} else {
+			for j, topic := range log.Topics {
+				vals = append(vals, fmt.Sprintf("Topic %d|%s", j, topic))
+			}
+
+			vals = append(vals, fmt.Sprintf("Data|%s", log.Data))
+		}
+
+		buffer.WriteString(helper.FormatKV(vals))
+		buffer.WriteString("\n")
+	}
+}
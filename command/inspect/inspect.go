@@ -0,0 +1,40 @@
+package inspect
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/command/inspect/account"
+	"github.com/0xPolygon/polygon-edge/command/inspect/block"
+	"github.com/0xPolygon/polygon-edge/command/inspect/checkpoint"
+	"github.com/0xPolygon/polygon-edge/command/inspect/receipt"
+	"github.com/0xPolygon/polygon-edge/command/inspect/tx"
+)
+
+func GetCommand() *cobra.Command {
+	inspectCmd := &cobra.Command{
+		Use:   "inspect",
+		Short: "Top level command for inspecting the blocks, the transactions and the accounts of the chain. Only accepts subcommands.",
+	}
+
+	helper.RegisterJSONRPCFlag(inspectCmd)
+
+	registerSubcommands(inspectCmd)
+
+	return inspectCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// inspect block
+		block.GetCommand(),
+		// inspect tx
+		tx.GetCommand(),
+		// inspect receipt
+		receipt.GetCommand(),
+		// inspect account
+		account.GetCommand(),
+		// inspect checkpoint
+		checkpoint.GetCommand(),
+	)
+}
@@ -0,0 +1,60 @@
+package receipt
+
+import (
+	"errors"
+
+	"github.com/umbracle/ethgo"
+
+	inspectHelper "github.com/0xPolygon/polygon-edge/command/inspect/helper"
+)
+
+const (
+	hashFlag = "hash"
+)
+
+var (
+	params = &receiptParams{}
+)
+
+var (
+	errReceiptNotFound = errors.New("receipt not found, the transaction is either unknown or pending")
+)
+
+type receiptParams struct {
+	hash   string
+	abiDir string
+
+	txHash  ethgo.Hash
+	decoder *inspectHelper.ABIDecoder
+}
+
+func (p *receiptParams) validateFlags() error {
+	txHash, err := inspectHelper.ParseHash(p.hash)
+	if err != nil {
+		return err
+	}
+
+	p.txHash = txHash
+
+	p.decoder, err = inspectHelper.LoadDecoder(p.abiDir)
+
+	return err
+}
+
+func (p *receiptParams) getReceipt(jsonRPCAddress string) (*ethgo.Receipt, error) {
+	eth, err := inspectHelper.NewEthClient(jsonRPCAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := eth.GetTransactionReceipt(p.txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if receipt == nil {
+		return nil, errReceiptNotFound
+	}
+
+	return receipt, nil
+}
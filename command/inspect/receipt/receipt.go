@@ -0,0 +1,60 @@
+package receipt
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	inspectHelper "github.com/0xPolygon/polygon-edge/command/inspect/helper"
+)
+
+func GetCommand() *cobra.Command {
+	receiptCmd := &cobra.Command{
+		Use:     "receipt",
+		Short:   "Prints the receipt of the transaction, decoding the logs if the contract ABIs are provided",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(receiptCmd)
+
+	helper.SetRequiredFlags(receiptCmd, []string{hashFlag})
+
+	return receiptCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.hash,
+		hashFlag,
+		"",
+		"the hash of the transaction",
+	)
+
+	cmd.Flags().StringVar(
+		&params.abiDir,
+		inspectHelper.ABIDirFlag,
+		"",
+		"the directory of the contract ABIs (or the compiler artifacts) used to decode the logs",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	receipt, err := params.getReceipt(helper.GetJSONRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(&ReceiptResult{
+		ReceiptResult: inspectHelper.NewReceiptResult(receipt, params.decoder),
+	})
+}
@@ -0,0 +1,19 @@
+package receipt
+
+import (
+	"bytes"
+
+	inspectHelper "github.com/0xPolygon/polygon-edge/command/inspect/helper"
+)
+
+type ReceiptResult struct {
+	*inspectHelper.ReceiptResult
+}
+
+func (r *ReceiptResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	r.WriteOutput(&buffer)
+
+	return buffer.String()
+}
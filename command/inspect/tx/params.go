@@ -0,0 +1,66 @@
+package tx
+
+import (
+	"errors"
+
+	"github.com/umbracle/ethgo"
+
+	inspectHelper "github.com/0xPolygon/polygon-edge/command/inspect/helper"
+)
+
+const (
+	hashFlag = "hash"
+)
+
+var (
+	params = &txParams{}
+)
+
+var (
+	errTxNotFound = errors.New("transaction not found")
+)
+
+type txParams struct {
+	hash   string
+	abiDir string
+
+	txHash  ethgo.Hash
+	decoder *inspectHelper.ABIDecoder
+}
+
+func (p *txParams) validateFlags() error {
+	txHash, err := inspectHelper.ParseHash(p.hash)
+	if err != nil {
+		return err
+	}
+
+	p.txHash = txHash
+
+	p.decoder, err = inspectHelper.LoadDecoder(p.abiDir)
+
+	return err
+}
+
+// getTx returns the transaction and its receipt, the receipt is nil if the transaction is still pending
+func (p *txParams) getTx(jsonRPCAddress string) (*ethgo.Transaction, *ethgo.Receipt, error) {
+	eth, err := inspectHelper.NewEthClient(jsonRPCAddress)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tx, err := eth.GetTransactionByHash(p.txHash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if tx == nil {
+		return nil, nil, errTxNotFound
+	}
+
+	receipt, err := eth.GetTransactionReceipt(p.txHash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tx, receipt, nil
+}
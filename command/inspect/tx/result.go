@@ -0,0 +1,123 @@
+package tx
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/umbracle/ethgo"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	inspectHelper "github.com/0xPolygon/polygon-edge/command/inspect/helper"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+)
+
+type TxResult struct {
+	Hash        string                     `json:"hash"`
+	Type        uint8                      `json:"type"`
+	From        string                     `json:"from"`
+	To          string                     `json:"to,omitempty"`
+	Value       string                     `json:"value"`
+	Nonce       uint64                     `json:"nonce"`
+	Gas         uint64                     `json:"gas"`
+	GasPrice    uint64                     `json:"gas_price,omitempty"`
+	GasTipCap   string                     `json:"max_priority_fee_per_gas,omitempty"`
+	GasFeeCap   string                     `json:"max_fee_per_gas,omitempty"`
+	Input       string                     `json:"input"`
+	Call        *inspectHelper.DecodedCall `json:"call,omitempty"`
+	BlockNumber uint64                     `json:"block_number"`
+	Pending     bool                       `json:"pending"`
+
+	Receipt *inspectHelper.ReceiptResult `json:"receipt,omitempty"`
+}
+
+func newTxResult(tx *ethgo.Transaction, receipt *ethgo.Receipt, decoder *inspectHelper.ABIDecoder) *TxResult {
+	res := &TxResult{
+		Hash:        tx.Hash.String(),
+		Type:        uint8(tx.Type),
+		From:        tx.From.String(),
+		Value:       "0",
+		Nonce:       tx.Nonce,
+		Gas:         tx.Gas,
+		GasPrice:    tx.GasPrice,
+		Input:       hex.EncodeToHex(tx.Input),
+		Call:        decoder.DecodeInput(tx.Input),
+		BlockNumber: tx.BlockNumber,
+		Pending:     receipt == nil,
+	}
+
+	if tx.To != nil {
+		res.To = tx.To.String()
+	}
+
+	if tx.Value != nil {
+		res.Value = tx.Value.String()
+	}
+
+	if tx.MaxPriorityFeePerGas != nil {
+		res.GasTipCap = tx.MaxPriorityFeePerGas.String()
+	}
+
+	if tx.MaxFeePerGas != nil {
+		res.GasFeeCap = tx.MaxFeePerGas.String()
+	}
+
+	if receipt != nil {
+		res.Receipt = inspectHelper.NewReceiptResult(receipt, decoder)
+	}
+
+	return res
+}
+
+func (r *TxResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[TRANSACTION]\n")
+
+	to := r.To
+	if to == "" {
+		to = "(contract creation)"
+	}
+
+	vals := []string{
+		fmt.Sprintf("Hash|%s", r.Hash),
+		fmt.Sprintf("Type|%d", r.Type),
+		fmt.Sprintf("From|%s", r.From),
+		fmt.Sprintf("To|%s", to),
+		fmt.Sprintf("Value|%s", r.Value),
+		fmt.Sprintf("Nonce|%d", r.Nonce),
+		fmt.Sprintf("Gas|%d", r.Gas),
+	}
+
+	if r.GasFeeCap != "" {
+		vals = append(vals,
+			fmt.Sprintf("Max Priority Fee Per Gas|%s", r.GasTipCap),
+			fmt.Sprintf("Max Fee Per Gas|%s", r.GasFeeCap),
+		)
+	} else {
+		vals = append(vals, fmt.Sprintf("Gas Price|%d", r.GasPrice))
+	}
+
+	if r.Pending {
+		vals = append(vals, "Block Number|pending")
+	} else {
+		vals = append(vals, fmt.Sprintf("Block Number|%d", r.BlockNumber))
+	}
+
+	if r.Call != nil {
+		vals = append(vals,
+			fmt.Sprintf("Method|%s", r.Call.Method),
+			fmt.Sprintf("Args|%s", inspectHelper.FormatArgs(r.Call.Args)),
+		)
+	} else {
+		vals = append(vals, fmt.Sprintf("Input|%s", r.Input))
+	}
+
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	if r.Receipt != nil {
+		r.Receipt.WriteOutput(&buffer)
+	}
+
+	return buffer.String()
+}
@@ -0,0 +1,58 @@
+package tx
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	inspectHelper "github.com/0xPolygon/polygon-edge/command/inspect/helper"
+)
+
+func GetCommand() *cobra.Command {
+	txCmd := &cobra.Command{
+		Use:     "tx",
+		Short:   "Prints the transaction and its receipt, decoding the call and the logs if the contract ABIs are provided",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(txCmd)
+
+	helper.SetRequiredFlags(txCmd, []string{hashFlag})
+
+	return txCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.hash,
+		hashFlag,
+		"",
+		"the hash of the transaction",
+	)
+
+	cmd.Flags().StringVar(
+		&params.abiDir,
+		inspectHelper.ABIDirFlag,
+		"",
+		"the directory of the contract ABIs (or the compiler artifacts) used to decode the call and the logs",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	tx, receipt, err := params.getTx(helper.GetJSONRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(newTxResult(tx, receipt, params.decoder))
+}
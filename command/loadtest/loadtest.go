@@ -0,0 +1,148 @@
+package loadtest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/command/polybftsecrets"
+	rootHelper "github.com/0xPolygon/polygon-edge/command/rootchain/helper"
+	"github.com/0xPolygon/polygon-edge/loadtest/runner"
+)
+
+var params loadTestParams
+
+func GetCommand() *cobra.Command {
+	loadTestCmd := &cobra.Command{
+		Use: "loadtest",
+		Short: "Sends the transactions at the target TPS to the chain, reporting the achieved TPS, " +
+			"the inclusion latencies and the gas utilization of the blocks",
+		PreRunE: runPreRun,
+		RunE:    runCommand,
+	}
+
+	setFlags(loadTestCmd)
+
+	return loadTestCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.accountDir,
+		polybftsecrets.AccountDirFlag,
+		"",
+		polybftsecrets.AccountDirFlagDesc,
+	)
+
+	cmd.Flags().StringVar(
+		&params.accountConfig,
+		polybftsecrets.AccountConfigFlag,
+		"",
+		polybftsecrets.AccountConfigFlagDesc,
+	)
+
+	cmd.Flags().StringVar(
+		&params.privateKey,
+		polybftsecrets.PrivateKeyFlag,
+		"",
+		"hex-encoded private key of the funded account, which funds the virtual users",
+	)
+
+	cmd.Flags().StringVar(
+		&params.mode,
+		modeFlag,
+		string(runner.EOAMode),
+		"the transactions sent by the load test: eoa (native token transfers), "+
+			"erc20 (ERC-20 token transfers) or deploy (contract deployments)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.tps,
+		tpsFlag,
+		100,
+		"the target number of the transactions sent per second",
+	)
+
+	cmd.Flags().DurationVar(
+		&params.duration,
+		durationFlag,
+		time.Minute,
+		"the duration of sending the transactions",
+	)
+
+	cmd.Flags().IntVar(
+		&params.vus,
+		vusFlag,
+		10,
+		"the number of the virtual users, the accounts sending the transactions in turns",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.dynamicTxs,
+		dynamicTxsFlag,
+		false,
+		"send the dynamic fee transactions instead of the legacy ones",
+	)
+
+	cmd.Flags().DurationVar(
+		&params.inclusionTimeout,
+		inclusionTimeoutFlag,
+		time.Minute,
+		"the time waited for the inclusion of the sent transactions",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(polybftsecrets.AccountDirFlag, polybftsecrets.AccountConfigFlag)
+	cmd.MarkFlagsMutuallyExclusive(polybftsecrets.PrivateKeyFlag, polybftsecrets.AccountConfigFlag)
+	cmd.MarkFlagsMutuallyExclusive(polybftsecrets.PrivateKeyFlag, polybftsecrets.AccountDirFlag)
+
+	helper.RegisterJSONRPCFlag(cmd)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	params.jsonRPC = helper.GetJSONRPCAddress(cmd)
+
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) error {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	senderKey, err := rootHelper.GetECDSAKey(params.privateKey, params.accountDir, params.accountConfig)
+	if err != nil {
+		return err
+	}
+
+	config := &runner.Config{
+		JSONRPCAddr:      params.jsonRPC,
+		SenderKey:        senderKey,
+		Mode:             runner.Mode(params.mode),
+		TPS:              params.tps,
+		Duration:         params.duration,
+		VUs:              params.vus,
+		DynamicTxs:       params.dynamicTxs,
+		InclusionTimeout: params.inclusionTimeout,
+	}
+
+	// the progress would break the JSON output
+	if !helper.GetJSONLogFormat(cmd) {
+		config.Writer = outputter
+	}
+
+	loadTestRunner, err := runner.NewRunner(config)
+	if err != nil {
+		return fmt.Errorf("failed to create the load test runner: %w", err)
+	}
+
+	result, err := loadTestRunner.Run()
+	if err != nil {
+		return fmt.Errorf("load test failed: %w", err)
+	}
+
+	outputter.SetCommandResult(newLoadTestResult(result))
+
+	return nil
+}
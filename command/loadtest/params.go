@@ -0,0 +1,138 @@
+package loadtest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	sidechainHelper "github.com/0xPolygon/polygon-edge/command/sidechain"
+	"github.com/0xPolygon/polygon-edge/loadtest/runner"
+)
+
+const (
+	modeFlag             = "mode"
+	tpsFlag              = "tps"
+	durationFlag         = "duration"
+	vusFlag              = "vus"
+	dynamicTxsFlag       = "dynamic-txs"
+	inclusionTimeoutFlag = "inclusion-timeout"
+)
+
+var (
+	errInvalidMode     = errors.New("invalid mode, expected one of eoa, erc20 or deploy")
+	errInvalidTPS      = errors.New("the target TPS must be greater than zero")
+	errInvalidVUs      = errors.New("at least one virtual user is required")
+	errInvalidDuration = errors.New("the duration of the load test must be at least one second")
+)
+
+type loadTestParams struct {
+	accountDir    string
+	accountConfig string
+	privateKey    string
+	jsonRPC       string
+
+	mode             string
+	tps              uint64
+	duration         time.Duration
+	vus              int
+	dynamicTxs       bool
+	inclusionTimeout time.Duration
+}
+
+func (p *loadTestParams) validateFlags() error {
+	switch runner.Mode(p.mode) {
+	case runner.EOAMode, runner.ERC20Mode, runner.DeployMode:
+	default:
+		return errInvalidMode
+	}
+
+	if p.tps == 0 {
+		return errInvalidTPS
+	}
+
+	if p.vus < 1 {
+		return errInvalidVUs
+	}
+
+	if p.duration < time.Second {
+		return errInvalidDuration
+	}
+
+	if p.privateKey == "" {
+		if err := sidechainHelper.ValidateSecretFlags(p.accountDir, p.accountConfig); err != nil {
+			return err
+		}
+	}
+
+	// validate jsonrpc address
+	_, err := helper.ParseJSONRPCAddress(p.jsonRPC)
+
+	return err
+}
+
+type loadTestResult struct {
+	Mode          string  `json:"mode"`
+	TargetTPS     uint64  `json:"targetTps"`
+	Duration      string  `json:"duration"`
+	Sent          uint64  `json:"sent"`
+	SendFailed    uint64  `json:"sendFailed"`
+	Included      uint64  `json:"included"`
+	LastSendError string  `json:"lastSendError,omitempty"`
+	AchievedTPS   float64 `json:"achievedTps"`
+	// LatencyP50 and LatencyP99 are the inclusion latencies in milliseconds
+	LatencyP50     int64   `json:"latencyP50Ms"`
+	LatencyP99     int64   `json:"latencyP99Ms"`
+	FirstBlock     uint64  `json:"firstBlock"`
+	LastBlock      uint64  `json:"lastBlock"`
+	GasUtilization float64 `json:"gasUtilization"`
+}
+
+func newLoadTestResult(result *runner.Result) *loadTestResult {
+	return &loadTestResult{
+		Mode:           string(result.Mode),
+		TargetTPS:      result.TargetTPS,
+		Duration:       result.Duration.String(),
+		Sent:           result.Sent,
+		SendFailed:     result.SendFailed,
+		Included:       result.Included,
+		LastSendError:  result.LastSendError,
+		AchievedTPS:    result.AchievedTPS,
+		LatencyP50:     result.LatencyP50.Milliseconds(),
+		LatencyP99:     result.LatencyP99.Milliseconds(),
+		FirstBlock:     result.FirstBlock,
+		LastBlock:      result.LastBlock,
+		GasUtilization: result.GasUtilization,
+	}
+}
+
+func (r *loadTestResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[LOAD TEST]\n")
+
+	vals := []string{
+		fmt.Sprintf("Mode|%s", r.Mode),
+		fmt.Sprintf("Duration|%s", r.Duration),
+		fmt.Sprintf("Target TPS|%d", r.TargetTPS),
+		fmt.Sprintf("Achieved TPS|%.2f", r.AchievedTPS),
+		fmt.Sprintf("Sent|%d", r.Sent),
+		fmt.Sprintf("Failed To Send|%d", r.SendFailed),
+		fmt.Sprintf("Included|%d", r.Included),
+		fmt.Sprintf("Not Included|%d", r.Sent-r.Included),
+		fmt.Sprintf("Inclusion Latency p50|%dms", r.LatencyP50),
+		fmt.Sprintf("Inclusion Latency p99|%dms", r.LatencyP99),
+		fmt.Sprintf("Blocks|%d - %d", r.FirstBlock, r.LastBlock),
+		fmt.Sprintf("Gas Utilization|%.2f%%", r.GasUtilization),
+	}
+
+	if r.LastSendError != "" {
+		vals = append(vals, fmt.Sprintf("Last Send Error|%s", r.LastSendError))
+	}
+
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,70 @@
+package monitor
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// clearScreen moves the cursor to the top left corner and clears the terminal
+const clearScreen = "\033[H\033[2J"
+
+// dashboard polls the status of the node and redraws it at every refresh
+type dashboard struct {
+	client    proto.SystemClient
+	outputter command.OutputFormatter
+	interval  time.Duration
+	redraw    bool
+
+	// the head of the previous refresh, to calculate the block rate
+	prevHead     int64
+	prevHeadTime time.Time
+}
+
+// run refreshes the dashboard until the done channel is closed, the failed refreshes are shown
+// on the dashboard, so it keeps running while the node restarts
+func (d *dashboard) run(doneCh <-chan os.Signal) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		d.refresh()
+
+		select {
+		case <-doneCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *dashboard) refresh() {
+	ctx, cancel := context.WithTimeout(context.Background(), d.interval)
+	defer cancel()
+
+	now := time.Now()
+	result := &DashboardResult{UpdatedAt: now.UTC().Format(time.RFC3339)}
+
+	status, err := d.client.GetStatus(ctx, &empty.Empty{})
+	if err != nil {
+		result.Error = err.Error()
+	} else {
+		result.fill(status, now)
+
+		if !d.prevHeadTime.IsZero() && status.Current.Number >= d.prevHead {
+			result.BlocksPerMinute = float64(status.Current.Number-d.prevHead) / now.Sub(d.prevHeadTime).Minutes()
+		}
+
+		d.prevHead, d.prevHeadTime = status.Current.Number, now
+	}
+
+	if d.redraw {
+		_, _ = os.Stdout.WriteString(clearScreen)
+	}
+
+	d.outputter.WriteCommandResult(result)
+}
@@ -17,24 +17,69 @@ import (
 
 func GetCommand() *cobra.Command {
 	monitorCmd := &cobra.Command{
-		Use:   "monitor",
-		Short: "Starts logging block add / remove events on the blockchain",
-		Run:   runCommand,
+		Use: "monitor",
+		Short: "Shows the live dashboard of the node: the head, the peers, the tx pool, the gas usage, " +
+			"the event tracker lag and the consensus round (use --events to log the block add / remove events instead)",
+		PreRunE: runPreRun,
+		Run:     runCommand,
 	}
 
 	helper.RegisterGRPCAddressFlag(monitorCmd)
+	helper.RegisterGRPCTLSFlags(monitorCmd)
+	setFlags(monitorCmd)
 
 	return monitorCmd
 }
 
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&params.events,
+		eventsFlag,
+		false,
+		"logs the block add / remove events on the blockchain instead of showing the dashboard",
+	)
+
+	cmd.Flags().DurationVar(
+		&params.interval,
+		intervalFlag,
+		defaultRefreshInterval,
+		"the refresh interval of the dashboard",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
 func runCommand(cmd *cobra.Command, _ []string) {
 	outputter := command.InitializeOutputter(cmd)
 	defer outputter.WriteOutput()
 
-	subscribeToEvents(
-		outputter,
-		helper.GetGRPCAddress(cmd),
-	)
+	if params.events {
+		subscribeToEvents(
+			outputter,
+			helper.GetGRPCAddress(cmd),
+		)
+
+		return
+	}
+
+	client, err := helper.GetSystemClientConnection(helper.GetGRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	d := &dashboard{
+		client:    client,
+		outputter: outputter,
+		interval:  params.interval,
+		// the screen is only redrawn for the terminal output, the JSON output is a status per line
+		redraw: !cmd.Flag(command.JSONOutputFlag).Changed,
+	}
+
+	d.run(common.GetTerminationSignalCh())
 }
 
 func subscribeToEvents(
@@ -0,0 +1,32 @@
+package monitor
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	eventsFlag   = "events"
+	intervalFlag = "interval"
+
+	defaultRefreshInterval = 2 * time.Second
+)
+
+var errInvalidInterval = errors.New("the refresh interval has to be at least 100ms")
+
+var params = &monitorParams{}
+
+type monitorParams struct {
+	// events streams the block add / remove events instead of showing the dashboard
+	events bool
+	// interval is the refresh interval of the dashboard
+	interval time.Duration
+}
+
+func (p *monitorParams) validateFlags() error {
+	if !p.events && p.interval < 100*time.Millisecond {
+		return errInvalidInterval
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package monitor
 import (
 	"bytes"
 	"fmt"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/server/proto"
@@ -72,3 +73,146 @@ func (r *BlockEventResult) getCombinedEvents() []BlockchainEvent {
 
 	return append(events, r.Events.Removed...)
 }
+
+// DashboardResult is a refresh of the node dashboard
+type DashboardResult struct {
+	UpdatedAt string `json:"updated_at"`
+	Error     string `json:"error,omitempty"`
+
+	ChainID         int64   `json:"chain_id"`
+	HeadNumber      int64   `json:"head_number"`
+	HeadHash        string  `json:"head_hash"`
+	HeadAge         string  `json:"head_age"`
+	BlocksPerMinute float64 `json:"blocks_per_minute"`
+	Peers           int64   `json:"peers"`
+
+	Syncing      bool   `json:"syncing"`
+	HighestBlock uint64 `json:"highest_block"`
+
+	TxPoolPending   uint64 `json:"txpool_pending"`
+	TxPoolEnqueued  uint64 `json:"txpool_enqueued"`
+	TxPoolUsedSlots uint64 `json:"txpool_used_slots"`
+	TxPoolMaxSlots  uint64 `json:"txpool_max_slots"`
+
+	GasUsed  uint64 `json:"gas_used"`
+	GasLimit uint64 `json:"gas_limit"`
+	BaseFee  uint64 `json:"base_fee"`
+
+	TrackerLag *uint64 `json:"tracker_lag,omitempty"`
+
+	ConsensusHeight *uint64 `json:"consensus_height,omitempty"`
+	ConsensusRound  *uint64 `json:"consensus_round,omitempty"`
+	ConsensusState  string  `json:"consensus_state,omitempty"`
+
+	Validator       string `json:"validator,omitempty"`
+	ActiveValidator bool   `json:"active_validator"`
+}
+
+// fill sets the fields of the node status, the nodes of the older versions don't report all of them
+func (r *DashboardResult) fill(status *proto.ServerStatus, now time.Time) {
+	r.ChainID = status.Network
+	r.Peers = status.Peers
+
+	if status.Current != nil {
+		r.HeadNumber = status.Current.Number
+		r.HeadHash = status.Current.Hash
+
+		if status.Current.Timestamp > 0 {
+			r.HeadAge = now.Sub(time.Unix(int64(status.Current.Timestamp), 0)).Truncate(time.Second).String()
+		}
+	}
+
+	if status.Sync != nil {
+		r.Syncing = status.Sync.Syncing
+		r.HighestBlock = status.Sync.HighestBlock
+	}
+
+	if status.TxPool != nil {
+		r.TxPoolPending = status.TxPool.Pending
+		r.TxPoolEnqueued = status.TxPool.Enqueued
+		r.TxPoolUsedSlots = status.TxPool.UsedSlots
+		r.TxPoolMaxSlots = status.TxPool.MaxSlots
+	}
+
+	if status.Gas != nil {
+		r.GasUsed = status.Gas.Used
+		r.GasLimit = status.Gas.Limit
+		r.BaseFee = status.Gas.BaseFee
+	}
+
+	if status.Tracker != nil {
+		r.TrackerLag = &status.Tracker.Lag
+	}
+
+	if status.Consensus != nil {
+		r.ConsensusHeight = &status.Consensus.Height
+		r.ConsensusRound = &status.Consensus.Round
+		r.ConsensusState = status.Consensus.State
+	}
+
+	if status.Validator != nil {
+		r.Validator = status.Validator.Address
+		r.ActiveValidator = status.Validator.Active
+	}
+}
+
+func (r *DashboardResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString(fmt.Sprintf("[NODE DASHBOARD] %s\n", r.UpdatedAt))
+
+	if r.Error != "" {
+		buffer.WriteString(helper.FormatKV([]string{fmt.Sprintf("Error|%s", r.Error)}))
+
+		return buffer.String()
+	}
+
+	outputs := []string{
+		fmt.Sprintf("Network (Chain ID)|%d", r.ChainID),
+		fmt.Sprintf("Head|%d (%s)", r.HeadNumber, r.HeadHash),
+		fmt.Sprintf("Head Age|%s", r.HeadAge),
+		fmt.Sprintf("Blocks per Minute|%.1f", r.BlocksPerMinute),
+		fmt.Sprintf("Peers|%d", r.Peers),
+	}
+
+	if r.Syncing {
+		outputs = append(outputs, fmt.Sprintf("Syncing|%d / %d", r.HeadNumber, r.HighestBlock))
+	}
+
+	outputs = append(outputs,
+		fmt.Sprintf("TxPool Pending / Enqueued|%d / %d", r.TxPoolPending, r.TxPoolEnqueued),
+		fmt.Sprintf("TxPool Slots|%d / %d", r.TxPoolUsedSlots, r.TxPoolMaxSlots),
+		fmt.Sprintf("Gas Used|%d / %d (%s)", r.GasUsed, r.GasLimit, percentage(r.GasUsed, r.GasLimit)),
+		fmt.Sprintf("Base Fee (wei)|%d", r.BaseFee),
+	)
+
+	if r.TrackerLag != nil {
+		outputs = append(outputs, fmt.Sprintf("Tracker Lag (blocks)|%d", *r.TrackerLag))
+	}
+
+	if r.ConsensusHeight != nil {
+		outputs = append(outputs,
+			fmt.Sprintf("Consensus Height / Round|%d / %d", *r.ConsensusHeight, *r.ConsensusRound),
+			fmt.Sprintf("Consensus State|%s", r.ConsensusState),
+		)
+	}
+
+	if r.Validator != "" {
+		outputs = append(outputs,
+			fmt.Sprintf("Validator|%s", r.Validator),
+			fmt.Sprintf("Active Validator|%t", r.ActiveValidator),
+		)
+	}
+
+	buffer.WriteString(helper.FormatKV(outputs))
+
+	return buffer.String()
+}
+
+func percentage(value, total uint64) string {
+	if total == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%.1f%%", 100*float64(value)/float64(total))
+}
@@ -0,0 +1,76 @@
+package dnstree
+
+import (
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/network/dnsdisc"
+)
+
+var (
+	params = &dnsTreeParams{}
+)
+
+var (
+	errNoEntries = errors.New("at least 1 peer address or tree URL is required")
+)
+
+const (
+	keyFileFlag = "key-file"
+	domainFlag  = "domain"
+	seqFlag     = "seq"
+	addrFlag    = "addr"
+	linkFlag    = "link"
+)
+
+type dnsTreeParams struct {
+	keyFile string
+	domain  string
+	seq     uint64
+	addrs   []string
+	links   []string
+
+	url     string
+	records map[string]string
+}
+
+func (p *dnsTreeParams) getRequiredFlags() []string {
+	return []string{
+		keyFileFlag,
+		domainFlag,
+		seqFlag,
+	}
+}
+
+func (p *dnsTreeParams) validateFlags() error {
+	if len(p.addrs) == 0 && len(p.links) == 0 {
+		return errNoEntries
+	}
+
+	return nil
+}
+
+func (p *dnsTreeParams) makeTree() error {
+	key, err := crypto.GenerateOrReadPrivateKey(p.keyFile)
+	if err != nil {
+		return err
+	}
+
+	tree, err := dnsdisc.MakeTree(p.seq, p.addrs, p.links)
+	if err != nil {
+		return err
+	}
+
+	if p.url, err = tree.Sign(key, p.domain); err != nil {
+		return err
+	}
+
+	p.records = tree.Records(p.domain)
+
+	return nil
+}
+
+func (p *dnsTreeParams) getResult() command.CommandResult {
+	return newDNSTreeResult(p.url, p.records)
+}
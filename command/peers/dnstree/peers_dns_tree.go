@@ -0,0 +1,76 @@
+package dnstree
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	peersDNSTreeCmd := &cobra.Command{
+		Use: "dns-tree",
+		Short: "Creates the signed tree of the peer addresses, whose TXT records are published at the domain " +
+			"for the nodes started with the --dns-seeds flag",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(peersDNSTreeCmd)
+	helper.SetRequiredFlags(peersDNSTreeCmd, params.getRequiredFlags())
+
+	return peersDNSTreeCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.keyFile,
+		keyFileFlag,
+		"",
+		"the file of the ECDSA key signing the tree, the key is generated if the file doesn't exist",
+	)
+
+	cmd.Flags().StringVar(
+		&params.domain,
+		domainFlag,
+		"",
+		"the domain the tree is published at",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.seq,
+		seqFlag,
+		0,
+		"the sequence number of the tree, which has to be increased on every update",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.addrs,
+		addrFlag,
+		[]string{},
+		"the libp2p addresses of the peers",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.links,
+		linkFlag,
+		[]string{},
+		"the URLs of the other trees whose peers are included",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.makeTree(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,62 @@
+package dnstree
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type DNSTreeRecord struct {
+	Name string `json:"name"`
+	TXT  string `json:"txt"`
+}
+
+type DNSTreeResult struct {
+	URL     string          `json:"url"`
+	Records []DNSTreeRecord `json:"records"`
+}
+
+// newDNSTreeResult returns the result with the root record first
+func newDNSTreeResult(url string, records map[string]string) *DNSTreeResult {
+	result := &DNSTreeResult{
+		URL:     url,
+		Records: make([]DNSTreeRecord, 0, len(records)),
+	}
+
+	for name, txt := range records {
+		result.Records = append(result.Records, DNSTreeRecord{Name: name, TXT: txt})
+	}
+
+	sort.Slice(result.Records, func(i, j int) bool {
+		return len(result.Records[i].Name) < len(result.Records[j].Name) ||
+			len(result.Records[i].Name) == len(result.Records[j].Name) &&
+				result.Records[i].Name < result.Records[j].Name
+	})
+
+	return result
+}
+
+func (r *DNSTreeResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DNS TREE]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("URL|%s", r.URL),
+	}))
+
+	buffer.WriteString("\n\n[TXT RECORDS]\n")
+
+	rows := make([]string, len(r.Records)+1)
+	rows[0] = "Name|TXT"
+
+	for i, record := range r.Records {
+		rows[i+1] = fmt.Sprintf("%s|%s", record.Name, record.TXT)
+	}
+
+	buffer.WriteString(helper.FormatList(rows))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -3,7 +3,9 @@ package peers
 import (
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/command/peers/add"
+	"github.com/0xPolygon/polygon-edge/command/peers/dnstree"
 	"github.com/0xPolygon/polygon-edge/command/peers/list"
+	"github.com/0xPolygon/polygon-edge/command/peers/score"
 	"github.com/0xPolygon/polygon-edge/command/peers/status"
 	"github.com/spf13/cobra"
 )
@@ -15,6 +17,7 @@ func GetCommand() *cobra.Command {
 	}
 
 	helper.RegisterGRPCAddressFlag(peersCmd)
+	helper.RegisterGRPCTLSFlags(peersCmd)
 
 	registerSubcommands(peersCmd)
 
@@ -29,5 +32,9 @@ func registerSubcommands(baseCmd *cobra.Command) {
 		list.GetCommand(),
 		// peers add
 		add.GetCommand(),
+		// peers score
+		score.GetCommand(),
+		// peers dns-tree
+		dnstree.GetCommand(),
 	)
 }
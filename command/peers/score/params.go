@@ -0,0 +1,48 @@
+package score
+
+import (
+	"context"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+)
+
+var (
+	params = &scoreParams{}
+)
+
+const (
+	peerIDFlag = "peer-id"
+)
+
+type scoreParams struct {
+	peerID string
+
+	scores []*proto.PeerScore
+}
+
+func (p *scoreParams) initPeerScores(grpcAddress string) error {
+	systemClient, err := helper.GetSystemClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	resp, err := systemClient.PeersScore(
+		context.Background(),
+		&proto.PeersScoreRequest{
+			Id: p.peerID,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	p.scores = resp.Scores
+
+	return nil
+}
+
+func (p *scoreParams) getResult() command.CommandResult {
+	return newPeersScoreResult(p.scores)
+}
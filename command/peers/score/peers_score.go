@@ -0,0 +1,42 @@
+package score
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	peersScoreCmd := &cobra.Command{
+		Use: "score",
+		Short: "Returns the reputation of the specified peer, or of all connected peers, " +
+			"the peers scored below the prune threshold are disconnected",
+		Run: runCommand,
+	}
+
+	setFlags(peersScoreCmd)
+
+	return peersScoreCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.peerID,
+		peerIDFlag,
+		"",
+		"libp2p node ID of a specific peer within p2p network, all connected peers if omitted",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initPeerScores(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
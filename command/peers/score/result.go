@@ -0,0 +1,75 @@
+package score
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+)
+
+type PeerScoreResult struct {
+	ID                 string  `json:"id"`
+	Score              float64 `json:"score"`
+	UsefulResponses    uint64  `json:"usefulResponses"`
+	InvalidMessages    uint64  `json:"invalidMessages"`
+	Timeouts           uint64  `json:"timeouts"`
+	ProtocolViolations uint64  `json:"protocolViolations"`
+	RateLimitExceeded  uint64  `json:"rateLimitExceeded"`
+}
+
+type PeersScoreResult struct {
+	Peers []PeerScoreResult `json:"peers"`
+}
+
+// newPeersScoreResult returns the result with the worst scored peers first
+func newPeersScoreResult(scores []*proto.PeerScore) *PeersScoreResult {
+	peers := make([]PeerScoreResult, len(scores))
+	for i, s := range scores {
+		peers[i] = PeerScoreResult{
+			ID:                 s.Id,
+			Score:              s.Score,
+			UsefulResponses:    s.UsefulResponses,
+			InvalidMessages:    s.InvalidMessages,
+			Timeouts:           s.Timeouts,
+			ProtocolViolations: s.ProtocolViolations,
+			RateLimitExceeded:  s.RateLimitExceeded,
+		}
+	}
+
+	sort.SliceStable(peers, func(i, j int) bool {
+		return peers[i].Score < peers[j].Score
+	})
+
+	return &PeersScoreResult{
+		Peers: peers,
+	}
+}
+
+func (r *PeersScoreResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[PEERS SCORE]\n")
+
+	if len(r.Peers) == 0 {
+		buffer.WriteString("No peers found")
+	} else {
+		buffer.WriteString(fmt.Sprintf("Prune threshold: %d\n\n", network.PeerPruneThreshold))
+
+		rows := make([]string, len(r.Peers)+1)
+		rows[0] = "ID|Score|Useful|Invalid|Timeouts|Violations|Rate Limited"
+
+		for i, p := range r.Peers {
+			rows[i+1] = fmt.Sprintf("%s|%.2f|%d|%d|%d|%d|%d",
+				p.ID, p.Score, p.UsefulResponses, p.InvalidMessages, p.Timeouts, p.ProtocolViolations, p.RateLimitExceeded)
+		}
+
+		buffer.WriteString(helper.FormatList(rows))
+	}
+
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -176,7 +176,13 @@ func (ip *initParams) initKeys(secretsManager secrets.SecretsManager) ([]string,
 		)
 
 		if !secretsManager.HasSecret(secrets.ValidatorKey) && !secretsManager.HasSecret(secrets.ValidatorBLSKey) {
-			a, err = wallet.GenerateAccount()
+			// the validator key held remotely is created by the secrets manager itself
+			if signer, remote := secrets.GetRemoteSigner(secretsManager, secrets.ValidatorKey); remote {
+				a, err = wallet.GenerateRemoteAccount(signer)
+			} else {
+				a, err = wallet.GenerateAccount()
+			}
+
 			if err != nil {
 				return generated, fmt.Errorf("error generating account: %w", err)
 			}
@@ -214,7 +220,7 @@ func (ip *initParams) getResult(
 		res.Generated = strings.Join(generated, ", ")
 
 		if ip.printPrivateKey {
-			pk, err := account.Ecdsa.MarshallPrivateKey()
+			pk, err := account.MarshalEcdsaPrivateKey()
 			if err != nil {
 				return nil, err
 			}
@@ -0,0 +1,106 @@
+package remotesigner
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/command/polybftsecrets"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/remotesigner"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
+	"github.com/0xPolygon/polygon-edge/helper/tlsconfig"
+)
+
+const (
+	listenFlag      = "listen"
+	stateFileFlag   = "state-file"
+	tlsCertFlag     = "tls-cert"
+	tlsKeyFlag      = "tls-key"
+	tlsClientCAFlag = "tls-client-ca"
+	leaseDuration   = "lease-duration"
+
+	defaultListenAddr    = "127.0.0.1:9650"
+	defaultStateFileName = "remote-signer-state.json"
+)
+
+var (
+	params = &remoteSignerParams{tls: &tlsconfig.Config{}}
+
+	errNoStateFile           = errors.New("the signing state file has to be set when the secrets manager config is used")
+	errNegativeLeaseDuration = errors.New("the lease duration can't be negative")
+)
+
+type remoteSignerParams struct {
+	accountDir    string
+	accountConfig string
+	listenAddr    string
+	stateFile     string
+	tls           *tlsconfig.Config
+	leaseDuration time.Duration
+
+	account  *wallet.Account
+	state    *remotesigner.SigningState
+	lease    *remotesigner.Lease
+	listener net.Listener
+}
+
+func (p *remoteSignerParams) validateFlags() error {
+	if p.accountDir == "" && p.accountConfig == "" {
+		return polybftsecrets.ErrInvalidParams
+	}
+
+	if p.stateFile == "" && p.accountDir == "" {
+		return errNoStateFile
+	}
+
+	if p.leaseDuration < 0 {
+		return errNegativeLeaseDuration
+	}
+
+	return p.tls.Validate()
+}
+
+func (p *remoteSignerParams) initSigner() error {
+	secretsManager, err := polybftsecrets.GetSecretsManager(p.accountDir, p.accountConfig, true)
+	if err != nil {
+		return err
+	}
+
+	if p.account, err = wallet.NewAccountFromSecret(secretsManager); err != nil {
+		return err
+	}
+
+	// the validator keys of the signer are local, the signatures of the remote keys can't be protected
+	if _, err := p.account.MarshalEcdsaPrivateKey(); err != nil {
+		return fmt.Errorf("the remote signer requires the local validator key: %w", err)
+	}
+
+	stateFile := p.stateFile
+	if stateFile == "" {
+		stateFile = filepath.Join(p.accountDir, defaultStateFileName)
+	}
+
+	p.state, err = remotesigner.LoadSigningState(stateFile)
+	if err != nil {
+		return err
+	}
+
+	if p.leaseDuration > 0 {
+		p.lease = remotesigner.NewLease(p.leaseDuration)
+	}
+
+	return nil
+}
+
+func (p *remoteSignerParams) initListener() error {
+	listener, err := net.Listen("tcp", p.listenAddr)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %s: %w", p.listenAddr, err)
+	}
+
+	p.listener = listener
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package remotesigner
+
+import (
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/polybftsecrets"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/remotesigner"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/remotesigner/proto"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func GetCommand() *cobra.Command {
+	remoteSignerCmd := &cobra.Command{
+		Use: "remote-signer",
+		Short: "Runs the signer service holding the polybft validator keys, which the nodes started with " +
+			"--remote-signer sign the consensus messages with. The service keeps the double sign protection state",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(remoteSignerCmd)
+
+	return remoteSignerCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.accountDir,
+		polybftsecrets.AccountDirFlag,
+		"",
+		polybftsecrets.AccountDirFlagDesc,
+	)
+
+	cmd.Flags().StringVar(
+		&params.accountConfig,
+		polybftsecrets.AccountConfigFlag,
+		"",
+		polybftsecrets.AccountConfigFlagDesc,
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(polybftsecrets.AccountDirFlag, polybftsecrets.AccountConfigFlag)
+
+	cmd.Flags().StringVar(
+		&params.listenAddr,
+		listenFlag,
+		defaultListenAddr,
+		"the address the gRPC signer service listens on",
+	)
+
+	cmd.Flags().StringVar(
+		&params.stateFile,
+		stateFileFlag,
+		"",
+		"the file the double sign protection state is persisted to, "+
+			"<data-dir>/"+defaultStateFileName+" by default",
+	)
+
+	cmd.Flags().StringVar(
+		&params.tls.CertFile,
+		tlsCertFlag,
+		"",
+		"the PEM certificate chain the signer service is served with over TLS",
+	)
+
+	cmd.Flags().StringVar(
+		&params.tls.KeyFile,
+		tlsKeyFlag,
+		"",
+		"the PEM private key of the TLS certificate",
+	)
+
+	cmd.Flags().StringVar(
+		&params.tls.ClientCAFile,
+		tlsClientCAFlag,
+		"",
+		"the PEM CA certificates the nodes are required to present a certificate signed by (mutual TLS)",
+	)
+
+	cmd.Flags().DurationVar(
+		&params.leaseDuration,
+		leaseDuration,
+		0,
+		"the duration of the signing lease granted to the nodes started with --remote-signer-failover, "+
+			"only the lease holder is allowed to sign. The leases are disabled if not set",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initSigner(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	tlsConfig, err := params.tls.ServerTLSConfig()
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	var opts []grpc.ServerOption
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	if err := params.initListener(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	grpcServer := grpc.NewServer(opts...)
+	proto.RegisterRemoteSignerServer(grpcServer, remotesigner.NewServer(params.account, params.state, params.lease))
+
+	go func() {
+		_ = grpcServer.Serve(params.listener)
+	}()
+
+	outputter.WriteCommandResult(&RemoteSignerResult{
+		Address:   types.Address(params.account.Ecdsa.Address()),
+		BLSPubkey: params.account.Bls.PublicKey().Marshal(),
+		Listen:    params.listener.Addr().String(),
+		TLS:       tlsConfig != nil,
+		Lease:     params.leaseDuration,
+	})
+
+	<-common.GetTerminationSignalCh()
+
+	grpcServer.GracefulStop()
+}
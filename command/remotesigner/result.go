@@ -0,0 +1,35 @@
+package remotesigner
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type RemoteSignerResult struct {
+	Address   types.Address `json:"address"`
+	BLSPubkey []byte        `json:"bls_pubkey"`
+	Listen    string        `json:"listen"`
+	TLS       bool          `json:"tls"`
+	Lease     time.Duration `json:"lease_duration"`
+}
+
+func (r *RemoteSignerResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[REMOTE SIGNER]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Public key (address)|%s", r.Address),
+		fmt.Sprintf("BLS Public key|%s", hex.EncodeToHex(r.BLSPubkey)),
+		fmt.Sprintf("Listening on|%s", r.Listen),
+		fmt.Sprintf("TLS|%t", r.TLS),
+		fmt.Sprintf("Signing lease duration|%s", r.Lease),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
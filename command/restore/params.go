@@ -0,0 +1,87 @@
+package restore
+
+import (
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/archive"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+const (
+	fileFlag       = "file"
+	verifyOnlyFlag = "verify-only"
+)
+
+var (
+	params = &restoreParams{}
+)
+
+type restoreParams struct {
+	files      []string
+	verifyOnly bool
+
+	summaries []*archive.BackupSummary
+	result    *archive.BackupRestoreResult
+}
+
+func (p *restoreParams) getRequiredFlags() []string {
+	return []string{
+		fileFlag,
+	}
+}
+
+func (p *restoreParams) restore(grpcAddress string) error {
+	var err error
+
+	if p.summaries, err = archive.VerifyBackups(p.files); err != nil {
+		return err
+	}
+
+	if p.verifyOnly {
+		return nil
+	}
+
+	connection, err := helper.GetGRPCConnection(
+		grpcAddress,
+	)
+	if err != nil {
+		return err
+	}
+
+	p.result, err = archive.RestoreBackup(
+		connection,
+		hclog.New(&hclog.LoggerOptions{
+			Name:  "restore",
+			Level: hclog.LevelFromString("INFO"),
+		}),
+		p.files,
+	)
+
+	return err
+}
+
+func (p *restoreParams) getResult() command.CommandResult {
+	result := &RestoreResult{
+		VerifyOnly: p.verifyOnly,
+		Backups:    make([]*BackupResult, len(p.summaries)),
+	}
+
+	for i, summary := range p.summaries {
+		result.Backups[i] = &BackupResult{
+			File:       summary.Path,
+			From:       summary.From,
+			To:         summary.To,
+			LatestHash: summary.LatestHash.String(),
+			Compressed: summary.Compressed,
+			Manifest:   summary.Manifest != nil,
+		}
+	}
+
+	if p.result != nil {
+		result.Imported = p.result.Imported
+		result.Latest = p.result.Latest
+	}
+
+	return result
+}
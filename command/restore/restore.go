@@ -0,0 +1,55 @@
+package restore
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	restoreCmd := &cobra.Command{
+		Use: "restore",
+		Short: "Restores the backup files into the running node, which verifies and executes the blocks. " +
+			"The backups are checked against their manifests before any block is sent",
+		Run: runCommand,
+	}
+
+	helper.RegisterGRPCAddressFlag(restoreCmd)
+	helper.RegisterGRPCTLSFlags(restoreCmd)
+
+	setFlags(restoreCmd)
+	helper.SetRequiredFlags(restoreCmd, params.getRequiredFlags())
+
+	return restoreCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(
+		&params.files,
+		fileFlag,
+		nil,
+		"the backup file or the s3://<bucket>/<key> or gs://<bucket>/<key> object the backup is streamed from, "+
+			"the full backup followed by its incremental backups in order if the flag is repeated",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.verifyOnly,
+		verifyOnlyFlag,
+		false,
+		"only verify the checksums and the blocks of the backups, without connecting to the node",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.restore(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
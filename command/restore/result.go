@@ -0,0 +1,57 @@
+package restore
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type BackupResult struct {
+	File       string `json:"file"`
+	From       uint64 `json:"from"`
+	To         uint64 `json:"to"`
+	LatestHash string `json:"latest_hash"`
+	Compressed bool   `json:"compressed"`
+	Manifest   bool   `json:"manifest"`
+}
+
+type RestoreResult struct {
+	VerifyOnly bool            `json:"verify_only"`
+	Backups    []*BackupResult `json:"backups"`
+	Imported   uint64          `json:"imported,omitempty"`
+	Latest     uint64          `json:"latest,omitempty"`
+}
+
+func (r *RestoreResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[RESTORE]\n")
+
+	if r.VerifyOnly {
+		buffer.WriteString("Verified backup files successfully:\n")
+	} else {
+		buffer.WriteString("Restored backup files successfully:\n")
+	}
+
+	rows := make([]string, len(r.Backups)+1)
+	rows[0] = "File|From|To|Latest Hash|Compressed|Checksum Verified"
+
+	for i, backup := range r.Backups {
+		rows[i+1] = fmt.Sprintf("%s|%d|%d|%s|%t|%t",
+			backup.File, backup.From, backup.To, backup.LatestHash, backup.Compressed, backup.Manifest)
+	}
+
+	buffer.WriteString(helper.FormatList(rows))
+	buffer.WriteString("\n")
+
+	if !r.VerifyOnly {
+		buffer.WriteString(helper.FormatKV([]string{
+			fmt.Sprintf("Imported blocks|%d", r.Imported),
+			fmt.Sprintf("Latest block|%d", r.Latest),
+		}))
+		buffer.WriteString("\n")
+	}
+
+	return buffer.String()
+}
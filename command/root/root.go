@@ -6,21 +6,31 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/0xPolygon/polygon-edge/command/acl"
+	"github.com/0xPolygon/polygon-edge/command/admin"
 	"github.com/0xPolygon/polygon-edge/command/backup"
 	"github.com/0xPolygon/polygon-edge/command/bridge"
+	"github.com/0xPolygon/polygon-edge/command/chain"
 	"github.com/0xPolygon/polygon-edge/command/genesis"
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/command/ibft"
+	"github.com/0xPolygon/polygon-edge/command/inspect"
 	"github.com/0xPolygon/polygon-edge/command/license"
+	"github.com/0xPolygon/polygon-edge/command/loadtest"
 	"github.com/0xPolygon/polygon-edge/command/monitor"
 	"github.com/0xPolygon/polygon-edge/command/peers"
 	"github.com/0xPolygon/polygon-edge/command/polybft"
 	"github.com/0xPolygon/polygon-edge/command/polybftsecrets"
 	"github.com/0xPolygon/polygon-edge/command/regenesis"
+	"github.com/0xPolygon/polygon-edge/command/remotesigner"
+	"github.com/0xPolygon/polygon-edge/command/restore"
 	"github.com/0xPolygon/polygon-edge/command/rootchain"
 	"github.com/0xPolygon/polygon-edge/command/secrets"
 	"github.com/0xPolygon/polygon-edge/command/server"
+	"github.com/0xPolygon/polygon-edge/command/snapshot"
+	"github.com/0xPolygon/polygon-edge/command/state"
 	"github.com/0xPolygon/polygon-edge/command/status"
+	"github.com/0xPolygon/polygon-edge/command/storage"
 	"github.com/0xPolygon/polygon-edge/command/txpool"
 	"github.com/0xPolygon/polygon-edge/command/version"
 )
@@ -50,10 +60,13 @@ func (rc *RootCommand) registerSubCommands() {
 		status.GetCommand(),
 		secrets.GetCommand(),
 		peers.GetCommand(),
+		admin.GetCommand(),
+		acl.GetCommand(),
 		rootchain.GetCommand(),
 		monitor.GetCommand(),
 		ibft.GetCommand(),
 		backup.GetCommand(),
+		restore.GetCommand(),
 		genesis.GetCommand(),
 		server.GetCommand(),
 		license.GetCommand(),
@@ -61,6 +74,13 @@ func (rc *RootCommand) registerSubCommands() {
 		polybft.GetCommand(),
 		bridge.GetCommand(),
 		regenesis.GetCommand(),
+		snapshot.GetCommand(),
+		storage.GetCommand(),
+		state.GetCommand(),
+		chain.GetCommand(),
+		inspect.GetCommand(),
+		remotesigner.GetCommand(),
+		loadtest.GetCommand(),
 	)
 }
 
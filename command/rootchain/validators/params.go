@@ -6,6 +6,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	sidechainHelper "github.com/0xPolygon/polygon-edge/command/sidechain"
+	"github.com/0xPolygon/polygon-edge/types"
 )
 
 type validatorInfoParams struct {
@@ -15,6 +16,7 @@ type validatorInfoParams struct {
 	supernetManagerAddress string
 	stakeManagerAddress    string
 	chainID                int64
+	childJSONRPC           string
 }
 
 func (v *validatorInfoParams) validateFlags() error {
@@ -22,6 +24,12 @@ func (v *validatorInfoParams) validateFlags() error {
 		return fmt.Errorf("failed to parse json rpc address. Error: %w", err)
 	}
 
+	if v.childJSONRPC != "" {
+		if _, err := helper.ParseJSONRPCAddress(v.childJSONRPC); err != nil {
+			return fmt.Errorf("failed to parse child chain json rpc address. Error: %w", err)
+		}
+	}
+
 	return sidechainHelper.ValidateSecretFlags(v.accountDir, v.accountConfig)
 }
 
@@ -30,6 +38,8 @@ type validatorsInfoResult struct {
 	Stake       uint64 `json:"stake"`
 	Active      bool   `json:"active"`
 	Whitelisted bool   `json:"whitelisted"`
+
+	Performance *types.ValidatorPerformance `json:"performance,omitempty"`
 }
 
 func (vr validatorsInfoResult) GetOutput() string {
@@ -43,6 +53,17 @@ func (vr validatorsInfoResult) GetOutput() string {
 	vals[2] = fmt.Sprintf("Is Whitelisted|%v", vr.Whitelisted)
 	vals[3] = fmt.Sprintf("Is Active|%v", vr.Active)
 
+	if vr.Performance != nil {
+		vals = append(vals,
+			fmt.Sprintf("Proposed Blocks|%d", vr.Performance.ProposedBlocks),
+			fmt.Sprintf("Signed Blocks|%d", vr.Performance.SignedBlocks),
+			fmt.Sprintf("Missed Blocks|%d", vr.Performance.MissedBlocks),
+			fmt.Sprintf("Last Signed Block|%d", vr.Performance.LastSignedBlock),
+			fmt.Sprintf("Consensus Messages|%d", vr.Performance.Messages),
+			fmt.Sprintf("Uptime|%.2f%%", vr.Performance.Uptime()*100),
+		)
+	}
+
 	buffer.WriteString(helper.FormatKV(vals))
 	buffer.WriteString("\n")
 
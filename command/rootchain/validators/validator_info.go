@@ -11,6 +11,14 @@ import (
 	"github.com/0xPolygon/polygon-edge/txrelayer"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/spf13/cobra"
+	"github.com/umbracle/ethgo/jsonrpc"
+)
+
+const (
+	childJSONRPCFlag = "child-json-rpc"
+
+	// getPerformanceFn is JSON RPC endpoint which returns the performance of the validator
+	getPerformanceFn = "validator_getPerformance"
 )
 
 var (
@@ -67,6 +75,13 @@ func setFlags(cmd *cobra.Command) {
 		polybftsecrets.ChainIDFlagDesc,
 	)
 
+	cmd.Flags().StringVar(
+		&params.childJSONRPC,
+		childJSONRPCFlag,
+		"",
+		"the JSON RPC child chain endpoint, if set the uptime of the validator recorded by the node is reported",
+	)
+
 	cmd.MarkFlagsMutuallyExclusive(polybftsecrets.AccountDirFlag, polybftsecrets.AccountConfigFlag)
 }
 
@@ -100,12 +115,27 @@ func runCommand(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to get validator info for %s: %w", validatorAddr, err)
 	}
 
-	outputter.WriteCommandResult(&validatorsInfoResult{
+	result := &validatorsInfoResult{
 		Address:     validatorInfo.Address.String(),
 		Stake:       validatorInfo.Stake.Uint64(),
 		Active:      validatorInfo.IsActive,
 		Whitelisted: validatorInfo.IsWhitelisted,
-	})
+	}
+
+	if params.childJSONRPC != "" {
+		childClient, err := jsonrpc.NewClient(params.childJSONRPC)
+		if err != nil {
+			return fmt.Errorf("could not create child chain JSON RPC client: %w", err)
+		}
+
+		result.Performance = new(types.ValidatorPerformance)
+
+		if err := childClient.Call(getPerformanceFn, result.Performance, types.Address(validatorAddr)); err != nil {
+			return fmt.Errorf("failed to get performance of %s: %w", validatorAddr, err)
+		}
+	}
+
+	outputter.WriteCommandResult(result)
 
 	return nil
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/secrets/encryptedlocal"
 )
 
 func GetCommand() *cobra.Command {
@@ -50,10 +51,13 @@ func setFlags(cmd *cobra.Command) {
 		typeFlag,
 		string(secrets.HashicorpVault),
 		fmt.Sprintf(
-			"the type of the secrets manager. Available types: %s, %s and %s",
+			"the type of the secrets manager. Available types: %s, %s, %s, %s, %s and %s",
 			secrets.HashicorpVault,
+			secrets.EncryptedLocal,
 			secrets.AWSSSM,
 			secrets.GCPSSM,
+			secrets.GCPKMS,
+			secrets.AzureKeyVault,
 		),
 	)
 
@@ -75,7 +79,16 @@ func setFlags(cmd *cobra.Command) {
 		&params.extra,
 		extraFlag,
 		"",
-		"Specifies the extra fields map in string format 'key1=val1,key2=val2'",
+		"Specifies the extra fields map in string format 'key1=val1,key2=val2'. "+
+			"For hashicorp-vault, 'transit-mount=<path>' keeps the validator key in the transit engine "+
+			"(optionally named by 'transit-key=<name>'), which produces the signatures. "+
+			"For gcp-kms, 'kms-key=<crypto key resource name>' and 'secrets-dir=<path>' are required, "+
+			"'gcp-kms-cred=<path>' is optional. For azure-key-vault, the server URL is the URL of the vault, "+
+			"and 'tenant-id=<id>,client-id=<id>' authenticate as the service principal with the token as "+
+			"its client secret; the managed identity of the host is used otherwise. For encrypted-local, "+
+			"'path=<dir>' is the secrets directory (the server data directory by default) and "+
+			"'passphrase-file=<path>' holds the passphrase, which is otherwise read from the "+
+			encryptedlocal.PassphraseEnv+" variable or prompted for",
 	)
 }
 
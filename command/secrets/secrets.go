@@ -15,6 +15,7 @@ func GetCommand() *cobra.Command {
 	}
 
 	helper.RegisterGRPCAddressFlag(secretsCmd)
+	helper.RegisterGRPCTLSFlags(secretsCmd)
 
 	registerSubcommands(secretsCmd)
 
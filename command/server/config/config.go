@@ -7,7 +7,15 @@ import (
 	"strings"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/remotesigner"
+	"github.com/0xPolygon/polygon-edge/helper/kvdb"
+	"github.com/0xPolygon/polygon-edge/helper/logging"
+	"github.com/0xPolygon/polygon-edge/helper/tlsconfig"
+	"github.com/0xPolygon/polygon-edge/helper/tracing"
 	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/streaming"
+	"github.com/0xPolygon/polygon-edge/syncer"
+	"github.com/0xPolygon/polygon-edge/webhooks"
 	"github.com/hashicorp/hcl"
 	"gopkg.in/yaml.v3"
 )
@@ -25,6 +33,8 @@ type Config struct {
 	ShouldSeal               bool       `json:"seal" yaml:"seal"`
 	TxPool                   *TxPool    `json:"tx_pool" yaml:"tx_pool"`
 	LogLevel                 string     `json:"log_level" yaml:"log_level"`
+	LogLevels                string     `json:"log_levels" yaml:"log_levels"`
+	LogFormat                string     `json:"log_format" yaml:"log_format"`
 	RestoreFile              string     `json:"restore_file" yaml:"restore_file"`
 	Headers                  *Headers   `json:"headers" yaml:"headers"`
 	LogFilePath              string     `json:"log_to" yaml:"log_to"`
@@ -36,15 +46,93 @@ type Config struct {
 	Relayer               bool   `json:"relayer" yaml:"relayer"`
 	NumBlockConfirmations uint64 `json:"num_block_confirmations" yaml:"num_block_confirmations"`
 
+	BlockTrackerPollInterval time.Duration `json:"block_tracker_poll_interval" yaml:"block_tracker_poll_interval"`
+	ProposalDeadline         uint64        `json:"proposal_deadline" yaml:"proposal_deadline"`
+
 	ConcurrentRequestsDebug uint64 `json:"concurrent_requests_debug" yaml:"concurrent_requests_debug"`
 	WebSocketReadLimit      uint64 `json:"web_socket_read_limit" yaml:"web_socket_read_limit"`
+	PendingTxsRateLimit     uint64 `json:"pending_txs_rate_limit" yaml:"pending_txs_rate_limit"`
+
+	WebSocketMaxConnections   uint64        `json:"web_socket_max_connections" yaml:"web_socket_max_connections"`
+	WebSocketIdleTimeout      time.Duration `json:"web_socket_idle_timeout" yaml:"web_socket_idle_timeout"`
+	WebSocketMaxSubscriptions uint64        `json:"web_socket_max_subscriptions" yaml:"web_socket_max_subscriptions"`
+	WebSocketSendQueue        uint64        `json:"web_socket_send_queue" yaml:"web_socket_send_queue"`
+
+	JSONRPCCallTimeout             time.Duration `json:"json_rpc_call_timeout" yaml:"json_rpc_call_timeout"`
+	JSONRPCCallCache               uint64        `json:"json_rpc_call_cache" yaml:"json_rpc_call_cache"`
+	JSONRPCEncodingCache           uint64        `json:"json_rpc_encoding_cache" yaml:"json_rpc_encoding_cache"`
+	JSONRPCMethodConcurrencyLimits []string      `json:"json_rpc_method_concurrency_limits" yaml:"json_rpc_method_concurrency_limits"`
+	JSONRPCAccessLog               bool          `json:"json_rpc_access_log" yaml:"json_rpc_access_log"`
+	JSONRPCRateLimitsFile          string        `json:"json_rpc_rate_limits_file" yaml:"json_rpc_rate_limits_file"`
+
+	JSONRPCTLS *tlsconfig.Config `json:"json_rpc_tls,omitempty" yaml:"json_rpc_tls,omitempty"`
+	GRPCTLS    *tlsconfig.Config `json:"grpc_tls,omitempty" yaml:"grpc_tls,omitempty"`
 
 	MetricsInterval time.Duration `json:"metrics_interval" yaml:"metrics_interval"`
+
+	StateHistory  uint64 `json:"state_history" yaml:"state_history"`
+	StateSnapshot bool   `json:"state_snapshot" yaml:"state_snapshot"`
+
+	ParallelExecution bool `json:"parallel_execution" yaml:"parallel_execution"`
+	StatePrefetch     bool `json:"state_prefetch" yaml:"state_prefetch"`
+
+	EVMStats bool `json:"evm_stats" yaml:"evm_stats"`
+
+	ExecutionEngine       string `json:"execution_engine" yaml:"execution_engine"`
+	ExecutionEngineBlocks bool   `json:"execution_engine_blocks" yaml:"execution_engine_blocks"`
+
+	LogIndex bool `json:"log_index" yaml:"log_index"`
+
+	StateDiffCache uint64 `json:"state_diff_cache" yaml:"state_diff_cache"`
+
+	MaxReorgDepth uint64 `json:"max_reorg_depth" yaml:"max_reorg_depth"`
+
+	BackupSchedule   string `json:"backup_schedule" yaml:"backup_schedule"`
+	BackupDir        string `json:"backup_dir" yaml:"backup_dir"`
+	BackupRetention  uint64 `json:"backup_retention" yaml:"backup_retention"`
+	BackupCompress   bool   `json:"backup_compress" yaml:"backup_compress"`
+	BackupS3Bucket   string `json:"backup_s3_bucket" yaml:"backup_s3_bucket"`
+	BackupS3Prefix   string `json:"backup_s3_prefix" yaml:"backup_s3_prefix"`
+	BackupS3Region   string `json:"backup_s3_region" yaml:"backup_s3_region"`
+	BackupS3Endpoint string `json:"backup_s3_endpoint" yaml:"backup_s3_endpoint"`
+
+	ReceiptsHistory uint64 `json:"receipts_history" yaml:"receipts_history"`
+	TxLookupHistory uint64 `json:"tx_lookup_history" yaml:"tx_lookup_history"`
+
+	StorageBackend string `json:"storage_backend" yaml:"storage_backend"`
+
+	FreezerThreshold uint64 `json:"freezer_threshold" yaml:"freezer_threshold"`
+	FreezerDir       string `json:"freezer_dir" yaml:"freezer_dir"`
+
+	SyncMode string `json:"sync_mode" yaml:"sync_mode"`
+
+	IntegrityCheckDepth uint64 `json:"integrity_check_depth" yaml:"integrity_check_depth"`
+	IntegrityRollback   bool   `json:"integrity_rollback" yaml:"integrity_rollback"`
+
+	AdminTokenFile string `json:"admin_token_file" yaml:"admin_token_file"`
+
+	RemoteSigner *remotesigner.Config `json:"remote_signer,omitempty" yaml:"remote_signer,omitempty"`
+
+	EthStats string `json:"ethstats" yaml:"ethstats"`
+
+	HealthMaxHeadAge     time.Duration `json:"health_max_head_age" yaml:"health_max_head_age"`
+	HealthMinPeers       uint64        `json:"health_min_peers" yaml:"health_min_peers"`
+	HealthMaxTrackerLag  uint64        `json:"health_max_tracker_lag" yaml:"health_max_tracker_lag"`
+	HealthMaxTxPoolUsage uint64        `json:"health_max_txpool_usage" yaml:"health_max_txpool_usage"`
+
+	Webhooks []*webhooks.Endpoint `json:"webhooks,omitempty" yaml:"webhooks,omitempty"`
+
+	Streaming *streaming.Config `json:"streaming,omitempty" yaml:"streaming,omitempty"`
 }
 
-// Telemetry holds the config details for metric services.
+// Telemetry holds the config details for metric and tracing services.
 type Telemetry struct {
-	PrometheusAddr string `json:"prometheus_addr" yaml:"prometheus_addr"`
+	PrometheusAddr     string  `json:"prometheus_addr" yaml:"prometheus_addr"`
+	DebugAddr          string  `json:"debug_addr" yaml:"debug_addr"`
+	OTLPEndpoint       string  `json:"otlp_endpoint" yaml:"otlp_endpoint"`
+	OTLPProtocol       string  `json:"otlp_protocol" yaml:"otlp_protocol"`
+	OTLPInsecure       bool    `json:"otlp_insecure" yaml:"otlp_insecure"`
+	TracingSampleRatio float64 `json:"tracing_sample_ratio" yaml:"tracing_sample_ratio"`
 }
 
 // Network defines the network configuration params
@@ -56,6 +144,14 @@ type Network struct {
 	MaxPeers         int64  `json:"max_peers,omitempty" yaml:"max_peers,omitempty"`
 	MaxOutboundPeers int64  `json:"max_outbound_peers,omitempty" yaml:"max_outbound_peers,omitempty"`
 	MaxInboundPeers  int64  `json:"max_inbound_peers,omitempty" yaml:"max_inbound_peers,omitempty"`
+
+	RateLimits *network.RateLimits `json:"rate_limits,omitempty" yaml:"rate_limits,omitempty"`
+
+	StaticPeers  []string `json:"static_peers,omitempty" yaml:"static_peers,omitempty"`
+	TrustedPeers []string `json:"trusted_peers,omitempty" yaml:"trusted_peers,omitempty"`
+	DNSSeeds     []string `json:"dns_seeds,omitempty" yaml:"dns_seeds,omitempty"`
+
+	PeerFilters *network.PeerFilters `json:"peer_filters,omitempty" yaml:"peer_filters,omitempty"`
 }
 
 // TxPool defines the TxPool configuration params
@@ -63,6 +159,10 @@ type TxPool struct {
 	PriceLimit         uint64 `json:"price_limit" yaml:"price_limit"`
 	MaxSlots           uint64 `json:"max_slots" yaml:"max_slots"`
 	MaxAccountEnqueued uint64 `json:"max_account_enqueued" yaml:"max_account_enqueued"`
+
+	UserOperationEntryPoints []string `json:"user_operation_entry_points" yaml:"user_operation_entry_points"`
+
+	GossipBatchInterval time.Duration `json:"gossip_batch_interval" yaml:"gossip_batch_interval"`
 }
 
 // Headers defines the HTTP response headers required to enable CORS.
@@ -94,9 +194,51 @@ const (
 	// the connection sends a close message to the peer and returns ErrReadLimit to the application.
 	DefaultWebSocketReadLimit uint64 = 8192
 
+	// DefaultWebSocketMaxConnections specifies max number of concurrent websocket connections.
+	// A value of 0 means there is no limit
+	DefaultWebSocketMaxConnections uint64 = 1024
+
+	// DefaultWebSocketIdleTimeout specifies the time after which the websocket connection is closed
+	// if it answers neither the pings nor sends a message. A value of 0 disables the pings
+	DefaultWebSocketIdleTimeout time.Duration = time.Minute
+
+	// DefaultWebSocketMaxSubscriptions specifies max number of subscriptions of a single websocket connection.
+	// A value of 0 means there is no limit
+	DefaultWebSocketMaxSubscriptions uint64 = 128
+
+	// DefaultWebSocketSendQueue specifies the number of messages queued for a single websocket connection,
+	// the connection is closed once its queue is full
+	DefaultWebSocketSendQueue uint64 = 256
+
+	// DefaultPendingTxsRateLimit specifies max number of pending transactions notifications
+	// sent per second over a single websocket connection
+	DefaultPendingTxsRateLimit uint64 = 1000
+
+	// DefaultJSONRPCCallTimeout specifies the maximum execution time of eth_call and eth_estimateGas requests.
+	// A value of 0 means there is no timeout
+	DefaultJSONRPCCallTimeout time.Duration = time.Second * 5
+
 	// DefaultMetricsInterval specifies the time interval after which Prometheus metrics will be generated.
 	// A value of 0 means the metrics are disabled.
 	DefaultMetricsInterval time.Duration = time.Second * 8
+
+	// DefaultStateHistory specifies the number of the most recent blocks whose state is retained.
+	// A value of 0 means the state of all blocks is retained (archive mode)
+	DefaultStateHistory uint64 = 0
+
+	// DefaultIntegrityCheckDepth specifies the number of the most recent blocks whose data is verified on startup.
+	// A value of 0 disables the check
+	DefaultIntegrityCheckDepth uint64 = 128
+
+	// DefaultHealthMaxHeadAge specifies the maximum age of the head block of the ready node.
+	// A value of 0 disables the criterion
+	DefaultHealthMaxHeadAge time.Duration = time.Minute
+
+	// DefaultExecutionEngine specifies the engine executing the contract code, the built-in EVM
+	DefaultExecutionEngine = "evm"
+
+	// DefaultProposalDeadline specifies the percentage of the block time the proposer fills the block for
+	DefaultProposalDeadline uint64 = 100
 )
 
 // DefaultConfig returns the default server configuration
@@ -112,31 +254,76 @@ func DefaultConfig() *Config {
 			MaxPeers:         defaultNetworkConfig.MaxPeers,
 			MaxOutboundPeers: defaultNetworkConfig.MaxOutboundPeers,
 			MaxInboundPeers:  defaultNetworkConfig.MaxInboundPeers,
+			RateLimits:       defaultNetworkConfig.RateLimits,
 			Libp2pAddr: fmt.Sprintf("%s:%d",
 				defaultNetworkConfig.Addr.IP,
 				defaultNetworkConfig.Addr.Port,
 			),
 		},
-		Telemetry:  &Telemetry{},
-		ShouldSeal: true,
+		Telemetry: &Telemetry{
+			OTLPProtocol:       tracing.ProtocolGRPC,
+			TracingSampleRatio: 1,
+		},
+		ShouldSeal:       true,
+		ProposalDeadline: DefaultProposalDeadline,
 		TxPool: &TxPool{
 			PriceLimit:         0,
 			MaxSlots:           4096,
 			MaxAccountEnqueued: 128,
 		},
 		LogLevel:    "INFO",
+		LogFormat:   logging.FormatText,
 		RestoreFile: "",
 		Headers: &Headers{
 			AccessControlAllowOrigins: []string{"*"},
 		},
-		LogFilePath:              "",
-		JSONRPCBatchRequestLimit: DefaultJSONRPCBatchRequestLimit,
-		JSONRPCBlockRangeLimit:   DefaultJSONRPCBlockRangeLimit,
-		Relayer:                  false,
-		NumBlockConfirmations:    DefaultNumBlockConfirmations,
-		ConcurrentRequestsDebug:  DefaultConcurrentRequestsDebug,
-		WebSocketReadLimit:       DefaultWebSocketReadLimit,
-		MetricsInterval:          DefaultMetricsInterval,
+		LogFilePath:               "",
+		JSONRPCBatchRequestLimit:  DefaultJSONRPCBatchRequestLimit,
+		JSONRPCBlockRangeLimit:    DefaultJSONRPCBlockRangeLimit,
+		Relayer:                   false,
+		NumBlockConfirmations:     DefaultNumBlockConfirmations,
+		ConcurrentRequestsDebug:   DefaultConcurrentRequestsDebug,
+		WebSocketReadLimit:        DefaultWebSocketReadLimit,
+		WebSocketMaxConnections:   DefaultWebSocketMaxConnections,
+		WebSocketIdleTimeout:      DefaultWebSocketIdleTimeout,
+		WebSocketMaxSubscriptions: DefaultWebSocketMaxSubscriptions,
+		WebSocketSendQueue:        DefaultWebSocketSendQueue,
+		PendingTxsRateLimit:       DefaultPendingTxsRateLimit,
+		JSONRPCCallTimeout:        DefaultJSONRPCCallTimeout,
+		JSONRPCCallCache:          0,
+		JSONRPCEncodingCache:      0,
+		JSONRPCTLS:                &tlsconfig.Config{},
+		GRPCTLS:                   &tlsconfig.Config{},
+		MetricsInterval:           DefaultMetricsInterval,
+		StateHistory:              DefaultStateHistory,
+		StateSnapshot:             false,
+		ParallelExecution:         false,
+		StatePrefetch:             false,
+		EVMStats:                  false,
+		ExecutionEngine:           DefaultExecutionEngine,
+		ExecutionEngineBlocks:     false,
+		LogIndex:                  false,
+		StateDiffCache:            0,
+		MaxReorgDepth:             0,
+		BackupSchedule:            "",
+		BackupDir:                 "",
+		BackupRetention:           0,
+		BackupCompress:            false,
+		ReceiptsHistory:           0,
+		TxLookupHistory:           0,
+		StorageBackend:            string(kvdb.DefaultBackend),
+		FreezerThreshold:          0,
+		FreezerDir:                "",
+		SyncMode:                  string(syncer.FullSync),
+		IntegrityCheckDepth:       DefaultIntegrityCheckDepth,
+		IntegrityRollback:         false,
+		AdminTokenFile:            "",
+		RemoteSigner:              &remotesigner.Config{},
+		EthStats:                  "",
+		HealthMaxHeadAge:          DefaultHealthMaxHeadAge,
+		HealthMinPeers:            0,
+		HealthMaxTrackerLag:       0,
+		HealthMaxTxPoolUsage:      0,
 	}
 }
 
@@ -168,6 +355,8 @@ func ReadConfigFile(path string) (*Config, error) {
 	config.Network.MaxPeers = -1
 	config.Network.MaxInboundPeers = -1
 	config.Network.MaxOutboundPeers = -1
+	// the limits not set in the file stay default
+	config.Network.RateLimits = network.DefaultRateLimits()
 
 	if err := unmarshalFunc(data, config); err != nil {
 		return nil, err
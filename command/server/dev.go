@@ -0,0 +1,203 @@
+package server
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	helperCommon "github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	devChainName = "polygon-edge-dev"
+	devChainID   = 1337
+
+	defaultDevAccountsCount = 10
+
+	// devAccountSeed is the seed the keys of the dev accounts are derived from,
+	// so the same accounts are prefunded on every dev run
+	devAccountSeed = "polygon-edge dev account"
+)
+
+// devStateDirs are the directories of the data dir removed by the dev state reset
+var devStateDirs = []string{"blockchain", "trie", "ancient"}
+
+// devAccount is a prefunded account of the dev chain, its private key is public knowledge
+type devAccount struct {
+	address    types.Address
+	privateKey []byte
+	balance    *big.Int
+}
+
+// generateDevAccounts derives the deterministic keys of the dev accounts
+func generateDevAccounts(count uint64, balance *big.Int) ([]*devAccount, error) {
+	accounts := make([]*devAccount, count)
+
+	for i := uint64(0); i < count; i++ {
+		index := make([]byte, 8)
+		binary.BigEndian.PutUint64(index, i)
+
+		privateKey := crypto.Keccak256([]byte(devAccountSeed), index)
+
+		key, err := crypto.ParseECDSAPrivateKey(privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive the dev account %d: %w", i, err)
+		}
+
+		accounts[i] = &devAccount{
+			address:    crypto.PubKeyToAddress(&key.PublicKey),
+			privateKey: privateKey,
+			balance:    balance,
+		}
+	}
+
+	return accounts, nil
+}
+
+// newDevGenesis returns the genesis of the single node dev chain, used when the genesis file is missing
+func newDevGenesis() *chain.Chain {
+	return &chain.Chain{
+		Name: devChainName,
+		Genesis: &chain.Genesis{
+			GasLimit:           command.DefaultGenesisGasLimit,
+			Difficulty:         1,
+			Alloc:              map[types.Address]*chain.GenesisAccount{},
+			GasUsed:            command.DefaultGenesisGasUsed,
+			BaseFee:            chain.GenesisBaseFee,
+			BaseFeeEM:          chain.GenesisBaseFeeEM,
+			BaseFeeChangeDenom: chain.BaseFeeChangeDenom,
+		},
+		Params: &chain.Params{
+			ChainID: devChainID,
+			Forks:   chain.AllForksEnabled.Copy(),
+			Engine: map[string]interface{}{
+				string(server.DevConsensus): map[string]interface{}{},
+			},
+		},
+	}
+}
+
+// initDevAccounts prefunds the dev accounts in the genesis, the accounts already in the allocation are kept
+func (p *serverParams) initDevAccounts() error {
+	balance, err := helperCommon.ParseUint256orHex(&p.devBalanceRaw)
+	if err != nil {
+		return fmt.Errorf("invalid dev account balance %s: %w", p.devBalanceRaw, err)
+	}
+
+	if p.devAccounts, err = generateDevAccounts(p.devAccountsCount, balance); err != nil {
+		return err
+	}
+
+	if p.genesisConfig.Genesis.Alloc == nil {
+		p.genesisConfig.Genesis.Alloc = map[types.Address]*chain.GenesisAccount{}
+	}
+
+	for _, account := range p.devAccounts {
+		if _, ok := p.genesisConfig.Genesis.Alloc[account.address]; ok {
+			continue
+		}
+
+		p.genesisConfig.Genesis.Alloc[account.address] = &chain.GenesisAccount{Balance: account.balance}
+	}
+
+	return nil
+}
+
+// resetDevState removes the chain and the state data of the previous dev run,
+// the secrets and the network key in the data dir are kept
+func resetDevState(dataDir, freezerDir string) error {
+	dirs := make([]string, 0, len(devStateDirs)+1)
+	for _, dir := range devStateDirs {
+		dirs = append(dirs, filepath.Join(dataDir, dir))
+	}
+
+	if freezerDir != "" {
+		dirs = append(dirs, freezerDir)
+	}
+
+	for _, dir := range dirs {
+		if err := os.RemoveAll(dir); err != nil {
+			return fmt.Errorf("failed to reset the dev state %s: %w", dir, err)
+		}
+	}
+
+	return nil
+}
+
+// startDevMode resets the dev state if requested and prints the prefunded dev accounts
+func startDevMode(outputter command.OutputFormatter) error {
+	if params.devReset {
+		if err := resetDevState(params.rawConfig.DataDir, params.rawConfig.FreezerDir); err != nil {
+			return err
+		}
+	}
+
+	outputter.WriteCommandResult(newDevAccountsResult(params.genesisConfig.Params.ChainID, params.devAccounts))
+
+	return nil
+}
+
+type DevAccountResult struct {
+	Address    string `json:"address"`
+	PrivateKey string `json:"private_key"`
+	Balance    string `json:"balance"`
+}
+
+type DevAccountsResult struct {
+	ChainID  int64               `json:"chain_id"`
+	Accounts []*DevAccountResult `json:"accounts"`
+}
+
+func newDevAccountsResult(chainID int64, accounts []*devAccount) *DevAccountsResult {
+	res := &DevAccountsResult{
+		ChainID:  chainID,
+		Accounts: make([]*DevAccountResult, len(accounts)),
+	}
+
+	for i, account := range accounts {
+		res.Accounts[i] = &DevAccountResult{
+			Address:    account.address.String(),
+			PrivateKey: hex.EncodeToHex(account.privateKey),
+			Balance:    account.balance.String(),
+		}
+	}
+
+	return res
+}
+
+func (r *DevAccountsResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DEV MODE]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Chain ID|%d", r.ChainID),
+		fmt.Sprintf("Prefunded Accounts|%d", len(r.Accounts)),
+	}))
+	buffer.WriteString("\n")
+
+	if len(r.Accounts) > 0 {
+		buffer.WriteString("\nWARNING: The private keys of the dev accounts are public, never use them on a live network\n\n")
+
+		rows := make([]string, len(r.Accounts)+1)
+		rows[0] = "#|Address|Private Key|Balance (wei)"
+
+		for i, account := range r.Accounts {
+			rows[i+1] = fmt.Sprintf("%d|%s|%s|%s", i, account.Address, account.PrivateKey, account.Balance)
+		}
+
+		buffer.WriteString(helper.FormatList(rows))
+		buffer.WriteString("\n")
+	}
+
+	return buffer.String()
+}
@@ -4,11 +4,17 @@ import (
 	"errors"
 	"fmt"
 	"math"
-	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
 
+	"github.com/0xPolygon/polygon-edge/archive"
 	"github.com/0xPolygon/polygon-edge/command/server/config"
+	"github.com/0xPolygon/polygon-edge/ethstats"
 
 	helperCommon "github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/logging"
+	"github.com/0xPolygon/polygon-edge/helper/tlsconfig"
 	"github.com/0xPolygon/polygon-edge/network/common"
 
 	"github.com/0xPolygon/polygon-edge/chain"
@@ -16,10 +22,16 @@ import (
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/types"
 )
 
 var (
-	errDataDirectoryUndefined = errors.New("data directory not defined")
+	errDataDirectoryUndefined  = errors.New("data directory not defined")
+	errInvalidLogFormat        = errors.New("log format has to be text or json")
+	errDebugAddrNoAdminToken   = errors.New("the debug port requires the admin token file")
+	errInvalidTxPoolUsage      = errors.New("the maximum txpool usage is a percentage, at most 100")
+	errInvalidProposalDeadline = errors.New("the proposal deadline is a percentage of the block time, " +
+		"between 1 and 100")
 )
 
 func (p *serverParams) initConfigFromFile() error {
@@ -50,12 +62,54 @@ func (p *serverParams) initRawParams() error {
 	}
 
 	if p.isDevMode {
-		p.initDevMode()
+		if err := p.initDevMode(); err != nil {
+			return err
+		}
 	}
 
 	p.initPeerLimits()
 	p.initLogFileLocation()
 
+	if err := p.initLogging(); err != nil {
+		return err
+	}
+
+	if err := p.initEthStats(); err != nil {
+		return err
+	}
+
+	if err := p.initBackup(); err != nil {
+		return err
+	}
+
+	if p.rawConfig.HealthMaxTxPoolUsage > 100 {
+		return errInvalidTxPoolUsage
+	}
+
+	if p.rawConfig.ProposalDeadline == 0 || p.rawConfig.ProposalDeadline > 100 {
+		return errInvalidProposalDeadline
+	}
+
+	if err := p.initWebhooks(); err != nil {
+		return err
+	}
+
+	if err := p.initStreaming(); err != nil {
+		return err
+	}
+
+	if err := p.initUserOperationEntryPoints(); err != nil {
+		return err
+	}
+
+	if err := p.initJSONRPCMethodConcurrencyLimits(); err != nil {
+		return err
+	}
+
+	if err := p.initTLSConfig(); err != nil {
+		return err
+	}
+
 	p.relayer = p.rawConfig.Relayer
 
 	return p.initAddresses()
@@ -75,6 +129,144 @@ func (p *serverParams) initLogFileLocation() {
 	}
 }
 
+func (p *serverParams) initLogging() error {
+	if p.rawConfig.LogFormat != "" &&
+		p.rawConfig.LogFormat != logging.FormatText && p.rawConfig.LogFormat != logging.FormatJSON {
+		return fmt.Errorf("%w: %s", errInvalidLogFormat, p.rawConfig.LogFormat)
+	}
+
+	var err error
+
+	if p.moduleLogLevels, err = logging.ParseModuleLevels(p.rawConfig.LogLevels); err != nil {
+		return fmt.Errorf("invalid module log levels: %w", err)
+	}
+
+	return nil
+}
+
+func (p *serverParams) initEthStats() error {
+	if p.rawConfig.EthStats == "" {
+		return nil
+	}
+
+	var err error
+
+	p.ethStats, err = ethstats.ParseURL(p.rawConfig.EthStats)
+
+	return err
+}
+
+func (p *serverParams) initBackup() error {
+	if p.rawConfig.BackupSchedule == "" {
+		return nil
+	}
+
+	schedule, err := archive.ParseSchedule(p.rawConfig.BackupSchedule)
+	if err != nil {
+		return err
+	}
+
+	dir := p.rawConfig.BackupDir
+	if dir == "" {
+		dir = filepath.Join(p.rawConfig.DataDir, "backups")
+	}
+
+	p.backup = &archive.SchedulerConfig{
+		Schedule:  schedule,
+		Dir:       dir,
+		Retention: p.rawConfig.BackupRetention,
+		Compress:  p.rawConfig.BackupCompress,
+	}
+
+	if p.rawConfig.BackupS3Bucket != "" {
+		p.backupS3 = &archive.S3Config{
+			Bucket:   p.rawConfig.BackupS3Bucket,
+			Prefix:   p.rawConfig.BackupS3Prefix,
+			Region:   p.rawConfig.BackupS3Region,
+			Endpoint: p.rawConfig.BackupS3Endpoint,
+		}
+	}
+
+	return nil
+}
+
+func (p *serverParams) initWebhooks() error {
+	for i, endpoint := range p.rawConfig.Webhooks {
+		if err := endpoint.Validate(); err != nil {
+			return fmt.Errorf("invalid webhook %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *serverParams) initStreaming() error {
+	if p.rawConfig.Streaming == nil {
+		return nil
+	}
+
+	if err := p.rawConfig.Streaming.Validate(); err != nil {
+		return fmt.Errorf("invalid streaming config: %w", err)
+	}
+
+	return nil
+}
+
+func (p *serverParams) initUserOperationEntryPoints() error {
+	entryPoints := p.rawConfig.TxPool.UserOperationEntryPoints
+	p.userOperationEntryPoints = make([]types.Address, 0, len(entryPoints))
+
+	for _, entryPoint := range entryPoints {
+		if err := types.IsValidAddress(entryPoint); err != nil {
+			return fmt.Errorf("invalid user operation entry point %s: %w", entryPoint, err)
+		}
+
+		p.userOperationEntryPoints = append(p.userOperationEntryPoints, types.StringToAddress(entryPoint))
+	}
+
+	return nil
+}
+
+func (p *serverParams) initJSONRPCMethodConcurrencyLimits() error {
+	limits := p.rawConfig.JSONRPCMethodConcurrencyLimits
+	p.jsonRPCMethodConcurrencyLimits = make(map[string]uint64, len(limits))
+
+	for _, entry := range limits {
+		method, rawLimit, ok := strings.Cut(entry, "=")
+		if !ok {
+			return fmt.Errorf("invalid json-rpc method concurrency limit %s, expected method=limit", entry)
+		}
+
+		limit, err := strconv.ParseUint(rawLimit, 10, 64)
+		if err != nil || limit == 0 {
+			return fmt.Errorf("invalid json-rpc method concurrency limit %s, limit must be a positive number", entry)
+		}
+
+		p.jsonRPCMethodConcurrencyLimits[method] = limit
+	}
+
+	return nil
+}
+
+// initTLSConfig validates the TLS configurations of the endpoints,
+// the ACME certificates are cached in the data directory by default
+func (p *serverParams) initTLSConfig() error {
+	for name, tlsConfig := range map[string]*tlsconfig.Config{
+		"JSON-RPC": p.rawConfig.JSONRPCTLS,
+		"GRPC":     p.rawConfig.GRPCTLS,
+	} {
+		if err := tlsConfig.Validate(); err != nil {
+			return fmt.Errorf("invalid %s TLS configuration: %w", name, err)
+		}
+
+		if tlsConfig != nil && len(tlsConfig.ACMEDomains) > 0 && tlsConfig.ACMECacheDir == "" {
+			tlsConfig.ACMECacheDir = filepath.Join(p.rawConfig.DataDir, "acme")
+		}
+	}
+
+	return nil
+}
+
 func (p *serverParams) initBlockGasTarget() error {
 	var parseErr error
 
@@ -106,6 +298,13 @@ func (p *serverParams) initSecretsConfig() error {
 func (p *serverParams) initGenesisConfig() error {
 	var parseErr error
 
+	if p.isDevMode && !helperCommon.FileExists(p.rawConfig.GenesisPath) {
+		// the dev chain doesn't need the genesis file
+		p.genesisConfig = newDevGenesis()
+
+		return nil
+	}
+
 	if p.genesisConfig, parseErr = chain.Import(
 		p.rawConfig.GenesisPath,
 	); parseErr != nil {
@@ -120,14 +319,28 @@ func (p *serverParams) initGenesisConfig() error {
 	return nil
 }
 
-func (p *serverParams) initDevMode() {
+func (p *serverParams) initDevMode() error {
 	// Dev mode:
 	// - disables peer discovery
-	// - enables all forks
+	// - enables all forks, except london if the base fee is zero
+	// - prefunds the dev accounts
 	p.rawConfig.Network.NoDiscover = true
-	p.genesisConfig.Params.Forks = chain.AllForksEnabled
+	p.genesisConfig.Params.Forks = chain.AllForksEnabled.Copy()
+
+	if p.devZeroBaseFee {
+		p.genesisConfig.Params.Forks.RemoveFork(chain.London)
+	} else if len(p.genesisConfig.Params.BurnContract) == 0 {
+		// the base fee is burnt if the genesis doesn't set the burn contract
+		p.genesisConfig.Params.BurnContract = map[uint64]types.Address{0: types.ZeroAddress}
+	}
+
+	if err := p.initDevAccounts(); err != nil {
+		return err
+	}
 
 	p.initDevConsensusConfig()
+
+	return nil
 }
 
 func (p *serverParams) initDevConsensusConfig() {
@@ -138,6 +351,7 @@ func (p *serverParams) initDevConsensusConfig() {
 	p.genesisConfig.Params.Engine = map[string]interface{}{
 		string(server.DevConsensus): map[string]interface{}{
 			"interval": p.devInterval,
+			"instant":  p.devInstantSealing,
 		},
 	}
 }
@@ -204,6 +418,10 @@ func (p *serverParams) initAddresses() error {
 		return err
 	}
 
+	if err := p.initDebugAddress(); err != nil {
+		return err
+	}
+
 	if err := p.initLibp2pAddress(); err != nil {
 		return err
 	}
@@ -240,6 +458,28 @@ func (p *serverParams) initPrometheusAddress() error {
 	return nil
 }
 
+func (p *serverParams) initDebugAddress() error {
+	if p.rawConfig.Telemetry.DebugAddr == "" {
+		return nil
+	}
+
+	// the profiles expose the internals of the node, so they are served only to the admin
+	if p.rawConfig.AdminTokenFile == "" {
+		return errDebugAddrNoAdminToken
+	}
+
+	var parseErr error
+
+	if p.debugAddress, parseErr = helper.ResolveAddr(
+		p.rawConfig.Telemetry.DebugAddr,
+		helper.LocalHostBinding,
+	); parseErr != nil {
+		return parseErr
+	}
+
+	return nil
+}
+
 func (p *serverParams) initLibp2pAddress() error {
 	var parseErr error
 
@@ -258,12 +498,14 @@ func (p *serverParams) initNATAddress() error {
 		return nil
 	}
 
-	if p.natAddress = net.ParseIP(
-		p.rawConfig.Network.NatAddr,
-	); p.natAddress == nil {
-		return errInvalidNATAddress
+	portMap, natAddress, err := network.ParseNAT(p.rawConfig.Network.NatAddr)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidNATAddress, err)
 	}
 
+	p.natPortMap = portMap
+	p.natAddress = natAddress
+
 	return nil
 }
 
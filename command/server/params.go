@@ -4,11 +4,20 @@ import (
 	"errors"
 	"net"
 
+	"github.com/0xPolygon/polygon-edge/archive"
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command/server/config"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/remotesigner"
+	"github.com/0xPolygon/polygon-edge/ethstats"
+	"github.com/0xPolygon/polygon-edge/helper/kvdb"
+	"github.com/0xPolygon/polygon-edge/helper/logging"
+	"github.com/0xPolygon/polygon-edge/helper/tlsconfig"
+	"github.com/0xPolygon/polygon-edge/helper/tracing"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/syncer"
+	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/multiformats/go-multiaddr"
 )
@@ -19,32 +28,134 @@ const (
 	dataDirFlag                  = "data-dir"
 	libp2pAddressFlag            = "libp2p"
 	prometheusAddressFlag        = "prometheus"
+	debugAddressFlag             = "debug-addr"
+	otlpEndpointFlag             = "otlp-endpoint"
+	otlpProtocolFlag             = "otlp-protocol"
+	otlpInsecureFlag             = "otlp-insecure"
+	tracingSampleRatioFlag       = "tracing-sample-ratio"
 	natFlag                      = "nat"
 	dnsFlag                      = "dns"
 	sealFlag                     = "seal"
+	proposalDeadlineFlag         = "proposal-deadline"
 	maxPeersFlag                 = "max-peers"
 	maxInboundPeersFlag          = "max-inbound-peers"
 	maxOutboundPeersFlag         = "max-outbound-peers"
+	staticPeersFlag              = "static-peers"
+	trustedPeersFlag             = "trusted-peers"
+	dnsSeedsFlag                 = "dns-seeds"
+	inboundAllowCIDRsFlag        = "inbound-allow-cidrs"
+	inboundDenyCIDRsFlag         = "inbound-deny-cidrs"
+	outboundAllowCIDRsFlag       = "outbound-allow-cidrs"
+	outboundDenyCIDRsFlag        = "outbound-deny-cidrs"
 	priceLimitFlag               = "price-limit"
 	jsonRPCBatchRequestLimitFlag = "json-rpc-batch-request-limit"
 	jsonRPCBlockRangeLimitFlag   = "json-rpc-block-range-limit"
 	maxSlotsFlag                 = "max-slots"
 	maxEnqueuedFlag              = "max-enqueued"
+	txGossipBatchIntervalFlag    = "tx-gossip-batch-interval"
 	blockGasTargetFlag           = "block-gas-target"
 	secretsConfigFlag            = "secrets-config"
 	restoreFlag                  = "restore"
 	devIntervalFlag              = "dev-interval"
 	devFlag                      = "dev"
+	devInstantSealingFlag        = "dev-instant-sealing"
+	devAccountsFlag              = "dev-accounts"
+	devBalanceFlag               = "dev-balance"
+	devZeroBaseFeeFlag           = "dev-zero-base-fee"
+	devResetFlag                 = "dev-reset"
 	corsOriginFlag               = "access-control-allow-origins"
 	logFileLocationFlag          = "log-to"
+	userOpEntryPointsFlag        = "user-operation-entry-points"
 
-	relayerFlag               = "relayer"
-	numBlockConfirmationsFlag = "num-block-confirmations"
+	relayerFlag                  = "relayer"
+	numBlockConfirmationsFlag    = "num-block-confirmations"
+	blockTrackerPollIntervalFlag = "block-tracker-poll-interval"
+
+	watchConfigFlag = "watch-config"
 
 	concurrentRequestsDebugFlag = "concurrent-requests-debug"
 	webSocketReadLimitFlag      = "websocket-read-limit"
 
+	webSocketMaxConnectionsFlag   = "websocket-max-connections"
+	webSocketIdleTimeoutFlag      = "websocket-idle-timeout"
+	webSocketMaxSubscriptionsFlag = "websocket-max-subscriptions"
+	webSocketSendQueueFlag        = "websocket-send-queue"
+	pendingTxsRateLimitFlag       = "pending-txs-rate-limit"
+
+	jsonRPCCallTimeoutFlag             = "json-rpc-call-timeout"
+	jsonRPCCallCacheFlag               = "json-rpc-call-cache"
+	jsonRPCEncodingCacheFlag           = "json-rpc-encoding-cache"
+	jsonRPCMethodConcurrencyLimitsFlag = "json-rpc-method-concurrency-limits"
+	jsonRPCAccessLogFlag               = "json-rpc-access-log"
+	jsonRPCRateLimitsFileFlag          = "json-rpc-rate-limits-file"
+
+	jsonRPCTLSCertFlag        = "json-rpc-tls-cert"
+	jsonRPCTLSKeyFlag         = "json-rpc-tls-key"
+	jsonRPCTLSClientCAFlag    = "json-rpc-tls-client-ca"
+	jsonRPCTLSACMEDomainsFlag = "json-rpc-tls-acme-domains"
+	grpcTLSCertFlag           = "grpc-tls-cert"
+	grpcTLSKeyFlag            = "grpc-tls-key"
+	grpcTLSClientCAFlag       = "grpc-tls-client-ca"
+	grpcTLSACMEDomainsFlag    = "grpc-tls-acme-domains"
+
 	metricsIntervalFlag = "metrics-interval"
+
+	stateHistoryFlag  = "state-history"
+	stateSnapshotFlag = "state-snapshot"
+
+	parallelExecutionFlag = "parallel-execution"
+	statePrefetchFlag     = "state-prefetch"
+
+	evmStatsFlag = "evm-stats"
+
+	executionEngineFlag       = "execution-engine"
+	executionEngineBlocksFlag = "execution-engine-blocks"
+
+	logIndexFlag = "log-index"
+
+	stateDiffCacheFlag = "state-diff-cache"
+
+	maxReorgDepthFlag = "max-reorg-depth"
+
+	backupScheduleFlag   = "backup-schedule"
+	backupDirFlag        = "backup-dir"
+	backupRetentionFlag  = "backup-retention"
+	backupCompressFlag   = "backup-compress"
+	backupS3BucketFlag   = "backup-s3-bucket"
+	backupS3PrefixFlag   = "backup-s3-prefix"
+	backupS3RegionFlag   = "backup-s3-region"
+	backupS3EndpointFlag = "backup-s3-endpoint"
+
+	receiptsHistoryFlag = "receipts-history"
+	txLookupHistoryFlag = "tx-lookup-history"
+
+	storageBackendFlag = "storage-backend"
+
+	freezerThresholdFlag = "freezer-threshold"
+	freezerDirFlag       = "freezer-dir"
+
+	syncModeFlag = "sync-mode"
+
+	integrityCheckDepthFlag = "integrity-check-depth"
+	integrityRollbackFlag   = "integrity-rollback"
+
+	adminTokenFileFlag = "admin-token-file"
+
+	remoteSignerFlag       = "remote-signer"
+	remoteSignerCACertFlag = "remote-signer-ca-cert"
+	remoteSignerCertFlag   = "remote-signer-cert"
+	remoteSignerKeyFlag    = "remote-signer-key"
+	remoteSignerFailover   = "remote-signer-failover"
+
+	ethStatsFlag = "ethstats"
+
+	healthMaxHeadAgeFlag     = "health-max-head-age"
+	healthMinPeersFlag       = "health-min-peers"
+	healthMaxTrackerLagFlag  = "health-max-tracker-lag"
+	healthMaxTxPoolUsageFlag = "health-max-txpool-usage"
+
+	logLevelsFlag = "log-levels"
+	logFormatFlag = "log-format"
 )
 
 // Flags that are deprecated, but need to be preserved for
@@ -61,8 +172,15 @@ var (
 	params = &serverParams{
 		rawConfig: &config.Config{
 			Telemetry: &config.Telemetry{},
-			Network:   &config.Network{},
-			TxPool:    &config.TxPool{},
+			Network: &config.Network{
+				RateLimits:  network.DefaultRateLimits(),
+				PeerFilters: &network.PeerFilters{},
+			},
+			TxPool:     &config.TxPool{},
+			JSONRPCTLS: &tlsconfig.Config{},
+			GRPCTLS:    &tlsconfig.Config{},
+
+			RemoteSigner: &remotesigner.Config{},
 		},
 	}
 )
@@ -75,9 +193,14 @@ type serverParams struct {
 	rawConfig  *config.Config
 	configPath string
 
+	// watchConfig reloads the config file when it changes, besides on SIGHUP
+	watchConfig bool
+
 	libp2pAddress     *net.TCPAddr
 	prometheusAddress *net.TCPAddr
+	debugAddress      *net.TCPAddr
 	natAddress        net.IP
+	natPortMap        bool
 	dnsAddress        multiaddr.Multiaddr
 	grpcAddress       *net.TCPAddr
 	jsonRPCAddress    *net.TCPAddr
@@ -86,14 +209,32 @@ type serverParams struct {
 	devInterval    uint64
 	isDevMode      bool
 
+	// devInstantSealing seals a block as soon as there are promoted transactions, instead of at the dev interval
+	devInstantSealing bool
+	devAccountsCount  uint64
+	devBalanceRaw     string
+	devZeroBaseFee    bool
+	// devReset removes the chain and the state data of the previous dev run before starting
+	devReset bool
+
+	devAccounts []*devAccount
+
 	ibftBaseTimeoutLegacy uint64
 
 	genesisConfig *chain.Chain
 	secretsConfig *secrets.SecretsManagerConfig
 
 	logFileLocation string
+	ethStats        *ethstats.Config
+	backup          *archive.SchedulerConfig
+	backupS3        *archive.S3Config
+	moduleLogLevels map[string]hclog.Level
 
 	relayer bool
+
+	userOperationEntryPoints []types.Address
+
+	jsonRPCMethodConcurrencyLimits map[string]uint64
 }
 
 func (p *serverParams) isMaxPeersSet() bool {
@@ -153,27 +294,53 @@ func (p *serverParams) generateConfig() *server.Config {
 	return &server.Config{
 		Chain: p.genesisConfig,
 		JSONRPC: &server.JSONRPC{
-			JSONRPCAddr:              p.jsonRPCAddress,
-			AccessControlAllowOrigin: p.rawConfig.CorsAllowedOrigins,
-			BatchLengthLimit:         p.rawConfig.JSONRPCBatchRequestLimit,
-			BlockRangeLimit:          p.rawConfig.JSONRPCBlockRangeLimit,
-			ConcurrentRequestsDebug:  p.rawConfig.ConcurrentRequestsDebug,
-			WebSocketReadLimit:       p.rawConfig.WebSocketReadLimit,
+			JSONRPCAddr:               p.jsonRPCAddress,
+			AccessControlAllowOrigin:  p.rawConfig.CorsAllowedOrigins,
+			BatchLengthLimit:          p.rawConfig.JSONRPCBatchRequestLimit,
+			BlockRangeLimit:           p.rawConfig.JSONRPCBlockRangeLimit,
+			ConcurrentRequestsDebug:   p.rawConfig.ConcurrentRequestsDebug,
+			WebSocketReadLimit:        p.rawConfig.WebSocketReadLimit,
+			WebSocketMaxConnections:   p.rawConfig.WebSocketMaxConnections,
+			WebSocketIdleTimeout:      p.rawConfig.WebSocketIdleTimeout,
+			WebSocketMaxSubscriptions: p.rawConfig.WebSocketMaxSubscriptions,
+			WebSocketSendQueue:        p.rawConfig.WebSocketSendQueue,
+			PendingTxsRateLimit:       p.rawConfig.PendingTxsRateLimit,
+			CallTimeout:               p.rawConfig.JSONRPCCallTimeout,
+			CallCacheSize:             p.rawConfig.JSONRPCCallCache,
+			EncodingCacheSize:         p.rawConfig.JSONRPCEncodingCache,
+			MethodConcurrencyLimits:   p.jsonRPCMethodConcurrencyLimits,
+			AccessLog:                 p.rawConfig.JSONRPCAccessLog,
+			RateLimitsFile:            p.rawConfig.JSONRPCRateLimitsFile,
+			TLS:                       p.rawConfig.JSONRPCTLS,
 		},
 		GRPCAddr:   p.grpcAddress,
+		GRPCTLS:    p.rawConfig.GRPCTLS,
 		LibP2PAddr: p.libp2pAddress,
 		Telemetry: &server.Telemetry{
 			PrometheusAddr: p.prometheusAddress,
+			DebugAddr:      p.debugAddress,
+			Tracing: &tracing.Config{
+				Endpoint:    p.rawConfig.Telemetry.OTLPEndpoint,
+				Protocol:    p.rawConfig.Telemetry.OTLPProtocol,
+				Insecure:    p.rawConfig.Telemetry.OTLPInsecure,
+				SampleRatio: p.rawConfig.Telemetry.TracingSampleRatio,
+			},
 		},
 		Network: &network.Config{
 			NoDiscover:       p.rawConfig.Network.NoDiscover,
 			Addr:             p.libp2pAddress,
 			NatAddr:          p.natAddress,
+			NATPortMap:       p.natPortMap,
 			DNS:              p.dnsAddress,
 			DataDir:          p.rawConfig.DataDir,
 			MaxPeers:         p.rawConfig.Network.MaxPeers,
 			MaxInboundPeers:  p.rawConfig.Network.MaxInboundPeers,
 			MaxOutboundPeers: p.rawConfig.Network.MaxOutboundPeers,
+			RateLimits:       p.rawConfig.Network.RateLimits,
+			StaticPeers:      p.rawConfig.Network.StaticPeers,
+			TrustedPeers:     p.rawConfig.Network.TrustedPeers,
+			DNSSeeds:         p.rawConfig.Network.DNSSeeds,
+			PeerFilters:      p.rawConfig.Network.PeerFilters,
 			Chain:            p.genesisConfig,
 		},
 		DataDir:            p.rawConfig.DataDir,
@@ -181,14 +348,66 @@ func (p *serverParams) generateConfig() *server.Config {
 		PriceLimit:         p.rawConfig.TxPool.PriceLimit,
 		MaxSlots:           p.rawConfig.TxPool.MaxSlots,
 		MaxAccountEnqueued: p.rawConfig.TxPool.MaxAccountEnqueued,
+		UserOpEntryPoints:  p.userOperationEntryPoints,
 		SecretsManager:     p.secretsConfig,
 		RestoreFile:        p.getRestoreFilePath(),
 		LogLevel:           hclog.LevelFromString(p.rawConfig.LogLevel),
-		JSONLogFormat:      p.rawConfig.JSONLogFormat,
+		ModuleLogLevels:    p.moduleLogLevels,
+		JSONLogFormat:      p.rawConfig.JSONLogFormat || p.rawConfig.LogFormat == logging.FormatJSON,
 		LogFilePath:        p.logFileLocation,
 
 		Relayer:               p.relayer,
 		NumBlockConfirmations: p.rawConfig.NumBlockConfirmations,
+		TxGossipBatchInterval: p.rawConfig.TxPool.GossipBatchInterval,
 		MetricsInterval:       p.rawConfig.MetricsInterval,
+		StateHistory:          p.rawConfig.StateHistory,
+		StateSnapshot:         p.rawConfig.StateSnapshot,
+		ParallelExecution:     p.rawConfig.ParallelExecution,
+		StatePrefetch:         p.rawConfig.StatePrefetch,
+		EVMStats:              p.rawConfig.EVMStats,
+		ExecutionEngine:       p.rawConfig.ExecutionEngine,
+		ExecutionEngineBlocks: p.rawConfig.ExecutionEngineBlocks,
+		LogIndex:              p.rawConfig.LogIndex,
+		StateDiffCache:        p.rawConfig.StateDiffCache,
+		MaxReorgDepth:         p.rawConfig.MaxReorgDepth,
+		ReceiptsHistory:       p.rawConfig.ReceiptsHistory,
+		TxLookupHistory:       p.rawConfig.TxLookupHistory,
+		StorageBackend:        kvdb.Backend(p.rawConfig.StorageBackend),
+		FreezerThreshold:      p.rawConfig.FreezerThreshold,
+		FreezerDir:            p.rawConfig.FreezerDir,
+		SyncMode:              syncer.SyncMode(p.rawConfig.SyncMode),
+		IntegrityCheckDepth:   p.rawConfig.IntegrityCheckDepth,
+		IntegrityRollback:     p.rawConfig.IntegrityRollback,
+		AdminTokenFile:        p.rawConfig.AdminTokenFile,
+		RemoteSigner:          p.rawConfig.RemoteSigner,
+		EthStats:              p.ethStats,
+		Backup:                p.backup,
+		BackupS3:              p.backupS3,
+		Webhooks:              p.rawConfig.Webhooks,
+		Streaming:             p.rawConfig.Streaming,
+		Health: &server.HealthConfig{
+			MaxHeadAge:     p.rawConfig.HealthMaxHeadAge,
+			MinPeers:       p.rawConfig.HealthMinPeers,
+			MaxTrackerLag:  p.rawConfig.HealthMaxTrackerLag,
+			MaxTxPoolUsage: p.rawConfig.HealthMaxTxPoolUsage,
+		},
+
+		BlockTrackerPollInterval: p.rawConfig.BlockTrackerPollInterval,
+		ProposalDeadline:         p.rawConfig.ProposalDeadline,
+	}
+}
+
+// generateReloadableConfig returns the parameters which are applied to the running server on the config reload
+func (p *serverParams) generateReloadableConfig() *server.ReloadableConfig {
+	return &server.ReloadableConfig{
+		LogLevel:                 hclog.LevelFromString(p.rawConfig.LogLevel),
+		ModuleLogLevels:          p.moduleLogLevels,
+		JSONRPCBatchLengthLimit:  p.rawConfig.JSONRPCBatchRequestLimit,
+		JSONRPCBlockRangeLimit:   p.rawConfig.JSONRPCBlockRangeLimit,
+		PriceLimit:               p.rawConfig.TxPool.PriceLimit,
+		MaxInboundPeers:          p.rawConfig.Network.MaxInboundPeers,
+		MaxOutboundPeers:         p.rawConfig.Network.MaxOutboundPeers,
+		PeerFilters:              p.rawConfig.Network.PeerFilters,
+		BlockTrackerPollInterval: p.rawConfig.BlockTrackerPollInterval,
 	}
 }
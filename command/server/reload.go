@@ -0,0 +1,76 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/server"
+)
+
+// configWatchInterval is the interval of checking the config file for changes, when it is watched
+const configWatchInterval = 5 * time.Second
+
+// configReloader applies the operational parameters of the config file to the running server
+type configReloader struct {
+	server *server.Server
+	path   string
+}
+
+// load reads the config file and returns its parameters which can be changed at runtime
+func (r *configReloader) load() (*server.ReloadableConfig, error) {
+	p := &serverParams{configPath: r.path}
+
+	if err := p.initConfigFromFile(); err != nil {
+		return nil, err
+	}
+
+	if hclog.LevelFromString(p.rawConfig.LogLevel) == hclog.NoLevel {
+		return nil, fmt.Errorf("invalid log level %s", p.rawConfig.LogLevel)
+	}
+
+	if err := p.initLogging(); err != nil {
+		return nil, err
+	}
+
+	p.initPeerLimits()
+
+	return p.generateReloadableConfig(), nil
+}
+
+// reload applies the parameters of the config file
+func (r *configReloader) reload() {
+	r.server.ReloadFrom(r.load)
+}
+
+// watch reloads the config file whenever its modification time changes, until the done channel is closed
+func (r *configReloader) watch(doneCh <-chan struct{}) {
+	var modTime time.Time
+
+	if info, err := os.Stat(r.path); err == nil {
+		modTime = info.ModTime()
+	}
+
+	ticker := time.NewTicker(configWatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-doneCh:
+			return
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(r.path)
+		if err != nil || info.ModTime().Equal(modTime) {
+			// the file which is missing while it is being replaced is checked again on the next tick
+			continue
+		}
+
+		modTime = info.ModTime()
+
+		r.reload()
+	}
+}
@@ -46,6 +46,21 @@ func setFlags(cmd *cobra.Command) {
 		"the log level for console output",
 	)
 
+	cmd.Flags().StringVar(
+		&params.rawConfig.LogLevels,
+		logLevelsFlag,
+		"",
+		"the log levels overriding the log level of the modules and their submodules, "+
+			"as a comma separated list of module=level (e.g. network=debug,txpool=warn)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.LogFormat,
+		logFormatFlag,
+		defaultConfig.LogFormat,
+		"the format of the logs, text or json",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.GenesisPath,
 		genesisPathFlag,
@@ -60,6 +75,13 @@ func setFlags(cmd *cobra.Command) {
 		"the path to the CLI config. Supports .json, .hcl, .yaml, .yml",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.watchConfig,
+		watchConfigFlag,
+		false,
+		"reload the operational parameters when the CLI config changes, besides on SIGHUP",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.DataDir,
 		dataDirFlag,
@@ -82,11 +104,50 @@ func setFlags(cmd *cobra.Command) {
 			"If only port is defined (:port) it will bind to 0.0.0.0:port",
 	)
 
+	cmd.Flags().StringVar(
+		&params.rawConfig.Telemetry.DebugAddr,
+		debugAddressFlag,
+		"",
+		"the address and port of the debug service serving the pprof profiles, the expvar variables "+
+			"and the memory and goroutine statistics (address:port), which require the admin token. "+
+			"If only port is defined (:port) it will bind to 127.0.0.1:port",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.Telemetry.OTLPEndpoint,
+		otlpEndpointFlag,
+		"",
+		"the address of the OpenTelemetry collector (host:port) the traces of the block lifecycle "+
+			"are exported to with OTLP. The tracing is disabled if not set",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.Telemetry.OTLPProtocol,
+		otlpProtocolFlag,
+		defaultConfig.Telemetry.OTLPProtocol,
+		"the OTLP protocol the traces are exported with, grpc or http",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.Telemetry.OTLPInsecure,
+		otlpInsecureFlag,
+		false,
+		"the flag indicating that the OTLP collector is connected to without TLS",
+	)
+
+	cmd.Flags().Float64Var(
+		&params.rawConfig.Telemetry.TracingSampleRatio,
+		tracingSampleRatioFlag,
+		defaultConfig.Telemetry.TracingSampleRatio,
+		"the ratio of the traces which are sampled, between 0 and 1",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.Network.NatAddr,
 		natFlag,
 		"",
-		"the external IP address without port, as can be seen by peers",
+		"the NAT traversal mode: none, any (maps the port on the gateway over UPnP or NAT-PMP), "+
+			"upnp, pmp or extip:<IP> (the external IP address without port, as can be seen by peers)",
 	)
 
 	cmd.Flags().StringVar(
@@ -161,6 +222,57 @@ func setFlags(cmd *cobra.Command) {
 	cmd.Flag(maxOutboundPeersFlag).DefValue = fmt.Sprintf("%d", defaultConfig.Network.MaxOutboundPeers)
 	cmd.MarkFlagsMutuallyExclusive(maxPeersFlag, maxOutboundPeersFlag)
 
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.Network.StaticPeers,
+		staticPeersFlag,
+		nil,
+		"the multiaddrs of the peers which are kept connected and never pruned (e.g. /ip4/1.2.3.4/tcp/1478/p2p/<id>)",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.Network.TrustedPeers,
+		trustedPeersFlag,
+		nil,
+		"the multiaddrs of the peers which are accepted even if all the connection slots are taken, "+
+			"and never pruned",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.Network.DNSSeeds,
+		dnsSeedsFlag,
+		nil,
+		"the URLs of the signed DNS trees (enrtree://<public key>@<domain>) or the domains of the TXT seed lists, "+
+			"whose peers are used as the bootnodes",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.Network.PeerFilters.InboundAllow,
+		inboundAllowCIDRsFlag,
+		nil,
+		"the CIDRs the inbound peer connections are accepted from, all the addresses are accepted if empty",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.Network.PeerFilters.InboundDeny,
+		inboundDenyCIDRsFlag,
+		nil,
+		"the CIDRs the inbound peer connections are rejected from, takes precedence over the allowed CIDRs",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.Network.PeerFilters.OutboundAllow,
+		outboundAllowCIDRsFlag,
+		nil,
+		"the CIDRs the outbound peer connections are allowed to, all the addresses are allowed if empty",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.Network.PeerFilters.OutboundDeny,
+		outboundDenyCIDRsFlag,
+		nil,
+		"the CIDRs the outbound peer connections are denied to, takes precedence over the allowed CIDRs",
+	)
+
 	cmd.Flags().Uint64Var(
 		&params.rawConfig.TxPool.PriceLimit,
 		priceLimitFlag,
@@ -185,6 +297,22 @@ func setFlags(cmd *cobra.Command) {
 		"maximum number of enqueued transactions per account",
 	)
 
+	cmd.Flags().DurationVar(
+		&params.rawConfig.TxPool.GossipBatchInterval,
+		txGossipBatchIntervalFlag,
+		defaultConfig.TxPool.GossipBatchInterval,
+		"interval in which the local transactions are gossiped in batches (e.g. 50ms), "+
+			"value of 0 gossips every transaction on its own",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.TxPool.UserOperationEntryPoints,
+		userOpEntryPointsFlag,
+		defaultConfig.TxPool.UserOperationEntryPoints,
+		"ERC-4337 entry point addresses the user operations pool lane accepts operations for. "+
+			"If omitted, eth_sendUserOperation is disabled",
+	)
+
 	cmd.Flags().StringArrayVar(
 		&params.rawConfig.CorsAllowedOrigins,
 		corsOriginFlag,
@@ -204,7 +332,8 @@ func setFlags(cmd *cobra.Command) {
 		jsonRPCBlockRangeLimitFlag,
 		defaultConfig.JSONRPCBlockRangeLimit,
 		"max block range to be considered when executing json-rpc requests "+
-			"that consider fromBlock/toBlock values (e.g. eth_getLogs), value of 0 disables it",
+			"that consider fromBlock/toBlock values (e.g. eth_getLogs), value of 0 disables it "+
+			"(the log subscriptions and trace_filter are still limited to 1000 blocks)",
 	)
 
 	cmd.Flags().StringVar(
@@ -228,6 +357,21 @@ func setFlags(cmd *cobra.Command) {
 		"minimal number of child blocks required for the parent block to be considered final",
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.ProposalDeadline,
+		proposalDeadlineFlag,
+		defaultConfig.ProposalDeadline,
+		"the percentage of the block time after which the proposer seals the block with the transactions "+
+			"executed so far, leaving the rest of the block time to propagate the proposal",
+	)
+
+	cmd.Flags().DurationVar(
+		&params.rawConfig.BlockTrackerPollInterval,
+		blockTrackerPollIntervalFlag,
+		defaultConfig.BlockTrackerPollInterval,
+		"the interval of polling the new blocks of the parent chain, overriding the one of the genesis if not 0",
+	)
+
 	cmd.Flags().Uint64Var(
 		&params.rawConfig.ConcurrentRequestsDebug,
 		concurrentRequestsDebugFlag,
@@ -242,6 +386,147 @@ func setFlags(cmd *cobra.Command) {
 		"maximum size in bytes for a message read from the peer by websocket",
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.WebSocketMaxConnections,
+		webSocketMaxConnectionsFlag,
+		defaultConfig.WebSocketMaxConnections,
+		"maximum number of concurrent websocket connections, value of 0 disables it",
+	)
+
+	cmd.Flags().DurationVar(
+		&params.rawConfig.WebSocketIdleTimeout,
+		webSocketIdleTimeoutFlag,
+		defaultConfig.WebSocketIdleTimeout,
+		"the websocket connection is pinged twice per timeout, and closed if it answers neither the pings "+
+			"nor sends a message within the timeout, value of 0 disables it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.WebSocketMaxSubscriptions,
+		webSocketMaxSubscriptionsFlag,
+		defaultConfig.WebSocketMaxSubscriptions,
+		"maximum number of subscriptions of a single websocket connection, value of 0 disables it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.WebSocketSendQueue,
+		webSocketSendQueueFlag,
+		defaultConfig.WebSocketSendQueue,
+		"number of messages queued for a single websocket connection, the slow connection whose queue is full "+
+			"is closed instead of delaying the notifications of the other connections",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.PendingTxsRateLimit,
+		pendingTxsRateLimitFlag,
+		defaultConfig.PendingTxsRateLimit,
+		"maximum number of newPendingTransactions notifications per second sent over a single websocket connection, "+
+			"value of 0 disables it",
+	)
+
+	cmd.Flags().DurationVar(
+		&params.rawConfig.JSONRPCCallTimeout,
+		jsonRPCCallTimeoutFlag,
+		defaultConfig.JSONRPCCallTimeout,
+		"maximum execution time of eth_call and eth_estimateGas requests, value of 0 disables it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCCallCache,
+		jsonRPCCallCacheFlag,
+		defaultConfig.JSONRPCCallCache,
+		"number of the cached results of eth_call and eth_estimateGas executed on top of the head block, "+
+			"value of 0 disables the cache",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCEncodingCache,
+		jsonRPCEncodingCacheFlag,
+		defaultConfig.JSONRPCEncodingCache,
+		"number of the cached JSON encodings of the requested blocks and block receipts, value of 0 disables the cache",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.JSONRPCMethodConcurrencyLimits,
+		jsonRPCMethodConcurrencyLimitsFlag,
+		defaultConfig.JSONRPCMethodConcurrencyLimits,
+		"maximum number of concurrent requests per json-rpc method, given as method=limit pairs "+
+			"(e.g. eth_call=16,eth_estimateGas=8)",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.JSONRPCAccessLog,
+		jsonRPCAccessLogFlag,
+		defaultConfig.JSONRPCAccessLog,
+		"log every json-rpc http request with the caller, the called methods, the status and the duration",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCRateLimitsFile,
+		jsonRPCRateLimitsFileFlag,
+		defaultConfig.JSONRPCRateLimitsFile,
+		"path to the json file with the json-rpc rate limits per IP address and API key, "+
+			"the file is reloaded on change",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCTLS.CertFile,
+		jsonRPCTLSCertFlag,
+		defaultConfig.JSONRPCTLS.CertFile,
+		"the PEM certificate chain the json-rpc http and websocket endpoints are served with over TLS",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCTLS.KeyFile,
+		jsonRPCTLSKeyFlag,
+		defaultConfig.JSONRPCTLS.KeyFile,
+		"the PEM private key of the json-rpc TLS certificate",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCTLS.ClientCAFile,
+		jsonRPCTLSClientCAFlag,
+		defaultConfig.JSONRPCTLS.ClientCAFile,
+		"the PEM CA certificates the json-rpc clients are required to present a certificate signed by (mutual TLS)",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.JSONRPCTLS.ACMEDomains,
+		jsonRPCTLSACMEDomainsFlag,
+		defaultConfig.JSONRPCTLS.ACMEDomains,
+		"the domains whose json-rpc TLS certificates are provisioned over ACME (Let's Encrypt), "+
+			"instead of the certificate files. The endpoint has to be reachable on port 443",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.GRPCTLS.CertFile,
+		grpcTLSCertFlag,
+		defaultConfig.GRPCTLS.CertFile,
+		"the PEM certificate chain the operator GRPC endpoint is served with over TLS",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.GRPCTLS.KeyFile,
+		grpcTLSKeyFlag,
+		defaultConfig.GRPCTLS.KeyFile,
+		"the PEM private key of the GRPC TLS certificate",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.GRPCTLS.ClientCAFile,
+		grpcTLSClientCAFlag,
+		defaultConfig.GRPCTLS.ClientCAFile,
+		"the PEM CA certificates the GRPC clients are required to present a certificate signed by (mutual TLS)",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.GRPCTLS.ACMEDomains,
+		grpcTLSACMEDomainsFlag,
+		defaultConfig.GRPCTLS.ACMEDomains,
+		"the domains whose GRPC TLS certificates are provisioned over ACME (Let's Encrypt), "+
+			"instead of the certificate files. The endpoint has to be reachable on port 443",
+	)
+
 	cmd.Flags().DurationVar(
 		&params.rawConfig.MetricsInterval,
 		metricsIntervalFlag,
@@ -249,6 +534,289 @@ func setFlags(cmd *cobra.Command) {
 		"the interval (in seconds) at which special metrics are generated. a value of zero means the metrics are disabled",
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.StateHistory,
+		stateHistoryFlag,
+		defaultConfig.StateHistory,
+		"the number of the most recent blocks whose state is retained and can be queried, "+
+			"a value of zero retains the state of all blocks (archive mode)",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.StateSnapshot,
+		stateSnapshotFlag,
+		defaultConfig.StateSnapshot,
+		"maintain the flat snapshot of the head state, which serves the state reads without traversing the trie",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.ParallelExecution,
+		parallelExecutionFlag,
+		defaultConfig.ParallelExecution,
+		"(experimental) execute the transactions of the imported blocks concurrently, "+
+			"re-executing the conflicting ones sequentially",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.StatePrefetch,
+		statePrefetchFlag,
+		defaultConfig.StatePrefetch,
+		"prefetch the state predicted to be read by the block transactions while they are executed sequentially",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.EVMStats,
+		evmStatsFlag,
+		defaultConfig.EVMStats,
+		"collect the execution counts, the gas and the execution time of the EVM opcodes, "+
+			"exposed via the debug_evmStats endpoint",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.ExecutionEngine,
+		executionEngineFlag,
+		defaultConfig.ExecutionEngine,
+		"(experimental) the engine executing the contract code of the JSON-RPC calls and traces, "+
+			"to compare the performance of the alternative engines with the built-in EVM",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.ExecutionEngineBlocks,
+		executionEngineBlocksFlag,
+		defaultConfig.ExecutionEngineBlocks,
+		"(experimental) execute the blocks with the engine set by --execution-engine as well, "+
+			"otherwise the blocks are always executed by the built-in EVM",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.LogIndex,
+		logIndexFlag,
+		defaultConfig.LogIndex,
+		"maintain the index of the log blooms in the background, which speeds up the log queries "+
+			"over large block ranges",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.StateDiffCache,
+		stateDiffCacheFlag,
+		defaultConfig.StateDiffCache,
+		"the number of the most recent blocks whose state diffs are collected during the execution and kept "+
+			"for debug_getStateDiff, the diffs of the other blocks are computed by executing them again (0 disables)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.MaxReorgDepth,
+		maxReorgDepthFlag,
+		defaultConfig.MaxReorgDepth,
+		"the maximum number of the canonical blocks replaced by a chain reorganization of the consensus engines "+
+			"without the instant finality, the reorganizations of the ibft and polybft chains are always rejected "+
+			"(0 is unlimited)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.BackupSchedule,
+		backupScheduleFlag,
+		defaultConfig.BackupSchedule,
+		"the cron expression (e.g. \"0 3 * * *\" or @daily) of the full backups of the chain created by the node, "+
+			"the backups aren't scheduled if empty",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.BackupDir,
+		backupDirFlag,
+		defaultConfig.BackupDir,
+		"the directory the scheduled backups are written to (default <data-dir>/backups)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.BackupRetention,
+		backupRetentionFlag,
+		defaultConfig.BackupRetention,
+		"the number of the most recent scheduled backups kept, the older ones are deleted (0 keeps all of them)",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.BackupCompress,
+		backupCompressFlag,
+		defaultConfig.BackupCompress,
+		"gzip the scheduled backups",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.BackupS3Bucket,
+		backupS3BucketFlag,
+		defaultConfig.BackupS3Bucket,
+		"the S3 bucket the scheduled backups are uploaded to, they are kept in the backup directory if empty",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.BackupS3Prefix,
+		backupS3PrefixFlag,
+		defaultConfig.BackupS3Prefix,
+		"the prefix of the object keys of the uploaded backups",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.BackupS3Region,
+		backupS3RegionFlag,
+		defaultConfig.BackupS3Region,
+		"the region of the S3 bucket of the backups",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.BackupS3Endpoint,
+		backupS3EndpointFlag,
+		defaultConfig.BackupS3Endpoint,
+		"the URL of the S3-compatible storage of the backups, AWS S3 if empty",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.ReceiptsHistory,
+		receiptsHistoryFlag,
+		defaultConfig.ReceiptsHistory,
+		"the number of the most recent blocks whose receipts are retained, "+
+			"a value of zero retains the receipts of all blocks",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxLookupHistory,
+		txLookupHistoryFlag,
+		defaultConfig.TxLookupHistory,
+		"the number of the most recent blocks whose transactions can be looked up by hash, "+
+			"a value of zero retains the lookups of all blocks",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.StorageBackend,
+		storageBackendFlag,
+		defaultConfig.StorageBackend,
+		"the key-value database backend of the blockchain and the state storages (leveldb, pebble), "+
+			"the existing data directory has to be migrated with 'storage migrate' before switching the backend",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.FreezerThreshold,
+		freezerThresholdFlag,
+		defaultConfig.FreezerThreshold,
+		"the number of the most recent blocks whose headers, bodies and receipts are kept in the key-value store, "+
+			"the older ones are moved to the append-only freezer. A value of zero disables the freezer",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.FreezerDir,
+		freezerDirFlag,
+		defaultConfig.FreezerDir,
+		"the directory of the freezer, which may be mounted on a cheaper storage (default <data-dir>/ancient)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.SyncMode,
+		syncModeFlag,
+		defaultConfig.SyncMode,
+		"the way the node catches up with the chain (full, fast). The fast sync downloads the state of a recent block "+
+			"from the peers and executes only the blocks after it, when the node is far behind",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.IntegrityCheckDepth,
+		integrityCheckDepthFlag,
+		defaultConfig.IntegrityCheckDepth,
+		"the number of the most recent blocks whose headers, bodies, receipts and canonical hashes are verified "+
+			"on startup. A value of zero disables the check",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.IntegrityRollback,
+		integrityRollbackFlag,
+		defaultConfig.IntegrityRollback,
+		"roll the chain back to the last consistent block if the startup integrity check fails, "+
+			"instead of refusing to start",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.AdminTokenFile,
+		adminTokenFileFlag,
+		defaultConfig.AdminTokenFile,
+		"path to the file with the bearer token required by the admin gRPC and json-rpc controls "+
+			"(pausing the proposals and the txpool, flushing the txpool, the log levels, the state snapshot). "+
+			"The controls are disabled if not set",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.EthStats,
+		ethStatsFlag,
+		defaultConfig.EthStats,
+		"the URL of the ethstats server the block, peer and sync statistics of the node are reported to, "+
+			"ws://host:port?secret=<secret>[&name=<node name>]. The node name is the host name if not set",
+	)
+
+	cmd.Flags().DurationVar(
+		&params.rawConfig.HealthMaxHeadAge,
+		healthMaxHeadAgeFlag,
+		defaultConfig.HealthMaxHeadAge,
+		"the maximum age of the head block of the node reported ready on /readyz, 0 disables the criterion",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.HealthMinPeers,
+		healthMinPeersFlag,
+		defaultConfig.HealthMinPeers,
+		"the minimum number of the peers of the node reported ready on /readyz, 0 disables the criterion",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.HealthMaxTrackerLag,
+		healthMaxTrackerLagFlag,
+		defaultConfig.HealthMaxTrackerLag,
+		"the maximum number of the root chain blocks the event tracker of the node reported ready on /readyz "+
+			"is behind, 0 disables the criterion",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.HealthMaxTxPoolUsage,
+		healthMaxTxPoolUsageFlag,
+		defaultConfig.HealthMaxTxPoolUsage,
+		"the maximum percentage of the txpool slots in use of the node reported ready on /readyz, "+
+			"0 disables the criterion",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.RemoteSigner.Addr,
+		remoteSignerFlag,
+		defaultConfig.RemoteSigner.Addr,
+		"the gRPC address of the remote signer holding the validator keys (polybft), "+
+			"the validator keys of the secrets manager are used if not set",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.RemoteSigner.CACertFile,
+		remoteSignerCACertFlag,
+		defaultConfig.RemoteSigner.CACertFile,
+		"the PEM CA certificates the remote signer certificate is verified by, TLS is used if set",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.RemoteSigner.CertFile,
+		remoteSignerCertFlag,
+		defaultConfig.RemoteSigner.CertFile,
+		"the PEM client certificate presented to the remote signer (mutual TLS)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.RemoteSigner.KeyFile,
+		remoteSignerKeyFlag,
+		defaultConfig.RemoteSigner.KeyFile,
+		"the PEM private key of the remote signer client certificate",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.RemoteSigner.Failover,
+		remoteSignerFailover,
+		defaultConfig.RemoteSigner.Failover,
+		"run the node in the active/standby mode with the other nodes sharing the remote signer, "+
+			"the node takes part in the consensus only while it holds the signing lease of the signer",
+	)
+
 	setLegacyFlags(cmd)
 
 	setDevFlags(cmd)
@@ -273,11 +841,10 @@ func setDevFlags(cmd *cobra.Command) {
 		&params.isDevMode,
 		devFlag,
 		false,
-		"should the client start in dev mode (default false)",
+		"should the client start in dev mode, running a single node chain with prefunded accounts. "+
+			"The genesis is generated if the genesis file is missing (default false)",
 	)
 
-	_ = cmd.Flags().MarkHidden(devFlag)
-
 	cmd.Flags().Uint64Var(
 		&params.devInterval,
 		devIntervalFlag,
@@ -285,7 +852,41 @@ func setDevFlags(cmd *cobra.Command) {
 		"the client's dev notification interval in seconds (default 1)",
 	)
 
-	_ = cmd.Flags().MarkHidden(devIntervalFlag)
+	cmd.Flags().BoolVar(
+		&params.devInstantSealing,
+		devInstantSealingFlag,
+		false,
+		"seal a block as soon as there are pending transactions, instead of at the dev interval",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.devAccountsCount,
+		devAccountsFlag,
+		defaultDevAccountsCount,
+		"the number of the deterministic dev accounts prefunded in the genesis",
+	)
+
+	cmd.Flags().StringVar(
+		&params.devBalanceRaw,
+		devBalanceFlag,
+		command.DefaultPremineBalance.String(),
+		"the balance of each dev account in wei, decimal or hex",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.devZeroBaseFee,
+		devZeroBaseFeeFlag,
+		false,
+		"run the dev chain without the base fee, by disabling the london fork",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.devReset,
+		devResetFlag,
+		false,
+		"remove the chain and the state of the previous dev run before starting",
+	)
+
 }
 
 func runPreRun(cmd *cobra.Command, _ []string) error {
@@ -317,7 +918,16 @@ func isConfigFileSpecified(cmd *cobra.Command) bool {
 func runCommand(cmd *cobra.Command, _ []string) {
 	outputter := command.InitializeOutputter(cmd)
 
-	if err := runServerLoop(params.generateConfig(), outputter); err != nil {
+	if params.isDevMode {
+		if err := startDevMode(outputter); err != nil {
+			outputter.SetError(err)
+			outputter.WriteOutput()
+
+			return
+		}
+	}
+
+	if err := runServerLoop(params.generateConfig(), outputter, isConfigFileSpecified(cmd)); err != nil {
 		outputter.SetError(err)
 		outputter.WriteOutput()
 
@@ -328,11 +938,26 @@ func runCommand(cmd *cobra.Command, _ []string) {
 func runServerLoop(
 	config *server.Config,
 	outputter command.OutputFormatter,
+	reloadable bool,
 ) error {
 	serverInstance, err := server.NewServer(config)
 	if err != nil {
 		return err
 	}
 
-	return helper.HandleSignals(serverInstance.Close, outputter)
+	if !reloadable {
+		return helper.HandleSignals(serverInstance.Close, outputter)
+	}
+
+	// the operational parameters of the config file are reloaded on SIGHUP, and on change if it is watched
+	reloader := &configReloader{server: serverInstance, path: params.configPath}
+
+	if params.watchConfig {
+		doneCh := make(chan struct{})
+		defer close(doneCh)
+
+		go reloader.watch(doneCh)
+	}
+
+	return helper.HandleSignalsWithReload(serverInstance.Close, reloader.reload, outputter)
 }
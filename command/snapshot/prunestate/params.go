@@ -0,0 +1,175 @@
+package prunestate
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/syndtr/goleveldb/leveldb"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	leveldb2 "github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	dataDirFlag      = "data-dir"
+	chainFlag        = "chain"
+	stateHistoryFlag = "state-history"
+)
+
+var (
+	params = &pruneStateParams{}
+)
+
+var (
+	errNoStateHistory = errors.New(`"state-history" must be greater than 0`)
+	errHeadNotFound   = errors.New("can't read the head of the chain")
+)
+
+type pruneStateParams struct {
+	dataDir      string
+	genesisPath  string
+	stateHistory uint64
+
+	head     uint64
+	retained int
+	deleted  uint64
+}
+
+func (p *pruneStateParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+	}
+}
+
+func (p *pruneStateParams) validateFlags() error {
+	if p.stateHistory == 0 {
+		return errNoStateHistory
+	}
+
+	return nil
+}
+
+// pruneState deletes the state nodes which are not reachable
+// from the genesis and the most recent blocks of the chain
+func (p *pruneStateParams) pruneState() error {
+	chainDB, err := leveldb2.NewLevelDBStorage(filepath.Join(p.dataDir, "blockchain"), hclog.NewNullLogger())
+	if err != nil {
+		return fmt.Errorf("can't open the blockchain db: %w", err)
+	}
+
+	defer chainDB.Close()
+
+	roots, err := p.retainedRoots(chainDB)
+	if err != nil {
+		return err
+	}
+
+	trieDB, err := leveldb.OpenFile(filepath.Join(p.dataDir, "trie"), nil)
+	if err != nil {
+		return fmt.Errorf("can't open the trie db: %w", err)
+	}
+
+	defer trieDB.Close()
+
+	trieStorage := itrie.NewKV(trieDB)
+
+	marked := make(map[types.Hash]struct{})
+	if err := itrie.MarkReachable(trieStorage, roots, marked); err != nil {
+		return err
+	}
+
+	p.retained = len(roots)
+
+	p.deleted, err = itrie.Sweep(trieStorage, marked)
+
+	return err
+}
+
+// retainedRoots returns the state roots of the genesis and the most recent blocks
+func (p *pruneStateParams) retainedRoots(chainDB storage.Storage) ([]types.Hash, error) {
+	head, ok := chainDB.ReadHeadNumber()
+	if !ok {
+		return nil, errHeadNotFound
+	}
+
+	p.head = head
+
+	roots, err := p.genesisRoots(chainDB)
+	if err != nil {
+		return nil, err
+	}
+
+	from := uint64(1)
+	if head >= p.stateHistory {
+		from = head - p.stateHistory + 1
+	}
+
+	for number := from; number <= head; number++ {
+		header, err := readCanonicalHeader(chainDB, number)
+		if err != nil {
+			return nil, err
+		}
+
+		roots = append(roots, header.StateRoot)
+	}
+
+	return roots, nil
+}
+
+// genesisRoots returns the state root of the genesis block,
+// along with the initial state root of the polybft chain which is checked on every start
+func (p *pruneStateParams) genesisRoots(chainDB storage.Storage) ([]types.Hash, error) {
+	genesis, err := readCanonicalHeader(chainDB, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := []types.Hash{genesis.StateRoot}
+
+	chainConfig, err := chain.ImportFromFile(p.genesisPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the chain config from %s: %w", p.genesisPath, err)
+	}
+
+	if chainConfig.Params.GetEngine() == polybft.ConsensusName {
+		polyBFTConfig, err := polybft.GetPolyBFTConfig(chainConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		if polyBFTConfig.InitialTrieRoot != types.ZeroHash {
+			roots = append(roots, polyBFTConfig.InitialTrieRoot)
+		}
+	}
+
+	return roots, nil
+}
+
+func readCanonicalHeader(chainDB storage.Storage, number uint64) (*types.Header, error) {
+	hash, ok := chainDB.ReadCanonicalHash(number)
+	if !ok {
+		return nil, fmt.Errorf("can't read the canonical hash of block %d", number)
+	}
+
+	header, err := chainDB.ReadHeader(hash)
+	if err != nil {
+		return nil, fmt.Errorf("can't read the header of block %d: %w", number, err)
+	}
+
+	return header, nil
+}
+
+func (p *pruneStateParams) getResult() command.CommandResult {
+	return &PruneStateResult{
+		Head:     p.head,
+		Retained: p.retained,
+		Deleted:  p.deleted,
+	}
+}
@@ -0,0 +1,65 @@
+package prunestate
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	pruneStateCmd := &cobra.Command{
+		Use: "prune-state",
+		Short: "Deletes the state which is not reachable from the most recent blocks. " +
+			"The node must be stopped while the state is pruned",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(pruneStateCmd)
+	helper.SetRequiredFlags(pruneStateCmd, params.getRequiredFlags())
+
+	return pruneStateCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		chainFlag,
+		fmt.Sprintf("./%s", command.DefaultGenesisFileName),
+		"the genesis file of the chain",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.stateHistory,
+		stateHistoryFlag,
+		0,
+		"the number of the most recent blocks whose state is retained",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.pruneState(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,28 @@
+package prunestate
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type PruneStateResult struct {
+	Head     uint64 `json:"head"`
+	Retained int    `json:"retained"`
+	Deleted  uint64 `json:"deleted"`
+}
+
+func (r *PruneStateResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[PRUNE STATE]\n")
+	buffer.WriteString("Pruned the state successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Head|%d", r.Head),
+		fmt.Sprintf("Retained state roots|%d", r.Retained),
+		fmt.Sprintf("Deleted nodes|%d", r.Deleted),
+	}))
+
+	return buffer.String()
+}
@@ -0,0 +1,120 @@
+package rebuildtxindex
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
+	"github.com/0xPolygon/polygon-edge/command"
+)
+
+const (
+	dataDirFlag = "data-dir"
+	fromFlag    = "from"
+
+	// batchSize is the number of blocks whose transaction lookups are written in a single batch
+	batchSize = 1024
+)
+
+var (
+	params = &rebuildTxIndexParams{}
+)
+
+var (
+	errHeadNotFound = errors.New("can't read the head of the chain")
+)
+
+type rebuildTxIndexParams struct {
+	dataDir string
+	from    uint64
+
+	head    uint64
+	blocks  uint64
+	indexed uint64
+}
+
+func (p *rebuildTxIndexParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+	}
+}
+
+// rebuildTxIndex writes the transaction lookups of the blocks from the given one up to the head,
+// restoring the lookups deleted by the pruning
+func (p *rebuildTxIndexParams) rebuildTxIndex() error {
+	chainDB, err := leveldb.NewLevelDBStorage(filepath.Join(p.dataDir, "blockchain"), hclog.NewNullLogger())
+	if err != nil {
+		return fmt.Errorf("can't open the blockchain db: %w", err)
+	}
+
+	defer chainDB.Close()
+
+	head, ok := chainDB.ReadHeadNumber()
+	if !ok {
+		return errHeadNotFound
+	}
+
+	p.head = head
+
+	if p.from > head {
+		return fmt.Errorf("block %d is past the head %d", p.from, head)
+	}
+
+	for batchFrom := p.from; batchFrom <= head; batchFrom += batchSize {
+		batchTo := batchFrom + batchSize - 1
+		if batchTo > head {
+			batchTo = head
+		}
+
+		if err := p.rebuildBatch(chainDB, batchFrom, batchTo); err != nil {
+			return err
+		}
+	}
+
+	// the lookups are retained from the given block on, unless they were retained from the earlier one
+	if tail, ok := chainDB.ReadTxLookupTail(); ok && tail > p.from {
+		batchWriter := storage.NewBatchWriter(chainDB)
+		batchWriter.PutTxLookupTail(p.from)
+
+		return batchWriter.WriteBatch()
+	}
+
+	return nil
+}
+
+func (p *rebuildTxIndexParams) rebuildBatch(chainDB storage.Storage, from, to uint64) error {
+	batchWriter := storage.NewBatchWriter(chainDB)
+
+	for number := from; number <= to; number++ {
+		hash, ok := chainDB.ReadCanonicalHash(number)
+		if !ok {
+			return fmt.Errorf("can't read the canonical hash of block %d", number)
+		}
+
+		body, err := chainDB.ReadBody(hash)
+		if err != nil {
+			return fmt.Errorf("can't read the body of block %d: %w", number, err)
+		}
+
+		for _, tx := range body.Transactions {
+			batchWriter.PutTxLookup(tx.Hash, hash)
+		}
+
+		p.blocks++
+		p.indexed += uint64(len(body.Transactions))
+	}
+
+	return batchWriter.WriteBatch()
+}
+
+func (p *rebuildTxIndexParams) getResult() command.CommandResult {
+	return &RebuildTxIndexResult{
+		Head:         p.head,
+		Blocks:       p.blocks,
+		Transactions: p.indexed,
+	}
+}
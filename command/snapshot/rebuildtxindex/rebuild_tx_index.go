@@ -0,0 +1,51 @@
+package rebuildtxindex
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	rebuildTxIndexCmd := &cobra.Command{
+		Use: "rebuild-tx-index",
+		Short: "Rebuilds the transaction lookups of the blocks, including the ones deleted by the pruning. " +
+			"The node must be stopped while the lookups are rebuilt",
+		Run: runCommand,
+	}
+
+	setFlags(rebuildTxIndexCmd)
+	helper.SetRequiredFlags(rebuildTxIndexCmd, params.getRequiredFlags())
+
+	return rebuildTxIndexCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the node",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.from,
+		fromFlag,
+		0,
+		"the number of the first block whose transaction lookups are rebuilt",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.rebuildTxIndex(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,28 @@
+package rebuildtxindex
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type RebuildTxIndexResult struct {
+	Head         uint64 `json:"head"`
+	Blocks       uint64 `json:"blocks"`
+	Transactions uint64 `json:"transactions"`
+}
+
+func (r *RebuildTxIndexResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[REBUILD TX INDEX]\n")
+	buffer.WriteString("Rebuilt the transaction lookups successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Head|%d", r.Head),
+		fmt.Sprintf("Blocks|%d", r.Blocks),
+		fmt.Sprintf("Transactions|%d", r.Transactions),
+	}))
+
+	return buffer.String()
+}
@@ -0,0 +1,28 @@
+package snapshot
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command/snapshot/prunestate"
+	"github.com/0xPolygon/polygon-edge/command/snapshot/rebuildtxindex"
+)
+
+func GetCommand() *cobra.Command {
+	snapshotCmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Top level command for maintaining the state of a stopped node. Only accepts subcommands.",
+	}
+
+	registerSubcommands(snapshotCmd)
+
+	return snapshotCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// snapshot prune-state
+		prunestate.GetCommand(),
+		// snapshot rebuild-tx-index
+		rebuildtxindex.GetCommand(),
+	)
+}
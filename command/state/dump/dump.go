@@ -0,0 +1,81 @@
+package dump
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	dumpCmd := &cobra.Command{
+		Use: "dump",
+		Short: "Exports all the accounts of the state of the block, along with their code and storage. " +
+			"The geth-genesis format is the alloc section of the geth genesis, which lets geth or anvil " +
+			"start a local chain with the same state. The node must be stopped while the state is exported",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(dumpCmd)
+	helper.SetRequiredFlags(dumpCmd, params.getRequiredFlags())
+
+	return dumpCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.freezerDir,
+		freezerDirFlag,
+		"",
+		"the directory of the freezer (default <data-dir>/ancient)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.block,
+		blockFlag,
+		0,
+		"the number of the block whose state is exported (default the head of the chain)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.format,
+		formatFlag,
+		edgeFormat,
+		"the format of the export: edge for the accounts along with the state root, "+
+			"or geth-genesis for the alloc section of the geth genesis",
+	)
+
+	cmd.Flags().StringVar(
+		&params.output,
+		outputFlag,
+		defaultOutput,
+		"the file the state is written to",
+	)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	params.latest = !cmd.Flags().Changed(blockFlag)
+
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.dumpState(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
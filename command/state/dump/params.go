@@ -0,0 +1,227 @@
+package dump
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/command"
+	storageHelper "github.com/0xPolygon/polygon-edge/command/storage/helper"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	dataDirFlag    = "data-dir"
+	freezerDirFlag = "freezer-dir"
+	blockFlag      = "block"
+	formatFlag     = "format"
+	outputFlag     = "output"
+
+	edgeFormat        = "edge"
+	gethGenesisFormat = "geth-genesis"
+
+	defaultOutput = "state-dump.json"
+
+	// dumpRangeSize is the number of the accounts read from the state at once
+	dumpRangeSize = 1000
+)
+
+var (
+	params = &dumpParams{}
+)
+
+var (
+	errHeadNotFound = errors.New("can't read the head of the chain")
+)
+
+type dumpParams struct {
+	dataDir    string
+	freezerDir string
+	block      uint64
+	latest     bool
+	format     string
+	output     string
+
+	root     types.Hash
+	accounts uint64
+}
+
+// gethAccount is the account of the alloc section of the geth genesis
+type gethAccount struct {
+	Balance string                    `json:"balance"`
+	Nonce   string                    `json:"nonce,omitempty"`
+	Code    string                    `json:"code,omitempty"`
+	Storage map[types.Hash]types.Hash `json:"storage,omitempty"`
+}
+
+func (p *dumpParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+	}
+}
+
+func (p *dumpParams) validateFlags() error {
+	if p.format != edgeFormat && p.format != gethGenesisFormat {
+		return fmt.Errorf("unknown format %q, expected %s or %s", p.format, edgeFormat, gethGenesisFormat)
+	}
+
+	if p.output == "" {
+		return fmt.Errorf("the output file is not set")
+	}
+
+	return nil
+}
+
+// dumpState writes all the accounts of the state of the block to the output file
+func (p *dumpParams) dumpState() error {
+	db, err := storageHelper.OpenChainStorage(p.dataDir, p.freezerDir)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	if p.latest {
+		head, ok := db.ReadHeadNumber()
+		if !ok {
+			return errHeadNotFound
+		}
+
+		p.block = head
+	}
+
+	hash, ok := db.ReadCanonicalHash(p.block)
+	if !ok {
+		return fmt.Errorf("block %d not found", p.block)
+	}
+
+	header, err := db.ReadHeader(hash)
+	if err != nil {
+		return fmt.Errorf("can't read the header of block %d: %w", p.block, err)
+	}
+
+	p.root = header.StateRoot
+
+	stateStorage, err := storageHelper.OpenStateStorage(p.dataDir, db.Backend)
+	if err != nil {
+		return err
+	}
+
+	defer stateStorage.Close()
+
+	file, err := os.Create(p.output)
+	if err != nil {
+		return fmt.Errorf("can't create the output file: %w", err)
+	}
+
+	defer file.Close()
+
+	if p.accounts, err = writeState(stateStorage, p.root, p.format, file); err != nil {
+		return err
+	}
+
+	return file.Close()
+}
+
+// writeState writes the accounts of the state with the given root in the given format,
+// reading them in the ranges so the whole state is never held in the memory
+func writeState(storage itrie.Storage, root types.Hash, format string, w io.Writer) (uint64, error) {
+	buf := bufio.NewWriter(w)
+
+	if format == gethGenesisFormat {
+		fmt.Fprint(buf, `{"alloc":{`)
+	} else {
+		fmt.Fprintf(buf, `{"root":"%s","accounts":{`, root)
+	}
+
+	var (
+		start    = types.ZeroHash
+		accounts = uint64(0)
+	)
+
+	for {
+		dump, err := itrie.DumpState(storage, root, start, dumpRangeSize)
+		if err != nil {
+			return 0, err
+		}
+
+		addrs := make([]types.Address, 0, len(dump.Accounts))
+		for addr := range dump.Accounts {
+			addrs = append(addrs, addr)
+		}
+
+		sort.Slice(addrs, func(i, j int) bool {
+			return bytes.Compare(addrs[i].Bytes(), addrs[j].Bytes()) < 0
+		})
+
+		for _, addr := range addrs {
+			raw, err := encodeAccount(dump.Accounts[addr], format)
+			if err != nil {
+				return 0, fmt.Errorf("failed to encode account %s: %w", addr, err)
+			}
+
+			if accounts > 0 {
+				fmt.Fprint(buf, ",")
+			}
+
+			fmt.Fprintf(buf, `"%s":%s`, addr, raw)
+
+			accounts++
+		}
+
+		if dump.Next == nil {
+			break
+		}
+
+		start = *dump.Next
+	}
+
+	fmt.Fprintln(buf, "}}")
+
+	return accounts, buf.Flush()
+}
+
+// encodeAccount encodes the account of the Edge genesis, or of the geth genesis,
+// which always has the balance
+func encodeAccount(account *chain.GenesisAccount, format string) ([]byte, error) {
+	if format != gethGenesisFormat {
+		return json.Marshal(account)
+	}
+
+	encoded := &gethAccount{
+		Balance: "0x0",
+		Storage: account.Storage,
+	}
+
+	if account.Balance != nil {
+		encoded.Balance = *common.EncodeBigInt(account.Balance)
+	}
+
+	if account.Nonce != 0 {
+		encoded.Nonce = *common.EncodeUint64(account.Nonce)
+	}
+
+	if len(account.Code) != 0 {
+		encoded.Code = *common.EncodeBytes(account.Code)
+	}
+
+	return json.Marshal(encoded)
+}
+
+func (p *dumpParams) getResult() command.CommandResult {
+	return &DumpResult{
+		Block:    p.block,
+		Root:     p.root.String(),
+		Format:   p.format,
+		Accounts: p.accounts,
+		Output:   p.output,
+	}
+}
@@ -0,0 +1,117 @@
+package dump
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestWriteState(t *testing.T) {
+	t.Parallel()
+
+	var (
+		code    = []byte{0x60, 0x00}
+		slot    = types.StringToHash("1")
+		storage = itrie.NewMemoryStorage()
+		objs    = make([]*state.Object, 3)
+	)
+
+	for i := range objs {
+		objs[i] = &state.Object{
+			Address:  types.StringToAddress(string(rune('1' + i))),
+			Balance:  big.NewInt(int64(i)),
+			Nonce:    uint64(i),
+			Root:     types.EmptyRootHash,
+			CodeHash: types.EmptyCodeHash,
+		}
+	}
+
+	objs[1].CodeHash = types.BytesToHash(crypto.Keccak256(code))
+	objs[1].Code = code
+	objs[1].DirtyCode = true
+	objs[1].Storage = []*state.StorageObject{{Key: slot.Bytes(), Val: big.NewInt(10).Bytes()}}
+
+	_, rawRoot, err := itrie.NewState(storage).NewSnapshot().Commit(objs)
+	require.NoError(t, err)
+
+	root := types.BytesToHash(rawRoot)
+
+	t.Run("geth genesis", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		accounts, err := writeState(storage, root, gethGenesisFormat, &buf)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(len(objs)), accounts)
+
+		var genesis struct {
+			Alloc map[string]map[string]interface{} `json:"alloc"`
+		}
+
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &genesis))
+		require.Len(t, genesis.Alloc, len(objs))
+
+		// the empty balance is written, since geth requires it
+		empty := genesis.Alloc[objs[0].Address.String()]
+		assert.Equal(t, map[string]interface{}{"balance": "0x0"}, empty)
+
+		contract := genesis.Alloc[objs[1].Address.String()]
+		assert.Equal(t, "0x1", contract["balance"])
+		assert.Equal(t, "0x1", contract["nonce"])
+		assert.Equal(t, "0x6000", contract["code"])
+		assert.Equal(t, map[string]interface{}{
+			slot.String(): types.BytesToHash(big.NewInt(10).Bytes()).String(),
+		}, contract["storage"])
+	})
+
+	t.Run("edge", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		accounts, err := writeState(storage, root, edgeFormat, &buf)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(len(objs)), accounts)
+
+		var dump itrie.StateDump
+
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &dump))
+		assert.Equal(t, root, dump.Root)
+		require.Len(t, dump.Accounts, len(objs))
+
+		for _, obj := range objs {
+			account, ok := dump.Accounts[obj.Address]
+			require.True(t, ok)
+			assert.Equal(t, obj.Nonce, account.Nonce)
+			assert.Zero(t, obj.Balance.Cmp(account.Balance))
+		}
+
+		assert.Equal(t, &chain.GenesisAccount{
+			Balance: big.NewInt(1),
+			Nonce:   1,
+			Code:    code,
+			Storage: map[types.Hash]types.Hash{slot: types.BytesToHash(big.NewInt(10).Bytes())},
+		}, dump.Accounts[objs[1].Address])
+	})
+}
+
+func TestDumpParams_ValidateFlags(t *testing.T) {
+	t.Parallel()
+
+	p := &dumpParams{format: "csv", output: defaultOutput}
+	require.ErrorContains(t, p.validateFlags(), "unknown format")
+
+	p.format = gethGenesisFormat
+	require.NoError(t, p.validateFlags())
+}
@@ -0,0 +1,32 @@
+package dump
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type DumpResult struct {
+	Block    uint64 `json:"block"`
+	Root     string `json:"root"`
+	Format   string `json:"format"`
+	Accounts uint64 `json:"accounts"`
+	Output   string `json:"output"`
+}
+
+func (r *DumpResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[STATE DUMP]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Block|%d", r.Block),
+		fmt.Sprintf("State Root|%s", r.Root),
+		fmt.Sprintf("Format|%s", r.Format),
+		fmt.Sprintf("Accounts|%d", r.Accounts),
+		fmt.Sprintf("Output|%s", r.Output),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -8,22 +8,93 @@ import (
 )
 
 type StatusResult struct {
-	ChainID            int64  `json:"chain_id"`
-	CurrentBlockNumber int64  `json:"current_block_number"`
-	CurrentBlockHash   string `json:"current_block_hash"`
-	LibP2PAddress      string `json:"libp2p_address"`
+	ChainID              int64            `json:"chain_id"`
+	CurrentBlockNumber   int64            `json:"current_block_number"`
+	CurrentBlockHash     string           `json:"current_block_hash"`
+	FinalizedBlockNumber int64            `json:"finalized_block_number"`
+	FinalizedBlockHash   string           `json:"finalized_block_hash"`
+	LibP2PAddress        string           `json:"libp2p_address"`
+	PeerCount            int64            `json:"peer_count"`
+	Sync                 *SyncStatus      `json:"sync"`
+	TxPool               *TxPoolStatus    `json:"txpool"`
+	Tracker              *TrackerStatus   `json:"tracker,omitempty"`
+	Validator            *ValidatorStatus `json:"validator,omitempty"`
+}
+
+// SyncStatus is the progress of the sync with the peers
+type SyncStatus struct {
+	Syncing       bool   `json:"syncing"`
+	StartingBlock uint64 `json:"starting_block"`
+	CurrentBlock  uint64 `json:"current_block"`
+	HighestBlock  uint64 `json:"highest_block"`
+}
+
+// TxPoolStatus is the number of the transactions and of the occupied slots of the tx pool
+type TxPoolStatus struct {
+	Pending   uint64 `json:"pending"`
+	Enqueued  uint64 `json:"enqueued"`
+	UsedSlots uint64 `json:"used_slots"`
+	MaxSlots  uint64 `json:"max_slots"`
+}
+
+// TrackerStatus is the progress of the event tracker of the root chain
+type TrackerStatus struct {
+	Head   uint64 `json:"head"`
+	Synced uint64 `json:"synced"`
+	Lag    uint64 `json:"lag"`
+}
+
+// ValidatorStatus is the validator status of the node
+type ValidatorStatus struct {
+	Address string `json:"address"`
+	Active  bool   `json:"active"`
 }
 
 func (r *StatusResult) GetOutput() string {
 	var buffer bytes.Buffer
 
 	buffer.WriteString("\n[CLIENT STATUS]\n")
-	buffer.WriteString(helper.FormatKV([]string{
+
+	outputs := []string{
 		fmt.Sprintf("Network (Chain ID)|%d", r.ChainID),
 		fmt.Sprintf("Current Block Number (base 10)|%d", r.CurrentBlockNumber),
 		fmt.Sprintf("Current Block Hash|%s", r.CurrentBlockHash),
+		fmt.Sprintf("Finalized Block Number (base 10)|%d", r.FinalizedBlockNumber),
 		fmt.Sprintf("Libp2p Address|%s", r.LibP2PAddress),
-	}))
+		fmt.Sprintf("Peers|%d", r.PeerCount),
+	}
+
+	if r.Sync != nil {
+		outputs = append(outputs,
+			fmt.Sprintf("Syncing|%t", r.Sync.Syncing),
+			fmt.Sprintf("Sync Progress|%d / %d", r.Sync.CurrentBlock, r.Sync.HighestBlock),
+		)
+	}
+
+	if r.TxPool != nil {
+		outputs = append(outputs,
+			fmt.Sprintf("TxPool Pending|%d", r.TxPool.Pending),
+			fmt.Sprintf("TxPool Enqueued|%d", r.TxPool.Enqueued),
+			fmt.Sprintf("TxPool Slots|%d / %d", r.TxPool.UsedSlots, r.TxPool.MaxSlots),
+		)
+	}
+
+	if r.Tracker != nil {
+		outputs = append(outputs,
+			fmt.Sprintf("Tracker Synced Block|%d", r.Tracker.Synced),
+			fmt.Sprintf("Tracker Root Chain Head|%d", r.Tracker.Head),
+			fmt.Sprintf("Tracker Lag (blocks)|%d", r.Tracker.Lag),
+		)
+	}
+
+	if r.Validator != nil {
+		outputs = append(outputs,
+			fmt.Sprintf("Validator Address|%s", r.Validator.Address),
+			fmt.Sprintf("Active Validator|%t", r.Validator.Active),
+		)
+	}
+
+	buffer.WriteString(helper.FormatKV(outputs))
 
 	return buffer.String()
 }
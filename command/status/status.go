@@ -13,13 +13,15 @@ import (
 
 func GetCommand() *cobra.Command {
 	statusCmd := &cobra.Command{
-		Use:   "status",
-		Short: "Returns the status of the Polygon Edge client",
-		Args:  cobra.NoArgs,
-		Run:   runCommand,
+		Use: "status",
+		Short: "Returns the status of the Polygon Edge client: the head, the sync progress, the peers, " +
+			"the tx pool depth, the event tracker lag and the validator status (use --json for the machine-readable output)",
+		Args: cobra.NoArgs,
+		Run:  runCommand,
 	}
 
 	helper.RegisterGRPCAddressFlag(statusCmd)
+	helper.RegisterGRPCTLSFlags(statusCmd)
 
 	return statusCmd
 }
@@ -35,12 +37,58 @@ func runCommand(cmd *cobra.Command, _ []string) {
 		return
 	}
 
-	outputter.SetCommandResult(&StatusResult{
-		ChainID:            statusResponse.Network,
-		CurrentBlockNumber: statusResponse.Current.Number,
-		CurrentBlockHash:   statusResponse.Current.Hash,
-		LibP2PAddress:      statusResponse.P2PAddr,
-	})
+	outputter.SetCommandResult(newStatusResult(statusResponse))
+}
+
+func newStatusResult(status *proto.ServerStatus) *StatusResult {
+	result := &StatusResult{
+		ChainID:            status.Network,
+		CurrentBlockNumber: status.Current.Number,
+		CurrentBlockHash:   status.Current.Hash,
+		LibP2PAddress:      status.P2PAddr,
+		PeerCount:          status.Peers,
+	}
+
+	// the nodes of the older versions don't report the fields below
+	if status.Finalized != nil {
+		result.FinalizedBlockNumber = status.Finalized.Number
+		result.FinalizedBlockHash = status.Finalized.Hash
+	}
+
+	if status.Sync != nil {
+		result.Sync = &SyncStatus{
+			Syncing:       status.Sync.Syncing,
+			StartingBlock: status.Sync.StartingBlock,
+			CurrentBlock:  status.Sync.CurrentBlock,
+			HighestBlock:  status.Sync.HighestBlock,
+		}
+	}
+
+	if status.TxPool != nil {
+		result.TxPool = &TxPoolStatus{
+			Pending:   status.TxPool.Pending,
+			Enqueued:  status.TxPool.Enqueued,
+			UsedSlots: status.TxPool.UsedSlots,
+			MaxSlots:  status.TxPool.MaxSlots,
+		}
+	}
+
+	if status.Tracker != nil {
+		result.Tracker = &TrackerStatus{
+			Head:   status.Tracker.Head,
+			Synced: status.Tracker.Synced,
+			Lag:    status.Tracker.Lag,
+		}
+	}
+
+	if status.Validator != nil {
+		result.Validator = &ValidatorStatus{
+			Address: status.Validator.Address,
+			Active:  status.Validator.Active,
+		}
+	}
+
+	return result
 }
 
 func getSystemStatus(grpcAddress string) (*proto.ServerStatus, error) {
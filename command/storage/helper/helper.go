@@ -0,0 +1,93 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/freezer"
+	"github.com/0xPolygon/polygon-edge/helper/kvdb"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+)
+
+var (
+	ErrNoChain = errors.New("no blockchain database found in the data directory")
+)
+
+// ChainStorage is the blockchain storage of the stopped node, along with its freezer if any
+type ChainStorage struct {
+	storage.Storage
+
+	// Backend is the backend of the key-value database, which holds the state database as well
+	Backend kvdb.Backend
+
+	db       kvdb.Database
+	ancients *freezer.Freezer
+}
+
+// OpenChainStorage opens the blockchain database in the data directory, and the freezer
+// in the given directory (<data-dir>/ancient by default) if it exists, since it holds the frozen blocks
+func OpenChainStorage(dataDir, freezerDir string) (*ChainStorage, error) {
+	path := filepath.Join(dataDir, "blockchain")
+
+	backend, ok := kvdb.Detect(path)
+	if !ok {
+		return nil, ErrNoChain
+	}
+
+	db, err := kvdb.Open(backend, path, kvdb.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("can't open the blockchain db: %w", err)
+	}
+
+	s := &ChainStorage{
+		Storage: storage.NewDatabaseStorage(hclog.NewNullLogger(), db),
+		Backend: backend,
+		db:      db,
+	}
+
+	if freezerDir == "" {
+		freezerDir = filepath.Join(dataDir, "ancient")
+	}
+
+	if _, err := os.Stat(freezerDir); err != nil {
+		return s, nil
+	}
+
+	if s.ancients, err = freezer.Open(freezerDir, storage.AncientTables); err != nil {
+		_ = db.Close()
+
+		return nil, fmt.Errorf("can't open the freezer: %w", err)
+	}
+
+	s.Storage = storage.NewDatabaseStorageWithAncients(hclog.NewNullLogger(), db, s.ancients)
+
+	return s, nil
+}
+
+// Close closes the blockchain database and the freezer
+func (s *ChainStorage) Close() error {
+	if s.ancients != nil {
+		if err := s.ancients.Close(); err != nil {
+			_ = s.db.Close()
+
+			return err
+		}
+	}
+
+	return s.db.Close()
+}
+
+// OpenStateStorage opens the state database in the data directory, created by the given backend
+func OpenStateStorage(dataDir string, backend kvdb.Backend) (itrie.Storage, error) {
+	db, err := kvdb.Open(backend, filepath.Join(dataDir, "trie"), kvdb.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("can't open the state db: %w", err)
+	}
+
+	return itrie.NewDatabaseStorage(db), nil
+}
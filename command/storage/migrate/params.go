@@ -0,0 +1,161 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/helper/kvdb"
+)
+
+const (
+	dataDirFlag = "data-dir"
+	fromFlag    = "from"
+	toFlag      = "to"
+
+	// batchSize is the number of the key-value pairs written in a single batch
+	batchSize = 10000
+)
+
+var (
+	params = &migrateParams{}
+)
+
+var (
+	errSameBackend  = errors.New("the databases are already stored by the backend")
+	errNoDatabases  = errors.New("no databases found in the data directory")
+	errBackupExists = errors.New("backup of the database already exists")
+)
+
+// databaseDirs are the directories of the databases within the data directory
+var databaseDirs = []string{"blockchain", "trie"}
+
+type migrateParams struct {
+	dataDir string
+	from    string
+	to      string
+
+	databases []MigratedDatabase
+}
+
+func (p *migrateParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+		toFlag,
+	}
+}
+
+// migrate copies each database into the new one created by the target backend,
+// and then swaps the databases, keeping the original one as the backup
+func (p *migrateParams) migrate() error {
+	from, err := kvdb.ParseBackend(p.from)
+	if err != nil {
+		return err
+	}
+
+	to, err := kvdb.ParseBackend(p.to)
+	if err != nil {
+		return err
+	}
+
+	if from == to {
+		return fmt.Errorf("%w: %s", errSameBackend, to)
+	}
+
+	var paths []string
+
+	for _, dir := range databaseDirs {
+		path := filepath.Join(p.dataDir, dir)
+
+		backend, ok := kvdb.Detect(path)
+		if !ok {
+			continue
+		}
+
+		if backend != from {
+			return fmt.Errorf("%s holds a %s database, not a %s one", path, backend, from)
+		}
+
+		if _, err := os.Stat(backupPath(path, from)); err == nil {
+			return fmt.Errorf("%w: %s", errBackupExists, backupPath(path, from))
+		}
+
+		paths = append(paths, path)
+	}
+
+	if len(paths) == 0 {
+		return errNoDatabases
+	}
+
+	for _, path := range paths {
+		keys, err := migrateDatabase(path, from, to)
+		if err != nil {
+			return fmt.Errorf("failed to migrate %s: %w", path, err)
+		}
+
+		p.databases = append(p.databases, MigratedDatabase{
+			Path:   path,
+			Backup: backupPath(path, from),
+			Keys:   keys,
+		})
+	}
+
+	return nil
+}
+
+// migrateDatabase copies the database at the path into the new database of the target backend,
+// which replaces it once all pairs are copied
+func migrateDatabase(path string, from, to kvdb.Backend) (uint64, error) {
+	// the leftover of the interrupted migration is dropped, the source database is left intact by it
+	tmpPath := path + ".migrating"
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return 0, err
+	}
+
+	src, err := kvdb.Open(from, path, kvdb.Options{})
+	if err != nil {
+		return 0, err
+	}
+
+	defer src.Close()
+
+	dst, err := kvdb.Open(to, tmpPath, kvdb.Options{})
+	if err != nil {
+		return 0, err
+	}
+
+	keys, err := kvdb.Copy(src, dst, batchSize, nil)
+	if err != nil {
+		_ = dst.Close()
+
+		return 0, err
+	}
+
+	if err := dst.Close(); err != nil {
+		return 0, err
+	}
+
+	if err := src.Close(); err != nil {
+		return 0, err
+	}
+
+	if err := os.Rename(path, backupPath(path, from)); err != nil {
+		return 0, err
+	}
+
+	return keys, os.Rename(tmpPath, path)
+}
+
+func backupPath(path string, backend kvdb.Backend) string {
+	return fmt.Sprintf("%s.%s.bak", path, backend)
+}
+
+func (p *migrateParams) getResult() command.CommandResult {
+	return &MigrateResult{
+		From:      p.from,
+		To:        p.to,
+		Databases: p.databases,
+	}
+}
@@ -0,0 +1,38 @@
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type MigrateResult struct {
+	From      string             `json:"from"`
+	To        string             `json:"to"`
+	Databases []MigratedDatabase `json:"databases"`
+}
+
+type MigratedDatabase struct {
+	Path   string `json:"path"`
+	Backup string `json:"backup"`
+	Keys   uint64 `json:"keys"`
+}
+
+func (r *MigrateResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[STORAGE MIGRATE]\n")
+	buffer.WriteString(fmt.Sprintf("Migrated the databases from %s to %s successfully:\n", r.From, r.To))
+
+	for _, db := range r.Databases {
+		buffer.WriteString(helper.FormatKV([]string{
+			fmt.Sprintf("Database|%s", db.Path),
+			fmt.Sprintf("Backup|%s", db.Backup),
+			fmt.Sprintf("Keys|%d", db.Keys),
+		}))
+		buffer.WriteString("\n")
+	}
+
+	return buffer.String()
+}
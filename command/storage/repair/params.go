@@ -0,0 +1,112 @@
+package repair
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/command"
+	storageHelper "github.com/0xPolygon/polygon-edge/command/storage/helper"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	dataDirFlag    = "data-dir"
+	freezerDirFlag = "freezer-dir"
+	depthFlag      = "depth"
+	checkStateFlag = "check-state"
+	dryRunFlag     = "dry-run"
+)
+
+var (
+	params = &repairParams{}
+)
+
+type repairParams struct {
+	dataDir    string
+	freezerDir string
+	depth      uint64
+	checkState bool
+	dryRun     bool
+
+	report     *blockchain.IntegrityReport
+	rolledBack bool
+	head       uint64
+	removed    uint64
+}
+
+func (p *repairParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+	}
+}
+
+// repair checks the integrity of the blockchain database and rolls the chain back
+// to the last consistent block, unless it is a dry run
+func (p *repairParams) repair() error {
+	db, err := storageHelper.OpenChainStorage(p.dataDir, p.freezerDir)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	var hasState func(types.Hash) bool
+
+	if p.checkState {
+		stateStorage, err := storageHelper.OpenStateStorage(p.dataDir, db.Backend)
+		if err != nil {
+			return err
+		}
+
+		defer stateStorage.Close()
+
+		hasState = func(root types.Hash) bool {
+			if root == types.EmptyRootHash {
+				return true
+			}
+
+			_, ok, err := stateStorage.Get(root.Bytes())
+
+			return err == nil && ok
+		}
+	}
+
+	p.report, err = blockchain.CheckIntegrity(db, p.depth, hasState)
+	if err != nil {
+		return err
+	}
+
+	if p.report == nil {
+		return storageHelper.ErrNoChain
+	}
+
+	last, ok := p.report.LastConsistent()
+	if !ok {
+		return blockchain.ErrGenesisInconsistent
+	}
+
+	p.head = last
+
+	if p.report.Consistent() || p.dryRun {
+		return nil
+	}
+
+	if p.removed, err = blockchain.Rollback(db, last); err != nil {
+		return fmt.Errorf("failed to roll back to block %d: %w", last, err)
+	}
+
+	p.rolledBack = true
+
+	return nil
+}
+
+func (p *repairParams) getResult() command.CommandResult {
+	return &RepairResult{
+		Head:            p.report.Head,
+		From:            p.report.From,
+		Inconsistencies: p.report.Inconsistencies,
+		LastConsistent:  p.head,
+		RolledBack:      p.rolledBack,
+		Removed:         p.removed,
+	}
+}
@@ -0,0 +1,72 @@
+package repair
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	repairCmd := &cobra.Command{
+		Use: "repair",
+		Short: "Verifies the headers, the bodies, the receipts and the canonical hashes of the blockchain database, " +
+			"and rolls the chain back to the last consistent block. The node must be stopped while the database is repaired",
+		Run: runCommand,
+	}
+
+	setFlags(repairCmd)
+	helper.SetRequiredFlags(repairCmd, params.getRequiredFlags())
+
+	return repairCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.freezerDir,
+		freezerDirFlag,
+		"",
+		"the directory of the freezer (default <data-dir>/ancient)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.depth,
+		depthFlag,
+		0,
+		"the number of the most recent blocks which are verified, a value of zero verifies all blocks",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.checkState,
+		checkStateFlag,
+		false,
+		"require the state of the head to be stored, rolling the chain back to the highest block whose state is stored",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.dryRun,
+		dryRunFlag,
+		false,
+		"only report the inconsistent blocks, without rolling the chain back",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.repair(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,48 @@
+package repair
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type RepairResult struct {
+	Head            uint64                     `json:"head"`
+	From            uint64                     `json:"from"`
+	Inconsistencies []blockchain.Inconsistency `json:"inconsistencies"`
+	LastConsistent  uint64                     `json:"last_consistent"`
+	RolledBack      bool                       `json:"rolled_back"`
+	Removed         uint64                     `json:"removed"`
+}
+
+func (r *RepairResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[STORAGE REPAIR]\n")
+
+	switch {
+	case len(r.Inconsistencies) == 0:
+		buffer.WriteString("No inconsistent blocks found:\n")
+	case r.RolledBack:
+		buffer.WriteString("Rolled the chain back to the last consistent block successfully:\n")
+	default:
+		buffer.WriteString("Found the inconsistent blocks, the chain is not rolled back:\n")
+	}
+
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Head|%d", r.Head),
+		fmt.Sprintf("Checked From|%d", r.From),
+		fmt.Sprintf("Inconsistent Blocks|%d", len(r.Inconsistencies)),
+		fmt.Sprintf("Last Consistent Block|%d", r.LastConsistent),
+		fmt.Sprintf("Removed Blocks|%d", r.Removed),
+	}))
+	buffer.WriteString("\n")
+
+	for _, inconsistency := range r.Inconsistencies {
+		buffer.WriteString(fmt.Sprintf("Block %d: %s\n", inconsistency.Number, inconsistency.Reason))
+	}
+
+	return buffer.String()
+}
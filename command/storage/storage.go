@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command/storage/migrate"
+	"github.com/0xPolygon/polygon-edge/command/storage/repair"
+	"github.com/0xPolygon/polygon-edge/command/storage/verifystate"
+)
+
+func GetCommand() *cobra.Command {
+	storageCmd := &cobra.Command{
+		Use:   "storage",
+		Short: "Top level command for maintaining the storage of a stopped node. Only accepts subcommands.",
+	}
+
+	registerSubcommands(storageCmd)
+
+	return storageCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// storage migrate
+		migrate.GetCommand(),
+		// storage repair
+		repair.GetCommand(),
+		// storage verify-state
+		verifystate.GetCommand(),
+	)
+}
@@ -0,0 +1,141 @@
+package verifystate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	storageHelper "github.com/0xPolygon/polygon-edge/command/storage/helper"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	dataDirFlag    = "data-dir"
+	freezerDirFlag = "freezer-dir"
+	blockFlag      = "block"
+	healFromFlag   = "heal-from"
+
+	// fetchTimeout is the timeout of fetching a batch of the trie nodes from the healthy node
+	fetchTimeout = time.Minute
+
+	// maxFetchSize is the max size of a batch of the trie nodes, which may include the contract codes
+	maxFetchSize = 64 * 1024 * 1024
+)
+
+var (
+	params = &verifyStateParams{}
+)
+
+var (
+	errHeadNotFound = errors.New("can't read the head of the chain")
+)
+
+type verifyStateParams struct {
+	dataDir    string
+	freezerDir string
+	block      uint64
+	latest     bool
+	healFrom   string
+
+	root         types.Hash
+	verification *itrie.StateVerification
+}
+
+func (p *verifyStateParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+	}
+}
+
+// verifyState walks the state trie of the block verifying the stored nodes,
+// and heals the missing and the corrupt ones from the healthy node if its address is set
+func (p *verifyStateParams) verifyState() error {
+	db, err := storageHelper.OpenChainStorage(p.dataDir, p.freezerDir)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	if p.latest {
+		if p.block, err = headNumber(db); err != nil {
+			return err
+		}
+	}
+
+	hash, ok := db.ReadCanonicalHash(p.block)
+	if !ok {
+		return fmt.Errorf("block %d not found", p.block)
+	}
+
+	header, err := db.ReadHeader(hash)
+	if err != nil {
+		return fmt.Errorf("can't read the header of block %d: %w", p.block, err)
+	}
+
+	p.root = header.StateRoot
+
+	stateStorage, err := storageHelper.OpenStateStorage(p.dataDir, db.Backend)
+	if err != nil {
+		return err
+	}
+
+	defer stateStorage.Close()
+
+	if p.verification, err = itrie.VerifyState(p.root, stateStorage); err != nil {
+		return err
+	}
+
+	if p.healFrom == "" || len(p.verification.Faults) == 0 {
+		return nil
+	}
+
+	client, err := helper.GetSystemClientConnection(p.healFrom)
+	if err != nil {
+		return err
+	}
+
+	return p.verification.Heal(func(hashes []types.Hash) ([][]byte, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+		defer cancel()
+
+		req := &proto.TrieNodesRequest{Hashes: make([][]byte, len(hashes))}
+		for i, hash := range hashes {
+			req.Hashes[i] = hash.Bytes()
+		}
+
+		resp, err := client.GetTrieNodes(ctx, req, grpc.MaxCallRecvMsgSize(maxFetchSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch trie nodes from %s: %w", p.healFrom, err)
+		}
+
+		return resp.Data, nil
+	})
+}
+
+func headNumber(db *storageHelper.ChainStorage) (uint64, error) {
+	head, ok := db.ReadHeadNumber()
+	if !ok {
+		return 0, errHeadNotFound
+	}
+
+	return head, nil
+}
+
+func (p *verifyStateParams) getResult() command.CommandResult {
+	return &VerifyStateResult{
+		Block:  p.block,
+		Root:   p.root.String(),
+		Nodes:  p.verification.Nodes,
+		Codes:  p.verification.Codes,
+		Healed: p.verification.Healed,
+		Faults: p.verification.Faults,
+	}
+}
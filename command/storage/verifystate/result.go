@@ -0,0 +1,56 @@
+package verifystate
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+)
+
+type VerifyStateResult struct {
+	Block  uint64            `json:"block"`
+	Root   string            `json:"root"`
+	Nodes  uint64            `json:"nodes"`
+	Codes  uint64            `json:"codes"`
+	Healed uint64            `json:"healed"`
+	Faults []itrie.TrieFault `json:"faults"`
+}
+
+func (r *VerifyStateResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[VERIFY STATE]\n")
+
+	if len(r.Faults) == 0 {
+		buffer.WriteString("The state is complete:\n")
+	} else {
+		buffer.WriteString("Found the missing or corrupt trie nodes, the subtries below them are not verified:\n")
+	}
+
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Block|%d", r.Block),
+		fmt.Sprintf("State Root|%s", r.Root),
+		fmt.Sprintf("Verified Nodes|%d", r.Nodes),
+		fmt.Sprintf("Verified Codes|%d", r.Codes),
+		fmt.Sprintf("Healed|%d", r.Healed),
+		fmt.Sprintf("Faults|%d", len(r.Faults)),
+	}))
+	buffer.WriteString("\n")
+
+	for _, fault := range r.Faults {
+		kind, problem := "Node", "missing"
+
+		if fault.Code {
+			kind = "Code"
+		}
+
+		if fault.Corrupt {
+			problem = "corrupt"
+		}
+
+		buffer.WriteString(fmt.Sprintf("%s %s: %s\n", kind, fault.Hash, problem))
+	}
+
+	return buffer.String()
+}
@@ -0,0 +1,71 @@
+package verifystate
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	verifyStateCmd := &cobra.Command{
+		Use: "verify-state",
+		Short: "Walks the state trie of the block, verifying every stored trie node and contract code against its hash, " +
+			"and optionally heals the missing and the corrupt ones from a healthy node. " +
+			"The node must be stopped while the state is verified",
+		PreRun: runPreRun,
+		Run:    runCommand,
+	}
+
+	setFlags(verifyStateCmd)
+	helper.SetRequiredFlags(verifyStateCmd, params.getRequiredFlags())
+
+	return verifyStateCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.freezerDir,
+		freezerDirFlag,
+		"",
+		"the directory of the freezer (default <data-dir>/ancient)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.block,
+		blockFlag,
+		0,
+		"the number of the block whose state is verified (default the head of the chain)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.healFrom,
+		healFromFlag,
+		"",
+		"the gRPC address of the healthy node of the same chain, which serves the missing and the corrupt trie nodes",
+	)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) {
+	params.latest = !cmd.Flags().Changed(blockFlag)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.verifyState(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -7,10 +7,13 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/remotesigner"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/syncer"
 	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
@@ -79,6 +82,7 @@ type Params struct {
 	Network        *network.Server
 	Blockchain     *blockchain.Blockchain
 	Executor       *state.Executor
+	StateStorage   itrie.Storage
 	Grpc           *grpc.Server
 	Logger         hclog.Logger
 	SecretsManager secrets.SecretsManager
@@ -86,6 +90,17 @@ type Params struct {
 
 	NumBlockConfirmations uint64
 	MetricsInterval       time.Duration
+	SyncMode              syncer.SyncMode
+
+	// BlockTrackerPollInterval overrides the poll interval of the block trackers set in the genesis, if not 0
+	BlockTrackerPollInterval time.Duration
+
+	// ProposalDeadline is the percentage of the block time after which the proposer seals the block
+	// with the transactions written so far, the whole block time if 0
+	ProposalDeadline uint64
+
+	// RemoteSigner is the signer service holding the validator keys, the keys of the secrets manager are used if nil
+	RemoteSigner *remotesigner.Config
 }
 
 // Factory is the factory function to create a discovery consensus
@@ -98,4 +113,13 @@ type BridgeDataProvider interface {
 
 	// GetStateSyncProof retrieves the StateSync proof
 	GetStateSyncProof(stateSyncID uint64) (types.Proof, error)
+
+	// GetTokenMappings returns the child chain tokens the rootchain token is mapped to by the child predicates
+	GetTokenMappings(rootToken types.Address) ([]*types.BridgeTokenMapping, error)
+
+	// GetDepositStatus returns the status of the state syncs emitted by the rootchain deposit transaction
+	GetDepositStatus(txHash types.Hash) ([]*types.BridgeTransferStatus, error)
+
+	// GetWithdrawalStatus returns the status of the exit events emitted by the child chain withdrawal transaction
+	GetWithdrawalStatus(txHash types.Hash) ([]*types.BridgeTransferStatus, error)
 }
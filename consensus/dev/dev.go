@@ -2,6 +2,7 @@ package dev
 
 import (
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
@@ -9,6 +10,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/txpool"
+	txpoolProto "github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 )
@@ -25,10 +27,15 @@ type Dev struct {
 	closeCh  chan struct{}
 
 	interval uint64
-	txpool   *txpool.TxPool
+	// instant seals a block as soon as a transaction is promoted, instead of at the interval
+	instant bool
+	txpool  *txpool.TxPool
 
 	blockchain *blockchain.Blockchain
 	executor   *state.Executor
+
+	// proposingPaused stops sealing the blocks
+	proposingPaused atomic.Bool
 }
 
 // Factory implements the base factory method
@@ -56,6 +63,16 @@ func Factory(
 		d.interval = interval
 	}
 
+	rawInstant, ok := params.Config.Config["instant"]
+	if ok {
+		instant, ok := rawInstant.(bool)
+		if !ok {
+			return nil, fmt.Errorf("instant expected bool")
+		}
+
+		d.instant = instant
+	}
+
 	return d, nil
 }
 
@@ -87,7 +104,13 @@ func (d *Dev) nextNotify() chan struct{} {
 }
 
 func (d *Dev) run() {
-	d.logger.Info("consensus started")
+	d.logger.Info("consensus started", "instant", d.instant)
+
+	if d.instant {
+		d.runInstant()
+
+		return
+	}
 
 	for {
 		// wait until there is a new txn
@@ -97,6 +120,10 @@ func (d *Dev) run() {
 			return
 		}
 
+		if d.proposingPaused.Load() {
+			continue
+		}
+
 		// There are new transactions in the pool, try to seal them
 		header := d.blockchain.Header()
 		if err := d.writeNewBlock(header); err != nil {
@@ -105,6 +132,56 @@ func (d *Dev) run() {
 	}
 }
 
+// runInstant seals a block whenever transactions are promoted in the pool,
+// the blocks are sealed until the pool is empty, in case the transactions don't fit the gas limit of one block
+func (d *Dev) runInstant() {
+	promotedCh, unsubscribe, err := d.txpool.TxPoolSubscribe(&txpoolProto.SubscribeRequest{
+		Types: []txpoolProto.EventType{txpoolProto.EventType_PROMOTED},
+	})
+	if err != nil {
+		d.logger.Error("failed to subscribe to the promoted transactions", "err", err)
+
+		return
+	}
+
+	defer unsubscribe()
+
+	for {
+		select {
+		case _, ok := <-promotedCh:
+			if !ok {
+				return
+			}
+		case <-d.closeCh:
+			return
+		}
+
+		for d.txpool.Length() > 0 && !d.proposingPaused.Load() {
+			select {
+			case <-d.closeCh:
+				return
+			default:
+			}
+
+			if err := d.writeNewBlock(d.blockchain.Header()); err != nil {
+				d.logger.Error("failed to mine block", "err", err)
+
+				break
+			}
+		}
+	}
+}
+
+// SetProposingPaused pauses or resumes sealing the blocks
+func (d *Dev) SetProposingPaused(paused bool) {
+	d.proposingPaused.Store(paused)
+}
+
+// ProposingPaused returns whether sealing the blocks is paused
+func (d *Dev) ProposingPaused() bool {
+	return d.proposingPaused.Load()
+}
+
 type transitionInterface interface {
 	Write(txn *types.Transaction) error
 }
@@ -205,16 +282,9 @@ func (d *Dev) writeNewBlock(parent *types.Header) error {
 		return err
 	}
 
-	// Write the block to the blockchain
-	if err := d.blockchain.WriteBlock(block, devConsensus); err != nil {
-		return err
-	}
-
-	// after the block has been written we reset the txpool so that
-	// the old transactions are removed
-	d.txpool.ResetWithHeaders(block.Header)
-
-	return nil
+	// Write the block to the blockchain,
+	// the txpool is reset with it by the chain event
+	return d.blockchain.WriteBlock(block, devConsensus)
 }
 
 // REQUIRED BASE INTERFACE METHODS //
@@ -16,6 +16,12 @@ import (
 )
 
 func (i *backendIBFT) BuildProposal(view *proto.View) []byte {
+	if i.proposingPaused.Load() {
+		i.logger.Info("proposing is paused, skipping the proposal", "height", view.Height, "round", view.Round)
+
+		return nil
+	}
+
 	var (
 		latestHeader      = i.blockchain.Header()
 		latestBlockNumber = latestHeader.Number
@@ -126,10 +132,6 @@ func (i *backendIBFT) InsertProposal(
 
 		return
 	}
-
-	// after the block has been written we reset the txpool so that
-	// the old transactions are removed
-	i.txpool.ResetWithHeaders(newBlock.Header)
 }
 
 func (i *backendIBFT) ID() []byte {
@@ -3,6 +3,7 @@ package ibft
 import (
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
@@ -48,7 +49,6 @@ type txPoolInterface interface {
 	Pop(tx *types.Transaction)
 	Drop(tx *types.Transaction)
 	Demote(tx *types.Transaction)
-	ResetWithHeaders(headers ...*types.Header)
 	SetSealing(bool)
 }
 
@@ -89,6 +89,9 @@ type backendIBFT struct {
 	quorumSizeBlockNum uint64
 	blockTime          time.Duration // Minimum block generation time in seconds
 
+	// proposingPaused skips building the proposals, so the rounds of the node are taken over by the other proposers
+	proposingPaused atomic.Bool
+
 	// Channels
 	closeCh chan struct{} // Channel for closing
 }
@@ -148,7 +151,10 @@ func Factory(params *consensus.Params) (consensus.Consensus, error) {
 			params.Logger,
 			params.Network,
 			params.Blockchain,
+			params.StateStorage,
 			time.Duration(params.BlockTime)*3*time.Second,
+			params.SyncMode,
+			params.TxPool,
 		),
 		secretsManager: params.SecretsManager,
 		Grpc:           params.Grpc,
@@ -216,8 +222,6 @@ func (i *backendIBFT) startSyncing() {
 			i.logger.Error("failed to update sub modules", "height", fullBlock.Block.Number()+1, "err", err)
 		}
 
-		i.txpool.ResetWithHeaders(fullBlock.Block.Header)
-
 		return false
 	}
 
@@ -262,8 +266,8 @@ func (i *backendIBFT) startConsensus() {
 		eventCh := newBlockSub.GetEventCh()
 
 		for {
-			if ev := <-eventCh; ev.Source == "syncer" {
-				if ev.NewChain[0].Number < i.blockchain.Header().Number {
+			if ev := <-eventCh; ev.Source == "syncer" && len(ev.NewChain) > 0 {
+				if ev.Header().Number < i.blockchain.Header().Number {
 					// The blockchain notification system can eventually deliver
 					// stale block notifications. These should be ignored
 					continue
@@ -550,6 +554,16 @@ func (i *backendIBFT) SetHeaderHash() {
 }
 
 // GetBridgeProvider returns an instance of BridgeDataProvider
+// SetProposingPaused pauses or resumes building the proposals, the node keeps validating and voting
+func (i *backendIBFT) SetProposingPaused(paused bool) {
+	i.proposingPaused.Store(paused)
+}
+
+// ProposingPaused returns whether building the proposals is paused
+func (i *backendIBFT) ProposingPaused() bool {
+	return i.proposingPaused.Load()
+}
+
 func (i *backendIBFT) GetBridgeProvider() consensus.BridgeDataProvider {
 	return nil
 }
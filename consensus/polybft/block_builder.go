@@ -8,6 +8,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
 	hcf "github.com/hashicorp/go-hclog"
 )
 
@@ -31,6 +32,10 @@ type BlockBuilderParams struct {
 	// duration for one block
 	BlockTime time.Duration
 
+	// ProposalDeadline is the time after which the block is sealed with the transactions written so far,
+	// the block time if 0
+	ProposalDeadline time.Duration
+
 	// Logger
 	Logger hcf.Logger
 
@@ -107,12 +112,9 @@ func (b *BlockBuilder) Block() *types.Block {
 	return b.block
 }
 
-// Build creates the state and the final block
+// Build creates the state and the final block.
+// The handler is called once the state is committed, so it may read the state root of the header
 func (b *BlockBuilder) Build(handler func(h *types.Header)) (*types.FullBlock, error) {
-	if handler != nil {
-		handler(b.header)
-	}
-
 	_, stateRoot, err := b.state.Commit()
 	if err != nil {
 		return nil, fmt.Errorf("failed to commit the state changes: %w", err)
@@ -120,6 +122,11 @@ func (b *BlockBuilder) Build(handler func(h *types.Header)) (*types.FullBlock, e
 
 	b.header.StateRoot = stateRoot
 	b.header.GasUsed = b.state.TotalGas()
+
+	if handler != nil {
+		handler(b.header)
+	}
+
 	b.header.LogsBloom = types.CreateBloom(b.Receipts())
 
 	// build the block
@@ -155,16 +162,32 @@ func (b *BlockBuilder) WriteTx(tx *types.Transaction) error {
 	return nil
 }
 
-// Fill fills the block with transactions from the txpool
+// Fill fills the block with transactions from the txpool until the proposal deadline
+// and returns once the block time has passed
 func (b *BlockBuilder) Fill() {
+	deadline := b.params.ProposalDeadline
+	if deadline == 0 || deadline > b.params.BlockTime {
+		deadline = b.params.BlockTime
+	}
+
 	blockTimer := time.NewTimer(b.params.BlockTime)
+	deadlineTimer := time.NewTimer(deadline)
+
+	defer deadlineTimer.Stop()
 
 	b.params.TxPool.Prepare()
 write:
 	for {
 		select {
-		case <-blockTimer.C:
-			return
+		case <-deadlineTimer.C:
+			// the pending transactions are left for the next block
+			if deadline < b.params.BlockTime {
+				metrics.IncrCounter([]string{consensusMetricsPrefix, "proposal_deadline_hit"}, 1)
+				b.params.Logger.Debug("Proposal deadline hit, sealing the block with the transactions written so far",
+					"deadline", deadline, "txs", len(b.txns))
+			}
+
+			break write
 		default:
 			tx := b.params.TxPool.Peek()
 
@@ -2,6 +2,7 @@ package polybft
 
 import (
 	"math/big"
+	"sync"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/umbracle/ethgo"
 )
@@ -158,3 +160,61 @@ func TestBlockBuilder_BuildBlockTxOneFailedTxAndOneTakesTooMuchGas(t *testing.T)
 	assert.False(t, fb.Block.Header.LogsBloom.IsLogInBloom(
 		&types.Log{Address: types.StringToAddress("111177779999")}))
 }
+
+func TestBlockBuilder_FillProposalDeadline(t *testing.T) {
+	t.Parallel()
+
+	const (
+		blockTime = 500 * time.Millisecond
+		deadline  = 50 * time.Millisecond
+	)
+
+	// the transaction exceeding the block gas limit is dropped, so the pool never runs out of transactions
+	tx := &types.Transaction{Gas: 100}
+
+	var (
+		lock      sync.Mutex
+		lastPeek  time.Time
+		peekCount int
+	)
+
+	txPool := &txPoolMock{}
+	txPool.On("Prepare").Once()
+	txPool.On("Peek").Return(tx).Run(func(args mock.Arguments) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		lastPeek = time.Now()
+		peekCount++
+
+		time.Sleep(time.Millisecond)
+	})
+	txPool.On("Drop", tx)
+
+	bb := NewBlockBuilder(&BlockBuilderParams{
+		BlockTime:        blockTime,
+		ProposalDeadline: deadline,
+		GasLimit:         10,
+		TxPool:           txPool,
+		Logger:           hclog.NewNullLogger(),
+	})
+
+	start := time.Now()
+
+	bb.Fill()
+
+	elapsed := time.Since(start)
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	// the transactions are executed until the deadline only
+	require.Positive(t, peekCount)
+	require.Less(t, lastPeek.Sub(start), blockTime-deadline)
+	// the pool isn't drained and none of its transactions is written
+	require.Empty(t, bb.txns)
+	txPool.AssertNotCalled(t, "Pop", mock.Anything)
+	// the block time is still waited for
+	require.GreaterOrEqual(t, elapsed, blockTime)
+	txPool.AssertExpectations(t)
+}
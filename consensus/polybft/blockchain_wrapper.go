@@ -35,7 +35,8 @@ type blockchainBackend interface {
 
 	// NewBlockBuilder is a factory method that returns a block builder on top of 'parent'.
 	NewBlockBuilder(parent *types.Header, coinbase types.Address,
-		txPool txPoolInterface, blockTime time.Duration, logger hclog.Logger) (blockBuilder, error)
+		txPool txPoolInterface, blockTime, proposalDeadline time.Duration,
+		logger hclog.Logger) (blockBuilder, error)
 
 	// ProcessBlock builds a final block from given 'block' on top of 'parent'.
 	ProcessBlock(parent *types.Header, block *types.Block) (*types.FullBlock, error)
@@ -66,6 +67,9 @@ type blockchainBackend interface {
 
 	// GetReceiptsByHash retrieves receipts by hash
 	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
+
+	// ReadTxLookup returns the hash of the block the transaction is included in
+	ReadTxLookup(txHash types.Hash) (types.Hash, bool)
 }
 
 var _ blockchainBackend = &blockchainWrapper{}
@@ -159,21 +163,23 @@ func (p *blockchainWrapper) GetHeaderByHash(hash types.Hash) (*types.Header, boo
 // NewBlockBuilder is an implementation of blockchainBackend interface
 func (p *blockchainWrapper) NewBlockBuilder(
 	parent *types.Header, coinbase types.Address,
-	txPool txPoolInterface, blockTime time.Duration, logger hclog.Logger) (blockBuilder, error) {
+	txPool txPoolInterface, blockTime, proposalDeadline time.Duration,
+	logger hclog.Logger) (blockBuilder, error) {
 	gasLimit, err := p.blockchain.CalculateGasLimit(parent.Number + 1)
 	if err != nil {
 		return nil, err
 	}
 
 	return NewBlockBuilder(&BlockBuilderParams{
-		BlockTime: blockTime,
-		Parent:    parent,
-		Coinbase:  coinbase,
-		Executor:  p.executor,
-		GasLimit:  gasLimit,
-		BaseFee:   p.blockchain.CalculateBaseFee(parent),
-		TxPool:    txPool,
-		Logger:    logger,
+		BlockTime:        blockTime,
+		ProposalDeadline: proposalDeadline,
+		Parent:           parent,
+		Coinbase:         coinbase,
+		Executor:         p.executor,
+		GasLimit:         gasLimit,
+		BaseFee:          p.blockchain.CalculateBaseFee(parent),
+		TxPool:           txPool,
+		Logger:           logger,
 	}), nil
 }
 
@@ -198,6 +204,10 @@ func (p *blockchainWrapper) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt
 	return p.blockchain.GetReceiptsByHash(hash)
 }
 
+func (p *blockchainWrapper) ReadTxLookup(txHash types.Hash) (types.Hash, bool) {
+	return p.blockchain.ReadTxLookup(txHash)
+}
+
 var _ contract.Provider = &stateProvider{}
 
 type stateProvider struct {
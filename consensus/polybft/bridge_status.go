@@ -0,0 +1,182 @@
+package polybft
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/txrelayer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/contract"
+)
+
+var (
+	// processedStateSyncsMethod is an ABI method object representation for
+	// processedStateSyncs getter function on StateReceiver contract
+	processedStateSyncsMethod = contractsapi.StateReceiver.Abi.Methods["processedStateSyncs"]
+	// processedExitsMethod is an ABI method object representation for
+	// processedExits getter function on ExitHelper contract
+	processedExitsMethod = contractsapi.ExitHelper.Abi.Methods["processedExits"]
+
+	// errBridgeDisabled is returned for the bridge transfer status if the bridge is not enabled
+	errBridgeDisabled = errors.New("bridge is not enabled")
+	// errNoBridgeEvents is returned if the transaction didn't emit any bridge event
+	errNoBridgeEvents = errors.New("no bridge events emitted by the transaction")
+)
+
+// getDepositStatus returns the status of the state syncs emitted by the rootchain deposit transaction,
+// read from the child chain state, nextCommittedIndex being the id of the first state sync not yet committed
+func getDepositStatus(logs []*ethgo.Log, provider contract.Provider,
+	nextCommittedIndex uint64) ([]*types.BridgeTransferStatus, error) {
+	statuses := []*types.BridgeTransferStatus{}
+
+	for _, log := range logs {
+		var event contractsapi.StateSyncedEvent
+
+		doesMatch, err := event.ParseLog(log)
+		if !doesMatch {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode state sync event: %w", err)
+		}
+
+		status := &types.BridgeTransferStatus{
+			ID:          event.ID.Uint64(),
+			BlockNumber: log.BlockNumber,
+			Status:      types.BridgeTxPending,
+		}
+
+		processed, err := isStateSyncProcessed(provider, event.ID.Uint64())
+		if err != nil {
+			return nil, err
+		}
+
+		if processed {
+			status.Status = types.BridgeTxExecuted
+		} else if status.ID < nextCommittedIndex {
+			status.Status = types.BridgeTxCommitted
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	if len(statuses) == 0 {
+		return nil, errNoBridgeEvents
+	}
+
+	return statuses, nil
+}
+
+// getWithdrawalStatus returns the status of the exit events emitted by the child chain withdrawal transaction,
+// read from the rootchain, blockNumber being the number of the child chain block the transaction is included in
+func getWithdrawalStatus(logs []*types.Log, blockNumber uint64, relayer txrelayer.TxRelayer,
+	exitHelperAddr, checkpointManagerAddr types.Address) ([]*types.BridgeTransferStatus, error) {
+	var events []*contractsapi.L2StateSyncedEvent
+
+	for _, log := range logs {
+		if log.Address != contracts.L2StateSenderContract {
+			continue
+		}
+
+		var event contractsapi.L2StateSyncedEvent
+
+		doesMatch, err := event.ParseLog(convertLog(log))
+		if !doesMatch {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode exit event: %w", err)
+		}
+
+		events = append(events, &event)
+	}
+
+	if len(events) == 0 {
+		return nil, errNoBridgeEvents
+	}
+
+	checkpointBlock, err := getCurrentCheckpointBlock(relayer, checkpointManagerAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*types.BridgeTransferStatus, 0, len(events))
+
+	for _, event := range events {
+		status := &types.BridgeTransferStatus{
+			ID:          event.ID.Uint64(),
+			BlockNumber: blockNumber,
+			Status:      types.BridgeTxPending,
+		}
+
+		exited, err := isExitProcessed(relayer, exitHelperAddr, event.ID.Uint64())
+		if err != nil {
+			return nil, err
+		}
+
+		if exited {
+			status.Status = types.BridgeTxExited
+		} else if blockNumber <= checkpointBlock {
+			status.Status = types.BridgeTxExitReady
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// isStateSyncProcessed checks if the state sync is executed by the StateReceiver contract of the child chain
+func isStateSyncProcessed(provider contract.Provider, stateSyncID uint64) (bool, error) {
+	input, err := processedStateSyncsMethod.Encode([]interface{}{stateSyncID})
+	if err != nil {
+		return false, fmt.Errorf("failed to encode processedStateSyncs function parameters: %w", err)
+	}
+
+	output, err := provider.Call(ethgo.Address(contracts.StateReceiverContract), input, &contract.CallOpts{})
+	if err != nil {
+		return false, fmt.Errorf("failed to invoke processedStateSyncs function on the child chain: %w", err)
+	}
+
+	return decodeBoolOutput(processedStateSyncsMethod.Decode(output))
+}
+
+// isExitProcessed checks if the exit event is processed by the ExitHelper contract of the rootchain
+func isExitProcessed(relayer txrelayer.TxRelayer, exitHelperAddr types.Address, exitID uint64) (bool, error) {
+	input, err := processedExitsMethod.Encode([]interface{}{exitID})
+	if err != nil {
+		return false, fmt.Errorf("failed to encode processedExits function parameters: %w", err)
+	}
+
+	response, err := relayer.Call(ethgo.ZeroAddress, ethgo.Address(exitHelperAddr), input)
+	if err != nil {
+		return false, fmt.Errorf("failed to invoke processedExits function on the rootchain: %w", err)
+	}
+
+	output, err := hex.DecodeHex(response)
+	if err != nil {
+		return false, fmt.Errorf("unable to decode hex response, %w", err)
+	}
+
+	return decodeBoolOutput(processedExitsMethod.Decode(output))
+}
+
+// decodeBoolOutput returns the boolean output of the decoded getter function
+func decodeBoolOutput(result map[string]interface{}, err error) (bool, error) {
+	if err != nil {
+		return false, err
+	}
+
+	value, ok := result["0"].(bool)
+	if !ok {
+		return false, errors.New("failed to decode the boolean output")
+	}
+
+	return value, nil
+}
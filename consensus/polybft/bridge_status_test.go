@@ -0,0 +1,117 @@
+package polybft
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/contract"
+)
+
+// processedStateSyncsProviderMock returns whether the state syncs are processed by the StateReceiver contract
+type processedStateSyncsProviderMock struct {
+	processed map[uint64]bool
+}
+
+func (p *processedStateSyncsProviderMock) Call(_ ethgo.Address, input []byte,
+	_ *contract.CallOpts) ([]byte, error) {
+	args, err := processedStateSyncsMethod.Inputs.Decode(input[4:])
+	if err != nil {
+		return nil, err
+	}
+
+	id := args.(map[string]interface{})["0"].(*big.Int) //nolint:forcetypeassert
+
+	return processedStateSyncsMethod.Outputs.Encode([]interface{}{p.processed[id.Uint64()]})
+}
+
+func (p *processedStateSyncsProviderMock) Txn(ethgo.Address, ethgo.Key, []byte) (contract.Txn, error) {
+	return nil, nil
+}
+
+func TestBridgeStatus_GetDepositStatus(t *testing.T) {
+	t.Parallel()
+
+	var stateSyncEvent contractsapi.StateSyncedEvent
+
+	logs := make([]*ethgo.Log, 0, 3)
+
+	for _, id := range []uint64{1, 2, 3} {
+		log := createTestLogForExitEvent(t, id)
+		log.Topics[0] = types.Hash(stateSyncEvent.Sig())
+
+		ethgoLog := convertLog(log)
+		ethgoLog.BlockNumber = 10
+
+		logs = append(logs, ethgoLog)
+	}
+
+	provider := &processedStateSyncsProviderMock{processed: map[uint64]bool{1: true}}
+
+	statuses, err := getDepositStatus(logs, provider, 3)
+	require.NoError(t, err)
+	require.Equal(t, []*types.BridgeTransferStatus{
+		{ID: 1, BlockNumber: 10, Status: types.BridgeTxExecuted},
+		{ID: 2, BlockNumber: 10, Status: types.BridgeTxCommitted},
+		{ID: 3, BlockNumber: 10, Status: types.BridgeTxPending},
+	}, statuses)
+
+	// the logs of the other events are not the state syncs of the deposit
+	_, err = getDepositStatus([]*ethgo.Log{convertLog(createTestLogForExitEvent(t, 1))}, provider, 3)
+	require.ErrorIs(t, err, errNoBridgeEvents)
+}
+
+func TestBridgeStatus_GetWithdrawalStatus(t *testing.T) {
+	t.Parallel()
+
+	exitHelperAddr := types.StringToAddress("0x10")
+	checkpointManagerAddr := types.StringToAddress("0x20")
+
+	checkpointBlockInput, err := currentCheckpointBlockNumMethod.Encode([]interface{}{})
+	require.NoError(t, err)
+
+	encodeProcessedExit := func(processed bool) string {
+		output, err := processedExitsMethod.Outputs.Encode([]interface{}{processed})
+		require.NoError(t, err)
+
+		return hex.EncodeToHex(output)
+	}
+
+	processedExitInput := func(exitID uint64) []byte {
+		input, err := processedExitsMethod.Encode([]interface{}{exitID})
+		require.NoError(t, err)
+
+		return input
+	}
+
+	relayer := newDummyTxRelayer(t)
+	relayer.On("Call", ethgo.ZeroAddress, ethgo.Address(checkpointManagerAddr), checkpointBlockInput).
+		Return("0x14", error(nil))
+	relayer.On("Call", ethgo.ZeroAddress, ethgo.Address(exitHelperAddr), processedExitInput(1)).
+		Return(encodeProcessedExit(true), error(nil))
+	relayer.On("Call", ethgo.ZeroAddress, ethgo.Address(exitHelperAddr), processedExitInput(2)).
+		Return(encodeProcessedExit(false), error(nil))
+
+	logs := []*types.Log{createTestLogForExitEvent(t, 1), createTestLogForExitEvent(t, 2)}
+
+	statuses, err := getWithdrawalStatus(logs, 20, relayer, exitHelperAddr, checkpointManagerAddr)
+	require.NoError(t, err)
+	require.Equal(t, []*types.BridgeTransferStatus{
+		{ID: 1, BlockNumber: 20, Status: types.BridgeTxExited},
+		{ID: 2, BlockNumber: 20, Status: types.BridgeTxExitReady},
+	}, statuses)
+
+	// the block of the withdrawal is not checkpointed yet
+	statuses, err = getWithdrawalStatus(logs[1:], 21, relayer, exitHelperAddr, checkpointManagerAddr)
+	require.NoError(t, err)
+	require.Equal(t, []*types.BridgeTransferStatus{
+		{ID: 2, BlockNumber: 21, Status: types.BridgeTxPending},
+	}, statuses)
+
+	_, err = getWithdrawalStatus(nil, 20, relayer, exitHelperAddr, checkpointManagerAddr)
+	require.ErrorIs(t, err, errNoBridgeEvents)
+}
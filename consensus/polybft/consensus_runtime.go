@@ -10,12 +10,15 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/consensus"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
 	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/helper/tracing"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful/governance"
 	"github.com/0xPolygon/polygon-edge/txrelayer"
 	"github.com/0xPolygon/polygon-edge/types"
 	bolt "go.etcd.io/bbolt"
@@ -23,6 +26,8 @@ import (
 	"github.com/0xPolygon/go-ibft/messages"
 	"github.com/0xPolygon/go-ibft/messages/proto"
 	hcf "github.com/hashicorp/go-hclog"
+	"github.com/umbracle/ethgo"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const (
@@ -47,7 +52,6 @@ type txPoolInterface interface {
 	Drop(*types.Transaction)
 	Demote(*types.Transaction)
 	SetSealing(bool)
-	ResetWithHeaders(...*types.Header)
 }
 
 // epochMetadata is the static info for epoch currently being processed
@@ -84,8 +88,39 @@ type runtimeConfig struct {
 	bridgeTopic           topic
 	numBlockConfirmations uint64
 	consensusConfig       *consensus.Config
+
+	// blockTrackerPollInterval is the poll interval of the block trackers
+	blockTrackerPollInterval time.Duration
+
+	// proposalDeadline is the percentage of the block time after which the proposer seals the block
+	// with the transactions written so far, the whole block time if 0
+	proposalDeadline uint64
+
+	// governance is the governance contract executing the parameter changes at the end of the epochs,
+	// nil if the chain has none
+	governance *chain.StatefulPrecompileConfig
+	// randomness is the randomness beacon contract the proposers contribute their VRF outputs to,
+	// nil if the chain has none
+	randomness *chain.StatefulPrecompileConfig
+	forks      *chain.Forks
+}
+
+// RoundStatus is the view of the consensus the node is at, and the last step it took in the round
+type RoundStatus struct {
+	Height uint64
+	Round  uint64
+	State  string
 }
 
+const (
+	roundStateStarted     = "started"
+	roundStatePrePrepare  = "preprepare"
+	roundStatePrepare     = "prepare"
+	roundStateCommit      = "commit"
+	roundStateRoundChange = "round-change"
+	roundStateInserted    = "inserted"
+)
+
 // consensusRuntime is a struct that provides consensus runtime features like epoch, state and event management
 type consensusRuntime struct {
 	// config represents wrapper around required parameters which are received from the outside
@@ -109,6 +144,12 @@ type consensusRuntime struct {
 	// activeValidatorFlag indicates whether the given node is amongst currently active validator set
 	activeValidatorFlag atomic.Bool
 
+	// proposingPaused skips building the proposals, so the rounds of the node are taken over by the other proposers
+	proposingPaused atomic.Bool
+
+	// roundStatus is the view of the consensus and the last step the node took in it
+	roundStatus atomic.Pointer[RoundStatus]
+
 	// checkpointManager represents abstraction for checkpoint submission
 	checkpointManager CheckpointManager
 
@@ -126,6 +167,9 @@ type consensusRuntime struct {
 	// stateSyncRelayer is relayer for commitment events
 	stateSyncRelayer StateSyncRelayer
 
+	// performanceTracker keeps the registry of the validators performance
+	performanceTracker *validatorPerformanceTracker
+
 	// logger instance
 	logger hcf.Logger
 }
@@ -151,6 +195,7 @@ func newConsensusRuntime(log hcf.Logger, config *runtimeConfig) (*consensusRunti
 		proposerCalculator: proposerCalculator,
 		logger:             log.Named("consensus_runtime"),
 		eventProvider:      NewEventProvider(config.blockchain),
+		performanceTracker: newValidatorPerformanceTracker(config.State.PerformanceStore),
 	}
 
 	if err := runtime.initStateSyncManager(log); err != nil {
@@ -193,6 +238,8 @@ func (c *consensusRuntime) close() {
 func (c *consensusRuntime) initStateSyncManager(logger hcf.Logger) error {
 	if c.IsBridgeEnabled() {
 		stateSenderAddr := c.config.PolyBFTConfig.Bridge.StateSenderAddr
+		numBlockConfirmations := c.config.PolyBFTConfig.Bridge.GetNumBlockConfirmations(
+			stateSenderAddr, c.config.numBlockConfirmations)
 		stateSyncManager := newStateSyncManager(
 			logger.Named("state-sync-manager"),
 			c.config.State,
@@ -204,8 +251,8 @@ func (c *consensusRuntime) initStateSyncManager(logger hcf.Logger) error {
 				dataDir:                  c.config.DataDir,
 				topic:                    c.config.bridgeTopic,
 				maxCommitmentSize:        maxCommitmentSize,
-				numBlockConfirmations:    c.config.numBlockConfirmations,
-				blockTrackerPollInterval: c.config.PolyBFTConfig.BlockTrackerPollInterval.Duration,
+				numBlockConfirmations:    numBlockConfirmations,
+				blockTrackerPollInterval: c.config.blockTrackerPollInterval,
 			},
 			c,
 		)
@@ -291,7 +338,6 @@ func (c *consensusRuntime) initStakeManager(logger hcf.Logger, dbTx *bolt.Tx) er
 		c.config.PolyBFTConfig.Bridge.CustomSupernetManagerAddr,
 		c.config.blockchain,
 		c.config.polybftBackend,
-		int(c.config.PolyBFTConfig.MaxValidatorSetSize),
 		dbTx,
 	)
 
@@ -343,9 +389,6 @@ func (c *consensusRuntime) OnBlockInserted(fullBlock *types.FullBlock) {
 		c.logger.Error("failed to update block metrics", "error", err)
 	}
 
-	// after the block has been written we reset the txpool so that the old transactions are removed
-	c.config.txPool.ResetWithHeaders(fullBlock.Block.Header)
-
 	var (
 		epoch = c.epoch
 		err   error
@@ -411,6 +454,11 @@ func (c *consensusRuntime) OnBlockInserted(fullBlock *types.FullBlock) {
 		c.logger.Error("post block callback failed in state sync relayer", "err", err)
 	}
 
+	// the performance is only reported, so the failure to record it doesn't stop the block finalization
+	if err := c.updateValidatorPerformance(postBlock, epoch); err != nil {
+		c.logger.Error("failed to update validator performance", "block", fullBlock.Block.Number(), "err", err)
+	}
+
 	if isEndOfEpoch {
 		if epoch, err = c.restartEpoch(fullBlock.Block.Header, dbTx); err != nil {
 			c.logger.Error("failed to restart epoch after block inserted", "error", err)
@@ -449,6 +497,29 @@ func (c *consensusRuntime) OnBlockInserted(fullBlock *types.FullBlock) {
 		"epoch", epoch.Number, "block", fullBlock.Block.Number())
 }
 
+// updateValidatorPerformance records the participation of the validators in the finalized block
+func (c *consensusRuntime) updateValidatorPerformance(postBlock *PostBlockRequest, epoch *epochMetadata) error {
+	blockNumber := postBlock.FullBlock.Block.Number()
+
+	var (
+		parentValidators validator.AccountSet
+		err              error
+	)
+
+	switch {
+	case blockNumber > epoch.FirstBlockInEpoch:
+		parentValidators = epoch.Validators
+	case blockNumber > 1:
+		// the parent block is the last block of the previous epoch
+		parentValidators, err = c.config.polybftBackend.GetValidators(blockNumber-2, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.performanceTracker.PostBlock(postBlock, parentValidators)
+}
+
 // FSM creates a new instance of fsm
 func (c *consensusRuntime) FSM() error {
 	sharedData, err := c.getGuardedData()
@@ -462,11 +533,14 @@ func (c *consensusRuntime) FSM() error {
 		return errNotAValidator
 	}
 
+	blockTime := c.config.PolyBFTConfig.BlockTime.Duration
+
 	blockBuilder, err := c.config.blockchain.NewBlockBuilder(
 		parent,
 		types.Address(c.config.Key.Address()),
 		c.config.txPool,
-		c.config.PolyBFTConfig.BlockTime.Duration,
+		blockTime,
+		blockTime*time.Duration(c.config.proposalDeadline)/100,
 		c.logger,
 	)
 
@@ -501,6 +575,20 @@ func (c *consensusRuntime) FSM() error {
 		logger:            c.logger.Named("fsm"),
 	}
 
+	if c.isRandomnessActive(pendingBlockNumber) {
+		output, err := c.config.Key.SignWithDomain(vrfMessage(parent.Hash, pendingBlockNumber), signer.DomainRandomness)
+		if err != nil {
+			return fmt.Errorf("cannot compute VRF output: %w", err)
+		}
+
+		ff.randomnessAddress = c.config.randomness.Address
+		ff.randomnessContribution = &randomnessContributeFn{
+			Sprint:    randomnessSprint(pendingBlockNumber, c.config.PolyBFTConfig.SprintSize),
+			Validator: types.Address(c.config.Key.Address()),
+			Output:    output,
+		}
+	}
+
 	if isEndOfSprint {
 		commitment, err := c.stateSyncManager.Commitment(pendingBlockNumber)
 		if err != nil {
@@ -516,7 +604,33 @@ func (c *consensusRuntime) FSM() error {
 			return fmt.Errorf("cannot calculate commit epoch info: %w", err)
 		}
 
-		ff.newValidatorsDelta, err = c.stakeManager.UpdateValidatorSet(epoch.Number, epoch.Validators.Copy())
+		maxValidatorSetSize := c.config.PolyBFTConfig.MaxValidatorSetSize
+
+		govState, err := c.getGovernanceState(parent)
+		if err != nil {
+			return fmt.Errorf("cannot get governance state: %w", err)
+		}
+
+		if govState != nil {
+			ff.governanceAddress = c.config.governance.Address
+
+			ff.governanceProposals, err = govState.passedProposals(pendingBlockNumber)
+			if err != nil {
+				return err
+			}
+
+			size, err := govState.parameter(governance.ParamMaxValidatorSetSize)
+			if err != nil {
+				return err
+			}
+
+			if size != 0 {
+				maxValidatorSetSize = size
+			}
+		}
+
+		ff.newValidatorsDelta, err = c.stakeManager.UpdateValidatorSet(
+			epoch.Number, int(maxValidatorSetSize), epoch.Validators.Copy())
 		if err != nil {
 			return fmt.Errorf("cannot update validator set on epoch ending: %w", err)
 		}
@@ -533,6 +647,8 @@ func (c *consensusRuntime) FSM() error {
 	c.fsm = ff
 	c.lock.Unlock()
 
+	c.setRoundStatus(pendingBlockNumber, 0, roundStateStarted)
+
 	return nil
 }
 
@@ -720,6 +836,83 @@ func (c *consensusRuntime) GetStateSyncProof(stateSyncID uint64) (types.Proof, e
 	return c.stateSyncManager.GetStateSyncProof(stateSyncID)
 }
 
+// GetTokenMappings returns the child chain tokens the rootchain token is mapped to,
+// read from the child predicates at the latest block
+func (c *consensusRuntime) GetTokenMappings(rootToken types.Address) ([]*types.BridgeTokenMapping, error) {
+	provider, err := c.config.blockchain.GetStateProviderForBlock(c.config.blockchain.CurrentHeader())
+	if err != nil {
+		return nil, err
+	}
+
+	return getTokenMappings(provider, rootToken)
+}
+
+// GetDepositStatus returns the status of the state syncs emitted by the rootchain deposit transaction,
+// tracked by the state sync event tracker and read from the child chain state at the latest block
+func (c *consensusRuntime) GetDepositStatus(txHash types.Hash) ([]*types.BridgeTransferStatus, error) {
+	if !c.IsBridgeEnabled() {
+		return nil, errBridgeDisabled
+	}
+
+	logs, err := c.stateSyncManager.GetStateSyncLogs(txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := c.config.blockchain.GetStateProviderForBlock(c.config.blockchain.CurrentHeader())
+	if err != nil {
+		return nil, err
+	}
+
+	nextCommittedIndex, err := c.config.blockchain.GetSystemState(provider).GetNextCommittedIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	return getDepositStatus(logs, provider, nextCommittedIndex)
+}
+
+// GetWithdrawalStatus returns the status of the exit events emitted by the child chain withdrawal transaction,
+// read from the checkpoints and the processed exits of the rootchain
+func (c *consensusRuntime) GetWithdrawalStatus(txHash types.Hash) ([]*types.BridgeTransferStatus, error) {
+	if !c.IsBridgeEnabled() {
+		return nil, errBridgeDisabled
+	}
+
+	blockHash, ok := c.config.blockchain.ReadTxLookup(txHash)
+	if !ok {
+		return nil, fmt.Errorf("transaction %s not found", txHash)
+	}
+
+	header, ok := c.config.blockchain.GetHeaderByHash(blockHash)
+	if !ok {
+		return nil, fmt.Errorf("block %s not found", blockHash)
+	}
+
+	receipts, err := c.config.blockchain.GetReceiptsByHash(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var logs []*types.Log
+
+	for _, receipt := range receipts {
+		if receipt.TxHash == txHash {
+			logs = receipt.Logs
+
+			break
+		}
+	}
+
+	relayer, err := txrelayer.NewTxRelayer(txrelayer.WithIPAddress(c.config.PolyBFTConfig.Bridge.JSONRPCEndpoint))
+	if err != nil {
+		return nil, err
+	}
+
+	return getWithdrawalStatus(logs, header.Number, relayer,
+		c.config.PolyBFTConfig.Bridge.ExitHelperAddr, c.config.PolyBFTConfig.Bridge.CheckpointManagerAddr)
+}
+
 // setIsActiveValidator updates the activeValidatorFlag field
 func (c *consensusRuntime) setIsActiveValidator(isActiveValidator bool) {
 	c.activeValidatorFlag.Store(isActiveValidator)
@@ -730,6 +923,17 @@ func (c *consensusRuntime) IsActiveValidator() bool {
 	return c.activeValidatorFlag.Load()
 }
 
+// setRoundStatus records the view of the consensus and the step the node took in it
+func (c *consensusRuntime) setRoundStatus(height, round uint64, state string) {
+	c.roundStatus.Store(&RoundStatus{Height: height, Round: round, State: state})
+}
+
+// RoundStatus returns the view of the consensus and the last step the node took in it,
+// nil if the node didn't take part in the consensus yet
+func (c *consensusRuntime) RoundStatus() *RoundStatus {
+	return c.roundStatus.Load()
+}
+
 // isFixedSizeOfEpochMet checks if epoch reached its end that was configured by its default size
 // this is only true if no slashing occurred in the given epoch
 func (c *consensusRuntime) isFixedSizeOfEpochMet(blockNumber uint64, epoch *epochMetadata) bool {
@@ -741,7 +945,27 @@ func (c *consensusRuntime) isFixedSizeOfSprintMet(blockNumber uint64, epoch *epo
 	return (blockNumber-epoch.FirstBlockInEpoch+1)%c.config.PolyBFTConfig.SprintSize == 0
 }
 
+// isRandomnessActive returns true if the proposers contribute to the randomness contract in the block
+func (c *consensusRuntime) isRandomnessActive(blockNumber uint64) bool {
+	return c.config.randomness != nil && c.config.forks.IsActive(c.config.randomness.Fork, blockNumber)
+}
+
 // getSystemState builds SystemState instance for the most current block header
+// getGovernanceState returns the state of the governance contract as of the header,
+// nil if the governance contract isn't active
+func (c *consensusRuntime) getGovernanceState(header *types.Header) (*governanceState, error) {
+	if c.config.governance == nil || !c.config.forks.IsActive(c.config.governance.Fork, header.Number) {
+		return nil, nil
+	}
+
+	provider, err := c.config.blockchain.GetStateProviderForBlock(header)
+	if err != nil {
+		return nil, err
+	}
+
+	return &governanceState{address: ethgo.Address(c.config.governance.Address), provider: provider}, nil
+}
+
 func (c *consensusRuntime) getSystemState(header *types.Header) (SystemState, error) {
 	provider, err := c.config.blockchain.GetStateProviderForBlock(header)
 	if err != nil {
@@ -752,7 +976,12 @@ func (c *consensusRuntime) getSystemState(header *types.Header) (SystemState, er
 }
 
 func (c *consensusRuntime) IsValidProposal(rawProposal []byte) bool {
-	if err := c.fsm.Validate(rawProposal); err != nil {
+	_, span := tracing.Start(tracing.BlockContext(c.fsm.Height()), "consensus.validate_proposal")
+
+	err := c.fsm.Validate(rawProposal)
+	tracing.EndSpan(span, err)
+
+	if err != nil {
 		c.logger.Error("failed to validate proposal", "error", err)
 
 		return false
@@ -839,6 +1068,12 @@ func (c *consensusRuntime) IsValidCommittedSeal(proposalHash []byte, committedSe
 }
 
 func (c *consensusRuntime) BuildProposal(view *proto.View) []byte {
+	if c.proposingPaused.Load() {
+		c.logger.Info("proposing is paused, skipping the proposal", "height", view.Height, "round", view.Round)
+
+		return nil
+	}
+
 	sharedData, err := c.getGuardedData()
 	if err != nil {
 		c.logger.Error("unable to build proposal", "error", err)
@@ -853,7 +1088,12 @@ func (c *consensusRuntime) BuildProposal(view *proto.View) []byte {
 		return nil
 	}
 
+	_, span := tracing.Start(tracing.BlockContext(view.Height), "consensus.build_proposal",
+		attribute.Int64("consensus.round", int64(view.Round)))
+
 	proposal, err := c.fsm.BuildProposal(view.Round)
+	tracing.EndSpan(span, err)
+
 	if err != nil {
 		c.logger.Error("unable to build proposal", "blockNumber", view, "error", err)
 
@@ -867,13 +1107,20 @@ func (c *consensusRuntime) BuildProposal(view *proto.View) []byte {
 func (c *consensusRuntime) InsertProposal(proposal *proto.Proposal, committedSeals []*messages.CommittedSeal) {
 	fsm := c.fsm
 
+	_, span := tracing.Start(tracing.BlockContext(fsm.Height()), "consensus.insert_proposal",
+		attribute.Int64("consensus.round", int64(proposal.Round)),
+		attribute.Int("consensus.committed_seals", len(committedSeals)))
+
 	fullBlock, err := fsm.Insert(proposal.RawProposal, committedSeals)
+	tracing.EndSpan(span, err)
+
 	if err != nil {
 		c.logger.Error("cannot insert proposal", "error", err)
 
 		return
 	}
 
+	c.setRoundStatus(fullBlock.Block.Number(), proposal.Round, roundStateInserted)
 	c.OnBlockInserted(fullBlock)
 }
 
@@ -930,6 +1177,9 @@ func (c *consensusRuntime) BuildPrePrepareMessage(
 		return nil
 	}
 
+	tracing.AddBlockEvent(view.Height, "consensus.preprepare", attribute.Int64("consensus.round", int64(view.Round)))
+	c.setRoundStatus(view.Height, view.Round, roundStatePrePrepare)
+
 	proposal := &proto.Proposal{
 		RawProposal: rawProposal,
 		Round:       view.Round,
@@ -960,6 +1210,9 @@ func (c *consensusRuntime) BuildPrePrepareMessage(
 
 // BuildPrepareMessage builds a PREPARE message based on the passed in proposal
 func (c *consensusRuntime) BuildPrepareMessage(proposalHash []byte, view *proto.View) *proto.Message {
+	tracing.AddBlockEvent(view.Height, "consensus.prepare", attribute.Int64("consensus.round", int64(view.Round)))
+	c.setRoundStatus(view.Height, view.Round, roundStatePrepare)
+
 	msg := proto.Message{
 		View: view,
 		From: c.ID(),
@@ -983,7 +1236,10 @@ func (c *consensusRuntime) BuildPrepareMessage(proposalHash []byte, view *proto.
 
 // BuildCommitMessage builds a COMMIT message based on the passed in proposal
 func (c *consensusRuntime) BuildCommitMessage(proposalHash []byte, view *proto.View) *proto.Message {
-	committedSeal, err := c.config.Key.SignWithDomain(proposalHash, signer.DomainCheckpointManager)
+	tracing.AddBlockEvent(view.Height, "consensus.commit", attribute.Int64("consensus.round", int64(view.Round)))
+	c.setRoundStatus(view.Height, view.Round, roundStateCommit)
+
+	committedSeal, err := c.config.Key.SignCommittedSeal(proposalHash, view)
 	if err != nil {
 		c.logger.Error("Cannot create committed seal message.", "error", err)
 
@@ -1018,6 +1274,9 @@ func (c *consensusRuntime) BuildRoundChangeMessage(
 	certificate *proto.PreparedCertificate,
 	view *proto.View,
 ) *proto.Message {
+	tracing.AddBlockEvent(view.Height, "consensus.round_change", attribute.Int64("consensus.round", int64(view.Round)))
+	c.setRoundStatus(view.Height, view.Round, roundStateRoundChange)
+
 	msg := proto.Message{
 		View: view,
 		From: c.ID(),
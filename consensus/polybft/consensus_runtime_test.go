@@ -204,7 +204,6 @@ func TestConsensusRuntime_OnBlockInserted_EndOfEpoch(t *testing.T) {
 	polybftBackendMock.On("GetValidatorsWithTx", mock.Anything, mock.Anything, mock.Anything).Return(validatorSet).Times(3)
 
 	txPool := new(txPoolMock)
-	txPool.On("ResetWithHeaders", mock.Anything).Once()
 
 	snapshot := NewProposerSnapshot(epochSize-1, validatorSet)
 	config := &runtimeConfig{
@@ -227,12 +226,13 @@ func TestConsensusRuntime_OnBlockInserted_EndOfEpoch(t *testing.T) {
 			Number:            currentEpochNumber,
 			FirstBlockInEpoch: header.Number - epochSize + 1,
 		},
-		lastBuiltBlock:    &types.Header{Number: header.Number - 1},
-		stateSyncManager:  &dummyStateSyncManager{},
-		checkpointManager: &dummyCheckpointManager{},
-		stakeManager:      &dummyStakeManager{},
-		eventProvider:     NewEventProvider(blockchainMock),
-		stateSyncRelayer:  &dummyStateSyncRelayer{},
+		lastBuiltBlock:     &types.Header{Number: header.Number - 1},
+		stateSyncManager:   &dummyStateSyncManager{},
+		checkpointManager:  &dummyCheckpointManager{},
+		stakeManager:       &dummyStakeManager{},
+		eventProvider:      NewEventProvider(blockchainMock),
+		stateSyncRelayer:   &dummyStateSyncRelayer{},
+		performanceTracker: newValidatorPerformanceTracker(config.State.PerformanceStore),
 	}
 	runtime.OnBlockInserted(&types.FullBlock{Block: builtBlock})
 
@@ -265,7 +265,6 @@ func TestConsensusRuntime_OnBlockInserted_MiddleOfEpoch(t *testing.T) {
 	polybftBackendMock.On("GetValidatorsWithTx", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 	txPool := new(txPoolMock)
-	txPool.On("ResetWithHeaders", mock.Anything).Once()
 
 	snapshot := NewProposerSnapshot(blockNumber, []*validator.ValidatorMetadata{})
 	config := &runtimeConfig{
@@ -990,6 +989,24 @@ func TestConsensusRuntime_BuildPrepareMessage(t *testing.T) {
 	assert.Equal(t, signedMsg, runtime.BuildPrepareMessage(proposalHash, view))
 }
 
+func TestConsensusRuntime_RoundStatus(t *testing.T) {
+	t.Parallel()
+
+	runtime := &consensusRuntime{
+		config: &runtimeConfig{
+			Key: createTestKey(t),
+		},
+	}
+
+	require.Nil(t, runtime.RoundStatus())
+
+	runtime.BuildPrepareMessage([]byte{1, 2, 4}, &proto.View{Height: 5, Round: 2})
+	require.Equal(t, &RoundStatus{Height: 5, Round: 2, State: roundStatePrepare}, runtime.RoundStatus())
+
+	runtime.BuildRoundChangeMessage(nil, nil, &proto.View{Height: 5, Round: 3})
+	require.Equal(t, &RoundStatus{Height: 5, Round: 3, State: roundStateRoundChange}, runtime.RoundStatus())
+}
+
 func createTestBlocks(t *testing.T, numberOfBlocks, defaultEpochSize uint64,
 	validatorSet validator.AccountSet) (*types.Header, *testHeadersMap) {
 	t.Helper()
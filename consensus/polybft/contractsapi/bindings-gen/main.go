@@ -159,6 +159,7 @@ func main() {
 			[]string{
 				"initialize",
 				"depositTo",
+				"mapToken",
 			},
 			[]string{
 				"TokenMapped",
@@ -572,6 +572,22 @@ func (d *DepositToRootERC20PredicateFn) DecodeAbi(buf []byte) error {
 	return decodeMethod(RootERC20Predicate.Abi.Methods["depositTo"], buf, d)
 }
 
+type MapTokenRootERC20PredicateFn struct {
+	RootToken types.Address `abi:"rootToken"`
+}
+
+func (m *MapTokenRootERC20PredicateFn) Sig() []byte {
+	return RootERC20Predicate.Abi.Methods["mapToken"].ID()
+}
+
+func (m *MapTokenRootERC20PredicateFn) EncodeAbi() ([]byte, error) {
+	return RootERC20Predicate.Abi.Methods["mapToken"].Encode(m)
+}
+
+func (m *MapTokenRootERC20PredicateFn) DecodeAbi(buf []byte) error {
+	return decodeMethod(RootERC20Predicate.Abi.Methods["mapToken"], buf, m)
+}
+
 type TokenMappedEvent struct {
 	RootToken  types.Address `abi:"rootToken"`
 	ChildToken types.Address `abi:"childToken"`
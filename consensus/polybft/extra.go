@@ -303,6 +303,17 @@ type CheckpointData struct {
 	CurrentValidatorsHash types.Hash
 	NextValidatorsHash    types.Hash
 	EventRoot             types.Hash
+	// StateCommitment is the state commitment of the epoch ending block, if the chain commits its state
+	StateCommitment *StateCommitment
+}
+
+// StateCommitment is the commitment of the epoch ending block to its state root,
+// along with the time the committed state can be challenged for, once the checkpoint is submitted to the rootchain.
+// It isn't a part of the checkpoint hash, the signatures of the checkpoint cover it through the block hash
+type StateCommitment struct {
+	StateRoot types.Hash
+	// ChallengeWindow is the length of the challenge window, in seconds
+	ChallengeWindow uint64
 }
 
 // MarshalRLPWith defines the marshal function implementation for CheckpointData
@@ -319,6 +330,14 @@ func (c *CheckpointData) MarshalRLPWith(ar *fastrlp.Arena) *fastrlp.Value {
 	// EventRoot
 	vv.Set(ar.NewBytes(c.EventRoot.Bytes()))
 
+	// StateCommitment is optional, so the checkpoints of the chains not committing their state are unchanged
+	if c.StateCommitment != nil {
+		commitment := ar.NewArray()
+		commitment.Set(ar.NewBytes(c.StateCommitment.StateRoot.Bytes()))
+		commitment.Set(ar.NewUint(c.StateCommitment.ChallengeWindow))
+		vv.Set(commitment)
+	}
+
 	return vv
 }
 
@@ -329,10 +348,11 @@ func (c *CheckpointData) UnmarshalRLPWith(v *fastrlp.Value) error {
 		return fmt.Errorf("array type expected for CheckpointData struct")
 	}
 
-	// there should be exactly 5 elements:
+	// there should be 5 elements:
 	// BlockRound, EpochNumber, CurrentValidatorsHash, NextValidatorsHash, EventRoot
-	if num := len(vals); num != 5 {
-		return fmt.Errorf("incorrect elements count to decode CheckpointData, expected 5 but found %d", num)
+	// and optionally the StateCommitment
+	if num := len(vals); num != 5 && num != 6 {
+		return fmt.Errorf("incorrect elements count to decode CheckpointData, expected 5 or 6 but found %d", num)
 	}
 
 	// BlockRound
@@ -371,6 +391,32 @@ func (c *CheckpointData) UnmarshalRLPWith(v *fastrlp.Value) error {
 
 	c.EventRoot = types.BytesToHash(eventRootRaw)
 
+	if len(vals) == 6 {
+		commitment, err := vals[5].GetElems()
+		if err != nil {
+			return fmt.Errorf("array type expected for StateCommitment")
+		}
+
+		if num := len(commitment); num != 2 {
+			return fmt.Errorf("incorrect elements count to decode StateCommitment, expected 2 but found %d", num)
+		}
+
+		stateRootRaw, err := commitment[0].GetBytes(nil)
+		if err != nil {
+			return err
+		}
+
+		challengeWindow, err := commitment[1].GetUint64()
+		if err != nil {
+			return err
+		}
+
+		c.StateCommitment = &StateCommitment{
+			StateRoot:       types.BytesToHash(stateRootRaw),
+			ChallengeWindow: challengeWindow,
+		}
+	}
+
 	return nil
 }
 
@@ -379,6 +425,11 @@ func (c *CheckpointData) Copy() *CheckpointData {
 	newCheckpointData := new(CheckpointData)
 	*newCheckpointData = *c
 
+	if c.StateCommitment != nil {
+		stateCommitment := *c.StateCommitment
+		newCheckpointData.StateCommitment = &stateCommitment
+	}
+
 	return newCheckpointData
 }
 
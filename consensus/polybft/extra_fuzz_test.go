@@ -0,0 +1,51 @@
+package polybft
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/bitmap"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func FuzzGetIbftExtra(f *testing.F) {
+	bmp := bitmap.Bitmap{}
+	bmp.Set(1)
+
+	seeds := []*Extra{
+		{},
+		{
+			Validators: &validator.ValidatorSetDelta{
+				Added:   validator.NewTestValidatorsWithAliases(f, []string{"A", "B"}).GetPublicIdentities(),
+				Removed: bmp,
+			},
+			Parent:    &Signature{AggregatedSignature: []byte{1, 2}, Bitmap: bmp},
+			Committed: &Signature{AggregatedSignature: []byte{3, 4}, Bitmap: bmp},
+			Checkpoint: &CheckpointData{
+				BlockRound:            1,
+				EpochNumber:           2,
+				CurrentValidatorsHash: types.StringToHash("1"),
+				NextValidatorsHash:    types.StringToHash("2"),
+				EventRoot:             types.StringToHash("3"),
+			},
+		},
+	}
+
+	for _, seed := range seeds {
+		f.Add(seed.MarshalRLPTo(nil))
+	}
+
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		extra, err := GetIbftExtra(raw)
+		if err != nil {
+			return
+		}
+
+		_, err = GetIbftExtra(extra.MarshalRLPTo(nil))
+		require.NoError(t, err)
+	})
+}
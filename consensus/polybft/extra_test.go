@@ -110,6 +110,24 @@ func TestExtra_Encoding(t *testing.T) {
 				},
 			},
 		},
+		{
+			&Extra{
+				Validators: &validator.ValidatorSetDelta{},
+				Parent:     &Signature{AggregatedSignature: parentSig, Bitmap: bmp},
+				Committed:  &Signature{AggregatedSignature: committedSig, Bitmap: bmp},
+				Checkpoint: &CheckpointData{
+					BlockRound:            1,
+					EpochNumber:           4,
+					CurrentValidatorsHash: types.BytesToHash(generateRandomBytes(t)),
+					NextValidatorsHash:    types.BytesToHash(generateRandomBytes(t)),
+					EventRoot:             types.BytesToHash(generateRandomBytes(t)),
+					StateCommitment: &StateCommitment{
+						StateRoot:       types.BytesToHash(generateRandomBytes(t)),
+						ChallengeWindow: 7 * 24 * 60 * 60,
+					},
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
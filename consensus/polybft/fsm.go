@@ -43,7 +43,15 @@ var (
 		"in a non epoch ending block")
 	errDistributeRewardsTxSingleExpected = errors.New("only one distribute rewards transaction is " +
 		"allowed in an epoch ending block")
-	errProposalDontMatch = errors.New("failed to insert proposal, because the validated proposal " +
+	errGovernanceTxDoesNotExist = errors.New("governance transaction is not found in the epoch ending block " +
+		"of the active governance contract")
+	errGovernanceTxNotExpected    = errors.New("didn't expect governance transaction in the block")
+	errGovernanceTxSingleExpected = errors.New("only one governance transaction is allowed " +
+		"in an epoch ending block")
+	errRandomnessTxDoesNotExist   = errors.New("randomness contribution transaction is not found in the block")
+	errRandomnessTxNotExpected    = errors.New("didn't expect randomness contribution transaction in the block")
+	errRandomnessTxSingleExpected = errors.New("only one randomness contribution transaction is allowed in the block")
+	errProposalDontMatch          = errors.New("failed to insert proposal, because the validated proposal " +
 		"is either nil or it does not match the received one")
 	errValidatorSetDeltaMismatch           = errors.New("validator set delta mismatch")
 	errValidatorsUpdateInNonEpochEnding    = errors.New("trying to update validator set in a non epoch ending block")
@@ -84,6 +92,24 @@ type fsm struct {
 	// It is populated only for epoch-ending blocks.
 	distributeRewardsInput *contractsapi.DistributeRewardForRewardPoolFn
 
+	// governanceProposals are the ids of the governance proposals passed by the validators,
+	// executed in the epoch-ending block
+	governanceProposals []*big.Int
+
+	// governanceAddress is the address of the governance contract, zero if it isn't active
+	governanceAddress types.Address
+
+	// randomnessAddress is the address of the randomness contract, zero if it isn't active
+	randomnessAddress types.Address
+
+	// randomnessContribution is the VRF output of the node contributed to the randomness beacon
+	// when it proposes the block, nil if the randomness contract isn't active
+	randomnessContribution *randomnessContributeFn
+
+	// blockProposer is the proposer of the validated block for its round,
+	// the randomness contribution of the block has to be its VRF output
+	blockProposer types.Address
+
 	// isEndOfEpoch indicates if epoch reached its end
 	isEndOfEpoch bool
 
@@ -127,6 +153,17 @@ func (f *fsm) BuildProposal(currentRound uint64) ([]byte, error) {
 		return nil, fmt.Errorf("failed to initialize block builder: %w", err)
 	}
 
+	if f.randomnessContribution != nil {
+		tx, err := f.createRandomnessTx()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := f.blockBuilder.WriteTx(tx); err != nil {
+			return nil, fmt.Errorf("failed to apply randomness contribution transaction: %w", err)
+		}
+	}
+
 	if f.isEndOfEpoch {
 		tx, err := f.createCommitEpochTx()
 		if err != nil {
@@ -145,6 +182,17 @@ func (f *fsm) BuildProposal(currentRound uint64) ([]byte, error) {
 		if err := f.blockBuilder.WriteTx(tx); err != nil {
 			return nil, fmt.Errorf("failed to apply distribute rewards transaction: %w", err)
 		}
+
+		if f.governanceAddress != types.ZeroAddress {
+			tx, err = f.createGovernanceTx()
+			if err != nil {
+				return nil, err
+			}
+
+			if err := f.blockBuilder.WriteTx(tx); err != nil {
+				return nil, fmt.Errorf("failed to apply governance transaction: %w", err)
+			}
+		}
 	}
 
 	if f.config.IsBridgeEnabled() {
@@ -187,6 +235,7 @@ func (f *fsm) BuildProposal(currentRound uint64) ([]byte, error) {
 		"Next validators hash", nextValidatorsHash)
 
 	stateBlock, err := f.blockBuilder.Build(func(h *types.Header) {
+		extra.Checkpoint.StateCommitment = newStateCommitment(f.config.StateCommitment, f.isEndOfEpoch, h.StateRoot)
 		h.ExtraData = extra.MarshalRLPTo(nil)
 		h.MixHash = PolyBFTMixDigest
 	})
@@ -237,6 +286,17 @@ func (f *fsm) createBridgeCommitmentTx() (*types.Transaction, error) {
 	return createStateTransactionWithData(f.Height(), contracts.StateReceiverContract, inputData), nil
 }
 
+// createRandomnessTx create a StateTransaction, which contributes the VRF output of the proposer
+// to the randomness contract
+func (f *fsm) createRandomnessTx() (*types.Transaction, error) {
+	input, err := f.randomnessContribution.EncodeAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	return createStateTransactionWithData(f.Height(), f.randomnessAddress, input), nil
+}
+
 // getValidatorsTransition applies delta to the current validators,
 func (f *fsm) getValidatorsTransition(delta *validator.ValidatorSetDelta) (validator.AccountSet, error) {
 	nextValidators, err := f.validators.Accounts().ApplyDelta(delta)
@@ -271,6 +331,22 @@ func (f *fsm) createDistributeRewardsTx() (*types.Transaction, error) {
 	return createStateTransactionWithData(f.Height(), contracts.RewardPoolContract, input), nil
 }
 
+// createGovernanceTx create a StateTransaction, which invokes the governance contract
+// to execute the proposals passed by the validators and to set the validators voting in the next epoch.
+func (f *fsm) createGovernanceTx() (*types.Transaction, error) {
+	nextValidators, err := f.validators.Accounts().ApplyDelta(f.newValidatorsDelta)
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := newGovernanceExecuteFn(f.governanceProposals, nextValidators).EncodeAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	return createStateTransactionWithData(f.Height(), f.governanceAddress, input), nil
+}
+
 // ValidateCommit is used to validate that a given commit is valid
 func (f *fsm) ValidateCommit(signerAddr []byte, seal []byte, proposalHash []byte) error {
 	from := types.BytesToAddress(signerAddr)
@@ -332,6 +408,13 @@ func (f *fsm) Validate(proposal []byte) error {
 		return err
 	}
 
+	if f.randomnessAddress != types.ZeroAddress {
+		f.blockProposer, err = f.proposerSnapshot.CalcProposer(extra.Checkpoint.BlockRound, block.Number())
+		if err != nil {
+			return fmt.Errorf("cannot calculate block proposer: %w", err)
+		}
+	}
+
 	if err := f.VerifyStateTransactions(block.Transactions); err != nil {
 		return err
 	}
@@ -377,6 +460,12 @@ func (f *fsm) Validate(proposal []byte) error {
 		return err
 	}
 
+	// the state root of the header is checked by the block processing, so the commitment is checked against it
+	if err := verifyStateCommitment(f.config.StateCommitment, f.isEndOfEpoch,
+		block.Header, extra.Checkpoint); err != nil {
+		return err
+	}
+
 	if f.logger.IsDebug() {
 		checkpointHash, err := extra.Checkpoint.Hash(f.backend.GetChainID(), block.Number(), block.Hash())
 		if err != nil {
@@ -421,6 +510,8 @@ func (f *fsm) VerifyStateTransactions(transactions []*types.Transaction) error {
 		commitmentTxExists        bool
 		commitEpochTxExists       bool
 		distributeRewardsTxExists bool
+		governanceTxExists        bool
+		randomnessTxExists        bool
 	)
 
 	for _, tx := range transactions {
@@ -474,11 +565,35 @@ func (f *fsm) VerifyStateTransactions(transactions []*types.Transaction) error {
 			if err := f.verifyDistributeRewardsTx(tx); err != nil {
 				return fmt.Errorf("error while verifying distribute rewards transaction. error: %w", err)
 			}
+		case *governanceExecuteFn:
+			if governanceTxExists {
+				return errGovernanceTxSingleExpected
+			}
+
+			governanceTxExists = true
+
+			if err := f.verifyGovernanceTx(tx); err != nil {
+				return fmt.Errorf("error while verifying governance transaction. error: %w", err)
+			}
+		case *randomnessContributeFn:
+			if randomnessTxExists {
+				return errRandomnessTxSingleExpected
+			}
+
+			randomnessTxExists = true
+
+			if err := f.verifyRandomnessTx(tx, stateTxData); err != nil {
+				return fmt.Errorf("error while verifying randomness contribution transaction. error: %w", err)
+			}
 		default:
 			return fmt.Errorf("invalid state transaction data type: %v", stateTxData)
 		}
 	}
 
+	if !randomnessTxExists && f.randomnessAddress != types.ZeroAddress {
+		return errRandomnessTxDoesNotExist
+	}
+
 	if f.isEndOfEpoch {
 		if !commitEpochTxExists {
 			// this is a check if commit epoch transaction is not in the list of transactions at all
@@ -491,6 +606,10 @@ func (f *fsm) VerifyStateTransactions(transactions []*types.Transaction) error {
 			// but it should be
 			return errDistributeRewardsTxDoesNotExist
 		}
+
+		if !governanceTxExists && f.governanceAddress != types.ZeroAddress {
+			return errGovernanceTxDoesNotExist
+		}
 	}
 
 	return nil
@@ -624,6 +743,54 @@ func (f *fsm) verifyDistributeRewardsTx(distributeRewardsTx *types.Transaction)
 	return errDistributeRewardsTxNotExpected
 }
 
+// verifyGovernanceTx creates governance transaction executing the passed proposals
+// and compares its hash with the one extracted from the block.
+func (f *fsm) verifyGovernanceTx(governanceTx *types.Transaction) error {
+	if !f.isEndOfEpoch || f.governanceAddress == types.ZeroAddress {
+		return errGovernanceTxNotExpected
+	}
+
+	localGovernanceTx, err := f.createGovernanceTx()
+	if err != nil {
+		return err
+	}
+
+	if governanceTx.Hash != localGovernanceTx.Hash {
+		return fmt.Errorf(
+			"invalid governance transaction. Expected '%s', but got '%s' governance transaction hash",
+			localGovernanceTx.Hash,
+			governanceTx.Hash,
+		)
+	}
+
+	return nil
+}
+
+// verifyRandomnessTx checks that the contribution is the VRF output of the block proposer for the block,
+// sent to the randomness contract
+func (f *fsm) verifyRandomnessTx(tx *types.Transaction, contribution *randomnessContributeFn) error {
+	if f.randomnessAddress == types.ZeroAddress {
+		return errRandomnessTxNotExpected
+	}
+
+	if tx.To == nil || *tx.To != f.randomnessAddress {
+		return fmt.Errorf("randomness contribution sent to %v instead of %s", tx.To, f.randomnessAddress)
+	}
+
+	if sprint := randomnessSprint(f.Height(), f.config.SprintSize); contribution.Sprint != sprint {
+		return fmt.Errorf("randomness contribution to sprint %d, expected sprint %d", contribution.Sprint, sprint)
+	}
+
+	// the other validators would let the proposer pick the randomness out of their contributions
+	if contribution.Validator != f.blockProposer {
+		return fmt.Errorf("randomness contribution of %s, expected the block proposer %s",
+			contribution.Validator, f.blockProposer)
+	}
+
+	return verifyVRFOutput(f.validators.Accounts(), contribution.Validator,
+		vrfMessage(f.parent.Hash, f.Height()), contribution.Output)
+}
+
 // verifyBridgeCommitmentTx validates bridge commitment transaction
 func verifyBridgeCommitmentTx(blockNumber uint64, txHash types.Hash,
 	commitment *CommitmentMessageSigned,
@@ -0,0 +1,203 @@
+package polybft
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+	"github.com/umbracle/ethgo/contract"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful/governance"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var _ contractsapi.StateTransactionInput = (*governanceExecuteFn)(nil)
+
+// governanceExecuteFn is the input of the state transaction executing the governance proposals
+// passed by the validators and setting the voting powers of the validators of the next epoch,
+// it's included in the epoch-ending blocks
+type governanceExecuteFn struct {
+	IDs    []*big.Int
+	Voters []types.Address
+	Powers []*big.Int
+}
+
+// newGovernanceExecuteFn creates the execution of the proposals, with the validators voting in the next epoch
+func newGovernanceExecuteFn(ids []*big.Int, validators validator.AccountSet) *governanceExecuteFn {
+	fn := &governanceExecuteFn{
+		IDs:    ids,
+		Voters: make([]types.Address, len(validators)),
+		Powers: make([]*big.Int, len(validators)),
+	}
+
+	for i, v := range validators {
+		fn.Voters[i], fn.Powers[i] = v.Address, v.VotingPower
+	}
+
+	return fn
+}
+
+// Sig returns the signature of the execute function of the governance contract
+func (g *governanceExecuteFn) Sig() []byte {
+	return governance.ExecuteFunc.ID()
+}
+
+// EncodeAbi encodes the execution of the proposals
+func (g *governanceExecuteFn) EncodeAbi() ([]byte, error) {
+	return governance.ExecuteFunc.Encode([]interface{}{g.IDs, g.Voters, g.Powers})
+}
+
+// DecodeAbi decodes the execution of the proposals
+func (g *governanceExecuteFn) DecodeAbi(buf []byte) error {
+	if len(buf) < abiMethodIDLength {
+		return fmt.Errorf("invalid governance execute input")
+	}
+
+	args, err := governance.ExecuteFunc.Inputs.Decode(buf[abiMethodIDLength:])
+	if err != nil {
+		return err
+	}
+
+	fields, ok := args.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid governance execute input")
+	}
+
+	ids, ok := fields["ids"].([]*big.Int)
+	if !ok {
+		return fmt.Errorf("invalid governance execute input")
+	}
+
+	voters, ok := fields["voters"].([]ethgo.Address)
+	if !ok {
+		return fmt.Errorf("invalid governance execute input")
+	}
+
+	powers, ok := fields["powers"].([]*big.Int)
+	if !ok || len(powers) != len(voters) {
+		return fmt.Errorf("invalid governance execute input")
+	}
+
+	g.IDs, g.Voters, g.Powers = ids, make([]types.Address, len(voters)), powers
+
+	for i, voter := range voters {
+		g.Voters[i] = types.Address(voter)
+	}
+
+	return nil
+}
+
+// findStatefulPrecompile returns the config of the stateful precompile with the given name in the chain params,
+// nil if there is none
+func findStatefulPrecompile(params *chain.Params, name string) *chain.StatefulPrecompileConfig {
+	if params == nil {
+		return nil
+	}
+
+	for _, precompile := range params.StatefulPrecompiles {
+		if precompile.Name == name {
+			return precompile
+		}
+	}
+
+	return nil
+}
+
+// governanceState reads the proposals and the parameters of the governance contract
+type governanceState struct {
+	address  ethgo.Address
+	provider contract.Provider
+}
+
+func (g *governanceState) call(method *abi.Method, args ...interface{}) (map[string]interface{}, error) {
+	input, err := method.Encode(args)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := g.provider.Call(g.address, input, &contract.CallOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	return method.Decode(output)
+}
+
+// parameter returns the value of the parameter enacted by the governance, 0 if it was never changed
+func (g *governanceState) parameter(param governance.Parameter) (uint64, error) {
+	result, err := g.call(governance.GetParameterFunc, uint8(param))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get governance parameter %s: %w", param, err)
+	}
+
+	value, ok := result["0"].(*big.Int)
+	if !ok {
+		return 0, fmt.Errorf("failed to decode governance parameter %s", param)
+	}
+
+	return value.Uint64(), nil
+}
+
+// passedProposals returns the ids of the open proposals voted for by the quorum of the validators,
+// in the order they were created in. The votes are tallied by the contract as they are cast
+func (g *governanceState) passedProposals(blockNumber uint64) ([]*big.Int, error) {
+	result, err := g.call(governance.TotalVotingPowerFunc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get governance total voting power: %w", err)
+	}
+
+	totalVotingPower, ok := result["0"].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("failed to decode governance total voting power")
+	}
+
+	// no proposal is made before the consensus sets the validators
+	if totalVotingPower.Sign() == 0 {
+		return nil, nil
+	}
+
+	result, err = g.call(governance.ProposalCountFunc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get governance proposal count: %w", err)
+	}
+
+	count, ok := result["0"].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("failed to decode governance proposal count")
+	}
+
+	var passed []*big.Int
+
+	// the proposals are closed in the order they were created in, so the open ones are the most recent
+	for id := count.Uint64(); id > 0; id-- {
+		proposal, err := g.call(governance.GetProposalFunc, new(big.Int).SetUint64(id))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get governance proposal %d: %w", id, err)
+		}
+
+		status, ok := proposal["status"].(uint8)
+		votes, okVotes := proposal["votes"].(*big.Int)
+
+		if !ok || !okVotes {
+			return nil, fmt.Errorf("failed to decode governance proposal %d", id)
+		}
+
+		if governance.Status(status) == governance.StatusExpired {
+			break
+		}
+
+		if governance.Status(status) != governance.StatusPending {
+			continue
+		}
+
+		if validator.HasVotingPowerQuorum(blockNumber, votes, totalVotingPower) {
+			passed = append([]*big.Int{new(big.Int).SetUint64(id)}, passed...)
+		}
+	}
+
+	return passed, nil
+}
@@ -0,0 +1,232 @@
+package polybft
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/bitmap"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful/governance"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestGovernanceExecuteFn_Decode(t *testing.T) {
+	t.Parallel()
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B"}, []uint64{3, 5})
+	fn := newGovernanceExecuteFn([]*big.Int{big.NewInt(3), big.NewInt(7)}, validators.GetPublicIdentities())
+
+	require.Equal(t, []types.Address{
+		validators.GetValidator("A").Address(),
+		validators.GetValidator("B").Address(),
+	}, fn.Voters)
+	require.Equal(t, []*big.Int{big.NewInt(3), big.NewInt(5)}, fn.Powers)
+
+	input, err := fn.EncodeAbi()
+	require.NoError(t, err)
+
+	decoded, err := decodeStateTransaction(input)
+	require.NoError(t, err)
+	require.Equal(t, fn, decoded)
+}
+
+func TestFSM_VerifyStateTransactions_Governance(t *testing.T) {
+	t.Parallel()
+
+	var (
+		governanceAddr         = types.StringToAddress("0x1001")
+		commitEpochInput       = createTestCommitEpochInput(t, 1, 10)
+		distributeRewardsInput = createTestDistributeRewardsInput(t, 1, nil, 10)
+		validators             = validator.NewTestValidators(t, 4)
+	)
+
+	newFSM := func(isEndOfEpoch bool, proposals ...int64) *fsm {
+		ids := make([]*big.Int, len(proposals))
+		for i, id := range proposals {
+			ids[i] = big.NewInt(id)
+		}
+
+		return &fsm{
+			parent:                 &types.Header{Number: 9},
+			isEndOfEpoch:           isEndOfEpoch,
+			commitEpochInput:       commitEpochInput,
+			distributeRewardsInput: distributeRewardsInput,
+			governanceProposals:    ids,
+			governanceAddress:      governanceAddr,
+			validators:             validators.ToValidatorSet(),
+			newValidatorsDelta: &validator.ValidatorSetDelta{
+				Removed: bitmap.Bitmap{},
+			},
+		}
+	}
+
+	epochTxs := func(f *fsm) []*types.Transaction {
+		commitEpochTx, err := f.createCommitEpochTx()
+		require.NoError(t, err)
+
+		distributeRewardsTx, err := f.createDistributeRewardsTx()
+		require.NoError(t, err)
+
+		return []*types.Transaction{commitEpochTx, distributeRewardsTx}
+	}
+
+	f := newFSM(true, 1, 2)
+
+	governanceTx, err := f.createGovernanceTx()
+	require.NoError(t, err)
+
+	require.NoError(t, f.VerifyStateTransactions(append(epochTxs(f), governanceTx)))
+
+	// the passed proposals have to be executed, and the validators of the next epoch set
+	require.ErrorIs(t, f.VerifyStateTransactions(epochTxs(f)), errGovernanceTxDoesNotExist)
+	require.ErrorIs(t, newFSM(true).VerifyStateTransactions(epochTxs(f)), errGovernanceTxDoesNotExist)
+	require.ErrorIs(t,
+		f.VerifyStateTransactions(append(epochTxs(f), governanceTx, governanceTx)), errGovernanceTxSingleExpected)
+
+	// the proposals the validators didn't pass can't be executed
+	otherTx, err := newFSM(true, 1).createGovernanceTx()
+	require.NoError(t, err)
+	require.ErrorContains(t, f.VerifyStateTransactions(append(epochTxs(f), otherTx)), "invalid governance transaction")
+
+	require.ErrorContains(t,
+		newFSM(true).VerifyStateTransactions(append(epochTxs(f), governanceTx)), "invalid governance transaction")
+
+	// the voting powers of the validators leaving the validator set are removed
+	leaving := newFSM(true, 1, 2)
+	leaving.newValidatorsDelta.Removed.Set(0)
+
+	require.ErrorContains(t,
+		leaving.VerifyStateTransactions(append(epochTxs(f), governanceTx)), "invalid governance transaction")
+
+	inactive := newFSM(true, 1, 2)
+	inactive.governanceAddress = types.ZeroAddress
+
+	require.ErrorIs(t, inactive.VerifyStateTransactions(append(epochTxs(f), governanceTx)), errGovernanceTxNotExpected)
+	require.NoError(t, inactive.VerifyStateTransactions(epochTxs(f)))
+	require.ErrorIs(t, newFSM(false, 1, 2).VerifyStateTransactions([]*types.Transaction{governanceTx}),
+		errGovernanceTxNotExpected)
+}
+
+func TestGovernanceState_PassedProposals(t *testing.T) {
+	t.Parallel()
+
+	governanceAddr := types.StringToAddress("0x1001")
+
+	contract, err := governance.Factory(json.RawMessage(`{"votingPeriod": 10}`))
+	require.NoError(t, err)
+
+	st := itrie.NewState(itrie.NewMemoryStorage())
+	executor := state.NewExecutor(
+		&chain.Params{Forks: &chain.Forks{governance.Name: chain.NewFork(0)}}, st, hclog.NewNullLogger())
+	executor.StatefulPrecompiles = []*stateful.Precompile{
+		{Name: governance.Name, Address: governanceAddr, Fork: governance.Name, Contract: contract},
+	}
+	executor.GetHash = func(*types.Header) state.GetHashByNumber {
+		return func(uint64) types.Hash { return types.ZeroHash }
+	}
+
+	validators := validator.NewTestValidatorsWithAliases(t,
+		[]string{"A", "B", "C", "D", "E"}, []uint64{6, 1, 1, 1, 1})
+	accounts := validators.GetPublicIdentities()
+
+	call := func(txn *state.Transition, from types.Address, input []byte) []byte {
+		t.Helper()
+
+		result := txn.Call2(from, governanceAddr, input, big.NewInt(0), 1_000_000)
+		require.NoError(t, result.Err)
+
+		return result.ReturnValue
+	}
+
+	propose := func(txn *state.Transition, voters ...string) {
+		t.Helper()
+
+		input, err := governance.ProposeFunc.Encode(
+			[]interface{}{uint8(governance.ParamMaxValidatorSetSize), big.NewInt(10)})
+		require.NoError(t, err)
+
+		id := new(big.Int).SetBytes(call(txn, validators.GetValidator("A").Address(), input))
+
+		for _, alias := range voters {
+			input, err := governance.VoteFunc.Encode([]interface{}{id})
+			require.NoError(t, err)
+
+			call(txn, validators.GetValidator(alias).Address(), input)
+		}
+	}
+
+	execute := func(txn *state.Transition, ids []*big.Int) {
+		t.Helper()
+
+		input, err := newGovernanceExecuteFn(ids, accounts).EncodeAbi()
+		require.NoError(t, err)
+
+		call(txn, contracts.SystemCaller, input)
+	}
+
+	txn, err := executor.BeginTxn(types.EmptyRootHash, &types.Header{Number: 1, GasLimit: 10_000_000}, types.ZeroAddress)
+	require.NoError(t, err)
+
+	govState := &governanceState{address: ethgo.Address(governanceAddr), provider: NewStateProvider(txn)}
+
+	// nothing passes before the consensus sets the validators at the end of the first epoch
+	passed, err := govState.passedProposals(2)
+	require.NoError(t, err)
+	require.Empty(t, passed)
+
+	execute(txn, nil)
+
+	// the proposal voted for by all the validators expires before the end of the epoch
+
+	propose(txn, "A", "B", "C", "D", "E")
+
+	_, root, err := txn.Commit()
+	require.NoError(t, err)
+
+	header := &types.Header{Number: 50, GasLimit: 10_000_000, StateRoot: root}
+
+	txn, err = executor.BeginTxn(root, header, types.ZeroAddress)
+	require.NoError(t, err)
+
+	propose(txn, "A", "B")           // 7 of 10 voting power reaches the quorum
+	propose(txn, "B", "C", "D", "E") // 4 of 10 doesn't
+	propose(txn, "A")                // 6 of 10 doesn't
+	propose(txn, "A", "B", "C", "D", "E")
+
+	_, header.StateRoot, err = txn.Commit()
+	require.NoError(t, err)
+
+	txn, err = executor.BeginTxn(header.StateRoot, header, types.ZeroAddress)
+	require.NoError(t, err)
+
+	govState = &governanceState{address: ethgo.Address(governanceAddr), provider: NewStateProvider(txn)}
+
+	passed, err = govState.passedProposals(header.Number + 1)
+	require.NoError(t, err)
+	require.Equal(t, []*big.Int{big.NewInt(2), big.NewInt(5)}, passed)
+
+	size, err := govState.parameter(governance.ParamMaxValidatorSetSize)
+	require.NoError(t, err)
+	require.Zero(t, size)
+
+	// the passed proposals are executed by the consensus
+	execute(txn, passed)
+
+	size, err = govState.parameter(governance.ParamMaxValidatorSetSize)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), size)
+
+	passed, err = govState.passedProposals(header.Number + 1)
+	require.NoError(t, err)
+	require.Empty(t, passed)
+}
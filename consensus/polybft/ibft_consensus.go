@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/0xPolygon/go-ibft/core"
+
+	"github.com/0xPolygon/polygon-edge/helper/tracing"
 )
 
 // IBFTConsensusWrapper is a convenience wrapper for the go-ibft package
@@ -29,8 +31,12 @@ func (c *IBFTConsensusWrapper) runSequence(height uint64) (<-chan struct{}, func
 	sequenceDone := make(chan struct{})
 	ctx, cancelSequence := context.WithCancel(context.Background())
 
+	// the spans of the consensus, the execution and the commit of the block are the children of the sequence span
+	tracing.StartBlock(height)
+
 	go func() {
 		c.IBFT.RunSequence(ctx, height)
+		tracing.EndBlock(height)
 		cancelSequence()
 		close(sequenceDone)
 	}()
@@ -35,7 +35,8 @@ func (m *blockchainMock) CommitBlock(block *types.FullBlock) error {
 }
 
 func (m *blockchainMock) NewBlockBuilder(parent *types.Header, coinbase types.Address,
-	txPool txPoolInterface, blockTime time.Duration, logger hclog.Logger) (blockBuilder, error) {
+	txPool txPoolInterface, blockTime, proposalDeadline time.Duration,
+	logger hclog.Logger) (blockBuilder, error) {
 	args := m.Called()
 
 	return args.Get(0).(blockBuilder), args.Error(1) //nolint:forcetypeassert
@@ -129,6 +130,12 @@ func (m *blockchainMock) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, e
 	return args.Get(0).([]*types.Receipt), args.Error(1) //nolint:forcetypeassert
 }
 
+func (m *blockchainMock) ReadTxLookup(txHash types.Hash) (types.Hash, bool) {
+	args := m.Called(txHash)
+
+	return args.Get(0).(types.Hash), args.Bool(1) //nolint:forcetypeassert
+}
+
 var _ polybftBackend = (*polybftBackendMock)(nil)
 
 type polybftBackendMock struct {
@@ -355,10 +362,6 @@ func (tp *txPoolMock) SetSealing(v bool) {
 	tp.Called(v)
 }
 
-func (tp *txPoolMock) ResetWithHeaders(values ...*types.Header) {
-	tp.Called(values)
-}
-
 var _ syncer.Syncer = (*syncerMock)(nil)
 
 type syncerMock struct {
@@ -16,6 +16,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/consensus"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/remotesigner"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
@@ -25,6 +26,8 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful/governance"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful/randomness"
 	"github.com/0xPolygon/polygon-edge/syncer"
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -117,6 +120,9 @@ type Polybft struct {
 	// key encapsulates ECDSA address and BLS signing logic
 	key *wallet.Key
 
+	// remoteSigner is the connection to the signer service holding the validator keys, nil if the keys are local
+	remoteSigner *remotesigner.Client
+
 	// validatorsCache represents cache of validators snapshots
 	validatorsCache *validatorsSnapshotCache
 
@@ -426,21 +432,35 @@ func ForkManagerFactory(forks *chain.Forks) error {
 func (p *Polybft) Initialize() error {
 	p.logger.Info("initializing polybft...")
 
-	// read account
-	account, err := wallet.NewAccountFromSecret(p.config.SecretsManager)
-	if err != nil {
-		return fmt.Errorf("failed to read account data. Error: %w", err)
-	}
+	// set key, the keys of the remote signer are used instead of the ones of the secrets manager if configured
+	if p.config.RemoteSigner.Enabled() {
+		remoteSigner, err := remotesigner.NewClient(p.config.RemoteSigner)
+		if err != nil {
+			return fmt.Errorf("failed to connect to the remote signer. Error: %w", err)
+		}
+
+		p.remoteSigner = remoteSigner
+		p.key = wallet.NewRemoteKey(remoteSigner)
+
+		p.logger.Info("using the remote signer", "addr", p.config.RemoteSigner.Addr, "signer", p.key.String())
+	} else {
+		account, err := wallet.NewAccountFromSecret(p.config.SecretsManager)
+		if err != nil {
+			return fmt.Errorf("failed to read account data. Error: %w", err)
+		}
 
-	// set key
-	p.key = wallet.NewKey(account)
+		p.key = wallet.NewKey(account)
+	}
 
 	// create and set syncer
 	p.syncer = syncer.NewSyncer(
 		p.config.Logger.Named("syncer"),
 		p.config.Network,
 		p.config.Blockchain,
+		p.config.StateStorage,
 		time.Duration(p.config.BlockTime)*3*time.Second,
+		p.config.SyncMode,
+		p.config.TxPool,
 	)
 
 	// set blockchain backend
@@ -450,7 +470,7 @@ func (p *Polybft) Initialize() error {
 	}
 
 	// create bridge and consensus topics
-	if err = p.createTopics(); err != nil {
+	if err := p.createTopics(); err != nil {
 		return fmt.Errorf("cannot create topics: %w", err)
 	}
 
@@ -460,7 +480,7 @@ func (p *Polybft) Initialize() error {
 	// initialize polybft consensus data directory
 	p.dataDir = filepath.Join(p.config.Config.Path, "polybft")
 	// create the data dir if not exists
-	if err = common.CreateDirSafe(p.dataDir, 0750); err != nil {
+	if err := common.CreateDirSafe(p.dataDir, 0750); err != nil {
 		return fmt.Errorf("failed to create data directory. Error: %w", err)
 	}
 
@@ -531,6 +551,11 @@ func (p *Polybft) Start() error {
 		return fmt.Errorf("consensus runtime start failed: %w", err)
 	}
 
+	// in the active/standby mode the node competes for the signing lease with the other nodes sharing the key
+	if p.failoverEnabled() {
+		go p.remoteSigner.RunLease(p.closeCh, p.logger.Named("signing_lease"))
+	}
+
 	// start state DB process
 	go p.state.startStatsReleasing()
 
@@ -540,6 +565,64 @@ func (p *Polybft) Start() error {
 	return nil
 }
 
+// blockTrackerPollInterval returns the poll interval of the block trackers,
+// which is the one of the genesis unless it is overridden
+func (p *Polybft) blockTrackerPollInterval(override time.Duration) time.Duration {
+	if override != 0 {
+		return override
+	}
+
+	return p.consensusConfig.BlockTrackerPollInterval.Duration
+}
+
+// SetBlockTrackerPollInterval overrides the poll interval of the running block trackers,
+// 0 restores the poll interval of the genesis
+func (p *Polybft) SetBlockTrackerPollInterval(override time.Duration) {
+	p.runtime.stateSyncManager.SetBlockTrackerPollInterval(p.blockTrackerPollInterval(override))
+}
+
+// SetProposingPaused pauses or resumes building the proposals, the node keeps validating and voting
+func (p *Polybft) SetProposingPaused(paused bool) {
+	p.runtime.proposingPaused.Store(paused)
+}
+
+// ProposingPaused returns whether building the proposals is paused
+func (p *Polybft) ProposingPaused() bool {
+	return p.runtime.proposingPaused.Load()
+}
+
+// TrackerProgress returns the latest polled block of the root chain and the latest block
+// whose state sync events are tracked, ok is false if the bridge is disabled
+func (p *Polybft) TrackerProgress() (head uint64, synced uint64, ok bool) {
+	return p.runtime.stateSyncManager.TrackerProgress()
+}
+
+// ConsensusStatus returns the view of the consensus and the last step the node took in it,
+// ok is false if the node didn't take part in the consensus yet
+func (p *Polybft) ConsensusStatus() (height uint64, round uint64, state string, ok bool) {
+	status := p.runtime.RoundStatus()
+	if status == nil {
+		return 0, 0, "", false
+	}
+
+	return status.Height, status.Round, status.State, true
+}
+
+// ValidatorStatus returns the address of the node and whether it is in the current validator set
+func (p *Polybft) ValidatorStatus() (types.Address, bool) {
+	return types.Address(p.key.Address()), p.runtime.IsActiveValidator()
+}
+
+// GetValidatorPerformance returns the performance of the validator recorded from the finalized blocks
+func (p *Polybft) GetValidatorPerformance(address types.Address) (*types.ValidatorPerformance, error) {
+	return p.runtime.performanceTracker.getValidatorPerformance(address)
+}
+
+// ListValidatorPerformance returns the performance of all the validators recorded from the finalized blocks
+func (p *Polybft) ListValidatorPerformance() ([]*types.ValidatorPerformance, error) {
+	return p.runtime.performanceTracker.listValidatorPerformance()
+}
+
 // initRuntime creates consensus runtime
 func (p *Polybft) initRuntime() error {
 	runtimeConfig := &runtimeConfig{
@@ -553,6 +636,15 @@ func (p *Polybft) initRuntime() error {
 		bridgeTopic:           p.bridgeTopic,
 		numBlockConfirmations: p.config.NumBlockConfirmations,
 		consensusConfig:       p.config.Config,
+
+		blockTrackerPollInterval: p.blockTrackerPollInterval(p.config.BlockTrackerPollInterval),
+		proposalDeadline:         p.config.ProposalDeadline,
+	}
+
+	if params := p.config.Config.Params; params != nil {
+		runtimeConfig.governance = findStatefulPrecompile(params, governance.Name)
+		runtimeConfig.randomness = findStatefulPrecompile(params, randomness.Name)
+		runtimeConfig.forks = params.Forks
 	}
 
 	runtime, err := newConsensusRuntime(p.logger, runtimeConfig)
@@ -596,8 +688,9 @@ func (p *Polybft) startConsensusProtocol() {
 			case ev := <-eventCh:
 				// The blockchain notification system can eventually deliver
 				// stale block notifications. These should be ignored
-				if ev.Source == "syncer" && ev.NewChain[0].Number >= p.blockchain.CurrentHeader().Number {
-					p.logger.Info("sync block notification received", "block height", ev.NewChain[0].Number,
+				if ev.Source == "syncer" && len(ev.NewChain) > 0 &&
+					ev.Header().Number >= p.blockchain.CurrentHeader().Number {
+					p.logger.Info("sync block notification received", "block height", ev.Header().Number,
 						"current height", p.blockchain.CurrentHeader().Number)
 					syncerBlockCh <- struct{}{}
 				}
@@ -608,8 +701,13 @@ func (p *Polybft) startConsensusProtocol() {
 	var (
 		sequenceCh   <-chan struct{}
 		stopSequence func()
+		leaseCh      <-chan struct{}
 	)
 
+	if p.failoverEnabled() {
+		leaseCh = p.remoteSigner.LeaseChanged()
+	}
+
 	for {
 		latestHeader := p.blockchain.CurrentHeader()
 
@@ -618,7 +716,8 @@ func (p *Polybft) startConsensusProtocol() {
 			p.logger.Error("failed to query current validator set", "block number", latestHeader.Number, "error", err)
 		}
 
-		isValidator := currentValidators.ContainsNodeID(p.key.String())
+		// the standby node keeps syncing, but it takes part in the consensus only once it holds the signing lease
+		isValidator := currentValidators.ContainsNodeID(p.key.String()) && p.holdsSigningLease()
 		p.runtime.setIsActiveValidator(isValidator)
 
 		p.txPool.SetSealing(isValidator) // update tx pool
@@ -643,6 +742,11 @@ func (p *Polybft) startConsensusProtocol() {
 				stopSequence()
 				p.logger.Info("canceled sequence", "sequence", latestHeader.Number+1)
 			}
+		case <-leaseCh:
+			if isValidator {
+				stopSequence()
+				p.logger.Info("canceled sequence, the signing lease is lost", "sequence", latestHeader.Number+1)
+			}
 		case <-sequenceCh:
 		case <-p.closeCh:
 			if isValidator {
@@ -656,6 +760,16 @@ func (p *Polybft) startConsensusProtocol() {
 	}
 }
 
+// failoverEnabled checks if the node runs in the active/standby mode with the other nodes sharing the remote signer
+func (p *Polybft) failoverEnabled() bool {
+	return p.remoteSigner != nil && p.config.RemoteSigner.Failover
+}
+
+// holdsSigningLease checks if the node is allowed to sign, in the active/standby mode only the lease holder is
+func (p *Polybft) holdsSigningLease() bool {
+	return !p.failoverEnabled() || p.remoteSigner.HoldsLease()
+}
+
 func (p *Polybft) waitForNPeers() bool {
 	for {
 		select {
@@ -683,6 +797,10 @@ func (p *Polybft) Close() error {
 	close(p.closeCh)
 	p.runtime.close()
 
+	if p.remoteSigner != nil {
+		return p.remoteSigner.Close()
+	}
+
 	return nil
 }
 
@@ -722,8 +840,18 @@ func (p *Polybft) verifyHeaderImpl(parent, header *types.Header, blockTimeDrift
 	}
 
 	// validate extra data
-	return extra.ValidateFinalizedData(
-		header, parent, parents, p.blockchain.GetChainID(), p, signer.DomainCheckpointManager, p.logger)
+	if err := extra.ValidateFinalizedData(
+		header, parent, parents, p.blockchain.GetChainID(), p, signer.DomainCheckpointManager, p.logger); err != nil {
+		return err
+	}
+
+	// the state root of the header is checked once the block is executed, so the committed one has to match it
+	if commitment := extra.Checkpoint.StateCommitment; commitment != nil && commitment.StateRoot != header.StateRoot {
+		return fmt.Errorf("failed to verify header for block %d. committed state root %s doesn't match %s",
+			header.Number, commitment.StateRoot, header.StateRoot)
+	}
+
+	return nil
 }
 
 func (p *Polybft) GetValidators(blockNumber uint64, parents []*types.Header) (validator.AccountSet, error) {
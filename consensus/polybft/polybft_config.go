@@ -86,6 +86,9 @@ type PolyBFTConfig struct {
 	// ProxyContractsAdmin is the address that will have the privilege to change both the proxy
 	// implementation address and the admin
 	ProxyContractsAdmin types.Address `json:"proxyContractsAdmin,omitempty"`
+
+	// StateCommitment enables the state commitments in the checkpoints of the epoch ending blocks
+	StateCommitment *StateCommitmentConfig `json:"stateCommitment,omitempty"`
 }
 
 // LoadPolyBFTConfig loads chain config from provided path and unmarshals PolyBFTConfig
@@ -142,6 +145,20 @@ type BridgeConfig struct {
 
 	JSONRPCEndpoint         string                   `json:"jsonRPCEndpoint"`
 	EventTrackerStartBlocks map[types.Address]uint64 `json:"eventTrackerStartBlocks"`
+	// EventTrackerNumBlockConfirmations is the number of the confirmations required for the events
+	// of the tracked contract, overriding the number of block confirmations the node is configured with
+	EventTrackerNumBlockConfirmations map[types.Address]uint64 `json:"eventTrackerNumBlockConfirmations,omitempty"`
+}
+
+// GetNumBlockConfirmations returns the number of the confirmations required for the events of the tracked contract,
+// defaultNumBlockConfirmations if the contract doesn't require its own
+func (b *BridgeConfig) GetNumBlockConfirmations(contractAddr types.Address,
+	defaultNumBlockConfirmations uint64) uint64 {
+	if numBlockConfirmations, ok := b.EventTrackerNumBlockConfirmations[contractAddr]; ok {
+		return numBlockConfirmations
+	}
+
+	return defaultNumBlockConfirmations
 }
 
 func (p *PolyBFTConfig) IsBridgeEnabled() bool {
@@ -260,6 +277,12 @@ func ParseRawTokenConfig(rawConfig string) (*TokenConfig, error) {
 	}, nil
 }
 
+// StateCommitmentConfig is the configuration of the state commitments of the epoch ending blocks
+type StateCommitmentConfig struct {
+	// ChallengeWindow is the time the committed state can be challenged for, once the checkpoint is submitted
+	ChallengeWindow common.Duration `json:"challengeWindow"`
+}
+
 type RewardsConfig struct {
 	// TokenAddress is the address of reward token on child chain
 	TokenAddress types.Address
@@ -267,20 +267,29 @@ func (pc *ProposerCalculator) updatePerBlock(blockNumber uint64, dbTx *bolt.Tx)
 		}
 	}
 
-	// if round = 0 then we need one iteration
-	_, err = incrementProposerPriorityNTimes(pc.snapshot, extra.Checkpoint.BlockRound+1)
-	if err != nil {
+	if err = pc.snapshot.UpdatePerBlock(extra.Checkpoint.BlockRound, newValidatorSet); err != nil {
 		return fmt.Errorf("failed to update proposers snapshot for block %d: %w", blockNumber, err)
 	}
 
+	return nil
+}
+
+// UpdatePerBlock prepares the snapshot for the next block, once the block of its height is finalized
+// in the given round. The snapshot switches to the new validator set, unless it is empty
+func (pcs *ProposerSnapshot) UpdatePerBlock(round uint64, newValidatorSet validator.AccountSet) error {
+	// if round = 0 then we need one iteration
+	if _, err := incrementProposerPriorityNTimes(pcs, round+1); err != nil {
+		return err
+	}
+
 	// update to new validator set and center if needed
-	if err = updateValidators(pc.snapshot, newValidatorSet); err != nil {
+	if err := updateValidators(pcs, newValidatorSet); err != nil {
 		return fmt.Errorf("cannot update validators: %w", err)
 	}
 
-	pc.snapshot.Height = blockNumber + 1 // snapshot (validator priorities) is prepared for the next block
-	pc.snapshot.Round = 0
-	pc.snapshot.Proposer = nil
+	pcs.Height++ // snapshot (validator priorities) is prepared for the next block
+	pcs.Round = 0
+	pcs.Proposer = nil
 
 	return nil
 }
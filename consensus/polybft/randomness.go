@@ -0,0 +1,106 @@
+package polybft
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/umbracle/ethgo"
+
+	"github.com/0xPolygon/polygon-edge/bls"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful/randomness"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var _ contractsapi.StateTransactionInput = (*randomnessContributeFn)(nil)
+
+// randomnessContributeFn is the input of the state transaction contributing the VRF output of the proposer
+// to the randomness beacon, it's included in every block once the randomness contract is active
+type randomnessContributeFn struct {
+	Sprint    uint64
+	Validator types.Address
+	Output    []byte
+}
+
+// Sig returns the signature of the contribute function of the randomness contract
+func (r *randomnessContributeFn) Sig() []byte {
+	return randomness.ContributeFunc.ID()
+}
+
+// EncodeAbi encodes the contribution
+func (r *randomnessContributeFn) EncodeAbi() ([]byte, error) {
+	return randomness.ContributeFunc.Encode([]interface{}{
+		new(big.Int).SetUint64(r.Sprint), ethgo.Address(r.Validator), r.Output})
+}
+
+// DecodeAbi decodes the contribution
+func (r *randomnessContributeFn) DecodeAbi(buf []byte) error {
+	if len(buf) < abiMethodIDLength {
+		return fmt.Errorf("invalid randomness contribute input")
+	}
+
+	args, err := randomness.ContributeFunc.Inputs.Decode(buf[abiMethodIDLength:])
+	if err != nil {
+		return err
+	}
+
+	decoded, ok := args.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid randomness contribute input")
+	}
+
+	sprint, ok := decoded["sprint"].(*big.Int)
+	if !ok || !sprint.IsUint64() {
+		return fmt.Errorf("invalid randomness contribute input")
+	}
+
+	validatorAddr, ok := decoded["validator"].(ethgo.Address)
+	if !ok {
+		return fmt.Errorf("invalid randomness contribute input")
+	}
+
+	output, ok := decoded["output"].([]byte)
+	if !ok {
+		return fmt.Errorf("invalid randomness contribute input")
+	}
+
+	r.Sprint = sprint.Uint64()
+	r.Validator = types.Address(validatorAddr)
+	r.Output = output
+
+	return nil
+}
+
+// randomnessSprint returns the sprint the block belongs to, the sprints are counted from the genesis
+func randomnessSprint(blockNumber, sprintSize uint64) uint64 {
+	return (blockNumber - 1) / sprintSize
+}
+
+// vrfMessage returns the message the proposer signs to produce its VRF output for the block.
+// The BLS signature is unique for the key and the message, so the proposer can't pick its output
+func vrfMessage(parentHash types.Hash, blockNumber uint64) []byte {
+	return crypto.Keccak256(parentHash.Bytes(), common.EncodeUint64ToBytes(blockNumber))
+}
+
+// verifyVRFOutput checks that the output is the VRF output of the validator for the message
+func verifyVRFOutput(validators validator.AccountSet, validatorAddr types.Address, message, output []byte) error {
+	metadata := validators.GetValidatorMetadata(validatorAddr)
+	if metadata == nil {
+		return fmt.Errorf("randomness contributor %s is not a validator", validatorAddr)
+	}
+
+	signature, err := bls.UnmarshalSignature(output)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal VRF output: %w", err)
+	}
+
+	if !signature.Verify(metadata.BlsKey, message, signer.DomainRandomness) {
+		return fmt.Errorf("invalid VRF output of %s", validatorAddr)
+	}
+
+	return nil
+}
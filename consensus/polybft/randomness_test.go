@@ -0,0 +1,98 @@
+package polybft
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestRandomnessContributeFn_Decode(t *testing.T) {
+	t.Parallel()
+
+	contribution := &randomnessContributeFn{Sprint: 3, Validator: types.StringToAddress("0x2000"), Output: []byte{1, 2, 3}}
+
+	input, err := contribution.EncodeAbi()
+	require.NoError(t, err)
+
+	decoded, err := decodeStateTransaction(input)
+	require.NoError(t, err)
+	require.Equal(t, contribution, decoded)
+}
+
+func TestRandomnessSprint(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, uint64(0), randomnessSprint(1, 5))
+	require.Equal(t, uint64(0), randomnessSprint(5, 5))
+	require.Equal(t, uint64(1), randomnessSprint(6, 5))
+}
+
+func TestFSM_VerifyStateTransactions_Randomness(t *testing.T) {
+	t.Parallel()
+
+	var (
+		randomnessAddr = types.StringToAddress("0x1002")
+		validators     = validator.NewTestValidatorsWithAliases(t, []string{"A", "B"})
+		outsider       = validator.NewTestValidator(t, "C", 1)
+		parent         = &types.Header{Number: 9, Hash: types.StringToHash("0x99")}
+	)
+
+	contribute := func(v *validator.TestValidator, sprint uint64) *randomnessContributeFn {
+		output, err := v.Key().SignWithDomain(vrfMessage(parent.Hash, 10), signer.DomainRandomness)
+		require.NoError(t, err)
+
+		return &randomnessContributeFn{Sprint: sprint, Validator: v.Address(), Output: output}
+	}
+
+	newFSM := func(address types.Address, contribution *randomnessContributeFn) *fsm {
+		return &fsm{
+			config:                 &PolyBFTConfig{SprintSize: 5},
+			parent:                 parent,
+			validators:             validators.ToValidatorSet(),
+			randomnessAddress:      address,
+			randomnessContribution: contribution,
+			blockProposer:          validators.GetValidator("B").Address(),
+		}
+	}
+
+	txOf := func(contribution *randomnessContributeFn) *types.Transaction {
+		tx, err := newFSM(randomnessAddr, contribution).createRandomnessTx()
+		require.NoError(t, err)
+
+		return tx
+	}
+
+	f := newFSM(randomnessAddr, nil)
+	validTx := txOf(contribute(validators.GetValidator("B"), 1))
+
+	require.NoError(t, f.VerifyStateTransactions([]*types.Transaction{validTx}))
+
+	// every block contributes exactly once
+	require.ErrorIs(t, f.VerifyStateTransactions(nil), errRandomnessTxDoesNotExist)
+	require.ErrorIs(t, f.VerifyStateTransactions([]*types.Transaction{validTx, validTx}), errRandomnessTxSingleExpected)
+	require.ErrorIs(t, newFSM(types.ZeroAddress, nil).VerifyStateTransactions([]*types.Transaction{validTx}),
+		errRandomnessTxNotExpected)
+
+	// the contribution is the VRF output of a validator for the block
+	require.ErrorContains(t, f.VerifyStateTransactions([]*types.Transaction{
+		txOf(contribute(validators.GetValidator("B"), 2))}), "expected sprint 1")
+	require.ErrorContains(t, f.VerifyStateTransactions([]*types.Transaction{
+		txOf(contribute(validators.GetValidator("A"), 1))}), "expected the block proposer")
+
+	outsiderFSM := newFSM(randomnessAddr, nil)
+	outsiderFSM.blockProposer = outsider.Address()
+	require.ErrorContains(t, outsiderFSM.VerifyStateTransactions([]*types.Transaction{
+		txOf(contribute(outsider, 1))}), "is not a validator")
+
+	forged := contribute(validators.GetValidator("A"), 1)
+	forged.Validator = validators.GetValidator("B").Address()
+	require.ErrorContains(t, f.VerifyStateTransactions([]*types.Transaction{txOf(forged)}), "invalid VRF output")
+
+	misrouted := txOf(contribute(validators.GetValidator("B"), 1))
+	misrouted.To = &types.ZeroAddress
+	require.ErrorContains(t, f.VerifyStateTransactions([]*types.Transaction{misrouted}), "instead of")
+}
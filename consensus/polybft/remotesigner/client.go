@@ -0,0 +1,268 @@
+package remotesigner
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/umbracle/ethgo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/0xPolygon/polygon-edge/bls"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/remotesigner/proto"
+	"github.com/0xPolygon/polygon-edge/helper/tlsconfig"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// requestTimeout is the timeout of the requests to the signer
+	requestTimeout = 5 * time.Second
+	// leaseRetryInterval is the interval the lease is acquired again at, if the signer couldn't be reached
+	leaseRetryInterval = time.Second
+)
+
+var errNoSignerAddr = errors.New("the address of the remote signer is not set")
+
+// Config is the connection configuration of the remote signer
+type Config struct {
+	// Addr is the gRPC address of the signer
+	Addr string `json:"addr" yaml:"addr"`
+	// CACertFile is the CA the certificate of the signer is verified by, TLS is used if it is set
+	CACertFile string `json:"ca_cert_file,omitempty" yaml:"ca_cert_file,omitempty"`
+	// CertFile and KeyFile are the client certificate presented to the signer
+	CertFile string `json:"cert_file,omitempty" yaml:"cert_file,omitempty"`
+	KeyFile  string `json:"key_file,omitempty" yaml:"key_file,omitempty"`
+	// Failover runs the node in the active/standby mode: the node takes part in the consensus
+	// only while it holds the signing lease of the signer, which is shared by the active and the standby nodes
+	Failover bool `json:"failover,omitempty" yaml:"failover,omitempty"`
+}
+
+// Enabled checks if the remote signer is configured
+func (c *Config) Enabled() bool {
+	return c != nil && c.Addr != ""
+}
+
+// Client signs the consensus messages with the validator keys held by the remote signer
+type Client struct {
+	conn   *grpc.ClientConn
+	signer proto.RemoteSignerClient
+
+	address      ethgo.Address
+	blsPublicKey *bls.PublicKey
+
+	// holder is the unique identifier the node acquires the signing lease with
+	holder string
+	// leaseExpiry is the local time the lease held by the node expires at, in unix nanoseconds
+	leaseExpiry atomic.Int64
+	// leaseCh is notified whenever the node acquires or loses the lease
+	leaseCh chan struct{}
+}
+
+// NewClient connects to the remote signer and reads the public keys of the validator
+func NewClient(config *Config) (*Client, error) {
+	if !config.Enabled() {
+		return nil, errNoSignerAddr
+	}
+
+	creds := insecure.NewCredentials()
+
+	if config.CACertFile != "" || config.CertFile != "" {
+		tlsConfig, err := tlsconfig.ClientTLSConfig(config.CACertFile, config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	conn, err := grpc.Dial(config.Addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to the remote signer: %w", err)
+	}
+
+	holder := make([]byte, 16)
+	if _, err := rand.Read(holder); err != nil {
+		_ = conn.Close()
+
+		return nil, err
+	}
+
+	client := &Client{
+		conn:    conn,
+		signer:  proto.NewRemoteSignerClient(conn),
+		holder:  hex.EncodeToString(holder),
+		leaseCh: make(chan struct{}, 1),
+	}
+
+	if err := client.readPublicKeys(); err != nil {
+		_ = conn.Close()
+
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (c *Client) readPublicKeys() error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	resp, err := c.signer.GetPublicKeys(ctx, &emptypb.Empty{})
+	if err != nil {
+		return fmt.Errorf("unable to read the public keys of the remote signer: %w", err)
+	}
+
+	if len(resp.Address) != types.AddressLength {
+		return fmt.Errorf("invalid address of the remote signer: %x", resp.Address)
+	}
+
+	if c.blsPublicKey, err = bls.UnmarshalPublicKey(resp.BlsPublicKey); err != nil {
+		return fmt.Errorf("invalid BLS public key of the remote signer: %w", err)
+	}
+
+	c.address = ethgo.BytesToAddress(resp.Address)
+
+	return nil
+}
+
+// Address returns the address of the validator
+func (c *Client) Address() ethgo.Address {
+	return c.address
+}
+
+// BLSPublicKey returns the BLS public key of the validator
+func (c *Client) BLSPublicKey() *bls.PublicKey {
+	return c.blsPublicKey
+}
+
+// SignIBFTMessage signs the marshaled IBFT message
+func (c *Client) SignIBFTMessage(msg []byte) ([]byte, error) {
+	return c.call(func(ctx context.Context) (*proto.SignatureResponse, error) {
+		return c.signer.SignIBFTMessage(ctx, &proto.SignIBFTMessageRequest{Message: msg, LeaseHolder: c.holder})
+	})
+}
+
+// SignCommittedSeal signs the committed seal of the block header at the view
+func (c *Client) SignCommittedSeal(hash []byte, height, round uint64) ([]byte, error) {
+	return c.call(func(ctx context.Context) (*proto.SignatureResponse, error) {
+		return c.signer.SignHeader(ctx,
+			&proto.SignHeaderRequest{Height: height, Round: round, Hash: hash, LeaseHolder: c.holder})
+	})
+}
+
+// SignBLS signs the digest with the BLS key in the domain
+func (c *Client) SignBLS(digest, domain []byte) ([]byte, error) {
+	return c.call(func(ctx context.Context) (*proto.SignatureResponse, error) {
+		return c.signer.SignBLS(ctx, &proto.SignBLSRequest{Digest: digest, Domain: domain, LeaseHolder: c.holder})
+	})
+}
+
+// HoldsLease checks if the node holds the signing lease, as of its last renewal
+func (c *Client) HoldsLease() bool {
+	return time.Now().UnixNano() < c.leaseExpiry.Load()
+}
+
+// LeaseChanged returns the channel notified whenever the node acquires or loses the signing lease
+func (c *Client) LeaseChanged() <-chan struct{} {
+	return c.leaseCh
+}
+
+// RunLease keeps acquiring the signing lease until the close channel is closed.
+// The lease is renewed three times within its duration, so a single failed renewal doesn't lose it
+func (c *Client) RunLease(closeCh <-chan struct{}, logger hclog.Logger) {
+	held := false
+
+	for {
+		interval := leaseRetryInterval
+
+		if duration, err := c.acquireLease(); err != nil {
+			logger.Warn("failed to acquire the signing lease", "error", err)
+		} else {
+			interval = duration / 3
+		}
+
+		if holds := c.HoldsLease(); holds != held {
+			held = holds
+			logger.Info("signing lease changed", "holder", c.holder, "active", held)
+
+			select {
+			case c.leaseCh <- struct{}{}:
+			default:
+			}
+		}
+
+		select {
+		case <-closeCh:
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// acquireLease acquires or renews the signing lease and returns its duration
+func (c *Client) acquireLease() (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	// the expiry is counted from the request, so the node never considers the lease held longer than the signer does
+	requestedAt := time.Now()
+
+	resp, err := c.signer.AcquireLease(ctx, &proto.LeaseRequest{Holder: c.holder})
+	if err != nil {
+		return 0, fmt.Errorf("remote signer: %w", err)
+	}
+
+	duration := time.Duration(resp.Duration) * time.Millisecond
+	if duration <= 0 {
+		return 0, fmt.Errorf("remote signer: invalid lease duration %d", resp.Duration)
+	}
+
+	if resp.Holder == c.holder {
+		c.leaseExpiry.Store(requestedAt.Add(duration).UnixNano())
+	} else {
+		c.leaseExpiry.Store(0)
+	}
+
+	return duration, nil
+}
+
+// releaseLease releases the signing lease, so the standby node takes over without waiting for the expiry
+func (c *Client) releaseLease() {
+	c.leaseExpiry.Store(0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	_, _ = c.signer.ReleaseLease(ctx, &proto.LeaseRequest{Holder: c.holder})
+}
+
+// Close releases the signing lease, if the node holds it, and closes the connection to the remote signer
+func (c *Client) Close() error {
+	if c.HoldsLease() {
+		c.releaseLease()
+	}
+
+	return c.conn.Close()
+}
+
+func (c *Client) call(
+	request func(ctx context.Context) (*proto.SignatureResponse, error),
+) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	resp, err := request(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: %w", err)
+	}
+
+	return resp.Signature, nil
+}
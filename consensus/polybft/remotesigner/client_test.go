@@ -0,0 +1,193 @@
+package remotesigner
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	ibftProto "github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/0xPolygon/polygon-edge/bls"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/remotesigner/proto"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func newTestSigner(t *testing.T) (*wallet.Account, *Client) {
+	t.Helper()
+
+	account, addr := newTestSignerServer(t, nil)
+
+	return account, newTestClient(t, addr)
+}
+
+func newTestSignerServer(t *testing.T, lease *Lease) (*wallet.Account, string) {
+	t.Helper()
+
+	account, err := wallet.GenerateAccount()
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	grpcServer := grpc.NewServer()
+	proto.RegisterRemoteSignerServer(grpcServer, NewServer(account, NewSigningState(), lease))
+
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+
+	t.Cleanup(grpcServer.Stop)
+
+	return account, listener.Addr().String()
+}
+
+func newTestClient(t *testing.T, addr string) *Client {
+	t.Helper()
+
+	client, err := NewClient(&Config{Addr: addr, Failover: true})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	return client
+}
+
+func TestClient_Sign(t *testing.T) {
+	t.Parallel()
+
+	account, client := newTestSigner(t)
+	key := wallet.NewRemoteKey(client)
+
+	require.Equal(t, account.Address(), types.Address(key.Address()))
+	require.Equal(t, account.Bls.PublicKey().Marshal(), client.BLSPublicKey().Marshal())
+
+	view := &ibftProto.View{Height: 5, Round: 1}
+	proposalHash := types.StringToHash("0x1").Bytes()
+
+	newPrepare := func(hash []byte) *ibftProto.Message {
+		return &ibftProto.Message{
+			View: view,
+			From: key.Address().Bytes(),
+			Type: ibftProto.MessageType_PREPARE,
+			Payload: &ibftProto.Message_PrepareData{
+				PrepareData: &ibftProto.PrepareMessage{ProposalHash: hash},
+			},
+		}
+	}
+
+	// the IBFT message is signed by the validator
+	msg, err := key.SignIBFTMessage(newPrepare(proposalHash))
+	require.NoError(t, err)
+
+	unsigned, ok := protobuf.Clone(msg).(*ibftProto.Message)
+	require.True(t, ok)
+
+	unsigned.Signature = nil
+	raw, err := protobuf.Marshal(unsigned)
+	require.NoError(t, err)
+
+	from, err := wallet.RecoverAddressFromSignature(msg.Signature, raw)
+	require.NoError(t, err)
+	require.Equal(t, account.Address(), from)
+
+	// the conflicting message of the same view is refused
+	_, err = key.SignIBFTMessage(newPrepare(types.StringToHash("0x2").Bytes()))
+	require.ErrorContains(t, err, "double sign protection")
+
+	// the committed seal verifies with the BLS key of the validator
+	seal, err := key.SignCommittedSeal(proposalHash, view)
+	require.NoError(t, err)
+
+	signature, err := bls.UnmarshalSignature(seal)
+	require.NoError(t, err)
+	require.True(t, signature.Verify(client.BLSPublicKey(), proposalHash, signer.DomainCheckpointManager))
+
+	_, err = key.SignCommittedSeal(types.StringToHash("0x2").Bytes(), view)
+	require.ErrorContains(t, err, "double sign protection")
+
+	// the committed seals can't bypass the protection
+	_, err = key.SignWithDomain(types.StringToHash("0x2").Bytes(), signer.DomainCheckpointManager)
+	require.Error(t, err)
+
+	_, err = key.SignWithDomain(proposalHash, signer.DomainStateReceiver)
+	require.NoError(t, err)
+
+	// and neither can the ECDSA digests
+	_, err = wallet.NewEcdsaSigner(key).Sign(proposalHash)
+	require.ErrorIs(t, err, wallet.ErrRemoteDigestSigning)
+}
+
+func TestServer_SignIBFTMessage_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, client := newTestSigner(t)
+
+	// the message of another validator
+	raw, err := protobuf.Marshal(&ibftProto.Message{
+		View: &ibftProto.View{Height: 1},
+		From: types.StringToAddress("0x1").Bytes(),
+		Type: ibftProto.MessageType_PREPARE,
+		Payload: &ibftProto.Message_PrepareData{
+			PrepareData: &ibftProto.PrepareMessage{ProposalHash: types.ZeroHash.Bytes()},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = client.SignIBFTMessage(raw)
+	require.ErrorContains(t, err, "not from the validator")
+
+	_, err = client.SignIBFTMessage([]byte{0xff})
+	require.ErrorContains(t, err, "invalid IBFT message")
+}
+
+func TestClient_Failover(t *testing.T) {
+	t.Parallel()
+
+	_, addr := newTestSignerServer(t, NewLease(time.Minute))
+	active, standby := newTestClient(t, addr), newTestClient(t, addr)
+
+	_, err := active.acquireLease()
+	require.NoError(t, err)
+	require.True(t, active.HoldsLease())
+
+	_, err = standby.acquireLease()
+	require.NoError(t, err)
+	require.False(t, standby.HoldsLease())
+
+	view := &ibftProto.View{Height: 3}
+	proposalHash := types.StringToHash("0x1").Bytes()
+
+	seal, err := wallet.NewRemoteKey(active).SignCommittedSeal(proposalHash, view)
+	require.NoError(t, err)
+
+	// the standby node can't sign while the active one holds the lease
+	_, err = wallet.NewRemoteKey(standby).SignCommittedSeal(proposalHash, view)
+	require.ErrorContains(t, err, ErrNotLeaseHolder.Error())
+
+	// once the lease is released, the standby node takes over
+	active.releaseLease()
+	require.False(t, active.HoldsLease())
+
+	_, err = standby.acquireLease()
+	require.NoError(t, err)
+	require.True(t, standby.HoldsLease())
+
+	// it may sign the same seal again, but not a conflicting one
+	standbySeal, err := wallet.NewRemoteKey(standby).SignCommittedSeal(proposalHash, view)
+	require.NoError(t, err)
+	require.Equal(t, seal, standbySeal)
+
+	_, err = wallet.NewRemoteKey(standby).SignCommittedSeal(types.StringToHash("0x2").Bytes(), view)
+	require.ErrorContains(t, err, "double sign protection")
+
+	// and the former leader is refused
+	_, err = wallet.NewRemoteKey(active).SignCommittedSeal(proposalHash, &ibftProto.View{Height: 4})
+	require.ErrorContains(t, err, ErrNotLeaseHolder.Error())
+}
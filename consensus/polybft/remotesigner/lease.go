@@ -0,0 +1,83 @@
+package remotesigner
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/remotesigner/proto"
+)
+
+var (
+	// ErrNotLeaseHolder is returned for the signature requested by the node not holding the signing lease
+	ErrNotLeaseHolder = errors.New("the node doesn't hold the signing lease")
+
+	errEmptyLeaseHolder = errors.New("empty lease holder")
+)
+
+// Lease is the signing lease of the active/standby mode. The nodes sharing the key of the signer compete for it,
+// and only the holder is allowed to sign, so a standby node taking over can't sign alongside the former leader.
+// The holder has to renew the lease before it expires, otherwise any other node may acquire it
+type Lease struct {
+	lock sync.Mutex
+
+	duration  time.Duration
+	holder    string
+	expiresAt time.Time
+
+	// now returns the current time, replaced in the tests
+	now func() time.Time
+}
+
+// NewLease returns the free lease granted for the duration
+func NewLease(duration time.Duration) *Lease {
+	return &Lease{duration: duration, now: time.Now}
+}
+
+// Acquire grants or renews the lease for the holder if it's free, expired or already held by it,
+// and returns the current holder of the lease and its expiry
+func (l *Lease) Acquire(holder string) (*proto.LeaseResponse, error) {
+	if holder == "" {
+		return nil, errEmptyLeaseHolder
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+
+	if l.holder == "" || l.holder == holder || !now.Before(l.expiresAt) {
+		l.holder = holder
+		l.expiresAt = now.Add(l.duration)
+	}
+
+	return &proto.LeaseResponse{
+		Holder:    l.holder,
+		ExpiresAt: l.expiresAt.UnixMilli(),
+		Duration:  l.duration.Milliseconds(),
+	}, nil
+}
+
+// Release frees the lease if it's held by the holder, so the standby node doesn't have to wait for the expiry
+func (l *Lease) Release(holder string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.holder == holder {
+		l.holder = ""
+		l.expiresAt = time.Time{}
+	}
+}
+
+// Check returns an error if the holder doesn't hold the lease or if the lease has expired
+func (l *Lease) Check(holder string) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if holder == "" || l.holder != holder || !l.now().Before(l.expiresAt) {
+		return fmt.Errorf("%w: %q", ErrNotLeaseHolder, holder)
+	}
+
+	return nil
+}
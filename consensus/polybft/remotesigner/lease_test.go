@@ -0,0 +1,59 @@
+package remotesigner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLease(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	lease := NewLease(10 * time.Second)
+	lease.now = func() time.Time { return now }
+
+	_, err := lease.Acquire("")
+	require.ErrorIs(t, err, errEmptyLeaseHolder)
+
+	// the free lease is granted
+	resp, err := lease.Acquire("A")
+	require.NoError(t, err)
+	require.Equal(t, "A", resp.Holder)
+	require.Equal(t, now.Add(10*time.Second).UnixMilli(), resp.ExpiresAt)
+	require.Equal(t, int64(10000), resp.Duration)
+	require.NoError(t, lease.Check("A"))
+
+	// the held lease isn't granted to another node
+	resp, err = lease.Acquire("B")
+	require.NoError(t, err)
+	require.Equal(t, "A", resp.Holder)
+	require.ErrorIs(t, lease.Check("B"), ErrNotLeaseHolder)
+	require.ErrorIs(t, lease.Check(""), ErrNotLeaseHolder)
+
+	// the holder renews it
+	now = now.Add(5 * time.Second)
+	resp, err = lease.Acquire("A")
+	require.NoError(t, err)
+	require.Equal(t, now.Add(10*time.Second).UnixMilli(), resp.ExpiresAt)
+
+	// the expired lease can't be used anymore, and it's granted to another node
+	now = now.Add(10 * time.Second)
+	require.ErrorIs(t, lease.Check("A"), ErrNotLeaseHolder)
+
+	resp, err = lease.Acquire("B")
+	require.NoError(t, err)
+	require.Equal(t, "B", resp.Holder)
+
+	// only the holder releases it
+	lease.Release("A")
+	require.NoError(t, lease.Check("B"))
+
+	lease.Release("B")
+	require.ErrorIs(t, lease.Check("B"), ErrNotLeaseHolder)
+
+	resp, err = lease.Acquire("A")
+	require.NoError(t, err)
+	require.Equal(t, "A", resp.Holder)
+}
@@ -0,0 +1,197 @@
+package remotesigner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	ibftProto "github.com/0xPolygon/go-ibft/messages/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/remotesigner/proto"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Server is the signer service holding the validator keys of the account.
+// It is the reference implementation of the protocol, the HSM-backed signers implement the same service
+type Server struct {
+	proto.UnimplementedRemoteSignerServer
+
+	account *wallet.Account
+	state   *SigningState
+	// lease is the signing lease of the active/standby mode, nil if every node is allowed to sign
+	lease *Lease
+}
+
+// NewServer returns the signer service of the account with the double-sign protection state,
+// if the lease is set only the node holding it is allowed to sign
+func NewServer(account *wallet.Account, state *SigningState, lease *Lease) *Server {
+	return &Server{account: account, state: state, lease: lease}
+}
+
+// GetPublicKeys returns the address and the BLS public key of the validator
+func (s *Server) GetPublicKeys(context.Context, *emptypb.Empty) (*proto.PublicKeysResponse, error) {
+	return &proto.PublicKeysResponse{
+		Address:      s.account.Address().Bytes(),
+		BlsPublicKey: s.account.Bls.PublicKey().Marshal(),
+	}, nil
+}
+
+// GetSigningState returns the last signed view of every signing step
+func (s *Server) GetSigningState(context.Context, *emptypb.Empty) (*proto.SigningState, error) {
+	return &proto.SigningState{Views: s.state.Views()}, nil
+}
+
+// AcquireLease acquires or renews the signing lease for the holder
+func (s *Server) AcquireLease(_ context.Context, req *proto.LeaseRequest) (*proto.LeaseResponse, error) {
+	if s.lease == nil {
+		return nil, status.Error(codes.FailedPrecondition, "the signer doesn't grant the signing leases")
+	}
+
+	resp, err := s.lease.Acquire(req.Holder)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return resp, nil
+}
+
+// ReleaseLease releases the signing lease held by the holder
+func (s *Server) ReleaseLease(_ context.Context, req *proto.LeaseRequest) (*emptypb.Empty, error) {
+	if s.lease != nil {
+		s.lease.Release(req.Holder)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// SignHeader signs the committed seal of the block header
+func (s *Server) SignHeader(_ context.Context, req *proto.SignHeaderRequest) (*proto.SignatureResponse, error) {
+	if err := s.checkLease(req.LeaseHolder); err != nil {
+		return nil, err
+	}
+
+	if len(req.Hash) != types.HashLength {
+		return nil, status.Error(codes.InvalidArgument, "invalid header hash")
+	}
+
+	return s.sign(proto.SigningStep_HEADER, req.Height, req.Round, req.Hash, func() ([]byte, error) {
+		return s.signBLS(req.Hash, signer.DomainCheckpointManager)
+	})
+}
+
+// SignIBFTMessage signs the IBFT consensus message of the validator
+func (s *Server) SignIBFTMessage(
+	_ context.Context,
+	req *proto.SignIBFTMessageRequest,
+) (*proto.SignatureResponse, error) {
+	if err := s.checkLease(req.LeaseHolder); err != nil {
+		return nil, err
+	}
+
+	var msg ibftProto.Message
+
+	if err := protobuf.Unmarshal(req.Message, &msg); err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid IBFT message: %v", err))
+	}
+
+	if !bytes.Equal(msg.From, s.account.Address().Bytes()) {
+		return nil, status.Error(codes.InvalidArgument, "the IBFT message is not from the validator")
+	}
+
+	if msg.View == nil {
+		return nil, status.Error(codes.InvalidArgument, "the IBFT message has no view")
+	}
+
+	var hash []byte
+
+	switch msg.Type {
+	case ibftProto.MessageType_PREPREPARE:
+		hash = msg.GetPreprepareData().GetProposalHash()
+	case ibftProto.MessageType_PREPARE:
+		hash = msg.GetPrepareData().GetProposalHash()
+	case ibftProto.MessageType_COMMIT:
+		hash = msg.GetCommitData().GetProposalHash()
+	case ibftProto.MessageType_ROUND_CHANGE:
+		// the round change carries no proposal hash, the same round change may only be signed again
+		hash = crypto.Keccak256(req.Message)
+	default:
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("unknown IBFT message type %d", msg.Type))
+	}
+
+	if len(hash) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "the IBFT message has no proposal hash")
+	}
+
+	step := proto.SigningStep(msg.Type)
+
+	return s.sign(step, msg.View.Height, msg.View.Round, hash, func() ([]byte, error) {
+		return s.account.Ecdsa.Sign(crypto.Keccak256(req.Message))
+	})
+}
+
+// SignBLS signs the digest with the BLS key in the domain, other than the domain of the committed seals
+func (s *Server) SignBLS(_ context.Context, req *proto.SignBLSRequest) (*proto.SignatureResponse, error) {
+	if err := s.checkLease(req.LeaseHolder); err != nil {
+		return nil, err
+	}
+
+	// the committed seals are signed only through SignHeader, which protects them from double signing
+	if bytes.Equal(req.Domain, signer.DomainCheckpointManager) {
+		return nil, status.Error(codes.PermissionDenied, "the committed seals are signed with SignHeader")
+	}
+
+	signature, err := s.signBLS(req.Digest, req.Domain)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &proto.SignatureResponse{Signature: signature}, nil
+}
+
+// checkLease checks the node holds the signing lease, if the signer grants the leases
+func (s *Server) checkLease(holder string) error {
+	if s.lease == nil {
+		return nil
+	}
+
+	if err := s.lease.Check(holder); err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	return nil
+}
+
+func (s *Server) signBLS(digest, domain []byte) ([]byte, error) {
+	signature, err := s.account.Bls.Sign(digest, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return signature.Marshal()
+}
+
+func (s *Server) sign(
+	step proto.SigningStep,
+	height, round uint64,
+	hash []byte,
+	sign func() ([]byte, error),
+) (*proto.SignatureResponse, error) {
+	signature, err := s.state.Sign(step, height, round, hash, sign)
+	if err != nil {
+		if errors.Is(err, ErrDoubleSign) || errors.Is(err, ErrOldView) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &proto.SignatureResponse{Signature: signature}, nil
+}
@@ -0,0 +1,153 @@
+package remotesigner
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/remotesigner/proto"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+)
+
+var (
+	// ErrDoubleSign is returned for the message conflicting with the already signed one
+	ErrDoubleSign = errors.New("double sign protection: the view is already signed with another payload")
+	// ErrOldView is returned for the message of the view older than the last signed one
+	ErrOldView = errors.New("double sign protection: the view is older than the last signed one")
+)
+
+// SigningState is the double-sign protection state of the signer. It keeps the last signed view
+// of every signing step, and allows signing only the views after it, or the same payload again.
+// The state is persisted before the signature is produced, so the protection survives the restarts
+type SigningState struct {
+	lock  sync.Mutex
+	path  string
+	views map[proto.SigningStep]*proto.SignedView
+}
+
+// signedViewJSON is the persisted form of the signed view
+type signedViewJSON struct {
+	Height uint64 `json:"height"`
+	Round  uint64 `json:"round"`
+	Hash   []byte `json:"hash"`
+}
+
+// NewSigningState returns the empty in-memory signing state
+func NewSigningState() *SigningState {
+	return &SigningState{views: make(map[proto.SigningStep]*proto.SignedView)}
+}
+
+// LoadSigningState loads the signing state persisted in the file, the file is created on the first signature
+func LoadSigningState(path string) (*SigningState, error) {
+	state := NewSigningState()
+	state.path = path
+
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to read the signing state: %w", err)
+	}
+
+	persisted := make(map[string]signedViewJSON)
+	if err := json.Unmarshal(raw, &persisted); err != nil {
+		return nil, fmt.Errorf("unable to decode the signing state: %w", err)
+	}
+
+	for name, view := range persisted {
+		step, ok := proto.SigningStep_value[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown signing step %s in the signing state", name)
+		}
+
+		state.views[proto.SigningStep(step)] = &proto.SignedView{
+			Step:   proto.SigningStep(step),
+			Height: view.Height,
+			Round:  view.Round,
+			Hash:   view.Hash,
+		}
+	}
+
+	return state, nil
+}
+
+// Sign checks the view can be signed at the step, records it and calls the sign function
+func (s *SigningState) Sign(
+	step proto.SigningStep,
+	height, round uint64,
+	hash []byte,
+	sign func() ([]byte, error),
+) ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if last, ok := s.views[step]; ok {
+		switch {
+		case height < last.Height || (height == last.Height && round < last.Round):
+			return nil, fmt.Errorf("%w: %s at %d/%d, last signed %d/%d",
+				ErrOldView, step, height, round, last.Height, last.Round)
+		case height == last.Height && round == last.Round && !bytes.Equal(hash, last.Hash):
+			return nil, fmt.Errorf("%w: %s at %d/%d", ErrDoubleSign, step, height, round)
+		}
+	}
+
+	previous := s.views[step]
+	s.views[step] = &proto.SignedView{Step: step, Height: height, Round: round, Hash: hash}
+
+	if err := s.persist(); err != nil {
+		s.views[step] = previous
+
+		return nil, err
+	}
+
+	return sign()
+}
+
+// Views returns the last signed views
+func (s *SigningState) Views() []*proto.SignedView {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	views := make([]*proto.SignedView, 0, len(s.views))
+
+	for step := proto.SigningStep_PREPREPARE; step <= proto.SigningStep_HEADER; step++ {
+		if view, ok := s.views[step]; ok {
+			views = append(views, view)
+		}
+	}
+
+	return views
+}
+
+// persist writes the state to the file, if the state is persistent
+func (s *SigningState) persist() error {
+	if s.path == "" {
+		return nil
+	}
+
+	persisted := make(map[string]signedViewJSON, len(s.views))
+	for step, view := range s.views {
+		persisted[step.String()] = signedViewJSON{Height: view.Height, Round: view.Round, Hash: view.Hash}
+	}
+
+	raw, err := json.Marshal(persisted)
+	if err != nil {
+		return err
+	}
+
+	// the state is written to a temporary file first, so a crash never leaves it truncated
+	tmp := s.path + ".tmp"
+
+	if err := common.SaveFileSafe(tmp, raw, 0600); err != nil {
+		return fmt.Errorf("unable to persist the signing state: %w", err)
+	}
+
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("unable to persist the signing state: %w", err)
+	}
+
+	return nil
+}
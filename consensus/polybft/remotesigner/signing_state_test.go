@@ -0,0 +1,61 @@
+package remotesigner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/remotesigner/proto"
+)
+
+func TestSigningState_Sign(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	state, err := LoadSigningState(path)
+	require.NoError(t, err)
+
+	signed := 0
+	sign := func(step proto.SigningStep, height, round uint64, hash string) error {
+		_, err := state.Sign(step, height, round, []byte(hash), func() ([]byte, error) {
+			signed++
+
+			return []byte("signature"), nil
+		})
+
+		return err
+	}
+
+	require.NoError(t, sign(proto.SigningStep_PREPARE, 10, 0, "a"))
+
+	// the same payload may be signed again, a conflicting one may not
+	require.NoError(t, sign(proto.SigningStep_PREPARE, 10, 0, "a"))
+	require.ErrorIs(t, sign(proto.SigningStep_PREPARE, 10, 0, "b"), ErrDoubleSign)
+
+	// the steps are protected independently
+	require.NoError(t, sign(proto.SigningStep_COMMIT, 10, 0, "a"))
+
+	// the next rounds and heights may be signed, the older views may not
+	require.NoError(t, sign(proto.SigningStep_PREPARE, 10, 1, "b"))
+	require.ErrorIs(t, sign(proto.SigningStep_PREPARE, 10, 0, "a"), ErrOldView)
+	require.NoError(t, sign(proto.SigningStep_PREPARE, 11, 0, "c"))
+	require.ErrorIs(t, sign(proto.SigningStep_PREPARE, 10, 2, "c"), ErrOldView)
+
+	require.Equal(t, 5, signed)
+
+	// the protection survives the restart
+	state, err = LoadSigningState(path)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, sign(proto.SigningStep_PREPARE, 11, 0, "d"), ErrDoubleSign)
+	require.ErrorIs(t, sign(proto.SigningStep_COMMIT, 10, 0, "b"), ErrDoubleSign)
+	require.NoError(t, sign(proto.SigningStep_HEADER, 1, 0, "a"))
+
+	views := state.Views()
+	require.Len(t, views, 3)
+	require.Equal(t, proto.SigningStep_PREPARE, views[0].Step)
+	require.Equal(t, uint64(11), views[0].Height)
+	require.Equal(t, proto.SigningStep_HEADER, views[2].Step)
+}
@@ -21,6 +21,7 @@ const (
 	DomainCheckpointManagerString = "DOMAIN_CHECKPOINT_MANAGER"
 	DomainCommonSigningString     = "DOMAIN_COMMON_SIGNING"
 	DomainStateReceiverString     = "DOMAIN_STATE_RECEIVER"
+	DomainRandomnessString        = "DOMAIN_RANDOMNESS"
 )
 
 var (
@@ -32,6 +33,9 @@ var (
 
 	DomainCommonSigning = crypto.Keccak256([]byte(DomainCommonSigningString))
 	DomainStateReceiver = crypto.Keccak256([]byte(DomainStateReceiverString))
+
+	// domain used to map hash to G1 used by the VRF outputs contributed to the randomness beacon
+	DomainRandomness = crypto.Keccak256([]byte(DomainRandomnessString))
 )
 
 // MakeKOSKSignature creates KOSK signature which prevents rogue attack
@@ -0,0 +1,107 @@
+package simulation
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// VirtualClock is the clock of the simulation, whose time only moves when it is advanced.
+// The functions scheduled for the same time run in the order they were scheduled in
+type VirtualClock struct {
+	lock   sync.Mutex
+	now    time.Time
+	seq    uint64
+	timers timerQueue
+}
+
+// NewVirtualClock creates the virtual clock starting at the given time
+func NewVirtualClock(start time.Time) *VirtualClock {
+	return &VirtualClock{now: start}
+}
+
+// Now returns the current time of the clock
+func (c *VirtualClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.now
+}
+
+// AfterFunc schedules the function to run once the clock is advanced by the given duration
+func (c *VirtualClock) AfterFunc(d time.Duration, f func()) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.seq++
+	heap.Push(&c.timers, &timer{at: c.now.Add(d), seq: c.seq, f: f})
+}
+
+// Advance moves the clock forward by the given duration, running the functions due until then
+// at their scheduled times. The functions scheduled by them run as well, if they are due
+func (c *VirtualClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	target := c.now.Add(d)
+	c.lock.Unlock()
+
+	for {
+		c.lock.Lock()
+
+		if c.timers.Len() == 0 || c.timers[0].at.After(target) {
+			c.now = target
+			c.lock.Unlock()
+
+			return
+		}
+
+		next, _ := heap.Pop(&c.timers).(*timer)
+		c.now = next.at
+		c.lock.Unlock()
+
+		next.f()
+	}
+}
+
+// Pending returns the number of the scheduled functions which didn't run yet
+func (c *VirtualClock) Pending() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.timers.Len()
+}
+
+type timer struct {
+	at  time.Time
+	seq uint64
+	f   func()
+}
+
+// timerQueue is the min heap of the timers, ordered by their time and then by their scheduling order
+type timerQueue []*timer
+
+func (q timerQueue) Len() int { return len(q) }
+
+func (q timerQueue) Less(i, j int) bool {
+	if q[i].at.Equal(q[j].at) {
+		return q[i].seq < q[j].seq
+	}
+
+	return q[i].at.Before(q[j].at)
+}
+
+func (q timerQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *timerQueue) Push(x interface{}) {
+	t, _ := x.(*timer)
+	*q = append(*q, t)
+}
+
+func (q *timerQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	t := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+
+	return t
+}
@@ -0,0 +1,39 @@
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVirtualClock_Advance(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(1000, 0)
+	clock := NewVirtualClock(start)
+
+	var order []string
+
+	clock.AfterFunc(2*time.Second, func() { order = append(order, "b") })
+	clock.AfterFunc(time.Second, func() {
+		order = append(order, "a")
+		require.Equal(t, start.Add(time.Second), clock.Now())
+
+		// scheduled while advancing and due before the target time
+		clock.AfterFunc(500*time.Millisecond, func() { order = append(order, "a2") })
+	})
+	clock.AfterFunc(2*time.Second, func() { order = append(order, "c") })
+	clock.AfterFunc(5*time.Second, func() { order = append(order, "d") })
+
+	clock.Advance(3 * time.Second)
+
+	require.Equal(t, []string{"a", "a2", "b", "c"}, order)
+	require.Equal(t, start.Add(3*time.Second), clock.Now())
+	require.Equal(t, 1, clock.Pending())
+
+	clock.Advance(2 * time.Second)
+
+	require.Equal(t, []string{"a", "a2", "b", "c", "d"}, order)
+	require.Equal(t, 0, clock.Pending())
+}
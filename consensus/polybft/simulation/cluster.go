@@ -0,0 +1,365 @@
+// Package simulation runs the in-process polybft validators, communicating over the simulated network
+// with the configurable latency, partitions and message drops. The network delivers the messages on the virtual
+// clock, while the round timeouts of the consensus still run on the real time, as go-ibft doesn't allow replacing them
+package simulation
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	ethgoWallet "github.com/umbracle/ethgo/wallet"
+
+	"github.com/0xPolygon/polygon-edge/bls"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	defaultEpochSize = 10
+	defaultLatency   = 50 * time.Millisecond
+	defaultTick      = 10 * time.Millisecond
+	defaultChainID   = 100
+)
+
+var errTimeout = errors.New("timeout")
+
+// Config is the configuration of the simulated cluster
+type Config struct {
+	// Validators is the number of the nodes of the cluster
+	Validators int
+	// EpochSize is the number of the blocks of the epoch
+	EpochSize uint64
+	// ValidatorSets maps the epochs to the indexes of the nodes which are their validators.
+	// The epoch without its own set has the validators of the previous one, all the nodes validate the first epoch
+	ValidatorSets map[uint64][]int
+	// Latency is the default latency of the network
+	Latency time.Duration
+	// Tick is the step the virtual clock is advanced by, once per the same duration of the real time
+	Tick time.Duration
+	// Seed is the seed of the validator keys and the message drops
+	Seed    int64
+	ChainID uint64
+	Logger  hclog.Logger
+}
+
+// Cluster is the set of the simulated nodes, communicating over the in-process network on the virtual clock
+type Cluster struct {
+	config  *Config
+	clock   *VirtualClock
+	network *Network
+	nodes   []*Node
+
+	accounts []*wallet.Account
+
+	lock       sync.Mutex
+	validators map[uint64]validator.ValidatorSet
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+// NewCluster creates the cluster of the nodes sharing the same genesis block
+func NewCluster(config *Config) (*Cluster, error) {
+	if config.Validators < 1 {
+		return nil, fmt.Errorf("at least one validator is required, got %d", config.Validators)
+	}
+
+	if config.EpochSize == 0 {
+		config.EpochSize = defaultEpochSize
+	}
+
+	if config.Latency == 0 {
+		config.Latency = defaultLatency
+	}
+
+	if config.Tick == 0 {
+		config.Tick = defaultTick
+	}
+
+	if config.ChainID == 0 {
+		config.ChainID = defaultChainID
+	}
+
+	if config.Logger == nil {
+		config.Logger = hclog.NewNullLogger()
+	}
+
+	for epoch, indexes := range config.ValidatorSets {
+		if len(indexes) == 0 {
+			return nil, fmt.Errorf("epoch %d has no validators", epoch)
+		}
+
+		for _, index := range indexes {
+			if index < 0 || index >= config.Validators {
+				return nil, fmt.Errorf("epoch %d has unknown validator %d", epoch, index)
+			}
+		}
+	}
+
+	clock := NewVirtualClock(time.Unix(0, 0).UTC())
+
+	c := &Cluster{
+		config:     config,
+		clock:      clock,
+		network:    NewNetwork(clock, config.Seed),
+		validators: map[uint64]validator.ValidatorSet{},
+	}
+
+	c.network.SetLatency(config.Latency)
+
+	for i := 0; i < config.Validators; i++ {
+		account, err := generateAccount(config.Seed, i)
+		if err != nil {
+			return nil, err
+		}
+
+		c.accounts = append(c.accounts, account)
+	}
+
+	genesis := &Block{
+		Header: (&types.Header{
+			Timestamp: uint64(clock.Now().Unix()),
+		}).ComputeHash(),
+		Extra: &polybft.Extra{Checkpoint: &polybft.CheckpointData{}},
+	}
+
+	for i, account := range c.accounts {
+		node := newNode(i, c, account, genesis)
+		c.nodes = append(c.nodes, node)
+		c.network.Register(i, node.receive)
+	}
+
+	return c, nil
+}
+
+// generateAccount derives the keys of the validator from the seed, so the proposers are the same on each run
+func generateAccount(seed int64, index int) (*wallet.Account, error) {
+	var buf [16]byte
+
+	binary.BigEndian.PutUint64(buf[0:], uint64(seed))
+	binary.BigEndian.PutUint64(buf[8:], uint64(index))
+
+	ecdsaKey, err := ethgoWallet.NewWalletFromPrivKey(crypto.Keccak256([]byte("ecdsa"), buf[:]))
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate key of validator %d: %w", index, err)
+	}
+
+	blsKey, err := bls.UnmarshalPrivateKey(
+		[]byte(types.BytesToHash(crypto.Keccak256([]byte("bls"), buf[:])).String()))
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate bls key of validator %d: %w", index, err)
+	}
+
+	return &wallet.Account{Ecdsa: ecdsaKey, Bls: blsKey}, nil
+}
+
+// Start starts all the nodes and advances the virtual clock by the tick on each tick of the real time
+func (c *Cluster) Start() {
+	for _, node := range c.nodes {
+		node.Start()
+	}
+
+	c.stopCh = make(chan struct{})
+	c.doneCh = make(chan struct{})
+
+	go func() {
+		defer close(c.doneCh)
+
+		ticker := time.NewTicker(c.config.Tick)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-c.stopCh:
+				return
+			case <-ticker.C:
+				c.clock.Advance(c.config.Tick)
+			}
+		}
+	}()
+}
+
+// Stop stops the virtual clock and all the nodes
+func (c *Cluster) Stop() {
+	if c.stopCh != nil {
+		close(c.stopCh)
+		<-c.doneCh
+
+		c.stopCh = nil
+	}
+
+	for _, node := range c.nodes {
+		node.Stop()
+	}
+}
+
+// Nodes returns the nodes of the cluster
+func (c *Cluster) Nodes() []*Node {
+	return c.nodes
+}
+
+// Network returns the network of the cluster
+func (c *Cluster) Network() *Network {
+	return c.network
+}
+
+// Clock returns the virtual clock of the cluster
+func (c *Cluster) Clock() *VirtualClock {
+	return c.clock
+}
+
+// epochOf returns the epoch of the block
+func (c *Cluster) epochOf(blockNumber uint64) uint64 {
+	if blockNumber == 0 {
+		return 0
+	}
+
+	return (blockNumber-1)/c.config.EpochSize + 1
+}
+
+// isEndOfEpoch returns whether the block is the last one of its epoch
+func (c *Cluster) isEndOfEpoch(blockNumber uint64) bool {
+	return blockNumber%c.config.EpochSize == 0
+}
+
+// validatorsAt returns the validator set of the block, each validator having the same voting power
+func (c *Cluster) validatorsAt(blockNumber uint64) validator.ValidatorSet {
+	epoch := c.epochOf(blockNumber)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if validators, ok := c.validators[epoch]; ok {
+		return validators
+	}
+
+	var indexes []int
+
+	for e := epoch; e > 0 && indexes == nil; e-- {
+		indexes = c.config.ValidatorSets[e]
+	}
+
+	if indexes == nil {
+		for i := range c.accounts {
+			indexes = append(indexes, i)
+		}
+	}
+
+	accounts := make(validator.AccountSet, 0, len(indexes))
+
+	for _, index := range indexes {
+		account := c.accounts[index]
+		blsKey := account.Bls.PublicKey()
+		// marshaling normalizes the point of the key once, so the nodes sharing the set only read it
+		_ = blsKey.Marshal()
+
+		accounts = append(accounts, &validator.ValidatorMetadata{
+			Address:     types.Address(account.Ecdsa.Address()),
+			BlsKey:      blsKey,
+			VotingPower: big.NewInt(1),
+			IsActive:    true,
+		})
+	}
+
+	validators := validator.NewValidatorSet(accounts, c.config.Logger)
+	c.validators[epoch] = validators
+
+	return validators
+}
+
+// proposalHash returns the checkpoint hash of the block, which the validators seal
+func (c *Cluster) proposalHash(header *types.Header, extra *polybft.Extra) (types.Hash, error) {
+	return extra.Checkpoint.Hash(c.config.ChainID, header.Number, header.Hash)
+}
+
+// WaitForHeight waits until the given nodes, or all the nodes if none is given, finalize the block of the height
+func (c *Cluster) WaitForHeight(height uint64, timeout time.Duration, nodes ...*Node) error {
+	if len(nodes) == 0 {
+		nodes = c.nodes
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+		reached := true
+
+		for _, node := range nodes {
+			if node.Height() < height {
+				reached = false
+
+				break
+			}
+		}
+
+		if reached {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w: waiting for height %d", errTimeout, height)
+		}
+
+		time.Sleep(c.config.Tick)
+	}
+}
+
+// CheckConsistency checks the nodes finalized the same blocks at the same heights,
+// each block sealed by the quorum of its validators
+func (c *Cluster) CheckConsistency() error {
+	finalized := map[uint64]*Block{}
+
+	for _, node := range c.nodes {
+		for number := uint64(1); number <= node.Height(); number++ {
+			block := node.Block(number)
+
+			if other, ok := finalized[number]; ok {
+				if other.Header.Hash != block.Header.Hash {
+					return fmt.Errorf("node %d finalized block %d with hash %s, while another node finalized hash %s",
+						node.index, number, block.Header.Hash, other.Header.Hash)
+				}
+
+				continue
+			}
+
+			if err := c.checkSeals(block); err != nil {
+				return fmt.Errorf("node %d finalized invalid block %d: %w", node.index, number, err)
+			}
+
+			finalized[number] = block
+		}
+	}
+
+	return nil
+}
+
+func (c *Cluster) checkSeals(block *Block) error {
+	proposalHash, err := c.proposalHash(block.Header, block.Extra)
+	if err != nil {
+		return err
+	}
+
+	validators := c.validatorsAt(block.Number())
+	signers := make(map[types.Address]struct{}, len(block.Seals))
+
+	for _, seal := range block.Seals {
+		if err := verifyCommittedSeal(validators, proposalHash.Bytes(), seal); err != nil {
+			return err
+		}
+
+		signers[types.BytesToAddress(seal.Signer)] = struct{}{}
+	}
+
+	if !validators.HasQuorum(block.Number(), signers) {
+		return errNoQuorum
+	}
+
+	return nil
+}
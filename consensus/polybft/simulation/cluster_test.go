@@ -0,0 +1,177 @@
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCluster(t *testing.T, config *Config) *Cluster {
+	t.Helper()
+
+	cluster, err := NewCluster(config)
+	require.NoError(t, err)
+
+	cluster.Start()
+	t.Cleanup(cluster.Stop)
+
+	return cluster
+}
+
+func TestCluster_EpochTransition(t *testing.T) {
+	t.Parallel()
+
+	cluster := newTestCluster(t, &Config{
+		Validators: 5,
+		EpochSize:  3,
+		// the last validator joins the second epoch, while the first one leaves it
+		ValidatorSets: map[uint64][]int{
+			1: {0, 1, 2, 3},
+			2: {1, 2, 3, 4},
+		},
+	})
+
+	require.NoError(t, cluster.WaitForHeight(8, 30*time.Second))
+	require.NoError(t, cluster.CheckConsistency())
+
+	node := cluster.Nodes()[4]
+
+	for number := uint64(1); number <= 8; number++ {
+		block := node.Block(number)
+		require.Equal(t, cluster.epochOf(number), block.Extra.Checkpoint.EpochNumber)
+
+		validators := cluster.validatorsAt(number)
+		require.True(t, validators.Includes(block.Proposer()))
+
+		nextValidatorsHash, err := cluster.validatorsAt(number + 1).Accounts().Hash()
+		require.NoError(t, err)
+		require.Equal(t, nextValidatorsHash, block.Extra.Checkpoint.NextValidatorsHash)
+	}
+
+	require.False(t, cluster.validatorsAt(4).Includes(cluster.Nodes()[0].Address()))
+}
+
+func TestCluster_PartitionAndHeal(t *testing.T) {
+	if testing.Short() {
+		t.Skip("round change waits for the round timeout of the consensus")
+	}
+
+	t.Parallel()
+
+	cluster := newTestCluster(t, &Config{Validators: 4})
+	nodes := cluster.Nodes()
+
+	require.NoError(t, cluster.WaitForHeight(2, 30*time.Second))
+
+	// the majority keeps finalizing the blocks without the isolated node
+	cluster.Network().Partition([]int{0, 1, 2})
+	isolatedHeight := nodes[3].Height()
+
+	require.NoError(t, cluster.WaitForHeight(isolatedHeight+3, 30*time.Second, nodes[:3]...))
+	require.LessOrEqual(t, nodes[3].Height(), isolatedHeight+1)
+
+	cluster.Network().Heal()
+
+	require.NoError(t, cluster.WaitForHeight(nodes[0].Height()+1, 30*time.Second))
+	require.NoError(t, cluster.CheckConsistency())
+}
+
+func TestCluster_MinorityPartitionHalts(t *testing.T) {
+	if testing.Short() {
+		t.Skip("round change waits for the round timeout of the consensus")
+	}
+
+	t.Parallel()
+
+	cluster := newTestCluster(t, &Config{Validators: 4})
+	nodes := cluster.Nodes()
+
+	require.NoError(t, cluster.WaitForHeight(1, 30*time.Second))
+
+	// none of the halves has the quorum
+	cluster.Network().Partition([]int{0, 1}, []int{2, 3})
+	time.Sleep(time.Second)
+
+	height := uint64(0)
+	for _, node := range nodes {
+		if node.Height() > height {
+			height = node.Height()
+		}
+	}
+
+	require.Error(t, cluster.WaitForHeight(height+1, time.Second, nodes[0]))
+	require.NoError(t, cluster.CheckConsistency())
+
+	cluster.Network().Heal()
+
+	require.NoError(t, cluster.WaitForHeight(height+2, 60*time.Second))
+	require.NoError(t, cluster.CheckConsistency())
+}
+
+func TestCluster_MessageDrops(t *testing.T) {
+	t.Parallel()
+
+	cluster := newTestCluster(t, &Config{Validators: 4, Seed: 7})
+	cluster.Network().SetDropRate(0.05)
+
+	require.NoError(t, cluster.WaitForHeight(3, 60*time.Second))
+	require.NoError(t, cluster.CheckConsistency())
+
+	_, dropped := cluster.Network().Stats()
+	require.Greater(t, dropped, uint64(0))
+}
+
+func TestCluster_ProposerCrash_RoundChange(t *testing.T) {
+	if testing.Short() {
+		t.Skip("round change waits for the round timeout of the consensus")
+	}
+
+	t.Parallel()
+
+	cluster := newTestCluster(t, &Config{Validators: 4})
+	nodes := cluster.Nodes()
+
+	require.NoError(t, cluster.WaitForHeight(1, 30*time.Second))
+
+	// stop the proposer of the first round of the next block
+	for _, node := range nodes {
+		node.Stop()
+	}
+
+	// the messages in flight are lost, once the clock passes their latency
+	time.Sleep(10 * defaultLatency)
+
+	height := nodes[0].Height()
+	for _, node := range nodes[1:] {
+		require.Equal(t, height, node.Height())
+	}
+
+	var crashed *Node
+
+	for _, node := range nodes {
+		if nodes[0].IsProposer(node.ID(), height+1, 0) {
+			crashed = node
+		}
+	}
+
+	require.NotNil(t, crashed)
+
+	for _, node := range nodes {
+		if node != crashed {
+			node.Start()
+		}
+	}
+
+	require.NoError(t, cluster.WaitForHeight(height+1, 60*time.Second, nodes[(crashed.Index()+1)%4]))
+
+	block := nodes[(crashed.Index()+1)%4].Block(height + 1)
+	require.Greater(t, block.Round(), uint64(0))
+	require.NotEqual(t, crashed.Address(), block.Proposer())
+
+	// the restarted node catches up with the others
+	crashed.Start()
+
+	require.NoError(t, cluster.WaitForHeight(height+2, 60*time.Second))
+	require.NoError(t, cluster.CheckConsistency())
+}
@@ -0,0 +1,191 @@
+package simulation
+
+import (
+	"encoding/binary"
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/0xPolygon/go-ibft/messages/proto"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+)
+
+// link is the direction of the communication between two nodes
+type link struct {
+	from int
+	to   int
+}
+
+// Network is the in-process network of the simulated nodes, delivering the consensus messages on the virtual clock.
+// Whether the message is dropped is decided from the seed, the link and the message itself,
+// so the drops don't depend on the order the nodes send their messages in
+type Network struct {
+	clock *VirtualClock
+	seed  int64
+
+	lock     sync.RWMutex
+	handlers map[int]func(*proto.Message)
+	// nodes are the registered nodes in the ascending order, so the messages are scheduled in the same order
+	nodes       []int
+	latency     time.Duration
+	linkLatency map[link]time.Duration
+	// groups maps the nodes to their partition, the network isn't partitioned if nil
+	groups   map[int]int
+	dropRate float64
+
+	delivered uint64
+	dropped   uint64
+}
+
+// NewNetwork creates the network delivering the messages on the given clock
+func NewNetwork(clock *VirtualClock, seed int64) *Network {
+	return &Network{
+		clock:       clock,
+		seed:        seed,
+		handlers:    map[int]func(*proto.Message){},
+		linkLatency: map[link]time.Duration{},
+	}
+}
+
+// Register connects the node to the network, the handler receiving the messages sent to the node
+func (n *Network) Register(node int, handler func(*proto.Message)) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if _, ok := n.handlers[node]; !ok {
+		n.nodes = append(n.nodes, node)
+		sort.Ints(n.nodes)
+	}
+
+	n.handlers[node] = handler
+}
+
+// SetLatency sets the latency of the links without their own latency
+func (n *Network) SetLatency(latency time.Duration) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.latency = latency
+}
+
+// SetLinkLatency sets the latency of the messages sent from one node to the other
+func (n *Network) SetLinkLatency(from, to int, latency time.Duration) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.linkLatency[link{from: from, to: to}] = latency
+}
+
+// SetDropRate sets the share of the messages between the different nodes which are dropped, from 0 to 1
+func (n *Network) SetDropRate(rate float64) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.dropRate = rate
+}
+
+// Partition splits the network into the given groups of the nodes,
+// which only communicate within their group. The nodes not included in any group are isolated
+func (n *Network) Partition(groups ...[]int) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.groups = map[int]int{}
+
+	for i, group := range groups {
+		for _, node := range group {
+			n.groups[node] = i
+		}
+	}
+}
+
+// Heal removes the partitions of the network
+func (n *Network) Heal() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.groups = nil
+}
+
+// Connected returns whether the nodes communicate with each other
+func (n *Network) Connected(a, b int) bool {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	return n.connected(a, b)
+}
+
+func (n *Network) connected(a, b int) bool {
+	if a == b || n.groups == nil {
+		return true
+	}
+
+	groupA, okA := n.groups[a]
+	groupB, okB := n.groups[b]
+
+	return okA && okB && groupA == groupB
+}
+
+// Broadcast sends the message to all the nodes connected to the sender, including itself.
+// The message is delivered once the clock is advanced by the latency of the link
+func (n *Network) Broadcast(from int, msg *proto.Message) {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	raw, err := protobuf.Marshal(msg)
+	if err != nil {
+		return
+	}
+
+	for _, to := range n.nodes {
+		if !n.connected(from, to) || n.isDropped(from, to, raw) {
+			atomic.AddUint64(&n.dropped, 1)
+
+			continue
+		}
+
+		latency, ok := n.linkLatency[link{from: from, to: to}]
+		if !ok {
+			latency = n.latency
+		}
+
+		if from == to {
+			latency = 0
+		}
+
+		handler := n.handlers[to]
+
+		// each node receives its own copy, since the consensus keeps the messages it receives
+		n.clock.AfterFunc(latency, func() {
+			atomic.AddUint64(&n.delivered, 1)
+
+			handler(protobuf.Clone(msg).(*proto.Message)) //nolint:forcetypeassert
+		})
+	}
+}
+
+// isDropped decides whether the message is dropped on the link between the different nodes
+func (n *Network) isDropped(from, to int, raw []byte) bool {
+	if from == to || n.dropRate <= 0 {
+		return false
+	}
+
+	var buf [24]byte
+
+	binary.BigEndian.PutUint64(buf[0:], uint64(n.seed))
+	binary.BigEndian.PutUint64(buf[8:], uint64(from))
+	binary.BigEndian.PutUint64(buf[16:], uint64(to))
+
+	sample := binary.BigEndian.Uint64(crypto.Keccak256(buf[:], raw))
+
+	return float64(sample)/math.MaxUint64 < n.dropRate
+}
+
+// Stats returns the number of the delivered and the dropped messages
+func (n *Network) Stats() (delivered, dropped uint64) {
+	return atomic.LoadUint64(&n.delivered), atomic.LoadUint64(&n.dropped)
+}
@@ -0,0 +1,126 @@
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestNetwork(t *testing.T, nodes int, seed int64) (*Network, *VirtualClock, [][]*proto.Message) {
+	t.Helper()
+
+	clock := NewVirtualClock(time.Unix(0, 0))
+	network := NewNetwork(clock, seed)
+	received := make([][]*proto.Message, nodes)
+
+	for i := 0; i < nodes; i++ {
+		i := i
+		network.Register(i, func(msg *proto.Message) {
+			received[i] = append(received[i], msg)
+		})
+	}
+
+	return network, clock, received
+}
+
+func testMessage(height uint64) *proto.Message {
+	return &proto.Message{
+		View: &proto.View{Height: height},
+		From: []byte{0x1},
+		Type: proto.MessageType_PREPARE,
+	}
+}
+
+func TestNetwork_Latency(t *testing.T) {
+	t.Parallel()
+
+	network, clock, received := newTestNetwork(t, 3, 0)
+
+	network.SetLatency(100 * time.Millisecond)
+	network.SetLinkLatency(0, 2, 300*time.Millisecond)
+	network.Broadcast(0, testMessage(1))
+
+	// the sender receives its own message right away
+	clock.Advance(0)
+	require.Len(t, received[0], 1)
+	require.Empty(t, received[1])
+
+	clock.Advance(100 * time.Millisecond)
+	require.Len(t, received[1], 1)
+	require.Empty(t, received[2])
+
+	clock.Advance(200 * time.Millisecond)
+	require.Len(t, received[2], 1)
+
+	delivered, dropped := network.Stats()
+	require.Equal(t, uint64(3), delivered)
+	require.Equal(t, uint64(0), dropped)
+}
+
+func TestNetwork_Partition(t *testing.T) {
+	t.Parallel()
+
+	network, clock, received := newTestNetwork(t, 4, 0)
+
+	network.Partition([]int{0, 1}, []int{2})
+	require.True(t, network.Connected(0, 1))
+	require.False(t, network.Connected(0, 2))
+	require.False(t, network.Connected(3, 2))
+	require.True(t, network.Connected(3, 3))
+
+	network.Broadcast(0, testMessage(1))
+	network.Broadcast(2, testMessage(1))
+	clock.Advance(time.Second)
+
+	require.Len(t, received[0], 1)
+	require.Len(t, received[1], 1)
+	require.Len(t, received[2], 1)
+	require.Empty(t, received[3])
+
+	network.Heal()
+	network.Broadcast(3, testMessage(2))
+	clock.Advance(time.Second)
+
+	for i := range received {
+		require.Equal(t, uint64(2), received[i][len(received[i])-1].View.Height)
+	}
+}
+
+func TestNetwork_DropRate(t *testing.T) {
+	t.Parallel()
+
+	run := func(seed int64) []int {
+		network, clock, received := newTestNetwork(t, 4, seed)
+
+		network.SetDropRate(0.5)
+
+		for height := uint64(1); height <= 50; height++ {
+			network.Broadcast(int(height%4), testMessage(height))
+		}
+
+		clock.Advance(time.Second)
+
+		counts := make([]int, len(received))
+		for i := range received {
+			counts[i] = len(received[i])
+		}
+
+		return counts
+	}
+
+	counts := run(1)
+
+	// the drops are the same on each run with the same seed
+	require.Equal(t, counts, run(1))
+
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+
+	// the senders always receive their own messages
+	require.Greater(t, total, 50)
+	require.Less(t, total, 200)
+}
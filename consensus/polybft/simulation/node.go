@@ -0,0 +1,610 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/go-ibft/core"
+	"github.com/0xPolygon/go-ibft/messages"
+	"github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/bls"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	_ core.Backend   = (*Node)(nil)
+	_ core.Transport = (*Node)(nil)
+
+	errNoQuorum = errors.New("committed seals don't have the quorum")
+)
+
+// Block is the block finalized by the simulated node
+type Block struct {
+	Header *types.Header
+	Extra  *polybft.Extra
+	// Seals are the committed seals of the proposal hash, the block is finalized with
+	Seals []*messages.CommittedSeal
+}
+
+// Number returns the number of the block
+func (b *Block) Number() uint64 {
+	return b.Header.Number
+}
+
+// Round returns the round of the consensus the block was proposed in
+func (b *Block) Round() uint64 {
+	return b.Extra.Checkpoint.BlockRound
+}
+
+// Proposer returns the validator which proposed the block
+func (b *Block) Proposer() types.Address {
+	return types.BytesToAddress(b.Header.Miner)
+}
+
+// Node is the simulated polybft validator, running the go-ibft consensus in process.
+// The node builds the empty blocks, signing the consensus messages and the committed seals
+// the same way the polybft runtime does, and follows the proposer calculation of polybft
+type Node struct {
+	index   int
+	cluster *Cluster
+	key     *wallet.Key
+	logger  hclog.Logger
+
+	lock   sync.RWMutex
+	blocks []*Block
+	// snapshot is the proposer snapshot of the next block
+	snapshot *polybft.ProposerSnapshot
+	ibft     *core.IBFT
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+}
+
+func newNode(index int, cluster *Cluster, account *wallet.Account, genesis *Block) *Node {
+	return &Node{
+		index:    index,
+		cluster:  cluster,
+		key:      wallet.NewKey(account),
+		logger:   cluster.config.Logger.Named(fmt.Sprintf("node-%d", index)),
+		blocks:   []*Block{genesis},
+		snapshot: polybft.NewProposerSnapshot(1, cluster.validatorsAt(1).Accounts()),
+	}
+}
+
+// Index returns the index of the node in the cluster
+func (n *Node) Index() int {
+	return n.index
+}
+
+// Address returns the address of the validator of the node
+func (n *Node) Address() types.Address {
+	return types.Address(n.key.Address())
+}
+
+// Height returns the number of the last block finalized by the node
+func (n *Node) Height() uint64 {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	return uint64(len(n.blocks) - 1)
+}
+
+// Block returns the block of the node with the given number, nil if the node didn't finalize it
+func (n *Node) Block(number uint64) *Block {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	if number >= uint64(len(n.blocks)) {
+		return nil
+	}
+
+	return n.blocks[number]
+}
+
+// Running returns whether the node runs the consensus
+func (n *Node) Running() bool {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	return n.stopCh != nil
+}
+
+// Start starts the node, which catches up with the connected nodes and runs the consensus.
+// The stopped node starts with the blocks it finalized before, as if it was restarted
+func (n *Node) Start() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.stopCh != nil {
+		return
+	}
+
+	n.ibft = core.NewIBFT(n.logger, n, n)
+	n.stopCh = make(chan struct{})
+	n.doneCh = make(chan struct{})
+
+	go n.run(n.ibft, n.stopCh, n.doneCh)
+}
+
+// Stop stops the node, as if it crashed. The messages sent to the stopped node are lost
+func (n *Node) Stop() {
+	n.lock.Lock()
+
+	if n.stopCh == nil {
+		n.lock.Unlock()
+
+		return
+	}
+
+	close(n.stopCh)
+	doneCh := n.doneCh
+	n.stopCh, n.doneCh, n.ibft = nil, nil, nil
+
+	n.lock.Unlock()
+
+	<-doneCh
+}
+
+// run runs the consensus sequences of the next heights, until the node is stopped
+func (n *Node) run(ibft *core.IBFT, stopCh, doneCh chan struct{}) {
+	defer close(doneCh)
+
+	syncTicker := time.NewTicker(n.cluster.config.Tick)
+	defer syncTicker.Stop()
+
+	for {
+		n.syncWithPeers()
+
+		height := n.Height() + 1
+
+		if !n.cluster.validatorsAt(height).Includes(n.Address()) {
+			// the node which isn't the validator only follows the chain
+			select {
+			case <-stopCh:
+				return
+			case <-syncTicker.C:
+				continue
+			}
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		sequenceDone := make(chan struct{})
+
+		go func() {
+			ibft.RunSequence(ctx, height)
+			close(sequenceDone)
+		}()
+
+	sequence:
+		for {
+			select {
+			case <-sequenceDone:
+				break sequence
+			case <-syncTicker.C:
+				// the sequence is abandoned once the connected nodes finalized its height
+				if n.peersHeight() >= height {
+					cancel()
+					<-sequenceDone
+
+					break sequence
+				}
+			case <-stopCh:
+				cancel()
+				<-sequenceDone
+
+				return
+			}
+		}
+
+		cancel()
+	}
+}
+
+// peersHeight returns the highest block finalized by the running nodes connected to the node
+func (n *Node) peersHeight() uint64 {
+	height := uint64(0)
+
+	for _, peer := range n.cluster.nodes {
+		if peer != n && peer.Running() && n.cluster.network.Connected(n.index, peer.index) {
+			if peerHeight := peer.Height(); peerHeight > height {
+				height = peerHeight
+			}
+		}
+	}
+
+	return height
+}
+
+// syncWithPeers imports the blocks finalized by the connected nodes, which the node didn't finalize yet
+func (n *Node) syncWithPeers() {
+	for _, peer := range n.cluster.nodes {
+		if peer == n || !peer.Running() || !n.cluster.network.Connected(n.index, peer.index) {
+			continue
+		}
+
+		for number := n.Height() + 1; ; number++ {
+			block := peer.Block(number)
+			if block == nil {
+				break
+			}
+
+			if err := n.insertBlock(block); err != nil {
+				n.logger.Error("failed to sync block", "number", number, "from", peer.index, "error", err)
+
+				break
+			}
+		}
+	}
+}
+
+// insertBlock verifies the block finalizes the next height with the quorum of the seals
+// and appends it to the chain of the node, advancing the proposer snapshot
+func (n *Node) insertBlock(block *Block) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	parent := n.blocks[len(n.blocks)-1]
+	if block.Number() != parent.Number()+1 {
+		return fmt.Errorf("expected block %d, got %d", parent.Number()+1, block.Number())
+	}
+
+	if block.Header.ParentHash != parent.Header.Hash {
+		return fmt.Errorf("block %d doesn't extend the chain of the node", block.Number())
+	}
+
+	if err := n.cluster.checkSeals(block); err != nil {
+		return err
+	}
+
+	var nextValidators validator.AccountSet
+
+	if n.cluster.isEndOfEpoch(block.Number()) {
+		nextValidators = n.cluster.validatorsAt(block.Number() + 1).Accounts()
+	}
+
+	if err := n.snapshot.UpdatePerBlock(block.Round(), nextValidators); err != nil {
+		return err
+	}
+
+	n.blocks = append(n.blocks, block)
+
+	return nil
+}
+
+// parseProposal decodes the block of the raw proposal
+func parseProposal(rawProposal []byte) (*types.Header, *polybft.Extra, error) {
+	block := &types.Block{}
+	if err := block.UnmarshalRLP(rawProposal); err != nil {
+		return nil, nil, err
+	}
+
+	extra, err := polybft.GetIbftExtra(block.Header.ExtraData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if extra.Checkpoint == nil {
+		return nil, nil, fmt.Errorf("block %d has no checkpoint data", block.Number())
+	}
+
+	return block.Header, extra, nil
+}
+
+func verifyCommittedSeal(validators validator.ValidatorSet, proposalHash []byte,
+	seal *messages.CommittedSeal) error {
+	from := types.BytesToAddress(seal.Signer)
+
+	metadata := validators.Accounts().GetValidatorMetadata(from)
+	if metadata == nil {
+		return fmt.Errorf("unable to resolve validator %s", from)
+	}
+
+	signature, err := bls.UnmarshalSignature(seal.Signature)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal signature: %w", err)
+	}
+
+	if !signature.Verify(metadata.BlsKey, proposalHash, signer.DomainCheckpointManager) {
+		return fmt.Errorf("incorrect commit signature from %s", from)
+	}
+
+	return nil
+}
+
+// BuildProposal builds the empty block of the given view on top of the last block of the node
+func (n *Node) BuildProposal(view *proto.View) []byte {
+	n.lock.RLock()
+	parent := n.blocks[len(n.blocks)-1]
+	n.lock.RUnlock()
+
+	if parent.Number()+1 != view.Height {
+		n.logger.Error("unable to build proposal, due to lack of parent block",
+			"parent height", parent.Number(), "current height", view.Height)
+
+		return nil
+	}
+
+	currentValidatorsHash, err := n.cluster.validatorsAt(view.Height).Accounts().Hash()
+	if err != nil {
+		n.logger.Error("unable to build proposal", "error", err)
+
+		return nil
+	}
+
+	nextValidatorsHash := currentValidatorsHash
+
+	if n.cluster.isEndOfEpoch(view.Height) {
+		if nextValidatorsHash, err = n.cluster.validatorsAt(view.Height + 1).Accounts().Hash(); err != nil {
+			n.logger.Error("unable to build proposal", "error", err)
+
+			return nil
+		}
+	}
+
+	extra := &polybft.Extra{
+		Parent:    &polybft.Signature{},
+		Committed: &polybft.Signature{},
+		Checkpoint: &polybft.CheckpointData{
+			BlockRound:            view.Round,
+			EpochNumber:           n.cluster.epochOf(view.Height),
+			CurrentValidatorsHash: currentValidatorsHash,
+			NextValidatorsHash:    nextValidatorsHash,
+		},
+	}
+
+	header := &types.Header{
+		ParentHash: parent.Header.Hash,
+		Number:     view.Height,
+		Miner:      n.Address().Bytes(),
+		Timestamp:  uint64(n.cluster.clock.Now().Unix()),
+		ExtraData:  extra.MarshalRLPTo(nil),
+	}
+
+	return (&types.Block{Header: header.ComputeHash()}).MarshalRLP()
+}
+
+// IsValidProposal checks the proposal extends the chain of the node and is built by the proposer of its round
+func (n *Node) IsValidProposal(rawProposal []byte) bool {
+	header, extra, err := parseProposal(rawProposal)
+	if err != nil {
+		n.logger.Error("failed to validate proposal", "error", err)
+
+		return false
+	}
+
+	// the proposer calculation updates the snapshot
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	parent := n.blocks[len(n.blocks)-1]
+
+	if header.Number != parent.Number()+1 || header.ParentHash != parent.Header.Hash {
+		n.logger.Error("proposal doesn't extend the chain", "number", header.Number)
+
+		return false
+	}
+
+	if extra.Checkpoint.EpochNumber != n.cluster.epochOf(header.Number) {
+		n.logger.Error("proposal has invalid epoch", "number", header.Number, "epoch", extra.Checkpoint.EpochNumber)
+
+		return false
+	}
+
+	proposer, err := n.snapshot.CalcProposer(extra.Checkpoint.BlockRound, header.Number)
+	if err != nil || !bytes.Equal(header.Miner, proposer.Bytes()) {
+		n.logger.Error("proposal isn't built by the proposer of its round", "number", header.Number)
+
+		return false
+	}
+
+	return true
+}
+
+// IsValidValidator checks the message is signed by its sender, which is the validator of the message height
+func (n *Node) IsValidValidator(msg *proto.Message) bool {
+	msgNoSig, err := msg.PayloadNoSig()
+	if err != nil {
+		return false
+	}
+
+	signerAddress, err := wallet.RecoverAddressFromSignature(msg.Signature, msgNoSig)
+	if err != nil || !bytes.Equal(msg.From, signerAddress.Bytes()) {
+		return false
+	}
+
+	return n.cluster.validatorsAt(msg.View.Height).Includes(signerAddress)
+}
+
+// IsProposer checks the id is the proposer of the view, calculated from the proposer snapshot of polybft
+func (n *Node) IsProposer(id []byte, height, round uint64) bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	proposer, err := n.snapshot.CalcProposer(round, height)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(id, proposer.Bytes())
+}
+
+// IsValidProposalHash checks the hash is the checkpoint hash of the proposed block
+func (n *Node) IsValidProposalHash(proposal *proto.Proposal, hash []byte) bool {
+	header, extra, err := parseProposal(proposal.RawProposal)
+	if err != nil {
+		return false
+	}
+
+	proposalHash, err := n.cluster.proposalHash(header, extra)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(proposalHash.Bytes(), hash)
+}
+
+// IsValidCommittedSeal checks the seal is the BLS signature of the proposal hash by the validator
+func (n *Node) IsValidCommittedSeal(proposalHash []byte, committedSeal *messages.CommittedSeal) bool {
+	validators := n.cluster.validatorsAt(n.Height() + 1)
+
+	if err := verifyCommittedSeal(validators, proposalHash, committedSeal); err != nil {
+		n.logger.Info("Invalid committed seal", "error", err)
+
+		return false
+	}
+
+	return true
+}
+
+// InsertProposal finalizes the proposed block with the committed seals
+func (n *Node) InsertProposal(proposal *proto.Proposal, committedSeals []*messages.CommittedSeal) {
+	header, extra, err := parseProposal(proposal.RawProposal)
+	if err != nil {
+		n.logger.Error("cannot insert proposal", "error", err)
+
+		return
+	}
+
+	if err := n.insertBlock(&Block{Header: header, Extra: extra, Seals: committedSeals}); err != nil {
+		n.logger.Error("cannot insert proposal", "error", err)
+	}
+}
+
+// ID returns the address of the validator of the node
+func (n *Node) ID() []byte {
+	return n.Address().Bytes()
+}
+
+// GetVotingPowers returns the voting powers of the validators of the height
+func (n *Node) GetVotingPowers(height uint64) (map[string]*big.Int, error) {
+	return n.cluster.validatorsAt(height).GetVotingPowers(), nil
+}
+
+// BuildPrePrepareMessage builds the PREPREPARE message of the proposal
+func (n *Node) BuildPrePrepareMessage(
+	rawProposal []byte,
+	certificate *proto.RoundChangeCertificate,
+	view *proto.View,
+) *proto.Message {
+	header, extra, err := parseProposal(rawProposal)
+	if err != nil {
+		n.logger.Error("cannot build pre-prepare message", "error", err)
+
+		return nil
+	}
+
+	proposalHash, err := n.cluster.proposalHash(header, extra)
+	if err != nil {
+		n.logger.Error("cannot build pre-prepare message", "error", err)
+
+		return nil
+	}
+
+	return n.signMessage(&proto.Message{
+		View: view,
+		From: n.ID(),
+		Type: proto.MessageType_PREPREPARE,
+		Payload: &proto.Message_PreprepareData{
+			PreprepareData: &proto.PrePrepareMessage{
+				Proposal: &proto.Proposal{
+					RawProposal: rawProposal,
+					Round:       view.Round,
+				},
+				ProposalHash: proposalHash.Bytes(),
+				Certificate:  certificate,
+			},
+		},
+	})
+}
+
+// BuildPrepareMessage builds the PREPARE message of the proposal hash
+func (n *Node) BuildPrepareMessage(proposalHash []byte, view *proto.View) *proto.Message {
+	return n.signMessage(&proto.Message{
+		View: view,
+		From: n.ID(),
+		Type: proto.MessageType_PREPARE,
+		Payload: &proto.Message_PrepareData{
+			PrepareData: &proto.PrepareMessage{
+				ProposalHash: proposalHash,
+			},
+		},
+	})
+}
+
+// BuildCommitMessage builds the COMMIT message, with the committed seal of the proposal hash
+func (n *Node) BuildCommitMessage(proposalHash []byte, view *proto.View) *proto.Message {
+	committedSeal, err := n.key.SignCommittedSeal(proposalHash, view)
+	if err != nil {
+		n.logger.Error("Cannot create committed seal message.", "error", err)
+
+		return nil
+	}
+
+	return n.signMessage(&proto.Message{
+		View: view,
+		From: n.ID(),
+		Type: proto.MessageType_COMMIT,
+		Payload: &proto.Message_CommitData{
+			CommitData: &proto.CommitMessage{
+				ProposalHash:  proposalHash,
+				CommittedSeal: committedSeal,
+			},
+		},
+	})
+}
+
+// BuildRoundChangeMessage builds the ROUND_CHANGE message with the latest prepared proposal and certificate
+func (n *Node) BuildRoundChangeMessage(
+	proposal *proto.Proposal,
+	certificate *proto.PreparedCertificate,
+	view *proto.View,
+) *proto.Message {
+	return n.signMessage(&proto.Message{
+		View: view,
+		From: n.ID(),
+		Type: proto.MessageType_ROUND_CHANGE,
+		Payload: &proto.Message_RoundChangeData{
+			RoundChangeData: &proto.RoundChangeMessage{
+				LastPreparedProposal:      proposal,
+				LatestPreparedCertificate: certificate,
+			},
+		},
+	})
+}
+
+func (n *Node) signMessage(msg *proto.Message) *proto.Message {
+	signedMsg, err := n.key.SignIBFTMessage(msg)
+	if err != nil {
+		n.logger.Error("Cannot sign message", "error", err)
+
+		return nil
+	}
+
+	return signedMsg
+}
+
+// Multicast sends the message to the connected nodes through the network of the cluster
+func (n *Node) Multicast(msg *proto.Message) {
+	n.cluster.network.Broadcast(n.index, msg)
+}
+
+// receive adds the message delivered by the network to the consensus of the running node
+func (n *Node) receive(msg *proto.Message) {
+	n.lock.RLock()
+	ibft := n.ibft
+	n.lock.RUnlock()
+
+	if ibft != nil {
+		ibft.AddMessage(msg)
+	}
+}
@@ -32,7 +32,8 @@ var (
 type StakeManager interface {
 	EventSubscriber
 	PostBlock(req *PostBlockRequest) error
-	UpdateValidatorSet(epoch uint64, currentValidatorSet validator.AccountSet) (*validator.ValidatorSetDelta, error)
+	UpdateValidatorSet(epoch uint64, maxValidatorSetSize int,
+		currentValidatorSet validator.AccountSet) (*validator.ValidatorSetDelta, error)
 }
 
 var _ StakeManager = (*dummyStakeManager)(nil)
@@ -42,7 +43,7 @@ var _ StakeManager = (*dummyStakeManager)(nil)
 type dummyStakeManager struct{}
 
 func (d *dummyStakeManager) PostBlock(req *PostBlockRequest) error { return nil }
-func (d *dummyStakeManager) UpdateValidatorSet(epoch uint64,
+func (d *dummyStakeManager) UpdateValidatorSet(epoch uint64, maxValidatorSetSize int,
 	currentValidatorSet validator.AccountSet) (*validator.ValidatorSetDelta, error) {
 	return &validator.ValidatorSetDelta{}, nil
 }
@@ -66,7 +67,6 @@ type stakeManager struct {
 	key                     ethgo.Key
 	supernetManagerContract types.Address
 	validatorSetContract    types.Address
-	maxValidatorSetSize     int
 	polybftBackend          polybftBackend
 }
 
@@ -79,7 +79,6 @@ func newStakeManager(
 	validatorSetAddr, supernetManagerAddr types.Address,
 	blockchain blockchainBackend,
 	polybftBackend polybftBackend,
-	maxValidatorSetSize int,
 	dbTx *bolt.Tx,
 ) (*stakeManager, error) {
 	sm := &stakeManager{
@@ -89,7 +88,6 @@ func newStakeManager(
 		key:                     key,
 		supernetManagerContract: supernetManagerAddr,
 		validatorSetContract:    validatorSetAddr,
-		maxValidatorSetSize:     maxValidatorSetSize,
 		polybftBackend:          polybftBackend,
 	}
 
@@ -259,8 +257,9 @@ func (s *stakeManager) updateWithReceipts(
 
 // UpdateValidatorSet returns an updated validator set
 // based on stake change (transfer) events from ValidatorSet contract
+// The maximum size of the validator set is set in the polybft config, unless it's changed by the governance
 func (s *stakeManager) UpdateValidatorSet(
-	epoch uint64, oldValidatorSet validator.AccountSet) (*validator.ValidatorSetDelta, error) {
+	epoch uint64, maxValidatorSetSize int, oldValidatorSet validator.AccountSet) (*validator.ValidatorSetDelta, error) {
 	s.logger.Info("Calculating validators set update...", "epoch", epoch)
 
 	fullValidatorSet, err := s.state.StakeStore.getFullValidatorSet(nil)
@@ -272,7 +271,7 @@ func (s *stakeManager) UpdateValidatorSet(
 	stakeMap := fullValidatorSet.Validators
 
 	// slice of all validator set
-	newValidatorSet := stakeMap.getSorted(s.maxValidatorSetSize)
+	newValidatorSet := stakeMap.getSorted(maxValidatorSetSize)
 	// set of all addresses that will be in next validator set
 	addressesSet := make(map[types.Address]struct{}, len(newValidatorSet))
 
@@ -117,7 +117,6 @@ func FuzzTestStakeManagerPostBlock(f *testing.F) {
 			types.StringToAddress("0x0002"),
 			bcMock,
 			nil,
-			5,
 			nil,
 		)
 		require.NoError(t, err)
@@ -162,7 +161,6 @@ func FuzzTestStakeManagerUpdateValidatorSet(f *testing.F) {
 		types.StringToAddress("0x0001"), types.StringToAddress("0x0002"),
 		bcMock,
 		nil,
-		10,
 		nil,
 	)
 	require.NoError(f, err)
@@ -212,14 +210,14 @@ func FuzzTestStakeManagerUpdateValidatorSet(f *testing.F) {
 			Validators: newValidatorStakeMap(validators.GetPublicIdentities())}, nil)
 		require.NoError(t, err)
 
-		_, err = stakeManager.UpdateValidatorSet(data.EpochID, validators.GetPublicIdentities(aliases[data.Index:]...))
+		_, err = stakeManager.UpdateValidatorSet(data.EpochID, 10, validators.GetPublicIdentities(aliases[data.Index:]...))
 		require.NoError(t, err)
 
 		fullValidatorSet := validators.GetPublicIdentities().Copy()
 		validatorToUpdate := fullValidatorSet[data.Index]
 		validatorToUpdate.VotingPower = big.NewInt(data.VotingPower)
 
-		_, err = stakeManager.UpdateValidatorSet(data.EpochID, validators.GetPublicIdentities())
+		_, err = stakeManager.UpdateValidatorSet(data.EpochID, 10, validators.GetPublicIdentities())
 		require.NoError(t, err)
 	})
 }
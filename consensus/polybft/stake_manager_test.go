@@ -56,7 +56,6 @@ func TestStakeManager_PostBlock(t *testing.T) {
 			validatorSetAddr, types.StringToAddress("0x0002"),
 			bcMock,
 			nil,
-			5,
 			nil,
 		)
 		require.NoError(t, err)
@@ -115,7 +114,6 @@ func TestStakeManager_PostBlock(t *testing.T) {
 			types.StringToAddress("0x0001"), types.StringToAddress("0x0002"),
 			bcMock,
 			nil,
-			5,
 			nil,
 		)
 		require.NoError(t, err)
@@ -182,7 +180,6 @@ func TestStakeManager_PostBlock(t *testing.T) {
 			types.StringToAddress("0x0001"), types.StringToAddress("0x0002"),
 			bcMock,
 			nil,
-			5,
 			nil,
 		)
 		require.NoError(t, err)
@@ -242,7 +239,6 @@ func TestStakeManager_UpdateValidatorSet(t *testing.T) {
 		types.StringToAddress("0x0001"), types.StringToAddress("0x0002"),
 		bcMock,
 		nil,
-		10,
 		nil,
 	)
 	require.NoError(t, err)
@@ -256,7 +252,7 @@ func TestStakeManager_UpdateValidatorSet(t *testing.T) {
 			Validators: newValidatorStakeMap(fullValidatorSet),
 		}, nil))
 
-		updateDelta, err := stakeManager.UpdateValidatorSet(epoch, validators.GetPublicIdentities())
+		updateDelta, err := stakeManager.UpdateValidatorSet(epoch, 10, validators.GetPublicIdentities())
 		require.NoError(t, err)
 		require.Len(t, updateDelta.Added, 0)
 		require.Len(t, updateDelta.Updated, 1)
@@ -272,7 +268,7 @@ func TestStakeManager_UpdateValidatorSet(t *testing.T) {
 			Validators: newValidatorStakeMap(fullValidatorSet),
 		}, nil))
 
-		updateDelta, err := stakeManager.UpdateValidatorSet(epoch+1, validators.GetPublicIdentities())
+		updateDelta, err := stakeManager.UpdateValidatorSet(epoch+1, 10, validators.GetPublicIdentities())
 		require.NoError(t, err)
 		require.Len(t, updateDelta.Added, 0)
 		require.Len(t, updateDelta.Updated, 0)
@@ -286,7 +282,7 @@ func TestStakeManager_UpdateValidatorSet(t *testing.T) {
 			Validators: newValidatorStakeMap(validators.GetPublicIdentities()),
 		}, nil))
 
-		updateDelta, err := stakeManager.UpdateValidatorSet(epoch+2,
+		updateDelta, err := stakeManager.UpdateValidatorSet(epoch+2, 10,
 			validators.GetPublicIdentities(aliases[1:]...))
 		require.NoError(t, err)
 		require.Len(t, updateDelta.Added, 1)
@@ -303,7 +299,7 @@ func TestStakeManager_UpdateValidatorSet(t *testing.T) {
 			Validators: newValidatorStakeMap(fullValidatorSet),
 		}, nil))
 
-		updateDelta, err := stakeManager.UpdateValidatorSet(epoch+3, validators.GetPublicIdentities())
+		updateDelta, err := stakeManager.UpdateValidatorSet(epoch+3, 10, validators.GetPublicIdentities())
 		require.NoError(t, err)
 		require.Len(t, updateDelta.Added, 0)
 		require.Len(t, updateDelta.Updated, 1)
@@ -319,7 +315,7 @@ func TestStakeManager_UpdateValidatorSet(t *testing.T) {
 			Validators: newValidatorStakeMap(fullValidatorSet),
 		}, nil))
 
-		updateDelta, err := stakeManager.UpdateValidatorSet(epoch+4, validators.GetPublicIdentities())
+		updateDelta, err := stakeManager.UpdateValidatorSet(epoch+4, 10, validators.GetPublicIdentities())
 		require.NoError(t, err)
 		require.Len(t, updateDelta.Added, 0)
 		require.Len(t, updateDelta.Updated, 0)
@@ -333,7 +329,7 @@ func TestStakeManager_UpdateValidatorSet(t *testing.T) {
 			Validators: newValidatorStakeMap(fullValidatorSet),
 		}, nil))
 
-		updateDelta, err := stakeManager.UpdateValidatorSet(epoch+5, validators.GetPublicIdentities())
+		updateDelta, err := stakeManager.UpdateValidatorSet(epoch+5, 10, validators.GetPublicIdentities())
 		require.NoError(t, err)
 		require.Len(t, updateDelta.Added, 0)
 		require.Len(t, updateDelta.Updated, 0)
@@ -342,8 +338,6 @@ func TestStakeManager_UpdateValidatorSet(t *testing.T) {
 
 	t.Run("UpdateValidatorSet - max validator set size reached", func(t *testing.T) {
 		// because we now have 5 validators, and the new validator has more stake
-		stakeManager.maxValidatorSetSize = 4
-
 		fullValidatorSet := validators.GetPublicIdentities().Copy()
 		validatorToAdd := fullValidatorSet[0]
 		validatorToAdd.VotingPower = big.NewInt(11)
@@ -352,7 +346,7 @@ func TestStakeManager_UpdateValidatorSet(t *testing.T) {
 			Validators: newValidatorStakeMap(fullValidatorSet),
 		}, nil))
 
-		updateDelta, err := stakeManager.UpdateValidatorSet(epoch+6,
+		updateDelta, err := stakeManager.UpdateValidatorSet(epoch+6, 4,
 			validators.GetPublicIdentities(aliases[1:]...))
 
 		require.NoError(t, err)
@@ -480,7 +474,6 @@ func TestStakeManager_UpdateOnInit(t *testing.T) {
 		validatorSetAddr, types.StringToAddress("0x0002"),
 		bcMock,
 		polyBackendMock,
-		5,
 		nil,
 	)
 	require.NoError(t, err)
@@ -43,6 +43,7 @@ type State struct {
 	EpochStore            *EpochStore
 	ProposerSnapshotStore *ProposerSnapshotStore
 	StakeStore            *StakeStore
+	PerformanceStore      *ValidatorPerformanceStore
 }
 
 // newState creates new instance of State
@@ -60,6 +61,7 @@ func newState(path string, logger hclog.Logger, closeCh chan struct{}) (*State,
 		EpochStore:            &EpochStore{db: db},
 		ProposerSnapshotStore: &ProposerSnapshotStore{db: db},
 		StakeStore:            &StakeStore{db: db},
+		PerformanceStore:      &ValidatorPerformanceStore{db: db},
 	}
 
 	if err = s.initStorages(); err != nil {
@@ -88,6 +90,9 @@ func (s *State) initStorages() error {
 		if err := s.StakeStore.initialize(tx); err != nil {
 			return err
 		}
+		if err := s.PerformanceStore.initialize(tx); err != nil {
+			return err
+		}
 
 		_, err := tx.CreateBucketIfNotExists(edgeEventsLastProcessedBlockBucket)
 		if err != nil {
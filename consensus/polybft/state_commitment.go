@@ -0,0 +1,55 @@
+package polybft
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	errStateCommitmentMissing    = errors.New("state commitment of the epoch ending block is missing")
+	errStateCommitmentNotAllowed = errors.New("state commitment is only allowed in the epoch ending blocks " +
+		"of the chains committing their state")
+)
+
+// newStateCommitment returns the state commitment of the block with the state root,
+// nil if the chain doesn't commit its state or the block is not the epoch ending one
+func newStateCommitment(config *StateCommitmentConfig, isEndOfEpoch bool, stateRoot types.Hash) *StateCommitment {
+	if config == nil || !isEndOfEpoch {
+		return nil
+	}
+
+	return &StateCommitment{
+		StateRoot:       stateRoot,
+		ChallengeWindow: uint64(config.ChallengeWindow.Seconds()),
+	}
+}
+
+// verifyStateCommitment checks the state commitment of the checkpoint is the one expected for the block header
+func verifyStateCommitment(config *StateCommitmentConfig, isEndOfEpoch bool,
+	header *types.Header, checkpoint *CheckpointData) error {
+	expected := newStateCommitment(config, isEndOfEpoch, header.StateRoot)
+	actual := checkpoint.StateCommitment
+
+	switch {
+	case expected == nil && actual != nil:
+		return errStateCommitmentNotAllowed
+	case expected != nil && actual == nil:
+		return errStateCommitmentMissing
+	case expected == nil:
+		return nil
+	}
+
+	if actual.StateRoot != expected.StateRoot {
+		return fmt.Errorf("committed state root %s doesn't match the state root %s of the block",
+			actual.StateRoot, expected.StateRoot)
+	}
+
+	if actual.ChallengeWindow != expected.ChallengeWindow {
+		return fmt.Errorf("committed challenge window %d doesn't match the configured one %d",
+			actual.ChallengeWindow, expected.ChallengeWindow)
+	}
+
+	return nil
+}
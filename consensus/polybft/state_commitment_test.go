@@ -0,0 +1,124 @@
+package polybft
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateCommitment_NewStateCommitment(t *testing.T) {
+	t.Parallel()
+
+	config := &StateCommitmentConfig{ChallengeWindow: common.Duration{Duration: time.Hour}}
+	stateRoot := types.StringToHash("0x1")
+
+	require.Nil(t, newStateCommitment(nil, true, stateRoot))
+	require.Nil(t, newStateCommitment(config, false, stateRoot))
+	require.Equal(t,
+		&StateCommitment{StateRoot: stateRoot, ChallengeWindow: 3600},
+		newStateCommitment(config, true, stateRoot))
+}
+
+func TestStateCommitment_VerifyStateCommitment(t *testing.T) {
+	t.Parallel()
+
+	config := &StateCommitmentConfig{ChallengeWindow: common.Duration{Duration: time.Hour}}
+	header := &types.Header{Number: 10, StateRoot: types.StringToHash("0x1")}
+
+	cases := []struct {
+		name         string
+		config       *StateCommitmentConfig
+		isEndOfEpoch bool
+		commitment   *StateCommitment
+		err          string
+	}{
+		{
+			name: "no commitments",
+		},
+		{
+			name:       "commitment not enabled",
+			commitment: &StateCommitment{StateRoot: header.StateRoot, ChallengeWindow: 3600},
+			err:        errStateCommitmentNotAllowed.Error(),
+		},
+		{
+			name:       "commitment not in the epoch ending block",
+			config:     config,
+			commitment: &StateCommitment{StateRoot: header.StateRoot, ChallengeWindow: 3600},
+			err:        errStateCommitmentNotAllowed.Error(),
+		},
+		{
+			name:         "commitment missing",
+			config:       config,
+			isEndOfEpoch: true,
+			err:          errStateCommitmentMissing.Error(),
+		},
+		{
+			name:         "state root mismatch",
+			config:       config,
+			isEndOfEpoch: true,
+			commitment:   &StateCommitment{StateRoot: types.StringToHash("0x2"), ChallengeWindow: 3600},
+			err:          "committed state root",
+		},
+		{
+			name:         "challenge window mismatch",
+			config:       config,
+			isEndOfEpoch: true,
+			commitment:   &StateCommitment{StateRoot: header.StateRoot, ChallengeWindow: 60},
+			err:          "committed challenge window",
+		},
+		{
+			name:         "valid commitment",
+			config:       config,
+			isEndOfEpoch: true,
+			commitment:   &StateCommitment{StateRoot: header.StateRoot, ChallengeWindow: 3600},
+		},
+		{
+			name:   "not the epoch ending block",
+			config: config,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := verifyStateCommitment(c.config, c.isEndOfEpoch, header,
+				&CheckpointData{StateCommitment: c.commitment})
+			if c.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, c.err)
+			}
+		})
+	}
+}
+
+func TestStateCommitment_CheckpointHashUnchanged(t *testing.T) {
+	t.Parallel()
+
+	checkpoint := &CheckpointData{
+		BlockRound:            2,
+		EpochNumber:           3,
+		CurrentValidatorsHash: types.StringToHash("0x3"),
+		NextValidatorsHash:    types.StringToHash("0x4"),
+		EventRoot:             types.StringToHash("0x5"),
+	}
+
+	withCommitment := checkpoint.Copy()
+	withCommitment.StateCommitment = &StateCommitment{StateRoot: types.StringToHash("0x6"), ChallengeWindow: 3600}
+
+	blockHash := types.StringToHash("0x7")
+
+	expected, err := checkpoint.Hash(100, 10, blockHash)
+	require.NoError(t, err)
+
+	actual, err := withCommitment.Hash(100, 10, blockHash)
+	require.NoError(t, err)
+
+	require.Equal(t, expected, actual)
+	require.Nil(t, checkpoint.StateCommitment)
+}
@@ -0,0 +1,113 @@
+package polybft
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// bucket to store the performance of the validators
+	validatorPerformanceBucket = []byte("validatorPerformance")
+	// error returned if the performance of the validator does not exist in db
+	errNoValidatorPerformance = errors.New("validator performance not in db")
+)
+
+type ValidatorPerformanceStore struct {
+	db *bolt.DB
+}
+
+// initialize creates necessary buckets in DB if they don't already exist
+func (s *ValidatorPerformanceStore) initialize(tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists(validatorPerformanceBucket); err != nil {
+		return fmt.Errorf("failed to create bucket=%s: %w", string(validatorPerformanceBucket), err)
+	}
+
+	return nil
+}
+
+// insertValidatorPerformance inserts the performance records of the validators (or updates them if they exist)
+// If the passed tx is already open (not nil), it will use it to insert the records
+// If the passed tx is not open (it is nil), it will open a new transaction on db and insert the records
+func (s *ValidatorPerformanceStore) insertValidatorPerformance(records []*types.ValidatorPerformance,
+	dbTx *bolt.Tx) error {
+	insertFn := func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(validatorPerformanceBucket)
+
+		for _, record := range records {
+			raw, err := json.Marshal(record)
+			if err != nil {
+				return err
+			}
+
+			if err := bucket.Put(record.Address.Bytes(), raw); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if dbTx == nil {
+		return s.db.Update(func(tx *bolt.Tx) error {
+			return insertFn(tx)
+		})
+	}
+
+	return insertFn(dbTx)
+}
+
+// getValidatorPerformance returns the performance record of the validator if it exists
+// If the passed tx is already open (not nil), it will use it to get the record
+// If the passed tx is not open (it is nil), it will open a new transaction on db and get the record
+func (s *ValidatorPerformanceStore) getValidatorPerformance(address types.Address,
+	dbTx *bolt.Tx) (*types.ValidatorPerformance, error) {
+	var (
+		record *types.ValidatorPerformance
+		err    error
+	)
+
+	getFn := func(tx *bolt.Tx) error {
+		raw := tx.Bucket(validatorPerformanceBucket).Get(address.Bytes())
+		if raw == nil {
+			return errNoValidatorPerformance
+		}
+
+		record = new(types.ValidatorPerformance)
+
+		return json.Unmarshal(raw, record)
+	}
+
+	if dbTx == nil {
+		err = s.db.View(func(tx *bolt.Tx) error {
+			return getFn(tx)
+		})
+	} else {
+		err = getFn(dbTx)
+	}
+
+	return record, err
+}
+
+// list returns the performance records of all the validators, ordered by the validator address
+func (s *ValidatorPerformanceStore) list() ([]*types.ValidatorPerformance, error) {
+	records := []*types.ValidatorPerformance{}
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(validatorPerformanceBucket).ForEach(func(k, v []byte) error {
+			record := new(types.ValidatorPerformance)
+			if err := json.Unmarshal(v, record); err != nil {
+				return err
+			}
+
+			records = append(records, record)
+
+			return nil
+		})
+	})
+
+	return records, err
+}
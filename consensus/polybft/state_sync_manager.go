@@ -28,6 +28,8 @@ import (
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
+var errEmptyTransportMessage = errors.New("empty transport message")
+
 type Runtime interface {
 	IsActiveValidator() bool
 }
@@ -44,8 +46,11 @@ type StateSyncManager interface {
 	Close()
 	Commitment(blockNumber uint64) (*CommitmentMessageSigned, error)
 	GetStateSyncProof(stateSyncID uint64) (types.Proof, error)
+	GetStateSyncLogs(txHash types.Hash) ([]*ethgo.Log, error)
 	PostBlock(req *PostBlockRequest) error
 	PostEpoch(req *PostEpochRequest) error
+	SetBlockTrackerPollInterval(pollInterval time.Duration)
+	TrackerProgress() (head uint64, synced uint64, ok bool)
 }
 
 var _ StateSyncManager = (*dummyStateSyncManager)(nil)
@@ -63,6 +68,9 @@ func (d *dummyStateSyncManager) PostEpoch(req *PostEpochRequest) error { return
 func (d *dummyStateSyncManager) GetStateSyncProof(stateSyncID uint64) (types.Proof, error) {
 	return types.Proof{}, nil
 }
+func (d *dummyStateSyncManager) GetStateSyncLogs(txHash types.Hash) ([]*ethgo.Log, error) {
+	return nil, nil
+}
 
 // EventSubscriber implementation
 func (d *dummyStateSyncManager) GetLogFilters() map[types.Address][]types.Hash {
@@ -73,6 +81,9 @@ func (d *dummyStateSyncManager) ProcessLog(header *types.Header,
 	return nil
 }
 
+func (d *dummyStateSyncManager) SetBlockTrackerPollInterval(pollInterval time.Duration) {}
+func (d *dummyStateSyncManager) TrackerProgress() (uint64, uint64, bool)                { return 0, 0, false }
+
 // stateSyncConfig holds the configuration data of state sync manager
 type stateSyncConfig struct {
 	stateSenderAddr          types.Address
@@ -105,6 +116,9 @@ type stateSyncManager struct {
 	nextCommittedIndex uint64
 
 	runtime Runtime
+
+	// eventTracker tracks the state sync events, nil until the manager is initialized
+	eventTracker *tracker.EventTracker
 }
 
 // topic is an interface for p2p message gossiping
@@ -146,7 +160,7 @@ func (s *stateSyncManager) Close() {
 func (s *stateSyncManager) initTracker() error {
 	ctx, cancelFn := context.WithCancel(context.Background())
 
-	evtTracker := tracker.NewEventTracker(
+	s.eventTracker = tracker.NewEventTracker(
 		path.Join(s.config.dataDir, "/deposit.db"),
 		s.config.jsonrpcAddr,
 		ethgo.Address(s.config.stateSenderAddr),
@@ -161,7 +175,35 @@ func (s *stateSyncManager) initTracker() error {
 		cancelFn()
 	}()
 
-	return evtTracker.Start(ctx)
+	return s.eventTracker.Start(ctx)
+}
+
+// TrackerProgress returns the latest polled block of the root chain and the latest block
+// whose state sync events are tracked, ok is false if the tracker is not initialized
+func (s *stateSyncManager) TrackerProgress() (head uint64, synced uint64, ok bool) {
+	if s.eventTracker == nil {
+		return 0, 0, false
+	}
+
+	head, synced = s.eventTracker.Progress()
+
+	return head, synced, true
+}
+
+// SetBlockTrackerPollInterval sets the poll interval of the block tracker of the state sync events
+func (s *stateSyncManager) SetBlockTrackerPollInterval(pollInterval time.Duration) {
+	if s.eventTracker != nil {
+		s.eventTracker.SetPollInterval(pollInterval)
+	}
+}
+
+// GetStateSyncLogs returns the tracked logs emitted by the rootchain transaction
+func (s *stateSyncManager) GetStateSyncLogs(txHash types.Hash) ([]*ethgo.Log, error) {
+	if s.eventTracker == nil {
+		return nil, errors.New("state sync event tracker is not initialized")
+	}
+
+	return s.eventTracker.GetLogsByTxHash(ethgo.Hash(txHash))
 }
 
 // initTransport subscribes to bridge topics (getting votes for commitments)
@@ -179,9 +221,8 @@ func (s *stateSyncManager) initTransport() error {
 			return
 		}
 
-		var transportMsg *TransportMessage
-
-		if err := json.Unmarshal(msg.Data, &transportMsg); err != nil {
+		transportMsg, err := decodeTransportMessage(msg.Data)
+		if err != nil {
 			s.logger.Warn("failed to deliver vote", "error", err)
 
 			return
@@ -193,6 +234,29 @@ func (s *stateSyncManager) initTransport() error {
 	})
 }
 
+// decodeTransportMessage decodes the gossiped vote, checking the sizes of its hash and signature
+func decodeTransportMessage(data []byte) (*TransportMessage, error) {
+	var msg *TransportMessage
+
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, err
+	}
+
+	if msg == nil {
+		return nil, errEmptyTransportMessage
+	}
+
+	if len(msg.Hash) != types.HashLength {
+		return nil, fmt.Errorf("invalid vote hash size: %d", len(msg.Hash))
+	}
+
+	if len(msg.Signature) != bls.SignatureSize {
+		return nil, fmt.Errorf("invalid vote signature size: %d", len(msg.Signature))
+	}
+
+	return msg, nil
+}
+
 // saveVote saves the gotten vote to boltDb for later quorum check and signature aggregation
 func (s *stateSyncManager) saveVote(msg *TransportMessage) error {
 	s.lock.RLock()
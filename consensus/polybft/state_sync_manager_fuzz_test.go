@@ -0,0 +1,44 @@
+package polybft
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+)
+
+func FuzzDecodeTransportMessage(f *testing.F) {
+	msg, err := newMockMsg().sign(validator.NewTestValidator(f, "A", 1), signer.DomainStateReceiver)
+	require.NoError(f, err)
+
+	raw, err := json.Marshal(msg)
+	require.NoError(f, err)
+
+	seeds := []string{
+		string(raw),
+		`null`,
+		`{}`,
+		`{"Hash": "AQI=", "Signature": "AQI=", "From": "0x1", "EpochNumber": 1}`,
+	}
+
+	for _, seed := range seeds {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		decoded, err := decodeTransportMessage(data)
+		if err != nil {
+			return
+		}
+
+		encoded, err := json.Marshal(decoded)
+		require.NoError(t, err)
+
+		redecoded, err := decodeTransportMessage(encoded)
+		require.NoError(t, err)
+		require.Equal(t, decoded, redecoded)
+	})
+}
@@ -20,6 +20,8 @@ func decodeStateTransaction(txData []byte) (contractsapi.StateTransactionInput,
 		commitFn            contractsapi.CommitStateReceiverFn
 		commitEpochFn       contractsapi.CommitEpochValidatorSetFn
 		distributeRewardsFn contractsapi.DistributeRewardForRewardPoolFn
+		governanceFn        governanceExecuteFn
+		randomnessFn        randomnessContributeFn
 		obj                 contractsapi.StateTransactionInput
 	)
 
@@ -32,6 +34,12 @@ func decodeStateTransaction(txData []byte) (contractsapi.StateTransactionInput,
 	} else if bytes.Equal(sig, distributeRewardsFn.Sig()) {
 		// distribute rewards
 		obj = &contractsapi.DistributeRewardForRewardPoolFn{}
+	} else if bytes.Equal(sig, governanceFn.Sig()) {
+		// governance proposals
+		obj = &governanceExecuteFn{}
+	} else if bytes.Equal(sig, randomnessFn.Sig()) {
+		// randomness contribution
+		obj = &randomnessContributeFn{}
 	} else {
 		return nil, fmt.Errorf("unknown state transaction")
 	}
@@ -0,0 +1,66 @@
+package polybft
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+	"github.com/umbracle/ethgo/contract"
+)
+
+// childTokenPredicates are the predicates of the child chain mapping the rootchain tokens to the child chain tokens
+var childTokenPredicates = []struct {
+	tokenType string
+	address   types.Address
+	abi       *abi.ABI
+}{
+	{"erc20", contracts.ChildERC20PredicateContract, contractsapi.ChildERC20Predicate.Abi},
+	{"erc721", contracts.ChildERC721PredicateContract, contractsapi.ChildERC721Predicate.Abi},
+	{"erc1155", contracts.ChildERC1155PredicateContract, contractsapi.ChildERC1155Predicate.Abi},
+}
+
+// getTokenMappings reads the child chain tokens the rootchain token is mapped to from the child predicates,
+// the predicates the token isn't mapped by are omitted
+func getTokenMappings(provider contract.Provider, rootToken types.Address) ([]*types.BridgeTokenMapping, error) {
+	mappings := []*types.BridgeTokenMapping{}
+
+	for _, predicate := range childTokenPredicates {
+		method := predicate.abi.GetMethod("rootTokenToChildToken")
+
+		input, err := method.Encode([]interface{}{rootToken})
+		if err != nil {
+			return nil, err
+		}
+
+		output, err := provider.Call(ethgo.Address(predicate.address), input, &contract.CallOpts{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the %s token mapping: %w", predicate.tokenType, err)
+		}
+
+		result, err := method.Decode(output)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the %s token mapping: %w", predicate.tokenType, err)
+		}
+
+		childToken, ok := result["0"].(ethgo.Address)
+		if !ok {
+			return nil, fmt.Errorf("failed to decode the %s token mapping", predicate.tokenType)
+		}
+
+		if childToken == ethgo.ZeroAddress {
+			continue
+		}
+
+		mappings = append(mappings, &types.BridgeTokenMapping{
+			TokenType:  predicate.tokenType,
+			Predicate:  predicate.address,
+			RootToken:  rootToken,
+			ChildToken: types.Address(childToken),
+		})
+	}
+
+	return mappings, nil
+}
@@ -0,0 +1,71 @@
+package polybft
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/contract"
+)
+
+// tokenMappingProviderMock returns the child token the predicate maps the root tokens to
+type tokenMappingProviderMock struct {
+	childTokens map[ethgo.Address]ethgo.Address
+	err         error
+}
+
+func (p *tokenMappingProviderMock) Call(addr ethgo.Address, _ []byte, _ *contract.CallOpts) ([]byte, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return contractsapi.ChildERC20Predicate.Abi.GetMethod("rootTokenToChildToken").Outputs.Encode(
+		[]interface{}{p.childTokens[addr]})
+}
+
+func (p *tokenMappingProviderMock) Txn(ethgo.Address, ethgo.Key, []byte) (contract.Txn, error) {
+	return nil, nil
+}
+
+func TestTokenMapping_GetTokenMappings(t *testing.T) {
+	t.Parallel()
+
+	rootToken := types.StringToAddress("0x1")
+	childERC20 := types.StringToAddress("0x2")
+	childERC1155 := types.StringToAddress("0x3")
+
+	provider := &tokenMappingProviderMock{
+		childTokens: map[ethgo.Address]ethgo.Address{
+			ethgo.Address(contracts.ChildERC20PredicateContract):   ethgo.Address(childERC20),
+			ethgo.Address(contracts.ChildERC1155PredicateContract): ethgo.Address(childERC1155),
+		},
+	}
+
+	mappings, err := getTokenMappings(provider, rootToken)
+	require.NoError(t, err)
+	require.Equal(t, []*types.BridgeTokenMapping{
+		{
+			TokenType:  "erc20",
+			Predicate:  contracts.ChildERC20PredicateContract,
+			RootToken:  rootToken,
+			ChildToken: childERC20,
+		},
+		{
+			TokenType:  "erc1155",
+			Predicate:  contracts.ChildERC1155PredicateContract,
+			RootToken:  rootToken,
+			ChildToken: childERC1155,
+		},
+	}, mappings)
+
+	mappings, err = getTokenMappings(&tokenMappingProviderMock{}, rootToken)
+	require.NoError(t, err)
+	require.Empty(t, mappings)
+
+	_, err = getTokenMappings(&tokenMappingProviderMock{err: errors.New("execution reverted")}, rootToken)
+	require.ErrorContains(t, err, "failed to read the erc20 token mapping")
+}
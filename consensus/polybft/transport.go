@@ -1,6 +1,7 @@
 package polybft
 
 import (
+	"errors"
 	"fmt"
 
 	ibftProto "github.com/0xPolygon/go-ibft/messages/proto"
@@ -9,6 +10,12 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
+var (
+	errIbftMessageNoView      = errors.New("ibft message has no view")
+	errIbftMessageNoPayload   = errors.New("ibft message has no payload of its type")
+	errIbftMessageInvalidFrom = errors.New("ibft message has invalid sender")
+)
+
 // BridgeTransport is an abstraction of network layer for a bridge
 type BridgeTransport interface {
 	Multicast(msg interface{})
@@ -28,7 +35,14 @@ func (p *Polybft) subscribeToIbftTopic() error {
 			return
 		}
 
+		if err := validateIbftMessage(msg); err != nil {
+			p.logger.Debug("malformed validator message received", "error", err)
+
+			return
+		}
+
 		p.ibft.AddMessage(msg)
+		p.runtime.performanceTracker.recordMessage(types.BytesToAddress(msg.From))
 
 		p.logger.Debug(
 			"validator message received",
@@ -40,6 +54,37 @@ func (p *Polybft) subscribeToIbftTopic() error {
 	})
 }
 
+// validateIbftMessage checks the gossiped message has the fields the consensus relies on,
+// before its signature is verified
+func validateIbftMessage(msg *ibftProto.Message) error {
+	if msg.View == nil {
+		return errIbftMessageNoView
+	}
+
+	if len(msg.From) != types.AddressLength {
+		return fmt.Errorf("%w: %d bytes long", errIbftMessageInvalidFrom, len(msg.From))
+	}
+
+	var hasPayload bool
+
+	switch msg.Type {
+	case ibftProto.MessageType_PREPREPARE:
+		hasPayload = msg.GetPreprepareData() != nil && msg.GetPreprepareData().Proposal != nil
+	case ibftProto.MessageType_PREPARE:
+		hasPayload = msg.GetPrepareData() != nil
+	case ibftProto.MessageType_COMMIT:
+		hasPayload = msg.GetCommitData() != nil
+	case ibftProto.MessageType_ROUND_CHANGE:
+		hasPayload = msg.GetRoundChangeData() != nil
+	}
+
+	if !hasPayload {
+		return fmt.Errorf("%w: %s", errIbftMessageNoPayload, msg.Type)
+	}
+
+	return nil
+}
+
 // createTopics create all topics for a PolyBft instance
 func (p *Polybft) createTopics() (err error) {
 	if p.consensusConfig.IsBridgeEnabled() {
@@ -0,0 +1,78 @@
+package polybft
+
+import (
+	"testing"
+
+	ibftProto "github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/stretchr/testify/require"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
+)
+
+func FuzzIbftMessageUnmarshal(f *testing.F) {
+	key := wallet.NewKey(generateTestAccount(f))
+	view := &ibftProto.View{Height: 10, Round: 1}
+
+	seeds := []*ibftProto.Message{
+		{
+			View: view,
+			From: key.Address().Bytes(),
+			Type: ibftProto.MessageType_PREPREPARE,
+			Payload: &ibftProto.Message_PreprepareData{PreprepareData: &ibftProto.PrePrepareMessage{
+				Proposal:     &ibftProto.Proposal{RawProposal: []byte{1, 2, 3}, Round: 1},
+				ProposalHash: []byte{4, 5, 6},
+			}},
+		},
+		{
+			View:    view,
+			From:    key.Address().Bytes(),
+			Type:    ibftProto.MessageType_COMMIT,
+			Payload: &ibftProto.Message_CommitData{CommitData: &ibftProto.CommitMessage{CommittedSeal: []byte{1}}},
+		},
+		{
+			View:    view,
+			From:    key.Address().Bytes(),
+			Type:    ibftProto.MessageType_ROUND_CHANGE,
+			Payload: &ibftProto.Message_RoundChangeData{RoundChangeData: &ibftProto.RoundChangeMessage{}},
+		},
+	}
+
+	for _, seed := range seeds {
+		msg, err := key.SignIBFTMessage(seed)
+		require.NoError(f, err)
+
+		raw, err := protobuf.Marshal(msg)
+		require.NoError(f, err)
+
+		f.Add(raw)
+	}
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		msg := &ibftProto.Message{}
+		if err := protobuf.Unmarshal(raw, msg); err != nil {
+			return
+		}
+
+		if err := validateIbftMessage(msg); err != nil {
+			return
+		}
+
+		// the fields accessed by the consensus are present in the valid message
+		_ = msg.View.Height
+		_ = msg.View.Round
+
+		if msg.Type == ibftProto.MessageType_PREPREPARE {
+			_ = msg.GetPreprepareData().Proposal.RawProposal
+		}
+
+		payload, err := msg.PayloadNoSig()
+		if err != nil {
+			return
+		}
+
+		if address, err := wallet.RecoverAddressFromSignature(msg.Signature, payload); err == nil {
+			_ = address.Bytes()
+		}
+	})
+}
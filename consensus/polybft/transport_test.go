@@ -0,0 +1,65 @@
+package polybft
+
+import (
+	"testing"
+
+	ibftProto "github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestValidateIbftMessage(t *testing.T) {
+	t.Parallel()
+
+	from := types.StringToAddress("1").Bytes()
+	view := &ibftProto.View{Height: 1}
+	prepare := &ibftProto.Message_PrepareData{PrepareData: &ibftProto.PrepareMessage{}}
+
+	cases := []struct {
+		name string
+		msg  *ibftProto.Message
+		err  error
+	}{
+		{
+			name: "valid",
+			msg:  &ibftProto.Message{View: view, From: from, Type: ibftProto.MessageType_PREPARE, Payload: prepare},
+		},
+		{
+			name: "no view",
+			msg:  &ibftProto.Message{From: from, Type: ibftProto.MessageType_PREPARE, Payload: prepare},
+			err:  errIbftMessageNoView,
+		},
+		{
+			name: "invalid sender",
+			msg:  &ibftProto.Message{View: view, From: from[1:], Type: ibftProto.MessageType_PREPARE, Payload: prepare},
+			err:  errIbftMessageInvalidFrom,
+		},
+		{
+			name: "payload of another type",
+			msg:  &ibftProto.Message{View: view, From: from, Type: ibftProto.MessageType_COMMIT, Payload: prepare},
+			err:  errIbftMessageNoPayload,
+		},
+		{
+			name: "preprepare without proposal",
+			msg: &ibftProto.Message{View: view, From: from, Type: ibftProto.MessageType_PREPREPARE,
+				Payload: &ibftProto.Message_PreprepareData{PreprepareData: &ibftProto.PrePrepareMessage{}}},
+			err: errIbftMessageNoPayload,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateIbftMessage(c.msg)
+			if c.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, c.err)
+			}
+		})
+	}
+}
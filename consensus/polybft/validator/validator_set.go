@@ -107,6 +107,11 @@ func (vs validatorSet) TotalVotingPower() big.Int {
 	return *vs.totalVotingPower
 }
 
+// HasVotingPowerQuorum determines if the given voting power reaches the quorum of the total voting power
+func HasVotingPowerQuorum(blockNumber uint64, votingPower, totalVotingPower *big.Int) bool {
+	return votingPower.Cmp(getQuorumSize(blockNumber, totalVotingPower)) >= 0
+}
+
 // getQuorumSize calculates quorum size as 2/3 super-majority of provided total voting power
 func getQuorumSize(blockNumber uint64, totalVotingPower *big.Int) *big.Int {
 	quorum := new(big.Int)
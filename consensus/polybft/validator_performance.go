@@ -0,0 +1,135 @@
+package polybft
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// validatorPerformanceTracker keeps the registry of the validators performance up to date.
+// The proposed, signed and missed blocks are derived from the finalized blocks, so every node records the same,
+// while the consensus messages are counted as the node receives them and are flushed on the block finalization
+type validatorPerformanceTracker struct {
+	store *ValidatorPerformanceStore
+
+	lock     sync.Mutex
+	messages map[types.Address]uint64
+}
+
+// newValidatorPerformanceTracker creates the tracker of the validators performance
+func newValidatorPerformanceTracker(store *ValidatorPerformanceStore) *validatorPerformanceTracker {
+	return &validatorPerformanceTracker{
+		store:    store,
+		messages: map[types.Address]uint64{},
+	}
+}
+
+// recordMessage counts the consensus message received from the sender
+func (t *validatorPerformanceTracker) recordMessage(from types.Address) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.messages[from]++
+}
+
+// PostBlock records the proposer of the finalized block and the seals of its parent,
+// parentValidators being the validators expected to seal the parent block
+func (t *validatorPerformanceTracker) PostBlock(req *PostBlockRequest, parentValidators validator.AccountSet) error {
+	header := req.FullBlock.Block.Header
+
+	extra, err := GetIbftExtra(header.ExtraData)
+	if err != nil {
+		return err
+	}
+
+	records := map[types.Address]*types.ValidatorPerformance{}
+
+	getRecord := func(address types.Address) (*types.ValidatorPerformance, error) {
+		if record, ok := records[address]; ok {
+			return record, nil
+		}
+
+		record, err := t.store.getValidatorPerformance(address, req.DBTx)
+		if errors.Is(err, errNoValidatorPerformance) {
+			record, err = &types.ValidatorPerformance{Address: address}, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		records[address] = record
+
+		return record, nil
+	}
+
+	proposer, err := getRecord(types.BytesToAddress(header.Miner))
+	if err != nil {
+		return err
+	}
+
+	proposer.ProposedBlocks++
+
+	// the genesis block is not sealed
+	if header.Number > 1 && extra.Parent != nil {
+		signers, err := parentValidators.GetFilteredValidators(extra.Parent.Bitmap)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range parentValidators {
+			record, err := getRecord(v.Address)
+			if err != nil {
+				return err
+			}
+
+			if signers.ContainsAddress(v.Address) {
+				record.SignedBlocks++
+				record.LastSignedBlock = header.Number - 1
+			} else {
+				record.MissedBlocks++
+			}
+		}
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	// only the messages of the validators are kept, so that the peers can't fill the registry
+	for _, v := range parentValidators {
+		if count := t.messages[v.Address]; count > 0 {
+			record, err := getRecord(v.Address)
+			if err != nil {
+				return err
+			}
+
+			record.Messages += count
+		}
+	}
+
+	result := make([]*types.ValidatorPerformance, 0, len(records))
+	for _, record := range records {
+		result = append(result, record)
+	}
+
+	if err := t.store.insertValidatorPerformance(result, req.DBTx); err != nil {
+		return err
+	}
+
+	t.messages = map[types.Address]uint64{}
+
+	return nil
+}
+
+// getValidatorPerformance returns the performance of the validator from the registry
+func (t *validatorPerformanceTracker) getValidatorPerformance(address types.Address) (
+	*types.ValidatorPerformance, error) {
+	return t.store.getValidatorPerformance(address, nil)
+}
+
+// listValidatorPerformance returns the performance of all the validators in the registry
+func (t *validatorPerformanceTracker) listValidatorPerformance() ([]*types.ValidatorPerformance, error) {
+	return t.store.list()
+}
@@ -0,0 +1,79 @@
+package polybft
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/consensus"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/bitmap"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatorPerformanceTracker_PostBlock(t *testing.T) {
+	t.Parallel()
+
+	state := newTestState(t)
+	tracker := newValidatorPerformanceTracker(state.PerformanceStore)
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B", "C"}).GetPublicIdentities()
+
+	postBlock := func(number uint64, proposer types.Address, signers ...int) {
+		t.Helper()
+
+		var b bitmap.Bitmap
+		for _, i := range signers {
+			b.Set(uint64(i))
+		}
+
+		header := &types.Header{
+			Number:    number,
+			Miner:     proposer.Bytes(),
+			ExtraData: createTestExtraForAccounts(t, 1, validators, b),
+		}
+
+		require.NoError(t, tracker.PostBlock(&PostBlockRequest{
+			FullBlock: &types.FullBlock{Block: consensus.BuildBlock(consensus.BuildBlockParams{Header: header})},
+		}, validators))
+	}
+
+	_, err := tracker.getValidatorPerformance(validators[0].Address)
+	require.ErrorIs(t, err, errNoValidatorPerformance)
+
+	// the messages of the validators are flushed with the next block, the ones of the other peers are dropped
+	tracker.recordMessage(validators[1].Address)
+	tracker.recordMessage(validators[1].Address)
+	tracker.recordMessage(types.StringToAddress("0xdead"))
+
+	postBlock(2, validators[0].Address, 0, 1, 2)
+	postBlock(3, validators[1].Address, 0, 1)
+	postBlock(4, validators[0].Address, 0)
+
+	a, err := tracker.getValidatorPerformance(validators[0].Address)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), a.ProposedBlocks)
+	require.Equal(t, uint64(3), a.SignedBlocks)
+	require.Equal(t, uint64(0), a.MissedBlocks)
+	require.Equal(t, uint64(3), a.LastSignedBlock)
+	require.Equal(t, float64(1), a.Uptime())
+
+	b, err := tracker.getValidatorPerformance(validators[1].Address)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), b.ProposedBlocks)
+	require.Equal(t, uint64(2), b.SignedBlocks)
+	require.Equal(t, uint64(1), b.MissedBlocks)
+	require.Equal(t, uint64(2), b.LastSignedBlock)
+	require.Equal(t, uint64(2), b.Messages)
+
+	c, err := tracker.getValidatorPerformance(validators[2].Address)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), c.SignedBlocks)
+	require.Equal(t, uint64(2), c.MissedBlocks)
+	require.Equal(t, uint64(1), c.LastSignedBlock)
+
+	_, err = tracker.getValidatorPerformance(types.StringToAddress("0xdead"))
+	require.ErrorIs(t, err, errNoValidatorPerformance)
+
+	all, err := tracker.listValidatorPerformance()
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+}
@@ -8,12 +8,14 @@ import (
 	"github.com/0xPolygon/polygon-edge/bls"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/ethgo"
 	"github.com/umbracle/ethgo/wallet"
 )
 
-// Account is an account for key signatures
+// Account is an account for key signatures.
+// The ECDSA key is either a local key or the signer of a key held by the secrets manager
 type Account struct {
-	Ecdsa *wallet.Key
+	Ecdsa ethgo.Key
 	Bls   *bls.PrivateKey
 }
 
@@ -50,8 +52,36 @@ func NewAccountFromSecret(secretsManager secrets.SecretsManager) (*Account, erro
 	return &Account{Ecdsa: ecdsaKey, Bls: blsKey}, nil
 }
 
-// GetEcdsaFromSecret retrieves validator(ECDSA) key by using provided secretsManager
-func GetEcdsaFromSecret(secretsManager secrets.SecretsManager) (*wallet.Key, error) {
+// GenerateRemoteAccount generates a new account whose ECDSA key is created and held by the remote signer
+func GenerateRemoteAccount(signer secrets.RemoteSigner) (*Account, error) {
+	key, err := signer.CreateKey(secrets.ValidatorKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create remote key. error: %w", err)
+	}
+
+	blsKey, err := bls.GenerateBlsKey()
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate bls key. error: %w", err)
+	}
+
+	return &Account{
+		Ecdsa: key,
+		Bls:   blsKey,
+	}, nil
+}
+
+// GetEcdsaFromSecret retrieves validator(ECDSA) key by using provided secretsManager,
+// it is the signer of the remote key if the secrets manager holds the key remotely
+func GetEcdsaFromSecret(secretsManager secrets.SecretsManager) (ethgo.Key, error) {
+	if signer, ok := secrets.GetRemoteSigner(secretsManager, secrets.ValidatorKey); ok {
+		key, err := signer.GetSigner(secrets.ValidatorKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve remote ecdsa key: %w", err)
+		}
+
+		return key, nil
+	}
+
 	encodedKey, err := secretsManager.GetSecret(secrets.ValidatorKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve ecdsa key: %w", err)
@@ -85,20 +115,23 @@ func GetBlsFromSecret(secretsManager secrets.SecretsManager) (*bls.PrivateKey, e
 	return blsKey, nil
 }
 
-// Save persists ECDSA and BLS private keys to the SecretsManager
+// Save persists ECDSA and BLS private keys to the SecretsManager,
+// the remote ECDSA key is held by the secrets manager already
 func (a *Account) Save(secretsManager secrets.SecretsManager) (err error) {
 	var (
 		ecdsaRaw []byte
 		blsRaw   []byte
 	)
 
-	// get serialized ecdsa private key
-	if ecdsaRaw, err = a.Ecdsa.MarshallPrivateKey(); err != nil {
-		return err
-	}
+	if _, local := a.Ecdsa.(*wallet.Key); local {
+		// get serialized ecdsa private key
+		if ecdsaRaw, err = a.MarshalEcdsaPrivateKey(); err != nil {
+			return err
+		}
 
-	if err = secretsManager.SetSecret(secrets.ValidatorKey, []byte(hex.EncodeToString(ecdsaRaw))); err != nil {
-		return err
+		if err = secretsManager.SetSecret(secrets.ValidatorKey, []byte(hex.EncodeToString(ecdsaRaw))); err != nil {
+			return err
+		}
 	}
 
 	// get serialized bls private key
@@ -109,8 +142,18 @@ func (a *Account) Save(secretsManager secrets.SecretsManager) (err error) {
 	return secretsManager.SetSecret(secrets.ValidatorBLSKey, blsRaw)
 }
 
+// MarshalEcdsaPrivateKey returns the serialized ECDSA private key, the remote key can't be serialized
+func (a *Account) MarshalEcdsaPrivateKey() ([]byte, error) {
+	key, ok := a.Ecdsa.(*wallet.Key)
+	if !ok {
+		return nil, secrets.ErrRemoteKey
+	}
+
+	return key.MarshallPrivateKey()
+}
+
 func (a *Account) GetEcdsaPrivateKey() (*ecdsa.PrivateKey, error) {
-	ecdsaRaw, err := a.Ecdsa.MarshallPrivateKey()
+	ecdsaRaw, err := a.MarshalEcdsaPrivateKey()
 	if err != nil {
 		return nil, err
 	}
@@ -1,19 +1,42 @@
 package wallet
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/0xPolygon/go-ibft/messages/proto"
 	"github.com/umbracle/ethgo"
 	protobuf "google.golang.org/protobuf/proto"
 
+	"github.com/0xPolygon/polygon-edge/bls"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
+// ErrRemoteDigestSigning is returned when the digest is signed with the ECDSA key of the remote signer,
+// which signs only the consensus messages, so its double sign protection can't be bypassed
+var ErrRemoteDigestSigning = errors.New("the remote signer doesn't sign arbitrary digests with the ECDSA key")
+
+// ConsensusSigner signs the consensus messages with the validator keys held by a remote signer
+type ConsensusSigner interface {
+	// Address returns the address of the validator
+	Address() ethgo.Address
+	// BLSPublicKey returns the BLS public key of the validator
+	BLSPublicKey() *bls.PublicKey
+	// SignIBFTMessage signs the marshaled IBFT message with the ECDSA key
+	SignIBFTMessage(msg []byte) ([]byte, error)
+	// SignCommittedSeal signs the committed seal of the block header at the view with the BLS key
+	SignCommittedSeal(hash []byte, height, round uint64) ([]byte, error)
+	// SignBLS signs the digest with the BLS key in the domain
+	SignBLS(digest, domain []byte) ([]byte, error)
+}
+
 type Key struct {
 	raw *Account
+
+	// remote signs with the keys of the remote signer, nil if the keys are local
+	remote ConsensusSigner
 }
 
 func NewKey(raw *Account) *Key {
@@ -22,6 +45,14 @@ func NewKey(raw *Account) *Key {
 	}
 }
 
+// NewRemoteKey returns the key whose signatures are produced by the remote signer
+func NewRemoteKey(remote ConsensusSigner) *Key {
+	return &Key{
+		raw:    &Account{Ecdsa: &remoteEcdsaKey{remote: remote}},
+		remote: remote,
+	}
+}
+
 // String returns hex encoded ECDSA address
 func (k *Key) String() string {
 	return k.raw.Ecdsa.Address().String()
@@ -40,6 +71,10 @@ func (k *Key) Sign(digest []byte) ([]byte, error) {
 
 // SignWithDomain signs the provided digest with BLS key and provided domain
 func (k *Key) SignWithDomain(digest, domain []byte) ([]byte, error) {
+	if k.remote != nil {
+		return k.remote.SignBLS(digest, domain)
+	}
+
 	signature, err := k.raw.Bls.Sign(digest, domain)
 	if err != nil {
 		return nil, err
@@ -48,6 +83,15 @@ func (k *Key) SignWithDomain(digest, domain []byte) ([]byte, error) {
 	return signature.Marshal()
 }
 
+// SignCommittedSeal signs the committed seal of the proposal at the view with BLS key
+func (k *Key) SignCommittedSeal(proposalHash []byte, view *proto.View) ([]byte, error) {
+	if k.remote != nil {
+		return k.remote.SignCommittedSeal(proposalHash, view.Height, view.Round)
+	}
+
+	return k.SignWithDomain(proposalHash, signer.DomainCheckpointManager)
+}
+
 // SignIBFTMessage signs the IBFT consensus message with ECDSA key
 func (k *Key) SignIBFTMessage(msg *proto.Message) (*proto.Message, error) {
 	msgRaw, err := protobuf.Marshal(msg)
@@ -55,7 +99,13 @@ func (k *Key) SignIBFTMessage(msg *proto.Message) (*proto.Message, error) {
 		return nil, fmt.Errorf("cannot marshal message: %w", err)
 	}
 
-	if msg.Signature, err = k.raw.Ecdsa.Sign(crypto.Keccak256(msgRaw)); err != nil {
+	if k.remote != nil {
+		msg.Signature, err = k.remote.SignIBFTMessage(msgRaw)
+	} else {
+		msg.Signature, err = k.raw.Ecdsa.Sign(crypto.Keccak256(msgRaw))
+	}
+
+	if err != nil {
 		return nil, fmt.Errorf("cannot create message signature: %w", err)
 	}
 
@@ -85,3 +135,16 @@ func NewEcdsaSigner(ecdsaKey *Key) *ECDSASigner {
 func (k *ECDSASigner) Sign(b []byte) ([]byte, error) {
 	return k.raw.Ecdsa.Sign(b)
 }
+
+// remoteEcdsaKey is the ECDSA key held by the remote signer
+type remoteEcdsaKey struct {
+	remote ConsensusSigner
+}
+
+func (k *remoteEcdsaKey) Address() ethgo.Address {
+	return k.remote.Address()
+}
+
+func (k *remoteEcdsaKey) Sign([]byte) ([]byte, error) {
+	return nil, ErrRemoteDigestSigning
+}
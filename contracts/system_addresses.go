@@ -78,6 +78,9 @@ var (
 	NativeTransferPrecompile = types.StringToAddress("0x2020")
 	// BLSAggSigsVerificationPrecompile is an address of BLS aggregated signatures verificatin precompile
 	BLSAggSigsVerificationPrecompile = types.StringToAddress("0x2030")
+	// StateSyncProofVerificationPrecompile is an address of the precompile verifying the membership
+	// of the state sync events in the commitments
+	StateSyncProofVerificationPrecompile = types.StringToAddress("0x2040")
 	// ConsolePrecompile is and address of Hardhat console precompile
 	ConsolePrecompile = types.StringToAddress("0x000000000000000000636F6e736F6c652e6c6f67")
 	// AllowListContractsAddr is the address of the contract deployer allow list
@@ -92,6 +95,10 @@ var (
 	AllowListBridgeAddr = types.StringToAddress("0x0200000000000000000000000000000000000004")
 	// BlockListBridgeAddr is the address of the bridge block list
 	BlockListBridgeAddr = types.StringToAddress("0x0300000000000000000000000000000000000004")
+	// AllowListPaymasterAddr is the address of the allow list of the paymasters sponsoring the gasless transactions
+	AllowListPaymasterAddr = types.StringToAddress("0x0200000000000000000000000000000000000006")
+	// TxPolicyAddr is the address of the policy denying the transactions by their function selector or value
+	TxPolicyAddr = types.StringToAddress("0x0400000000000000000000000000000000000000")
 )
 
 // GetProxyImplementationMapping retrieves the addresses of proxy contracts that should be deployed unconditionally
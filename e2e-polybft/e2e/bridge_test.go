@@ -162,7 +162,7 @@ func TestE2E_Bridge_Transfers(t *testing.T) {
 
 		t.Logf("Withdraw sender: %s\n", senderAccount.Ecdsa.Address())
 
-		rawKey, err := senderAccount.Ecdsa.MarshallPrivateKey()
+		rawKey, err := senderAccount.MarshalEcdsaPrivateKey()
 		require.NoError(t, err)
 
 		// send withdraw transaction
@@ -1459,7 +1459,7 @@ func TestE2E_Bridge_Transfers_AccessLists(t *testing.T) {
 
 		t.Logf("Withdraw sender: %s\n", senderAccount.Ecdsa.Address())
 
-		rawKey, err := senderAccount.Ecdsa.MarshallPrivateKey()
+		rawKey, err := senderAccount.MarshalEcdsaPrivateKey()
 		require.NoError(t, err)
 
 		// send withdraw transaction.
@@ -1704,7 +1704,7 @@ func TestE2E_Bridge_NonMintableERC20Token_WithPremine(t *testing.T) {
 		validatorAcc, err := sidechain.GetAccountFromDir(validatorSrv.DataDir())
 		require.NoError(t, err)
 
-		validatorRawKey, err := validatorAcc.Ecdsa.MarshallPrivateKey()
+		validatorRawKey, err := validatorAcc.MarshalEcdsaPrivateKey()
 		require.NoError(t, err)
 
 		err = cluster.Bridge.Withdraw(
@@ -1838,3 +1838,79 @@ func TestE2E_Bridge_NonMintableERC20Token_WithPremine(t *testing.T) {
 		}
 	})
 }
+
+func TestE2E_Bridge_DepositAndWithdrawalStatus(t *testing.T) {
+	const (
+		numBlockConfirmations = 2
+		epochSize             = 10
+		amount                = 100
+	)
+
+	cluster := framework.NewTestCluster(t, 5,
+		framework.WithTestRewardToken(),
+		framework.WithNumBlockConfirmations(numBlockConfirmations),
+		framework.WithEpochSize(epochSize))
+	defer cluster.Stop()
+
+	cluster.WaitForReady(t)
+
+	polybftCfg, err := polybft.LoadPolyBFTConfig(path.Join(cluster.Config.TmpDir, chainConfigFileName))
+	require.NoError(t, err)
+
+	validatorSrv := cluster.Servers[0]
+	childJSONRPC := validatorSrv.JSONRPCAddr()
+
+	senderAccount, err := sidechain.GetAccountFromDir(validatorSrv.DataDir())
+	require.NoError(t, err)
+
+	receiver := types.StringToAddress("0xabcd")
+
+	// deposit the tokens to the receiver on the child chain
+	depositResult, err := cluster.Bridge.DepositWithResult(
+		common.ERC20,
+		polybftCfg.Bridge.RootNativeERC20Addr,
+		polybftCfg.Bridge.RootERC20PredicateAddr,
+		rootHelper.TestAccountPrivKey,
+		receiver.String(),
+		fmt.Sprintf("%d", amount),
+		"",
+		cluster.Bridge.JSONRPCAddr(),
+		rootHelper.TestAccountPrivKey,
+		false)
+	require.NoError(t, err)
+	require.Len(t, depositResult.TxHashes, 1)
+
+	require.NoError(t, cluster.Bridge.WaitForDepositStatus(childJSONRPC, depositResult.TxHashes[0],
+		types.BridgeTxExecuted, 2*time.Minute))
+
+	balance, err := validatorSrv.JSONRPC().Eth().GetBalance(ethgo.Address(receiver), ethgo.Latest)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(amount), balance)
+
+	// withdraw the tokens of the validator back to the receiver on the root chain
+	rawKey, err := senderAccount.MarshalEcdsaPrivateKey()
+	require.NoError(t, err)
+
+	withdrawResult, err := cluster.Bridge.WithdrawWithResult(
+		common.ERC20,
+		hex.EncodeToString(rawKey),
+		receiver.String(),
+		fmt.Sprintf("%d", amount),
+		"",
+		childJSONRPC,
+		contracts.ChildERC20PredicateContract,
+		contracts.NativeERC20TokenContract,
+		false)
+	require.NoError(t, err)
+	require.Len(t, withdrawResult.TxHashes, 1)
+	require.Len(t, withdrawResult.ExitEventIDs, 1)
+
+	// the exit is ready once the block of the withdrawal is checkpointed on the root chain
+	require.NoError(t, cluster.Bridge.WaitForWithdrawalStatus(childJSONRPC, withdrawResult.TxHashes[0],
+		types.BridgeTxExitReady, 3*time.Minute))
+
+	require.NoError(t, cluster.Bridge.ExitAll(polybftCfg.Bridge.ExitHelperAddr, withdrawResult, childJSONRPC))
+
+	require.NoError(t, cluster.Bridge.WaitForWithdrawalStatus(childJSONRPC, withdrawResult.TxHashes[0],
+		types.BridgeTxExited, time.Minute))
+}
@@ -0,0 +1,181 @@
+package e2e
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/wallet"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft"
+	"github.com/0xPolygon/polygon-edge/e2e-polybft/framework"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful/kvstore"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// The blocks with conflicting transactions, executed concurrently by all the nodes,
+// yield the same state on every node
+func TestE2E_ParallelExecution_Determinism(t *testing.T) {
+	const (
+		sendersNum = 4
+		txsNum     = 10
+	)
+
+	senders := make([]*wallet.Key, sendersNum)
+	premine := make([]types.Address, sendersNum)
+
+	for i := range senders {
+		key, err := wallet.GenerateKey()
+		require.NoError(t, err)
+
+		senders[i] = key
+		premine[i] = types.Address(key.Address())
+	}
+
+	cluster := framework.NewTestCluster(t, 5,
+		framework.WithNativeTokenConfig(fmt.Sprintf(framework.NativeTokenMintableTestCfg, senders[0].Address())),
+		framework.WithPremine(premine...),
+		framework.WithBurnContract(&polybft.BurnContractInfo{BlockNumber: 0, Address: types.ZeroAddress}),
+		framework.WithNonValidators(1),
+		framework.WithParallelExecution(),
+	)
+	defer cluster.Stop()
+
+	cluster.WaitForReady(t)
+
+	client := cluster.Servers[0].JSONRPC().Eth()
+
+	// every sender transfers to the shared receiver, so the transactions of the same block conflict
+	shared, err := wallet.GenerateKey()
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+
+	for _, sender := range senders {
+		wg.Add(1)
+
+		go func(sender *wallet.Key) {
+			defer wg.Done()
+
+			for i := 0; i < txsNum; i++ {
+				to := shared.Address()
+				if i%2 == 0 {
+					key, err := wallet.GenerateKey()
+					require.NoError(t, err)
+
+					to = key.Address()
+				}
+
+				sendTransaction(t, client, sender, &ethgo.Transaction{
+					From:     sender.Address(),
+					To:       &to,
+					Gas:      30000,
+					GasPrice: ethgo.Gwei(2).Uint64(),
+					Value:    big.NewInt(1),
+					Nonce:    uint64(i),
+				})
+			}
+		}(sender)
+	}
+
+	wg.Wait()
+
+	expected := big.NewInt(sendersNum * txsNum / 2)
+
+	err = cluster.WaitUntil(2*time.Minute, 2*time.Second, func() bool {
+		balance, err := client.GetBalance(shared.Address(), ethgo.Latest)
+		if err != nil {
+			return true
+		}
+
+		return balance.Cmp(expected) == 0
+	})
+	require.NoError(t, err)
+
+	head, err := client.BlockNumber()
+	require.NoError(t, err)
+
+	require.NoError(t, cluster.WaitForBlock(head, 1*time.Minute))
+
+	// all the nodes agree on the blocks, so the state roots are the same
+	for number := uint64(1); number <= head; number++ {
+		expectedBlock, err := client.GetBlockByNumber(ethgo.BlockNumber(number), false)
+		require.NoError(t, err)
+
+		for _, srv := range cluster.Servers[1:] {
+			block, err := srv.JSONRPC().Eth().GetBlockByNumber(ethgo.BlockNumber(number), false)
+			require.NoError(t, err)
+			require.Equal(t, expectedBlock.Hash, block.Hash)
+			require.Equal(t, expectedBlock.StateRoot, block.StateRoot)
+		}
+	}
+}
+
+// The stateful precompile is activated at the fork block,
+// keeping the values set by the accounts in its own state
+func TestE2E_StatefulPrecompile(t *testing.T) {
+	const forkBlock = 5
+
+	var (
+		store = types.StringToAddress("0x1000")
+		key   = types.StringToHash("0x1")
+		value = types.StringToHash("0x2")
+	)
+
+	sender, err := wallet.GenerateKey()
+	require.NoError(t, err)
+
+	cluster := framework.NewTestCluster(t, 4,
+		framework.WithNativeTokenConfig(fmt.Sprintf(framework.NativeTokenMintableTestCfg, sender.Address())),
+		framework.WithPremine(types.Address(sender.Address())),
+		framework.WithStatefulPrecompile(kvstore.Name, store, forkBlock),
+	)
+	defer cluster.Stop()
+
+	cluster.WaitForReady(t)
+
+	client := cluster.Servers[0].JSONRPC().Eth()
+	storeAddr := ethgo.Address(store)
+
+	getInput, err := kvstore.GetFunc.Encode([]interface{}{sender.Address(), key})
+	require.NoError(t, err)
+
+	setInput, err := kvstore.SetFunc.Encode([]interface{}{key, value})
+	require.NoError(t, err)
+
+	require.NoError(t, cluster.WaitForBlock(forkBlock, 1*time.Minute))
+
+	// the precompile is called through the contract interface, checking the code of the callee
+	code, err := client.GetCode(storeAddr, ethgo.Latest)
+	require.NoError(t, err)
+	require.NotEqual(t, "0x", code)
+
+	txn := cluster.MethodTxn(t, sender, store, setInput)
+	require.NoError(t, txn.Wait())
+	require.True(t, txn.Succeed())
+
+	require.Len(t, txn.Receipt().Logs, 1)
+	require.Equal(t, storeAddr, txn.Receipt().Logs[0].Address)
+	require.Equal(t, kvstore.ValueSetEvent.ID(), txn.Receipt().Logs[0].Topics[0])
+
+	resp, err := client.Call(&ethgo.CallMsg{To: &storeAddr, Data: getInput}, ethgo.Latest)
+	require.NoError(t, err)
+	require.Equal(t, value.String(), resp)
+
+	// the values are stored per account, so the value of the other account is empty
+	otherInput, err := kvstore.GetFunc.Encode([]interface{}{ethgo.ZeroAddress, key})
+	require.NoError(t, err)
+
+	resp, err = client.Call(&ethgo.CallMsg{To: &storeAddr, Data: otherInput}, ethgo.Latest)
+	require.NoError(t, err)
+	require.Equal(t, types.ZeroHash.String(), resp)
+
+	// the precompile wasn't active before the fork block
+	resp, err = client.Call(&ethgo.CallMsg{To: &storeAddr, Data: getInput}, ethgo.BlockNumber(forkBlock-1))
+	require.NoError(t, err)
+	require.Equal(t, "0x", resp)
+}
@@ -0,0 +1,170 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/e2e-polybft/framework"
+	"github.com/0xPolygon/polygon-edge/secrets"
+)
+
+// kmsEmulator emulates the encrypt and decrypt methods of Cloud KMS,
+// the ciphertext is the plaintext prefixed by the additional authenticated data
+func kmsEmulator(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Plaintext  string `json:"plaintext"`
+		Ciphertext string `json:"ciphertext"`
+		AAD        string `json:"additionalAuthenticatedData"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	switch {
+	case strings.HasSuffix(r.URL.Path, ":encrypt"):
+		_ = json.NewEncoder(w).Encode(map[string]string{"ciphertext": req.AAD + "." + req.Plaintext})
+	case strings.HasSuffix(r.URL.Path, ":decrypt"):
+		aad, plaintext, _ := strings.Cut(req.Ciphertext, ".")
+		if aad != req.AAD {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]string{"plaintext": plaintext})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// keyVaultEmulator emulates the Azure AD token endpoint and the secrets of Azure Key Vault
+type keyVaultEmulator struct {
+	lock    sync.Mutex
+	secrets map[string][]byte
+}
+
+func (e *keyVaultEmulator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if strings.HasSuffix(r.URL.Path, "/oauth2/v2.0/token") {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token", "expires_in": 3600})
+
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/secrets/")
+
+	switch r.Method {
+	case http.MethodGet:
+		secret, ok := e.secrets[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		_, _ = w.Write(secret)
+	case http.MethodPut:
+		var buf bytes.Buffer
+
+		_, _ = buf.ReadFrom(r.Body)
+		e.secrets[name] = buf.Bytes()
+
+		_, _ = w.Write(buf.Bytes())
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestE2E_SecretsManagers_Emulators(t *testing.T) {
+	if strings.ToLower(os.Getenv("E2E_TESTS")) != "true" {
+		t.Skip("E2E tests are disabled.")
+	}
+
+	kmsServer := httptest.NewServer(http.HandlerFunc(kmsEmulator))
+	defer kmsServer.Close()
+
+	keyVaultServer := httptest.NewServer(&keyVaultEmulator{secrets: make(map[string][]byte)})
+	defer keyVaultServer.Close()
+
+	addressRegex := regexp.MustCompile(`\(address\)\s*=\s*(0x[a-fA-F0-9]{40})`)
+
+	cases := []struct {
+		managerType secrets.SecretsManagerType
+		args        []string
+	}{
+		{
+			managerType: secrets.GCPKMS,
+			args: []string{
+				"--server-url", kmsServer.URL + "/",
+				"--extra", fmt.Sprintf(
+					"kms-key=projects/edge/locations/global/keyRings/edge/cryptoKeys/secrets,secrets-dir=%s",
+					t.TempDir(),
+				),
+			},
+		},
+		{
+			managerType: secrets.AzureKeyVault,
+			args: []string{
+				"--server-url", keyVaultServer.URL,
+				"--token", "client-secret",
+				"--extra", fmt.Sprintf("tenant-id=tenant,client-id=client,authority-host=%s", keyVaultServer.URL),
+			},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(string(c.managerType), func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "secretsManagerConfig.json")
+
+			require.NoError(t, framework.RunEdgeCommand(append([]string{
+				"secrets", "generate",
+				"--type", string(c.managerType),
+				"--name", "node",
+				"--dir", configPath,
+			}, c.args...), os.Stdout))
+
+			// the secrets are generated and stored through the secrets manager
+			var initOut bytes.Buffer
+
+			require.NoError(t, framework.RunEdgeCommand([]string{
+				"polybft-secrets", "--config", configPath,
+			}, &initOut))
+
+			initAddress := addressRegex.FindStringSubmatch(initOut.String())
+			require.Len(t, initAddress, 2, initOut.String())
+
+			// and the same secrets are read back
+			var outputOut bytes.Buffer
+
+			require.NoError(t, framework.RunEdgeCommand([]string{
+				"polybft-secrets", "--config", configPath, "--output",
+			}, &outputOut))
+
+			outputAddress := addressRegex.FindStringSubmatch(outputOut.String())
+			require.Len(t, outputAddress, 2, outputOut.String())
+			require.Equal(t, initAddress[1], outputAddress[1])
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package framework
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"path"
 	"strconv"
@@ -11,6 +14,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/umbracle/ethgo/jsonrpc"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/0xPolygon/polygon-edge/command"
@@ -48,6 +52,11 @@ func NewTestBridge(t *testing.T, clusterConfig *TestClusterConfig) (*TestBridge,
 }
 
 func (t *TestBridge) Start() error {
+	if t.clusterConfig.RootchainJSONRPC != "" {
+		// the rootchain is already running, so it is only waited for
+		return t.waitForRootchain(30 * time.Second)
+	}
+
 	// Build arguments
 	args := []string{
 		"rootchain",
@@ -72,6 +81,11 @@ func (t *TestBridge) Start() error {
 }
 
 func (t *TestBridge) Stop() {
+	if t.node == nil {
+		// the rootchain is not started by the bridge
+		return
+	}
+
 	if err := t.node.Stop(); err != nil {
 		t.t.Error(err)
 	}
@@ -80,6 +94,10 @@ func (t *TestBridge) Stop() {
 }
 
 func (t *TestBridge) JSONRPCAddr() string {
+	if t.clusterConfig.RootchainJSONRPC != "" {
+		return t.clusterConfig.RootchainJSONRPC
+	}
+
 	return fmt.Sprintf("http://%s:%d", hostIP, 8545)
 }
 
@@ -105,28 +123,59 @@ func (t *TestBridge) WaitUntil(pollFrequency, timeout time.Duration, handler fun
 	}
 }
 
+// waitForRootchain waits until the JSON-RPC endpoint of the already running rootchain responds
+func (t *TestBridge) waitForRootchain(timeout time.Duration) error {
+	client, err := jsonrpc.NewClient(t.JSONRPCAddr())
+	if err != nil {
+		return err
+	}
+
+	defer client.Close()
+
+	if err := t.WaitUntil(500*time.Millisecond, timeout, func() (bool, error) {
+		_, err := client.Eth().BlockNumber()
+
+		return err == nil, nil
+	}); err != nil {
+		return fmt.Errorf("rootchain %s is not reachable: %w", t.JSONRPCAddr(), err)
+	}
+
+	return nil
+}
+
 // Deposit function invokes bridge deposit of ERC tokens (from the root to the child chain)
 // with given receivers, amounts and/or token ids
 func (t *TestBridge) Deposit(token bridgeCommon.TokenType, rootTokenAddr, rootPredicateAddr types.Address,
 	senderKey, receivers, amounts, tokenIDs, jsonRPCAddr, minterKey string, childChainMintable bool) error {
+	_, err := t.DepositWithResult(token, rootTokenAddr, rootPredicateAddr,
+		senderKey, receivers, amounts, tokenIDs, jsonRPCAddr, minterKey, childChainMintable)
+
+	return err
+}
+
+// DepositWithResult invokes bridge deposit of ERC tokens the same way as Deposit does,
+// returning the result of the deposit, which holds the hashes of the deposit transactions
+func (t *TestBridge) DepositWithResult(token bridgeCommon.TokenType, rootTokenAddr, rootPredicateAddr types.Address,
+	senderKey, receivers, amounts, tokenIDs, jsonRPCAddr, minterKey string,
+	childChainMintable bool) (*bridgeCommon.BridgeTxResult, error) {
 	args := []string{}
 
 	if receivers == "" {
-		return errors.New("provide at least one receiver address value")
+		return nil, errors.New("provide at least one receiver address value")
 	}
 
 	if jsonRPCAddr == "" {
-		return errors.New("provide a JSON RPC endpoint URL")
+		return nil, errors.New("provide a JSON RPC endpoint URL")
 	}
 
 	switch token {
 	case bridgeCommon.ERC20:
 		if amounts == "" {
-			return errors.New("provide at least one amount value")
+			return nil, errors.New("provide at least one amount value")
 		}
 
 		if tokenIDs != "" {
-			return errors.New("not expected to provide token ids for ERC 20 deposits")
+			return nil, errors.New("not expected to provide token ids for ERC 20 deposits")
 		}
 
 		args = append(args,
@@ -146,7 +195,7 @@ func (t *TestBridge) Deposit(token bridgeCommon.TokenType, rootTokenAddr, rootPr
 
 	case bridgeCommon.ERC721:
 		if tokenIDs == "" {
-			return errors.New("provide at least one token id value")
+			return nil, errors.New("provide at least one token id value")
 		}
 
 		args = append(args,
@@ -166,11 +215,11 @@ func (t *TestBridge) Deposit(token bridgeCommon.TokenType, rootTokenAddr, rootPr
 
 	case bridgeCommon.ERC1155:
 		if amounts == "" {
-			return errors.New("provide at least one amount value")
+			return nil, errors.New("provide at least one amount value")
 		}
 
 		if tokenIDs == "" {
-			return errors.New("provide at least one token id value")
+			return nil, errors.New("provide at least one token id value")
 		}
 
 		args = append(args,
@@ -190,7 +239,7 @@ func (t *TestBridge) Deposit(token bridgeCommon.TokenType, rootTokenAddr, rootPr
 		}
 	}
 
-	return t.cmdRun(args...)
+	return t.cmdRunWithResult(args...)
 }
 
 // Withdraw function is used to invoke bridge withdrawals for any kind of ERC tokens (from the child to the root chain)
@@ -198,16 +247,27 @@ func (t *TestBridge) Deposit(token bridgeCommon.TokenType, rootTokenAddr, rootPr
 func (t *TestBridge) Withdraw(token bridgeCommon.TokenType,
 	senderKey, receivers, amounts, tokenIDs, jsonRPCAddr string,
 	childPredicate, childToken types.Address, childChainMintable bool) error {
+	_, err := t.WithdrawWithResult(token, senderKey, receivers, amounts, tokenIDs, jsonRPCAddr,
+		childPredicate, childToken, childChainMintable)
+
+	return err
+}
+
+// WithdrawWithResult invokes bridge withdrawals the same way as Withdraw does,
+// returning the result of the withdrawals, which holds the hashes of the withdrawal transactions and the exit event ids
+func (t *TestBridge) WithdrawWithResult(token bridgeCommon.TokenType,
+	senderKey, receivers, amounts, tokenIDs, jsonRPCAddr string,
+	childPredicate, childToken types.Address, childChainMintable bool) (*bridgeCommon.BridgeTxResult, error) {
 	if senderKey == "" {
-		return errors.New("provide hex-encoded sender private key")
+		return nil, errors.New("provide hex-encoded sender private key")
 	}
 
 	if receivers == "" {
-		return errors.New("provide at least one receiver address value")
+		return nil, errors.New("provide at least one receiver address value")
 	}
 
 	if jsonRPCAddr == "" {
-		return errors.New("provide a JSON RPC endpoint URL")
+		return nil, errors.New("provide a JSON RPC endpoint URL")
 	}
 
 	args := []string{}
@@ -215,11 +275,11 @@ func (t *TestBridge) Withdraw(token bridgeCommon.TokenType,
 	switch token {
 	case bridgeCommon.ERC20:
 		if amounts == "" {
-			return errors.New("provide at least one amount value")
+			return nil, errors.New("provide at least one amount value")
 		}
 
 		if tokenIDs != "" {
-			return errors.New("not expected to provide token ids for ERC 20 withdrawals")
+			return nil, errors.New("not expected to provide token ids for ERC 20 withdrawals")
 		}
 
 		args = append(args,
@@ -238,7 +298,7 @@ func (t *TestBridge) Withdraw(token bridgeCommon.TokenType,
 
 	case bridgeCommon.ERC721:
 		if tokenIDs == "" {
-			return errors.New("provide at least one token id value")
+			return nil, errors.New("provide at least one token id value")
 		}
 
 		args = append(args,
@@ -257,11 +317,11 @@ func (t *TestBridge) Withdraw(token bridgeCommon.TokenType,
 
 	case bridgeCommon.ERC1155:
 		if amounts == "" {
-			return errors.New("provide at least one amount value")
+			return nil, errors.New("provide at least one amount value")
 		}
 
 		if tokenIDs == "" {
-			return errors.New("provide at least one token id value")
+			return nil, errors.New("provide at least one token id value")
 		}
 
 		args = append(args,
@@ -280,7 +340,7 @@ func (t *TestBridge) Withdraw(token bridgeCommon.TokenType,
 		}
 	}
 
-	return t.cmdRun(args...)
+	return t.cmdRunWithResult(args...)
 }
 
 // SendExitTransaction sends exit transaction to the root chain
@@ -300,11 +360,109 @@ func (t *TestBridge) SendExitTransaction(exitHelper types.Address, exitID uint64
 	)
 }
 
+// ExitAll sends the exit transactions of all the exit events of the given withdrawal result to the root chain
+func (t *TestBridge) ExitAll(exitHelper types.Address, result *bridgeCommon.BridgeTxResult,
+	childJSONRPCAddr string) error {
+	for _, exitID := range result.ExitEventIDs {
+		if err := t.SendExitTransaction(exitHelper, exitID.Uint64(), childJSONRPCAddr); err != nil {
+			return fmt.Errorf("failed to send exit transaction for exit event %d: %w", exitID, err)
+		}
+	}
+
+	return nil
+}
+
+// WaitForDepositStatus waits until all the state syncs emitted by the deposit transaction on the root chain
+// reach the given status, as reported by the bridge_getDepositStatus endpoint of the child chain
+func (t *TestBridge) WaitForDepositStatus(childJSONRPCAddr string, txHash types.Hash,
+	status types.BridgeTxStatus, timeout time.Duration) error {
+	return t.waitForBridgeStatus(childJSONRPCAddr, "bridge_getDepositStatus", txHash, status, timeout)
+}
+
+// WaitForWithdrawalStatus waits until all the exit events emitted by the withdrawal transaction on the child chain
+// reach the given status, as reported by the bridge_getWithdrawalStatus endpoint of the child chain
+func (t *TestBridge) WaitForWithdrawalStatus(childJSONRPCAddr string, txHash types.Hash,
+	status types.BridgeTxStatus, timeout time.Duration) error {
+	return t.waitForBridgeStatus(childJSONRPCAddr, "bridge_getWithdrawalStatus", txHash, status, timeout)
+}
+
+func (t *TestBridge) waitForBridgeStatus(childJSONRPCAddr, method string, txHash types.Hash,
+	status types.BridgeTxStatus, timeout time.Duration) error {
+	client, err := jsonrpc.NewClient(childJSONRPCAddr)
+	if err != nil {
+		return err
+	}
+
+	defer client.Close()
+
+	// the error is kept, since the transfer isn't found until the child chain processes it
+	var lastErr error
+
+	err = t.WaitUntil(time.Second, timeout, func() (bool, error) {
+		var statuses []*types.BridgeTransferStatus
+
+		if lastErr = client.Call(method, &statuses, txHash); lastErr != nil {
+			return false, nil
+		}
+
+		for _, s := range statuses {
+			if bridgeStatusStage(s.Status) < bridgeStatusStage(status) {
+				return false, nil
+			}
+		}
+
+		return len(statuses) > 0, nil
+	})
+	if err != nil && lastErr != nil {
+		return fmt.Errorf("%s of %s didn't reach %s status: %w (last error: %v)", method, txHash, status, err, lastErr)
+	} else if err != nil {
+		return fmt.Errorf("%s of %s didn't reach %s status: %w", method, txHash, status, err)
+	}
+
+	return nil
+}
+
+// bridgeStatusStage returns the order of the status of the deposit or withdrawal,
+// so that a transfer which went past the awaited status is considered to reach it
+func bridgeStatusStage(status types.BridgeTxStatus) int {
+	switch status {
+	case types.BridgeTxCommitted, types.BridgeTxExitReady:
+		return 1
+	case types.BridgeTxExecuted, types.BridgeTxExited:
+		return 2
+	default:
+		return 0
+	}
+}
+
 // cmdRun executes arbitrary command from the given binary
 func (t *TestBridge) cmdRun(args ...string) error {
 	return runCommand(t.clusterConfig.Binary, args, t.clusterConfig.GetStdout("bridge"))
 }
 
+// cmdRunWithResult executes the bridge command from the given binary with JSON output,
+// decoding the result of the bridge transactions it sent
+func (t *TestBridge) cmdRunWithResult(args ...string) (*bridgeCommon.BridgeTxResult, error) {
+	var stdout bytes.Buffer
+
+	args = append(args, "--"+command.JSONOutputFlag)
+
+	if err := runCommand(t.clusterConfig.Binary, args,
+		io.MultiWriter(&stdout, t.clusterConfig.GetStdout("bridge"))); err != nil {
+		return nil, err
+	}
+
+	// the result is written on the last line of the output
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+
+	result := &bridgeCommon.BridgeTxResult{}
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), result); err != nil {
+		return nil, fmt.Errorf("failed to decode the result of the bridge command: %w", err)
+	}
+
+	return result, nil
+}
+
 // deployRootchainContracts deploys and initializes rootchain contracts
 func (t *TestBridge) deployRootchainContracts(genesisPath string) error {
 	polybftConfig, err := polybft.LoadPolyBFTConfig(genesisPath)
@@ -319,6 +477,7 @@ func (t *TestBridge) deployRootchainContracts(genesisPath string) error {
 		"--stake-token", polybftConfig.Bridge.StakeTokenAddr.String(),
 		"--proxy-contracts-admin", t.clusterConfig.GetProxyContractsAdmin(),
 		"--genesis", genesisPath,
+		"--json-rpc", t.JSONRPCAddr(),
 		"--test",
 	}
 
@@ -361,6 +520,7 @@ func (t *TestBridge) fundAddressesOnRoot(tokenConfig *polybft.TokenConfig, polyb
 	args := []string{
 		"rootchain",
 		"fund",
+		"--json-rpc", t.JSONRPCAddr(),
 	}
 
 	for _, premineRaw := range t.clusterConfig.Premine {
@@ -516,6 +676,7 @@ func (t *TestBridge) FundValidators(tokenAddress types.Address, secretsPaths []s
 	args := []string{
 		"rootchain",
 		"fund",
+		"--json-rpc", t.JSONRPCAddr(),
 		"--stake-token", tokenAddress.String(),
 		"--mint",
 	}
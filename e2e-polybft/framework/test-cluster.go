@@ -43,6 +43,10 @@ const (
 	// envStdoutEnabled signal whether the output of the nodes get piped to stdout
 	envStdoutEnabled = "E2E_STDOUT"
 
+	// envRootchainJSONRPC is the JSON-RPC endpoint of an already running rootchain (geth or anvil dev node),
+	// used instead of starting the rootchain server
+	envRootchainJSONRPC = "E2E_ROOTCHAIN_JSONRPC"
+
 	// prefix for validator directory
 	defaultValidatorPrefix = "test-chain-"
 
@@ -134,8 +138,15 @@ type TestClusterConfig struct {
 
 	RootTrackerPollInterval time.Duration
 
+	// RootchainJSONRPC is the JSON-RPC endpoint of an already running rootchain, if set
+	RootchainJSONRPC string
+
 	ProxyContractsAdmin string
 
+	ParallelExecution bool
+
+	StatefulPrecompiles []string
+
 	logsDirOnce sync.Once
 }
 
@@ -399,6 +410,26 @@ func WithProxyContractsAdmin(address string) ClusterOption {
 	}
 }
 
+// WithRootchainJSONRPC runs the cluster against the already running rootchain (geth or anvil dev node),
+// whose first account is unlocked and funded, instead of starting the rootchain server
+func WithRootchainJSONRPC(jsonRPCAddr string) ClusterOption {
+	return func(h *TestClusterConfig) {
+		h.RootchainJSONRPC = jsonRPCAddr
+	}
+}
+
+func WithParallelExecution() ClusterOption {
+	return func(h *TestClusterConfig) {
+		h.ParallelExecution = true
+	}
+}
+
+func WithStatefulPrecompile(name string, address types.Address, block uint64) ClusterOption {
+	return func(h *TestClusterConfig) {
+		h.StatefulPrecompiles = append(h.StatefulPrecompiles, fmt.Sprintf("%s:%s:%d", name, address, block))
+	}
+}
+
 func isTrueEnv(e string) bool {
 	return strings.ToLower(os.Getenv(e)) == "true"
 }
@@ -425,6 +456,8 @@ func NewTestCluster(t *testing.T, validatorsCount int, opts ...ClusterOption) *T
 		EpochReward:   1,
 		BlockGasLimit: 1e7, // 10M
 		StakeAmounts:  []*big.Int{},
+
+		RootchainJSONRPC: os.Getenv(envRootchainJSONRPC),
 	}
 
 	if config.ValidatorPrefix == "" {
@@ -612,6 +645,10 @@ func NewTestCluster(t *testing.T, validatorsCount int, opts ...ClusterOption) *T
 		}
 		args = append(args, "--proxy-contracts-admin", proxyAdminAddr)
 
+		for _, precompile := range cluster.Config.StatefulPrecompiles {
+			args = append(args, "--stateful-precompile", precompile)
+		}
+
 		// run genesis command with all the arguments
 		err = cluster.cmdRun(args...)
 		require.NoError(t, err)
@@ -703,6 +740,7 @@ func (c *TestCluster) InitTestServer(t *testing.T,
 		config.Relayer = nodeType.IsSet(Relayer)
 		config.NumBlockConfirmations = c.Config.NumBlockConfirmations
 		config.BridgeJSONRPC = bridgeJSONRPC
+		config.ParallelExecution = c.Config.ParallelExecution
 	})
 
 	// watch the server for stop signals. It is important to fix the specific
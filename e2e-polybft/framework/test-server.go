@@ -38,6 +38,7 @@ type TestServerConfig struct {
 	Relayer               bool
 	NumBlockConfirmations uint64
 	BridgeJSONRPC         string
+	ParallelExecution     bool
 }
 
 type TestServerConfigCallback func(*TestServerConfig)
@@ -176,6 +177,10 @@ func (t *TestServer) Start() {
 		args = append(args, "--relayer")
 	}
 
+	if config.ParallelExecution {
+		args = append(args, "--parallel-execution")
+	}
+
 	// Start the server
 	stdout := t.clusterConfig.GetStdout(t.config.Name)
 
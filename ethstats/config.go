@@ -0,0 +1,72 @@
+package ethstats
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+)
+
+const (
+	// defaultPath is the path of the websocket endpoint of the ethstats servers
+	defaultPath = "/api"
+
+	secretParam = "secret"
+	nameParam   = "name"
+)
+
+var (
+	errInvalidScheme = errors.New("the ethstats URL scheme has to be ws or wss")
+	errNoSecret      = errors.New("the ethstats URL has to set the secret, ws://host:port?secret=<secret>")
+)
+
+// Config is the ethstats server the statistics of the node are reported to
+type Config struct {
+	// URL is the websocket endpoint of the ethstats server, without the secret and the node name
+	URL string
+	// Secret is the secret the node logs in to the server with
+	Secret string
+	// Name is the name of the node on the dashboard, the host name if not set
+	Name string
+}
+
+// ParseURL parses the ethstats URL, ws://host:port?secret=<secret>[&name=<node name>]
+func ParseURL(raw string) (*Config, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ethstats URL: %w", err)
+	}
+
+	if u.Scheme != "ws" && u.Scheme != "wss" {
+		return nil, errInvalidScheme
+	}
+
+	query := u.Query()
+
+	config := &Config{
+		Secret: query.Get(secretParam),
+		Name:   query.Get(nameParam),
+	}
+
+	if config.Secret == "" {
+		return nil, errNoSecret
+	}
+
+	if config.Name == "" {
+		if config.Name, err = os.Hostname(); err != nil {
+			return nil, fmt.Errorf("the ethstats node name is not set and the host name is unknown: %w", err)
+		}
+	}
+
+	query.Del(secretParam)
+	query.Del(nameParam)
+
+	u.RawQuery = query.Encode()
+	if u.Path == "" {
+		u.Path = defaultPath
+	}
+
+	config.URL = u.String()
+
+	return config, nil
+}
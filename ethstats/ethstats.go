@@ -0,0 +1,475 @@
+package ethstats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/versioning"
+)
+
+const (
+	// reportInterval is the interval of the full reports, besides the reports of the new blocks
+	reportInterval = 15 * time.Second
+	// reconnectInterval is the interval of the reconnection attempts after the connection failed
+	reconnectInterval = 10 * time.Second
+	// messageTimeout is the timeout of the login, the ping and the writes
+	messageTimeout = 5 * time.Second
+	// historyLength is the number of the most recent blocks reported on the login
+	historyLength = 50
+
+	protocolName = "polygon-edge"
+)
+
+var (
+	errUnauthorized = errors.New("the ethstats server rejected the login")
+	errPingTimeout  = errors.New("the ethstats server did not answer the ping")
+)
+
+// Blockchain is the chain the blocks are reported of
+type Blockchain interface {
+	Header() *types.Header
+	GetBlockByNumber(number uint64, full bool) (*types.Block, bool)
+	GetTD(hash types.Hash) (*big.Int, bool)
+	SubscribeEvents() blockchain.Subscription
+	UnsubscribeEvents(sub blockchain.Subscription)
+}
+
+// NodeStats provides the statistics of the node
+type NodeStats interface {
+	PeerCount() int
+	Syncing() bool
+	Mining() bool
+	PendingTxs() uint64
+	GasPrice() *big.Int
+}
+
+// Service reports the blocks, the peers and the sync status of the node to the ethstats server
+type Service struct {
+	logger hclog.Logger
+	config *Config
+	chain  Blockchain
+	node   NodeStats
+	info   nodeInfo
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewService creates the ethstats reporting service of the node
+func NewService(
+	logger hclog.Logger,
+	config *Config,
+	chain Blockchain,
+	node NodeStats,
+	chainID int64,
+	p2pPort int,
+) *Service {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Service{
+		logger: logger.Named("ethstats"),
+		config: config,
+		chain:  chain,
+		node:   node,
+		info: nodeInfo{
+			Name:     config.Name,
+			Node:     fmt.Sprintf("%s/%s/%s-%s", protocolName, versioning.Version, runtime.GOOS, runtime.GOARCH),
+			Port:     p2pPort,
+			Network:  strconv.FormatInt(chainID, 10),
+			Protocol: protocolName,
+			API:      "No",
+			OS:       runtime.GOOS,
+			OSVer:    runtime.GOARCH,
+			Client:   "0.1.1",
+			History:  true,
+		},
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Start connects to the ethstats server and keeps reporting to it, reconnecting if the connection fails
+func (s *Service) Start() {
+	sub := s.chain.SubscribeEvents()
+
+	// the heads are dropped while the reports are slow, the next head is reported anyway
+	headCh := make(chan *types.Header, 1)
+
+	s.wg.Add(2)
+
+	go func() {
+		defer s.wg.Done()
+		defer close(headCh)
+
+		for {
+			ev := sub.GetEvent()
+			if ev == nil {
+				return
+			}
+
+			if len(ev.NewChain) == 0 {
+				continue
+			}
+
+			select {
+			case headCh <- ev.Header():
+			default:
+			}
+		}
+	}()
+
+	go func() {
+		defer s.wg.Done()
+		defer s.chain.UnsubscribeEvents(sub)
+
+		s.loop(headCh)
+	}()
+}
+
+// Close disconnects from the ethstats server
+func (s *Service) Close() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *Service) loop(headCh <-chan *types.Header) {
+	for {
+		conn, err := s.login()
+		if err == nil {
+			s.logger.Info("connected to the ethstats server", "url", s.config.URL)
+
+			err = s.serve(conn, headCh)
+			conn.close()
+		}
+
+		if s.ctx.Err() != nil {
+			return
+		}
+
+		s.logger.Warn("ethstats connection failed", "url", s.config.URL, "err", err)
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(reconnectInterval):
+		}
+	}
+}
+
+// login dials the server and logs in with the secret
+func (s *Service) login() (*connWrapper, error) {
+	ctx, cancel := context.WithTimeout(s.ctx, messageTimeout)
+	defer cancel()
+
+	ws, _, err := websocket.DefaultDialer.DialContext(ctx, s.config.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &connWrapper{conn: ws}
+
+	if err := conn.emit("hello", &authMsg{ID: s.config.Name, Info: s.info, Secret: s.config.Secret}); err != nil {
+		conn.close()
+
+		return nil, err
+	}
+
+	_ = ws.SetReadDeadline(time.Now().Add(messageTimeout))
+
+	command, _, err := conn.read()
+	if err != nil {
+		conn.close()
+
+		return nil, err
+	}
+
+	if command != "ready" {
+		conn.close()
+
+		return nil, errUnauthorized
+	}
+
+	_ = ws.SetReadDeadline(time.Time{})
+
+	return conn, nil
+}
+
+// serve reports to the server until the connection fails or the service is closed
+func (s *Service) serve(conn *connWrapper, headCh <-chan *types.Header) error {
+	var (
+		errCh     = make(chan error, 1)
+		pongCh    = make(chan struct{}, 1)
+		historyCh = make(chan []uint64, 1)
+	)
+
+	// the reader answers the pings of the server, and passes the pongs and the history requests on
+	go func() {
+		for {
+			command, payload, err := conn.read()
+			if err != nil {
+				errCh <- err
+
+				return
+			}
+
+			switch command {
+			case "node-ping":
+				var ping pingMsg
+				if err := json.Unmarshal(payload, &ping); err != nil {
+					s.logger.Debug("invalid ethstats ping", "err", err)
+
+					continue
+				}
+
+				if err := conn.emit("node-pong", &pingMsg{ID: s.config.Name, ClientTime: ping.ClientTime}); err != nil {
+					errCh <- err
+
+					return
+				}
+			case "node-pong":
+				select {
+				case pongCh <- struct{}{}:
+				default:
+				}
+			case "history":
+				var request historyRequest
+				if err := json.Unmarshal(payload, &request); err != nil {
+					s.logger.Debug("invalid ethstats history request", "err", err)
+
+					continue
+				}
+
+				select {
+				case historyCh <- request.List:
+				default:
+				}
+			}
+		}
+	}()
+
+	if err := s.reportHistory(conn, nil); err != nil {
+		return err
+	}
+
+	if err := s.fullReport(conn, pongCh); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(reportInterval)
+	defer ticker.Stop()
+
+	for {
+		var err error
+
+		select {
+		case <-s.ctx.Done():
+			return nil
+		case err = <-errCh:
+		case <-ticker.C:
+			err = s.fullReport(conn, pongCh)
+		case head, ok := <-headCh:
+			if !ok {
+				return nil
+			}
+
+			if err = s.reportBlock(conn, head); err == nil {
+				err = s.reportPending(conn)
+			}
+		case numbers := <-historyCh:
+			err = s.reportHistory(conn, numbers)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// fullReport reports the latency, the head, the pending transactions and the node statistics
+func (s *Service) fullReport(conn *connWrapper, pongCh <-chan struct{}) error {
+	if err := s.reportLatency(conn, pongCh); err != nil {
+		return err
+	}
+
+	if err := s.reportBlock(conn, s.chain.Header()); err != nil {
+		return err
+	}
+
+	if err := s.reportPending(conn); err != nil {
+		return err
+	}
+
+	return s.reportStats(conn)
+}
+
+func (s *Service) reportLatency(conn *connWrapper, pongCh <-chan struct{}) error {
+	// the pong of the previous ping, which timed out, is dropped
+	select {
+	case <-pongCh:
+	default:
+	}
+
+	start := time.Now()
+
+	if err := conn.emit("node-ping", &pingMsg{ID: s.config.Name, ClientTime: start.String()}); err != nil {
+		return err
+	}
+
+	select {
+	case <-pongCh:
+	case <-s.ctx.Done():
+		return nil
+	case <-time.After(messageTimeout):
+		return errPingTimeout
+	}
+
+	latency := strconv.FormatInt(time.Since(start).Milliseconds()/2, 10)
+
+	return conn.emit("latency", &latencyReport{ID: s.config.Name, Latency: latency})
+}
+
+func (s *Service) reportBlock(conn *connWrapper, header *types.Header) error {
+	return conn.emit("block", &blockReport{ID: s.config.Name, Block: s.blockStats(header)})
+}
+
+// reportHistory reports the blocks of the numbers, or the most recent blocks if the numbers are not set
+func (s *Service) reportHistory(conn *connWrapper, numbers []uint64) error {
+	if len(numbers) == 0 {
+		head := s.chain.Header().Number
+
+		for number := head; number+historyLength > head; number-- {
+			numbers = append(numbers, number)
+
+			if number == 0 {
+				break
+			}
+		}
+	}
+
+	history := make([]*blockStats, 0, len(numbers))
+
+	for _, number := range numbers {
+		if block, ok := s.chain.GetBlockByNumber(number, true); ok {
+			history = append(history, s.statsOfBlock(block))
+		}
+	}
+
+	return conn.emit("history", &historyReport{ID: s.config.Name, History: history})
+}
+
+func (s *Service) reportPending(conn *connWrapper) error {
+	return conn.emit("pending", &pendingReport{ID: s.config.Name, Stats: pendingStats{Pending: s.node.PendingTxs()}})
+}
+
+func (s *Service) reportStats(conn *connWrapper) error {
+	gasPrice := 0
+	if price := s.node.GasPrice(); price != nil && price.IsInt64() {
+		gasPrice = int(price.Int64())
+	}
+
+	return conn.emit("stats", &statsReport{
+		ID: s.config.Name,
+		Stats: nodeStats{
+			Active:   true,
+			Syncing:  s.node.Syncing(),
+			Mining:   s.node.Mining(),
+			Peers:    s.node.PeerCount(),
+			GasPrice: gasPrice,
+			Uptime:   100,
+		},
+	})
+}
+
+// blockStats returns the statistics of the block of the header, with the transactions if the block is found
+func (s *Service) blockStats(header *types.Header) *blockStats {
+	if block, ok := s.chain.GetBlockByNumber(header.Number, true); ok && block.Hash() == header.Hash {
+		return s.statsOfBlock(block)
+	}
+
+	return s.statsOfBlock(&types.Block{Header: header})
+}
+
+func (s *Service) statsOfBlock(block *types.Block) *blockStats {
+	header := block.Header
+
+	stats := &blockStats{
+		Number:     new(big.Int).SetUint64(header.Number),
+		Hash:       header.Hash,
+		ParentHash: header.ParentHash,
+		Timestamp:  new(big.Int).SetUint64(header.Timestamp),
+		Miner:      types.BytesToAddress(header.Miner),
+		GasUsed:    header.GasUsed,
+		GasLimit:   header.GasLimit,
+		Diff:       strconv.FormatUint(header.Difficulty, 10),
+		TotalDiff:  "0",
+		Txs:        make([]txStats, len(block.Transactions)),
+		TxHash:     header.TxRoot,
+		Root:       header.StateRoot,
+		Uncles:     []struct{}{},
+	}
+
+	if td, ok := s.chain.GetTD(header.Hash); ok {
+		stats.TotalDiff = td.String()
+	}
+
+	for i, tx := range block.Transactions {
+		stats.Txs[i].Hash = tx.Hash
+	}
+
+	return stats
+}
+
+// connWrapper serializes the writes to the websocket connection, which are done by both the reader and the reporter
+type connWrapper struct {
+	conn      *websocket.Conn
+	writeLock sync.Mutex
+}
+
+func (c *connWrapper) emit(command string, payload interface{}) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	_ = c.conn.SetWriteDeadline(time.Now().Add(messageTimeout))
+
+	return c.conn.WriteJSON(&emitMessage{Emit: []interface{}{command, payload}})
+}
+
+// read reads the next message, and returns its command and its raw payload
+func (c *connWrapper) read() (string, json.RawMessage, error) {
+	var msg receivedMessage
+	if err := c.conn.ReadJSON(&msg); err != nil {
+		return "", nil, err
+	}
+
+	if len(msg.Emit) == 0 {
+		return "", nil, nil
+	}
+
+	var command string
+	if err := json.Unmarshal(msg.Emit[0], &command); err != nil {
+		return "", nil, fmt.Errorf("invalid ethstats command: %w", err)
+	}
+
+	if len(msg.Emit) < 2 {
+		return command, nil, nil
+	}
+
+	return command, msg.Emit[1], nil
+}
+
+func (c *connWrapper) close() {
+	_ = c.conn.Close()
+}
@@ -0,0 +1,249 @@
+package ethstats
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type mockChain struct {
+	blocks []*types.Block
+	sub    *blockchain.MockSubscription
+}
+
+func newMockChain(length int) *mockChain {
+	chain := &mockChain{sub: blockchain.NewMockSubscription()}
+
+	for i := 0; i < length; i++ {
+		header := &types.Header{Number: uint64(i), GasLimit: 1000, Timestamp: uint64(i)}
+		header.ComputeHash()
+
+		chain.blocks = append(chain.blocks, &types.Block{Header: header})
+	}
+
+	return chain
+}
+
+func (m *mockChain) Header() *types.Header {
+	return m.blocks[len(m.blocks)-1].Header
+}
+
+func (m *mockChain) GetBlockByNumber(number uint64, _ bool) (*types.Block, bool) {
+	if number >= uint64(len(m.blocks)) {
+		return nil, false
+	}
+
+	return m.blocks[number], true
+}
+
+func (m *mockChain) GetTD(types.Hash) (*big.Int, bool) {
+	return big.NewInt(1), true
+}
+
+func (m *mockChain) SubscribeEvents() blockchain.Subscription {
+	return m.sub
+}
+
+func (m *mockChain) UnsubscribeEvents(blockchain.Subscription) {
+	m.sub.Push(nil)
+}
+
+type mockNodeStats struct{}
+
+func (mockNodeStats) PeerCount() int     { return 3 }
+func (mockNodeStats) Syncing() bool      { return false }
+func (mockNodeStats) Mining() bool       { return true }
+func (mockNodeStats) PendingTxs() uint64 { return 7 }
+func (mockNodeStats) GasPrice() *big.Int { return big.NewInt(10) }
+
+type emitted struct {
+	command string
+	payload json.RawMessage
+}
+
+// newMockServer starts the ethstats server which accepts the secret, answers the pings
+// and passes the other messages on
+func newMockServer(t *testing.T, secret string) (*httptest.Server, <-chan emitted) {
+	t.Helper()
+
+	msgCh := make(chan emitted, 64)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, defaultPath, r.URL.Path)
+
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		wrapper := &connWrapper{conn: conn}
+
+		command, payload, err := wrapper.read()
+		require.NoError(t, err)
+		require.Equal(t, "hello", command)
+
+		var auth authMsg
+		require.NoError(t, json.Unmarshal(payload, &auth))
+
+		if auth.Secret != secret {
+			_ = wrapper.emit("unauthorized", nil)
+
+			return
+		}
+
+		require.NoError(t, wrapper.emit("ready", nil))
+		require.NoError(t, wrapper.emit("node-ping", &pingMsg{ID: "server", ClientTime: "now"}))
+
+		for {
+			command, payload, err := wrapper.read()
+			if err != nil {
+				return
+			}
+
+			if command == "node-ping" {
+				_ = wrapper.emit("node-pong", nil)
+			}
+
+			msgCh <- emitted{command: command, payload: payload}
+		}
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server, msgCh
+}
+
+// messages receives the messages of the mock server, the messages skipped while waiting for
+// the other commands are kept, as the reader and the reporter of the service send them concurrently
+type messages struct {
+	ch      <-chan emitted
+	skipped []emitted
+}
+
+func (m *messages) waitFor(t *testing.T, command string) json.RawMessage {
+	t.Helper()
+
+	for i, msg := range m.skipped {
+		if msg.command == command {
+			m.skipped = append(m.skipped[:i], m.skipped[i+1:]...)
+
+			return msg.payload
+		}
+	}
+
+	timeout := time.After(5 * time.Second)
+
+	for {
+		select {
+		case msg := <-m.ch:
+			if msg.command == command {
+				return msg.payload
+			}
+
+			m.skipped = append(m.skipped, msg)
+		case <-timeout:
+			t.Fatalf("%s was not reported", command)
+		}
+	}
+}
+
+func TestParseURL(t *testing.T) {
+	t.Parallel()
+
+	config, err := ParseURL("ws://localhost:3000?secret=s3cret&name=node-1")
+	require.NoError(t, err)
+	require.Equal(t, &Config{URL: "ws://localhost:3000/api", Secret: "s3cret", Name: "node-1"}, config)
+
+	config, err = ParseURL("wss://stats.example.com/custom?secret=s3cret")
+	require.NoError(t, err)
+	require.Equal(t, "wss://stats.example.com/custom", config.URL)
+	require.NotEmpty(t, config.Name)
+
+	_, err = ParseURL("http://localhost:3000?secret=s3cret")
+	require.ErrorIs(t, err, errInvalidScheme)
+
+	_, err = ParseURL("ws://localhost:3000")
+	require.ErrorIs(t, err, errNoSecret)
+}
+
+func TestService_Report(t *testing.T) {
+	t.Parallel()
+
+	server, msgCh := newMockServer(t, "s3cret")
+	msgs := &messages{ch: msgCh}
+
+	config, err := ParseURL("ws" + strings.TrimPrefix(server.URL, "http") + "?secret=s3cret&name=node-1")
+	require.NoError(t, err)
+
+	chain := newMockChain(60)
+
+	service := NewService(hclog.NewNullLogger(), config, chain, mockNodeStats{}, 100, 1478)
+	service.Start()
+
+	defer service.Close()
+
+	var pong pingMsg
+
+	require.NoError(t, json.Unmarshal(msgs.waitFor(t, "node-pong"), &pong))
+	require.Equal(t, pingMsg{ID: "node-1", ClientTime: "now"}, pong)
+
+	var history historyReport
+
+	require.NoError(t, json.Unmarshal(msgs.waitFor(t, "history"), &history))
+	require.Len(t, history.History, historyLength)
+	require.Equal(t, uint64(59), history.History[0].Number.Uint64())
+
+	msgs.waitFor(t, "latency")
+
+	var block blockReport
+
+	require.NoError(t, json.Unmarshal(msgs.waitFor(t, "block"), &block))
+	require.Equal(t, chain.Header().Hash, block.Block.Hash)
+	require.Equal(t, "1", block.Block.TotalDiff)
+
+	var pending pendingReport
+
+	require.NoError(t, json.Unmarshal(msgs.waitFor(t, "pending"), &pending))
+	require.Equal(t, uint64(7), pending.Stats.Pending)
+
+	var stats statsReport
+
+	require.NoError(t, json.Unmarshal(msgs.waitFor(t, "stats"), &stats))
+	require.Equal(t, nodeStats{Active: true, Mining: true, Peers: 3, GasPrice: 10, Uptime: 100}, stats.Stats)
+
+	// the new heads are reported
+	head := &types.Header{Number: 60}
+	head.ComputeHash()
+
+	chain.sub.Push(&blockchain.Event{NewChain: []*types.Header{head}})
+
+	require.NoError(t, json.Unmarshal(msgs.waitFor(t, "block"), &block))
+	require.Equal(t, head.Hash, block.Block.Hash)
+}
+
+func TestService_Unauthorized(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newMockServer(t, "s3cret")
+
+	config, err := ParseURL("ws" + strings.TrimPrefix(server.URL, "http") + "?secret=wrong")
+	require.NoError(t, err)
+
+	service := NewService(hclog.NewNullLogger(), config, newMockChain(1), mockNodeStats{}, 100, 1478)
+
+	_, err = service.login()
+	require.ErrorIs(t, err, errUnauthorized)
+}
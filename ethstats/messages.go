@@ -0,0 +1,107 @@
+package ethstats
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// the messages of the ethstats protocol are {"emit": [<command>, <payload>]}
+type emitMessage struct {
+	Emit []interface{} `json:"emit"`
+}
+
+// the received messages are decoded as {"emit": [<command>, <raw payload>]}
+type receivedMessage struct {
+	Emit []json.RawMessage `json:"emit"`
+}
+
+type authMsg struct {
+	ID     string   `json:"id"`
+	Info   nodeInfo `json:"info"`
+	Secret string   `json:"secret"`
+}
+
+// nodeInfo is the node shown on the dashboard
+type nodeInfo struct {
+	Name     string `json:"name"`
+	Node     string `json:"node"`
+	Port     int    `json:"port"`
+	Network  string `json:"net"`
+	Protocol string `json:"protocol"`
+	API      string `json:"api"`
+	OS       string `json:"os"`
+	OSVer    string `json:"os_v"`
+	Client   string `json:"client"`
+	History  bool   `json:"canUpdateHistory"`
+}
+
+type blockStats struct {
+	Number     *big.Int      `json:"number"`
+	Hash       types.Hash    `json:"hash"`
+	ParentHash types.Hash    `json:"parentHash"`
+	Timestamp  *big.Int      `json:"timestamp"`
+	Miner      types.Address `json:"miner"`
+	GasUsed    uint64        `json:"gasUsed"`
+	GasLimit   uint64        `json:"gasLimit"`
+	Diff       string        `json:"difficulty"`
+	TotalDiff  string        `json:"totalDifficulty"`
+	Txs        []txStats     `json:"transactions"`
+	TxHash     types.Hash    `json:"transactionsRoot"`
+	Root       types.Hash    `json:"stateRoot"`
+	Uncles     []struct{}    `json:"uncles"`
+}
+
+type txStats struct {
+	Hash types.Hash `json:"hash"`
+}
+
+type blockReport struct {
+	ID    string      `json:"id"`
+	Block *blockStats `json:"block"`
+}
+
+type historyReport struct {
+	ID      string        `json:"id"`
+	History []*blockStats `json:"history"`
+}
+
+type pendingStats struct {
+	Pending uint64 `json:"pending"`
+}
+
+type pendingReport struct {
+	ID    string       `json:"id"`
+	Stats pendingStats `json:"stats"`
+}
+
+type nodeStats struct {
+	Active   bool `json:"active"`
+	Syncing  bool `json:"syncing"`
+	Mining   bool `json:"mining"`
+	Hashrate int  `json:"hashrate"`
+	Peers    int  `json:"peers"`
+	GasPrice int  `json:"gasPrice"`
+	Uptime   int  `json:"uptime"`
+}
+
+type statsReport struct {
+	ID    string    `json:"id"`
+	Stats nodeStats `json:"stats"`
+}
+
+type pingMsg struct {
+	ID         string `json:"id"`
+	ClientTime string `json:"clientTime"`
+}
+
+type latencyReport struct {
+	ID      string `json:"id"`
+	Latency string `json:"latency"`
+}
+
+// historyRequest is the request of the server for the blocks it misses
+type historyRequest struct {
+	List []uint64 `json:"list"`
+}
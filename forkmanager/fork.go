@@ -45,25 +45,58 @@ type ForkParams struct {
 
 	// BlockTimeDrift defines the time slot in which a new block can be created
 	BlockTimeDrift *uint64 `json:"blockTimeDrift,omitempty"`
+
+	// BaseFeeEM is the elasticity multiplier, i.e. the ratio of the block gas limit to the gas target
+	BaseFeeEM *uint64 `json:"baseFeeEM,omitempty"`
+
+	// BaseFeeChangeDenom bounds the amount the base fee can change between the blocks
+	BaseFeeChangeDenom *uint64 `json:"baseFeeChangeDenom,omitempty"`
+
+	// MinBaseFee is the floor of the base fee
+	MinBaseFee *uint64 `json:"minBaseFee,omitempty"`
+
+	// BurnToZeroAddress sends the base fees to the zero address instead of the burn contract
+	BurnToZeroAddress *bool `json:"burnToZeroAddress,omitempty"`
+
+	// MaxCodeSize is the maximum size of the deployed contract code (EIP-170)
+	MaxCodeSize *uint64 `json:"maxCodeSize,omitempty"`
+
+	// MaxInitCodeSize is the maximum size of the init code of the contract creation transaction (EIP-3860)
+	MaxInitCodeSize *uint64 `json:"maxInitCodeSize,omitempty"`
 }
 
 // Copy creates a deep copy of ForkParams
 func (fp *ForkParams) Copy() *ForkParams {
-	maxValSetSize := *fp.MaxValidatorSetSize
-	epochSize := *fp.EpochSize
-	sprintSize := *fp.SprintSize
-	blockTime := *fp.BlockTime
-	blockTimeDrift := *fp.BlockTimeDrift
-
 	return &ForkParams{
-		MaxValidatorSetSize: &maxValSetSize,
-		EpochSize:           &epochSize,
-		SprintSize:          &sprintSize,
-		BlockTime:           &blockTime,
-		BlockTimeDrift:      &blockTimeDrift,
+		MaxValidatorSetSize: copyPtr(fp.MaxValidatorSetSize),
+		EpochSize:           copyPtr(fp.EpochSize),
+		SprintSize:          copyPtr(fp.SprintSize),
+		BlockTime:           copyPtr(fp.BlockTime),
+		BlockTimeDrift:      copyPtr(fp.BlockTimeDrift),
+		BaseFeeEM:           copyPtr(fp.BaseFeeEM),
+		BaseFeeChangeDenom:  copyPtr(fp.BaseFeeChangeDenom),
+		MinBaseFee:          copyPtr(fp.MinBaseFee),
+		BurnToZeroAddress:   copyPtr(fp.BurnToZeroAddress),
+		MaxCodeSize:         copyPtr(fp.MaxCodeSize),
+		MaxInitCodeSize:     copyPtr(fp.MaxInitCodeSize),
 	}
 }
 
+// Merge overrides the params with the ones set in the params of the later fork
+func (fp *ForkParams) Merge(later *ForkParams) {
+	copyParams(fp, later, true)
+}
+
+func copyPtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+
+	v := *p
+
+	return &v
+}
+
 // forkHandler defines one custom handler
 type forkHandler struct {
 	// id - if two handlers start from the same block number, the one with the greater ID should take precedence.
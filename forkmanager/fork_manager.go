@@ -275,12 +275,12 @@ func (fm *forkManager) addParams(blockNumber uint64, params *ForkParams) {
 
 		if index > 0 {
 			// copy all nil parameters from previous
-			copyParams(item.params, fm.params[index-1].params)
+			copyParams(item.params, fm.params[index-1].params, false)
 		}
 
 		// update parameters for next
 		for i := index; i < len(fm.params)-1; i++ {
-			copyParams(fm.params[i+1].params, fm.params[i].params)
+			copyParams(fm.params[i+1].params, fm.params[i].params, false)
 		}
 	}
 }
@@ -297,7 +297,8 @@ func (fm *forkManager) removeParams(blockNumber uint64) {
 	}
 }
 
-func copyParams(dest, src *ForkParams) {
+// copyParams copies the params set in src to dest, overriding the ones set in dest only if requested
+func copyParams(dest, src *ForkParams, override bool) {
 	srcValue := reflect.ValueOf(src).Elem()
 	dstValue := reflect.ValueOf(dest).Elem()
 
@@ -305,8 +306,8 @@ func copyParams(dest, src *ForkParams) {
 		dstField := dstValue.Field(i)
 		srcField := srcValue.Field(i)
 
-		// copy if dst is nil, but src is not
-		if dstField.Kind() == reflect.Ptr && dstField.IsNil() && !srcField.IsNil() {
+		// copy if src is set, and dst is not or it's overridden
+		if dstField.Kind() == reflect.Ptr && (dstField.IsNil() || override) && !srcField.IsNil() {
 			dstField.Set(srcField)
 		}
 	}
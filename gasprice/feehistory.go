@@ -1,11 +1,12 @@
 package gasprice
 
 import (
-	"encoding/binary"
 	"errors"
 	"math"
 	"math/big"
 	"sort"
+
+	"github.com/0xPolygon/polygon-edge/types"
 )
 
 var (
@@ -18,23 +19,48 @@ const (
 	maxBlockRequest = 1024
 )
 
-type cacheKey struct {
-	number      uint64
-	percentiles string
-}
-
 // processedFees contains the results of a processed block.
+// It doesn't depend on the requested reward percentiles, so it is
+// recorded once per block and reused by all the fee history requests
 type processedFees struct {
-	reward       []uint64
 	baseFee      uint64
+	gasUsed      uint64
 	gasUsedRatio float64
+	// txs are the block transactions sorted by the effective tip (ascending)
+	txs []*txGasAndReward
 }
 
 type txGasAndReward struct {
-	gasUsed *big.Int
+	gasUsed uint64
 	reward  *big.Int
 }
 
+// rewards returns the effective tips at the given percentiles of the block gas used
+func (f *processedFees) rewards(rewardPercentiles []float64) []uint64 {
+	reward := make([]uint64, len(rewardPercentiles))
+	if len(f.txs) == 0 {
+		// no transactions in block, rewards are 0
+		return reward
+	}
+
+	var txIndex int
+
+	sumGasUsed := f.txs[0].gasUsed
+
+	// calculate reward for each percentile
+	for c, v := range rewardPercentiles {
+		thresholdGasUsed := uint64(float64(f.gasUsed) * v / 100)
+		for sumGasUsed < thresholdGasUsed && txIndex < len(f.txs)-1 {
+			txIndex++
+			sumGasUsed += f.txs[txIndex].gasUsed
+		}
+
+		reward[c] = f.txs[txIndex].reward.Uint64()
+	}
+
+	return reward
+}
+
 type FeeHistoryReturn struct {
 	OldestBlock   uint64
 	BaseFeePerGas []uint64
@@ -81,93 +107,96 @@ func (g *GasHelper) FeeHistory(blockCount uint64, newestBlock uint64, rewardPerc
 		oldestBlock = 1
 	}
 
-	percentileKey := make([]byte, 8*len(rewardPercentiles))
-	for i, p := range rewardPercentiles {
-		binary.LittleEndian.PutUint64(percentileKey[i*8:(i+1)*8], math.Float64bits(p))
-	}
+	var newestHeader *types.Header
 
 	for i := oldestBlock; i <= newestBlock; i++ {
-		cacheKey := cacheKey{number: i, percentiles: string(percentileKey)}
-		//cache is hit, load from cache and continue to next block
-		if p, ok := g.historyCache.Get(cacheKey); ok {
-			processedFee, isOk := p.(*processedFees)
-			if !isOk {
-				return &FeeHistoryReturn{0, nil, nil, nil}, errors.New("could not convert catched processed fee")
-			}
-
-			baseFeePerGas[i-oldestBlock] = processedFee.baseFee
-			gasUsedRatio[i-oldestBlock] = processedFee.gasUsedRatio
-			reward[i-oldestBlock] = processedFee.reward
-
-			continue
-		}
-
 		block, ok := g.backend.GetBlockByNumber(i, true)
 		if !ok {
 			return &FeeHistoryReturn{0, nil, nil, nil}, ErrBlockNotFound
 		}
 
-		baseFeePerGas[i-oldestBlock] = block.Header.BaseFee
-		gasUsedRatio[i-oldestBlock] = float64(block.Header.GasUsed) / float64(block.Header.GasLimit)
-
-		if math.IsNaN(gasUsedRatio[i-oldestBlock]) {
-			//gasUsedRatio is NaN, set to 0
-			gasUsedRatio[i-oldestBlock] = 0
+		fees, err := g.processBlockFees(block)
+		if err != nil {
+			return &FeeHistoryReturn{0, nil, nil, nil}, err
 		}
 
+		baseFeePerGas[i-oldestBlock] = fees.baseFee
+		gasUsedRatio[i-oldestBlock] = fees.gasUsedRatio
+		newestHeader = block.Header
+
 		if len(rewardPercentiles) == 0 {
 			//reward percentiles not requested, skip rest of this loop
 			continue
 		}
 
-		reward[i-oldestBlock] = make([]uint64, len(rewardPercentiles))
-		if len(block.Transactions) == 0 {
-			for j := range reward[i-oldestBlock] {
-				reward[i-oldestBlock][j] = 0
-			}
-			//no transactions in block, set rewards to 0 and move to next block
-			continue
-		}
+		reward[i-oldestBlock] = fees.rewards(rewardPercentiles)
+	}
 
-		sorter := make([]*txGasAndReward, len(block.Transactions))
-		baseFee := new(big.Int).SetUint64(block.Header.BaseFee)
+	// the last base fee is the one of the block following the newest block
+	if newestHeader != nil {
+		baseFeePerGas[blockCount] = g.backend.CalculateBaseFee(newestHeader)
+	} else {
+		baseFeePerGas[blockCount] = g.backend.Header().BaseFee
+	}
 
-		for j, tx := range block.Transactions {
-			cost := tx.Cost()
-			sorter[j] = &txGasAndReward{
-				gasUsed: cost.Sub(cost, tx.Value),
-				reward:  tx.EffectiveGasTip(baseFee),
-			}
+	return &FeeHistoryReturn{oldestBlock, baseFeePerGas, gasUsedRatio, reward}, nil
+}
+
+// processBlockFees returns the fee data of the given block, either from cache
+// or by sorting the block transactions by their effective tips.
+// Transactions are weighted by the gas they actually used (taken from the receipts)
+func (g *GasHelper) processBlockFees(block *types.Block) (*processedFees, error) {
+	if p, ok := g.historyCache.Get(block.Hash()); ok {
+		processedFee, isOk := p.(*processedFees)
+		if !isOk {
+			return nil, errors.New("could not convert cached processed fee")
 		}
 
-		sort.Slice(sorter, func(i, j int) bool {
-			return sorter[i].reward.Cmp(sorter[j].reward) < 0
-		})
+		return processedFee, nil
+	}
 
-		var txIndex int
+	fees := &processedFees{
+		baseFee:      block.Header.BaseFee,
+		gasUsed:      block.Header.GasUsed,
+		gasUsedRatio: float64(block.Header.GasUsed) / float64(block.Header.GasLimit),
+		txs:          make([]*txGasAndReward, len(block.Transactions)),
+	}
+
+	if math.IsNaN(fees.gasUsedRatio) {
+		//gasUsedRatio is NaN, set to 0
+		fees.gasUsedRatio = 0
+	}
 
-		sumGasUsed := sorter[0].gasUsed.Uint64()
+	var receipts []*types.Receipt
 
-		// calculate reward for each percentile
-		for c, v := range rewardPercentiles {
-			thresholdGasUsed := uint64(float64(block.Header.GasUsed) * v / 100)
-			for sumGasUsed < thresholdGasUsed && txIndex < len(block.Transactions)-1 {
-				txIndex++
-				sumGasUsed += sorter[txIndex].gasUsed.Uint64()
-			}
+	if len(block.Transactions) > 0 {
+		var err error
 
-			reward[i-oldestBlock][c] = sorter[txIndex].reward.Uint64()
+		if receipts, err = g.backend.GetReceiptsByHash(block.Hash()); err != nil {
+			return nil, err
 		}
+	}
+
+	baseFee := new(big.Int).SetUint64(block.Header.BaseFee)
 
-		blockFees := &processedFees{
-			reward:       reward[i-oldestBlock],
-			baseFee:      block.Header.BaseFee,
-			gasUsedRatio: gasUsedRatio[i-oldestBlock],
+	for i, tx := range block.Transactions {
+		// fallback to the gas limit of the transaction if receipts are not available
+		gasUsed := tx.Gas
+		if len(receipts) == len(block.Transactions) {
+			gasUsed = receipts[i].GasUsed
+		}
+
+		fees.txs[i] = &txGasAndReward{
+			gasUsed: gasUsed,
+			reward:  tx.EffectiveGasTip(baseFee),
 		}
-		g.historyCache.Add(cacheKey, blockFees)
 	}
 
-	baseFeePerGas[blockCount] = g.backend.Header().BaseFee
+	sort.Slice(fees.txs, func(i, j int) bool {
+		return fees.txs[i].reward.Cmp(fees.txs[j].reward) < 0
+	})
 
-	return &FeeHistoryReturn{oldestBlock, baseFeePerGas, gasUsedRatio, reward}, nil
+	g.historyCache.Add(block.Hash(), fees)
+
+	return fees, nil
 }
@@ -1,6 +1,7 @@
 package gasprice
 
 import (
+	"math/big"
 	"math/rand"
 	"testing"
 	"time"
@@ -263,6 +264,61 @@ func TestGasHelper_FeeHistory(t *testing.T) {
 	}
 }
 
+func TestGasHelper_FeeHistory_GasUsedWeightedRewards(t *testing.T) {
+	t.Parallel()
+
+	backend := createTestBlocks(t, 1)
+	block := backend.blocksByNumber[1]
+
+	senderKey, sender := tests.GenerateKeyAndAddr(t)
+	signer := crypto.NewSigner(backend.Config().Forks.At(block.Number()), uint64(backend.Config().ChainID))
+
+	// tips of 1, 2 and 3 gwei, where the cheapest transaction used most of the block gas
+	gasUsed := []uint64{80_000, 10_000, 10_000}
+	block.Transactions = make([]*types.Transaction, len(gasUsed))
+	backend.receipts = map[types.Hash][]*types.Receipt{block.Hash(): make([]*types.Receipt, len(gasUsed))}
+
+	for i := range gasUsed {
+		tx, err := signer.SignTx(&types.Transaction{
+			From:      sender,
+			Nonce:     uint64(i),
+			Value:     big.NewInt(0),
+			To:        &types.ZeroAddress,
+			Type:      types.DynamicFeeTx,
+			Gas:       1_000_000,
+			GasTipCap: ethgo.Gwei(uint64(i + 1)),
+			GasFeeCap: ethgo.Gwei(uint64(i + 1 + 200)),
+		}, senderKey)
+		require.NoError(t, err)
+
+		block.Transactions[i] = tx
+		backend.receipts[block.Hash()][i] = &types.Receipt{GasUsed: gasUsed[i]}
+	}
+
+	block.Header.GasUsed = 100_000
+	block.Header.GasLimit = 200_000
+
+	gasHelper, err := NewGasHelper(DefaultGasHelperConfig, backend)
+	require.NoError(t, err)
+
+	history, err := gasHelper.FeeHistory(1, 1, []float64{50, 85, 100})
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(1), history.OldestBlock)
+	require.Equal(t, []float64{0.5}, history.GasUsedRatio)
+	require.Equal(t, [][]uint64{{
+		ethgo.Gwei(1).Uint64(),
+		ethgo.Gwei(2).Uint64(),
+		ethgo.Gwei(3).Uint64(),
+	}}, history.Reward)
+
+	// processed block fees are reused by requests with different percentiles
+	history, err = gasHelper.FeeHistory(1, 1, []float64{0})
+	require.NoError(t, err)
+	require.Equal(t, [][]uint64{{ethgo.Gwei(1).Uint64()}}, history.Reward)
+	require.Equal(t, 1, gasHelper.historyCache.Len())
+}
+
 var _ Blockchain = (*backendMock)(nil)
 
 func (b *backendMock) GetBlockByNumber(number uint64, full bool) (*types.Block, bool) {
@@ -49,6 +49,8 @@ type Blockchain interface {
 	GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool)
 	Header() *types.Header
 	Config() *chain.Params
+	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
+	CalculateBaseFee(parent *types.Header) uint64
 }
 
 // GasStore interface is providing functions regarding gas and fees
@@ -93,7 +95,7 @@ func NewGasHelper(config *Config, backend Blockchain) (*GasHelper, error) {
 		pricePercentile = 100
 	}
 
-	cache, err := lru.New(100)
+	cache, err := lru.New(maxBlockRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -205,6 +207,11 @@ func (g *GasHelper) MaxPriorityFeePerGas() (*big.Int, error) {
 		if err := collectPrices(currentBlock); err != nil {
 			return nil, err
 		}
+
+		currentBlock, found = g.backend.GetBlockByHash(currentBlock.ParentHash(), true)
+		if !found {
+			return nil, fmt.Errorf(couldNotFoundBlockFormat, currentHeader.Number, currentHeader.Hash)
+		}
 	}
 
 	price := lastPrice
@@ -242,6 +242,7 @@ type backendMock struct {
 	mock.Mock
 	blocks         map[types.Hash]*types.Block
 	blocksByNumber map[uint64]*types.Block
+	receipts       map[types.Hash][]*types.Receipt
 }
 
 func (b *backendMock) Header() *types.Header {
@@ -262,6 +263,14 @@ func (b *backendMock) GetBlockByHash(hash types.Hash, full bool) (*types.Block,
 	return block, exists
 }
 
+func (b *backendMock) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	return b.receipts[hash], nil
+}
+
+func (b *backendMock) CalculateBaseFee(parent *types.Header) uint64 {
+	return parent.BaseFee
+}
+
 func (b *backendMock) Config() *chain.Params {
 	return &chain.Params{
 		ChainID: 1,
@@ -0,0 +1,214 @@
+package kvdb
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// Backend is the name of the key-value database implementation
+type Backend string
+
+const (
+	LevelDB Backend = "leveldb"
+	Pebble  Backend = "pebble"
+
+	// DefaultBackend is the backend used unless configured otherwise
+	DefaultBackend = LevelDB
+)
+
+var (
+	ErrUnknownBackend   = errors.New("unknown storage backend")
+	ErrBackendMismatch  = errors.New("database was created by a different storage backend")
+	errBackendNotBuiltF = "storage backend %s is not available, the binary has to be built with the '%s' build tag"
+)
+
+// Batch is the set of the writes applied to the database atomically
+type Batch interface {
+	Put(k, v []byte)
+	Delete(k []byte)
+	Write() error
+}
+
+// Database is the key-value database backing the blockchain and the state storages
+type Database interface {
+	// Get returns the value of the key, the bool is false if the key is not found
+	Get(k []byte) ([]byte, bool, error)
+	Put(k, v []byte) error
+	Delete(k []byte) error
+	NewBatch() Batch
+	// ForEach iterates over the key-value pairs in the key order, the pairs passed to fn are safe copies
+	ForEach(fn func(k, v []byte) error) error
+	Close() error
+}
+
+// Options are the tuning options of the database, the zero values select the defaults of the backend
+type Options struct {
+	// Cache is the memory in MiB used for caching the data
+	Cache int
+	// Handles is the number of the open files
+	Handles int
+}
+
+// Opener opens or creates the database of the backend at the path
+type Opener func(path string, opts Options) (Database, error)
+
+type backendInfo struct {
+	open Opener
+	// isBackendDir reports whether the existing database directory was created by the backend
+	isBackendDir func(path string) bool
+}
+
+var (
+	backendsLock sync.RWMutex
+	backends     = map[Backend]backendInfo{
+		LevelDB: {open: openLevelDB, isBackendDir: isLevelDBDir},
+	}
+)
+
+// register adds the backend, it is called by the backends built conditionally
+func register(backend Backend, open Opener, isBackendDir func(path string) bool) {
+	backendsLock.Lock()
+	defer backendsLock.Unlock()
+
+	backends[backend] = backendInfo{open: open, isBackendDir: isBackendDir}
+}
+
+// Backends returns the names of the backends available in the binary
+func Backends() []Backend {
+	backendsLock.RLock()
+	defer backendsLock.RUnlock()
+
+	names := make([]Backend, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+
+	return names
+}
+
+// ParseBackend validates the backend name, the known backends which aren't built in are rejected
+func ParseBackend(name string) (Backend, error) {
+	backend := Backend(name)
+	if name == "" {
+		backend = DefaultBackend
+	}
+
+	backendsLock.RLock()
+	_, ok := backends[backend]
+	backendsLock.RUnlock()
+
+	switch {
+	case ok:
+		return backend, nil
+	case backend == Pebble:
+		return "", fmt.Errorf(errBackendNotBuiltF, backend, backend)
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownBackend, name)
+	}
+}
+
+// Open opens the database of the backend at the path, creating it if it doesn't exist.
+// The existing database created by another backend is not opened
+func Open(backend Backend, path string, opts Options) (Database, error) {
+	backend, err := ParseBackend(string(backend))
+	if err != nil {
+		return nil, err
+	}
+
+	if detected, ok := Detect(path); ok && detected != backend {
+		return nil, fmt.Errorf("%w: %s holds a %s database, migrate it to %s first",
+			ErrBackendMismatch, path, detected, backend)
+	}
+
+	backendsLock.RLock()
+	info := backends[backend]
+	backendsLock.RUnlock()
+
+	return info.open(path, opts)
+}
+
+// Detect returns the backend which created the database at the path,
+// the bool is false if there is no database or it can't be recognized
+func Detect(path string) (Backend, bool) {
+	// both backends keep the CURRENT file pointing to the manifest
+	if _, err := os.Stat(filepath.Join(path, "CURRENT")); err != nil {
+		return "", false
+	}
+
+	backendsLock.RLock()
+	defer backendsLock.RUnlock()
+
+	// the pebble directory is recognized before the leveldb one, whose check is less specific
+	if isPebbleDir(path) {
+		return Pebble, true
+	}
+
+	for backend, info := range backends {
+		if info.isBackendDir(path) {
+			return backend, true
+		}
+	}
+
+	return "", false
+}
+
+// isPebbleDir reports whether the database directory holds the OPTIONS file written only by pebble,
+// it is recognized even if pebble isn't built in, so the database isn't opened by leveldb
+func isPebbleDir(path string) bool {
+	matches, _ := filepath.Glob(filepath.Join(path, "OPTIONS-*"))
+
+	return len(matches) > 0
+}
+
+// Copy writes all key-value pairs of the source database to the destination database
+// in batches of batchSize pairs, and returns the number of the copied pairs
+func Copy(src, dst Database, batchSize int, progress func(copied uint64)) (uint64, error) {
+	var (
+		batch   = dst.NewBatch()
+		pending = 0
+		copied  = uint64(0)
+	)
+
+	err := src.ForEach(func(k, v []byte) error {
+		batch.Put(k, v)
+		pending++
+
+		if pending < batchSize {
+			return nil
+		}
+
+		if err := batch.Write(); err != nil {
+			return err
+		}
+
+		copied += uint64(pending)
+		batch, pending = dst.NewBatch(), 0
+
+		if progress != nil {
+			progress(copied)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return copied, err
+	}
+
+	if pending > 0 {
+		if err := batch.Write(); err != nil {
+			return copied, err
+		}
+
+		copied += uint64(pending)
+	}
+
+	return copied, nil
+}
@@ -0,0 +1,152 @@
+package kvdb
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testDatabase(t *testing.T, db Database) {
+	t.Helper()
+
+	_, ok, err := db.Get([]byte("a"))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, db.Put([]byte("a"), []byte("1")))
+
+	value, ok, err := db.Get([]byte("a"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte("1"), value)
+
+	batch := db.NewBatch()
+	batch.Put([]byte("c"), []byte("3"))
+	batch.Put([]byte("b"), []byte("2"))
+	batch.Delete([]byte("a"))
+
+	// the batch isn't applied until it is written
+	_, ok, err = db.Get([]byte("b"))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, batch.Write())
+
+	var keys, values []string
+
+	require.NoError(t, db.ForEach(func(k, v []byte) error {
+		keys = append(keys, string(k))
+		values = append(values, string(v))
+
+		return nil
+	}))
+
+	require.Equal(t, []string{"b", "c"}, keys)
+	require.Equal(t, []string{"2", "3"}, values)
+
+	require.NoError(t, db.Delete([]byte("b")))
+
+	_, ok, err = db.Get([]byte("b"))
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestMemoryDatabase(t *testing.T) {
+	t.Parallel()
+
+	testDatabase(t, NewMemoryDatabase())
+}
+
+func TestLevelDB(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "db")
+
+	db, err := Open(LevelDB, path, Options{Cache: 16, Handles: 16})
+	require.NoError(t, err)
+
+	testDatabase(t, db)
+	require.NoError(t, db.Close())
+
+	backend, ok := Detect(path)
+	require.True(t, ok)
+	require.Equal(t, LevelDB, backend)
+
+	// the data is persisted
+	db, err = Open(LevelDB, path, Options{})
+	require.NoError(t, err)
+
+	value, ok, err := db.Get([]byte("c"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte("3"), value)
+	require.NoError(t, db.Close())
+}
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+
+	_, ok := Detect(filepath.Join(t.TempDir(), "missing"))
+	require.False(t, ok)
+
+	// the pebble database is recognized even if the backend isn't built in
+	path := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(path, "CURRENT"), []byte("MANIFEST-000001\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(path, "OPTIONS-000003"), nil, 0600))
+
+	backend, ok := Detect(path)
+	require.True(t, ok)
+	require.Equal(t, Pebble, backend)
+
+	if _, err := ParseBackend(string(Pebble)); err == nil {
+		t.Skip("pebble backend is built in")
+	}
+
+	_, err := Open(LevelDB, path, Options{})
+	require.ErrorIs(t, err, ErrBackendMismatch)
+}
+
+func TestParseBackend(t *testing.T) {
+	t.Parallel()
+
+	backend, err := ParseBackend("")
+	require.NoError(t, err)
+	require.Equal(t, DefaultBackend, backend)
+
+	backend, err = ParseBackend("leveldb")
+	require.NoError(t, err)
+	require.Equal(t, LevelDB, backend)
+
+	_, err = ParseBackend("rocksdb")
+	require.ErrorIs(t, err, ErrUnknownBackend)
+
+	require.Contains(t, Backends(), LevelDB)
+}
+
+func TestCopy(t *testing.T) {
+	t.Parallel()
+
+	src, dst := NewMemoryDatabase(), NewMemoryDatabase()
+
+	for i := 0; i < 25; i++ {
+		require.NoError(t, src.Put([]byte{byte(i)}, []byte{byte(i), byte(i)}))
+	}
+
+	var progress []uint64
+
+	copied, err := Copy(src, dst, 10, func(copied uint64) {
+		progress = append(progress, copied)
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(25), copied)
+	require.Equal(t, []uint64{10, 20}, progress)
+
+	for i := 0; i < 25; i++ {
+		value, ok, err := dst.Get([]byte{byte(i)})
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, []byte{byte(i), byte(i)}, value)
+	}
+}
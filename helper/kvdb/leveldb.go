@@ -0,0 +1,105 @@
+package kvdb
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// levelDB is the leveldb backend of the database
+type levelDB struct {
+	db *leveldb.DB
+}
+
+func openLevelDB(path string, opts Options) (Database, error) {
+	var options *opt.Options
+
+	if opts.Cache != 0 || opts.Handles != 0 {
+		options = &opt.Options{
+			OpenFilesCacheCapacity: opts.Handles,
+			BlockCacheCapacity:     opts.Cache / 2 * opt.MiB,
+			WriteBuffer:            opts.Cache / 4 * opt.MiB, // Two of these are used internally
+		}
+	}
+
+	db, err := leveldb.OpenFile(path, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &levelDB{db: db}, nil
+}
+
+// isLevelDBDir reports whether the database directory holds the leveldb manifest and not the pebble one
+func isLevelDBDir(path string) bool {
+	if _, err := os.Stat(filepath.Join(path, "CURRENT")); err != nil {
+		return false
+	}
+
+	return !isPebbleDir(path)
+}
+
+func (l *levelDB) Get(k []byte) ([]byte, bool, error) {
+	data, err := l.db.Get(k, nil)
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return data, true, nil
+}
+
+func (l *levelDB) Put(k, v []byte) error {
+	return l.db.Put(k, v, nil)
+}
+
+func (l *levelDB) Delete(k []byte) error {
+	return l.db.Delete(k, nil)
+}
+
+func (l *levelDB) NewBatch() Batch {
+	return &levelDBBatch{db: l.db, batch: new(leveldb.Batch)}
+}
+
+func (l *levelDB) ForEach(fn func(k, v []byte) error) error {
+	iter := l.db.NewIterator(nil, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		key := append([]byte{}, iter.Key()...)
+		value := append([]byte{}, iter.Value()...)
+
+		if err := fn(key, value); err != nil {
+			return err
+		}
+	}
+
+	return iter.Error()
+}
+
+func (l *levelDB) Close() error {
+	return l.db.Close()
+}
+
+type levelDBBatch struct {
+	db    *leveldb.DB
+	batch *leveldb.Batch
+}
+
+func (b *levelDBBatch) Put(k, v []byte) {
+	b.batch.Put(k, v)
+}
+
+func (b *levelDBBatch) Delete(k []byte) {
+	b.batch.Delete(k)
+}
+
+func (b *levelDBBatch) Write() error {
+	return b.db.Write(b.batch, nil)
+}
@@ -0,0 +1,118 @@
+package kvdb
+
+import (
+	"sort"
+	"sync"
+)
+
+// memoryDB is the in-memory database, it isn't registered as the backend since it isn't persisted
+type memoryDB struct {
+	lock sync.RWMutex
+	db   map[string][]byte
+}
+
+// NewMemoryDatabase creates the in-memory database
+func NewMemoryDatabase() Database {
+	return &memoryDB{db: make(map[string][]byte)}
+}
+
+func (m *memoryDB) Get(k []byte) ([]byte, bool, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	v, ok := m.db[string(k)]
+	if !ok {
+		return nil, false, nil
+	}
+
+	return append([]byte{}, v...), true, nil
+}
+
+func (m *memoryDB) Put(k, v []byte) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.db[string(k)] = append([]byte{}, v...)
+
+	return nil
+}
+
+func (m *memoryDB) Delete(k []byte) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	delete(m.db, string(k))
+
+	return nil
+}
+
+func (m *memoryDB) NewBatch() Batch {
+	return &memoryBatch{db: m}
+}
+
+// ForEach iterates over the snapshot of the pairs, so fn is free to modify the database
+func (m *memoryDB) ForEach(fn func(k, v []byte) error) error {
+	m.lock.RLock()
+
+	keys := make([]string, 0, len(m.db))
+	for k := range m.db {
+		keys = append(keys, k)
+	}
+
+	values := make(map[string][]byte, len(m.db))
+	for k, v := range m.db {
+		values[k] = append([]byte{}, v...)
+	}
+
+	m.lock.RUnlock()
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if err := fn([]byte(k), values[k]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *memoryDB) Close() error {
+	return nil
+}
+
+type memoryBatch struct {
+	db  *memoryDB
+	ops []memoryOp
+}
+
+type memoryOp struct {
+	key    string
+	value  []byte
+	delete bool
+}
+
+func (b *memoryBatch) Put(k, v []byte) {
+	b.ops = append(b.ops, memoryOp{key: string(k), value: append([]byte{}, v...)})
+}
+
+func (b *memoryBatch) Delete(k []byte) {
+	b.ops = append(b.ops, memoryOp{key: string(k), delete: true})
+}
+
+func (b *memoryBatch) Write() error {
+	b.db.lock.Lock()
+	defer b.db.lock.Unlock()
+
+	for _, op := range b.ops {
+		if op.delete {
+			delete(b.db.db, op.key)
+		} else {
+			b.db.db[op.key] = op.value
+		}
+	}
+
+	b.ops = nil
+
+	return nil
+}
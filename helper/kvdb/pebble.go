@@ -0,0 +1,110 @@
+//go:build pebble
+
+package kvdb
+
+import (
+	"errors"
+
+	"github.com/cockroachdb/pebble"
+)
+
+// The pebble backend is built with the 'pebble' build tag,
+// which requires the github.com/cockroachdb/pebble module to be added to the build
+func init() {
+	register(Pebble, openPebble, isPebbleDir)
+}
+
+// pebbleDB is the pebble backend of the database
+type pebbleDB struct {
+	db *pebble.DB
+}
+
+func openPebble(path string, opts Options) (Database, error) {
+	options := &pebble.Options{}
+
+	if opts.Cache != 0 {
+		cache := pebble.NewCache(int64(opts.Cache / 2 * 1024 * 1024))
+		defer cache.Unref()
+
+		options.Cache = cache
+		options.MemTableSize = uint64(opts.Cache / 4 * 1024 * 1024)
+	}
+
+	if opts.Handles != 0 {
+		options.MaxOpenFiles = opts.Handles
+	}
+
+	db, err := pebble.Open(path, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pebbleDB{db: db}, nil
+}
+
+func (p *pebbleDB) Get(k []byte) ([]byte, bool, error) {
+	data, closer, err := p.db.Get(k)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	defer closer.Close()
+
+	return append([]byte{}, data...), true, nil
+}
+
+func (p *pebbleDB) Put(k, v []byte) error {
+	return p.db.Set(k, v, pebble.Sync)
+}
+
+func (p *pebbleDB) Delete(k []byte) error {
+	return p.db.Delete(k, pebble.Sync)
+}
+
+func (p *pebbleDB) NewBatch() Batch {
+	return &pebbleBatch{batch: p.db.NewBatch()}
+}
+
+func (p *pebbleDB) ForEach(fn func(k, v []byte) error) error {
+	iter, err := p.db.NewIter(&pebble.IterOptions{})
+	if err != nil {
+		return err
+	}
+
+	defer iter.Close()
+
+	for iter.First(); iter.Valid(); iter.Next() {
+		key := append([]byte{}, iter.Key()...)
+		value := append([]byte{}, iter.Value()...)
+
+		if err := fn(key, value); err != nil {
+			return err
+		}
+	}
+
+	return iter.Error()
+}
+
+func (p *pebbleDB) Close() error {
+	return p.db.Close()
+}
+
+type pebbleBatch struct {
+	batch *pebble.Batch
+}
+
+func (b *pebbleBatch) Put(k, v []byte) {
+	_ = b.batch.Set(k, v, nil)
+}
+
+func (b *pebbleBatch) Delete(k []byte) {
+	_ = b.batch.Delete(k, nil)
+}
+
+func (b *pebbleBatch) Write() error {
+	return b.batch.Commit(pebble.Sync)
+}
@@ -0,0 +1,309 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// FormatText is the human readable log format
+	FormatText = "text"
+	// FormatJSON is the log format with a JSON object per record, for the log aggregation pipelines
+	FormatJSON = "json"
+)
+
+var (
+	errEmptyModule        = errors.New("module name is empty")
+	errInvalidModuleLevel = errors.New("module log levels have to be set as module=level")
+)
+
+// Levels holds the level of the root logger and the overridden levels of the modules.
+// The module of a logger is its name below the root logger, e.g. "network" or "network.discovery",
+// the override of a module applies to its submodules as well, unless they are overridden too
+type Levels struct {
+	lock      sync.RWMutex
+	root      hclog.Level
+	overrides map[string]hclog.Level
+
+	// base is the wrapped root logger, its level is kept at the most verbose level in use
+	// so the records of every module reach the level check of the module
+	base hclog.Logger
+}
+
+// NewLevelLogger wraps the root logger, so the levels of its named loggers can be overridden per module
+func NewLevelLogger(base hclog.Logger) (hclog.Logger, *Levels) {
+	levels := &Levels{
+		root:      base.GetLevel(),
+		overrides: make(map[string]hclog.Level),
+		base:      base,
+	}
+
+	return &moduleLogger{Logger: base, levels: levels}, levels
+}
+
+// Root returns the level of the modules which are not overridden
+func (l *Levels) Root() hclog.Level {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	return l.root
+}
+
+// SetRoot sets the level of the modules which are not overridden
+func (l *Levels) SetRoot(level hclog.Level) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.root = level
+	l.updateBase()
+}
+
+// SetModule overrides the level of the module and its submodules, hclog.NoLevel removes the override
+func (l *Levels) SetModule(module string, level hclog.Level) error {
+	if module == "" {
+		return errEmptyModule
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if level == hclog.NoLevel {
+		delete(l.overrides, module)
+	} else {
+		l.overrides[module] = level
+	}
+
+	l.updateBase()
+
+	return nil
+}
+
+// SetModules replaces the overridden levels of all the modules, the empty module names are skipped
+func (l *Levels) SetModules(overrides map[string]hclog.Level) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.overrides = make(map[string]hclog.Level, len(overrides))
+
+	for module, level := range overrides {
+		if module != "" && level != hclog.NoLevel {
+			l.overrides[module] = level
+		}
+	}
+
+	l.updateBase()
+}
+
+// Overrides returns the overridden levels by the module names
+func (l *Levels) Overrides() map[string]hclog.Level {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	overrides := make(map[string]hclog.Level, len(l.overrides))
+	for module, level := range l.overrides {
+		overrides[module] = level
+	}
+
+	return overrides
+}
+
+// Modules returns the names of the overridden modules in order
+func (l *Levels) Modules() []string {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	modules := make([]string, 0, len(l.overrides))
+	for module := range l.overrides {
+		modules = append(modules, module)
+	}
+
+	sort.Strings(modules)
+
+	return modules
+}
+
+// level returns the level of the module, which is the override of the module
+// or of its closest overridden parent, the root level otherwise
+func (l *Levels) level(module string) hclog.Level {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	if len(l.overrides) == 0 {
+		return l.root
+	}
+
+	for module != "" {
+		if level, ok := l.overrides[module]; ok {
+			return level
+		}
+
+		idx := strings.LastIndexByte(module, '.')
+		if idx < 0 {
+			break
+		}
+
+		module = module[:idx]
+	}
+
+	return l.root
+}
+
+// updateBase sets the level of the wrapped logger to the most verbose level in use
+func (l *Levels) updateBase() {
+	minLevel := l.root
+	for _, level := range l.overrides {
+		if level < minLevel {
+			minLevel = level
+		}
+	}
+
+	l.base.SetLevel(minLevel)
+}
+
+// moduleLogger checks the level of its module before passing the records to the wrapped logger.
+// The standard loggers of the wrapped logger are filtered by the most verbose level in use
+type moduleLogger struct {
+	hclog.Logger
+
+	module string
+	levels *Levels
+}
+
+func (m *moduleLogger) enabled(level hclog.Level) bool {
+	return level >= m.levels.level(m.module)
+}
+
+func (m *moduleLogger) Log(level hclog.Level, msg string, args ...interface{}) {
+	if m.enabled(level) {
+		m.Logger.Log(level, msg, args...)
+	}
+}
+
+func (m *moduleLogger) Trace(msg string, args ...interface{}) {
+	m.Log(hclog.Trace, msg, args...)
+}
+
+func (m *moduleLogger) Debug(msg string, args ...interface{}) {
+	m.Log(hclog.Debug, msg, args...)
+}
+
+func (m *moduleLogger) Info(msg string, args ...interface{}) {
+	m.Log(hclog.Info, msg, args...)
+}
+
+func (m *moduleLogger) Warn(msg string, args ...interface{}) {
+	m.Log(hclog.Warn, msg, args...)
+}
+
+func (m *moduleLogger) Error(msg string, args ...interface{}) {
+	m.Log(hclog.Error, msg, args...)
+}
+
+func (m *moduleLogger) IsTrace() bool {
+	return m.enabled(hclog.Trace)
+}
+
+func (m *moduleLogger) IsDebug() bool {
+	return m.enabled(hclog.Debug)
+}
+
+func (m *moduleLogger) IsInfo() bool {
+	return m.enabled(hclog.Info)
+}
+
+func (m *moduleLogger) IsWarn() bool {
+	return m.enabled(hclog.Warn)
+}
+
+func (m *moduleLogger) IsError() bool {
+	return m.enabled(hclog.Error)
+}
+
+func (m *moduleLogger) With(args ...interface{}) hclog.Logger {
+	return &moduleLogger{Logger: m.Logger.With(args...), module: m.module, levels: m.levels}
+}
+
+func (m *moduleLogger) Named(name string) hclog.Logger {
+	module := name
+	if m.module != "" {
+		module = m.module + "." + name
+	}
+
+	return &moduleLogger{Logger: m.Logger.Named(name), module: module, levels: m.levels}
+}
+
+func (m *moduleLogger) ResetNamed(name string) hclog.Logger {
+	return &moduleLogger{Logger: m.Logger.ResetNamed(name), module: name, levels: m.levels}
+}
+
+// SetLevel sets the root level, as the named loggers of hclog share the level of their root logger
+func (m *moduleLogger) SetLevel(level hclog.Level) {
+	m.levels.SetRoot(level)
+}
+
+func (m *moduleLogger) GetLevel() hclog.Level {
+	return m.levels.level(m.module)
+}
+
+// ParseLevel parses the level name, "default" is hclog.NoLevel which removes the override of the module
+func ParseLevel(raw string) (hclog.Level, error) {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	if raw == "default" {
+		return hclog.NoLevel, nil
+	}
+
+	level := hclog.LevelFromString(raw)
+	if level == hclog.NoLevel {
+		return hclog.NoLevel, fmt.Errorf("unknown log level %q", raw)
+	}
+
+	return level, nil
+}
+
+// ParseModuleLevels parses the module levels set as a comma separated list of module=level,
+// e.g. "network=debug,txpool=warn"
+func ParseModuleLevels(raw string) (map[string]hclog.Level, error) {
+	overrides := make(map[string]hclog.Level)
+
+	for _, pair := range strings.Split(raw, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		module, rawLevel, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", errInvalidModuleLevel, pair)
+		}
+
+		module = strings.TrimSpace(module)
+		if module == "" {
+			return nil, fmt.Errorf("%w: %q", errEmptyModule, pair)
+		}
+
+		level, err := ParseLevel(rawLevel)
+		if err != nil {
+			return nil, fmt.Errorf("module %s: %w", module, err)
+		}
+
+		overrides[module] = level
+	}
+
+	return overrides, nil
+}
+
+// FormatModuleLevels formats the module levels as ParseModuleLevels parses them, ordered by the module names
+func FormatModuleLevels(overrides map[string]hclog.Level) string {
+	pairs := make([]string, 0, len(overrides))
+	for module, level := range overrides {
+		pairs = append(pairs, module+"="+level.String())
+	}
+
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
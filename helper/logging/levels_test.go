@@ -0,0 +1,111 @@
+package logging
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelLogger(t *testing.T) {
+	t.Parallel()
+
+	var output bytes.Buffer
+
+	root, levels := NewLevelLogger(hclog.New(&hclog.LoggerOptions{
+		Name:   "polygon",
+		Level:  hclog.Info,
+		Output: &output,
+	}))
+
+	var (
+		network   = root.Named("network")
+		discovery = network.Named("discovery")
+		txpool    = root.Named("txpool").With("key", "value")
+	)
+
+	logged := func(logger hclog.Logger, level hclog.Level) bool {
+		output.Reset()
+		logger.Log(level, "message")
+
+		return output.Len() > 0
+	}
+
+	require.False(t, logged(discovery, hclog.Debug))
+	require.True(t, logged(txpool, hclog.Info))
+
+	// the override applies to the submodules
+	require.NoError(t, levels.SetModule("network", hclog.Debug))
+	require.True(t, logged(network, hclog.Debug))
+	require.True(t, logged(discovery, hclog.Debug))
+	require.True(t, discovery.IsDebug())
+	require.False(t, logged(txpool, hclog.Debug))
+	require.False(t, txpool.IsDebug())
+
+	// the override of the submodule takes precedence
+	require.NoError(t, levels.SetModule("network.discovery", hclog.Error))
+	require.False(t, logged(discovery, hclog.Warn))
+	require.True(t, logged(network, hclog.Debug))
+	require.Equal(t, []string{"network", "network.discovery"}, levels.Modules())
+
+	require.NoError(t, levels.SetModule("txpool", hclog.Warn))
+	require.False(t, logged(txpool, hclog.Info))
+
+	// the root level applies to the modules which are not overridden
+	require.NoError(t, levels.SetModule("txpool", hclog.NoLevel))
+	txpool.SetLevel(hclog.Trace)
+	require.Equal(t, hclog.Trace, levels.Root())
+	require.True(t, logged(txpool, hclog.Trace))
+	require.False(t, logged(discovery, hclog.Warn))
+
+	require.ErrorIs(t, levels.SetModule("", hclog.Debug), errEmptyModule)
+}
+
+func TestParseModuleLevels(t *testing.T) {
+	t.Parallel()
+
+	overrides, err := ParseModuleLevels(" network=debug, txpool=WARN,polybft.consensus=default,")
+	require.NoError(t, err)
+	require.Equal(t, map[string]hclog.Level{
+		"network":           hclog.Debug,
+		"txpool":            hclog.Warn,
+		"polybft.consensus": hclog.NoLevel,
+	}, overrides)
+
+	overrides, err = ParseModuleLevels("")
+	require.NoError(t, err)
+	require.Empty(t, overrides)
+
+	_, err = ParseModuleLevels("network")
+	require.ErrorIs(t, err, errInvalidModuleLevel)
+
+	_, err = ParseModuleLevels("=debug")
+	require.ErrorIs(t, err, errEmptyModule)
+
+	_, err = ParseModuleLevels("network=loud")
+	require.Error(t, err)
+
+	require.Equal(t, "network=debug,txpool=warn",
+		FormatModuleLevels(map[string]hclog.Level{"txpool": hclog.Warn, "network": hclog.Debug}))
+}
+
+func TestLevels_SetModules(t *testing.T) {
+	t.Parallel()
+
+	base := hclog.New(&hclog.LoggerOptions{Level: hclog.Info, Output: &bytes.Buffer{}})
+	root, levels := NewLevelLogger(base)
+
+	require.NoError(t, levels.SetModule("txpool", hclog.Error))
+	levels.SetModules(map[string]hclog.Level{
+		"network": hclog.Debug,
+		"syncer":  hclog.NoLevel,
+		"":        hclog.Trace,
+	})
+
+	// the previous overrides are replaced
+	require.Equal(t, []string{"network"}, levels.Modules())
+	require.True(t, root.Named("network").IsDebug())
+	require.True(t, root.Named("txpool").IsInfo())
+	require.Equal(t, hclog.Debug, base.GetLevel())
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/umbracle/ethgo/abi"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state"
 	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
@@ -31,6 +32,55 @@ const (
 	bytecodeValue         = "bytecode"
 )
 
+var (
+	// DeterministicDeployerAddr is the address of the deterministic deployment proxy,
+	// deploying the contracts with CREATE2 to the same addresses on every chain it's deployed on
+	DeterministicDeployerAddr = types.StringToAddress("0x4e59b44847b379578588920cA78FbF26c0B4956C")
+
+	// DeterministicDeployerCode is the runtime code of the deterministic deployment proxy.
+	// It takes the salt and the creation code as the input, and returns the address of the created contract
+	DeterministicDeployerCode = hex.MustDecodeHex("0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe0" +
+		"3601600081602082378035828234f58015156039578182fd5b8082525050506014600cf3")
+
+	// ProxyImplementationSlot is the EIP-1967 storage slot of the proxy holding the address of the implementation
+	ProxyImplementationSlot = eip1967Slot("eip1967.proxy.implementation")
+
+	// ProxyAdminSlot is the EIP-1967 storage slot of the proxy holding the address of the admin
+	ProxyAdminSlot = eip1967Slot("eip1967.proxy.admin")
+)
+
+// Deployment is the contract deployed at genesis
+type Deployment struct {
+	// InitCode is the creation code of the contract, followed by the encoded constructor arguments
+	InitCode []byte
+	// Salt deploys the contract through the deterministic deployment proxy if set,
+	// otherwise the contract is deployed to the Address
+	Salt *types.Hash
+	// Address is the address the contract is deployed to, if it isn't deployed deterministically
+	Address types.Address
+	// Storage is set on the contract once it's created, such as the implementation slot of the proxy
+	Storage map[types.Hash]types.Hash
+	// InitCalls are called on the contract from the zero address once the storage is set,
+	// such as the initializer of the proxy
+	InitCalls [][]byte
+}
+
+// DeploymentAddress returns the address the contract is deployed to
+func (d *Deployment) DeploymentAddress() types.Address {
+	if d.Salt != nil {
+		return crypto.CreateAddress2(DeterministicDeployerAddr, *d.Salt, d.InitCode)
+	}
+
+	return d.Address
+}
+
+// eip1967Slot returns the storage slot of the label as defined by EIP-1967, the hash of the label decreased by one
+func eip1967Slot(label string) types.Hash {
+	slot := new(big.Int).SetBytes(crypto.Keccak256([]byte(label)))
+
+	return types.BytesToHash(slot.Sub(slot, big.NewInt(1)).Bytes())
+}
+
 type contractArtifact struct {
 	ABI              []byte // the ABI of the Smart Contract
 	Bytecode         []byte // the raw bytecode of the Smart Contract
@@ -104,6 +154,11 @@ func getModifiedStorageMap(radix *state.Txn, address types.Address) map[types.Ha
 		}
 
 		obj, _ := v.(*state.StateObject)
+		if obj.Txn == nil {
+			// The contract has no storage
+			return true
+		}
+
 		obj.Txn.Root().Walk(func(k []byte, v interface{}) bool {
 			val, _ := v.([]byte)
 			storageMap[types.BytesToHash(k)] = types.BytesToHash(val)
@@ -117,7 +172,14 @@ func getModifiedStorageMap(radix *state.Txn, address types.Address) map[types.Ha
 	return storageMap
 }
 
-func getPredeployAccount(address types.Address, input []byte, chainID int64) (*chain.GenesisAccount, error) {
+// GenerateGenesisAccount simulates the deployment of the contract on top of the genesis allocs,
+// and returns the account holding the deployed code and the storage the deployment left behind.
+// The changes the deployment makes to the other accounts are discarded
+func GenerateGenesisAccount(
+	deployment *Deployment,
+	alloc map[types.Address]*chain.GenesisAccount,
+	chainID int64,
+) (*chain.GenesisAccount, error) {
 	// Create an instance of the state
 	st := itrie.NewState(itrie.NewMemoryStorage())
 
@@ -127,15 +189,40 @@ func getPredeployAccount(address types.Address, input []byte, chainID int64) (*c
 	// Create a radix
 	radix := state.NewTxn(snapshot)
 
+	// Load the genesis allocs, so that the deployment can call the contracts predeployed before
+	for addr, account := range alloc {
+		if account.Balance != nil {
+			radix.SetBalance(addr, account.Balance)
+		}
+
+		radix.SetNonce(addr, account.Nonce)
+
+		if len(account.Code) != 0 {
+			radix.SetCode(addr, account.Code)
+		}
+
+		for key, value := range account.Storage {
+			radix.SetState(addr, key, value)
+		}
+	}
+
+	address := deployment.DeploymentAddress()
+
+	// The deterministically deployed contracts are created by the deployment proxy
+	creator := types.ZeroAddress
+	if deployment.Salt != nil {
+		creator = DeterministicDeployerAddr
+	}
+
 	// Create the contract object for the EVM
 	contract := runtime.NewContractCreation(
 		1,
-		types.ZeroAddress,
-		types.ZeroAddress,
+		creator,
+		creator,
 		address,
 		big.NewInt(0),
 		math.MaxInt64,
-		input,
+		deployment.InitCode,
 	)
 
 	// Enable all forks
@@ -151,6 +238,19 @@ func getPredeployAccount(address types.Address, input []byte, chainID int64) (*c
 		return nil, fmt.Errorf("EVM predeployment failed, %w", res.Err)
 	}
 
+	// The code is set, so that the init calls can call the contract
+	radix.SetCode(address, res.ReturnValue)
+
+	for key, value := range deployment.Storage {
+		radix.SetState(address, key, value)
+	}
+
+	for i, input := range deployment.InitCalls {
+		if callRes := transition.Call2(types.ZeroAddress, address, input, big.NewInt(0), math.MaxInt64); callRes.Failed() {
+			return nil, fmt.Errorf("init call %d failed, %w", i, callRes.Err)
+		}
+	}
+
 	// After the execution finishes,
 	// the state needs to be walked to collect all touched all storage slots
 	storageMap := getModifiedStorageMap(radix, address)
@@ -168,14 +268,9 @@ func getPredeployAccount(address types.Address, input []byte, chainID int64) (*c
 	}, nil
 }
 
-// GenerateGenesisAccountFromFile generates an account that is going to be directly
-// inserted into state
-func GenerateGenesisAccountFromFile(
-	filepath string,
-	constructorArgs []string,
-	predeployAddress types.Address,
-	chainID int64,
-) (*chain.GenesisAccount, error) {
+// LoadInitCode returns the creation code of the contract from the artifacts JSON,
+// followed by the encoded constructor arguments
+func LoadInitCode(filepath string, constructorArgs []string) ([]byte, error) {
 	// Create the artifact from JSON
 	artifact, err := loadContractArtifact(filepath)
 	if err != nil {
@@ -212,5 +307,21 @@ func GenerateGenesisAccountFromFile(
 		finalBytecode = append(artifact.Bytecode, constructor...)
 	}
 
-	return getPredeployAccount(predeployAddress, finalBytecode, chainID)
+	return finalBytecode, nil
+}
+
+// GenerateGenesisAccountFromFile generates an account that is going to be directly
+// inserted into state
+func GenerateGenesisAccountFromFile(
+	filepath string,
+	constructorArgs []string,
+	predeployAddress types.Address,
+	chainID int64,
+) (*chain.GenesisAccount, error) {
+	initCode, err := LoadInitCode(filepath, constructorArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	return GenerateGenesisAccount(&Deployment{InitCode: initCode, Address: predeployAddress}, nil, chainID)
 }
@@ -0,0 +1,83 @@
+package predeployment
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// storeInitCode deploys the contract storing the first word of the calldata at the slot 0
+var storeInitCode = hex.MustDecodeHex("0x66600035600055006000526007601" + "9f3")
+
+func TestProxySlots(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t,
+		types.StringToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc"), ProxyImplementationSlot)
+	require.Equal(t,
+		types.StringToHash("0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103"), ProxyAdminSlot)
+}
+
+func TestDeterministicDeployer(t *testing.T) {
+	t.Parallel()
+
+	salt := types.StringToHash("0x1234")
+	deployment := &Deployment{InitCode: storeInitCode, Salt: &salt}
+
+	// the address is the one the deployment proxy creates the contract at
+	snapshot := itrie.NewState(itrie.NewMemoryStorage()).NewSnapshot()
+	radix := state.NewTxn(snapshot)
+	radix.SetCode(DeterministicDeployerAddr, DeterministicDeployerCode)
+
+	transition := state.NewTransition(chain.AllForksEnabled.At(0), snapshot, radix)
+
+	result := transition.Call2(types.ZeroAddress, DeterministicDeployerAddr,
+		append(salt.Bytes(), storeInitCode...), big.NewInt(0), math.MaxInt64/2)
+	require.NoError(t, result.Err)
+	require.Equal(t, deployment.DeploymentAddress(), types.BytesToAddress(result.ReturnValue))
+
+	account, err := GenerateGenesisAccount(deployment, nil, 100)
+	require.NoError(t, err)
+	require.Equal(t, transition.GetCode(deployment.DeploymentAddress()), account.Code)
+}
+
+func TestGenerateGenesisAccount_InitializedStorage(t *testing.T) {
+	t.Parallel()
+
+	var (
+		address        = types.StringToAddress("0x1100")
+		implementation = types.StringToAddress("0x1200")
+	)
+
+	deployment := &Deployment{
+		InitCode:  storeInitCode,
+		Address:   address,
+		Storage:   map[types.Hash]types.Hash{ProxyImplementationSlot: types.BytesToHash(implementation.Bytes())},
+		InitCalls: [][]byte{types.StringToHash("0x2a").Bytes()},
+	}
+
+	alloc := map[types.Address]*chain.GenesisAccount{
+		implementation: {Code: []byte{0x00}, Balance: big.NewInt(1)},
+	}
+
+	account, err := GenerateGenesisAccount(deployment, alloc, 100)
+	require.NoError(t, err)
+	require.Equal(t, map[types.Hash]types.Hash{
+		ProxyImplementationSlot: types.BytesToHash(implementation.Bytes()),
+		types.ZeroHash:          types.StringToHash("0x2a"),
+	}, account.Storage)
+
+	// the failing init calls fail the deployment
+	deployment.InitCode = hex.MustDecodeHex("0x60fd60005360016000f3")
+
+	_, err = GenerateGenesisAccount(deployment, alloc, 100)
+	require.ErrorContains(t, err, "init call 0 failed")
+}
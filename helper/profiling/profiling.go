@@ -0,0 +1,144 @@
+// Package profiling serves the runtime profiles and statistics of the node on the debug port,
+// and controls the sampling rates of the block and the mutex profiles
+package profiling
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// PprofPath is the prefix of the pprof handlers, e.g. /debug/pprof/goroutine?debug=2
+	PprofPath = "/debug/pprof/"
+	// VarsPath is the path of the expvar variables
+	VarsPath = "/debug/vars"
+	// StatsPath is the path of the memory and goroutine statistics
+	StatsPath = "/debug/stats"
+
+	bearerPrefix = "Bearer "
+)
+
+var (
+	startTime = time.Now()
+
+	publishOnce sync.Once
+
+	// ratesLock guards the rates, which the runtime doesn't expose
+	ratesLock sync.Mutex
+	rates     Rates
+)
+
+// Rates are the sampling rates of the block and the mutex profiles, 0 disables the profile
+type Rates struct {
+	// Block is the average nanoseconds blocked per sampled blocking event, 1 samples every event
+	Block int `json:"block"`
+	// Mutex is the fraction (1/Mutex) of the sampled mutex contention events
+	Mutex int `json:"mutex"`
+}
+
+// SetRates sets the sampling rates of the block and the mutex profiles and returns the previous ones
+func SetRates(r Rates) Rates {
+	ratesLock.Lock()
+	defer ratesLock.Unlock()
+
+	previous := rates
+
+	runtime.SetBlockProfileRate(r.Block)
+	runtime.SetMutexProfileFraction(r.Mutex)
+
+	rates = r
+
+	return previous
+}
+
+// CurrentRates returns the sampling rates of the block and the mutex profiles
+func CurrentRates() Rates {
+	ratesLock.Lock()
+	defer ratesLock.Unlock()
+
+	return rates
+}
+
+// Stats are the memory and goroutine statistics of the process
+type Stats struct {
+	Uptime      string `json:"uptime"`
+	Goroutines  int    `json:"goroutines"`
+	GOMAXPROCS  int    `json:"gomaxprocs"`
+	HeapAlloc   uint64 `json:"heapAlloc"`
+	HeapInuse   uint64 `json:"heapInuse"`
+	HeapObjects uint64 `json:"heapObjects"`
+	StackInuse  uint64 `json:"stackInuse"`
+	Sys         uint64 `json:"sys"`
+	NumGC       uint32 `json:"numGC"`
+	LastGC      string `json:"lastGC,omitempty"`
+	PauseTotal  string `json:"pauseTotal"`
+	Rates       Rates  `json:"profilingRates"`
+}
+
+// CurrentStats reads the statistics of the process, which stops the world for a short while
+func CurrentStats() *Stats {
+	var mem runtime.MemStats
+
+	runtime.ReadMemStats(&mem)
+
+	stats := &Stats{
+		Uptime:      time.Since(startTime).Round(time.Second).String(),
+		Goroutines:  runtime.NumGoroutine(),
+		GOMAXPROCS:  runtime.GOMAXPROCS(0),
+		HeapAlloc:   mem.HeapAlloc,
+		HeapInuse:   mem.HeapInuse,
+		HeapObjects: mem.HeapObjects,
+		StackInuse:  mem.StackInuse,
+		Sys:         mem.Sys,
+		NumGC:       mem.NumGC,
+		PauseTotal:  time.Duration(mem.PauseTotalNs).String(),
+		Rates:       CurrentRates(),
+	}
+
+	if mem.LastGC != 0 {
+		stats.LastGC = time.Unix(0, int64(mem.LastGC)).UTC().Format(time.RFC3339)
+	}
+
+	return stats
+}
+
+// NewHandler returns the handler of the pprof profiles, the expvar variables and the statistics,
+// which requires the token as the bearer token of the Authorization header
+func NewHandler(token string) http.Handler {
+	publishOnce.Do(func() {
+		expvar.Publish("goroutines", expvar.Func(func() interface{} { return runtime.NumGoroutine() }))
+		expvar.Publish("uptime", expvar.Func(func() interface{} { return time.Since(startTime).Seconds() }))
+	})
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(PprofPath, pprof.Index)
+	mux.HandleFunc(PprofPath+"cmdline", pprof.Cmdline)
+	mux.HandleFunc(PprofPath+"profile", pprof.Profile)
+	mux.HandleFunc(PprofPath+"symbol", pprof.Symbol)
+	mux.HandleFunc(PprofPath+"trace", pprof.Trace)
+	mux.Handle(VarsPath, expvar.Handler())
+	mux.HandleFunc(StatsPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(CurrentStats())
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, ok := strings.CutPrefix(r.Header.Get("Authorization"), bearerPrefix)
+		if !ok || subtle.ConstantTimeCompare([]byte(received), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "the debug endpoints require a valid admin token", http.StatusUnauthorized)
+
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+}
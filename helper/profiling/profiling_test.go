@@ -0,0 +1,53 @@
+package profiling
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_Auth(t *testing.T) {
+	t.Parallel()
+
+	handler := NewHandler("s3cret")
+
+	get := func(path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", bearerPrefix+token)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	require.Equal(t, http.StatusUnauthorized, get(PprofPath, "").Code)
+	require.Equal(t, http.StatusUnauthorized, get(PprofPath, "wrong").Code)
+
+	require.Equal(t, http.StatusOK, get(PprofPath+"goroutine?debug=1", "s3cret").Code)
+	require.Contains(t, get(VarsPath, "s3cret").Body.String(), `"goroutines"`)
+
+	rec := get(StatsPath, "s3cret")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var stats Stats
+
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &stats))
+	require.Positive(t, stats.Goroutines)
+	require.Equal(t, runtime.GOMAXPROCS(0), stats.GOMAXPROCS)
+}
+
+func TestSetRates(t *testing.T) {
+	previous := SetRates(Rates{Block: 1000, Mutex: 5})
+	defer SetRates(previous)
+
+	require.Equal(t, Rates{Block: 1000, Mutex: 5}, CurrentRates())
+	require.Equal(t, 5, runtime.SetMutexProfileFraction(-1))
+	require.Equal(t, Rates{Block: 1000, Mutex: 5}, SetRates(Rates{}))
+}
@@ -13,6 +13,19 @@ const (
 	ChainSyncBulk    ChainSyncType = "bulk-sync"
 )
 
+// ChainSyncStage is the stage of the sync
+type ChainSyncStage string
+
+const (
+	// ChainSyncStageBlockImport executes and writes the blocks
+	ChainSyncStageBlockImport ChainSyncStage = "block-import"
+	// ChainSyncStageStateDownload downloads the state of the pivot block of the fast sync
+	ChainSyncStageStateDownload ChainSyncStage = "state-download"
+	// ChainSyncStageBlockDownload writes the blocks until the pivot block of the fast sync
+	// along with their receipts, without executing them
+	ChainSyncStageBlockDownload ChainSyncStage = "block-download"
+)
+
 // Progression defines the status of the sync
 // progression of the node
 type Progression struct {
@@ -28,6 +41,16 @@ type Progression struct {
 
 	// HighestBlock is the target block in the sync batch
 	HighestBlock uint64
+
+	// Stage is the current stage of the sync
+	Stage ChainSyncStage
+
+	// PulledStates is the number of the state trie nodes downloaded by the fast sync
+	PulledStates uint64
+
+	// KnownStates is the number of the state trie nodes the fast sync knows about,
+	// both the downloaded and the pending ones
+	KnownStates uint64
 }
 
 type ProgressionWrapper struct {
@@ -63,6 +86,7 @@ func (pw *ProgressionWrapper) StartProgression(
 	pw.progression = &Progression{
 		SyncType:      pw.syncType,
 		StartingBlock: startingBlock,
+		Stage:         ChainSyncStageBlockImport,
 	}
 
 	go pw.RunUpdateLoop(subscription)
@@ -118,10 +142,33 @@ func (pw *ProgressionWrapper) UpdateHighestProgression(highestBlock uint64) {
 	pw.progression.HighestBlock = highestBlock
 }
 
-// GetProgression returns the latest sync progression
+// UpdateStage sets the current stage of the sync
+func (pw *ProgressionWrapper) UpdateStage(stage ChainSyncStage) {
+	pw.lock.Lock()
+	defer pw.lock.Unlock()
+
+	pw.progression.Stage = stage
+}
+
+// UpdateStateProgression sets the numbers of the downloaded and of the known state trie nodes in the fast sync
+func (pw *ProgressionWrapper) UpdateStateProgression(pulledStates, knownStates uint64) {
+	pw.lock.Lock()
+	defer pw.lock.Unlock()
+
+	pw.progression.PulledStates = pulledStates
+	pw.progression.KnownStates = knownStates
+}
+
+// GetProgression returns the copy of the latest sync progression
 func (pw *ProgressionWrapper) GetProgression() *Progression {
 	pw.lock.RLock()
 	defer pw.lock.RUnlock()
 
-	return pw.progression
+	if pw.progression == nil {
+		return nil
+	}
+
+	progression := *pw.progression
+
+	return &progression
 }
@@ -0,0 +1,150 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+var (
+	ErrMissingKeyFile  = errors.New("TLS certificate requires the key file")
+	ErrMissingCertFile = errors.New("TLS key requires the certificate file")
+	ErrCertAndACME     = errors.New("TLS certificate files and ACME domains are mutually exclusive")
+	ErrClientCAOnly    = errors.New("client CA requires the TLS certificate files or ACME domains")
+	ErrNoCACerts       = errors.New("no CA certificates found")
+)
+
+// Config is the TLS configuration of a server endpoint.
+// The certificate is either loaded from the files or provisioned over ACME (TLS-ALPN-01 challenge),
+// the clients are required to present a certificate signed by the client CA if it is set
+type Config struct {
+	CertFile     string   `json:"cert_file,omitempty" yaml:"cert_file,omitempty"`
+	KeyFile      string   `json:"key_file,omitempty" yaml:"key_file,omitempty"`
+	ClientCAFile string   `json:"client_ca_file,omitempty" yaml:"client_ca_file,omitempty"`
+	ACMEDomains  []string `json:"acme_domains,omitempty" yaml:"acme_domains,omitempty"`
+	ACMECacheDir string   `json:"acme_cache_dir,omitempty" yaml:"acme_cache_dir,omitempty"`
+}
+
+// Enabled checks if the endpoint is served over TLS
+func (c *Config) Enabled() bool {
+	return c != nil && (c.CertFile != "" || len(c.ACMEDomains) > 0)
+}
+
+// Validate checks the certificate is configured in exactly one way
+func (c *Config) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	switch {
+	case c.CertFile != "" && c.KeyFile == "":
+		return ErrMissingKeyFile
+	case c.CertFile == "" && c.KeyFile != "":
+		return ErrMissingCertFile
+	case c.CertFile != "" && len(c.ACMEDomains) > 0:
+		return ErrCertAndACME
+	case c.ClientCAFile != "" && !c.Enabled():
+		return ErrClientCAOnly
+	}
+
+	return nil
+}
+
+// ServerTLSConfig returns the TLS configuration of the server, nil if TLS is not enabled
+func (c *Config) ServerTLSConfig() (*tls.Config, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	if !c.Enabled() {
+		return nil, nil
+	}
+
+	var tlsConfig *tls.Config
+
+	if len(c.ACMEDomains) > 0 {
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(c.ACMEDomains...),
+		}
+
+		if c.ACMECacheDir != "" {
+			manager.Cache = autocert.DirCache(c.ACMECacheDir)
+		}
+
+		// the application protocols are added by the servers
+		tlsConfig = manager.TLSConfig()
+		tlsConfig.NextProtos = []string{acme.ALPNProto}
+	} else {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load TLS certificate: %w", err)
+		}
+
+		tlsConfig = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+		}
+	}
+
+	tlsConfig.MinVersion = tls.VersionTLS12
+
+	if c.ClientCAFile != "" {
+		pool, err := loadCertPool(c.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
+
+// ClientTLSConfig returns the TLS configuration of the client, which verifies the server
+// by the given CA (the system CAs if empty) and presents the given certificate if set
+func ClientTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if caFile != "" {
+		pool, err := loadCertPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load TLS client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// loadCertPool loads the PEM encoded CA certificates
+func loadCertPool(path string) (*x509.CertPool, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read CA certificates: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(raw) {
+		return nil, fmt.Errorf("%w in %s", ErrNoCACerts, path)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,211 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCert is the certificate and its key, written as the PEM files
+type testCert struct {
+	cert     *x509.Certificate
+	key      *ecdsa.PrivateKey
+	certFile string
+	keyFile  string
+}
+
+// newTestCert creates the certificate signed by the parent, or the self-signed CA if the parent is nil
+func newTestCert(t *testing.T, name string, parent *testCert) *testCert {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	signerCert, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+	} else {
+		signerCert, signerKey = parent.cert, parent.key
+	}
+
+	raw, err := x509.CreateCertificate(rand.Reader, template, signerCert, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(raw)
+	require.NoError(t, err)
+
+	rawKey, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	c := &testCert{
+		cert:     cert,
+		key:      key,
+		certFile: filepath.Join(dir, name+".crt"),
+		keyFile:  filepath.Join(dir, name+".key"),
+	}
+
+	require.NoError(t, os.WriteFile(c.certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: raw}), 0600))
+	require.NoError(t, os.WriteFile(c.keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: rawKey}), 0600))
+
+	return c
+}
+
+// serveTLS accepts the TLS connections and echoes the first byte of each
+func serveTLS(t *testing.T, config *tls.Config) string {
+	t.Helper()
+
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", config)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = lis.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+
+				buf := make([]byte, 1)
+				if _, err := io.ReadFull(conn, buf); err == nil {
+					_, _ = conn.Write(buf)
+				}
+			}()
+		}
+	}()
+
+	return lis.Addr().String()
+}
+
+// echo sends a byte over the TLS connection and waits for it to be echoed
+func echo(addr string, config *tls.Config) error {
+	conn, err := tls.Dial("tcp", addr, config)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte{1}); err != nil {
+		return err
+	}
+
+	_, err = io.ReadFull(conn, make([]byte, 1))
+
+	return err
+}
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	var nilConfig *Config
+
+	assert.NoError(t, nilConfig.Validate())
+	assert.False(t, nilConfig.Enabled())
+
+	assert.NoError(t, (&Config{}).Validate())
+	assert.NoError(t, (&Config{CertFile: "cert", KeyFile: "key", ClientCAFile: "ca"}).Validate())
+	assert.NoError(t, (&Config{ACMEDomains: []string{"rpc.example.org"}}).Validate())
+
+	assert.ErrorIs(t, (&Config{CertFile: "cert"}).Validate(), ErrMissingKeyFile)
+	assert.ErrorIs(t, (&Config{KeyFile: "key"}).Validate(), ErrMissingCertFile)
+	assert.ErrorIs(t, (&Config{CertFile: "cert", KeyFile: "key", ACMEDomains: []string{"rpc.example.org"}}).Validate(), ErrCertAndACME)
+	assert.ErrorIs(t, (&Config{ClientCAFile: "ca"}).Validate(), ErrClientCAOnly)
+}
+
+func TestConfig_ServerTLSConfig(t *testing.T) {
+	t.Parallel()
+
+	ca := newTestCert(t, "ca", nil)
+	serverCert := newTestCert(t, "server", ca)
+
+	config, err := (&Config{}).ServerTLSConfig()
+	require.NoError(t, err)
+	assert.Nil(t, config)
+
+	_, err = (&Config{CertFile: serverCert.certFile, KeyFile: ca.keyFile}).ServerTLSConfig()
+	assert.Error(t, err)
+
+	config, err = (&Config{CertFile: serverCert.certFile, KeyFile: serverCert.keyFile}).ServerTLSConfig()
+	require.NoError(t, err)
+
+	addr := serveTLS(t, config)
+
+	clientConfig, err := ClientTLSConfig(ca.certFile, "", "")
+	require.NoError(t, err)
+	assert.NoError(t, echo(addr, clientConfig))
+
+	// the server certificate isn't verified by the system CAs
+	clientConfig, err = ClientTLSConfig("", "", "")
+	require.NoError(t, err)
+	assert.Error(t, echo(addr, clientConfig))
+
+	_, err = ClientTLSConfig(serverCert.keyFile, "", "")
+	assert.ErrorIs(t, err, ErrNoCACerts)
+}
+
+func TestConfig_ServerTLSConfig_MutualTLS(t *testing.T) {
+	t.Parallel()
+
+	ca := newTestCert(t, "ca", nil)
+	otherCA := newTestCert(t, "other-ca", nil)
+	serverCert := newTestCert(t, "server", ca)
+	clientCert := newTestCert(t, "client", ca)
+	otherClientCert := newTestCert(t, "other-client", otherCA)
+
+	config, err := (&Config{
+		CertFile:     serverCert.certFile,
+		KeyFile:      serverCert.keyFile,
+		ClientCAFile: ca.certFile,
+	}).ServerTLSConfig()
+	require.NoError(t, err)
+
+	addr := serveTLS(t, config)
+
+	// the client certificate signed by the client CA is accepted
+	clientConfig, err := ClientTLSConfig(ca.certFile, clientCert.certFile, clientCert.keyFile)
+	require.NoError(t, err)
+	assert.NoError(t, echo(addr, clientConfig))
+
+	// the client without the certificate is rejected
+	clientConfig, err = ClientTLSConfig(ca.certFile, "", "")
+	require.NoError(t, err)
+	assert.Error(t, echo(addr, clientConfig))
+
+	// the client certificate signed by another CA is rejected
+	clientConfig, err = ClientTLSConfig(ca.certFile, otherClientCert.certFile, otherClientCert.keyFile)
+	require.NoError(t, err)
+	assert.Error(t, echo(addr, clientConfig))
+}
@@ -0,0 +1,212 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// ProtocolGRPC exports the spans with OTLP over gRPC
+	ProtocolGRPC = "grpc"
+	// ProtocolHTTP exports the spans with OTLP over HTTP
+	ProtocolHTTP = "http"
+
+	serviceName = "polygon-edge"
+	tracerName  = "github.com/0xPolygon/polygon-edge"
+
+	// maxBlockContexts bounds the block contexts kept for the heights which were never ended
+	maxBlockContexts = 16
+)
+
+var (
+	errUnknownProtocol = errors.New("unknown OTLP protocol")
+	errSampleRatio     = errors.New("the tracing sample ratio has to be between 0 and 1")
+
+	blockContextsLock sync.RWMutex
+	blockContexts     = make(map[uint64]*blockContext)
+)
+
+// Config is the OpenTelemetry tracing configuration, the spans are only exported if the endpoint is set
+type Config struct {
+	// Endpoint is the address of the OTLP collector, host:port
+	Endpoint string
+	// Protocol is the OTLP protocol, grpc or http
+	Protocol string
+	// Insecure disables the TLS of the collector connection
+	Insecure bool
+	// SampleRatio is the ratio of the traces which are sampled
+	SampleRatio float64
+}
+
+// Enabled checks if the tracing is configured
+func (c *Config) Enabled() bool {
+	return c != nil && c.Endpoint != ""
+}
+
+// Validate checks the configuration
+func (c *Config) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+
+	if c.Protocol != ProtocolGRPC && c.Protocol != ProtocolHTTP {
+		return fmt.Errorf("%w: %s", errUnknownProtocol, c.Protocol)
+	}
+
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return errSampleRatio
+	}
+
+	return nil
+}
+
+// Setup installs the global tracer provider exporting the spans to the OTLP collector,
+// and returns the function flushing and stopping it.
+// Until it is called, the spans of the node are no-ops
+func Setup(config *Config) (func(context.Context) error, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	var client otlptrace.Client
+
+	switch config.Protocol {
+	case ProtocolGRPC:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(config.Endpoint)}
+		if config.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+
+		client = otlptracegrpc.NewClient(opts...)
+	case ProtocolHTTP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(config.Endpoint)}
+		if config.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+
+		client = otlptracehttp.NewClient(opts...)
+	}
+
+	// the exporter connects lazily, so the node starts while the collector is unreachable
+	exporter, err := otlptrace.New(context.Background(), client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the OTLP exporter: %w", err)
+	}
+
+	// the instance of the node is told apart by its host name
+	hostname, _ := os.Hostname()
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			semconv.ServiceName(serviceName),
+			semconv.ServiceInstanceID(hostname),
+		)),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of the node
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// Start starts the span with the parent of the context
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan ends the span, recording the error if it is set
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+type blockContext struct {
+	ctx  context.Context
+	span trace.Span
+}
+
+// StartBlock starts the root span of the block lifecycle at the height,
+// which is the parent of the spans started with the block context of the height
+func StartBlock(height uint64, attrs ...attribute.KeyValue) context.Context {
+	ctx, span := Start(context.Background(), "block",
+		append([]attribute.KeyValue{attribute.Int64("block.number", int64(height))}, attrs...)...)
+
+	blockContextsLock.Lock()
+	defer blockContextsLock.Unlock()
+
+	if previous, ok := blockContexts[height]; ok {
+		previous.span.End()
+	}
+
+	blockContexts[height] = &blockContext{ctx: ctx, span: span}
+
+	// the heights which were never ended (e.g. the node was syncing) are dropped
+	for h, block := range blockContexts {
+		if h+maxBlockContexts < height {
+			block.span.End()
+			delete(blockContexts, h)
+		}
+	}
+
+	return ctx
+}
+
+// EndBlock ends the root span of the block at the height
+func EndBlock(height uint64) {
+	blockContextsLock.Lock()
+	defer blockContextsLock.Unlock()
+
+	if block, ok := blockContexts[height]; ok {
+		block.span.End()
+		delete(blockContexts, height)
+	}
+}
+
+// BlockContext returns the context of the block at the height,
+// or the background context if the block lifecycle is not traced
+func BlockContext(height uint64) context.Context {
+	blockContextsLock.RLock()
+	defer blockContextsLock.RUnlock()
+
+	if block, ok := blockContexts[height]; ok {
+		return block.ctx
+	}
+
+	return context.Background()
+}
+
+// AddBlockEvent adds the event to the root span of the block at the height
+func AddBlockEvent(height uint64, name string, attrs ...attribute.KeyValue) {
+	blockContextsLock.RLock()
+	defer blockContextsLock.RUnlock()
+
+	if block, ok := blockContexts[height]; ok {
+		block.span.AddEvent(name, trace.WithAttributes(attrs...))
+	}
+}
@@ -0,0 +1,33 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.False(t, (*Config)(nil).Enabled())
+	require.NoError(t, (&Config{Protocol: "unknown"}).Validate())
+	require.NoError(t, (&Config{Endpoint: "localhost:4317", Protocol: ProtocolHTTP, SampleRatio: 0.5}).Validate())
+	require.ErrorIs(t, (&Config{Endpoint: "localhost:4317", Protocol: "udp"}).Validate(), errUnknownProtocol)
+	require.ErrorIs(t, (&Config{Endpoint: "localhost:4317", Protocol: ProtocolGRPC, SampleRatio: 2}).Validate(), errSampleRatio)
+}
+
+func TestBlockContexts(t *testing.T) {
+	ctx := StartBlock(10)
+	require.Equal(t, ctx, BlockContext(10))
+	require.Equal(t, context.Background(), BlockContext(11))
+
+	AddBlockEvent(10, "event")
+
+	// the heights which were never ended are dropped
+	StartBlock(10 + maxBlockContexts + 1)
+	require.Equal(t, context.Background(), BlockContext(10))
+
+	EndBlock(10 + maxBlockContexts + 1)
+	require.Equal(t, context.Background(), BlockContext(10+maxBlockContexts+1))
+}
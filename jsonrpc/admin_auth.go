@@ -0,0 +1,114 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// adminNamespace is the prefix of the methods of the admin endpoint
+	adminNamespace = "admin_"
+
+	// bearerPrefix is the prefix of the admin token in the Authorization header
+	bearerPrefix = "Bearer "
+)
+
+// adminReadMethods are the admin methods only reading the configuration of the node, which are available
+// without the admin token if none is configured. All the other admin methods are the control methods,
+// so the new admin methods are protected by default
+var adminReadMethods = map[string]struct{}{
+	"admin_peerFilters": {},
+}
+
+// adminControlMethods are the methods of the other namespaces changing the operation of the node,
+// which require the admin token as the admin control methods
+var adminControlMethods = map[string]struct{}{
+	"debug_setProfilingRate": {},
+}
+
+// isAdminControlMethod tells whether the method changes the operation of the node
+func isAdminControlMethod(method string) bool {
+	if strings.HasPrefix(method, adminNamespace) {
+		_, read := adminReadMethods[method]
+
+		return !read
+	}
+
+	_, control := adminControlMethods[method]
+
+	return control
+}
+
+// adminAuth authorizes the calls of the admin methods. If the token is set,
+// all the admin methods require it, otherwise the control methods are disabled
+type adminAuth struct {
+	token string
+}
+
+// authorized checks the request carries the admin token in the Authorization header
+func (a *adminAuth) authorized(r *http.Request) bool {
+	if a.token == "" {
+		return false
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), bearerPrefix)
+
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(a.token)) == 1
+}
+
+// check returns the error for the first admin method the client is not allowed to call, nil if all are allowed
+func (a *adminAuth) check(methods []string, authorized bool) Error {
+	for _, method := range methods {
+		control := isAdminControlMethod(method)
+		if !control && !strings.HasPrefix(method, adminNamespace) {
+			continue
+		}
+
+		if a.token == "" {
+			if control {
+				return NewUnauthorizedError("the admin controls are disabled, the node has no admin token")
+			}
+
+			continue
+		}
+
+		if !authorized {
+			return NewUnauthorizedError("the admin methods require a valid admin token")
+		}
+	}
+
+	return nil
+}
+
+// adminAuthMiddleware rejects the http requests calling the admin methods the client is not authorized for
+func adminAuthMiddleware(auth *adminAuth) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || r.Body == nil {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+			if err != nil {
+				_, _ = w.Write([]byte(err.Error()))
+
+				return
+			}
+
+			if rpcErr := auth.check(requestMethods(body), auth.authorized(r)); rpcErr != nil {
+				writeHTTPError(w, http.StatusUnauthorized, rpcErr)
+
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package jsonrpc
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminAuthMiddleware(t *testing.T) {
+	t.Parallel()
+
+	newHandler := func(token string) http.Handler {
+		return adminAuthMiddleware(&adminAuth{token: token})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// the body is passed on to the next handler
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.NotEmpty(t, body)
+
+			w.WriteHeader(http.StatusOK)
+		}))
+	}
+
+	send := func(handler http.Handler, body string, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", bearerPrefix+token)
+		}
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		return recorder
+	}
+
+	var (
+		peers     = `{"method": "admin_peerFilters"}`
+		control   = `{"method": "admin_pauseProposing"}`
+		batch     = `[{"method": "eth_blockNumber"}, {"method": "admin_flushTxPool"}]`
+		nonAdmin  = `{"method": "eth_blockNumber"}`
+		debug     = `{"method": "debug_setProfilingRate"}`
+		withToken = newHandler("secret")
+		noToken   = newHandler("")
+	)
+
+	// without the admin token, the controls are disabled and the other admin methods are open
+	assert.Equal(t, http.StatusOK, send(noToken, peers, "").Code)
+	assert.Equal(t, http.StatusOK, send(noToken, nonAdmin, "").Code)
+	assert.Equal(t, http.StatusUnauthorized, send(noToken, control, "").Code)
+	assert.Equal(t, http.StatusUnauthorized, send(noToken, control, "secret").Code)
+	assert.Equal(t, http.StatusUnauthorized, send(noToken, debug, "").Code)
+
+	// with the admin token, all the admin methods require it
+	assert.Equal(t, http.StatusOK, send(withToken, nonAdmin, "").Code)
+	assert.Equal(t, http.StatusUnauthorized, send(withToken, peers, "").Code)
+	assert.Equal(t, http.StatusUnauthorized, send(withToken, control, "wrong").Code)
+	assert.Equal(t, http.StatusUnauthorized, send(withToken, batch, "").Code)
+	assert.Equal(t, http.StatusOK, send(withToken, peers, "secret").Code)
+	assert.Equal(t, http.StatusOK, send(withToken, control, "secret").Code)
+	assert.Equal(t, http.StatusOK, send(withToken, batch, "secret").Code)
+
+	// the debug controls require the admin token as well
+	assert.Equal(t, http.StatusUnauthorized, send(withToken, debug, "").Code)
+	assert.Equal(t, http.StatusOK, send(withToken, debug, "secret").Code)
+
+	resp := send(withToken, control, "wrong")
+	assert.Contains(t, resp.Body.String(), "require a valid admin token")
+
+	// the admin methods are the controls unless they only read the node configuration
+	assert.Equal(t, http.StatusUnauthorized, send(noToken, `{"method": "admin_newMethod"}`, "").Code)
+	assert.Equal(t, http.StatusUnauthorized, send(noToken, `{"method": "admin_removeTrustedPeer"}`, "").Code)
+}
+
+func TestIsAdminControlMethod(t *testing.T) {
+	t.Parallel()
+
+	for _, method := range []string{
+		"admin_addPeer",
+		"admin_removePeer",
+		"admin_addTrustedPeer",
+		"admin_removeTrustedPeer",
+		"admin_setPeerFilters",
+		"admin_pauseProposing",
+		"admin_maintenanceStatus",
+		"debug_setProfilingRate",
+	} {
+		assert.True(t, isAdminControlMethod(method), method)
+	}
+
+	for _, method := range []string{"admin_peerFilters", "debug_traceCall", "eth_blockNumber"} {
+		assert.False(t, isAdminControlMethod(method), method)
+	}
+}
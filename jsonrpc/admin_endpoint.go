@@ -0,0 +1,181 @@
+package jsonrpc
+
+import (
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/helper/logging"
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// adminStore provides methods needed for Admin endpoint
+type adminStore interface {
+	AddStaticPeer(rawAddr string) error
+	RemoveStaticPeer(rawAddr string) error
+	AddTrustedPeer(rawAddr string) error
+	RemoveTrustedPeer(rawAddr string) error
+	PeerFilters() *network.PeerFilters
+	SetPeerFilters(filters *network.PeerFilters) error
+
+	SetProposingPaused(paused bool) error
+	ProposingPaused() bool
+	SetTxPoolPaused(paused bool)
+	TxPoolPaused() bool
+	FlushTxPool() uint64
+	SetLogLevel(module string, level hclog.Level) error
+	LogLevels() (hclog.Level, map[string]hclog.Level)
+	RegenerateStateSnapshot() (types.Hash, error)
+}
+
+// maintenanceStatus is the state of the operational controls of the node
+type maintenanceStatus struct {
+	ProposingPaused bool              `json:"proposingPaused"`
+	TxPoolPaused    bool              `json:"txPoolPaused"`
+	LogLevel        string            `json:"logLevel"`
+	ModuleLogLevels map[string]string `json:"moduleLogLevels"`
+}
+
+// Admin is the admin jsonrpc endpoint, which manages the peers and the peer filters of the node at runtime.
+// The peers are given as multiaddrs containing the peer ID, e.g. /ip4/127.0.0.1/tcp/1478/p2p/16Uiu2HAm...
+// It also exposes the operational controls of the node. Managing the peers and the controls require the admin token
+type Admin struct {
+	store adminStore
+}
+
+// AddPeer adds the static peer, which is dialed and kept connected
+func (a *Admin) AddPeer(addr string) (interface{}, error) {
+	if err := a.store.AddStaticPeer(addr); err != nil {
+		return nil, err
+	}
+
+	return true, nil
+}
+
+// RemovePeer removes the static peer and disconnects from it
+func (a *Admin) RemovePeer(addr string) (interface{}, error) {
+	if err := a.store.RemoveStaticPeer(addr); err != nil {
+		return nil, err
+	}
+
+	return true, nil
+}
+
+// AddTrustedPeer adds the trusted peer, which is accepted even if all the connection slots are taken
+func (a *Admin) AddTrustedPeer(addr string) (interface{}, error) {
+	if err := a.store.AddTrustedPeer(addr); err != nil {
+		return nil, err
+	}
+
+	return true, nil
+}
+
+// RemoveTrustedPeer removes the trusted peer, without disconnecting from it
+func (a *Admin) RemoveTrustedPeer(addr string) (interface{}, error) {
+	if err := a.store.RemoveTrustedPeer(addr); err != nil {
+		return nil, err
+	}
+
+	return true, nil
+}
+
+// PeerFilters returns the CIDR lists the peer connections are filtered by
+func (a *Admin) PeerFilters() (interface{}, error) {
+	return a.store.PeerFilters(), nil
+}
+
+// SetPeerFilters replaces the CIDR lists the peer connections are filtered by,
+// the connected peers which are not allowed anymore are disconnected
+func (a *Admin) SetPeerFilters(filters *network.PeerFilters) (interface{}, error) {
+	if err := a.store.SetPeerFilters(filters); err != nil {
+		return nil, err
+	}
+
+	return true, nil
+}
+
+// PauseProposing stops the node from proposing the blocks (maintenance mode),
+// the node keeps validating and voting on the blocks of the other proposers
+func (a *Admin) PauseProposing() (interface{}, error) {
+	if err := a.store.SetProposingPaused(true); err != nil {
+		return nil, err
+	}
+
+	return true, nil
+}
+
+// ResumeProposing resumes proposing the blocks
+func (a *Admin) ResumeProposing() (interface{}, error) {
+	if err := a.store.SetProposingPaused(false); err != nil {
+		return nil, err
+	}
+
+	return true, nil
+}
+
+// PauseTxPool stops accepting the new transactions into the pool, both local and gossiped
+func (a *Admin) PauseTxPool() (interface{}, error) {
+	a.store.SetTxPoolPaused(true)
+
+	return true, nil
+}
+
+// ResumeTxPool resumes accepting the new transactions into the pool
+func (a *Admin) ResumeTxPool() (interface{}, error) {
+	a.store.SetTxPoolPaused(false)
+
+	return true, nil
+}
+
+// FlushTxPool drops all the transactions of the pool, it returns the number of the dropped transactions
+func (a *Admin) FlushTxPool() (interface{}, error) {
+	return argUint64(a.store.FlushTxPool()), nil
+}
+
+// SetLogLevel sets the log level of the module (e.g. "network" or "polybft.consensus"),
+// or of the whole node if the module is not given. The level "default" removes the override of the module
+func (a *Admin) SetLogLevel(rawLevel string, module *string) (interface{}, error) {
+	level, err := logging.ParseLevel(rawLevel)
+	if err != nil {
+		return nil, NewInvalidParamsError(err.Error())
+	}
+
+	var name string
+	if module != nil {
+		name = *module
+	}
+
+	if err := a.store.SetLogLevel(name, level); err != nil {
+		return nil, NewInvalidParamsError(err.Error())
+	}
+
+	return true, nil
+}
+
+// RegenerateStateSnapshot rebuilds the flat state snapshot at the head of the chain in the background,
+// it returns the state root the snapshot is generated for
+func (a *Admin) RegenerateStateSnapshot() (interface{}, error) {
+	root, err := a.store.RegenerateStateSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	return root, nil
+}
+
+// MaintenanceStatus returns the state of the operational controls of the node, including the log levels
+func (a *Admin) MaintenanceStatus() (interface{}, error) {
+	root, overrides := a.store.LogLevels()
+
+	status := &maintenanceStatus{
+		ProposingPaused: a.store.ProposingPaused(),
+		TxPoolPaused:    a.store.TxPoolPaused(),
+		LogLevel:        root.String(),
+		ModuleLogLevels: make(map[string]string, len(overrides)),
+	}
+
+	for module, level := range overrides {
+		status.ModuleLogLevels[module] = level.String()
+	}
+
+	return status, nil
+}
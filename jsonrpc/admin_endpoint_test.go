@@ -0,0 +1,256 @@
+package jsonrpc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type mockAdminStore struct {
+	*mockStore
+
+	static  map[string]bool
+	trusted map[string]bool
+	filters *network.PeerFilters
+
+	proposingPaused bool
+	txPoolPaused    bool
+	txPoolSize      uint64
+	logLevel        hclog.Level
+	moduleLogLevels map[string]hclog.Level
+}
+
+func (m *mockAdminStore) AddStaticPeer(rawAddr string) error {
+	if rawAddr == "invalid" {
+		return errors.New("invalid peer address")
+	}
+
+	m.static[rawAddr] = true
+
+	return nil
+}
+
+func (m *mockAdminStore) RemoveStaticPeer(rawAddr string) error {
+	delete(m.static, rawAddr)
+
+	return nil
+}
+
+func (m *mockAdminStore) AddTrustedPeer(rawAddr string) error {
+	m.trusted[rawAddr] = true
+
+	return nil
+}
+
+func (m *mockAdminStore) RemoveTrustedPeer(rawAddr string) error {
+	delete(m.trusted, rawAddr)
+
+	return nil
+}
+
+func (m *mockAdminStore) PeerFilters() *network.PeerFilters {
+	return m.filters
+}
+
+func (m *mockAdminStore) SetPeerFilters(filters *network.PeerFilters) error {
+	if len(filters.InboundDeny) > 0 && filters.InboundDeny[0] == "invalid" {
+		return errors.New("invalid CIDR")
+	}
+
+	m.filters = filters
+
+	return nil
+}
+
+func (m *mockAdminStore) SetProposingPaused(paused bool) error {
+	m.proposingPaused = paused
+
+	return nil
+}
+
+func (m *mockAdminStore) ProposingPaused() bool {
+	return m.proposingPaused
+}
+
+func (m *mockAdminStore) SetTxPoolPaused(paused bool) {
+	m.txPoolPaused = paused
+}
+
+func (m *mockAdminStore) TxPoolPaused() bool {
+	return m.txPoolPaused
+}
+
+func (m *mockAdminStore) FlushTxPool() uint64 {
+	dropped := m.txPoolSize
+	m.txPoolSize = 0
+
+	return dropped
+}
+
+func (m *mockAdminStore) SetLogLevel(module string, level hclog.Level) error {
+	switch {
+	case module == "":
+		m.logLevel = level
+	case level == hclog.NoLevel:
+		delete(m.moduleLogLevels, module)
+	default:
+		m.moduleLogLevels[module] = level
+	}
+
+	return nil
+}
+
+func (m *mockAdminStore) LogLevels() (hclog.Level, map[string]hclog.Level) {
+	return m.logLevel, m.moduleLogLevels
+}
+
+func (m *mockAdminStore) RegenerateStateSnapshot() (types.Hash, error) {
+	return types.StringToHash("0x1"), nil
+}
+
+func TestAdminEndpoint_Peers(t *testing.T) {
+	store := &mockAdminStore{
+		mockStore: newMockStore(),
+		static:    make(map[string]bool),
+		trusted:   make(map[string]bool),
+	}
+
+	dispatcher := newTestDispatcher(t, hclog.NewNullLogger(), store, &dispatcherParams{})
+
+	call := func(method string, addr string) ([]byte, error) {
+		return dispatcher.Handle([]byte(`{
+			"method": "` + method + `",
+			"params": ["` + addr + `"]
+		}`))
+	}
+
+	addr := "/ip4/127.0.0.1/tcp/1478/p2p/16Uiu2HAmJxxH1tScDX2rLGSU9exnuvZKNM9SoK3v315azp68DLPW"
+
+	for _, method := range []string{"admin_addPeer", "admin_addTrustedPeer"} {
+		resp, err := call(method, addr)
+		require.NoError(t, err)
+
+		var res bool
+
+		require.NoError(t, expectJSONResult(resp, &res))
+		assert.True(t, res)
+	}
+
+	assert.True(t, store.static[addr])
+	assert.True(t, store.trusted[addr])
+
+	for _, method := range []string{"admin_removePeer", "admin_removeTrustedPeer"} {
+		_, err := call(method, addr)
+		require.NoError(t, err)
+	}
+
+	assert.Empty(t, store.static)
+	assert.Empty(t, store.trusted)
+
+	resp, err := call("admin_addPeer", "invalid")
+	require.NoError(t, err)
+
+	var res bool
+
+	assert.Error(t, expectJSONResult(resp, &res))
+}
+
+func TestAdminEndpoint_PeerFilters(t *testing.T) {
+	store := &mockAdminStore{
+		mockStore: newMockStore(),
+		filters:   &network.PeerFilters{},
+	}
+
+	dispatcher := newTestDispatcher(t, hclog.NewNullLogger(), store, &dispatcherParams{})
+
+	resp, err := dispatcher.Handle([]byte(`{
+		"method": "admin_setPeerFilters",
+		"params": [{"inbound_allow": ["10.0.0.0/8"], "outbound_deny": ["192.168.1.1"]}]
+	}`))
+	require.NoError(t, err)
+
+	var res bool
+
+	require.NoError(t, expectJSONResult(resp, &res))
+	assert.True(t, res)
+
+	resp, err = dispatcher.Handle([]byte(`{"method": "admin_peerFilters", "params": []}`))
+	require.NoError(t, err)
+
+	filters := &network.PeerFilters{}
+
+	require.NoError(t, expectJSONResult(resp, filters))
+	assert.Equal(t, []string{"10.0.0.0/8"}, filters.InboundAllow)
+	assert.Equal(t, []string{"192.168.1.1"}, filters.OutboundDeny)
+	assert.Empty(t, filters.InboundDeny)
+
+	resp, err = dispatcher.Handle([]byte(`{
+		"method": "admin_setPeerFilters",
+		"params": [{"inbound_deny": ["invalid"]}]
+	}`))
+	require.NoError(t, err)
+
+	assert.Error(t, expectJSONResult(resp, &res))
+	assert.Equal(t, []string{"10.0.0.0/8"}, store.filters.InboundAllow)
+}
+
+func TestAdminEndpoint_Controls(t *testing.T) {
+	store := &mockAdminStore{
+		mockStore:       newMockStore(),
+		txPoolSize:      3,
+		logLevel:        hclog.Info,
+		moduleLogLevels: make(map[string]hclog.Level),
+	}
+
+	dispatcher := newTestDispatcher(t, hclog.NewNullLogger(), store, &dispatcherParams{})
+
+	call := func(method string, params string, result interface{}) error {
+		resp, err := dispatcher.Handle([]byte(`{"method": "` + method + `", "params": [` + params + `]}`))
+		require.NoError(t, err)
+
+		return expectJSONResult(resp, result)
+	}
+
+	var res bool
+
+	require.NoError(t, call("admin_pauseProposing", "", &res))
+	require.NoError(t, call("admin_pauseTxPool", "", &res))
+	require.NoError(t, call("admin_setLogLevel", `"debug", "network"`, &res))
+	require.NoError(t, call("admin_setLogLevel", `"warn"`, &res))
+
+	status := &maintenanceStatus{}
+
+	require.NoError(t, call("admin_maintenanceStatus", "", status))
+	assert.Equal(t, &maintenanceStatus{
+		ProposingPaused: true,
+		TxPoolPaused:    true,
+		LogLevel:        "warn",
+		ModuleLogLevels: map[string]string{"network": "debug"},
+	}, status)
+
+	var dropped argUint64
+
+	require.NoError(t, call("admin_flushTxPool", "", &dropped))
+	assert.Equal(t, argUint64(3), dropped)
+
+	var root types.Hash
+
+	require.NoError(t, call("admin_regenerateStateSnapshot", "", &root))
+	assert.Equal(t, types.StringToHash("0x1"), root)
+
+	require.NoError(t, call("admin_resumeProposing", "", &res))
+	require.NoError(t, call("admin_resumeTxPool", "", &res))
+	require.NoError(t, call("admin_setLogLevel", `"default", "network"`, &res))
+
+	assert.False(t, store.proposingPaused)
+	assert.False(t, store.txPoolPaused)
+	assert.Empty(t, store.moduleLogLevels)
+
+	assert.Error(t, call("admin_setLogLevel", `"verbose"`, &res))
+}
@@ -8,6 +8,9 @@ import (
 type bridgeStore interface {
 	GenerateExitProof(exitID uint64) (types.Proof, error)
 	GetStateSyncProof(stateSyncID uint64) (types.Proof, error)
+	GetTokenMappings(rootToken types.Address) ([]*types.BridgeTokenMapping, error)
+	GetDepositStatus(txHash types.Hash) ([]*types.BridgeTransferStatus, error)
+	GetWithdrawalStatus(txHash types.Hash) ([]*types.BridgeTransferStatus, error)
 }
 
 // Bridge is the bridge jsonrpc endpoint
@@ -24,3 +27,18 @@ func (b *Bridge) GenerateExitProof(exitID argUint64) (interface{}, error) {
 func (b *Bridge) GetStateSyncProof(stateSyncID argUint64) (interface{}, error) {
 	return b.store.GetStateSyncProof(uint64(stateSyncID))
 }
+
+// GetTokenMappings returns the child chain tokens the rootchain token is mapped to by the child predicates
+func (b *Bridge) GetTokenMappings(rootToken types.Address) (interface{}, error) {
+	return b.store.GetTokenMappings(rootToken)
+}
+
+// GetDepositStatus returns the status of the state syncs emitted by the rootchain deposit transaction
+func (b *Bridge) GetDepositStatus(txHash types.Hash) (interface{}, error) {
+	return b.store.GetDepositStatus(txHash)
+}
+
+// GetWithdrawalStatus returns the status of the exit events emitted by the child chain withdrawal transaction
+func (b *Bridge) GetWithdrawalStatus(txHash types.Hash) (interface{}, error) {
+	return b.store.GetWithdrawalStatus(txHash)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/require"
 )
@@ -51,4 +52,62 @@ func TestBridgeEndpoint(t *testing.T) {
 	require.NoError(t, json.Unmarshal(data, resp))
 	require.Nil(t, resp.Error)
 	require.NotNil(t, resp.Result)
+
+	msg = []byte(`{
+		"method": "bridge_getTokenMappings",
+		"params": ["0x0000000000000000000000000000000000000001"],
+		"id": 1
+	}`)
+
+	data, err = dispatcher.HandleWs(msg, mockConnection)
+	require.NoError(t, err)
+
+	resp = new(SuccessResponse)
+	require.NoError(t, json.Unmarshal(data, resp))
+	require.Nil(t, resp.Error)
+
+	var mappings []*types.BridgeTokenMapping
+
+	require.NoError(t, json.Unmarshal(resp.Result, &mappings))
+	require.Len(t, mappings, 1)
+	require.Equal(t, types.StringToAddress("0x1"), mappings[0].RootToken)
+	require.Equal(t, types.StringToAddress("0x2"), mappings[0].ChildToken)
+
+	msg = []byte(`{
+		"method": "bridge_getDepositStatus",
+		"params": ["0x0000000000000000000000000000000000000000000000000000000000000001"],
+		"id": 1
+	}`)
+
+	data, err = dispatcher.HandleWs(msg, mockConnection)
+	require.NoError(t, err)
+
+	resp = new(SuccessResponse)
+	require.NoError(t, json.Unmarshal(data, resp))
+	require.Nil(t, resp.Error)
+
+	var statuses []*types.BridgeTransferStatus
+
+	require.NoError(t, json.Unmarshal(resp.Result, &statuses))
+	require.Len(t, statuses, 1)
+	require.Equal(t, types.BridgeTxCommitted, statuses[0].Status)
+
+	msg = []byte(`{
+		"method": "bridge_getWithdrawalStatus",
+		"params": ["0x0000000000000000000000000000000000000000000000000000000000000002"],
+		"id": 1
+	}`)
+
+	data, err = dispatcher.HandleWs(msg, mockConnection)
+	require.NoError(t, err)
+
+	resp = new(SuccessResponse)
+	require.NoError(t, json.Unmarshal(data, resp))
+	require.Nil(t, resp.Error)
+
+	statuses = nil
+
+	require.NoError(t, json.Unmarshal(resp.Result, &statuses))
+	require.Len(t, statuses, 1)
+	require.Equal(t, types.BridgeTxExitReady, statuses[0].Status)
 }
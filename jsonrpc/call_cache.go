@@ -0,0 +1,105 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/armon/go-metrics"
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// callCache caches the results of eth_call and eth_estimateGas. The results are keyed by the hash of the block
+// they are executed on top of, so they don't go stale, but the cache is purged once the head changes,
+// since the calls are mostly made on top of the latest block
+type callCache struct {
+	lock sync.Mutex
+	// head is the hash of the head block the cached results were added at
+	head    types.Hash
+	results *lru.Cache
+}
+
+func newCallCache(size int) (*callCache, error) {
+	results, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &callCache{results: results}, nil
+}
+
+// callCacheKey is the input of the call determining its result
+type callCacheKey struct {
+	Method        string
+	Block         types.Hash
+	Tx            *types.Transaction
+	Override      *stateOverride
+	BlockOverride *blockOverride
+}
+
+// hash returns the key of the result in the cache
+func (k *callCacheKey) hash() (types.Hash, error) {
+	raw, err := json.Marshal(k)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	return types.BytesToHash(crypto.Keccak256(raw)), nil
+}
+
+// get returns the cached result of the call, purging the cache if the head has changed
+func (c *callCache) get(head types.Hash, key types.Hash) (interface{}, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if head != c.head {
+		c.results.Purge()
+		c.head = head
+
+		return nil, false
+	}
+
+	return c.results.Get(key)
+}
+
+// add caches the result of the call executed while the given block is the head
+func (c *callCache) add(head types.Hash, key types.Hash, result interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if head != c.head {
+		return
+	}
+
+	c.results.Add(key, result)
+}
+
+// getCachedCall returns the cached result of the call, if any, along with the function caching its result otherwise
+func (e *Eth) getCachedCall(key *callCacheKey) (interface{}, bool, func(result interface{})) {
+	noCache := func(interface{}) {}
+
+	if e.callCache == nil {
+		return nil, false, noCache
+	}
+
+	hash, err := key.hash()
+	if err != nil {
+		e.logger.Debug("failed to compute the call cache key", "method", key.Method, "err", err)
+
+		return nil, false, noCache
+	}
+
+	head := e.store.Header().Hash
+
+	if result, ok := e.callCache.get(head, hash); ok {
+		metrics.IncrCounter([]string{jsonRPCMetric, key.Method + "_cache_hits"}, 1)
+
+		return result, true, noCache
+	}
+
+	return nil, false, func(result interface{}) {
+		e.callCache.add(head, hash, result)
+	}
+}
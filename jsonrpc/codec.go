@@ -1,6 +1,7 @@
 package jsonrpc
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -74,9 +75,34 @@ func (s *SuccessResponse) Data() json.RawMessage {
 	return json.RawMessage("No Data")
 }
 
-// Bytes return the serialized response
+// Bytes return the serialized response. The result is encoded already, so it is written as is,
+// instead of being validated and compacted again by the json package
 func (s *SuccessResponse) Bytes() ([]byte, error) {
-	return json.Marshal(s)
+	if s.Result == nil || s.Error != nil {
+		return json.Marshal(s)
+	}
+
+	version, err := json.Marshal(s.JSONRPC)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := json.Marshal(s.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return writeBuffered(func(buf *bytes.Buffer) error {
+		buf.WriteString(`{"jsonrpc":`)
+		buf.Write(version)
+		buf.WriteString(`,"id":`)
+		buf.Write(id)
+		buf.WriteString(`,"result":`)
+		buf.Write(s.Result)
+		buf.WriteByte('}')
+
+		return nil
+	})
 }
 
 // ObjectError is a jsonrpc error
@@ -116,15 +142,21 @@ func (e *ObjectError) MarshalJSON() ([]byte, error) {
 }
 
 const (
-	pending  = "pending"
-	latest   = "latest"
-	earliest = "earliest"
+	pending   = "pending"
+	latest    = "latest"
+	earliest  = "earliest"
+	finalized = "finalized"
+	safe      = "safe"
 )
 
+// The finalized and the safe blocks are the head of the chain, since the blocks of polybft
+// are final once they are committed by the quorum of the validators
 const (
-	PendingBlockNumber  = BlockNumber(-3)
-	LatestBlockNumber   = BlockNumber(-2)
-	EarliestBlockNumber = BlockNumber(-1)
+	SafeBlockNumber      = BlockNumber(-5)
+	FinalizedBlockNumber = BlockNumber(-4)
+	PendingBlockNumber   = BlockNumber(-3)
+	LatestBlockNumber    = BlockNumber(-2)
+	EarliestBlockNumber  = BlockNumber(-1)
 )
 
 type BlockNumber int64
@@ -137,7 +169,7 @@ type BlockNumberOrHash struct {
 // UnmarshalJSON will try to extract the filter's data.
 // Here are the possible input formats :
 //
-// 1 - "latest", "pending" or "earliest"	- self-explaining keywords
+// 1 - "latest", "pending", "earliest", "finalized" or "safe"	- self-explaining keywords
 // 2 - "0x2"								- block number #2 (EIP-1898 backward compatible)
 // 3 - {blockNumber:	"0x2"}				- EIP-1898 compliant block number #2
 // 4 - {blockHash:		"0xe0e..."}			- EIP-1898 compliant block hash 0xe0e...
@@ -197,6 +229,10 @@ func stringToBlockNumber(str string) (BlockNumber, error) {
 		return LatestBlockNumber, nil
 	case earliest:
 		return EarliestBlockNumber, nil
+	case finalized:
+		return FinalizedBlockNumber, nil
+	case safe:
+		return SafeBlockNumber, nil
 	}
 
 	n, err := common.ParseUint64orHex(&str)
@@ -6,14 +6,29 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/blockchain/bloombits"
+	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/helper/profiling"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/calltracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/prestatetracer"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/structtracer"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
-const callTracerName = "callTracer"
+const (
+	callTracerName     = "callTracer"
+	prestateTracerName = "prestateTracer"
+
+	// defaultDumpAccounts is the number of the accounts of a state dump range, if not set
+	defaultDumpAccounts = 256
+	// maxDumpAccounts bounds the number of the accounts of a state dump range
+	maxDumpAccounts = 4096
+)
 
 var (
 	defaultTraceTimeout = 5 * time.Second
@@ -24,6 +39,10 @@ var (
 	ErrTraceGenesisBlock = errors.New("genesis is not traceable")
 	// ErrNoConfig is an error returns when config is empty
 	ErrNoConfig = errors.New("missing config object")
+	// ErrEVMStatsDisabled is an error returned when the EVM stats are requested but not collected
+	ErrEVMStatsDisabled = errors.New("evm stats collection is not enabled")
+	// ErrLogIndexDisabled is an error returned when the log index status is requested but the index is not maintained
+	ErrLogIndexDisabled = errors.New("log index is not enabled")
 )
 
 type debugBlockchainStore interface {
@@ -50,6 +69,9 @@ type debugBlockchainStore interface {
 
 	// TraceCall traces a single call at the point when the given header is mined
 	TraceCall(*types.Transaction, *types.Header, tracer.Tracer) (interface{}, error)
+
+	// TraceCallBundles traces the bundles of calls applied one after another on top of the given header
+	TraceCallBundles(*types.Header, []*CallBundle, tracer.Tracer) ([][]interface{}, error)
 }
 
 type debugTxPoolStore interface {
@@ -58,6 +80,12 @@ type debugTxPoolStore interface {
 
 type debugStateStore interface {
 	GetAccount(root types.Hash, addr types.Address) (*Account, error)
+
+	// DumpState returns a range of the accounts of the state, starting at the given address hash
+	DumpState(root types.Hash, start types.Hash, maxAccounts int) (*itrie.StateDump, error)
+
+	// GetStateDiff returns the change of the state made by the block with the given hash
+	GetStateDiff(hash types.Hash) (*state.StateDiff, error)
 }
 
 type debugStore interface {
@@ -70,6 +98,9 @@ type debugStore interface {
 type Debug struct {
 	store      debugStore
 	throttling *Throttling
+
+	// logIndex is the index of the log blooms, nil if the index is not maintained
+	logIndex LogIndex
 }
 
 func NewDebug(store debugStore, requestsPerSecond uint64) *Debug {
@@ -213,6 +244,198 @@ func (d *Debug) TraceCall(
 	)
 }
 
+// TraceCallMany traces the bundles of calls executed one after another on top of the given block
+func (d *Debug) TraceCallMany(
+	bundles []*callBundle,
+	filter BlockNumberOrHash,
+	config *TraceConfig,
+) (interface{}, error) {
+	return d.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			header, err := GetHeaderFromBlockNumberOrHash(filter, d.store)
+			if err != nil {
+				return nil, ErrHeaderNotFound
+			}
+
+			callBundles, err := decodeCallBundles(bundles, header, d.store)
+			if err != nil {
+				return nil, err
+			}
+
+			tracer, cancel, err := newTracer(config)
+			if err != nil {
+				return nil, err
+			}
+
+			defer cancel()
+
+			return d.store.TraceCallBundles(header, callBundles, tracer)
+		},
+	)
+}
+
+// evmOpcodeStats are the statistics of the executions of the opcode
+type evmOpcodeStats struct {
+	Opcode        string `json:"opcode"`
+	Count         uint64 `json:"count"`
+	Gas           uint64 `json:"gas"`
+	TotalTimeNs   int64  `json:"totalTimeNs"`
+	AverageTimeNs int64  `json:"averageTimeNs"`
+}
+
+// DumpState returns a range of the accounts of the state at the given block, along with their code and storage,
+// in the genesis allocation format. The accounts are ordered by the hashes of their addresses,
+// the range starts at the given address hash and the next range starts at the returned next hash
+func (d *Debug) DumpState(blockNumber BlockNumber, start *types.Hash, maxAccounts *argUint64) (interface{}, error) {
+	return d.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			num, err := GetNumericBlockNumber(blockNumber, d.store)
+			if err != nil {
+				return nil, err
+			}
+
+			header, ok := d.store.GetHeaderByNumber(num)
+			if !ok {
+				return nil, fmt.Errorf("block %d not found", num)
+			}
+
+			limit := defaultDumpAccounts
+			if maxAccounts != nil && *maxAccounts > 0 {
+				limit = int(common.Min(uint64(*maxAccounts), maxDumpAccounts))
+			}
+
+			from := types.ZeroHash
+			if start != nil {
+				from = *start
+			}
+
+			return d.store.DumpState(header.StateRoot, from, limit)
+		},
+	)
+}
+
+// stateDiffResult is the change of the state made by the block
+type stateDiffResult struct {
+	BlockHash   types.Hash           `json:"blockHash"`
+	BlockNumber argUint64            `json:"blockNumber"`
+	Accounts    []*accountDiffResult `json:"accounts"`
+}
+
+// accountDiffResult is the change of the account, the values of the deleted account are zero
+type accountDiffResult struct {
+	Address     types.Address        `json:"address"`
+	Kind        string               `json:"kind"`
+	Balance     argBig               `json:"balance"`
+	PrevBalance argBig               `json:"prevBalance"`
+	Nonce       argUint64            `json:"nonce"`
+	PrevNonce   argUint64            `json:"prevNonce"`
+	CodeHash    types.Hash           `json:"codeHash"`
+	Code        *argBytes            `json:"code,omitempty"`
+	Storage     []*storageDiffResult `json:"storage"`
+}
+
+// storageDiffResult is the change of the storage slot, the deleted slot has the zero value
+type storageDiffResult struct {
+	Key       types.Hash `json:"key"`
+	Kind      string     `json:"kind"`
+	Value     types.Hash `json:"value"`
+	PrevValue types.Hash `json:"prevValue"`
+}
+
+// GetStateDiff returns the accounts and the storage slots created, updated or deleted by the block.
+// The diffs of the recent blocks are cached if enabled, the others are computed by executing the block again
+func (d *Debug) GetStateDiff(blockHash types.Hash) (interface{}, error) {
+	return d.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			block, ok := d.store.GetBlockByHash(blockHash, false)
+			if !ok {
+				return nil, fmt.Errorf("block %s not found", blockHash)
+			}
+
+			diff, err := d.store.GetStateDiff(blockHash)
+			if err != nil {
+				return nil, err
+			}
+
+			return toStateDiffResult(block.Header, diff), nil
+		},
+	)
+}
+
+func toStateDiffResult(header *types.Header, diff *state.StateDiff) *stateDiffResult {
+	result := &stateDiffResult{
+		BlockHash:   header.Hash,
+		BlockNumber: argUint64(header.Number),
+		Accounts:    make([]*accountDiffResult, len(diff.Accounts)),
+	}
+
+	for i, account := range diff.Accounts {
+		res := &accountDiffResult{
+			Address:     account.Address,
+			Kind:        string(account.Kind),
+			Balance:     argBig(*account.Balance),
+			PrevBalance: argBig(*account.PrevBalance),
+			Nonce:       argUint64(account.Nonce),
+			PrevNonce:   argUint64(account.PrevNonce),
+			CodeHash:    account.CodeHash,
+			Storage:     make([]*storageDiffResult, len(account.Storage)),
+		}
+
+		if len(account.Code) != 0 {
+			res.Code = argBytesPtr(account.Code)
+		}
+
+		for j, slot := range account.Storage {
+			res.Storage[j] = &storageDiffResult{
+				Key:       slot.Key,
+				Kind:      string(slot.Kind),
+				Value:     slot.Value,
+				PrevValue: slot.PrevValue,
+			}
+		}
+
+		result.Accounts[i] = res
+	}
+
+	return result
+}
+
+// EvmStats returns the execution counts, the consumed gas and the execution time of the EVM opcodes
+// since the node started or the stats were reset. The stats are reset after being read if reset is true
+func (d *Debug) EvmStats(reset *bool) (interface{}, error) {
+	if !evm.StatsEnabled() {
+		return nil, ErrEVMStatsDisabled
+	}
+
+	opcodes := evm.GetStats()
+	if reset != nil && *reset {
+		evm.ResetStats()
+	}
+
+	result := make([]*evmOpcodeStats, len(opcodes))
+
+	for i, op := range opcodes {
+		result[i] = &evmOpcodeStats{
+			Opcode:        op.Opcode,
+			Count:         op.Count,
+			Gas:           op.Gas,
+			TotalTimeNs:   op.Duration.Nanoseconds(),
+			AverageTimeNs: op.Duration.Nanoseconds() / int64(op.Count),
+		}
+	}
+
+	return result, nil
+}
+
+// SetProfilingRate sets the sampling rates of the block and the mutex profiles served on the debug port,
+// 0 disables the profile. It returns the previous rates
+func (d *Debug) SetProfilingRate(blockRate argUint64, mutexFraction argUint64) (interface{}, error) {
+	return profiling.SetRates(profiling.Rates{Block: int(blockRate), Mutex: int(mutexFraction)}), nil
+}
+
 func (d *Debug) traceBlock(
 	block *types.Block,
 	config *TraceConfig,
@@ -254,9 +477,12 @@ func newTracer(config *TraceConfig) (
 
 	var tracer tracer.Tracer
 
-	if config.Tracer == callTracerName {
+	switch config.Tracer {
+	case callTracerName:
 		tracer = &calltracer.CallTracer{}
-	} else {
+	case prestateTracerName:
+		tracer = &prestatetracer.PrestateTracer{}
+	default:
 		tracer = structtracer.NewStructTracer(structtracer.Config{
 			EnableMemory:     config.EnableMemory && !config.DisableStructLogs,
 			EnableStack:      !config.DisableStack && !config.DisableStructLogs,
@@ -266,6 +492,12 @@ func newTracer(config *TraceConfig) (
 		})
 	}
 
+	// cancellation of context is done by caller
+	return tracer, cancelOnTimeout(tracer, timeout), nil
+}
+
+// cancelOnTimeout cancels the tracing after the given timeout
+func cancelOnTimeout(tracer tracer.Tracer, timeout time.Duration) context.CancelFunc {
 	timeoutCtx, cancel := context.WithTimeout(context.Background(), timeout)
 
 	go func() {
@@ -276,6 +508,32 @@ func newTracer(config *TraceConfig) (
 		}
 	}()
 
-	// cancellation of context is done by caller
-	return tracer, cancel, nil
+	return cancel
+}
+
+// logIndexStatus is the progress of the log index build
+type logIndexStatus struct {
+	SectionSize   argUint64 `json:"sectionSize"`
+	Sections      argUint64 `json:"sections"`
+	IndexedBlocks argUint64 `json:"indexedBlocks"`
+	HeadBlock     argUint64 `json:"headBlock"`
+	Building      bool      `json:"building"`
+}
+
+// LogIndexStatus returns the progress of the log index build. The log queries read only the matching blocks
+// among the indexed ones, the blocks past them are scanned
+func (d *Debug) LogIndexStatus() (interface{}, error) {
+	if d.logIndex == nil {
+		return nil, ErrLogIndexDisabled
+	}
+
+	status := d.logIndex.Status()
+
+	return &logIndexStatus{
+		SectionSize:   argUint64(bloombits.SectionSize),
+		Sections:      argUint64(status.Sections),
+		IndexedBlocks: argUint64(status.IndexedBlocks),
+		HeadBlock:     argUint64(status.HeadBlock),
+		Building:      status.Building,
+	}, nil
 }
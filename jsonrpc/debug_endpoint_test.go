@@ -10,7 +10,11 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/calltracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/prestatetracer"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/structtracer"
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -24,8 +28,11 @@ type debugEndpointMockStore struct {
 	traceBlockFn        func(*types.Block, tracer.Tracer) ([]interface{}, error)
 	traceTxnFn          func(*types.Block, types.Hash, tracer.Tracer) (interface{}, error)
 	traceCallFn         func(*types.Transaction, *types.Header, tracer.Tracer) (interface{}, error)
+	traceCallBundlesFn  func(*types.Header, []*CallBundle, tracer.Tracer) ([][]interface{}, error)
 	getNonceFn          func(types.Address) uint64
 	getAccountFn        func(types.Hash, types.Address) (*Account, error)
+	dumpStateFn         func(types.Hash, types.Hash, int) (*itrie.StateDump, error)
+	getStateDiffFn      func(types.Hash) (*state.StateDiff, error)
 }
 
 func (s *debugEndpointMockStore) Header() *types.Header {
@@ -60,6 +67,14 @@ func (s *debugEndpointMockStore) TraceCall(tx *types.Transaction, parent *types.
 	return s.traceCallFn(tx, parent, tracer)
 }
 
+func (s *debugEndpointMockStore) TraceCallBundles(
+	header *types.Header,
+	bundles []*CallBundle,
+	tracer tracer.Tracer,
+) ([][]interface{}, error) {
+	return s.traceCallBundlesFn(header, bundles, tracer)
+}
+
 func (s *debugEndpointMockStore) GetNonce(acc types.Address) uint64 {
 	return s.getNonceFn(acc)
 }
@@ -68,6 +83,14 @@ func (s *debugEndpointMockStore) GetAccount(root types.Hash, addr types.Address)
 	return s.getAccountFn(root, addr)
 }
 
+func (s *debugEndpointMockStore) DumpState(root types.Hash, start types.Hash, maxAccounts int) (*itrie.StateDump, error) {
+	return s.dumpStateFn(root, start, maxAccounts)
+}
+
+func (s *debugEndpointMockStore) GetStateDiff(hash types.Hash) (*state.StateDiff, error) {
+	return s.getStateDiffFn(hash)
+}
+
 func TestDebugTraceConfigDecode(t *testing.T) {
 	timeout15s := "15s"
 
@@ -726,6 +749,69 @@ func TestTraceCall(t *testing.T) {
 	}
 }
 
+func TestTraceCallMany(t *testing.T) {
+	t.Parallel()
+
+	var (
+		from  = types.StringToAddress("1")
+		to    = types.StringToAddress("2")
+		gas   = argUint64(10000)
+		nonce = argUint64(1)
+
+		balance = argBig(*big.NewInt(100))
+	)
+
+	store := &debugEndpointMockStore{
+		headerFn: func() *types.Header {
+			return testLatestHeader
+		},
+		getAccountFn: func(h types.Hash, a types.Address) (*Account, error) {
+			return &Account{Nonce: 1}, nil
+		},
+		traceCallBundlesFn: func(
+			header *types.Header,
+			bundles []*CallBundle,
+			tracer tracer.Tracer,
+		) ([][]interface{}, error) {
+			assert.Equal(t, testLatestHeader, header)
+			require.Len(t, bundles, 2)
+			require.Len(t, bundles[0].Transactions, 2)
+			require.Len(t, bundles[1].Transactions, 1)
+			assert.Equal(t, big.NewInt(100), bundles[1].StateOverride[from].Balance)
+
+			return [][]interface{}{{testTraceResult, testTraceResult}, {testTraceResult}}, nil
+		},
+	}
+
+	txArg := func() *txnArgs {
+		return &txnArgs{
+			From:  &from,
+			To:    &to,
+			Gas:   &gas,
+			Nonce: &nonce,
+		}
+	}
+
+	endpoint := NewDebug(store, 100000)
+
+	res, err := endpoint.TraceCallMany(
+		[]*callBundle{
+			{Transactions: []*txnArgs{txArg(), txArg()}},
+			{
+				Transactions:  []*txnArgs{txArg()},
+				StateOverride: &stateOverride{from: overrideAccount{Balance: &balance}},
+			},
+		},
+		BlockNumberOrHash{},
+		&TraceConfig{},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, [][]interface{}{{testTraceResult, testTraceResult}, {testTraceResult}}, res)
+
+	_, err = endpoint.TraceCallMany([]*callBundle{nil}, BlockNumberOrHash{}, &TraceConfig{})
+	assert.Error(t, err)
+}
+
 func Test_newTracer(t *testing.T) {
 	t.Parallel()
 
@@ -747,6 +833,28 @@ func Test_newTracer(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("should create tracer by name", func(t *testing.T) {
+		t.Parallel()
+
+		tracer, cancel, err := newTracer(&TraceConfig{
+			Tracer: callTracerName,
+		})
+		require.NoError(t, err)
+
+		cancel()
+
+		assert.IsType(t, &calltracer.CallTracer{}, tracer)
+
+		tracer, cancel, err = newTracer(&TraceConfig{
+			Tracer: prestateTracerName,
+		})
+		require.NoError(t, err)
+
+		cancel()
+
+		assert.IsType(t, &prestatetracer.PrestateTracer{}, tracer)
+	})
+
 	t.Run("should return error if arg is nil", func(t *testing.T) {
 		t.Parallel()
 
@@ -834,3 +942,128 @@ func Test_newTracer(t *testing.T) {
 		}, st.Config)
 	})
 }
+
+func TestEvmStats_Disabled(t *testing.T) {
+	t.Parallel()
+
+	endpoint := NewDebug(&debugEndpointMockStore{}, 100000)
+
+	_, err := endpoint.EvmStats(nil)
+	require.ErrorIs(t, err, ErrEVMStatsDisabled)
+}
+
+func TestLogIndexStatus(t *testing.T) {
+	t.Parallel()
+
+	endpoint := NewDebug(&debugEndpointMockStore{}, 100000)
+
+	_, err := endpoint.LogIndexStatus()
+	require.ErrorIs(t, err, ErrLogIndexDisabled)
+
+	endpoint.logIndex = &mockLogIndex{indexed: 4096}
+
+	res, err := endpoint.LogIndexStatus()
+	require.NoError(t, err)
+	require.Equal(t, &logIndexStatus{
+		SectionSize:   argUint64(4096),
+		Sections:      argUint64(1),
+		IndexedBlocks: argUint64(4096),
+	}, res)
+}
+
+func TestDumpState(t *testing.T) {
+	t.Parallel()
+
+	var (
+		root  = types.StringToHash("root")
+		start = types.StringToHash("start")
+		calls []int
+	)
+
+	store := &debugEndpointMockStore{
+		headerFn: func() *types.Header {
+			return &types.Header{Number: 10}
+		},
+		getHeaderByNumberFn: func(num uint64) (*types.Header, bool) {
+			return &types.Header{Number: num, StateRoot: root}, num <= 10
+		},
+		dumpStateFn: func(stateRoot types.Hash, from types.Hash, maxAccounts int) (*itrie.StateDump, error) {
+			assert.Equal(t, root, stateRoot)
+
+			calls = append(calls, maxAccounts)
+
+			return &itrie.StateDump{Root: stateRoot, Next: &from}, nil
+		},
+	}
+
+	endpoint := NewDebug(store, 100000)
+
+	res, err := endpoint.DumpState(LatestBlockNumber, &start, argUintPtr(10))
+	require.NoError(t, err)
+	assert.Equal(t, start, *res.(*itrie.StateDump).Next) //nolint:forcetypeassert
+
+	// the range is bounded, and the default range is dumped if the number of the accounts is not set
+	_, err = endpoint.DumpState(BlockNumber(5), nil, argUintPtr(maxDumpAccounts+1))
+	require.NoError(t, err)
+
+	_, err = endpoint.DumpState(BlockNumber(5), nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{10, maxDumpAccounts, defaultDumpAccounts}, calls)
+
+	_, err = endpoint.DumpState(BlockNumber(11), nil, nil)
+	require.Error(t, err)
+}
+
+func TestGetStateDiff(t *testing.T) {
+	t.Parallel()
+
+	var (
+		blockHash = types.StringToHash("block")
+		addr      = types.StringToAddress("1")
+		slot      = types.StringToHash("2")
+	)
+
+	store := &debugEndpointMockStore{
+		getBlockByHashFn: func(hash types.Hash, full bool) (*types.Block, bool) {
+			return &types.Block{Header: &types.Header{Hash: hash, Number: 5}}, hash == blockHash
+		},
+		getStateDiffFn: func(hash types.Hash) (*state.StateDiff, error) {
+			assert.Equal(t, blockHash, hash)
+
+			return &state.StateDiff{
+				Accounts: []*state.AccountDiff{
+					{
+						Address:     addr,
+						Kind:        state.DiffCreated,
+						Balance:     big.NewInt(10),
+						PrevBalance: big.NewInt(0),
+						Nonce:       1,
+						CodeHash:    types.EmptyCodeHash,
+						Storage: []*state.StorageDiff{
+							{Key: slot, Kind: state.DiffCreated, Value: types.StringToHash("3")},
+						},
+					},
+				},
+			}, nil
+		},
+	}
+
+	endpoint := NewDebug(store, 100000)
+
+	res, err := endpoint.GetStateDiff(blockHash)
+	require.NoError(t, err)
+
+	raw, err := json.Marshal(res)
+	require.NoError(t, err)
+
+	expected := `{"blockHash":"` + blockHash.String() + `","blockNumber":"0x5","accounts":[{` +
+		`"address":"` + addr.String() + `","kind":"created","balance":"0xa","prevBalance":"0x0",` +
+		`"nonce":"0x1","prevNonce":"0x0","codeHash":"` + types.EmptyCodeHash.String() + `",` +
+		`"storage":[{"key":"` + slot.String() + `","kind":"created","value":"` + types.StringToHash("3").String() +
+		`","prevValue":"` + types.ZeroHash.String() + `"}]}]}`
+	assert.JSONEq(t, expected, string(raw))
+
+	_, err = endpoint.GetStateDiff(types.StringToHash("unknown"))
+	require.ErrorContains(t, err, "not found")
+}
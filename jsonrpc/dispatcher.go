@@ -2,6 +2,7 @@ package jsonrpc
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,11 +10,15 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 	"unicode"
 
 	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/0xPolygon/polygon-edge/helper/tracing"
 )
 
 type serviceData struct {
@@ -33,12 +38,15 @@ func (f *funcData) numParams() int {
 }
 
 type endpoints struct {
-	Eth    *Eth
-	Web3   *Web3
-	Net    *Net
-	TxPool *TxPool
-	Bridge *Bridge
-	Debug  *Debug
+	Eth       *Eth
+	Web3      *Web3
+	Net       *Net
+	TxPool    *TxPool
+	Bridge    *Bridge
+	Debug     *Debug
+	Trace     *Trace
+	Admin     *Admin
+	Validator *Validator
 }
 
 // Dispatcher handles all json rpc requests by delegating
@@ -49,7 +57,13 @@ type Dispatcher struct {
 	filterManager *FilterManager
 	endpoints     endpoints
 
+	// methodThrottling limits the number of concurrent requests per method
+	methodThrottling map[string]*Throttling
+
 	params *dispatcherParams
+
+	// batchLengthLimit is the maximum length of the batch requests, 0 for no limit, updated on the config reload
+	batchLengthLimit atomic.Uint64
 }
 
 type dispatcherParams struct {
@@ -61,10 +75,40 @@ type dispatcherParams struct {
 	blockRangeLimit         uint64
 
 	concurrentRequestsDebug uint64
+
+	pendingTxsRateLimit uint64
+
+	// callTimeout caps the execution time of eth_call and eth_estimateGas
+	callTimeout time.Duration
+
+	// methodConcurrencyLimits maps the method names to the maximum number of their concurrent requests
+	methodConcurrencyLimits map[string]uint64
+
+	// stateHistory is the number of the most recent blocks whose state is retained by the node,
+	// 0 means the state of all blocks is retained (archive mode)
+	stateHistory uint64
+
+	// logIndex is the index of the log blooms, nil if the index is not maintained
+	logIndex LogIndex
+
+	// wsMaxSubscriptions is the maximum number of the subscriptions of a websocket connection, 0 for no limit
+	wsMaxSubscriptions uint64
+
+	// callCacheSize is the number of the cached results of eth_call and eth_estimateGas, 0 disables the cache
+	callCacheSize uint64
+
+	// encodingCacheSize is the number of the cached encoded blocks and block receipts, 0 disables the cache
+	encodingCacheSize uint64
 }
 
-func (dp dispatcherParams) isExceedingBatchLengthLimit(value uint64) bool {
-	return dp.jsonRPCBatchLengthLimit != 0 && value > dp.jsonRPCBatchLengthLimit
+// methodThrottlingWaitTimeout is how long a request waits for a free slot
+// of its method concurrency limit before it is rejected
+const methodThrottlingWaitTimeout = time.Second
+
+func (d *Dispatcher) isExceedingBatchLengthLimit(value uint64) bool {
+	limit := d.batchLengthLimit.Load()
+
+	return limit != 0 && value > limit
 }
 
 func newDispatcher(
@@ -77,8 +121,11 @@ func newDispatcher(
 		params: params,
 	}
 
+	d.batchLengthLimit.Store(params.jsonRPCBatchLengthLimit)
+
 	if store != nil {
-		d.filterManager = NewFilterManager(logger, store, params.blockRangeLimit)
+		d.filterManager = NewFilterManager(logger, store, params.blockRangeLimit, params.pendingTxsRateLimit)
+		d.filterManager.logIndex = params.logIndex
 		go d.filterManager.Run()
 	}
 
@@ -86,17 +133,61 @@ func newDispatcher(
 		return nil, err
 	}
 
+	if err := d.initMethodThrottling(); err != nil {
+		return nil, err
+	}
+
 	return d, nil
 }
 
+// initMethodThrottling sets up the concurrency limits of the configured methods
+func (d *Dispatcher) initMethodThrottling() error {
+	d.methodThrottling = make(map[string]*Throttling, len(d.params.methodConcurrencyLimits))
+
+	for method, limit := range d.params.methodConcurrencyLimits {
+		if _, _, err := d.getFnHandler(Request{Method: method}); err != nil {
+			return fmt.Errorf("invalid concurrency limit: %w", err)
+		}
+
+		if limit == 0 {
+			return fmt.Errorf("invalid concurrency limit for the method %s: limit must be greater than 0", method)
+		}
+
+		d.methodThrottling[method] = NewThrottling(limit, methodThrottlingWaitTimeout)
+	}
+
+	return nil
+}
+
 func (d *Dispatcher) registerEndpoints(store JSONRPCStore) error {
 	d.endpoints.Eth = &Eth{
-		d.logger,
-		store,
-		d.params.chainID,
-		d.filterManager,
-		d.params.priceLimit,
+		logger:        d.logger,
+		store:         store,
+		chainID:       d.params.chainID,
+		filterManager: d.filterManager,
+		callTimeout:   d.params.callTimeout,
+		stateHistory:  d.params.stateHistory,
+	}
+	d.endpoints.Eth.priceLimit.Store(d.params.priceLimit)
+
+	if d.params.callCacheSize != 0 {
+		callCache, err := newCallCache(int(d.params.callCacheSize))
+		if err != nil {
+			return err
+		}
+
+		d.endpoints.Eth.callCache = callCache
+	}
+
+	if d.params.encodingCacheSize != 0 {
+		encodingCache, err := newEncodingCache(int(d.params.encodingCacheSize))
+		if err != nil {
+			return err
+		}
+
+		d.endpoints.Eth.encodingCache = encodingCache
 	}
+
 	d.endpoints.Net = &Net{
 		store,
 		d.params.chainID,
@@ -111,6 +202,15 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) error {
 		store,
 	}
 	d.endpoints.Debug = NewDebug(store, d.params.concurrentRequestsDebug)
+	d.endpoints.Debug.logIndex = d.params.logIndex
+	// trace requests replay the blocks as the debug ones, so they share the concurrency limit setting
+	d.endpoints.Trace = NewTrace(store, d.params.concurrentRequestsDebug, d.params.blockRangeLimit)
+	d.endpoints.Admin = &Admin{
+		store,
+	}
+	d.endpoints.Validator = &Validator{
+		store,
+	}
 
 	var err error
 
@@ -134,7 +234,19 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) error {
 		return err
 	}
 
-	return d.registerService("debug", d.endpoints.Debug)
+	if err = d.registerService("debug", d.endpoints.Debug); err != nil {
+		return err
+	}
+
+	if err = d.registerService("trace", d.endpoints.Trace); err != nil {
+		return err
+	}
+
+	if err = d.registerService("admin", d.endpoints.Admin); err != nil {
+		return err
+	}
+
+	return d.registerService("validator", d.endpoints.Validator)
 }
 
 func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error) {
@@ -184,6 +296,23 @@ func formatID(id interface{}) (interface{}, Error) {
 	}
 }
 
+// logSubscriptionOptions are the optional parameters of the logs subscription
+type logSubscriptionOptions struct {
+	// ResumeToken keeps the subscription alive after the connection is closed,
+	// so it can be resumed by subscribing with the same token
+	ResumeToken string `json:"resumeToken"`
+}
+
+// decodeFromInterface decodes the already unmarshalled json value into the given object
+func decodeFromInterface(i interface{}, v interface{}) error {
+	raw, err := json.Marshal(i)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, v)
+}
+
 func (d *Dispatcher) handleSubscribe(req Request, conn wsConn) (string, Error) {
 	var params []interface{}
 	if err := json.Unmarshal(req.Params, &params); err != nil {
@@ -199,17 +328,44 @@ func (d *Dispatcher) handleSubscribe(req Request, conn wsConn) (string, Error) {
 		return "", NewSubscriptionNotFoundError(subscribeMethod)
 	}
 
+	if limit := d.params.wsMaxSubscriptions; limit > 0 && uint64(d.filterManager.countWsFilters(conn)) >= limit {
+		return "", NewLimitExceededError(fmt.Sprintf("connection subscriptions limit of %d reached", limit))
+	}
+
 	var filterID string
 	if subscribeMethod == "newHeads" {
 		filterID = d.filterManager.NewBlockFilter(conn)
 	} else if subscribeMethod == "logs" {
+		if len(params) < 2 {
+			return "", NewInvalidParamsError("Invalid params")
+		}
+
 		logQuery, err := decodeLogQueryFromInterface(params[1])
 		if err != nil {
 			return "", NewInternalError(err.Error())
 		}
-		filterID = d.filterManager.NewLogFilter(logQuery, conn)
+
+		// optional subscription options
+		var options logSubscriptionOptions
+		if len(params) > 2 {
+			if err := decodeFromInterface(params[2], &options); err != nil {
+				return "", NewInvalidParamsError("Invalid params")
+			}
+		}
+
+		if filterID, err = d.filterManager.NewLogSubscription(logQuery, conn, options.ResumeToken); err != nil {
+			return "", NewInvalidParamsError(err.Error())
+		}
 	} else if subscribeMethod == "newPendingTransactions" {
-		filterID = d.filterManager.NewPendingTxFilter(conn)
+		// optional flag requesting full transaction objects instead of hashes
+		var fullTxs bool
+		if len(params) > 1 {
+			if fullTxs, ok = params[1].(bool); !ok {
+				return "", NewInvalidParamsError("Invalid params")
+			}
+		}
+
+		filterID = d.filterManager.NewPendingTxFilter(conn, fullTxs)
 	} else {
 		return "", NewSubscriptionNotFoundError(subscribeMethod)
 	}
@@ -235,6 +391,17 @@ func (d *Dispatcher) handleUnsubscribe(req Request) (bool, Error) {
 	return d.filterManager.Uninstall(filterID), nil
 }
 
+// SetLimits updates the limits of the endpoints
+func (d *Dispatcher) SetLimits(limits Limits) {
+	d.batchLengthLimit.Store(limits.BatchLengthLimit)
+	d.endpoints.Eth.priceLimit.Store(limits.PriceLimit)
+	d.endpoints.Trace.blockRangeLimit.Store(limits.BlockRangeLimit)
+
+	if d.filterManager != nil {
+		d.filterManager.blockRangeLimit.Store(limits.BlockRangeLimit)
+	}
+}
+
 func (d *Dispatcher) RemoveFilterByWs(conn wsConn) {
 	d.filterManager.RemoveFilterByWs(conn)
 }
@@ -259,7 +426,7 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 		}
 
 		// if not disabled, avoid handling long batch requests
-		if d.params.isExceedingBatchLengthLimit(uint64(len(batchReq))) {
+		if d.isExceedingBatchLengthLimit(uint64(len(batchReq))) {
 			return NewRPCResponse(
 				nil,
 				"2.0",
@@ -359,7 +526,7 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 	}
 
 	// if not disabled, avoid handling long batch requests
-	if d.params.isExceedingBatchLengthLimit(uint64(len(requests))) {
+	if d.isExceedingBatchLengthLimit(uint64(len(requests))) {
 		return NewRPCResponse(
 			nil,
 			"2.0",
@@ -368,22 +535,28 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 		).Bytes()
 	}
 
-	responses := make([]Response, 0)
+	respBytes, err := writeBuffered(func(buf *bytes.Buffer) error {
+		buf.WriteByte('[')
 
-	for _, req := range requests {
-		var response, err = d.handleReq(req)
-		if err != nil {
-			errorResponse := NewRPCResponse(req.ID, "2.0", response, err)
-			responses = append(responses, errorResponse)
+		for i, req := range requests {
+			response, err := d.handleReq(req)
 
-			continue
+			resp, marshalErr := NewRPCResponse(req.ID, "2.0", response, err).Bytes()
+			if marshalErr != nil {
+				return marshalErr
+			}
+
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			buf.Write(resp)
 		}
 
-		resp := NewRPCResponse(req.ID, "2.0", response, nil)
-		responses = append(responses, resp)
-	}
+		buf.WriteByte(']')
 
-	respBytes, err := json.Marshal(responses)
+		return nil
+	})
 	if err != nil {
 		return NewRPCResponse(nil, "2.0", nil, NewInternalError("Internal error")).Bytes()
 	}
@@ -392,6 +565,19 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 }
 
 func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
+	_, span := tracing.Start(context.Background(), "jsonrpc.request", attribute.String("rpc.method", req.Method))
+
+	data, err := d.handleReqImpl(req)
+	if err != nil {
+		tracing.EndSpan(span, err)
+	} else {
+		span.End()
+	}
+
+	return data, err
+}
+
+func (d *Dispatcher) handleReqImpl(req Request) ([]byte, Error) {
 	d.logger.Debug("request", "method", req.Method, "id", req.ID)
 
 	service, fd, ferr := d.getFnHandler(req)
@@ -423,9 +609,18 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 	)
 
 	start := time.Now().UTC()
-	output := fd.fv.Call(inArgs) // call rpc endpoint function
+
+	output, err := d.callWithThrottling(req.Method, fd, inArgs) // call rpc endpoint function
+	if err != nil {
+		metrics.IncrCounter([]string{jsonRPCMetric, req.Method + "_throttled"}, 1)
+
+		return nil, NewLimitExceededError(fmt.Sprintf("too many concurrent %s requests", req.Method))
+	}
+
 	// measure execution time of rpc endpoint function
 	metrics.SetGauge([]string{jsonRPCMetric, req.Method + "_time"}, float32(time.Now().UTC().Sub(start).Seconds()))
+	metrics.MeasureSince([]string{jsonRPCMetric, req.Method + "_latency"}, start)
+	metrics.IncrCounter([]string{jsonRPCMetric, req.Method + "_calls"}, 1)
 
 	if err := getError(output[1]); err != nil {
 		// measure error on the rpc endpoint function
@@ -440,7 +635,12 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 			}
 		}
 
-		return data, NewInvalidRequestError(err.Error())
+		return data, toRPCError(err)
+	}
+
+	// the results encoded by the endpoints are passed through
+	if raw, ok := output[0].Interface().(json.RawMessage); ok {
+		return raw, nil
 	}
 
 	if res := output[0].Interface(); res != nil {
@@ -455,6 +655,26 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 	return data, nil
 }
 
+// callWithThrottling calls the endpoint function, respecting the concurrency limit of the method if there is any
+func (d *Dispatcher) callWithThrottling(method string, fd *funcData, inArgs []reflect.Value) ([]reflect.Value, error) {
+	throttling, ok := d.methodThrottling[method]
+	if !ok {
+		return fd.fv.Call(inArgs), nil
+	}
+
+	var output []reflect.Value
+
+	if _, err := throttling.AttemptRequest(context.Background(), func() (interface{}, error) {
+		output = fd.fv.Call(inArgs)
+
+		return nil, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
 func (d *Dispatcher) logInternalError(method string, err error) {
 	d.logger.Warn("failed to dispatch", "method", method, "err", err)
 }
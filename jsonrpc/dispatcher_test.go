@@ -2,12 +2,19 @@ package jsonrpc
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
@@ -122,6 +129,122 @@ func TestDispatcher_HandleWebsocketConnection_EthSubscribe(t *testing.T) {
 			t.Fatal("\"newPendingTransactions\" event not received in 2 seconds")
 		}
 	})
+
+	t.Run("clients should be able to receive full transactions through eth_subscribe", func(t *testing.T) {
+		t.Parallel()
+
+		tx := newTestTransaction(1, types.Address{0x1})
+		store.addPendingTx(tx)
+
+		mockConnection, msgCh := newMockWsConnWithMsgCh()
+
+		req := []byte(`{
+		"method": "eth_subscribe",
+		"params": ["newPendingTransactions", true]
+	}`)
+		_, err := dispatcher.HandleWs(req, mockConnection)
+		require.NoError(t, err)
+
+		store.emitTxPoolEvent(proto.EventType_ADDED, tx.Hash.String())
+
+		select {
+		case msg := <-msgCh:
+			assert.Contains(t, string(msg), `"nonce":"0x1"`)
+		case <-time.After(2 * time.Second):
+			t.Fatal("\"newPendingTransactions\" event not received in 2 seconds")
+		}
+	})
+
+	t.Run("clients should be able to resume \"logs\" subscription with the resume token", func(t *testing.T) {
+		t.Parallel()
+
+		req := []byte(`{
+		"method": "eth_subscribe",
+		"params": ["logs", {}, {"resumeToken": "dispatcher-token"}]
+	}`)
+
+		oldConn, _ := newMockWsConnWithMsgCh()
+
+		resp, err := dispatcher.HandleWs(req, oldConn)
+		require.NoError(t, err)
+
+		var id string
+		require.NoError(t, expectJSONResult(resp, &id))
+
+		dispatcher.RemoveFilterByWs(oldConn)
+
+		newConn, _ := newMockWsConnWithMsgCh()
+
+		resp, err = dispatcher.HandleWs(req, newConn)
+		require.NoError(t, err)
+
+		var resumedID string
+		require.NoError(t, expectJSONResult(resp, &resumedID))
+		assert.Equal(t, id, resumedID)
+	})
+
+	t.Run("invalid log subscription options", func(t *testing.T) {
+		t.Parallel()
+
+		mockConnection, _ := newMockWsConnWithMsgCh()
+
+		req := []byte(`{
+		"method": "eth_subscribe",
+		"params": ["logs", {}, "token"]
+	}`)
+		resp, err := dispatcher.HandleWs(req, mockConnection)
+		require.NoError(t, err)
+		assert.Contains(t, string(resp), "Invalid params")
+	})
+
+	t.Run("invalid full transactions flag", func(t *testing.T) {
+		t.Parallel()
+
+		mockConnection, _ := newMockWsConnWithMsgCh()
+
+		req := []byte(`{
+		"method": "eth_subscribe",
+		"params": ["newPendingTransactions", "yes"]
+	}`)
+		resp, err := dispatcher.HandleWs(req, mockConnection)
+		require.NoError(t, err)
+		assert.Contains(t, string(resp), "Invalid params")
+	})
+}
+
+func TestDispatcher_WebsocketConnection_SubscriptionsLimit(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		store,
+		&dispatcherParams{
+			jsonRPCBatchLengthLimit: 20,
+			blockRangeLimit:         1000,
+			wsMaxSubscriptions:      2,
+		},
+	)
+
+	mockConnection, _ := newMockWsConnWithMsgCh()
+	subscribe := []byte(`{"method": "eth_subscribe", "params": ["newHeads"], "id": 1}`)
+
+	for i := 0; i < 2; i++ {
+		resp, err := dispatcher.HandleWs(subscribe, mockConnection)
+		require.NoError(t, err)
+		assert.NotContains(t, string(resp), "error")
+	}
+
+	resp, err := dispatcher.HandleWs(subscribe, mockConnection)
+	require.NoError(t, err)
+	assert.Contains(t, string(resp), "connection subscriptions limit of 2 reached")
+
+	// the limit is per connection
+	otherConnection, _ := newMockWsConnWithMsgCh()
+
+	resp, err = dispatcher.HandleWs(subscribe, otherConnection)
+	require.NoError(t, err)
+	assert.NotContains(t, string(resp), "error")
 }
 
 func TestDispatcher_WebsocketConnection_RequestFormats(t *testing.T) {
@@ -557,6 +680,137 @@ func TestDispatcher_WebsocketConnection_Unsubscribe(t *testing.T) {
 	assert.Equal(t, "true", string(resp.Result))
 }
 
+func TestDispatcher_SetLimits(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			jsonRPCBatchLengthLimit: 3,
+			blockRangeLimit:         1000,
+		},
+	)
+
+	batch := []byte(`[
+		{"id":1,"jsonrpc":"2.0","method":"web3_clientVersion","params":[]},
+		{"id":2,"jsonrpc":"2.0","method":"web3_clientVersion","params":[]},
+		{"id":3,"jsonrpc":"2.0","method":"web3_clientVersion","params":[]},
+		{"id":4,"jsonrpc":"2.0","method":"web3_clientVersion","params":[]}]`)
+
+	var resp ErrorResponse
+
+	res, err := dispatcher.Handle(batch)
+	require.NoError(t, err)
+	require.NoError(t, expectBatchJSONResult(res, &resp))
+	require.Equal(t, &ObjectError{Code: -32600, Message: "Batch request length too long"}, resp.Error)
+
+	dispatcher.SetLimits(Limits{PriceLimit: 10, BatchLengthLimit: 4, BlockRangeLimit: 10})
+
+	res, err = dispatcher.Handle(batch)
+	require.NoError(t, err)
+
+	var batchResp []SuccessResponse
+
+	require.NoError(t, expectBatchJSONResult(res, &batchResp))
+	require.Len(t, batchResp, 4)
+
+	assert.Equal(t, uint64(10), dispatcher.endpoints.Eth.priceLimit.Load())
+	assert.Equal(t, uint64(10), dispatcher.endpoints.Trace.blockRangeLimit.Load())
+	assert.Equal(t, uint64(10), dispatcher.filterManager.blockRangeLimit.Load())
+}
+
+func TestDispatcher_AdminPeersRequireToken(t *testing.T) {
+	t.Parallel()
+
+	store := &mockAdminStore{
+		mockStore: newMockStore(),
+		static:    make(map[string]bool),
+		trusted:   make(map[string]bool),
+	}
+
+	dispatcher := newTestDispatcher(t, hclog.NewNullLogger(), store, &dispatcherParams{})
+
+	const addPeer = `{"method": "admin_addPeer", "params": ["/ip4/10.0.0.2/tcp/1478/p2p/peer"]}`
+
+	// the node without the admin token refuses to manage the peers
+	resp := serveAdminRequest(t, dispatcher, "", "", addPeer)
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	assert.Empty(t, store.static)
+
+	// the unauthenticated call is rejected before it reaches the endpoint
+	resp = serveAdminRequest(t, dispatcher, "secret", "", addPeer)
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	assert.Empty(t, store.static)
+
+	resp = serveAdminRequest(t, dispatcher, "secret", "wrong", addPeer)
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	assert.Empty(t, store.static)
+
+	resp = serveAdminRequest(t, dispatcher, "secret", "secret", addPeer)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.True(t, store.static["/ip4/10.0.0.2/tcp/1478/p2p/peer"])
+}
+
+func TestDispatcher_AdminSetPeerFiltersRequireToken(t *testing.T) {
+	t.Parallel()
+
+	store := &mockAdminStore{
+		mockStore: newMockStore(),
+		filters:   &network.PeerFilters{},
+	}
+
+	dispatcher := newTestDispatcher(t, hclog.NewNullLogger(), store, &dispatcherParams{})
+
+	const setPeerFilters = `{"method": "admin_setPeerFilters", "params": [{"inbound_deny": ["0.0.0.0/0"]}]}`
+
+	// the node without the admin token refuses to change the peer filters
+	resp := serveAdminRequest(t, dispatcher, "", "", setPeerFilters)
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	assert.Empty(t, store.filters.InboundDeny)
+
+	// the unauthenticated call is rejected before it reaches the endpoint
+	resp = serveAdminRequest(t, dispatcher, "secret", "", setPeerFilters)
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	assert.Empty(t, store.filters.InboundDeny)
+
+	resp = serveAdminRequest(t, dispatcher, "secret", "secret", setPeerFilters)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, []string{"0.0.0.0/0"}, store.filters.InboundDeny)
+}
+
+// serveAdminRequest handles the http request by the dispatcher behind the admin authorization of the node
+// with the given admin token, the request carries the given token (none if empty)
+func serveAdminRequest(
+	t *testing.T,
+	dispatcher *Dispatcher,
+	adminToken, token, body string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	handler := adminAuthMiddleware(&adminAuth{token: adminToken})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			resp, err := dispatcher.Handle(data)
+			require.NoError(t, err)
+
+			_, _ = w.Write(resp)
+		}),
+	)
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", bearerPrefix+token)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	return recorder
+}
+
 func newTestDispatcher(tb testing.TB, logger hclog.Logger, store JSONRPCStore, params *dispatcherParams) *Dispatcher {
 	tb.Helper()
 
@@ -565,3 +819,120 @@ func newTestDispatcher(tb testing.TB, logger hclog.Logger, store JSONRPCStore, p
 
 	return d
 }
+
+type mockErrorService struct {
+	started chan struct{}
+	unblock chan struct{}
+}
+
+func (m *mockErrorService) Revert() (interface{}, error) {
+	return []byte("abcd"), fmt.Errorf("%w: reason", runtime.ErrExecutionReverted)
+}
+
+func (m *mockErrorService) Fail() (interface{}, error) {
+	return nil, errors.New("failure")
+}
+
+func (m *mockErrorService) Throttled() (interface{}, error) {
+	return nil, errRequestLimitExceeded
+}
+
+func (m *mockErrorService) InvalidParams() (interface{}, error) {
+	return nil, NewInvalidParamsError("invalid")
+}
+
+func (m *mockErrorService) Slow() (interface{}, error) {
+	m.started <- struct{}{}
+	<-m.unblock
+
+	return nil, nil
+}
+
+func TestDispatcher_ErrorCodes(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{},
+	)
+
+	require.NoError(t, dispatcher.registerService("mock", &mockErrorService{}))
+
+	testCases := []struct {
+		method       string
+		expectedCode int
+		expectedData []byte
+	}{
+		{"mock_revert", 3, []byte("abcd")},
+		{"mock_fail", -32000, nil},
+		{"mock_throttled", -32005, nil},
+		{"mock_invalidParams", -32602, nil},
+		{"mock_unknown", -32601, nil},
+	}
+
+	for _, tc := range testCases {
+		data, err := dispatcher.handleReq(Request{Method: tc.method})
+
+		require.Error(t, err, tc.method)
+		assert.Equal(t, tc.expectedCode, err.ErrorCode(), tc.method)
+		assert.Equal(t, tc.expectedData, data, tc.method)
+	}
+}
+
+func TestDispatcher_MethodConcurrencyLimits(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newDispatcher(hclog.NewNullLogger(), newMockStore(), &dispatcherParams{
+			methodConcurrencyLimits: map[string]uint64{"eth_unknown": 1},
+		})
+		require.Error(t, err)
+
+		_, err = newDispatcher(hclog.NewNullLogger(), newMockStore(), &dispatcherParams{
+			methodConcurrencyLimits: map[string]uint64{"eth_call": 0},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("requests above the limit are rejected", func(t *testing.T) {
+		t.Parallel()
+
+		dispatcher := newTestDispatcher(t,
+			hclog.NewNullLogger(),
+			newMockStore(),
+			&dispatcherParams{
+				methodConcurrencyLimits: map[string]uint64{"eth_call": 1},
+			},
+		)
+		require.Contains(t, dispatcher.methodThrottling, "eth_call")
+
+		srv := &mockErrorService{started: make(chan struct{}), unblock: make(chan struct{})}
+		require.NoError(t, dispatcher.registerService("mock", srv))
+
+		dispatcher.methodThrottling["mock_slow"] = NewThrottling(1, 10*time.Millisecond)
+
+		done := make(chan Error)
+
+		go func() {
+			_, err := dispatcher.handleReq(Request{Method: "mock_slow"})
+			done <- err
+		}()
+
+		// the first request occupies the only slot
+		<-srv.started
+
+		_, err := dispatcher.handleReq(Request{Method: "mock_slow"})
+		require.Error(t, err)
+		require.Equal(t, -32005, err.ErrorCode())
+
+		close(srv.unblock)
+		require.Nil(t, <-done)
+
+		// other methods are not limited
+		_, err = dispatcher.handleReq(Request{Method: "mock_fail"})
+		require.Equal(t, -32000, err.ErrorCode())
+	})
+}
@@ -0,0 +1,99 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+
+	"github.com/armon/go-metrics"
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// encodingKind is the kind of the block data whose encoding is cached
+type encodingKind uint8
+
+const (
+	encodingBlock encodingKind = iota
+	encodingBlockFullTxs
+	encodingBlockReceipts
+)
+
+// encodingCacheKey is the key of the encoded block data
+type encodingCacheKey struct {
+	kind encodingKind
+	hash types.Hash
+}
+
+// encodingCache keeps the JSON encoding of the recently requested blocks and block receipts,
+// so that the popular blocks aren't converted and marshaled again on each request.
+// The entries are keyed by the block hash, as the block data never changes
+type encodingCache struct {
+	entries *lru.Cache
+}
+
+func newEncodingCache(size int) (*encodingCache, error) {
+	entries, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &encodingCache{entries: entries}, nil
+}
+
+// encode returns the cached encoding of the block data, otherwise it encodes and caches the value returned by fn.
+// The value is returned as is if the cache is disabled, and the nil values and the errors are never cached
+func (c *encodingCache) encode(key encodingCacheKey, fn func() (interface{}, error)) (interface{}, error) {
+	if c == nil {
+		return fn()
+	}
+
+	if raw, ok := c.entries.Get(key); ok {
+		metrics.IncrCounter([]string{jsonRPCMetric, "encoding_cache_hits"}, 1)
+
+		return raw, nil
+	}
+
+	value, err := fn()
+	if err != nil || value == nil {
+		return value, err
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	c.entries.Add(key, json.RawMessage(raw))
+
+	return json.RawMessage(raw), nil
+}
+
+// maxPooledBufferSize is the capacity of the largest buffer returned to the pool,
+// so that a single huge response doesn't keep its memory allocated
+const maxPooledBufferSize = 4 * 1024 * 1024
+
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// writeBuffered writes the data by the given function to the pooled buffer, returning the copy of the written bytes
+func writeBuffered(write func(buf *bytes.Buffer) error) ([]byte, error) {
+	buf, _ := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+
+	defer func() {
+		if buf.Cap() <= maxPooledBufferSize {
+			bufferPool.Put(buf)
+		}
+	}()
+
+	if err := write(buf); err != nil {
+		return nil, err
+	}
+
+	return bytes.Clone(buf.Bytes()), nil
+}
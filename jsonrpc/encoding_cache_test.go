@@ -0,0 +1,171 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestEncodingCache_Encode(t *testing.T) {
+	t.Parallel()
+
+	cache, err := newEncodingCache(2)
+	require.NoError(t, err)
+
+	key := encodingCacheKey{kind: encodingBlock, hash: hash1}
+	value := map[string]string{"hash": hash1.String()}
+	calls := 0
+
+	encode := func() (interface{}, error) {
+		calls++
+
+		return value, nil
+	}
+
+	expected, err := json.Marshal(value)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		res, err := cache.encode(key, encode)
+		require.NoError(t, err)
+		assert.Equal(t, json.RawMessage(expected), res)
+	}
+
+	assert.Equal(t, 1, calls)
+
+	// the kinds of the block data are cached separately
+	_, err = cache.encode(encodingCacheKey{kind: encodingBlockReceipts, hash: hash1}, encode)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	// nil values and errors are not cached
+	missingKey := encodingCacheKey{kind: encodingBlock, hash: hash2}
+
+	for i := 0; i < 2; i++ {
+		res, err := cache.encode(missingKey, func() (interface{}, error) {
+			return nil, nil
+		})
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	}
+
+	encodeErr := errors.New("encode error")
+
+	_, err = cache.encode(missingKey, func() (interface{}, error) {
+		return nil, encodeErr
+	})
+	require.ErrorIs(t, err, encodeErr)
+	assert.False(t, cache.entries.Contains(missingKey))
+}
+
+func TestEncodingCache_Disabled(t *testing.T) {
+	t.Parallel()
+
+	var cache *encodingCache
+
+	value := map[string]string{"hash": hash1.String()}
+
+	res, err := cache.encode(encodingCacheKey{hash: hash1}, func() (interface{}, error) {
+		return value, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, value, res)
+}
+
+func TestEth_GetBlock_EncodingCache(t *testing.T) {
+	t.Parallel()
+
+	store := newMockBlockStore()
+
+	block := newTestBlock(1, hash1)
+	block.Transactions = []*types.Transaction{newTestTransaction(0, addr0)}
+	store.add(block)
+	receipt := &types.Receipt{}
+	receipt.SetStatus(types.ReceiptSuccess)
+	store.receipts[hash1] = []*types.Receipt{receipt}
+
+	eth := newTestEthEndpoint(store)
+
+	cache, err := newEncodingCache(10)
+	require.NoError(t, err)
+
+	eth.encodingCache = cache
+
+	for _, fullTx := range []bool{false, true} {
+		expected, err := json.Marshal(toBlock(block, fullTx))
+		require.NoError(t, err)
+
+		res, err := eth.GetBlockByNumber(BlockNumber(1), fullTx)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(expected), string(res.(json.RawMessage))) //nolint:forcetypeassert
+
+		res, err = eth.GetBlockByHash(hash1, fullTx)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(expected), string(res.(json.RawMessage))) //nolint:forcetypeassert
+	}
+
+	res, err := eth.GetBlockByNumber(BlockNumber(2), false)
+	require.NoError(t, err)
+	assert.Nil(t, res)
+
+	receipts, err := eth.GetBlockReceipts(BlockNumberOrHash{BlockHash: &hash1})
+	require.NoError(t, err)
+	assert.IsType(t, json.RawMessage{}, receipts)
+
+	assert.Equal(t, 3, cache.entries.Len())
+}
+
+func TestSuccessResponse_Bytes(t *testing.T) {
+	t.Parallel()
+
+	cases := []*SuccessResponse{
+		{JSONRPC: "2.0", ID: float64(1), Result: json.RawMessage(`{"a":"b"}`)},
+		{JSONRPC: "2.0", ID: "id", Result: json.RawMessage(`"0x1"`)},
+		{JSONRPC: "2.0", ID: nil, Result: json.RawMessage(`null`)},
+		{JSONRPC: "2.0", ID: float64(2), Error: &ObjectError{Code: -32600, Message: "invalid"}},
+	}
+
+	for _, c := range cases {
+		expected, err := json.Marshal(c)
+		require.NoError(t, err)
+
+		res, err := c.Bytes()
+		require.NoError(t, err)
+		assert.JSONEq(t, string(expected), string(res))
+	}
+}
+
+func TestWriteBuffered(t *testing.T) {
+	t.Parallel()
+
+	first, err := writeBuffered(func(buf *bytes.Buffer) error {
+		buf.WriteString("first")
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	second, err := writeBuffered(func(buf *bytes.Buffer) error {
+		buf.WriteString("second")
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	// the returned bytes are not shared with the pooled buffers
+	assert.Equal(t, "first", string(first))
+	assert.Equal(t, "second", string(second))
+
+	writeErr := errors.New("write error")
+
+	_, err = writeBuffered(func(buf *bytes.Buffer) error {
+		return writeErr
+	})
+	require.ErrorIs(t, err, writeErr)
+}
@@ -10,6 +10,9 @@ import (
 
 var (
 	ErrStateNotFound = errors.New("given root and slot not found in storage")
+
+	// ErrStateNotRetained is returned when the state of the requested block is older than the retained history
+	ErrStateNotRetained = errors.New("historical state is not available, the node is not running in archive mode")
 )
 
 type Error interface {
@@ -76,6 +79,59 @@ func (e *methodNotFoundError) ErrorCode() int {
 	return -32601
 }
 
+// executionRevertedError is returned when the call was reverted,
+// the revert data is passed along in the error response
+type executionRevertedError struct {
+	err string
+}
+
+func (e *executionRevertedError) Error() string {
+	return e.err
+}
+
+func (e *executionRevertedError) ErrorCode() int {
+	return 3
+}
+
+// serverError is a generic error which occurred while processing the valid request
+type serverError struct {
+	err string
+}
+
+func (e *serverError) Error() string {
+	return e.err
+}
+
+func (e *serverError) ErrorCode() int {
+	return -32000
+}
+
+// limitExceededError is returned when the request exceeds one of the configured limits
+type limitExceededError struct {
+	err string
+}
+
+func (e *limitExceededError) Error() string {
+	return e.err
+}
+
+func (e *limitExceededError) ErrorCode() int {
+	return -32005
+}
+
+// unauthorizedError is returned when the request calls a method the client is not authorized for
+type unauthorizedError struct {
+	err string
+}
+
+func (e *unauthorizedError) Error() string {
+	return e.err
+}
+
+func (e *unauthorizedError) ErrorCode() int {
+	return -32001
+}
+
 func NewMethodNotFoundError(method string) *methodNotFoundError {
 	return &methodNotFoundError{fmt.Sprintf("the method %s does not exist/is not available", method)}
 }
@@ -90,10 +146,43 @@ func NewInternalError(msg string) *internalError {
 	return &internalError{msg}
 }
 
+func NewExecutionRevertedError(msg string) *executionRevertedError {
+	return &executionRevertedError{msg}
+}
+
+func NewServerError(msg string) *serverError {
+	return &serverError{msg}
+}
+
+func NewLimitExceededError(msg string) *limitExceededError {
+	return &limitExceededError{msg}
+}
+
+func NewUnauthorizedError(msg string) *unauthorizedError {
+	return &unauthorizedError{msg}
+}
+
 func NewSubscriptionNotFoundError(method string) *subscriptionNotFoundError {
 	return &subscriptionNotFoundError{fmt.Sprintf("subscribe method %s not found", method)}
 }
 
+// toRPCError maps the error returned by an endpoint to the JSON-RPC error with the matching code
+func toRPCError(err error) Error {
+	var rpcErr Error
+	if errors.As(err, &rpcErr) {
+		return rpcErr
+	}
+
+	switch {
+	case errors.Is(err, runtime.ErrExecutionReverted):
+		return NewExecutionRevertedError(err.Error())
+	case errors.Is(err, errRequestLimitExceeded):
+		return NewLimitExceededError(err.Error())
+	default:
+		return NewServerError(err.Error())
+	}
+}
+
 func constructErrorFromRevert(result *runtime.ExecutionResult) error {
 	revertErrMsg, unpackErr := abi.UnpackRevertError(result.ReturnValue)
 	if unpackErr != nil {
@@ -13,6 +13,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEth_Block_GetBlockByNumber(t *testing.T) {
@@ -246,6 +247,76 @@ func TestEth_GetTransactionReceipt(t *testing.T) {
 	})
 }
 
+func TestEth_GetBlockReceipts(t *testing.T) {
+	t.Parallel()
+
+	store := newMockBlockStore()
+	eth := newTestEthEndpoint(store)
+
+	txn0 := newTestTransaction(uint64(0), addr0)
+	txn1 := newTestTransaction(uint64(1), addr1)
+
+	block := newTestBlock(1, hash4)
+	block.Transactions = []*types.Transaction{txn0, txn1}
+
+	emptyBlockHash := types.StringToHash("5")
+	store.add(block, newTestBlock(2, emptyBlockHash))
+
+	receipt0 := &types.Receipt{
+		Logs: []*types.Log{
+			{Topics: []types.Hash{hash1}},
+			{Topics: []types.Hash{hash2}},
+		},
+	}
+	receipt0.SetStatus(types.ReceiptSuccess)
+
+	receipt1 := &types.Receipt{
+		Logs: []*types.Log{
+			{Topics: []types.Hash{hash3}},
+		},
+	}
+	receipt1.SetStatus(types.ReceiptFailed)
+
+	store.receipts[hash4] = []*types.Receipt{receipt0, receipt1}
+
+	t.Run("returns all receipts of the block", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := eth.GetBlockReceipts(BlockNumberOrHash{BlockHash: &hash4})
+		require.NoError(t, err)
+
+		//nolint:forcetypeassert
+		receipts := res.([]*receipt)
+		require.Len(t, receipts, 2)
+
+		assert.Equal(t, txn0.Hash, receipts[0].TxHash)
+		assert.Equal(t, argUint64(types.ReceiptSuccess), receipts[0].Status)
+		assert.Len(t, receipts[0].Logs, 2)
+
+		assert.Equal(t, txn1.Hash, receipts[1].TxHash)
+		assert.Equal(t, argUint64(1), receipts[1].TxIndex)
+		assert.Equal(t, argUint64(types.ReceiptFailed), receipts[1].Status)
+		require.Len(t, receipts[1].Logs, 1)
+		assert.Equal(t, argUint64(2), receipts[1].Logs[0].LogIndex)
+		assert.Equal(t, txn1.Hash, receipts[1].Logs[0].TxHash)
+	})
+
+	t.Run("returns empty list for block without transactions", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := eth.GetBlockReceipts(BlockNumberOrHash{BlockHash: &emptyBlockHash})
+		require.NoError(t, err)
+		assert.Equal(t, []*receipt{}, res)
+	})
+
+	t.Run("returns error for unknown block", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := eth.GetBlockReceipts(BlockNumberOrHash{BlockHash: &hash1})
+		require.Error(t, err)
+	})
+}
+
 func TestEth_Syncing(t *testing.T) {
 	store := newMockBlockStore()
 	eth := newTestEthEndpoint(store)
@@ -264,6 +335,9 @@ func TestEth_Syncing(t *testing.T) {
 		assert.Equal(t, argUint64(1), response.StartingBlock)
 		assert.Equal(t, argUint64(10), response.CurrentBlock)
 		assert.Equal(t, argUint64(100), response.HighestBlock)
+		assert.Equal(t, string(progress.ChainSyncStageStateDownload), response.Stage)
+		assert.Equal(t, argUint64(200), response.PulledStates)
+		assert.Equal(t, argUint64(300), response.KnownStates)
 	})
 
 	t.Run("returns \"false\" if sync is not progress", func(t *testing.T) {
@@ -382,7 +456,7 @@ func TestEth_Call(t *testing.T) {
 			Nonce:    argUintPtr(0),
 		}
 
-		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil)
+		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil, nil)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), store.ethCallError.Error())
@@ -406,7 +480,7 @@ func TestEth_Call(t *testing.T) {
 			Nonce:    argUintPtr(0),
 		}
 
-		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil)
+		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil, nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
@@ -432,7 +506,7 @@ func TestEth_Call(t *testing.T) {
 			Nonce:    argUintPtr(0),
 		}
 
-		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil)
+		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil, nil)
 		assert.Error(t, err)
 		assert.NotNil(t, res)
 		bres := res.([]byte) //nolint:forcetypeassert
@@ -440,6 +514,99 @@ func TestEth_Call(t *testing.T) {
 	})
 }
 
+func TestEth_CallMany(t *testing.T) {
+	t.Parallel()
+
+	contractCall := func() *txnArgs {
+		return &txnArgs{
+			From:     &addr0,
+			To:       &addr1,
+			GasPrice: argBytesPtr([]byte{0x64}),
+			Nonce:    argUintPtr(0),
+		}
+	}
+
+	t.Run("returns the results of all the calls", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockBlockStore()
+		store.add(newTestBlock(100, hash1))
+		store.returnValue = []byte{0x1}
+		eth := newTestEthEndpoint(store)
+
+		res, err := eth.CallMany([]*callBundle{
+			{Transactions: []*txnArgs{contractCall(), contractCall()}},
+			{Transactions: []*txnArgs{contractCall()}},
+		}, BlockNumberOrHash{})
+		require.NoError(t, err)
+
+		results, ok := res.([][]*callManyResult)
+		require.True(t, ok)
+		require.Len(t, results, 2)
+		require.Len(t, results[0], 2)
+		require.Len(t, results[1], 1)
+		assert.Equal(t, argBytesPtr([]byte{0x1}), results[1][0].Value)
+		assert.Empty(t, results[1][0].Error)
+	})
+
+	t.Run("returns the errors of the failed calls", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockBlockStore()
+		store.add(newTestBlock(100, hash1))
+		store.ethCallError = runtime.ErrExecutionReverted
+		store.returnValue = []byte("Reverted()")
+		eth := newTestEthEndpoint(store)
+
+		res, err := eth.CallMany([]*callBundle{
+			{Transactions: []*txnArgs{contractCall()}},
+		}, BlockNumberOrHash{})
+		require.NoError(t, err)
+
+		results, ok := res.([][]*callManyResult)
+		require.True(t, ok)
+		assert.Equal(t, runtime.ErrExecutionReverted.Error(), results[0][0].Error)
+		assert.Equal(t, argBytesPtr(store.returnValue), results[0][0].Value)
+	})
+
+	t.Run("uses the overridden gas limit by default", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockBlockStore()
+		store.add(newTestBlock(100, hash1))
+
+		gasLimit := argUint64(21000)
+
+		bundles, err := decodeCallBundles([]*callBundle{
+			{Transactions: []*txnArgs{contractCall()}},
+			{Transactions: []*txnArgs{contractCall()}, BlockOverride: &blockOverride{GasLimit: &gasLimit}},
+			{Transactions: []*txnArgs{contractCall()}},
+		}, store.Header(), store)
+		require.NoError(t, err)
+
+		assert.Equal(t, store.Header().GasLimit, bundles[0].Transactions[0].Gas)
+		assert.Equal(t, uint64(gasLimit), bundles[1].Transactions[0].Gas)
+		assert.Equal(t, uint64(gasLimit), bundles[2].Transactions[0].Gas)
+		assert.Equal(t, uint64(gasLimit), *bundles[1].BlockOverride.GasLimit)
+	})
+
+	t.Run("returns error if there are too many transactions", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockBlockStore()
+		store.add(newTestBlock(100, hash1))
+		eth := newTestEthEndpoint(store)
+
+		txns := make([]*txnArgs, maxCallBundleTransactions+1)
+		for i := range txns {
+			txns[i] = contractCall()
+		}
+
+		_, err := eth.CallMany([]*callBundle{{Transactions: txns}}, BlockNumberOrHash{})
+		require.ErrorContains(t, err, "too many transactions")
+	})
+}
+
 type testStore interface {
 	ethStore
 }
@@ -585,6 +752,15 @@ func (m *mockBlockStore) GetBlockByHash(hash types.Hash, full bool) (*types.Bloc
 	return nil, false
 }
 
+func (m *mockBlockStore) GetHeaderByNumber(blockNumber uint64) (*types.Header, bool) {
+	block, ok := m.GetBlockByNumber(blockNumber, false)
+	if !ok {
+		return nil, false
+	}
+
+	return block.Header, true
+}
+
 func (m *mockBlockStore) Header() *types.Header {
 	return m.blocks[len(m.blocks)-1].Header
 }
@@ -618,6 +794,9 @@ func (m *mockBlockStore) GetSyncProgression() *progress.Progression {
 			StartingBlock: 1,
 			CurrentBlock:  10,
 			HighestBlock:  100,
+			Stage:         progress.ChainSyncStageStateDownload,
+			PulledStates:  200,
+			KnownStates:   300,
 		}
 	} else {
 		return nil
@@ -628,13 +807,37 @@ func (m *mockBlockStore) GetAvgGasPrice() *big.Int {
 	return big.NewInt(m.averageGasPrice)
 }
 
-func (m *mockBlockStore) ApplyTxn(_ *types.Header, _ *types.Transaction, _ types.StateOverride, _ bool) (*runtime.ExecutionResult, error) {
+func (m *mockBlockStore) ApplyTxn(
+	_ *types.Header,
+	_ *types.Transaction,
+	_ types.StateOverride,
+	_ *types.BlockOverride,
+	_ bool,
+) (*runtime.ExecutionResult, error) {
 	return &runtime.ExecutionResult{
 		Err:         m.ethCallError,
 		ReturnValue: m.returnValue,
 	}, nil
 }
 
+func (m *mockBlockStore) ApplyTxnBundles(
+	_ *types.Header,
+	bundles []*CallBundle,
+) ([][]*runtime.ExecutionResult, error) {
+	results := make([][]*runtime.ExecutionResult, len(bundles))
+
+	for i, bundle := range bundles {
+		for range bundle.Transactions {
+			results[i] = append(results[i], &runtime.ExecutionResult{
+				Err:         m.ethCallError,
+				ReturnValue: m.returnValue,
+			})
+		}
+	}
+
+	return results, nil
+}
+
 func (m *mockBlockStore) SubscribeEvents() blockchain.Subscription {
 	return nil
 }
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync/atomic"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 
@@ -14,6 +16,8 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/accesslisttracer"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -29,6 +33,15 @@ type ethTxPoolStore interface {
 
 	// GetBaseFee returns the current base fee of TxPool
 	GetBaseFee() uint64
+
+	// AddUserOperation adds a new ERC-4337 user operation to the tx pool user operations lane
+	AddUserOperation(op *types.UserOperation, entryPoint types.Address) (types.Hash, error)
+
+	// GetUserOperation gets the pending user operation and its entry point from the tx pool, if it's present
+	GetUserOperation(hash types.Hash) (*types.UserOperation, types.Address, bool)
+
+	// SupportedEntryPoints returns the entry points the tx pool accepts user operations for
+	SupportedEntryPoints() []types.Address
 }
 
 type Account struct {
@@ -36,11 +49,32 @@ type Account struct {
 	Nonce   uint64
 }
 
+// StorageProof is the Merkle proof of a storage slot of an account
+type StorageProof struct {
+	Key   types.Hash
+	Value []byte
+	Proof [][]byte
+}
+
+// AccountProof is the Merkle proof of an account and some of its storage slots.
+// Non-existing accounts are reported with the empty values and the proof of their absence
+type AccountProof struct {
+	Balance       *big.Int
+	Nonce         uint64
+	CodeHash      types.Hash
+	StorageHash   types.Hash
+	Proof         [][]byte
+	StorageProofs []*StorageProof
+}
+
 type ethStateStore interface {
 	GetAccount(root types.Hash, addr types.Address) (*Account, error)
 	GetStorage(root types.Hash, addr types.Address, slot types.Hash) ([]byte, error)
 	GetForksInTime(blockNumber uint64) chain.ForksInTime
 	GetCode(root types.Hash, addr types.Address) ([]byte, error)
+
+	// GetProof returns the Merkle proofs of the account and the given storage slots at the given state root
+	GetProof(root types.Hash, addr types.Address, slots []types.Hash) (*AccountProof, error)
 }
 
 type ethBlockchainStore interface {
@@ -70,9 +104,16 @@ type ethBlockchainStore interface {
 		header *types.Header,
 		txn *types.Transaction,
 		override types.StateOverride,
+		blockOverride *types.BlockOverride,
 		nonPayable bool,
 	) (*runtime.ExecutionResult, error)
 
+	// ApplyTxnBundles applies the bundles of transactions one after another on top of the given header
+	ApplyTxnBundles(header *types.Header, bundles []*CallBundle) ([][]*runtime.ExecutionResult, error)
+
+	// ApplyTracedTxn applies a transaction object to the blockchain, tracing its execution
+	ApplyTracedTxn(header *types.Header, txn *types.Transaction, tracer tracer.Tracer) (*runtime.ExecutionResult, error)
+
 	// GetSyncProgression retrieves the current sync progression, if any
 	GetSyncProgression() *progress.Progression
 }
@@ -97,9 +138,23 @@ type Eth struct {
 	store         ethStore
 	chainID       uint64
 	filterManager *FilterManager
-	priceLimit    uint64
+	// priceLimit is the minimum gas price suggested to the users, updated on the config reload
+	priceLimit  atomic.Uint64
+	callTimeout time.Duration
+	// stateHistory is the number of the most recent blocks whose state is retained, 0 for all blocks
+	stateHistory uint64
+	// callCache caches the results of eth_call and eth_estimateGas, nil if they are not cached
+	callCache *callCache
+	// encodingCache caches the encoded blocks and block receipts, nil if they are not cached
+	encodingCache *encodingCache
 }
 
+// maxProofStorageKeys is the maximum number of storage slots proven in a single eth_getProof request
+const maxProofStorageKeys = 1024
+
+// maxCallBundleTransactions is the maximum number of transactions in all the bundles of a single request
+const maxCallBundleTransactions = 1000
+
 var (
 	ErrInsufficientFunds = errors.New("insufficient funds for execution")
 )
@@ -116,9 +171,12 @@ func (e *Eth) Syncing() (interface{}, error) {
 		// Node is bulk syncing, return the status
 		return progression{
 			Type:          string(syncProgression.SyncType),
+			Stage:         string(syncProgression.Stage),
 			StartingBlock: argUint64(syncProgression.StartingBlock),
 			CurrentBlock:  argUint64(syncProgression.CurrentBlock),
 			HighestBlock:  argUint64(syncProgression.HighestBlock),
+			PulledStates:  argUint64(syncProgression.PulledStates),
+			KnownStates:   argUint64(syncProgression.KnownStates),
 		}, nil
 	}
 
@@ -133,6 +191,16 @@ func (e *Eth) GetBlockByNumber(number BlockNumber, fullTx bool) (interface{}, er
 		return nil, err
 	}
 
+	// the encoded blocks are cached by their hashes
+	if e.encodingCache != nil {
+		header, ok := e.store.GetHeaderByNumber(num)
+		if !ok {
+			return nil, nil
+		}
+
+		return e.GetBlockByHash(header.Hash, fullTx)
+	}
+
 	block, ok := e.store.GetBlockByNumber(num, true)
 	if !ok {
 		return nil, nil
@@ -147,16 +215,23 @@ func (e *Eth) GetBlockByNumber(number BlockNumber, fullTx bool) (interface{}, er
 
 // GetBlockByHash returns information about a block by hash
 func (e *Eth) GetBlockByHash(hash types.Hash, fullTx bool) (interface{}, error) {
-	block, ok := e.store.GetBlockByHash(hash, true)
-	if !ok {
-		return nil, nil
+	kind := encodingBlock
+	if fullTx {
+		kind = encodingBlockFullTxs
 	}
 
-	if err := e.filterExtra(block); err != nil {
-		return nil, err
-	}
+	return e.encodingCache.encode(encodingCacheKey{kind: kind, hash: hash}, func() (interface{}, error) {
+		block, ok := e.store.GetBlockByHash(hash, true)
+		if !ok {
+			return nil, nil
+		}
 
-	return toBlock(block, fullTx), nil
+		if err := e.filterExtra(block); err != nil {
+			return nil, err
+		}
+
+		return toBlock(block, fullTx), nil
+	})
 }
 
 func (e *Eth) filterExtra(block *types.Block) error {
@@ -176,6 +251,35 @@ func (e *Eth) filterExtra(block *types.Block) error {
 	return nil
 }
 
+// getStateHeader returns the header of the given block, if the node retains its state
+func (e *Eth) getStateHeader(filter BlockNumberOrHash) (*types.Header, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.checkStateRetained(header.Number); err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+// checkStateRetained checks the state of the given block is within the retained state history
+func (e *Eth) checkStateRetained(number uint64) error {
+	if e.stateHistory == 0 {
+		// archive mode
+		return nil
+	}
+
+	latest := e.store.Header()
+	if latest == nil || number+e.stateHistory > latest.Number {
+		return nil
+	}
+
+	return fmt.Errorf("%w: block %d is older than the last %d blocks", ErrStateNotRetained, number, e.stateHistory)
+}
+
 func (e *Eth) GetBlockTransactionCountByNumber(number BlockNumber) (interface{}, error) {
 	num, err := GetNumericBlockNumber(number, e.store)
 	if err != nil {
@@ -216,6 +320,39 @@ func (e *Eth) SendRawTransaction(buf argBytes) (interface{}, error) {
 	return tx.Hash.String(), nil
 }
 
+// SendUserOperation submits an ERC-4337 user operation to the tx pool user operations lane,
+// to be routed to the given entry point by a colocated bundler
+func (e *Eth) SendUserOperation(op *userOperation, entryPoint types.Address) (interface{}, error) {
+	if op == nil {
+		return nil, errors.New("missing user operation")
+	}
+
+	hash, err := e.store.AddUserOperation(op.ToType(), entryPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return hash.String(), nil
+}
+
+// SupportedEntryPoints returns the ERC-4337 entry points supported by the node
+func (e *Eth) SupportedEntryPoints() (interface{}, error) {
+	return e.store.SupportedEntryPoints(), nil
+}
+
+// GetUserOperationByHash returns a pending user operation by its hash
+func (e *Eth) GetUserOperationByHash(hash types.Hash) (interface{}, error) {
+	op, entryPoint, ok := e.store.GetUserOperation(hash)
+	if !ok {
+		return nil, nil
+	}
+
+	return &userOperationByHashResult{
+		UserOperation: toUserOperation(op),
+		EntryPoint:    entryPoint,
+	}, nil
+}
+
 // SendTransaction rejects eth_sendTransaction json-rpc call as we don't support wallet management
 func (e *Eth) SendTransaction(_ *txnArgs) (interface{}, error) {
 	return nil, fmt.Errorf("request calls to eth_sendTransaction method are not supported," +
@@ -344,13 +481,72 @@ func (e *Eth) GetTransactionReceipt(hash types.Hash) (interface{}, error) {
 	return toReceipt(raw, txn, uint64(txIndex), block.Header, logs), nil
 }
 
+// GetBlockReceipts returns the receipts of all transactions in the block
+func (e *Eth) GetBlockReceipts(filter BlockNumberOrHash) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	key := encodingCacheKey{kind: encodingBlockReceipts, hash: header.Hash}
+
+	return e.encodingCache.encode(key, func() (interface{}, error) {
+		return e.getBlockReceipts(header)
+	})
+}
+
+// getBlockReceipts returns the receipts of the block, or nil if they are not found
+func (e *Eth) getBlockReceipts(header *types.Header) (interface{}, error) {
+	block, ok := e.store.GetBlockByHash(header.Hash, true)
+	if !ok {
+		// block not found
+		return nil, nil
+	}
+
+	if len(block.Transactions) == 0 {
+		return []*receipt{}, nil
+	}
+
+	receipts, err := e.store.GetReceiptsByHash(header.Hash)
+	if err != nil || len(receipts) == 0 {
+		// block receipts not found or not written yet on the db
+		e.logger.Warn(
+			fmt.Sprintf("Receipts for block with hash [%s] not found", header.Hash.String()),
+		)
+
+		return nil, nil
+	}
+
+	if len(receipts) != len(block.Transactions) {
+		return nil, fmt.Errorf(
+			"block %d has %d transactions but %d receipts",
+			header.Number, len(block.Transactions), len(receipts),
+		)
+	}
+
+	var (
+		logIndex uint64
+		result   = make([]*receipt, len(receipts))
+	)
+
+	for i, raw := range receipts {
+		txn := block.Transactions[i]
+		logs := toLogs(raw.Logs, logIndex, uint64(i), block.Header, txn.Hash)
+		logIndex += uint64(len(raw.Logs))
+
+		result[i] = toReceipt(raw, txn, uint64(i), block.Header, logs)
+	}
+
+	return result, nil
+}
+
 // GetStorageAt returns the contract storage at the index position
 func (e *Eth) GetStorageAt(
 	address types.Address,
 	index types.Hash,
 	filter BlockNumberOrHash,
 ) (interface{}, error) {
-	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	header, err := e.getStateHeader(filter)
 	if err != nil {
 		return nil, err
 	}
@@ -388,13 +584,13 @@ func (e *Eth) getGasPrice() (uint64, error) {
 			return 0, err
 		}
 
-		return common.Max(e.priceLimit, priorityFee.Uint64()+e.store.GetBaseFee()), nil
+		return common.Max(e.priceLimit.Load(), priorityFee.Uint64()+e.store.GetBaseFee()), nil
 	}
 
 	// Fetch average gas price in uint64
 	avgGasPrice := e.store.GetAvgGasPrice().Uint64()
 
-	return common.Max(e.priceLimit, avgGasPrice), nil
+	return common.Max(e.priceLimit.Load(), avgGasPrice), nil
 }
 
 // fillTransactionGasPrice fills transaction gas price if no provided
@@ -420,7 +616,7 @@ func (e *Eth) fillTransactionGasPrice(tx *types.Transaction) error {
 type overrideAccount struct {
 	Nonce     *argUint64                 `json:"nonce"`
 	Code      *argBytes                  `json:"code"`
-	Balance   *argUint64                 `json:"balance"`
+	Balance   *argBig                    `json:"balance"`
 	State     *map[types.Hash]types.Hash `json:"state"`
 	StateDiff *map[types.Hash]types.Hash `json:"stateDiff"`
 }
@@ -437,7 +633,7 @@ func (o *overrideAccount) ToType() types.OverrideAccount {
 	}
 
 	if o.Balance != nil {
-		res.Balance = new(big.Int).SetUint64(*(*uint64)(o.Balance))
+		res.Balance = new(big.Int).Set((*big.Int)(o.Balance))
 	}
 
 	if o.State != nil {
@@ -454,9 +650,121 @@ func (o *overrideAccount) ToType() types.OverrideAccount {
 // StateOverride is the collection of overridden accounts.
 type stateOverride map[types.Address]overrideAccount
 
+func (s stateOverride) ToType() types.StateOverride {
+	res := types.StateOverride{}
+
+	for addr, o := range s {
+		res[addr] = o.ToType()
+	}
+
+	return res
+}
+
+// blockOverride is the set of block fields overridden for the call
+type blockOverride struct {
+	Number    *argUint64     `json:"number"`
+	Timestamp *argUint64     `json:"time"`
+	GasLimit  *argUint64     `json:"gasLimit"`
+	Coinbase  *types.Address `json:"coinbase"`
+	BaseFee   *argBig        `json:"baseFee"`
+}
+
+func (o *blockOverride) ToType() *types.BlockOverride {
+	res := &types.BlockOverride{
+		Number:    (*uint64)(o.Number),
+		Timestamp: (*uint64)(o.Timestamp),
+		GasLimit:  (*uint64)(o.GasLimit),
+		Coinbase:  o.Coinbase,
+	}
+
+	if o.BaseFee != nil {
+		res.BaseFee = new(big.Int).Set((*big.Int)(o.BaseFee))
+	}
+
+	return res
+}
+
+// CallBundle is a sequence of transactions simulated one after another with the given overrides
+// applied before the first of them
+type CallBundle struct {
+	Transactions  []*types.Transaction
+	StateOverride types.StateOverride
+	BlockOverride *types.BlockOverride
+}
+
+// callBundle is the bundle of calls passed to eth_callMany and debug_traceCallMany
+type callBundle struct {
+	Transactions  []*txnArgs     `json:"transactions"`
+	StateOverride *stateOverride `json:"stateOverride"`
+	BlockOverride *blockOverride `json:"blockOverride"`
+}
+
+// callManyResult is the result of a single call of eth_callMany
+type callManyResult struct {
+	Value *argBytes `json:"value,omitempty"`
+	Error string    `json:"error,omitempty"`
+}
+
+// decodeCallBundles decodes the call bundles to be executed on top of the given header
+func decodeCallBundles(bundles []*callBundle, header *types.Header, store nonceGetter) ([]*CallBundle, error) {
+	var (
+		res      = make([]*CallBundle, len(bundles))
+		gasLimit = header.GasLimit
+		total    = 0
+	)
+
+	for i, bundle := range bundles {
+		if bundle == nil {
+			return nil, fmt.Errorf("bundle %d is empty", i)
+		}
+
+		if total += len(bundle.Transactions); total > maxCallBundleTransactions {
+			return nil, fmt.Errorf("too many transactions in bundles, the limit is %d", maxCallBundleTransactions)
+		}
+
+		res[i] = &CallBundle{
+			Transactions: make([]*types.Transaction, len(bundle.Transactions)),
+		}
+
+		if bundle.StateOverride != nil {
+			res[i].StateOverride = bundle.StateOverride.ToType()
+		}
+
+		if bundle.BlockOverride != nil {
+			res[i].BlockOverride = bundle.BlockOverride.ToType()
+
+			// the overridden gas limit stays in effect for the following bundles
+			if bundle.BlockOverride.GasLimit != nil {
+				gasLimit = uint64(*bundle.BlockOverride.GasLimit)
+			}
+		}
+
+		for k, arg := range bundle.Transactions {
+			tx, err := DecodeTxn(arg, header.Number, store, true)
+			if err != nil {
+				return nil, fmt.Errorf("bundle %d, transaction %d: %w", i, k, err)
+			}
+
+			// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
+			if tx.Gas == 0 {
+				tx.Gas = gasLimit
+			}
+
+			res[i].Transactions[k] = tx
+		}
+	}
+
+	return res, nil
+}
+
 // Call executes a smart contract call using the transaction object data
-func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash, apiOverride *stateOverride) (interface{}, error) {
-	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+func (e *Eth) Call(
+	arg *txnArgs,
+	filter BlockNumberOrHash,
+	apiOverride *stateOverride,
+	apiBlockOverride *blockOverride,
+) (interface{}, error) {
+	header, err := e.getStateHeader(filter)
 	if err != nil {
 		return nil, err
 	}
@@ -466,9 +774,11 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash, apiOverride *stateOve
 		return nil, err
 	}
 
+	override, blockOverride := toOverrides(apiOverride, apiBlockOverride)
+
 	// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
 	if transaction.Gas == 0 {
-		transaction.Gas = header.GasLimit
+		transaction.Gas = overriddenGasLimit(header, blockOverride)
 	}
 
 	// Force transaction gas price if empty
@@ -476,16 +786,19 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash, apiOverride *stateOve
 		return nil, err
 	}
 
-	var override types.StateOverride
-	if apiOverride != nil {
-		override = types.StateOverride{}
-		for addr, o := range *apiOverride {
-			override[addr] = o.ToType()
-		}
+	cached, ok, cacheResult := e.getCachedCall(&callCacheKey{
+		Method:        "eth_call",
+		Block:         header.Hash,
+		Tx:            transaction,
+		Override:      apiOverride,
+		BlockOverride: apiBlockOverride,
+	})
+	if ok {
+		return cached, nil
 	}
 
 	// The return value of the execution is saved in the transition (returnValue field)
-	result, err := e.store.ApplyTxn(header, transaction, override, true)
+	result, err := e.store.ApplyTxn(header, transaction, override, blockOverride, true)
 	if err != nil {
 		return nil, err
 	}
@@ -499,11 +812,152 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash, apiOverride *stateOve
 		return nil, fmt.Errorf("unable to execute call: %w", result.Err)
 	}
 
-	return argBytesPtr(result.ReturnValue), nil
+	returnValue := argBytesPtr(result.ReturnValue)
+	cacheResult(returnValue)
+
+	return returnValue, nil
+}
+
+// toOverrides converts the optional state and block overrides of the call
+func toOverrides(
+	apiOverride *stateOverride,
+	apiBlockOverride *blockOverride,
+) (types.StateOverride, *types.BlockOverride) {
+	var (
+		override      types.StateOverride
+		blockOverride *types.BlockOverride
+	)
+
+	if apiOverride != nil {
+		override = apiOverride.ToType()
+	}
+
+	if apiBlockOverride != nil {
+		blockOverride = apiBlockOverride.ToType()
+	}
+
+	return override, blockOverride
+}
+
+// overriddenGasLimit returns the block gas limit the call is executed with
+func overriddenGasLimit(header *types.Header, blockOverride *types.BlockOverride) uint64 {
+	if blockOverride != nil && blockOverride.GasLimit != nil {
+		return *blockOverride.GasLimit
+	}
+
+	return header.GasLimit
+}
+
+// CallMany executes the bundles of calls one after another on top of the given block.
+// Each call sees the state changes made by the previous ones
+func (e *Eth) CallMany(bundles []*callBundle, filter BlockNumberOrHash) (interface{}, error) {
+	header, err := e.getStateHeader(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	callBundles, err := decodeCallBundles(bundles, header, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, bundle := range callBundles {
+		for _, tx := range bundle.Transactions {
+			// Force transaction gas price if empty
+			if err = e.fillTransactionGasPrice(tx); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	results, err := e.store.ApplyTxnBundles(header, callBundles)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([][]*callManyResult, len(results))
+
+	for i, bundleResults := range results {
+		res[i] = make([]*callManyResult, len(bundleResults))
+
+		for k, result := range bundleResults {
+			switch {
+			case result.Reverted():
+				res[i][k] = &callManyResult{
+					Value: argBytesPtr(result.ReturnValue),
+					Error: constructErrorFromRevert(result).Error(),
+				}
+			case result.Failed():
+				res[i][k] = &callManyResult{Error: result.Err.Error()}
+			default:
+				res[i][k] = &callManyResult{Value: argBytesPtr(result.ReturnValue)}
+			}
+		}
+	}
+
+	return res, nil
+}
+
+type accessListResult struct {
+	AccessList accesslisttracer.AccessList `json:"accessList"`
+	GasUsed    argUint64                   `json:"gasUsed"`
+	Error      string                      `json:"error,omitempty"`
+}
+
+// CreateAccessList executes the call, returning the EIP-2930 access list of the accounts and storage slots
+// it accesses, along with the gas used by the call with the access list applied
+func (e *Eth) CreateAccessList(arg *txnArgs, filter BlockNumberOrHash) (interface{}, error) {
+	header, err := e.getStateHeader(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := DecodeTxn(arg, header.Number, e.store, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
+	if transaction.Gas == 0 {
+		transaction.Gas = header.GasLimit
+	}
+
+	// Force transaction gas price if empty
+	if err = e.fillTransactionGasPrice(transaction); err != nil {
+		return nil, err
+	}
+
+	// the access lists don't change the gas costs of the chain,
+	// so the gas used by the traced call is the gas used with the access list applied
+	tracer := &accesslisttracer.AccessListTracer{}
+
+	result, err := e.store.ApplyTracedTxn(header, transaction, tracer)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &accessListResult{
+		AccessList: tracer.AccessList(),
+		GasUsed:    argUint64(result.GasUsed),
+	}
+
+	switch {
+	case result.Reverted():
+		res.Error = constructErrorFromRevert(result).Error()
+	case result.Failed():
+		res.Error = result.Err.Error()
+	}
+
+	return res, nil
 }
 
 // EstimateGas estimates the gas needed to execute a transaction
-func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error) {
+func (e *Eth) EstimateGas(
+	arg *txnArgs,
+	rawNum *BlockNumber,
+	apiOverride *stateOverride,
+	apiBlockOverride *blockOverride,
+) (interface{}, error) {
 	number := LatestBlockNumber
 	if rawNum != nil {
 		number = *rawNum
@@ -515,6 +969,10 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 		return nil, err
 	}
 
+	if err := e.checkStateRetained(header.Number); err != nil {
+		return nil, err
+	}
+
 	// testTransaction should execute tx with nonce always set to the current expected nonce for the account
 	transaction, err := DecodeTxn(arg, header.Number, e.store, true)
 	if err != nil {
@@ -523,7 +981,10 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 
 	forksInTime := e.store.GetForksInTime(header.Number)
 
-	if transaction.IsValueTransfer() {
+	override, blockOverride := toOverrides(apiOverride, apiBlockOverride)
+
+	// the overridden state may turn the recipient into a contract, so the transfer needs to be applied
+	if transaction.IsValueTransfer() && override == nil {
 		// if it is a simple value transfer or a contract creation,
 		// we already know what is the transaction gas cost, no need to apply transaction
 		gasCost, err := state.TransactionGasCost(transaction, forksInTime.Homestead, forksInTime.Istanbul)
@@ -539,6 +1000,17 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 		return nil, err
 	}
 
+	cached, ok, cacheResult := e.getCachedCall(&callCacheKey{
+		Method:        "eth_estimateGas",
+		Block:         header.Hash,
+		Tx:            transaction,
+		Override:      apiOverride,
+		BlockOverride: apiBlockOverride,
+	})
+	if ok {
+		return cached, nil
+	}
+
 	var standardGas uint64
 	if transaction.IsContractCreation() && forksInTime.Homestead {
 		standardGas = state.TxGasContractCreation
@@ -556,7 +1028,7 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 		highEnd = transaction.Gas
 	} else {
 		// If not, use the referenced block number
-		highEnd = header.GasLimit
+		highEnd = overriddenGasLimit(header, blockOverride)
 	}
 
 	gasPriceInt := new(big.Int).Set(transaction.GasPrice)
@@ -581,6 +1053,11 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 			accountBalance = acc.Balance
 		}
 
+		// the overridden balance takes precedence over the one in state
+		if o, ok := override[transaction.From]; ok && o.Balance != nil {
+			accountBalance = o.Balance
+		}
+
 		availableBalance = new(big.Int).Set(accountBalance)
 	}
 
@@ -628,14 +1105,24 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 		return errors.Is(err, runtime.ErrExecutionReverted)
 	}
 
+	// The whole search is bounded by the call timeout, not only the single executions
+	var deadline time.Time
+	if e.callTimeout > 0 {
+		deadline = time.Now().Add(e.callTimeout)
+	}
+
 	// Run the transaction with the specified gas value.
 	// Returns a status indicating if the transaction failed, return value (data), and the accompanying error
 	testTransaction := func(gas uint64, shouldOmitErr bool) (bool, interface{}, error) {
 		var data interface{}
 
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return true, nil, fmt.Errorf("%w (timeout = %s)", ErrExecutionTimeout, e.callTimeout)
+		}
+
 		transaction.Gas = gas
 
-		result, applyErr := e.store.ApplyTxn(header, transaction, nil, true)
+		result, applyErr := e.store.ApplyTxn(header, transaction, override, blockOverride, true)
 
 		if result != nil {
 			data = []byte(hex.EncodeToString(result.ReturnValue))
@@ -706,6 +1193,8 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 		)
 	}
 
+	cacheResult(argUint64(highEnd))
+
 	return argUint64(highEnd), nil
 }
 
@@ -726,7 +1215,7 @@ func (e *Eth) GetLogs(query *LogQuery) (interface{}, error) {
 
 // GetBalance returns the account's balance at the referenced block.
 func (e *Eth) GetBalance(address types.Address, filter BlockNumberOrHash) (interface{}, error) {
-	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	header, err := e.getStateHeader(filter)
 	if err != nil {
 		return nil, err
 	}
@@ -767,6 +1256,17 @@ func (e *Eth) GetTransactionCount(address types.Address, filter BlockNumberOrHas
 		blockNumber = *filter.BlockNumber
 	}
 
+	if blockNumber != PendingBlockNumber {
+		num, err := GetNumericBlockNumber(blockNumber, e.store)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := e.checkStateRetained(num); err != nil {
+			return nil, err
+		}
+	}
+
 	nonce, err := GetNextNonce(address, blockNumber, e.store)
 	if err != nil {
 		if errors.Is(err, ErrStateNotFound) {
@@ -781,7 +1281,7 @@ func (e *Eth) GetTransactionCount(address types.Address, filter BlockNumberOrHas
 
 // GetCode returns account code at given block number
 func (e *Eth) GetCode(address types.Address, filter BlockNumberOrHash) (interface{}, error) {
-	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	header, err := e.getStateHeader(filter)
 	if err != nil {
 		return nil, err
 	}
@@ -800,6 +1300,30 @@ func (e *Eth) GetCode(address types.Address, filter BlockNumberOrHash) (interfac
 	return argBytesPtr(code), nil
 }
 
+// GetProof returns the Merkle proof of the account and the given storage slots at the referenced block
+func (e *Eth) GetProof(
+	address types.Address,
+	storageKeys []types.Hash,
+	filter BlockNumberOrHash,
+) (interface{}, error) {
+	if len(storageKeys) > maxProofStorageKeys {
+		return nil, fmt.Errorf("too many storage keys requested (%d), the limit is %d",
+			len(storageKeys), maxProofStorageKeys)
+	}
+
+	header, err := e.getStateHeader(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := e.store.GetProof(header.StateRoot, address, storageKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAccountProofResult(address, proof), nil
+}
+
 // NewFilter creates a filter object, based on filter options, to notify when the state changes (logs).
 func (e *Eth) NewFilter(filter *LogQuery) (interface{}, error) {
 	return e.filterManager.NewLogFilter(filter, nil), nil
@@ -880,5 +1404,10 @@ func (e *Eth) FeeHistory(blockCount argUint64, newestBlock BlockNumber,
 		Reward:        rewardResult,
 	}
 
+	// reward is omitted if no percentiles were requested
+	if len(rewardPercentiles) == 0 {
+		result.Reward = nil
+	}
+
 	return result, nil
 }
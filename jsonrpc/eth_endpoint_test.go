@@ -298,15 +298,19 @@ func TestEth_TxnType(t *testing.T) {
 }
 
 func newTestEthEndpoint(store testStore) *Eth {
-	return &Eth{
-		hclog.NewNullLogger(), store, 100, nil, 0,
-	}
+	return newTestEthEndpointWithPriceLimit(store, 0)
 }
 
 func newTestEthEndpointWithPriceLimit(store testStore, priceLimit uint64) *Eth {
-	return &Eth{
-		hclog.NewNullLogger(), store, 100, nil, priceLimit,
+	eth := &Eth{
+		logger:  hclog.NewNullLogger(),
+		store:   store,
+		chainID: 100,
 	}
+
+	eth.priceLimit.Store(priceLimit)
+
+	return eth
 }
 
 func TestEth_HeaderResolveBlock(t *testing.T) {
@@ -378,7 +382,7 @@ func TestOverrideAccount_ToType(t *testing.T) {
 	overrideAcc := &overrideAccount{
 		Nonce:     toArgUint64Ptr(nonce),
 		Code:      toArgBytesPtr(code),
-		Balance:   toArgUint64Ptr(balance),
+		Balance:   argBigPtr(new(big.Int).SetUint64(balance)),
 		State:     &state,
 		StateDiff: &stateDiff,
 	}
@@ -4,13 +4,18 @@ import (
 	"errors"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/accesslisttracer"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -269,6 +274,57 @@ func TestEth_State_GetTransactionCount(t *testing.T) {
 	}
 }
 
+func TestEth_State_StateHistory(t *testing.T) {
+	t.Parallel()
+
+	store := &mockSpecialStore{
+		account: &mockAccount{
+			address: addr0,
+			account: &Account{
+				Balance: big.NewInt(100),
+				Nonce:   5,
+			},
+			storage: make(map[types.Hash][]byte),
+		},
+		block: &types.Block{
+			Header: &types.Header{
+				Number:    100,
+				StateRoot: types.EmptyRootHash,
+			},
+		},
+	}
+
+	eth := newTestEthEndpoint(store)
+	eth.stateHistory = 10
+
+	latest := LatestBlockNumber
+
+	// the latest state is retained
+	balance, err := eth.GetBalance(addr0, BlockNumberOrHash{BlockNumber: &latest})
+	assert.NoError(t, err)
+	assert.Equal(t, argBigPtr(big.NewInt(100)), balance)
+
+	// the pending nonce doesn't need the historical state
+	pending := PendingBlockNumber
+
+	_, err = eth.GetTransactionCount(addr0, BlockNumberOrHash{BlockNumber: &pending})
+	assert.NoError(t, err)
+
+	// the state of the old blocks is not retained
+	old := BlockNumber(90)
+
+	_, err = eth.GetTransactionCount(addr0, BlockNumberOrHash{BlockNumber: &old})
+	assert.ErrorIs(t, err, ErrStateNotRetained)
+
+	assert.NoError(t, eth.checkStateRetained(91))
+	assert.ErrorIs(t, eth.checkStateRetained(90), ErrStateNotRetained)
+
+	// archive mode retains the state of all blocks
+	eth.stateHistory = 0
+
+	assert.NoError(t, eth.checkStateRetained(0))
+}
+
 func TestEth_State_GetCode(t *testing.T) {
 	store := &mockSpecialStore{
 		account: &mockAccount{
@@ -681,7 +737,7 @@ func TestEth_EstimateGas_GasLimit(t *testing.T) {
 			}
 
 			// Run the estimation
-			estimate, estimateErr := ethEndpoint.EstimateGas(testCase.transaction, nil)
+			estimate, estimateErr := ethEndpoint.EstimateGas(testCase.transaction, nil, nil, nil)
 
 			if testCase.expectedError != nil {
 				if estimateErr == nil {
@@ -757,6 +813,8 @@ func TestEth_EstimateGas_Reverts(t *testing.T) {
 		estimate, estimateErr := ethEndpoint.EstimateGas(
 			constructMockTx(nil, nil),
 			nil,
+			nil,
+			nil,
 		)
 
 		responseData, ok := estimate.([]byte)
@@ -772,6 +830,252 @@ func TestEth_EstimateGas_Reverts(t *testing.T) {
 	}
 }
 
+func TestEth_EstimateGas_Timeout(t *testing.T) {
+	store := getExampleStore()
+	ethEndpoint := newTestEthEndpoint(store)
+	ethEndpoint.callTimeout = 50 * time.Millisecond
+
+	// every attempt fails due to low gas, so the search goes on until the timeout
+	store.applyTxnHook = func(
+		header *types.Header,
+		txn *types.Transaction,
+	) (*runtime.ExecutionResult, error) {
+		time.Sleep(20 * time.Millisecond)
+
+		return &runtime.ExecutionResult{Err: runtime.ErrOutOfGas}, nil
+	}
+
+	_, err := ethEndpoint.EstimateGas(constructMockTx(nil, nil), nil, nil, nil)
+	assert.ErrorIs(t, err, ErrExecutionTimeout)
+}
+
+func TestEth_EstimateGas_Overrides(t *testing.T) {
+	store := getExampleStore()
+	ethEndpoint := newTestEthEndpoint(store)
+
+	var requiredGas, highestGas uint64
+
+	// the transaction needs all the gas available
+	store.applyTxnHook = func(
+		header *types.Header,
+		txn *types.Transaction,
+	) (*runtime.ExecutionResult, error) {
+		if txn.Gas > highestGas {
+			highestGas = txn.Gas
+		}
+
+		if txn.Gas < requiredGas {
+			return &runtime.ExecutionResult{Err: runtime.ErrOutOfGas}, nil
+		}
+
+		return &runtime.ExecutionResult{}, nil
+	}
+
+	t.Run("block gas limit override bounds the search", func(t *testing.T) {
+		gasLimit := argUint64(100000)
+		requiredGas, highestGas = uint64(gasLimit), 0
+
+		estimate, err := ethEndpoint.EstimateGas(
+			constructMockTx(nil, nil),
+			nil,
+			nil,
+			&blockOverride{GasLimit: &gasLimit},
+		)
+		assert.NoError(t, err)
+
+		assert.Equal(t, gasLimit, estimate)
+		assert.Equal(t, uint64(gasLimit), highestGas)
+		assert.Equal(t, uint64(gasLimit), *store.blockOverride.GasLimit)
+	})
+
+	t.Run("balance override caps the gas allowance", func(t *testing.T) {
+		requiredGas, highestGas = 30000, 0
+
+		tx := constructMockTx(nil, nil)
+		tx.GasPrice = argBytesPtr([]byte{0x1})
+
+		estimate, err := ethEndpoint.EstimateGas(
+			tx,
+			nil,
+			&stateOverride{addr0: overrideAccount{Balance: argBigPtr(big.NewInt(30000))}},
+			nil,
+		)
+		assert.NoError(t, err)
+
+		assert.Equal(t, argUint64(30000), estimate)
+		assert.Equal(t, uint64(30000), highestGas)
+		assert.Equal(t, big.NewInt(30000), store.override[addr0].Balance)
+	})
+
+	t.Run("value transfer is applied with state override", func(t *testing.T) {
+		requiredGas, highestGas = 0, 0
+
+		tx := constructMockTx(nil, nil)
+		tx.Value = argBytesPtr([]byte{0x1})
+
+		_, err := ethEndpoint.EstimateGas(
+			tx,
+			nil,
+			&stateOverride{addr1: overrideAccount{Code: argBytesPtr([]byte{0x1})}},
+			nil,
+		)
+		assert.NoError(t, err)
+
+		assert.NotZero(t, highestGas)
+	})
+}
+
+func TestEth_Call_Overrides(t *testing.T) {
+	store := getExampleStore()
+	ethEndpoint := newTestEthEndpoint(store)
+
+	var gas uint64
+
+	store.applyTxnHook = func(
+		header *types.Header,
+		txn *types.Transaction,
+	) (*runtime.ExecutionResult, error) {
+		gas = txn.Gas
+
+		return &runtime.ExecutionResult{}, nil
+	}
+
+	gasLimit := argUint64(100000)
+	timestamp := argUint64(1000)
+
+	_, err := ethEndpoint.Call(
+		constructMockTx(nil, nil),
+		BlockNumberOrHash{},
+		&stateOverride{addr1: overrideAccount{Code: argBytesPtr([]byte{0x1})}},
+		&blockOverride{GasLimit: &gasLimit, Timestamp: &timestamp},
+	)
+	assert.NoError(t, err)
+
+	// the overridden block gas limit is used when the call gas isn't set
+	assert.Equal(t, uint64(gasLimit), gas)
+	assert.Equal(t, uint64(timestamp), *store.blockOverride.Timestamp)
+	assert.Equal(t, []byte{0x1}, store.override[addr1].Code)
+}
+
+func TestEth_Call_Cache(t *testing.T) {
+	store := getExampleStore()
+	ethEndpoint := newTestEthEndpoint(store)
+
+	callCache, err := newCallCache(16)
+	require.NoError(t, err)
+
+	ethEndpoint.callCache = callCache
+
+	executions := 0
+
+	store.applyTxnHook = func(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error) {
+		executions++
+
+		return &runtime.ExecutionResult{ReturnValue: txn.Input}, nil
+	}
+
+	call := func(data []byte) interface{} {
+		t.Helper()
+
+		res, err := ethEndpoint.Call(constructMockTx(nil, argBytesPtr(data)), BlockNumberOrHash{}, nil, nil)
+		require.NoError(t, err)
+
+		return res
+	}
+
+	// the same call on top of the same head is executed once
+	require.Equal(t, argBytesPtr([]byte{0x1}), call([]byte{0x1}))
+	require.Equal(t, argBytesPtr([]byte{0x1}), call([]byte{0x1}))
+	require.Equal(t, 1, executions)
+
+	// the call with the different input is executed
+	require.Equal(t, argBytesPtr([]byte{0x2}), call([]byte{0x2}))
+	require.Equal(t, 2, executions)
+
+	// the cache is purged on the new head
+	store.block = &types.Block{Header: &types.Header{Hash: hash2, Number: 1, GasLimit: 500000}}
+
+	require.Equal(t, argBytesPtr([]byte{0x1}), call([]byte{0x1}))
+	require.Equal(t, 3, executions)
+
+	// the failed calls are not cached
+	store.applyTxnHook = func(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error) {
+		executions++
+
+		return &runtime.ExecutionResult{Err: runtime.ErrExecutionReverted}, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := ethEndpoint.Call(constructMockTx(nil, argBytesPtr([]byte{0x3})), BlockNumberOrHash{}, nil, nil)
+		require.Error(t, err)
+	}
+
+	require.Equal(t, 5, executions)
+}
+
+func TestEth_CreateAccessList(t *testing.T) {
+	var (
+		contract = types.StringToAddress("0x1000")
+		other    = types.StringToAddress("0x2000")
+		slot     = types.StringToHash("0x1")
+	)
+
+	store := getExampleStore()
+	ethEndpoint := newTestEthEndpoint(store)
+
+	var result *runtime.ExecutionResult
+
+	// the call reads the slot of the recipient, the balance of the other account and calls the precompile
+	store.applyTracedTxnHook = func(txn *types.Transaction, tr tracer.Tracer) *runtime.ExecutionResult {
+		stateTracer, ok := tr.(tracer.StateTracer)
+		require.True(t, ok)
+
+		stateTracer.TxStartState(txn.From, txn.To, types.ZeroAddress, nil)
+
+		tr.CaptureState(nil, []*big.Int{new(big.Int).SetBytes(slot.Bytes())}, evm.SLOAD, *txn.To, 1, nil, nil)
+		tr.CaptureState(nil, []*big.Int{new(big.Int).SetBytes(other.Bytes())}, evm.BALANCE, *txn.To, 1, nil, nil)
+		tr.CaptureState(nil, []*big.Int{big.NewInt(1), big.NewInt(1000)}, evm.STATICCALL, *txn.To, 2, nil, nil)
+
+		return result
+	}
+
+	arg := constructMockTx(nil, nil)
+	arg.To = &contract
+
+	t.Run("succeeded call", func(t *testing.T) {
+		result = &runtime.ExecutionResult{GasUsed: 30000}
+
+		res, err := ethEndpoint.CreateAccessList(arg, BlockNumberOrHash{})
+		require.NoError(t, err)
+
+		accessList, ok := res.(*accessListResult)
+		require.True(t, ok)
+
+		require.Equal(t, argUint64(30000), accessList.GasUsed)
+		require.Empty(t, accessList.Error)
+
+		// the recipient is listed for its storage, while the precompile is omitted
+		require.Equal(t, accesslisttracer.AccessList{
+			{Address: contract, StorageKeys: []types.Hash{slot}},
+			{Address: other, StorageKeys: []types.Hash{}},
+		}, accessList.AccessList)
+	})
+
+	t.Run("reverted call", func(t *testing.T) {
+		result = &runtime.ExecutionResult{GasUsed: 25000, Err: runtime.ErrExecutionReverted}
+
+		res, err := ethEndpoint.CreateAccessList(arg, BlockNumberOrHash{})
+		require.NoError(t, err)
+
+		accessList, ok := res.(*accessListResult)
+		require.True(t, ok)
+
+		require.Equal(t, argUint64(25000), accessList.GasUsed)
+		require.Equal(t, runtime.ErrExecutionReverted.Error(), accessList.Error)
+		require.Len(t, accessList.AccessList, 2)
+	})
+}
+
 func TestEth_EstimateGas_ValueTransfer(t *testing.T) {
 	store := getExampleStore()
 	ethEndpoint := newTestEthEndpoint(store)
@@ -791,6 +1095,8 @@ func TestEth_EstimateGas_ValueTransfer(t *testing.T) {
 	estimate, err := ethEndpoint.EstimateGas(
 		mockTx,
 		nil,
+		nil,
+		nil,
 	)
 
 	assert.NotNil(t, estimate)
@@ -818,6 +1124,8 @@ func TestEth_EstimateGas_ContractCreation(t *testing.T) {
 	estimate, err := ethEndpoint.EstimateGas(
 		mockTx,
 		nil,
+		nil,
+		nil,
 	)
 
 	assert.NotNil(t, estimate)
@@ -827,12 +1135,86 @@ func TestEth_EstimateGas_ContractCreation(t *testing.T) {
 	assert.Equal(t, state.TxGasContractCreation, uint64(estimateUint64))
 }
 
+func TestEth_State_GetProof(t *testing.T) {
+	slot := types.StringToHash("0x1")
+
+	store := &mockSpecialStore{
+		account: &mockAccount{
+			address: addr0,
+			account: &Account{
+				Balance: big.NewInt(100),
+				Nonce:   5,
+			},
+			storage: map[types.Hash][]byte{
+				slot: {0x2a},
+			},
+		},
+		block: &types.Block{
+			Header: &types.Header{
+				Hash:      types.ZeroHash,
+				Number:    0,
+				StateRoot: types.EmptyRootHash,
+			},
+		},
+	}
+
+	eth := newTestEthEndpoint(store)
+	latest := LatestBlockNumber
+
+	t.Run("existing account", func(t *testing.T) {
+		res, err := eth.GetProof(addr0, []types.Hash{slot}, BlockNumberOrHash{BlockNumber: &latest})
+		assert.NoError(t, err)
+
+		proof, ok := res.(*accountProofResult)
+		assert.True(t, ok)
+
+		assert.Equal(t, addr0, proof.Address)
+		assert.Equal(t, argBig(*big.NewInt(100)), proof.Balance)
+		assert.Equal(t, argUint64(5), proof.Nonce)
+		assert.Equal(t, []argBytes{{0x1}, {0x2}}, proof.AccountProof)
+		assert.Len(t, proof.StorageProof, 1)
+		assert.Equal(t, slot, proof.StorageProof[0].Key)
+		assert.Equal(t, argBig(*big.NewInt(0x2a)), proof.StorageProof[0].Value)
+		assert.Equal(t, []argBytes{{0x3}}, proof.StorageProof[0].Proof)
+	})
+
+	t.Run("too many storage keys", func(t *testing.T) {
+		_, err := eth.GetProof(addr0, make([]types.Hash, maxProofStorageKeys+1), BlockNumberOrHash{BlockNumber: &latest})
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown block", func(t *testing.T) {
+		invalid := BlockNumber(0x1)
+
+		_, err := eth.GetProof(addr0, nil, BlockNumberOrHash{BlockNumber: &invalid})
+		assert.Error(t, err)
+	})
+}
+
 type mockSpecialStore struct {
 	ethStore
 	account *mockAccount
 	block   *types.Block
 
 	applyTxnHook func(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error)
+
+	applyTracedTxnHook func(txn *types.Transaction, tracer tracer.Tracer) *runtime.ExecutionResult
+
+	// overrides passed to the last ApplyTxn call
+	override      types.StateOverride
+	blockOverride *types.BlockOverride
+}
+
+func (m *mockSpecialStore) ApplyTracedTxn(
+	_ *types.Header,
+	txn *types.Transaction,
+	tracer tracer.Tracer,
+) (*runtime.ExecutionResult, error) {
+	if m.applyTracedTxnHook != nil {
+		return m.applyTracedTxnHook(txn, tracer), nil
+	}
+
+	return &runtime.ExecutionResult{}, nil
 }
 
 func (m *mockSpecialStore) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
@@ -898,11 +1280,43 @@ func (m *mockSpecialStore) GetCode(root types.Hash, addr types.Address) ([]byte,
 	return m.account.code, nil
 }
 
+func (m *mockSpecialStore) GetProof(root types.Hash, addr types.Address, slots []types.Hash) (*AccountProof, error) {
+	proof := &AccountProof{
+		Balance:     big.NewInt(0),
+		CodeHash:    types.EmptyCodeHash,
+		StorageHash: types.EmptyRootHash,
+		Proof:       [][]byte{{0x1}, {0x2}},
+	}
+
+	if m.account.address == addr {
+		proof.Balance = m.account.account.Balance
+		proof.Nonce = m.account.account.Nonce
+	}
+
+	for _, slot := range slots {
+		proof.StorageProofs = append(proof.StorageProofs, &StorageProof{
+			Key:   slot,
+			Value: m.account.storage[slot],
+			Proof: [][]byte{{0x3}},
+		})
+	}
+
+	return proof, nil
+}
+
 func (m *mockSpecialStore) GetForksInTime(blockNumber uint64) chain.ForksInTime {
 	return chain.AllForksEnabled.At(0)
 }
 
-func (m *mockSpecialStore) ApplyTxn(header *types.Header, txn *types.Transaction, _ types.StateOverride, _ bool) (*runtime.ExecutionResult, error) {
+func (m *mockSpecialStore) ApplyTxn(
+	header *types.Header,
+	txn *types.Transaction,
+	override types.StateOverride,
+	blockOverride *types.BlockOverride,
+	_ bool,
+) (*runtime.ExecutionResult, error) {
+	m.override, m.blockOverride = override, blockOverride
+
 	if m.applyTxnHook != nil {
 		return m.applyTxnHook(header, txn)
 	}
@@ -6,13 +6,17 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"reflect"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/bloombits"
+	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-hclog"
@@ -27,6 +31,7 @@ var (
 	ErrBlockRangeTooHigh                = errors.New("block range too high")
 	ErrNoWSConnection                   = errors.New("no websocket connection")
 	ErrUnknownSubscriptionType          = errors.New("unknown subscription type")
+	ErrResumeTokenQueryMismatch         = errors.New("resume token is used by a subscription with a different query")
 )
 
 // defaultTimeout is the timeout to remove the filters that don't have a web socket stream
@@ -188,6 +193,26 @@ type logFilter struct {
 
 	query *LogQuery
 	logs  []*Log
+
+	// resumeToken is the client provided token which keeps the subscription
+	// alive for a while after its web socket connection is closed
+	resumeToken string
+
+	// catchingUp is set while the historical logs are being collected,
+	// live logs are buffered until the catch-up is done
+	catchingUp bool
+
+	// sendLock serializes the writes of the catch-up and the live updates
+	sendLock sync.Mutex
+}
+
+// finishCatchUp puts the historical logs before the buffered live logs and ends the catch-up
+func (f *logFilter) finishCatchUp(logs []*Log) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.logs = append(logs, f.logs...)
+	f.catchingUp = false
 }
 
 // appendLog appends new log to logs
@@ -218,6 +243,18 @@ func (f *logFilter) getUpdates() (interface{}, error) {
 
 // sendUpdates writes stored logs to web socket stream
 func (f *logFilter) sendUpdates() error {
+	f.sendLock.Lock()
+	defer f.sendLock.Unlock()
+
+	f.Lock()
+	catchingUp := f.catchingUp
+	f.Unlock()
+
+	// live logs are held back until the historical logs are sent
+	if catchingUp {
+		return nil
+	}
+
 	logs := f.takeLogUpdates()
 
 	for _, log := range logs {
@@ -245,6 +282,14 @@ type pendingTxFilter struct {
 	sync.Mutex
 
 	txHashes []string
+
+	// fullTxs indicates the filter returns whole transaction objects instead of hashes
+	fullTxs bool
+	txs     []*transaction
+
+	// limiter restricts the number of notifications written to the web socket stream,
+	// it is shared by all the pending tx subscriptions of the connection
+	limiter *notificationLimiter
 }
 
 // appendPendingTxHashes appends new pending tx hash to tx hashes
@@ -255,6 +300,25 @@ func (f *pendingTxFilter) appendPendingTxHashes(txHash string) {
 	f.txHashes = append(f.txHashes, txHash)
 }
 
+// appendPendingTx appends new pending tx to the full tx objects
+func (f *pendingTxFilter) appendPendingTx(tx *transaction) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.txs = append(f.txs, tx)
+}
+
+// takePendingTxs returns all saved pending tx objects in filter and sets a new slice
+func (f *pendingTxFilter) takePendingTxs() []*transaction {
+	f.Lock()
+	defer f.Unlock()
+
+	txs := f.txs
+	f.txs = []*transaction{}
+
+	return txs
+}
+
 // takePendingTxsUpdates returns all saved pending tx hashes in filter and sets a new slice
 func (f *pendingTxFilter) takePendingTxsUpdates() []string {
 	f.Lock()
@@ -271,18 +335,50 @@ func (f *pendingTxFilter) getSubscriptionType() subscriptionType {
 	return PendingTransactions
 }
 
-// getUpdates returns stored pending tx hashes
+// getUpdates returns stored pending tx hashes (or tx objects, if the filter requested full txs)
 func (f *pendingTxFilter) getUpdates() (interface{}, error) {
+	if f.fullTxs {
+		return f.takePendingTxs(), nil
+	}
+
 	pendingTxHashes := f.takePendingTxsUpdates()
 
 	return pendingTxHashes, nil
 }
 
-// sendUpdates write the hashes for all pending transactions to web socket stream
+// sendUpdates write the hashes (or tx objects) for all pending transactions to web socket stream.
+// Notifications exceeding the connection rate limit are dropped
 func (f *pendingTxFilter) sendUpdates() error {
+	if f.fullTxs {
+		for _, tx := range f.takePendingTxs() {
+			if !f.limiter.allow() {
+				metrics.IncrCounter([]string{jsonRPCMetric, "pending_tx_notifications_dropped"}, 1)
+
+				continue
+			}
+
+			raw, err := json.Marshal(tx)
+			if err != nil {
+				return err
+			}
+
+			if err := f.writeMessageToWs(string(raw)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
 	pendingTxHashes := f.takePendingTxsUpdates()
 
 	for _, txHash := range pendingTxHashes {
+		if !f.limiter.allow() {
+			metrics.IncrCounter([]string{jsonRPCMetric, "pending_tx_notifications_dropped"}, 1)
+
+			continue
+		}
+
 		if err := f.writeMessageToWs(txHash); err != nil {
 			return err
 		}
@@ -291,6 +387,50 @@ func (f *pendingTxFilter) sendUpdates() error {
 	return nil
 }
 
+// notificationLimiter limits the number of notifications per second sent over a single connection
+type notificationLimiter struct {
+	sync.Mutex
+
+	limit uint64
+
+	windowStart time.Time
+	sent        uint64
+}
+
+// newNotificationLimiter creates a limiter allowing the given number of notifications per second.
+// Returns nil (no limit) if the limit is 0
+func newNotificationLimiter(limit uint64) *notificationLimiter {
+	if limit == 0 {
+		return nil
+	}
+
+	return &notificationLimiter{limit: limit}
+}
+
+// allow checks if one more notification can be sent in the current window
+func (l *notificationLimiter) allow() bool {
+	if l == nil {
+		return true
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.windowStart) >= time.Second {
+		l.windowStart = now
+		l.sent = 0
+	}
+
+	if l.sent >= l.limit {
+		return false
+	}
+
+	l.sent++
+
+	return true
+}
+
 // filterManagerStore provides methods required by FilterManager
 type filterManagerStore interface {
 	// Header returns the current header of the chain (genesis if empty)
@@ -310,6 +450,22 @@ type filterManagerStore interface {
 
 	// TxPoolSubscribe subscribes for tx pool events
 	TxPoolSubscribe(request *proto.SubscribeRequest) (<-chan *proto.TxPoolEvent, func(), error)
+
+	// GetPendingTx gets the pending transaction from the transaction pool, if it's present
+	GetPendingTx(txHash types.Hash) (*types.Transaction, bool)
+}
+
+// LogIndex is the index of the log blooms of the canonical chain,
+// which narrows the blocks read by the log queries over the indexed range
+type LogIndex interface {
+	// IndexedBlocks returns the number of the indexed blocks, starting from the genesis
+	IndexedBlocks() uint64
+
+	// Match returns the numbers of the indexed blocks in the range whose log blooms match the addresses and topics
+	Match(from, to uint64, addresses []types.Address, topics [][]types.Hash) ([]uint64, error)
+
+	// Status returns the progress of the index build
+	Status() *bloombits.Status
 }
 
 // FilterManager manages all running filters
@@ -320,30 +476,54 @@ type FilterManager struct {
 
 	timeout time.Duration
 
-	store           filterManagerStore
-	subscription    blockchain.Subscription
-	blockStream     *blockStream
-	blockRangeLimit uint64
+	store        filterManagerStore
+	subscription blockchain.Subscription
+	blockStream  *blockStream
+
+	// blockRangeLimit is the maximum block range of the log queries, 0 for no limit, updated on the config reload
+	blockRangeLimit atomic.Uint64
+
+	// logIndex narrows the blocks read by the log queries, nil if the index is not maintained
+	logIndex LogIndex
+
+	// pendingTxsRateLimit is the max number of pending tx notifications
+	// per second sent to a single web socket connection (0 means no limit)
+	pendingTxsRateLimit uint64
 
 	filters  map[string]filter
 	timeouts timeHeapImpl
 
+	// resumeTokens maps the resume tokens to the IDs of the log subscriptions
+	resumeTokens map[string]string
+
+	// wsLimiters are the pending tx notification limiters of the web socket connections
+	wsLimiters map[wsConn]*notificationLimiter
+
 	updateCh chan struct{}
 	closeCh  chan struct{}
 }
 
-func NewFilterManager(logger hclog.Logger, store filterManagerStore, blockRangeLimit uint64) *FilterManager {
+func NewFilterManager(
+	logger hclog.Logger,
+	store filterManagerStore,
+	blockRangeLimit uint64,
+	pendingTxsRateLimit uint64,
+) *FilterManager {
 	m := &FilterManager{
-		logger:          logger.Named("filter"),
-		timeout:         defaultTimeout,
-		store:           store,
-		blockRangeLimit: blockRangeLimit,
-		filters:         make(map[string]filter),
-		timeouts:        timeHeapImpl{},
-		updateCh:        make(chan struct{}),
-		closeCh:         make(chan struct{}),
+		logger:              logger.Named("filter"),
+		timeout:             defaultTimeout,
+		store:               store,
+		pendingTxsRateLimit: pendingTxsRateLimit,
+		filters:             make(map[string]filter),
+		resumeTokens:        make(map[string]string),
+		wsLimiters:          make(map[wsConn]*notificationLimiter),
+		timeouts:            timeHeapImpl{},
+		updateCh:            make(chan struct{}),
+		closeCh:             make(chan struct{}),
 	}
 
+	m.blockRangeLimit.Store(blockRangeLimit)
+
 	// start blockstream with the current header
 	header := store.Header()
 
@@ -459,20 +639,187 @@ func (f *FilterManager) NewLogFilter(logQuery *LogQuery, ws wsConn) string {
 	return f.addFilter(filter)
 }
 
-// NewPendingTxFilter adds new PendingTxFilter
-func (f *FilterManager) NewPendingTxFilter(ws wsConn) string {
+// NewLogSubscription adds new LogFilter with web socket connection.
+// If the query starts at a past block, the matching logs of the blocks up to the current head
+// are sent before the live ones. If the resume token is set, the subscription survives
+// the close of the connection for a while, and the subscription with the same token
+// resumes it on the new connection, including the logs emitted in the meantime
+func (f *FilterManager) NewLogSubscription(logQuery *LogQuery, ws wsConn, resumeToken string) (string, error) {
+	if resumeToken != "" {
+		if id, ok, err := f.resumeLogSubscription(resumeToken, logQuery, ws); err != nil || ok {
+			return id, err
+		}
+	}
+
+	filter := &logFilter{
+		filterBase:  newFilterBase(ws),
+		query:       logQuery,
+		resumeToken: resumeToken,
+	}
+
+	f.Lock()
+
+	// the head is read under the lock so that the blocks up to the head are covered by the catch-up,
+	// and all the blocks after it are processed by the filter
+	head := uint64(f.blockStream.getHead().header.Number)
+
+	from, to, catchUp := catchUpRange(logQuery, head)
+	if limit := scanBlockRangeLimit(f.blockRangeLimit.Load()); catchUp && to-from > limit {
+		f.Unlock()
+
+		return "", ErrBlockRangeTooHigh
+	}
+
+	filter.catchingUp = catchUp
+	f.filters[filter.id] = filter
+
+	if resumeToken != "" {
+		f.resumeTokens[resumeToken] = filter.id
+	}
+
+	f.Unlock()
+
+	ws.SetFilterID(filter.id)
+
+	if catchUp {
+		go f.catchUpLogSubscription(filter, from, to)
+	}
+
+	return filter.id, nil
+}
+
+// catchUpRange returns the range of the past blocks the log subscription needs to go through
+func catchUpRange(query *LogQuery, head uint64) (uint64, uint64, bool) {
+	switch query.fromBlock {
+	case LatestBlockNumber, PendingBlockNumber, FinalizedBlockNumber, SafeBlockNumber:
+		return 0, 0, false
+	}
+
+	if query.BlockHash != nil {
+		return 0, 0, false
+	}
+
+	from := uint64(0)
+	if query.fromBlock > 0 {
+		from = uint64(query.fromBlock)
+	}
+
+	to := head
+	if query.toBlock >= 0 && uint64(query.toBlock) < to {
+		to = uint64(query.toBlock)
+	}
+
+	// skip the genesis block
+	if from == 0 {
+		from = 1
+	}
+
+	if from > to {
+		return 0, 0, false
+	}
+
+	return from, to, true
+}
+
+// catchUpLogSubscription collects the historical logs of the subscription and sends them
+func (f *FilterManager) catchUpLogSubscription(filter *logFilter, from, to uint64) {
+	logs, err := f.getLogsFromRange(filter.query, from, to)
+	if err != nil {
+		f.logger.Error("failed to get historical logs", "id", filter.id, "err", err)
+	}
+
+	filter.finishCatchUp(logs)
+
+	f.sendLogSubscriptionUpdates(filter)
+}
+
+// resumeLogSubscription moves the subscription with the given resume token to the new connection
+func (f *FilterManager) resumeLogSubscription(resumeToken string, logQuery *LogQuery, ws wsConn) (string, bool, error) {
+	f.Lock()
+
+	id, ok := f.resumeTokens[resumeToken]
+	if !ok {
+		f.Unlock()
+
+		return "", false, nil
+	}
+
+	filter, _ := f.filters[id].(*logFilter)
+
+	if !reflect.DeepEqual(filter.query.Addresses, logQuery.Addresses) ||
+		!reflect.DeepEqual(filter.query.Topics, logQuery.Topics) {
+		f.Unlock()
+
+		return "", false, ErrResumeTokenQueryMismatch
+	}
+
+	if !filter.hasWSConn() {
+		// the subscription is not removed while it has the connection
+		if f.timeouts.removeFilter(&filter.filterBase) {
+			f.emitSignalToUpdateCh()
+		}
+	}
+
+	filter.ws = ws
+
+	f.Unlock()
+
+	ws.SetFilterID(id)
+
+	// send the logs emitted while the subscription was disconnected
+	go f.sendLogSubscriptionUpdates(filter)
+
+	return id, true, nil
+}
+
+// sendLogSubscriptionUpdates writes the stored logs of the subscription to its connection, if it has one
+func (f *FilterManager) sendLogSubscriptionUpdates(filter *logFilter) {
+	f.RLock()
+	defer f.RUnlock()
+
+	if !filter.hasWSConn() {
+		return
+	}
+
+	if err := filter.sendUpdates(); err != nil {
+		f.logger.Error("failed to send log subscription updates", "id", filter.id, "err", err)
+	}
+}
+
+// NewPendingTxFilter adds new PendingTxFilter.
+// If fullTxs is set, the filter returns whole transaction objects instead of hashes
+func (f *FilterManager) NewPendingTxFilter(ws wsConn, fullTxs bool) string {
 	filter := &pendingTxFilter{
 		filterBase: newFilterBase(ws),
 		txHashes:   []string{},
+		fullTxs:    fullTxs,
+		txs:        []*transaction{},
 	}
 
 	if filter.hasWSConn() {
 		ws.SetFilterID(filter.id)
+
+		filter.limiter = f.wsLimiter(ws)
 	}
 
 	return f.addFilter(filter)
 }
 
+// wsLimiter returns the pending tx notification limiter of the web socket connection,
+// so subscribing multiple times doesn't multiply the allowed rate [Thread safe]
+func (f *FilterManager) wsLimiter(ws wsConn) *notificationLimiter {
+	f.Lock()
+	defer f.Unlock()
+
+	limiter, ok := f.wsLimiters[ws]
+	if !ok {
+		limiter = newNotificationLimiter(f.pendingTxsRateLimit)
+		f.wsLimiters[ws] = limiter
+	}
+
+	return limiter
+}
+
 // Exists checks the filter with given ID exists
 func (f *FilterManager) Exists(id string) bool {
 	f.RLock()
@@ -526,13 +873,66 @@ func (f *FilterManager) getLogsFromBlocks(query *LogQuery) ([]*Log, error) {
 		from = 1
 	}
 
-	// if not disabled, avoid handling large block ranges
-	if f.blockRangeLimit != 0 && to-from > f.blockRangeLimit {
+	// if not disabled, avoid handling large block ranges.
+	// The blocks covered by the log index are not scanned, so they don't count towards the limit
+	if scanFrom, limit := common.Max(from, f.indexedBlocks(query)), f.blockRangeLimit.Load(); limit != 0 &&
+		to >= scanFrom && to-scanFrom > limit {
 		return nil, ErrBlockRangeTooHigh
 	}
 
+	return f.getLogsFromRange(query, from, to)
+}
+
+// indexedBlocks returns the number of the blocks from the genesis whose logs are looked up with the log index.
+// The index is not used if the query matches any log, since every block has to be read anyway
+func (f *FilterManager) indexedBlocks(query *LogQuery) uint64 {
+	if f.logIndex == nil {
+		return 0
+	}
+
+	filtered := len(query.Addresses) > 0
+
+	for _, topics := range query.Topics {
+		filtered = filtered || len(topics) > 0
+	}
+
+	if !filtered {
+		return 0
+	}
+
+	return f.logIndex.IndexedBlocks()
+}
+
+// getLogsFromRange returns the logs matching the query from the given range of blocks
+func (f *FilterManager) getLogsFromRange(query *LogQuery, from, to uint64) ([]*Log, error) {
 	logs := make([]*Log, 0)
 
+	// only the indexed blocks whose log blooms match the query are read
+	if indexed := f.indexedBlocks(query); from < indexed {
+		indexedTo := common.Min(to, indexed-1)
+
+		numbers, err := f.logIndex.Match(from, indexedTo, query.Addresses, query.Topics)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, number := range numbers {
+			block, ok := f.store.GetBlockByNumber(number, true)
+			if !ok {
+				return nil, ErrBlockNotFound
+			}
+
+			blockLogs, err := f.getLogsFromBlock(query, block)
+			if err != nil {
+				return nil, err
+			}
+
+			logs = append(logs, blockLogs...)
+		}
+
+		from = indexedTo + 1
+	}
+
 	for i := from; i <= to; i++ {
 		block, ok := f.store.GetBlockByNumber(i, true)
 		if !ok {
@@ -656,6 +1056,10 @@ func (f *FilterManager) removeFilterByID(id string) bool {
 
 	delete(f.filters, id)
 
+	if logFilter, ok := filter.(*logFilter); ok && logFilter.resumeToken != "" {
+		delete(f.resumeTokens, logFilter.resumeToken)
+	}
+
 	if removed := f.timeouts.removeFilter(filter.getFilterBase()); removed {
 		f.emitSignalToUpdateCh()
 	}
@@ -663,12 +1067,59 @@ func (f *FilterManager) removeFilterByID(id string) bool {
 	return true
 }
 
-// RemoveFilterByWs removes the filter with given WS [Thread safe]
+// RemoveFilterByWs removes the filters with given WS [Thread safe]
 func (f *FilterManager) RemoveFilterByWs(ws wsConn) {
 	f.Lock()
 	defer f.Unlock()
 
-	f.removeFilterByID(ws.GetFilterID())
+	for id, filter := range f.filters {
+		if filter.getFilterBase().ws == ws {
+			f.removeWsFilter(id, ws)
+		}
+	}
+
+	delete(f.wsLimiters, ws)
+}
+
+// countWsFilters returns the number of the filters with given WS [Thread safe]
+func (f *FilterManager) countWsFilters(ws wsConn) int {
+	f.RLock()
+	defer f.RUnlock()
+
+	count := 0
+
+	for _, filter := range f.filters {
+		if filter.getFilterBase().ws == ws {
+			count++
+		}
+	}
+
+	return count
+}
+
+// removeWsFilter removes the filter with given ID after its web socket connection is closed.
+// Log subscriptions with the resume token are kept until the timeout, so they can be resumed [NOT Thread Safe]
+func (f *FilterManager) removeWsFilter(id string, ws wsConn) {
+	filter, ok := f.filters[id]
+	if !ok {
+		return
+	}
+
+	base := filter.getFilterBase()
+
+	// the filter has been resumed on the other connection
+	if base.ws != ws {
+		return
+	}
+
+	if logFilter, ok := filter.(*logFilter); ok && logFilter.resumeToken != "" {
+		base.ws = nil
+		f.addFilterTimeout(base)
+
+		return
+	}
+
+	f.removeFilterByID(id)
 }
 
 // refreshFilterTimeout updates the timeout for a filter to the current time
@@ -757,6 +1208,17 @@ func (f *FilterManager) processBlockEvent(evnt *blockchain.Event) {
 	f.RLock()
 	defer f.RUnlock()
 
+	// the logs of the blocks dropped by the reorg are sent again as removed, before the logs of the new chain
+	if evnt.Type == blockchain.EventReorg {
+		for i := len(evnt.OldChain) - 1; i >= 0; i-- {
+			block := toBlock(&types.Block{Header: evnt.OldChain[i]}, false)
+
+			if processErr := f.appendLogsToFilters(block, true); processErr != nil {
+				f.logger.Error(fmt.Sprintf("Unable to process removed block, %v", processErr))
+			}
+		}
+	}
+
 	for _, header := range evnt.NewChain {
 		block := toBlock(&types.Block{Header: header}, false)
 
@@ -764,14 +1226,15 @@ func (f *FilterManager) processBlockEvent(evnt *blockchain.Event) {
 		f.blockStream.push(block)
 
 		// process new chain to include new logs for LogFilter
-		if processErr := f.appendLogsToFilters(block); processErr != nil {
+		if processErr := f.appendLogsToFilters(block, false); processErr != nil {
 			f.logger.Error(fmt.Sprintf("Unable to process block, %v", processErr))
 		}
 	}
 }
 
-// appendLogsToFilters makes each LogFilters append logs in the header
-func (f *FilterManager) appendLogsToFilters(header *block) error {
+// appendLogsToFilters makes each LogFilters append logs in the header,
+// the logs of the block dropped by the reorg are marked as removed
+func (f *FilterManager) appendLogsToFilters(header *block, removed bool) error {
 	receipts, err := f.store.GetReceiptsByHash(header.Hash)
 	if err != nil {
 		return err
@@ -808,7 +1271,10 @@ func (f *FilterManager) appendLogsToFilters(header *block) error {
 		for _, log := range receipt.Logs {
 			for _, f := range logFilters {
 				if f.query.Match(log) {
-					f.appendLog(toLog(log, logIndex, uint64(indx), block.Header, receipt.TxHash))
+					filterLog := toLog(log, logIndex, uint64(indx), block.Header, receipt.TxHash)
+					filterLog.Removed = removed
+
+					f.appendLog(filterLog)
 				}
 			}
 
@@ -824,9 +1290,35 @@ func (f *FilterManager) processTxEvent(evnt *proto.TxPoolEvent) {
 	f.RLock()
 	defer f.RUnlock()
 
-	for _, f := range f.filters {
-		if txFilter, ok := f.(*pendingTxFilter); ok {
+	// the tx object is resolved only once, and only if there is a filter requesting it
+	var (
+		tx       *transaction
+		resolved bool
+	)
+
+	for _, filter := range f.filters {
+		txFilter, ok := filter.(*pendingTxFilter)
+		if !ok {
+			continue
+		}
+
+		if !txFilter.fullTxs {
 			txFilter.appendPendingTxHashes(evnt.TxHash)
+
+			continue
+		}
+
+		if !resolved {
+			resolved = true
+
+			if pendingTx, found := f.store.GetPendingTx(types.StringToHash(evnt.TxHash)); found {
+				tx = toPendingTransaction(pendingTx)
+			}
+		}
+
+		// the tx may already be removed from the pool
+		if tx != nil {
+			txFilter.appendPendingTx(tx)
 		}
 	}
 }
@@ -834,7 +1326,7 @@ func (f *FilterManager) processTxEvent(evnt *proto.TxPoolEvent) {
 // flushWsFilters make each filters with web socket connection write the updates to web socket stream
 // flushWsFilters also removes the filters if flushWsFilters notices the connection is closed
 func (f *FilterManager) flushWsFilters(subType subscriptionType) error {
-	closedFilterIDs := make([]string, 0)
+	closedFilters := make(map[string]wsConn)
 
 	f.RLock()
 
@@ -845,8 +1337,9 @@ func (f *FilterManager) flushWsFilters(subType subscriptionType) error {
 
 		if flushErr := filter.sendUpdates(); flushErr != nil {
 			// mark as closed if the connection is closed
-			if errors.Is(flushErr, websocket.ErrCloseSent) || errors.Is(flushErr, net.ErrClosed) {
-				closedFilterIDs = append(closedFilterIDs, id)
+			if errors.Is(flushErr, websocket.ErrCloseSent) || errors.Is(flushErr, net.ErrClosed) ||
+				errors.Is(flushErr, errWsSlowConsumer) {
+				closedFilters[id] = filter.getFilterBase().ws
 
 				f.logger.Warn(fmt.Sprintf("Subscription %s has been closed", id))
 
@@ -860,14 +1353,14 @@ func (f *FilterManager) flushWsFilters(subType subscriptionType) error {
 	f.RUnlock()
 
 	// remove filters with closed web socket connections from FilterManager
-	if len(closedFilterIDs) > 0 {
+	if len(closedFilters) > 0 {
 		f.Lock()
-		for _, id := range closedFilterIDs {
-			f.removeFilterByID(id)
+		for id, ws := range closedFilters {
+			f.removeWsFilter(id, ws)
 		}
 		f.Unlock()
 
-		f.logger.Info(fmt.Sprintf("Removed %d filters due to closed connections", len(closedFilterIDs)))
+		f.logger.Info(fmt.Sprintf("Removed %d filters due to closed connections", len(closedFilters)))
 	}
 
 	return nil
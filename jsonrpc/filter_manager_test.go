@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/bloombits"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/gorilla/websocket"
@@ -129,7 +130,7 @@ func Test_GetLogsForQuery(t *testing.T) {
 
 	store.appendBlocksToStore(blocks)
 
-	f := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	f := NewFilterManager(hclog.NewNullLogger(), store, 1000, 0)
 
 	t.Cleanup(func() {
 		defer f.Close()
@@ -154,6 +155,83 @@ func Test_GetLogsForQuery(t *testing.T) {
 	}
 }
 
+type mockLogIndex struct {
+	indexed uint64
+	matches []uint64
+}
+
+func (m *mockLogIndex) IndexedBlocks() uint64 {
+	return m.indexed
+}
+
+func (m *mockLogIndex) Match(from, to uint64, _ []types.Address, _ [][]types.Hash) ([]uint64, error) {
+	numbers := make([]uint64, 0, len(m.matches))
+
+	for _, number := range m.matches {
+		if number >= from && number <= to {
+			numbers = append(numbers, number)
+		}
+	}
+
+	return numbers, nil
+}
+
+func (m *mockLogIndex) Status() *bloombits.Status {
+	return &bloombits.Status{Sections: 1, IndexedBlocks: m.indexed}
+}
+
+func Test_GetLogsForQuery_LogIndex(t *testing.T) {
+	t.Parallel()
+
+	topics := [][]types.Hash{{types.StringToHash("4")}, {types.StringToHash("5")}, {types.StringToHash("6")}}
+
+	store := &mockBlockStore{topics: []types.Hash{topics[0][0], topics[1][0], topics[2][0]}}
+	store.setupLogs()
+
+	blocks := make([]*types.Block, 5)
+
+	for i := range blocks {
+		blocks[i] = &types.Block{
+			Header: &types.Header{
+				Number: uint64(i),
+				Hash:   types.StringToHash(strconv.Itoa(i)),
+			},
+			Transactions: []*types.Transaction{{Value: big.NewInt(10)}, {Value: big.NewInt(11)}, {Value: big.NewInt(12)}},
+		}
+	}
+
+	store.appendBlocksToStore(blocks)
+
+	f := NewFilterManager(hclog.NewNullLogger(), store, 100, 0)
+	defer f.Close()
+
+	// the blocks up to 2 are indexed, and only block 2 matches the query among them
+	f.logIndex = &mockLogIndex{indexed: 3, matches: []uint64{2}}
+
+	logs, err := f.GetLogsForQuery(&LogQuery{fromBlock: 1, toBlock: 3, Topics: topics})
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	require.Equal(t, argUint64(2), logs[0].BlockNumber)
+	require.Equal(t, argUint64(3), logs[1].BlockNumber)
+
+	// the query matching any log doesn't use the index
+	logs, err = f.GetLogsForQuery(&LogQuery{fromBlock: 1, toBlock: 3})
+	require.NoError(t, err)
+	require.Len(t, logs, 7)
+
+	// the indexed blocks don't count towards the block range limit
+	_, err = f.GetLogsForQuery(&LogQuery{fromBlock: 1, toBlock: 200, Topics: topics})
+	require.ErrorIs(t, err, ErrBlockRangeTooHigh)
+
+	f.logIndex = &mockLogIndex{indexed: 150}
+
+	_, err = f.GetLogsForQuery(&LogQuery{fromBlock: 1, toBlock: 200, Topics: topics})
+	require.NoError(t, err)
+
+	_, err = f.GetLogsForQuery(&LogQuery{fromBlock: 1, toBlock: 200})
+	require.ErrorIs(t, err, ErrBlockRangeTooHigh)
+}
+
 func Test_getLogsFromBlock(t *testing.T) {
 	t.Parallel()
 
@@ -211,7 +289,7 @@ func Test_getLogsFromBlock(t *testing.T) {
 
 	store.appendBlocksToStore([]*types.Block{block})
 
-	f := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	f := NewFilterManager(hclog.NewNullLogger(), store, 1000, 0)
 
 	t.Cleanup(func() {
 		defer f.Close()
@@ -235,7 +313,7 @@ func Test_GetLogFilterFromID(t *testing.T) {
 
 	store := newMockStore()
 
-	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000, 0)
 	defer m.Close()
 
 	go m.Run()
@@ -258,7 +336,7 @@ func TestFilterLog(t *testing.T) {
 
 	store := newMockStore()
 
-	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000, 0)
 	defer m.Close()
 
 	go m.Run()
@@ -322,7 +400,7 @@ func TestFilterBlock(t *testing.T) {
 
 	store := newMockStore()
 
-	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000, 0)
 	defer m.Close()
 
 	go m.Run()
@@ -387,13 +465,13 @@ func TestFilterPendingTx(t *testing.T) {
 
 	store := newMockStore()
 
-	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000, 0)
 	defer m.Close()
 
 	go m.Run()
 
 	// add pending tx filter
-	id := m.NewPendingTxFilter(nil)
+	id := m.NewPendingTxFilter(nil, false)
 
 	// emit two events
 	store.emitTxPoolEvent(proto.EventType_ADDED, "evt1")
@@ -437,7 +515,7 @@ func TestFilterTimeout(t *testing.T) {
 
 	store := newMockStore()
 
-	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000, 0)
 	defer m.Close()
 
 	m.timeout = 2 * time.Second
@@ -459,7 +537,7 @@ func TestRemoveFilterByWebsocket(t *testing.T) {
 
 	mock, _ := newMockWsConnWithMsgCh()
 
-	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000, 0)
 	defer m.Close()
 
 	go m.Run()
@@ -472,12 +550,44 @@ func TestRemoveFilterByWebsocket(t *testing.T) {
 	assert.False(t, m.Exists(id))
 }
 
+func TestRemoveFilterByWebsocket_AllSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	mock, _ := newMockWsConnWithMsgCh()
+	other, _ := newMockWsConnWithMsgCh()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000, 0)
+	defer m.Close()
+
+	go m.Run()
+
+	ids := []string{
+		m.NewBlockFilter(mock),
+		m.NewBlockFilter(mock),
+		m.NewPendingTxFilter(mock, false),
+	}
+	otherID := m.NewBlockFilter(other)
+
+	assert.Equal(t, 3, m.countWsFilters(mock))
+
+	m.RemoveFilterByWs(mock)
+
+	for _, id := range ids {
+		assert.False(t, m.Exists(id))
+	}
+
+	assert.Equal(t, 0, m.countWsFilters(mock))
+	assert.True(t, m.Exists(otherID))
+}
+
 func Test_flushWsFilters(t *testing.T) {
 	t.Parallel()
 
 	store := newMockStore()
 
-	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000, 0)
 
 	t.Cleanup(func() {
 		m.Close()
@@ -566,7 +676,7 @@ func TestFilterWebsocket(t *testing.T) {
 
 	mock, msgCh := newMockWsConnWithMsgCh()
 
-	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000, 0)
 	defer m.Close()
 
 	go m.Run()
@@ -602,12 +712,12 @@ func TestFilterPendingTxWebsocket(t *testing.T) {
 
 	mock, msgCh := newMockWsConnWithMsgCh()
 
-	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000, 0)
 	defer m.Close()
 
 	go m.Run()
 
-	id := m.NewPendingTxFilter(mock)
+	id := m.NewPendingTxFilter(mock, false)
 
 	// we cannot call get filter changes for a websocket filter
 	_, err := m.GetFilterChanges(id)
@@ -623,6 +733,119 @@ func TestFilterPendingTxWebsocket(t *testing.T) {
 	}
 }
 
+func TestFilterPendingTxFull(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	tx := newTestTransaction(5, types.Address{0x1})
+	store.addPendingTx(tx)
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000, 0)
+	defer m.Close()
+
+	go m.Run()
+
+	id := m.NewPendingTxFilter(nil, true)
+
+	// the second tx is not in the pool anymore, so it is skipped
+	store.emitTxPoolEvent(proto.EventType_ADDED, tx.Hash.String())
+	store.emitTxPoolEvent(proto.EventType_ADDED, types.StringToHash("0x2").String())
+
+	time.Sleep(500 * time.Millisecond)
+
+	res, err := m.GetFilterChanges(id)
+	require.NoError(t, err)
+
+	txs, ok := res.([]*transaction)
+	require.True(t, ok)
+	require.Len(t, txs, 1)
+	require.Equal(t, tx.Hash, txs[0].Hash)
+	require.Equal(t, argUint64(tx.Nonce), txs[0].Nonce)
+}
+
+func TestFilterPendingTxWebsocket_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	f := &pendingTxFilter{
+		txHashes: []string{"evt1", "evt2", "evt3"},
+		limiter:  newNotificationLimiter(2),
+	}
+
+	sent := 0
+	f.ws = &mockWsConn{
+		WriteMessageFn: func(int, []byte) error {
+			sent++
+
+			return nil
+		},
+	}
+
+	require.NoError(t, f.sendUpdates())
+	require.Equal(t, 2, sent)
+
+	// notifications over the limit are dropped, not delayed
+	f.appendPendingTxHashes("evt4")
+	require.NoError(t, f.sendUpdates())
+	require.Equal(t, 2, sent)
+}
+
+func TestFilterPendingTxWebsocket_RateLimitPerConnection(t *testing.T) {
+	t.Parallel()
+
+	m := NewFilterManager(hclog.NewNullLogger(), newMockStore(), 1000, 2)
+	defer m.Close()
+
+	sent := 0
+	ws := &mockWsConn{
+		SetFilterIDFn: func(string) {},
+		WriteMessageFn: func(int, []byte) error {
+			sent++
+
+			return nil
+		},
+	}
+
+	first := m.NewPendingTxFilter(ws, false)
+	second := m.NewPendingTxFilter(ws, false)
+
+	// the subscriptions of the connection share the allowance
+	for _, id := range []string{first, second} {
+		filter, ok := m.filters[id].(*pendingTxFilter)
+		require.True(t, ok)
+
+		filter.appendPendingTxHashes("evt1")
+		filter.appendPendingTxHashes("evt2")
+
+		require.NoError(t, filter.sendUpdates())
+	}
+
+	require.Equal(t, 2, sent)
+
+	// the other connection has its own allowance
+	other := m.NewPendingTxFilter(&mockWsConn{SetFilterIDFn: func(string) {}}, false)
+	require.NotSame(t, m.filters[first].(*pendingTxFilter).limiter, m.filters[other].(*pendingTxFilter).limiter)
+
+	m.RemoveFilterByWs(ws)
+	require.NotContains(t, m.wsLimiters, ws)
+}
+
+func TestNotificationLimiter(t *testing.T) {
+	t.Parallel()
+
+	var unlimited *notificationLimiter
+	require.True(t, unlimited.allow())
+	require.Nil(t, newNotificationLimiter(0))
+
+	limiter := newNotificationLimiter(1)
+	require.True(t, limiter.allow())
+	require.False(t, limiter.allow())
+
+	// a new window starts after a second
+	limiter.windowStart = limiter.windowStart.Add(-time.Second)
+	require.True(t, limiter.allow())
+}
+
 type mockWsConn struct {
 	SetFilterIDFn  func(string)
 	GetFilterIDFn  func() string
@@ -767,7 +990,7 @@ func TestClosedFilterDeletion(t *testing.T) {
 
 	store := newMockStore()
 
-	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000, 0)
 	defer m.Close()
 
 	go m.Run()
@@ -851,7 +1074,7 @@ func Test_appendLogsToFilters(t *testing.T) {
 
 	store.appendBlocksToStore([]*types.Block{block})
 
-	f := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	f := NewFilterManager(hclog.NewNullLogger(), store, 1000, 0)
 
 	logFilter := &logFilter{
 		filterBase: newFilterBase(nil),
@@ -876,12 +1099,175 @@ func Test_appendLogsToFilters(t *testing.T) {
 	}
 
 	b := toBlock(&types.Block{Header: block.Header, Transactions: txs}, false)
-	err := f.appendLogsToFilters(b)
+	err := f.appendLogsToFilters(b, false)
 
 	require.NoError(t, err)
 	require.Len(t, logFilter.logs, numOfLogs)
 
 	for i := 0; i < numOfLogs; i++ {
 		require.Equal(t, uint64(i), uint64(logFilter.logs[i].LogIndex))
+		require.False(t, logFilter.logs[i].Removed)
+	}
+
+	// the logs of the block dropped by the reorg are appended again as removed
+	require.NoError(t, f.appendLogsToFilters(b, true))
+	require.Len(t, logFilter.logs, 2*numOfLogs)
+
+	for i := numOfLogs; i < 2*numOfLogs; i++ {
+		require.True(t, logFilter.logs[i].Removed)
+	}
+}
+
+func TestLogSubscription_CatchUp(t *testing.T) {
+	t.Parallel()
+
+	topics := []types.Hash{types.StringToHash("4"), types.StringToHash("5")}
+
+	store := &mockBlockStore{topics: topics}
+	store.setupLogs()
+
+	for i := 0; i < 5; i++ {
+		store.add(&types.Block{
+			Header: &types.Header{
+				Number: uint64(i),
+				Hash:   types.StringToHash(strconv.Itoa(i)),
+			},
+			Transactions: []*types.Transaction{{}, {}, {}},
+		})
+	}
+
+	t.Run("historical logs are sent", func(t *testing.T) {
+		t.Parallel()
+
+		m := NewFilterManager(hclog.NewNullLogger(), store, 1000, 0)
+		defer m.Close()
+
+		mock, msgCh := newMockWsConnWithMsgCh()
+
+		query := &LogQuery{
+			fromBlock: 1,
+			toBlock:   LatestBlockNumber,
+			Topics:    [][]types.Hash{topics},
+		}
+
+		_, err := m.NewLogSubscription(query, mock, "")
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			select {
+			case msg := <-msgCh:
+				assert.Contains(t, string(msg), topics[0].String())
+			case <-time.After(2 * time.Second):
+				t.Fatal("historical logs not received in 2 seconds")
+			}
+		}
+	})
+
+	t.Run("block range too high", func(t *testing.T) {
+		t.Parallel()
+
+		m := NewFilterManager(hclog.NewNullLogger(), store, 2, 0)
+		defer m.Close()
+
+		mock, _ := newMockWsConnWithMsgCh()
+
+		_, err := m.NewLogSubscription(&LogQuery{fromBlock: 1, toBlock: LatestBlockNumber}, mock, "")
+		require.ErrorIs(t, err, ErrBlockRangeTooHigh)
+	})
+
+	t.Run("block range limit disabled", func(t *testing.T) {
+		t.Parallel()
+
+		longStore := newMockBlockStore()
+		longStore.add(&types.Block{Header: &types.Header{Number: maxScanBlockRange + 2}})
+
+		m := NewFilterManager(hclog.NewNullLogger(), longStore, 0, 0)
+		defer m.Close()
+
+		mock, _ := newMockWsConnWithMsgCh()
+
+		// the replayed block range is capped even if the block range limit is disabled
+		_, err := m.NewLogSubscription(&LogQuery{fromBlock: 1, toBlock: LatestBlockNumber}, mock, "")
+		require.ErrorIs(t, err, ErrBlockRangeTooHigh)
+	})
+
+	t.Run("no catch-up from the latest block", func(t *testing.T) {
+		t.Parallel()
+
+		from, to, catchUp := catchUpRange(&LogQuery{fromBlock: LatestBlockNumber, toBlock: LatestBlockNumber}, 4)
+		assert.False(t, catchUp)
+		assert.Zero(t, from)
+		assert.Zero(t, to)
+
+		_, _, catchUp = catchUpRange(&LogQuery{fromBlock: FinalizedBlockNumber, toBlock: SafeBlockNumber}, 4)
+		assert.False(t, catchUp)
+
+		from, to, catchUp = catchUpRange(&LogQuery{fromBlock: EarliestBlockNumber, toBlock: 3}, 4)
+		assert.True(t, catchUp)
+		assert.Equal(t, uint64(1), from)
+		assert.Equal(t, uint64(3), to)
+	})
+}
+
+func TestLogSubscription_Resume(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000, 0)
+	defer m.Close()
+
+	m.timeout = 2 * time.Second
+
+	go m.Run()
+
+	query := &LogQuery{fromBlock: LatestBlockNumber, toBlock: LatestBlockNumber}
+
+	oldConn, _ := newMockWsConnWithMsgCh()
+
+	id, err := m.NewLogSubscription(query, oldConn, "token")
+	require.NoError(t, err)
+
+	// the subscription is kept after the connection is closed
+	m.RemoveFilterByWs(oldConn)
+	require.True(t, m.Exists(id))
+
+	filter, err := m.GetLogFilterFromID(id)
+	require.NoError(t, err)
+
+	filter.appendLog(&Log{TxHash: types.StringToHash("1")})
+
+	// the different query can't take over the subscription
+	_, err = m.NewLogSubscription(&LogQuery{Addresses: []types.Address{{0x1}}}, oldConn, "token")
+	require.ErrorIs(t, err, ErrResumeTokenQueryMismatch)
+
+	newConn, msgCh := newMockWsConnWithMsgCh()
+
+	resumedID, err := m.NewLogSubscription(query, newConn, "token")
+	require.NoError(t, err)
+	require.Equal(t, id, resumedID)
+
+	// the log emitted in the meantime is sent to the new connection
+	select {
+	case msg := <-msgCh:
+		assert.Contains(t, string(msg), types.StringToHash("1").String())
+	case <-time.After(2 * time.Second):
+		t.Fatal("buffered logs not received in 2 seconds")
 	}
+
+	// the late close of the old connection doesn't affect the resumed subscription
+	m.RemoveFilterByWs(oldConn)
+	require.True(t, m.Exists(id))
+
+	// the subscription is removed if it isn't resumed in time
+	m.RemoveFilterByWs(newConn)
+	require.True(t, m.Exists(id))
+
+	time.Sleep(3 * time.Second)
+	require.False(t, m.Exists(id))
+
+	// the token can be used by the new subscription
+	newID, err := m.NewLogSubscription(query, newConn, "token")
+	require.NoError(t, err)
+	require.NotEqual(t, id, newID)
 }
@@ -16,6 +16,19 @@ var (
 	ErrNoDataInContractCreation = errors.New("contract creation without data provided")
 )
 
+// maxScanBlockRange caps the block range replayed by the log subscriptions and traced by trace_filter
+// if the block range limit is disabled, since these scans are far more expensive than eth_getLogs
+const maxScanBlockRange uint64 = 1000
+
+// scanBlockRangeLimit returns the max block range of the scans of the log subscriptions and trace_filter
+func scanBlockRangeLimit(blockRangeLimit uint64) uint64 {
+	if blockRangeLimit == 0 {
+		return maxScanBlockRange
+	}
+
+	return blockRangeLimit
+}
+
 type latestHeaderGetter interface {
 	Header() *types.Header
 }
@@ -23,7 +36,7 @@ type latestHeaderGetter interface {
 // GetNumericBlockNumber returns block number based on current state or specified number
 func GetNumericBlockNumber(number BlockNumber, store latestHeaderGetter) (uint64, error) {
 	switch number {
-	case LatestBlockNumber, PendingBlockNumber:
+	case LatestBlockNumber, PendingBlockNumber, FinalizedBlockNumber, SafeBlockNumber:
 		latest := store.Header()
 		if latest == nil {
 			return 0, ErrLatestNotFound
@@ -51,7 +64,7 @@ type headerGetter interface {
 // GetBlockHeader returns a header using the provided number
 func GetBlockHeader(number BlockNumber, store headerGetter) (*types.Header, error) {
 	switch number {
-	case PendingBlockNumber, LatestBlockNumber:
+	case PendingBlockNumber, LatestBlockNumber, FinalizedBlockNumber, SafeBlockNumber:
 		return store.Header(), nil
 
 	case EarliestBlockNumber:
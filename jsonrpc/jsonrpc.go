@@ -1,21 +1,32 @@
 package jsonrpc
 
 import (
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/versioning"
+	"github.com/armon/go-metrics"
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-hclog"
 )
 
 type serverType int
 
+const (
+	// HealthzPath is the path of the liveness of the node
+	HealthzPath = "/healthz"
+	// ReadyzPath is the path of the readiness of the node
+	ReadyzPath = "/readyz"
+)
+
 const (
 	serverIPC serverType = iota
 	serverHTTP
@@ -40,12 +51,29 @@ type JSONRPC struct {
 	logger     hclog.Logger
 	config     *Config
 	dispatcher dispatcher
+
+	// rateLimiter limits the requests per client, nil if there are no limits
+	rateLimiter *rateLimiter
+
+	// adminAuth authorizes the calls of the admin methods
+	adminAuth adminAuth
+
+	// wsConnections is the number of the open websocket connections
+	wsConnections atomic.Int64
 }
 
 type dispatcher interface {
 	RemoveFilterByWs(conn wsConn)
 	HandleWs(reqBody []byte, conn wsConn) ([]byte, error)
 	Handle(reqBody []byte) ([]byte, error)
+	SetLimits(limits Limits)
+}
+
+// Limits are the limits of the requests which can be changed while the server is running
+type Limits struct {
+	PriceLimit       uint64
+	BatchLengthLimit uint64
+	BlockRangeLimit  uint64
 }
 
 // JSONRPCStore defines all the methods required
@@ -57,6 +85,9 @@ type JSONRPCStore interface {
 	filterManagerStore
 	bridgeStore
 	debugStore
+	traceStore
+	adminStore
+	validatorStore
 }
 
 type Config struct {
@@ -71,6 +102,46 @@ type Config struct {
 
 	ConcurrentRequestsDebug uint64
 	WebSocketReadLimit      uint64
+
+	PendingTxsRateLimit uint64
+
+	CallTimeout             time.Duration
+	MethodConcurrencyLimits map[string]uint64
+
+	// CallCacheSize is the number of the cached results of eth_call and eth_estimateGas, 0 disables the cache
+	CallCacheSize uint64
+	// EncodingCacheSize is the number of the cached encoded blocks and block receipts, 0 disables the cache
+	EncodingCacheSize uint64
+
+	// AccessLog enables logging of every http request
+	AccessLog bool
+	// RateLimitsFile is the path to the file with the per client rate limits, reloaded on change
+	RateLimitsFile string
+	// AdminToken is the bearer token required by the admin methods, the admin controls are disabled if empty
+	AdminToken string
+
+	// StateHistory is the number of the most recent blocks whose state is retained, 0 for all blocks
+	StateHistory uint64
+
+	// LogIndex is the index of the log blooms used by the log queries, nil if the index is not maintained
+	LogIndex LogIndex
+
+	// TLS is the TLS configuration of the http and the websocket endpoints, nil if TLS is disabled
+	TLS *tls.Config
+
+	// WebSocketMaxConnections is the maximum number of the concurrent websocket connections, 0 for no limit
+	WebSocketMaxConnections uint64
+	// WebSocketIdleTimeout closes the websocket connections which answer neither the pings nor send a message
+	// within the timeout, 0 disables the pings
+	WebSocketIdleTimeout time.Duration
+	// WebSocketMaxSubscriptions is the maximum number of the subscriptions of a websocket connection, 0 for no limit
+	WebSocketMaxSubscriptions uint64
+	// WebSocketSendQueue is the number of the messages queued for a websocket connection,
+	// the slow connection whose queue is full is closed instead of blocking the notifications
+	WebSocketSendQueue uint64
+
+	// HealthHandler serves the liveness at HealthzPath and the readiness at ReadyzPath, nil to not serve them
+	HealthHandler http.Handler
 }
 
 // NewJSONRPC returns the JSONRPC http server
@@ -85,6 +156,14 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 			jsonRPCBatchLengthLimit: config.BatchLengthLimit,
 			blockRangeLimit:         config.BlockRangeLimit,
 			concurrentRequestsDebug: config.ConcurrentRequestsDebug,
+			pendingTxsRateLimit:     config.PendingTxsRateLimit,
+			callTimeout:             config.CallTimeout,
+			methodConcurrencyLimits: config.MethodConcurrencyLimits,
+			stateHistory:            config.StateHistory,
+			logIndex:                config.LogIndex,
+			wsMaxSubscriptions:      config.WebSocketMaxSubscriptions,
+			callCacheSize:           config.CallCacheSize,
+			encodingCacheSize:       config.EncodingCacheSize,
 		},
 	)
 
@@ -96,6 +175,15 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 		logger:     logger.Named("jsonrpc"),
 		config:     config,
 		dispatcher: d,
+		adminAuth:  adminAuth{token: config.AdminToken},
+	}
+
+	if config.RateLimitsFile != "" {
+		if srv.rateLimiter, err = newRateLimiter(srv.logger, config.RateLimitsFile); err != nil {
+			return nil, err
+		}
+
+		go srv.rateLimiter.run()
 	}
 
 	// start http server
@@ -106,24 +194,64 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 	return srv, nil
 }
 
+// Close stops the background tasks of the server
+func (j *JSONRPC) Close() {
+	if j.rateLimiter != nil {
+		j.rateLimiter.close()
+	}
+}
+
+// SetLimits applies the new limits to the requests handled from now on
+func (j *JSONRPC) SetLimits(limits Limits) {
+	j.dispatcher.SetLimits(limits)
+}
+
 func (j *JSONRPC) setupHTTP() error {
-	j.logger.Info("http server started", "addr", j.config.Addr.String())
+	j.logger.Info("http server started", "addr", j.config.Addr.String(), "tls", j.config.TLS != nil)
 
 	lis, err := net.Listen("tcp", j.config.Addr.String())
 	if err != nil {
 		return err
 	}
 
+	if j.config.TLS != nil {
+		// the server is not configured for HTTP/2, so only HTTP/1.1 is negotiated
+		tlsConfig := j.config.TLS.Clone()
+		tlsConfig.NextProtos = append([]string{"http/1.1"}, tlsConfig.NextProtos...)
+
+		lis = tls.NewListener(lis, tlsConfig)
+	}
+
 	// NewServeMux must be used, as it disables all debug features.
 	// For some strange reason, with DefaultServeMux debug/vars is always enabled (but not debug/pprof).
 	// If pprof need to be enabled, this should be DefaultServeMux
 	mux := http.NewServeMux()
 
 	// The middleware factory returns a handler, so we need to wrap the handler function properly.
-	jsonRPCHandler := http.HandlerFunc(j.handle)
-	mux.Handle("/", middlewareFactory(j.config)(jsonRPCHandler))
+	var (
+		jsonRPCHandler http.Handler = middlewareFactory(j.config)(http.HandlerFunc(j.handle))
+		wsHandler      http.Handler = http.HandlerFunc(j.handleWs)
+	)
+
+	jsonRPCHandler = adminAuthMiddleware(&j.adminAuth)(jsonRPCHandler)
 
-	mux.HandleFunc("/ws", j.handleWs)
+	if j.rateLimiter != nil {
+		jsonRPCHandler = rateLimitMiddleware(j.rateLimiter)(jsonRPCHandler)
+		wsHandler = rateLimitMiddleware(j.rateLimiter)(wsHandler)
+	}
+
+	if j.config.AccessLog {
+		jsonRPCHandler = accessLogMiddleware(j.logger)(jsonRPCHandler)
+	}
+
+	mux.Handle("/", jsonRPCHandler)
+	mux.Handle("/ws", wsHandler)
+
+	// the health checks of the load balancers are neither rate limited nor logged
+	if j.config.HealthHandler != nil {
+		mux.Handle(HealthzPath, j.config.HealthHandler)
+		mux.Handle(ReadyzPath, j.config.HealthHandler)
+	}
 
 	srv := http.Server{
 		Handler:           mux,
@@ -173,13 +301,47 @@ var wsUpgrader = websocket.Upgrader{
 	WriteBufferSize: 1024,
 }
 
-// wsWrapper is a wrapping object for the web socket connection and logger
-type wsWrapper struct {
-	sync.Mutex
+const (
+	// defaultWebSocketSendQueue is the number of the queued messages of a websocket connection if not configured
+	defaultWebSocketSendQueue = 256
+
+	// wsWriteTimeout bounds the write of a single message to the websocket connection
+	wsWriteTimeout = 10 * time.Second
+)
+
+var (
+	errWsSlowConsumer = errors.New("websocket send queue is full")
+)
 
+// wsMessage is the message queued for the websocket connection
+type wsMessage struct {
+	messageType int
+	data        []byte
+}
+
+// wsWrapper is a wrapping object for the web socket connection and logger.
+// The messages are queued and written by the write loop, so the slow connections don't block the writers
+type wsWrapper struct {
 	ws       *websocket.Conn // the actual WS connection
 	logger   hclog.Logger    // module logger
 	filterID string          // filter ID
+
+	sendCh    chan wsMessage // the queue of the messages to write
+	closeCh   chan struct{}  // closed once the connection is closed
+	closeOnce sync.Once
+}
+
+func newWsWrapper(ws *websocket.Conn, logger hclog.Logger, queueSize uint64) *wsWrapper {
+	if queueSize == 0 {
+		queueSize = defaultWebSocketSendQueue
+	}
+
+	return &wsWrapper{
+		ws:      ws,
+		logger:  logger,
+		sendCh:  make(chan wsMessage, queueSize),
+		closeCh: make(chan struct{}),
+	}
 }
 
 func (w *wsWrapper) SetFilterID(filterID string) {
@@ -190,19 +352,83 @@ func (w *wsWrapper) GetFilterID() string {
 	return w.filterID
 }
 
-// WriteMessage writes out the message to the WS peer
+// WriteMessage queues the message for the WS peer, the connection is closed if its queue is full
 func (w *wsWrapper) WriteMessage(messageType int, data []byte) error {
-	w.Lock()
-	defer w.Unlock()
-	writeErr := w.ws.WriteMessage(messageType, data)
-
-	if writeErr != nil {
-		w.logger.Error(
-			fmt.Sprintf("Unable to write WS message, %s", writeErr.Error()),
-		)
+	select {
+	case <-w.closeCh:
+		return websocket.ErrCloseSent
+	default:
 	}
 
-	return writeErr
+	select {
+	case w.sendCh <- wsMessage{messageType: messageType, data: data}:
+		return nil
+	case <-w.closeCh:
+		return websocket.ErrCloseSent
+	default:
+		metrics.IncrCounter([]string{jsonRPCMetric, "ws_slow_consumers"}, 1)
+
+		w.logger.Warn("Closing slow WS connection", "remote", w.ws.RemoteAddr().String())
+		w.close(websocket.ClosePolicyViolation, errWsSlowConsumer.Error())
+
+		return errWsSlowConsumer
+	}
+}
+
+// writeLoop writes the queued messages and pings the WS peer in the given interval, until the connection is closed
+func (w *wsWrapper) writeLoop(pingInterval time.Duration) {
+	var pingCh <-chan time.Time
+
+	if pingInterval > 0 {
+		ticker := time.NewTicker(pingInterval)
+		defer ticker.Stop()
+
+		pingCh = ticker.C
+	}
+
+	for {
+		var err error
+
+		select {
+		case msg := <-w.sendCh:
+			if err = w.ws.SetWriteDeadline(time.Now().Add(wsWriteTimeout)); err == nil {
+				err = w.ws.WriteMessage(msg.messageType, msg.data)
+			}
+		case <-pingCh:
+			err = w.ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout))
+		case <-w.closeCh:
+			return
+		}
+
+		if err != nil {
+			w.logger.Error(fmt.Sprintf("Unable to write WS message, %s", err.Error()))
+			w.close(websocket.CloseGoingAway, "")
+
+			return
+		}
+	}
+}
+
+// close stops the writes and closes the connection in the background with the given close code,
+// so the writer noticing the slow connection isn't blocked [Thread safe]
+func (w *wsWrapper) close(code int, reason string) {
+	w.closeOnce.Do(func() {
+		close(w.closeCh)
+
+		go func() {
+			_ = w.ws.WriteControl(
+				websocket.CloseMessage,
+				websocket.FormatCloseMessage(code, reason),
+				time.Now().Add(time.Second),
+			)
+
+			if err := w.ws.Close(); err != nil {
+				w.logger.Error(
+					fmt.Sprintf("Unable to gracefully close WS connection, %s", err.Error()),
+				)
+			}
+		}()
+	})
 }
 
 // isSupportedWSType returns a status indicating if the message type is supported
@@ -212,6 +438,17 @@ func isSupportedWSType(messageType int) bool {
 }
 
 func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
+	// Limit the number of the concurrent connections before upgrading
+	connections := j.wsConnections.Add(1)
+	defer j.wsConnections.Add(-1)
+
+	if limit := j.config.WebSocketMaxConnections; limit > 0 && uint64(connections) > limit {
+		metrics.IncrCounter([]string{jsonRPCMetric, "ws_rejected"}, 1)
+		http.Error(w, "too many websocket connections", http.StatusServiceUnavailable)
+
+		return
+	}
+
 	// CORS rule - Allow requests from anywhere
 	wsUpgrader.CheckOrigin = func(r *http.Request) bool { return true }
 
@@ -228,17 +465,33 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 		ws.SetReadLimit(int64(j.config.WebSocketReadLimit))
 	}
 
+	wrapConn := newWsWrapper(ws, j.logger, j.config.WebSocketSendQueue)
+
 	// Defer WS closure
-	defer func(ws *websocket.Conn) {
-		err = ws.Close()
-		if err != nil {
-			j.logger.Error(
-				fmt.Sprintf("Unable to gracefully close WS connection, %s", err.Error()),
-			)
+	defer wrapConn.close(websocket.CloseNormalClosure, "")
+
+	// The idle connection is closed once the read deadline passes,
+	// the deadline is extended by every message and pong of the peer
+	idleTimeout := j.config.WebSocketIdleTimeout
+	extendDeadline := func() {
+		if idleTimeout > 0 {
+			_ = ws.SetReadDeadline(time.Now().Add(idleTimeout))
 		}
-	}(ws)
+	}
+
+	extendDeadline()
+	ws.SetPongHandler(func(string) error {
+		extendDeadline()
+
+		return nil
+	})
 
-	wrapConn := &wsWrapper{ws: ws, logger: j.logger}
+	go wrapConn.writeLoop(idleTimeout / 2)
+
+	// the messages of the connection count towards the rate limit of the client
+	apiKey, ip := clientIdentity(req)
+	// the admin token is checked once on the handshake
+	adminAuthorized := j.adminAuth.authorized(req)
 
 	j.logger.Info("Websocket connection established")
 	// Run the listen loop
@@ -263,7 +516,27 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 			break
 		}
 
+		extendDeadline()
+
 		if isSupportedWSType(msgType) {
+			if j.rateLimiter != nil {
+				if allowed, _ := j.rateLimiter.allow(apiKey, ip); !allowed {
+					metrics.IncrCounter([]string{jsonRPCMetric, "rate_limited"}, 1)
+
+					resp, _ := NewRPCResponse(nil, "2.0", nil, NewLimitExceededError("rate limit exceeded")).Bytes()
+					_ = wrapConn.WriteMessage(msgType, resp)
+
+					continue
+				}
+			}
+
+			if rpcErr := j.adminAuth.check(requestMethods(message), adminAuthorized); rpcErr != nil {
+				resp, _ := NewRPCResponse(nil, "2.0", nil, rpcErr).Bytes()
+				_ = wrapConn.WriteMessage(msgType, resp)
+
+				continue
+			}
+
 			go func() {
 				resp, handleErr := j.dispatcher.HandleWs(message, wrapConn)
 				if handleErr != nil {
@@ -3,11 +3,15 @@ package jsonrpc
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net"
+	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/require"
 
@@ -113,3 +117,108 @@ func newTestJSONRPC(t *testing.T) (*JSONRPC, error) {
 
 	return NewJSONRPC(hclog.NewNullLogger(), config)
 }
+
+type mockDispatcher struct {
+	dispatcher
+}
+
+func (m *mockDispatcher) RemoveFilterByWs(wsConn) {}
+
+func TestJSONRPC_handleWs_MaxConnections(t *testing.T) {
+	t.Parallel()
+
+	j := &JSONRPC{
+		logger:     hclog.NewNullLogger(),
+		config:     &Config{WebSocketMaxConnections: 1},
+		dispatcher: &mockDispatcher{},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(j.handleWs))
+	defer srv.Close()
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http")
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+
+	// the second connection exceeds the limit
+	_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	require.Error(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	require.NoError(t, conn.Close())
+
+	// the connection is accepted once the first one is gone
+	require.Eventually(t, func() bool {
+		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		if err != nil {
+			return false
+		}
+
+		return conn.Close() == nil
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestJSONRPC_handleWs_IdleTimeout(t *testing.T) {
+	t.Parallel()
+
+	j := &JSONRPC{
+		logger:     hclog.NewNullLogger(),
+		config:     &Config{WebSocketIdleTimeout: 200 * time.Millisecond},
+		dispatcher: &mockDispatcher{},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(j.handleWs))
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	// the pings are not answered unless the connection is read,
+	// so the server drops the connection once the idle timeout passes
+	time.Sleep(time.Second)
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	conn.SetPingHandler(func(string) error { return nil })
+
+	_, _, err = conn.ReadMessage()
+	require.Error(t, err)
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		require.False(t, netErr.Timeout(), "connection should be closed by the server")
+	}
+}
+
+func TestWsWrapper_SlowConsumer(t *testing.T) {
+	t.Parallel()
+
+	upgraded := make(chan *websocket.Conn, 1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := wsUpgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+
+		upgraded <- ws
+	}))
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	// the write loop isn't running, so the queue fills up
+	wrapper := newWsWrapper(<-upgraded, hclog.NewNullLogger(), 1)
+
+	require.NoError(t, wrapper.WriteMessage(websocket.TextMessage, []byte("1")))
+	require.ErrorIs(t, wrapper.WriteMessage(websocket.TextMessage, []byte("2")), errWsSlowConsumer)
+
+	// the connection is closed
+	require.ErrorIs(t, wrapper.WriteMessage(websocket.TextMessage, []byte("3")), websocket.ErrCloseSent)
+
+	_, _, err = conn.ReadMessage()
+	require.True(t, websocket.IsCloseError(err, websocket.ClosePolicyViolation))
+}
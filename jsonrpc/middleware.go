@@ -0,0 +1,418 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// apiKeyHeader is the request header carrying the API key of the client
+	apiKeyHeader = "X-API-Key"
+
+	// apiKeyQueryParam is the query parameter carrying the API key of the client,
+	// for the clients which can't set the headers (e.g. the browser websockets)
+	apiKeyQueryParam = "apikey"
+
+	// rateLimitsReloadInterval is the interval of checking the rate limits file for changes
+	rateLimitsReloadInterval = 5 * time.Second
+
+	// idleBucketTimeout is the time after which the buckets of inactive clients are dropped
+	idleBucketTimeout = time.Minute
+
+	// maxRequestBodySize is the max size of the request bodies read by the middlewares
+	maxRequestBodySize = 5 * 1024 * 1024
+)
+
+var errInvalidAPIKey = errors.New("invalid API key")
+
+// RateLimit is the limit of the requests of a single client
+type RateLimit struct {
+	// RequestsPerSecond is the rate at which the requests are allowed
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+
+	// Burst is the max number of requests allowed at once
+	Burst uint64 `json:"burst"`
+}
+
+// RateLimitsConfig is the content of the rate limits file
+type RateLimitsConfig struct {
+	// Default is the limit of the clients without the API key, applied per IP address.
+	// If not set, these clients are not limited
+	Default *RateLimit `json:"default"`
+
+	// APIKeys are the limits of the clients per API key.
+	// The requests with an API key not listed here are rejected
+	APIKeys map[string]*RateLimit `json:"apiKeys"`
+}
+
+// validate checks the limits are correct
+func (c *RateLimitsConfig) validate() error {
+	if c.Default != nil && c.Default.RequestsPerSecond <= 0 {
+		return fmt.Errorf("default rate limit must be positive")
+	}
+
+	for key, limit := range c.APIKeys {
+		if limit == nil || limit.RequestsPerSecond <= 0 {
+			return fmt.Errorf("rate limit of the API key %s must be positive", key)
+		}
+	}
+
+	return nil
+}
+
+// loadRateLimitsConfig reads the rate limits from the given file
+func loadRateLimitsConfig(path string) (*RateLimitsConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &RateLimitsConfig{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse rate limits file %s: %w", path, err)
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// tokenBucket allows the requests at the given rate, with the given burst
+type tokenBucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+// take refills the bucket and takes one token from it, if there is any
+func (b *tokenBucket) take(limit *RateLimit, now time.Time) bool {
+	burst := math.Max(float64(limit.Burst), 1)
+
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.lastRefill).Seconds()*limit.RequestsPerSecond)
+	b.lastRefill = now
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+
+	return true
+}
+
+// rateLimiter limits the requests per client (API key or IP address),
+// and reloads the limits when the rate limits file changes
+type rateLimiter struct {
+	lock sync.Mutex
+
+	logger hclog.Logger
+
+	path    string
+	modTime time.Time
+	config  *RateLimitsConfig
+
+	buckets map[string]*tokenBucket
+
+	closeCh chan struct{}
+}
+
+// newRateLimiter creates the rate limiter with the limits from the given file
+func newRateLimiter(logger hclog.Logger, path string) (*rateLimiter, error) {
+	l := &rateLimiter{
+		logger:  logger.Named("rate-limiter"),
+		path:    path,
+		buckets: make(map[string]*tokenBucket),
+		closeCh: make(chan struct{}),
+	}
+
+	if _, err := l.reload(); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// reload reads the rate limits file if it has been modified since the last read
+func (l *rateLimiter) reload() (bool, error) {
+	info, err := os.Stat(l.path)
+	if err != nil {
+		return false, err
+	}
+
+	l.lock.Lock()
+	modTime := l.modTime
+	l.lock.Unlock()
+
+	if info.ModTime().Equal(modTime) {
+		return false, nil
+	}
+
+	config, err := loadRateLimitsConfig(l.path)
+	if err != nil {
+		return false, err
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.config = config
+	l.modTime = info.ModTime()
+
+	// the buckets are recreated with the new limits
+	l.buckets = make(map[string]*tokenBucket)
+
+	return true, nil
+}
+
+// run reloads the rate limits file on change and drops the buckets of the inactive clients, until closed
+func (l *rateLimiter) run() {
+	ticker := time.NewTicker(rateLimitsReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-l.closeCh:
+			return
+		}
+
+		if reloaded, err := l.reload(); err != nil {
+			// the previous limits stay in effect
+			l.logger.Error("failed to reload rate limits", "path", l.path, "err", err)
+		} else if reloaded {
+			l.logger.Info("rate limits reloaded", "path", l.path)
+		}
+
+		l.dropIdleBuckets(time.Now())
+	}
+}
+
+// close stops reloading the rate limits file
+func (l *rateLimiter) close() {
+	close(l.closeCh)
+}
+
+// dropIdleBuckets removes the buckets which haven't been used for a while
+func (l *rateLimiter) dropIdleBuckets(now time.Time) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	for id, bucket := range l.buckets {
+		if now.Sub(bucket.lastRefill) > idleBucketTimeout {
+			delete(l.buckets, id)
+		}
+	}
+}
+
+// allow checks if one more request of the client is allowed.
+// The client is identified by the API key if there is one, or by the IP address otherwise
+func (l *rateLimiter) allow(apiKey, ip string) (bool, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	var (
+		limit *RateLimit
+		id    string
+	)
+
+	if apiKey != "" {
+		keyLimit, ok := l.config.APIKeys[apiKey]
+		if !ok {
+			return false, errInvalidAPIKey
+		}
+
+		limit, id = keyLimit, "key:"+apiKey
+	} else {
+		limit, id = l.config.Default, "ip:"+ip
+	}
+
+	if limit == nil {
+		return true, nil
+	}
+
+	now := time.Now()
+
+	bucket, ok := l.buckets[id]
+	if !ok {
+		bucket = &tokenBucket{
+			tokens:     math.Max(float64(limit.Burst), 1),
+			lastRefill: now,
+		}
+
+		l.buckets[id] = bucket
+	}
+
+	return bucket.take(limit, now), nil
+}
+
+// clientIdentity returns the API key and the IP address of the client sending the request
+func clientIdentity(r *http.Request) (string, string) {
+	apiKey := r.Header.Get(apiKeyHeader)
+	if apiKey == "" {
+		apiKey = r.URL.Query().Get(apiKeyQueryParam)
+	}
+
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	return apiKey, ip
+}
+
+// rateLimitMiddleware rejects the requests of the clients exceeding their rate limit
+func rateLimitMiddleware(limiter *rateLimiter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// preflight requests are not limited
+			if r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			allowed, err := limiter.allow(clientIdentity(r))
+			if err != nil {
+				writeHTTPError(w, http.StatusUnauthorized, NewInvalidRequestError(err.Error()))
+
+				return
+			}
+
+			if !allowed {
+				metrics.IncrCounter([]string{jsonRPCMetric, "rate_limited"}, 1)
+
+				writeHTTPError(w, http.StatusTooManyRequests, NewLimitExceededError("rate limit exceeded"))
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// writeHTTPError writes the json-rpc error response with the given http status
+func writeHTTPError(w http.ResponseWriter, status int, rpcErr Error) {
+	resp, _ := NewRPCResponse(nil, "2.0", nil, rpcErr).Bytes()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(resp)
+}
+
+// statusRecorder records the status and the size of the response
+type statusRecorder struct {
+	http.ResponseWriter
+
+	status int
+	size   int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	n, err := r.ResponseWriter.Write(b)
+	r.size += n
+
+	return n, err
+}
+
+// requestMethods returns the json-rpc methods called in the request body (single or batch request)
+func requestMethods(body []byte) []string {
+	var requests []struct {
+		Method string `json:"method"`
+	}
+
+	body = bytes.TrimLeft(body, " \t\r\n")
+	if len(body) > 0 && body[0] == '[' {
+		_ = json.Unmarshal(body, &requests)
+	} else {
+		requests = append(requests, struct {
+			Method string `json:"method"`
+		}{})
+
+		_ = json.Unmarshal(body, &requests[0])
+	}
+
+	methods := make([]string, 0, len(requests))
+	for _, req := range requests {
+		methods = append(methods, req.Method)
+	}
+
+	return methods
+}
+
+// accessLogMiddleware writes an access log entry for each http request
+func accessLogMiddleware(logger hclog.Logger) func(http.Handler) http.Handler {
+	logger = logger.Named("access")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			var methods []string
+
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			if r.Method == http.MethodPost && r.Body != nil {
+				body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+				if err == nil {
+					methods = requestMethods(body)
+					r.Body = io.NopCloser(bytes.NewReader(body))
+
+					next.ServeHTTP(recorder, r)
+				} else {
+					status := http.StatusBadRequest
+
+					var maxBytesErr *http.MaxBytesError
+					if errors.As(err, &maxBytesErr) {
+						status = http.StatusRequestEntityTooLarge
+					}
+
+					writeHTTPError(recorder, status, NewInvalidRequestError(err.Error()))
+				}
+			} else {
+				next.ServeHTTP(recorder, r)
+			}
+
+			apiKey, ip := clientIdentity(r)
+
+			logger.Info("request",
+				"remote", ip,
+				"api_key", maskAPIKey(apiKey),
+				"http_method", r.Method,
+				"methods", methods,
+				"status", recorder.status,
+				"size", recorder.size,
+				"duration", time.Since(start),
+			)
+		})
+	}
+}
+
+// maskAPIKey hides the API key in the logs, leaving only its prefix for the identification
+func maskAPIKey(apiKey string) string {
+	const visiblePrefix = 4
+
+	if len(apiKey) <= visiblePrefix {
+		return apiKey
+	}
+
+	return apiKey[:visiblePrefix] + "..."
+}
@@ -0,0 +1,260 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeRateLimitsFile(t *testing.T, path string, content string, modTime time.Time) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func TestRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "rate-limits.json")
+
+	writeRateLimitsFile(t, path, `{
+		"default": {"requestsPerSecond": 0.001, "burst": 2},
+		"apiKeys": {"key": {"requestsPerSecond": 0.001, "burst": 1}}
+	}`, time.Now().Add(-time.Hour))
+
+	limiter, err := newRateLimiter(hclog.NewNullLogger(), path)
+	require.NoError(t, err)
+
+	t.Run("limits per IP address", func(t *testing.T) {
+		for _, expected := range []bool{true, true, false} {
+			allowed, err := limiter.allow("", "1.1.1.1")
+			require.NoError(t, err)
+			assert.Equal(t, expected, allowed)
+		}
+
+		// the other address has its own limit
+		allowed, err := limiter.allow("", "2.2.2.2")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	})
+
+	t.Run("limits per API key", func(t *testing.T) {
+		for _, expected := range []bool{true, false} {
+			allowed, err := limiter.allow("key", "1.1.1.1")
+			require.NoError(t, err)
+			assert.Equal(t, expected, allowed)
+		}
+
+		_, err := limiter.allow("unknown", "1.1.1.1")
+		require.ErrorIs(t, err, errInvalidAPIKey)
+	})
+
+	t.Run("reloads the changed file", func(t *testing.T) {
+		reloaded, err := limiter.reload()
+		require.NoError(t, err)
+		assert.False(t, reloaded)
+
+		writeRateLimitsFile(t, path, `{"apiKeys": {"other": {"requestsPerSecond": 10, "burst": 1}}}`, time.Now())
+
+		reloaded, err = limiter.reload()
+		require.NoError(t, err)
+		assert.True(t, reloaded)
+
+		// no default limit
+		allowed, err := limiter.allow("", "1.1.1.1")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+
+		_, err = limiter.allow("key", "1.1.1.1")
+		require.ErrorIs(t, err, errInvalidAPIKey)
+
+		allowed, err = limiter.allow("other", "1.1.1.1")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	})
+
+	t.Run("keeps the limits if the file is invalid", func(t *testing.T) {
+		writeRateLimitsFile(t, path, `{"default": {"requestsPerSecond": -1}}`, time.Now().Add(time.Hour))
+
+		_, err := limiter.reload()
+		require.Error(t, err)
+
+		// the key of the previous file is still valid
+		_, err = limiter.allow("other", "1.1.1.1")
+		require.NoError(t, err)
+	})
+
+	t.Run("drops idle buckets", func(t *testing.T) {
+		limiter.dropIdleBuckets(time.Now().Add(2 * idleBucketTimeout))
+
+		limiter.lock.Lock()
+		defer limiter.lock.Unlock()
+
+		assert.Empty(t, limiter.buckets)
+	})
+}
+
+func TestTokenBucket_Refill(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now    = time.Now()
+		limit  = &RateLimit{RequestsPerSecond: 2, Burst: 1}
+		bucket = &tokenBucket{tokens: 1, lastRefill: now}
+	)
+
+	assert.True(t, bucket.take(limit, now))
+	assert.False(t, bucket.take(limit, now.Add(100*time.Millisecond)))
+	assert.True(t, bucket.take(limit, now.Add(600*time.Millisecond)))
+
+	// the tokens don't accumulate above the burst
+	assert.True(t, bucket.take(limit, now.Add(time.Hour)))
+	assert.False(t, bucket.take(limit, now.Add(time.Hour)))
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "rate-limits.json")
+
+	writeRateLimitsFile(t, path, `{
+		"default": {"requestsPerSecond": 0.001, "burst": 1},
+		"apiKeys": {"key": {"requestsPerSecond": 0.001, "burst": 1}}
+	}`, time.Now())
+
+	limiter, err := newRateLimiter(hclog.NewNullLogger(), path)
+	require.NoError(t, err)
+
+	handler := rateLimitMiddleware(limiter)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	send := func(apiKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{}"))
+		if apiKey != "" {
+			req.Header.Set(apiKeyHeader, apiKey)
+		}
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		return recorder
+	}
+
+	assert.Equal(t, http.StatusOK, send("").Code)
+
+	resp := send("")
+	assert.Equal(t, http.StatusTooManyRequests, resp.Code)
+	assert.Contains(t, resp.Body.String(), "rate limit exceeded")
+
+	assert.Equal(t, http.StatusOK, send("key").Code)
+	assert.Equal(t, http.StatusTooManyRequests, send("key").Code)
+	assert.Equal(t, http.StatusUnauthorized, send("unknown").Code)
+}
+
+func TestAccessLogMiddleware(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger := hclog.New(&hclog.LoggerOptions{Output: &buf, Level: hclog.Info})
+
+	handler := accessLogMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the body is still readable by the handler
+		methods := requestMethods(mustReadBody(t, r))
+		assert.Equal(t, []string{"eth_chainId", "eth_blockNumber"}, methods)
+
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("response"))
+	}))
+
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/?apikey=secret-key",
+		strings.NewReader(`[{"method":"eth_chainId"},{"method":"eth_blockNumber"}]`),
+	)
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	entry := buf.String()
+	assert.Contains(t, entry, "eth_chainId")
+	assert.Contains(t, entry, "status=202")
+	assert.Contains(t, entry, "size=8")
+	assert.Contains(t, entry, "api_key=secr...")
+	assert.NotContains(t, entry, "secret-key")
+}
+
+func TestAccessLogMiddleware_BodyTooLarge(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger := hclog.New(&hclog.LoggerOptions{Output: &buf, Level: hclog.Info})
+
+	handler := accessLogMiddleware(logger)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Fatal("the oversized request is handled")
+	}))
+
+	body := `{"method":"eth_call","params":["` + strings.Repeat("a", maxRequestBodySize) + `"]}`
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	assert.Contains(t, buf.String(), "status=413")
+}
+
+func TestRateLimiter_Close(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "rate-limits.json")
+
+	writeRateLimitsFile(t, path, `{"default": {"requestsPerSecond": 1, "burst": 1}}`, time.Now())
+
+	limiter, err := newRateLimiter(hclog.NewNullLogger(), path)
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+
+	go func() {
+		limiter.run()
+		close(done)
+	}()
+
+	limiter.close()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the rate limiter is still running after close")
+	}
+}
+
+func Test_requestMethods(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"eth_call"}, requestMethods([]byte(` {"method":"eth_call"}`)))
+	assert.Equal(t, []string{"a", "b"}, requestMethods([]byte(`[{"method":"a"},{"method":"b"}]`)))
+	assert.Equal(t, []string{""}, requestMethods([]byte(`invalid`)))
+}
+
+func mustReadBody(t *testing.T, r *http.Request) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	_, err := buf.ReadFrom(r.Body)
+	require.NoError(t, err)
+
+	return buf.Bytes()
+}
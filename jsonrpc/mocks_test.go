@@ -51,6 +51,7 @@ type mockStore struct {
 	receiptsLock  sync.Mutex
 	receipts      map[types.Hash][]*types.Receipt
 	accounts      map[types.Address]*Account
+	pendingTxs    sync.Map
 
 	// headers is the list of historical headers
 	historicalHeaders []*types.Header
@@ -120,6 +121,19 @@ func (m *mockStore) emitTxPoolEvent(eventType proto.EventType, txHash string) {
 	m.txPoolChannel <- evt
 }
 
+func (m *mockStore) addPendingTx(tx *types.Transaction) {
+	m.pendingTxs.Store(tx.Hash, tx)
+}
+
+func (m *mockStore) GetPendingTx(txHash types.Hash) (*types.Transaction, bool) {
+	tx, ok := m.pendingTxs.Load(txHash)
+	if !ok {
+		return nil, false
+	}
+
+	return tx.(*types.Transaction), true //nolint:forcetypeassert
+}
+
 func (m *mockStore) GetAccount(root types.Hash, addr types.Address) (*Account, error) {
 	if acc, ok := m.accounts[addr]; ok {
 		return acc, nil
@@ -217,6 +231,42 @@ func (m *mockStore) GetStateSyncProof(stateSyncID uint64) (types.Proof, error) {
 	return ssp, nil
 }
 
+func (m *mockStore) GetTokenMappings(rootToken types.Address) ([]*types.BridgeTokenMapping, error) {
+	return []*types.BridgeTokenMapping{
+		{
+			TokenType:  "erc20",
+			Predicate:  types.StringToAddress("0x1004"),
+			RootToken:  rootToken,
+			ChildToken: types.StringToAddress("0x2"),
+		},
+	}, nil
+}
+
+func (m *mockStore) GetDepositStatus(txHash types.Hash) ([]*types.BridgeTransferStatus, error) {
+	return []*types.BridgeTransferStatus{
+		{ID: 1, BlockNumber: 10, Status: types.BridgeTxCommitted},
+	}, nil
+}
+
+func (m *mockStore) GetWithdrawalStatus(txHash types.Hash) ([]*types.BridgeTransferStatus, error) {
+	return []*types.BridgeTransferStatus{
+		{ID: 2, BlockNumber: 20, Status: types.BridgeTxExitReady},
+	}, nil
+}
+
+func (m *mockStore) GetValidatorPerformance(address types.Address) (*types.ValidatorPerformance, error) {
+	return &types.ValidatorPerformance{
+		Address:        address,
+		ProposedBlocks: 2,
+		SignedBlocks:   9,
+		MissedBlocks:   1,
+	}, nil
+}
+
+func (m *mockStore) ListValidatorPerformance() ([]*types.ValidatorPerformance, error) {
+	return []*types.ValidatorPerformance{}, nil
+}
+
 func (m *mockStore) FilterExtra(extra []byte) ([]byte, error) {
 	return extra, nil
 }
@@ -0,0 +1,308 @@
+package jsonrpc
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/flattracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type traceStore interface {
+	// Header returns the current header of the chain (genesis if empty)
+	Header() *types.Header
+
+	// ReadTxLookup returns a block hash in which a given txn was mined
+	ReadTxLookup(txnHash types.Hash) (types.Hash, bool)
+
+	// GetBlockByHash gets a block using the provided hash
+	GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool)
+
+	// GetBlockByNumber gets a block using the provided height
+	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+
+	// TraceBlock traces all transactions in the given block
+	TraceBlock(*types.Block, tracer.Tracer) ([]interface{}, error)
+
+	// TraceTxn traces a transaction in the block, associated with the given hash
+	TraceTxn(*types.Block, types.Hash, tracer.Tracer) (interface{}, error)
+}
+
+// Trace is the trace jsonrpc endpoint, returning the traces in the parity (OpenEthereum) flat format
+type Trace struct {
+	store           traceStore
+	throttling      *Throttling
+	blockRangeLimit atomic.Uint64
+}
+
+func NewTrace(store traceStore, concurrentRequests uint64, blockRangeLimit uint64) *Trace {
+	t := &Trace{
+		store:      store,
+		throttling: NewThrottling(concurrentRequests, time.Second),
+	}
+
+	t.blockRangeLimit.Store(blockRangeLimit)
+
+	return t
+}
+
+// localizedTrace is the flat trace along with the transaction and the block it belongs to
+type localizedTrace struct {
+	*flattracer.Trace
+
+	BlockHash           types.Hash `json:"blockHash"`
+	BlockNumber         argUint64  `json:"blockNumber"`
+	TransactionHash     types.Hash `json:"transactionHash"`
+	TransactionPosition argUint64  `json:"transactionPosition"`
+}
+
+// traceFilter is the filter of the trace_filter request
+type traceFilter struct {
+	FromBlock   *BlockNumber    `json:"fromBlock"`
+	ToBlock     *BlockNumber    `json:"toBlock"`
+	FromAddress []types.Address `json:"fromAddress"`
+	ToAddress   []types.Address `json:"toAddress"`
+	After       *argUint64      `json:"after"`
+	Count       *argUint64      `json:"count"`
+}
+
+// matches checks if the trace is sent from and to the addresses of the filter
+func (f *traceFilter) matches(trace *flattracer.Trace) bool {
+	if len(f.FromAddress) > 0 && !containsAddress(f.FromAddress, trace.Action.From) {
+		return false
+	}
+
+	if len(f.ToAddress) > 0 {
+		to := trace.Action.To
+		if to == nil && trace.Result != nil {
+			// the created contract is the receiver of the create trace
+			to = trace.Result.Address
+		}
+
+		if to == nil || !containsAddress(f.ToAddress, *to) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Block returns the traces of all transactions in the block
+func (t *Trace) Block(number BlockNumber) (interface{}, error) {
+	return t.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			num, err := GetNumericBlockNumber(number, t.store)
+			if err != nil {
+				return nil, err
+			}
+
+			block, ok := t.store.GetBlockByNumber(num, true)
+			if !ok {
+				return nil, nil
+			}
+
+			return t.traceBlock(block)
+		},
+	)
+}
+
+// Transaction returns the traces of the transaction
+func (t *Trace) Transaction(txHash types.Hash) (interface{}, error) {
+	return t.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			blockHash, ok := t.store.ReadTxLookup(txHash)
+			if !ok {
+				return nil, nil
+			}
+
+			block, ok := t.store.GetBlockByHash(blockHash, true)
+			if !ok {
+				return nil, nil
+			}
+
+			_, txIndex := types.FindTxByHash(block.Transactions, txHash)
+			if txIndex == -1 {
+				return nil, nil
+			}
+
+			if block.Number() == 0 {
+				return nil, ErrTraceGenesisBlock
+			}
+
+			tracer := &flattracer.FlatTracer{}
+
+			cancel := cancelOnTimeout(tracer, defaultTraceTimeout)
+			defer cancel()
+
+			result, err := t.store.TraceTxn(block, txHash, tracer)
+			if err != nil {
+				return nil, err
+			}
+
+			return localizeTraces(result, block, txIndex)
+		},
+	)
+}
+
+// Filter returns the traces of the given block range, matching the given addresses
+func (t *Trace) Filter(filter *traceFilter) (interface{}, error) {
+	return t.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			from, to, err := t.filterRange(filter)
+			if err != nil {
+				return nil, err
+			}
+
+			var (
+				skip  uint64
+				count uint64
+				found = make([]*localizedTrace, 0)
+			)
+
+			if filter.After != nil {
+				skip = uint64(*filter.After)
+			}
+
+			if filter.Count != nil {
+				count = uint64(*filter.Count)
+			}
+
+			for num := from; num <= to; num++ {
+				block, ok := t.store.GetBlockByNumber(num, true)
+				if !ok {
+					return nil, fmt.Errorf("block %d not found", num)
+				}
+
+				traces, err := t.traceBlock(block)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, trace := range traces {
+					if !filter.matches(trace.Trace) {
+						continue
+					}
+
+					if skip > 0 {
+						skip--
+
+						continue
+					}
+
+					found = append(found, trace)
+
+					if count != 0 && uint64(len(found)) == count {
+						return found, nil
+					}
+				}
+			}
+
+			return found, nil
+		},
+	)
+}
+
+// filterRange returns the block range of the filter, latest block by default
+func (t *Trace) filterRange(filter *traceFilter) (uint64, uint64, error) {
+	if filter == nil {
+		return 0, 0, ErrNoConfig
+	}
+
+	resolve := func(number *BlockNumber) (uint64, error) {
+		if number == nil {
+			return GetNumericBlockNumber(LatestBlockNumber, t.store)
+		}
+
+		return GetNumericBlockNumber(*number, t.store)
+	}
+
+	from, err := resolve(filter.FromBlock)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	to, err := resolve(filter.ToBlock)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if from > to {
+		return 0, 0, ErrIncorrectBlockRange
+	}
+
+	if limit := scanBlockRangeLimit(t.blockRangeLimit.Load()); to-from > limit {
+		return 0, 0, ErrBlockRangeTooHigh
+	}
+
+	return from, to, nil
+}
+
+// traceBlock traces all transactions in the block with the flat tracer
+func (t *Trace) traceBlock(block *types.Block) ([]*localizedTrace, error) {
+	// the genesis and the empty blocks have nothing to trace
+	if block.Number() == 0 || len(block.Transactions) == 0 {
+		return []*localizedTrace{}, nil
+	}
+
+	tracer := &flattracer.FlatTracer{}
+
+	cancel := cancelOnTimeout(tracer, defaultTraceTimeout)
+	defer cancel()
+
+	results, err := t.store.TraceBlock(block, tracer)
+	if err != nil {
+		return nil, err
+	}
+
+	traces := make([]*localizedTrace, 0, len(results))
+
+	for txIndex, result := range results {
+		txTraces, err := localizeTraces(result, block, txIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		traces = append(traces, txTraces...)
+	}
+
+	return traces, nil
+}
+
+// localizeTraces adds the transaction and the block details to the flat traces of the transaction
+func localizeTraces(result interface{}, block *types.Block, txIndex int) ([]*localizedTrace, error) {
+	traces, ok := result.([]*flattracer.Trace)
+	if !ok {
+		return nil, fmt.Errorf("unexpected trace result type %T", result)
+	}
+
+	localized := make([]*localizedTrace, len(traces))
+
+	for i, trace := range traces {
+		localized[i] = &localizedTrace{
+			Trace:               trace,
+			BlockHash:           block.Hash(),
+			BlockNumber:         argUint64(block.Number()),
+			TransactionHash:     block.Transactions[txIndex].Hash,
+			TransactionPosition: argUint64(txIndex),
+		}
+	}
+
+	return localized, nil
+}
+
+// containsAddress checks if the address is in the given list
+func containsAddress(addresses []types.Address, address types.Address) bool {
+	for _, addr := range addresses {
+		if addr == address {
+			return true
+		}
+	}
+
+	return false
+}
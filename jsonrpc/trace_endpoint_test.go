@@ -0,0 +1,264 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// newTraceEndpointMockStore returns the store with the given blocks, replaying their transactions on the tracer
+func newTraceEndpointMockStore(blocks []*types.Block) *debugEndpointMockStore {
+	// replays the transaction on the tracer
+	replay := func(tx *types.Transaction, tracer tracer.Tracer) {
+		if tx.To == nil {
+			tracer.CallStart(1, tx.From, addr2, int(runtime.Create), tx.Gas, nil, tx.Input)
+			tracer.CallEnd(1, []byte{0x1}, nil)
+
+			return
+		}
+
+		tracer.CallStart(1, tx.From, *tx.To, int(runtime.Call), tx.Gas, nil, tx.Input)
+		tracer.CallStart(2, *tx.To, addr2, int(runtime.Call), tx.Gas/2, nil, nil)
+		tracer.CallEnd(2, nil, runtime.ErrExecutionReverted)
+		tracer.CallEnd(1, nil, nil)
+	}
+
+	return &debugEndpointMockStore{
+		headerFn: func() *types.Header {
+			return blocks[len(blocks)-1].Header
+		},
+		readTxLookupFn: func(hash types.Hash) (types.Hash, bool) {
+			for _, block := range blocks {
+				if tx, _ := types.FindTxByHash(block.Transactions, hash); tx != nil {
+					return block.Hash(), true
+				}
+			}
+
+			return types.ZeroHash, false
+		},
+		getBlockByHashFn: func(hash types.Hash, _ bool) (*types.Block, bool) {
+			for _, block := range blocks {
+				if block.Hash() == hash {
+					return block, true
+				}
+			}
+
+			return nil, false
+		},
+		getBlockByNumberFn: func(num uint64, _ bool) (*types.Block, bool) {
+			if num >= uint64(len(blocks)) {
+				return nil, false
+			}
+
+			return blocks[num], true
+		},
+		traceBlockFn: func(block *types.Block, tracer tracer.Tracer) ([]interface{}, error) {
+			results := make([]interface{}, len(block.Transactions))
+
+			for i, tx := range block.Transactions {
+				tracer.Clear()
+				replay(tx, tracer)
+
+				res, err := tracer.GetResult()
+				if err != nil {
+					return nil, err
+				}
+
+				results[i] = res
+			}
+
+			return results, nil
+		},
+		traceTxnFn: func(block *types.Block, hash types.Hash, tracer tracer.Tracer) (interface{}, error) {
+			tx, _ := types.FindTxByHash(block.Transactions, hash)
+			replay(tx, tracer)
+
+			return tracer.GetResult()
+		},
+	}
+}
+
+// newTraceTestBlocks returns the genesis and two blocks,
+// block 1 has a call from addr0 to addr1 with a nested call to addr2,
+// block 2 has a contract creation by addr1
+func newTraceTestBlocks() []*types.Block {
+	callTx := &types.Transaction{From: addr0, To: &addr1, Gas: 100000}
+	callTx.ComputeHash(1)
+
+	createTx := &types.Transaction{From: addr1, Gas: 200000, Input: []byte{0x2}}
+	createTx.ComputeHash(2)
+
+	return []*types.Block{
+		{Header: &types.Header{Number: 0, Hash: hash1}},
+		{Header: &types.Header{Number: 1, Hash: hash2}, Transactions: []*types.Transaction{callTx}},
+		{Header: &types.Header{Number: 2, Hash: hash3}, Transactions: []*types.Transaction{createTx}},
+	}
+}
+
+func TestTrace_Block(t *testing.T) {
+	t.Parallel()
+
+	blocks := newTraceTestBlocks()
+	endpoint := NewTrace(newTraceEndpointMockStore(blocks), 100000, 0)
+
+	res, err := endpoint.Block(BlockNumber(1))
+	require.NoError(t, err)
+
+	traces, ok := res.([]*localizedTrace)
+	require.True(t, ok)
+	require.Len(t, traces, 2)
+
+	assert.Equal(t, hash2, traces[0].BlockHash)
+	assert.Equal(t, argUint64(1), traces[0].BlockNumber)
+	assert.Equal(t, blocks[1].Transactions[0].Hash, traces[0].TransactionHash)
+	assert.Equal(t, 1, traces[0].Subtraces)
+	assert.Equal(t, []int{0}, traces[1].TraceAddress)
+	assert.Equal(t, "Reverted", traces[1].Error)
+
+	// the traces are flattened into the parity format
+	encoded, err := json.Marshal(traces[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(encoded), `"traceAddress":[]`)
+	assert.Contains(t, string(encoded), `"transactionPosition":"0x0"`)
+	assert.Contains(t, string(encoded), `"callType":"call"`)
+
+	// genesis has nothing to trace
+	res, err = endpoint.Block(EarliestBlockNumber)
+	require.NoError(t, err)
+	assert.Empty(t, res)
+
+	// unknown block
+	res, err = endpoint.Block(BlockNumber(10))
+	require.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestTrace_Transaction(t *testing.T) {
+	t.Parallel()
+
+	blocks := newTraceTestBlocks()
+	endpoint := NewTrace(newTraceEndpointMockStore(blocks), 100000, 0)
+
+	createTx := blocks[2].Transactions[0]
+
+	res, err := endpoint.Transaction(createTx.Hash)
+	require.NoError(t, err)
+
+	traces, ok := res.([]*localizedTrace)
+	require.True(t, ok)
+	require.Len(t, traces, 1)
+
+	assert.Equal(t, "create", traces[0].Type)
+	assert.Equal(t, createTx.Hash, traces[0].TransactionHash)
+	assert.Equal(t, argUint64(2), traces[0].BlockNumber)
+	assert.Equal(t, addr2, *traces[0].Result.Address)
+
+	res, err = endpoint.Transaction(hash4)
+	require.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestTrace_Filter(t *testing.T) {
+	t.Parallel()
+
+	blocks := newTraceTestBlocks()
+	endpoint := NewTrace(newTraceEndpointMockStore(blocks), 100000, 1)
+
+	numberPtr := func(n int64) *BlockNumber {
+		num := BlockNumber(n)
+
+		return &num
+	}
+
+	countPtr := func(n uint64) *argUint64 {
+		count := argUint64(n)
+
+		return &count
+	}
+
+	tests := []struct {
+		name     string
+		filter   *traceFilter
+		expected int
+		err      error
+	}{
+		{
+			name:     "all traces of the range",
+			filter:   &traceFilter{FromBlock: numberPtr(1), ToBlock: numberPtr(2)},
+			expected: 3,
+		},
+		{
+			name:     "latest block by default",
+			filter:   &traceFilter{},
+			expected: 1,
+		},
+		{
+			name:     "from address",
+			filter:   &traceFilter{FromBlock: numberPtr(1), FromAddress: []types.Address{addr1}},
+			expected: 2,
+		},
+		{
+			name:     "to address matches the created contract",
+			filter:   &traceFilter{FromBlock: numberPtr(1), ToAddress: []types.Address{addr2}},
+			expected: 2,
+		},
+		{
+			name: "after and count",
+			filter: &traceFilter{
+				FromBlock: numberPtr(1),
+				After:     countPtr(1),
+				Count:     countPtr(1),
+			},
+			expected: 1,
+		},
+		{
+			name:   "incorrect range",
+			filter: &traceFilter{FromBlock: numberPtr(2), ToBlock: numberPtr(1)},
+			err:    ErrIncorrectBlockRange,
+		},
+		{
+			name:   "range too high",
+			filter: &traceFilter{FromBlock: numberPtr(0), ToBlock: numberPtr(2)},
+			err:    ErrBlockRangeTooHigh,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := endpoint.Filter(test.filter)
+			if test.err != nil {
+				require.ErrorIs(t, err, test.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			traces, ok := res.([]*localizedTrace)
+			require.True(t, ok)
+			assert.Len(t, traces, test.expected)
+		})
+	}
+}
+
+func TestTrace_FilterRangeLimitDisabled(t *testing.T) {
+	t.Parallel()
+
+	// the traced block range is capped even if the block range limit is disabled
+	endpoint := NewTrace(newTraceEndpointMockStore(newTraceTestBlocks()), 100000, 0)
+
+	from, to := BlockNumber(1), BlockNumber(maxScanBlockRange+2)
+
+	_, err := endpoint.Filter(&traceFilter{FromBlock: &from, ToBlock: &to})
+	require.ErrorIs(t, err, ErrBlockRangeTooHigh)
+}
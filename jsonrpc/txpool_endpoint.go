@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -17,6 +18,9 @@ type txPoolStore interface {
 
 	// GetBaseFee returns current base fee
 	GetBaseFee() uint64
+
+	// GetAccountDiagnostics returns the nonce gaps and the blocking transaction of the account
+	GetAccountDiagnostics(addr types.Address) *txpool.AccountDiagnostics
 }
 
 // TxPool is the txpool jsonrpc endpoint
@@ -41,6 +45,16 @@ type StatusResponse struct {
 	Queued  uint64 `json:"queued"`
 }
 
+type AccountDiagnosticsResponse struct {
+	Nonce                  argUint64      `json:"nonce"`
+	Chained                []*transaction `json:"chained"`
+	Gapped                 []*transaction `json:"gapped"`
+	MissingNonces          []argUint64    `json:"missingNonces"`
+	MissingNoncesTruncated bool           `json:"missingNoncesTruncated"`
+	BlockingTransaction    *transaction   `json:"blockingTransaction"`
+	MinReplacementGasPrice *argBig        `json:"minReplacementGasPrice"`
+}
+
 // Create response for txpool_content request.
 // See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_content.
 func (t *TxPool) Content() (interface{}, error) {
@@ -125,3 +139,39 @@ func (t *TxPool) Status() (interface{}, error) {
 
 	return resp, nil
 }
+
+// AccountDiagnostics reports why the account's transactions are stuck in the pool:
+// which transactions are chained and which are gapped, the nonces missing in between
+// and the minimum gas price needed to replace the blocking transaction
+func (t *TxPool) AccountDiagnostics(address types.Address) (interface{}, error) {
+	diagnostics := t.store.GetAccountDiagnostics(address)
+
+	convertTxs := func(txs []*types.Transaction) []*transaction {
+		result := make([]*transaction, len(txs))
+
+		for i, tx := range txs {
+			result[i] = toTransaction(tx, nil, &types.ZeroHash, nil)
+		}
+
+		return result
+	}
+
+	resp := AccountDiagnosticsResponse{
+		Nonce:                  argUint64(diagnostics.Nonce),
+		Chained:                convertTxs(diagnostics.Chained),
+		Gapped:                 convertTxs(diagnostics.Gapped),
+		MissingNonces:          make([]argUint64, len(diagnostics.MissingNonces)),
+		MissingNoncesTruncated: diagnostics.MissingNoncesTruncated,
+	}
+
+	for i, nonce := range diagnostics.MissingNonces {
+		resp.MissingNonces[i] = argUint64(nonce)
+	}
+
+	if diagnostics.BlockingTx != nil {
+		resp.BlockingTransaction = toTransaction(diagnostics.BlockingTx, nil, &types.ZeroHash, nil)
+		resp.MinReplacementGasPrice = argBigPtr(diagnostics.MinReplacementGasPrice)
+	}
+
+	return resp, nil
+}
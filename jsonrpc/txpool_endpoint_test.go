@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 
 	"github.com/stretchr/testify/assert"
@@ -264,6 +265,64 @@ func TestStatusEndpoint(t *testing.T) {
 	})
 }
 
+func TestAccountDiagnosticsEndpoint(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns empty response for unknown account", func(t *testing.T) {
+		t.Parallel()
+
+		mockStore := newMockTxPoolStore()
+		txPoolEndpoint := &TxPool{mockStore}
+
+		result, err := txPoolEndpoint.AccountDiagnostics(types.Address{0x1})
+		assert.NoError(t, err)
+
+		//nolint:forcetypeassert
+		response := result.(AccountDiagnosticsResponse)
+
+		assert.Equal(t, argUint64(0), response.Nonce)
+		assert.Empty(t, response.Chained)
+		assert.Empty(t, response.Gapped)
+		assert.Empty(t, response.MissingNonces)
+		assert.Nil(t, response.BlockingTransaction)
+		assert.Nil(t, response.MinReplacementGasPrice)
+	})
+
+	t.Run("returns gapped transactions and missing nonces", func(t *testing.T) {
+		t.Parallel()
+
+		address1 := types.Address{0x1}
+		testTx1 := newTestTransaction(2, address1)
+		testTx2 := newTestTransaction(5, address1)
+
+		mockStore := newMockTxPoolStore()
+		mockStore.diagnostics[address1] = &txpool.AccountDiagnostics{
+			Nonce:                  3,
+			Chained:                []*types.Transaction{testTx1},
+			Gapped:                 []*types.Transaction{testTx2},
+			MissingNonces:          []uint64{3, 4},
+			BlockingTx:             testTx1,
+			MinReplacementGasPrice: big.NewInt(2),
+		}
+		txPoolEndpoint := &TxPool{mockStore}
+
+		result, err := txPoolEndpoint.AccountDiagnostics(address1)
+		assert.NoError(t, err)
+
+		//nolint:forcetypeassert
+		response := result.(AccountDiagnosticsResponse)
+
+		assert.Equal(t, argUint64(3), response.Nonce)
+		assert.Len(t, response.Chained, 1)
+		assert.Equal(t, testTx1.Hash, response.Chained[0].Hash)
+		assert.Len(t, response.Gapped, 1)
+		assert.Equal(t, testTx2.Hash, response.Gapped[0].Hash)
+		assert.Equal(t, []argUint64{3, 4}, response.MissingNonces)
+		assert.Equal(t, testTx1.Hash, response.BlockingTransaction.Hash)
+		assert.Equal(t, *big.NewInt(2), big.Int(*response.MinReplacementGasPrice))
+	})
+}
+
 type mockTxPoolStore struct {
 	pending       map[types.Address][]*types.Transaction
 	queued        map[types.Address][]*types.Transaction
@@ -271,12 +330,14 @@ type mockTxPoolStore struct {
 	maxSlots      uint64
 	baseFee       uint64
 	includeQueued bool
+	diagnostics   map[types.Address]*txpool.AccountDiagnostics
 }
 
 func newMockTxPoolStore() *mockTxPoolStore {
 	return &mockTxPoolStore{
-		pending: make(map[types.Address][]*types.Transaction),
-		queued:  make(map[types.Address][]*types.Transaction),
+		pending:     make(map[types.Address][]*types.Transaction),
+		queued:      make(map[types.Address][]*types.Transaction),
+		diagnostics: make(map[types.Address]*txpool.AccountDiagnostics),
 	}
 }
 
@@ -294,6 +355,14 @@ func (s *mockTxPoolStore) GetBaseFee() uint64 {
 	return s.baseFee
 }
 
+func (s *mockTxPoolStore) GetAccountDiagnostics(addr types.Address) *txpool.AccountDiagnostics {
+	if diagnostics, ok := s.diagnostics[addr]; ok {
+		return diagnostics
+	}
+
+	return &txpool.AccountDiagnostics{}
+}
+
 func newTestTransaction(nonce uint64, from types.Address) *types.Transaction {
 	txn := &types.Transaction{
 		Nonce:    nonce,
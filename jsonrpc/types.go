@@ -389,11 +389,78 @@ type txnArgs struct {
 	Type      *argUint64
 }
 
+// userOperation is the ERC-4337 user operation representation used by the bundler rpc endpoints
+type userOperation struct {
+	Sender               types.Address `json:"sender"`
+	Nonce                *argBig       `json:"nonce"`
+	InitCode             argBytes      `json:"initCode"`
+	CallData             argBytes      `json:"callData"`
+	CallGasLimit         *argBig       `json:"callGasLimit"`
+	VerificationGasLimit *argBig       `json:"verificationGasLimit"`
+	PreVerificationGas   *argBig       `json:"preVerificationGas"`
+	MaxFeePerGas         *argBig       `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *argBig       `json:"maxPriorityFeePerGas"`
+	PaymasterAndData     argBytes      `json:"paymasterAndData"`
+	Signature            argBytes      `json:"signature"`
+}
+
+func toUserOperation(op *types.UserOperation) *userOperation {
+	return &userOperation{
+		Sender:               op.Sender,
+		Nonce:                argBigPtr(op.Nonce),
+		InitCode:             op.InitCode,
+		CallData:             op.CallData,
+		CallGasLimit:         argBigPtr(op.CallGasLimit),
+		VerificationGasLimit: argBigPtr(op.VerificationGasLimit),
+		PreVerificationGas:   argBigPtr(op.PreVerificationGas),
+		MaxFeePerGas:         argBigPtr(op.MaxFeePerGas),
+		MaxPriorityFeePerGas: argBigPtr(op.MaxPriorityFeePerGas),
+		PaymasterAndData:     op.PaymasterAndData,
+		Signature:            op.Signature,
+	}
+}
+
+// ToType converts the user operation to the types.UserOperation, missing numeric fields are left nil
+func (u *userOperation) ToType() *types.UserOperation {
+	toBig := func(a *argBig) *big.Int {
+		if a == nil {
+			return nil
+		}
+
+		return new(big.Int).Set((*big.Int)(a))
+	}
+
+	return &types.UserOperation{
+		Sender:               u.Sender,
+		Nonce:                toBig(u.Nonce),
+		InitCode:             u.InitCode,
+		CallData:             u.CallData,
+		CallGasLimit:         toBig(u.CallGasLimit),
+		VerificationGasLimit: toBig(u.VerificationGasLimit),
+		PreVerificationGas:   toBig(u.PreVerificationGas),
+		MaxFeePerGas:         toBig(u.MaxFeePerGas),
+		MaxPriorityFeePerGas: toBig(u.MaxPriorityFeePerGas),
+		PaymasterAndData:     u.PaymasterAndData,
+		Signature:            u.Signature,
+	}
+}
+
+type userOperationByHashResult struct {
+	UserOperation   *userOperation `json:"userOperation"`
+	EntryPoint      types.Address  `json:"entryPoint"`
+	BlockNumber     *argUint64     `json:"blockNumber"`
+	BlockHash       *types.Hash    `json:"blockHash"`
+	TransactionHash *types.Hash    `json:"transactionHash"`
+}
+
 type progression struct {
 	Type          string    `json:"type"`
+	Stage         string    `json:"stage"`
 	StartingBlock argUint64 `json:"startingBlock"`
 	CurrentBlock  argUint64 `json:"currentBlock"`
 	HighestBlock  argUint64 `json:"highestBlock"`
+	PulledStates  argUint64 `json:"pulledStates"`
+	KnownStates   argUint64 `json:"knownStates"`
 }
 
 type feeHistoryResult struct {
@@ -403,6 +470,53 @@ type feeHistoryResult struct {
 	Reward        [][]argUint64 `json:"reward,omitempty"`
 }
 
+type storageProofResult struct {
+	Key   types.Hash `json:"key"`
+	Value argBig     `json:"value"`
+	Proof []argBytes `json:"proof"`
+}
+
+type accountProofResult struct {
+	Address      types.Address         `json:"address"`
+	AccountProof []argBytes            `json:"accountProof"`
+	Balance      argBig                `json:"balance"`
+	CodeHash     types.Hash            `json:"codeHash"`
+	Nonce        argUint64             `json:"nonce"`
+	StorageHash  types.Hash            `json:"storageHash"`
+	StorageProof []*storageProofResult `json:"storageProof"`
+}
+
+func toAccountProofResult(addr types.Address, proof *AccountProof) *accountProofResult {
+	res := &accountProofResult{
+		Address:      addr,
+		AccountProof: convertToArgBytesSlice(proof.Proof),
+		Balance:      argBig(*proof.Balance),
+		CodeHash:     proof.CodeHash,
+		Nonce:        argUint64(proof.Nonce),
+		StorageHash:  proof.StorageHash,
+		StorageProof: make([]*storageProofResult, len(proof.StorageProofs)),
+	}
+
+	for i, storageProof := range proof.StorageProofs {
+		res.StorageProof[i] = &storageProofResult{
+			Key:   storageProof.Key,
+			Value: argBig(*new(big.Int).SetBytes(storageProof.Value)),
+			Proof: convertToArgBytesSlice(storageProof.Proof),
+		}
+	}
+
+	return res
+}
+
+func convertToArgBytesSlice(slice [][]byte) []argBytes {
+	argSlice := make([]argBytes, len(slice))
+	for i, value := range slice {
+		argSlice[i] = argBytes(value)
+	}
+
+	return argSlice
+}
+
 func convertToArgUint64Slice(slice []uint64) []argUint64 {
 	argSlice := make([]argUint64, len(slice))
 	for i, value := range slice {
@@ -0,0 +1,26 @@
+package jsonrpc
+
+import (
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// validatorStore interface provides access to the methods needed by validator endpoint
+type validatorStore interface {
+	GetValidatorPerformance(address types.Address) (*types.ValidatorPerformance, error)
+	ListValidatorPerformance() ([]*types.ValidatorPerformance, error)
+}
+
+// Validator is the validator jsonrpc endpoint
+type Validator struct {
+	store validatorStore
+}
+
+// GetPerformance returns the performance of the validator recorded by the consensus
+func (v *Validator) GetPerformance(address types.Address) (interface{}, error) {
+	return v.store.GetValidatorPerformance(address)
+}
+
+// ListPerformance returns the performance of all the validators recorded by the consensus
+func (v *Validator) ListPerformance() (interface{}, error) {
+	return v.store.ListValidatorPerformance()
+}
@@ -0,0 +1,61 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestValidatorEndpoint(t *testing.T) {
+	store := newMockStore()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		store,
+		&dispatcherParams{
+			chainID:                 0,
+			priceLimit:              0,
+			jsonRPCBatchLengthLimit: 20,
+			blockRangeLimit:         1000,
+		},
+	)
+
+	mockConnection, _ := newMockWsConnWithMsgCh()
+
+	msg := []byte(`{
+		"method": "validator_getPerformance",
+		"params": ["0x0000000000000000000000000000000000000001"],
+		"id": 1
+	}`)
+
+	data, err := dispatcher.HandleWs(msg, mockConnection)
+	require.NoError(t, err)
+
+	resp := new(SuccessResponse)
+	require.NoError(t, json.Unmarshal(data, resp))
+	require.Nil(t, resp.Error)
+
+	performance := new(types.ValidatorPerformance)
+	require.NoError(t, json.Unmarshal(resp.Result, performance))
+	require.Equal(t, types.StringToAddress("0x1"), performance.Address)
+	require.Equal(t, uint64(9), performance.SignedBlocks)
+	require.Equal(t, 0.9, performance.Uptime())
+
+	msg = []byte(`{
+		"method": "validator_listPerformance",
+		"params": [],
+		"id": 1
+	}`)
+
+	data, err = dispatcher.HandleWs(msg, mockConnection)
+	require.NoError(t, err)
+
+	resp = new(SuccessResponse)
+	require.NoError(t, json.Unmarshal(data, resp))
+	require.Nil(t, resp.Error)
+	require.NotNil(t, resp.Result)
+}
@@ -0,0 +1,387 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc"
+	"github.com/umbracle/ethgo/wallet"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/0xPolygon/polygon-edge/txrelayer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// blockPollInterval is the interval of polling the new blocks, bounding the precision of the latencies
+	blockPollInterval = 100 * time.Millisecond
+	// feeIncreasePercentage is the percentage the fees are increased by, so the transactions
+	// are still accepted if the base fee rises during the load test
+	feeIncreasePercentage = 100
+	// gasLimitIncreasePercentage is the percentage the estimated gas of the contract calls is increased by
+	gasLimitIncreasePercentage = 20
+)
+
+var (
+	errNoVUs = errors.New("at least one virtual user is required")
+	errNoTPS = errors.New("the target TPS must be greater than zero")
+)
+
+// Config is the configuration of the load test
+type Config struct {
+	JSONRPCAddr string
+	// SenderKey is the key of the funded account, which funds the virtual users
+	SenderKey ethgo.Key
+	Mode      Mode
+	TPS       uint64
+	Duration  time.Duration
+	// VUs is the number of the virtual users, the accounts sending the transactions in turns
+	VUs int
+	// DynamicTxs sends the dynamic fee transactions instead of the legacy ones
+	DynamicTxs bool
+	// InclusionTimeout is the time waited for the sent transactions to be included, once the sending ends
+	InclusionTimeout time.Duration
+	// Writer receives the progress of the load test, if set
+	Writer io.Writer
+}
+
+// virtualUser is the account sending the transactions of the load test
+type virtualUser struct {
+	key   ethgo.Key
+	nonce uint64
+}
+
+// Runner sends the transactions of the load test at the target TPS and measures their inclusion
+type Runner struct {
+	config   *Config
+	client   *jsonrpc.Client
+	relayer  txrelayer.TxRelayer
+	workload workload
+	vus      []*virtualUser
+
+	chainID *big.Int
+	signer  wallet.Signer
+	// template holds the gas and the fees of the transactions
+	template *ethgo.Transaction
+
+	sentLock sync.Mutex
+	sent     map[ethgo.Hash]time.Time
+
+	sendFailed    uint64
+	lastSendError atomic.Value
+}
+
+// NewRunner creates the load test runner connected to the JSON-RPC endpoint of the config
+func NewRunner(config *Config) (*Runner, error) {
+	if config.VUs < 1 {
+		return nil, errNoVUs
+	}
+
+	if config.TPS == 0 {
+		return nil, errNoTPS
+	}
+
+	client, err := jsonrpc.NewClient(config.JSONRPCAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	relayer, err := txrelayer.NewTxRelayer(txrelayer.WithClient(client))
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := newWorkload(config.Mode, config.SenderKey.Address())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Runner{
+		config:   config,
+		client:   client,
+		relayer:  relayer,
+		workload: w,
+		sent:     map[ethgo.Hash]time.Time{},
+	}, nil
+}
+
+// Run prepares the virtual users, sends the transactions for the duration of the load test
+// and waits for their inclusion
+func (r *Runner) Run() (*Result, error) {
+	defer r.client.Close()
+
+	txsPerVU := r.config.TPS*uint64(r.config.Duration.Seconds())/uint64(r.config.VUs) + 1
+
+	if err := r.setup(txsPerVU); err != nil {
+		return nil, err
+	}
+
+	startBlock, err := r.client.Eth().BlockNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	tracker := newBlockTracker(r.client, startBlock+1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go tracker.run(ctx, blockPollInterval)
+
+	r.logf("sending the transactions at %d TPS for %s\n", r.config.TPS, r.config.Duration)
+	r.send()
+
+	r.logf("waiting for the inclusion of %d transactions\n", len(r.sent))
+
+	timer := time.NewTimer(r.config.InclusionTimeout)
+	defer timer.Stop()
+
+wait:
+	for tracker.countIncluded(r.sent) < len(r.sent) {
+		select {
+		case <-timer.C:
+			r.logf("timeout waiting for the inclusion of the transactions\n")
+
+			break wait
+		case <-time.After(blockPollInterval):
+		}
+	}
+
+	cancel()
+
+	result := &Result{
+		Mode:       r.config.Mode,
+		TargetTPS:  r.config.TPS,
+		Duration:   r.config.Duration,
+		Sent:       uint64(len(r.sent)),
+		SendFailed: atomic.LoadUint64(&r.sendFailed),
+	}
+
+	if lastErr, ok := r.lastSendError.Load().(string); ok {
+		result.LastSendError = lastErr
+	}
+
+	tracker.fillResult(result, r.sent)
+
+	return result, nil
+}
+
+// setup creates and funds the virtual users, prepares the workload
+// and resolves the gas and the fees of the transactions
+func (r *Runner) setup(txsPerVU uint64) error {
+	var err error
+
+	if r.chainID, err = r.client.Eth().ChainID(); err != nil {
+		return err
+	}
+
+	r.signer = wallet.NewEIP155Signer(r.chainID.Uint64())
+
+	if r.template, err = r.getFees(); err != nil {
+		return err
+	}
+
+	r.vus = make([]*virtualUser, r.config.VUs)
+
+	for i := range r.vus {
+		key, err := wallet.GenerateKey()
+		if err != nil {
+			return err
+		}
+
+		r.vus[i] = &virtualUser{key: key}
+	}
+
+	r.logf("preparing the %s workload\n", r.config.Mode)
+
+	if err := r.workload.setup(r.relayer, r.config.SenderKey, r.vus, txsPerVU); err != nil {
+		return err
+	}
+
+	// the gas is estimated on the transaction of the sender, since the virtual users aren't funded yet
+	txn := r.workload.newTxn(r.vus[0])
+	txn.From = r.config.SenderKey.Address()
+
+	if r.template.Gas, err = r.client.Eth().EstimateGas(txrelayer.ConvertTxnToCallMsg(txn)); err != nil {
+		return fmt.Errorf("failed to estimate gas: %w", err)
+	}
+
+	if txn.To == nil || len(txn.Input) > 0 {
+		r.template.Gas += r.template.Gas * gasLimitIncreasePercentage / 100
+	}
+
+	r.logf("funding %d virtual users\n", r.config.VUs)
+
+	return r.fundVUs(txsPerVU)
+}
+
+// getFees returns the transaction template with the fees of the current block increased by feeIncreasePercentage
+func (r *Runner) getFees() (*ethgo.Transaction, error) {
+	if !r.config.DynamicTxs {
+		gasPrice, err := r.client.Eth().GasPrice()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get gas price: %w", err)
+		}
+
+		return &ethgo.Transaction{
+			Type:     ethgo.TransactionLegacy,
+			GasPrice: gasPrice + gasPrice*feeIncreasePercentage/100,
+		}, nil
+	}
+
+	maxPriorityFee, err := r.client.Eth().MaxPriorityFeePerGas()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get max priority fee per gas: %w", err)
+	}
+
+	feeHist, err := r.client.Eth().FeeHistory(1, ethgo.Latest, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fee history: %w", err)
+	}
+
+	maxFeePerGas := new(big.Int).Add(feeHist.BaseFee[len(feeHist.BaseFee)-1], maxPriorityFee)
+	maxFeePerGas.Add(maxFeePerGas, new(big.Int).Div(
+		new(big.Int).Mul(maxFeePerGas, big.NewInt(feeIncreasePercentage)), big.NewInt(100)))
+
+	return &ethgo.Transaction{
+		Type:                 ethgo.TransactionDynamicFee,
+		ChainID:              r.chainID,
+		MaxPriorityFeePerGas: maxPriorityFee,
+		MaxFeePerGas:         maxFeePerGas,
+	}, nil
+}
+
+// fundVUs sends the virtual users enough native tokens to pay for their transactions
+func (r *Runner) fundVUs(txsPerVU uint64) error {
+	feePerGas := new(big.Int).SetUint64(r.template.GasPrice)
+	if r.template.Type == ethgo.TransactionDynamicFee {
+		feePerGas = r.template.MaxFeePerGas
+	}
+
+	// each transaction costs its fee and at most the single token transferred by the EOA mode
+	txCost := new(big.Int).Mul(feePerGas, new(big.Int).SetUint64(r.template.Gas))
+	txCost.Add(txCost, big.NewInt(1))
+
+	amount := new(big.Int).Mul(txCost, new(big.Int).SetUint64(txsPerVU))
+
+	g := errgroup.Group{}
+
+	for _, vu := range r.vus {
+		to := vu.key.Address()
+
+		g.Go(func() error {
+			receipt, err := r.relayer.SendTransaction(&ethgo.Transaction{To: &to, Value: amount}, r.config.SenderKey)
+			if err != nil {
+				return fmt.Errorf("failed to fund virtual user %s: %w", to, err)
+			}
+
+			if receipt.Status != uint64(types.ReceiptSuccess) {
+				return fmt.Errorf("failed to fund virtual user %s, transaction %s reverted", to, receipt.TransactionHash)
+			}
+
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
+
+// send sends the transactions at the target TPS for the duration of the load test,
+// the virtual users sending them in turns
+func (r *Runner) send() {
+	var wg sync.WaitGroup
+
+	queues := make([]chan struct{}, len(r.vus))
+
+	for i, vu := range r.vus {
+		queues[i] = make(chan struct{}, r.config.TPS)
+
+		wg.Add(1)
+
+		go func(vu *virtualUser, queue <-chan struct{}) {
+			defer wg.Done()
+
+			for range queue {
+				r.sendTxn(vu)
+			}
+		}(vu, queues[i])
+	}
+
+	ticker := time.NewTicker(time.Second / time.Duration(r.config.TPS))
+	defer ticker.Stop()
+
+	deadline := time.NewTimer(r.config.Duration)
+	defer deadline.Stop()
+
+send:
+	for i := 0; ; i++ {
+		select {
+		case <-ticker.C:
+			queues[i%len(queues)] <- struct{}{}
+		case <-deadline.C:
+			break send
+		}
+	}
+
+	for _, queue := range queues {
+		close(queue)
+	}
+
+	wg.Wait()
+}
+
+// sendTxn signs and sends the transaction of the virtual user, recording the time it is sent at
+func (r *Runner) sendTxn(vu *virtualUser) {
+	txn := r.workload.newTxn(vu)
+	txn.Type = r.template.Type
+	txn.ChainID = r.template.ChainID
+	txn.Gas = r.template.Gas
+	txn.GasPrice = r.template.GasPrice
+	txn.MaxPriorityFeePerGas = r.template.MaxPriorityFeePerGas
+	txn.MaxFeePerGas = r.template.MaxFeePerGas
+	txn.From = vu.key.Address()
+	txn.Nonce = vu.nonce
+
+	sentAt := time.Now()
+
+	hash, err := r.sendRawTxn(txn, vu.key)
+	if err != nil {
+		atomic.AddUint64(&r.sendFailed, 1)
+		r.lastSendError.Store(err.Error())
+
+		return
+	}
+
+	vu.nonce++
+
+	r.sentLock.Lock()
+	r.sent[hash] = sentAt
+	r.sentLock.Unlock()
+}
+
+func (r *Runner) sendRawTxn(txn *ethgo.Transaction, key ethgo.Key) (ethgo.Hash, error) {
+	signedTxn, err := r.signer.SignTx(txn, key)
+	if err != nil {
+		return ethgo.ZeroHash, err
+	}
+
+	data, err := signedTxn.MarshalRLPTo(nil)
+	if err != nil {
+		return ethgo.ZeroHash, err
+	}
+
+	return r.client.Eth().SendRawTransaction(data)
+}
+
+func (r *Runner) logf(format string, args ...interface{}) {
+	if r.config.Writer != nil {
+		_, _ = fmt.Fprintf(r.config.Writer, format, args...)
+	}
+}
@@ -0,0 +1,224 @@
+package runner
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc"
+)
+
+// Result is the result of the load test
+type Result struct {
+	Mode      Mode          `json:"mode"`
+	TargetTPS uint64        `json:"targetTps"`
+	Duration  time.Duration `json:"duration"`
+
+	Sent       uint64 `json:"sent"`
+	SendFailed uint64 `json:"sendFailed"`
+	Included   uint64 `json:"included"`
+	// LastSendError is the last error of sending the transaction, if any
+	LastSendError string `json:"lastSendError,omitempty"`
+
+	// AchievedTPS is the number of the included transactions per second,
+	// from the first transaction sent until the block of the last included one is observed
+	AchievedTPS float64 `json:"achievedTps"`
+	// LatencyP50 and LatencyP99 are the percentiles of the time from sending the transaction
+	// until its block is observed
+	LatencyP50 time.Duration `json:"latencyP50"`
+	LatencyP99 time.Duration `json:"latencyP99"`
+
+	// FirstBlock and LastBlock are the blocks including the first and the last transactions of the load test
+	FirstBlock uint64 `json:"firstBlock"`
+	LastBlock  uint64 `json:"lastBlock"`
+	// GasUtilization is the percentage of the gas used by the blocks from the first to the last one
+	// out of their gas limit
+	GasUtilization float64 `json:"gasUtilization"`
+}
+
+// trackedBlock is the block observed during the load test
+type trackedBlock struct {
+	number     uint64
+	gasUsed    uint64
+	gasLimit   uint64
+	txHashes   []ethgo.Hash
+	observedAt time.Time
+}
+
+// blockTracker polls the new blocks, recording the time they are observed at
+type blockTracker struct {
+	client *jsonrpc.Client
+	next   uint64
+
+	lock   sync.Mutex
+	blocks []*trackedBlock
+	// included are the times the blocks of the transactions are observed at
+	included map[ethgo.Hash]time.Time
+}
+
+func newBlockTracker(client *jsonrpc.Client, fromBlock uint64) *blockTracker {
+	return &blockTracker{
+		client:   client,
+		next:     fromBlock,
+		included: map[ethgo.Hash]time.Time{},
+	}
+}
+
+// run polls the new blocks until the context is done
+func (b *blockTracker) run(ctx context.Context, pollInterval time.Duration) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// the failed poll is retried on the next tick
+			_ = b.poll()
+		}
+	}
+}
+
+func (b *blockTracker) poll() error {
+	latest, err := b.client.Eth().BlockNumber()
+	if err != nil {
+		return err
+	}
+
+	for ; b.next <= latest; b.next++ {
+		block, err := b.client.Eth().GetBlockByNumber(ethgo.BlockNumber(b.next), false)
+		if err != nil {
+			return err
+		}
+
+		b.addBlock(&trackedBlock{
+			number:     block.Number,
+			gasUsed:    block.GasUsed,
+			gasLimit:   block.GasLimit,
+			txHashes:   block.TransactionsHashes,
+			observedAt: time.Now(),
+		})
+	}
+
+	return nil
+}
+
+func (b *blockTracker) addBlock(block *trackedBlock) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.blocks = append(b.blocks, block)
+
+	for _, hash := range block.txHashes {
+		b.included[hash] = block.observedAt
+	}
+}
+
+// countIncluded returns the number of the given transactions included in the observed blocks
+func (b *blockTracker) countIncluded(sent map[ethgo.Hash]time.Time) int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	count := 0
+
+	for hash := range sent {
+		if _, ok := b.included[hash]; ok {
+			count++
+		}
+	}
+
+	return count
+}
+
+// fillResult computes the inclusion and the gas statistics of the sent transactions
+func (b *blockTracker) fillResult(result *Result, sent map[ethgo.Hash]time.Time) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	var (
+		latencies          = make([]time.Duration, 0, len(sent))
+		firstSent, lastInc time.Time
+	)
+
+	for hash, sentAt := range sent {
+		if firstSent.IsZero() || sentAt.Before(firstSent) {
+			firstSent = sentAt
+		}
+
+		includedAt, ok := b.included[hash]
+		if !ok {
+			continue
+		}
+
+		if includedAt.After(lastInc) {
+			lastInc = includedAt
+		}
+
+		latencies = append(latencies, includedAt.Sub(sentAt))
+	}
+
+	result.Included = uint64(len(latencies))
+	if result.Included == 0 {
+		return
+	}
+
+	if elapsed := lastInc.Sub(firstSent).Seconds(); elapsed > 0 {
+		result.AchievedTPS = float64(result.Included) / elapsed
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	result.LatencyP50 = percentile(latencies, 50)
+	result.LatencyP99 = percentile(latencies, 99)
+
+	var gasUsed, gasLimit uint64
+
+	for _, block := range b.blocks {
+		includesSent := false
+
+		for _, hash := range block.txHashes {
+			if _, ok := sent[hash]; ok {
+				includesSent = true
+
+				break
+			}
+		}
+
+		if includesSent {
+			if result.FirstBlock == 0 {
+				result.FirstBlock = block.number
+			}
+
+			result.LastBlock = block.number
+		}
+	}
+
+	for _, block := range b.blocks {
+		if block.number >= result.FirstBlock && block.number <= result.LastBlock {
+			gasUsed += block.gasUsed
+			gasLimit += block.gasLimit
+		}
+	}
+
+	if gasLimit > 0 {
+		result.GasUtilization = float64(gasUsed) * 100 / float64(gasLimit)
+	}
+}
+
+// percentile returns the nearest-rank percentile of the sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
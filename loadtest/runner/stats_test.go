@@ -0,0 +1,72 @@
+package runner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+)
+
+func TestBlockTracker_FillResult(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+
+	sent := map[ethgo.Hash]time.Time{
+		{0x1}: start,
+		{0x2}: start.Add(100 * time.Millisecond),
+		{0x3}: start.Add(200 * time.Millisecond),
+		{0x4}: start.Add(300 * time.Millisecond),
+	}
+
+	tracker := newBlockTracker(nil, 1)
+	tracker.addBlock(&trackedBlock{
+		number: 1, gasUsed: 10, gasLimit: 100, txHashes: []ethgo.Hash{{0x10}}, observedAt: start,
+	})
+	tracker.addBlock(&trackedBlock{
+		number: 2, gasUsed: 50, gasLimit: 100, txHashes: []ethgo.Hash{{0x1}, {0x2}},
+		observedAt: start.Add(time.Second),
+	})
+	tracker.addBlock(&trackedBlock{
+		number: 3, gasUsed: 0, gasLimit: 100, observedAt: start.Add(2 * time.Second),
+	})
+	tracker.addBlock(&trackedBlock{
+		number: 4, gasUsed: 70, gasLimit: 100, txHashes: []ethgo.Hash{{0x3}}, observedAt: start.Add(3 * time.Second),
+	})
+
+	require.Equal(t, 3, tracker.countIncluded(sent))
+
+	result := &Result{}
+	tracker.fillResult(result, sent)
+
+	require.Equal(t, uint64(3), result.Included)
+	require.Equal(t, 1.0, result.AchievedTPS)
+	require.Equal(t, time.Second, result.LatencyP50)
+	require.Equal(t, 2800*time.Millisecond, result.LatencyP99)
+	require.Equal(t, uint64(2), result.FirstBlock)
+	require.Equal(t, uint64(4), result.LastBlock)
+	// the empty block between the blocks of the load test lowers the utilization
+	require.InDelta(t, 40.0, result.GasUtilization, 0.001)
+
+	// nothing is computed without the included transactions
+	result = &Result{}
+	tracker.fillResult(result, map[ethgo.Hash]time.Time{{0x5}: start})
+
+	require.Equal(t, &Result{}, result)
+}
+
+func TestPercentile(t *testing.T) {
+	t.Parallel()
+
+	latencies := make([]time.Duration, 100)
+	for i := range latencies {
+		latencies[i] = time.Duration(i+1) * time.Millisecond
+	}
+
+	require.Equal(t, 50*time.Millisecond, percentile(latencies, 50))
+	require.Equal(t, 99*time.Millisecond, percentile(latencies, 99))
+	require.Equal(t, 100*time.Millisecond, percentile(latencies, 100))
+	require.Equal(t, 1*time.Millisecond, percentile(latencies[:1], 99))
+	require.Equal(t, time.Duration(0), percentile(nil, 50))
+}
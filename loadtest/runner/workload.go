@@ -0,0 +1,136 @@
+package runner
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/umbracle/ethgo"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
+	"github.com/0xPolygon/polygon-edge/txrelayer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Mode is the kind of the transactions sent by the load test
+type Mode string
+
+const (
+	// EOAMode sends the native token transfers between the externally owned accounts
+	EOAMode Mode = "eoa"
+	// ERC20Mode sends the transfers of the ERC-20 token deployed by the load test
+	ERC20Mode Mode = "erc20"
+	// DeployMode sends the contract deployments
+	DeployMode Mode = "deploy"
+)
+
+// workload builds the transactions of the load test mode
+type workload interface {
+	// setup prepares the workload before the virtual users start sending the transactions
+	setup(relayer txrelayer.TxRelayer, sender ethgo.Key, vus []*virtualUser, txsPerVU uint64) error
+	// newTxn returns the unsigned transaction of the virtual user
+	newTxn(vu *virtualUser) *ethgo.Transaction
+}
+
+func newWorkload(mode Mode, receiver ethgo.Address) (workload, error) {
+	switch mode {
+	case EOAMode:
+		return &eoaWorkload{receiver: receiver}, nil
+	case ERC20Mode:
+		return &erc20Workload{receiver: receiver}, nil
+	case DeployMode:
+		return &deployWorkload{}, nil
+	default:
+		return nil, fmt.Errorf("unknown load test mode: %s", mode)
+	}
+}
+
+// eoaWorkload transfers the native token to the receiver
+type eoaWorkload struct {
+	receiver ethgo.Address
+}
+
+func (w *eoaWorkload) setup(txrelayer.TxRelayer, ethgo.Key, []*virtualUser, uint64) error {
+	return nil
+}
+
+func (w *eoaWorkload) newTxn(*virtualUser) *ethgo.Transaction {
+	receiver := w.receiver
+
+	return &ethgo.Transaction{To: &receiver, Value: big.NewInt(1)}
+}
+
+// erc20Workload transfers the ERC-20 token, deployed and minted to the virtual users on the setup, to the receiver.
+// The token is minted to the sender as well, so the gas of the transfer can be estimated on its behalf
+type erc20Workload struct {
+	receiver ethgo.Address
+	token    ethgo.Address
+	input    []byte
+}
+
+func (w *erc20Workload) setup(relayer txrelayer.TxRelayer, sender ethgo.Key,
+	vus []*virtualUser, txsPerVU uint64) error {
+	receipt, err := relayer.SendTransaction(&ethgo.Transaction{Input: contractsapi.RootERC20.Bytecode}, sender)
+	if err != nil {
+		return fmt.Errorf("failed to deploy the ERC-20 token: %w", err)
+	}
+
+	if receipt.Status != uint64(types.ReceiptSuccess) {
+		return fmt.Errorf("failed to deploy the ERC-20 token, transaction %s reverted", receipt.TransactionHash)
+	}
+
+	w.token = receipt.ContractAddress
+
+	if w.input, err = contractsapi.RootERC20.Abi.Methods["transfer"].Encode(
+		[]interface{}{w.receiver, big.NewInt(1)}); err != nil {
+		return err
+	}
+
+	mintMethod := contractsapi.RootERC20.Abi.Methods["mint"]
+
+	holders := make([]ethgo.Address, 0, len(vus)+1)
+	holders = append(holders, sender.Address())
+
+	for _, vu := range vus {
+		holders = append(holders, vu.key.Address())
+	}
+
+	g := errgroup.Group{}
+
+	for _, holder := range holders {
+		input, err := mintMethod.Encode([]interface{}{holder, new(big.Int).SetUint64(txsPerVU)})
+		if err != nil {
+			return err
+		}
+
+		g.Go(func() error {
+			receipt, err := relayer.SendTransaction(&ethgo.Transaction{To: &w.token, Input: input}, sender)
+			if err != nil {
+				return fmt.Errorf("failed to mint the ERC-20 token: %w", err)
+			}
+
+			if receipt.Status != uint64(types.ReceiptSuccess) {
+				return fmt.Errorf("failed to mint the ERC-20 token, transaction %s reverted", receipt.TransactionHash)
+			}
+
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
+
+func (w *erc20Workload) newTxn(*virtualUser) *ethgo.Transaction {
+	return &ethgo.Transaction{To: &w.token, Input: w.input}
+}
+
+// deployWorkload deploys the simple test contract
+type deployWorkload struct{}
+
+func (w *deployWorkload) setup(txrelayer.TxRelayer, ethgo.Key, []*virtualUser, uint64) error {
+	return nil
+}
+
+func (w *deployWorkload) newTxn(*virtualUser) *ethgo.Transaction {
+	return &ethgo.Transaction{Input: contractsapi.TestSimple.Bytecode}
+}
@@ -1,12 +1,16 @@
 package network
 
 import (
+	"sync"
 	"sync/atomic"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
 type bootnodesWrapper struct {
+	// lock protects the bootnodes, which are extended by the DNS seeds
+	lock sync.RWMutex
+
 	// bootnodeArr is the array that contains all the bootnode addresses
 	bootnodeArr []*peer.AddrInfo
 
@@ -20,6 +24,9 @@ type bootnodesWrapper struct {
 
 // isBootnode checks if the node ID belongs to a set bootnode
 func (bw *bootnodesWrapper) isBootnode(nodeID peer.ID) bool {
+	bw.lock.RLock()
+	defer bw.lock.RUnlock()
+
 	_, ok := bw.bootnodesMap[nodeID]
 
 	return ok
@@ -37,14 +44,42 @@ func (bw *bootnodesWrapper) increaseBootnodeConnCount(delta int64) {
 
 // getBootnodes gets all the bootnodes
 func (bw *bootnodesWrapper) getBootnodes() []*peer.AddrInfo {
+	bw.lock.RLock()
+	defer bw.lock.RUnlock()
+
 	return bw.bootnodeArr
 }
 
 // getBootnodeCount returns the number of set bootnodes
 func (bw *bootnodesWrapper) getBootnodeCount() int {
+	bw.lock.RLock()
+	defer bw.lock.RUnlock()
+
 	return len(bw.bootnodeArr)
 }
 
+// addBootnodes adds the bootnodes which are not set yet, and returns them [Thread safe]
+func (bw *bootnodesWrapper) addBootnodes(nodes []*peer.AddrInfo) []*peer.AddrInfo {
+	bw.lock.Lock()
+	defer bw.lock.Unlock()
+
+	added := make([]*peer.AddrInfo, 0, len(nodes))
+
+	for _, node := range nodes {
+		if _, ok := bw.bootnodesMap[node.ID]; ok {
+			continue
+		}
+
+		// the array is replaced rather than appended to, as it is read without the lock by the callers
+		bw.bootnodeArr = append(bw.bootnodeArr[:len(bw.bootnodeArr):len(bw.bootnodeArr)], node)
+		bw.bootnodesMap[node.ID] = node
+
+		added = append(added, node)
+	}
+
+	return added
+}
+
 // hasBootnodes checks if any bootnodes are set [Thread safe]
 func (bw *bootnodesWrapper) hasBootnodes() bool {
 	return bw.getBootnodeCount() > 0
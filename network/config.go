@@ -13,6 +13,7 @@ type Config struct {
 	NoDiscover       bool                   // flag indicating if the discovery mechanism should be turned on
 	Addr             *net.TCPAddr           // the base address
 	NatAddr          net.IP                 // the NAT address
+	NATPortMap       bool                   // flag indicating if the port is mapped on the gateway over UPnP or NAT-PMP
 	DNS              multiaddr.Multiaddr    // the DNS address
 	DataDir          string                 // the base data directory for the client
 	MaxPeers         int64                  // the maximum number of peer connections
@@ -20,6 +21,11 @@ type Config struct {
 	MaxOutboundPeers int64                  // the maximum number of outbound peer connections
 	Chain            *chain.Chain           // the reference to the chain configuration
 	SecretsManager   secrets.SecretsManager // the secrets manager used for key storage
+	RateLimits       *RateLimits            // the inbound limits per protocol class
+	StaticPeers      []string               // the multiaddrs of the peers kept connected
+	TrustedPeers     []string               // the multiaddrs of the peers accepted without free slots
+	DNSSeeds         []string               // the URLs of the signed DNS trees or the domains of the TXT seed lists
+	PeerFilters      *PeerFilters           // the CIDR lists the peer connections are filtered by
 }
 
 func DefaultConfig() *Config {
@@ -36,5 +42,7 @@ func DefaultConfig() *Config {
 		// The default ratio for outbound / inbound connections is 0.25
 		MaxInboundPeers:  32,
 		MaxOutboundPeers: 8,
+		// The inbound messages are limited per peer
+		RateLimits: DefaultRateLimits(),
 	}
 }
@@ -23,7 +23,7 @@ type ConnectionInfo struct {
 	pendingInboundConnectionCount  int64
 	pendingOutboundConnectionCount int64
 
-	// CONNECTION LIMITS //
+	// CONNECTION LIMITS (UPDATED ON THE CONFIG RELOAD) //
 	maxInboundConnectionCount  int64
 	maxOutboundConnectionCount int64
 }
@@ -97,16 +97,20 @@ func (ci *ConnectionInfo) HasFreeInboundConn() bool {
 	return ci.GetInboundConnCount()+ci.GetPendingInboundConnCount() < ci.maxInboundConnCount()
 }
 
-// maxOutboundConnCount returns the maximum number of outbound connections.
-// [Thread safe] since this value is unchanged during runtime
+// maxOutboundConnCount returns the maximum number of outbound connections [Thread safe]
 func (ci *ConnectionInfo) maxOutboundConnCount() int64 {
-	return ci.maxOutboundConnectionCount
+	return atomic.LoadInt64(&ci.maxOutboundConnectionCount)
 }
 
-// maxInboundConnCount returns the minimum number of outbound connections
-// [Thread safe] since this value is unchanged during runtime
+// maxInboundConnCount returns the maximum number of inbound connections [Thread safe]
 func (ci *ConnectionInfo) maxInboundConnCount() int64 {
-	return ci.maxInboundConnectionCount
+	return atomic.LoadInt64(&ci.maxInboundConnectionCount)
+}
+
+// setMaxConnCounts sets the maximum numbers of connections in both directions [Thread safe]
+func (ci *ConnectionInfo) setMaxConnCounts(maxInboundConnCount, maxOutboundConnCount int64) {
+	atomic.StoreInt64(&ci.maxInboundConnectionCount, maxInboundConnCount)
+	atomic.StoreInt64(&ci.maxOutboundConnectionCount, maxOutboundConnCount)
 }
 
 // UpdateConnCountByDirection updates the connection count by delta
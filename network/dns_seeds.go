@@ -0,0 +1,63 @@
+package network
+
+import (
+	"context"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// dnsSeedsRefreshInterval is the interval of re-resolving the DNS seeds for the new bootnodes
+	dnsSeedsRefreshInterval = 30 * time.Minute
+
+	// dnsSeedsTimeout bounds a single resolving of all the DNS seeds
+	dnsSeedsTimeout = 30 * time.Second
+)
+
+// resolveDNSSeeds resolves the DNS seeds and adds their peers to the bootnodes,
+// the newly added bootnodes are returned
+func (s *Server) resolveDNSSeeds() []*peer.AddrInfo {
+	ctx, cancel := context.WithTimeout(context.Background(), dnsSeedsTimeout)
+	defer cancel()
+
+	peers, err := s.dnsSeeds.Resolve(ctx, s.config.DNSSeeds)
+	if err != nil {
+		// the peers of the seeds resolved successfully are still used
+		s.logger.Warn("Unable to resolve DNS seeds", "err", err)
+	}
+
+	nodes := make([]*peer.AddrInfo, 0, len(peers))
+
+	for _, info := range peers {
+		if info.ID != s.host.ID() {
+			nodes = append(nodes, info)
+		}
+	}
+
+	added := s.bootnodes.addBootnodes(nodes)
+	if len(added) > 0 {
+		s.logger.Info("Bootnodes resolved from DNS seeds", "added", len(added), "total", s.bootnodes.getBootnodeCount())
+	}
+
+	return added
+}
+
+// refreshDNSSeeds periodically resolves the DNS seeds,
+// and adds the new bootnodes to the routing table of the discovery service
+func (s *Server) refreshDNSSeeds() {
+	ticker := time.NewTicker(dnsSeedsRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-s.closeCh:
+			return
+		}
+
+		if added := s.resolveDNSSeeds(); len(added) > 0 {
+			s.discovery.ConnectToBootnodes(added)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package network
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/0xPolygon/polygon-edge/network/dnsdisc"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedsResolver serves the TXT records of the seed lists
+type seedsResolver struct {
+	lock    sync.Mutex
+	records map[string][]string
+}
+
+func (r *seedsResolver) LookupTXT(_ context.Context, domain string) ([]string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.records[domain], nil
+}
+
+func (r *seedsResolver) add(domain, txt string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.records[domain] = append(r.records[domain], txt)
+}
+
+func TestDNSSeeds_Bootnodes(t *testing.T) {
+	t.Parallel()
+
+	seed, err := CreateServer(nil)
+	require.NoError(t, err)
+
+	seedAddr, err := common.AddrInfoToString(seed.AddrInfo())
+	require.NoError(t, err)
+
+	resolver := &seedsResolver{records: make(map[string][]string)}
+	resolver.add("seeds.example.org", "libp2p:"+seedAddr)
+
+	// the server has no bootnodes in the genesis, they are resolved from the DNS seeds
+	server, err := CreateServer(&CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.NoDiscover = false
+			c.DNSSeeds = []string{"seeds.example.org"}
+		},
+		ServerCallback: func(server *Server) {
+			server.config.Chain.Bootnodes = nil
+			server.dnsSeeds = dnsdisc.NewClient(hclog.NewNullLogger(), resolver)
+		},
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		closeTestServers(t, []*Server{seed, server})
+	})
+
+	assert.Equal(t, []string{seedAddr}, bootnodeAddrs(t, server))
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer cancel()
+
+	_, err = WaitUntilPeerConnectsTo(ctx, server, seed.AddrInfo().ID)
+	require.NoError(t, err)
+
+	// the refresh adds only the new seeds
+	newAddr := tests.GenerateTestMultiAddr(t).String()
+	resolver.add("seeds.example.org", "libp2p:"+newAddr)
+
+	added := server.resolveDNSSeeds()
+	require.Len(t, added, 1)
+	assert.Equal(t, []string{seedAddr, newAddr}, bootnodeAddrs(t, server))
+}
+
+// bootnodeAddrs returns the multiaddrs of the bootnodes of the server
+func bootnodeAddrs(t *testing.T, server *Server) []string {
+	t.Helper()
+
+	addrs := make([]string, 0)
+
+	for _, info := range server.bootnodes.getBootnodes() {
+		addr, err := common.AddrInfoToString(info)
+		require.NoError(t, err)
+
+		addrs = append(addrs, addr)
+	}
+
+	return addrs
+}
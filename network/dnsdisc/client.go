@@ -0,0 +1,315 @@
+package dnsdisc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// maxTreeEntries bounds the number of the entries resolved for a single tree
+	maxTreeEntries = 2000
+
+	// maxLinkDepth bounds the chain of the links followed from a configured tree
+	maxLinkDepth = 4
+)
+
+var (
+	errNoRoot         = errors.New("no root entry found")
+	errStaleRoot      = errors.New("root sequence number is lower than the previously seen one")
+	errTreeTooLarge   = errors.New("tree has too many entries")
+	errUnexpectedType = errors.New("unexpected entry type in the subtree")
+)
+
+// Resolver looks up the TXT records of a domain
+type Resolver interface {
+	LookupTXT(ctx context.Context, domain string) ([]string, error)
+}
+
+// syncedTree is the last verified state of a tree
+type syncedTree struct {
+	root    *rootEntry
+	entries map[string]fmt.Stringer
+	peers   []*peer.AddrInfo
+	links   []*linkEntry
+}
+
+// Client resolves the peers of the signed trees and of the plain TXT seed lists.
+// The trees are re-resolved only if their root changes
+type Client struct {
+	logger   hclog.Logger
+	resolver Resolver
+
+	lock  sync.Mutex
+	trees map[string]*syncedTree // URL -> last verified state
+}
+
+// NewClient creates the client resolving over the given resolver
+func NewClient(logger hclog.Logger, resolver Resolver) *Client {
+	return &Client{
+		logger:   logger,
+		resolver: resolver,
+		trees:    make(map[string]*syncedTree),
+	}
+}
+
+// Resolve returns the peers of the given seeds. A seed is either the URL of a signed tree
+// (enrtree://<public key>@<domain>) or the domain whose TXT records are the peers (libp2p:<multiaddr>).
+// The peers of the seeds resolved successfully are returned along with the errors of the others
+func (c *Client) Resolve(ctx context.Context, seeds []string) ([]*peer.AddrInfo, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var (
+		peers   = make([]*peer.AddrInfo, 0)
+		seen    = make(map[peer.ID]struct{})
+		visited = make(map[string]struct{})
+		errs    []error
+	)
+
+	addPeers := func(infos []*peer.AddrInfo) {
+		for _, info := range infos {
+			if _, ok := seen[info.ID]; !ok {
+				seen[info.ID] = struct{}{}
+
+				peers = append(peers, info)
+			}
+		}
+	}
+
+	for _, seed := range seeds {
+		var (
+			infos []*peer.AddrInfo
+			err   error
+		)
+
+		if strings.HasPrefix(seed, linkPrefix) {
+			infos, err = c.resolveTree(ctx, seed, 0, visited)
+		} else {
+			infos, err = c.resolveList(ctx, seed)
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("seed %s: %w", seed, err))
+		}
+
+		addPeers(infos)
+	}
+
+	return peers, errors.Join(errs...)
+}
+
+// resolveList returns the peers of the plain TXT seed list, the other TXT records of the domain are ignored
+func (c *Client) resolveList(ctx context.Context, domain string) ([]*peer.AddrInfo, error) {
+	records, err := c.resolver.LookupTXT(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	peers := make([]*peer.AddrInfo, 0, len(records))
+
+	for _, txt := range records {
+		if !strings.HasPrefix(txt, peerPrefix) {
+			continue
+		}
+
+		e, err := parsePeer(strings.TrimPrefix(txt, peerPrefix))
+		if err != nil {
+			c.logger.Debug("Skipping invalid seed record", "domain", domain, "err", err)
+
+			continue
+		}
+
+		peers = append(peers, e.info)
+	}
+
+	return peers, nil
+}
+
+// resolveTree returns the peers of the tree and of the trees it links to
+func (c *Client) resolveTree(
+	ctx context.Context,
+	url string,
+	depth int,
+	visited map[string]struct{},
+) ([]*peer.AddrInfo, error) {
+	if _, ok := visited[url]; ok || depth > maxLinkDepth {
+		return nil, nil
+	}
+
+	visited[url] = struct{}{}
+
+	link, err := parseLink(url)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := c.syncTree(ctx, url, link)
+	if err != nil {
+		return nil, err
+	}
+
+	peers := tree.peers
+
+	for _, linked := range tree.links {
+		linkedPeers, err := c.resolveTree(ctx, linked.String(), depth+1, visited)
+		if err != nil {
+			// the linked trees are maintained by others, so their failures don't fail the tree
+			c.logger.Debug("Unable to resolve linked tree", "tree", url, "link", linked.String(), "err", err)
+
+			continue
+		}
+
+		peers = append(peers, linkedPeers...)
+	}
+
+	return peers, nil
+}
+
+// syncTree verifies the root of the tree and resolves the entries which are not known yet
+func (c *Client) syncTree(ctx context.Context, url string, link *linkEntry) (*syncedTree, error) {
+	root, err := c.resolveRoot(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+
+	prev := c.trees[url]
+	if prev != nil {
+		if root.seq < prev.root.seq {
+			return nil, fmt.Errorf("%w: %d < %d", errStaleRoot, root.seq, prev.root.seq)
+		}
+
+		if root.peersRoot == prev.root.peersRoot && root.linksRoot == prev.root.linksRoot {
+			prev.root = root
+
+			return prev, nil
+		}
+	} else {
+		prev = &syncedTree{entries: make(map[string]fmt.Stringer)}
+	}
+
+	tree := &syncedTree{
+		root:    root,
+		entries: make(map[string]fmt.Stringer),
+	}
+
+	if err := c.resolveSubtree(ctx, link.domain, root.peersRoot, false, prev.entries, tree); err != nil {
+		return nil, err
+	}
+
+	if err := c.resolveSubtree(ctx, link.domain, root.linksRoot, true, prev.entries, tree); err != nil {
+		return nil, err
+	}
+
+	c.trees[url] = tree
+
+	return tree, nil
+}
+
+// resolveRoot resolves the root entry of the tree and verifies its signature
+func (c *Client) resolveRoot(ctx context.Context, link *linkEntry) (*rootEntry, error) {
+	records, err := c.resolver.LookupTXT(ctx, link.domain)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, txt := range records {
+		if !strings.HasPrefix(txt, rootPrefix) {
+			continue
+		}
+
+		root, err := parseRoot(txt)
+		if err != nil {
+			return nil, err
+		}
+
+		if !root.verify(link.pubkey) {
+			return nil, fmt.Errorf("%w: %s", errInvalidSig, link.domain)
+		}
+
+		return root, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", errNoRoot, link.domain)
+}
+
+// resolveSubtree resolves the entries under the given hash, reusing the entries of the previous sync.
+// The subtree of the peers may contain only the peers, the subtree of the links only the links
+func (c *Client) resolveSubtree(
+	ctx context.Context,
+	domain string,
+	hash string,
+	links bool,
+	known map[string]fmt.Stringer,
+	tree *syncedTree,
+) error {
+	queue := []string{hash}
+	visited := make(map[string]struct{})
+
+	for len(queue) > 0 {
+		hash, queue = queue[0], queue[1:]
+
+		if _, ok := visited[hash]; ok {
+			continue
+		}
+
+		visited[hash] = struct{}{}
+
+		if len(tree.entries) >= maxTreeEntries {
+			return fmt.Errorf("%w: %s", errTreeTooLarge, domain)
+		}
+
+		e, ok := known[hash]
+		if !ok {
+			var err error
+
+			if e, err = c.resolveEntry(ctx, domain, hash); err != nil {
+				return err
+			}
+		}
+
+		tree.entries[hash] = e
+
+		switch e := e.(type) {
+		case *branchEntry:
+			queue = append(queue, e.children...)
+		case *peerEntry:
+			if links {
+				return fmt.Errorf("%w: %s", errUnexpectedType, e.String())
+			}
+
+			tree.peers = append(tree.peers, e.info)
+		case *linkEntry:
+			if !links {
+				return fmt.Errorf("%w: %s", errUnexpectedType, e.String())
+			}
+
+			tree.links = append(tree.links, e)
+		}
+	}
+
+	return nil
+}
+
+// resolveEntry resolves the entry and checks it matches its hash
+func (c *Client) resolveEntry(ctx context.Context, domain, hash string) (fmt.Stringer, error) {
+	name := hash + "." + domain
+
+	records, err := c.resolver.LookupTXT(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, txt := range records {
+		if entryHash(txt) == hash {
+			return parseEntry(txt)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", errHashMismatch, name)
+}
@@ -0,0 +1,212 @@
+package dnsdisc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errNotFound = errors.New("not found")
+
+// mockResolver serves the TXT records from the map and counts the lookups
+type mockResolver struct {
+	lock    sync.Mutex
+	records map[string][]string
+	lookups int
+}
+
+func newMockResolver() *mockResolver {
+	return &mockResolver{records: make(map[string][]string)}
+}
+
+func (r *mockResolver) LookupTXT(_ context.Context, domain string) ([]string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.lookups++
+
+	records, ok := r.records[domain]
+	if !ok {
+		return nil, errNotFound
+	}
+
+	return records, nil
+}
+
+// publish replaces the records of the tree published at the given domain
+func (r *mockResolver) publish(t *testing.T, key *ecdsa.PrivateKey, domain string, seq uint64, peers, links []string) string {
+	t.Helper()
+
+	tree, err := MakeTree(seq, peers, links)
+	require.NoError(t, err)
+
+	url, err := tree.Sign(key, domain)
+	require.NoError(t, err)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for name, txt := range tree.Records(domain) {
+		r.records[name] = []string{txt}
+	}
+
+	return url
+}
+
+// peerIDs returns the IDs of the peers
+func peerIDs(infos []*peer.AddrInfo) []peer.ID {
+	ids := make([]peer.ID, len(infos))
+	for i, info := range infos {
+		ids[i] = info.ID
+	}
+
+	return ids
+}
+
+// addrIDs returns the peer IDs of the multiaddrs
+func addrIDs(t *testing.T, addrs []string) []peer.ID {
+	t.Helper()
+
+	ids := make([]peer.ID, len(addrs))
+
+	for i, addr := range addrs {
+		e, err := parsePeer(addr)
+		require.NoError(t, err)
+
+		ids[i] = e.info.ID
+	}
+
+	return ids
+}
+
+func TestClient_ResolveTree(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	linkKey, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	resolver := newMockResolver()
+	peers := testPeerAddrs(t, 20)
+	linkedPeers := testPeerAddrs(t, 2)
+
+	linkURL := resolver.publish(t, linkKey, "linked.example.org", 1, linkedPeers, nil)
+	url := resolver.publish(t, key, "nodes.example.org", 1, peers, []string{linkURL})
+
+	client := NewClient(hclog.NewNullLogger(), resolver)
+
+	resolved, err := client.Resolve(context.Background(), []string{url})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, append(addrIDs(t, peers), addrIDs(t, linkedPeers)...), peerIDs(resolved))
+
+	// the unchanged trees are resolved by their roots only
+	lookups := resolver.lookups
+
+	_, err = client.Resolve(context.Background(), []string{url})
+	require.NoError(t, err)
+	assert.Equal(t, lookups+2, resolver.lookups)
+
+	// the updated tree is resolved again
+	resolver.publish(t, key, "nodes.example.org", 2, peers[:5], []string{linkURL})
+
+	resolved, err = client.Resolve(context.Background(), []string{url})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, append(addrIDs(t, peers[:5]), addrIDs(t, linkedPeers)...), peerIDs(resolved))
+}
+
+func TestClient_ResolveTree_Invalid(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	otherKey, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	peers := testPeerAddrs(t, 3)
+
+	t.Run("root signed by another key", func(t *testing.T) {
+		t.Parallel()
+
+		resolver := newMockResolver()
+		url := resolver.publish(t, key, "nodes.example.org", 1, peers, nil)
+		resolver.publish(t, otherKey, "nodes.example.org", 2, peers, nil)
+
+		_, err := NewClient(hclog.NewNullLogger(), resolver).Resolve(context.Background(), []string{url})
+		assert.ErrorIs(t, err, errInvalidSig)
+	})
+
+	t.Run("entry not matching its hash", func(t *testing.T) {
+		t.Parallel()
+
+		resolver := newMockResolver()
+		url := resolver.publish(t, key, "nodes.example.org", 1, peers, nil)
+
+		// the entries are replaced by the other peers
+		otherPeers := testPeerAddrs(t, 1)
+
+		for name, records := range resolver.records {
+			if name != "nodes.example.org" && records[0] != "enrtree-branch:" {
+				resolver.records[name] = []string{"libp2p:" + otherPeers[0]}
+			}
+		}
+
+		_, err := NewClient(hclog.NewNullLogger(), resolver).Resolve(context.Background(), []string{url})
+		assert.ErrorIs(t, err, errHashMismatch)
+	})
+
+	t.Run("sequence number rolled back", func(t *testing.T) {
+		t.Parallel()
+
+		resolver := newMockResolver()
+		url := resolver.publish(t, key, "nodes.example.org", 2, peers, nil)
+		client := NewClient(hclog.NewNullLogger(), resolver)
+
+		_, err := client.Resolve(context.Background(), []string{url})
+		require.NoError(t, err)
+
+		resolver.publish(t, key, "nodes.example.org", 1, peers[:1], nil)
+
+		_, err = client.Resolve(context.Background(), []string{url})
+		assert.ErrorIs(t, err, errStaleRoot)
+	})
+}
+
+func TestClient_ResolveList(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	resolver := newMockResolver()
+	listPeers := testPeerAddrs(t, 2)
+	treePeers := testPeerAddrs(t, 2)
+
+	resolver.records["seeds.example.org"] = []string{
+		"libp2p:" + listPeers[0],
+		"libp2p:" + listPeers[1],
+		"v=spf1 -all",
+		"libp2p:/ip4/10.0.0.1/tcp/1478",
+	}
+
+	url := resolver.publish(t, key, "nodes.example.org", 1, append(treePeers, listPeers[0]), nil)
+
+	resolved, err := NewClient(hclog.NewNullLogger(), resolver).Resolve(
+		context.Background(),
+		[]string{"seeds.example.org", url, "missing.example.org"},
+	)
+
+	// the peers of the resolved seeds are returned along with the error of the missing one
+	assert.ErrorIs(t, err, errNotFound)
+	assert.ElementsMatch(t, append(addrIDs(t, listPeers), addrIDs(t, treePeers)...), peerIDs(resolved))
+}
@@ -0,0 +1,300 @@
+package dnsdisc
+
+import (
+	"crypto/ecdsa"
+	"encoding/base32"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// The tree follows the layout of EIP-1459, the leaves are the libp2p multiaddrs instead of the ENRs:
+//
+//	<domain>              enrtree-root:v1 e=<peers root> l=<links root> seq=<seq> sig=<signature>
+//	<hash>.<domain>       enrtree-branch:<hash>,<hash>,...
+//	<hash>.<domain>       libp2p:/ip4/1.2.3.4/tcp/1478/p2p/<peer ID>
+//	<hash>.<domain>       enrtree://<public key>@<domain of the linked tree>
+const (
+	rootPrefix   = "enrtree-root:v1"
+	branchPrefix = "enrtree-branch:"
+	linkPrefix   = "enrtree://"
+	peerPrefix   = "libp2p:"
+
+	// hashLength is the number of the keccak256 bytes identifying the entry
+	hashLength = 16
+
+	// maxBranchChildren keeps the branch entries within the size of a single TXT string
+	maxBranchChildren = 13
+)
+
+var (
+	b32 = base32.StdEncoding.WithPadding(base32.NoPadding)
+	b64 = base64.RawURLEncoding
+
+	errUnknownEntry  = errors.New("unknown entry type")
+	errInvalidRoot   = errors.New("invalid root entry")
+	errInvalidSig    = errors.New("invalid root signature")
+	errInvalidHash   = errors.New("invalid entry hash")
+	errInvalidURL    = errors.New("invalid tree URL")
+	errHashMismatch  = errors.New("no entry matches the hash")
+	errNoPeerAddress = errors.New("entry doesn't contain the peer ID")
+)
+
+// rootEntry is the signed entry referencing the subtrees of the peers and of the links
+type rootEntry struct {
+	peersRoot string
+	linksRoot string
+	seq       uint64
+	sig       []byte
+}
+
+// signedText returns the part of the root entry covered by the signature
+func (e *rootEntry) signedText() string {
+	return fmt.Sprintf("%s e=%s l=%s seq=%d", rootPrefix, e.peersRoot, e.linksRoot, e.seq)
+}
+
+func (e *rootEntry) String() string {
+	return fmt.Sprintf("%s sig=%s", e.signedText(), b64.EncodeToString(e.sig))
+}
+
+// verify checks the root entry is signed by the given key
+func (e *rootEntry) verify(pub *ecdsa.PublicKey) bool {
+	signer, err := crypto.RecoverPubkey(e.sig, crypto.Keccak256([]byte(e.signedText())))
+	if err != nil {
+		return false
+	}
+
+	return signer.X.Cmp(pub.X) == 0 && signer.Y.Cmp(pub.Y) == 0
+}
+
+// branchEntry references the child entries
+type branchEntry struct {
+	children []string
+}
+
+func (e *branchEntry) String() string {
+	return branchPrefix + strings.Join(e.children, ",")
+}
+
+// peerEntry is the leaf holding the address of a peer
+type peerEntry struct {
+	info *peer.AddrInfo
+	addr string
+}
+
+func (e *peerEntry) String() string {
+	return peerPrefix + e.addr
+}
+
+// linkEntry references a tree, which is signed by its own key
+type linkEntry struct {
+	domain string
+	pubkey *ecdsa.PublicKey
+}
+
+func (e *linkEntry) String() string {
+	return linkPrefix + b32.EncodeToString((*btcec.PublicKey)(e.pubkey).SerializeCompressed()) + "@" + e.domain
+}
+
+// entryHash returns the hash under which the entry is published
+func entryHash(txt string) string {
+	return b32.EncodeToString(crypto.Keccak256([]byte(txt))[:hashLength])
+}
+
+// isValidHash checks the hash is the base32 encoding of the entry hash
+func isValidHash(hash string) bool {
+	raw, err := b32.DecodeString(hash)
+
+	return err == nil && len(raw) == hashLength
+}
+
+// parseRoot parses the root entry, the signature isn't verified
+func parseRoot(txt string) (*rootEntry, error) {
+	var (
+		e   rootEntry
+		sig string
+	)
+
+	if _, err := fmt.Sscanf(txt, rootPrefix+" e=%s l=%s seq=%d sig=%s", &e.peersRoot, &e.linksRoot, &e.seq, &sig); err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidRoot, txt)
+	}
+
+	if !isValidHash(e.peersRoot) || !isValidHash(e.linksRoot) {
+		return nil, fmt.Errorf("%w: %s", errInvalidHash, txt)
+	}
+
+	rawSig, err := b64.DecodeString(sig)
+	if err != nil || len(rawSig) != 65 {
+		return nil, fmt.Errorf("%w: %s", errInvalidSig, txt)
+	}
+
+	e.sig = rawSig
+
+	return &e, nil
+}
+
+// parseLink parses the URL of the tree
+func parseLink(url string) (*linkEntry, error) {
+	if !strings.HasPrefix(url, linkPrefix) {
+		return nil, fmt.Errorf("%w: %s", errInvalidURL, url)
+	}
+
+	rawKey, domain, ok := strings.Cut(strings.TrimPrefix(url, linkPrefix), "@")
+	if !ok || domain == "" {
+		return nil, fmt.Errorf("%w: %s", errInvalidURL, url)
+	}
+
+	keyBytes, err := b32.DecodeString(rawKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", errInvalidURL, url, err)
+	}
+
+	pubkey, err := btcec.ParsePubKey(keyBytes, crypto.S256)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", errInvalidURL, url, err)
+	}
+
+	return &linkEntry{domain: domain, pubkey: pubkey.ToECDSA()}, nil
+}
+
+// parsePeer parses the multiaddr of the peer, which has to contain the peer ID
+func parsePeer(rawAddr string) (*peerEntry, error) {
+	addr, err := multiaddr.NewMultiaddr(rawAddr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid peer address %s: %w", rawAddr, err)
+	}
+
+	info, err := peer.AddrInfoFromP2pAddr(addr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errNoPeerAddress, rawAddr)
+	}
+
+	return &peerEntry{info: info, addr: rawAddr}, nil
+}
+
+// parseEntry parses the branch, the peer or the link entry
+func parseEntry(txt string) (fmt.Stringer, error) {
+	switch {
+	case strings.HasPrefix(txt, branchPrefix):
+		children := strings.Split(strings.TrimPrefix(txt, branchPrefix), ",")
+		if len(children) == 1 && children[0] == "" {
+			children = nil
+		}
+
+		for _, child := range children {
+			if !isValidHash(child) {
+				return nil, fmt.Errorf("%w: %s", errInvalidHash, child)
+			}
+		}
+
+		return &branchEntry{children: children}, nil
+	case strings.HasPrefix(txt, peerPrefix):
+		return parsePeer(strings.TrimPrefix(txt, peerPrefix))
+	case strings.HasPrefix(txt, linkPrefix):
+		return parseLink(txt)
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownEntry, txt)
+	}
+}
+
+// Tree is the signed tree of the peer addresses and of the links to other trees
+type Tree struct {
+	root    *rootEntry
+	entries map[string]fmt.Stringer
+}
+
+// MakeTree creates the unsigned tree of the given peer multiaddrs and tree URLs
+func MakeTree(seq uint64, peers []string, links []string) (*Tree, error) {
+	t := &Tree{
+		root:    &rootEntry{seq: seq},
+		entries: make(map[string]fmt.Stringer),
+	}
+
+	peerHashes := make([]string, len(peers))
+
+	for i, rawAddr := range peers {
+		e, err := parsePeer(rawAddr)
+		if err != nil {
+			return nil, err
+		}
+
+		peerHashes[i] = t.add(e)
+	}
+
+	linkHashes := make([]string, len(links))
+
+	for i, url := range links {
+		e, err := parseLink(url)
+		if err != nil {
+			return nil, err
+		}
+
+		linkHashes[i] = t.add(e)
+	}
+
+	t.root.peersRoot = t.addBranches(peerHashes)
+	t.root.linksRoot = t.addBranches(linkHashes)
+
+	return t, nil
+}
+
+// add adds the entry to the tree and returns its hash
+func (t *Tree) add(e fmt.Stringer) string {
+	hash := entryHash(e.String())
+	t.entries[hash] = e
+
+	return hash
+}
+
+// addBranches adds the layers of the branches over the given hashes and returns the hash of the top branch
+func (t *Tree) addBranches(hashes []string) string {
+	sort.Strings(hashes)
+
+	for len(hashes) > maxBranchChildren {
+		parents := make([]string, 0, len(hashes)/maxBranchChildren+1)
+
+		for i := 0; i < len(hashes); i += maxBranchChildren {
+			end := i + maxBranchChildren
+			if end > len(hashes) {
+				end = len(hashes)
+			}
+
+			parents = append(parents, t.add(&branchEntry{children: hashes[i:end]}))
+		}
+
+		hashes = parents
+	}
+
+	return t.add(&branchEntry{children: hashes})
+}
+
+// Sign signs the root of the tree, and returns the URL of the tree published at the given domain
+func (t *Tree) Sign(key *ecdsa.PrivateKey, domain string) (string, error) {
+	sig, err := crypto.Sign(key, crypto.Keccak256([]byte(t.root.signedText())))
+	if err != nil {
+		return "", err
+	}
+
+	t.root.sig = sig
+
+	return (&linkEntry{domain: domain, pubkey: &key.PublicKey}).String(), nil
+}
+
+// Records returns the TXT records of the tree published at the given domain
+func (t *Tree) Records(domain string) map[string]string {
+	records := make(map[string]string, len(t.entries)+1)
+	records[domain] = t.root.String()
+
+	for hash, e := range t.entries {
+		records[hash+"."+domain] = e.String()
+	}
+
+	return records
+}
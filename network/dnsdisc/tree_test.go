@@ -0,0 +1,96 @@
+package dnsdisc
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPeerAddrs returns the multiaddrs of num random peers
+func testPeerAddrs(t *testing.T, num int) []string {
+	t.Helper()
+
+	addrs := make([]string, num)
+	for i := range addrs {
+		addrs[i] = tests.GenerateTestMultiAddr(t).String()
+	}
+
+	return addrs
+}
+
+func TestTree_Records(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	linkKey, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	link := (&linkEntry{domain: "other.example.org", pubkey: &linkKey.PublicKey}).String()
+	peers := testPeerAddrs(t, 3*maxBranchChildren)
+
+	tree, err := MakeTree(3, peers, []string{link})
+	require.NoError(t, err)
+
+	url, err := tree.Sign(key, "nodes.example.org")
+	require.NoError(t, err)
+
+	parsedLink, err := parseLink(url)
+	require.NoError(t, err)
+	assert.Equal(t, "nodes.example.org", parsedLink.domain)
+	assert.True(t, key.PublicKey.Equal(parsedLink.pubkey))
+
+	records := tree.Records("nodes.example.org")
+
+	root, err := parseRoot(records["nodes.example.org"])
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), root.seq)
+	assert.True(t, root.verify(&key.PublicKey))
+	assert.False(t, root.verify(&linkKey.PublicKey))
+
+	// every record is published under its hash and the branches are within the child limit
+	var leaves int
+
+	for name, txt := range records {
+		if name == "nodes.example.org" {
+			continue
+		}
+
+		assert.Equal(t, entryHash(txt)+".nodes.example.org", name)
+
+		e, err := parseEntry(txt)
+		require.NoError(t, err)
+
+		switch e := e.(type) {
+		case *branchEntry:
+			assert.LessOrEqual(t, len(e.children), maxBranchChildren)
+		default:
+			leaves++
+		}
+	}
+
+	assert.Equal(t, len(peers)+1, leaves)
+}
+
+func TestTree_InvalidEntries(t *testing.T) {
+	t.Parallel()
+
+	_, err := MakeTree(1, []string{"/ip4/10.0.0.1/tcp/1478"}, nil)
+	assert.ErrorIs(t, err, errNoPeerAddress)
+
+	_, err = MakeTree(1, nil, []string{"enrtree://invalid"})
+	assert.ErrorIs(t, err, errInvalidURL)
+
+	_, err = parseEntry("enrtree-branch:not-a-hash")
+	assert.ErrorIs(t, err, errInvalidHash)
+
+	_, err = parseEntry("enr:-HW4QOFzoVLaFJnNhbgMoDXPnOvcdVuj7pDpqRvh6BRDO68aVi5ZcjB3vzQRZH2IcLBGHzo8uUN3snqmgTiE56CH3AMBgmlkgnY0iXNlY3AyNTZrMaECC2_24YYkYHEgdzxlSNKQEnHhuNAbNlMlWJxrJxbAFvA")
+	assert.ErrorIs(t, err, errUnknownEntry)
+
+	_, err = parseRoot("enrtree-root:v1 e=AAAA l=BBBB seq=1 sig=CCCC")
+	assert.ErrorIs(t, err, errInvalidHash)
+}
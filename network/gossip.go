@@ -2,6 +2,7 @@ package network
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
 	"reflect"
 	"sync"
@@ -10,6 +11,7 @@ import (
 	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"google.golang.org/protobuf/proto"
 )
@@ -21,11 +23,28 @@ const (
 	subscribeOutputBufferSize = 1024
 )
 
+// TopicOption configures the topic joined by NewTopic
+type TopicOption = pubsub.TopicOpt
+
+// WithContentMessageID identifies the messages of the topic by their content instead of their publisher
+// and sequence number, so the same content published by different peers is delivered and relayed only once
+func WithContentMessageID() TopicOption {
+	return pubsub.WithTopicMessageIdFn(contentMessageID)
+}
+
+func contentMessageID(msg *pb.Message) string {
+	hash := sha256.Sum256(msg.Data)
+
+	return string(hash[:])
+}
+
 type Topic struct {
 	logger hclog.Logger
 
 	topic     *pubsub.Topic
 	typ       reflect.Type
+	reportFn  func(peer.ID, PeerScoreEvent)
+	closeFn   func()
 	closeCh   chan struct{}
 	closed    atomic.Bool
 	waitGroup sync.WaitGroup
@@ -54,6 +73,10 @@ func (t *Topic) Close() {
 		t.topic.Close()
 		t.topic = nil
 	}
+
+	if t.closeFn != nil {
+		t.closeFn()
+	}
 }
 
 func (t *Topic) Publish(obj proto.Message) error {
@@ -76,13 +99,15 @@ func (t *Topic) Subscribe(handler func(obj interface{}, from peer.ID)) error {
 	// Mark topic active.
 	t.closed.Store(false)
 
+	// added before the goroutine starts, so Close can't wait before the loop is counted
+	t.waitGroup.Add(1)
+
 	go t.readLoop(sub, handler)
 
 	return nil
 }
 
 func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{}, from peer.ID)) {
-	t.waitGroup.Add(1)
 	defer t.waitGroup.Done()
 
 	ctx, cancelFn := context.WithCancel(context.Background())
@@ -112,6 +137,10 @@ func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{},
 				t.logger.Error("failed to unmarshal topic", "err", err)
 				metrics.IncrCounter([]string{networkMetrics, "bad_messages"}, float32(1))
 
+				if t.reportFn != nil {
+					t.reportFn(msg.ReceivedFrom, InvalidMessage)
+				}
+
 				return
 			}
 
@@ -122,17 +151,37 @@ func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{},
 	}
 }
 
-func (s *Server) NewTopic(protoID string, obj proto.Message) (*Topic, error) {
-	topic, err := s.ps.Join(protoID)
+func (s *Server) NewTopic(protoID string, obj proto.Message, opts ...TopicOption) (*Topic, error) {
+	topic, err := s.ps.Join(protoID, opts...)
 	if err != nil {
 		return nil, err
 	}
 
+	var closeFn func()
+
+	if s.inboundLimiter.isLimited(protoID) {
+		if err := s.ps.RegisterTopicValidator(
+			protoID,
+			s.topicValidator(protoID),
+			pubsub.WithValidatorInline(true),
+		); err != nil {
+			topic.Close()
+
+			return nil, err
+		}
+
+		closeFn = func() {
+			_ = s.ps.UnregisterTopicValidator(protoID)
+		}
+	}
+
 	tt := &Topic{
-		logger:  s.logger.Named(protoID),
-		topic:   topic,
-		typ:     reflect.TypeOf(obj).Elem(),
-		closeCh: make(chan struct{}),
+		logger:   s.logger.Named(protoID),
+		topic:    topic,
+		typ:      reflect.TypeOf(obj).Elem(),
+		reportFn: s.ReportPeer,
+		closeFn:  closeFn,
+		closeCh:  make(chan struct{}),
 	}
 	tt.closed.Store(false)
 
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	testproto "github.com/0xPolygon/polygon-edge/network/proto"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/require"
 )
@@ -108,3 +109,74 @@ func Test_RepeatedClose(t *testing.T) {
 	topic.Close()
 	topic.Close()
 }
+
+func Test_contentMessageID(t *testing.T) {
+	t.Parallel()
+
+	// the same content published by different peers has the same ID
+	id := contentMessageID(&pb.Message{Data: []byte{0x1}, From: []byte("peer1"), Seqno: []byte{0x1}})
+
+	require.Equal(t, id, contentMessageID(&pb.Message{Data: []byte{0x1}, From: []byte("peer2"), Seqno: []byte{0x2}}))
+	require.NotEqual(t, id, contentMessageID(&pb.Message{Data: []byte{0x2}, From: []byte("peer1"), Seqno: []byte{0x1}}))
+}
+
+func TestGossip_InboundRateLimit(t *testing.T) {
+	const (
+		topicName = "txpool/test"
+		burst     = 3
+	)
+
+	servers, createErr := createServers(2, map[int]*CreateServerParams{
+		1: {
+			ConfigCallback: func(c *Config) {
+				c.RateLimits = &RateLimits{
+					TxPool: &ProtocolLimit{MessagesPerSecond: 0.001, Burst: burst},
+				}
+			},
+		},
+	})
+	require.NoError(t, createErr)
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	require.Empty(t, MeshJoin(servers...))
+
+	publisherTopic, err := servers[0].NewTopic(topicName, &testproto.GenericMessage{})
+	require.NoError(t, err)
+	require.NoError(t, publisherTopic.Subscribe(func(interface{}, peer.ID) {}))
+
+	receiverTopic, err := servers[1].NewTopic(topicName, &testproto.GenericMessage{})
+	require.NoError(t, err)
+
+	receivedCh := make(chan struct{}, 10)
+
+	require.NoError(t, receiverTopic.Subscribe(func(interface{}, peer.ID) {
+		receivedCh <- struct{}{}
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	require.NoError(t, WaitForSubscribers(ctx, servers[0], topicName, 1))
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, publisherTopic.Publish(&testproto.GenericMessage{Message: fmt.Sprintf("%d", i)}))
+	}
+
+	// only the burst is delivered, the rest exceeds the rate
+	received := 0
+
+	for done := false; !done; {
+		select {
+		case <-receivedCh:
+			received++
+		case <-time.After(3 * time.Second):
+			done = true
+		}
+	}
+
+	require.Equal(t, burst, received)
+	require.Equal(t, uint64(10-burst), servers[1].GetPeerScore(servers[0].AddrInfo().ID).RateLimitExceeded)
+}
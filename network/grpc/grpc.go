@@ -5,6 +5,7 @@ import (
 	"errors"
 	"io"
 	"net"
+	"sync/atomic"
 
 	"google.golang.org/grpc/credentials/insecure"
 
@@ -15,21 +16,54 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	"google.golang.org/grpc"
 	grpcPeer "google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/proto"
 )
 
+// InboundLimiter checks the inbound message of the given size sent by the peer,
+// the message is rejected if it returns an error
+type InboundLimiter func(from peer.ID, size int) error
+
 type GrpcStream struct {
 	ctx      context.Context
 	streamCh chan network.Stream
 
 	grpcServer *grpc.Server
+
+	limiter atomic.Value // InboundLimiter
 }
 
 func NewGrpcStream() *GrpcStream {
-	return &GrpcStream{
-		ctx:        context.Background(),
-		streamCh:   make(chan network.Stream),
-		grpcServer: grpc.NewServer(grpc.UnaryInterceptor(interceptor)),
+	g := &GrpcStream{
+		ctx:      context.Background(),
+		streamCh: make(chan network.Stream),
 	}
+
+	g.grpcServer = grpc.NewServer(
+		grpc.UnaryInterceptor(g.interceptor),
+		grpc.StreamInterceptor(g.streamInterceptor),
+	)
+
+	return g
+}
+
+// SetInboundLimiter sets the limiter the inbound messages are checked with
+func (g *GrpcStream) SetInboundLimiter(limiter InboundLimiter) {
+	g.limiter.Store(limiter)
+}
+
+// limitInbound checks the inbound message with the limiter, if any
+func (g *GrpcStream) limitInbound(from peer.ID, msg interface{}) error {
+	limiter, ok := g.limiter.Load().(InboundLimiter)
+	if !ok {
+		return nil
+	}
+
+	size := 0
+	if m, ok := msg.(proto.Message); ok {
+		size = proto.Size(m)
+	}
+
+	return limiter(from, size)
 }
 
 type Context struct {
@@ -39,7 +73,7 @@ type Context struct {
 
 // interceptor is the middleware function that wraps
 // gRPC peer data to custom Polygon Edge structures
-func interceptor(
+func (g *GrpcStream) interceptor(
 	ctx context.Context,
 	req interface{},
 	_ *grpc.UnaryServerInfo,
@@ -50,17 +84,13 @@ func interceptor(
 		return nil, err
 	}
 
-	// Grab the peer info from the connection
-	contextPeer, ok := grpcPeer.FromContext(ctx)
-	if !ok {
-		return nil, errors.New("invalid type assertion for peer context")
+	peerID, err := peerFromContext(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	// The peer address is expected to be wrapped in a custom
-	// structure that contains the PeerID
-	addr, ok := contextPeer.Addr.(*wrapLibp2pAddr)
-	if !ok {
-		return nil, errors.New("invalid type assertion")
+	if err := g.limitInbound(peerID, req); err != nil {
+		return nil, err
 	}
 
 	// Wrap the extracted PeerID and the context
@@ -68,12 +98,61 @@ func interceptor(
 	return handler(
 		&Context{
 			Context: ctx,
-			PeerID:  addr.id,
+			PeerID:  peerID,
 		},
 		req,
 	)
 }
 
+// streamInterceptor checks the messages received on the stream with the limiter
+func (g *GrpcStream) streamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	_ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	peerID, err := peerFromContext(ss.Context())
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &limitedServerStream{ServerStream: ss, peerID: peerID, stream: g})
+}
+
+// limitedServerStream is the server stream whose received messages are checked with the limiter
+type limitedServerStream struct {
+	grpc.ServerStream
+
+	peerID peer.ID
+	stream *GrpcStream
+}
+
+func (s *limitedServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return s.stream.limitInbound(s.peerID, m)
+}
+
+// peerFromContext returns the ID of the peer the gRPC call comes from
+func peerFromContext(ctx context.Context) (peer.ID, error) {
+	// Grab the peer info from the connection
+	contextPeer, ok := grpcPeer.FromContext(ctx)
+	if !ok {
+		return "", errors.New("invalid type assertion for peer context")
+	}
+
+	// The peer address is expected to be wrapped in a custom
+	// structure that contains the PeerID
+	addr, ok := contextPeer.Addr.(*wrapLibp2pAddr)
+	if !ok {
+		return "", errors.New("invalid type assertion")
+	}
+
+	return addr.id, nil
+}
+
 func (g *GrpcStream) Client(stream network.Stream) (*grpc.ClientConn, error) {
 	return WrapClient(stream)
 }
@@ -0,0 +1,128 @@
+package network
+
+import (
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/armon/go-metrics"
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// NAT traversal modes, mirroring the ones of geth
+const (
+	// NATNone doesn't map the port, the listen and the observed addresses are advertised
+	NATNone = "none"
+	// NATAny maps the port on the gateway over UPnP or NAT-PMP, whichever the gateway supports
+	NATAny = "any"
+	// NATUPnP maps the port on the gateway, accepted for the compatibility with geth and behaves as NATAny
+	NATUPnP = "upnp"
+	// NATPMP maps the port on the gateway, accepted for the compatibility with geth and behaves as NATAny
+	NATPMP = "pmp"
+
+	// natExtIPPrefix is the prefix of the mode advertising the given external IP
+	natExtIPPrefix = "extip:"
+)
+
+var errInvalidNAT = errors.New("invalid NAT mode, expected none, any, upnp, pmp or extip:<IP>")
+
+// ParseNAT parses the NAT traversal mode, which is none, any, upnp, pmp, extip:<IP> or just the external IP.
+// It returns if the listen port is mapped on the gateway and the external IP advertised to the peers
+func ParseNAT(rawNAT string) (bool, net.IP, error) {
+	switch strings.ToLower(rawNAT) {
+	case "", NATNone:
+		return false, nil, nil
+	case NATAny, NATUPnP, NATPMP:
+		return true, nil, nil
+	}
+
+	extIP := net.ParseIP(strings.TrimPrefix(rawNAT, natExtIPPrefix))
+	if extIP == nil {
+		return false, nil, errInvalidNAT
+	}
+
+	return false, extIP, nil
+}
+
+// reachableAddrs returns the addresses advertised to the peers. Once AutoNAT finds the node publicly reachable,
+// only the public addresses are advertised, so the peers don't waste their dials on the private ones
+func reachableAddrs(addrs []multiaddr.Multiaddr, reachability network.Reachability) []multiaddr.Multiaddr {
+	if reachability != network.ReachabilityPublic {
+		return addrs
+	}
+
+	public := make([]multiaddr.Multiaddr, 0, len(addrs))
+
+	for _, addr := range addrs {
+		if manet.IsPublicAddr(addr) {
+			public = append(public, addr)
+		}
+	}
+
+	if len(public) == 0 {
+		return addrs
+	}
+
+	return public
+}
+
+// watchNAT follows the reachability of the node determined by AutoNAT, along with the addresses of the node,
+// which change as the port is mapped on the gateway and the external addresses are observed by the peers
+func (s *Server) watchNAT() {
+	sub, err := s.host.EventBus().Subscribe([]interface{}{
+		new(event.EvtLocalReachabilityChanged),
+		new(event.EvtLocalAddressesUpdated),
+	})
+	if err != nil {
+		s.logger.Error("Unable to subscribe to the NAT events", "err", err)
+
+		return
+	}
+
+	defer sub.Close()
+
+	for {
+		select {
+		case <-s.closeCh:
+			return
+		case ev, ok := <-sub.Out():
+			if !ok {
+				return
+			}
+
+			switch ev := ev.(type) {
+			case event.EvtLocalReachabilityChanged:
+				s.reachability.Store(int32(ev.Reachability))
+
+				metrics.SetGauge([]string{networkMetrics, "reachability"}, float32(ev.Reachability))
+				s.logger.Info("Reachability changed", "reachability", ev.Reachability)
+
+				if ev.Reachability == network.ReachabilityPrivate && !s.config.NATPortMap && s.config.NatAddr == nil {
+					s.logger.Warn("The node isn't dialable by the peers, " +
+						"map the port on the gateway (--nat any) or set the external IP (--nat extip:<IP>)")
+				}
+			case event.EvtLocalAddressesUpdated:
+				addrs := s.host.Addrs()
+
+				s.setAddrs(addrs)
+				s.logger.Info("Advertised addresses updated", "addrs", addrs)
+			}
+		}
+	}
+}
+
+// Reachability returns the reachability of the node determined by AutoNAT
+func (s *Server) Reachability() network.Reachability {
+	return network.Reachability(s.reachability.Load())
+}
+
+// setAddrs updates the addresses the node is reachable at
+func (s *Server) setAddrs(addrs []multiaddr.Multiaddr) {
+	s.addrsLock.Lock()
+	defer s.addrsLock.Unlock()
+
+	s.addrs = addrs
+}
@@ -0,0 +1,62 @@
+package network
+
+import (
+	"net"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNAT(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		rawNAT  string
+		portMap bool
+		extIP   net.IP
+	}{
+		{"", false, nil},
+		{"none", false, nil},
+		{"any", true, nil},
+		{"UPnP", true, nil},
+		{"pmp", true, nil},
+		{"extip:192.0.2.1", false, net.ParseIP("192.0.2.1")},
+		{"192.0.2.1", false, net.ParseIP("192.0.2.1")},
+		{"extip:2001:db8::68", false, net.ParseIP("2001:db8::68")},
+	}
+
+	for _, testCase := range testTable {
+		portMap, extIP, err := ParseNAT(testCase.rawNAT)
+		require.NoError(t, err, testCase.rawNAT)
+		assert.Equal(t, testCase.portMap, portMap, testCase.rawNAT)
+		assert.Equal(t, testCase.extIP, extIP, testCase.rawNAT)
+	}
+
+	for _, rawNAT := range []string{"extip:", "stun", "extip:host.example"} {
+		_, _, err := ParseNAT(rawNAT)
+		assert.ErrorIs(t, err, errInvalidNAT, rawNAT)
+	}
+}
+
+func TestReachableAddrs(t *testing.T) {
+	t.Parallel()
+
+	privateAddr := multiaddr.StringCast("/ip4/192.168.1.2/tcp/1478")
+	loopbackAddr := multiaddr.StringCast("/ip4/127.0.0.1/tcp/1478")
+	publicAddr := multiaddr.StringCast("/ip4/1.2.3.4/tcp/1478")
+
+	addrs := []multiaddr.Multiaddr{loopbackAddr, privateAddr, publicAddr}
+
+	// until the node is found publicly reachable, all the addresses are advertised
+	assert.Equal(t, addrs, reachableAddrs(addrs, network.ReachabilityUnknown))
+	assert.Equal(t, addrs, reachableAddrs(addrs, network.ReachabilityPrivate))
+
+	assert.Equal(t, []multiaddr.Multiaddr{publicAddr}, reachableAddrs(addrs, network.ReachabilityPublic))
+
+	// the addresses aren't dropped if none of them is public
+	privateAddrs := []multiaddr.Multiaddr{loopbackAddr, privateAddr}
+	assert.Equal(t, privateAddrs, reachableAddrs(privateAddrs, network.ReachabilityPublic))
+}
@@ -0,0 +1,274 @@
+package network
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// PeerFilters are the CIDR allow and deny lists the addresses of the peers are checked against,
+// separately for the inbound and for the outbound connections.
+// The deny lists take precedence, an empty allow list allows all the addresses which are not denied
+type PeerFilters struct {
+	InboundAllow  []string `json:"inbound_allow,omitempty" yaml:"inbound_allow,omitempty"`
+	InboundDeny   []string `json:"inbound_deny,omitempty" yaml:"inbound_deny,omitempty"`
+	OutboundAllow []string `json:"outbound_allow,omitempty" yaml:"outbound_allow,omitempty"`
+	OutboundDeny  []string `json:"outbound_deny,omitempty" yaml:"outbound_deny,omitempty"`
+}
+
+func (f *PeerFilters) copy() *PeerFilters {
+	return &PeerFilters{
+		InboundAllow:  append([]string(nil), f.InboundAllow...),
+		InboundDeny:   append([]string(nil), f.InboundDeny...),
+		OutboundAllow: append([]string(nil), f.OutboundAllow...),
+		OutboundDeny:  append([]string(nil), f.OutboundDeny...),
+	}
+}
+
+// Equal checks if the filters have the same lists, nil filters and nil lists are equal to the empty ones
+func (f *PeerFilters) Equal(other *PeerFilters) bool {
+	if f == nil || other == nil {
+		return f.isEmpty() && other.isEmpty()
+	}
+
+	return equalCIDRLists(f.InboundAllow, other.InboundAllow) &&
+		equalCIDRLists(f.InboundDeny, other.InboundDeny) &&
+		equalCIDRLists(f.OutboundAllow, other.OutboundAllow) &&
+		equalCIDRLists(f.OutboundDeny, other.OutboundDeny)
+}
+
+func (f *PeerFilters) isEmpty() bool {
+	return f == nil || len(f.InboundAllow)+len(f.InboundDeny)+len(f.OutboundAllow)+len(f.OutboundDeny) == 0
+}
+
+func equalCIDRLists(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Validate checks that all the lists consist of valid CIDRs or IPs
+func (f *PeerFilters) Validate() error {
+	if _, err := parseRules(f.InboundAllow, f.InboundDeny); err != nil {
+		return err
+	}
+
+	_, err := parseRules(f.OutboundAllow, f.OutboundDeny)
+
+	return err
+}
+
+// cidrRules are the parsed allow and deny lists of a single direction
+type cidrRules struct {
+	allow []*net.IPNet
+	deny  []*net.IPNet
+}
+
+// allows checks if the IP is allowed by the rules, the addresses without an IP
+// are allowed only if there is no allow list
+func (r *cidrRules) allows(ip net.IP) bool {
+	if ip == nil {
+		return len(r.allow) == 0
+	}
+
+	for _, subnet := range r.deny {
+		if subnet.Contains(ip) {
+			return false
+		}
+	}
+
+	if len(r.allow) == 0 {
+		return true
+	}
+
+	for _, subnet := range r.allow {
+		if subnet.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseCIDRs parses the CIDR list, the single IPs are accepted as the subnets of a single address
+func parseCIDRs(rawCIDRs []string) ([]*net.IPNet, error) {
+	subnets := make([]*net.IPNet, 0, len(rawCIDRs))
+
+	for _, rawCIDR := range rawCIDRs {
+		rawCIDR = strings.TrimSpace(rawCIDR)
+
+		if !strings.Contains(rawCIDR, "/") {
+			ip := net.ParseIP(rawCIDR)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid CIDR %s", rawCIDR)
+			}
+
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+
+			subnets = append(subnets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+
+			continue
+		}
+
+		_, subnet, err := net.ParseCIDR(rawCIDR)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %s: %w", rawCIDR, err)
+		}
+
+		subnets = append(subnets, subnet)
+	}
+
+	return subnets, nil
+}
+
+// parseRules parses the allow and the deny lists of a single direction
+func parseRules(rawAllow, rawDeny []string) (cidrRules, error) {
+	allow, err := parseCIDRs(rawAllow)
+	if err != nil {
+		return cidrRules{}, err
+	}
+
+	deny, err := parseCIDRs(rawDeny)
+	if err != nil {
+		return cidrRules{}, err
+	}
+
+	return cidrRules{allow: allow, deny: deny}, nil
+}
+
+// peerFilter is the connection gater which rejects the connections from and to the addresses
+// not allowed by the peer filters. The filters are replaced at runtime
+type peerFilter struct {
+	lock sync.RWMutex
+
+	filters  PeerFilters
+	inbound  cidrRules
+	outbound cidrRules
+}
+
+func newPeerFilter(filters *PeerFilters) (*peerFilter, error) {
+	f := &peerFilter{}
+	if err := f.set(filters); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// set replaces the filters, the filters are left intact if any of the lists is invalid
+func (f *peerFilter) set(filters *PeerFilters) error {
+	if filters == nil {
+		filters = &PeerFilters{}
+	}
+
+	inbound, err := parseRules(filters.InboundAllow, filters.InboundDeny)
+	if err != nil {
+		return err
+	}
+
+	outbound, err := parseRules(filters.OutboundAllow, filters.OutboundDeny)
+	if err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.filters = *filters.copy()
+	f.inbound = inbound
+	f.outbound = outbound
+
+	return nil
+}
+
+// get returns a copy of the filters
+func (f *peerFilter) get() *PeerFilters {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	return f.filters.copy()
+}
+
+// allows checks if the connection in the given direction to the remote address is allowed
+func (f *peerFilter) allows(direction network.Direction, addr multiaddr.Multiaddr) bool {
+	var ip net.IP
+
+	if addr != nil {
+		ip, _ = manet.ToIP(addr)
+	}
+
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	if direction == network.DirInbound {
+		return f.inbound.allows(ip)
+	}
+
+	return f.outbound.allows(ip)
+}
+
+// InterceptPeerDial allows dialing all the peers, the addresses are checked when they are dialed
+func (f *peerFilter) InterceptPeerDial(peer.ID) bool {
+	return true
+}
+
+// InterceptAddrDial checks if the dialed address is allowed by the outbound filters
+func (f *peerFilter) InterceptAddrDial(_ peer.ID, addr multiaddr.Multiaddr) bool {
+	return f.allows(network.DirOutbound, addr)
+}
+
+// InterceptAccept checks if the accepted connection is allowed by the inbound filters
+func (f *peerFilter) InterceptAccept(addrs network.ConnMultiaddrs) bool {
+	return f.allows(network.DirInbound, addrs.RemoteMultiaddr())
+}
+
+// InterceptSecured checks the connection again, as the filters might have been replaced in the meantime
+func (f *peerFilter) InterceptSecured(direction network.Direction, _ peer.ID, addrs network.ConnMultiaddrs) bool {
+	return f.allows(direction, addrs.RemoteMultiaddr())
+}
+
+// InterceptUpgraded allows all the upgraded connections
+func (f *peerFilter) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
+
+// PeerFilters returns the CIDR lists the connections of the peers are filtered by
+func (s *Server) PeerFilters() *PeerFilters {
+	return s.peerFilter.get()
+}
+
+// SetPeerFilters replaces the CIDR lists the connections of the peers are filtered by,
+// and disconnects from the connected peers which are not allowed anymore
+func (s *Server) SetPeerFilters(filters *PeerFilters) error {
+	if err := s.peerFilter.set(filters); err != nil {
+		return err
+	}
+
+	for _, conn := range s.host.Network().Conns() {
+		if s.peerFilter.allows(conn.Stat().Direction, conn.RemoteMultiaddr()) {
+			continue
+		}
+
+		s.DisconnectFromPeer(conn.RemotePeer(), "peer address filtered")
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerFilter_Allows(t *testing.T) {
+	t.Parallel()
+
+	filter, err := newPeerFilter(&PeerFilters{
+		InboundAllow:  []string{"10.0.0.0/8"},
+		InboundDeny:   []string{"10.0.1.0/24"},
+		OutboundDeny:  []string{"192.168.1.1", "fd00::/8"},
+		OutboundAllow: nil,
+	})
+	require.NoError(t, err)
+
+	cases := []struct {
+		direction network.Direction
+		addr      string
+		allowed   bool
+	}{
+		{network.DirInbound, "/ip4/10.0.0.1/tcp/1478", true},
+		{network.DirInbound, "/ip4/10.0.1.1/tcp/1478", false},
+		{network.DirInbound, "/ip4/11.0.0.1/tcp/1478", false},
+		// the address without an IP doesn't match the allow list
+		{network.DirInbound, "/dns4/example.com/tcp/1478", false},
+		{network.DirOutbound, "/ip4/192.168.1.1/tcp/1478", false},
+		{network.DirOutbound, "/ip4/192.168.1.2/tcp/1478", true},
+		{network.DirOutbound, "/ip6/fd00::1/tcp/1478", false},
+		{network.DirOutbound, "/dns4/example.com/tcp/1478", true},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.allowed, filter.allows(c.direction, multiaddr.StringCast(c.addr)), c.addr)
+	}
+
+	// the invalid filters aren't applied
+	require.Error(t, filter.set(&PeerFilters{OutboundDeny: []string{"10.0.0.0/33"}}))
+	require.Error(t, filter.set(&PeerFilters{InboundAllow: []string{"invalid"}}))
+	assert.Equal(t, []string{"10.0.0.0/8"}, filter.get().InboundAllow)
+
+	// no filters allow all the addresses
+	require.NoError(t, filter.set(nil))
+	assert.True(t, filter.allows(network.DirInbound, multiaddr.StringCast("/ip4/11.0.0.1/tcp/1478")))
+	assert.Equal(t, &PeerFilters{}, filter.get())
+}
+
+func TestPeerFilters_EqualAndValidate(t *testing.T) {
+	t.Parallel()
+
+	var unset *PeerFilters
+
+	filters := &PeerFilters{InboundDeny: []string{"10.0.0.0/8"}}
+
+	assert.True(t, unset.Equal(&PeerFilters{InboundAllow: []string{}}))
+	assert.True(t, filters.Equal(&PeerFilters{InboundDeny: []string{"10.0.0.0/8"}}))
+	assert.False(t, filters.Equal(unset))
+	assert.False(t, filters.Equal(&PeerFilters{OutboundDeny: []string{"10.0.0.0/8"}}))
+
+	require.NoError(t, filters.Validate())
+	require.Error(t, (&PeerFilters{OutboundAllow: []string{"10.0.0.0/33"}}).Validate())
+}
+
+func TestPeerFilters_ConnectionsFiltered(t *testing.T) {
+	t.Parallel()
+
+	servers, createErr := createServers(2, nil)
+	require.NoError(t, createErr)
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	require.NoError(t, JoinAndWait(servers[0], servers[1], DefaultJoinTimeout, DefaultJoinTimeout))
+
+	// the connected peer which is denied is disconnected
+	require.NoError(t, servers[1].SetPeerFilters(&PeerFilters{InboundDeny: []string{"127.0.0.0/8"}}))
+
+	disconnectCtx, disconnectFn := context.WithTimeout(context.Background(), DefaultLeaveTimeout)
+	defer disconnectFn()
+
+	_, err := WaitUntilPeerDisconnectsFrom(disconnectCtx, servers[0], servers[1].AddrInfo().ID)
+	require.NoError(t, err)
+
+	// the denied peer can't connect again
+	assert.Error(t, JoinAndWait(servers[0], servers[1], 5*time.Second, 5*time.Second))
+	assert.False(t, servers[0].IsConnected(servers[1].AddrInfo().ID))
+}
@@ -0,0 +1,267 @@
+package network
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// peerScoreHalfLife is the period after which a peer score is halved
+	peerScoreHalfLife = 5 * time.Minute
+
+	// maxPeerScore caps the score earned by the useful responses,
+	// so a peer can't outweigh its future misbehaviour by being useful in advance
+	maxPeerScore = 20
+
+	// PeerPruneThreshold is the score below which the peer is disconnected,
+	// and is not dialed nor accepted until its score decays above the threshold
+	PeerPruneThreshold = -100
+
+	// peerGossipThreshold is the score below which the gossip isn't exchanged with the peer
+	peerGossipThreshold = -50
+
+	// peerPublishThreshold is the score below which the messages aren't published to the peer
+	peerPublishThreshold = -75
+
+	// maxPeerScoreEntries is the number of tracked peers after which
+	// the disconnected peers with fully decayed scores are pruned
+	maxPeerScoreEntries = 4096
+
+	// minPeerScore is the absolute score below which the peer is considered neutral
+	minPeerScore = 1
+
+	// peerScoreDecayInterval is the interval the gossip scores are refreshed at
+	peerScoreDecayInterval = 10 * time.Second
+)
+
+// PeerScoreEvent is the behaviour of a peer which affects its score
+type PeerScoreEvent int
+
+const (
+	// UsefulResponse is the valid response to the request sent to the peer
+	UsefulResponse PeerScoreEvent = iota
+	// InvalidMessage is the gossip message or the response which can't be decoded or fails the validation
+	InvalidMessage
+	// Timeout is the request the peer didn't respond to in time
+	Timeout
+	// ProtocolViolation is the response breaking the protocol, such as the block not following the requested one
+	ProtocolViolation
+	// RateLimitExceeded is the inbound message over the rate limit of its protocol
+	RateLimitExceeded
+)
+
+func (e PeerScoreEvent) String() string {
+	switch e {
+	case UsefulResponse:
+		return "useful_response"
+	case InvalidMessage:
+		return "invalid_message"
+	case Timeout:
+		return "timeout"
+	case ProtocolViolation:
+		return "protocol_violation"
+	case RateLimitExceeded:
+		return "rate_limit_exceeded"
+	default:
+		return "unknown"
+	}
+}
+
+// weight returns the score the peer gets for the event
+func (e PeerScoreEvent) weight() float64 {
+	switch e {
+	case UsefulResponse:
+		return 1
+	case InvalidMessage:
+		return -10
+	case Timeout:
+		return -5
+	case ProtocolViolation:
+		return -50
+	case RateLimitExceeded:
+		return -2
+	default:
+		return 0
+	}
+}
+
+// PeerScore is the reputation of a single peer
+type PeerScore struct {
+	ID    peer.ID `json:"id"`
+	Score float64 `json:"score"`
+
+	UsefulResponses    uint64 `json:"usefulResponses"`
+	InvalidMessages    uint64 `json:"invalidMessages"`
+	Timeouts           uint64 `json:"timeouts"`
+	ProtocolViolations uint64 `json:"protocolViolations"`
+	RateLimitExceeded  uint64 `json:"rateLimitExceeded"`
+}
+
+// peerScore is the score of a single peer at the time of the last update, along with the event counts
+type peerScore struct {
+	score     float64
+	updatedAt time.Time
+	events    [RateLimitExceeded + 1]uint64
+}
+
+// peerScores keeps exponentially decaying scores of the peers
+type peerScores struct {
+	sync.Mutex
+
+	scores map[peer.ID]*peerScore
+
+	// now returns the current time (overridden in tests)
+	now func() time.Time
+}
+
+func newPeerScores() *peerScores {
+	return &peerScores{
+		scores: make(map[peer.ID]*peerScore),
+		now:    time.Now,
+	}
+}
+
+// decayedPeerScore returns the score decayed to the given moment
+func decayedPeerScore(s *peerScore, now time.Time) float64 {
+	elapsed := now.Sub(s.updatedAt)
+	if elapsed <= 0 {
+		return s.score
+	}
+
+	return s.score * math.Pow(0.5, float64(elapsed)/float64(peerScoreHalfLife))
+}
+
+// report applies the event to the score of the given peer,
+// it returns true if the event moved the score below the prune threshold
+func (p *peerScores) report(id peer.ID, event PeerScoreEvent) bool {
+	p.Lock()
+	defer p.Unlock()
+
+	now := p.now()
+
+	s, ok := p.scores[id]
+	if !ok {
+		if len(p.scores) >= maxPeerScoreEntries {
+			p.prune(now)
+		}
+
+		s = &peerScore{updatedAt: now}
+		p.scores[id] = s
+	}
+
+	current := decayedPeerScore(s, now)
+
+	s.score = math.Min(current+event.weight(), maxPeerScore)
+	s.updatedAt = now
+
+	if int(event) < len(s.events) {
+		s.events[event]++
+	}
+
+	return current >= PeerPruneThreshold && s.score < PeerPruneThreshold
+}
+
+// score returns the current score of the given peer
+func (p *peerScores) score(id peer.ID) float64 {
+	p.Lock()
+	defer p.Unlock()
+
+	s, ok := p.scores[id]
+	if !ok {
+		return 0
+	}
+
+	return decayedPeerScore(s, p.now())
+}
+
+// isPruned checks if the score of the given peer is below the prune threshold
+func (p *peerScores) isPruned(id peer.ID) bool {
+	return p.score(id) < PeerPruneThreshold
+}
+
+// get returns the reputation of the given peer
+func (p *peerScores) get(id peer.ID) PeerScore {
+	p.Lock()
+	defer p.Unlock()
+
+	result := PeerScore{ID: id}
+
+	s, ok := p.scores[id]
+	if !ok {
+		return result
+	}
+
+	result.Score = decayedPeerScore(s, p.now())
+	result.UsefulResponses = s.events[UsefulResponse]
+	result.InvalidMessages = s.events[InvalidMessage]
+	result.Timeouts = s.events[Timeout]
+	result.ProtocolViolations = s.events[ProtocolViolation]
+	result.RateLimitExceeded = s.events[RateLimitExceeded]
+
+	return result
+}
+
+// prune removes the peers whose scores have decayed enough. Must be called with the lock held
+func (p *peerScores) prune(now time.Time) {
+	for id, s := range p.scores {
+		if math.Abs(decayedPeerScore(s, now)) < minPeerScore {
+			delete(p.scores, id)
+		}
+	}
+
+	metrics.SetGauge([]string{networkMetrics, "peer_score_entries"}, float32(len(p.scores)))
+}
+
+// gossipScoreOptions returns the gossipsub options making the peer scores the application specific score,
+// so the gossip isn't exchanged with the misbehaving peers
+func (p *peerScores) gossipScoreOptions() pubsub.Option {
+	return pubsub.WithPeerScore(
+		&pubsub.PeerScoreParams{
+			AppSpecificScore:  p.score,
+			AppSpecificWeight: 1,
+			DecayInterval:     peerScoreDecayInterval,
+			DecayToZero:       0.01,
+		},
+		&pubsub.PeerScoreThresholds{
+			GossipThreshold:   peerGossipThreshold,
+			PublishThreshold:  peerPublishThreshold,
+			GraylistThreshold: PeerPruneThreshold,
+		},
+	)
+}
+
+// ReportPeer applies the event to the score of the given peer,
+// the peer is disconnected if its score falls below the prune threshold
+func (s *Server) ReportPeer(peerID peer.ID, event PeerScoreEvent) {
+	metrics.IncrCounter([]string{networkMetrics, "peer_" + event.String()}, 1)
+
+	if !s.peerScores.report(peerID, event) {
+		return
+	}
+
+	// the static and the trusted peers are kept regardless of their score
+	if s.peerLists.isProtected(peerID) {
+		s.logger.Warn("Score of protected peer below prune threshold", "id", peerID)
+
+		return
+	}
+
+	metrics.IncrCounter([]string{networkMetrics, "pruned_peers"}, 1)
+
+	s.DisconnectFromPeer(peerID, "peer score below prune threshold")
+}
+
+// isPrunedPeer checks if the peer is pruned for its score, the static and the trusted peers are never pruned
+func (s *Server) isPrunedPeer(peerID peer.ID) bool {
+	return !s.peerLists.isProtected(peerID) && s.peerScores.isPruned(peerID)
+}
+
+// GetPeerScore returns the reputation of the given peer
+func (s *Server) GetPeerScore(peerID peer.ID) PeerScore {
+	return s.peerScores.get(peerID)
+}
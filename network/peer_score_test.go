@@ -0,0 +1,103 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerScores_PruneAndDecay(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	scores := newPeerScores()
+	scores.now = func() time.Time { return now }
+
+	// the useful responses are capped, so they don't outweigh the misbehaviour
+	for i := 0; i < 2*maxPeerScore; i++ {
+		assert.False(t, scores.report("A", UsefulResponse))
+	}
+
+	assert.Equal(t, float64(maxPeerScore), scores.score("A"))
+
+	assert.False(t, scores.report("A", ProtocolViolation))
+	assert.False(t, scores.report("A", ProtocolViolation))
+	assert.False(t, scores.isPruned("A"))
+
+	// the event moving the score below the threshold prunes the peer, the following ones don't
+	assert.True(t, scores.report("A", ProtocolViolation))
+	assert.False(t, scores.report("A", Timeout))
+	assert.True(t, scores.isPruned("A"))
+	assert.False(t, scores.isPruned("B"))
+
+	assert.Equal(t, PeerScore{
+		ID:                 "A",
+		Score:              maxPeerScore - 3*50 - 5,
+		UsefulResponses:    2 * maxPeerScore,
+		Timeouts:           1,
+		ProtocolViolations: 3,
+	}, scores.get("A"))
+
+	// after a half life the score is halved, and the peer is not pruned anymore
+	now = now.Add(peerScoreHalfLife)
+	assert.False(t, scores.isPruned("A"))
+}
+
+func TestPeerScores_Prune(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	scores := newPeerScores()
+	scores.now = func() time.Time { return now }
+
+	for i := 0; i < maxPeerScoreEntries; i++ {
+		scores.report(peer.ID(fmt.Sprintf("peer-%d", i)), UsefulResponse)
+	}
+
+	require.Len(t, scores.scores, maxPeerScoreEntries)
+
+	// scores decayed below the min score get pruned when a new peer is added
+	now = now.Add(peerScoreHalfLife)
+	scores.report("new", InvalidMessage)
+
+	assert.Len(t, scores.scores, 1)
+}
+
+func TestReportPeer_Disconnects(t *testing.T) {
+	t.Parallel()
+
+	servers, createErr := createServers(2, nil)
+	require.NoError(t, createErr)
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	require.NoError(t, JoinAndWait(servers[0], servers[1], DefaultBufferTimeout, DefaultJoinTimeout))
+
+	peerID := servers[1].AddrInfo().ID
+
+	servers[0].ReportPeer(peerID, InvalidMessage)
+	assert.True(t, servers[0].IsConnected(peerID))
+
+	for !servers[0].peerScores.isPruned(peerID) {
+		servers[0].ReportPeer(peerID, ProtocolViolation)
+	}
+
+	disconnectCtx, disconnectFn := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer disconnectFn()
+
+	_, err := WaitUntilPeerDisconnectsFrom(disconnectCtx, servers[0], peerID)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(1), servers[0].GetPeerScore(peerID).InvalidMessages)
+
+	// the pruned peer isn't dialed until its score decays
+	smallTimeout := 5 * time.Second
+	assert.Error(t, JoinAndWait(servers[0], servers[1], smallTimeout, smallTimeout))
+}
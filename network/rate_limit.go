@@ -0,0 +1,247 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// Protocol classes the inbound limits are configured for
+const (
+	SyncerProtocols    = "syncer"
+	ConsensusProtocols = "consensus"
+	TxPoolProtocols    = "txpool"
+)
+
+var (
+	ErrInboundRateExceeded = errors.New("inbound message rate exceeded")
+	ErrInboundMessageSize  = errors.New("inbound message too large")
+)
+
+// protocolClasses are the prefixes of the protocol IDs and the classes of the protocols,
+// the protocols of every version fall into the same class
+var protocolClasses = []struct {
+	prefix string
+	class  string
+}{
+	{"/syncer/", SyncerProtocols},
+	{"syncer/", SyncerProtocols},
+	{"/ibft/", ConsensusProtocols},
+	{"/pbft/", ConsensusProtocols},
+	{"/bridge/", ConsensusProtocols},
+	{"txpool/", TxPoolProtocols},
+}
+
+// protocolClass returns the class of the protocol, which is empty if the protocol isn't limited
+func protocolClass(protocolID string) string {
+	for _, c := range protocolClasses {
+		if strings.HasPrefix(protocolID, c.prefix) {
+			return c.class
+		}
+	}
+
+	return ""
+}
+
+// ProtocolLimit is the limit of the inbound messages a single peer sends over the protocols of a class
+type ProtocolLimit struct {
+	// MessagesPerSecond is the rate at which the messages are accepted
+	MessagesPerSecond float64 `json:"messages_per_second" yaml:"messages_per_second"`
+
+	// Burst is the max number of messages accepted at once
+	Burst uint64 `json:"burst" yaml:"burst"`
+
+	// MaxMessageSize is the max size of a message in bytes, 0 doesn't limit the size
+	MaxMessageSize uint64 `json:"max_message_size" yaml:"max_message_size"`
+}
+
+// RateLimits are the inbound limits per protocol class, the classes without the limit aren't limited
+type RateLimits struct {
+	Syncer    *ProtocolLimit `json:"syncer,omitempty" yaml:"syncer,omitempty"`
+	Consensus *ProtocolLimit `json:"consensus,omitempty" yaml:"consensus,omitempty"`
+	TxPool    *ProtocolLimit `json:"tx_pool,omitempty" yaml:"tx_pool,omitempty"`
+}
+
+// DefaultRateLimits returns the limits high enough for the honest peers,
+// which cap the cost of the flood of cheap messages
+func DefaultRateLimits() *RateLimits {
+	return &RateLimits{
+		Syncer: &ProtocolLimit{
+			MessagesPerSecond: 50,
+			Burst:             100,
+			MaxMessageSize:    64 * 1024,
+		},
+		Consensus: &ProtocolLimit{
+			MessagesPerSecond: 200,
+			Burst:             500,
+			MaxMessageSize:    pubsub.DefaultMaxMessageSize,
+		},
+		TxPool: &ProtocolLimit{
+			MessagesPerSecond: 500,
+			Burst:             1000,
+			MaxMessageSize:    128 * 1024,
+		},
+	}
+}
+
+// limit returns the limit of the protocol class
+func (r *RateLimits) limit(class string) *ProtocolLimit {
+	if r == nil {
+		return nil
+	}
+
+	switch class {
+	case SyncerProtocols:
+		return r.Syncer
+	case ConsensusProtocols:
+		return r.Consensus
+	case TxPoolProtocols:
+		return r.TxPool
+	default:
+		return nil
+	}
+}
+
+// tokenBucket allows the messages at the given rate, with the given burst
+type tokenBucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+// take refills the bucket and takes one token from it, if there is any
+func (b *tokenBucket) take(limit *ProtocolLimit, now time.Time) bool {
+	burst := math.Max(float64(limit.Burst), 1)
+
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.lastRefill).Seconds()*limit.MessagesPerSecond)
+	b.lastRefill = now
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+
+	return true
+}
+
+// inboundLimiter limits the inbound messages per peer and protocol class
+type inboundLimiter struct {
+	lock sync.Mutex
+
+	limits *RateLimits
+
+	// buckets are the buckets of the peers per protocol class
+	buckets map[string]map[peer.ID]*tokenBucket
+
+	// now returns the current time (overridden in tests)
+	now func() time.Time
+}
+
+func newInboundLimiter(limits *RateLimits) *inboundLimiter {
+	return &inboundLimiter{
+		limits:  limits,
+		buckets: make(map[string]map[peer.ID]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// isLimited checks if the protocol has the inbound limit
+func (l *inboundLimiter) isLimited(protocolID string) bool {
+	return l.limits.limit(protocolClass(protocolID)) != nil
+}
+
+// allow checks the message of the given size sent by the peer over the protocol
+func (l *inboundLimiter) allow(protocolID string, from peer.ID, size int) error {
+	class := protocolClass(protocolID)
+
+	limit := l.limits.limit(class)
+	if limit == nil {
+		return nil
+	}
+
+	if limit.MaxMessageSize != 0 && uint64(size) > limit.MaxMessageSize {
+		return ErrInboundMessageSize
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	peers, ok := l.buckets[class]
+	if !ok {
+		peers = make(map[peer.ID]*tokenBucket)
+		l.buckets[class] = peers
+	}
+
+	now := l.now()
+
+	bucket, ok := peers[from]
+	if !ok {
+		// a new peer starts with the full bucket
+		bucket = &tokenBucket{tokens: math.Max(float64(limit.Burst), 1), lastRefill: now}
+		peers[from] = bucket
+	}
+
+	if !bucket.take(limit, now) {
+		return ErrInboundRateExceeded
+	}
+
+	return nil
+}
+
+// removePeer drops the buckets of the disconnected peer
+func (l *inboundLimiter) removePeer(id peer.ID) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	for _, peers := range l.buckets {
+		delete(peers, id)
+	}
+}
+
+// limitInbound enforces the inbound limit of the protocol,
+// the peer exceeding it is penalized
+func (s *Server) limitInbound(protocolID string, from peer.ID, size int) error {
+	err := s.inboundLimiter.allow(protocolID, from, size)
+	if err == nil {
+		return nil
+	}
+
+	metrics.IncrCounter([]string{networkMetrics, protocolClass(protocolID) + "_rejected_messages"}, 1)
+
+	if errors.Is(err, ErrInboundMessageSize) {
+		s.ReportPeer(from, InvalidMessage)
+	} else {
+		s.ReportPeer(from, RateLimitExceeded)
+	}
+
+	return err
+}
+
+// topicValidator returns the gossip validator enforcing the inbound limit of the topic,
+// the oversized messages are rejected, while the ones over the rate are ignored
+func (s *Server) topicValidator(topic string) pubsub.ValidatorEx {
+	return func(_ context.Context, from peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+		// the messages published by the node aren't limited
+		if from == s.host.ID() {
+			return pubsub.ValidationAccept
+		}
+
+		err := s.limitInbound(topic, from, len(msg.Data))
+
+		switch {
+		case err == nil:
+			return pubsub.ValidationAccept
+		case errors.Is(err, ErrInboundMessageSize):
+			return pubsub.ValidationReject
+		default:
+			return pubsub.ValidationIgnore
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtocolClass(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, SyncerProtocols, protocolClass("/syncer/0.2"))
+	assert.Equal(t, SyncerProtocols, protocolClass("syncer/status/0.1"))
+	assert.Equal(t, ConsensusProtocols, protocolClass("/ibft/0.2"))
+	assert.Equal(t, ConsensusProtocols, protocolClass("/pbft/0.2"))
+	assert.Equal(t, ConsensusProtocols, protocolClass("/bridge/0.2"))
+	assert.Equal(t, TxPoolProtocols, protocolClass("txpool/0.1"))
+	assert.Empty(t, protocolClass("/id/0.1"))
+}
+
+func TestInboundLimiter_Allow(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	limiter := newInboundLimiter(&RateLimits{
+		TxPool: &ProtocolLimit{MessagesPerSecond: 10, Burst: 5, MaxMessageSize: 100},
+	})
+	limiter.now = func() time.Time { return now }
+
+	// the burst is allowed at once, the rest at the given rate
+	for i := 0; i < 5; i++ {
+		require.NoError(t, limiter.allow("txpool/0.1", "A", 10))
+	}
+
+	require.ErrorIs(t, limiter.allow("txpool/0.1", "A", 10), ErrInboundRateExceeded)
+
+	// the peers are limited separately
+	require.NoError(t, limiter.allow("txpool/0.1", "B", 10))
+
+	now = now.Add(100 * time.Millisecond)
+	require.NoError(t, limiter.allow("txpool/0.1", "A", 10))
+	require.ErrorIs(t, limiter.allow("txpool/0.1", "A", 10), ErrInboundRateExceeded)
+
+	require.ErrorIs(t, limiter.allow("txpool/0.1", "B", 101), ErrInboundMessageSize)
+
+	// the protocols without the limit aren't limited
+	for i := 0; i < 10; i++ {
+		require.NoError(t, limiter.allow("/syncer/0.2", "A", 1000))
+	}
+
+	assert.True(t, limiter.isLimited("txpool/0.1"))
+	assert.False(t, limiter.isLimited("/syncer/0.2"))
+
+	// the disconnected peer starts with the full bucket again
+	limiter.removePeer("A")
+	require.NoError(t, limiter.allow("txpool/0.1", "A", 10))
+}
+
+func TestLimitInbound_PenalizesPeer(t *testing.T) {
+	t.Parallel()
+
+	server, err := CreateServer(&CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.RateLimits = &RateLimits{
+				Consensus: &ProtocolLimit{MessagesPerSecond: 1, Burst: 1, MaxMessageSize: 100},
+			}
+		},
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, server.Close())
+	})
+
+	peerID := peer.ID("A")
+
+	require.NoError(t, server.limitInbound("/ibft/0.2", peerID, 10))
+	require.ErrorIs(t, server.limitInbound("/ibft/0.2", peerID, 10), ErrInboundRateExceeded)
+	require.ErrorIs(t, server.limitInbound("/ibft/0.2", peerID, 1000), ErrInboundMessageSize)
+
+	score := server.GetPeerScore(peerID)
+	assert.Equal(t, uint64(1), score.RateLimitExceeded)
+	assert.Equal(t, uint64(1), score.InvalidMessages)
+	assert.Negative(t, score.Score)
+}
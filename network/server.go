@@ -4,12 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/network/dial"
 	"github.com/0xPolygon/polygon-edge/network/discovery"
+	"github.com/0xPolygon/polygon-edge/network/dnsdisc"
+	"github.com/0xPolygon/polygon-edge/network/grpc"
 	"github.com/armon/go-metrics"
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p/p2p/security/noise"
@@ -56,6 +60,10 @@ const (
 var (
 	ErrNoBootnodes  = errors.New("no bootnodes specified")
 	ErrMinBootnodes = errors.New("minimum 1 bootnode is required")
+
+	// ErrMaxOutboundPeersIncreased is returned when the maximum number of outbound peers is raised at runtime,
+	// since the dial slots are allocated on the start
+	ErrMaxOutboundPeersIncreased = errors.New("maximum number of outbound peers can't exceed the startup value")
 )
 
 type Server struct {
@@ -64,8 +72,11 @@ type Server struct {
 
 	closeCh chan struct{} // the channel used for closing the networking server
 
-	host  host.Host             // the libp2p host reference
-	addrs []multiaddr.Multiaddr // the list of supported (bound) addresses
+	host      host.Host             // the libp2p host reference
+	addrs     []multiaddr.Multiaddr // the list of supported (bound) addresses
+	addrsLock sync.RWMutex          // lock for the addresses, which change as the NAT is traversed
+
+	reachability *atomic.Int32 // the reachability of the node determined by AutoNAT
 
 	peers     map[peer.ID]*PeerConnInfo // map of all peer connections
 	peersLock sync.Mutex                // lock for the peer map
@@ -88,6 +99,16 @@ type Server struct {
 	temporaryDials sync.Map // map of temporary connections; peerID -> bool
 
 	bootnodes *bootnodesWrapper // reference of all bootnodes for the node
+
+	peerScores *peerScores // reputation of the peers
+
+	inboundLimiter *inboundLimiter // limits of the inbound messages per protocol
+
+	peerLists *peerLists // static and trusted peers
+
+	peerFilter *peerFilter // CIDR filters of the peer connections
+
+	dnsSeeds *dnsdisc.Client // resolver of the bootnodes published over DNS
 }
 
 // NewServer returns a new instance of the networking server
@@ -104,6 +125,8 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	reachability := new(atomic.Int32)
+
 	addrsFactory := func(addrs []multiaddr.Multiaddr) []multiaddr.Multiaddr {
 		if config.NatAddr != nil {
 			addr, _ := multiaddr.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", config.NatAddr.String(), config.Addr.Port))
@@ -113,18 +136,35 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 			}
 		} else if config.DNS != nil {
 			addrs = []multiaddr.Multiaddr{config.DNS}
+		} else {
+			addrs = reachableAddrs(addrs, network.Reachability(reachability.Load()))
 		}
 
 		return addrs
 	}
 
-	host, err := libp2p.New(
+	peerFilter, err := newPeerFilter(config.PeerFilters)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []libp2p.Option{
 		// Use noise as the encryption protocol
 		libp2p.Security(noise.ID, noise.New),
 		libp2p.ListenAddrs(listenAddr),
 		libp2p.AddrsFactory(addrsFactory),
 		libp2p.Identity(key),
-	)
+		// Help the peers to determine their reachability
+		libp2p.EnableNATService(),
+		// Filter the connections by the addresses of the peers
+		libp2p.ConnectionGater(peerFilter),
+	}
+
+	if config.NATPortMap {
+		opts = append(opts, libp2p.NATPortMap())
+	}
+
+	host, err := libp2p.New(opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create libp2p stack: %w", err)
 	}
@@ -139,6 +179,7 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		config:           config,
 		host:             host,
 		addrs:            host.Addrs(),
+		reachability:     reachability,
 		peers:            make(map[peer.ID]*PeerConnInfo),
 		dialQueue:        dial.NewDialQueue(),
 		closeCh:          make(chan struct{}),
@@ -154,6 +195,11 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 			config.MaxInboundPeers,
 			config.MaxOutboundPeers,
 		),
+		peerScores:     newPeerScores(),
+		inboundLimiter: newInboundLimiter(config.RateLimits),
+		peerLists:      newPeerLists(),
+		peerFilter:     peerFilter,
+		dnsSeeds:       dnsdisc.NewClient(logger.Named("dns-seeds"), net.DefaultResolver),
 	}
 
 	// start gossip protocol
@@ -161,6 +207,7 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		context.Background(),
 		host, pubsub.WithPeerOutboundQueueSize(peerOutboundBufferSize),
 		pubsub.WithValidateQueueSize(validateBufferSize),
+		srv.peerScores.gossipScoreOptions(),
 	)
 	if err != nil {
 		return nil, err
@@ -253,6 +300,10 @@ func (s *Server) Start() error {
 		return fmt.Errorf("unable to setup identity, %w", setupErr)
 	}
 
+	if setupErr := s.setupPeerLists(); setupErr != nil {
+		return fmt.Errorf("unable to setup static and trusted peers, %w", setupErr)
+	}
+
 	// Set up the peer discovery mechanism if needed
 	if !s.config.NoDiscover {
 		// Parse the bootnode data
@@ -264,10 +315,15 @@ func (s *Server) Start() error {
 		if setupErr := s.setupDiscovery(); setupErr != nil {
 			return fmt.Errorf("unable to setup discovery, %w", setupErr)
 		}
+
+		if len(s.config.DNSSeeds) > 0 {
+			go s.refreshDNSSeeds()
+		}
 	}
 
 	go s.runDial()
 	go s.keepAliveMinimumPeerConnections()
+	go s.watchNAT()
 
 	// watch for disconnected peers
 	s.host.Network().Notify(&network.NotifyBundle{
@@ -282,13 +338,16 @@ func (s *Server) Start() error {
 
 // setupBootnodes sets up the node's bootnode connections
 func (s *Server) setupBootnodes() error {
+	// The bootnodes can be resolved from the DNS seeds instead
+	hasDNSSeeds := len(s.config.DNSSeeds) > 0
+
 	// Check the bootnode config is present
-	if s.config.Chain.Bootnodes == nil {
+	if s.config.Chain.Bootnodes == nil && !hasDNSSeeds {
 		return ErrNoBootnodes
 	}
 
 	// Check if at least one bootnode is specified
-	if len(s.config.Chain.Bootnodes) < MinimumBootNodes {
+	if len(s.config.Chain.Bootnodes) < MinimumBootNodes && !hasDNSSeeds {
 		return ErrMinBootnodes
 	}
 
@@ -320,6 +379,10 @@ func (s *Server) setupBootnodes() error {
 		bootnodeConnCount: 0,
 	}
 
+	if hasDNSSeeds {
+		s.resolveDNSSeeds()
+	}
+
 	return nil
 }
 
@@ -333,6 +396,8 @@ func (s *Server) keepAliveMinimumPeerConnections() {
 			return
 		}
 
+		s.dialStaticPeers()
+
 		if s.numPeers() < MinimumPeerConnections {
 			if s.config.NoDiscover || !s.bootnodes.hasBootnodes() {
 				// dial unconnected peer
@@ -354,7 +419,7 @@ func (s *Server) keepAliveMinimumPeerConnections() {
 // Essentially, the networking server monitors for any open connection slots
 // and attempts to fill them as soon as they open up
 func (s *Server) runDial() {
-	slots := NewSlots(s.connectionCounts.maxOutboundConnectionCount)
+	slots := NewSlots(s.connectionCounts.maxOutboundConnCount())
 	ctx, cancel := context.WithCancel(context.Background())
 
 	defer cancel()
@@ -398,6 +463,12 @@ func (s *Server) runDial() {
 				continue
 			}
 
+			if s.isPrunedPeer(peerInfo.ID) {
+				s.logger.Debug("Skipping pruned peer", "addr", peerInfo)
+
+				continue
+			}
+
 			s.logger.Debug("Waiting for a dialing slot", "addr", peerInfo, "local", s.host.ID())
 
 			if closed := slots.Take(ctx); closed {
@@ -419,6 +490,18 @@ func (s *Server) runDial() {
 	}
 }
 
+// SetMaxPeers sets the maximum numbers of inbound and outbound peers, which are applied to the new connections.
+// The maximum number of outbound peers can't exceed the one the server is started with [Thread safe]
+func (s *Server) SetMaxPeers(maxInbound, maxOutbound int64) error {
+	if maxOutbound > s.config.MaxOutboundPeers {
+		return fmt.Errorf("%w (%d > %d)", ErrMaxOutboundPeersIncreased, maxOutbound, s.config.MaxOutboundPeers)
+	}
+
+	s.connectionCounts.setMaxConnCounts(maxInbound, maxOutbound)
+
+	return nil
+}
+
 // numPeers returns the number of connected peers [Thread safe]
 func (s *Server) numPeers() int64 {
 	s.peersLock.Lock()
@@ -480,6 +563,8 @@ func (s *Server) removePeer(peerID peer.ID) {
 		return
 	}
 
+	s.inboundLimiter.removePeer(peerID)
+
 	// Emit the event alerting listeners
 	s.emitEvent(peerID, peerEvent.PeerDisconnected)
 }
@@ -625,6 +710,13 @@ func (s *Server) RegisterProtocol(id string, p Protocol) {
 	defer s.protocolsLock.Unlock()
 
 	s.protocols[id] = p
+
+	if stream, ok := p.(*grpc.GrpcStream); ok && s.inboundLimiter.isLimited(id) {
+		stream.SetInboundLimiter(func(from peer.ID, size int) error {
+			return s.limitInbound(id, from, size)
+		})
+	}
+
 	s.wrapStream(id, p.Handler())
 }
 
@@ -638,6 +730,9 @@ func (s *Server) wrapStream(id string, handle func(network.Stream)) {
 }
 
 func (s *Server) AddrInfo() *peer.AddrInfo {
+	s.addrsLock.RLock()
+	defer s.addrsLock.RUnlock()
+
 	return &peer.AddrInfo{
 		ID:    s.host.ID(),
 		Addrs: s.addrs,
@@ -33,6 +33,13 @@ func (s *Server) NewIdentityClient(peerID peer.ID) (proto.IdentityClient, error)
 func (s *Server) AddPeer(id peer.ID, direction network.Direction) {
 	s.logger.Info("Peer connected", "id", id.String())
 
+	// The peer pruned for its misbehaviour is not accepted until its score decays
+	if s.isPrunedPeer(id) {
+		s.DisconnectFromPeer(id, "peer score below prune threshold")
+
+		return
+	}
+
 	// Update the peer connection info
 	if connectionExists := s.addPeerInfo(id, direction); connectionExists {
 		// The peer connection information was already present in the networking
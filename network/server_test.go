@@ -18,6 +18,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConnLimit_Inbound(t *testing.T) {
@@ -134,6 +135,37 @@ func TestConnLimit_Outbound(t *testing.T) {
 	}
 }
 
+func TestSetMaxPeers(t *testing.T) {
+	servers, createErr := createServers(1, map[int]*CreateServerParams{
+		0: {
+			ConfigCallback: func(c *Config) {
+				c.MaxInboundPeers = 1
+				c.MaxOutboundPeers = 2
+				c.NoDiscover = true
+			},
+		},
+	})
+	require.NoError(t, createErr)
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	server := servers[0]
+
+	// the outbound limit can't be raised above the startup one
+	require.ErrorIs(t, server.SetMaxPeers(1, 3), ErrMaxOutboundPeersIncreased)
+	assert.True(t, server.connectionCounts.HasFreeInboundConn())
+
+	require.NoError(t, server.SetMaxPeers(0, 1))
+	assert.False(t, server.connectionCounts.HasFreeInboundConn())
+	assert.Equal(t, int64(1), server.connectionCounts.maxOutboundConnCount())
+
+	require.NoError(t, server.SetMaxPeers(5, 2))
+	assert.True(t, server.connectionCounts.HasFreeInboundConn())
+	assert.Equal(t, int64(5), server.connectionCounts.maxInboundConnCount())
+}
+
 func TestPeerEvent_EmitAndSubscribe(t *testing.T) {
 	server, createErr := CreateServer(&CreateServerParams{ConfigCallback: func(c *Config) {
 		c.NoDiscover = true
@@ -0,0 +1,168 @@
+package network
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// peerLists holds the static and the trusted peers, which are both immune to the pruning.
+// The static peers are kept connected, the trusted peers are accepted even if there are no free slots
+type peerLists struct {
+	sync.RWMutex
+
+	static  map[peer.ID]*peer.AddrInfo
+	trusted map[peer.ID]*peer.AddrInfo
+}
+
+func newPeerLists() *peerLists {
+	return &peerLists{
+		static:  make(map[peer.ID]*peer.AddrInfo),
+		trusted: make(map[peer.ID]*peer.AddrInfo),
+	}
+}
+
+// parsePeerAddr parses the multiaddr of the peer, which has to contain the peer ID
+func parsePeerAddr(rawAddr string) (*peer.AddrInfo, error) {
+	addr, err := multiaddr.NewMultiaddr(rawAddr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid peer address %s: %w", rawAddr, err)
+	}
+
+	peerInfo, err := peer.AddrInfoFromP2pAddr(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid peer address %s: %w", rawAddr, err)
+	}
+
+	return peerInfo, nil
+}
+
+// isProtected checks if the peer is static or trusted
+func (l *peerLists) isProtected(id peer.ID) bool {
+	l.RLock()
+	defer l.RUnlock()
+
+	_, static := l.static[id]
+	_, trusted := l.trusted[id]
+
+	return static || trusted
+}
+
+// isTrusted checks if the peer is trusted
+func (l *peerLists) isTrusted(id peer.ID) bool {
+	l.RLock()
+	defer l.RUnlock()
+
+	_, ok := l.trusted[id]
+
+	return ok
+}
+
+// staticPeers returns the static peers
+func (l *peerLists) staticPeers() []*peer.AddrInfo {
+	l.RLock()
+	defer l.RUnlock()
+
+	peers := make([]*peer.AddrInfo, 0, len(l.static))
+	for _, info := range l.static {
+		peers = append(peers, info)
+	}
+
+	return peers
+}
+
+// setupPeerLists adds the static and the trusted peers of the configuration
+func (s *Server) setupPeerLists() error {
+	for _, rawAddr := range s.config.StaticPeers {
+		if err := s.AddStaticPeer(rawAddr); err != nil {
+			return err
+		}
+	}
+
+	for _, rawAddr := range s.config.TrustedPeers {
+		if err := s.AddTrustedPeer(rawAddr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dialStaticPeers dials the static peers which are not connected
+func (s *Server) dialStaticPeers() {
+	for _, info := range s.peerLists.staticPeers() {
+		if !s.IsConnected(info.ID) {
+			s.addToDialQueue(info, common.PriorityRequestedDial)
+		}
+	}
+}
+
+// AddStaticPeer adds the peer which is kept connected
+func (s *Server) AddStaticPeer(rawAddr string) error {
+	peerInfo, err := parsePeerAddr(rawAddr)
+	if err != nil {
+		return err
+	}
+
+	s.peerLists.Lock()
+	s.peerLists.static[peerInfo.ID] = peerInfo
+	s.peerLists.Unlock()
+
+	s.joinPeer(peerInfo)
+
+	return nil
+}
+
+// AddTrustedPeer adds the peer which is accepted even if there are no free connection slots
+func (s *Server) AddTrustedPeer(rawAddr string) error {
+	peerInfo, err := parsePeerAddr(rawAddr)
+	if err != nil {
+		return err
+	}
+
+	s.peerLists.Lock()
+	s.peerLists.trusted[peerInfo.ID] = peerInfo
+	s.peerLists.Unlock()
+
+	return nil
+}
+
+// RemoveStaticPeer removes the peer from the static peers and disconnects from it,
+// the trusted peer stays trusted
+func (s *Server) RemoveStaticPeer(rawAddr string) error {
+	peerInfo, err := parsePeerAddr(rawAddr)
+	if err != nil {
+		return err
+	}
+
+	s.peerLists.Lock()
+	delete(s.peerLists.static, peerInfo.ID)
+	s.peerLists.Unlock()
+
+	s.dialQueue.DeleteTask(peerInfo.ID)
+	s.DisconnectFromPeer(peerInfo.ID, "static peer removed")
+
+	return nil
+}
+
+// RemoveTrustedPeer removes the peer from the trusted peers, the peer stays connected
+func (s *Server) RemoveTrustedPeer(rawAddr string) error {
+	peerInfo, err := parsePeerAddr(rawAddr)
+	if err != nil {
+		return err
+	}
+
+	s.peerLists.Lock()
+	delete(s.peerLists.trusted, peerInfo.ID)
+	s.peerLists.Unlock()
+
+	return nil
+}
+
+// IsTrustedPeer checks if the peer is trusted [Thread safe]
+func (s *Server) IsTrustedPeer(peerID peer.ID) bool {
+	return s.peerLists.isTrusted(peerID)
+}
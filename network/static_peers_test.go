@@ -0,0 +1,119 @@
+package network
+
+import (
+	"context"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePeerAddr(t *testing.T) {
+	t.Parallel()
+
+	peerInfo, err := parsePeerAddr("/ip4/127.0.0.1/tcp/1478/p2p/16Uiu2HAmJxxH1tScDX2rLGSU9exnuvZKNM9SoK3v315azp68DLPW")
+	require.NoError(t, err)
+	assert.Equal(t, "16Uiu2HAmJxxH1tScDX2rLGSU9exnuvZKNM9SoK3v315azp68DLPW", peerInfo.ID.String())
+	assert.Len(t, peerInfo.Addrs, 1)
+
+	// the peer ID is required
+	_, err = parsePeerAddr("/ip4/127.0.0.1/tcp/1478")
+	assert.Error(t, err)
+
+	_, err = parsePeerAddr("127.0.0.1:1478")
+	assert.Error(t, err)
+}
+
+func TestStaticPeers_RedialedAndNotPruned(t *testing.T) {
+	t.Parallel()
+
+	servers, createErr := createServers(2, nil)
+	require.NoError(t, createErr)
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	peerID := servers[1].AddrInfo().ID
+
+	rawAddr, err := common.AddrInfoToString(servers[1].AddrInfo())
+	require.NoError(t, err)
+
+	require.NoError(t, servers[0].AddStaticPeer(rawAddr))
+
+	connectCtx, connectFn := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer connectFn()
+
+	_, err = WaitUntilPeerConnectsTo(connectCtx, servers[0], peerID)
+	require.NoError(t, err)
+
+	// the static peer isn't disconnected nor pruned for its score
+	for !servers[0].peerScores.isPruned(peerID) {
+		servers[0].ReportPeer(peerID, ProtocolViolation)
+	}
+
+	assert.True(t, servers[0].IsConnected(peerID))
+	assert.False(t, servers[0].isPrunedPeer(peerID))
+
+	// the disconnected static peer is dialed again
+	servers[0].DisconnectFromPeer(peerID, "test")
+
+	disconnectCtx, disconnectFn := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer disconnectFn()
+
+	_, err = WaitUntilPeerDisconnectsFrom(disconnectCtx, servers[0], peerID)
+	require.NoError(t, err)
+
+	servers[0].dialStaticPeers()
+
+	reconnectCtx, reconnectFn := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer reconnectFn()
+
+	_, err = WaitUntilPeerConnectsTo(reconnectCtx, servers[0], peerID)
+	require.NoError(t, err)
+
+	// the removed static peer is disconnected and pruned again
+	require.NoError(t, servers[0].RemoveStaticPeer(rawAddr))
+	assert.True(t, servers[0].isPrunedPeer(peerID))
+
+	removeCtx, removeFn := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer removeFn()
+
+	_, err = WaitUntilPeerDisconnectsFrom(removeCtx, servers[0], peerID)
+	require.NoError(t, err)
+}
+
+func TestTrustedPeers(t *testing.T) {
+	t.Parallel()
+
+	trustedAddr := "/ip4/127.0.0.1/tcp/1478/p2p/16Uiu2HAmJxxH1tScDX2rLGSU9exnuvZKNM9SoK3v315azp68DLPW"
+
+	server, err := CreateServer(&CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.TrustedPeers = []string{trustedAddr}
+		},
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, server.Close())
+	})
+
+	peerInfo, err := parsePeerAddr(trustedAddr)
+	require.NoError(t, err)
+
+	assert.True(t, server.IsTrustedPeer(peerInfo.ID))
+
+	for !server.peerScores.isPruned(peerInfo.ID) {
+		server.ReportPeer(peerInfo.ID, ProtocolViolation)
+	}
+
+	assert.False(t, server.isPrunedPeer(peerInfo.ID))
+
+	require.NoError(t, server.RemoveTrustedPeer(trustedAddr))
+	assert.False(t, server.IsTrustedPeer(peerInfo.ID))
+	assert.True(t, server.isPrunedPeer(peerInfo.ID))
+
+	assert.Error(t, server.AddTrustedPeer("invalid"))
+}
@@ -0,0 +1,243 @@
+package azurekeyvault
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/secrets"
+)
+
+// AzureKeyVaultSecretsManager is a SecretsManager that
+// stores the secrets in an Azure Key Vault
+type AzureKeyVaultSecretsManager struct {
+	// Logger object
+	logger hclog.Logger
+
+	// URL of the vault, https://<vault-name>.vault.azure.net
+	vaultURL string
+
+	// Name of the node, used as the prefix of the secret names
+	name string
+
+	// HTTP client used for the vault and the token requests
+	client *http.Client
+
+	// Source of the access tokens of the vault
+	tokens *tokenSource
+}
+
+type configExtraParamFields string
+
+const (
+	// tenantID is the Azure AD tenant of the service principal,
+	// the managed identity of the host is used if it is not set
+	tenantID configExtraParamFields = "tenant-id"
+	// clientID is the client ID of the service principal, or of the user-assigned managed identity
+	clientID configExtraParamFields = "client-id"
+	// authorityHost overrides the Azure AD authority host, used with the sovereign clouds and the emulators
+	authorityHost configExtraParamFields = "authority-host"
+
+	apiVersion = "7.4"
+
+	// secretContentType marks the secret values which are base64 encoded by the manager
+	secretContentType = "application/octet-stream;base64"
+
+	requestTimeout = 30 * time.Second
+)
+
+var (
+	// secretNamePattern are the characters allowed in the Key Vault secret names
+	secretNamePattern = regexp.MustCompile("^[0-9a-zA-Z-]+$")
+
+	errInvalidVaultURL = errors.New("the server URL has to be the URL of the vault")
+	errInvalidNodeName = errors.New("the node name can only contain alphanumeric characters and dashes")
+	errNoClientSecret  = fmt.Errorf("the client secret has to be set as the token when %s is specified", tenantID)
+	errNoClientID      = fmt.Errorf("no %s variable specified", clientID)
+)
+
+// SecretsManagerFactory implements the factory method
+func SecretsManagerFactory(
+	config *secrets.SecretsManagerConfig,
+	params *secrets.SecretsManagerParams,
+) (secrets.SecretsManager, error) {
+	if !vaultURLValid(config.ServerURL) {
+		return nil, errInvalidVaultURL
+	}
+
+	if !secretNamePattern.MatchString(config.Name) {
+		return nil, errInvalidNodeName
+	}
+
+	client := &http.Client{Timeout: requestTimeout}
+
+	tokens, err := newTokenSource(config, client)
+	if err != nil {
+		return nil, err
+	}
+
+	keyVaultManager := &AzureKeyVaultSecretsManager{
+		logger:   params.Logger.Named(string(secrets.AzureKeyVault)),
+		vaultURL: strings.TrimSuffix(config.ServerURL, "/"),
+		name:     config.Name,
+		client:   client,
+		tokens:   tokens,
+	}
+
+	if err := keyVaultManager.Setup(); err != nil {
+		return nil, err
+	}
+
+	return keyVaultManager, nil
+}
+
+// Setup checks the access token of the vault can be obtained
+func (a *AzureKeyVaultSecretsManager) Setup() error {
+	if _, err := a.tokens.token(); err != nil {
+		return fmt.Errorf("unable to authenticate to Azure Key Vault, %w", err)
+	}
+
+	return nil
+}
+
+// secretBundle is the secret of the Key Vault REST API
+type secretBundle struct {
+	Value       string `json:"value"`
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// GetSecret gets the secret by name
+func (a *AzureKeyVaultSecretsManager) GetSecret(name string) ([]byte, error) {
+	var bundle secretBundle
+
+	if err := a.do(http.MethodGet, a.secretURL("secrets", name), nil, &bundle); err != nil {
+		return nil, err
+	}
+
+	if bundle.ContentType != secretContentType {
+		return []byte(bundle.Value), nil
+	}
+
+	return base64.StdEncoding.DecodeString(bundle.Value)
+}
+
+// SetSecret sets the secret to a provided value
+func (a *AzureKeyVaultSecretsManager) SetSecret(name string, value []byte) error {
+	// the Key Vault keeps the previous versions, so the existing secret is not overwritten
+	if a.HasSecret(name) {
+		return fmt.Errorf("%s already initialized", a.getSecretName(name))
+	}
+
+	return a.do(http.MethodPut, a.secretURL("secrets", name), &secretBundle{
+		Value:       base64.StdEncoding.EncodeToString(value),
+		ContentType: secretContentType,
+	}, nil)
+}
+
+// HasSecret checks if the secret is present
+func (a *AzureKeyVaultSecretsManager) HasSecret(name string) bool {
+	_, err := a.GetSecret(name)
+
+	return err == nil
+}
+
+// RemoveSecret deletes the secret and purges it, so the secret with the same name can be set again
+func (a *AzureKeyVaultSecretsManager) RemoveSecret(name string) error {
+	if err := a.do(http.MethodDelete, a.secretURL("secrets", name), nil, nil); err != nil {
+		return err
+	}
+
+	// the purge fails if the soft delete is disabled, or the purge is not allowed for the caller
+	if err := a.do(http.MethodDelete, a.secretURL("deletedsecrets", name), nil, nil); err != nil {
+		a.logger.Warn("Unable to purge the deleted secret", "name", a.getSecretName(name), "err", err)
+	}
+
+	return nil
+}
+
+// getSecretName returns the name of the secret in the vault, <node name>-<secret name>
+func (a *AzureKeyVaultSecretsManager) getSecretName(name string) string {
+	return fmt.Sprintf("%s-%s", a.name, name)
+}
+
+func (a *AzureKeyVaultSecretsManager) secretURL(collection, name string) string {
+	return fmt.Sprintf("%s/%s/%s?api-version=%s", a.vaultURL, collection, a.getSecretName(name), apiVersion)
+}
+
+// do sends the authorized request to the vault and decodes the response into the result, if given
+func (a *AzureKeyVaultSecretsManager) do(method, target string, body, result interface{}) error {
+	token, err := a.tokens.token()
+	if err != nil {
+		return fmt.Errorf("unable to authenticate to Azure Key Vault, %w", err)
+	}
+
+	var reqBody bytes.Buffer
+
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, target, &reqBody)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("azure key vault request failed, %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return secrets.ErrSecretNotFound
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newResponseError(resp)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// newResponseError returns the error of the failed request,
+// with the message of the Azure error response if there is one
+func newResponseError(resp *http.Response) error {
+	var errResp struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil || errResp.Error.Code == "" {
+		return fmt.Errorf("azure key vault request failed with status %d", resp.StatusCode)
+	}
+
+	return fmt.Errorf("azure key vault request failed with status %d: %s: %s",
+		resp.StatusCode, errResp.Error.Code, errResp.Error.Message)
+}
+
+// vaultURLValid checks the vault URL is an absolute URL
+func vaultURLValid(raw string) bool {
+	u, err := url.Parse(raw)
+
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
@@ -0,0 +1,206 @@
+package azurekeyvault
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/secrets"
+)
+
+const (
+	testTenant       = "tenant"
+	testClientID     = "client"
+	testClientSecret = "client-secret"
+	testAccessToken  = "access-token"
+)
+
+// keyVaultMock is the Azure AD and Key Vault emulator
+type keyVaultMock struct {
+	lock sync.Mutex
+
+	secrets       map[string]secretBundle
+	deleted       map[string]bool
+	tokenRequests int
+}
+
+func newKeyVaultMock() *keyVaultMock {
+	return &keyVaultMock{
+		secrets: make(map[string]secretBundle),
+		deleted: make(map[string]bool),
+	}
+}
+
+func (m *keyVaultMock) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if r.URL.Path == "/"+testTenant+"/oauth2/v2.0/token" {
+		m.tokenRequests++
+
+		if r.FormValue("client_id") != testClientID || r.FormValue("client_secret") != testClientSecret {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		writeJSON(w, map[string]interface{}{"access_token": testAccessToken, "expires_in": 3599})
+
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer "+testAccessToken || r.URL.Query().Get("api-version") != apiVersion {
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	collection, name, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+
+	switch {
+	case collection == "secrets" && r.Method == http.MethodGet:
+		bundle, ok := m.secrets[name]
+		if !ok {
+			writeNotFound(w)
+
+			return
+		}
+
+		writeJSON(w, bundle)
+
+	case collection == "secrets" && r.Method == http.MethodPut:
+		if m.deleted[name] {
+			// the soft-deleted secret has to be purged before it's set again
+			w.WriteHeader(http.StatusConflict)
+
+			return
+		}
+
+		var bundle secretBundle
+		_ = json.NewDecoder(r.Body).Decode(&bundle)
+
+		m.secrets[name] = bundle
+		writeJSON(w, bundle)
+
+	case collection == "secrets" && r.Method == http.MethodDelete:
+		if _, ok := m.secrets[name]; !ok {
+			writeNotFound(w)
+
+			return
+		}
+
+		delete(m.secrets, name)
+		m.deleted[name] = true
+
+	case collection == "deletedsecrets" && r.Method == http.MethodDelete:
+		delete(m.deleted, name)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(data)
+}
+
+func writeNotFound(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNotFound)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]string{"code": "SecretNotFound", "message": "secret not found"},
+	})
+}
+
+func TestAzureKeyVaultSecretsManager(t *testing.T) {
+	t.Parallel()
+
+	mock := newKeyVaultMock()
+
+	srv := httptest.NewServer(mock)
+	defer srv.Close()
+
+	manager, err := SecretsManagerFactory(&secrets.SecretsManagerConfig{
+		Token:     testClientSecret,
+		ServerURL: srv.URL,
+		Name:      "node-1",
+		Type:      secrets.AzureKeyVault,
+		Extra: map[string]interface{}{
+			string(tenantID):      testTenant,
+			string(clientID):      testClientID,
+			string(authorityHost): srv.URL,
+		},
+	}, &secrets.SecretsManagerParams{Logger: hclog.NewNullLogger()})
+	require.NoError(t, err)
+
+	require.False(t, manager.HasSecret(secrets.ValidatorKey))
+
+	_, err = manager.GetSecret(secrets.ValidatorKey)
+	require.ErrorIs(t, err, secrets.ErrSecretNotFound)
+
+	// the binary secrets are kept intact
+	value := []byte{0x00, 0xff, 0x10}
+
+	require.NoError(t, manager.SetSecret(secrets.ValidatorKey, value))
+	require.Error(t, manager.SetSecret(secrets.ValidatorKey, value))
+	require.Contains(t, mock.secrets, "node-1-"+secrets.ValidatorKey)
+
+	secret, err := manager.GetSecret(secrets.ValidatorKey)
+	require.NoError(t, err)
+	assert.Equal(t, value, secret)
+
+	// the removed secret is purged, so it can be set again
+	require.NoError(t, manager.RemoveSecret(secrets.ValidatorKey))
+	require.False(t, manager.HasSecret(secrets.ValidatorKey))
+	require.NoError(t, manager.SetSecret(secrets.ValidatorKey, value))
+
+	// the access token is cached
+	assert.Equal(t, 1, mock.tokenRequests)
+}
+
+func TestAzureKeyVaultSecretsManager_Config(t *testing.T) {
+	t.Parallel()
+
+	mock := newKeyVaultMock()
+
+	srv := httptest.NewServer(mock)
+	defer srv.Close()
+
+	newConfig := func(token, name string) *secrets.SecretsManagerConfig {
+		return &secrets.SecretsManagerConfig{
+			Token:     token,
+			ServerURL: srv.URL,
+			Name:      name,
+			Extra: map[string]interface{}{
+				string(tenantID):      testTenant,
+				string(clientID):      testClientID,
+				string(authorityHost): srv.URL,
+			},
+		}
+	}
+
+	params := &secrets.SecretsManagerParams{Logger: hclog.NewNullLogger()}
+
+	_, err := SecretsManagerFactory(newConfig(testClientSecret, "node_1"), params)
+	require.ErrorIs(t, err, errInvalidNodeName)
+
+	_, err = SecretsManagerFactory(newConfig("", "node"), params)
+	require.ErrorIs(t, err, errNoClientSecret)
+
+	_, err = SecretsManagerFactory(newConfig("wrong", "node"), params)
+	require.ErrorContains(t, err, "unable to authenticate")
+
+	config := newConfig(testClientSecret, "node")
+	config.ServerURL = "vault"
+
+	_, err = SecretsManagerFactory(config, params)
+	require.ErrorIs(t, err, errInvalidVaultURL)
+}
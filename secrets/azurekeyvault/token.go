@@ -0,0 +1,170 @@
+package azurekeyvault
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/secrets"
+)
+
+const (
+	defaultAuthorityHost = "https://login.microsoftonline.com"
+
+	// imdsTokenURL is the token endpoint of the managed identity of the Azure VMs
+	imdsTokenURL = "http://169.254.169.254/metadata/identity/oauth2/token"
+
+	vaultResource = "https://vault.azure.net"
+
+	// tokenExpiryMargin is how long before the expiry the token is renewed
+	tokenExpiryMargin = 5 * time.Minute
+)
+
+// tokenSource obtains the access tokens of the vault, either with the client credentials
+// of a service principal, or with the managed identity of the host, and caches them until they expire
+type tokenSource struct {
+	client *http.Client
+
+	// tokenURL is the Azure AD token endpoint, or the managed identity endpoint
+	tokenURL string
+	// managedIdentity is true if the managed identity of the host is used
+	managedIdentity bool
+
+	clientID     string
+	clientSecret string
+
+	lock      sync.Mutex
+	cached    string
+	expiresAt time.Time
+}
+
+func newTokenSource(config *secrets.SecretsManagerConfig, client *http.Client) (*tokenSource, error) {
+	source := &tokenSource{
+		client:       client,
+		clientSecret: config.Token,
+	}
+
+	if id, ok := config.Extra[string(clientID)]; ok {
+		source.clientID = fmt.Sprintf("%s", id)
+	}
+
+	tenant, ok := config.Extra[string(tenantID)]
+	if !ok {
+		source.managedIdentity = true
+		source.tokenURL = imdsTokenURL
+
+		return source, nil
+	}
+
+	if source.clientID == "" {
+		return nil, errNoClientID
+	}
+
+	if source.clientSecret == "" {
+		return nil, errNoClientSecret
+	}
+
+	authority := defaultAuthorityHost
+	if host, ok := config.Extra[string(authorityHost)]; ok {
+		authority = strings.TrimSuffix(fmt.Sprintf("%s", host), "/")
+	}
+
+	source.tokenURL = fmt.Sprintf("%s/%s/oauth2/v2.0/token", authority, tenant)
+
+	return source, nil
+}
+
+// tokenResponse is the token response of Azure AD and of the managed identity endpoint,
+// the latter returns expires_in as a string
+type tokenResponse struct {
+	AccessToken string          `json:"access_token"`
+	ExpiresIn   json.RawMessage `json:"expires_in"`
+}
+
+// token returns the cached access token, or obtains a new one if it is about to expire
+func (t *tokenSource) token() (string, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.cached != "" && time.Now().Before(t.expiresAt) {
+		return t.cached, nil
+	}
+
+	req, err := t.newRequest()
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("token request failed, %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token request failed with status %d", resp.StatusCode)
+	}
+
+	var tokenResp tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", fmt.Errorf("unable to decode the token response, %w", err)
+	}
+
+	if tokenResp.AccessToken == "" {
+		return "", fmt.Errorf("the token response has no access token")
+	}
+
+	expiresIn, err := strconv.ParseInt(strings.Trim(string(tokenResp.ExpiresIn), `"`), 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse the token expiry, %w", err)
+	}
+
+	t.cached = tokenResp.AccessToken
+	t.expiresAt = time.Now().Add(time.Duration(expiresIn)*time.Second - tokenExpiryMargin)
+
+	return t.cached, nil
+}
+
+func (t *tokenSource) newRequest() (*http.Request, error) {
+	if t.managedIdentity {
+		query := url.Values{
+			"api-version": {"2018-02-01"},
+			"resource":    {vaultResource},
+		}
+
+		// the user-assigned identity is selected by its client ID
+		if t.clientID != "" {
+			query.Set("client_id", t.clientID)
+		}
+
+		req, err := http.NewRequest(http.MethodGet, t.tokenURL+"?"+query.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Metadata", "true")
+
+		return req, nil
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {t.clientID},
+		"client_secret": {t.clientSecret},
+		"scope":         {vaultResource + "/.default"},
+	}
+
+	req, err := http.NewRequest(http.MethodPost, t.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return req, nil
+}
@@ -0,0 +1,273 @@
+package encryptedlocal
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/secrets"
+)
+
+// EncryptedLocalSecretsManager is a SecretsManager that stores the secrets locally on disk
+// as the keystore V3 JSON files, encrypted with the key derived from a passphrase.
+// The validator key file is compatible with the Ethereum wallets and clients
+type EncryptedLocalSecretsManager struct {
+	// Logger object
+	logger hclog.Logger
+
+	// Path to the base working directory
+	path string
+
+	// passphrase file path, the environment variable or the prompt are used if empty
+	passphraseFile string
+
+	// the light scrypt parameters are used to encrypt the secrets
+	lightKDF bool
+
+	// Map of known secrets and their paths
+	secretPathMap map[string]string
+
+	// the passphrase is only read once it is needed
+	passphrase     []byte
+	passphraseErr  error
+	passphraseOnce sync.Once
+}
+
+type configExtraParamFields string
+
+const (
+	passphraseFile configExtraParamFields = "passphrase-file"
+	lightKDF       configExtraParamFields = "light-kdf"
+
+	// PassphraseEnv is the environment variable the passphrase is read from,
+	// if the passphrase file is not set
+	PassphraseEnv = "POLYGON_EDGE_SECRETS_PASSPHRASE"
+
+	keystoreExt = ".json"
+)
+
+var (
+	errNoPath          = errors.New("no path specified for encrypted local secrets manager")
+	errNoPassphrase    = fmt.Errorf("no passphrase given, set the %s or the %s variable", passphraseFile, PassphraseEnv)
+	errEmptyPassphrase = errors.New("the passphrase can not be empty")
+)
+
+// SecretsManagerFactory implements the factory method
+func SecretsManagerFactory(
+	config *secrets.SecretsManagerConfig,
+	params *secrets.SecretsManagerParams,
+) (secrets.SecretsManager, error) {
+	localManager := &EncryptedLocalSecretsManager{
+		logger:        params.Logger.Named(string(secrets.EncryptedLocal)),
+		secretPathMap: make(map[string]string),
+	}
+
+	// the path of the config takes precedence over the data directory of the server
+	path, ok := config.Extra[secrets.Path]
+	if !ok {
+		path, ok = params.Extra[secrets.Path]
+	}
+
+	if !ok || fmt.Sprintf("%s", path) == "" {
+		return nil, errNoPath
+	}
+
+	localManager.path = fmt.Sprintf("%s", path)
+
+	if file, ok := config.Extra[string(passphraseFile)]; ok {
+		localManager.passphraseFile = fmt.Sprintf("%s", file)
+	}
+
+	if light, ok := config.Extra[string(lightKDF)]; ok {
+		localManager.lightKDF = fmt.Sprintf("%v", light) == "true"
+	}
+
+	if err := localManager.Setup(); err != nil {
+		return nil, err
+	}
+
+	return localManager, nil
+}
+
+// Setup sets up the directories of the encrypted local SecretsManager
+func (l *EncryptedLocalSecretsManager) Setup() error {
+	subDirectories := []string{secrets.ConsensusFolderLocal, secrets.NetworkFolderLocal}
+
+	if err := common.SetupDataDir(l.path, subDirectories, 0770); err != nil {
+		return err
+	}
+
+	// baseDir/consensus/validator.key.json
+	l.secretPathMap[secrets.ValidatorKey] = filepath.Join(
+		l.path,
+		secrets.ConsensusFolderLocal,
+		secrets.ValidatorKeyLocal+keystoreExt,
+	)
+
+	// baseDir/consensus/validator-bls.key.json
+	l.secretPathMap[secrets.ValidatorBLSKey] = filepath.Join(
+		l.path,
+		secrets.ConsensusFolderLocal,
+		secrets.ValidatorBLSKeyLocal+keystoreExt,
+	)
+
+	// baseDir/libp2p/libp2p.key.json
+	l.secretPathMap[secrets.NetworkKey] = filepath.Join(
+		l.path,
+		secrets.NetworkFolderLocal,
+		secrets.NetworkKeyLocal+keystoreExt,
+	)
+
+	return nil
+}
+
+// GetSecret reads the keystore of the secret and decrypts it with the passphrase
+func (l *EncryptedLocalSecretsManager) GetSecret(name string) ([]byte, error) {
+	secretPath, ok := l.secretPathMap[name]
+	if !ok {
+		return nil, secrets.ErrSecretNotFound
+	}
+
+	keystoreJSON, err := os.ReadFile(secretPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, secrets.ErrSecretNotFound
+		}
+
+		return nil, fmt.Errorf("unable to read secret from disk (%s), %w", secretPath, err)
+	}
+
+	passphrase, err := l.getPassphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := decryptKeystore(keystoreJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt secret (%s), %w", secretPath, err)
+	}
+
+	// the keystore holds the raw ECDSA key, the secrets manager returns it hex encoded
+	if name == secrets.ValidatorKey {
+		return []byte(hex.EncodeToString(secret)), nil
+	}
+
+	return secret, nil
+}
+
+// SetSecret encrypts the secret with the passphrase and saves the keystore to disk
+func (l *EncryptedLocalSecretsManager) SetSecret(name string, value []byte) error {
+	secretPath, ok := l.secretPathMap[name]
+	if !ok {
+		return secrets.ErrSecretNotFound
+	}
+
+	if common.FileExists(secretPath) {
+		return fmt.Errorf("%s already initialized", secretPath)
+	}
+
+	passphrase, err := l.getPassphrase()
+	if err != nil {
+		return err
+	}
+
+	var address string
+
+	if name == secrets.ValidatorKey {
+		// the raw key and the address are kept, as the wallets expect them
+		if value, err = hex.DecodeString(strings.TrimSpace(string(value))); err != nil {
+			return fmt.Errorf("invalid validator key, %w", err)
+		}
+
+		privateKey, err := crypto.ParseECDSAPrivateKey(value)
+		if err != nil {
+			return fmt.Errorf("invalid validator key, %w", err)
+		}
+
+		address = crypto.PubKeyToAddress(&privateKey.PublicKey).String()
+	}
+
+	scryptN, scryptP := standardScryptN, standardScryptP
+	if l.lightKDF {
+		scryptN, scryptP = lightScryptN, lightScryptP
+	}
+
+	keystoreJSON, err := encryptKeystore(value, passphrase, address, scryptN, scryptP)
+	if err != nil {
+		return fmt.Errorf("unable to encrypt secret, %w", err)
+	}
+
+	if err := common.SaveFileSafe(secretPath, keystoreJSON, 0440); err != nil {
+		return fmt.Errorf("unable to write secret to disk (%s), %w", secretPath, err)
+	}
+
+	return nil
+}
+
+// HasSecret checks if the keystore of the secret is present on disk
+func (l *EncryptedLocalSecretsManager) HasSecret(name string) bool {
+	secretPath, ok := l.secretPathMap[name]
+
+	return ok && common.FileExists(secretPath)
+}
+
+// RemoveSecret removes the keystore of the secret from disk
+func (l *EncryptedLocalSecretsManager) RemoveSecret(name string) error {
+	secretPath, ok := l.secretPathMap[name]
+	if !ok {
+		return secrets.ErrSecretNotFound
+	}
+
+	if err := os.Remove(secretPath); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return secrets.ErrSecretNotFound
+		}
+
+		return fmt.Errorf("unable to remove secret, %w", err)
+	}
+
+	return nil
+}
+
+// getPassphrase reads the passphrase from the passphrase file, the environment variable
+// or the terminal prompt, in that order
+func (l *EncryptedLocalSecretsManager) getPassphrase() ([]byte, error) {
+	l.passphraseOnce.Do(func() {
+		switch {
+		case l.passphraseFile != "":
+			passphrase, err := os.ReadFile(l.passphraseFile)
+			if err != nil {
+				l.passphraseErr = fmt.Errorf("unable to read the passphrase file, %w", err)
+
+				return
+			}
+
+			l.passphrase = []byte(strings.TrimRight(string(passphrase), "\r\n"))
+		case os.Getenv(PassphraseEnv) != "":
+			l.passphrase = []byte(os.Getenv(PassphraseEnv))
+		default:
+			passphrase, err := promptPassphrase(fmt.Sprintf("Passphrase of the secrets in %s: ", l.path))
+			if err != nil {
+				l.passphraseErr = err
+
+				return
+			}
+
+			l.passphrase = passphrase
+		}
+
+		if l.passphraseErr == nil && len(l.passphrase) == 0 {
+			l.passphraseErr = errEmptyPassphrase
+		}
+	})
+
+	return l.passphrase, l.passphraseErr
+}
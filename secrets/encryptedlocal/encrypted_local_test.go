@@ -0,0 +1,114 @@
+package encryptedlocal
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/secrets"
+)
+
+func newTestManager(t *testing.T, dir, passphrase string) secrets.SecretsManager {
+	t.Helper()
+
+	passphrasePath := filepath.Join(t.TempDir(), "passphrase")
+	require.NoError(t, os.WriteFile(passphrasePath, []byte(passphrase+"\n"), 0600))
+
+	manager, err := SecretsManagerFactory(
+		&secrets.SecretsManagerConfig{
+			Type: secrets.EncryptedLocal,
+			Extra: map[string]interface{}{
+				string(passphraseFile): passphrasePath,
+				string(lightKDF):       "true",
+			},
+		},
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+			Extra:  map[string]interface{}{secrets.Path: dir},
+		},
+	)
+	require.NoError(t, err)
+
+	return manager
+}
+
+func TestEncryptedLocalSecretsManager(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	manager := newTestManager(t, dir, "passphrase")
+
+	privateKey, validatorKey, err := crypto.GenerateAndEncodeECDSAPrivateKey()
+	require.NoError(t, err)
+
+	require.False(t, manager.HasSecret(secrets.ValidatorKey))
+	require.NoError(t, manager.SetSecret(secrets.ValidatorKey, validatorKey))
+	require.NoError(t, manager.SetSecret(secrets.NetworkKey, []byte("network key")))
+	require.Error(t, manager.SetSecret(secrets.NetworkKey, []byte("network key")))
+	require.True(t, manager.HasSecret(secrets.ValidatorKey))
+
+	value, err := manager.GetSecret(secrets.ValidatorKey)
+	require.NoError(t, err)
+	require.Equal(t, validatorKey, value)
+
+	// the validator keystore is the V3 keystore of the address
+	keystoreJSON, err := os.ReadFile(filepath.Join(dir, secrets.ConsensusFolderLocal, "validator.key.json"))
+	require.NoError(t, err)
+	require.NotContains(t, string(keystoreJSON), string(validatorKey))
+
+	var ks keystoreV3
+	require.NoError(t, json.Unmarshal(keystoreJSON, &ks))
+	require.Equal(t, 3, ks.Version)
+	require.Equal(t, hex.EncodeToString(crypto.PubKeyToAddress(&privateKey.PublicKey).Bytes()), ks.Address)
+
+	// the secrets can't be read with another passphrase
+	_, err = newTestManager(t, dir, "another passphrase").GetSecret(secrets.NetworkKey)
+	require.ErrorIs(t, err, ErrInvalidPassphrase)
+
+	value, err = newTestManager(t, dir, "passphrase").GetSecret(secrets.NetworkKey)
+	require.NoError(t, err)
+	require.Equal(t, []byte("network key"), value)
+
+	require.NoError(t, manager.RemoveSecret(secrets.NetworkKey))
+	require.False(t, manager.HasSecret(secrets.NetworkKey))
+	require.ErrorIs(t, manager.RemoveSecret(secrets.NetworkKey), secrets.ErrSecretNotFound)
+
+	_, err = manager.GetSecret(secrets.ValidatorBLSKey)
+	require.ErrorIs(t, err, secrets.ErrSecretNotFound)
+}
+
+func TestDecryptKeystore_PBKDF2(t *testing.T) {
+	t.Parallel()
+
+	// the PBKDF2 test vector of the Web3 Secret Storage definition
+	keystoreJSON := []byte(`{
+		"crypto": {
+			"cipher": "aes-128-ctr",
+			"cipherparams": {"iv": "6087dab2f9fdbbfaddc31a909735c1e6"},
+			"ciphertext": "5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46",
+			"kdf": "pbkdf2",
+			"kdfparams": {
+				"c": 262144,
+				"dklen": 32,
+				"prf": "hmac-sha256",
+				"salt": "ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"
+			},
+			"mac": "517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"
+		},
+		"id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+		"version": 3
+	}`)
+
+	secret, err := decryptKeystore(keystoreJSON, []byte("testpassword"))
+	require.NoError(t, err)
+	require.Equal(t, "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d", hex.EncodeToString(secret))
+
+	_, err = decryptKeystore(keystoreJSON, []byte("wrongpassword"))
+	require.ErrorIs(t, err, ErrInvalidPassphrase)
+}
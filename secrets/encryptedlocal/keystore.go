@@ -0,0 +1,207 @@
+package encryptedlocal
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+)
+
+const (
+	keystoreVersion = 3
+
+	cipherAES128CTR = "aes-128-ctr"
+	kdfScrypt       = "scrypt"
+	kdfPBKDF2       = "pbkdf2"
+	prfHMACSHA256   = "hmac-sha256"
+
+	scryptDKLen = 32
+	scryptR     = 8
+
+	// the standard scrypt parameters of the V3 keystores, about 256MB of memory and a second of CPU
+	standardScryptN = 1 << 18
+	standardScryptP = 1
+
+	// the light scrypt parameters, about 4MB of memory and 100ms of CPU
+	lightScryptN = 1 << 12
+	lightScryptP = 6
+)
+
+var (
+	ErrInvalidPassphrase = errors.New("could not decrypt the keystore with the given passphrase")
+
+	errUnsupportedVersion = errors.New("unsupported keystore version")
+	errUnsupportedCipher  = errors.New("unsupported keystore cipher")
+	errUnsupportedKDF     = errors.New("unsupported keystore key derivation function")
+)
+
+// keystoreV3 is the Web3 Secret Storage (keystore V3) JSON file
+type keystoreV3 struct {
+	Address string       `json:"address,omitempty"`
+	Crypto  cryptoParams `json:"crypto"`
+	ID      string       `json:"id"`
+	Version int          `json:"version"`
+}
+
+type cryptoParams struct {
+	Cipher       string                 `json:"cipher"`
+	CipherText   string                 `json:"ciphertext"`
+	CipherParams cipherParams           `json:"cipherparams"`
+	KDF          string                 `json:"kdf"`
+	KDFParams    map[string]interface{} `json:"kdfparams"`
+	MAC          string                 `json:"mac"`
+}
+
+type cipherParams struct {
+	IV string `json:"iv"`
+}
+
+// encryptKeystore encrypts the secret with the key derived from the passphrase by scrypt.
+// The address is only set for the ECDSA keys, so the keystore can be imported into the wallets
+func encryptKeystore(secret, passphrase []byte, address string, scryptN, scryptP int) ([]byte, error) {
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	derivedKey, err := scrypt.Key(passphrase, salt, scryptN, scryptR, scryptP, scryptDKLen)
+	if err != nil {
+		return nil, err
+	}
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, err
+	}
+
+	cipherText, err := aesCTRXOR(derivedKey[:16], secret, iv)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(&keystoreV3{
+		Address: strings.ToLower(strings.TrimPrefix(address, "0x")),
+		Crypto: cryptoParams{
+			Cipher:       cipherAES128CTR,
+			CipherText:   hex.EncodeToString(cipherText),
+			CipherParams: cipherParams{IV: hex.EncodeToString(iv)},
+			KDF:          kdfScrypt,
+			KDFParams: map[string]interface{}{
+				"n":     scryptN,
+				"r":     scryptR,
+				"p":     scryptP,
+				"dklen": scryptDKLen,
+				"salt":  hex.EncodeToString(salt),
+			},
+			MAC: hex.EncodeToString(crypto.Keccak256(derivedKey[16:32], cipherText)),
+		},
+		ID:      uuid.NewString(),
+		Version: keystoreVersion,
+	}, "", "  ")
+}
+
+// decryptKeystore decrypts the secret of the keystore V3 JSON, either scrypt or pbkdf2 derived
+func decryptKeystore(keystoreJSON, passphrase []byte) ([]byte, error) {
+	var ks keystoreV3
+	if err := json.Unmarshal(keystoreJSON, &ks); err != nil {
+		return nil, fmt.Errorf("invalid keystore: %w", err)
+	}
+
+	if ks.Version != keystoreVersion {
+		return nil, fmt.Errorf("%w: %d", errUnsupportedVersion, ks.Version)
+	}
+
+	if ks.Crypto.Cipher != cipherAES128CTR {
+		return nil, fmt.Errorf("%w: %s", errUnsupportedCipher, ks.Crypto.Cipher)
+	}
+
+	cipherText, err := hex.DecodeString(ks.Crypto.CipherText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore ciphertext: %w", err)
+	}
+
+	iv, err := hex.DecodeString(ks.Crypto.CipherParams.IV)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore iv: %w", err)
+	}
+
+	mac, err := hex.DecodeString(ks.Crypto.MAC)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore mac: %w", err)
+	}
+
+	derivedKey, err := deriveKey(&ks.Crypto, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(crypto.Keccak256(derivedKey[16:32], cipherText), mac) {
+		return nil, ErrInvalidPassphrase
+	}
+
+	return aesCTRXOR(derivedKey[:16], cipherText, iv)
+}
+
+// deriveKey derives the encryption key from the passphrase with the KDF params of the keystore
+func deriveKey(params *cryptoParams, passphrase []byte) ([]byte, error) {
+	salt, err := hex.DecodeString(getString(params.KDFParams, "salt"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore salt: %w", err)
+	}
+
+	dkLen := getInt(params.KDFParams, "dklen")
+	if dkLen < 32 {
+		return nil, fmt.Errorf("invalid keystore derived key length: %d", dkLen)
+	}
+
+	switch params.KDF {
+	case kdfScrypt:
+		return scrypt.Key(passphrase, salt,
+			getInt(params.KDFParams, "n"), getInt(params.KDFParams, "r"), getInt(params.KDFParams, "p"), dkLen)
+	case kdfPBKDF2:
+		if prf := getString(params.KDFParams, "prf"); prf != prfHMACSHA256 {
+			return nil, fmt.Errorf("%w: pbkdf2 with %s", errUnsupportedKDF, prf)
+		}
+
+		return pbkdf2.Key(passphrase, salt, getInt(params.KDFParams, "c"), dkLen, sha256.New), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedKDF, params.KDF)
+	}
+}
+
+func aesCTRXOR(key, in, iv []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, len(in))
+	cipher.NewCTR(block, iv).XORKeyStream(out, in)
+
+	return out, nil
+}
+
+func getString(params map[string]interface{}, name string) string {
+	value, _ := params[name].(string)
+
+	return value
+}
+
+func getInt(params map[string]interface{}, name string) int {
+	// the JSON numbers are decoded as floats
+	value, _ := params[name].(float64)
+
+	return int(value)
+}
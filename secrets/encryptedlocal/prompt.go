@@ -0,0 +1,33 @@
+package encryptedlocal
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// promptPassphrase reads the passphrase from the terminal, without echoing it
+func promptPassphrase(prompt string) ([]byte, error) {
+	fd := int(os.Stdin.Fd())
+
+	restore, err := disableEcho(fd)
+	if err != nil {
+		// the standard input is not a terminal, the passphrase has to be given otherwise
+		return nil, errNoPassphrase
+	}
+
+	defer func() {
+		restore()
+		fmt.Fprintln(os.Stderr)
+	}()
+
+	fmt.Fprint(os.Stderr, prompt)
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the passphrase, %w", err)
+	}
+
+	return []byte(strings.TrimRight(line, "\r\n")), nil
+}
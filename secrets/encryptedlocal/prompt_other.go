@@ -0,0 +1,10 @@
+//go:build !linux && !darwin
+
+package encryptedlocal
+
+import "errors"
+
+// disableEcho is not supported, the passphrase is never prompted for
+func disableEcho(_ int) (func(), error) {
+	return nil, errors.New("the passphrase prompt is not supported on this platform")
+}
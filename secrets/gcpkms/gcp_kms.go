@@ -0,0 +1,201 @@
+package gcpkms
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/api/cloudkms/v1"
+	"google.golang.org/api/option"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/secrets"
+)
+
+// GCPKMSSecretsManager is a SecretsManager that envelope-encrypts the secrets
+// with a Google Cloud KMS key and keeps the ciphertexts on disk.
+// The plaintext secrets never touch the disk, and they can't be read without access to the key
+type GCPKMSSecretsManager struct {
+	// full resource name of the crypto key,
+	// projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>
+	keyName string
+	// directory with the encrypted secrets
+	dir string
+	// credential file path, the application default credentials are used if empty
+	credFilePath string
+	// endpoint of the KMS API, used with the emulators
+	endpoint string
+	// node name is used to create unique secret file names
+	nodeName string
+	// KMS client
+	client *cloudkms.Service
+	// logger instance
+	logger hclog.Logger
+	// context used in API calls
+	context context.Context
+}
+
+type configExtraParamFields string
+
+const (
+	kmsKey         configExtraParamFields = "kms-key"
+	secretsDir     configExtraParamFields = "secrets-dir"
+	gcpKMSCredFile configExtraParamFields = "gcp-kms-cred"
+
+	// encryptedSecretExt is the extension of the encrypted secret files
+	encryptedSecretExt = ".kms"
+)
+
+var (
+	errNoKMSKey     = fmt.Errorf("no %s variable specified", kmsKey)
+	errNoSecretsDir = fmt.Errorf("no %s variable specified", secretsDir)
+	errParamsEmpty  = fmt.Errorf("name, %s or %s, can not be an empty string", kmsKey, secretsDir)
+)
+
+func SecretsManagerFactory(
+	config *secrets.SecretsManagerConfig,
+	params *secrets.SecretsManagerParams,
+) (secrets.SecretsManager, error) {
+	// Check if the key and the directory are defined
+	if _, ok := config.Extra[string(kmsKey)]; !ok {
+		return nil, errNoKMSKey
+	}
+
+	if _, ok := config.Extra[string(secretsDir)]; !ok {
+		return nil, errNoSecretsDir
+	}
+
+	if config.Name == "" || config.Extra[string(kmsKey)] == "" || config.Extra[string(secretsDir)] == "" {
+		return nil, errParamsEmpty
+	}
+
+	kmsManager := &GCPKMSSecretsManager{
+		keyName:  fmt.Sprintf("%s", config.Extra[string(kmsKey)]),
+		dir:      fmt.Sprintf("%s", config.Extra[string(secretsDir)]),
+		endpoint: config.ServerURL,
+		nodeName: config.Name,
+		logger:   params.Logger.Named(string(secrets.GCPKMS)),
+	}
+
+	if credFile, ok := config.Extra[string(gcpKMSCredFile)]; ok {
+		kmsManager.credFilePath = fmt.Sprintf("%s", credFile)
+	}
+
+	if err := kmsManager.Setup(); err != nil {
+		return nil, err
+	}
+
+	return kmsManager, nil
+}
+
+// Setup creates the KMS client and the secrets directory
+func (gm *GCPKMSSecretsManager) Setup() error {
+	var (
+		opts      []option.ClientOption
+		clientErr error
+	)
+
+	if gm.credFilePath != "" {
+		opts = append(opts, option.WithCredentialsFile(gm.credFilePath))
+	}
+
+	if gm.endpoint != "" {
+		// the emulators don't authenticate the requests
+		opts = append(opts, option.WithEndpoint(gm.endpoint))
+
+		if gm.credFilePath == "" {
+			opts = append(opts, option.WithoutAuthentication())
+		}
+	}
+
+	gm.context = context.Background()
+
+	gm.client, clientErr = cloudkms.NewService(gm.context, opts...)
+	if clientErr != nil {
+		return fmt.Errorf("could not initialize new GCP KMS client %w", clientErr)
+	}
+
+	if err := common.CreateDirSafe(gm.dir, 0750); err != nil {
+		return fmt.Errorf("could not create the secrets directory, %w", err)
+	}
+
+	return nil
+}
+
+// GetSecret reads the encrypted secret and decrypts it with the KMS key
+func (gm *GCPKMSSecretsManager) GetSecret(name string) ([]byte, error) {
+	ciphertext, err := os.ReadFile(gm.getSecretPath(name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, secrets.ErrSecretNotFound
+		}
+
+		return nil, fmt.Errorf("unable to read the encrypted secret, %w", err)
+	}
+
+	resp, err := gm.client.Projects.Locations.KeyRings.CryptoKeys.Decrypt(gm.keyName, &cloudkms.DecryptRequest{
+		Ciphertext:                  string(ciphertext),
+		AdditionalAuthenticatedData: gm.getAdditionalData(name),
+	}).Context(gm.context).Do()
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt secret with GCP KMS: %w", err)
+	}
+
+	return base64.StdEncoding.DecodeString(resp.Plaintext)
+}
+
+// SetSecret encrypts the secret with the KMS key and saves the ciphertext
+func (gm *GCPKMSSecretsManager) SetSecret(name string, value []byte) error {
+	path := gm.getSecretPath(name)
+
+	if common.FileExists(path) {
+		return fmt.Errorf("%s already initialized", path)
+	}
+
+	resp, err := gm.client.Projects.Locations.KeyRings.CryptoKeys.Encrypt(gm.keyName, &cloudkms.EncryptRequest{
+		Plaintext:                   base64.StdEncoding.EncodeToString(value),
+		AdditionalAuthenticatedData: gm.getAdditionalData(name),
+	}).Context(gm.context).Do()
+	if err != nil {
+		return fmt.Errorf("could not encrypt secret with GCP KMS: %w", err)
+	}
+
+	if err := common.SaveFileSafe(path, []byte(resp.Ciphertext), 0440); err != nil {
+		return fmt.Errorf("unable to write the encrypted secret to disk (%s), %w", path, err)
+	}
+
+	return nil
+}
+
+// HasSecret checks if the encrypted secret is present
+func (gm *GCPKMSSecretsManager) HasSecret(name string) bool {
+	return common.FileExists(gm.getSecretPath(name))
+}
+
+// RemoveSecret removes the encrypted secret from disk
+func (gm *GCPKMSSecretsManager) RemoveSecret(name string) error {
+	if err := os.Remove(gm.getSecretPath(name)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return secrets.ErrSecretNotFound
+		}
+
+		return fmt.Errorf("unable to remove secret, %w", err)
+	}
+
+	return nil
+}
+
+// getSecretPath returns the path of the encrypted secret, <dir>/<nodeName>_<secretName>.kms
+func (gm *GCPKMSSecretsManager) getSecretPath(secretName string) string {
+	return filepath.Join(gm.dir, fmt.Sprintf("%s_%s%s", gm.nodeName, secretName, encryptedSecretExt))
+}
+
+// getAdditionalData binds the ciphertext to the node and the secret name,
+// so the encrypted secret files can't be swapped
+func (gm *GCPKMSSecretsManager) getAdditionalData(secretName string) string {
+	return base64.StdEncoding.EncodeToString([]byte(gm.nodeName + "/" + secretName))
+}
@@ -0,0 +1,152 @@
+package gcpkms
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/secrets"
+)
+
+const testKeyName = "projects/test/locations/global/keyRings/edge/cryptoKeys/secrets"
+
+// kmsMock is the Cloud KMS emulator, it "encrypts" by prepending the additional data to the plaintext
+type kmsMock struct {
+	t *testing.T
+}
+
+func (m *kmsMock) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name, method, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/v1/"), ":")
+	if name != testKeyName {
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+
+	var req struct {
+		Plaintext                   string `json:"plaintext"`
+		Ciphertext                  string `json:"ciphertext"`
+		AdditionalAuthenticatedData string `json:"additionalAuthenticatedData"`
+	}
+
+	require.NoError(m.t, json.NewDecoder(r.Body).Decode(&req))
+
+	switch method {
+	case "encrypt":
+		writeJSON(w, map[string]string{
+			"ciphertext": req.AdditionalAuthenticatedData + "." + req.Plaintext,
+		})
+
+	case "decrypt":
+		aad, plaintext, _ := strings.Cut(req.Ciphertext, ".")
+		if aad != req.AdditionalAuthenticatedData {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		writeJSON(w, map[string]string{"plaintext": plaintext})
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(data)
+}
+
+func newTestManager(t *testing.T, serverURL, dir, name string) secrets.SecretsManager {
+	t.Helper()
+
+	manager, err := SecretsManagerFactory(&secrets.SecretsManagerConfig{
+		ServerURL: serverURL,
+		Name:      name,
+		Type:      secrets.GCPKMS,
+		Extra: map[string]interface{}{
+			string(kmsKey):     testKeyName,
+			string(secretsDir): dir,
+		},
+	}, &secrets.SecretsManagerParams{Logger: hclog.NewNullLogger()})
+	require.NoError(t, err)
+
+	return manager
+}
+
+func TestGCPKMSSecretsManager(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(&kmsMock{t: t})
+	defer srv.Close()
+
+	dir := t.TempDir()
+	manager := newTestManager(t, srv.URL+"/", dir, "node")
+
+	require.False(t, manager.HasSecret(secrets.ValidatorKey))
+
+	_, err := manager.GetSecret(secrets.ValidatorKey)
+	require.ErrorIs(t, err, secrets.ErrSecretNotFound)
+
+	require.NoError(t, manager.SetSecret(secrets.ValidatorKey, []byte("validator")))
+	require.True(t, manager.HasSecret(secrets.ValidatorKey))
+	require.Error(t, manager.SetSecret(secrets.ValidatorKey, []byte("validator")))
+
+	value, err := manager.GetSecret(secrets.ValidatorKey)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("validator"), value)
+
+	// only the ciphertext is kept on disk
+	path := manager.(*GCPKMSSecretsManager).getSecretPath(secrets.ValidatorKey) //nolint:forcetypeassert
+	ciphertext, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotEqual(t, base64.StdEncoding.EncodeToString([]byte("validator")), string(ciphertext))
+
+	// the ciphertext of another node can't be decrypted
+	other := newTestManager(t, srv.URL+"/", dir, "other")
+	require.NoError(t, other.SetSecret(secrets.ValidatorKey, []byte("other")))
+
+	otherPath := other.(*GCPKMSSecretsManager).getSecretPath(secrets.ValidatorKey) //nolint:forcetypeassert
+	require.NoError(t, manager.RemoveSecret(secrets.ValidatorKey))
+
+	ciphertext, err = os.ReadFile(otherPath)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, ciphertext, 0600))
+
+	_, err = manager.GetSecret(secrets.ValidatorKey)
+	require.Error(t, err)
+
+	require.NoError(t, manager.RemoveSecret(secrets.ValidatorKey))
+	require.ErrorIs(t, manager.RemoveSecret(secrets.ValidatorKey), secrets.ErrSecretNotFound)
+}
+
+func TestGCPKMSSecretsManager_Config(t *testing.T) {
+	t.Parallel()
+
+	params := &secrets.SecretsManagerParams{Logger: hclog.NewNullLogger()}
+
+	_, err := SecretsManagerFactory(&secrets.SecretsManagerConfig{
+		Name:  "node",
+		Extra: map[string]interface{}{string(secretsDir): t.TempDir()},
+	}, params)
+	require.ErrorIs(t, err, errNoKMSKey)
+
+	_, err = SecretsManagerFactory(&secrets.SecretsManagerConfig{
+		Name:  "node",
+		Extra: map[string]interface{}{string(kmsKey): testKeyName},
+	}, params)
+	require.ErrorIs(t, err, errNoSecretsDir)
+
+	_, err = SecretsManagerFactory(&secrets.SecretsManagerConfig{
+		Extra: map[string]interface{}{string(kmsKey): testKeyName, string(secretsDir): t.TempDir()},
+	}, params)
+	require.ErrorIs(t, err, errParamsEmpty)
+}
@@ -3,6 +3,7 @@ package hashicorpvault
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/hashicorp/go-hclog"
@@ -32,6 +33,12 @@ type VaultSecretsManager struct {
 
 	// The namespace under which the secrets are stored
 	namespace string
+
+	// The mount path of the transit engine holding the validator key, empty if the key is stored in KV-2
+	transitMount string
+
+	// The name of the validator key in the transit engine
+	transitKey string
 }
 
 // SecretsManagerFactory implements the factory method
@@ -74,6 +81,16 @@ func SecretsManagerFactory(
 	// Set the base path to store the secrets in the KV-2 Vault storage
 	vaultManager.basePath = fmt.Sprintf("secret/data/%s", vaultManager.name)
 
+	// The validator key is held by the transit engine, if its mount is set
+	if mount, ok := config.Extra[transitMountExtra]; ok && fmt.Sprint(mount) != "" {
+		vaultManager.transitMount = strings.Trim(fmt.Sprint(mount), "/")
+		vaultManager.transitKey = fmt.Sprintf("%s-%s", vaultManager.name, secrets.ValidatorKey)
+
+		if key, ok := config.Extra[transitKeyExtra]; ok && fmt.Sprint(key) != "" {
+			vaultManager.transitKey = fmt.Sprint(key)
+		}
+	}
+
 	// Run the initial setup
 	_ = vaultManager.Setup()
 
@@ -108,8 +125,39 @@ func (v *VaultSecretsManager) constructSecretPath(name string) string {
 	return fmt.Sprintf("%s/%s", v.basePath, name)
 }
 
+// IsRemoteKey checks if the key is held by the transit engine
+func (v *VaultSecretsManager) IsRemoteKey(name string) bool {
+	return v.transitMount != "" && name == secrets.ValidatorKey
+}
+
+// GetSigner returns the signer of the transit key
+func (v *VaultSecretsManager) GetSigner(name string) (secrets.Signer, error) {
+	if !v.IsRemoteKey(name) {
+		return nil, secrets.ErrSecretNotFound
+	}
+
+	return newTransitSigner(v.client, v.transitMount, v.transitKey)
+}
+
+// CreateKey creates the key in the transit engine and returns its signer
+func (v *VaultSecretsManager) CreateKey(name string) (secrets.Signer, error) {
+	if !v.IsRemoteKey(name) {
+		return nil, secrets.ErrSecretNotFound
+	}
+
+	if err := createTransitKey(v.client, v.transitMount, v.transitKey); err != nil {
+		return nil, err
+	}
+
+	return newTransitSigner(v.client, v.transitMount, v.transitKey)
+}
+
 // GetSecret fetches a secret from the Hashicorp Vault server
 func (v *VaultSecretsManager) GetSecret(name string) ([]byte, error) {
+	if v.IsRemoteKey(name) {
+		return nil, secrets.ErrRemoteKey
+	}
+
 	secret, err := v.client.Logical().Read(v.constructSecretPath(name))
 	if err != nil {
 		return nil, fmt.Errorf("unable to read secret from Vault, %w", err)
@@ -162,6 +210,10 @@ func (v *VaultSecretsManager) GetSecret(name string) ([]byte, error) {
 // SetSecret saves a secret to the Hashicorp Vault server
 // Secrets saved in Vault need to have a string value (Base64)
 func (v *VaultSecretsManager) SetSecret(name string, value []byte) error {
+	if v.IsRemoteKey(name) {
+		return secrets.ErrRemoteKey
+	}
+
 	// Check if overwrite is possible
 	_, err := v.GetSecret(name)
 	if err == nil {
@@ -188,6 +240,12 @@ func (v *VaultSecretsManager) SetSecret(name string, value []byte) error {
 
 // HasSecret checks if the secret is present on the Hashicorp Vault server
 func (v *VaultSecretsManager) HasSecret(name string) bool {
+	if v.IsRemoteKey(name) {
+		_, err := v.GetSigner(name)
+
+		return err == nil
+	}
+
 	_, err := v.GetSecret(name)
 
 	return err == nil
@@ -195,6 +253,10 @@ func (v *VaultSecretsManager) HasSecret(name string) bool {
 
 // RemoveSecret removes a secret from the Hashicorp Vault server
 func (v *VaultSecretsManager) RemoveSecret(name string) error {
+	if v.IsRemoteKey(name) {
+		return secrets.ErrRemoteKey
+	}
+
 	// Check if overwrite is possible
 	_, err := v.GetSecret(name)
 	if err != nil {
@@ -0,0 +1,205 @@
+package hashicorpvault
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	vault "github.com/hashicorp/vault/api"
+	"github.com/umbracle/ethgo"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// transitMountExtra is the extra config entry with the mount path of the transit engine,
+	// the validator key is held by the transit engine if it is set
+	transitMountExtra = "transit-mount"
+
+	// transitKeyExtra is the extra config entry with the name of the transit key, <name>-validator-key by default
+	transitKeyExtra = "transit-key"
+
+	// transitKeyType is the type of the transit key, the transit engine has to support secp256k1 keys
+	transitKeyType = "ecdsa-secp256k1"
+)
+
+var (
+	errTransitKeyType      = fmt.Errorf("the transit key is not of the %s type", transitKeyType)
+	errTransitNoPublicKey  = errors.New("the transit key has no public key")
+	errTransitNoSignature  = errors.New("the transit engine returned no signature")
+	errTransitSignature    = errors.New("the signature of the transit engine doesn't match its key")
+	errTransitDigestLength = fmt.Errorf("the digest has to be %d bytes long", types.HashLength)
+)
+
+var (
+	secp256k1N     = crypto.S256.Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+// transitSigner signs the digests with the key of the Vault transit engine,
+// the private key never leaves Vault
+type transitSigner struct {
+	client *vault.Client
+	mount  string
+	key    string
+
+	address ethgo.Address
+}
+
+// newTransitSigner reads the public key of the transit key and returns its signer
+func newTransitSigner(client *vault.Client, mount, key string) (*transitSigner, error) {
+	secret, err := client.Logical().Read(fmt.Sprintf("%s/keys/%s", mount, key))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the transit key from Vault, %w", err)
+	}
+
+	if secret == nil {
+		return nil, secrets.ErrSecretNotFound
+	}
+
+	pub, err := transitPublicKey(secret.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transitSigner{
+		client:  client,
+		mount:   mount,
+		key:     key,
+		address: ethgo.Address(crypto.PubKeyToAddress(pub)),
+	}, nil
+}
+
+// Address returns the address of the transit key
+func (t *transitSigner) Address() ethgo.Address {
+	return t.address
+}
+
+// Sign signs the digest with the transit key
+func (t *transitSigner) Sign(digest []byte) ([]byte, error) {
+	if len(digest) != types.HashLength {
+		return nil, errTransitDigestLength
+	}
+
+	secret, err := t.client.Logical().Write(fmt.Sprintf("%s/sign/%s", t.mount, t.key), map[string]interface{}{
+		"input":                base64.StdEncoding.EncodeToString(digest),
+		"prehashed":            true,
+		"hash_algorithm":       "sha2-256",
+		"marshaling_algorithm": "asn1",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign with the transit key, %w", err)
+	}
+
+	if secret == nil {
+		return nil, errTransitNoSignature
+	}
+
+	signature, ok := secret.Data["signature"].(string)
+	if !ok {
+		return nil, errTransitNoSignature
+	}
+
+	return toRecoverableSignature(signature, digest, t.address)
+}
+
+// createTransitKey creates the non-exportable secp256k1 key in the transit engine
+func createTransitKey(client *vault.Client, mount, key string) error {
+	if _, err := client.Logical().Write(fmt.Sprintf("%s/keys/%s", mount, key), map[string]interface{}{
+		"type":       transitKeyType,
+		"exportable": false,
+	}); err != nil {
+		return fmt.Errorf("unable to create the transit key, %w", err)
+	}
+
+	return nil
+}
+
+// transitPublicKey parses the public key of the latest version of the transit key
+func transitPublicKey(data map[string]interface{}) (*ecdsa.PublicKey, error) {
+	if keyType, _ := data["type"].(string); keyType != transitKeyType {
+		return nil, fmt.Errorf("%w: %s", errTransitKeyType, keyType)
+	}
+
+	versions, ok := data["keys"].(map[string]interface{})
+	if !ok {
+		return nil, errTransitNoPublicKey
+	}
+
+	latest, ok := versions[fmt.Sprint(data["latest_version"])].(map[string]interface{})
+	if !ok {
+		return nil, errTransitNoPublicKey
+	}
+
+	encoded, ok := latest["public_key"].(string)
+	if !ok {
+		return nil, errTransitNoPublicKey
+	}
+
+	block, _ := pem.Decode([]byte(encoded))
+	if block == nil {
+		return nil, errTransitNoPublicKey
+	}
+
+	// the standard library doesn't support the secp256k1 curve, so the key is taken out of the PKIX structure
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+
+	if _, err := asn1.Unmarshal(block.Bytes, &spki); err != nil {
+		return nil, fmt.Errorf("unable to parse the transit public key, %w", err)
+	}
+
+	return crypto.ParsePublicKey(spki.PublicKey.Bytes)
+}
+
+// toRecoverableSignature converts the ASN.1 signature of the transit engine ("vault:v<version>:<base64>")
+// to the [R || S || V] format. S is normalized to the lower half of the curve order,
+// and the recovery ID is found by recovering the address of the key
+func toRecoverableSignature(signature string, digest []byte, address ethgo.Address) ([]byte, error) {
+	parts := strings.Split(signature, ":")
+	if len(parts) != 3 || parts[0] != "vault" {
+		return nil, fmt.Errorf("unexpected transit signature format %q", signature)
+	}
+
+	der, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode the transit signature, %w", err)
+	}
+
+	var rs struct {
+		R, S *big.Int
+	}
+
+	if _, err := asn1.Unmarshal(der, &rs); err != nil {
+		return nil, fmt.Errorf("unable to parse the transit signature, %w", err)
+	}
+
+	if rs.S.Cmp(secp256k1HalfN) > 0 {
+		rs.S = new(big.Int).Sub(secp256k1N, rs.S)
+	}
+
+	sig := make([]byte, 65)
+	rs.R.FillBytes(sig[:32])
+	rs.S.FillBytes(sig[32:64])
+
+	for v := byte(0); v < 2; v++ {
+		sig[64] = v
+
+		pub, err := crypto.RecoverPubkey(sig, digest)
+		if err == nil && ethgo.Address(crypto.PubKeyToAddress(pub)) == address {
+			return sig, nil
+		}
+	}
+
+	return nil, errTransitSignature
+}
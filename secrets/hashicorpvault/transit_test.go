@@ -0,0 +1,166 @@
+package hashicorpvault
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/secrets"
+)
+
+// transitMock is the transit engine of the Vault server holding a single secp256k1 key
+type transitMock struct {
+	lock sync.Mutex
+	key  *ecdsa.PrivateKey
+}
+
+func (m *transitMock) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	switch {
+	case r.URL.Path == "/v1/transit/keys/node-validator-key" && r.Method == http.MethodGet:
+		if m.key == nil {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		spki, _ := asn1.Marshal(struct {
+			Algorithm pkix.AlgorithmIdentifier
+			PublicKey asn1.BitString
+		}{
+			Algorithm: pkix.AlgorithmIdentifier{
+				Algorithm:  asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1},
+				Parameters: asn1.RawValue{FullBytes: []byte{0x06, 0x05, 0x2b, 0x81, 0x04, 0x00, 0x0a}},
+			},
+			PublicKey: asn1.BitString{Bytes: crypto.MarshalPublicKey(&m.key.PublicKey)},
+		})
+
+		writeData(w, map[string]interface{}{
+			"type":           transitKeyType,
+			"latest_version": 1,
+			"keys": map[string]interface{}{
+				"1": map[string]interface{}{
+					"public_key": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: spki})),
+				},
+			},
+		})
+
+	case r.URL.Path == "/v1/transit/keys/node-validator-key":
+		m.key, _ = crypto.GenerateECDSAKey()
+
+		w.WriteHeader(http.StatusNoContent)
+
+	case r.URL.Path == "/v1/transit/sign/node-validator-key":
+		var req struct {
+			Input string `json:"input"`
+		}
+
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		digest, _ := base64.StdEncoding.DecodeString(req.Input)
+
+		r, s, err := ecdsa.Sign(rand.Reader, m.key, digest)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		der, _ := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+
+		writeData(w, map[string]interface{}{
+			"signature": "vault:v1:" + base64.StdEncoding.EncodeToString(der),
+		})
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func writeData(w http.ResponseWriter, data map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+}
+
+func TestVaultSecretsManager_Transit(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(&transitMock{})
+	defer srv.Close()
+
+	manager, err := SecretsManagerFactory(&secrets.SecretsManagerConfig{
+		Token:     "token",
+		ServerURL: srv.URL,
+		Name:      "node",
+		Extra:     map[string]interface{}{transitMountExtra: "transit"},
+	}, &secrets.SecretsManagerParams{Logger: hclog.NewNullLogger()})
+	require.NoError(t, err)
+
+	signer, ok := secrets.GetRemoteSigner(manager, secrets.ValidatorKey)
+	require.True(t, ok)
+
+	_, ok = secrets.GetRemoteSigner(manager, secrets.ValidatorBLSKey)
+	require.False(t, ok)
+
+	require.False(t, manager.HasSecret(secrets.ValidatorKey))
+
+	key, err := signer.CreateKey(secrets.ValidatorKey)
+	require.NoError(t, err)
+	require.True(t, manager.HasSecret(secrets.ValidatorKey))
+
+	// the signatures are recoverable, so the key can sign the blocks and the transactions
+	for i := 0; i < 10; i++ {
+		digest := crypto.Keccak256([]byte{byte(i)})
+
+		signature, err := key.Sign(digest)
+		require.NoError(t, err)
+		require.Len(t, signature, 65)
+
+		pub, err := crypto.RecoverPubkey(signature, digest)
+		require.NoError(t, err)
+		assert.Equal(t, key.Address(), ethgo.Address(crypto.PubKeyToAddress(pub)))
+	}
+
+	// the private key never leaves the transit engine
+	_, err = manager.GetSecret(secrets.ValidatorKey)
+	require.ErrorIs(t, err, secrets.ErrRemoteKey)
+	require.ErrorIs(t, manager.SetSecret(secrets.ValidatorKey, []byte("key")), secrets.ErrRemoteKey)
+}
+
+func Test_toRecoverableSignature_Invalid(t *testing.T) {
+	t.Parallel()
+
+	digest := crypto.Keccak256([]byte("digest"))
+
+	_, err := toRecoverableSignature("signature", digest, ethgo.ZeroAddress)
+	require.Error(t, err)
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest)
+	require.NoError(t, err)
+
+	der, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+	require.NoError(t, err)
+
+	// the signature of another key
+	_, err = toRecoverableSignature("vault:v1:"+base64.StdEncoding.EncodeToString(der), digest, ethgo.ZeroAddress)
+	require.ErrorIs(t, err, errTransitSignature)
+}
@@ -13,6 +13,9 @@ import (
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/secrets/awsssm"
+	"github.com/0xPolygon/polygon-edge/secrets/azurekeyvault"
+	"github.com/0xPolygon/polygon-edge/secrets/encryptedlocal"
+	"github.com/0xPolygon/polygon-edge/secrets/gcpkms"
 	"github.com/0xPolygon/polygon-edge/secrets/gcpssm"
 	"github.com/0xPolygon/polygon-edge/secrets/hashicorpvault"
 	"github.com/0xPolygon/polygon-edge/secrets/local"
@@ -32,6 +35,18 @@ func SetupLocalSecretsManager(dataDir string) (secrets.SecretsManager, error) {
 	)
 }
 
+// setupEncryptedLocal is a helper method for boilerplate encrypted local secrets manager setup
+func setupEncryptedLocal(
+	secretsConfig *secrets.SecretsManagerConfig,
+) (secrets.SecretsManager, error) {
+	return encryptedlocal.SecretsManagerFactory(
+		secretsConfig,
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+		},
+	)
+}
+
 // setupHashicorpVault is a helper method for boilerplate hashicorp vault secrets manager setup
 func setupHashicorpVault(
 	secretsConfig *secrets.SecretsManagerConfig,
@@ -68,6 +83,30 @@ func setupGCPSSM(
 	)
 }
 
+// setupGCPKMS is a helper method for boilerplate Google Cloud KMS secrets manager setup
+func setupGCPKMS(
+	secretsConfig *secrets.SecretsManagerConfig,
+) (secrets.SecretsManager, error) {
+	return gcpkms.SecretsManagerFactory(
+		secretsConfig,
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+		},
+	)
+}
+
+// setupAzureKeyVault is a helper method for boilerplate Azure Key Vault secrets manager setup
+func setupAzureKeyVault(
+	secretsConfig *secrets.SecretsManagerConfig,
+) (secrets.SecretsManager, error) {
+	return azurekeyvault.SecretsManagerFactory(
+		secretsConfig,
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+		},
+	)
+}
+
 // InitECDSAValidatorKey creates new ECDSA key and set as a validator key
 func InitECDSAValidatorKey(secretsManager secrets.SecretsManager) (types.Address, error) {
 	if secretsManager.HasSecret(secrets.ValidatorKey) {
@@ -212,6 +251,13 @@ func InitCloudSecretsManager(secretsConfig *secrets.SecretsManagerConfig) (secre
 	var secretsManager secrets.SecretsManager
 
 	switch secretsConfig.Type {
+	case secrets.EncryptedLocal:
+		encryptedLocal, err := setupEncryptedLocal(secretsConfig)
+		if err != nil {
+			return secretsManager, err
+		}
+
+		secretsManager = encryptedLocal
 	case secrets.HashicorpVault:
 		vault, err := setupHashicorpVault(secretsConfig)
 		if err != nil {
@@ -233,6 +279,20 @@ func InitCloudSecretsManager(secretsConfig *secrets.SecretsManagerConfig) (secre
 		}
 
 		secretsManager = GCPSSM
+	case secrets.GCPKMS:
+		GCPKMS, err := setupGCPKMS(secretsConfig)
+		if err != nil {
+			return secretsManager, err
+		}
+
+		secretsManager = GCPKMS
+	case secrets.AzureKeyVault:
+		azureKeyVault, err := setupAzureKeyVault(secretsConfig)
+		if err != nil {
+			return secretsManager, err
+		}
+
+		secretsManager = azureKeyVault
 	default:
 		return secretsManager, errors.New("unsupported secrets manager")
 	}
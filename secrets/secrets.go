@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/hashicorp/go-hclog"
+	"github.com/umbracle/ethgo"
 )
 
 // Define constant key names for SecretsManagerParams.Extra
@@ -48,6 +49,9 @@ const (
 
 var (
 	ErrSecretNotFound = errors.New("secret not found")
+
+	// ErrRemoteKey is returned when the secret is a key held remotely, which can't be read or written
+	ErrRemoteKey = errors.New("the key is held by the remote signer and never leaves it")
 )
 
 type SecretsManagerType string
@@ -57,6 +61,9 @@ const (
 	// Local pertains to the local FS [Default]
 	Local SecretsManagerType = "local"
 
+	// EncryptedLocal pertains to the local FS, with the secrets encrypted by a passphrase
+	EncryptedLocal SecretsManagerType = "encrypted-local"
+
 	// HashicorpVault pertains to the Hashicorp Vault server
 	HashicorpVault SecretsManagerType = "hashicorp-vault"
 
@@ -65,6 +72,12 @@ const (
 
 	// GCPSSM pertains to the Google Cloud Computing secret store manager
 	GCPSSM SecretsManagerType = "gcp-ssm"
+
+	// GCPKMS pertains to the secrets encrypted with a Google Cloud KMS key
+	GCPKMS SecretsManagerType = "gcp-kms"
+
+	// AzureKeyVault pertains to the Azure Key Vault secret store
+	AzureKeyVault SecretsManagerType = "azure-key-vault"
 )
 
 // SecretsManager defines the base public interface that all
@@ -86,6 +99,39 @@ type SecretsManager interface {
 	RemoveSecret(name string) error
 }
 
+// Signer produces the secp256k1 signatures of the 32 byte digests
+// in the [R || S || V] format, where V is 0 or 1
+type Signer interface {
+	// Address returns the address of the key
+	Address() ethgo.Address
+
+	// Sign signs the digest
+	Sign(digest []byte) ([]byte, error)
+}
+
+// RemoteSigner is implemented by the secrets managers which hold some of the signing keys remotely
+// and produce the signatures themselves, so the private keys never leave the manager
+type RemoteSigner interface {
+	// IsRemoteKey checks if the key of the secret is held remotely
+	IsRemoteKey(name string) bool
+
+	// GetSigner returns the signer of the remote key
+	GetSigner(name string) (Signer, error)
+
+	// CreateKey creates the remote key and returns its signer
+	CreateKey(name string) (Signer, error)
+}
+
+// GetRemoteSigner returns the remote signer of the manager, if it holds the key of the secret remotely
+func GetRemoteSigner(manager SecretsManager, name string) (RemoteSigner, bool) {
+	signer, ok := manager.(RemoteSigner)
+	if !ok || !signer.IsRemoteKey(name) {
+		return nil, false
+	}
+
+	return signer, true
+}
+
 // SecretsManagerParams defines the configuration params for the
 // secrets manager
 type SecretsManagerParams struct {
@@ -110,5 +156,6 @@ type SecretsManagerFactory func(
 // SupportedServiceManager checks if the passed in service manager type is supported
 func SupportedServiceManager(service SecretsManagerType) bool {
 	return service == HashicorpVault || service == AWSSSM ||
-		service == Local || service == GCPSSM
+		service == Local || service == EncryptedLocal || service == GCPSSM ||
+		service == GCPKMS || service == AzureKeyVault
 }
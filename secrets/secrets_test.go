@@ -17,6 +17,11 @@ func TestSupportedServiceManager(t *testing.T) {
 			Local,
 			true,
 		},
+		{
+			"Valid encrypted local secrets manager",
+			EncryptedLocal,
+			true,
+		},
 		{
 			"Valid Hashicorp Vault secrets manager",
 			HashicorpVault,
@@ -32,6 +37,16 @@ func TestSupportedServiceManager(t *testing.T) {
 			GCPSSM,
 			true,
 		},
+		{
+			"Valid GCP KMS secrets manager",
+			GCPKMS,
+			true,
+		},
+		{
+			"Valid Azure Key Vault secrets manager",
+			AzureKeyVault,
+			true,
+		},
 		{
 			"Invalid secrets manager",
 			"MarsSecretsManager",
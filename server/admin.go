@@ -0,0 +1,262 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/0xPolygon/polygon-edge/consensus"
+	"github.com/0xPolygon/polygon-edge/helper/logging"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/0xPolygon/polygon-edge/txpool"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// adminMethodPrefix is the prefix of the gRPC methods which require the admin token
+const adminMethodPrefix = "/v1.System/Admin"
+
+var (
+	errProposingNotSupported  = errors.New("the consensus engine doesn't support pausing the block proposals")
+	errStateSnapshotDisabled  = errors.New("the state snapshot is disabled")
+	errEmptyAdminToken        = errors.New("the admin token file is empty")
+	errDebugWithoutAdminToken = errors.New("the debug server requires the admin token")
+)
+
+// proposingConsensus is implemented by the consensus engines whose block proposals can be paused
+type proposingConsensus interface {
+	SetProposingPaused(paused bool)
+	ProposingPaused() bool
+}
+
+// nodeControls are the operational controls of the running node used by the admin APIs,
+// every change is logged to the audit log
+type nodeControls struct {
+	logger    hclog.Logger
+	consensus consensus.Consensus
+	txpool    *txpool.TxPool
+	logLevels *logging.Levels
+
+	// stateSnapshot is set once the snapshot follows the chain, nil if disabled
+	stateSnapshot atomic.Pointer[stateSnapshot]
+}
+
+func newNodeControls(
+	logger hclog.Logger,
+	consensus consensus.Consensus,
+	txpool *txpool.TxPool,
+	logLevels *logging.Levels,
+) *nodeControls {
+	return &nodeControls{
+		logger:    logger.Named("admin"),
+		consensus: consensus,
+		txpool:    txpool,
+		logLevels: logLevels,
+	}
+}
+
+// SetProposingPaused pauses or resumes the block proposals of the node (maintenance mode),
+// the paused node keeps validating and voting on the blocks of the other proposers
+func (c *nodeControls) SetProposingPaused(paused bool) error {
+	proposing, ok := c.consensus.(proposingConsensus)
+	if !ok {
+		return errProposingNotSupported
+	}
+
+	proposing.SetProposingPaused(paused)
+
+	c.logger.Info("Applied admin control", "control", "proposing_paused", "value", paused)
+
+	return nil
+}
+
+// ProposingPaused returns true if the block proposals of the node are paused
+func (c *nodeControls) ProposingPaused() bool {
+	proposing, ok := c.consensus.(proposingConsensus)
+
+	return ok && proposing.ProposingPaused()
+}
+
+// SetTxPoolPaused pauses or resumes accepting the new transactions into the pool
+func (c *nodeControls) SetTxPoolPaused(paused bool) {
+	c.txpool.SetPaused(paused)
+
+	c.logger.Info("Applied admin control", "control", "txpool_paused", "value", paused)
+}
+
+// TxPoolPaused returns true if the pool doesn't accept the new transactions
+func (c *nodeControls) TxPoolPaused() bool {
+	return c.txpool.Paused()
+}
+
+// FlushTxPool drops all the transactions of the pool, it returns the number of the dropped transactions
+func (c *nodeControls) FlushTxPool() uint64 {
+	dropped := c.txpool.Flush()
+
+	c.logger.Info("Applied admin control", "control", "txpool_flush", "dropped", dropped)
+
+	return dropped
+}
+
+// SetLogLevel sets the log level of the module, or of the whole node if the module is empty.
+// hclog.NoLevel removes the override of the module
+func (c *nodeControls) SetLogLevel(module string, level hclog.Level) error {
+	if module == "" {
+		if level == hclog.NoLevel {
+			return fmt.Errorf("the log level of the node can't be %s", level)
+		}
+
+		c.logLevels.SetRoot(level)
+	} else if err := c.logLevels.SetModule(module, level); err != nil {
+		return err
+	}
+
+	c.logger.Info("Applied admin control", "control", "log_level", "module", module, "value", level.String())
+
+	return nil
+}
+
+// LogLevels returns the log level of the node and the overridden levels of the modules
+func (c *nodeControls) LogLevels() (hclog.Level, map[string]hclog.Level) {
+	return c.logLevels.Root(), c.logLevels.Overrides()
+}
+
+// RegenerateStateSnapshot rebuilds the flat state snapshot at the head of the chain in the background,
+// it returns the state root the snapshot is generated for
+func (c *nodeControls) RegenerateStateSnapshot() (types.Hash, error) {
+	snapshot := c.stateSnapshot.Load()
+	if snapshot == nil {
+		return types.ZeroHash, errStateSnapshotDisabled
+	}
+
+	root, err := snapshot.regenerate()
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	c.logger.Info("Applied admin control", "control", "state_snapshot_regenerate", "root", root)
+
+	return root, nil
+}
+
+// authorizeAdmin checks the request metadata carries the admin token as "authorization: Bearer <token>"
+func authorizeAdmin(ctx context.Context, adminToken string) error {
+	if adminToken == "" {
+		return status.Error(codes.Unauthenticated, "the admin controls are disabled, the node has no admin token")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	for _, value := range md.Get("authorization") {
+		token, ok := strings.CutPrefix(value, "Bearer ")
+		if ok && subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1 {
+			return nil
+		}
+	}
+
+	return status.Error(codes.Unauthenticated, "invalid admin token")
+}
+
+// readAdminToken reads the admin token from the file, the surrounding whitespace is ignored
+func readAdminToken(path string) (string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the admin token file: %w", err)
+	}
+
+	token := strings.TrimSpace(string(raw))
+	if token == "" {
+		return "", errEmptyAdminToken
+	}
+
+	return token, nil
+}
+
+// AdminStatus returns the state of the operational controls of the node
+func (s *systemService) AdminStatus(_ context.Context, _ *empty.Empty) (*proto.AdminStatusResponse, error) {
+	return s.adminStatus(), nil
+}
+
+// AdminSetProposingPaused pauses or resumes the block proposals of the node
+func (s *systemService) AdminSetProposingPaused(
+	_ context.Context,
+	req *proto.AdminPauseRequest,
+) (*proto.AdminStatusResponse, error) {
+	if err := s.server.controls.SetProposingPaused(req.Paused); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return s.adminStatus(), nil
+}
+
+// AdminSetTxPoolPaused pauses or resumes accepting the new transactions into the pool
+func (s *systemService) AdminSetTxPoolPaused(
+	_ context.Context,
+	req *proto.AdminPauseRequest,
+) (*proto.AdminStatusResponse, error) {
+	s.server.controls.SetTxPoolPaused(req.Paused)
+
+	return s.adminStatus(), nil
+}
+
+// AdminFlushTxPool drops all the transactions of the pool
+func (s *systemService) AdminFlushTxPool(_ context.Context, _ *empty.Empty) (*proto.AdminFlushTxPoolResponse, error) {
+	return &proto.AdminFlushTxPoolResponse{Dropped: s.server.controls.FlushTxPool()}, nil
+}
+
+// AdminSetLogLevel sets the log level of the module, or of the whole node if the module is empty
+func (s *systemService) AdminSetLogLevel(
+	_ context.Context,
+	req *proto.AdminSetLogLevelRequest,
+) (*proto.AdminStatusResponse, error) {
+	level, err := logging.ParseLevel(req.Level)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.server.controls.SetLogLevel(req.Module, level); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return s.adminStatus(), nil
+}
+
+// AdminRegenerateStateSnapshot regenerates the flat state snapshot at the head of the chain
+func (s *systemService) AdminRegenerateStateSnapshot(
+	_ context.Context,
+	_ *empty.Empty,
+) (*proto.AdminRegenerateStateSnapshotResponse, error) {
+	root, err := s.server.controls.RegenerateStateSnapshot()
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return &proto.AdminRegenerateStateSnapshotResponse{Root: root.String()}, nil
+}
+
+func (s *systemService) adminStatus() *proto.AdminStatusResponse {
+	controls := s.server.controls
+	root, overrides := controls.LogLevels()
+
+	resp := &proto.AdminStatusResponse{
+		ProposingPaused: controls.ProposingPaused(),
+		TxPoolPaused:    controls.TxPoolPaused(),
+		LogLevel:        root.String(),
+		ModuleLogLevels: make(map[string]string, len(overrides)),
+	}
+
+	for module, level := range overrides {
+		resp.ModuleLogLevels[module] = level.String()
+	}
+
+	return resp
+}
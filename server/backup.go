@@ -0,0 +1,89 @@
+package server
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/0xPolygon/polygon-edge/archive"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+)
+
+// backupBufferSize is the size of the in-memory connection the scheduled backups are streamed over
+const backupBufferSize = 1024 * 1024
+
+// backupService creates the scheduled backups over the in-memory connection to the system service,
+// the same way the backup command does over the gRPC endpoint of the node
+type backupService struct {
+	scheduler  *archive.BackupScheduler
+	conn       *grpc.ClientConn
+	grpcServer *grpc.Server
+}
+
+// setupBackupService starts the scheduled backups of the chain
+func (s *Server) setupBackupService() error {
+	config := *s.config.Backup
+
+	if s.config.BackupS3 != nil {
+		store, err := archive.NewS3Store(s.config.BackupS3)
+		if err != nil {
+			return err
+		}
+
+		config.Store = store
+	}
+
+	// the system service is served without the TLS and the admin token of the gRPC endpoint,
+	// it is reachable by the node only
+	lis := bufconn.Listen(backupBufferSize)
+	grpcServer := grpc.NewServer()
+
+	proto.RegisterSystemServer(grpcServer, &systemService{server: s})
+
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			s.logger.Error("backup gRPC server failed", "err", err)
+		}
+	}()
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		grpcServer.Stop()
+
+		return err
+	}
+
+	scheduler, err := archive.NewBackupScheduler(conn, s.logger, &config)
+	if err != nil {
+		conn.Close()
+		grpcServer.Stop()
+
+		return err
+	}
+
+	scheduler.Start()
+
+	s.backupService = &backupService{
+		scheduler:  scheduler,
+		conn:       conn,
+		grpcServer: grpcServer,
+	}
+
+	return nil
+}
+
+// close stops the scheduled backups, cancelling the backup in progress
+func (b *backupService) close() {
+	b.scheduler.Close()
+	b.conn.Close()
+	b.grpcServer.Stop()
+}
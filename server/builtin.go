@@ -10,10 +10,20 @@ import (
 	"github.com/0xPolygon/polygon-edge/forkmanager"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/secrets/awsssm"
+	"github.com/0xPolygon/polygon-edge/secrets/azurekeyvault"
+	"github.com/0xPolygon/polygon-edge/secrets/encryptedlocal"
+	"github.com/0xPolygon/polygon-edge/secrets/gcpkms"
 	"github.com/0xPolygon/polygon-edge/secrets/gcpssm"
 	"github.com/0xPolygon/polygon-edge/secrets/hashicorpvault"
 	"github.com/0xPolygon/polygon-edge/secrets/local"
 	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful/governance"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful/kvstore"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful/nativesupply"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful/randomness"
 )
 
 type GenesisFactoryHook func(config *chain.Chain, engineName string) func(*state.Transition) error
@@ -42,9 +52,12 @@ var consensusBackends = map[ConsensusType]consensus.Factory{
 // secret management solutions
 var secretsManagerBackends = map[secrets.SecretsManagerType]secrets.SecretsManagerFactory{
 	secrets.Local:          local.SecretsManagerFactory,
+	secrets.EncryptedLocal: encryptedlocal.SecretsManagerFactory,
 	secrets.HashicorpVault: hashicorpvault.SecretsManagerFactory,
 	secrets.AWSSSM:         awsssm.SecretsManagerFactory,
 	secrets.GCPSSM:         gcpssm.SecretsManagerFactory,
+	secrets.GCPKMS:         gcpkms.SecretsManagerFactory,
+	secrets.AzureKeyVault:  azurekeyvault.SecretsManagerFactory,
 }
 
 var genesisCreationFactory = map[ConsensusType]GenesisFactoryHook{
@@ -59,6 +72,25 @@ var forkManagerInitialParamsFactory = map[ConsensusType]ForkManagerInitialParams
 	PolyBFTConsensus: consensusPolyBFT.ForkManagerInitialParamsFactory,
 }
 
+// statefulPrecompileFactories defines the factories of the stateful precompiles
+// which can be configured in the chain params, by the name
+var statefulPrecompileFactories = map[string]stateful.Factory{
+	kvstore.Name:      kvstore.Factory,
+	governance.Name:   governance.Factory,
+	nativesupply.Name: nativesupply.Factory,
+	randomness.Name:   randomness.Factory,
+}
+
+// executionEngines defines the factories of the engines executing the contract code, by the name.
+// The experimental engines (e.g. the bindings of the native EVM implementations, built behind their build tags)
+// are added to compare their performance with the built-in EVM
+var executionEngines = map[string]runtime.Factory{
+	DefaultExecutionEngine: evm.Factory,
+}
+
+// DefaultExecutionEngine is the name of the built-in EVM
+const DefaultExecutionEngine = "evm"
+
 func ConsensusSupported(value string) bool {
 	_, ok := consensusBackends[ConsensusType(value)]
 
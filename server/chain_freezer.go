@@ -0,0 +1,244 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/freezer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// freezeInterval is the number of blocks between two freezing cycles
+	freezeInterval = 128
+
+	// freezeBatchSize is the number of blocks moved to the freezer before their data is deleted
+	// from the key-value store in a single batch
+	freezeBatchSize = 1024
+
+	chainFreezerMetrics = "chain_freezer"
+)
+
+// chainFreezer periodically moves the headers, the bodies and the receipts of the ancient blocks
+// from the key-value store to the append-only freezer
+type chainFreezer struct {
+	logger     hclog.Logger
+	db         storage.Storage
+	freezer    *freezer.Freezer
+	blockchain *blockchain.Blockchain
+
+	// threshold is the number of the most recent blocks whose data is kept in the key-value store
+	threshold uint64
+
+	subscription blockchain.Subscription
+	ctx          context.Context
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+
+	// lastFrozen is accessed by the event loop only
+	lastFrozen uint64
+	running    atomic.Bool
+}
+
+func newChainFreezer(
+	logger hclog.Logger,
+	db storage.Storage,
+	freezer *freezer.Freezer,
+	blockchain *blockchain.Blockchain,
+	threshold uint64,
+) (*chainFreezer, error) {
+	// the blocks are deleted from the key-value store once they are frozen,
+	// so the freezer can't be behind the key-value store
+	if frozen, _ := db.ReadFrozenBlocks(); freezer.Frozen() < frozen {
+		return nil, fmt.Errorf("freezer holds %d blocks, while %d blocks were moved to it", freezer.Frozen(), frozen)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &chainFreezer{
+		logger:     logger.Named("chain_freezer"),
+		db:         db,
+		freezer:    freezer,
+		blockchain: blockchain,
+		threshold:  threshold,
+		ctx:        ctx,
+		cancel:     cancel,
+	}, nil
+}
+
+// start freezes the ancient blocks, and then keeps freezing them as the chain grows
+func (f *chainFreezer) start() {
+	f.subscription = f.blockchain.SubscribeEvents()
+	f.onHead(f.blockchain.Header().Number)
+
+	f.wg.Add(1)
+
+	go func() {
+		defer f.wg.Done()
+
+		for {
+			ev := f.subscription.GetEvent()
+			if ev == nil {
+				return
+			}
+
+			if ev.Type == blockchain.EventFork || len(ev.NewChain) == 0 {
+				continue
+			}
+
+			f.onHead(ev.Header().Number)
+		}
+	}()
+}
+
+func (f *chainFreezer) onHead(number uint64) {
+	if f.running.Load() || (f.lastFrozen != 0 && number < f.lastFrozen+freezeInterval) {
+		return
+	}
+
+	f.lastFrozen = number
+	f.running.Store(true)
+	f.wg.Add(1)
+
+	go func() {
+		defer f.wg.Done()
+		defer f.running.Store(false)
+
+		if err := f.freeze(number); err != nil {
+			f.logger.Error("failed to freeze the ancient blocks", "block", number, "err", err)
+		}
+	}()
+}
+
+// freeze moves the data of the blocks which are past the threshold to the freezer
+func (f *chainFreezer) freeze(head uint64) error {
+	if head+1 <= f.threshold {
+		return nil
+	}
+
+	start := time.Now()
+
+	// the blocks frozen by the interrupted cycle are still deleted from the key-value store
+	from, _ := f.db.ReadFrozenBlocks()
+	to := head + 1 - f.threshold
+
+	if from >= to {
+		return nil
+	}
+
+	for batchFrom := from; batchFrom < to; batchFrom += freezeBatchSize {
+		if err := f.ctx.Err(); err != nil {
+			f.logger.Info("freezing interrupted", "block", batchFrom)
+
+			return nil
+		}
+
+		batchTo := batchFrom + freezeBatchSize
+		if batchTo > to {
+			batchTo = to
+		}
+
+		if err := f.freezeBatch(batchFrom, batchTo); err != nil {
+			return err
+		}
+	}
+
+	metrics.SetGauge([]string{chainFreezerMetrics, "frozen_blocks"}, float32(to))
+
+	f.logger.Info("ancient blocks frozen", "from", from, "to", to, "elapsed", time.Since(start))
+
+	return nil
+}
+
+// freezeBatch appends the blocks to the freezer, and deletes them from the key-value store once they are synced
+func (f *chainFreezer) freezeBatch(from, to uint64) error {
+	hashes := make([]types.Hash, 0, to-from)
+
+	for number := from; number < to; number++ {
+		hash, ok := f.db.ReadCanonicalHash(number)
+		if !ok {
+			return fmt.Errorf("canonical hash of block %d not found", number)
+		}
+
+		hashes = append(hashes, hash)
+
+		if number < f.freezer.Frozen() {
+			continue
+		}
+
+		items, err := f.readBlock(number, hash)
+		if err != nil {
+			return err
+		}
+
+		if err := f.freezer.Append(number, items); err != nil {
+			return err
+		}
+	}
+
+	if err := f.freezer.Sync(); err != nil {
+		return err
+	}
+
+	batchWriter := storage.NewBatchWriter(f.db)
+
+	for i, hash := range hashes {
+		batchWriter.PutAncientNumber(hash, from+uint64(i))
+		batchWriter.DeleteHeader(hash)
+		batchWriter.DeleteBody(hash)
+		batchWriter.DeleteReceipts(hash)
+	}
+
+	batchWriter.PutFrozenBlocks(to)
+
+	return batchWriter.WriteBatch()
+}
+
+// readBlock returns the items of the block kept by the freezer tables
+func (f *chainFreezer) readBlock(number uint64, hash types.Hash) (map[string][]byte, error) {
+	header, err := f.db.ReadHeader(hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the header of block %d: %w", number, err)
+	}
+
+	body, err := f.db.ReadBody(hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the body of block %d: %w", number, err)
+	}
+
+	// the pruned receipts are frozen as the empty items
+	var receiptsItem []byte
+
+	receipts, err := f.db.ReadReceipts(hash)
+	if err == nil {
+		rr := types.Receipts(receipts)
+		receiptsItem = storage.EncodeRLP(&rr)
+	} else if !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("failed to read the receipts of block %d: %w", number, err)
+	}
+
+	return map[string][]byte{
+		storage.AncientHeaders:  storage.EncodeRLP(header),
+		storage.AncientBodies:   storage.EncodeRLP(body),
+		storage.AncientReceipts: receiptsItem,
+	}, nil
+}
+
+// close stops the freezer, interrupting the running freezing cycle
+func (f *chainFreezer) close() {
+	if f.subscription != nil {
+		f.blockchain.UnsubscribeEvents(f.subscription)
+	}
+
+	f.cancel()
+	f.wg.Wait()
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+)
+
+// checkChainIntegrity verifies the data of the most recent blocks left by the last shutdown,
+// which may have been unclean. The inconsistent blocks are rolled back if allowed,
+// otherwise the node refuses to start until the storage is repaired
+func checkChainIntegrity(logger hclog.Logger, db storage.Storage, depth uint64, rollback bool) error {
+	report, err := blockchain.CheckIntegrity(db, depth, nil)
+	if err != nil {
+		return fmt.Errorf("integrity check failed: %w", err)
+	}
+
+	if report == nil {
+		return nil
+	}
+
+	if report.Consistent() {
+		logger.Info("integrity check passed", "head", report.Head, "from", report.From)
+
+		return nil
+	}
+
+	for _, inconsistency := range report.Inconsistencies {
+		logger.Error("inconsistent block", "number", inconsistency.Number, "reason", inconsistency.Reason)
+	}
+
+	last, ok := report.LastConsistent()
+	if !ok {
+		return blockchain.ErrGenesisInconsistent
+	}
+
+	if !rollback {
+		return fmt.Errorf("%d inconsistent blocks found below head %d, the last consistent block is %d: "+
+			"restart with --integrity-rollback or run polygon-edge storage repair",
+			len(report.Inconsistencies), report.Head, last)
+	}
+
+	removed, err := blockchain.Rollback(db, last)
+	if err != nil {
+		return fmt.Errorf("failed to roll back to block %d: %w", last, err)
+	}
+
+	logger.Warn("chain rolled back to the last consistent block", "number", last, "removed", removed)
+
+	return nil
+}
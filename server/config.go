@@ -6,9 +6,19 @@ import (
 
 	"github.com/hashicorp/go-hclog"
 
+	"github.com/0xPolygon/polygon-edge/archive"
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/remotesigner"
+	"github.com/0xPolygon/polygon-edge/ethstats"
+	"github.com/0xPolygon/polygon-edge/helper/kvdb"
+	"github.com/0xPolygon/polygon-edge/helper/tlsconfig"
+	"github.com/0xPolygon/polygon-edge/helper/tracing"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/streaming"
+	"github.com/0xPolygon/polygon-edge/syncer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/webhooks"
 )
 
 const DefaultGRPCPort int = 9632
@@ -22,9 +32,16 @@ type Config struct {
 	GRPCAddr   *net.TCPAddr
 	LibP2PAddr *net.TCPAddr
 
+	// GRPCTLS is the TLS configuration of the operator gRPC endpoint, TLS is disabled if nil
+	GRPCTLS *tlsconfig.Config
+
 	PriceLimit         uint64
 	MaxAccountEnqueued uint64
 	MaxSlots           uint64
+	UserOpEntryPoints  []types.Address
+
+	// TxGossipBatchInterval is the interval in which the local transactions are gossiped in batches, 0 disables it
+	TxGossipBatchInterval time.Duration
 
 	Telemetry *Telemetry
 	Network   *network.Config
@@ -37,6 +54,8 @@ type Config struct {
 	SecretsManager *secrets.SecretsManagerConfig
 
 	LogLevel hclog.Level
+	// ModuleLogLevels overrides the log level of the modules, by the module names
+	ModuleLogLevels map[string]hclog.Level
 
 	JSONLogFormat bool
 
@@ -46,19 +65,118 @@ type Config struct {
 
 	NumBlockConfirmations uint64
 	MetricsInterval       time.Duration
+
+	// BlockTrackerPollInterval overrides the poll interval of the block trackers set in the genesis, if not 0
+	BlockTrackerPollInterval time.Duration
+
+	// ProposalDeadline is the percentage of the block time after which the proposer seals the block
+	// with the transactions written so far
+	ProposalDeadline uint64
+
+	// StateHistory is the number of the most recent blocks whose state is retained,
+	// 0 retains the state of all blocks (archive mode)
+	StateHistory uint64
+
+	// StateSnapshot enables the flat snapshot of the head state
+	StateSnapshot bool
+
+	// ParallelExecution enables the experimental concurrent execution of the block transactions
+	ParallelExecution bool
+
+	// StatePrefetch enables the prefetching of the state read by the block transactions
+	StatePrefetch bool
+
+	// EVMStats enables the collection of the EVM opcode statistics
+	EVMStats bool
+
+	// ExecutionEngine is the name of the engine executing the contract code of the JSON-RPC calls and traces.
+	// The blocks are executed by it only if ExecutionEngineBlocks is set, otherwise by the built-in EVM
+	ExecutionEngine       string
+	ExecutionEngineBlocks bool
+
+	// LogIndex enables the index of the log blooms used by the log queries
+	LogIndex bool
+
+	// StateDiffCache is the number of the most recent blocks whose state diffs are kept,
+	// 0 disables the collection of the state diffs during the execution
+	StateDiffCache uint64
+
+	// MaxReorgDepth is the maximum number of the canonical blocks replaced by a reorganization
+	// of the chain of the non-finalizing consensus, 0 if unlimited
+	MaxReorgDepth uint64
+
+	// Backup is the schedule of the backups of the chain, nil if the backups aren't scheduled
+	Backup *archive.SchedulerConfig
+	// BackupS3 is the S3-compatible storage the scheduled backups are uploaded to, nil if they are kept locally
+	BackupS3 *archive.S3Config
+
+	// ReceiptsHistory and TxLookupHistory are the numbers of the most recent blocks
+	// whose receipts and transaction lookups are retained, 0 retains all of them
+	ReceiptsHistory uint64
+	TxLookupHistory uint64
+
+	// StorageBackend is the key-value database backend of the blockchain and the state storages
+	StorageBackend kvdb.Backend
+
+	// FreezerThreshold is the number of the most recent blocks whose data is kept in the key-value store,
+	// the data of the older blocks is moved to the freezer in FreezerDir. 0 disables the freezer
+	FreezerThreshold uint64
+	FreezerDir       string
+
+	// SyncMode is the way the syncer catches up with the peers
+	SyncMode syncer.SyncMode
+
+	// IntegrityCheckDepth is the number of the most recent blocks verified on startup, 0 disables the check.
+	// IntegrityRollback rolls the chain back to the last consistent block if the check fails
+	IntegrityCheckDepth uint64
+	IntegrityRollback   bool
+
+	// AdminTokenFile is the path to the file with the bearer token of the admin APIs,
+	// the admin controls are disabled if not set
+	AdminTokenFile string
+
+	// RemoteSigner is the signer service holding the validator keys, the keys of the secrets manager are used if nil
+	RemoteSigner *remotesigner.Config
+
+	// EthStats is the ethstats server the statistics of the node are reported to, nil if disabled
+	EthStats *ethstats.Config
+
+	// Webhooks are the endpoints the new blocks, logs and receipts are posted to
+	Webhooks []*webhooks.Endpoint
+
+	// Health are the readiness criteria of the node served on the JSON-RPC port
+	Health *HealthConfig
+
+	// Streaming is the broker the contract events and the block headers are published to, nil if disabled
+	Streaming *streaming.Config
 }
 
-// Telemetry holds the config details for metric services
+// Telemetry holds the config details for metric and tracing services
 type Telemetry struct {
 	PrometheusAddr *net.TCPAddr
+	// DebugAddr is the address of the pprof profiles and the runtime statistics, nil if disabled
+	DebugAddr *net.TCPAddr
+	Tracing   *tracing.Config
 }
 
 // JSONRPC holds the config details for the JSON-RPC server
 type JSONRPC struct {
-	JSONRPCAddr              *net.TCPAddr
-	AccessControlAllowOrigin []string
-	BatchLengthLimit         uint64
-	BlockRangeLimit          uint64
-	ConcurrentRequestsDebug  uint64
-	WebSocketReadLimit       uint64
+	JSONRPCAddr               *net.TCPAddr
+	AccessControlAllowOrigin  []string
+	BatchLengthLimit          uint64
+	BlockRangeLimit           uint64
+	ConcurrentRequestsDebug   uint64
+	WebSocketReadLimit        uint64
+	WebSocketMaxConnections   uint64
+	WebSocketIdleTimeout      time.Duration
+	WebSocketMaxSubscriptions uint64
+	WebSocketSendQueue        uint64
+	PendingTxsRateLimit       uint64
+	CallTimeout               time.Duration
+	CallCacheSize             uint64
+	EncodingCacheSize         uint64
+	MethodConcurrencyLimits   map[string]uint64
+	AccessLog                 bool
+	RateLimitsFile            string
+	TLS                       *tlsconfig.Config
 }
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"math/big"
+)
+
+// ethStatsNode provides the statistics of the node to the ethstats service
+type ethStatsNode struct {
+	server *Server
+}
+
+func (n *ethStatsNode) PeerCount() int {
+	return len(n.server.network.Peers())
+}
+
+func (n *ethStatsNode) Syncing() bool {
+	return n.server.restoreProgression.GetProgression() != nil || n.server.consensus.GetSyncProgression() != nil
+}
+
+// Mining reports if the node is an active validator
+func (n *ethStatsNode) Mining() bool {
+	if c, ok := n.server.consensus.(validatorConsensus); ok {
+		_, active := c.ValidatorStatus()
+
+		return active
+	}
+
+	return n.server.config.Seal
+}
+
+func (n *ethStatsNode) PendingTxs() uint64 {
+	return n.server.txpool.Length()
+}
+
+func (n *ethStatsNode) GasPrice() *big.Int {
+	return n.server.blockchain.GetAvgGasPrice()
+}
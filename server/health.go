@@ -0,0 +1,144 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
+)
+
+// HealthConfig are the criteria of the readiness of the node, the zero values disable the criteria
+type HealthConfig struct {
+	// MaxHeadAge is the maximum age of the head block
+	MaxHeadAge time.Duration
+	// MinPeers is the minimum number of the connected peers
+	MinPeers uint64
+	// MaxTrackerLag is the maximum number of the root chain blocks the event tracker is behind
+	MaxTrackerLag uint64
+	// MaxTxPoolUsage is the maximum percentage of the txpool slots in use
+	MaxTxPoolUsage uint64
+}
+
+// healthCheck is the result of a readiness criterion
+type healthCheck struct {
+	Name      string `json:"name"`
+	OK        bool   `json:"ok"`
+	Value     string `json:"value"`
+	Threshold string `json:"threshold"`
+}
+
+type healthStatus struct {
+	Status string         `json:"status"`
+	Checks []*healthCheck `json:"checks,omitempty"`
+}
+
+// healthHandler serves the liveness (/healthz) and the readiness (/readyz) of the node, the node which
+// is not ready is answered with 503, so the load balancers stop routing the requests to it
+type healthHandler struct {
+	server *Server
+	config *HealthConfig
+}
+
+func (s *Server) newHealthHandler() *healthHandler {
+	config := s.config.Health
+	if config == nil {
+		config = &HealthConfig{}
+	}
+
+	return &healthHandler{server: s, config: config}
+}
+
+func (h *healthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	status := &healthStatus{Status: "ok"}
+
+	if r.URL.Path == jsonrpc.ReadyzPath {
+		status.Checks = h.checks()
+
+		for _, check := range status.Checks {
+			if !check.OK {
+				status.Status = "unavailable"
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if status.Status != "ok" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	_ = json.NewEncoder(w).Encode(status)
+}
+
+// checks evaluates the enabled readiness criteria
+func (h *healthHandler) checks() []*healthCheck {
+	var checks []*healthCheck
+
+	if h.config.MaxHeadAge > 0 {
+		header := h.server.blockchain.Header()
+		age := time.Since(time.Unix(int64(header.Timestamp), 0)).Round(time.Second)
+
+		checks = append(checks, &healthCheck{
+			Name:      "head_age",
+			OK:        age <= h.config.MaxHeadAge,
+			Value:     age.String(),
+			Threshold: h.config.MaxHeadAge.String(),
+		})
+	}
+
+	if h.config.MinPeers > 0 {
+		peers := uint64(len(h.server.network.Peers()))
+
+		checks = append(checks, &healthCheck{
+			Name:      "peers",
+			OK:        peers >= h.config.MinPeers,
+			Value:     strconv.FormatUint(peers, 10),
+			Threshold: strconv.FormatUint(h.config.MinPeers, 10),
+		})
+	}
+
+	if h.config.MaxTrackerLag > 0 {
+		if c, ok := h.server.consensus.(trackerConsensus); ok {
+			if head, synced, ok := c.TrackerProgress(); ok {
+				lag := uint64(0)
+				if head > synced {
+					lag = head - synced
+				}
+
+				checks = append(checks, &healthCheck{
+					Name:      "tracker_lag",
+					OK:        lag <= h.config.MaxTrackerLag,
+					Value:     strconv.FormatUint(lag, 10),
+					Threshold: strconv.FormatUint(h.config.MaxTrackerLag, 10),
+				})
+			}
+		}
+	}
+
+	if h.config.MaxTxPoolUsage > 0 {
+		used, max := h.server.txpool.GetCapacity()
+
+		usage := uint64(0)
+		if max > 0 {
+			usage = used * 100 / max
+		}
+
+		checks = append(checks, &healthCheck{
+			Name:      "txpool_usage",
+			OK:        usage <= h.config.MaxTxPoolUsage,
+			Value:     fmt.Sprintf("%d%%", usage),
+			Threshold: fmt.Sprintf("%d%%", h.config.MaxTxPoolUsage),
+		})
+	}
+
+	return checks
+}
@@ -0,0 +1,223 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+)
+
+const (
+	// historyPruneInterval is the number of blocks between two pruning cycles of the receipts and tx lookups
+	historyPruneInterval = 128
+
+	// historyPruneBatchSize is the number of blocks whose data is deleted in a single batch
+	historyPruneBatchSize = 1024
+
+	historyPrunerMetrics = "history_pruner"
+)
+
+// historyPruner periodically deletes the receipts and the transaction lookups of the ancient blocks
+type historyPruner struct {
+	logger     hclog.Logger
+	db         storage.Storage
+	blockchain *blockchain.Blockchain
+
+	// receiptsHistory and txLookupHistory are the numbers of the most recent blocks
+	// whose receipts and transaction lookups are retained, 0 retains all of them
+	receiptsHistory uint64
+	txLookupHistory uint64
+
+	subscription blockchain.Subscription
+	ctx          context.Context
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+
+	// lastPruned is accessed by the event loop only
+	lastPruned uint64
+	running    atomic.Bool
+}
+
+func newHistoryPruner(
+	logger hclog.Logger,
+	db storage.Storage,
+	blockchain *blockchain.Blockchain,
+	receiptsHistory uint64,
+	txLookupHistory uint64,
+) *historyPruner {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &historyPruner{
+		logger:          logger.Named("history_pruner"),
+		db:              db,
+		blockchain:      blockchain,
+		receiptsHistory: receiptsHistory,
+		txLookupHistory: txLookupHistory,
+		ctx:             ctx,
+		cancel:          cancel,
+	}
+}
+
+// start prunes the history which is not retained, and then keeps pruning it as the chain grows
+func (p *historyPruner) start() {
+	p.subscription = p.blockchain.SubscribeEvents()
+	p.onHead(p.blockchain.Header().Number)
+
+	p.wg.Add(1)
+
+	go func() {
+		defer p.wg.Done()
+
+		for {
+			ev := p.subscription.GetEvent()
+			if ev == nil {
+				return
+			}
+
+			if ev.Type == blockchain.EventFork || len(ev.NewChain) == 0 {
+				continue
+			}
+
+			p.onHead(ev.Header().Number)
+		}
+	}()
+}
+
+func (p *historyPruner) onHead(number uint64) {
+	if p.running.Load() || (p.lastPruned != 0 && number < p.lastPruned+historyPruneInterval) {
+		return
+	}
+
+	p.lastPruned = number
+	p.running.Store(true)
+	p.wg.Add(1)
+
+	go func() {
+		defer p.wg.Done()
+		defer p.running.Store(false)
+
+		if err := p.prune(number); err != nil {
+			p.logger.Error("failed to prune the history", "block", number, "err", err)
+		}
+	}()
+}
+
+// prune deletes the receipts and the transaction lookups of the blocks which are no longer retained
+func (p *historyPruner) prune(head uint64) error {
+	start := time.Now()
+
+	receiptsTail, _ := p.db.ReadReceiptsTail()
+	txLookupTail, _ := p.db.ReadTxLookupTail()
+
+	newReceiptsTail := retainedTail(head, p.receiptsHistory, receiptsTail)
+	newTxLookupTail := retainedTail(head, p.txLookupHistory, txLookupTail)
+
+	// the range covers the blocks of both kinds of the data being pruned
+	from, to := uint64(math.MaxUint64), uint64(0)
+
+	if newReceiptsTail > receiptsTail {
+		from, to = common.Min(from, receiptsTail), common.Max(to, newReceiptsTail)
+	}
+
+	if newTxLookupTail > txLookupTail {
+		from, to = common.Min(from, txLookupTail), common.Max(to, newTxLookupTail)
+	}
+
+	if from >= to {
+		return nil
+	}
+
+	var prunedBlocks uint64
+
+	for batchFrom := from; batchFrom < to; batchFrom += historyPruneBatchSize {
+		if err := p.ctx.Err(); err != nil {
+			p.logger.Info("history pruning interrupted", "block", batchFrom)
+
+			return nil
+		}
+
+		batchTo := batchFrom + historyPruneBatchSize
+		if batchTo > to {
+			batchTo = to
+		}
+
+		batchWriter := storage.NewBatchWriter(p.db)
+
+		for number := batchFrom; number < batchTo; number++ {
+			hash, ok := p.db.ReadCanonicalHash(number)
+			if !ok {
+				return fmt.Errorf("canonical hash of block %d not found", number)
+			}
+
+			if number >= receiptsTail && number < newReceiptsTail {
+				batchWriter.DeleteReceipts(hash)
+			}
+
+			if number >= txLookupTail && number < newTxLookupTail {
+				body, err := p.db.ReadBody(hash)
+				if err != nil {
+					return fmt.Errorf("failed to read the body of block %d: %w", number, err)
+				}
+
+				for _, tx := range body.Transactions {
+					batchWriter.DeleteTxLookup(tx.Hash)
+				}
+			}
+		}
+
+		// the tails are moved along with the deleted data, so the interrupted pruning is resumed
+		if newReceiptsTail > receiptsTail {
+			batchWriter.PutReceiptsTail(common.Max(receiptsTail, common.Min(batchTo, newReceiptsTail)))
+		}
+
+		if newTxLookupTail > txLookupTail {
+			batchWriter.PutTxLookupTail(common.Max(txLookupTail, common.Min(batchTo, newTxLookupTail)))
+		}
+
+		if err := batchWriter.WriteBatch(); err != nil {
+			return err
+		}
+
+		prunedBlocks += batchTo - batchFrom
+	}
+
+	metrics.IncrCounter([]string{historyPrunerMetrics, "pruned_blocks"}, float32(prunedBlocks))
+
+	p.logger.Info("history pruned", "block", head, "receipts tail", newReceiptsTail,
+		"tx lookup tail", newTxLookupTail, "elapsed", time.Since(start))
+
+	return nil
+}
+
+// retainedTail returns the number of the first block whose data is retained,
+// which never moves backwards
+func retainedTail(head, history, tail uint64) uint64 {
+	if history == 0 || head+1 <= history {
+		return tail
+	}
+
+	if newTail := head + 1 - history; newTail > tail {
+		return newTail
+	}
+
+	return tail
+}
+
+// close stops the pruner, interrupting the running pruning cycle
+func (p *historyPruner) close() {
+	if p.subscription != nil {
+		p.blockchain.UnsubscribeEvents(p.subscription)
+	}
+
+	p.cancel()
+	p.wg.Wait()
+}
@@ -903,44 +903,1458 @@ var _ interface {
 	ErrorName() string
 } = PeersListResponseValidationError{}
 
+// Validate checks the field values on PeersScoreRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *PeersScoreRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PeersScoreRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PeersScoreRequestMultiError, or nil if none found.
+func (m *PeersScoreRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PeersScoreRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if !_PeersScoreRequest_Id_Pattern.MatchString(m.GetId()) {
+		err := PeersScoreRequestValidationError{
+			field:  "Id",
+			reason: "value does not match regex pattern \"^[A-Za-z0-9]*$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return PeersScoreRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// PeersScoreRequestMultiError is an error wrapping multiple validation errors
+// returned by PeersScoreRequest.ValidateAll() if the designated constraints
+// aren't met.
+type PeersScoreRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PeersScoreRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PeersScoreRequestMultiError) AllErrors() []error { return m }
+
+// PeersScoreRequestValidationError is the validation error returned by
+// PeersScoreRequest.Validate if the designated constraints aren't met.
+type PeersScoreRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PeersScoreRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PeersScoreRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PeersScoreRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PeersScoreRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PeersScoreRequestValidationError) ErrorName() string {
+	return "PeersScoreRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PeersScoreRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPeersScoreRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PeersScoreRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PeersScoreRequestValidationError{}
+
+var _PeersScoreRequest_Id_Pattern = regexp.MustCompile("^[A-Za-z0-9]*$")
+
+// Validate checks the field values on PeerScore with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *PeerScore) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PeerScore with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in PeerScoreMultiError, or
+// nil if none found.
+func (m *PeerScore) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PeerScore) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Score
+
+	// no validation rules for UsefulResponses
+
+	// no validation rules for InvalidMessages
+
+	// no validation rules for Timeouts
+
+	// no validation rules for ProtocolViolations
+
+	// no validation rules for RateLimitExceeded
+
+	if len(errors) > 0 {
+		return PeerScoreMultiError(errors)
+	}
+
+	return nil
+}
+
+// PeerScoreMultiError is an error wrapping multiple validation errors
+// returned by PeerScore.ValidateAll() if the designated constraints aren't met.
+type PeerScoreMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PeerScoreMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PeerScoreMultiError) AllErrors() []error { return m }
+
+// PeerScoreValidationError is the validation error returned by
+// PeerScore.Validate if the designated constraints aren't met.
+type PeerScoreValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PeerScoreValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PeerScoreValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PeerScoreValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PeerScoreValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PeerScoreValidationError) ErrorName() string {
+	return "PeerScoreValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PeerScoreValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPeerScore.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PeerScoreValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PeerScoreValidationError{}
+
+// Validate checks the field values on PeersScoreResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *PeersScoreResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PeersScoreResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PeersScoreResponseMultiError, or nil if none found.
+func (m *PeersScoreResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PeersScoreResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetScores() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, PeersScoreResponseValidationError{
+						field:  fmt.Sprintf("Scores[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, PeersScoreResponseValidationError{
+						field:  fmt.Sprintf("Scores[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return PeersScoreResponseValidationError{
+					field:  fmt.Sprintf("Scores[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return PeersScoreResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// PeersScoreResponseMultiError is an error wrapping multiple validation errors
+// returned by PeersScoreResponse.ValidateAll() if the designated constraints
+// aren't met.
+type PeersScoreResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PeersScoreResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PeersScoreResponseMultiError) AllErrors() []error { return m }
+
+// PeersScoreResponseValidationError is the validation error returned by
+// PeersScoreResponse.Validate if the designated constraints aren't met.
+type PeersScoreResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PeersScoreResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PeersScoreResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PeersScoreResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PeersScoreResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PeersScoreResponseValidationError) ErrorName() string {
+	return "PeersScoreResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PeersScoreResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPeersScoreResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PeersScoreResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PeersScoreResponseValidationError{}
+
 // Validate checks the field values on BlockByNumberRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *BlockByNumberRequest) Validate() error {
+func (m *BlockByNumberRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BlockByNumberRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BlockByNumberRequestMultiError, or nil if none found.
+func (m *BlockByNumberRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BlockByNumberRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Number
+
+	if len(errors) > 0 {
+		return BlockByNumberRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// BlockByNumberRequestMultiError is an error wrapping multiple validation
+// errors returned by BlockByNumberRequest.ValidateAll() if the designated
+// constraints aren't met.
+type BlockByNumberRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BlockByNumberRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BlockByNumberRequestMultiError) AllErrors() []error { return m }
+
+// BlockByNumberRequestValidationError is the validation error returned by
+// BlockByNumberRequest.Validate if the designated constraints aren't met.
+type BlockByNumberRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BlockByNumberRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BlockByNumberRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BlockByNumberRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BlockByNumberRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BlockByNumberRequestValidationError) ErrorName() string {
+	return "BlockByNumberRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BlockByNumberRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBlockByNumberRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BlockByNumberRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BlockByNumberRequestValidationError{}
+
+// Validate checks the field values on BlockResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *BlockResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BlockResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in BlockResponseMultiError, or
+// nil if none found.
+func (m *BlockResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BlockResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Data
+
+	if len(errors) > 0 {
+		return BlockResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// BlockResponseMultiError is an error wrapping multiple validation errors
+// returned by BlockResponse.ValidateAll() if the designated constraints
+// aren't met.
+type BlockResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BlockResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BlockResponseMultiError) AllErrors() []error { return m }
+
+// BlockResponseValidationError is the validation error returned by
+// BlockResponse.Validate if the designated constraints aren't met.
+type BlockResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BlockResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BlockResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BlockResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BlockResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BlockResponseValidationError) ErrorName() string { return "BlockResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e BlockResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBlockResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BlockResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BlockResponseValidationError{}
+
+// Validate checks the field values on ExportRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ExportRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ExportRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ExportRequestMultiError, or
+// nil if none found.
+func (m *ExportRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ExportRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for From
+
+	// no validation rules for To
+
+	// no validation rules for Receipts
+
+	if len(errors) > 0 {
+		return ExportRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ExportRequestMultiError is an error wrapping multiple validation errors
+// returned by ExportRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ExportRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ExportRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ExportRequestMultiError) AllErrors() []error { return m }
+
+// ExportRequestValidationError is the validation error returned by
+// ExportRequest.Validate if the designated constraints aren't met.
+type ExportRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ExportRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ExportRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ExportRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ExportRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ExportRequestValidationError) ErrorName() string { return "ExportRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ExportRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sExportRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ExportRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ExportRequestValidationError{}
+
+// Validate checks the field values on ExportEvent with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ExportEvent) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ExportEvent with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ExportEventMultiError, or
+// nil if none found.
+func (m *ExportEvent) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ExportEvent) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for From
+
+	// no validation rules for To
+
+	// no validation rules for Latest
+
+	// no validation rules for Data
+
+	// no validation rules for Receipts
+
+	if len(errors) > 0 {
+		return ExportEventMultiError(errors)
+	}
+
+	return nil
+}
+
+// ExportEventMultiError is an error wrapping multiple validation errors
+// returned by ExportEvent.ValidateAll() if the designated constraints aren't met.
+type ExportEventMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ExportEventMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ExportEventMultiError) AllErrors() []error { return m }
+
+// ExportEventValidationError is the validation error returned by
+// ExportEvent.Validate if the designated constraints aren't met.
+type ExportEventValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ExportEventValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ExportEventValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ExportEventValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ExportEventValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ExportEventValidationError) ErrorName() string { return "ExportEventValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ExportEventValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sExportEvent.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ExportEventValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ExportEventValidationError{}
+
+// Validate checks the field values on ImportBlocksRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ImportBlocksRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ImportBlocksRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ImportBlocksRequestMultiError, or
+// nil if none found.
+func (m *ImportBlocksRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ImportBlocksRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Data
+
+	if len(errors) > 0 {
+		return ImportBlocksRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ImportBlocksRequestMultiError is an error wrapping multiple validation errors
+// returned by ImportBlocksRequest.ValidateAll() if the designated constraints aren't met.
+type ImportBlocksRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ImportBlocksRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ImportBlocksRequestMultiError) AllErrors() []error { return m }
+
+// ImportBlocksRequestValidationError is the validation error returned by
+// ImportBlocksRequest.Validate if the designated constraints aren't met.
+type ImportBlocksRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ImportBlocksRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ImportBlocksRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ImportBlocksRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ImportBlocksRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ImportBlocksRequestValidationError) ErrorName() string {
+	return "ImportBlocksRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ImportBlocksRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sImportBlocksRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ImportBlocksRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ImportBlocksRequestValidationError{}
+
+// Validate checks the field values on ImportBlocksResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ImportBlocksResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ImportBlocksResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ImportBlocksResponseMultiError, or
+// nil if none found.
+func (m *ImportBlocksResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ImportBlocksResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Imported
+
+	// no validation rules for Latest
+
+	if len(errors) > 0 {
+		return ImportBlocksResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ImportBlocksResponseMultiError is an error wrapping multiple validation errors
+// returned by ImportBlocksResponse.ValidateAll() if the designated constraints aren't met.
+type ImportBlocksResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ImportBlocksResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ImportBlocksResponseMultiError) AllErrors() []error { return m }
+
+// ImportBlocksResponseValidationError is the validation error returned by
+// ImportBlocksResponse.Validate if the designated constraints aren't met.
+type ImportBlocksResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ImportBlocksResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ImportBlocksResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ImportBlocksResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ImportBlocksResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ImportBlocksResponseValidationError) ErrorName() string {
+	return "ImportBlocksResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ImportBlocksResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sImportBlocksResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ImportBlocksResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ImportBlocksResponseValidationError{}
+
+// Validate checks the field values on BlockchainEvent_Header with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BlockchainEvent_Header) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BlockchainEvent_Header with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BlockchainEvent_HeaderMultiError, or nil if none found.
+func (m *BlockchainEvent_Header) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BlockchainEvent_Header) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Number
+
+	// no validation rules for Hash
+
+	if len(errors) > 0 {
+		return BlockchainEvent_HeaderMultiError(errors)
+	}
+
+	return nil
+}
+
+// BlockchainEvent_HeaderMultiError is an error wrapping multiple validation
+// errors returned by BlockchainEvent_Header.ValidateAll() if the designated
+// constraints aren't met.
+type BlockchainEvent_HeaderMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BlockchainEvent_HeaderMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BlockchainEvent_HeaderMultiError) AllErrors() []error { return m }
+
+// BlockchainEvent_HeaderValidationError is the validation error returned by
+// BlockchainEvent_Header.Validate if the designated constraints aren't met.
+type BlockchainEvent_HeaderValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BlockchainEvent_HeaderValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BlockchainEvent_HeaderValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BlockchainEvent_HeaderValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BlockchainEvent_HeaderValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BlockchainEvent_HeaderValidationError) ErrorName() string {
+	return "BlockchainEvent_HeaderValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BlockchainEvent_HeaderValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBlockchainEvent_Header.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BlockchainEvent_HeaderValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BlockchainEvent_HeaderValidationError{}
+
+// Validate checks the field values on ServerStatus_Block with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ServerStatus_Block) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ServerStatus_Block with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ServerStatus_BlockMultiError, or nil if none found.
+func (m *ServerStatus_Block) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ServerStatus_Block) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Number
+
+	// no validation rules for Hash
+
+	if len(errors) > 0 {
+		return ServerStatus_BlockMultiError(errors)
+	}
+
+	return nil
+}
+
+// ServerStatus_BlockMultiError is an error wrapping multiple validation errors
+// returned by ServerStatus_Block.ValidateAll() if the designated constraints
+// aren't met.
+type ServerStatus_BlockMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ServerStatus_BlockMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ServerStatus_BlockMultiError) AllErrors() []error { return m }
+
+// ServerStatus_BlockValidationError is the validation error returned by
+// ServerStatus_Block.Validate if the designated constraints aren't met.
+type ServerStatus_BlockValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ServerStatus_BlockValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ServerStatus_BlockValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ServerStatus_BlockValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ServerStatus_BlockValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ServerStatus_BlockValidationError) ErrorName() string {
+	return "ServerStatus_BlockValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ServerStatus_BlockValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServerStatus_Block.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ServerStatus_BlockValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ServerStatus_BlockValidationError{}
+
+// Validate checks the field values on TrieNodesRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *TrieNodesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TrieNodesRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in TrieNodesRequestMultiError, or
+// nil if none found.
+func (m *TrieNodesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TrieNodesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return TrieNodesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// TrieNodesRequestMultiError is an error wrapping multiple validation errors
+// returned by TrieNodesRequest.ValidateAll() if the designated constraints aren't met.
+type TrieNodesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TrieNodesRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TrieNodesRequestMultiError) AllErrors() []error { return m }
+
+// TrieNodesRequestValidationError is the validation error returned by
+// TrieNodesRequest.Validate if the designated constraints aren't met.
+type TrieNodesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TrieNodesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TrieNodesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TrieNodesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TrieNodesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TrieNodesRequestValidationError) ErrorName() string {
+	return "TrieNodesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e TrieNodesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTrieNodesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TrieNodesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TrieNodesRequestValidationError{}
+
+// Validate checks the field values on TrieNodesResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *TrieNodesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TrieNodesResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in TrieNodesResponseMultiError, or
+// nil if none found.
+func (m *TrieNodesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TrieNodesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return TrieNodesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// TrieNodesResponseMultiError is an error wrapping multiple validation errors
+// returned by TrieNodesResponse.ValidateAll() if the designated constraints aren't met.
+type TrieNodesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TrieNodesResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TrieNodesResponseMultiError) AllErrors() []error { return m }
+
+// TrieNodesResponseValidationError is the validation error returned by
+// TrieNodesResponse.Validate if the designated constraints aren't met.
+type TrieNodesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TrieNodesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TrieNodesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TrieNodesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TrieNodesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TrieNodesResponseValidationError) ErrorName() string {
+	return "TrieNodesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e TrieNodesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTrieNodesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TrieNodesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TrieNodesResponseValidationError{}
+
+// Validate checks the field values on AdminStatusResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AdminStatusResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on BlockByNumberRequest with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// BlockByNumberRequestMultiError, or nil if none found.
-func (m *BlockByNumberRequest) ValidateAll() error {
+// ValidateAll checks the field values on AdminStatusResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AdminStatusResponseMultiError, or
+// nil if none found.
+func (m *AdminStatusResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *BlockByNumberRequest) validate(all bool) error {
+func (m *AdminStatusResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Number
+	// no validation rules for ProposingPaused
+
+	// no validation rules for TxPoolPaused
+
+	// no validation rules for LogLevel
+
+	// no validation rules for ModuleLogLevels
 
 	if len(errors) > 0 {
-		return BlockByNumberRequestMultiError(errors)
+		return AdminStatusResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// BlockByNumberRequestMultiError is an error wrapping multiple validation
-// errors returned by BlockByNumberRequest.ValidateAll() if the designated
-// constraints aren't met.
-type BlockByNumberRequestMultiError []error
+// AdminStatusResponseMultiError is an error wrapping multiple validation errors
+// returned by AdminStatusResponse.ValidateAll() if the designated constraints aren't met.
+type AdminStatusResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m BlockByNumberRequestMultiError) Error() string {
+func (m AdminStatusResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -949,11 +2363,11 @@ func (m BlockByNumberRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m BlockByNumberRequestMultiError) AllErrors() []error { return m }
+func (m AdminStatusResponseMultiError) AllErrors() []error { return m }
 
-// BlockByNumberRequestValidationError is the validation error returned by
-// BlockByNumberRequest.Validate if the designated constraints aren't met.
-type BlockByNumberRequestValidationError struct {
+// AdminStatusResponseValidationError is the validation error returned by
+// AdminStatusResponse.Validate if the designated constraints aren't met.
+type AdminStatusResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -961,24 +2375,24 @@ type BlockByNumberRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e BlockByNumberRequestValidationError) Field() string { return e.field }
+func (e AdminStatusResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e BlockByNumberRequestValidationError) Reason() string { return e.reason }
+func (e AdminStatusResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e BlockByNumberRequestValidationError) Cause() error { return e.cause }
+func (e AdminStatusResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e BlockByNumberRequestValidationError) Key() bool { return e.key }
+func (e AdminStatusResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e BlockByNumberRequestValidationError) ErrorName() string {
-	return "BlockByNumberRequestValidationError"
+func (e AdminStatusResponseValidationError) ErrorName() string {
+	return "AdminStatusResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e BlockByNumberRequestValidationError) Error() string {
+func (e AdminStatusResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -990,14 +2404,14 @@ func (e BlockByNumberRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sBlockByNumberRequest.%s: %s%s",
+		"invalid %sAdminStatusResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = BlockByNumberRequestValidationError{}
+var _ error = AdminStatusResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -1005,46 +2419,45 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = BlockByNumberRequestValidationError{}
+} = AdminStatusResponseValidationError{}
 
-// Validate checks the field values on BlockResponse with the rules defined in
+// Validate checks the field values on AdminPauseRequest with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
-func (m *BlockResponse) Validate() error {
+func (m *AdminPauseRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on BlockResponse with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in BlockResponseMultiError, or
+// ValidateAll checks the field values on AdminPauseRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AdminPauseRequestMultiError, or
 // nil if none found.
-func (m *BlockResponse) ValidateAll() error {
+func (m *AdminPauseRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *BlockResponse) validate(all bool) error {
+func (m *AdminPauseRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Data
+	// no validation rules for Paused
 
 	if len(errors) > 0 {
-		return BlockResponseMultiError(errors)
+		return AdminPauseRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// BlockResponseMultiError is an error wrapping multiple validation errors
-// returned by BlockResponse.ValidateAll() if the designated constraints
-// aren't met.
-type BlockResponseMultiError []error
+// AdminPauseRequestMultiError is an error wrapping multiple validation errors
+// returned by AdminPauseRequest.ValidateAll() if the designated constraints aren't met.
+type AdminPauseRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m BlockResponseMultiError) Error() string {
+func (m AdminPauseRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1053,11 +2466,11 @@ func (m BlockResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m BlockResponseMultiError) AllErrors() []error { return m }
+func (m AdminPauseRequestMultiError) AllErrors() []error { return m }
 
-// BlockResponseValidationError is the validation error returned by
-// BlockResponse.Validate if the designated constraints aren't met.
-type BlockResponseValidationError struct {
+// AdminPauseRequestValidationError is the validation error returned by
+// AdminPauseRequest.Validate if the designated constraints aren't met.
+type AdminPauseRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1065,22 +2478,24 @@ type BlockResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e BlockResponseValidationError) Field() string { return e.field }
+func (e AdminPauseRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e BlockResponseValidationError) Reason() string { return e.reason }
+func (e AdminPauseRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e BlockResponseValidationError) Cause() error { return e.cause }
+func (e AdminPauseRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e BlockResponseValidationError) Key() bool { return e.key }
+func (e AdminPauseRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e BlockResponseValidationError) ErrorName() string { return "BlockResponseValidationError" }
+func (e AdminPauseRequestValidationError) ErrorName() string {
+	return "AdminPauseRequestValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e BlockResponseValidationError) Error() string {
+func (e AdminPauseRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1092,14 +2507,14 @@ func (e BlockResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sBlockResponse.%s: %s%s",
+		"invalid %sAdminPauseRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = BlockResponseValidationError{}
+var _ error = AdminPauseRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1107,48 +2522,45 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = BlockResponseValidationError{}
+} = AdminPauseRequestValidationError{}
 
-// Validate checks the field values on ExportRequest with the rules defined in
+// Validate checks the field values on AdminFlushTxPoolResponse with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
-func (m *ExportRequest) Validate() error {
+func (m *AdminFlushTxPoolResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ExportRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in ExportRequestMultiError, or
+// ValidateAll checks the field values on AdminFlushTxPoolResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AdminFlushTxPoolResponseMultiError, or
 // nil if none found.
-func (m *ExportRequest) ValidateAll() error {
+func (m *AdminFlushTxPoolResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ExportRequest) validate(all bool) error {
+func (m *AdminFlushTxPoolResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for From
-
-	// no validation rules for To
+	// no validation rules for Dropped
 
 	if len(errors) > 0 {
-		return ExportRequestMultiError(errors)
+		return AdminFlushTxPoolResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// ExportRequestMultiError is an error wrapping multiple validation errors
-// returned by ExportRequest.ValidateAll() if the designated constraints
-// aren't met.
-type ExportRequestMultiError []error
+// AdminFlushTxPoolResponseMultiError is an error wrapping multiple validation errors
+// returned by AdminFlushTxPoolResponse.ValidateAll() if the designated constraints aren't met.
+type AdminFlushTxPoolResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ExportRequestMultiError) Error() string {
+func (m AdminFlushTxPoolResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1157,11 +2569,11 @@ func (m ExportRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ExportRequestMultiError) AllErrors() []error { return m }
+func (m AdminFlushTxPoolResponseMultiError) AllErrors() []error { return m }
 
-// ExportRequestValidationError is the validation error returned by
-// ExportRequest.Validate if the designated constraints aren't met.
-type ExportRequestValidationError struct {
+// AdminFlushTxPoolResponseValidationError is the validation error returned by
+// AdminFlushTxPoolResponse.Validate if the designated constraints aren't met.
+type AdminFlushTxPoolResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1169,22 +2581,24 @@ type ExportRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e ExportRequestValidationError) Field() string { return e.field }
+func (e AdminFlushTxPoolResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ExportRequestValidationError) Reason() string { return e.reason }
+func (e AdminFlushTxPoolResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ExportRequestValidationError) Cause() error { return e.cause }
+func (e AdminFlushTxPoolResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ExportRequestValidationError) Key() bool { return e.key }
+func (e AdminFlushTxPoolResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ExportRequestValidationError) ErrorName() string { return "ExportRequestValidationError" }
+func (e AdminFlushTxPoolResponseValidationError) ErrorName() string {
+	return "AdminFlushTxPoolResponseValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e ExportRequestValidationError) Error() string {
+func (e AdminFlushTxPoolResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1196,14 +2610,14 @@ func (e ExportRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sExportRequest.%s: %s%s",
+		"invalid %sAdminFlushTxPoolResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ExportRequestValidationError{}
+var _ error = AdminFlushTxPoolResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -1211,51 +2625,47 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ExportRequestValidationError{}
+} = AdminFlushTxPoolResponseValidationError{}
 
-// Validate checks the field values on ExportEvent with the rules defined in
+// Validate checks the field values on AdminSetLogLevelRequest with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
-func (m *ExportEvent) Validate() error {
+func (m *AdminSetLogLevelRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ExportEvent with the rules defined in
+// ValidateAll checks the field values on AdminSetLogLevelRequest with the rules defined in
 // the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in ExportEventMultiError, or
+// result is a list of violation errors wrapped in AdminSetLogLevelRequestMultiError, or
 // nil if none found.
-func (m *ExportEvent) ValidateAll() error {
+func (m *AdminSetLogLevelRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ExportEvent) validate(all bool) error {
+func (m *AdminSetLogLevelRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for From
-
-	// no validation rules for To
-
-	// no validation rules for Latest
+	// no validation rules for Module
 
-	// no validation rules for Data
+	// no validation rules for Level
 
 	if len(errors) > 0 {
-		return ExportEventMultiError(errors)
+		return AdminSetLogLevelRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// ExportEventMultiError is an error wrapping multiple validation errors
-// returned by ExportEvent.ValidateAll() if the designated constraints aren't met.
-type ExportEventMultiError []error
+// AdminSetLogLevelRequestMultiError is an error wrapping multiple validation errors
+// returned by AdminSetLogLevelRequest.ValidateAll() if the designated constraints aren't met.
+type AdminSetLogLevelRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ExportEventMultiError) Error() string {
+func (m AdminSetLogLevelRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1264,11 +2674,11 @@ func (m ExportEventMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ExportEventMultiError) AllErrors() []error { return m }
+func (m AdminSetLogLevelRequestMultiError) AllErrors() []error { return m }
 
-// ExportEventValidationError is the validation error returned by
-// ExportEvent.Validate if the designated constraints aren't met.
-type ExportEventValidationError struct {
+// AdminSetLogLevelRequestValidationError is the validation error returned by
+// AdminSetLogLevelRequest.Validate if the designated constraints aren't met.
+type AdminSetLogLevelRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1276,22 +2686,24 @@ type ExportEventValidationError struct {
 }
 
 // Field function returns field value.
-func (e ExportEventValidationError) Field() string { return e.field }
+func (e AdminSetLogLevelRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ExportEventValidationError) Reason() string { return e.reason }
+func (e AdminSetLogLevelRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ExportEventValidationError) Cause() error { return e.cause }
+func (e AdminSetLogLevelRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ExportEventValidationError) Key() bool { return e.key }
+func (e AdminSetLogLevelRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ExportEventValidationError) ErrorName() string { return "ExportEventValidationError" }
+func (e AdminSetLogLevelRequestValidationError) ErrorName() string {
+	return "AdminSetLogLevelRequestValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e ExportEventValidationError) Error() string {
+func (e AdminSetLogLevelRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1303,14 +2715,14 @@ func (e ExportEventValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sExportEvent.%s: %s%s",
+		"invalid %sAdminSetLogLevelRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ExportEventValidationError{}
+var _ error = AdminSetLogLevelRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1318,48 +2730,45 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ExportEventValidationError{}
+} = AdminSetLogLevelRequestValidationError{}
 
-// Validate checks the field values on BlockchainEvent_Header with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *BlockchainEvent_Header) Validate() error {
+// Validate checks the field values on AdminRegenerateStateSnapshotResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AdminRegenerateStateSnapshotResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on BlockchainEvent_Header with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// BlockchainEvent_HeaderMultiError, or nil if none found.
-func (m *BlockchainEvent_Header) ValidateAll() error {
+// ValidateAll checks the field values on AdminRegenerateStateSnapshotResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AdminRegenerateStateSnapshotResponseMultiError, or
+// nil if none found.
+func (m *AdminRegenerateStateSnapshotResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *BlockchainEvent_Header) validate(all bool) error {
+func (m *AdminRegenerateStateSnapshotResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Number
-
-	// no validation rules for Hash
+	// no validation rules for Root
 
 	if len(errors) > 0 {
-		return BlockchainEvent_HeaderMultiError(errors)
+		return AdminRegenerateStateSnapshotResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// BlockchainEvent_HeaderMultiError is an error wrapping multiple validation
-// errors returned by BlockchainEvent_Header.ValidateAll() if the designated
-// constraints aren't met.
-type BlockchainEvent_HeaderMultiError []error
+// AdminRegenerateStateSnapshotResponseMultiError is an error wrapping multiple validation errors
+// returned by AdminRegenerateStateSnapshotResponse.ValidateAll() if the designated constraints aren't met.
+type AdminRegenerateStateSnapshotResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m BlockchainEvent_HeaderMultiError) Error() string {
+func (m AdminRegenerateStateSnapshotResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1368,11 +2777,11 @@ func (m BlockchainEvent_HeaderMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m BlockchainEvent_HeaderMultiError) AllErrors() []error { return m }
+func (m AdminRegenerateStateSnapshotResponseMultiError) AllErrors() []error { return m }
 
-// BlockchainEvent_HeaderValidationError is the validation error returned by
-// BlockchainEvent_Header.Validate if the designated constraints aren't met.
-type BlockchainEvent_HeaderValidationError struct {
+// AdminRegenerateStateSnapshotResponseValidationError is the validation error returned by
+// AdminRegenerateStateSnapshotResponse.Validate if the designated constraints aren't met.
+type AdminRegenerateStateSnapshotResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1380,24 +2789,24 @@ type BlockchainEvent_HeaderValidationError struct {
 }
 
 // Field function returns field value.
-func (e BlockchainEvent_HeaderValidationError) Field() string { return e.field }
+func (e AdminRegenerateStateSnapshotResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e BlockchainEvent_HeaderValidationError) Reason() string { return e.reason }
+func (e AdminRegenerateStateSnapshotResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e BlockchainEvent_HeaderValidationError) Cause() error { return e.cause }
+func (e AdminRegenerateStateSnapshotResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e BlockchainEvent_HeaderValidationError) Key() bool { return e.key }
+func (e AdminRegenerateStateSnapshotResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e BlockchainEvent_HeaderValidationError) ErrorName() string {
-	return "BlockchainEvent_HeaderValidationError"
+func (e AdminRegenerateStateSnapshotResponseValidationError) ErrorName() string {
+	return "AdminRegenerateStateSnapshotResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e BlockchainEvent_HeaderValidationError) Error() string {
+func (e AdminRegenerateStateSnapshotResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1409,14 +2818,14 @@ func (e BlockchainEvent_HeaderValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sBlockchainEvent_Header.%s: %s%s",
+		"invalid %sAdminRegenerateStateSnapshotResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = BlockchainEvent_HeaderValidationError{}
+var _ error = AdminRegenerateStateSnapshotResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -1424,48 +2833,61 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = BlockchainEvent_HeaderValidationError{}
+} = AdminRegenerateStateSnapshotResponseValidationError{}
 
-// Validate checks the field values on ServerStatus_Block with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *ServerStatus_Block) Validate() error {
+// Validate checks the field values on SyncerStatusResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SyncerStatusResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ServerStatus_Block with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// ServerStatus_BlockMultiError, or nil if none found.
-func (m *ServerStatus_Block) ValidateAll() error {
+// ValidateAll checks the field values on SyncerStatusResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SyncerStatusResponseMultiError, or
+// nil if none found.
+func (m *SyncerStatusResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ServerStatus_Block) validate(all bool) error {
+func (m *SyncerStatusResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Number
+	// no validation rules for Syncing
 
-	// no validation rules for Hash
+	// no validation rules for Mode
+
+	// no validation rules for Type
+
+	// no validation rules for Stage
+
+	// no validation rules for StartingBlock
+
+	// no validation rules for CurrentBlock
+
+	// no validation rules for HighestBlock
+
+	// no validation rules for PulledStates
+
+	// no validation rules for KnownStates
 
 	if len(errors) > 0 {
-		return ServerStatus_BlockMultiError(errors)
+		return SyncerStatusResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// ServerStatus_BlockMultiError is an error wrapping multiple validation errors
-// returned by ServerStatus_Block.ValidateAll() if the designated constraints
-// aren't met.
-type ServerStatus_BlockMultiError []error
+// SyncerStatusResponseMultiError is an error wrapping multiple validation errors
+// returned by SyncerStatusResponse.ValidateAll() if the designated constraints aren't met.
+type SyncerStatusResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ServerStatus_BlockMultiError) Error() string {
+func (m SyncerStatusResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1474,11 +2896,11 @@ func (m ServerStatus_BlockMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ServerStatus_BlockMultiError) AllErrors() []error { return m }
+func (m SyncerStatusResponseMultiError) AllErrors() []error { return m }
 
-// ServerStatus_BlockValidationError is the validation error returned by
-// ServerStatus_Block.Validate if the designated constraints aren't met.
-type ServerStatus_BlockValidationError struct {
+// SyncerStatusResponseValidationError is the validation error returned by
+// SyncerStatusResponse.Validate if the designated constraints aren't met.
+type SyncerStatusResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1486,24 +2908,24 @@ type ServerStatus_BlockValidationError struct {
 }
 
 // Field function returns field value.
-func (e ServerStatus_BlockValidationError) Field() string { return e.field }
+func (e SyncerStatusResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ServerStatus_BlockValidationError) Reason() string { return e.reason }
+func (e SyncerStatusResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ServerStatus_BlockValidationError) Cause() error { return e.cause }
+func (e SyncerStatusResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ServerStatus_BlockValidationError) Key() bool { return e.key }
+func (e SyncerStatusResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ServerStatus_BlockValidationError) ErrorName() string {
-	return "ServerStatus_BlockValidationError"
+func (e SyncerStatusResponseValidationError) ErrorName() string {
+	return "SyncerStatusResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e ServerStatus_BlockValidationError) Error() string {
+func (e SyncerStatusResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1515,14 +2937,14 @@ func (e ServerStatus_BlockValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sServerStatus_Block.%s: %s%s",
+		"invalid %sSyncerStatusResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ServerStatus_BlockValidationError{}
+var _ error = SyncerStatusResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -1530,4 +2952,4 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ServerStatus_BlockValidationError{}
+} = SyncerStatusResponseValidationError{}
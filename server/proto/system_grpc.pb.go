@@ -31,12 +31,32 @@ type SystemClient interface {
 	PeersList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PeersListResponse, error)
 	// PeersInfo returns the info of a peer
 	PeersStatus(ctx context.Context, in *PeersStatusRequest, opts ...grpc.CallOption) (*Peer, error)
+	// PeersScore returns the reputation of a peer, or of all connected peers
+	PeersScore(ctx context.Context, in *PeersScoreRequest, opts ...grpc.CallOption) (*PeersScoreResponse, error)
 	// Subscribe subscribes to blockchain events
 	Subscribe(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (System_SubscribeClient, error)
 	// Export returns blockchain data
 	BlockByNumber(ctx context.Context, in *BlockByNumberRequest, opts ...grpc.CallOption) (*BlockResponse, error)
 	// Export returns blockchain data
 	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (System_ExportClient, error)
+	// ImportBlocks verifies and writes blockchain data
+	ImportBlocks(ctx context.Context, in *ImportBlocksRequest, opts ...grpc.CallOption) (*ImportBlocksResponse, error)
+	// GetTrieNodes returns state trie nodes and contract codes by their hashes
+	GetTrieNodes(ctx context.Context, in *TrieNodesRequest, opts ...grpc.CallOption) (*TrieNodesResponse, error)
+	// AdminStatus returns the state of the operational controls of the node
+	AdminStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AdminStatusResponse, error)
+	// AdminSetProposingPaused pauses or resumes building the block proposals (maintenance mode)
+	AdminSetProposingPaused(ctx context.Context, in *AdminPauseRequest, opts ...grpc.CallOption) (*AdminStatusResponse, error)
+	// AdminSetTxPoolPaused pauses or resumes accepting the new transactions
+	AdminSetTxPoolPaused(ctx context.Context, in *AdminPauseRequest, opts ...grpc.CallOption) (*AdminStatusResponse, error)
+	// AdminFlushTxPool drops all the transactions of the pool
+	AdminFlushTxPool(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AdminFlushTxPoolResponse, error)
+	// AdminSetLogLevel sets the log level of the node or of a single module
+	AdminSetLogLevel(ctx context.Context, in *AdminSetLogLevelRequest, opts ...grpc.CallOption) (*AdminStatusResponse, error)
+	// AdminRegenerateStateSnapshot regenerates the flat state snapshot at the head of the chain
+	AdminRegenerateStateSnapshot(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AdminRegenerateStateSnapshotResponse, error)
+	// SyncerStatus returns the detailed progress of the restore or of the sync with the peers
+	SyncerStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SyncerStatusResponse, error)
 }
 
 type systemClient struct {
@@ -83,6 +103,15 @@ func (c *systemClient) PeersStatus(ctx context.Context, in *PeersStatusRequest,
 	return out, nil
 }
 
+func (c *systemClient) PeersScore(ctx context.Context, in *PeersScoreRequest, opts ...grpc.CallOption) (*PeersScoreResponse, error) {
+	out := new(PeersScoreResponse)
+	err := c.cc.Invoke(ctx, "/v1.System/PeersScore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *systemClient) Subscribe(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (System_SubscribeClient, error) {
 	stream, err := c.cc.NewStream(ctx, &System_ServiceDesc.Streams[0], "/v1.System/Subscribe", opts...)
 	if err != nil {
@@ -156,6 +185,87 @@ func (x *systemExportClient) Recv() (*ExportEvent, error) {
 	return m, nil
 }
 
+func (c *systemClient) ImportBlocks(ctx context.Context, in *ImportBlocksRequest, opts ...grpc.CallOption) (*ImportBlocksResponse, error) {
+	out := new(ImportBlocksResponse)
+	err := c.cc.Invoke(ctx, "/v1.System/ImportBlocks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) GetTrieNodes(ctx context.Context, in *TrieNodesRequest, opts ...grpc.CallOption) (*TrieNodesResponse, error) {
+	out := new(TrieNodesResponse)
+	err := c.cc.Invoke(ctx, "/v1.System/GetTrieNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) AdminStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AdminStatusResponse, error) {
+	out := new(AdminStatusResponse)
+	err := c.cc.Invoke(ctx, "/v1.System/AdminStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) AdminSetProposingPaused(ctx context.Context, in *AdminPauseRequest, opts ...grpc.CallOption) (*AdminStatusResponse, error) {
+	out := new(AdminStatusResponse)
+	err := c.cc.Invoke(ctx, "/v1.System/AdminSetProposingPaused", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) AdminSetTxPoolPaused(ctx context.Context, in *AdminPauseRequest, opts ...grpc.CallOption) (*AdminStatusResponse, error) {
+	out := new(AdminStatusResponse)
+	err := c.cc.Invoke(ctx, "/v1.System/AdminSetTxPoolPaused", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) AdminFlushTxPool(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AdminFlushTxPoolResponse, error) {
+	out := new(AdminFlushTxPoolResponse)
+	err := c.cc.Invoke(ctx, "/v1.System/AdminFlushTxPool", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) AdminSetLogLevel(ctx context.Context, in *AdminSetLogLevelRequest, opts ...grpc.CallOption) (*AdminStatusResponse, error) {
+	out := new(AdminStatusResponse)
+	err := c.cc.Invoke(ctx, "/v1.System/AdminSetLogLevel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) AdminRegenerateStateSnapshot(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AdminRegenerateStateSnapshotResponse, error) {
+	out := new(AdminRegenerateStateSnapshotResponse)
+	err := c.cc.Invoke(ctx, "/v1.System/AdminRegenerateStateSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) SyncerStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SyncerStatusResponse, error) {
+	out := new(SyncerStatusResponse)
+	err := c.cc.Invoke(ctx, "/v1.System/SyncerStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SystemServer is the server API for System service.
 // All implementations must embed UnimplementedSystemServer
 // for forward compatibility
@@ -168,12 +278,32 @@ type SystemServer interface {
 	PeersList(context.Context, *emptypb.Empty) (*PeersListResponse, error)
 	// PeersInfo returns the info of a peer
 	PeersStatus(context.Context, *PeersStatusRequest) (*Peer, error)
+	// PeersScore returns the reputation of a peer, or of all connected peers
+	PeersScore(context.Context, *PeersScoreRequest) (*PeersScoreResponse, error)
 	// Subscribe subscribes to blockchain events
 	Subscribe(*emptypb.Empty, System_SubscribeServer) error
 	// Export returns blockchain data
 	BlockByNumber(context.Context, *BlockByNumberRequest) (*BlockResponse, error)
 	// Export returns blockchain data
 	Export(*ExportRequest, System_ExportServer) error
+	// ImportBlocks verifies and writes blockchain data
+	ImportBlocks(context.Context, *ImportBlocksRequest) (*ImportBlocksResponse, error)
+	// GetTrieNodes returns state trie nodes and contract codes by their hashes
+	GetTrieNodes(context.Context, *TrieNodesRequest) (*TrieNodesResponse, error)
+	// AdminStatus returns the state of the operational controls of the node
+	AdminStatus(context.Context, *emptypb.Empty) (*AdminStatusResponse, error)
+	// AdminSetProposingPaused pauses or resumes building the block proposals (maintenance mode)
+	AdminSetProposingPaused(context.Context, *AdminPauseRequest) (*AdminStatusResponse, error)
+	// AdminSetTxPoolPaused pauses or resumes accepting the new transactions
+	AdminSetTxPoolPaused(context.Context, *AdminPauseRequest) (*AdminStatusResponse, error)
+	// AdminFlushTxPool drops all the transactions of the pool
+	AdminFlushTxPool(context.Context, *emptypb.Empty) (*AdminFlushTxPoolResponse, error)
+	// AdminSetLogLevel sets the log level of the node or of a single module
+	AdminSetLogLevel(context.Context, *AdminSetLogLevelRequest) (*AdminStatusResponse, error)
+	// AdminRegenerateStateSnapshot regenerates the flat state snapshot at the head of the chain
+	AdminRegenerateStateSnapshot(context.Context, *emptypb.Empty) (*AdminRegenerateStateSnapshotResponse, error)
+	// SyncerStatus returns the detailed progress of the restore or of the sync with the peers
+	SyncerStatus(context.Context, *emptypb.Empty) (*SyncerStatusResponse, error)
 	mustEmbedUnimplementedSystemServer()
 }
 
@@ -193,6 +323,9 @@ func (UnimplementedSystemServer) PeersList(context.Context, *emptypb.Empty) (*Pe
 func (UnimplementedSystemServer) PeersStatus(context.Context, *PeersStatusRequest) (*Peer, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PeersStatus not implemented")
 }
+func (UnimplementedSystemServer) PeersScore(context.Context, *PeersScoreRequest) (*PeersScoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeersScore not implemented")
+}
 func (UnimplementedSystemServer) Subscribe(*emptypb.Empty, System_SubscribeServer) error {
 	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
 }
@@ -202,6 +335,33 @@ func (UnimplementedSystemServer) BlockByNumber(context.Context, *BlockByNumberRe
 func (UnimplementedSystemServer) Export(*ExportRequest, System_ExportServer) error {
 	return status.Errorf(codes.Unimplemented, "method Export not implemented")
 }
+func (UnimplementedSystemServer) ImportBlocks(context.Context, *ImportBlocksRequest) (*ImportBlocksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportBlocks not implemented")
+}
+func (UnimplementedSystemServer) GetTrieNodes(context.Context, *TrieNodesRequest) (*TrieNodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrieNodes not implemented")
+}
+func (UnimplementedSystemServer) AdminStatus(context.Context, *emptypb.Empty) (*AdminStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminStatus not implemented")
+}
+func (UnimplementedSystemServer) AdminSetProposingPaused(context.Context, *AdminPauseRequest) (*AdminStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminSetProposingPaused not implemented")
+}
+func (UnimplementedSystemServer) AdminSetTxPoolPaused(context.Context, *AdminPauseRequest) (*AdminStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminSetTxPoolPaused not implemented")
+}
+func (UnimplementedSystemServer) AdminFlushTxPool(context.Context, *emptypb.Empty) (*AdminFlushTxPoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminFlushTxPool not implemented")
+}
+func (UnimplementedSystemServer) AdminSetLogLevel(context.Context, *AdminSetLogLevelRequest) (*AdminStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminSetLogLevel not implemented")
+}
+func (UnimplementedSystemServer) AdminRegenerateStateSnapshot(context.Context, *emptypb.Empty) (*AdminRegenerateStateSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminRegenerateStateSnapshot not implemented")
+}
+func (UnimplementedSystemServer) SyncerStatus(context.Context, *emptypb.Empty) (*SyncerStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncerStatus not implemented")
+}
 func (UnimplementedSystemServer) mustEmbedUnimplementedSystemServer() {}
 
 // UnsafeSystemServer may be embedded to opt out of forward compatibility for this service.
@@ -287,6 +447,24 @@ func _System_PeersStatus_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _System_PeersScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeersScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).PeersScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.System/PeersScore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).PeersScore(ctx, req.(*PeersScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _System_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(emptypb.Empty)
 	if err := stream.RecvMsg(m); err != nil {
@@ -347,6 +525,168 @@ func (x *systemExportServer) Send(m *ExportEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _System_ImportBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportBlocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).ImportBlocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.System/ImportBlocks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).ImportBlocks(ctx, req.(*ImportBlocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_GetTrieNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrieNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).GetTrieNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.System/GetTrieNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).GetTrieNodes(ctx, req.(*TrieNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_AdminStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).AdminStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.System/AdminStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).AdminStatus(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_AdminSetProposingPaused_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminPauseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).AdminSetProposingPaused(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.System/AdminSetProposingPaused",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).AdminSetProposingPaused(ctx, req.(*AdminPauseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_AdminSetTxPoolPaused_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminPauseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).AdminSetTxPoolPaused(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.System/AdminSetTxPoolPaused",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).AdminSetTxPoolPaused(ctx, req.(*AdminPauseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_AdminFlushTxPool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).AdminFlushTxPool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.System/AdminFlushTxPool",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).AdminFlushTxPool(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_AdminSetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminSetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).AdminSetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.System/AdminSetLogLevel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).AdminSetLogLevel(ctx, req.(*AdminSetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_AdminRegenerateStateSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).AdminRegenerateStateSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.System/AdminRegenerateStateSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).AdminRegenerateStateSnapshot(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_SyncerStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).SyncerStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.System/SyncerStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).SyncerStatus(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // System_ServiceDesc is the grpc.ServiceDesc for System service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -370,10 +710,50 @@ var System_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PeersStatus",
 			Handler:    _System_PeersStatus_Handler,
 		},
+		{
+			MethodName: "PeersScore",
+			Handler:    _System_PeersScore_Handler,
+		},
 		{
 			MethodName: "BlockByNumber",
 			Handler:    _System_BlockByNumber_Handler,
 		},
+		{
+			MethodName: "ImportBlocks",
+			Handler:    _System_ImportBlocks_Handler,
+		},
+		{
+			MethodName: "GetTrieNodes",
+			Handler:    _System_GetTrieNodes_Handler,
+		},
+		{
+			MethodName: "AdminStatus",
+			Handler:    _System_AdminStatus_Handler,
+		},
+		{
+			MethodName: "AdminSetProposingPaused",
+			Handler:    _System_AdminSetProposingPaused_Handler,
+		},
+		{
+			MethodName: "AdminSetTxPoolPaused",
+			Handler:    _System_AdminSetTxPoolPaused_Handler,
+		},
+		{
+			MethodName: "AdminFlushTxPool",
+			Handler:    _System_AdminFlushTxPool_Handler,
+		},
+		{
+			MethodName: "AdminSetLogLevel",
+			Handler:    _System_AdminSetLogLevel_Handler,
+		},
+		{
+			MethodName: "AdminRegenerateStateSnapshot",
+			Handler:    _System_AdminRegenerateStateSnapshot_Handler,
+		},
+		{
+			MethodName: "SyncerStatus",
+			Handler:    _System_SyncerStatus_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -0,0 +1,184 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/helper/logging"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
+	"github.com/0xPolygon/polygon-edge/network"
+)
+
+// ReloadableConfig holds the operational parameters which can be changed while the node is running,
+// the changes of the other parameters require a restart
+type ReloadableConfig struct {
+	LogLevel        hclog.Level
+	ModuleLogLevels map[string]hclog.Level
+
+	JSONRPCBatchLengthLimit uint64
+	JSONRPCBlockRangeLimit  uint64
+
+	PriceLimit uint64
+
+	MaxInboundPeers  int64
+	MaxOutboundPeers int64
+	PeerFilters      *network.PeerFilters
+
+	// BlockTrackerPollInterval overrides the poll interval of the block trackers set in the genesis, if not 0
+	BlockTrackerPollInterval time.Duration
+}
+
+// NewReloadableConfig returns the reloadable parameters of the server config
+func NewReloadableConfig(config *Config) *ReloadableConfig {
+	reloadable := &ReloadableConfig{
+		LogLevel:                 config.LogLevel,
+		ModuleLogLevels:          config.ModuleLogLevels,
+		PriceLimit:               config.PriceLimit,
+		BlockTrackerPollInterval: config.BlockTrackerPollInterval,
+	}
+
+	if config.JSONRPC != nil {
+		reloadable.JSONRPCBatchLengthLimit = config.JSONRPC.BatchLengthLimit
+		reloadable.JSONRPCBlockRangeLimit = config.JSONRPC.BlockRangeLimit
+	}
+
+	if config.Network != nil {
+		reloadable.MaxInboundPeers = config.Network.MaxInboundPeers
+		reloadable.MaxOutboundPeers = config.Network.MaxOutboundPeers
+		reloadable.PeerFilters = config.Network.PeerFilters
+	}
+
+	return reloadable
+}
+
+// ConfigChange is a parameter changed by the config reload
+type ConfigChange struct {
+	Param string      `json:"param"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// blockTrackerConsensus is implemented by the consensus engines tracking the blocks of the root chain
+type blockTrackerConsensus interface {
+	SetBlockTrackerPollInterval(override time.Duration)
+}
+
+// Reload applies the changed reloadable parameters to the running node, and logs each change
+// to the audit log. The config is validated first, so either all the changes are applied or none
+func (s *Server) Reload(config *ReloadableConfig) ([]*ConfigChange, error) {
+	s.reloadLock.Lock()
+	defer s.reloadLock.Unlock()
+
+	if config.MaxOutboundPeers > s.config.Network.MaxOutboundPeers {
+		return nil, fmt.Errorf("%w (%d > %d)",
+			network.ErrMaxOutboundPeersIncreased, config.MaxOutboundPeers, s.config.Network.MaxOutboundPeers)
+	}
+
+	if config.PeerFilters != nil {
+		if err := config.PeerFilters.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid peer filters: %w", err)
+		}
+	}
+
+	var (
+		current = s.reloadable
+		logger  = s.logger.Named("config-reload")
+		changes = make([]*ConfigChange, 0)
+	)
+
+	record := func(param string, oldValue, newValue interface{}) {
+		changes = append(changes, &ConfigChange{Param: param, Old: oldValue, New: newValue})
+
+		logger.Info("Applied config change", "param", param, "old", oldValue, "new", newValue)
+	}
+
+	// the network parameters go first, since they are the only ones whose setters can fail
+	if config.MaxInboundPeers != current.MaxInboundPeers || config.MaxOutboundPeers != current.MaxOutboundPeers {
+		if err := s.network.SetMaxPeers(config.MaxInboundPeers, config.MaxOutboundPeers); err != nil {
+			return nil, err
+		}
+
+		if config.MaxInboundPeers != current.MaxInboundPeers {
+			record("network.max_inbound_peers", current.MaxInboundPeers, config.MaxInboundPeers)
+		}
+
+		if config.MaxOutboundPeers != current.MaxOutboundPeers {
+			record("network.max_outbound_peers", current.MaxOutboundPeers, config.MaxOutboundPeers)
+		}
+	}
+
+	if !config.PeerFilters.Equal(current.PeerFilters) {
+		if err := s.network.SetPeerFilters(config.PeerFilters); err != nil {
+			return nil, err
+		}
+
+		record("network.peer_filters", current.PeerFilters, config.PeerFilters)
+	}
+
+	if config.LogLevel != current.LogLevel {
+		// the named loggers of all the modules share the level of the root logger
+		s.logger.SetLevel(config.LogLevel)
+		record("log_level", current.LogLevel.String(), config.LogLevel.String())
+	}
+
+	oldLevels, newLevels := logging.FormatModuleLevels(current.ModuleLogLevels),
+		logging.FormatModuleLevels(config.ModuleLogLevels)
+	if oldLevels != newLevels {
+		// the module levels set via the admin APIs are replaced as well
+		s.logLevels.SetModules(config.ModuleLogLevels)
+		record("log_levels", oldLevels, newLevels)
+	}
+
+	if config.JSONRPCBatchLengthLimit != current.JSONRPCBatchLengthLimit {
+		record("json_rpc_batch_request_limit", current.JSONRPCBatchLengthLimit, config.JSONRPCBatchLengthLimit)
+	}
+
+	if config.JSONRPCBlockRangeLimit != current.JSONRPCBlockRangeLimit {
+		record("json_rpc_block_range_limit", current.JSONRPCBlockRangeLimit, config.JSONRPCBlockRangeLimit)
+	}
+
+	if config.PriceLimit != current.PriceLimit {
+		s.txpool.SetPriceLimit(config.PriceLimit)
+		record("tx_pool.price_limit", current.PriceLimit, config.PriceLimit)
+	}
+
+	if s.jsonrpcServer != nil {
+		s.jsonrpcServer.SetLimits(jsonrpc.Limits{
+			PriceLimit:       config.PriceLimit,
+			BatchLengthLimit: config.JSONRPCBatchLengthLimit,
+			BlockRangeLimit:  config.JSONRPCBlockRangeLimit,
+		})
+	}
+
+	if config.BlockTrackerPollInterval != current.BlockTrackerPollInterval {
+		if c, ok := s.consensus.(blockTrackerConsensus); ok {
+			c.SetBlockTrackerPollInterval(config.BlockTrackerPollInterval)
+		}
+
+		record("block_tracker_poll_interval",
+			current.BlockTrackerPollInterval.String(), config.BlockTrackerPollInterval.String())
+	}
+
+	s.reloadable = config
+
+	if len(changes) == 0 {
+		logger.Info("Config reloaded, no parameter changed")
+	}
+
+	return changes, nil
+}
+
+// ReloadFrom loads the reloadable parameters and applies them to the running node,
+// the failures are logged and the previous parameters stay in effect
+func (s *Server) ReloadFrom(load func() (*ReloadableConfig, error)) {
+	config, err := load()
+	if err == nil {
+		_, err = s.Reload(config)
+	}
+
+	if err != nil {
+		s.logger.Named("config-reload").Error("Failed to reload config", "err", err)
+	}
+}
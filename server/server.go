@@ -10,9 +10,13 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/freezer"
 	"github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
 	"github.com/0xPolygon/polygon-edge/blockchain/storage/memory"
 	consensusPolyBFT "github.com/0xPolygon/polygon-edge/consensus/polybft"
@@ -21,12 +25,18 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/archive"
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/bloombits"
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/consensus"
 	"github.com/0xPolygon/polygon-edge/contracts"
 	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/ethstats"
 	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/kvdb"
+	"github.com/0xPolygon/polygon-edge/helper/logging"
+	"github.com/0xPolygon/polygon-edge/helper/profiling"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/helper/tracing"
 	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
@@ -35,19 +45,29 @@ import (
 	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/state/runtime/addresslist"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful/governance"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/txpolicy"
+	"github.com/0xPolygon/polygon-edge/streaming"
+	"github.com/0xPolygon/polygon-edge/syncer"
 	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validate"
+	"github.com/0xPolygon/polygon-edge/webhooks"
 	"github.com/hashicorp/go-hclog"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 var (
 	errBlockTimeMissing = errors.New("block time configuration is missing")
 	errBlockTimeInvalid = errors.New("block time configuration is invalid")
+
+	errValidatorPerformanceNotSupported = errors.New("validator performance is not recorded by the consensus")
 )
 
 // Server is the central manager of the blockchain client
@@ -57,6 +77,22 @@ type Server struct {
 	state        state.State
 	stateStorage itrie.Storage
 
+	// statePruner prunes the state which is not retained, nil in the archive mode
+	statePruner *statePruner
+
+	// stateSnapshot maintains the flat snapshot of the head state, nil if disabled
+	stateSnapshot *stateSnapshot
+
+	// logIndexer maintains the index of the log blooms, nil if disabled
+	logIndexer *bloombits.Indexer
+
+	// historyPruner prunes the receipts and the tx lookups which are not retained, nil if all are retained
+	historyPruner *historyPruner
+
+	// freezer holds the data of the ancient blocks and chainFreezer moves it there, nil if disabled
+	freezer      *freezer.Freezer
+	chainFreezer *chainFreezer
+
 	consensus consensus.Consensus
 
 	// blockchain stack
@@ -66,6 +102,9 @@ type Server struct {
 	// state executor
 	executor *state.Executor
 
+	// engine executes the contract code of the JSON-RPC calls and traces
+	engine runtime.Runtime
+
 	// jsonrpc stack
 	jsonrpcServer *jsonrpc.JSONRPC
 
@@ -79,6 +118,23 @@ type Server struct {
 	txpool *txpool.TxPool
 
 	prometheusServer *http.Server
+	// debugServer serves the profiles and the runtime statistics to the admin, nil if disabled
+	debugServer *http.Server
+
+	// ethStats reports the statistics of the node to the ethstats server, nil if disabled
+	ethStats *ethstats.Service
+
+	// webhooks posts the new blocks, logs and receipts to the configured endpoints, nil if none is configured
+	webhooks *webhooks.Dispatcher
+
+	// streaming publishes the contract events and the block headers to the broker, nil if disabled
+	streaming *streaming.Service
+
+	// backupService creates the scheduled backups of the chain, nil if the backups aren't scheduled
+	backupService *backupService
+
+	// shutdownTracing flushes and stops the tracer provider, nil if the tracing is disabled
+	shutdownTracing func(context.Context) error
 
 	// secrets manager
 	secretsManager secrets.SecretsManager
@@ -88,6 +144,17 @@ type Server struct {
 
 	// gasHelper is providing functions regarding gas and fees
 	gasHelper *gasprice.GasHelper
+
+	// controls are the operational controls of the node used by the admin APIs
+	controls *nodeControls
+	// logLevels holds the log levels of the node and of its modules
+	logLevels *logging.Levels
+	// adminToken is the bearer token of the admin APIs, empty if the admin controls are disabled
+	adminToken string
+
+	// reloadable holds the parameters applied on the start or by the last config reload
+	reloadLock sync.Mutex
+	reloadable *ReloadableConfig
 }
 
 // newFileLogger returns logger instance that writes all logs to a specified file.
@@ -138,12 +205,39 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, fmt.Errorf("could not setup new logger instance, %w", err)
 	}
 
+	// the log levels of the modules can be changed via the admin APIs
+	logger, logLevels := logging.NewLevelLogger(logger)
+
+	logLevels.SetModules(config.ModuleLogLevels)
+
+	var adminToken string
+
+	if config.AdminTokenFile != "" {
+		if adminToken, err = readAdminToken(config.AdminTokenFile); err != nil {
+			return nil, err
+		}
+	}
+
+	grpcOpts := []grpc.ServerOption{grpc.UnaryInterceptor(newUnaryInterceptor(adminToken))}
+
+	grpcTLS, err := config.GRPCTLS.ServerTLSConfig()
+	if err != nil {
+		return nil, fmt.Errorf("could not setup GRPC TLS, %w", err)
+	}
+
+	if grpcTLS != nil {
+		grpcOpts = append(grpcOpts, grpc.Creds(credentials.NewTLS(grpcTLS)))
+	}
+
 	m := &Server{
 		logger:             logger.Named("server"),
 		config:             config,
 		chain:              config.Chain,
-		grpcServer:         grpc.NewServer(grpc.UnaryInterceptor(unaryInterceptor)),
+		grpcServer:         grpc.NewServer(grpcOpts...),
 		restoreProgression: progress.NewProgressionWrapper(progress.ChainSyncRestore),
+		reloadable:         NewReloadableConfig(config),
+		logLevels:          logLevels,
+		adminToken:         adminToken,
 	}
 
 	if config.Chain.Params.GetEngine() == string(IBFTConsensus) {
@@ -171,6 +265,22 @@ func NewServer(config *Config) (*Server, error) {
 		m.prometheusServer = m.startPrometheusServer(config.Telemetry.PrometheusAddr)
 	}
 
+	if config.Telemetry.Tracing.Enabled() {
+		if m.shutdownTracing, err = tracing.Setup(config.Telemetry.Tracing); err != nil {
+			return nil, fmt.Errorf("failed to set up tracing: %w", err)
+		}
+
+		m.logger.Info("OpenTelemetry tracing enabled", "endpoint", config.Telemetry.Tracing.Endpoint)
+	}
+
+	if config.Telemetry.DebugAddr != nil {
+		if adminToken == "" {
+			return nil, errDebugWithoutAdminToken
+		}
+
+		m.debugServer = m.startDebugServer(config.Telemetry.DebugAddr)
+	}
+
 	// Set up datadog profiler
 	if ddErr := m.enableDataDogProfiler(); err != nil {
 		m.logger.Error("DataDog profiler setup failed", "err", ddErr.Error())
@@ -195,18 +305,83 @@ func NewServer(config *Config) (*Server, error) {
 		m.network = network
 	}
 
+	storageBackend, err := kvdb.ParseBackend(string(config.StorageBackend))
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("storage backend", "backend", storageBackend)
+
+	if m.config.SyncMode, err = syncer.ParseSyncMode(string(config.SyncMode)); err != nil {
+		return nil, err
+	}
+
 	// start blockchain object
-	stateStorage, err := itrie.NewLevelDBStorage(filepath.Join(m.config.DataDir, "trie"), logger)
+	stateDB, err := kvdb.Open(storageBackend, filepath.Join(m.config.DataDir, "trie"), kvdb.Options{})
 	if err != nil {
 		return nil, err
 	}
 
+	var stateStorage itrie.Storage = itrie.NewDatabaseStorage(stateDB)
+
 	m.stateStorage = stateStorage
 
-	st := itrie.NewState(stateStorage)
+	var pruningStorage *itrie.PruningStorage
+
+	if config.StateHistory == 0 {
+		logger.Info("archive mode, the state of all blocks is retained")
+	} else {
+		logger.Info("the state of the most recent blocks is retained", "blocks", config.StateHistory)
+
+		prunableStorage, ok := stateStorage.(itrie.PrunableStorage)
+		if !ok {
+			return nil, errors.New("state storage does not support pruning")
+		}
+
+		pruningStorage = itrie.NewPruningStorage(prunableStorage)
+		m.stateStorage = pruningStorage
+	}
+
+	st := itrie.NewState(m.stateStorage)
 	m.state = st
 
+	var flatLayer *itrie.FlatLayer
+
+	if config.StateSnapshot {
+		prunableStorage, ok := m.stateStorage.(itrie.PrunableStorage)
+		if !ok {
+			return nil, errors.New("state storage does not support the flat snapshot")
+		}
+
+		if flatLayer, err = itrie.NewFlatLayer(prunableStorage, logger); err != nil {
+			return nil, fmt.Errorf("failed to open the flat snapshot: %w", err)
+		}
+
+		st.SetFlatLayer(flatLayer)
+	}
+
 	m.executor = state.NewExecutor(config.Chain.Params, st, logger)
+	m.executor.ParallelExecution = config.ParallelExecution
+	m.executor.Prefetch = config.StatePrefetch
+
+	m.executor.StatefulPrecompiles, err = stateful.NewPrecompiles(config.Chain.Params, statefulPrecompileFactories)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stateful precompiles: %w", err)
+	}
+
+	if m.engine, err = newExecutionEngine(config.ExecutionEngine); err != nil {
+		return nil, err
+	}
+
+	if config.ExecutionEngineBlocks {
+		logger.Warn("the blocks are executed by the experimental execution engine", "engine", m.engine.Name())
+
+		m.executor.Engine = m.engine
+	}
+
+	if config.EVMStats {
+		evm.EnableStats()
+	}
 
 	// custom write genesis hook per consensus engine
 	engineName := m.config.Chain.Params.GetEngine()
@@ -250,6 +425,18 @@ func NewServer(config *Config) (*Server, error) {
 			m.config.Chain.Params.BridgeBlockList)
 	}
 
+	// apply paymaster allow list genesis data
+	if m.config.Chain.Params.PaymasterAllowList != nil {
+		addresslist.ApplyGenesisAllocs(m.config.Chain.Genesis, contracts.AllowListPaymasterAddr,
+			m.config.Chain.Params.PaymasterAllowList)
+	}
+
+	// apply transaction policy genesis data
+	if m.config.Chain.Params.TxPolicy != nil {
+		txpolicy.ApplyGenesisAllocs(m.config.Chain.Genesis, contracts.TxPolicyAddr,
+			m.config.Chain.Params.TxPolicy)
+	}
+
 	var initialStateRoot = types.ZeroHash
 
 	if ConsensusType(engineName) == PolyBFTConsensus {
@@ -305,13 +492,36 @@ func NewServer(config *Config) (*Server, error) {
 				return nil, err
 			}
 		} else {
-			db, err = leveldb.NewLevelDBStorage(
+			chainDB, err := kvdb.Open(
+				storageBackend,
 				filepath.Join(m.config.DataDir, "blockchain"),
-				m.logger,
+				kvdb.Options{Cache: leveldb.DefaultCache, Handles: leveldb.DefaultHandles},
 			)
 			if err != nil {
 				return nil, err
 			}
+
+			if config.FreezerThreshold == 0 {
+				db = storage.NewDatabaseStorage(m.logger.Named(string(storageBackend)), chainDB)
+			} else {
+				freezerDir := config.FreezerDir
+				if freezerDir == "" {
+					freezerDir = filepath.Join(m.config.DataDir, "ancient")
+				}
+
+				m.freezer, err = freezer.Open(freezerDir, storage.AncientTables)
+				if err != nil {
+					return nil, fmt.Errorf("failed to open the freezer: %w", err)
+				}
+
+				db = storage.NewDatabaseStorageWithAncients(m.logger.Named(string(storageBackend)), chainDB, m.freezer)
+			}
+		}
+	}
+
+	if config.IntegrityCheckDepth != 0 {
+		if err := checkChainIntegrity(logger, db, config.IntegrityCheckDepth, config.IntegrityRollback); err != nil {
+			return nil, err
 		}
 	}
 
@@ -328,6 +538,46 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	// the chain parameters enacted by the governance contract override the ones of the chain params
+	for _, precompile := range config.Chain.Params.StatefulPrecompiles {
+		if precompile.Name == governance.Name {
+			m.blockchain.SetParamsOverrides(governance.NewParams(logger, m.state, precompile.Address))
+		}
+	}
+
+	// the blocks of the BFT engines are final once written, so their chains are never reorganized
+	m.blockchain.SetReorgLimits(blockchain.ReorgLimits{
+		Finalizing: ConsensusType(engineName) == PolyBFTConsensus || ConsensusType(engineName) == IBFTConsensus,
+		MaxDepth:   config.MaxReorgDepth,
+	})
+
+	if config.StateDiffCache != 0 {
+		if err := m.blockchain.EnableStateDiffCache(int(config.StateDiffCache)); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.LogIndex {
+		m.logIndexer = bloombits.NewIndexer(logger, db, m.blockchain)
+	}
+
+	if config.ReceiptsHistory != 0 || config.TxLookupHistory != 0 {
+		logger.Info("the receipts and the transaction lookups of the most recent blocks are retained",
+			"receipts", config.ReceiptsHistory, "tx lookups", config.TxLookupHistory)
+
+		m.historyPruner = newHistoryPruner(logger, db, m.blockchain, config.ReceiptsHistory, config.TxLookupHistory)
+	}
+
+	if m.freezer != nil {
+		logger.Info("the data of the ancient blocks is moved to the freezer",
+			"threshold", config.FreezerThreshold, "frozen", m.freezer.Frozen())
+
+		m.chainFreezer, err = newChainFreezer(logger, db, m.freezer, m.blockchain, config.FreezerThreshold)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// here we can provide some other configuration
 	m.gasHelper, err = gasprice.NewGasHelper(gasprice.DefaultGasHelperConfig, m.blockchain)
 	if err != nil {
@@ -339,6 +589,7 @@ func NewServer(config *Config) (*Server, error) {
 	{
 		hub := &txpoolHub{
 			state:      m.state,
+			executor:   m.executor,
 			Blockchain: m.blockchain,
 		}
 
@@ -354,6 +605,9 @@ func NewServer(config *Config) (*Server, error) {
 				PriceLimit:         m.config.PriceLimit,
 				MaxAccountEnqueued: m.config.MaxAccountEnqueued,
 				ChainID:            big.NewInt(m.config.Chain.Params.ChainID),
+
+				UserOperationEntryPoints: m.config.UserOpEntryPoints,
+				GossipBatchInterval:      m.config.TxGossipBatchInterval,
 			},
 		)
 		if err != nil {
@@ -361,6 +615,14 @@ func NewServer(config *Config) (*Server, error) {
 		}
 
 		m.txpool.SetSigner(signer)
+
+		if m.config.Chain.Params.PaymasterAllowList != nil {
+			m.txpool.SetPaymasters(hub)
+		}
+
+		if m.config.Chain.Params.TxPolicy != nil {
+			m.txpool.SetTxPolicy(hub)
+		}
 	}
 
 	{
@@ -378,6 +640,8 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	m.controls = newNodeControls(logger, m.consensus, m.txpool, m.logLevels)
+
 	// initialize data in consensus layer
 	if err := m.consensus.Initialize(); err != nil {
 		return nil, err
@@ -410,21 +674,129 @@ func NewServer(config *Config) (*Server, error) {
 	m.txpool.SetBaseFee(m.blockchain.Header())
 	m.txpool.Start()
 
+	// start following the head of the chain with the flat snapshot
+	if flatLayer != nil {
+		m.stateSnapshot = newStateSnapshot(flatLayer, m.blockchain)
+		m.stateSnapshot.start()
+		m.controls.stateSnapshot.Store(m.stateSnapshot)
+	}
+
+	// start indexing the log blooms of the confirmed blocks
+	if m.logIndexer != nil {
+		m.logIndexer.Start()
+	}
+
+	// start pruning the receipts and the transaction lookups which are not retained
+	if m.historyPruner != nil {
+		m.historyPruner.start()
+	}
+
+	// start moving the data of the ancient blocks to the freezer
+	if m.chainFreezer != nil {
+		m.chainFreezer.start()
+	}
+
+	// start pruning the state which is not retained
+	if pruningStorage != nil {
+		// the genesis state is always retained, it is checked against on every start
+		genesisRoots := []types.Hash{genesisRoot}
+		if initialStateRoot != types.ZeroHash {
+			genesisRoots = append(genesisRoots, initialStateRoot)
+		}
+
+		m.statePruner = newStatePruner(logger, pruningStorage, m.blockchain, config.StateHistory, genesisRoots)
+		m.statePruner.start()
+	}
+
+	// start reporting to the ethstats server
+	if config.EthStats != nil {
+		m.ethStats = ethstats.NewService(
+			logger,
+			config.EthStats,
+			m.blockchain,
+			&ethStatsNode{server: m},
+			config.Chain.Params.ChainID,
+			config.Network.Addr.Port,
+		)
+		m.ethStats.Start()
+	}
+
+	// start posting to the webhook endpoints
+	if len(config.Webhooks) > 0 {
+		if m.webhooks, err = webhooks.NewDispatcher(
+			logger,
+			config.Webhooks,
+			m.blockchain,
+			config.Chain.Params.ChainID,
+		); err != nil {
+			return nil, err
+		}
+
+		m.webhooks.Start()
+	}
+
+	// start publishing to the streaming broker, the events are tracked over the JSON-RPC of the node
+	if config.Streaming != nil {
+		if m.streaming, err = streaming.NewService(
+			logger,
+			config.Streaming,
+			m.blockchain,
+			uint64(config.Chain.Params.ChainID),
+			localJSONRPCEndpoint(config.JSONRPC.JSONRPCAddr),
+			config.DataDir,
+			config.BlockTrackerPollInterval,
+		); err != nil {
+			return nil, err
+		}
+
+		if err := m.streaming.Start(); err != nil {
+			return nil, err
+		}
+	}
+
+	// start creating the scheduled backups
+	if config.Backup != nil {
+		if err := m.setupBackupService(); err != nil {
+			return nil, err
+		}
+	}
+
 	return m, nil
 }
 
-func unaryInterceptor(
-	ctx context.Context,
-	req interface{},
-	_ *grpc.UnaryServerInfo,
-	handler grpc.UnaryHandler,
-) (interface{}, error) {
-	// Validate request
-	if err := validate.ValidateRequest(req); err != nil {
-		return nil, err
+// localJSONRPCEndpoint returns the URL the node reaches its own JSON-RPC at,
+// the loopback address is used if the JSON-RPC listens on all the interfaces
+func localJSONRPCEndpoint(addr *net.TCPAddr) string {
+	ip := addr.IP
+	if ip == nil || ip.IsUnspecified() {
+		ip = net.IPv4(127, 0, 0, 1)
 	}
 
-	return handler(ctx, req)
+	return "http://" + net.JoinHostPort(ip.String(), strconv.Itoa(addr.Port))
+}
+
+// newUnaryInterceptor returns the interceptor validating the requests,
+// the admin methods require the admin token
+func newUnaryInterceptor(adminToken string) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if strings.HasPrefix(info.FullMethod, adminMethodPrefix) {
+			if err := authorizeAdmin(ctx, adminToken); err != nil {
+				return nil, err
+			}
+		}
+
+		// Validate request
+		if err := validate.ValidateRequest(req); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
 }
 
 func (s *Server) restoreChain() error {
@@ -440,7 +812,8 @@ func (s *Server) restoreChain() error {
 }
 
 type txpoolHub struct {
-	state state.State
+	state    state.State
+	executor *state.Executor
 	*blockchain.Blockchain
 }
 
@@ -487,6 +860,42 @@ func (t *txpoolHub) GetBalance(root types.Hash, addr types.Address) (*big.Int, e
 	return account.Balance, nil
 }
 
+// IsSponsored returns true if the gasless transaction is approved by the paymaster it calls,
+// which is enabled in the paymaster allow list as of the header
+func (t *txpoolHub) IsSponsored(header *types.Header, tx *types.Transaction) bool {
+	transition, err := t.executor.BeginTxn(header.StateRoot, header, types.ZeroAddress)
+	if err != nil {
+		return false
+	}
+
+	return transition.IsSponsored(tx)
+}
+
+// CheckTxPolicy returns the error if the transaction is denied by the transaction policy as of the state root
+func (t *txpoolHub) CheckTxPolicy(root types.Hash, tx *types.Transaction) error {
+	snap, err := t.state.NewSnapshotAt(root)
+	if err != nil {
+		return err
+	}
+
+	policy := txpolicy.NewTxPolicy(&txPolicyState{state.NewTxn(snap)}, contracts.TxPolicyAddr)
+
+	return policy.Check(tx.To, tx.Input, tx.Value)
+}
+
+// txPolicyState reads the transaction policy from the state snapshot
+type txPolicyState struct {
+	*state.Txn
+}
+
+func (s *txPolicyState) GetStorage(addr types.Address, key types.Hash) types.Hash {
+	return s.GetState(addr, key)
+}
+
+func (s *txPolicyState) GetTxContext() runtime.TxContext {
+	return runtime.TxContext{}
+}
+
 // setupSecretsManager sets up the secrets manager
 func (s *Server) setupSecretsManager() error {
 	secretsManagerConfig := s.config.SecretsManager
@@ -502,9 +911,9 @@ func (s *Server) setupSecretsManager() error {
 		Logger: s.logger,
 	}
 
-	if secretsManagerType == secrets.Local {
+	if secretsManagerType == secrets.Local || secretsManagerType == secrets.EncryptedLocal {
 		// Only the base directory is required for
-		// the local secrets manager
+		// the local secrets managers
 		secretsManagerParams.Extra = map[string]interface{}{
 			secrets.Path: s.config.DataDir,
 		}
@@ -573,12 +982,18 @@ func (s *Server) setupConsensus() error {
 			Network:               s.network,
 			Blockchain:            s.blockchain,
 			Executor:              s.executor,
+			StateStorage:          s.stateStorage,
 			Grpc:                  s.grpcServer,
 			Logger:                s.logger,
 			SecretsManager:        s.secretsManager,
 			BlockTime:             uint64(blockTime.Seconds()),
 			NumBlockConfirmations: s.config.NumBlockConfirmations,
 			MetricsInterval:       s.config.MetricsInterval,
+			SyncMode:              s.config.SyncMode,
+
+			BlockTrackerPollInterval: s.config.BlockTrackerPollInterval,
+			ProposalDeadline:         s.config.ProposalDeadline,
+			RemoteSigner:             s.config.RemoteSigner,
 		},
 	)
 
@@ -619,8 +1034,15 @@ func extractBlockTime(engineConfig map[string]interface{}) (common.Duration, err
 
 type jsonRPCHub struct {
 	state              state.State
+	stateStorage       itrie.Storage
 	restoreProgression *progress.ProgressionWrapper
 
+	// callTimeout caps the execution time of a single eth_call (or eth_estimateGas attempt)
+	callTimeout time.Duration
+
+	// engine executes the contract code of the calls and the traces
+	engine runtime.Runtime
+
 	*blockchain.Blockchain
 	*txpool.TxPool
 	*state.Executor
@@ -628,6 +1050,7 @@ type jsonRPCHub struct {
 	consensus.Consensus
 	consensus.BridgeDataProvider
 	gasprice.GasStore
+	*nodeControls
 }
 
 func (j *jsonRPCHub) GetPeers() int {
@@ -648,6 +1071,11 @@ func (j *jsonRPCHub) GetAccount(root types.Hash, addr types.Address) (*jsonrpc.A
 	return account, nil
 }
 
+// DumpState returns a range of the accounts of the state, with their code and storage
+func (j *jsonRPCHub) DumpState(root types.Hash, start types.Hash, maxAccounts int) (*itrie.StateDump, error) {
+	return itrie.DumpState(j.stateStorage, root, start, maxAccounts)
+}
+
 // GetForksInTime returns the active forks at the given block height
 func (j *jsonRPCHub) GetForksInTime(blockNumber uint64) chain.ForksInTime {
 	return j.Executor.GetForksInTime(blockNumber)
@@ -683,10 +1111,63 @@ func (j *jsonRPCHub) GetCode(root types.Hash, addr types.Address) ([]byte, error
 	return code, nil
 }
 
+// GetProof returns the Merkle proofs of the account and the given storage slots at the given state root
+func (j *jsonRPCHub) GetProof(
+	root types.Hash,
+	addr types.Address,
+	slots []types.Hash,
+) (*jsonrpc.AccountProof, error) {
+	snap, err := j.state.NewSnapshotAt(root)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get snapshot for root '%s': %w", root, err)
+	}
+
+	account, err := snap.GetAccount(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	accountProof, err := itrie.Prove(root, crypto.Keccak256(addr.Bytes()), j.stateStorage)
+	if err != nil {
+		return nil, err
+	}
+
+	proof := &jsonrpc.AccountProof{
+		Balance:       big.NewInt(0),
+		CodeHash:      types.EmptyCodeHash,
+		StorageHash:   types.EmptyRootHash,
+		Proof:         accountProof,
+		StorageProofs: make([]*jsonrpc.StorageProof, len(slots)),
+	}
+
+	if account != nil {
+		proof.Balance = new(big.Int).Set(account.Balance)
+		proof.Nonce = account.Nonce
+		proof.CodeHash = types.BytesToHash(account.CodeHash)
+		proof.StorageHash = account.Root
+	}
+
+	for i, slot := range slots {
+		storageProof, err := itrie.Prove(proof.StorageHash, crypto.Keccak256(slot.Bytes()), j.stateStorage)
+		if err != nil {
+			return nil, err
+		}
+
+		proof.StorageProofs[i] = &jsonrpc.StorageProof{
+			Key:   slot,
+			Value: snap.GetStorage(addr, proof.StorageHash, slot).Bytes(),
+			Proof: storageProof,
+		}
+	}
+
+	return proof, nil
+}
+
 func (j *jsonRPCHub) ApplyTxn(
 	header *types.Header,
 	txn *types.Transaction,
 	override types.StateOverride,
+	blockOverride *types.BlockOverride,
 	nonPayable bool,
 ) (result *runtime.ExecutionResult, err error) {
 	blockCreator, err := j.GetConsensus().GetBlockCreator(header)
@@ -694,7 +1175,7 @@ func (j *jsonRPCHub) ApplyTxn(
 		return nil, err
 	}
 
-	transition, err := j.BeginTxn(header.StateRoot, header, blockCreator)
+	transition, err := j.beginTxn(header.StateRoot, header, blockCreator)
 	if err != nil {
 		return
 	}
@@ -705,13 +1186,234 @@ func (j *jsonRPCHub) ApplyTxn(
 		}
 	}
 
+	if blockOverride != nil {
+		transition.WithBlockOverride(blockOverride)
+	}
+
 	transition.SetNonPayable(nonPayable)
 
+	if j.callTimeout > 0 {
+		transition.SetInterruptible()
+
+		timer := time.AfterFunc(j.callTimeout, transition.Interrupt)
+		defer timer.Stop()
+	}
+
 	result, err = transition.Apply(txn)
+	if err == nil && errors.Is(result.Err, runtime.ErrExecutionInterrupted) {
+		return nil, fmt.Errorf("%w (timeout = %s)", jsonrpc.ErrExecutionTimeout, j.callTimeout)
+	}
 
 	return
 }
 
+// ApplyTracedTxn applies the transaction on top of the given header, tracing its execution
+func (j *jsonRPCHub) ApplyTracedTxn(
+	header *types.Header,
+	txn *types.Transaction,
+	tracer tracer.Tracer,
+) (*runtime.ExecutionResult, error) {
+	blockCreator, err := j.GetConsensus().GetBlockCreator(header)
+	if err != nil {
+		return nil, err
+	}
+
+	transition, err := j.beginTxn(header.StateRoot, header, blockCreator)
+	if err != nil {
+		return nil, err
+	}
+
+	transition.SetNonPayable(true)
+	transition.SetTracer(tracer)
+
+	if j.callTimeout > 0 {
+		transition.SetInterruptible()
+
+		timer := time.AfterFunc(j.callTimeout, transition.Interrupt)
+		defer timer.Stop()
+	}
+
+	result, err := transition.Apply(txn)
+	if err == nil && errors.Is(result.Err, runtime.ErrExecutionInterrupted) {
+		return nil, fmt.Errorf("%w (timeout = %s)", jsonrpc.ErrExecutionTimeout, j.callTimeout)
+	}
+
+	return result, err
+}
+
+// ApplyTxnBundles applies the bundles of transactions one after another on top of the given header
+func (j *jsonRPCHub) ApplyTxnBundles(
+	header *types.Header,
+	bundles []*jsonrpc.CallBundle,
+) ([][]*runtime.ExecutionResult, error) {
+	transition, err := j.beginBundlesTxn(header)
+	if err != nil {
+		return nil, err
+	}
+
+	transition.SetNonPayable(true)
+
+	if j.callTimeout > 0 {
+		transition.SetInterruptible()
+
+		timer := time.AfterFunc(j.callTimeout, transition.Interrupt)
+		defer timer.Stop()
+	}
+
+	results := make([][]*runtime.ExecutionResult, len(bundles))
+	for i, bundle := range bundles {
+		results[i] = make([]*runtime.ExecutionResult, 0, len(bundle.Transactions))
+	}
+
+	err = applyTxnBundles(transition, bundles, func(bundleIdx int, result *runtime.ExecutionResult) error {
+		if errors.Is(result.Err, runtime.ErrExecutionInterrupted) {
+			return fmt.Errorf("%w (timeout = %s)", jsonrpc.ErrExecutionTimeout, j.callTimeout)
+		}
+
+		results[bundleIdx] = append(results[bundleIdx], result)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// TraceCallBundles traces the bundles of calls applied one after another on top of the given header
+func (j *jsonRPCHub) TraceCallBundles(
+	header *types.Header,
+	bundles []*jsonrpc.CallBundle,
+	tracer tracer.Tracer,
+) ([][]interface{}, error) {
+	transition, err := j.beginBundlesTxn(header)
+	if err != nil {
+		return nil, err
+	}
+
+	transition.SetTracer(tracer)
+
+	if j.callTimeout > 0 {
+		transition.SetInterruptible()
+
+		timer := time.AfterFunc(j.callTimeout, transition.Interrupt)
+		defer timer.Stop()
+	}
+
+	results := make([][]interface{}, len(bundles))
+	for i, bundle := range bundles {
+		results[i] = make([]interface{}, 0, len(bundle.Transactions))
+	}
+
+	err = applyTxnBundles(transition, bundles, func(bundleIdx int, txResult *runtime.ExecutionResult) error {
+		if errors.Is(txResult.Err, runtime.ErrExecutionInterrupted) {
+			return fmt.Errorf("%w (timeout = %s)", jsonrpc.ErrExecutionTimeout, j.callTimeout)
+		}
+
+		result, err := tracer.GetResult()
+		if err != nil {
+			return err
+		}
+
+		tracer.Clear()
+
+		results[bundleIdx] = append(results[bundleIdx], result)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// beginTxn creates the transition of the calls and the traces, executed by the configured engine
+func (j *jsonRPCHub) beginTxn(
+	parentRoot types.Hash,
+	header *types.Header,
+	blockCreator types.Address,
+) (*state.Transition, error) {
+	transition, err := j.BeginTxn(parentRoot, header, blockCreator)
+	if err != nil {
+		return nil, err
+	}
+
+	if j.engine != nil {
+		transition.SetEngine(j.engine)
+	}
+
+	return transition, nil
+}
+
+// newExecutionEngine creates the execution engine by its name, the built-in EVM if the name is empty
+func newExecutionEngine(name string) (runtime.Runtime, error) {
+	if name == "" {
+		name = DefaultExecutionEngine
+	}
+
+	factory, ok := executionEngines[name]
+	if !ok {
+		return nil, fmt.Errorf("execution engine %s not found", name)
+	}
+
+	engine, err := factory()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create execution engine %s: %w", name, err)
+	}
+
+	return engine, nil
+}
+
+// beginBundlesTxn creates the transition the call bundles are applied on
+func (j *jsonRPCHub) beginBundlesTxn(header *types.Header) (*state.Transition, error) {
+	blockCreator, err := j.GetConsensus().GetBlockCreator(header)
+	if err != nil {
+		return nil, err
+	}
+
+	return j.beginTxn(header.StateRoot, header, blockCreator)
+}
+
+// applyTxnBundles applies the transactions of the bundles one after another,
+// passing the result of each of them to the given handler
+func applyTxnBundles(
+	transition *state.Transition,
+	bundles []*jsonrpc.CallBundle,
+	handler func(bundleIdx int, result *runtime.ExecutionResult) error,
+) error {
+	for i, bundle := range bundles {
+		if bundle.BlockOverride != nil {
+			transition.WithBlockOverride(bundle.BlockOverride)
+		}
+
+		if bundle.StateOverride != nil {
+			if err := transition.WithStateOverride(bundle.StateOverride); err != nil {
+				return fmt.Errorf("bundle %d: %w", i, err)
+			}
+		}
+
+		for k, txn := range bundle.Transactions {
+			// the calls are simulated independently of the gas used by the previous ones,
+			// and from the account nonce changed by them
+			transition.ResetGasPool()
+			txn.Nonce = transition.GetNonce(txn.From)
+
+			result, err := transition.Apply(txn)
+			if err != nil {
+				return fmt.Errorf("bundle %d, transaction %d: %w", i, k, err)
+			}
+
+			if err := handler(i, result); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 // TraceBlock traces all transactions in the given block and returns all results
 func (j *jsonRPCHub) TraceBlock(
 	block *types.Block,
@@ -731,7 +1433,7 @@ func (j *jsonRPCHub) TraceBlock(
 		return nil, err
 	}
 
-	transition, err := j.BeginTxn(parentHeader.StateRoot, block.Header, blockCreator)
+	transition, err := j.beginTxn(parentHeader.StateRoot, block.Header, blockCreator)
 	if err != nil {
 		return nil, err
 	}
@@ -775,7 +1477,7 @@ func (j *jsonRPCHub) TraceTxn(
 		return nil, err
 	}
 
-	transition, err := j.BeginTxn(parentHeader.StateRoot, block.Header, blockCreator)
+	transition, err := j.beginTxn(parentHeader.StateRoot, block.Header, blockCreator)
 	if err != nil {
 		return nil, err
 	}
@@ -818,17 +1520,29 @@ func (j *jsonRPCHub) TraceCall(
 		return nil, err
 	}
 
-	transition, err := j.BeginTxn(parentHeader.StateRoot, parentHeader, blockCreator)
+	transition, err := j.beginTxn(parentHeader.StateRoot, parentHeader, blockCreator)
 	if err != nil {
 		return nil, err
 	}
 
 	transition.SetTracer(tracer)
 
-	if _, err := transition.Apply(tx); err != nil {
+	if j.callTimeout > 0 {
+		transition.SetInterruptible()
+
+		timer := time.AfterFunc(j.callTimeout, transition.Interrupt)
+		defer timer.Stop()
+	}
+
+	result, err := transition.Apply(tx)
+	if err != nil {
 		return nil, err
 	}
 
+	if errors.Is(result.Err, runtime.ErrExecutionInterrupted) {
+		return nil, fmt.Errorf("%w (timeout = %s)", jsonrpc.ErrExecutionTimeout, j.callTimeout)
+	}
+
 	return tracer.GetResult()
 }
 
@@ -846,13 +1560,40 @@ func (j *jsonRPCHub) GetSyncProgression() *progress.Progression {
 	return nil
 }
 
+// performanceConsensus is implemented by the consensus engines recording the performance of the validators
+type performanceConsensus interface {
+	GetValidatorPerformance(address types.Address) (*types.ValidatorPerformance, error)
+	ListValidatorPerformance() ([]*types.ValidatorPerformance, error)
+}
+
+func (j *jsonRPCHub) GetValidatorPerformance(address types.Address) (*types.ValidatorPerformance, error) {
+	c, ok := j.Consensus.(performanceConsensus)
+	if !ok {
+		return nil, errValidatorPerformanceNotSupported
+	}
+
+	return c.GetValidatorPerformance(address)
+}
+
+func (j *jsonRPCHub) ListValidatorPerformance() ([]*types.ValidatorPerformance, error) {
+	c, ok := j.Consensus.(performanceConsensus)
+	if !ok {
+		return nil, errValidatorPerformanceNotSupported
+	}
+
+	return c.ListValidatorPerformance()
+}
+
 // SETUP //
 
 // setupJSONRCP sets up the JSONRPC server, using the set configuration
 func (s *Server) setupJSONRPC() error {
 	hub := &jsonRPCHub{
 		state:              s.state,
+		stateStorage:       s.stateStorage,
 		restoreProgression: s.restoreProgression,
+		callTimeout:        s.config.JSONRPC.CallTimeout,
+		engine:             s.engine,
 		Blockchain:         s.blockchain,
 		TxPool:             s.txpool,
 		Executor:           s.executor,
@@ -860,21 +1601,47 @@ func (s *Server) setupJSONRPC() error {
 		Server:             s.network,
 		BridgeDataProvider: s.consensus.GetBridgeProvider(),
 		GasStore:           s.gasHelper,
+		nodeControls:       s.controls,
 	}
 
 	conf := &jsonrpc.Config{
-		Store:                    hub,
-		Addr:                     s.config.JSONRPC.JSONRPCAddr,
-		ChainID:                  uint64(s.config.Chain.Params.ChainID),
-		ChainName:                s.chain.Name,
-		AccessControlAllowOrigin: s.config.JSONRPC.AccessControlAllowOrigin,
-		PriceLimit:               s.config.PriceLimit,
-		BatchLengthLimit:         s.config.JSONRPC.BatchLengthLimit,
-		BlockRangeLimit:          s.config.JSONRPC.BlockRangeLimit,
-		ConcurrentRequestsDebug:  s.config.JSONRPC.ConcurrentRequestsDebug,
-		WebSocketReadLimit:       s.config.JSONRPC.WebSocketReadLimit,
+		Store:                     hub,
+		Addr:                      s.config.JSONRPC.JSONRPCAddr,
+		ChainID:                   uint64(s.config.Chain.Params.ChainID),
+		ChainName:                 s.chain.Name,
+		AccessControlAllowOrigin:  s.config.JSONRPC.AccessControlAllowOrigin,
+		PriceLimit:                s.config.PriceLimit,
+		BatchLengthLimit:          s.config.JSONRPC.BatchLengthLimit,
+		BlockRangeLimit:           s.config.JSONRPC.BlockRangeLimit,
+		ConcurrentRequestsDebug:   s.config.JSONRPC.ConcurrentRequestsDebug,
+		WebSocketReadLimit:        s.config.JSONRPC.WebSocketReadLimit,
+		WebSocketMaxConnections:   s.config.JSONRPC.WebSocketMaxConnections,
+		WebSocketIdleTimeout:      s.config.JSONRPC.WebSocketIdleTimeout,
+		WebSocketMaxSubscriptions: s.config.JSONRPC.WebSocketMaxSubscriptions,
+		WebSocketSendQueue:        s.config.JSONRPC.WebSocketSendQueue,
+		PendingTxsRateLimit:       s.config.JSONRPC.PendingTxsRateLimit,
+		CallTimeout:               s.config.JSONRPC.CallTimeout,
+		CallCacheSize:             s.config.JSONRPC.CallCacheSize,
+		EncodingCacheSize:         s.config.JSONRPC.EncodingCacheSize,
+		MethodConcurrencyLimits:   s.config.JSONRPC.MethodConcurrencyLimits,
+		AccessLog:                 s.config.JSONRPC.AccessLog,
+		RateLimitsFile:            s.config.JSONRPC.RateLimitsFile,
+		AdminToken:                s.adminToken,
+		StateHistory:              s.config.StateHistory,
+		HealthHandler:             s.newHealthHandler(),
+	}
+
+	if s.logIndexer != nil {
+		conf.LogIndex = s.logIndexer
+	}
+
+	tlsConfig, err := s.config.JSONRPC.TLS.ServerTLSConfig()
+	if err != nil {
+		return fmt.Errorf("could not setup JSON-RPC TLS, %w", err)
 	}
 
+	conf.TLS = tlsConfig
+
 	srv, err := jsonrpc.NewJSONRPC(s.logger, conf)
 	if err != nil {
 		return err
@@ -901,7 +1668,7 @@ func (s *Server) setupGRPC() error {
 		}
 	}()
 
-	s.logger.Info("GRPC server running", "addr", s.config.GRPCAddr.String())
+	s.logger.Info("GRPC server running", "addr", s.config.GRPCAddr.String(), "tls", s.config.GRPCTLS.Enabled())
 
 	return nil
 }
@@ -918,11 +1685,51 @@ func (s *Server) JoinPeer(rawPeerMultiaddr string) error {
 
 // Close closes the Minimal server (blockchain, networking, consensus)
 func (s *Server) Close() {
+	// Stop indexing the log blooms and pruning the history before the blockchain storage is closed
+	if s.logIndexer != nil {
+		s.logIndexer.Close()
+	}
+
+	if s.webhooks != nil {
+		s.webhooks.Close()
+	}
+
+	if s.jsonrpcServer != nil {
+		s.jsonrpcServer.Close()
+	}
+
+	if s.streaming != nil {
+		s.streaming.Close()
+	}
+
+	if s.ethStats != nil {
+		s.ethStats.Close()
+	}
+
+	if s.historyPruner != nil {
+		s.historyPruner.close()
+	}
+
+	if s.chainFreezer != nil {
+		s.chainFreezer.close()
+	}
+
+	if s.backupService != nil {
+		s.backupService.close()
+	}
+
 	// Close the blockchain layer
 	if err := s.blockchain.Close(); err != nil {
 		s.logger.Error("failed to close blockchain", "err", err.Error())
 	}
 
+	// Close the freezer once the blockchain doesn't read the ancient blocks
+	if s.freezer != nil {
+		if err := s.freezer.Close(); err != nil {
+			s.logger.Error("failed to close the freezer", "err", err.Error())
+		}
+	}
+
 	// Close the networking layer
 	if err := s.network.Close(); err != nil {
 		s.logger.Error("failed to close networking", "err", err.Error())
@@ -933,11 +1740,26 @@ func (s *Server) Close() {
 		s.logger.Error("failed to close consensus", "err", err.Error())
 	}
 
+	// Stop the state pruning and the flat snapshot before the state storage is closed
+	if s.statePruner != nil {
+		s.statePruner.close()
+	}
+
+	if s.stateSnapshot != nil {
+		s.stateSnapshot.close()
+	}
+
 	// Close the state storage
 	if err := s.stateStorage.Close(); err != nil {
 		s.logger.Error("failed to close storage for trie", "err", err.Error())
 	}
 
+	if s.debugServer != nil {
+		if err := s.debugServer.Shutdown(context.Background()); err != nil {
+			s.logger.Error("Debug server shutdown error", "err", err)
+		}
+	}
+
 	if s.prometheusServer != nil {
 		if err := s.prometheusServer.Shutdown(context.Background()); err != nil {
 			s.logger.Error("Prometheus server shutdown error", err)
@@ -947,6 +1769,13 @@ func (s *Server) Close() {
 	// Close the txpool's main loop
 	s.txpool.Close()
 
+	// Flush the remaining spans
+	if s.shutdownTracing != nil {
+		if err := s.shutdownTracing(context.Background()); err != nil {
+			s.logger.Error("failed to shut down tracing", "err", err.Error())
+		}
+	}
+
 	// Close DataDog profiler
 	s.closeDataDogProfiler()
 }
@@ -958,14 +1787,18 @@ type Entry struct {
 }
 
 func (s *Server) startPrometheusServer(listenAddr *net.TCPAddr) *http.Server {
-	srv := &http.Server{
-		Addr: listenAddr.String(),
-		Handler: promhttp.InstrumentMetricHandler(
-			prometheus.DefaultRegisterer, promhttp.HandlerFor(
-				prometheus.DefaultGatherer,
-				promhttp.HandlerOpts{},
-			),
+	mux := http.NewServeMux()
+	mux.Handle("/", promhttp.InstrumentMetricHandler(
+		prometheus.DefaultRegisterer, promhttp.HandlerFor(
+			prometheus.DefaultGatherer,
+			promhttp.HandlerOpts{},
 		),
+	))
+	mux.HandleFunc(summaryPath, s.handleSummary)
+
+	srv := &http.Server{
+		Addr:              listenAddr.String(),
+		Handler:           mux,
 		ReadHeaderTimeout: 60 * time.Second,
 	}
 
@@ -982,6 +1815,24 @@ func (s *Server) startPrometheusServer(listenAddr *net.TCPAddr) *http.Server {
 	return srv
 }
 
+func (s *Server) startDebugServer(listenAddr *net.TCPAddr) *http.Server {
+	srv := &http.Server{
+		Addr:              listenAddr.String(),
+		Handler:           profiling.NewHandler(s.adminToken),
+		ReadHeaderTimeout: 60 * time.Second,
+	}
+
+	s.logger.Info("Debug server started", "addr", listenAddr.String())
+
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("Debug HTTP server ListenAndServe", "err", err)
+		}
+	}()
+
+	return srv
+}
+
 func initForkManager(engineName string, config *chain.Chain) error {
 	var initialParams *forkmanager.ForkParams
 
@@ -1000,11 +1851,19 @@ func initForkManager(engineName string, config *chain.Chain) error {
 	fm.Clear()
 	fm.RegisterFork(forkmanager.InitialFork, initialParams)
 
+	// the forks activating the stateful precompiles are defined by the chain operator
+	precompileForks := make(map[string]struct{}, len(config.Params.StatefulPrecompiles))
+	for _, precompile := range config.Params.StatefulPrecompiles {
+		precompileForks[precompile.Fork] = struct{}{}
+	}
+
 	// Register forks
 	for name, f := range *config.Params.Forks {
 		// check if fork is not supported by current edge version
 		if _, found := (*chain.AllForksEnabled)[name]; !found {
-			return fmt.Errorf("fork is not available: %s", name)
+			if _, found := precompileForks[name]; !found {
+				return fmt.Errorf("fork is not available: %s", name)
+			}
 		}
 
 		fm.RegisterFork(name, f.Params)
@@ -0,0 +1,202 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// minStatePruneInterval is the minimal number of blocks between two pruning cycles
+	minStatePruneInterval = 1024
+
+	// statePruneSettleBlocks is the number of blocks to wait after the tracking of the written nodes started,
+	// so the states built before it (e.g. the pending proposals) become part of the chain
+	statePruneSettleBlocks = 2
+
+	statePrunerMetrics = "state_pruner"
+)
+
+// statePruner periodically deletes the state which is not reachable from the most recent blocks
+type statePruner struct {
+	logger     hclog.Logger
+	storage    *itrie.PruningStorage
+	blockchain *blockchain.Blockchain
+
+	// history is the number of the most recent blocks whose state is retained
+	history uint64
+	// interval is the number of blocks between two pruning cycles
+	interval uint64
+	// genesisRoots are the state roots of the genesis, which are always retained
+	genesisRoots []types.Hash
+
+	subscription blockchain.Subscription
+	ctx          context.Context
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+
+	// lastPruned, tracking and trackingFrom are accessed by the event loop only
+	lastPruned   uint64
+	tracking     bool
+	trackingFrom uint64
+	running      atomic.Bool
+}
+
+func newStatePruner(
+	logger hclog.Logger,
+	storage *itrie.PruningStorage,
+	blockchain *blockchain.Blockchain,
+	history uint64,
+	genesisRoots []types.Hash,
+) *statePruner {
+	interval := history
+	if interval < minStatePruneInterval {
+		interval = minStatePruneInterval
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &statePruner{
+		logger:       logger.Named("state_pruner"),
+		storage:      storage,
+		blockchain:   blockchain,
+		history:      history,
+		interval:     interval,
+		genesisRoots: genesisRoots,
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+}
+
+// start subscribes to the new blocks and prunes the state every interval blocks
+func (p *statePruner) start() {
+	p.subscription = p.blockchain.SubscribeEvents()
+
+	p.wg.Add(1)
+
+	go func() {
+		defer p.wg.Done()
+
+		for {
+			ev := p.subscription.GetEvent()
+			if ev == nil {
+				return
+			}
+
+			if ev.Type == blockchain.EventFork || len(ev.NewChain) == 0 {
+				continue
+			}
+
+			p.onHead(ev.Header().Number)
+		}
+	}()
+}
+
+// onHead starts the tracking of the written nodes once the interval is reached,
+// and prunes the state after the following blocks settle
+func (p *statePruner) onHead(number uint64) {
+	if p.running.Load() {
+		return
+	}
+
+	if !p.tracking {
+		if number < p.history || number < p.lastPruned+p.interval {
+			return
+		}
+
+		p.storage.StartTracking()
+		p.tracking = true
+		p.trackingFrom = number
+
+		return
+	}
+
+	if number < p.trackingFrom+statePruneSettleBlocks {
+		return
+	}
+
+	p.tracking = false
+	p.lastPruned = number
+	p.running.Store(true)
+	p.wg.Add(1)
+
+	go func() {
+		defer p.wg.Done()
+		defer p.running.Store(false)
+
+		p.prune()
+	}()
+}
+
+func (p *statePruner) prune() {
+	start := time.Now()
+
+	roots, head, err := p.retainedRoots()
+	if err != nil {
+		p.storage.StopTracking()
+		p.logger.Error("failed to get the retained state roots", "err", err)
+
+		return
+	}
+
+	p.logger.Info("pruning the state", "block", head, "retained", len(roots))
+
+	deleted, err := p.storage.Prune(p.ctx, roots)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			p.logger.Info("state pruning interrupted", "nodes", deleted)
+		} else {
+			p.logger.Error("failed to prune the state", "block", head, "err", err)
+		}
+
+		return
+	}
+
+	metrics.IncrCounter([]string{statePrunerMetrics, "pruned_nodes"}, float32(deleted))
+	metrics.MeasureSince([]string{statePrunerMetrics, "duration"}, start)
+
+	p.logger.Info("state pruned", "block", head, "nodes", deleted, "elapsed", time.Since(start))
+}
+
+// retainedRoots returns the state roots of the genesis and the most recent blocks, along with the head number
+func (p *statePruner) retainedRoots() ([]types.Hash, uint64, error) {
+	head := p.blockchain.Header().Number
+
+	from := uint64(1)
+	if head >= p.history {
+		from = head - p.history + 1
+	}
+
+	roots := append([]types.Hash{}, p.genesisRoots...)
+
+	for number := from; number <= head; number++ {
+		header, ok := p.blockchain.GetHeaderByNumber(number)
+		if !ok {
+			return nil, 0, fmt.Errorf("header %d not found", number)
+		}
+
+		roots = append(roots, header.StateRoot)
+	}
+
+	return roots, head, nil
+}
+
+// close stops the pruner, interrupting the running pruning cycle
+func (p *statePruner) close() {
+	if p.subscription != nil {
+		p.blockchain.UnsubscribeEvents(p.subscription)
+	}
+
+	p.cancel()
+	p.wg.Wait()
+}
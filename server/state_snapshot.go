@@ -0,0 +1,76 @@
+package server
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var errStateSnapshotGenerating = errors.New("the state snapshot is being generated already")
+
+// stateSnapshot moves the flat layer along with the head of the chain
+type stateSnapshot struct {
+	flat       *itrie.FlatLayer
+	blockchain *blockchain.Blockchain
+
+	subscription blockchain.Subscription
+	wg           sync.WaitGroup
+}
+
+func newStateSnapshot(flat *itrie.FlatLayer, blockchain *blockchain.Blockchain) *stateSnapshot {
+	return &stateSnapshot{
+		flat:       flat,
+		blockchain: blockchain,
+	}
+}
+
+// start moves the flat layer to the current head, and then to every new head of the chain.
+// The layer regenerates itself when it can't follow the chain (e.g. on a reorg)
+func (s *stateSnapshot) start() {
+	s.subscription = s.blockchain.SubscribeEvents()
+	s.flat.Update(s.blockchain.Header().StateRoot)
+
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+
+		for {
+			ev := s.subscription.GetEvent()
+			if ev == nil {
+				return
+			}
+
+			if ev.Type == blockchain.EventFork {
+				continue
+			}
+
+			for _, header := range ev.NewChain {
+				s.flat.Update(header.StateRoot)
+			}
+		}
+	}()
+}
+
+// regenerate rebuilds the flat layer at the current head in the background, it returns the state root of the head
+func (s *stateSnapshot) regenerate() (types.Hash, error) {
+	root := s.blockchain.Header().StateRoot
+	if !s.flat.Regenerate(root) {
+		return types.ZeroHash, errStateSnapshotGenerating
+	}
+
+	return root, nil
+}
+
+// close stops following the chain and interrupts the generation of the flat layer
+func (s *stateSnapshot) close() {
+	if s.subscription != nil {
+		s.blockchain.UnsubscribeEvents(s.subscription)
+	}
+
+	s.wg.Wait()
+	s.flat.Close()
+}
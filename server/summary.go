@@ -0,0 +1,45 @@
+package server
+
+import (
+	"net/http"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// summaryPath is the path of the node summary on the telemetry server
+const summaryPath = "/debug/summary"
+
+// handleSummary writes the status of the node as JSON: the head, the peers, the txpool,
+// the gas usage, the tracker lag and the consensus round, the same as the monitor command shows
+func (s *Server) handleSummary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	// the telemetry server is started before the rest of the node
+	if s.network == nil || s.consensus == nil {
+		http.Error(w, "the node is starting", http.StatusServiceUnavailable)
+
+		return
+	}
+
+	status, err := (&systemService{server: s}).GetStatus(r.Context(), &empty.Empty{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	raw, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(status)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(raw)
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/0xPolygon/polygon-edge/archive"
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/server/proto"
@@ -27,6 +28,9 @@ type systemService struct {
 // Current: { Number: <blockNumber>; Hash: <headerHash> }
 //
 // P2PAddr: <libp2pAddress>
+//
+// along with the sync progress, the finalized block, the peer count, the tx pool depth,
+// the lag of the root chain event tracker and the validator status
 func (s *systemService) GetStatus(ctx context.Context, req *empty.Empty) (*proto.ServerStatus, error) {
 	header := s.server.blockchain.Header()
 
@@ -35,18 +39,153 @@ func (s *systemService) GetStatus(ctx context.Context, req *empty.Empty) (*proto
 		return nil, err
 	}
 
+	current := &proto.ServerStatus_Block{
+		Number:    int64(header.Number),
+		Hash:      header.Hash.String(),
+		Timestamp: header.Timestamp,
+	}
+
 	status := &proto.ServerStatus{
 		Network: s.server.chain.Params.ChainID,
-		Current: &proto.ServerStatus_Block{
-			Number: int64(header.Number),
-			Hash:   header.Hash.String(),
-		},
+		Current: current,
 		P2PAddr: addr,
+		Sync:    s.getSyncStatus(header.Number),
+		// the supported consensus engines have the instant finality, so the head is final
+		Finalized: current,
+		Peers:     int64(len(s.server.network.Peers())),
+		TxPool:    s.getTxPoolStatus(),
+		Gas: &proto.ServerStatus_Gas{
+			Used:    header.GasUsed,
+			Limit:   header.GasLimit,
+			BaseFee: header.BaseFee,
+		},
+	}
+
+	if c, ok := s.server.consensus.(trackerConsensus); ok {
+		if head, synced, ok := c.TrackerProgress(); ok {
+			status.Tracker = &proto.ServerStatus_Tracker{
+				Head:   head,
+				Synced: synced,
+			}
+
+			if head > synced {
+				status.Tracker.Lag = head - synced
+			}
+		}
+	}
+
+	if c, ok := s.server.consensus.(validatorConsensus); ok {
+		address, active := c.ValidatorStatus()
+
+		status.Validator = &proto.ServerStatus_Validator{
+			Address: address.String(),
+			Active:  active,
+		}
+	}
+
+	if c, ok := s.server.consensus.(roundConsensus); ok {
+		if height, round, state, ok := c.ConsensusStatus(); ok {
+			status.Consensus = &proto.ServerStatus_Consensus{
+				Height: height,
+				Round:  round,
+				State:  state,
+			}
+		}
+	}
+
+	return status, nil
+}
+
+// roundConsensus is implemented by the consensus engines reporting the round status of the node
+type roundConsensus interface {
+	ConsensusStatus() (height uint64, round uint64, state string, ok bool)
+}
+
+// trackerConsensus is implemented by the consensus engines tracking the events of the root chain
+type trackerConsensus interface {
+	TrackerProgress() (head uint64, synced uint64, ok bool)
+}
+
+// validatorConsensus is implemented by the consensus engines reporting the validator status of the node
+type validatorConsensus interface {
+	ValidatorStatus() (types.Address, bool)
+}
+
+// getSyncStatus returns the progress of the restore or of the sync with the peers, if any
+func (s *systemService) getSyncStatus(head uint64) *proto.ServerStatus_Sync {
+	syncProgression := s.server.restoreProgression.GetProgression()
+	if syncProgression == nil {
+		syncProgression = s.server.consensus.GetSyncProgression()
 	}
 
+	if syncProgression == nil {
+		return &proto.ServerStatus_Sync{
+			StartingBlock: head,
+			CurrentBlock:  head,
+			HighestBlock:  head,
+		}
+	}
+
+	return &proto.ServerStatus_Sync{
+		Syncing:       true,
+		StartingBlock: syncProgression.StartingBlock,
+		CurrentBlock:  syncProgression.CurrentBlock,
+		HighestBlock:  syncProgression.HighestBlock,
+	}
+}
+
+// SyncerStatus returns the detailed progress of the restore or of the sync with the peers
+func (s *systemService) SyncerStatus(context.Context, *empty.Empty) (*proto.SyncerStatusResponse, error) {
+	status := &proto.SyncerStatusResponse{
+		Mode: string(s.server.config.SyncMode),
+	}
+
+	syncProgression := s.server.restoreProgression.GetProgression()
+	if syncProgression == nil {
+		syncProgression = s.server.consensus.GetSyncProgression()
+	}
+
+	if syncProgression == nil {
+		head := s.server.blockchain.Header().Number
+
+		status.StartingBlock = head
+		status.CurrentBlock = head
+		status.HighestBlock = head
+
+		return status, nil
+	}
+
+	status.Syncing = true
+	status.Type = string(syncProgression.SyncType)
+	status.Stage = string(syncProgression.Stage)
+	status.StartingBlock = syncProgression.StartingBlock
+	status.CurrentBlock = syncProgression.CurrentBlock
+	status.HighestBlock = syncProgression.HighestBlock
+	status.PulledStates = syncProgression.PulledStates
+	status.KnownStates = syncProgression.KnownStates
+
 	return status, nil
 }
 
+// getTxPoolStatus returns the numbers of the transactions and of the occupied slots of the tx pool
+func (s *systemService) getTxPoolStatus() *proto.ServerStatus_TxPool {
+	promoted, enqueued := s.server.txpool.GetTxs(true)
+
+	status := &proto.ServerStatus_TxPool{}
+
+	for _, txs := range promoted {
+		status.Pending += uint64(len(txs))
+	}
+
+	for _, txs := range enqueued {
+		status.Enqueued += uint64(len(txs))
+	}
+
+	status.UsedSlots, status.MaxSlots = s.server.txpool.GetCapacity()
+
+	return status
+}
+
 // Subscribe implements the blockchain event subscription service
 func (s *systemService) Subscribe(req *empty.Empty, stream proto.System_SubscribeServer) error {
 	sub := s.server.blockchain.SubscribeEvents()
@@ -161,6 +300,45 @@ func (s *systemService) PeersList(
 	return resp, nil
 }
 
+// PeersScore implements the 'peers score' operator service,
+// it returns the reputation of all connected peers if no peer is specified
+func (s *systemService) PeersScore(_ context.Context, req *proto.PeersScoreRequest) (*proto.PeersScoreResponse, error) {
+	var ids []peer.ID
+
+	if req.Id != "" {
+		peerID, err := peer.Decode(req.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, peerID)
+	} else {
+		for _, p := range s.server.network.Peers() {
+			ids = append(ids, p.Info.ID)
+		}
+	}
+
+	resp := &proto.PeersScoreResponse{
+		Scores: make([]*proto.PeerScore, 0, len(ids)),
+	}
+
+	for _, id := range ids {
+		score := s.server.network.GetPeerScore(id)
+
+		resp.Scores = append(resp.Scores, &proto.PeerScore{
+			Id:                 id.String(),
+			Score:              score.Score,
+			UsefulResponses:    score.UsefulResponses,
+			InvalidMessages:    score.InvalidMessages,
+			Timeouts:           score.Timeouts,
+			ProtocolViolations: score.ProtocolViolations,
+			RateLimitExceeded:  score.RateLimitExceeded,
+		})
+	}
+
+	return resp, nil
+}
+
 // BlockByNumber implements the BlockByNumber operator service
 func (s *systemService) BlockByNumber(
 	ctx context.Context,
@@ -187,8 +365,8 @@ func (s *systemService) Export(req *proto.ExportRequest, stream proto.System_Exp
 	}
 
 	if req.To != 0 {
-		if from >= req.To {
-			return errors.New("to must be greater than from")
+		if from > req.To {
+			return errors.New("to must not be less than from")
 		}
 
 		to = &req.To
@@ -205,6 +383,7 @@ func (s *systemService) Export(req *proto.ExportRequest, stream proto.System_Exp
 	}
 
 	writer := newBlockStreamWriter(stream, s.server.blockchain, defaultMaxGRPCPayloadSize)
+	writer.withReceipts = req.Receipts
 	i := from
 
 	for canLoop(i) {
@@ -227,20 +406,75 @@ func (s *systemService) Export(req *proto.ExportRequest, stream proto.System_Exp
 	return nil
 }
 
+// ImportBlocks verifies and writes the blocks to the chain, the blocks already in the chain are skipped
+func (s *systemService) ImportBlocks(
+	ctx context.Context,
+	req *proto.ImportBlocksRequest,
+) (*proto.ImportBlocksResponse, error) {
+	imported, err := archive.ImportBlocks(s.server.blockchain, req.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.ImportBlocksResponse{
+		Imported: imported,
+		Latest:   s.server.blockchain.Header().Number,
+	}, nil
+}
+
+// GetTrieNodes returns the state trie nodes and the contract codes by their hashes,
+// the data of the hashes which aren't stored is empty
+func (s *systemService) GetTrieNodes(
+	ctx context.Context,
+	req *proto.TrieNodesRequest,
+) (*proto.TrieNodesResponse, error) {
+	if len(req.Hashes) > maxTrieNodesPerRequest {
+		return nil, fmt.Errorf("%d trie nodes requested, at most %d are served", len(req.Hashes), maxTrieNodesPerRequest)
+	}
+
+	resp := &proto.TrieNodesResponse{
+		Data: make([][]byte, len(req.Hashes)),
+	}
+
+	for i, hash := range req.Hashes {
+		if len(hash) != types.HashLength {
+			continue
+		}
+
+		data, ok, err := s.server.stateStorage.Get(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			data, _ = s.server.stateStorage.GetCode(types.BytesToHash(hash))
+		}
+
+		resp.Data[i] = data
+	}
+
+	return resp, nil
+}
+
 const (
 	defaultMaxGRPCPayloadSize uint64 = 512 * 1024 // 4MB
 
+	// maxTrieNodesPerRequest is the max number of the trie nodes served by a single request
+	maxTrieNodesPerRequest = 512
+
 	// Number of header fields * bytes per field (From, To, Latest all them uint64)
 	maxHeaderInfoSize int = 3 * 8
 )
 
 type blockStreamWriter struct {
-	buf         bytes.Buffer
-	blockchain  *blockchain.Blockchain
-	stream      proto.System_ExportServer
-	maxPayload  uint64
-	pendingFrom *uint64 // first block height in buffer
-	pendingTo   *uint64 // last block height in buffer
+	buf          bytes.Buffer
+	receiptsBuf  bytes.Buffer
+	withReceipts bool
+	blockchain   *blockchain.Blockchain
+	stream       proto.System_ExportServer
+	maxPayload   uint64
+	pendingFrom  *uint64 // first block height in buffer
+	pendingTo    *uint64 // last block height in buffer
 }
 
 func newBlockStreamWriter(
@@ -258,7 +492,19 @@ func newBlockStreamWriter(
 
 func (w *blockStreamWriter) appendBlock(b *types.Block) error {
 	data := b.MarshalRLP()
-	if uint64(maxHeaderInfoSize+w.buf.Len()+len(data)) >= w.maxPayload {
+
+	var receipts []byte
+
+	if w.withReceipts {
+		var err error
+
+		if receipts, err = w.blockReceipts(b); err != nil {
+			return err
+		}
+	}
+
+	size := maxHeaderInfoSize + w.buf.Len() + w.receiptsBuf.Len() + len(data) + len(receipts)
+	if uint64(size) >= w.maxPayload {
 		// send buffered data to client first
 		if err := w.flush(); err != nil {
 			return err
@@ -266,6 +512,7 @@ func (w *blockStreamWriter) appendBlock(b *types.Block) error {
 	}
 
 	w.buf.Write(data)
+	w.receiptsBuf.Write(receipts)
 
 	n := b.Number()
 	if w.pendingFrom == nil {
@@ -289,10 +536,11 @@ func (w *blockStreamWriter) flush() error {
 	}
 
 	err := w.stream.Send(&proto.ExportEvent{
-		From:   *w.pendingFrom,
-		To:     *w.pendingTo,
-		Latest: w.blockchain.Header().Number,
-		Data:   w.buf.Bytes(),
+		From:     *w.pendingFrom,
+		To:       *w.pendingTo,
+		Latest:   w.blockchain.Header().Number,
+		Data:     w.buf.Bytes(),
+		Receipts: w.receiptsBuf.Bytes(),
 	})
 
 	if err != nil {
@@ -304,8 +552,23 @@ func (w *blockStreamWriter) flush() error {
 	return nil
 }
 
+// blockReceipts returns the RLP encoded receipts of the block, which are empty for the block without transactions
+func (w *blockStreamWriter) blockReceipts(b *types.Block) ([]byte, error) {
+	if len(b.Transactions) == 0 {
+		return types.Receipts{}.MarshalStoreRLPTo(nil), nil
+	}
+
+	receipts, err := w.blockchain.GetReceiptsByHash(b.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("receipts of block #%d not found: %w", b.Number(), err)
+	}
+
+	return types.Receipts(receipts).MarshalStoreRLPTo(nil), nil
+}
+
 func (w *blockStreamWriter) reset() {
 	w.buf.Reset()
+	w.receiptsBuf.Reset()
 	w.pendingFrom = nil
 	w.pendingTo = nil
 }
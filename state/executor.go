@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"sync/atomic"
 
 	"github.com/hashicorp/go-hclog"
 
@@ -15,13 +16,15 @@ import (
 	"github.com/0xPolygon/polygon-edge/state/runtime/addresslist"
 	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
 	"github.com/0xPolygon/polygon-edge/state/runtime/precompiled"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/txpolicy"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
 const (
-	SpuriousDragonMaxCodeSize = 24576
-	TxPoolMaxInitCodeSize     = 2 * SpuriousDragonMaxCodeSize
+	SpuriousDragonMaxCodeSize = chain.DefaultMaxCodeSize
+	TxPoolMaxInitCodeSize     = chain.DefaultMaxInitCodeSize
 
 	TxGas                 uint64 = 21000 // Per transaction not creating a contract
 	TxGasContractCreation uint64 = 53000 // Per transaction that creates a contract
@@ -41,6 +44,19 @@ type Executor struct {
 
 	PostHook        func(txn *Transition)
 	GenesisPostHook func(*Transition) error
+
+	// ParallelExecution enables the experimental concurrent execution of the block transactions
+	ParallelExecution bool
+
+	// Prefetch enables the prefetching of the state read by the block transactions executed sequentially
+	Prefetch       bool
+	accessPatterns *accessPatterns
+
+	// StatefulPrecompiles are the custom precompiled contracts configured in the chain params
+	StatefulPrecompiles []*stateful.Precompile
+
+	// Engine executes the contract code of the transitions, the built-in EVM if nil
+	Engine runtime.Runtime
 }
 
 // NewExecutor creates a new executor
@@ -49,6 +65,8 @@ func NewExecutor(config *chain.Params, s State, logger hclog.Logger) *Executor {
 		logger: logger,
 		config: config,
 		state:  s,
+
+		accessPatterns: newAccessPatterns(),
 	}
 }
 
@@ -84,6 +102,7 @@ func (e *Executor) WriteGenesis(
 		auxState:    e.state,
 		gasPool:     uint64(env.GasLimit),
 		config:      config,
+		engine:      e.executionEngine(),
 		precompiles: precompiled.NewPrecompiled(),
 	}
 
@@ -141,11 +160,33 @@ func (e *Executor) ProcessBlock(
 		return nil, err
 	}
 
+	txs := make([]*types.Transaction, 0, len(block.Transactions))
+
 	for _, t := range block.Transactions {
 		if t.Gas > block.Header.GasLimit {
 			continue
 		}
 
+		txs = append(txs, t)
+	}
+
+	if e.ParallelExecution && e.PostHook == nil && len(txs) > 1 {
+		if err = e.writeParallel(txn, txs); err != nil {
+			return nil, err
+		}
+
+		return txn, nil
+	}
+
+	if e.Prefetch && e.accessPatterns != nil && len(txs) > 1 {
+		if err = e.writePrefetched(txn, txs); err != nil {
+			return nil, err
+		}
+
+		return txn, nil
+	}
+
+	for _, t := range txs {
 		if err = txn.Write(t); err != nil {
 			return nil, err
 		}
@@ -154,6 +195,15 @@ func (e *Executor) ProcessBlock(
 	return txn, nil
 }
 
+// executionEngine returns the engine executing the contract code
+func (e *Executor) executionEngine() runtime.Runtime {
+	if e.Engine == nil {
+		return evm.NewEVM()
+	}
+
+	return e.Engine
+}
+
 // StateAt returns snapshot at given root
 func (e *Executor) State() State {
 	return e.state
@@ -181,8 +231,10 @@ func (e *Executor) BeginTxn(
 		return nil, err
 	}
 
+	// the base fees are sent to the burn contract, unless the active forks send them to the zero address
 	burnContract := types.ZeroAddress
-	if forkConfig.London {
+	if burnToZero := e.config.Forks.ParamsAt(header.Number).BurnToZeroAddress; forkConfig.London &&
+		(burnToZero == nil || !*burnToZero) {
 		burnContract, err = e.config.CalculateBurnContract(header.Number)
 		if err != nil {
 			return nil, err
@@ -215,11 +267,50 @@ func (e *Executor) BeginTxn(
 		receipts: []*types.Receipt{},
 		totalGas: 0,
 
-		evm:         evm.NewEVM(),
+		engine:      e.executionEngine(),
 		precompiles: precompiled.NewPrecompiled(),
 		PostHook:    e.PostHook,
+
+		feeDistribution: e.config.FeeDistributionAt(header.Number),
+		maxCodeSize:     e.config.Forks.CodeSizeLimitsAt(header.Number).MaxCodeSize,
 	}
 
+	e.enableAddressLists(txn)
+	e.enableStatefulPrecompiles(txn, header.Number)
+
+	return txn, nil
+}
+
+// statefulPrecompileCode is the code placed at the address of the activated stateful precompile,
+// so that it can be called through the contract interfaces, which check the code of the callee.
+// The calls are run by the precompile, so the code is never executed
+var statefulPrecompileCode = []byte{0xfe}
+
+// enableStatefulPrecompiles enables the stateful precompiles whose forks are active at the given block
+func (e *Executor) enableStatefulPrecompiles(txn *Transition, number uint64) {
+	for _, precompile := range e.StatefulPrecompiles {
+		if !e.config.Forks.IsActive(precompile.Fork, number) {
+			continue
+		}
+
+		if txn.statefulPrecompiles == nil {
+			txn.statefulPrecompiles = make(map[types.Address]*stateful.Precompile)
+		}
+
+		txn.statefulPrecompiles[precompile.Address] = precompile
+
+		if _, ok := precompile.Contract.(stateful.SupplyHook); ok {
+			txn.enableSupplyHook(precompile.Address)
+		}
+
+		if txn.state.GetCodeSize(precompile.Address) == 0 {
+			txn.state.SetCode(precompile.Address, statefulPrecompileCode)
+		}
+	}
+}
+
+// enableAddressLists enables the allow and block lists configured in the chain params
+func (e *Executor) enableAddressLists(txn *Transition) {
 	// enable contract deployment allow list (if any)
 	if e.config.ContractDeployerAllowList != nil {
 		txn.deploymentAllowList = addresslist.NewAddressList(txn, contracts.AllowListContractsAddr)
@@ -247,7 +338,15 @@ func (e *Executor) BeginTxn(
 		txn.bridgeBlockList = addresslist.NewAddressList(txn, contracts.BlockListBridgeAddr)
 	}
 
-	return txn, nil
+	// enable paymaster allow list (if any)
+	if e.config.PaymasterAllowList != nil {
+		txn.paymasterAllowList = addresslist.NewAddressList(txn, contracts.AllowListPaymasterAddr)
+	}
+
+	// enable transaction policy (if any)
+	if e.config.TxPolicy != nil {
+		txn.txPolicy = txpolicy.NewTxPolicy(txn, contracts.TxPolicyAddr)
+	}
 }
 
 type Transition struct {
@@ -270,7 +369,7 @@ type Transition struct {
 	PostHook func(t *Transition)
 
 	// runtimes
-	evm         *evm.EVM
+	engine      runtime.Runtime
 	precompiles *precompiled.Precompiled
 
 	// allow list runtimes
@@ -280,6 +379,31 @@ type Transition struct {
 	txnBlockList        *addresslist.AddressList
 	bridgeAllowList     *addresslist.AddressList
 	bridgeBlockList     *addresslist.AddressList
+	paymasterAllowList  *addresslist.AddressList
+	txPolicy            *txpolicy.TxPolicy
+
+	// statefulPrecompiles are the custom precompiled contracts active in the block
+	statefulPrecompiles map[types.Address]*stateful.Precompile
+
+	// supply is set when the native token supply is managed by the supply hook
+	supply *nativeSupply
+
+	// fees are set when the fees are paid after the speculative execution is merged
+	fees *deferredFees
+
+	// feeDistribution is set when the fees are shared between the proposer, the staker reward pool and the treasury
+	feeDistribution *chain.FeeDistribution
+
+	// maxCodeSize is the maximum size of the deployed contract code
+	maxCodeSize uint64
+
+	// interrupt is set when the execution should be stopped (e.g. on eth_call timeout).
+	// It is nil unless the transition is interruptible, so the block execution doesn't check it
+	interrupt *atomic.Bool
+
+	// collectStateDiff is set when the state diff is computed on commit
+	collectStateDiff bool
+	stateDiff        *StateDiff
 }
 
 func NewTransition(config chain.ForksInTime, snap Snapshot, radix *Txn) *Transition {
@@ -287,8 +411,9 @@ func NewTransition(config chain.ForksInTime, snap Snapshot, radix *Txn) *Transit
 		config:      config,
 		state:       radix,
 		snap:        snap,
-		evm:         evm.NewEVM(),
+		engine:      evm.NewEVM(),
 		precompiles: precompiled.NewPrecompiled(),
+		maxCodeSize: SpuriousDragonMaxCodeSize,
 	}
 }
 
@@ -322,6 +447,36 @@ func (t *Transition) WithStateOverride(override types.StateOverride) error {
 	return nil
 }
 
+// WithBlockOverride overrides the block context of the transactions applied afterwards
+func (t *Transition) WithBlockOverride(override *types.BlockOverride) {
+	if override.Number != nil {
+		t.ctx.Number = int64(*override.Number)
+	}
+
+	if override.Timestamp != nil {
+		t.ctx.Timestamp = int64(*override.Timestamp)
+	}
+
+	if override.GasLimit != nil {
+		t.ctx.GasLimit = int64(*override.GasLimit)
+		t.gasPool = *override.GasLimit
+	}
+
+	if override.Coinbase != nil {
+		t.ctx.Coinbase = *override.Coinbase
+	}
+
+	if override.BaseFee != nil {
+		t.ctx.BaseFee = new(big.Int).Set(override.BaseFee)
+	}
+}
+
+// ResetGasPool makes the whole block gas limit available again, so that the simulated calls
+// don't consume the gas of each other
+func (t *Transition) ResetGasPool() {
+	t.gasPool = uint64(t.ctx.GasLimit)
+}
+
 func (t *Transition) TotalGas() uint64 {
 	return t.totalGas
 }
@@ -334,6 +489,25 @@ var emptyFrom = types.Address{}
 
 // Write writes another transaction to the executor
 func (t *Transition) Write(txn *types.Transaction) error {
+	if err := t.recoverSender(txn); err != nil {
+		return err
+	}
+
+	// Make a local copy and apply the transaction
+	msg := txn.Copy()
+
+	result, e := t.Apply(msg)
+	if e != nil {
+		t.logger.Error("failed to apply tx", "err", e)
+
+		return e
+	}
+
+	return t.writeReceipt(txn, result, t.state.Logs())
+}
+
+// recoverSender sets the sender of the signed transaction
+func (t *Transition) recoverSender(txn *types.Transaction) error {
 	var err error
 
 	if txn.From == emptyFrom &&
@@ -347,20 +521,13 @@ func (t *Transition) Write(txn *types.Transaction) error {
 		}
 	}
 
-	// Make a local copy and apply the transaction
-	msg := txn.Copy()
-
-	result, e := t.Apply(msg)
-	if e != nil {
-		t.logger.Error("failed to apply tx", "err", e)
-
-		return e
-	}
+	return nil
+}
 
+// writeReceipt creates the receipt of the applied transaction
+func (t *Transition) writeReceipt(txn *types.Transaction, result *runtime.ExecutionResult, logs []*types.Log) error {
 	t.totalGas += result.GasUsed
 
-	logs := t.state.Logs()
-
 	receipt := &types.Receipt{
 		CumulativeGasUsed: t.totalGas,
 		TransactionType:   txn.Type,
@@ -369,6 +536,8 @@ func (t *Transition) Write(txn *types.Transaction) error {
 	}
 
 	// The suicided accounts are set as deleted for the next iteration
+	t.trackDestroyedSupply()
+
 	if err := t.state.CleanDeleteObjects(true); err != nil {
 		return fmt.Errorf("failed to clean deleted objects: %w", err)
 	}
@@ -380,8 +549,8 @@ func (t *Transition) Write(txn *types.Transaction) error {
 	}
 
 	// if the transaction created a contract, store the creation address in the receipt.
-	if msg.To == nil {
-		receipt.ContractAddress = crypto.CreateAddress(msg.From, txn.Nonce).Ptr()
+	if txn.To == nil {
+		receipt.ContractAddress = crypto.CreateAddress(txn.From, txn.Nonce).Ptr()
 	}
 
 	// Set the receipt logs and create a bloom for filtering
@@ -394,11 +563,24 @@ func (t *Transition) Write(txn *types.Transaction) error {
 
 // Commit commits the final result
 func (t *Transition) Commit() (Snapshot, types.Hash, error) {
+	t.trackDestroyedSupply()
+	expectedSupplyChange := t.expectedSupplyChange()
+
 	objs, err := t.state.Commit(t.config.EIP155)
 	if err != nil {
 		return nil, types.ZeroHash, err
 	}
 
+	if err := t.checkNativeSupply(objs, expectedSupplyChange); err != nil {
+		return nil, types.ZeroHash, err
+	}
+
+	if t.collectStateDiff {
+		if t.stateDiff, err = t.diffState(objs); err != nil {
+			return nil, types.ZeroHash, err
+		}
+	}
+
 	s2, root, err := t.snap.Commit(objs)
 	if err != nil {
 		return nil, types.ZeroHash, err
@@ -425,6 +607,11 @@ func (t *Transition) Txn() *Txn {
 	return t.state
 }
 
+// SetEngine sets the engine executing the contract code of the transition
+func (t *Transition) SetEngine(engine runtime.Runtime) {
+	t.engine = engine
+}
+
 // Apply applies a new transaction
 func (t *Transition) Apply(msg *types.Transaction) (*runtime.ExecutionResult, error) {
 	s := t.state.Snapshot()
@@ -538,10 +725,17 @@ func NewGasLimitReachedTransitionApplicationError(err error) *GasLimitReachedTra
 func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, error) {
 	var err error
 
+	if stateTracer, ok := t.ctx.Tracer.(tracer.StateTracer); ok {
+		stateTracer.TxStartState(msg.From, msg.To, t.ctx.Coinbase, t)
+	}
+
+	// the paymaster is resolved once, so that the sponsorship doesn't change during the execution
+	paymaster := t.sponsoringPaymaster(msg)
+
 	if msg.Type == types.StateTx {
 		err = checkAndProcessStateTx(msg)
 	} else {
-		err = checkAndProcessTx(msg, t)
+		err = checkAndProcessTx(msg, t, paymaster)
 	}
 
 	if err != nil {
@@ -578,7 +772,19 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	t.ctx.Origin = msg.From
 
 	var result *runtime.ExecutionResult
-	if msg.IsContractCreation() {
+	if policyErr := t.checkTxPolicy(msg); policyErr != nil {
+		// the transactions denied by the policy are included as failed, consuming all their gas,
+		// and the denial is logged by the policy contract
+		if err := t.state.IncrNonce(msg.From); err != nil {
+			return nil, err
+		}
+
+		if err := t.txPolicy.EmitDenied(t, msg.From, msg.To, msg.Input, value); err != nil {
+			return nil, err
+		}
+
+		result = &runtime.ExecutionResult{GasLeft: 0, Err: policyErr}
+	} else if msg.IsContractCreation() {
 		result = t.Create2(msg.From, msg.Input, value, gasLeft)
 	} else {
 		if err := t.state.IncrNonce(msg.From); err != nil {
@@ -590,6 +796,8 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	refund := t.state.GetRefund()
 	result.UpdateGasUsed(msg.Gas, refund)
 
+	t.state.ClearTransientStorage()
+
 	if t.ctx.Tracer != nil {
 		t.ctx.Tracer.TxEnd(result.GasLeft)
 	}
@@ -606,15 +814,28 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 		msg, gasPrice, t.ctx.BaseFee, t.config.London,
 	)
 
+	// the sponsored transactions pay no tip, and the unused gas is refunded to the paymaster
+	if paymaster != nil {
+		effectiveTip = big.NewInt(0)
+
+		t.state.AddBalance(*paymaster, new(big.Int).Mul(new(big.Int).SetUint64(result.GasLeft), t.ctx.BaseFee))
+	}
+
 	// Pay the coinbase fee as a miner reward using the calculated effective tip.
 	coinbaseFee := new(big.Int).Mul(new(big.Int).SetUint64(result.GasUsed), effectiveTip)
-	t.state.AddBalance(t.ctx.Coinbase, coinbaseFee)
+
+	var burnAmount *big.Int
 
 	// Burn some amount if the london hardfork is applied.
 	// Basically, burn amount is just transferred to the current burn contract.
 	if t.config.London && msg.Type != types.StateTx {
-		burnAmount := new(big.Int).Mul(new(big.Int).SetUint64(result.GasUsed), t.ctx.BaseFee)
-		t.state.AddBalance(t.ctx.BurnContract, burnAmount)
+		burnAmount = new(big.Int).Mul(new(big.Int).SetUint64(result.GasUsed), t.ctx.BaseFee)
+	}
+
+	if t.fees != nil {
+		t.fees.coinbase, t.fees.burn = coinbaseFee, burnAmount
+	} else {
+		t.payFees(coinbaseFee, burnAmount)
 	}
 
 	// return gas to the pool
@@ -623,6 +844,52 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	return result, nil
 }
 
+// checkTxPolicy returns the error if the transaction is denied by the transaction policy (if any)
+func (t *Transition) checkTxPolicy(msg *types.Transaction) error {
+	if t.txPolicy == nil || msg.Type == types.StateTx || msg.From == contracts.SystemCaller {
+		return nil
+	}
+
+	if err := t.txPolicy.Check(msg.To, msg.Input, msg.Value); err != nil {
+		t.logger.Debug("Failing transaction. Denied by the transaction policy",
+			"from", msg.From, "to", msg.To, "err", err)
+
+		return err
+	}
+
+	return nil
+}
+
+// payFees pays the coinbase fee to the block proposer and the burn amount to the burn contract,
+// or shares them with the staker reward pool and the treasury if the fee distribution is active
+func (t *Transition) payFees(coinbaseFee, burnAmount *big.Int) {
+	if t.feeDistribution == nil {
+		t.state.AddBalance(t.ctx.Coinbase, coinbaseFee)
+
+		if burnAmount != nil {
+			t.state.AddBalance(t.ctx.BurnContract, burnAmount)
+		}
+
+		return
+	}
+
+	fee := new(big.Int).Set(coinbaseFee)
+
+	if burnAmount != nil {
+		if t.feeDistribution.IncludeBaseFee {
+			fee.Add(fee, burnAmount)
+		} else {
+			t.state.AddBalance(t.ctx.BurnContract, burnAmount)
+		}
+	}
+
+	proposer, stakerPool, treasury := t.feeDistribution.Split(fee)
+
+	t.state.AddBalance(t.ctx.Coinbase, proposer)
+	t.state.AddBalance(t.feeDistribution.StakerPool, stakerPool)
+	t.state.AddBalance(t.feeDistribution.Treasury, treasury)
+}
+
 func (t *Transition) Create2(
 	caller types.Address,
 	code []byte,
@@ -652,11 +919,12 @@ func (t *Transition) run(contract *runtime.Contract, host runtime.Host) *runtime
 		return result
 	}
 
-	// check txns access lists, allow list takes precedence over block list
+	// check txns access lists, allow list takes precedence over block list.
+	// The frozen lists authorize only their admins
 	if t.txnAllowList != nil {
 		if contract.Caller != contracts.SystemCaller {
 			role := t.txnAllowList.GetRole(contract.Caller)
-			if !role.Enabled() {
+			if !role.Enabled() || (role != addresslist.AdminRole && t.txnAllowList.IsFrozen()) {
 				t.logger.Debug(
 					"Failing transaction. Caller is not in the transaction allowlist",
 					"contract.Caller", contract.Caller,
@@ -672,7 +940,7 @@ func (t *Transition) run(contract *runtime.Contract, host runtime.Host) *runtime
 	} else if t.txnBlockList != nil {
 		if contract.Caller != contracts.SystemCaller {
 			role := t.txnBlockList.GetRole(contract.Caller)
-			if role == addresslist.EnabledRole {
+			if role == addresslist.EnabledRole || (role != addresslist.AdminRole && t.txnBlockList.IsFrozen()) {
 				t.logger.Debug(
 					"Failing transaction. Caller is in the transaction blocklist",
 					"contract.Caller", contract.Caller,
@@ -687,13 +955,18 @@ func (t *Transition) run(contract *runtime.Contract, host runtime.Host) *runtime
 		}
 	}
 
+	// check the stateful precompiles
+	if precompile, ok := t.statefulPrecompiles[contract.CodeAddress]; ok {
+		return precompile.Run(contract, host)
+	}
+
 	// check the precompiles
 	if t.precompiles.CanRun(contract, host, &t.config) {
 		return t.precompiles.Run(contract, host, &t.config)
 	}
-	// check the evm
-	if t.evm.CanRun(contract, host, &t.config) {
-		return t.evm.Run(contract, host, &t.config)
+	// check the execution engine
+	if t.engine.CanRun(contract, host, &t.config) {
+		return t.engine.Run(contract, host, &t.config)
 	}
 
 	return &runtime.ExecutionResult{
@@ -773,7 +1046,7 @@ func (t *Transition) hasCodeOrNonce(addr types.Address) bool {
 	return codeHash != types.EmptyCodeHash && codeHash != types.ZeroHash
 }
 
-func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtime.ExecutionResult {
+func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) (result *runtime.ExecutionResult) {
 	gasLimit := c.Gas
 
 	if c.Depth > int(1024)+1 {
@@ -816,12 +1089,10 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 		}
 	}
 
-	var result *runtime.ExecutionResult
-
-	t.captureCallStart(c, evm.CREATE)
+	t.captureCallStart(c, c.Type)
 
 	defer func() {
-		// pass result to be set later
+		// result is the returned value, including the early returns
 		t.captureCallEnd(c, result)
 	}()
 
@@ -829,7 +1100,8 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 	if t.deploymentAllowList != nil {
 		role := t.deploymentAllowList.GetRole(c.Caller)
 
-		if !role.Enabled() {
+		// the frozen lists authorize only their admins
+		if !role.Enabled() || (role != addresslist.AdminRole && t.deploymentAllowList.IsFrozen()) {
 			t.logger.Debug(
 				"Failing contract deployment. Caller is not in the deployment allowlist",
 				"contract.Caller", c.Caller,
@@ -844,7 +1116,7 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 	} else if t.deploymentBlockList != nil {
 		role := t.deploymentBlockList.GetRole(c.Caller)
 
-		if role == addresslist.EnabledRole {
+		if role == addresslist.EnabledRole || (role != addresslist.AdminRole && t.deploymentBlockList.IsFrozen()) {
 			t.logger.Debug(
 				"Failing contract deployment. Caller is in the deployment blocklist",
 				"contract.Caller", c.Caller,
@@ -869,8 +1141,8 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 		return result
 	}
 
-	if t.config.EIP158 && len(result.ReturnValue) > SpuriousDragonMaxCodeSize {
-		// Contract size exceeds 'SpuriousDragon' size limit
+	if t.config.EIP158 && uint64(len(result.ReturnValue)) > t.maxCodeSize {
+		// Contract size exceeds the code size limit of the chain
 		if err := t.state.RevertToSnapshot(snapshot); err != nil {
 			return &runtime.ExecutionResult{
 				Err: err,
@@ -932,6 +1204,16 @@ func (t *Transition) handleAllowBlockListsUpdate(contract *runtime.Contract,
 		return t.bridgeBlockList.Run(contract, host, &t.config)
 	}
 
+	// check paymaster allow list (if any)
+	if t.paymasterAllowList != nil && t.paymasterAllowList.Addr() == contract.CodeAddress {
+		return t.paymasterAllowList.Run(contract, host, &t.config)
+	}
+
+	// check transaction policy (if any)
+	if t.txPolicy != nil && t.txPolicy.Addr() == contract.CodeAddress {
+		return t.txPolicy.Run(contract, host, &t.config)
+	}
+
 	// check transaction allow list (if any)
 	if t.txnAllowList != nil && t.txnAllowList.Addr() == contract.CodeAddress {
 		return t.txnAllowList.Run(contract, host, &t.config)
@@ -990,6 +1272,14 @@ func (t *Transition) GetStorage(addr types.Address, key types.Hash) types.Hash {
 	return t.state.GetState(addr, key)
 }
 
+func (t *Transition) GetTransientStorage(addr types.Address, key types.Hash) types.Hash {
+	return t.state.GetTransientState(addr, key)
+}
+
+func (t *Transition) SetTransientStorage(addr types.Address, key types.Hash, value types.Hash) {
+	t.state.SetTransientState(addr, key, value)
+}
+
 func (t *Transition) AccountExists(addr types.Address) bool {
 	return t.state.Exist(addr)
 }
@@ -1007,12 +1297,19 @@ func (t *Transition) Selfdestruct(addr types.Address, beneficiary types.Address)
 		t.state.AddRefund(24000)
 	}
 
-	t.state.AddBalance(beneficiary, t.state.GetBalance(addr))
+	balance := t.state.GetBalance(addr)
+
+	// the balance of the account destroying itself is burned
+	if t.supply != nil && beneficiary == addr {
+		t.state.AddDestroyed(balance)
+	}
+
+	t.state.AddBalance(beneficiary, balance)
 	t.state.Suicide(addr)
 }
 
 func (t *Transition) Callx(c *runtime.Contract, h runtime.Host) *runtime.ExecutionResult {
-	if c.Type == runtime.Create {
+	if c.Type == runtime.Create || c.Type == runtime.Create2 {
 		return t.applyCreate(c, h)
 	}
 
@@ -1055,6 +1352,25 @@ func (t *Transition) SetNonPayable(nonPayable bool) {
 	t.ctx.NonPayable = nonPayable
 }
 
+// SetInterruptible makes the executions of the transition stoppable by Interrupt,
+// it must be called before the transition is applied
+func (t *Transition) SetInterruptible() {
+	t.interrupt = new(atomic.Bool)
+}
+
+// Interrupt stops the ongoing and all the subsequent executions of the interruptible transition.
+// It is safe to call it from other goroutines
+func (t *Transition) Interrupt() {
+	if t.interrupt != nil {
+		t.interrupt.Store(true)
+	}
+}
+
+// InterruptFlag returns the flag set by Interrupt, nil if the transition is not interruptible
+func (t *Transition) InterruptFlag() *atomic.Bool {
+	return t.interrupt
+}
+
 // SetTracer sets tracer to the context in order to enable it
 func (t *Transition) SetTracer(tracer tracer.Tracer) {
 	t.ctx.Tracer = tracer
@@ -1116,13 +1432,18 @@ func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul bool) (u
 // applying the message. The rules include these clauses:
 // 1. the nonce of the message caller is correct
 // 2. caller has enough balance to cover transaction fee(gaslimit * gasprice * val) or fee(gasfeecap * gasprice * val)
-func checkAndProcessTx(msg *types.Transaction, t *Transition) error {
+func checkAndProcessTx(msg *types.Transaction, t *Transition, paymaster *types.Address) error {
 	// 1. the nonce of the message caller is correct
 	if err := t.nonceCheck(msg); err != nil {
 		return NewTransitionApplicationError(err, true)
 	}
 
-	if !t.ctx.NonPayable {
+	if paymaster != nil {
+		// 2. the paymaster has enough deposit to cover the gas of the sponsored transaction
+		if err := t.subPaymasterDeposit(*paymaster, msg); err != nil {
+			return NewTransitionApplicationError(err, true)
+		}
+	} else if !t.ctx.NonPayable {
 		// 2. check dynamic fees of the transaction
 		if err := t.checkDynamicFees(msg); err != nil {
 			return NewTransitionApplicationError(err, true)
@@ -1194,6 +1515,10 @@ func (t *Transition) captureCallEnd(c *runtime.Contract, result *runtime.Executi
 		return
 	}
 
+	if gasTracer, ok := t.ctx.Tracer.(tracer.CallGasTracer); ok {
+		gasTracer.CallGasLeft(c.Depth, result.GasLeft)
+	}
+
 	t.ctx.Tracer.CallEnd(
 		c.Depth,
 		result.ReturnValue,
@@ -3,13 +3,21 @@ package state
 import (
 	"fmt"
 	"math/big"
+	"sync"
 	"testing"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful/kvstore"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/calltracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/txpolicy"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -66,6 +74,39 @@ func TestOverride(t *testing.T) {
 	require.Equal(t, types.Hash{0x1}, tt.state.GetState(types.Address{0x1}, types.Hash{0x1}))
 }
 
+func TestBlockOverride(t *testing.T) {
+	t.Parallel()
+
+	state := newStateWithPreState(nil)
+
+	tt := NewTransition(chain.ForksInTime{}, state, newTxn(state))
+	tt.ctx.GasLimit = 100
+	tt.gasPool = 10
+
+	number, timestamp, gasLimit := uint64(5), uint64(1000), uint64(200)
+	coinbase := types.Address{0x1}
+
+	tt.WithBlockOverride(&types.BlockOverride{
+		Number:    &number,
+		Timestamp: &timestamp,
+		GasLimit:  &gasLimit,
+		Coinbase:  &coinbase,
+		BaseFee:   big.NewInt(7),
+	})
+
+	require.Equal(t, int64(5), tt.ctx.Number)
+	require.Equal(t, int64(1000), tt.ctx.Timestamp)
+	require.Equal(t, int64(200), tt.ctx.GasLimit)
+	require.Equal(t, coinbase, tt.ctx.Coinbase)
+	require.Equal(t, big.NewInt(7), tt.ctx.BaseFee)
+	require.Equal(t, uint64(200), tt.gasPool)
+
+	require.NoError(t, tt.subGasPool(150))
+
+	tt.ResetGasPool()
+	require.Equal(t, uint64(200), tt.gasPool)
+}
+
 func Test_Transition_checkDynamicFees(t *testing.T) {
 	t.Parallel()
 
@@ -157,3 +198,668 @@ func Test_Transition_checkDynamicFees(t *testing.T) {
 		})
 	}
 }
+
+// codeSnapshot is the pre state with the contracts deployed
+type codeSnapshot struct {
+	*mockSnapshot
+
+	code map[types.Address][]byte
+}
+
+func (s *codeSnapshot) GetAccount(addr types.Address) (*Account, error) {
+	account, err := s.mockSnapshot.GetAccount(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	account.Root = emptyStateHash
+	if len(s.state[addr].State) > 0 {
+		account.Root = types.BytesToHash(addr.Bytes())
+	}
+
+	account.CodeHash = types.EmptyCodeHash.Bytes()
+
+	if code, ok := s.code[addr]; ok {
+		account.CodeHash = crypto.Keccak256(code)
+	}
+
+	return account, nil
+}
+
+func (s *codeSnapshot) GetCode(hash types.Hash) ([]byte, bool) {
+	for _, code := range s.code {
+		if types.BytesToHash(crypto.Keccak256(code)) == hash {
+			return code, true
+		}
+	}
+
+	return nil, false
+}
+
+// countingSnapshot records the accounts and the storage slots read by the prefetcher
+type countingSnapshot struct {
+	readSnapshot
+
+	lock     sync.Mutex
+	accounts []types.Address
+	slots    []slotAccess
+}
+
+func (s *countingSnapshot) GetAccount(addr types.Address) (*Account, error) {
+	s.lock.Lock()
+	s.accounts = append(s.accounts, addr)
+	s.lock.Unlock()
+
+	return s.readSnapshot.GetAccount(addr)
+}
+
+func (s *countingSnapshot) GetStorage(addr types.Address, root types.Hash, key types.Hash) types.Hash {
+	s.lock.Lock()
+	s.slots = append(s.slots, slotAccess{addr: addr, key: key})
+	s.lock.Unlock()
+
+	return s.readSnapshot.GetStorage(addr, root, key)
+}
+
+func TestExecutor_Prefetch(t *testing.T) {
+	t.Parallel()
+
+	var (
+		coinbase = types.StringToAddress("0xc0")
+		// counter increments the first slot
+		counter = types.StringToAddress("0xe0")
+		senders = []types.Address{types.StringToAddress("1"), types.StringToAddress("2")}
+	)
+
+	preState := map[types.Address]*PreState{
+		counter: {State: map[types.Hash]types.Hash{types.ZeroHash: types.BytesToHash([]byte{1})}},
+	}
+
+	for _, sender := range senders {
+		preState[sender] = &PreState{Balance: 1_000_000_000}
+	}
+
+	snap := &codeSnapshot{
+		mockSnapshot: &mockSnapshot{state: preState},
+		code: map[types.Address][]byte{
+			// PUSH1 0 SLOAD PUSH1 1 ADD PUSH1 0 SSTORE STOP
+			counter: {0x60, 0x00, 0x54, 0x60, 0x01, 0x01, 0x60, 0x00, 0x55, 0x00},
+		},
+	}
+
+	txs := make([]*types.Transaction, len(senders))
+
+	for i, sender := range senders {
+		txs[i] = &types.Transaction{
+			From:     sender,
+			To:       &counter,
+			Value:    big.NewInt(0),
+			Gas:      100_000,
+			GasPrice: big.NewInt(1),
+		}
+		txs[i].ComputeHash(1)
+	}
+
+	executor := &Executor{logger: hclog.NewNullLogger(), config: &chain.Params{}, accessPatterns: newAccessPatterns()}
+
+	newTransition := func() *Transition {
+		tr := NewTransition(chain.AllForksEnabled.At(0), snap, newTxn(snap))
+		tr.logger = hclog.NewNullLogger()
+		tr.ctx = runtime.TxContext{Coinbase: coinbase, BaseFee: big.NewInt(0), GasLimit: 10_000_000, ChainID: 1}
+		tr.gasPool = uint64(tr.ctx.GasLimit)
+		tr.getHash = func(uint64) types.Hash { return types.ZeroHash }
+
+		return tr
+	}
+
+	serial := newTransition()
+	for _, tx := range txs {
+		require.NoError(t, serial.Write(tx))
+	}
+
+	prefetched := newTransition()
+	require.NoError(t, executor.writePrefetched(prefetched, txs))
+
+	// the prefetched execution yields the same state and receipts
+	serialObjs, err := serial.state.Commit(true)
+	require.NoError(t, err)
+
+	prefetchedObjs, err := prefetched.state.Commit(true)
+	require.NoError(t, err)
+
+	require.Equal(t, serialObjs, prefetchedObjs)
+	require.Equal(t, serial.Receipts(), prefetched.Receipts())
+	require.Equal(t, snap, prefetched.state.snapshot)
+
+	// the slot read by the transactions calling the counter is recorded
+	counterSlot := slotAccess{addr: counter, key: types.ZeroHash}
+	require.Equal(t, []slotAccess{counterSlot}, executor.accessPatterns.get(counter))
+
+	// the senders, the counter and its recently read slot are prefetched for the next block
+	counting := &countingSnapshot{readSnapshot: snap}
+
+	p := executor.prefetch(counting, txs)
+	p.wg.Wait()
+
+	assert.ElementsMatch(t, append(senders, counter), counting.accounts)
+	assert.Equal(t, []slotAccess{counterSlot}, counting.slots)
+}
+
+func TestAccessPatterns_Add(t *testing.T) {
+	t.Parallel()
+
+	patterns := newAccessPatterns()
+	contract := types.StringToAddress("1")
+
+	slot := func(i int) slotAccess {
+		return slotAccess{addr: contract, key: types.BytesToHash([]byte{byte(i), byte(i >> 8)})}
+	}
+
+	patterns.add(contract, []slotAccess{slot(0), slot(1)})
+	patterns.add(contract, []slotAccess{slot(1), slot(2)})
+	require.Equal(t, []slotAccess{slot(0), slot(1), slot(2)}, patterns.get(contract))
+
+	// the oldest slots are dropped
+	reads := make([]slotAccess, 0, maxPatternSlots)
+	for i := 3; i < maxPatternSlots+3; i++ {
+		reads = append(reads, slot(i))
+	}
+
+	patterns.add(contract, reads)
+
+	slots := patterns.get(contract)
+	require.Len(t, slots, maxPatternSlots)
+	assert.Equal(t, slot(3), slots[0])
+}
+
+func TestExecutor_ParallelExecution(t *testing.T) {
+	t.Parallel()
+
+	var (
+		coinbase = types.StringToAddress("0xc0")
+		receiver = types.StringToAddress("0xd0")
+		// counter increments the first slot
+		counter = types.StringToAddress("0xe0")
+		// flagger sets the slot of the caller, emitting the log
+		flagger = types.StringToAddress("0xf0")
+		senders = []types.Address{
+			types.StringToAddress("1"), types.StringToAddress("2"), types.StringToAddress("3"),
+			types.StringToAddress("4"), types.StringToAddress("5"), types.StringToAddress("6"),
+		}
+	)
+
+	preState := map[types.Address]*PreState{
+		counter: {State: map[types.Hash]types.Hash{types.ZeroHash: types.BytesToHash([]byte{1})}},
+		flagger: {},
+	}
+
+	for _, sender := range senders {
+		preState[sender] = &PreState{Balance: 1_000_000_000}
+	}
+
+	snap := &codeSnapshot{
+		mockSnapshot: &mockSnapshot{state: preState},
+		code: map[types.Address][]byte{
+			// PUSH1 0 SLOAD PUSH1 1 ADD PUSH1 0 SSTORE STOP
+			counter: {0x60, 0x00, 0x54, 0x60, 0x01, 0x01, 0x60, 0x00, 0x55, 0x00},
+			// PUSH1 1 CALLER SSTORE PUSH1 0 PUSH1 0 LOG0 STOP
+			flagger: {0x60, 0x01, 0x33, 0x55, 0x60, 0x00, 0x60, 0x00, 0xa0, 0x00},
+		},
+	}
+
+	tx := func(from types.Address, nonce uint64, to *types.Address, value int64, input []byte) *types.Transaction {
+		tx := &types.Transaction{
+			From:     from,
+			Nonce:    nonce,
+			To:       to,
+			Value:    big.NewInt(value),
+			Gas:      100_000,
+			GasPrice: big.NewInt(1),
+			Input:    input,
+		}
+		tx.ComputeHash(1)
+
+		return tx
+	}
+
+	txs := []*types.Transaction{
+		tx(senders[0], 0, &flagger, 0, nil),
+		tx(senders[1], 0, &flagger, 0, nil),
+		// the transfers to the same receiver conflict
+		tx(senders[2], 0, &receiver, 1, nil),
+		tx(senders[3], 0, &receiver, 1, nil),
+		// the increments of the counter conflict
+		tx(senders[4], 0, &counter, 0, nil),
+		tx(senders[5], 0, &counter, 0, nil),
+		// the following transactions of the same sender conflict
+		tx(senders[0], 1, &receiver, 1, nil),
+		// PUSH1 1 PUSH1 0 SSTORE STOP, which deploys the empty contract
+		tx(senders[1], 1, nil, 0, []byte{0x60, 0x01, 0x60, 0x00, 0x55, 0x00}),
+	}
+
+	executor := &Executor{logger: hclog.NewNullLogger(), config: &chain.Params{}}
+
+	newTransition := func() *Transition {
+		tr := NewTransition(chain.AllForksEnabled.At(0), snap, newTxn(snap))
+		tr.logger = hclog.NewNullLogger()
+		tr.ctx = runtime.TxContext{Coinbase: coinbase, BaseFee: big.NewInt(0), GasLimit: 10_000_000, ChainID: 1}
+		tr.gasPool = uint64(tr.ctx.GasLimit)
+		tr.getHash = func(uint64) types.Hash { return types.ZeroHash }
+
+		return tr
+	}
+
+	serial := newTransition()
+	for _, tx := range txs {
+		require.NoError(t, serial.Write(tx))
+	}
+
+	parallel := newTransition()
+	require.NoError(t, executor.writeParallel(parallel, txs))
+
+	// the parallel execution yields the same state and receipts
+	serialObjs, err := serial.state.Commit(true)
+	require.NoError(t, err)
+
+	parallelObjs, err := parallel.state.Commit(true)
+	require.NoError(t, err)
+
+	require.Equal(t, serialObjs, parallelObjs)
+	require.Equal(t, serial.Receipts(), parallel.Receipts())
+	require.Equal(t, serial.TotalGas(), parallel.TotalGas())
+
+	// the first transactions don't conflict, while the transfer to the same receiver does
+	executions := executor.speculate(newTransition(), txs[:4])
+	merging := newTransition()
+
+	for i, expected := range []bool{true, true, true, false} {
+		merged, err := merging.mergeSpeculative(txs[i], executions[i])
+		require.NoError(t, err)
+		require.Equal(t, expected, merged)
+	}
+}
+
+func TestExecutor_StatefulPrecompiles(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender = types.StringToAddress("0xa0")
+		store  = types.StringToAddress("0xb0")
+		key    = types.StringToHash("0x1")
+		value  = types.StringToHash("0x2")
+	)
+
+	contract, err := kvstore.Factory(nil)
+	require.NoError(t, err)
+
+	forks := chain.AllForksEnabled.Copy()
+	forks.SetFork(kvstore.Name, chain.NewFork(5))
+
+	executor := &Executor{
+		logger: hclog.NewNullLogger(),
+		config: &chain.Params{Forks: forks},
+		StatefulPrecompiles: []*stateful.Precompile{
+			{Name: kvstore.Name, Address: store, Fork: kvstore.Name, Contract: contract},
+		},
+	}
+
+	snap := &mockSnapshot{state: map[types.Address]*PreState{sender: {Balance: 1_000_000_000}}}
+
+	newTransition := func(number uint64) *Transition {
+		tr := NewTransition(forks.At(number), snap, newTxn(snap))
+		tr.logger = hclog.NewNullLogger()
+		tr.ctx = runtime.TxContext{BaseFee: big.NewInt(0), GasLimit: 10_000_000, ChainID: 1, Number: int64(number)}
+		tr.gasPool = uint64(tr.ctx.GasLimit)
+		tr.getHash = func(uint64) types.Hash { return types.ZeroHash }
+
+		executor.enableStatefulPrecompiles(tr, number)
+
+		return tr
+	}
+
+	input, err := kvstore.SetFunc.Encode([]interface{}{key, value})
+	require.NoError(t, err)
+
+	tx := &types.Transaction{
+		From:     sender,
+		To:       &store,
+		Value:    big.NewInt(0),
+		Gas:      100_000,
+		GasPrice: big.NewInt(1),
+		Input:    input,
+	}
+	tx.ComputeHash(1)
+
+	// the precompile isn't active before the fork, so the call doesn't store anything
+	tr := newTransition(4)
+	require.NoError(t, tr.Write(tx))
+	require.Zero(t, tr.state.GetCodeSize(store))
+	require.Empty(t, tr.Receipts()[0].Logs)
+
+	// the precompile gets the stub code once active
+	tr = newTransition(5)
+	require.NoError(t, tr.Write(tx))
+	require.Equal(t, statefulPrecompileCode, tr.state.GetCode(store))
+	require.Equal(t, types.ReceiptSuccess, *tr.Receipts()[0].Status)
+	require.Len(t, tr.Receipts()[0].Logs, 1)
+
+	result, err := tr.Apply(&types.Transaction{
+		From:     sender,
+		To:       &store,
+		Value:    big.NewInt(0),
+		Gas:      100_000,
+		GasPrice: big.NewInt(0),
+		Nonce:    1,
+		Input:    append(kvstore.GetFunc.ID(), append(types.BytesToHash(sender.Bytes()).Bytes(), key.Bytes()...)...),
+	})
+	require.NoError(t, err)
+	require.NoError(t, result.Err)
+	require.Equal(t, value.Bytes(), result.ReturnValue)
+}
+
+func TestTransition_FeeDistribution(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender     = types.StringToAddress("0xa0")
+		receiver   = types.StringToAddress("0xb0")
+		coinbase   = types.StringToAddress("0xc0")
+		burn       = types.StringToAddress("0xd0")
+		stakerPool = types.StringToAddress("0xe0")
+		treasury   = types.StringToAddress("0xf0")
+	)
+
+	newTransition := func(distribution *chain.FeeDistribution) *Transition {
+		snap := &mockSnapshot{state: map[types.Address]*PreState{sender: {Balance: 1_000_000_000}}}
+
+		tr := NewTransition(chain.AllForksEnabled.At(0), snap, newTxn(snap))
+		tr.logger = hclog.NewNullLogger()
+		tr.ctx = runtime.TxContext{
+			Coinbase:     coinbase,
+			BurnContract: burn,
+			BaseFee:      big.NewInt(10),
+			GasLimit:     10_000_000,
+			ChainID:      1,
+		}
+		tr.gasPool = uint64(tr.ctx.GasLimit)
+		tr.getHash = func(uint64) types.Hash { return types.ZeroHash }
+		tr.feeDistribution = distribution
+
+		return tr
+	}
+
+	tx := &types.Transaction{
+		From:     sender,
+		To:       &receiver,
+		Value:    big.NewInt(1),
+		Gas:      21000,
+		GasPrice: big.NewInt(20),
+	}
+	tx.ComputeHash(1)
+
+	distribution := &chain.FeeDistribution{
+		ProposerShare:   5000,
+		StakerPoolShare: 3000,
+		TreasuryShare:   2000,
+		StakerPool:      stakerPool,
+		Treasury:        treasury,
+	}
+
+	cases := []struct {
+		name           string
+		includeBaseFee bool
+		expected       map[types.Address]int64
+	}{
+		{
+			name: "tips shared",
+			// the tip and the base fee are 210000 each
+			expected: map[types.Address]int64{coinbase: 105000, stakerPool: 63000, treasury: 42000, burn: 210000},
+		},
+		{
+			name:           "tips and base fees shared",
+			includeBaseFee: true,
+			expected:       map[types.Address]int64{coinbase: 210000, stakerPool: 126000, treasury: 84000, burn: 0},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			distribution := *distribution
+			distribution.IncludeBaseFee = c.includeBaseFee
+
+			executor := &Executor{logger: hclog.NewNullLogger(), config: &chain.Params{}}
+
+			serial := newTransition(&distribution)
+			require.NoError(t, serial.Write(tx))
+
+			parallel := newTransition(&distribution)
+			require.NoError(t, executor.writeParallel(parallel, []*types.Transaction{tx}))
+
+			for address, balance := range c.expected {
+				require.Equal(t, big.NewInt(balance), serial.state.GetBalance(address), address)
+				require.Equal(t, big.NewInt(balance), parallel.state.GetBalance(address), address)
+			}
+		})
+	}
+}
+
+func TestTransition_TxPolicy(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender   = types.StringToAddress("0xa0")
+		receiver = types.StringToAddress("0xb0")
+		transfer = [4]byte{0xa9, 0x05, 0x9c, 0xbb}
+	)
+
+	// the policy account is kept alive by the balance it gets in the genesis
+	snap := &mockSnapshot{state: map[types.Address]*PreState{
+		sender:                 {Balance: 1_000_000_000},
+		contracts.TxPolicyAddr: {Balance: 1},
+	}}
+
+	tr := NewTransition(chain.AllForksEnabled.At(0), snap, newTxn(snap))
+	tr.logger = hclog.NewNullLogger()
+	tr.ctx = runtime.TxContext{BaseFee: big.NewInt(10), GasLimit: 10_000_000, ChainID: 1}
+	tr.gasPool = uint64(tr.ctx.GasLimit)
+	tr.getHash = func(uint64) types.Hash { return types.ZeroHash }
+	tr.txPolicy = txpolicy.NewTxPolicy(tr, contracts.TxPolicyAddr)
+	tr.txPolicy.SetSelectorDenied(receiver, transfer, true)
+
+	tx := func(nonce uint64, input []byte) *types.Transaction {
+		tx := &types.Transaction{
+			From:     sender,
+			To:       &receiver,
+			Nonce:    nonce,
+			Value:    big.NewInt(1),
+			Gas:      30000,
+			GasPrice: big.NewInt(10),
+			Input:    input,
+		}
+		tx.ComputeHash(1)
+
+		return tx
+	}
+
+	require.NoError(t, tr.Write(tx(0, []byte{0x1, 0x2, 0x3, 0x4})))
+	require.NoError(t, tr.Write(tx(1, transfer[:])))
+
+	receipts := tr.Receipts()
+	require.Len(t, receipts, 2)
+	require.Equal(t, types.ReceiptSuccess, *receipts[0].Status)
+
+	// the denied transaction is included as failed, consuming all its gas, and the denial is logged
+	denied := receipts[1]
+	require.Equal(t, types.ReceiptFailed, *denied.Status)
+	require.Equal(t, uint64(30000), denied.GasUsed)
+	require.Len(t, denied.Logs, 1)
+	require.Equal(t, contracts.TxPolicyAddr, denied.Logs[0].Address)
+	require.Equal(t, types.Hash(txpolicy.TransactionDeniedEvent.ID()), denied.Logs[0].Topics[0])
+
+	require.Equal(t, uint64(2), tr.state.GetNonce(sender))
+	require.Equal(t, big.NewInt(1), tr.state.GetBalance(receiver))
+}
+
+// stubEngine is the execution engine returning the fixed output for any code
+type stubEngine struct {
+	output []byte
+	calls  int
+}
+
+func (e *stubEngine) Run(c *runtime.Contract, _ runtime.Host, _ *chain.ForksInTime) *runtime.ExecutionResult {
+	e.calls++
+
+	return &runtime.ExecutionResult{ReturnValue: e.output, GasLeft: c.Gas}
+}
+
+func (e *stubEngine) CanRun(*runtime.Contract, runtime.Host, *chain.ForksInTime) bool {
+	return true
+}
+
+func (e *stubEngine) Name() string {
+	return "stub"
+}
+
+func TestTransition_SetEngine(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender   = types.StringToAddress("0xa0")
+		contract = types.StringToAddress("0xb0")
+	)
+
+	snap := &codeSnapshot{
+		mockSnapshot: &mockSnapshot{state: map[types.Address]*PreState{
+			sender:   {Balance: 1_000_000_000},
+			contract: {},
+		}},
+		// returns 0x01
+		code: map[types.Address][]byte{contract: {0x60, 0x01, 0x60, 0x00, 0x52, 0x60, 0x01, 0x60, 0x1f, 0xf3}},
+	}
+
+	call := func(tr *Transition) []byte {
+		tr.logger = hclog.NewNullLogger()
+		tr.ctx = runtime.TxContext{BaseFee: big.NewInt(0), GasLimit: 10_000_000, ChainID: 1}
+		tr.gasPool = uint64(tr.ctx.GasLimit)
+
+		result, err := tr.Apply(&types.Transaction{
+			From:     sender,
+			To:       &contract,
+			Value:    big.NewInt(0),
+			Gas:      100_000,
+			GasPrice: big.NewInt(0),
+		})
+		require.NoError(t, err)
+		require.NoError(t, result.Err)
+
+		return result.ReturnValue
+	}
+
+	// the built-in EVM executes the code by default
+	require.Equal(t, []byte{0x01}, call(NewTransition(chain.AllForksEnabled.At(0), snap, newTxn(snap))))
+
+	engine := &stubEngine{output: []byte{0x02}}
+
+	tr := NewTransition(chain.AllForksEnabled.At(0), snap, newTxn(snap))
+	tr.SetEngine(engine)
+
+	require.Equal(t, []byte{0x02}, call(tr))
+	require.Equal(t, 1, engine.calls)
+}
+
+func TestTransition_MaxCodeSize(t *testing.T) {
+	t.Parallel()
+
+	sender := types.StringToAddress("0xa0")
+
+	// the init code returning the code of 30000 zero bytes, above the mainnet limit
+	initCode := []byte{
+		0x61, 0x75, 0x30, // PUSH2 30000
+		0x60, 0x00, // PUSH1 0
+		0xf3, // RETURN
+	}
+
+	cases := []struct {
+		name        string
+		maxCodeSize uint64
+		err         error
+	}{
+		{"mainnet limit", SpuriousDragonMaxCodeSize, runtime.ErrMaxCodeSizeExceeded},
+		{"raised limit", 32768, nil},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			snap := &mockSnapshot{state: map[types.Address]*PreState{sender: {Balance: 1_000_000_000}}}
+
+			tr := NewTransition(chain.AllForksEnabled.At(0), snap, newTxn(snap))
+			tr.logger = hclog.NewNullLogger()
+			tr.ctx = runtime.TxContext{BaseFee: big.NewInt(10), GasLimit: 10_000_000, ChainID: 1}
+			tr.gasPool = uint64(tr.ctx.GasLimit)
+			tr.getHash = func(uint64) types.Hash { return types.ZeroHash }
+			tr.maxCodeSize = c.maxCodeSize
+
+			result, err := tr.Apply(&types.Transaction{
+				From:     sender,
+				Value:    big.NewInt(0),
+				Input:    initCode,
+				Gas:      7_000_000,
+				GasPrice: big.NewInt(20),
+			})
+			require.NoError(t, err)
+			require.ErrorIs(t, result.Err, c.err)
+		})
+	}
+}
+
+func TestTransition_TraceCreate2(t *testing.T) {
+	t.Parallel()
+
+	sender := types.StringToAddress("0xa0")
+
+	// the init code deploying the empty contract with CREATE2
+	initCode := []byte{
+		0x60, 0x00, // PUSH1 0 (salt)
+		0x60, 0x00, // PUSH1 0 (length)
+		0x60, 0x00, // PUSH1 0 (offset)
+		0x60, 0x00, // PUSH1 0 (value)
+		0xf5, // CREATE2
+		0x00, // STOP
+	}
+
+	snap := &mockSnapshot{state: map[types.Address]*PreState{sender: {Balance: 1_000_000_000}}}
+	callTracer := &calltracer.CallTracer{}
+
+	tr := NewTransition(chain.AllForksEnabled.At(0), snap, newTxn(snap))
+	tr.logger = hclog.NewNullLogger()
+	tr.ctx = runtime.TxContext{BaseFee: big.NewInt(10), GasLimit: 10_000_000, ChainID: 1, Tracer: callTracer}
+	tr.gasPool = uint64(tr.ctx.GasLimit)
+	tr.getHash = func(uint64) types.Hash { return types.ZeroHash }
+
+	result, err := tr.Apply(&types.Transaction{
+		From:     sender,
+		Value:    big.NewInt(0),
+		Input:    initCode,
+		Gas:      1_000_000,
+		GasPrice: big.NewInt(20),
+	})
+	require.NoError(t, err)
+	require.NoError(t, result.Err)
+
+	trace, err := callTracer.GetResult()
+	require.NoError(t, err)
+
+	call, ok := trace.(*calltracer.Call)
+	require.True(t, ok)
+	require.Equal(t, "CREATE", call.Type)
+	require.Len(t, call.Calls, 1)
+	require.Equal(t, "CREATE2", call.Calls[0].Type)
+}
@@ -0,0 +1,58 @@
+package itrie
+
+import (
+	"github.com/0xPolygon/polygon-edge/helper/kvdb"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var _ PrunableStorage = (*DatabaseStorage)(nil)
+
+// DatabaseStorage is the trie storage on top of the key-value database of any backend
+type DatabaseStorage struct {
+	db kvdb.Database
+}
+
+// NewDatabaseStorage creates the trie storage on top of the key-value database
+func NewDatabaseStorage(db kvdb.Database) *DatabaseStorage {
+	return &DatabaseStorage{db: db}
+}
+
+func (d *DatabaseStorage) Put(k, v []byte) error {
+	return d.db.Put(k, v)
+}
+
+func (d *DatabaseStorage) Get(k []byte) ([]byte, bool, error) {
+	return d.db.Get(k)
+}
+
+func (d *DatabaseStorage) Delete(k []byte) error {
+	return d.db.Delete(k)
+}
+
+func (d *DatabaseStorage) Batch() Batch {
+	return d.db.NewBatch()
+}
+
+func (d *DatabaseStorage) SetCode(hash types.Hash, code []byte) error {
+	return d.Put(GetCodeKey(hash), code)
+}
+
+func (d *DatabaseStorage) GetCode(hash types.Hash) ([]byte, bool) {
+	res, ok, err := d.Get(GetCodeKey(hash))
+	if err != nil {
+		return nil, false
+	}
+
+	return res, ok
+}
+
+// ForEachKey iterates over all keys in the storage, the keys passed to fn are safe copies
+func (d *DatabaseStorage) ForEachKey(fn func(k []byte) error) error {
+	return d.db.ForEach(func(k, _ []byte) error {
+		return fn(k)
+	})
+}
+
+func (d *DatabaseStorage) Close() error {
+	return d.db.Close()
+}
@@ -0,0 +1,143 @@
+package itrie
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/umbracle/fastrlp"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// preimagePrefix is the prefix of the preimages of the hashed trie keys, followed by the key hash
+	preimagePrefix = []byte("pi")
+
+	// ErrMissingPreimage is returned when the address or the storage key of a hashed trie key is not known,
+	// since the state was written before the preimages were recorded
+	ErrMissingPreimage = errors.New("missing preimage of the trie key")
+
+	errDumpFull = errors.New("dump is full")
+)
+
+// StateDump is a range of the accounts of a state, ordered by the hashes of their addresses
+type StateDump struct {
+	Root     types.Hash                              `json:"root"`
+	Accounts map[types.Address]*chain.GenesisAccount `json:"accounts"`
+
+	// Next is the hash of the address the next range starts at, nil if the range is the last one
+	Next *types.Hash `json:"next,omitempty"`
+}
+
+func preimageKey(hash types.Hash) []byte {
+	return append(append(make([]byte, 0, len(preimagePrefix)+types.HashLength), preimagePrefix...), hash.Bytes()...)
+}
+
+// readPreimage returns the address or the storage key the trie key is hashed from
+func readPreimage(storage Storage, hash types.Hash) ([]byte, error) {
+	preimage, ok, err := storage.Get(preimageKey(hash))
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrMissingPreimage, hash)
+	}
+
+	return preimage, nil
+}
+
+// DumpState returns up to maxAccounts accounts of the state with the given root, along with their code
+// and storage, starting at the account whose address hash is start. The addresses and the storage keys
+// are resolved from the preimages recorded on the commits
+func DumpState(storage Storage, root types.Hash, start types.Hash, maxAccounts int) (*StateDump, error) {
+	dump := &StateDump{
+		Root:     root,
+		Accounts: make(map[types.Address]*chain.GenesisAccount),
+	}
+
+	err := walkTrieLeavesFrom(root, storage, start, func(accountHash types.Hash, data []byte) error {
+		if len(dump.Accounts) == maxAccounts {
+			dump.Next = &accountHash
+
+			return errDumpFull
+		}
+
+		preimage, err := readPreimage(storage, accountHash)
+		if err != nil {
+			return err
+		}
+
+		account, err := dumpAccount(storage, data)
+		if err != nil {
+			return fmt.Errorf("failed to dump account %s: %w", types.BytesToAddress(preimage), err)
+		}
+
+		dump.Accounts[types.BytesToAddress(preimage)] = account
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDumpFull) {
+		return nil, err
+	}
+
+	return dump, nil
+}
+
+// dumpAccount decodes the account of the state trie, and reads its code and storage
+func dumpAccount(storage Storage, data []byte) (*chain.GenesisAccount, error) {
+	var account state.Account
+	if err := account.UnmarshalRlp(data); err != nil {
+		return nil, err
+	}
+
+	dumped := &chain.GenesisAccount{
+		Balance: account.Balance,
+		Nonce:   account.Nonce,
+	}
+
+	if codeHash := types.BytesToHash(account.CodeHash); codeHash != types.EmptyCodeHash {
+		code, ok := storage.GetCode(codeHash)
+		if !ok {
+			return nil, fmt.Errorf("code %s not found", codeHash)
+		}
+
+		dumped.Code = code
+	}
+
+	if account.Root == types.EmptyRootHash {
+		return dumped, nil
+	}
+
+	dumped.Storage = make(map[types.Hash]types.Hash)
+
+	parser := &fastrlp.Parser{}
+
+	err := walkTrieLeaves(account.Root, storage, func(slotHash types.Hash, slot []byte) error {
+		key, err := readPreimage(storage, slotHash)
+		if err != nil {
+			return err
+		}
+
+		v, err := parser.Parse(slot)
+		if err != nil {
+			return err
+		}
+
+		val, err := v.GetBytes(nil)
+		if err != nil {
+			return err
+		}
+
+		dumped.Storage[types.BytesToHash(key)] = types.BytesToHash(val)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dumped, nil
+}
@@ -0,0 +1,107 @@
+package itrie
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestDumpState(t *testing.T) {
+	t.Parallel()
+
+	var (
+		code    = []byte{0x60, 0x00}
+		slot    = types.StringToHash("1")
+		storage = NewMemoryStorage()
+		objs    = make([]*state.Object, 5)
+	)
+
+	for i := range objs {
+		objs[i] = &state.Object{
+			Address:  types.StringToAddress(string(rune('1' + i))),
+			Balance:  big.NewInt(int64(i + 1)),
+			Nonce:    uint64(i),
+			Root:     types.EmptyRootHash,
+			CodeHash: types.EmptyCodeHash,
+		}
+	}
+
+	objs[0].CodeHash = types.BytesToHash(crypto.Keccak256(code))
+	objs[0].Code = code
+	objs[0].DirtyCode = true
+	objs[0].Storage = []*state.StorageObject{{Key: slot.Bytes(), Val: big.NewInt(10).Bytes()}}
+
+	_, rawRoot, err := NewState(storage).NewSnapshot().Commit(objs)
+	require.NoError(t, err)
+
+	root := types.BytesToHash(rawRoot)
+
+	// the state is dumped in the ranges of two accounts
+	var (
+		accounts = make(map[types.Address]int)
+		start    = types.ZeroHash
+		ranges   = 0
+	)
+
+	for {
+		dump, err := DumpState(storage, root, start, 2)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(dump.Accounts), 2)
+
+		for addr := range dump.Accounts {
+			accounts[addr]++
+		}
+
+		ranges++
+
+		if dump.Next == nil {
+			break
+		}
+
+		start = *dump.Next
+	}
+
+	assert.Equal(t, 3, ranges)
+	assert.Len(t, accounts, len(objs))
+
+	full, err := DumpState(storage, root, types.ZeroHash, len(objs))
+	require.NoError(t, err)
+	require.Nil(t, full.Next)
+
+	for i, obj := range objs {
+		account, ok := full.Accounts[obj.Address]
+		require.True(t, ok)
+		assert.Equal(t, 1, accounts[obj.Address])
+		assert.Equal(t, obj.Balance, account.Balance)
+		assert.Equal(t, uint64(i), account.Nonce)
+	}
+
+	assert.Equal(t, code, full.Accounts[objs[0].Address].Code)
+	assert.Equal(t, map[types.Hash]types.Hash{slot: types.BytesToHash(big.NewInt(10).Bytes())},
+		full.Accounts[objs[0].Address].Storage)
+	assert.Nil(t, full.Accounts[objs[1].Address].Storage)
+
+	// the state written without the preimages can't be dumped
+	_, err = DumpState(&withoutPreimages{storage}, root, types.ZeroHash, len(objs))
+	require.ErrorIs(t, err, ErrMissingPreimage)
+}
+
+// withoutPreimages is the storage which doesn't return the preimages
+type withoutPreimages struct {
+	Storage
+}
+
+func (s *withoutPreimages) Get(k []byte) ([]byte, bool, error) {
+	if bytes.HasPrefix(k, preimagePrefix) {
+		return nil, false, nil
+	}
+
+	return s.Storage.Get(k)
+}
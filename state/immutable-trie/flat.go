@@ -0,0 +1,592 @@
+package itrie
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// maxFlatDiffs is the number of the recent commits whose diffs are kept to be applied to the flat layer
+	maxFlatDiffs = 1024
+
+	// flatGenerationBatchSize is the number of the entries written at once while generating the flat layer
+	flatGenerationBatchSize = 10000
+
+	// incarnationLength is the length of the account incarnation, stored in front of the flat account
+	incarnationLength = 8
+
+	flatLayerMetrics = "flat_snapshot"
+)
+
+var (
+	// flatAccountPrefix is the prefix of the flat accounts, followed by the account hash
+	flatAccountPrefix = []byte("fa")
+	// flatStoragePrefix is the prefix of the flat storage slots,
+	// followed by the account hash, the account incarnation and the slot hash
+	flatStoragePrefix = []byte("fs")
+	// flatRootKey is the key of the state root the flat layer holds
+	flatRootKey = []byte("flat-root")
+
+	flatAccountKeyLength = len(flatAccountPrefix) + types.HashLength
+	flatStorageKeyLength = len(flatStoragePrefix) + 2*types.HashLength + incarnationLength
+
+	errFlatDiffNotFound = errors.New("diff not found")
+	errFlatDiffParent   = errors.New("diff is not based on the state of the flat layer")
+)
+
+// flatDiff is the change of the accounts and storage slots made by a commit
+type flatDiff struct {
+	parent types.Hash
+
+	// accounts are the encoded accounts by their hashes, nil if the account is deleted
+	accounts map[types.Hash][]byte
+	// storage are the encoded slots by the account and slot hashes, empty if the slot is deleted
+	storage map[types.Hash]map[types.Hash][]byte
+	// wiped are the accounts whose previous storage is discarded (deleted or recreated accounts)
+	wiped map[types.Hash]struct{}
+}
+
+func newFlatDiff(parent types.Hash) *flatDiff {
+	return &flatDiff{
+		parent:   parent,
+		accounts: make(map[types.Hash][]byte),
+		storage:  make(map[types.Hash]map[types.Hash][]byte),
+		wiped:    make(map[types.Hash]struct{}),
+	}
+}
+
+// FlatLayer is the flat key/value copy of the accounts and the storage slots of a single state,
+// which serves the reads of that state without traversing the trie.
+// The layer follows the head of the chain by applying the diffs recorded on the commits,
+// and it is regenerated from the trie in the background whenever it can't follow (e.g. on a reorg)
+type FlatLayer struct {
+	logger  hclog.Logger
+	storage PrunableStorage
+	diffs   *lru.Cache
+
+	// lock guards root, the reads are served only while the layer holds the read state
+	lock sync.RWMutex
+	root types.Hash
+
+	// updateLock serializes the updates and guards the generation state
+	updateLock sync.Mutex
+	generating bool
+	pending    []types.Hash
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewFlatLayer creates the flat layer on top of the storage, holding the state it was left with
+func NewFlatLayer(storage PrunableStorage, logger hclog.Logger) (*FlatLayer, error) {
+	diffs, err := lru.New(maxFlatDiffs)
+	if err != nil {
+		return nil, err
+	}
+
+	root, ok, err := storage.Get(flatRootKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	f := &FlatLayer{
+		logger:  logger.Named("flat_layer"),
+		storage: storage,
+		diffs:   diffs,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+
+	if ok && len(root) == types.HashLength {
+		f.root = types.BytesToHash(root)
+		metrics.SetGauge([]string{flatLayerMetrics, "coverage"}, 100)
+	}
+
+	return f, nil
+}
+
+// Root returns the state root the layer holds, zero hash while the layer is being generated
+func (f *FlatLayer) Root() types.Hash {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	return f.root
+}
+
+// Update moves the layer to the state with the given root, which is the new head of the chain.
+// The roots have to be passed in the order of the blocks
+func (f *FlatLayer) Update(root types.Hash) {
+	f.updateLock.Lock()
+	defer f.updateLock.Unlock()
+
+	if f.generating {
+		f.pending = append(f.pending, root)
+
+		return
+	}
+
+	if root == f.Root() {
+		return
+	}
+
+	if err := f.apply(root); err != nil {
+		f.logger.Info("regenerating the flat layer", "root", root, "reason", err)
+		f.generate(root)
+	}
+}
+
+// Regenerate rebuilds the layer from the trie of the given state in the background,
+// it returns false if the layer is being generated already
+func (f *FlatLayer) Regenerate(root types.Hash) bool {
+	f.updateLock.Lock()
+	defer f.updateLock.Unlock()
+
+	if f.generating {
+		return false
+	}
+
+	f.logger.Info("regenerating the flat layer", "root", root, "reason", "requested")
+	f.generate(root)
+
+	return true
+}
+
+// Close stops the generation of the layer
+func (f *FlatLayer) Close() {
+	f.cancel()
+	f.wg.Wait()
+}
+
+// recordDiff keeps the diff of the commit, until its state becomes the head of the chain
+func (f *FlatLayer) recordDiff(root types.Hash, diff *flatDiff) {
+	f.diffs.Add(root, diff)
+}
+
+// apply writes the diff of the given state to the storage,
+// the diff has to be based on the state the layer holds
+func (f *FlatLayer) apply(root types.Hash) error {
+	raw, ok := f.diffs.Get(root)
+	if !ok {
+		return errFlatDiffNotFound
+	}
+
+	diff, ok := raw.(*flatDiff)
+	if !ok {
+		return fmt.Errorf("invalid type assertion on diff: %s", root)
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if diff.parent != f.root {
+		return errFlatDiffParent
+	}
+
+	batch := f.storage.Batch()
+
+	for accountHash, account := range diff.accounts {
+		incarnation, err := f.incarnation(accountHash)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := diff.wiped[accountHash]; ok {
+			// the slots of the previous incarnation are not reachable anymore
+			incarnation++
+		}
+
+		batch.Put(flatAccountKey(accountHash), encodeFlatAccount(incarnation, account))
+
+		for slotHash, slot := range diff.storage[accountHash] {
+			batch.Put(flatStorageKey(accountHash, incarnation, slotHash), slot)
+		}
+	}
+
+	batch.Put(flatRootKey, root.Bytes())
+
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	f.root = root
+
+	return nil
+}
+
+// generate regenerates the layer from the trie of the given state in the background,
+// and then applies the diffs of the blocks added meanwhile
+func (f *FlatLayer) generate(root types.Hash) {
+	f.lock.Lock()
+	f.root = types.ZeroHash
+	f.lock.Unlock()
+
+	f.generating = true
+
+	f.wg.Add(1)
+
+	go func() {
+		defer f.wg.Done()
+
+		err := f.regenerate(root)
+
+		f.updateLock.Lock()
+		defer f.updateLock.Unlock()
+
+		f.generating = false
+
+		pending := f.pending
+		f.pending = nil
+
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				// it is retried on the next update
+				f.logger.Error("failed to generate the flat layer", "root", root, "err", err)
+			}
+
+			return
+		}
+
+		f.lock.Lock()
+		f.root = root
+		f.lock.Unlock()
+
+		f.logger.Info("flat layer generated", "root", root)
+
+		for _, pendingRoot := range pending {
+			if pendingRoot == f.root {
+				continue
+			}
+
+			if err := f.apply(pendingRoot); err != nil {
+				latest := pending[len(pending)-1]
+
+				f.logger.Info("regenerating the flat layer", "root", latest, "reason", err)
+				f.generate(latest)
+
+				return
+			}
+		}
+	}()
+}
+
+// regenerate wipes the layer and writes all accounts and storage slots of the given state
+func (f *FlatLayer) regenerate(root types.Hash) error {
+	metrics.SetGauge([]string{flatLayerMetrics, "coverage"}, 0)
+
+	if err := f.ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := f.storage.Delete(flatRootKey); err != nil {
+		return err
+	}
+
+	err := f.storage.ForEachKey(func(k []byte) error {
+		if !isFlatKey(k) {
+			return nil
+		}
+
+		return f.storage.Delete(k)
+	})
+	if err != nil {
+		return err
+	}
+
+	var (
+		batch   = f.storage.Batch()
+		entries = 0
+	)
+
+	put := func(k, v []byte) error {
+		batch.Put(k, v)
+
+		if entries++; entries < flatGenerationBatchSize {
+			return nil
+		}
+
+		if err := f.ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := batch.Write(); err != nil {
+			return err
+		}
+
+		batch = f.storage.Batch()
+		entries = 0
+
+		return nil
+	}
+
+	err = walkTrieLeaves(root, f.storage, func(accountHash types.Hash, data []byte) error {
+		var account state.Account
+		if err := account.UnmarshalRlp(data); err != nil {
+			return fmt.Errorf("can't parse account %s: %w", accountHash, err)
+		}
+
+		if err := put(flatAccountKey(accountHash), encodeFlatAccount(0, data)); err != nil {
+			return err
+		}
+
+		if account.Root != types.EmptyRootHash {
+			err := walkTrieLeaves(account.Root, f.storage, func(slotHash types.Hash, slot []byte) error {
+				return put(flatStorageKey(accountHash, 0, slotHash), slot)
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		setFlatCoverage(accountHash)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	batch.Put(flatRootKey, root.Bytes())
+
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	metrics.SetGauge([]string{flatLayerMetrics, "coverage"}, 100)
+
+	return nil
+}
+
+// account returns the encoded account of the given state,
+// it is not served unless the layer holds the state
+func (f *FlatLayer) account(root types.Hash, accountHash types.Hash) ([]byte, bool) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	if root != f.root || root == types.ZeroHash {
+		metrics.IncrCounter([]string{flatLayerMetrics, "miss"}, 1)
+
+		return nil, false
+	}
+
+	entry, ok, err := f.storage.Get(flatAccountKey(accountHash))
+	if err != nil {
+		metrics.IncrCounter([]string{flatLayerMetrics, "miss"}, 1)
+
+		return nil, false
+	}
+
+	metrics.IncrCounter([]string{flatLayerMetrics, "hit"}, 1)
+
+	if !ok || len(entry) <= incarnationLength {
+		return nil, true
+	}
+
+	return entry[incarnationLength:], true
+}
+
+// slot returns the encoded storage slot of the account in the given state,
+// it is not served unless the layer holds the state
+func (f *FlatLayer) slot(root types.Hash, accountHash types.Hash, slotHash types.Hash) ([]byte, bool) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	if root != f.root || root == types.ZeroHash {
+		metrics.IncrCounter([]string{flatLayerMetrics, "miss"}, 1)
+
+		return nil, false
+	}
+
+	entry, ok, err := f.storage.Get(flatAccountKey(accountHash))
+	if err != nil {
+		metrics.IncrCounter([]string{flatLayerMetrics, "miss"}, 1)
+
+		return nil, false
+	}
+
+	if !ok || len(entry) <= incarnationLength {
+		metrics.IncrCounter([]string{flatLayerMetrics, "hit"}, 1)
+
+		return nil, true
+	}
+
+	incarnation := binary.BigEndian.Uint64(entry[:incarnationLength])
+
+	slot, ok, err := f.storage.Get(flatStorageKey(accountHash, incarnation, slotHash))
+	if err != nil {
+		metrics.IncrCounter([]string{flatLayerMetrics, "miss"}, 1)
+
+		return nil, false
+	}
+
+	metrics.IncrCounter([]string{flatLayerMetrics, "hit"}, 1)
+
+	if !ok || len(slot) == 0 {
+		return nil, true
+	}
+
+	return slot, true
+}
+
+// incarnation returns the incarnation of the account stored in the layer
+func (f *FlatLayer) incarnation(accountHash types.Hash) (uint64, error) {
+	entry, ok, err := f.storage.Get(flatAccountKey(accountHash))
+	if err != nil || !ok || len(entry) < incarnationLength {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint64(entry[:incarnationLength]), nil
+}
+
+func flatAccountKey(accountHash types.Hash) []byte {
+	key := make([]byte, 0, flatAccountKeyLength)
+	key = append(key, flatAccountPrefix...)
+
+	return append(key, accountHash.Bytes()...)
+}
+
+func flatStorageKey(accountHash types.Hash, incarnation uint64, slotHash types.Hash) []byte {
+	key := make([]byte, 0, flatStorageKeyLength)
+	key = append(key, flatStoragePrefix...)
+	key = append(key, accountHash.Bytes()...)
+	key = binary.BigEndian.AppendUint64(key, incarnation)
+
+	return append(key, slotHash.Bytes()...)
+}
+
+// encodeFlatAccount prepends the incarnation to the encoded account, the deleted account has no data
+func encodeFlatAccount(incarnation uint64, data []byte) []byte {
+	entry := make([]byte, incarnationLength, incarnationLength+len(data))
+	binary.BigEndian.PutUint64(entry, incarnation)
+
+	return append(entry, data...)
+}
+
+// isFlatKey checks if the key belongs to the flat layer,
+// the trie nodes and the codes have keys of the different lengths
+func isFlatKey(k []byte) bool {
+	return (len(k) == flatAccountKeyLength && bytes.HasPrefix(k, flatAccountPrefix)) ||
+		(len(k) == flatStorageKeyLength && bytes.HasPrefix(k, flatStoragePrefix))
+}
+
+// setFlatCoverage estimates the generated part of the layer from the last generated account,
+// since the accounts are walked in the order of their hashes
+func setFlatCoverage(accountHash types.Hash) {
+	progress := float64(binary.BigEndian.Uint64(accountHash[:8])) / math.MaxUint64
+
+	metrics.SetGauge([]string{flatLayerMetrics, "coverage"}, float32(progress*100))
+}
+
+// walkTrieLeaves calls fn for every leaf of the trie with the given root, in the order of the keys
+func walkTrieLeaves(root types.Hash, storage Storage, fn func(key types.Hash, value []byte) error) error {
+	if root == types.EmptyRootHash {
+		return nil
+	}
+
+	node, ok, err := GetNode(root.Bytes(), storage)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return fmt.Errorf("trie node %s not found", root)
+	}
+
+	return walkTrieNode(node, storage, nil, nil, fn)
+}
+
+// walkTrieLeavesFrom calls fn for every leaf of the trie with the given root whose key is not below start,
+// in the order of the keys. The subtries below start are skipped
+func walkTrieLeavesFrom(
+	root types.Hash,
+	storage Storage,
+	start types.Hash,
+	fn func(key types.Hash, value []byte) error,
+) error {
+	if root == types.EmptyRootHash {
+		return nil
+	}
+
+	node, ok, err := GetNode(root.Bytes(), storage)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return fmt.Errorf("trie node %s not found", root)
+	}
+
+	return walkTrieNode(node, storage, nil, bytesToHexNibbles(start.Bytes()), fn)
+}
+
+// isBelowStart checks if all the keys under the path are below the start path
+func isBelowStart(path, start []byte) bool {
+	if len(path) > len(start) {
+		path = path[:len(start)]
+	}
+
+	return bytes.Compare(path, start[:len(path)]) < 0
+}
+
+func walkTrieNode(node Node, storage Storage, path, start []byte, fn func(key types.Hash, value []byte) error) error {
+	if start != nil && isBelowStart(path, start) {
+		return nil
+	}
+
+	switch n := node.(type) {
+	case nil:
+		return nil
+	case *FullNode:
+		for i, child := range n.children {
+			if err := walkTrieNode(child, storage, concat(path, []byte{byte(i)}), start, fn); err != nil {
+				return err
+			}
+		}
+
+		if n.value != nil {
+			return walkTrieNode(n.value, storage, concat(path, []byte{16}), start, fn)
+		}
+	case *ShortNode:
+		return walkTrieNode(n.child, storage, concat(path, n.key), start, fn)
+	case *ValueNode:
+		if n.hash {
+			child, ok, err := GetNode(n.buf, storage)
+			if err != nil {
+				return err
+			}
+
+			if !ok {
+				return fmt.Errorf("trie node %s not found", types.BytesToHash(n.buf))
+			}
+
+			return walkTrieNode(child, storage, path, start, fn)
+		}
+
+		if !hasTerminator(path) || len(path) != 2*types.HashLength+1 {
+			return fmt.Errorf("unexpected trie leaf path length %d", len(path))
+		}
+
+		key := make([]byte, types.HashLength)
+		for i := range key {
+			key[i] = path[2*i]<<4 | path[2*i+1]
+		}
+
+		return fn(types.BytesToHash(key), n.buf)
+	}
+
+	return nil
+}
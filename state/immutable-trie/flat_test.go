@@ -0,0 +1,170 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func newFlatTestState(t *testing.T) (*State, *FlatLayer) {
+	t.Helper()
+
+	storage := NewMemoryStorage().(PrunableStorage) //nolint:forcetypeassert
+
+	flat, err := NewFlatLayer(storage, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	t.Cleanup(flat.Close)
+
+	st := NewState(storage)
+	st.SetFlatLayer(flat)
+
+	return st, flat
+}
+
+func waitFlatRoot(t *testing.T, flat *FlatLayer, root types.Hash) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		return flat.Root() == root
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestFlatLayer_FollowsChain(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1 = types.StringToAddress("1")
+		addr2 = types.StringToAddress("2")
+		slot1 = types.StringToHash("1")
+		slot2 = types.StringToHash("2")
+	)
+
+	st, flat := newFlatTestState(t)
+
+	// the genesis state is generated, since the layer holds no state
+	genesis, genesisRoot, err := st.NewSnapshot().Commit([]*state.Object{
+		{Address: addr1, Balance: big.NewInt(1), Root: types.EmptyRootHash, CodeHash: types.EmptyCodeHash},
+		{
+			Address:  addr2,
+			Balance:  big.NewInt(2),
+			Root:     types.EmptyRootHash,
+			CodeHash: types.EmptyCodeHash,
+			Storage: []*state.StorageObject{
+				{Key: slot1.Bytes(), Val: big.NewInt(10).Bytes()},
+				{Key: slot2.Bytes(), Val: big.NewInt(20).Bytes()},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	flat.Update(types.BytesToHash(genesisRoot))
+	waitFlatRoot(t, flat, types.BytesToHash(genesisRoot))
+
+	genesisAccount, err := genesis.GetAccount(addr2)
+	require.NoError(t, err)
+
+	// the next block deletes the first account and updates the storage of the second one
+	block, blockRoot, err := genesis.Commit([]*state.Object{
+		{Address: addr1, Deleted: true},
+		{
+			Address:  addr2,
+			Balance:  big.NewInt(3),
+			Root:     genesisAccount.Root,
+			CodeHash: types.EmptyCodeHash,
+			Storage: []*state.StorageObject{
+				{Key: slot1.Bytes(), Deleted: true},
+				{Key: slot2.Bytes(), Val: big.NewInt(30).Bytes()},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// the diff is applied right away
+	flat.Update(types.BytesToHash(blockRoot))
+	require.Equal(t, types.BytesToHash(blockRoot), flat.Root())
+
+	account, err := block.GetAccount(addr1)
+	require.NoError(t, err)
+	require.Nil(t, account)
+
+	account, err = block.GetAccount(addr2)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(3), account.Balance)
+
+	_, served := flat.account(types.BytesToHash(blockRoot), types.BytesToHash(hashit(addr2.Bytes())))
+	require.True(t, served)
+
+	require.Equal(t, types.Hash{}, block.GetStorage(addr2, account.Root, slot1))
+	require.Equal(t, types.BytesToHash(big.NewInt(30).Bytes()), block.GetStorage(addr2, account.Root, slot2))
+
+	// the states other than the held one are read from the trie
+	require.Equal(t, types.BytesToHash(big.NewInt(10).Bytes()), genesis.GetStorage(addr2, genesisAccount.Root, slot1))
+
+	// the reorg to the sibling block, which recreates the second account, regenerates the layer
+	sibling, siblingRoot, err := genesis.Commit([]*state.Object{
+		{Address: addr2, Deleted: true},
+	})
+	require.NoError(t, err)
+
+	recreated, recreatedRoot, err := sibling.Commit([]*state.Object{
+		{
+			Address:  addr2,
+			Balance:  big.NewInt(4),
+			Root:     types.EmptyRootHash,
+			CodeHash: types.EmptyCodeHash,
+			Storage: []*state.StorageObject{
+				{Key: slot2.Bytes(), Val: big.NewInt(40).Bytes()},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	flat.Update(types.BytesToHash(siblingRoot))
+	flat.Update(types.BytesToHash(recreatedRoot))
+	waitFlatRoot(t, flat, types.BytesToHash(recreatedRoot))
+
+	account, err = recreated.GetAccount(addr1)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1), account.Balance)
+
+	account, err = recreated.GetAccount(addr2)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(4), account.Balance)
+
+	// the slot of the previous incarnation of the account is gone
+	require.Equal(t, types.Hash{}, recreated.GetStorage(addr2, account.Root, slot1))
+	require.Equal(t, types.BytesToHash(big.NewInt(40).Bytes()), recreated.GetStorage(addr2, account.Root, slot2))
+}
+
+func TestFlatLayer_Reopen(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage().(PrunableStorage) //nolint:forcetypeassert
+
+	flat, err := NewFlatLayer(storage, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	st := NewState(storage)
+	st.SetFlatLayer(flat)
+
+	_, root, err := st.NewSnapshot().Commit([]*state.Object{
+		{Address: types.StringToAddress("1"), Balance: big.NewInt(1), Root: types.EmptyRootHash},
+	})
+	require.NoError(t, err)
+
+	flat.Update(types.BytesToHash(root))
+	waitFlatRoot(t, flat, types.BytesToHash(root))
+	flat.Close()
+
+	// the layer holds the same state once reopened
+	flat, err = NewFlatLayer(storage, hclog.NewNullLogger())
+	require.NoError(t, err)
+	require.Equal(t, types.BytesToHash(root), flat.Root())
+}
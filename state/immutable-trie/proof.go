@@ -0,0 +1,150 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/umbracle/fastrlp"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// ErrMissingProofNode is returned when a node referenced on the key path is not available
+	ErrMissingProofNode = errors.New("missing trie node")
+)
+
+// Prove returns the encoded trie nodes on the path from the root to the given key.
+// The proof of the absent key ends with the node where the path to the key diverges,
+// so it proves the absence of the key
+func Prove(root types.Hash, key []byte, storage Storage) ([][]byte, error) {
+	proof := [][]byte{}
+
+	_, err := walkProofPath(root, key, func(hash []byte) ([]byte, error) {
+		data, ok, err := storage.Get(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return nil, fmt.Errorf("%w: %x", ErrMissingProofNode, hash)
+		}
+
+		proof = append(proof, data)
+
+		return data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return proof, nil
+}
+
+// VerifyProof checks the proof of the given key against the root and returns the proven value.
+// A nil value is returned if the proof shows the key is not in the trie
+func VerifyProof(root types.Hash, key []byte, proof [][]byte) ([]byte, error) {
+	nodes := make(map[types.Hash][]byte, len(proof))
+	for _, node := range proof {
+		nodes[types.BytesToHash(crypto.Keccak256(node))] = node
+	}
+
+	return walkProofPath(root, key, func(hash []byte) ([]byte, error) {
+		data, ok := nodes[types.BytesToHash(hash)]
+		if !ok {
+			return nil, fmt.Errorf("%w: %x", ErrMissingProofNode, hash)
+		}
+
+		return data, nil
+	})
+}
+
+// walkProofPath follows the path of the key from the root, resolving the hashed nodes with the given function,
+// and returns the value stored under the key (or nil if there is none)
+func walkProofPath(root types.Hash, key []byte, resolve func(hash []byte) ([]byte, error)) ([]byte, error) {
+	if root == types.EmptyRootHash || root == types.ZeroHash {
+		return nil, nil
+	}
+
+	p := parserPool.Get()
+	defer parserPool.Put(p)
+
+	path := bytesToHexNibbles(key)
+	hash := root.Bytes()
+
+	for {
+		data, err := resolve(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := p.Parse(data)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			next  *fastrlp.Value
+			value []byte
+			found bool
+		)
+
+		// inline nodes are embedded in their parent, so the walk continues
+		// until the reference to the next hashed node is found
+		for next == nil {
+			if v.Type() != fastrlp.TypeArray {
+				return nil, fmt.Errorf("trie node should be an array")
+			}
+
+			switch v.Elems() {
+			case 2:
+				nodeKey := decodeCompact(v.Get(0).Raw())
+				if !bytes.HasPrefix(path, nodeKey) {
+					return nil, nil
+				}
+
+				path = path[len(nodeKey):]
+
+				if hasTerminator(nodeKey) {
+					value, found = v.Get(1).Raw(), true
+				} else {
+					next = v.Get(1)
+				}
+
+			case 17:
+				if path[0] == 16 {
+					value, found = v.Get(16).Raw(), true
+				} else {
+					next = v.Get(int(path[0]))
+					path = path[1:]
+				}
+
+			default:
+				return nil, fmt.Errorf("node has incorrect number of leafs")
+			}
+
+			if found {
+				if len(value) == 0 {
+					return nil, nil
+				}
+
+				return append([]byte{}, value...), nil
+			}
+
+			if next.Type() == fastrlp.TypeArray {
+				v, next = next, nil
+			}
+		}
+
+		switch len(next.Raw()) {
+		case 0:
+			return nil, nil
+		case types.HashLength:
+			hash = append(hash[:0:0], next.Raw()...)
+		default:
+			return nil, fmt.Errorf("invalid trie node reference")
+		}
+	}
+}
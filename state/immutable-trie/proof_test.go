@@ -0,0 +1,108 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func buildProofTestTrie(t *testing.T, entries map[string][]byte) (types.Hash, Storage) {
+	t.Helper()
+
+	storage := NewMemoryStorage()
+	batch := storage.Batch()
+
+	txn := NewTrie().Txn(storage)
+	txn.batch = batch
+
+	for k, v := range entries {
+		txn.Insert([]byte(k), v)
+	}
+
+	root, err := txn.Hash()
+	require.NoError(t, err)
+	require.NoError(t, batch.Write())
+
+	return types.BytesToHash(root), storage
+}
+
+func TestProof(t *testing.T) {
+	t.Parallel()
+
+	entries := map[string][]byte{}
+
+	// short values make small leaves which are embedded into their parents
+	for i := 0; i < 300; i++ {
+		key := crypto.Keccak256(big.NewInt(int64(i)).Bytes())
+
+		value := []byte{byte(i)}
+		if i%2 == 0 {
+			value = crypto.Keccak256(key)
+		}
+
+		entries[string(key)] = value
+	}
+
+	root, storage := buildProofTestTrie(t, entries)
+
+	for k, v := range entries {
+		proof, err := Prove(root, []byte(k), storage)
+		require.NoError(t, err)
+		require.NotEmpty(t, proof)
+
+		value, err := VerifyProof(root, []byte(k), proof)
+		require.NoError(t, err)
+		require.Equal(t, v, value)
+	}
+
+	t.Run("absent key", func(t *testing.T) {
+		t.Parallel()
+
+		key := crypto.Keccak256([]byte("absent"))
+
+		proof, err := Prove(root, key, storage)
+		require.NoError(t, err)
+		require.NotEmpty(t, proof)
+
+		value, err := VerifyProof(root, key, proof)
+		require.NoError(t, err)
+		require.Nil(t, value)
+	})
+
+	t.Run("tampered proof", func(t *testing.T) {
+		t.Parallel()
+
+		for k := range entries {
+			proof, err := Prove(root, []byte(k), storage)
+			require.NoError(t, err)
+
+			_, err = VerifyProof(root, []byte(k), proof[:len(proof)-1])
+			require.ErrorIs(t, err, ErrMissingProofNode)
+
+			break
+		}
+	})
+
+	t.Run("missing node in storage", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Prove(types.StringToHash("0x1"), []byte("key"), storage)
+		require.ErrorIs(t, err, ErrMissingProofNode)
+	})
+}
+
+func TestProof_EmptyTrie(t *testing.T) {
+	t.Parallel()
+
+	proof, err := Prove(types.EmptyRootHash, []byte("key"), NewMemoryStorage())
+	require.NoError(t, err)
+	require.Empty(t, proof)
+
+	value, err := VerifyProof(types.EmptyRootHash, []byte("key"), proof)
+	require.NoError(t, err)
+	require.Nil(t, value)
+}
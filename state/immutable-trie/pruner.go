@@ -0,0 +1,258 @@
+package itrie
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// sweepChunkSize is the number of keys deleted while holding the lock of the pruning storage
+const sweepChunkSize = 1024
+
+var errTrackingNotStarted = errors.New("tracking of the written nodes is not started")
+
+// PrunableStorage is the trie storage whose nodes can be deleted
+type PrunableStorage interface {
+	Storage
+	Delete(k []byte) error
+	ForEachKey(fn func(k []byte) error) error
+}
+
+// MarkReachable marks the nodes of the state tries with the given roots,
+// including the storage tries of their accounts.
+// The nodes already in marked are considered reachable along with their subtries
+func MarkReachable(storage Storage, roots []types.Hash, marked map[types.Hash]struct{}) error {
+	for _, root := range roots {
+		if root == types.EmptyRootHash {
+			continue
+		}
+
+		if err := markTrieHash(root.Bytes(), storage, marked, false); err != nil {
+			return fmt.Errorf("failed to mark state %s: %w", root, err)
+		}
+	}
+
+	return nil
+}
+
+func markTrieHash(nodeHash []byte, storage Storage, marked map[types.Hash]struct{}, isStorage bool) error {
+	hash := types.BytesToHash(nodeHash)
+	if _, ok := marked[hash]; ok {
+		return nil
+	}
+
+	node, ok, err := GetNode(nodeHash, storage)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return fmt.Errorf("trie node %s not found", hash)
+	}
+
+	marked[hash] = struct{}{}
+
+	return markTrieNode(node, storage, marked, isStorage)
+}
+
+func markTrieNode(node Node, storage Storage, marked map[types.Hash]struct{}, isStorage bool) error {
+	switch n := node.(type) {
+	case nil:
+		return nil
+	case *FullNode:
+		for _, child := range n.children {
+			if err := markTrieNode(child, storage, marked, isStorage); err != nil {
+				return err
+			}
+		}
+
+		if n.value != nil {
+			return markTrieNode(n.value, storage, marked, isStorage)
+		}
+	case *ShortNode:
+		return markTrieNode(n.child, storage, marked, isStorage)
+	case *ValueNode:
+		if n.hash {
+			return markTrieHash(n.buf, storage, marked, isStorage)
+		}
+
+		if isStorage {
+			return nil
+		}
+
+		var account state.Account
+		if err := account.UnmarshalRlp(n.buf); err != nil {
+			return fmt.Errorf("can't parse account: %w", err)
+		}
+
+		if account.Root != types.EmptyRootHash {
+			return markTrieHash(account.Root.Bytes(), storage, marked, true)
+		}
+	}
+
+	return nil
+}
+
+// Sweep deletes the trie nodes which are not marked.
+// The contract codes are never deleted, since they are shared between the states
+func Sweep(storage PrunableStorage, marked map[types.Hash]struct{}) (uint64, error) {
+	var deleted uint64
+
+	err := storage.ForEachKey(func(k []byte) error {
+		if len(k) != types.HashLength {
+			return nil
+		}
+
+		if _, ok := marked[types.BytesToHash(k)]; ok {
+			return nil
+		}
+
+		deleted++
+
+		return storage.Delete(k)
+	})
+
+	return deleted, err
+}
+
+// PruningStorage is the trie storage which prunes the nodes while the chain is running.
+// The nodes written since the tracking started are never pruned,
+// so the states committed while the reachable nodes are being marked stay intact
+type PruningStorage struct {
+	PrunableStorage
+
+	lock    sync.Mutex
+	written map[types.Hash]struct{}
+}
+
+// NewPruningStorage wraps the storage into the pruning storage
+func NewPruningStorage(storage PrunableStorage) *PruningStorage {
+	return &PruningStorage{PrunableStorage: storage}
+}
+
+func (p *PruningStorage) Put(k, v []byte) error {
+	p.track(k)
+
+	return p.PrunableStorage.Put(k, v)
+}
+
+func (p *PruningStorage) Batch() Batch {
+	return &pruningBatch{Batch: p.PrunableStorage.Batch(), storage: p}
+}
+
+// StartTracking starts recording the written nodes.
+// It has to be called before the state roots to retain are read,
+// so the nodes of the states which are not yet part of the chain are retained too
+func (p *PruningStorage) StartTracking() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.written == nil {
+		p.written = make(map[types.Hash]struct{})
+	}
+}
+
+// Prune deletes the nodes which are neither reachable from the given state roots
+// nor written since the tracking started, and stops the tracking
+func (p *PruningStorage) Prune(ctx context.Context, roots []types.Hash) (uint64, error) {
+	defer p.StopTracking()
+
+	p.lock.Lock()
+	tracking := p.written != nil
+	p.lock.Unlock()
+
+	if !tracking {
+		return 0, errTrackingNotStarted
+	}
+
+	marked := make(map[types.Hash]struct{})
+	if err := MarkReachable(p.PrunableStorage, roots, marked); err != nil {
+		return 0, err
+	}
+
+	var (
+		deleted uint64
+		chunk   = make([][]byte, 0, sweepChunkSize)
+	)
+
+	// deletes the chunk of the unmarked keys unless they were written meanwhile
+	flush := func() error {
+		p.lock.Lock()
+		defer p.lock.Unlock()
+
+		for _, k := range chunk {
+			if _, ok := p.written[types.BytesToHash(k)]; ok {
+				continue
+			}
+
+			if err := p.PrunableStorage.Delete(k); err != nil {
+				return err
+			}
+
+			deleted++
+		}
+
+		chunk = chunk[:0]
+
+		return nil
+	}
+
+	err := p.PrunableStorage.ForEachKey(func(k []byte) error {
+		if len(k) != types.HashLength {
+			return nil
+		}
+
+		if _, ok := marked[types.BytesToHash(k)]; ok {
+			return nil
+		}
+
+		chunk = append(chunk, k)
+		if len(chunk) < sweepChunkSize {
+			return nil
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		return flush()
+	})
+	if err != nil {
+		return deleted, err
+	}
+
+	return deleted, flush()
+}
+
+func (p *PruningStorage) track(k []byte) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.written != nil && len(k) == types.HashLength {
+		p.written[types.BytesToHash(k)] = struct{}{}
+	}
+}
+
+// StopTracking stops recording the written nodes
+func (p *PruningStorage) StopTracking() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.written = nil
+}
+
+// pruningBatch records the nodes written with the batch of the pruning storage
+type pruningBatch struct {
+	Batch
+
+	storage *PruningStorage
+}
+
+func (b *pruningBatch) Put(k, v []byte) {
+	b.storage.track(k)
+	b.Batch.Put(k, v)
+}
@@ -0,0 +1,120 @@
+package itrie
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// commitBalances commits the given balances and storage slot on top of the snapshot
+func commitBalances(t *testing.T, snap state.Snapshot, balance int64) (state.Snapshot, types.Hash) {
+	t.Helper()
+
+	objs := []*state.Object{
+		{
+			Address:  types.StringToAddress("1"),
+			Balance:  big.NewInt(balance),
+			Root:     types.EmptyRootHash,
+			CodeHash: types.EmptyCodeHash,
+		},
+		{
+			Address:  types.StringToAddress("2"),
+			Balance:  big.NewInt(balance),
+			Root:     types.EmptyRootHash,
+			CodeHash: types.EmptyCodeHash,
+			Storage: []*state.StorageObject{
+				{Key: types.StringToHash("1").Bytes(), Val: big.NewInt(balance).Bytes()},
+			},
+		},
+	}
+
+	newSnap, root, err := snap.Commit(objs)
+	require.NoError(t, err)
+
+	return newSnap, types.BytesToHash(root)
+}
+
+func TestPruningStorage_Prune(t *testing.T) {
+	t.Parallel()
+
+	storage := NewPruningStorage(NewMemoryStorage().(PrunableStorage)) //nolint:forcetypeassert
+	st := NewState(storage)
+
+	snap, oldRoot := commitBalances(t, st.NewSnapshot(), 1)
+	_, latestRoot := commitBalances(t, snap, 2)
+
+	// pruning requires the tracking to be started
+	_, err := storage.Prune(context.Background(), []types.Hash{latestRoot})
+	require.ErrorIs(t, err, errTrackingNotStarted)
+
+	storage.StartTracking()
+
+	// the node committed after the tracking started is retained
+	pendingNode := types.StringToHash("pending")
+	require.NoError(t, storage.Put(pendingNode.Bytes(), []byte{0x1}))
+
+	deleted, err := storage.Prune(context.Background(), []types.Hash{latestRoot})
+	require.NoError(t, err)
+	require.NotZero(t, deleted)
+
+	_, ok, err := storage.Get(oldRoot.Bytes())
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, ok, err = storage.Get(pendingNode.Bytes())
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// the latest state is intact
+	marked := make(map[types.Hash]struct{})
+	require.NoError(t, MarkReachable(storage, []types.Hash{latestRoot}, marked))
+
+	snap, err = NewState(storage).NewSnapshotAt(latestRoot)
+	require.NoError(t, err)
+
+	account, err := snap.GetAccount(types.StringToAddress("2"))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2), account.Balance)
+
+	// the tracking is stopped once pruned
+	_, err = storage.Prune(context.Background(), []types.Hash{latestRoot})
+	require.ErrorIs(t, err, errTrackingNotStarted)
+}
+
+func TestSweep(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage().(PrunableStorage) //nolint:forcetypeassert
+	st := NewState(storage)
+
+	snap, oldRoot := commitBalances(t, st.NewSnapshot(), 1)
+	_, latestRoot := commitBalances(t, snap, 2)
+
+	code := []byte{0x1, 0x2}
+	codeHash := types.StringToHash("code")
+	require.NoError(t, storage.SetCode(codeHash, code))
+
+	marked := make(map[types.Hash]struct{})
+	require.NoError(t, MarkReachable(storage, []types.Hash{latestRoot}, marked))
+
+	deleted, err := Sweep(storage, marked)
+	require.NoError(t, err)
+	require.NotZero(t, deleted)
+
+	// the old state is gone while the code is kept
+	require.Error(t, MarkReachable(storage, []types.Hash{oldRoot}, make(map[types.Hash]struct{})))
+
+	storedCode, ok := storage.GetCode(codeHash)
+	require.True(t, ok)
+	require.Equal(t, code, storedCode)
+
+	// nothing else to sweep
+	deleted, err = Sweep(storage, marked)
+	require.NoError(t, err)
+	require.Zero(t, deleted)
+}
@@ -13,29 +13,29 @@ import (
 type Snapshot struct {
 	state *State
 	trie  *Trie
+	root  types.Hash
 }
 
 var emptyStateHash = types.StringToHash("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
 
 func (s *Snapshot) GetStorage(addr types.Address, root types.Hash, rawkey types.Hash) types.Hash {
-	var (
-		err  error
-		trie *Trie
-	)
-
 	if root == emptyStateHash {
-		trie = s.state.newTrie()
-	} else {
-		trie, err = s.state.newTrieAt(root)
-		if err != nil {
-			return types.Hash{}
-		}
+		return types.Hash{}
 	}
 
 	key := crypto.Keccak256(rawkey.Bytes())
 
-	val, ok := trie.Get(key, s.state.storage)
+	val, ok := s.getFlatStorage(addr, key)
 	if !ok {
+		trie, err := s.state.newTrieAt(root)
+		if err != nil {
+			return types.Hash{}
+		}
+
+		val, ok = trie.Get(key, s.state.storage)
+	}
+
+	if !ok || len(val) == 0 {
 		return types.Hash{}
 	}
 
@@ -57,8 +57,12 @@ func (s *Snapshot) GetStorage(addr types.Address, root types.Hash, rawkey types.
 func (s *Snapshot) GetAccount(addr types.Address) (*state.Account, error) {
 	key := crypto.Keccak256(addr.Bytes())
 
-	data, ok := s.trie.Get(key, s.state.storage)
+	data, ok := s.getFlatAccount(key)
 	if !ok {
+		data, ok = s.trie.Get(key, s.state.storage)
+	}
+
+	if !ok || len(data) == 0 {
 		return nil, nil
 	}
 
@@ -83,10 +87,25 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte, error)
 	arena := stateArenaPool.Get()
 	defer stateArenaPool.Put(arena)
 
+	var diff *flatDiff
+	if s.state.flat != nil {
+		diff = newFlatDiff(s.root)
+	}
+
 	for _, obj := range objs {
+		accountHash := types.BytesToHash(hashit(obj.Address.Bytes()))
+
 		if obj.Deleted {
-			tt.Delete(hashit(obj.Address.Bytes()))
+			tt.Delete(accountHash.Bytes())
+
+			if diff != nil {
+				diff.accounts[accountHash] = nil
+				diff.wiped[accountHash] = struct{}{}
+			}
 		} else {
+			// the preimages of the hashed keys let the state be dumped by the addresses and the storage keys
+			batch.Put(preimageKey(accountHash), obj.Address.Bytes())
+
 			account := state.Account{
 				Balance:  obj.Balance,
 				Nonce:    obj.Nonce,
@@ -103,13 +122,35 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte, error)
 				localTxn := trie.Txn(s.state.storage)
 				localTxn.batch = batch
 
+				var slots map[types.Hash][]byte
+				if diff != nil {
+					slots = make(map[types.Hash][]byte, len(obj.Storage))
+					diff.storage[accountHash] = slots
+
+					if obj.Root == types.EmptyRootHash {
+						// the account is created (or recreated), so its previous storage is discarded
+						diff.wiped[accountHash] = struct{}{}
+					}
+				}
+
 				for _, entry := range obj.Storage {
 					k := hashit(entry.Key)
 					if entry.Deleted {
 						localTxn.Delete(k)
+
+						if slots != nil {
+							slots[types.BytesToHash(k)] = []byte{}
+						}
 					} else {
+						batch.Put(preimageKey(types.BytesToHash(k)), entry.Key)
+
 						vv := arena.NewBytes(bytes.TrimLeft(entry.Val, "\x00"))
-						localTxn.Insert(k, vv.MarshalTo(nil))
+						val := vv.MarshalTo(nil)
+						localTxn.Insert(k, val)
+
+						if slots != nil {
+							slots[types.BytesToHash(k)] = val
+						}
 					}
 				}
 
@@ -129,8 +170,12 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte, error)
 			vv := account.MarshalWith(arena)
 			data := vv.MarshalTo(nil)
 
-			tt.Insert(hashit(obj.Address.Bytes()), data)
+			tt.Insert(accountHash.Bytes(), data)
 			arena.Reset()
+
+			if diff != nil {
+				diff.accounts[accountHash] = data
+			}
 		}
 	}
 
@@ -148,5 +193,28 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte, error)
 
 	s.state.AddState(types.BytesToHash(root), nTrie)
 
-	return &Snapshot{trie: nTrie, state: s.state}, root, nil
+	if diff != nil {
+		s.state.flat.recordDiff(types.BytesToHash(root), diff)
+	}
+
+	return &Snapshot{trie: nTrie, state: s.state, root: types.BytesToHash(root)}, root, nil
+}
+
+// getFlatAccount returns the encoded account from the flat layer, if the layer holds the state of the snapshot
+func (s *Snapshot) getFlatAccount(accountHash []byte) ([]byte, bool) {
+	if s.state.flat == nil {
+		return nil, false
+	}
+
+	return s.state.flat.account(s.root, types.BytesToHash(accountHash))
+}
+
+// getFlatStorage returns the encoded storage slot from the flat layer,
+// if the layer holds the state of the snapshot
+func (s *Snapshot) getFlatStorage(addr types.Address, slotHash []byte) ([]byte, bool) {
+	if s.state.flat == nil {
+		return nil, false
+	}
+
+	return s.state.flat.slot(s.root, types.BytesToHash(hashit(addr.Bytes())), types.BytesToHash(slotHash))
 }
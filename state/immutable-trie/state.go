@@ -12,6 +12,9 @@ import (
 type State struct {
 	storage Storage
 	cache   *lru.Cache
+
+	// flat serves the reads of the head state, nil if disabled
+	flat *FlatLayer
 }
 
 func NewState(storage Storage) *State {
@@ -25,8 +28,13 @@ func NewState(storage Storage) *State {
 	return s
 }
 
+// SetFlatLayer sets the flat layer which serves the reads of the state it holds
+func (s *State) SetFlatLayer(flat *FlatLayer) {
+	s.flat = flat
+}
+
 func (s *State) NewSnapshot() state.Snapshot {
-	return &Snapshot{state: s, trie: s.newTrie()}
+	return &Snapshot{state: s, trie: s.newTrie(), root: types.EmptyRootHash}
 }
 
 func (s *State) NewSnapshotAt(root types.Hash) (state.Snapshot, error) {
@@ -35,7 +43,7 @@ func (s *State) NewSnapshotAt(root types.Hash) (state.Snapshot, error) {
 		return nil, err
 	}
 
-	return &Snapshot{state: s, trie: t}, nil
+	return &Snapshot{state: s, trie: t, root: root}, nil
 }
 
 func (s *State) newTrie() *Trie {
@@ -93,6 +93,27 @@ func (kv *KVStorage) Get(k []byte) ([]byte, bool, error) {
 	return data, true, nil
 }
 
+func (kv *KVStorage) Delete(k []byte) error {
+	return kv.db.Delete(k, nil)
+}
+
+// ForEachKey iterates over all keys in the storage, the keys passed to fn are safe copies
+func (kv *KVStorage) ForEachKey(fn func(k []byte) error) error {
+	iter := kv.db.NewIterator(nil, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		key := make([]byte, len(iter.Key()))
+		copy(key, iter.Key())
+
+		if err := fn(key); err != nil {
+			return err
+		}
+	}
+
+	return iter.Error()
+}
+
 func (kv *KVStorage) Close() error {
 	return kv.db.Close()
 }
@@ -162,6 +183,44 @@ func (m *memStorage) Batch() Batch {
 	return &memBatch{db: &m.db, l: new(sync.Mutex)}
 }
 
+func (m *memStorage) Delete(p []byte) error {
+	m.l.Lock()
+	defer m.l.Unlock()
+
+	delete(m.db, hex.EncodeToHex(p))
+
+	return nil
+}
+
+// ForEachKey iterates over all keys in the storage,
+// the keys are collected beforehand so fn is free to modify the storage
+func (m *memStorage) ForEachKey(fn func(k []byte) error) error {
+	m.l.Lock()
+
+	keys := make([][]byte, 0, len(m.db))
+
+	for k := range m.db {
+		key, err := hex.DecodeHex(k)
+		if err != nil {
+			m.l.Unlock()
+
+			return err
+		}
+
+		keys = append(keys, key)
+	}
+
+	m.l.Unlock()
+
+	for _, key := range keys {
+		if err := fn(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *memStorage) Close() error {
 	return nil
 }
@@ -0,0 +1,290 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/umbracle/fastrlp"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrSyncNotRequested = errors.New("trie node is not requested")
+	ErrSyncHashMismatch = errors.New("trie node does not match its hash")
+)
+
+// syncRequest is the trie node or the contract code being synced
+type syncRequest struct {
+	hash      types.Hash
+	code      bool
+	isStorage bool
+	data      []byte
+
+	// deps is the number of the children which are not stored yet
+	deps    int
+	parents []*syncRequest
+}
+
+// StateSync downloads the state with the given root from the peers, node by node.
+// The data delivered for a requested hash is verified against it, so the whole state is verified against the root.
+// A node is stored only after all of its children are stored, so a stored node always has a complete subtrie,
+// which lets the interrupted sync be resumed by starting a new sync of the same root
+type StateSync struct {
+	storage Storage
+
+	// requests are the nodes being synced by their hashes, either not fetched yet or waiting for their children
+	requests map[types.Hash]*syncRequest
+	// queue are the hashes to be fetched, it is used as a stack so the tries are synced depth first
+	queue []types.Hash
+	// inflight are the hashes returned by Missing which are not processed yet
+	inflight map[types.Hash]struct{}
+	// completed are the nodes whose subtries are complete, which are written on Commit
+	completed map[types.Hash]*syncRequest
+
+	synced uint64
+}
+
+// NewStateSync creates the sync of the state with the given root into the storage
+func NewStateSync(root types.Hash, storage Storage) (*StateSync, error) {
+	s := &StateSync{
+		storage:   storage,
+		requests:  make(map[types.Hash]*syncRequest),
+		inflight:  make(map[types.Hash]struct{}),
+		completed: make(map[types.Hash]*syncRequest),
+	}
+
+	if root == types.EmptyRootHash || root == types.ZeroHash {
+		return s, nil
+	}
+
+	if err := s.schedule(&syncRequest{hash: root}, nil); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Missing returns up to max hashes whose data has to be fetched from the peers
+func (s *StateSync) Missing(max int) []types.Hash {
+	hashes := make([]types.Hash, 0, max)
+
+	for len(s.queue) > 0 && len(hashes) < max {
+		hash := s.queue[len(s.queue)-1]
+		s.queue = s.queue[:len(s.queue)-1]
+
+		s.inflight[hash] = struct{}{}
+		hashes = append(hashes, hash)
+	}
+
+	return hashes
+}
+
+// Process handles the data fetched for the hash returned by Missing.
+// The empty data means the peer didn't deliver it, and the hash is fetched again
+func (s *StateSync) Process(hash types.Hash, data []byte) error {
+	if _, ok := s.inflight[hash]; !ok {
+		return fmt.Errorf("%w: %s", ErrSyncNotRequested, hash)
+	}
+
+	delete(s.inflight, hash)
+
+	if len(data) == 0 {
+		s.queue = append(s.queue, hash)
+
+		return nil
+	}
+
+	if !bytes.Equal(crypto.Keccak256(data), hash.Bytes()) {
+		s.queue = append(s.queue, hash)
+
+		return fmt.Errorf("%w: %s", ErrSyncHashMismatch, hash)
+	}
+
+	req := s.requests[hash]
+	req.data = data
+
+	children, err := syncChildren(req)
+	if err != nil {
+		return fmt.Errorf("failed to decode trie node %s: %w", hash, err)
+	}
+
+	for _, child := range children {
+		if err := s.schedule(child, req); err != nil {
+			return err
+		}
+	}
+
+	if req.deps == 0 {
+		s.complete(req)
+	}
+
+	return nil
+}
+
+// Commit writes the nodes whose subtries are complete to the storage
+func (s *StateSync) Commit() error {
+	if len(s.completed) == 0 {
+		return nil
+	}
+
+	batch := s.storage.Batch()
+
+	for hash, req := range s.completed {
+		if req.code {
+			batch.Put(GetCodeKey(hash), req.data)
+		} else {
+			batch.Put(hash.Bytes(), req.data)
+		}
+	}
+
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	s.synced += uint64(len(s.completed))
+	s.completed = make(map[types.Hash]*syncRequest)
+
+	return nil
+}
+
+// Pending returns the number of the nodes which are not synced yet, the sync is done once it is zero
+func (s *StateSync) Pending() int {
+	return len(s.requests)
+}
+
+// Synced returns the number of the nodes and the codes written by the sync
+func (s *StateSync) Synced() uint64 {
+	return s.synced
+}
+
+// schedule requests the node unless it is already stored or being synced
+func (s *StateSync) schedule(req *syncRequest, parent *syncRequest) error {
+	if existing, ok := s.requests[req.hash]; ok {
+		if parent != nil {
+			existing.parents = append(existing.parents, parent)
+			parent.deps++
+		}
+
+		return nil
+	}
+
+	if _, ok := s.completed[req.hash]; ok {
+		return nil
+	}
+
+	stored, err := s.isStored(req)
+	if err != nil || stored {
+		return err
+	}
+
+	if parent != nil {
+		req.parents = append(req.parents, parent)
+		parent.deps++
+	}
+
+	s.requests[req.hash] = req
+	s.queue = append(s.queue, req.hash)
+
+	return nil
+}
+
+// complete moves the node to the completed ones, along with its parents waiting for it only
+func (s *StateSync) complete(req *syncRequest) {
+	delete(s.requests, req.hash)
+	s.completed[req.hash] = req
+
+	for _, parent := range req.parents {
+		parent.deps--
+
+		if parent.deps == 0 {
+			s.complete(parent)
+		}
+	}
+}
+
+func (s *StateSync) isStored(req *syncRequest) (bool, error) {
+	if req.code {
+		_, ok := s.storage.GetCode(req.hash)
+
+		return ok, nil
+	}
+
+	_, ok, err := s.storage.Get(req.hash.Bytes())
+
+	return ok, err
+}
+
+// syncChildren returns the nodes referenced by the fetched node,
+// including the storage tries and the codes of the accounts held by the state trie
+func syncChildren(req *syncRequest) ([]*syncRequest, error) {
+	if req.code {
+		return nil, nil
+	}
+
+	p := parserPool.Get()
+	defer parserPool.Put(p)
+
+	v, err := p.Parse(req.data)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.Type() != fastrlp.TypeArray {
+		return nil, fmt.Errorf("storage item should be an array")
+	}
+
+	node, err := decodeNode(v, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return appendSyncChildren(nil, node, req.isStorage)
+}
+
+func appendSyncChildren(children []*syncRequest, node Node, isStorage bool) ([]*syncRequest, error) {
+	var err error
+
+	switch n := node.(type) {
+	case nil:
+		return children, nil
+	case *FullNode:
+		for _, child := range n.children {
+			if children, err = appendSyncChildren(children, child, isStorage); err != nil {
+				return nil, err
+			}
+		}
+
+		if n.value != nil {
+			return appendSyncChildren(children, n.value, isStorage)
+		}
+	case *ShortNode:
+		return appendSyncChildren(children, n.child, isStorage)
+	case *ValueNode:
+		if n.hash {
+			return append(children, &syncRequest{hash: types.BytesToHash(n.buf), isStorage: isStorage}), nil
+		}
+
+		if isStorage {
+			return children, nil
+		}
+
+		var account state.Account
+		if err := account.UnmarshalRlp(n.buf); err != nil {
+			return nil, fmt.Errorf("can't parse account: %w", err)
+		}
+
+		if account.Root != types.EmptyRootHash {
+			children = append(children, &syncRequest{hash: account.Root, isStorage: true})
+		}
+
+		if len(account.CodeHash) > 0 && !bytes.Equal(account.CodeHash, emptyCodeHash) {
+			children = append(children, &syncRequest{hash: types.BytesToHash(account.CodeHash), code: true})
+		}
+	}
+
+	return children, nil
+}
@@ -0,0 +1,156 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// commitSyncState commits the accounts with the storage slots and the codes to the storage
+func commitSyncState(t *testing.T, storage Storage) types.Hash {
+	t.Helper()
+
+	code := []byte{0x60, 0x00, 0x60, 0x00}
+	objs := make([]*state.Object, 0, 64)
+
+	for i := 0; i < 64; i++ {
+		obj := &state.Object{
+			Address:  types.BytesToAddress(big.NewInt(int64(i + 1)).Bytes()),
+			Balance:  big.NewInt(int64(i)),
+			Root:     types.EmptyRootHash,
+			CodeHash: types.EmptyCodeHash,
+		}
+
+		if i%4 == 0 {
+			obj.Code = code
+			obj.CodeHash = types.BytesToHash(crypto.Keccak256(code))
+			obj.DirtyCode = true
+
+			for j := 0; j < 8; j++ {
+				obj.Storage = append(obj.Storage, &state.StorageObject{
+					Key: types.BytesToHash(big.NewInt(int64(j + 1)).Bytes()).Bytes(),
+					Val: big.NewInt(int64(i + j + 1)).Bytes(),
+				})
+			}
+		}
+
+		objs = append(objs, obj)
+	}
+
+	_, root, err := NewState(storage).NewSnapshot().Commit(objs)
+	require.NoError(t, err)
+
+	return types.BytesToHash(root)
+}
+
+// runStateSync serves the sync from the source storage, stopping after the given number of the rounds
+func runStateSync(t *testing.T, sync *StateSync, source Storage, rounds int) {
+	t.Helper()
+
+	for i := 0; i < rounds && sync.Pending() > 0; i++ {
+		for _, hash := range sync.Missing(16) {
+			data, ok, err := source.Get(hash.Bytes())
+			require.NoError(t, err)
+
+			if !ok {
+				data, _ = source.GetCode(hash)
+			}
+
+			require.NoError(t, sync.Process(hash, data))
+		}
+
+		require.NoError(t, sync.Commit())
+	}
+}
+
+func TestStateSync(t *testing.T) {
+	t.Parallel()
+
+	source := NewMemoryStorage()
+	root := commitSyncState(t, source)
+
+	storage := NewMemoryStorage()
+
+	sync, err := NewStateSync(root, storage)
+	require.NoError(t, err)
+
+	runStateSync(t, sync, source, 1000)
+	require.Zero(t, sync.Pending())
+	require.NotZero(t, sync.Synced())
+
+	checkedRoot, err := HashChecker(root.Bytes(), storage)
+	require.NoError(t, err)
+	require.Equal(t, root, checkedRoot)
+
+	snap, err := NewState(storage).NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	account, err := snap.GetAccount(types.BytesToAddress(big.NewInt(5).Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(4), account.Balance)
+
+	code, ok := snap.GetCode(types.BytesToHash(account.CodeHash))
+	require.True(t, ok)
+	require.Equal(t, []byte{0x60, 0x00, 0x60, 0x00}, code)
+
+	// the stored state isn't synced again
+	sync, err = NewStateSync(root, storage)
+	require.NoError(t, err)
+	require.Zero(t, sync.Pending())
+}
+
+func TestStateSync_Resume(t *testing.T) {
+	t.Parallel()
+
+	source := NewMemoryStorage()
+	root := commitSyncState(t, source)
+
+	storage := NewMemoryStorage()
+
+	sync, err := NewStateSync(root, storage)
+	require.NoError(t, err)
+
+	runStateSync(t, sync, source, 3)
+	require.NotZero(t, sync.Pending())
+
+	// the interrupted sync is resumed by the new one, which skips the complete subtries
+	synced := sync.Synced()
+
+	sync, err = NewStateSync(root, storage)
+	require.NoError(t, err)
+
+	runStateSync(t, sync, source, 1000)
+	require.Zero(t, sync.Pending())
+	require.Positive(t, synced)
+
+	checkedRoot, err := HashChecker(root.Bytes(), storage)
+	require.NoError(t, err)
+	require.Equal(t, root, checkedRoot)
+}
+
+func TestStateSync_InvalidData(t *testing.T) {
+	t.Parallel()
+
+	source := NewMemoryStorage()
+	root := commitSyncState(t, source)
+
+	sync, err := NewStateSync(root, NewMemoryStorage())
+	require.NoError(t, err)
+
+	require.ErrorIs(t, sync.Process(root, []byte{0x1}), ErrSyncNotRequested)
+
+	hashes := sync.Missing(16)
+	require.Equal(t, []types.Hash{root}, hashes)
+
+	require.ErrorIs(t, sync.Process(root, []byte{0xc0}), ErrSyncHashMismatch)
+
+	// the hash is fetched again, also when it isn't delivered
+	require.Equal(t, []types.Hash{root}, sync.Missing(16))
+	require.NoError(t, sync.Process(root, nil))
+	require.Equal(t, []types.Hash{root}, sync.Missing(16))
+}
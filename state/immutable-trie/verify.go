@@ -0,0 +1,205 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// maxHealNodesPerFetch is the number of the trie nodes fetched at once while healing the state
+const maxHealNodesPerFetch = 512
+
+var ErrHealNotServed = errors.New("trie nodes not served")
+
+// TrieFault is the trie node or the contract code which is missing or doesn't match its hash
+type TrieFault struct {
+	Hash    types.Hash `json:"hash"`
+	Code    bool       `json:"code"`
+	Corrupt bool       `json:"corrupt"`
+}
+
+// StateVerification walks the state trie along with the storage tries and the codes of its accounts,
+// verifying every stored node against its hash. The subtries below the faulty nodes are not walked
+// until the nodes are healed
+type StateVerification struct {
+	storage Storage
+
+	// Nodes and Codes are the numbers of the verified trie nodes and contract codes
+	Nodes uint64
+	Codes uint64
+	// Healed is the number of the faulty nodes and codes replaced by Heal
+	Healed uint64
+	// Faults are the nodes and the codes which are missing or corrupt
+	Faults []TrieFault
+
+	// codes are the verified codes, faults are the requests of Faults
+	codes  map[types.Hash]struct{}
+	faults []*syncRequest
+}
+
+// VerifyState verifies the state with the given root held by the storage
+func VerifyState(root types.Hash, storage Storage) (*StateVerification, error) {
+	v := &StateVerification{
+		storage: storage,
+		codes:   make(map[types.Hash]struct{}),
+	}
+
+	if root == types.EmptyRootHash || root == types.ZeroHash {
+		return v, nil
+	}
+
+	if err := v.walk([]*syncRequest{{hash: root}}); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// Heal replaces the faulty nodes and codes with the ones fetched by their hashes, and verifies their subtries,
+// until the state is complete. The fetched data is verified against the hashes, the empty data is not served
+func (v *StateVerification) Heal(fetch func([]types.Hash) ([][]byte, error)) error {
+	for len(v.faults) > 0 {
+		faults, corrupt := v.faults, v.Faults
+		healed := make(map[types.Hash]struct{}, len(faults))
+		subtries := make([]*syncRequest, 0, len(faults))
+
+		for start := 0; start < len(faults); start += maxHealNodesPerFetch {
+			batch := faults[start:]
+			if len(batch) > maxHealNodesPerFetch {
+				batch = batch[:maxHealNodesPerFetch]
+			}
+
+			hashes := make([]types.Hash, len(batch))
+			for i, req := range batch {
+				hashes[i] = req.hash
+			}
+
+			data, err := fetch(hashes)
+			if err != nil {
+				return err
+			}
+
+			if len(data) != len(hashes) {
+				return fmt.Errorf("%d trie nodes returned, while %d were requested", len(data), len(hashes))
+			}
+
+			writer := v.storage.Batch()
+
+			for i, req := range batch {
+				if len(data[i]) == 0 || !bytes.Equal(crypto.Keccak256(data[i]), req.hash.Bytes()) {
+					continue
+				}
+
+				healed[req.hash] = struct{}{}
+
+				if req.code {
+					writer.Put(GetCodeKey(req.hash), data[i])
+					v.Codes++
+
+					continue
+				}
+
+				writer.Put(req.hash.Bytes(), data[i])
+				subtries = append(subtries, &syncRequest{hash: req.hash, isStorage: req.isStorage})
+			}
+
+			if err := writer.Write(); err != nil {
+				return err
+			}
+		}
+
+		if len(healed) == 0 {
+			return fmt.Errorf("%w: %d nodes are still missing or corrupt", ErrHealNotServed, len(faults))
+		}
+
+		v.Healed += uint64(len(healed))
+
+		// the faults which weren't healed are kept, and the subtries of the healed nodes are verified
+		v.faults, v.Faults = nil, nil
+
+		for i, req := range faults {
+			if _, ok := healed[req.hash]; !ok {
+				v.addFault(req, corrupt[i].Corrupt)
+			}
+		}
+
+		if err := v.walk(subtries); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// walk verifies the subtries of the given nodes depth first
+func (v *StateVerification) walk(stack []*syncRequest) error {
+	for len(stack) > 0 {
+		req := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if req.code {
+			v.verifyCode(req)
+
+			continue
+		}
+
+		data, ok, err := v.storage.Get(req.hash.Bytes())
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			v.addFault(req, false)
+
+			continue
+		}
+
+		if !bytes.Equal(crypto.Keccak256(data), req.hash.Bytes()) {
+			v.addFault(req, true)
+
+			continue
+		}
+
+		req.data = data
+
+		// the node matching its hash can't be healed, so it is not reported as the fault
+		children, err := syncChildren(req)
+		if err != nil {
+			return fmt.Errorf("failed to decode trie node %s: %w", req.hash, err)
+		}
+
+		v.Nodes++
+
+		stack = append(stack, children...)
+	}
+
+	return nil
+}
+
+// verifyCode verifies the contract code, which is shared by the accounts so it is verified once
+func (v *StateVerification) verifyCode(req *syncRequest) {
+	if _, ok := v.codes[req.hash]; ok {
+		return
+	}
+
+	v.codes[req.hash] = struct{}{}
+
+	code, ok := v.storage.GetCode(req.hash)
+
+	switch {
+	case !ok:
+		v.addFault(req, false)
+	case !bytes.Equal(crypto.Keccak256(code), req.hash.Bytes()):
+		v.addFault(req, true)
+	default:
+		v.Codes++
+	}
+}
+
+func (v *StateVerification) addFault(req *syncRequest, corrupt bool) {
+	v.faults = append(v.faults, req)
+	v.Faults = append(v.Faults, TrieFault{Hash: req.hash, Code: req.code, Corrupt: corrupt})
+}
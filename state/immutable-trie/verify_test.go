@@ -0,0 +1,103 @@
+package itrie
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// fetchFrom serves the trie nodes and the codes of the healthy storage
+func fetchFrom(source Storage) func([]types.Hash) ([][]byte, error) {
+	return func(hashes []types.Hash) ([][]byte, error) {
+		data := make([][]byte, len(hashes))
+
+		for i, hash := range hashes {
+			var ok bool
+
+			if data[i], ok, _ = source.Get(hash.Bytes()); !ok {
+				data[i], _ = source.GetCode(hash)
+			}
+		}
+
+		return data, nil
+	}
+}
+
+func TestVerifyState(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	root := commitSyncState(t, storage)
+
+	v, err := VerifyState(root, storage)
+	require.NoError(t, err)
+	require.Empty(t, v.Faults)
+	require.NotZero(t, v.Nodes)
+	require.Equal(t, uint64(1), v.Codes)
+
+	v, err = VerifyState(types.EmptyRootHash, storage)
+	require.NoError(t, err)
+	require.Empty(t, v.Faults)
+	require.Zero(t, v.Nodes)
+}
+
+func TestVerifyState_Heal(t *testing.T) {
+	t.Parallel()
+
+	source := NewMemoryStorage()
+	root := commitSyncState(t, source)
+
+	storage := NewMemoryStorage()
+	require.Equal(t, root, commitSyncState(t, storage))
+
+	prunable, ok := storage.(PrunableStorage)
+	require.True(t, ok)
+
+	// the nodes and the code lost or damaged by the disk incident
+	var keys [][]byte
+
+	require.NoError(t, prunable.ForEachKey(func(k []byte) error {
+		keys = append(keys, k)
+
+		return nil
+	}))
+
+	var deleted int
+
+	for _, key := range keys {
+		if bytes.HasPrefix(key, codePrefix) || (deleted < 4 && !bytes.Equal(key, root.Bytes())) {
+			require.NoError(t, prunable.Delete(key))
+
+			deleted++
+		}
+	}
+
+	require.NoError(t, storage.Put(root.Bytes(), []byte{0xc0}))
+
+	// the subtries below the corrupt root are not walked until it is healed
+	v, err := VerifyState(root, storage)
+	require.NoError(t, err)
+	require.Equal(t, []TrieFault{{Hash: root, Corrupt: true}}, v.Faults)
+	require.Zero(t, v.Nodes)
+
+	// the peer not serving the nodes doesn't heal the state
+	err = v.Heal(func(hashes []types.Hash) ([][]byte, error) {
+		return make([][]byte, len(hashes)), nil
+	})
+	require.ErrorIs(t, err, ErrHealNotServed)
+
+	require.NoError(t, v.Heal(fetchFrom(source)))
+	require.Empty(t, v.Faults)
+	require.Positive(t, v.Healed)
+
+	v, err = VerifyState(root, storage)
+	require.NoError(t, err)
+	require.Empty(t, v.Faults)
+
+	checkedRoot, err := HashChecker(root.Bytes(), storage)
+	require.NoError(t, err)
+	require.Equal(t, root, checkedRoot)
+}
@@ -0,0 +1,167 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var _ runtime.SupplyManager = (*Transition)(nil)
+
+// ErrNativeSupplyChanged is returned when the total supply of the native token changes
+// other than through the supply hook
+var ErrNativeSupplyChanged = errors.New("native token supply changed outside of the supply hook")
+
+// nativeSupply tracks the supply of the native token in the blocks in which the supply hook is active
+type nativeSupply struct {
+	// hooks are the addresses of the active supply hooks
+	hooks map[types.Address]struct{}
+
+	// initial is the net amount minted by the hooks as of the parent state of the block
+	initial *big.Int
+}
+
+// enableSupplyHook enables the supply hook, so that the supply of the block is checked on commit
+func (t *Transition) enableSupplyHook(hook types.Address) {
+	if t.supply == nil {
+		t.supply = &nativeSupply{
+			hooks:   make(map[types.Address]struct{}),
+			initial: big.NewInt(0),
+		}
+	}
+
+	t.supply.hooks[hook] = struct{}{}
+	t.supply.initial.Add(t.supply.initial, t.hookSupply(hook))
+}
+
+// hookSupply returns the net amount of the native token minted by the hook
+func (t *Transition) hookSupply(hook types.Address) *big.Int {
+	minted := new(big.Int).SetBytes(t.state.GetState(hook, stateful.MintedSupplySlot).Bytes())
+	burned := new(big.Int).SetBytes(t.state.GetState(hook, stateful.BurnedSupplySlot).Bytes())
+
+	return minted.Sub(minted, burned)
+}
+
+// addHookSupply adds the amount to the total kept in the slot of the hook state
+func (t *Transition) addHookSupply(hook types.Address, slot types.Hash, amount *big.Int) {
+	total := new(big.Int).SetBytes(t.state.GetState(hook, slot).Bytes())
+
+	t.state.SetState(hook, slot, types.BytesToHash(total.Add(total, amount).Bytes()))
+}
+
+// MintNative mints the amount of the native token to the account on behalf of the supply hook
+func (t *Transition) MintNative(hook types.Address, to types.Address, amount *big.Int) error {
+	if _, ok := t.supplyHooks()[hook]; !ok {
+		return stateful.ErrSupplyNotManaged
+	}
+
+	if amount.Sign() < 0 {
+		return runtime.ErrInvalidInputData
+	}
+
+	t.state.AddBalance(to, amount)
+	t.addHookSupply(hook, stateful.MintedSupplySlot, amount)
+
+	return nil
+}
+
+// BurnNative burns the amount of the native token from the account on behalf of the supply hook
+func (t *Transition) BurnNative(hook types.Address, from types.Address, amount *big.Int) error {
+	if _, ok := t.supplyHooks()[hook]; !ok {
+		return stateful.ErrSupplyNotManaged
+	}
+
+	if amount.Sign() < 0 {
+		return runtime.ErrInvalidInputData
+	}
+
+	if err := t.state.SubBalance(from, amount); err != nil {
+		if errors.Is(err, runtime.ErrNotEnoughFunds) {
+			return runtime.ErrInsufficientBalance
+		}
+
+		return err
+	}
+
+	t.addHookSupply(hook, stateful.BurnedSupplySlot, amount)
+
+	return nil
+}
+
+func (t *Transition) supplyHooks() map[types.Address]struct{} {
+	if t.supply == nil {
+		return nil
+	}
+
+	return t.supply.hooks
+}
+
+// trackDestroyedSupply records the balance received by the self-destructed accounts before they are deleted
+func (t *Transition) trackDestroyedSupply() {
+	if t.supply == nil {
+		return
+	}
+
+	destroyed := big.NewInt(0)
+
+	t.state.txn.Root().Walk(func(k []byte, v interface{}) bool {
+		if obj, ok := v.(*StateObject); ok && obj.Suicide && !obj.Deleted {
+			destroyed.Add(destroyed, obj.Account.Balance)
+		}
+
+		return false
+	})
+
+	if destroyed.Sign() > 0 {
+		t.state.AddDestroyed(destroyed)
+	}
+}
+
+// expectedSupplyChange returns the amount the total supply is expected to change by in the block,
+// which is the net amount minted by the supply hooks less the balance destroyed by self-destructs
+func (t *Transition) expectedSupplyChange() *big.Int {
+	if t.supply == nil {
+		return nil
+	}
+
+	expected := new(big.Int).Neg(t.supply.initial)
+	for hook := range t.supply.hooks {
+		expected.Add(expected, t.hookSupply(hook))
+	}
+
+	return expected.Sub(expected, t.state.GetDestroyed())
+}
+
+// checkNativeSupply checks that the total balance of the committed accounts changed by the expected amount
+func (t *Transition) checkNativeSupply(objs []*Object, expected *big.Int) error {
+	if expected == nil {
+		return nil
+	}
+
+	actual := big.NewInt(0)
+
+	for _, obj := range objs {
+		if !obj.Deleted {
+			actual.Add(actual, obj.Balance)
+		}
+
+		account, err := t.state.snapshot.GetAccount(obj.Address)
+		if err != nil {
+			return fmt.Errorf("failed to get account %s: %w", obj.Address, err)
+		}
+
+		if account != nil {
+			actual.Sub(actual, account.Balance)
+		}
+	}
+
+	if actual.Cmp(expected) != 0 {
+		return fmt.Errorf("%w: changed by %s, expected %s", ErrNativeSupplyChanged, actual, expected)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful/nativesupply"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestTransition_NativeSupply(t *testing.T) {
+	t.Parallel()
+
+	var (
+		manager  = types.StringToAddress("0xa0")
+		hook     = types.StringToAddress("0xb0")
+		receiver = types.StringToAddress("0xc0")
+	)
+
+	contract, err := nativesupply.Factory(json.RawMessage(fmt.Sprintf(`{"manager": "%s"}`, manager)))
+	require.NoError(t, err)
+
+	forks := chain.AllForksEnabled.Copy()
+	forks.SetFork(nativesupply.Name, chain.NewFork(0))
+
+	executor := &Executor{
+		logger: hclog.NewNullLogger(),
+		config: &chain.Params{Forks: forks},
+		StatefulPrecompiles: []*stateful.Precompile{
+			{Name: nativesupply.Name, Address: hook, Fork: nativesupply.Name, Contract: contract},
+		},
+	}
+
+	newTransition := func() *Transition {
+		snap := &mockSnapshot{state: map[types.Address]*PreState{
+			manager:  {Balance: 100},
+			hook:     {},
+			receiver: {},
+		}}
+
+		tr := NewTransition(forks.At(1), snap, newTxn(snap))
+		tr.logger = hclog.NewNullLogger()
+		tr.ctx = runtime.TxContext{BaseFee: big.NewInt(0), GasLimit: 10_000_000, ChainID: 1, Number: 1}
+		tr.getHash = func(uint64) types.Hash { return types.ZeroHash }
+
+		executor.enableStatefulPrecompiles(tr, 1)
+
+		return tr
+	}
+
+	call := func(tr *Transition, from types.Address, input []byte, err error) *runtime.ExecutionResult {
+		t.Helper()
+
+		result := tr.Call2(from, hook, input, big.NewInt(0), 100_000)
+		require.ErrorIs(t, result.Err, err)
+
+		return result
+	}
+
+	mintInput, err := nativesupply.MintFunc.Encode([]interface{}{receiver, big.NewInt(50)})
+	require.NoError(t, err)
+
+	burnInput, err := nativesupply.BurnFunc.Encode([]interface{}{big.NewInt(30)})
+	require.NoError(t, err)
+
+	t.Run("supply changes through the hook", func(t *testing.T) {
+		t.Parallel()
+
+		tr := newTransition()
+
+		call(tr, receiver, mintInput, runtime.ErrUnauthorizedCaller)
+		call(tr, manager, mintInput, nil)
+		call(tr, manager, burnInput, nil)
+
+		require.Equal(t, big.NewInt(50), tr.state.GetBalance(receiver))
+		require.Equal(t, big.NewInt(70), tr.state.GetBalance(manager))
+
+		result := call(tr, receiver, nativesupply.TotalMintedFunc.ID(), nil)
+		require.Equal(t, big.NewInt(50), new(big.Int).SetBytes(result.ReturnValue))
+
+		result = call(tr, receiver, nativesupply.TotalBurnedFunc.ID(), nil)
+		require.Equal(t, big.NewInt(30), new(big.Int).SetBytes(result.ReturnValue))
+
+		_, _, err := tr.Commit()
+		require.NoError(t, err)
+	})
+
+	t.Run("reverted mint", func(t *testing.T) {
+		t.Parallel()
+
+		tr := newTransition()
+
+		snapshot := tr.state.Snapshot()
+
+		call(tr, manager, mintInput, nil)
+		require.NoError(t, tr.state.RevertToSnapshot(snapshot))
+
+		_, _, err := tr.Commit()
+		require.NoError(t, err)
+	})
+
+	t.Run("self-destruct burns the balance", func(t *testing.T) {
+		t.Parallel()
+
+		tr := newTransition()
+
+		tr.Selfdestruct(manager, manager)
+
+		_, _, err := tr.Commit()
+		require.NoError(t, err)
+	})
+
+	t.Run("supply changes outside of the hook", func(t *testing.T) {
+		t.Parallel()
+
+		tr := newTransition()
+
+		require.ErrorIs(t, tr.MintNative(receiver, receiver, big.NewInt(1)), stateful.ErrSupplyNotManaged)
+
+		tr.state.AddBalance(receiver, big.NewInt(1))
+
+		_, _, err := tr.Commit()
+		require.ErrorIs(t, err, ErrNativeSupplyChanged)
+	})
+}
@@ -0,0 +1,312 @@
+package state
+
+import (
+	"bytes"
+	"math/big"
+	goruntime "runtime"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/precompiled"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// lockedSnapshot serializes the reads of the parent state shared by the speculative executions,
+// since the trie caches the loaded nodes while being read
+type lockedSnapshot struct {
+	lock     sync.Mutex
+	snapshot readSnapshot
+}
+
+func (s *lockedSnapshot) GetStorage(addr types.Address, root types.Hash, key types.Hash) types.Hash {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.snapshot.GetStorage(addr, root, key)
+}
+
+func (s *lockedSnapshot) GetAccount(addr types.Address) (*Account, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.snapshot.GetAccount(addr)
+}
+
+func (s *lockedSnapshot) GetCode(hash types.Hash) ([]byte, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.snapshot.GetCode(hash)
+}
+
+// readSet records the accounts and the storage slots of the parent state read by the speculative execution
+type readSet struct {
+	snapshot readSnapshot
+
+	// accounts holds the read accounts, nil if the account doesn't exist
+	accounts map[types.Address]*Account
+	storage  map[types.Address]map[types.Hash]types.Hash
+}
+
+func newReadSet(snapshot readSnapshot) *readSet {
+	return &readSet{
+		snapshot: snapshot,
+		accounts: make(map[types.Address]*Account),
+		storage:  make(map[types.Address]map[types.Hash]types.Hash),
+	}
+}
+
+func (r *readSet) GetStorage(addr types.Address, root types.Hash, key types.Hash) types.Hash {
+	value := r.snapshot.GetStorage(addr, root, key)
+
+	slots, ok := r.storage[addr]
+	if !ok {
+		slots = make(map[types.Hash]types.Hash)
+		r.storage[addr] = slots
+	}
+
+	slots[key] = value
+
+	return value
+}
+
+func (r *readSet) GetAccount(addr types.Address) (*Account, error) {
+	account, err := r.snapshot.GetAccount(addr)
+	if err != nil || account == nil {
+		r.accounts[addr] = nil
+
+		return account, err
+	}
+
+	r.accounts[addr] = account.Copy()
+
+	return account, nil
+}
+
+func (r *readSet) GetCode(hash types.Hash) ([]byte, bool) {
+	// the code is addressed by its hash, so it can't be changed by the other transactions
+	return r.snapshot.GetCode(hash)
+}
+
+// validate tells whether the state read by the speculative execution
+// is the same in the given state, which includes the preceding transactions of the block
+func (r *readSet) validate(txn *Txn) bool {
+	for addr, account := range r.accounts {
+		obj, exists := txn.getStateObject(addr)
+		if account == nil {
+			if exists {
+				return false
+			}
+
+			continue
+		}
+
+		if !exists ||
+			obj.Account.Nonce != account.Nonce ||
+			obj.Account.Balance.Cmp(account.Balance) != 0 ||
+			obj.Account.Root != account.Root ||
+			!bytes.Equal(obj.Account.CodeHash, account.CodeHash) {
+			return false
+		}
+	}
+
+	for addr, slots := range r.storage {
+		for key, value := range slots {
+			if txn.GetState(addr, key) != value {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// deferredFees are the fees of the speculative execution,
+// paid once the execution is merged so that the transactions don't conflict on the fee receivers
+type deferredFees struct {
+	coinbase *big.Int
+	burn     *big.Int
+}
+
+// speculativeExecution is the execution of the transaction on top of the parent state of the block
+type speculativeExecution struct {
+	reads  *readSet
+	state  *Txn
+	fees   *deferredFees
+	result *runtime.ExecutionResult
+	logs   []*types.Log
+	err    error
+}
+
+// newSpeculativeTransition creates the transition executing the transaction on top of the parent state of the block
+func (e *Executor) newSpeculativeTransition(t *Transition, reads *readSet) *Transition {
+	spec := &Transition{
+		logger:   t.logger,
+		ctx:      t.ctx,
+		state:    newTxn(reads),
+		snap:     t.snap,
+		getHash:  t.getHash,
+		auxState: t.auxState,
+		config:   t.config,
+		gasPool:  uint64(t.ctx.GasLimit),
+
+		engine:      t.engine,
+		precompiles: precompiled.NewPrecompiled(),
+		fees:        &deferredFees{},
+
+		statefulPrecompiles: t.statefulPrecompiles,
+		supply:              t.supply,
+		maxCodeSize:         t.maxCodeSize,
+	}
+
+	e.enableAddressLists(spec)
+
+	return spec
+}
+
+// writeParallel executes the transactions concurrently on top of the parent state of the block,
+// and merges their results in the block order. The transactions which read the state written by
+// the preceding transactions of the block are executed again on top of the merged state
+func (e *Executor) writeParallel(t *Transition, txs []*types.Transaction) error {
+	executions := e.speculate(t, txs)
+
+	reexecuted := 0
+
+	for i, txn := range txs {
+		merged, err := t.mergeSpeculative(txn, executions[i])
+		if err != nil {
+			return err
+		}
+
+		if merged {
+			continue
+		}
+
+		reexecuted++
+
+		if err := t.Write(txn); err != nil {
+			return err
+		}
+	}
+
+	e.logger.Debug("parallel block execution", "txs", len(txs), "reexecuted", reexecuted)
+
+	return nil
+}
+
+// speculate executes the transactions concurrently, each of them on top of the parent state of the block
+func (e *Executor) speculate(t *Transition, txs []*types.Transaction) []*speculativeExecution {
+	var (
+		snapshot   = &lockedSnapshot{snapshot: t.state.snapshot}
+		executions = make([]*speculativeExecution, len(txs))
+		indexes    = make(chan int, len(txs))
+		workers    = goruntime.NumCPU()
+		wg         sync.WaitGroup
+	)
+
+	for i := range txs {
+		indexes <- i
+	}
+
+	close(indexes)
+
+	if workers > len(txs) {
+		workers = len(txs)
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				reads := newReadSet(snapshot)
+				spec := e.newSpeculativeTransition(t, reads)
+				execution := &speculativeExecution{reads: reads, state: spec.state, fees: spec.fees}
+
+				if execution.err = spec.recoverSender(txs[i]); execution.err == nil {
+					execution.result, execution.err = spec.Apply(txs[i].Copy())
+					execution.logs = spec.state.Logs()
+				}
+
+				executions[i] = execution
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return executions
+}
+
+// mergeSpeculative applies the result of the speculative execution to the transition
+// if the execution didn't read any state written by the preceding transactions of the block
+func (t *Transition) mergeSpeculative(txn *types.Transaction, execution *speculativeExecution) (bool, error) {
+	// the failed executions are repeated to surface the same error as the sequential execution
+	if execution.err != nil || t.gasPool < txn.Gas || !execution.reads.validate(t.state) {
+		return false, nil
+	}
+
+	objects := make(map[types.Address]*StateObject)
+
+	execution.state.txn.Root().Walk(func(k []byte, v interface{}) bool {
+		if obj, ok := v.(*StateObject); ok {
+			objects[types.BytesToAddress(k)] = obj
+		}
+
+		return false
+	})
+
+	// the storage written by the preceding transactions is kept, unless the account is recreated.
+	// Only the accounts without code can be recreated, and their storage can't be written
+	// by the preceding transactions unless the code is deployed meanwhile, which is a conflict anyway.
+	// Such accounts are executed again to be on the safe side
+	for addr := range objects {
+		val, exists := t.state.txn.Get(addr.Bytes())
+		if !exists {
+			continue
+		}
+
+		//nolint:forcetypeassert
+		if current := val.(*StateObject); current.Txn != nil {
+			if account := execution.reads.accounts[addr]; account == nil ||
+				bytes.Equal(account.CodeHash, types.EmptyCodeHash.Bytes()) {
+				return false, nil
+			}
+		}
+	}
+
+	for addr, obj := range objects {
+		merged := obj.Copy()
+
+		if val, exists := t.state.txn.Get(addr.Bytes()); exists {
+			//nolint:forcetypeassert
+			if current := val.(*StateObject); current.Txn != nil && !current.Deleted {
+				storage := current.Txn.CommitOnly().Txn()
+
+				if obj.Txn != nil {
+					obj.Txn.Root().Walk(func(k []byte, v interface{}) bool {
+						storage.Insert(k, v)
+
+						return false
+					})
+				}
+
+				merged.Txn = storage
+			}
+		}
+
+		t.state.txn.Insert(addr.Bytes(), merged)
+	}
+
+	if destroyed := execution.state.GetDestroyed(); destroyed.Sign() > 0 {
+		t.state.AddDestroyed(destroyed)
+	}
+
+	t.payFees(execution.fees.coinbase, execution.fees.burn)
+
+	t.gasPool -= execution.result.GasUsed
+
+	return true, t.writeReceipt(txn, execution.result, execution.logs)
+}
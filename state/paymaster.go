@@ -0,0 +1,107 @@
+package state
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/addresslist"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// ErrNotEnoughPaymasterDeposit is returned when the paymaster can't cover the gas of the sponsored transaction
+var ErrNotEnoughPaymasterDeposit = errors.New("not enough paymaster deposit to cover gas costs")
+
+// ValidateSponsorshipFunc is called on the paymaster before it's charged for the gasless transaction.
+// The paymaster sponsors the transaction only if it returns the selector of the function
+var ValidateSponsorshipFunc = abi.MustNewMethod(
+	"function validateSponsorship(address sender, uint256 gasLimit, bytes data) returns (bytes4)")
+
+// PaymasterValidationGas is the gas limit of the static call validating the sponsorship
+const PaymasterValidationGas = 50000
+
+// IsGasless returns true if the transaction calls a contract with zero gas price,
+// so that its fees can be sponsored by the called paymaster
+func IsGasless(tx *types.Transaction) bool {
+	if tx.To == nil {
+		return false
+	}
+
+	switch tx.Type {
+	case types.LegacyTx:
+		return tx.GasPrice != nil && tx.GasPrice.Sign() == 0
+	case types.DynamicFeeTx:
+		return tx.GasFeeCap != nil && tx.GasFeeCap.Sign() == 0 &&
+			tx.GasTipCap != nil && tx.GasTipCap.Sign() == 0
+	default:
+		return false
+	}
+}
+
+// IsSponsored returns true if the gasless transaction is sponsored by the paymaster it calls
+func (t *Transition) IsSponsored(msg *types.Transaction) bool {
+	return t.sponsoringPaymaster(msg) != nil
+}
+
+// sponsoringPaymaster returns the paymaster sponsoring the gasless transaction,
+// or nil if the transaction isn't sponsored.
+// The transactions sponsored by the paymasters enabled in the allow list are charged the base fee
+// out of the paymaster balance, which acts as its deposit, once the paymaster approves them
+func (t *Transition) sponsoringPaymaster(msg *types.Transaction) *types.Address {
+	if t.paymasterAllowList == nil || t.ctx.NonPayable || !IsGasless(msg) {
+		return nil
+	}
+
+	if t.paymasterAllowList.GetRole(*msg.To) != addresslist.EnabledRole || t.paymasterAllowList.IsFrozen() {
+		return nil
+	}
+
+	if !t.paymasterApproves(*msg.To, msg) {
+		return nil
+	}
+
+	return msg.To
+}
+
+// paymasterApproves calls the validateSponsorship function of the paymaster with the transaction.
+// The call is static and bounded by PaymasterValidationGas, and it isn't traced
+func (t *Transition) paymasterApproves(paymaster types.Address, msg *types.Transaction) bool {
+	input, err := ValidateSponsorshipFunc.Encode(
+		[]interface{}{msg.From, new(big.Int).SetUint64(msg.Gas), msg.Input})
+	if err != nil {
+		return false
+	}
+
+	c := runtime.NewContractCall(1, msg.From, contracts.SystemCaller, paymaster, big.NewInt(0),
+		PaymasterValidationGas, t.state.GetCode(paymaster), input)
+	c.Static = true
+
+	snapshot := t.state.Snapshot()
+	result := t.run(c, t)
+
+	if err := t.state.RevertToSnapshot(snapshot); err != nil {
+		return false
+	}
+
+	return !result.Failed() && len(result.ReturnValue) >= types.SignatureSize &&
+		bytes.Equal(result.ReturnValue[:types.SignatureSize], ValidateSponsorshipFunc.ID())
+}
+
+// subPaymasterDeposit charges the paymaster for the gas of the sponsored transaction at the base fee
+func (t *Transition) subPaymasterDeposit(paymaster types.Address, msg *types.Transaction) error {
+	upfrontGasCost := new(big.Int).Mul(new(big.Int).SetUint64(msg.Gas), t.ctx.BaseFee)
+
+	if err := t.state.SubBalance(paymaster, upfrontGasCost); err != nil {
+		if errors.Is(err, runtime.ErrNotEnoughFunds) {
+			return ErrNotEnoughPaymasterDeposit
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/addresslist"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestIsGasless(t *testing.T) {
+	t.Parallel()
+
+	to := types.StringToAddress("0x1")
+
+	require.True(t, IsGasless(&types.Transaction{To: &to, GasPrice: big.NewInt(0)}))
+	require.False(t, IsGasless(&types.Transaction{To: &to, GasPrice: big.NewInt(1)}))
+	require.False(t, IsGasless(&types.Transaction{GasPrice: big.NewInt(0)}))
+	require.True(t, IsGasless(&types.Transaction{
+		Type: types.DynamicFeeTx, To: &to, GasFeeCap: big.NewInt(0), GasTipCap: big.NewInt(0),
+	}))
+	require.False(t, IsGasless(&types.Transaction{
+		Type: types.DynamicFeeTx, To: &to, GasFeeCap: big.NewInt(1), GasTipCap: big.NewInt(0),
+	}))
+	require.False(t, IsGasless(&types.Transaction{Type: types.StateTx, To: &to, GasPrice: big.NewInt(0)}))
+}
+
+func TestTransition_Paymaster(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender    = types.StringToAddress("0xa0")
+		paymaster = types.StringToAddress("0xb0")
+		other     = types.StringToAddress("0xb1")
+		coinbase  = types.StringToAddress("0xc0")
+		burn      = types.StringToAddress("0xd0")
+	)
+
+	newTransition := func(deposit uint64) *Transition {
+		snap := &mockSnapshot{state: map[types.Address]*PreState{
+			sender:    {Balance: 1000},
+			paymaster: {Balance: deposit},
+		}}
+
+		tr := NewTransition(chain.AllForksEnabled.At(0), snap, newTxn(snap))
+		tr.logger = hclog.NewNullLogger()
+		tr.ctx = runtime.TxContext{
+			Coinbase:     coinbase,
+			BurnContract: burn,
+			BaseFee:      big.NewInt(10),
+			GasLimit:     10_000_000,
+			ChainID:      1,
+		}
+		tr.gasPool = uint64(tr.ctx.GasLimit)
+		tr.getHash = func(uint64) types.Hash { return types.ZeroHash }
+		tr.paymasterAllowList = addresslist.NewAddressList(tr, contracts.AllowListPaymasterAddr)
+		tr.paymasterAllowList.SetRole(paymaster, addresslist.EnabledRole)
+		tr.paymasterAllowList.SetRole(other, addresslist.EnabledRole)
+
+		// the paymaster approves the validation calls, returning the selector, and stops on the other calls
+		tr.state.SetCode(paymaster, append(append(
+			[]byte{0x36, 0x60, 0x05, 0x57, 0x00, 0x5b, 0x63}, ValidateSponsorshipFunc.ID()...),
+			0x60, 0xe0, 0x1b, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3))
+
+		return tr
+	}
+
+	tx := func(to types.Address) *types.Transaction {
+		tx := &types.Transaction{
+			From:     sender,
+			To:       &to,
+			Value:    big.NewInt(0),
+			Gas:      30000,
+			GasPrice: big.NewInt(0),
+		}
+		tx.ComputeHash(1)
+
+		return tx
+	}
+
+	t.Run("sponsored", func(t *testing.T) {
+		t.Parallel()
+
+		tr := newTransition(1_000_000)
+		require.NoError(t, tr.Write(tx(paymaster)))
+
+		// the paymaster pays the base fee of the used gas, and no tip is paid.
+		// The validation call isn't charged, the transaction uses 21015 gas
+		require.Equal(t, big.NewInt(1000), tr.state.GetBalance(sender))
+		require.Equal(t, big.NewInt(1_000_000-210150), tr.state.GetBalance(paymaster))
+		require.Equal(t, big.NewInt(210150), tr.state.GetBalance(burn))
+		require.Zero(t, tr.state.GetBalance(coinbase).Sign())
+	})
+
+	t.Run("not enough deposit", func(t *testing.T) {
+		t.Parallel()
+
+		// the deposit has to cover the gas limit
+		tr := newTransition(299999)
+		require.ErrorContains(t, tr.Write(tx(paymaster)), ErrNotEnoughPaymasterDeposit.Error())
+	})
+
+	t.Run("frozen allow list", func(t *testing.T) {
+		t.Parallel()
+
+		tr := newTransition(1_000_000)
+		tr.paymasterAllowList.SetFrozen(true)
+		require.ErrorContains(t, tr.Write(tx(paymaster)), ErrFeeCapTooLow.Error())
+	})
+
+	t.Run("not a paymaster", func(t *testing.T) {
+		t.Parallel()
+
+		tr := newTransition(1_000_000)
+		tr.paymasterAllowList.SetRole(other, addresslist.NoRole)
+		require.ErrorContains(t, tr.Write(tx(other)), ErrFeeCapTooLow.Error())
+	})
+
+	t.Run("not approved by the paymaster", func(t *testing.T) {
+		t.Parallel()
+
+		// the allowlisted contract doesn't sponsor the transactions it doesn't approve
+		tr := newTransition(1_000_000)
+		tr.state.SetBalance(other, big.NewInt(1_000_000))
+		require.False(t, tr.IsSponsored(tx(other)))
+		require.ErrorContains(t, tr.Write(tx(other)), ErrFeeCapTooLow.Error())
+		require.Equal(t, big.NewInt(1_000_000), tr.state.GetBalance(other))
+
+		// the paymaster rejecting the sponsorship
+		tr.state.SetCode(other, []byte{0x60, 0x00, 0x60, 0x00, 0xfd})
+		require.False(t, tr.IsSponsored(tx(other)))
+
+		require.True(t, tr.IsSponsored(tx(paymaster)))
+	})
+}
@@ -0,0 +1,247 @@
+package state
+
+import (
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// accessPatternsSize is the number of the contracts whose recently read storage slots are kept
+	accessPatternsSize = 1024
+
+	// maxPatternSlots bounds the number of the storage slots kept for a single contract
+	maxPatternSlots = 256
+)
+
+// slotAccess is the storage slot of the account
+type slotAccess struct {
+	addr types.Address
+	key  types.Hash
+}
+
+// accessPatterns keeps the storage slots read by the recent transactions calling the contracts,
+// which are likely to be read again by the next transactions calling them
+type accessPatterns struct {
+	slots *lru.Cache // contract address -> []slotAccess
+}
+
+func newAccessPatterns() *accessPatterns {
+	slots, _ := lru.New(accessPatternsSize)
+
+	return &accessPatterns{slots: slots}
+}
+
+// get returns the storage slots recently read by the transactions calling the contract
+func (a *accessPatterns) get(contract types.Address) []slotAccess {
+	slots, ok := a.slots.Get(contract)
+	if !ok {
+		return nil
+	}
+
+	return slots.([]slotAccess) //nolint:forcetypeassert
+}
+
+// add adds the storage slots read by the transaction calling the contract,
+// the oldest slots are dropped once there are more than maxPatternSlots of them
+func (a *accessPatterns) add(contract types.Address, reads []slotAccess) {
+	if len(reads) == 0 {
+		return
+	}
+
+	existing := a.get(contract)
+
+	known := make(map[slotAccess]struct{}, len(existing))
+	for _, slot := range existing {
+		known[slot] = struct{}{}
+	}
+
+	slots := make([]slotAccess, len(existing), len(existing)+len(reads))
+	copy(slots, existing)
+
+	for _, slot := range reads {
+		if _, ok := known[slot]; ok {
+			continue
+		}
+
+		known[slot] = struct{}{}
+
+		slots = append(slots, slot)
+	}
+
+	if len(slots) > maxPatternSlots {
+		slots = slots[len(slots)-maxPatternSlots:]
+	}
+
+	a.slots.Add(contract, slots)
+}
+
+// recordingSnapshot records the storage slots read by the executed transaction
+type recordingSnapshot struct {
+	readSnapshot
+
+	reads []slotAccess
+}
+
+func (r *recordingSnapshot) GetStorage(addr types.Address, root types.Hash, key types.Hash) types.Hash {
+	if len(r.reads) < maxPatternSlots {
+		r.reads = append(r.reads, slotAccess{addr: addr, key: key})
+	}
+
+	return r.readSnapshot.GetStorage(addr, root, key)
+}
+
+// flush returns the recorded storage slots and resets them
+func (r *recordingSnapshot) flush() []slotAccess {
+	reads := r.reads
+	r.reads = nil
+
+	return reads
+}
+
+// prefetcher loads the accounts and the storage slots the transactions of the block are predicted to read,
+// while the transactions are executed sequentially, so that the reads of the execution don't wait for the disk.
+// The loaded trie nodes are kept by the parent state shared with the execution
+type prefetcher struct {
+	snapshot readSnapshot
+
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+}
+
+// prefetch starts loading the senders, the recipients and the storage slots recently read
+// by the transactions calling the same contracts
+func (e *Executor) prefetch(snapshot readSnapshot, txs []*types.Transaction) *prefetcher {
+	// the predictions are taken before the block is executed, since its reads update the patterns
+	slots := make([][]slotAccess, len(txs))
+
+	for i, txn := range txs {
+		if txn.To != nil {
+			slots[i] = e.accessPatterns.get(*txn.To)
+		}
+	}
+
+	p := &prefetcher{
+		snapshot: snapshot,
+		closeCh:  make(chan struct{}),
+	}
+
+	p.wg.Add(1)
+
+	go func() {
+		defer p.wg.Done()
+
+		p.run(txs, slots)
+	}()
+
+	return p
+}
+
+// run loads the state read by the transactions in the block order, until the prefetcher is stopped
+func (p *prefetcher) run(txs []*types.Transaction, slots [][]slotAccess) {
+	// roots are the storage roots of the loaded accounts, nil if the account doesn't exist
+	roots := make(map[types.Address]*types.Hash)
+
+	loadAccount := func(addr types.Address) *types.Hash {
+		if root, ok := roots[addr]; ok {
+			return root
+		}
+
+		var root *types.Hash
+
+		account, err := p.snapshot.GetAccount(addr)
+		if err == nil && account != nil {
+			root = &account.Root
+
+			// the code of the called contracts is loaded as well
+			if len(account.CodeHash) > 0 {
+				p.snapshot.GetCode(types.BytesToHash(account.CodeHash))
+			}
+		}
+
+		roots[addr] = root
+
+		return root
+	}
+
+	// loaded are the storage slots loaded already
+	loaded := make(map[slotAccess]struct{})
+
+	for i, txn := range txs {
+		select {
+		case <-p.closeCh:
+			return
+		default:
+		}
+
+		if txn.From != types.ZeroAddress {
+			loadAccount(txn.From)
+		}
+
+		if txn.To != nil {
+			loadAccount(*txn.To)
+		}
+
+		for _, slot := range slots[i] {
+			select {
+			case <-p.closeCh:
+				return
+			default:
+			}
+
+			if _, ok := loaded[slot]; ok {
+				continue
+			}
+
+			loaded[slot] = struct{}{}
+
+			if root := loadAccount(slot.addr); root != nil && *root != types.EmptyRootHash {
+				p.snapshot.GetStorage(slot.addr, *root, slot.key)
+			}
+		}
+	}
+}
+
+// stop stops the prefetcher and waits until it doesn't read the state anymore
+func (p *prefetcher) stop() {
+	close(p.closeCh)
+	p.wg.Wait()
+}
+
+// writePrefetched executes the transactions sequentially, while the state they are predicted to read is prefetched.
+// The storage slots read by the transactions are recorded to predict the reads of the next ones
+func (e *Executor) writePrefetched(t *Transition, txs []*types.Transaction) error {
+	var (
+		parent    = t.state.snapshot
+		snapshot  = &lockedSnapshot{snapshot: parent}
+		recording = &recordingSnapshot{readSnapshot: snapshot}
+	)
+
+	t.state.snapshot = recording
+
+	p := e.prefetch(snapshot, txs)
+
+	defer func() {
+		p.stop()
+
+		t.state.snapshot = parent
+	}()
+
+	for _, txn := range txs {
+		err := t.Write(txn)
+
+		if txn.To != nil {
+			e.accessPatterns.add(*txn.To, recording.flush())
+		} else {
+			recording.flush()
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
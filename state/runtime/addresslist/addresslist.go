@@ -5,8 +5,11 @@ import (
 	"fmt"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/ethgo"
 	"github.com/umbracle/ethgo/abi"
 )
 
@@ -15,7 +18,20 @@ var (
 	SetAdminFunc        = abi.MustNewMethod("function setAdmin(address)")
 	SetEnabledFunc      = abi.MustNewMethod("function setEnabled(address)")
 	SetNoneFunc         = abi.MustNewMethod("function setNone(address)")
+	SetFreezerFunc      = abi.MustNewMethod("function setFreezer(address)")
 	ReadAddressListFunc = abi.MustNewMethod("function readAddressList(address) returns (uint256)")
+
+	SetAdminBatchFunc   = abi.MustNewMethod("function setAdminBatch(address[])")
+	SetEnabledBatchFunc = abi.MustNewMethod("function setEnabledBatch(address[])")
+	SetNoneBatchFunc    = abi.MustNewMethod("function setNoneBatch(address[])")
+	SetFreezerBatchFunc = abi.MustNewMethod("function setFreezerBatch(address[])")
+
+	ScheduleRoleFunc      = abi.MustNewMethod("function scheduleRole(address,uint8,uint64)")
+	ReadScheduledRoleFunc = abi.MustNewMethod("function readScheduledRole(address) returns (uint8,uint64)")
+
+	FreezeFunc   = abi.MustNewMethod("function freeze()")
+	UnfreezeFunc = abi.MustNewMethod("function unfreeze()")
+	IsFrozenFunc = abi.MustNewMethod("function isFrozen() returns (bool)")
 )
 
 // list of gas costs for the operations
@@ -56,6 +72,18 @@ var (
 	errFunctionNotFound    = fmt.Errorf("function not found")
 	errWriteProtection     = fmt.Errorf("write protection")
 	errAdminSelfRemove     = fmt.Errorf("cannot remove admin role from caller")
+	errInvalidInput        = fmt.Errorf("invalid input")
+	errEmptyBatch          = fmt.Errorf("no addresses in the batch")
+	errInvalidRole         = fmt.Errorf("invalid role")
+	errActivationInPast    = fmt.Errorf("activation time of the scheduled role is not in the future")
+)
+
+var (
+	// frozenSlot holds the flag of the frozen list
+	frozenSlot = types.BytesToHash(crypto.Keccak256([]byte("frozen")))
+
+	scheduledRolePrefix       = []byte("scheduledRole")
+	scheduledActivationPrefix = []byte("scheduledActivation")
 )
 
 func (a *AddressList) runInputCall(caller types.Address, input []byte,
@@ -67,6 +95,34 @@ func (a *AddressList) runInputCall(caller types.Address, input []byte,
 
 	sig, inputBytes := input[:4], input[4:]
 
+	switch {
+	case bytes.Equal(sig, SetAdminBatchFunc.ID()):
+		return a.runBatchCall(caller, SetAdminBatchFunc, AdminRole, inputBytes, gas, isStatic)
+	case bytes.Equal(sig, SetEnabledBatchFunc.ID()):
+		return a.runBatchCall(caller, SetEnabledBatchFunc, EnabledRole, inputBytes, gas, isStatic)
+	case bytes.Equal(sig, SetNoneBatchFunc.ID()):
+		return a.runBatchCall(caller, SetNoneBatchFunc, NoRole, inputBytes, gas, isStatic)
+	case bytes.Equal(sig, SetFreezerBatchFunc.ID()):
+		return a.runBatchCall(caller, SetFreezerBatchFunc, FreezerRole, inputBytes, gas, isStatic)
+	case bytes.Equal(sig, ScheduleRoleFunc.ID()):
+		return a.runScheduleRoleCall(caller, inputBytes, gas, isStatic)
+	case bytes.Equal(sig, ReadScheduledRoleFunc.ID()):
+		return a.runReadScheduledRoleCall(inputBytes, gas)
+	case bytes.Equal(sig, FreezeFunc.ID()):
+		// freezers can freeze the list in an emergency, but only the admins can unfreeze it
+		return a.runFreezeCall(caller, true, gas, isStatic, AdminRole, FreezerRole)
+	case bytes.Equal(sig, UnfreezeFunc.ID()):
+		return a.runFreezeCall(caller, false, gas, isStatic, AdminRole)
+	case bytes.Equal(sig, IsFrozenFunc.ID()):
+		if gas < readAddressListCost {
+			return nil, 0, runtime.ErrOutOfGas
+		}
+
+		ret, err := IsFrozenFunc.Outputs.Encode([]interface{}{a.IsFrozen()})
+
+		return ret, readAddressListCost, err
+	}
+
 	// all the functions have the same input (i.e. tuple(address)) which
 	// in abi gets codified as a 32 bytes array with the first 20 bytes
 	// encoding the address
@@ -107,6 +163,8 @@ func (a *AddressList) runInputCall(caller types.Address, input []byte,
 		updateRole = EnabledRole
 	} else if bytes.Equal(sig, SetNoneFunc.ID()) {
 		updateRole = NoRole
+	} else if bytes.Equal(sig, SetFreezerFunc.ID()) {
+		updateRole = FreezerRole
 	} else {
 		return nil, 0, errFunctionNotFound
 	}
@@ -136,30 +194,248 @@ func (a *AddressList) runInputCall(caller types.Address, input []byte,
 	return nil, gasUsed, nil
 }
 
+// authorizeWrite charges the gas of the write operation and checks the caller has one of the given roles
+func (a *AddressList) authorizeWrite(caller types.Address, gas, cost uint64,
+	isStatic bool, roles ...Role) (uint64, error) {
+	if gas < cost {
+		return 0, runtime.ErrOutOfGas
+	}
+
+	// we cannot perform any write operation if the call is static
+	if isStatic {
+		return cost, errWriteProtection
+	}
+
+	callerRole := a.GetRole(caller)
+	for _, role := range roles {
+		if callerRole == role {
+			return cost, nil
+		}
+	}
+
+	return cost, runtime.ErrNotAuth
+}
+
+// runBatchCall sets the role of all the addresses in the batch, or of none of them if any can't be updated
+func (a *AddressList) runBatchCall(caller types.Address, method *abi.Method, updateRole Role,
+	input []byte, gas uint64, isStatic bool) ([]byte, uint64, error) {
+	raw, err := method.Inputs.Decode(input)
+	if err != nil {
+		return nil, 0, errInvalidInput
+	}
+
+	addrs, ok := raw.(map[string]interface{})["0"].([]ethgo.Address)
+	if !ok {
+		return nil, 0, errInvalidInput
+	}
+
+	if len(addrs) == 0 {
+		return nil, 0, errEmptyBatch
+	}
+
+	gasUsed, err := a.authorizeWrite(caller, gas, writeAddressListCost*uint64(len(addrs)), isStatic, AdminRole)
+	if err != nil {
+		return nil, gasUsed, err
+	}
+
+	// An admin can not remove himself from the list
+	for _, addr := range addrs {
+		if types.Address(addr) == caller {
+			return nil, gasUsed, errAdminSelfRemove
+		}
+	}
+
+	for _, addr := range addrs {
+		a.SetRole(types.Address(addr), updateRole)
+	}
+
+	return nil, gasUsed, nil
+}
+
+// runScheduleRoleCall schedules the role of the address to change once the activation time is reached
+func (a *AddressList) runScheduleRoleCall(caller types.Address, input []byte,
+	gas uint64, isStatic bool) ([]byte, uint64, error) {
+	raw, err := ScheduleRoleFunc.Inputs.Decode(input)
+	if err != nil {
+		return nil, 0, errInvalidInput
+	}
+
+	args, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, 0, errInvalidInput
+	}
+
+	inputAddr, okAddr := args["0"].(ethgo.Address)
+	rawRole, okRole := args["1"].(uint8)
+	activation, okActivation := args["2"].(uint64)
+
+	if !okAddr || !okRole || !okActivation {
+		return nil, 0, errInvalidInput
+	}
+
+	gasUsed, err := a.authorizeWrite(caller, gas, writeAddressListCost, isStatic, AdminRole)
+	if err != nil {
+		return nil, gasUsed, err
+	}
+
+	updateRole, ok := roleFromUint64(uint64(rawRole))
+	if !ok {
+		return nil, gasUsed, errInvalidRole
+	}
+
+	if activation <= a.now() {
+		return nil, gasUsed, errActivationInPast
+	}
+
+	// An admin can not remove himself from the list
+	if types.Address(inputAddr) == caller {
+		return nil, gasUsed, errAdminSelfRemove
+	}
+
+	a.ScheduleRole(types.Address(inputAddr), updateRole, activation)
+
+	return nil, gasUsed, nil
+}
+
+func (a *AddressList) runReadScheduledRoleCall(input []byte, gas uint64) ([]byte, uint64, error) {
+	if len(input) != 32 {
+		return nil, 0, errInputTooShort
+	}
+
+	if gas < readAddressListCost {
+		return nil, 0, runtime.ErrOutOfGas
+	}
+
+	var (
+		role, activation = a.GetScheduledRole(types.BytesToAddress(input))
+		roleNum          = role.Uint64()
+	)
+
+	// the roles scheduled in the past are already in effect
+	if activation <= a.now() {
+		roleNum, activation = 0, 0
+	}
+
+	ret, err := ReadScheduledRoleFunc.Outputs.Encode([]interface{}{uint8(roleNum), activation})
+
+	return ret, readAddressListCost, err
+}
+
+func (a *AddressList) runFreezeCall(caller types.Address, frozen bool,
+	gas uint64, isStatic bool, roles ...Role) ([]byte, uint64, error) {
+	gasUsed, err := a.authorizeWrite(caller, gas, writeAddressListCost, isStatic, roles...)
+	if err != nil {
+		return nil, gasUsed, err
+	}
+
+	a.SetFrozen(frozen)
+
+	return nil, gasUsed, nil
+}
+
+// SetRole sets the role of the address, overriding its scheduled role (if any)
 func (a *AddressList) SetRole(addr types.Address, role Role) {
 	a.state.SetState(a.addr, types.BytesToHash(addr.Bytes()), types.Hash(role))
+
+	if _, activation := a.GetScheduledRole(addr); activation != 0 {
+		a.state.SetState(a.addr, scheduledSlot(scheduledRolePrefix, addr), types.ZeroHash)
+		a.state.SetState(a.addr, scheduledSlot(scheduledActivationPrefix, addr), types.ZeroHash)
+	}
 }
 
+// GetRole returns the role of the address, which is its scheduled role once the activation time is reached
 func (a *AddressList) GetRole(addr types.Address) Role {
+	if role, activation := a.GetScheduledRole(addr); activation != 0 && activation <= a.now() {
+		return role
+	}
+
 	res := a.state.GetStorage(a.addr, types.BytesToHash(addr.Bytes()))
 
 	return Role(res)
 }
 
+// ScheduleRole schedules the role of the address to change at the activation time (unix seconds).
+// It replaces the pending scheduled role of the address (if any)
+func (a *AddressList) ScheduleRole(addr types.Address, role Role, activation uint64) {
+	// the scheduled role already in effect becomes the role of the address
+	if scheduled, scheduledActivation := a.GetScheduledRole(addr); scheduledActivation != 0 &&
+		scheduledActivation <= a.now() {
+		a.state.SetState(a.addr, types.BytesToHash(addr.Bytes()), types.Hash(scheduled))
+	}
+
+	a.state.SetState(a.addr, scheduledSlot(scheduledRolePrefix, addr), types.Hash(role))
+	a.state.SetState(a.addr, scheduledSlot(scheduledActivationPrefix, addr),
+		types.BytesToHash(common.EncodeUint64ToBytes(activation)))
+}
+
+// GetScheduledRole returns the scheduled role of the address and its activation time, which is zero if none
+func (a *AddressList) GetScheduledRole(addr types.Address) (Role, uint64) {
+	activation := a.state.GetStorage(a.addr, scheduledSlot(scheduledActivationPrefix, addr))
+	if activation == types.ZeroHash {
+		return NoRole, 0
+	}
+
+	role := a.state.GetStorage(a.addr, scheduledSlot(scheduledRolePrefix, addr))
+
+	return Role(role), common.EncodeBytesToUint64(activation[types.HashLength-8:])
+}
+
+// SetFrozen freezes or unfreezes the list
+func (a *AddressList) SetFrozen(frozen bool) {
+	value := types.ZeroHash
+	if frozen {
+		value = types.Hash(EnabledRole)
+	}
+
+	a.state.SetState(a.addr, frozenSlot, value)
+}
+
+// IsFrozen returns true if the list is frozen. The frozen lists authorize only their admins
+func (a *AddressList) IsFrozen() bool {
+	return a.state.GetStorage(a.addr, frozenSlot) != types.ZeroHash
+}
+
+// now returns the timestamp of the block the list is used in
+func (a *AddressList) now() uint64 {
+	return uint64(a.state.GetTxContext().Timestamp)
+}
+
+func scheduledSlot(prefix []byte, addr types.Address) types.Hash {
+	return types.BytesToHash(crypto.Keccak256(prefix, addr.Bytes()))
+}
+
 type Role types.Hash
 
 var (
 	NoRole      Role = Role(types.StringToHash("0x0000000000000000000000000000000000000000000000000000000000000000"))
 	EnabledRole Role = Role(types.StringToHash("0x0000000000000000000000000000000000000000000000000000000000000001"))
 	AdminRole   Role = Role(types.StringToHash("0x0000000000000000000000000000000000000000000000000000000000000002"))
+	FreezerRole Role = Role(types.StringToHash("0x0000000000000000000000000000000000000000000000000000000000000003"))
 )
 
+func roleFromUint64(num uint64) (Role, bool) {
+	switch num {
+	case 0:
+		return NoRole, true
+	case 1:
+		return EnabledRole, true
+	case 2:
+		return AdminRole, true
+	case 3:
+		return FreezerRole, true
+	default:
+		return NoRole, false
+	}
+}
+
 func (r Role) Uint64() uint64 {
 	switch r {
 	case EnabledRole:
 		return 1
 	case AdminRole:
 		return 2
+	case FreezerRole:
+		return 3
 	default:
 		return 0
 	}
@@ -176,4 +452,5 @@ func (r Role) Enabled() bool {
 type stateRef interface {
 	SetState(addr types.Address, key, value types.Hash)
 	GetStorage(addr types.Address, key types.Hash) types.Hash
+	GetTxContext() runtime.TxContext
 }
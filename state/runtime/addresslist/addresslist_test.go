@@ -10,7 +10,8 @@ import (
 )
 
 type mockState struct {
-	state map[types.Hash]types.Hash
+	state     map[types.Hash]types.Hash
+	timestamp int64
 }
 
 func (m *mockState) SetState(addr types.Address, key, value types.Hash) {
@@ -21,6 +22,10 @@ func (m *mockState) GetStorage(addr types.Address, key types.Hash) types.Hash {
 	return m.state[key]
 }
 
+func (m *mockState) GetTxContext() runtime.TxContext {
+	return runtime.TxContext{Timestamp: m.timestamp}
+}
+
 func newMockAddressList() *AddressList {
 	state := &mockState{
 		state: map[types.Hash]types.Hash{},
@@ -140,6 +145,7 @@ func TestAddressList_WriteOp_Full(t *testing.T) {
 		{SetAdminFunc, AdminRole},
 		{SetEnabledFunc, EnabledRole},
 		{SetNoneFunc, NoRole},
+		{SetFreezerFunc, FreezerRole},
 	}
 
 	for _, c := range cases {
@@ -153,11 +159,149 @@ func TestAddressList_WriteOp_Full(t *testing.T) {
 	}
 }
 
+func TestAddressList_BatchOp(t *testing.T) {
+	a := newMockAddressList()
+	a.SetRole(types.Address{}, AdminRole)
+
+	targets := []types.Address{{0x1}, {0x2}, {0x3}}
+
+	input, _ := SetEnabledBatchFunc.Encode([]interface{}{targets})
+
+	_, _, err := a.runInputCall(types.Address{}, input, 3*writeAddressListCost-1, false)
+	require.Equal(t, runtime.ErrOutOfGas, err)
+
+	_, gasCost, err := a.runInputCall(types.Address{0x1}, input, 3*writeAddressListCost, false)
+	require.Equal(t, 3*writeAddressListCost, gasCost)
+	require.Equal(t, runtime.ErrNotAuth, err)
+
+	_, gasCost, err = a.runInputCall(types.Address{}, input, 3*writeAddressListCost, false)
+	require.Equal(t, 3*writeAddressListCost, gasCost)
+	require.NoError(t, err)
+
+	for _, target := range targets {
+		require.Equal(t, EnabledRole, a.GetRole(target))
+	}
+
+	// the batch including the caller is rejected as a whole
+	input, _ = SetNoneBatchFunc.Encode([]interface{}{[]types.Address{{0x1}, {}}})
+
+	_, _, err = a.runInputCall(types.Address{}, input, 2*writeAddressListCost, false)
+	require.Equal(t, errAdminSelfRemove, err)
+	require.Equal(t, EnabledRole, a.GetRole(types.Address{0x1}))
+	require.Equal(t, AdminRole, a.GetRole(types.Address{}))
+
+	input, _ = SetNoneBatchFunc.Encode([]interface{}{[]types.Address{}})
+
+	_, _, err = a.runInputCall(types.Address{}, input, writeAddressListCost, false)
+	require.Equal(t, errEmptyBatch, err)
+}
+
+func TestAddressList_ScheduleOp(t *testing.T) {
+	state := &mockState{
+		state:     map[types.Hash]types.Hash{},
+		timestamp: 100,
+	}
+
+	a := NewAddressList(state, types.Address{})
+	a.SetRole(types.Address{}, AdminRole)
+
+	targetAddr := types.Address{0x1}
+
+	readScheduled := func() (uint64, uint64) {
+		input, _ := ReadScheduledRoleFunc.Encode([]interface{}{targetAddr})
+
+		ret, _, err := a.runInputCall(types.Address{}, input, readAddressListCost, false)
+		require.NoError(t, err)
+
+		raw, err := ReadScheduledRoleFunc.Outputs.Decode(ret)
+		require.NoError(t, err)
+
+		out := raw.(map[string]interface{}) //nolint:forcetypeassert
+
+		return uint64(out["0"].(uint8)), out["1"].(uint64) //nolint:forcetypeassert
+	}
+
+	input, _ := ScheduleRoleFunc.Encode([]interface{}{targetAddr, uint8(4), uint64(200)})
+	_, _, err := a.runInputCall(types.Address{}, input, writeAddressListCost, false)
+	require.Equal(t, errInvalidRole, err)
+
+	input, _ = ScheduleRoleFunc.Encode([]interface{}{targetAddr, uint8(1), uint64(100)})
+	_, _, err = a.runInputCall(types.Address{}, input, writeAddressListCost, false)
+	require.Equal(t, errActivationInPast, err)
+
+	input, _ = ScheduleRoleFunc.Encode([]interface{}{targetAddr, uint8(1), uint64(200)})
+	_, gasCost, err := a.runInputCall(types.Address{}, input, writeAddressListCost, false)
+	require.Equal(t, writeAddressListCost, gasCost)
+	require.NoError(t, err)
+
+	// the role doesn't change before the activation time
+	require.Equal(t, NoRole, a.GetRole(targetAddr))
+
+	role, activation := readScheduled()
+	require.Equal(t, uint64(1), role)
+	require.Equal(t, uint64(200), activation)
+
+	state.timestamp = 200
+
+	require.Equal(t, EnabledRole, a.GetRole(targetAddr))
+
+	role, activation = readScheduled()
+	require.Zero(t, role)
+	require.Zero(t, activation)
+
+	// the role in effect is kept when the next role is scheduled
+	input, _ = ScheduleRoleFunc.Encode([]interface{}{targetAddr, uint8(0), uint64(300)})
+	_, _, err = a.runInputCall(types.Address{}, input, writeAddressListCost, false)
+	require.NoError(t, err)
+	require.Equal(t, EnabledRole, a.GetRole(targetAddr))
+
+	// setting the role cancels the scheduled role
+	a.SetRole(targetAddr, AdminRole)
+
+	state.timestamp = 300
+
+	require.Equal(t, AdminRole, a.GetRole(targetAddr))
+}
+
+func TestAddressList_FreezeOp(t *testing.T) {
+	a := newMockAddressList()
+	a.SetRole(types.Address{}, AdminRole)
+	a.SetRole(types.Address{0x1}, FreezerRole)
+	a.SetRole(types.Address{0x2}, EnabledRole)
+
+	freeze, _ := FreezeFunc.Encode([]interface{}{})
+	unfreeze, _ := UnfreezeFunc.Encode([]interface{}{})
+	isFrozen, _ := IsFrozenFunc.Encode([]interface{}{})
+
+	_, _, err := a.runInputCall(types.Address{0x2}, freeze, writeAddressListCost, false)
+	require.Equal(t, runtime.ErrNotAuth, err)
+
+	_, _, err = a.runInputCall(types.Address{0x1}, freeze, writeAddressListCost, false)
+	require.NoError(t, err)
+	require.True(t, a.IsFrozen())
+
+	ret, _, err := a.runInputCall(types.Address{0x2}, isFrozen, readAddressListCost, false)
+	require.NoError(t, err)
+	require.Equal(t, types.Hash(EnabledRole).Bytes(), ret)
+
+	// only the admins can unfreeze the list
+	_, _, err = a.runInputCall(types.Address{0x1}, unfreeze, writeAddressListCost, false)
+	require.Equal(t, runtime.ErrNotAuth, err)
+
+	_, _, err = a.runInputCall(types.Address{}, unfreeze, writeAddressListCost, true)
+	require.Equal(t, errWriteProtection, err)
+
+	_, _, err = a.runInputCall(types.Address{}, unfreeze, writeAddressListCost, false)
+	require.NoError(t, err)
+	require.False(t, a.IsFrozen())
+}
+
 func TestRole_ToUint(t *testing.T) {
 	cases := []struct {
 		role Role
 		num  uint64
 	}{
+		{FreezerRole, uint64(3)},
 		{AdminRole, uint64(2)},
 		{EnabledRole, uint64(1)},
 		{NoRole, uint64(0)},
@@ -175,6 +319,7 @@ func TestRole_Enabled(t *testing.T) {
 	}{
 		{AdminRole, true},
 		{EnabledRole, true},
+		{FreezerRole, false},
 		{NoRole, false},
 	}
 
@@ -4,6 +4,7 @@ import (
 	"math/big"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -48,6 +49,10 @@ func (g *genesisState) SetState(addr types.Address, key, value types.Hash) {
 
 func (g *genesisState) GetStorage(addr types.Address, key types.Hash) types.Hash {
 	// since `genesisState` is used only as part of `ApplyGenesisAllocs` to set the initial
-	// roles in the contract. It never calls this `GetStorage` function.
+	// roles in the contract, there are no scheduled roles to read.
 	return types.Hash{}
 }
+
+func (g *genesisState) GetTxContext() runtime.TxContext {
+	return runtime.TxContext{Timestamp: int64(g.chain.Timestamp)}
+}
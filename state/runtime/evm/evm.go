@@ -18,6 +18,11 @@ func NewEVM() *EVM {
 	return &EVM{}
 }
 
+// Factory creates the built-in EVM as the execution engine
+func Factory() (runtime.Runtime, error) {
+	return NewEVM(), nil
+}
+
 // CanRun implements the runtime interface
 func (e *EVM) CanRun(*runtime.Contract, runtime.Host, *chain.ForksInTime) bool {
 	return true
@@ -40,6 +45,10 @@ func (e *EVM) Run(c *runtime.Contract, host runtime.Host, config *chain.ForksInT
 	contract.host = host
 	contract.config = config
 
+	if interruptible, ok := host.(runtime.Interruptible); ok {
+		contract.interrupt = interruptible.InterruptFlag()
+	}
+
 	contract.bitmap.setCode(c.Code)
 
 	ret, err := contract.Run()
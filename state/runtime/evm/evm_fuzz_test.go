@@ -61,6 +61,14 @@ func (m *mockHostF) SetState(addr types.Address, key types.Hash, value types.Has
 	return
 }
 
+func (m *mockHostF) GetTransientStorage(addr types.Address, key types.Hash) types.Hash {
+	return types.ZeroHash
+}
+
+func (m *mockHostF) SetTransientStorage(addr types.Address, key types.Hash, value types.Hash) {
+	return
+}
+
 func (m *mockHostF) SetNonPayable(nonPayable bool) {
 	return
 }
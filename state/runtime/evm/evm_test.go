@@ -1,8 +1,11 @@
 package evm
 
 import (
+	"math"
 	"math/big"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
@@ -45,6 +48,14 @@ func (m *mockHost) SetState(
 	panic("Not implemented in tests") //nolint:gocritic
 }
 
+func (m *mockHost) GetTransientStorage(addr types.Address, key types.Hash) types.Hash {
+	panic("Not implemented in tests") //nolint:gocritic
+}
+
+func (m *mockHost) SetTransientStorage(addr types.Address, key types.Hash, value types.Hash) {
+	panic("Not implemented in tests") //nolint:gocritic
+}
+
 func (m *mockHost) SetStorage(
 	addr types.Address,
 	key types.Hash,
@@ -200,6 +211,88 @@ func TestRun(t *testing.T) {
 	}
 }
 
+// the stats are shared by all the EVM instances, so the test doesn't run in parallel
+func TestRun_Stats(t *testing.T) {
+	EnableStats()
+	ResetStats()
+
+	t.Cleanup(func() {
+		stats.enabled.Store(false)
+		ResetStats()
+	})
+
+	code := []byte{
+		PUSH1, 0x01, PUSH1, 0x02, ADD,
+		PUSH1, 0x00, MSTORE8,
+	}
+
+	res := NewEVM().Run(newMockContract(big.NewInt(0), 5000, code), &mockHost{}, &chain.ForksInTime{})
+	assert.NoError(t, res.Err)
+
+	opcodes := GetStats()
+	assert.Len(t, opcodes, 3)
+
+	// the stats are ordered by the opcode
+	assert.Equal(t, "ADD", opcodes[0].Opcode)
+	assert.Equal(t, uint64(1), opcodes[0].Count)
+	assert.Equal(t, uint64(3), opcodes[0].Gas)
+
+	assert.Equal(t, "MSTORE8", opcodes[1].Opcode)
+	assert.Equal(t, uint64(1), opcodes[1].Count)
+	// the memory expansion is included
+	assert.Equal(t, uint64(6), opcodes[1].Gas)
+
+	assert.Equal(t, "PUSH1", opcodes[2].Opcode)
+	assert.Equal(t, uint64(3), opcodes[2].Count)
+	assert.Equal(t, uint64(9), opcodes[2].Gas)
+
+	ResetStats()
+	assert.Empty(t, GetStats())
+}
+
+type interruptibleMockHost struct {
+	mockHost
+
+	interrupt *atomic.Bool
+}
+
+func (m *interruptibleMockHost) InterruptFlag() *atomic.Bool {
+	return m.interrupt
+}
+
+func TestRun_Interrupted(t *testing.T) {
+	t.Parallel()
+
+	evm := NewEVM()
+	host := &interruptibleMockHost{interrupt: new(atomic.Bool)}
+
+	// endless loop
+	contract := newMockContract(big.NewInt(0), math.MaxUint64, []byte{JUMPDEST, PUSH1, 0x00, JUMP})
+
+	time.AfterFunc(10*time.Millisecond, func() {
+		host.interrupt.Store(true)
+	})
+
+	res := evm.Run(contract, host, &chain.ForksInTime{})
+
+	assert.ErrorIs(t, res.Err, runtime.ErrExecutionInterrupted)
+	assert.Equal(t, uint64(0), res.GasLeft)
+}
+
+func TestRun_NotInterruptible(t *testing.T) {
+	t.Parallel()
+
+	evm := NewEVM()
+
+	// the host without the interrupt flag runs the loop until it is out of gas
+	host := &interruptibleMockHost{}
+	contract := newMockContract(big.NewInt(0), 100_000, []byte{JUMPDEST, PUSH1, 0x00, JUMP})
+
+	res := evm.Run(contract, host, &chain.ForksInTime{})
+
+	assert.ErrorIs(t, res.Err, runtime.ErrOutOfGas)
+}
+
 type mockCall struct {
 	name string
 	args map[string]interface{}
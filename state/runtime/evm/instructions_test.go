@@ -797,3 +797,153 @@ func Test_opCall(t *testing.T) {
 		})
 	}
 }
+
+func TestPush0(t *testing.T) {
+	t.Run("pushes zero", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &allEnabledForks
+
+		opPush0(s)
+
+		assert.False(t, s.stop)
+		assert.Equal(t, 1, s.stackSize())
+		assert.Equal(t, uint64(0), s.pop().Uint64())
+	})
+
+	t.Run("fails if EIP-3855 is not enabled", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{}
+
+		opPush0(s)
+
+		assert.True(t, s.stop)
+		assert.Equal(t, errOpCodeNotFound, s.err)
+	})
+}
+
+func TestMCopy(t *testing.T) {
+	t.Run("copies overlapping areas", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &allEnabledForks
+		s.gas = 1000
+		s.memory = make([]byte, 64)
+
+		for i := 0; i < 32; i++ {
+			s.memory[i] = byte(i + 1)
+		}
+
+		s.push(big.NewInt(32)) // length
+		s.push(big.NewInt(0))  // src
+		s.push(big.NewInt(16)) // dst
+
+		opMCopy(s)
+
+		assert.False(t, s.stop)
+		assert.Equal(t, uint64(1000-3), s.gas)
+
+		for i := 0; i < 32; i++ {
+			assert.Equal(t, byte(i+1), s.memory[16+i])
+		}
+	})
+
+	t.Run("expands the memory", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &allEnabledForks
+		s.gas = 1000
+
+		s.push(big.NewInt(10)) // length
+		s.push(big.NewInt(64)) // src
+		s.push(big.NewInt(0))  // dst
+
+		opMCopy(s)
+
+		assert.False(t, s.stop)
+		assert.Len(t, s.memory, 96)
+	})
+
+	t.Run("fails if EIP-5656 is not enabled", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{}
+
+		opMCopy(s)
+
+		assert.True(t, s.stop)
+		assert.Equal(t, errOpCodeNotFound, s.err)
+	})
+}
+
+type mockHostForTransientStorage struct {
+	mockHost
+	transient map[types.Hash]types.Hash
+}
+
+func (m *mockHostForTransientStorage) GetTransientStorage(addr types.Address, key types.Hash) types.Hash {
+	return m.transient[key]
+}
+
+func (m *mockHostForTransientStorage) SetTransientStorage(addr types.Address, key types.Hash, value types.Hash) {
+	m.transient[key] = value
+}
+
+func TestTransientStorage(t *testing.T) {
+	t.Run("stores and loads the value", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &allEnabledForks
+		s.msg = &runtime.Contract{Address: addr1}
+		s.host = &mockHostForTransientStorage{transient: make(map[types.Hash]types.Hash)}
+
+		s.push(big.NewInt(10)) // value
+		s.push(big.NewInt(1))  // key
+
+		opTstore(s)
+		assert.False(t, s.stop)
+
+		s.push(big.NewInt(1))
+
+		opTload(s)
+		assert.False(t, s.stop)
+		assert.Equal(t, uint64(10), s.pop().Uint64())
+	})
+
+	t.Run("fails to store in the static call", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &allEnabledForks
+		s.msg = &runtime.Contract{Address: addr1, Static: true}
+
+		s.push(big.NewInt(10))
+		s.push(big.NewInt(1))
+
+		opTstore(s)
+
+		assert.True(t, s.stop)
+		assert.Equal(t, errWriteProtection, s.err)
+	})
+
+	t.Run("fails if EIP-1153 is not enabled", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{}
+
+		s.push(big.NewInt(1))
+
+		opTload(s)
+
+		assert.True(t, s.stop)
+		assert.Equal(t, errOpCodeNotFound, s.err)
+	})
+}
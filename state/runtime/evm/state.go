@@ -4,8 +4,9 @@ import (
 	"errors"
 	"math/big"
 	"strings"
-
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/helper/common"
@@ -34,7 +35,13 @@ func releaseState(s *state) {
 	statePool.Put(s)
 }
 
-const stackSize = 1024
+const (
+	stackSize = 1024
+
+	// interruptCheckInterval is the number of the steps after which the interruptible execution checks
+	// whether it has been interrupted
+	interruptCheckInterval = 1024
+)
 
 var (
 	errOutOfGas              = runtime.ErrOutOfGas
@@ -71,6 +78,9 @@ type state struct {
 	err  error
 	stop bool
 
+	// interrupt is set if the execution can be stopped from outside, it is checked every interruptCheckInterval steps
+	interrupt *atomic.Bool
+
 	gas                uint64
 	currentConsumedGas uint64
 
@@ -89,6 +99,7 @@ func (c *state) reset() {
 	c.lastGasCost = 0
 	c.stop = false
 	c.err = nil
+	c.interrupt = nil
 
 	// reset bitmap
 	c.bitmap.reset()
@@ -219,9 +230,24 @@ func (c *state) Run() ([]byte, error) {
 
 		op OpCode
 		ok bool
+
+		collectStats = stats.enabled.Load()
+		start        time.Time
+
+		steps uint64
 	)
 
 	for !c.stop {
+		if c.interrupt != nil {
+			if steps%interruptCheckInterval == 0 && c.interrupt.Load() {
+				c.exit(runtime.ErrExecutionInterrupted)
+
+				break
+			}
+
+			steps++
+		}
+
 		op, ok = c.CurrentOpCode()
 		gasCopy, ipCopy := c.gas, uint64(c.ip)
 
@@ -257,9 +283,17 @@ func (c *state) Run() ([]byte, error) {
 			break
 		}
 
+		if collectStats {
+			start = time.Now()
+		}
+
 		// execute the instruction
 		inst.inst(c)
 
+		if collectStats {
+			stats.record(op, gasCopy-c.gas, time.Since(start))
+		}
+
 		c.captureExecution(op.String(), ipCopy, gasCopy, gasCopy-c.gas)
 
 		// check if stack size exceeds the max size
@@ -0,0 +1,73 @@
+package evm
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// stats is the collector of the opcode statistics, shared by all the EVM instances
+var stats opcodeStatsCollector
+
+// opcodeStatsCollector counts the executed opcodes along with the gas and the time they took
+type opcodeStatsCollector struct {
+	enabled atomic.Bool
+
+	count    [256]atomic.Uint64
+	gas      [256]atomic.Uint64
+	duration [256]atomic.Int64
+}
+
+func (s *opcodeStatsCollector) record(op OpCode, gas uint64, duration time.Duration) {
+	s.count[op].Add(1)
+	s.gas[op].Add(gas)
+	s.duration[op].Add(int64(duration))
+}
+
+// OpcodeStats are the statistics of the opcode executions.
+// The gas and the duration of the call and create opcodes include the nested executions
+type OpcodeStats struct {
+	Opcode   string
+	Count    uint64
+	Gas      uint64
+	Duration time.Duration
+}
+
+// EnableStats enables the collection of the opcode statistics
+func EnableStats() {
+	stats.enabled.Store(true)
+}
+
+// StatsEnabled tells if the opcode statistics are collected
+func StatsEnabled() bool {
+	return stats.enabled.Load()
+}
+
+// GetStats returns the statistics of the executed opcodes, ordered by the opcode
+func GetStats() []*OpcodeStats {
+	result := []*OpcodeStats{}
+
+	for op := range stats.count {
+		count := stats.count[op].Load()
+		if count == 0 {
+			continue
+		}
+
+		result = append(result, &OpcodeStats{
+			Opcode:   OpCode(op).String(),
+			Count:    count,
+			Gas:      stats.gas[op].Load(),
+			Duration: time.Duration(stats.duration[op].Load()),
+		})
+	}
+
+	return result
+}
+
+// ResetStats clears the collected opcode statistics
+func ResetStats() {
+	for op := range stats.count {
+		stats.count[op].Store(0)
+		stats.gas[op].Store(0)
+		stats.duration[op].Store(0)
+	}
+}
@@ -0,0 +1,447 @@
+package precompiled
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/coinbase/kryptology/pkg/core/curves/native"
+	"github.com/coinbase/kryptology/pkg/core/curves/native/bls12381"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// The BLS12-381 precompiles of EIP-2537. The encoded field element takes 64 bytes, the value
+// being left padded by 16 zero bytes, the point at infinity is encoded as zeros
+const (
+	blsFpLength     = 64
+	blsG1Length     = 2 * blsFpLength
+	blsG2Length     = 4 * blsFpLength
+	blsScalarLength = 32
+
+	blsG1AddGas          = 375
+	blsG2AddGas          = 600
+	blsG1MulGas          = 12000
+	blsG2MulGas          = 22500
+	blsPairingBaseGas    = 37700
+	blsPairingPerPairGas = 32600
+	blsMapFpToG1Gas      = 5500
+	blsMapFp2ToG2Gas     = 23800
+
+	// blsFlagsMask clears the flags kryptology stores in the top bits of the uncompressed point
+	blsFlagsMask = 0x1f
+)
+
+var (
+	errBLS12381InvalidInputLength  = errors.New("invalid input length")
+	errBLS12381InvalidFieldElement = errors.New("invalid field element")
+	errBLS12381PointNotOnCurve     = errors.New("point is not on the curve")
+	errBLS12381PointNotInSubgroup  = errors.New("point is not in the subgroup")
+)
+
+var (
+	// blsG1MultiExpDiscount is the discount per mille of the multiplications by the number of the pairs
+	blsG1MultiExpDiscount = []uint64{
+		1000, 949, 848, 797, 764, 750, 738, 728, 719, 712, 705, 698, 692, 687, 682, 677, 673, 669, 665, 661,
+		658, 654, 651, 648, 645, 642, 640, 637, 635, 632, 630, 627, 625, 623, 621, 619, 617, 615, 613, 611,
+		609, 608, 606, 604, 603, 601, 599, 598, 596, 595, 593, 592, 591, 589, 588, 586, 585, 584, 582, 581,
+		580, 579, 577, 576, 575, 574, 573, 572, 570, 569, 568, 567, 566, 565, 564, 563, 562, 561, 560, 559,
+		558, 557, 556, 555, 554, 553, 552, 551, 550, 549, 548, 547, 547, 546, 545, 544, 543, 542, 541, 540,
+		540, 539, 538, 537, 536, 536, 535, 534, 533, 532, 532, 531, 530, 529, 528, 528, 527, 526, 525, 525,
+		524, 523, 522, 522, 521, 520, 520, 519,
+	}
+	// blsG2MultiExpDiscount is the discount per mille of the multiplications by the number of the pairs
+	blsG2MultiExpDiscount = []uint64{
+		1000, 1000, 923, 884, 855, 832, 812, 796, 782, 770, 759, 749, 740, 732, 724, 717, 711, 704, 699, 693,
+		688, 683, 679, 674, 670, 666, 663, 659, 655, 652, 649, 646, 643, 640, 637, 634, 632, 629, 627, 624,
+		622, 620, 618, 615, 613, 611, 609, 607, 606, 604, 602, 600, 598, 597, 595, 593, 592, 590, 589, 587,
+		586, 584, 583, 582, 580, 579, 578, 576, 575, 574, 573, 571, 570, 569, 568, 567, 566, 565, 563, 562,
+		561, 560, 559, 558, 557, 556, 555, 554, 553, 552, 552, 551, 550, 549, 548, 547, 546, 545, 545, 544,
+		543, 542, 541, 541, 540, 539, 538, 537, 537, 536, 535, 535, 534, 533, 532, 532, 531, 530, 530, 529,
+		528, 528, 527, 526, 526, 525, 524, 524,
+	}
+)
+
+// bls12381G1Add adds two points of G1, which may be outside of the subgroup
+type bls12381G1Add struct{}
+
+func (c *bls12381G1Add) gas(_ []byte, _ *chain.ForksInTime) uint64 {
+	return blsG1AddGas
+}
+
+func (c *bls12381G1Add) run(input []byte, _ types.Address, _ runtime.Host) ([]byte, error) {
+	if len(input) != 2*blsG1Length {
+		return nil, errBLS12381InvalidInputLength
+	}
+
+	p0, err := decodeBLSG1(input[:blsG1Length])
+	if err != nil {
+		return nil, err
+	}
+
+	p1, err := decodeBLSG1(input[blsG1Length:])
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeBLSG1(p0.add(p1)), nil
+}
+
+// bls12381G2Add adds two points of G2, which may be outside of the subgroup
+type bls12381G2Add struct{}
+
+func (c *bls12381G2Add) gas(_ []byte, _ *chain.ForksInTime) uint64 {
+	return blsG2AddGas
+}
+
+func (c *bls12381G2Add) run(input []byte, _ types.Address, _ runtime.Host) ([]byte, error) {
+	if len(input) != 2*blsG2Length {
+		return nil, errBLS12381InvalidInputLength
+	}
+
+	p0, err := decodeBLSG2(input[:blsG2Length])
+	if err != nil {
+		return nil, err
+	}
+
+	p1, err := decodeBLSG2(input[blsG2Length:])
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeBLSG2(p0.add(p1)), nil
+}
+
+// bls12381G1MultiExp computes the sum of the products of the points of G1 and the scalars
+type bls12381G1MultiExp struct{}
+
+func (c *bls12381G1MultiExp) gas(input []byte, _ *chain.ForksInTime) uint64 {
+	return blsMultiExpGas(len(input)/(blsG1Length+blsScalarLength), blsG1MulGas, blsG1MultiExpDiscount)
+}
+
+func (c *bls12381G1MultiExp) run(input []byte, _ types.Address, _ runtime.Host) ([]byte, error) {
+	pairLength := blsG1Length + blsScalarLength
+	if len(input) == 0 || len(input)%pairLength != 0 {
+		return nil, errBLS12381InvalidInputLength
+	}
+
+	points := make([]*bls12381.G1, 0, len(input)/pairLength)
+	scalars := make([]*native.Field, 0, len(input)/pairLength)
+
+	for ; len(input) > 0; input = input[pairLength:] {
+		p, err := decodeBLSG1Subgroup(input[:blsG1Length])
+		if err != nil {
+			return nil, err
+		}
+
+		points = append(points, p)
+		scalars = append(scalars, decodeBLSScalar(input[blsG1Length:pairLength]))
+	}
+
+	r, err := new(bls12381.G1).SumOfProducts(points, scalars)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeBLSG1Subgroup(r), nil
+}
+
+// bls12381G2MultiExp computes the sum of the products of the points of G2 and the scalars
+type bls12381G2MultiExp struct{}
+
+func (c *bls12381G2MultiExp) gas(input []byte, _ *chain.ForksInTime) uint64 {
+	return blsMultiExpGas(len(input)/(blsG2Length+blsScalarLength), blsG2MulGas, blsG2MultiExpDiscount)
+}
+
+func (c *bls12381G2MultiExp) run(input []byte, _ types.Address, _ runtime.Host) ([]byte, error) {
+	pairLength := blsG2Length + blsScalarLength
+	if len(input) == 0 || len(input)%pairLength != 0 {
+		return nil, errBLS12381InvalidInputLength
+	}
+
+	points := make([]*bls12381.G2, 0, len(input)/pairLength)
+	scalars := make([]*native.Field, 0, len(input)/pairLength)
+
+	for ; len(input) > 0; input = input[pairLength:] {
+		p, err := decodeBLSG2Subgroup(input[:blsG2Length])
+		if err != nil {
+			return nil, err
+		}
+
+		points = append(points, p)
+		scalars = append(scalars, decodeBLSScalar(input[blsG2Length:pairLength]))
+	}
+
+	r, err := new(bls12381.G2).SumOfProducts(points, scalars)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeBLSG2Subgroup(r), nil
+}
+
+// blsMultiExpGas returns the gas of the multiplications of k pairs, discounted by the number of the pairs
+func blsMultiExpGas(k int, mulGas uint64, discounts []uint64) uint64 {
+	if k == 0 {
+		return 0
+	}
+
+	discount := discounts[len(discounts)-1]
+	if k <= len(discounts) {
+		discount = discounts[k-1]
+	}
+
+	return uint64(k) * mulGas * discount / 1000
+}
+
+// bls12381Pairing checks whether the product of the pairings of the points of G1 and G2 is the identity
+type bls12381Pairing struct{}
+
+func (c *bls12381Pairing) gas(input []byte, _ *chain.ForksInTime) uint64 {
+	return blsPairingBaseGas + blsPairingPerPairGas*uint64(len(input)/(blsG1Length+blsG2Length))
+}
+
+func (c *bls12381Pairing) run(input []byte, _ types.Address, _ runtime.Host) ([]byte, error) {
+	pairLength := blsG1Length + blsG2Length
+	if len(input) == 0 || len(input)%pairLength != 0 {
+		return nil, errBLS12381InvalidInputLength
+	}
+
+	engine := new(bls12381.Engine)
+
+	for ; len(input) > 0; input = input[pairLength:] {
+		p1, err := decodeBLSG1Subgroup(input[:blsG1Length])
+		if err != nil {
+			return nil, err
+		}
+
+		p2, err := decodeBLSG2Subgroup(input[blsG1Length:pairLength])
+		if err != nil {
+			return nil, err
+		}
+
+		engine.AddPair(p1, p2)
+	}
+
+	if engine.Check() {
+		return abiBoolTrue, nil
+	}
+
+	return abiBoolFalse, nil
+}
+
+// bls12381MapFpToG1 maps the element of Fp to G1
+type bls12381MapFpToG1 struct{}
+
+func (c *bls12381MapFpToG1) gas(_ []byte, _ *chain.ForksInTime) uint64 {
+	return blsMapFpToG1Gas
+}
+
+func (c *bls12381MapFpToG1) run(input []byte, _ types.Address, _ runtime.Host) ([]byte, error) {
+	if len(input) != blsFpLength {
+		return nil, errBLS12381InvalidInputLength
+	}
+
+	u, err := decodeBLSFp(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeBLSG1(mapFpToG1(u)), nil
+}
+
+// bls12381MapFp2ToG2 maps the element of Fp2 to G2
+type bls12381MapFp2ToG2 struct{}
+
+func (c *bls12381MapFp2ToG2) gas(_ []byte, _ *chain.ForksInTime) uint64 {
+	return blsMapFp2ToG2Gas
+}
+
+func (c *bls12381MapFp2ToG2) run(input []byte, _ types.Address, _ runtime.Host) ([]byte, error) {
+	if len(input) != 2*blsFpLength {
+		return nil, errBLS12381InvalidInputLength
+	}
+
+	u, err := decodeBLSFp2(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeBLSG2(mapFp2ToG2(u)), nil
+}
+
+// decodeBLSFp decodes the element of Fp, whose padding must be zero and whose value must be below the modulus
+func decodeBLSFp(buf []byte) (*big.Int, error) {
+	for _, b := range buf[:blsFpLength-bls12381.FieldBytes] {
+		if b != 0 {
+			return nil, errBLS12381InvalidFieldElement
+		}
+	}
+
+	v := new(big.Int).SetBytes(buf)
+	if v.Cmp(blsModulus) >= 0 {
+		return nil, errBLS12381InvalidFieldElement
+	}
+
+	return v, nil
+}
+
+// decodeBLSFp2 decodes the element c0 + c1 * i of Fp2, encoded as c0 || c1
+func decodeBLSFp2(buf []byte) (fp2, error) {
+	c0, err := decodeBLSFp(buf[:blsFpLength])
+	if err != nil {
+		return fp2{}, err
+	}
+
+	c1, err := decodeBLSFp(buf[blsFpLength:])
+	if err != nil {
+		return fp2{}, err
+	}
+
+	return fp2{c0, c1}, nil
+}
+
+// decodeBLSG1 decodes the point x || y of the curve of G1, which may be outside of the subgroup
+func decodeBLSG1(buf []byte) (*blsPoint, error) {
+	x, err := decodeBLSFp(buf[:blsFpLength])
+	if err != nil {
+		return nil, err
+	}
+
+	y, err := decodeBLSFp(buf[blsFpLength:])
+	if err != nil {
+		return nil, err
+	}
+
+	return newBLSPoint(fp2{x, new(big.Int)}, fp2{y, new(big.Int)}, blsG1B)
+}
+
+// decodeBLSG2 decodes the point x || y of the curve of G2, which may be outside of the subgroup
+func decodeBLSG2(buf []byte) (*blsPoint, error) {
+	x, err := decodeBLSFp2(buf[:2*blsFpLength])
+	if err != nil {
+		return nil, err
+	}
+
+	y, err := decodeBLSFp2(buf[2*blsFpLength:])
+	if err != nil {
+		return nil, err
+	}
+
+	return newBLSPoint(x, y, blsG2B)
+}
+
+func newBLSPoint(x, y, b fp2) (*blsPoint, error) {
+	if x.isZero() && y.isZero() {
+		return &blsPoint{infinity: true}, nil
+	}
+
+	p := &blsPoint{x: x, y: y}
+	if !p.isOnCurve(b) {
+		return nil, errBLS12381PointNotOnCurve
+	}
+
+	return p, nil
+}
+
+// decodeBLSG1Subgroup decodes the point of G1, which must be in the subgroup
+func decodeBLSG1Subgroup(buf []byte) (*bls12381.G1, error) {
+	p, err := decodeBLSG1(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.infinity {
+		return new(bls12381.G1).Identity(), nil
+	}
+
+	g1, err := new(bls12381.G1).SetBigInt(p.x.c0, p.y.c0)
+	if err != nil {
+		return nil, errBLS12381PointNotInSubgroup
+	}
+
+	return g1, nil
+}
+
+// decodeBLSG2Subgroup decodes the point of G2, which must be in the subgroup
+func decodeBLSG2Subgroup(buf []byte) (*bls12381.G2, error) {
+	p, err := decodeBLSG2(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.infinity {
+		return new(bls12381.G2).Identity(), nil
+	}
+
+	// kryptology takes the coordinates as c1 || c0
+	join := func(e fp2) *big.Int {
+		return new(big.Int).Or(new(big.Int).Lsh(e.c1, 8*bls12381.FieldBytes), e.c0)
+	}
+
+	g2, err := new(bls12381.G2).SetBigInt(join(p.x), join(p.y))
+	if err != nil {
+		return nil, errBLS12381PointNotInSubgroup
+	}
+
+	return g2, nil
+}
+
+// decodeBLSScalar decodes the 32 bytes scalar, reduced by the order of the subgroup
+func decodeBLSScalar(buf []byte) *native.Field {
+	return bls12381.Bls12381FqNew().SetBigInt(new(big.Int).SetBytes(buf))
+}
+
+func encodeBLSG1(p *blsPoint) []byte {
+	out := make([]byte, blsG1Length)
+	if !p.infinity {
+		p.x.c0.FillBytes(out[:blsFpLength])
+		p.y.c0.FillBytes(out[blsFpLength:])
+	}
+
+	return out
+}
+
+func encodeBLSG2(p *blsPoint) []byte {
+	out := make([]byte, blsG2Length)
+	if !p.infinity {
+		p.x.c0.FillBytes(out[:blsFpLength])
+		p.x.c1.FillBytes(out[blsFpLength : 2*blsFpLength])
+		p.y.c0.FillBytes(out[2*blsFpLength : 3*blsFpLength])
+		p.y.c1.FillBytes(out[3*blsFpLength:])
+	}
+
+	return out
+}
+
+// encodeBLSG1Subgroup encodes the point of kryptology, whose uncompressed form is x || y
+// of 48 bytes each, the point at infinity having the zero coordinates and the flag bit set
+func encodeBLSG1Subgroup(p *bls12381.G1) []byte {
+	raw := p.ToUncompressed()
+	raw[0] &= blsFlagsMask
+
+	n := bls12381.FieldBytes
+
+	return encodeBLSFieldElements(raw[:n], raw[n:])
+}
+
+// encodeBLSG2Subgroup encodes the point of kryptology, whose uncompressed form is x.c1 || x.c0 || y.c1 || y.c0
+func encodeBLSG2Subgroup(p *bls12381.G2) []byte {
+	raw := p.ToUncompressed()
+	raw[0] &= blsFlagsMask
+
+	n := bls12381.FieldBytes
+
+	return encodeBLSFieldElements(raw[n:2*n], raw[:n], raw[3*n:], raw[2*n:3*n])
+}
+
+// encodeBLSFieldElements left pads the 48 bytes field elements
+func encodeBLSFieldElements(elements ...[]byte) []byte {
+	out := make([]byte, len(elements)*blsFpLength)
+	for i, e := range elements {
+		copy(out[(i+1)*blsFpLength-len(e):], e)
+	}
+
+	return out
+}
@@ -0,0 +1,301 @@
+package precompiled
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/coinbase/kryptology/pkg/core/curves/native"
+	"github.com/coinbase/kryptology/pkg/core/curves/native/bls12381"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func blsG1Generator() *bls12381.G1 {
+	return new(bls12381.G1).Generator()
+}
+
+func blsG2Generator() *bls12381.G2 {
+	return new(bls12381.G2).Generator()
+}
+
+func blsScalar(k int64) *native.Field {
+	return bls12381.Bls12381FqNew().SetBigInt(big.NewInt(k))
+}
+
+func blsScalarBytes(k int64) []byte {
+	return types.BytesToHash(big.NewInt(k).Bytes()).Bytes()
+}
+
+func concat(parts ...[]byte) []byte {
+	var out []byte
+	for _, p := range parts {
+		out = append(out, p...)
+	}
+
+	return out
+}
+
+// blsG1OutsideSubgroup returns the point of the curve of G1, which isn't in the subgroup
+func blsG1OutsideSubgroup(t *testing.T) []byte {
+	t.Helper()
+
+	for x := int64(1); ; x++ {
+		xx := big.NewInt(x)
+
+		y, ok := fpSqrt(fpAdd(fpMul(fpMul(xx, xx), xx), big.NewInt(4)))
+		if !ok {
+			continue
+		}
+
+		if _, err := new(bls12381.G1).SetBigInt(xx, y); err == nil {
+			continue
+		}
+
+		return encodeBLSG1(&blsPoint{x: fp2{xx, new(big.Int)}, y: fp2{y, new(big.Int)}})
+	}
+}
+
+func TestBLS12381_G1Add(t *testing.T) {
+	t.Parallel()
+
+	g := encodeBLSG1Subgroup(blsG1Generator())
+	infinity := make([]byte, blsG1Length)
+	negG := encodeBLSG1Subgroup(new(bls12381.G1).Neg(blsG1Generator()))
+	outside := blsG1OutsideSubgroup(t)
+
+	out, err := (&bls12381G1Add{}).run(concat(g, g), types.ZeroAddress, nil)
+	require.NoError(t, err)
+	require.Equal(t, encodeBLSG1Subgroup(new(bls12381.G1).Double(blsG1Generator())), out)
+
+	out, err = (&bls12381G1Add{}).run(concat(g, negG), types.ZeroAddress, nil)
+	require.NoError(t, err)
+	require.Equal(t, infinity, out)
+
+	out, err = (&bls12381G1Add{}).run(concat(infinity, g), types.ZeroAddress, nil)
+	require.NoError(t, err)
+	require.Equal(t, g, out)
+
+	// the points outside of the subgroup are added
+	_, err = (&bls12381G1Add{}).run(concat(outside, g), types.ZeroAddress, nil)
+	require.NoError(t, err)
+
+	notOnCurve := append([]byte{}, g...)
+	notOnCurve[blsG1Length-1] ^= 1
+
+	topBytes := append([]byte{}, g...)
+	topBytes[0] = 1
+
+	aboveModulus := append([]byte{}, g...)
+	blsModulus.FillBytes(aboveModulus[:blsFpLength])
+
+	for _, c := range []struct {
+		input []byte
+		err   error
+	}{
+		{g, errBLS12381InvalidInputLength},
+		{concat(g, notOnCurve), errBLS12381PointNotOnCurve},
+		{concat(topBytes, g), errBLS12381InvalidFieldElement},
+		{concat(aboveModulus, g), errBLS12381InvalidFieldElement},
+	} {
+		_, err := (&bls12381G1Add{}).run(c.input, types.ZeroAddress, nil)
+		require.ErrorIs(t, err, c.err)
+	}
+}
+
+func TestBLS12381_G2Add(t *testing.T) {
+	t.Parallel()
+
+	g := encodeBLSG2Subgroup(blsG2Generator())
+
+	out, err := (&bls12381G2Add{}).run(concat(g, g), types.ZeroAddress, nil)
+	require.NoError(t, err)
+	require.Equal(t, encodeBLSG2Subgroup(new(bls12381.G2).Double(blsG2Generator())), out)
+
+	out, err = (&bls12381G2Add{}).run(concat(g, encodeBLSG2Subgroup(new(bls12381.G2).Neg(blsG2Generator()))),
+		types.ZeroAddress, nil)
+	require.NoError(t, err)
+	require.Equal(t, make([]byte, blsG2Length), out)
+
+	_, err = (&bls12381G2Add{}).run(concat(g, g[:blsG2Length-1]), types.ZeroAddress, nil)
+	require.ErrorIs(t, err, errBLS12381InvalidInputLength)
+}
+
+func TestBLS12381_MultiExp(t *testing.T) {
+	t.Parallel()
+
+	g1 := encodeBLSG1Subgroup(blsG1Generator())
+	g2 := encodeBLSG2Subgroup(blsG2Generator())
+
+	// 3G + 5G
+	out, err := (&bls12381G1MultiExp{}).run(concat(g1, blsScalarBytes(3), g1, blsScalarBytes(5)), types.ZeroAddress, nil)
+	require.NoError(t, err)
+	require.Equal(t, encodeBLSG1Subgroup(new(bls12381.G1).Mul(blsG1Generator(), blsScalar(8))), out)
+
+	out, err = (&bls12381G2MultiExp{}).run(concat(g2, blsScalarBytes(7)), types.ZeroAddress, nil)
+	require.NoError(t, err)
+	require.Equal(t, encodeBLSG2Subgroup(new(bls12381.G2).Mul(blsG2Generator(), blsScalar(7))), out)
+
+	// the points must be in the subgroup
+	_, err = (&bls12381G1MultiExp{}).run(concat(blsG1OutsideSubgroup(t), blsScalarBytes(1)), types.ZeroAddress, nil)
+	require.ErrorIs(t, err, errBLS12381PointNotInSubgroup)
+
+	_, err = (&bls12381G1MultiExp{}).run(nil, types.ZeroAddress, nil)
+	require.ErrorIs(t, err, errBLS12381InvalidInputLength)
+
+	config := chain.AllForksEnabled.At(0)
+
+	require.Equal(t, uint64(12000), (&bls12381G1MultiExp{}).gas(make([]byte, 160), &config))
+	require.Equal(t, uint64(2*12000*949/1000), (&bls12381G1MultiExp{}).gas(make([]byte, 2*160), &config))
+	require.Equal(t, uint64(200*12000*519/1000), (&bls12381G1MultiExp{}).gas(make([]byte, 200*160), &config))
+	require.Equal(t, uint64(22500), (&bls12381G2MultiExp{}).gas(make([]byte, 288), &config))
+}
+
+func TestBLS12381_Pairing(t *testing.T) {
+	t.Parallel()
+
+	a := blsScalar(42)
+
+	// e(aP, Q) * e(-P, aQ) = 1
+	input := concat(
+		encodeBLSG1Subgroup(new(bls12381.G1).Mul(blsG1Generator(), a)),
+		encodeBLSG2Subgroup(blsG2Generator()),
+		encodeBLSG1Subgroup(new(bls12381.G1).Neg(blsG1Generator())),
+		encodeBLSG2Subgroup(new(bls12381.G2).Mul(blsG2Generator(), a)),
+	)
+
+	out, err := (&bls12381Pairing{}).run(input, types.ZeroAddress, nil)
+	require.NoError(t, err)
+	require.Equal(t, abiBoolTrue, out)
+
+	out, err = (&bls12381Pairing{}).run(input[:blsG1Length+blsG2Length], types.ZeroAddress, nil)
+	require.NoError(t, err)
+	require.Equal(t, abiBoolFalse, out)
+
+	_, err = (&bls12381Pairing{}).run(nil, types.ZeroAddress, nil)
+	require.ErrorIs(t, err, errBLS12381InvalidInputLength)
+}
+
+// TestBLS12381_Map checks the sum of the maps of the two field elements, hashed from the message,
+// is the point the hash to curve of kryptology returns
+func TestBLS12381_Map(t *testing.T) {
+	t.Parallel()
+
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_RO_")
+
+	fieldElement := func(b []byte) []byte {
+		u := new(big.Int).Mod(new(big.Int).SetBytes(b), blsModulus)
+
+		return u.FillBytes(make([]byte, blsFpLength))
+	}
+
+	for _, msg := range []string{"", "abc", "abcdef0123456789"} {
+		u := native.ExpandMsgXmd(native.EllipticPointHasherSha256(), []byte(msg), dst, 128)
+
+		p0, err := (&bls12381MapFpToG1{}).run(fieldElement(u[:64]), types.ZeroAddress, nil)
+		require.NoError(t, err)
+
+		p1, err := (&bls12381MapFpToG1{}).run(fieldElement(u[64:]), types.ZeroAddress, nil)
+		require.NoError(t, err)
+
+		sum, err := (&bls12381G1Add{}).run(concat(p0, p1), types.ZeroAddress, nil)
+		require.NoError(t, err)
+
+		expected := new(bls12381.G1).Hash(native.EllipticPointHasherSha256(), []byte(msg), dst)
+		require.Equal(t, encodeBLSG1Subgroup(expected), sum)
+
+		u = native.ExpandMsgXmd(native.EllipticPointHasherSha256(), []byte(msg), dst, 256)
+
+		q0, err := (&bls12381MapFp2ToG2{}).run(concat(fieldElement(u[:64]), fieldElement(u[64:128])),
+			types.ZeroAddress, nil)
+		require.NoError(t, err)
+
+		q1, err := (&bls12381MapFp2ToG2{}).run(concat(fieldElement(u[128:192]), fieldElement(u[192:])),
+			types.ZeroAddress, nil)
+		require.NoError(t, err)
+
+		sum, err = (&bls12381G2Add{}).run(concat(q0, q1), types.ZeroAddress, nil)
+		require.NoError(t, err)
+
+		expectedG2 := new(bls12381.G2).Hash(native.EllipticPointHasherSha256(), []byte(msg), dst)
+		require.Equal(t, encodeBLSG2Subgroup(expectedG2), sum)
+	}
+
+	_, err := (&bls12381MapFpToG1{}).run(make([]byte, blsFpLength+1), types.ZeroAddress, nil)
+	require.ErrorIs(t, err, errBLS12381InvalidInputLength)
+}
+
+func TestBLS12381_Fork(t *testing.T) {
+	t.Parallel()
+
+	forks := chain.AllForksEnabled.Copy()
+	forks.SetFork(chain.EIP2537, chain.NewFork(10))
+
+	p := NewPrecompiled()
+	contract := &runtime.Contract{CodeAddress: types.StringToAddress("f")}
+
+	before, after := forks.At(9), forks.At(10)
+
+	require.False(t, p.CanRun(contract, nil, &before))
+	require.True(t, p.CanRun(contract, nil, &after))
+}
+
+func TestBLS12381_Vectors(t *testing.T) {
+	t.Parallel()
+
+	forks := chain.AllForksEnabled.At(0)
+
+	vectors := []struct {
+		name string
+		c    contract
+	}{
+		{"g1add", &bls12381G1Add{}},
+		{"g2add", &bls12381G2Add{}},
+		{"g1msm", &bls12381G1MultiExp{}},
+		{"g2msm", &bls12381G2MultiExp{}},
+		{"pairing", &bls12381Pairing{}},
+	}
+
+	for _, v := range vectors {
+		v := v
+
+		t.Run(v.name, func(t *testing.T) {
+			ReadTestCase(t, "bls12381_"+v.name+".json", func(t *testing.T, c *TestCase) {
+				t.Helper()
+
+				out, err := v.c.run(c.Input, types.ZeroAddress, nil)
+				require.NoError(t, err)
+				require.Equal(t, c.Expected, out)
+				require.Equal(t, c.Gas, v.c.gas(c.Input, &forks))
+			})
+		})
+	}
+
+	failures := []struct {
+		name string
+		c    contract
+	}{
+		{"g1add", &bls12381G1Add{}},
+		{"g2add", &bls12381G2Add{}},
+		{"g1msm", &bls12381G1MultiExp{}},
+		{"g2msm", &bls12381G2MultiExp{}},
+		{"pairing", &bls12381Pairing{}},
+		{"map_fp_to_g1", &bls12381MapFpToG1{}},
+		{"map_fp2_to_g2", &bls12381MapFp2ToG2{}},
+	}
+
+	for _, v := range failures {
+		v := v
+
+		t.Run(v.name+"_fail", func(t *testing.T) {
+			ReadFailureTestCase(t, "bls12381_"+v.name+"_fail.json", func(t *testing.T, c *FailureTestCase) {
+				t.Helper()
+
+				_, err := v.c.run(c.Input, types.ZeroAddress, nil)
+				require.EqualError(t, err, c.ExpectedError)
+			})
+		})
+	}
+}
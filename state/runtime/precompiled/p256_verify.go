@@ -0,0 +1,53 @@
+package precompiled
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	p256VerifyInputLength = 160
+	p256VerifyGas         = 3450
+)
+
+// p256Verify verifies the secp256r1 (P-256) signature of RIP-7212.
+// The input is hash || r || s || x || y, 32 bytes each. The output is the 32 bytes one if the signature is valid,
+// otherwise it is empty, the invalid input doesn't fail the call
+type p256Verify struct{}
+
+func (c *p256Verify) gas(_ []byte, _ *chain.ForksInTime) uint64 {
+	return p256VerifyGas
+}
+
+func (c *p256Verify) run(input []byte, _ types.Address, _ runtime.Host) ([]byte, error) {
+	if len(input) != p256VerifyInputLength {
+		return nil, nil
+	}
+
+	hash := input[:32]
+	r := new(big.Int).SetBytes(input[32:64])
+	s := new(big.Int).SetBytes(input[64:96])
+
+	// the public key must be the valid point, other than the point at infinity
+	if _, err := ecdh.P256().NewPublicKey(append([]byte{4}, input[96:]...)); err != nil {
+		return nil, nil
+	}
+
+	key := &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(input[96:128]),
+		Y:     new(big.Int).SetBytes(input[128:]),
+	}
+
+	if !ecdsa.Verify(key, hash, r, s) {
+		return nil, nil
+	}
+
+	return abiBoolTrue, nil
+}
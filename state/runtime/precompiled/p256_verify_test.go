@@ -0,0 +1,80 @@
+package precompiled
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestP256Verify(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	hash := crypto.Keccak256([]byte("message"))
+
+	r, s, err := ecdsa.Sign(rand.Reader, key, hash)
+	require.NoError(t, err)
+
+	input := concat(
+		hash,
+		types.BytesToHash(r.Bytes()).Bytes(),
+		types.BytesToHash(s.Bytes()).Bytes(),
+		types.BytesToHash(key.X.Bytes()).Bytes(),
+		types.BytesToHash(key.Y.Bytes()).Bytes(),
+	)
+
+	invalidHash := concat(crypto.Keccak256([]byte("other")), input[32:])
+	invalidKey := concat(input[:96], make([]byte, 64))
+
+	c := &p256Verify{}
+
+	cases := []struct {
+		name   string
+		input  []byte
+		output []byte
+	}{
+		{"valid signature", input, abiBoolTrue},
+		{"different hash", invalidHash, nil},
+		{"point at infinity", invalidKey, nil},
+		{"short input", input[:159], nil},
+		{"long input", append(concat(input), 0), nil},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := c.run(tc.input, types.ZeroAddress, nil)
+			require.NoError(t, err)
+			require.Equal(t, tc.output, output)
+			require.Equal(t, uint64(3450), c.gas(tc.input, nil))
+		})
+	}
+}
+
+func TestP256Verify_Fork(t *testing.T) {
+	t.Parallel()
+
+	forks := chain.AllForksEnabled.Copy()
+	forks.SetFork(chain.RIP7212, chain.NewFork(10))
+
+	p := NewPrecompiled()
+	contract := &runtime.Contract{CodeAddress: types.StringToAddress("100")}
+
+	before, after := forks.At(9), forks.At(10)
+
+	require.False(t, p.CanRun(contract, nil, &before))
+	require.True(t, p.CanRun(contract, nil, &after))
+}
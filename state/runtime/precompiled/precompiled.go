@@ -70,6 +70,18 @@ func (p *Precompiled) setupContracts() {
 	// Istanbul fork
 	p.register("9", &blake2f{p})
 
+	// EIP-2537 fork
+	p.register("b", &bls12381G1Add{})
+	p.register("c", &bls12381G1MultiExp{})
+	p.register("d", &bls12381G2Add{})
+	p.register("e", &bls12381G2MultiExp{})
+	p.register("f", &bls12381Pairing{})
+	p.register("10", &bls12381MapFpToG1{})
+	p.register("11", &bls12381MapFp2ToG2{})
+
+	// RIP-7212 fork
+	p.register("100", &p256Verify{})
+
 	// Native transfer precompile
 	p.register(contracts.NativeTransferPrecompile.String(), &nativeTransfer{})
 
@@ -78,6 +90,9 @@ func (p *Precompiled) setupContracts() {
 
 	// BLS aggregated signatures verification precompile
 	p.register(contracts.BLSAggSigsVerificationPrecompile.String(), &blsAggSignsVerification{})
+
+	// State sync proof verification precompile
+	p.register(contracts.StateSyncProofVerificationPrecompile.String(), &stateSyncProofVerification{})
 }
 
 func (p *Precompiled) register(addrStr string, b contract) {
@@ -88,12 +103,32 @@ func (p *Precompiled) register(addrStr string, b contract) {
 	p.contracts[types.StringToAddress(addrStr)] = b
 }
 
+// builtinContracts are the builtin precompiled contracts by their addresses
+var builtinContracts = NewPrecompiled().contracts
+
+// IsPrecompiled tells whether the builtin precompiled contract is placed at the given address
+func IsPrecompiled(addr types.Address) bool {
+	_, ok := builtinContracts[addr]
+
+	return ok
+}
+
 var (
 	five  = types.StringToAddress("5")
 	six   = types.StringToAddress("6")
 	seven = types.StringToAddress("7")
 	eight = types.StringToAddress("8")
 	nine  = types.StringToAddress("9")
+
+	eleven    = types.StringToAddress("b")
+	twelve    = types.StringToAddress("c")
+	thirteen  = types.StringToAddress("d")
+	fourteen  = types.StringToAddress("e")
+	fifteen   = types.StringToAddress("f")
+	sixteen   = types.StringToAddress("10")
+	seventeen = types.StringToAddress("11")
+
+	p256VerifyAddress = types.StringToAddress("100")
 )
 
 // CanRun implements the runtime interface
@@ -120,6 +155,21 @@ func (p *Precompiled) CanRun(c *runtime.Contract, _ runtime.Host, config *chain.
 		return config.Istanbul
 	}
 
+	// EIP-2537 precompiles
+	switch c.CodeAddress {
+	case eleven, twelve, thirteen, fourteen, fifteen, sixteen, seventeen:
+		return config.EIP2537
+	}
+
+	// RIP-7212 precompile
+	if c.CodeAddress == p256VerifyAddress {
+		return config.RIP7212
+	}
+
+	if c.CodeAddress == contracts.StateSyncProofVerificationPrecompile {
+		return config.StateSyncProof
+	}
+
 	return true
 }
 
@@ -0,0 +1,147 @@
+package precompiled
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/merkle-tree"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+)
+
+const (
+	stateSyncProofBaseGas     = 10000
+	stateSyncProofPerHashGas  = 200
+	stateSyncProofMaxProofLen = 64
+)
+
+var (
+	// stateSyncProofInputABIType is the ABI type of the input, the same as the arguments of the execute function
+	// of the state receiver contract: the merkle proof and the state sync event
+	stateSyncProofInputABIType = abi.MustNewType(
+		"tuple(bytes32[] proof, tuple(uint256 id, address sender, address receiver, bytes data) obj)")
+
+	// the slots of the state receiver contract storage holding the commitments submitted by the validators,
+	// the commitments mapping from the commitment index, and the array of the end ids of the commitments
+	stateReceiverCommitmentsSlot   = big.NewInt(0x35)
+	stateReceiverCommitmentIDsSlot = big.NewInt(0x36)
+)
+
+// stateSyncProofVerification verifies the membership of the state sync event from the rootchain
+// in the commitment the validators submitted to the state receiver contract, without executing it.
+// It returns ABI encoded "true" if the event is proven, "false" if it isn't committed or the proof is invalid
+type stateSyncProofVerification struct{}
+
+// gas returns the gas required to execute the pre-compiled contract
+func (c *stateSyncProofVerification) gas(input []byte, _ *chain.ForksInTime) uint64 {
+	return stateSyncProofBaseGas + stateSyncProofPerHashGas*uint64(len(input)/types.HashLength)
+}
+
+// Run runs the precompiled contract with the given input.
+// Input must be ABI encoded: tuple(bytes32[], tuple(uint256, address, address, bytes))
+// Output could be an error or ABI encoded "bool" value
+func (c *stateSyncProofVerification) run(input []byte, _ types.Address, host runtime.Host) ([]byte, error) {
+	rawData, err := abi.Decode(stateSyncProofInputABIType, input)
+	if err != nil {
+		return nil, runtime.ErrInvalidInputData
+	}
+
+	data, ok := rawData.(map[string]interface{})
+	if !ok {
+		return nil, runtime.ErrInvalidInputData
+	}
+
+	rawProof, ok := data["proof"].([][types.HashLength]byte)
+	if !ok || len(rawProof) > stateSyncProofMaxProofLen {
+		return nil, runtime.ErrInvalidInputData
+	}
+
+	obj, ok := data["obj"].(map[string]interface{})
+	if !ok {
+		return nil, runtime.ErrInvalidInputData
+	}
+
+	id, ok := obj["id"].(*big.Int)
+	if !ok || !id.IsUint64() {
+		return abiBoolFalse, nil
+	}
+
+	sender, ok := obj["sender"].(ethgo.Address)
+	if !ok {
+		return nil, runtime.ErrInvalidInputData
+	}
+
+	receiver, ok := obj["receiver"].(ethgo.Address)
+	if !ok {
+		return nil, runtime.ErrInvalidInputData
+	}
+
+	payload, ok := obj["data"].([]byte)
+	if !ok {
+		return nil, runtime.ErrInvalidInputData
+	}
+
+	startID, root, found := findStateSyncCommitment(host, id.Uint64())
+	if !found {
+		return abiBoolFalse, nil
+	}
+
+	leaf, err := (&types.StateSyncEvent{ID: id.Uint64(), Sender: sender, Receiver: receiver, Data: payload}).EncodeAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	proof := make([]types.Hash, len(rawProof))
+	for i, hash := range rawProof {
+		proof[i] = hash
+	}
+
+	if err := merkle.VerifyProof(id.Uint64()-startID, leaf, proof, root); err != nil {
+		return abiBoolFalse, nil
+	}
+
+	return abiBoolTrue, nil
+}
+
+// findStateSyncCommitment returns the start id and the root of the commitment containing the state sync event,
+// looking it up in the state receiver contract storage the same way the contract does.
+// It returns false if the event isn't committed yet
+func findStateSyncCommitment(host runtime.Host, id uint64) (uint64, types.Hash, bool) {
+	getState := func(slot *big.Int) *big.Int {
+		value := host.GetStorage(contracts.StateReceiverContract, types.BytesToHash(slot.Bytes()))
+
+		return new(big.Int).SetBytes(value.Bytes())
+	}
+
+	// the end ids of the commitments are sorted, so the commitment is the first one ending at or after the id
+	count := getState(stateReceiverCommitmentIDsSlot).Uint64()
+	idsStart := new(big.Int).SetBytes(crypto.Keccak256(types.BytesToHash(stateReceiverCommitmentIDsSlot.Bytes()).Bytes()))
+
+	index := sort.Search(int(count), func(i int) bool {
+		return getState(new(big.Int).Add(idsStart, big.NewInt(int64(i)))).Uint64() >= id
+	})
+	if index == int(count) {
+		return 0, types.ZeroHash, false
+	}
+
+	// the commitment fields are start id, end id and root
+	commitmentSlot := new(big.Int).SetBytes(crypto.Keccak256(
+		types.BytesToHash(big.NewInt(int64(index)).Bytes()).Bytes(),
+		types.BytesToHash(stateReceiverCommitmentsSlot.Bytes()).Bytes(),
+	))
+
+	startID := getState(commitmentSlot).Uint64()
+	if id < startID {
+		return 0, types.ZeroHash, false
+	}
+
+	root := host.GetStorage(contracts.StateReceiverContract,
+		types.BytesToHash(new(big.Int).Add(commitmentSlot, big.NewInt(2)).Bytes()))
+
+	return startID, root, true
+}
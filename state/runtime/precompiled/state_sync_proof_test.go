@@ -0,0 +1,130 @@
+package precompiled
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/merkle-tree"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+)
+
+type storageHost struct {
+	runtime.Host
+
+	storage map[types.Hash]types.Hash
+}
+
+func (s *storageHost) GetStorage(addr types.Address, key types.Hash) types.Hash {
+	if addr != contracts.StateReceiverContract {
+		return types.ZeroHash
+	}
+
+	return s.storage[key]
+}
+
+func (s *storageHost) GetTracer() runtime.VMTracer {
+	return nil
+}
+
+// commit stores the commitment the way the state receiver contract does
+func (s *storageHost) commit(startID, endID uint64, root types.Hash) {
+	slot := func(base *big.Int, offset int64) types.Hash {
+		return types.BytesToHash(new(big.Int).Add(base, big.NewInt(offset)).Bytes())
+	}
+
+	count := new(big.Int).SetBytes(s.storage[types.BytesToHash(stateReceiverCommitmentIDsSlot.Bytes())].Bytes())
+	idsStart := new(big.Int).SetBytes(crypto.Keccak256(types.BytesToHash(stateReceiverCommitmentIDsSlot.Bytes()).Bytes()))
+	commitment := new(big.Int).SetBytes(crypto.Keccak256(
+		types.BytesToHash(count.Bytes()).Bytes(), types.BytesToHash(stateReceiverCommitmentsSlot.Bytes()).Bytes()))
+
+	s.storage[slot(commitment, 0)] = types.BytesToHash(new(big.Int).SetUint64(startID).Bytes())
+	s.storage[slot(commitment, 1)] = types.BytesToHash(new(big.Int).SetUint64(endID).Bytes())
+	s.storage[slot(commitment, 2)] = root
+	s.storage[slot(idsStart, count.Int64())] = types.BytesToHash(new(big.Int).SetUint64(endID).Bytes())
+	s.storage[types.BytesToHash(stateReceiverCommitmentIDsSlot.Bytes())] =
+		types.BytesToHash(new(big.Int).Add(count, big.NewInt(1)).Bytes())
+}
+
+func Test_StateSyncProofVerification(t *testing.T) {
+	t.Parallel()
+
+	events := make([]*types.StateSyncEvent, 3)
+	leaves := make([][]byte, len(events))
+
+	for i := range events {
+		events[i] = &types.StateSyncEvent{
+			ID:       uint64(5 + i),
+			Sender:   ethgo.Address{0x1},
+			Receiver: ethgo.Address{0x2},
+			Data:     []byte{byte(i)},
+		}
+
+		leaf, err := events[i].EncodeAbi()
+		require.NoError(t, err)
+
+		leaves[i] = leaf
+	}
+
+	tree, err := merkle.NewMerkleTree(leaves)
+	require.NoError(t, err)
+
+	host := &storageHost{storage: make(map[types.Hash]types.Hash)}
+	host.commit(1, 4, types.StringToHash("0x1234"))
+	host.commit(5, 7, tree.Hash())
+
+	contract := &stateSyncProofVerification{}
+
+	verify := func(event *types.StateSyncEvent, proof []types.Hash) []byte {
+		t.Helper()
+
+		input, err := stateSyncProofInputABIType.Encode(map[string]interface{}{
+			"proof": proof,
+			"obj":   event.ToMap(),
+		})
+		require.NoError(t, err)
+
+		output, err := contract.run(input, types.ZeroAddress, host)
+		require.NoError(t, err)
+
+		return output
+	}
+
+	proof, err := tree.GenerateProof(leaves[1])
+	require.NoError(t, err)
+
+	require.Equal(t, abiBoolTrue, verify(events[1], proof))
+
+	// the proof of the other event or the tampered event are rejected
+	require.Equal(t, abiBoolFalse, verify(events[2], proof))
+
+	tampered := *events[1]
+	tampered.Data = []byte{0xff}
+	require.Equal(t, abiBoolFalse, verify(&tampered, proof))
+
+	// the events which are not committed yet are rejected
+	uncommitted := *events[1]
+	uncommitted.ID = 8
+	require.Equal(t, abiBoolFalse, verify(&uncommitted, proof))
+
+	_, err = contract.run([]byte{0x1}, types.ZeroAddress, host)
+	require.ErrorIs(t, err, runtime.ErrInvalidInputData)
+
+	// the storage layout is the one of the state receiver contract
+	input, err := contractsapi.StateReceiver.Abi.Methods["getRootByStateSyncId"].Encode([]interface{}{big.NewInt(6)})
+	require.NoError(t, err)
+
+	config := chain.AllForksEnabled.At(0)
+	result := evm.NewEVM().Run(runtime.NewContractCall(1, types.ZeroAddress, types.ZeroAddress,
+		contracts.StateReceiverContract, big.NewInt(0), 1_000_000, contractsapi.StateReceiver.DeployedBytecode, input),
+		host, &config)
+	require.NoError(t, result.Err)
+	require.Equal(t, tree.Hash().Bytes(), result.ReturnValue)
+}
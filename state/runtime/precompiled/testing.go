@@ -71,3 +71,47 @@ func ReadTestCase(t *testing.T, path string, f func(t *testing.T, c *TestCase))
 		})
 	}
 }
+
+type FailureTestCase struct {
+	Name          string
+	Input         []byte
+	ExpectedError string
+}
+
+func ReadFailureTestCase(t *testing.T, path string, f func(t *testing.T, c *FailureTestCase)) {
+	t.Helper()
+	t.Parallel()
+
+	data, err := os.ReadFile(filepath.Join("./fixtures", path))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type testCase struct {
+		Name          string
+		Input         string
+		ExpectedError string
+	}
+
+	var cases []*testCase
+
+	if err := json.Unmarshal(data, &cases); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, i := range cases {
+		i := i
+
+		c := &FailureTestCase{
+			Name:          i.Name,
+			Input:         decodeHex(t, fmt.Sprintf("0x%s", i.Input)),
+			ExpectedError: i.ExpectedError,
+		}
+
+		t.Run(i.Name, func(t *testing.T) {
+			t.Parallel()
+
+			f(t, c)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync/atomic"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
@@ -65,6 +66,8 @@ type Host interface {
 	GetStorage(addr types.Address, key types.Hash) types.Hash
 	SetStorage(addr types.Address, key types.Hash, value types.Hash, config *chain.ForksInTime) StorageStatus
 	SetState(addr types.Address, key types.Hash, value types.Hash)
+	GetTransientStorage(addr types.Address, key types.Hash) types.Hash
+	SetTransientStorage(addr types.Address, key types.Hash, value types.Hash)
 	SetNonPayable(nonPayable bool)
 	GetBalance(addr types.Address) *big.Int
 	GetCodeSize(addr types.Address) int
@@ -82,6 +85,22 @@ type Host interface {
 	GetRefund() uint64
 }
 
+// Interruptible is implemented by the hosts whose execution can be stopped before it completes
+type Interruptible interface {
+	// InterruptFlag returns the flag set once the execution should be stopped,
+	// nil if the execution can't be interrupted
+	InterruptFlag() *atomic.Bool
+}
+
+// SupplyManager is implemented by the hosts which let the designated system contracts
+// mint and burn the native token
+type SupplyManager interface {
+	// MintNative mints the amount of the native token to the account on behalf of the hook contract
+	MintNative(hook types.Address, to types.Address, amount *big.Int) error
+	// BurnNative burns the amount of the native token from the account on behalf of the hook contract
+	BurnNative(hook types.Address, from types.Address, amount *big.Int) error
+}
+
 type VMTracer interface {
 	CaptureState(
 		memory []byte,
@@ -143,6 +162,7 @@ var (
 	ErrUnauthorizedCaller       = errors.New("unauthorized caller")
 	ErrInvalidInputData         = errors.New("invalid input data")
 	ErrNotAuth                  = errors.New("not in allow list")
+	ErrExecutionInterrupted     = errors.New("execution interrupted")
 )
 
 // StackUnderflowError wraps an evm error when the items on the stack less
@@ -185,6 +205,10 @@ type Runtime interface {
 	Name() string
 }
 
+// Factory creates the execution engine of the contract code. The engine is shared by the transitions
+// executed concurrently, so it must be safe for the concurrent use
+type Factory func() (Runtime, error)
+
 // Contract is the instance being called
 type Contract struct {
 	Code        []byte
@@ -233,6 +257,7 @@ func NewContractCreation(
 	code []byte,
 ) *Contract {
 	c := NewContract(depth, origin, from, to, value, gas, code)
+	c.Type = Create
 
 	return c
 }
@@ -0,0 +1,502 @@
+// Package governance is the stateful precompile keeping the proposals to change the chain parameters
+// and the votes on them. The proposals are made and voted for by the validators, whose voting powers are set
+// by the consensus at the end of each epoch. The votes are tallied as they are cast, and the consensus executes
+// the proposals reaching the quorum at the end of the epoch, overriding the chain parameters from the next block on
+package governance
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Name is the name the contract is configured with in the chain params
+const Name = "governance"
+
+// DefaultVotingPeriod is the number of blocks the proposals are open for voting, if it's not configured
+const DefaultVotingPeriod = 10000
+
+// Parameter is the chain parameter which can be changed by the governance
+type Parameter uint8
+
+const (
+	// ParamBlockGasTarget is the gas limit the blocks move towards
+	ParamBlockGasTarget Parameter = iota + 1
+	// ParamBaseFeeChangeDenom bounds the change of the base fee between the blocks
+	ParamBaseFeeChangeDenom
+	// ParamMaxValidatorSetSize is the maximum number of the validators elected at the end of the epoch
+	ParamMaxValidatorSetSize
+)
+
+// String returns the name of the parameter
+func (p Parameter) String() string {
+	switch p {
+	case ParamBlockGasTarget:
+		return "blockGasTarget"
+	case ParamBaseFeeChangeDenom:
+		return "baseFeeChangeDenom"
+	case ParamMaxValidatorSetSize:
+		return "maxValidatorSetSize"
+	default:
+		return "unknown"
+	}
+}
+
+// Status is the status of the proposal
+type Status uint8
+
+const (
+	// StatusNone is the status of the proposal which doesn't exist
+	StatusNone Status = iota
+	// StatusPending is the status of the proposal open for voting
+	StatusPending
+	// StatusExecuted is the status of the proposal executed by the consensus
+	StatusExecuted
+	// StatusExpired is the status of the proposal whose voting period passed before it was executed
+	StatusExpired
+)
+
+// list of the contract functions and events
+var (
+	ProposeFunc       = abi.MustNewMethod("function propose(uint8 parameter, uint256 value) returns (uint256 id)")
+	VoteFunc          = abi.MustNewMethod("function vote(uint256 id)")
+	ExecuteFunc       = abi.MustNewMethod("function execute(uint256[] ids, address[] voters, uint256[] powers)")
+	ProposalCountFunc = abi.MustNewMethod("function proposalCount() returns (uint256)")
+	GetProposalFunc   = abi.MustNewMethod("function getProposal(uint256 id) " +
+		"returns (uint8 parameter, uint256 value, uint256 deadline, uint8 status, uint256 votes)")
+	HasVotedFunc         = abi.MustNewMethod("function hasVoted(uint256 id, address voter) returns (bool)")
+	GetParameterFunc     = abi.MustNewMethod("function getParameter(uint8 parameter) returns (uint256)")
+	VotingPowerFunc      = abi.MustNewMethod("function votingPower(address voter) returns (uint256)")
+	TotalVotingPowerFunc = abi.MustNewMethod("function totalVotingPower() returns (uint256)")
+
+	ProposalCreatedEvent = abi.MustNewEvent("event ProposalCreated(uint256 indexed id, address indexed proposer, " +
+		"uint8 parameter, uint256 value, uint256 deadline)")
+	VotedEvent            = abi.MustNewEvent("event Voted(uint256 indexed id, address indexed voter)")
+	ProposalExecutedEvent = abi.MustNewEvent("event ProposalExecuted(uint256 indexed id, uint8 parameter, uint256 value)")
+)
+
+var (
+	errFunctionNotFound = errors.New("function not found")
+	errUnknownParameter = errors.New("unknown parameter")
+	errInvalidValue     = errors.New("invalid value, expected a positive 64-bit value")
+	errProposalNotFound = errors.New("proposal not found")
+	errProposalClosed   = errors.New("proposal is not open for voting")
+	errAlreadyVoted     = errors.New("already voted")
+	errAlreadyExecuted  = errors.New("proposal is already executed")
+	errNotSystemCaller  = errors.New("proposals are executed only by the consensus")
+	errNotValidator     = errors.New("only the validators can propose and vote")
+)
+
+// proposal fields are kept in the consecutive slots
+const (
+	fieldParameter byte = iota
+	fieldValue
+	fieldDeadline
+	fieldExecuted
+	fieldVotes
+)
+
+var (
+	// countSlot holds the number of the proposals, which is the id of the last proposal
+	countSlot = types.ZeroHash
+
+	// totalPowerSlot holds the total voting power of the validators
+	totalPowerSlot = types.BytesToHash(crypto.Keccak256([]byte("totalVotingPower")))
+	// voterCountSlot holds the number of the validators, listed in the voter slots
+	voterCountSlot = types.BytesToHash(crypto.Keccak256([]byte("voterCount")))
+
+	parameterSlotPrefix = []byte("parameter")
+	voterSlotPrefix     = []byte("voter")
+	powerSlotPrefix     = []byte("power")
+)
+
+// Config is the config of the contract
+type Config struct {
+	// VotingPeriod is the number of blocks the proposals are open for voting
+	VotingPeriod uint64 `json:"votingPeriod"`
+	// WriteGas is the gas cost of the functions modifying the state
+	WriteGas uint64 `json:"writeGas"`
+	// ReadGas is the gas cost of the view functions
+	ReadGas uint64 `json:"readGas"`
+}
+
+// Governance is the governance contract
+type Governance struct {
+	config Config
+}
+
+var _ stateful.Contract = (*Governance)(nil)
+
+// Factory creates the contract from its config
+func Factory(rawConfig json.RawMessage) (stateful.Contract, error) {
+	config := Config{
+		VotingPeriod: DefaultVotingPeriod,
+		WriteGas:     50000,
+		ReadGas:      2500,
+	}
+
+	if len(rawConfig) != 0 {
+		if err := json.Unmarshal(rawConfig, &config); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.VotingPeriod == 0 {
+		return nil, errors.New("voting period must be positive")
+	}
+
+	return &Governance{config: config}, nil
+}
+
+func (g *Governance) RequiredGas(input []byte) uint64 {
+	if len(input) >= types.SignatureSize {
+		sig := input[:types.SignatureSize]
+		if bytes.Equal(sig, ProposeFunc.ID()) || bytes.Equal(sig, VoteFunc.ID()) || bytes.Equal(sig, ExecuteFunc.ID()) {
+			return g.config.WriteGas
+		}
+	}
+
+	return g.config.ReadGas
+}
+
+func (g *Governance) Run(ctx *stateful.Context, input []byte) ([]byte, error) {
+	if len(input) < types.SignatureSize {
+		return nil, runtime.ErrInvalidInputData
+	}
+
+	sig, input := input[:types.SignatureSize], input[types.SignatureSize:]
+
+	switch {
+	case bytes.Equal(sig, ProposeFunc.ID()):
+		args, err := decodeArgs(ProposeFunc, input)
+		if err != nil {
+			return nil, err
+		}
+
+		return g.propose(ctx, Parameter(args["parameter"].(uint8)), args["value"].(*big.Int)) //nolint:forcetypeassert
+
+	case bytes.Equal(sig, VoteFunc.ID()):
+		args, err := decodeArgs(VoteFunc, input)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, g.vote(ctx, args["id"].(*big.Int)) //nolint:forcetypeassert
+
+	case bytes.Equal(sig, ExecuteFunc.ID()):
+		args, err := decodeArgs(ExecuteFunc, input)
+		if err != nil {
+			return nil, err
+		}
+
+		ids := args["ids"].([]*big.Int)            //nolint:forcetypeassert
+		voters := args["voters"].([]ethgo.Address) //nolint:forcetypeassert
+		powers := args["powers"].([]*big.Int)      //nolint:forcetypeassert
+
+		if len(voters) != len(powers) {
+			return nil, runtime.ErrInvalidInputData
+		}
+
+		if err := g.execute(ctx, ids); err != nil {
+			return nil, err
+		}
+
+		return nil, setVoters(ctx, voters, powers)
+
+	case bytes.Equal(sig, ProposalCountFunc.ID()):
+		return ctx.GetState(countSlot).Bytes(), nil
+
+	case bytes.Equal(sig, GetProposalFunc.ID()):
+		args, err := decodeArgs(GetProposalFunc, input)
+		if err != nil {
+			return nil, err
+		}
+
+		id := args["id"].(*big.Int) //nolint:forcetypeassert
+		status := proposalStatus(ctx.GetState, id, ctx.BlockNumber())
+
+		return GetProposalFunc.Outputs.Encode(map[string]interface{}{
+			"parameter": uint8(toBig(ctx.GetState(proposalSlot(id, fieldParameter))).Uint64()),
+			"value":     toBig(ctx.GetState(proposalSlot(id, fieldValue))),
+			"deadline":  toBig(ctx.GetState(proposalSlot(id, fieldDeadline))),
+			"status":    uint8(status),
+			"votes":     toBig(ctx.GetState(proposalSlot(id, fieldVotes))),
+		})
+
+	case bytes.Equal(sig, HasVotedFunc.ID()):
+		args, err := decodeArgs(HasVotedFunc, input)
+		if err != nil {
+			return nil, err
+		}
+
+		id, voter := args["id"].(*big.Int), types.Address(args["voter"].(ethgo.Address)) //nolint:forcetypeassert
+
+		return ctx.GetState(voteSlot(id, voter)).Bytes(), nil
+
+	case bytes.Equal(sig, GetParameterFunc.ID()):
+		args, err := decodeArgs(GetParameterFunc, input)
+		if err != nil {
+			return nil, err
+		}
+
+		return ctx.GetState(parameterSlot(Parameter(args["parameter"].(uint8)))).Bytes(), nil //nolint:forcetypeassert
+
+	case bytes.Equal(sig, VotingPowerFunc.ID()):
+		args, err := decodeArgs(VotingPowerFunc, input)
+		if err != nil {
+			return nil, err
+		}
+
+		return ctx.GetState(powerSlot(types.Address(args["voter"].(ethgo.Address)))).Bytes(), nil //nolint:forcetypeassert
+
+	case bytes.Equal(sig, TotalVotingPowerFunc.ID()):
+		return ctx.GetState(totalPowerSlot).Bytes(), nil
+
+	default:
+		return nil, errFunctionNotFound
+	}
+}
+
+// propose creates the proposal of the validator, open for voting for the voting period
+func (g *Governance) propose(ctx *stateful.Context, param Parameter, value *big.Int) ([]byte, error) {
+	if ctx.GetState(powerSlot(ctx.Caller)) == types.ZeroHash {
+		return nil, errNotValidator
+	}
+
+	if param.String() == "unknown" {
+		return nil, errUnknownParameter
+	}
+
+	if value.Sign() <= 0 || !value.IsUint64() {
+		return nil, errInvalidValue
+	}
+
+	id := new(big.Int).Add(toBig(ctx.GetState(countSlot)), big.NewInt(1))
+	deadline := new(big.Int).SetUint64(ctx.BlockNumber() + g.config.VotingPeriod)
+
+	writes := []struct {
+		slot  types.Hash
+		value *big.Int
+	}{
+		{countSlot, id},
+		{proposalSlot(id, fieldParameter), big.NewInt(int64(param))},
+		{proposalSlot(id, fieldValue), value},
+		{proposalSlot(id, fieldDeadline), deadline},
+	}
+
+	for _, w := range writes {
+		if err := ctx.SetState(w.slot, types.BytesToHash(w.value.Bytes())); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := abi.Encode([]interface{}{uint8(param), value, deadline}, abi.MustNewType("tuple(uint8,uint256,uint256)"))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctx.EmitLog(
+		[]types.Hash{
+			types.BytesToHash(ProposalCreatedEvent.ID().Bytes()),
+			types.BytesToHash(id.Bytes()),
+			types.BytesToHash(ctx.Caller.Bytes()),
+		},
+		data,
+	); err != nil {
+		return nil, err
+	}
+
+	return types.BytesToHash(id.Bytes()).Bytes(), nil
+}
+
+// vote records the vote of the validator for the open proposal,
+// adding its current voting power to the votes of the proposal
+func (g *Governance) vote(ctx *stateful.Context, id *big.Int) error {
+	power := ctx.GetState(powerSlot(ctx.Caller))
+	if power == types.ZeroHash {
+		return errNotValidator
+	}
+
+	switch proposalStatus(ctx.GetState, id, ctx.BlockNumber()) {
+	case StatusNone:
+		return errProposalNotFound
+	case StatusPending:
+	default:
+		return errProposalClosed
+	}
+
+	slot := voteSlot(id, ctx.Caller)
+	if ctx.GetState(slot) != types.ZeroHash {
+		return errAlreadyVoted
+	}
+
+	if err := ctx.SetState(slot, types.BytesToHash([]byte{1})); err != nil {
+		return err
+	}
+
+	votes := new(big.Int).Add(toBig(ctx.GetState(proposalSlot(id, fieldVotes))), toBig(power))
+	if err := ctx.SetState(proposalSlot(id, fieldVotes), types.BytesToHash(votes.Bytes())); err != nil {
+		return err
+	}
+
+	return ctx.EmitLog(
+		[]types.Hash{
+			types.BytesToHash(VotedEvent.ID().Bytes()),
+			types.BytesToHash(id.Bytes()),
+			types.BytesToHash(ctx.Caller.Bytes()),
+		},
+		nil,
+	)
+}
+
+// execute applies the values of the proposals in the given order, it's called by the consensus
+// with the proposals passed by the validators
+func (g *Governance) execute(ctx *stateful.Context, ids []*big.Int) error {
+	if ctx.Caller != contracts.SystemCaller {
+		return errNotSystemCaller
+	}
+
+	for _, id := range ids {
+		if ctx.GetState(proposalSlot(id, fieldDeadline)) == types.ZeroHash {
+			return errProposalNotFound
+		}
+
+		if ctx.GetState(proposalSlot(id, fieldExecuted)) != types.ZeroHash {
+			return errAlreadyExecuted
+		}
+
+		param := ctx.GetState(proposalSlot(id, fieldParameter))
+		value := ctx.GetState(proposalSlot(id, fieldValue))
+
+		if err := ctx.SetState(proposalSlot(id, fieldExecuted), types.BytesToHash([]byte{1})); err != nil {
+			return err
+		}
+
+		if err := ctx.SetState(parameterSlot(Parameter(toBig(param).Uint64())), value); err != nil {
+			return err
+		}
+
+		if err := ctx.EmitLog(
+			[]types.Hash{
+				types.BytesToHash(ProposalExecutedEvent.ID().Bytes()),
+				types.BytesToHash(id.Bytes()),
+			},
+			append(param.Bytes(), value.Bytes()...),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setVoters replaces the validators allowed to propose and vote with the given ones,
+// it's called by the consensus with the validator set of the next epoch
+func setVoters(ctx *stateful.Context, voters []ethgo.Address, powers []*big.Int) error {
+	count := toBig(ctx.GetState(voterCountSlot)).Uint64()
+
+	for i := uint64(0); i < count; i++ {
+		voter := types.BytesToAddress(ctx.GetState(voterSlot(i)).Bytes())
+
+		if err := ctx.SetState(powerSlot(voter), types.ZeroHash); err != nil {
+			return err
+		}
+	}
+
+	total := new(big.Int)
+
+	for i, voter := range voters {
+		if err := ctx.SetState(voterSlot(uint64(i)), types.BytesToHash(voter.Bytes())); err != nil {
+			return err
+		}
+
+		if err := ctx.SetState(powerSlot(types.Address(voter)), types.BytesToHash(powers[i].Bytes())); err != nil {
+			return err
+		}
+
+		total.Add(total, powers[i])
+	}
+
+	for i := uint64(len(voters)); i < count; i++ {
+		if err := ctx.SetState(voterSlot(i), types.ZeroHash); err != nil {
+			return err
+		}
+	}
+
+	voterCount := new(big.Int).SetInt64(int64(len(voters)))
+	if err := ctx.SetState(voterCountSlot, types.BytesToHash(voterCount.Bytes())); err != nil {
+		return err
+	}
+
+	return ctx.SetState(totalPowerSlot, types.BytesToHash(total.Bytes()))
+}
+
+// proposalStatus returns the status of the proposal at the given block
+func proposalStatus(getState func(types.Hash) types.Hash, id *big.Int, number uint64) Status {
+	deadline := getState(proposalSlot(id, fieldDeadline))
+
+	switch {
+	case deadline == types.ZeroHash:
+		return StatusNone
+	case getState(proposalSlot(id, fieldExecuted)) != types.ZeroHash:
+		return StatusExecuted
+	case toBig(deadline).Cmp(new(big.Int).SetUint64(number)) < 0:
+		return StatusExpired
+	default:
+		return StatusPending
+	}
+}
+
+// ReadParameter returns the value of the parameter enacted by the governance from the contract state,
+// 0 if the parameter was never changed
+func ReadParameter(getState func(types.Hash) types.Hash, param Parameter) uint64 {
+	return toBig(getState(parameterSlot(param))).Uint64()
+}
+
+func toBig(h types.Hash) *big.Int {
+	return new(big.Int).SetBytes(h.Bytes())
+}
+
+func decodeArgs(method *abi.Method, input []byte) (map[string]interface{}, error) {
+	args, err := method.Inputs.Decode(input)
+	if err != nil {
+		return nil, runtime.ErrInvalidInputData
+	}
+
+	return args.(map[string]interface{}), nil //nolint:forcetypeassert
+}
+
+// proposalSlot returns the slot of the contract state holding the field of the proposal
+func proposalSlot(id *big.Int, field byte) types.Hash {
+	return types.BytesToHash(crypto.Keccak256(types.BytesToHash(id.Bytes()).Bytes(), []byte{field}))
+}
+
+// voteSlot returns the slot of the contract state holding the vote of the voter for the proposal
+func voteSlot(id *big.Int, voter types.Address) types.Hash {
+	return types.BytesToHash(crypto.Keccak256(types.BytesToHash(id.Bytes()).Bytes(), voter.Bytes()))
+}
+
+// voterSlot returns the slot of the contract state holding the address of the i-th validator
+func voterSlot(i uint64) types.Hash {
+	return types.BytesToHash(crypto.Keccak256(voterSlotPrefix, new(big.Int).SetUint64(i).Bytes()))
+}
+
+// powerSlot returns the slot of the contract state holding the voting power of the validator
+func powerSlot(voter types.Address) types.Hash {
+	return types.BytesToHash(crypto.Keccak256(powerSlotPrefix, voter.Bytes()))
+}
+
+// parameterSlot returns the slot of the contract state holding the value of the parameter
+func parameterSlot(param Parameter) types.Hash {
+	return types.BytesToHash(crypto.Keccak256(parameterSlotPrefix, []byte{byte(param)}))
+}
@@ -0,0 +1,233 @@
+package governance
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var address = types.StringToAddress("0x1001")
+
+type mockHost struct {
+	runtime.Host
+
+	number  int64
+	storage map[types.Hash]types.Hash
+	topics  [][]types.Hash
+}
+
+func (m *mockHost) GetStorage(_ types.Address, key types.Hash) types.Hash {
+	return m.storage[key]
+}
+
+func (m *mockHost) SetState(_ types.Address, key types.Hash, value types.Hash) {
+	m.storage[key] = value
+}
+
+func (m *mockHost) EmitLog(_ types.Address, topics []types.Hash, _ []byte) {
+	m.topics = append(m.topics, topics)
+}
+
+func (m *mockHost) GetTxContext() runtime.TxContext {
+	return runtime.TxContext{Number: m.number}
+}
+
+func TestGovernance(t *testing.T) {
+	t.Parallel()
+
+	var (
+		voter1  = types.StringToAddress("0x2000")
+		voter2  = types.StringToAddress("0x3000")
+		account = types.StringToAddress("0x4000")
+	)
+
+	contract, err := Factory(json.RawMessage(`{"votingPeriod": 10}`))
+	require.NoError(t, err)
+
+	precompile := &stateful.Precompile{Name: Name, Address: address, Fork: Name, Contract: contract}
+	host := &mockHost{number: 100, storage: make(map[types.Hash]types.Hash)}
+
+	call := func(caller types.Address, method *abi.Method, args ...interface{}) *runtime.ExecutionResult {
+		t.Helper()
+
+		input, err := method.Encode(args)
+		require.NoError(t, err)
+
+		return precompile.Run(
+			runtime.NewContractCall(1, caller, caller, address, big.NewInt(0), 100000, nil, input),
+			host,
+		)
+	}
+
+	proposal := func(id uint64) map[string]interface{} {
+		t.Helper()
+
+		result := call(voter1, GetProposalFunc, new(big.Int).SetUint64(id))
+		require.NoError(t, result.Err)
+
+		decoded, err := GetProposalFunc.Decode(result.ReturnValue)
+		require.NoError(t, err)
+
+		return decoded
+	}
+
+	execute := func(ids []*big.Int, voters map[types.Address]int64) *runtime.ExecutionResult {
+		t.Helper()
+
+		addrs, powers := make([]types.Address, 0, len(voters)), make([]*big.Int, 0, len(voters))
+		for addr, power := range voters {
+			addrs, powers = append(addrs, addr), append(powers, big.NewInt(power))
+		}
+
+		return call(contracts.SystemCaller, ExecuteFunc, ids, addrs, powers)
+	}
+
+	// nobody proposes until the consensus sets the validators
+	require.ErrorIs(t, call(voter1, ProposeFunc, uint8(ParamBlockGasTarget), big.NewInt(1)).Err, errNotValidator)
+	require.NoError(t, execute(nil, map[types.Address]int64{voter1: 3, voter2: 2}).Err)
+
+	result := call(voter1, TotalVotingPowerFunc)
+	require.NoError(t, result.Err)
+	require.Equal(t, uint64(5), new(big.Int).SetBytes(result.ReturnValue).Uint64())
+
+	// the unknown parameters and the invalid values are rejected
+	require.ErrorIs(t, call(voter1, ProposeFunc, uint8(0), big.NewInt(1)).Err, errUnknownParameter)
+	require.ErrorIs(t, call(voter1, ProposeFunc, uint8(ParamBlockGasTarget), big.NewInt(0)).Err, errInvalidValue)
+
+	require.ErrorIs(t, call(account, ProposeFunc, uint8(ParamBlockGasTarget), big.NewInt(1)).Err, errNotValidator)
+
+	result = call(voter1, ProposeFunc, uint8(ParamBlockGasTarget), big.NewInt(30_000_000))
+	require.NoError(t, result.Err)
+	require.Equal(t, types.BytesToHash([]byte{1}).Bytes(), result.ReturnValue)
+	require.Len(t, host.topics, 1)
+	require.Equal(t, types.BytesToHash(ProposalCreatedEvent.ID().Bytes()), host.topics[0][0])
+
+	require.Equal(t, uint8(ParamBlockGasTarget), proposal(1)["parameter"])
+	require.Equal(t, big.NewInt(110), proposal(1)["deadline"])
+	require.Equal(t, uint8(StatusPending), proposal(1)["status"])
+	require.Equal(t, uint8(StatusNone), proposal(2)["status"])
+
+	// the votes of the validators are tallied by their voting power
+	require.ErrorIs(t, call(account, VoteFunc, big.NewInt(1)).Err, errNotValidator)
+	require.NoError(t, call(voter2, VoteFunc, big.NewInt(1)).Err)
+	require.ErrorIs(t, call(voter2, VoteFunc, big.NewInt(1)).Err, errAlreadyVoted)
+	require.ErrorIs(t, call(voter2, VoteFunc, big.NewInt(2)).Err, errProposalNotFound)
+	require.Equal(t, big.NewInt(2), proposal(1)["votes"])
+
+	require.NoError(t, call(voter1, VoteFunc, big.NewInt(1)).Err)
+	require.Equal(t, big.NewInt(5), proposal(1)["votes"])
+
+	result = call(voter1, HasVotedFunc, big.NewInt(1), voter2)
+	require.NoError(t, result.Err)
+	require.Equal(t, types.BytesToHash([]byte{1}).Bytes(), result.ReturnValue)
+
+	// only the consensus executes the proposals, replacing the validators
+	validators := map[types.Address]int64{voter1: 1, account: 4}
+
+	require.ErrorIs(t,
+		call(voter1, ExecuteFunc, []*big.Int{big.NewInt(1)}, []types.Address{}, []*big.Int{}).Err, errNotSystemCaller)
+	require.ErrorIs(t,
+		call(contracts.SystemCaller, ExecuteFunc, []*big.Int{}, []types.Address{voter1}, []*big.Int{}).Err,
+		runtime.ErrInvalidInputData)
+	require.NoError(t, execute([]*big.Int{big.NewInt(1)}, validators).Err)
+	require.ErrorIs(t, execute([]*big.Int{big.NewInt(1)}, validators).Err, errAlreadyExecuted)
+
+	for voter, power := range map[types.Address]int64{voter1: 1, voter2: 0, account: 4} {
+		result = call(voter1, VotingPowerFunc, voter)
+		require.NoError(t, result.Err)
+		require.Equal(t, power, new(big.Int).SetBytes(result.ReturnValue).Int64())
+	}
+
+	result = call(voter1, TotalVotingPowerFunc)
+	require.NoError(t, result.Err)
+	require.Equal(t, uint64(5), new(big.Int).SetBytes(result.ReturnValue).Uint64())
+
+	require.Equal(t, uint8(StatusExecuted), proposal(1)["status"])
+	require.ErrorIs(t, call(voter1, VoteFunc, big.NewInt(1)).Err, errProposalClosed)
+
+	result = call(voter1, GetParameterFunc, uint8(ParamBlockGasTarget))
+	require.NoError(t, result.Err)
+	require.Equal(t, uint64(30_000_000), new(big.Int).SetBytes(result.ReturnValue).Uint64())
+
+	// the proposals which are not executed within the voting period expire
+	require.NoError(t, call(voter1, ProposeFunc, uint8(ParamMaxValidatorSetSize), big.NewInt(50)).Err)
+
+	host.number = 111
+
+	require.Equal(t, uint8(StatusExpired), proposal(2)["status"])
+	require.ErrorIs(t, call(account, VoteFunc, big.NewInt(2)).Err, errProposalClosed)
+}
+
+func TestParams(t *testing.T) {
+	t.Parallel()
+
+	contract, err := Factory(nil)
+	require.NoError(t, err)
+
+	forks := &chain.Forks{Name: chain.NewFork(0)}
+
+	st := itrie.NewState(itrie.NewMemoryStorage())
+	executor := state.NewExecutor(&chain.Params{Forks: forks}, st, hclog.NewNullLogger())
+	executor.StatefulPrecompiles = []*stateful.Precompile{
+		{Name: Name, Address: address, Fork: Name, Contract: contract},
+	}
+	executor.GetHash = func(*types.Header) state.GetHashByNumber {
+		return func(uint64) types.Hash { return types.ZeroHash }
+	}
+
+	params := NewParams(hclog.NewNullLogger(), st, address)
+
+	header := &types.Header{Number: 1, GasLimit: 10_000_000, StateRoot: types.EmptyRootHash}
+
+	_, ok := params.BlockGasTarget(header)
+	require.False(t, ok)
+
+	txn, err := executor.BeginTxn(types.EmptyRootHash, header, types.ZeroAddress)
+	require.NoError(t, err)
+
+	var (
+		voter  = types.StringToAddress("0x2000")
+		voters = []types.Address{voter}
+		powers = []*big.Int{big.NewInt(1)}
+	)
+
+	for _, input := range []struct {
+		from types.Address
+		args []interface{}
+	}{
+		{contracts.SystemCaller, []interface{}{ExecuteFunc, []*big.Int{}, voters, powers}},
+		{voter, []interface{}{ProposeFunc, uint8(ParamBaseFeeChangeDenom), big.NewInt(16)}},
+		{voter, []interface{}{VoteFunc, big.NewInt(1)}},
+		{contracts.SystemCaller, []interface{}{ExecuteFunc, []*big.Int{big.NewInt(1)}, voters, powers}},
+	} {
+		data, err := input.args[0].(*abi.Method).Encode(input.args[1:])
+		require.NoError(t, err)
+
+		result := txn.Call2(input.from, address, data, big.NewInt(0), 1_000_000)
+		require.NoError(t, result.Err)
+	}
+
+	_, root, err := txn.Commit()
+	require.NoError(t, err)
+
+	header.StateRoot = root
+
+	denom, ok := params.BaseFeeChangeDenom(header)
+	require.True(t, ok)
+	require.Equal(t, uint64(16), denom)
+
+	_, ok = params.BlockGasTarget(header)
+	require.False(t, ok)
+}
@@ -0,0 +1,57 @@
+package governance
+
+import (
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Params reads the chain parameters enacted by the governance from the state of the blocks,
+// so they apply from the block following the epoch-ending block which executed the proposal
+type Params struct {
+	logger  hclog.Logger
+	state   state.State
+	address types.Address
+}
+
+// NewParams creates the reader of the parameters of the governance contract at the address
+func NewParams(logger hclog.Logger, st state.State, address types.Address) *Params {
+	return &Params{
+		logger:  logger.Named("governance"),
+		state:   st,
+		address: address,
+	}
+}
+
+// Get returns the value of the parameter as of the state of the header, false if it was never changed
+func (p *Params) Get(header *types.Header, param Parameter) (uint64, bool) {
+	snap, err := p.state.NewSnapshotAt(header.StateRoot)
+	if err != nil {
+		p.logger.Error("failed to read the state", "block", header.Number, "err", err)
+
+		return 0, false
+	}
+
+	account, err := snap.GetAccount(p.address)
+	if err != nil || account == nil {
+		// the contract has no state until the first proposal
+		return 0, false
+	}
+
+	value := ReadParameter(func(slot types.Hash) types.Hash {
+		return snap.GetStorage(p.address, account.Root, slot)
+	}, param)
+
+	return value, value != 0
+}
+
+// BlockGasTarget returns the block gas target enacted by the governance
+func (p *Params) BlockGasTarget(header *types.Header) (uint64, bool) {
+	return p.Get(header, ParamBlockGasTarget)
+}
+
+// BaseFeeChangeDenom returns the base fee change denominator enacted by the governance
+func (p *Params) BaseFeeChangeDenom(header *types.Header) (uint64, bool) {
+	return p.Get(header, ParamBaseFeeChangeDenom)
+}
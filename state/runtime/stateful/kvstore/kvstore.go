@@ -0,0 +1,112 @@
+// Package kvstore is the example of the stateful precompile,
+// which keeps the values set by the accounts under the keys of their own
+package kvstore
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Name is the name the contract is configured with in the chain params
+const Name = "kvstore"
+
+// list of the contract functions and events
+var (
+	SetFunc       = abi.MustNewMethod("function set(bytes32,bytes32)")
+	GetFunc       = abi.MustNewMethod("function get(address,bytes32) returns (bytes32)")
+	ValueSetEvent = abi.MustNewEvent("event ValueSet(address indexed owner, bytes32 indexed key, bytes32 value)")
+)
+
+var (
+	errFunctionNotFound = errors.New("function not found")
+	errInvalidInput     = errors.New("invalid input, expected 64 bytes")
+)
+
+// Config is the config of the contract
+type Config struct {
+	// SetGas is the gas cost of the set function
+	SetGas uint64 `json:"setGas"`
+	// GetGas is the gas cost of the get function
+	GetGas uint64 `json:"getGas"`
+}
+
+// KVStore is the key value store contract
+type KVStore struct {
+	config Config
+}
+
+var _ stateful.Contract = (*KVStore)(nil)
+
+// Factory creates the contract from its config
+func Factory(rawConfig json.RawMessage) (stateful.Contract, error) {
+	config := Config{
+		SetGas: 25000,
+		GetGas: 2500,
+	}
+
+	if len(rawConfig) != 0 {
+		if err := json.Unmarshal(rawConfig, &config); err != nil {
+			return nil, err
+		}
+	}
+
+	return &KVStore{config: config}, nil
+}
+
+func (k *KVStore) RequiredGas(input []byte) uint64 {
+	if len(input) >= types.SignatureSize && bytes.Equal(input[:types.SignatureSize], SetFunc.ID()) {
+		return k.config.SetGas
+	}
+
+	return k.config.GetGas
+}
+
+func (k *KVStore) Run(ctx *stateful.Context, input []byte) ([]byte, error) {
+	if len(input) < types.SignatureSize {
+		return nil, runtime.ErrInvalidInputData
+	}
+
+	sig, input := input[:types.SignatureSize], input[types.SignatureSize:]
+	if len(input) != 2*types.HashLength {
+		return nil, errInvalidInput
+	}
+
+	switch {
+	case bytes.Equal(sig, SetFunc.ID()):
+		key, value := types.BytesToHash(input[:types.HashLength]), types.BytesToHash(input[types.HashLength:])
+
+		if err := ctx.SetState(slot(ctx.Caller, key), value); err != nil {
+			return nil, err
+		}
+
+		return nil, ctx.EmitLog(
+			[]types.Hash{
+				types.BytesToHash(ValueSetEvent.ID().Bytes()),
+				types.BytesToHash(ctx.Caller.Bytes()),
+				key,
+			},
+			value.Bytes(),
+		)
+
+	case bytes.Equal(sig, GetFunc.ID()):
+		owner, key := types.BytesToAddress(input[:types.HashLength]), types.BytesToHash(input[types.HashLength:])
+
+		return ctx.GetState(slot(owner, key)).Bytes(), nil
+
+	default:
+		return nil, errFunctionNotFound
+	}
+}
+
+// slot returns the slot of the contract state holding the value of the owner
+func slot(owner types.Address, key types.Hash) types.Hash {
+	return types.BytesToHash(crypto.Keccak256(owner.Bytes(), key.Bytes()))
+}
@@ -0,0 +1,88 @@
+package kvstore
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type mockHost struct {
+	runtime.Host
+
+	storage map[types.Hash]types.Hash
+	topics  [][]types.Hash
+}
+
+func (m *mockHost) GetStorage(_ types.Address, key types.Hash) types.Hash {
+	return m.storage[key]
+}
+
+func (m *mockHost) SetState(_ types.Address, key types.Hash, value types.Hash) {
+	m.storage[key] = value
+}
+
+func (m *mockHost) EmitLog(_ types.Address, topics []types.Hash, _ []byte) {
+	m.topics = append(m.topics, topics)
+}
+
+func TestKVStore(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr   = types.StringToAddress("0x1000")
+		owner1 = types.StringToAddress("0x2000")
+		owner2 = types.StringToAddress("0x3000")
+		key    = types.StringToHash("0x1")
+		value  = types.StringToHash("0x2")
+	)
+
+	contract, err := Factory(json.RawMessage(`{"setGas": 1000, "getGas": 100}`))
+	require.NoError(t, err)
+
+	precompile := &stateful.Precompile{Name: Name, Address: addr, Fork: Name, Contract: contract}
+	host := &mockHost{storage: make(map[types.Hash]types.Hash)}
+
+	call := func(caller types.Address, method *abi.Method, args ...interface{}) *runtime.ExecutionResult {
+		t.Helper()
+
+		input, err := method.Encode(args)
+		require.NoError(t, err)
+
+		return precompile.Run(
+			runtime.NewContractCall(1, caller, caller, addr, big.NewInt(0), 10000, nil, input),
+			host,
+		)
+	}
+
+	result := call(owner1, SetFunc, key, value)
+	require.NoError(t, result.Err)
+	require.Equal(t, uint64(1000), result.GasUsed)
+
+	require.Len(t, host.topics, 1)
+	require.Equal(t, types.BytesToHash(ValueSetEvent.ID().Bytes()), host.topics[0][0])
+	require.Equal(t, types.BytesToHash(owner1.Bytes()), host.topics[0][1])
+
+	// the values are kept per owner
+	result = call(owner2, GetFunc, owner1, key)
+	require.NoError(t, result.Err)
+	require.Equal(t, uint64(100), result.GasUsed)
+	require.Equal(t, value.Bytes(), result.ReturnValue)
+
+	result = call(owner2, GetFunc, owner2, key)
+	require.NoError(t, result.Err)
+	require.Equal(t, types.ZeroHash.Bytes(), result.ReturnValue)
+
+	// the unknown function fails
+	input := append([]byte{0x1, 0x2, 0x3, 0x4}, make([]byte, 2*types.HashLength)...)
+
+	result = precompile.Run(runtime.NewContractCall(1, owner1, owner1, addr, big.NewInt(0), 10000, nil, input), host)
+	require.ErrorIs(t, result.Err, errFunctionNotFound)
+	require.Zero(t, result.GasLeft)
+}
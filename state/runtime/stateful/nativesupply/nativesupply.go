@@ -0,0 +1,182 @@
+// Package nativesupply is the supply hook of the networks whose native token supply
+// is managed by the designated system contract, e.g. the bridge or the governance
+package nativesupply
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Name is the name the contract is configured with in the chain params
+const Name = "nativesupply"
+
+// list of the contract functions and events
+var (
+	MintFunc        = abi.MustNewMethod("function mint(address to, uint256 amount)")
+	BurnFunc        = abi.MustNewMethod("function burn(uint256 amount)")
+	TotalMintedFunc = abi.MustNewMethod("function totalMinted() returns (uint256)")
+	TotalBurnedFunc = abi.MustNewMethod("function totalBurned() returns (uint256)")
+
+	MintedEvent = abi.MustNewEvent("event Minted(address indexed to, uint256 amount)")
+	BurnedEvent = abi.MustNewEvent("event Burned(address indexed from, uint256 amount)")
+)
+
+var (
+	errFunctionNotFound = errors.New("function not found")
+	errMintCapExceeded  = errors.New("mint cap exceeded")
+)
+
+// Config is the config of the contract
+type Config struct {
+	// Manager is the contract allowed to mint and burn the native token
+	Manager types.Address `json:"manager"`
+	// MintCap caps the net amount of the native token minted by the contract, it's unlimited if not set
+	MintCap *big.Int `json:"mintCap,omitempty"`
+	// WriteGas is the gas cost of the mint and burn functions
+	WriteGas uint64 `json:"writeGas,omitempty"`
+	// ReadGas is the gas cost of the view functions
+	ReadGas uint64 `json:"readGas,omitempty"`
+}
+
+// NativeSupply is the contract minting and burning the native token on behalf of the manager
+type NativeSupply struct {
+	config Config
+}
+
+var _ stateful.SupplyHook = (*NativeSupply)(nil)
+
+// Factory creates the contract from its config
+func Factory(rawConfig json.RawMessage) (stateful.Contract, error) {
+	config := Config{
+		WriteGas: 30000,
+		ReadGas:  2500,
+	}
+
+	if len(rawConfig) != 0 {
+		if err := json.Unmarshal(rawConfig, &config); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.Manager == types.ZeroAddress {
+		return nil, errors.New("supply manager is not set")
+	}
+
+	if config.MintCap != nil && config.MintCap.Sign() < 0 {
+		return nil, errors.New("mint cap must not be negative")
+	}
+
+	return &NativeSupply{config: config}, nil
+}
+
+func (n *NativeSupply) ManagesSupply() {}
+
+func (n *NativeSupply) RequiredGas(input []byte) uint64 {
+	if len(input) >= types.SignatureSize {
+		sig := input[:types.SignatureSize]
+		if bytes.Equal(sig, MintFunc.ID()) || bytes.Equal(sig, BurnFunc.ID()) {
+			return n.config.WriteGas
+		}
+	}
+
+	return n.config.ReadGas
+}
+
+func (n *NativeSupply) Run(ctx *stateful.Context, input []byte) ([]byte, error) {
+	if len(input) < types.SignatureSize {
+		return nil, runtime.ErrInvalidInputData
+	}
+
+	sig, input := input[:types.SignatureSize], input[types.SignatureSize:]
+
+	switch {
+	case bytes.Equal(sig, MintFunc.ID()):
+		args, err := decodeArgs(MintFunc, input)
+		if err != nil {
+			return nil, err
+		}
+
+		//nolint:forcetypeassert
+		return nil, n.mint(ctx, types.Address(args["to"].(ethgo.Address)), args["amount"].(*big.Int))
+
+	case bytes.Equal(sig, BurnFunc.ID()):
+		args, err := decodeArgs(BurnFunc, input)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, n.burn(ctx, args["amount"].(*big.Int)) //nolint:forcetypeassert
+
+	case bytes.Equal(sig, TotalMintedFunc.ID()):
+		return types.BytesToHash(ctx.MintedSupply().Bytes()).Bytes(), nil
+
+	case bytes.Equal(sig, TotalBurnedFunc.ID()):
+		return types.BytesToHash(ctx.BurnedSupply().Bytes()).Bytes(), nil
+
+	default:
+		return nil, errFunctionNotFound
+	}
+}
+
+// mint mints the amount to the account, within the mint cap
+func (n *NativeSupply) mint(ctx *stateful.Context, to types.Address, amount *big.Int) error {
+	if ctx.Caller != n.config.Manager {
+		return runtime.ErrUnauthorizedCaller
+	}
+
+	if n.config.MintCap != nil {
+		minted := new(big.Int).Sub(ctx.MintedSupply(), ctx.BurnedSupply())
+		if minted.Add(minted, amount).Cmp(n.config.MintCap) > 0 {
+			return errMintCapExceeded
+		}
+	}
+
+	if err := ctx.Mint(to, amount); err != nil {
+		return err
+	}
+
+	return ctx.EmitLog(
+		[]types.Hash{
+			types.BytesToHash(MintedEvent.ID().Bytes()),
+			types.BytesToHash(to.Bytes()),
+		},
+		types.BytesToHash(amount.Bytes()).Bytes(),
+	)
+}
+
+// burn burns the amount from the balance of the manager
+func (n *NativeSupply) burn(ctx *stateful.Context, amount *big.Int) error {
+	if ctx.Caller != n.config.Manager {
+		return runtime.ErrUnauthorizedCaller
+	}
+
+	if err := ctx.Burn(ctx.Caller, amount); err != nil {
+		return err
+	}
+
+	return ctx.EmitLog(
+		[]types.Hash{
+			types.BytesToHash(BurnedEvent.ID().Bytes()),
+			types.BytesToHash(ctx.Caller.Bytes()),
+		},
+		types.BytesToHash(amount.Bytes()).Bytes(),
+	)
+}
+
+func decodeArgs(method *abi.Method, input []byte) (map[string]interface{}, error) {
+	args, err := method.Inputs.Decode(input)
+	if err != nil {
+		return nil, runtime.ErrInvalidInputData
+	}
+
+	return args.(map[string]interface{}), nil //nolint:forcetypeassert
+}
@@ -0,0 +1,128 @@
+package nativesupply
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	address = types.StringToAddress("0x1001")
+	manager = types.StringToAddress("0x2000")
+)
+
+type mockHost struct {
+	runtime.Host
+
+	storage  map[types.Hash]types.Hash
+	balances map[types.Address]*big.Int
+	logs     int
+}
+
+func (m *mockHost) GetStorage(_ types.Address, key types.Hash) types.Hash {
+	return m.storage[key]
+}
+
+func (m *mockHost) EmitLog(types.Address, []types.Hash, []byte) {
+	m.logs++
+}
+
+func (m *mockHost) add(hook types.Address, slot types.Hash, amount *big.Int) {
+	total := new(big.Int).SetBytes(m.storage[slot].Bytes())
+	m.storage[slot] = types.BytesToHash(total.Add(total, amount).Bytes())
+}
+
+func (m *mockHost) MintNative(hook types.Address, to types.Address, amount *big.Int) error {
+	m.balances[to] = new(big.Int).Add(m.balances[to], amount)
+	m.add(hook, stateful.MintedSupplySlot, amount)
+
+	return nil
+}
+
+func (m *mockHost) BurnNative(hook types.Address, from types.Address, amount *big.Int) error {
+	if m.balances[from].Cmp(amount) < 0 {
+		return runtime.ErrInsufficientBalance
+	}
+
+	m.balances[from] = new(big.Int).Sub(m.balances[from], amount)
+	m.add(hook, stateful.BurnedSupplySlot, amount)
+
+	return nil
+}
+
+func TestFactory(t *testing.T) {
+	t.Parallel()
+
+	_, err := Factory(nil)
+	require.ErrorContains(t, err, "supply manager is not set")
+
+	_, err = Factory(json.RawMessage(`{"manager": "0x0000000000000000000000000000000000002000", "mintCap": -1}`))
+	require.ErrorContains(t, err, "mint cap must not be negative")
+
+	contract, err := Factory(json.RawMessage(`{"manager": "0x0000000000000000000000000000000000002000"}`))
+	require.NoError(t, err)
+	require.Equal(t, manager, contract.(*NativeSupply).config.Manager) //nolint:forcetypeassert
+	require.Equal(t, uint64(30000), contract.RequiredGas(MintFunc.ID()))
+	require.Equal(t, uint64(2500), contract.RequiredGas(TotalMintedFunc.ID()))
+}
+
+func TestNativeSupply(t *testing.T) {
+	t.Parallel()
+
+	receiver := types.StringToAddress("0x3000")
+
+	contract, err := Factory(json.RawMessage(`{"manager": "0x0000000000000000000000000000000000002000", "mintCap": 100}`))
+	require.NoError(t, err)
+
+	precompile := &stateful.Precompile{Name: Name, Address: address, Fork: Name, Contract: contract}
+	host := &mockHost{
+		storage:  make(map[types.Hash]types.Hash),
+		balances: map[types.Address]*big.Int{manager: big.NewInt(0), receiver: big.NewInt(0)},
+	}
+
+	call := func(caller types.Address, method *abi.Method, args ...interface{}) *runtime.ExecutionResult {
+		t.Helper()
+
+		input, err := method.Encode(args)
+		require.NoError(t, err)
+
+		return precompile.Run(
+			runtime.NewContractCall(1, caller, caller, address, big.NewInt(0), 100000, nil, input),
+			host,
+		)
+	}
+
+	// only the manager mints and burns
+	require.ErrorIs(t, call(receiver, MintFunc, receiver, big.NewInt(10)).Err, runtime.ErrUnauthorizedCaller)
+	require.ErrorIs(t, call(receiver, BurnFunc, big.NewInt(10)).Err, runtime.ErrUnauthorizedCaller)
+
+	require.NoError(t, call(manager, MintFunc, receiver, big.NewInt(60)).Err)
+	require.NoError(t, call(manager, MintFunc, manager, big.NewInt(40)).Err)
+	require.Equal(t, big.NewInt(60), host.balances[receiver])
+	require.Equal(t, 2, host.logs)
+
+	// the net minted amount is capped
+	require.ErrorIs(t, call(manager, MintFunc, receiver, big.NewInt(1)).Err, errMintCapExceeded)
+
+	// the manager burns its own balance
+	require.ErrorIs(t, call(manager, BurnFunc, big.NewInt(41)).Err, runtime.ErrInsufficientBalance)
+	require.NoError(t, call(manager, BurnFunc, big.NewInt(40)).Err)
+	require.Zero(t, host.balances[manager].Sign())
+
+	require.NoError(t, call(manager, MintFunc, receiver, big.NewInt(40)).Err)
+
+	result := call(receiver, TotalMintedFunc)
+	require.NoError(t, result.Err)
+	require.Equal(t, big.NewInt(140), new(big.Int).SetBytes(result.ReturnValue))
+
+	result = call(receiver, TotalBurnedFunc)
+	require.NoError(t, result.Err)
+	require.Equal(t, big.NewInt(40), new(big.Int).SetBytes(result.ReturnValue))
+}
@@ -0,0 +1,253 @@
+// Package randomness is the stateful precompile keeping the randomness beacon of the chain.
+// Every block proposer contributes its VRF output, the BLS signature of the parent hash and the block number
+// verified by the consensus, and the outputs contributed within the sprint are aggregated into its randomness.
+// The randomness of the sprint is exposed once the next sprint starts, so it can't be read before it's final
+package randomness
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Name is the name the contract is configured with in the chain params
+const Name = "randomness"
+
+// list of the contract functions and events
+var (
+	ContributeFunc       = abi.MustNewMethod("function contribute(uint256 sprint, address validator, bytes output)")
+	GetRandomnessFunc    = abi.MustNewMethod("function getRandomness(uint256 sprint) returns (bytes32)")
+	LatestRandomnessFunc = abi.MustNewMethod("function latestRandomness() returns (uint256 sprint, bytes32 randomness)")
+
+	ContributedEvent = abi.MustNewEvent("event Contributed(uint256 indexed sprint, address indexed validator)")
+	FinalizedEvent   = abi.MustNewEvent("event RandomnessFinalized(uint256 indexed sprint, bytes32 randomness)")
+)
+
+var (
+	errFunctionNotFound    = errors.New("function not found")
+	errNotSystemCaller     = errors.New("randomness is contributed only by the consensus")
+	errEmptyOutput         = errors.New("empty VRF output")
+	errPastSprint          = errors.New("contribution to the finalized sprint")
+	errRandomnessNotFound  = errors.New("randomness of the sprint is not finalized")
+	errNoRandomnessYet     = errors.New("no randomness is finalized yet")
+	errInvalidSprintNumber = errors.New("invalid sprint number, expected a 64-bit value")
+)
+
+var (
+	// sprintSlot holds the sprint the contributions are aggregated for, increased by one,
+	// so that the zero value means there were no contributions
+	sprintSlot = types.BytesToHash([]byte{1})
+	// accumulatorSlot holds the aggregate of the contributions
+	accumulatorSlot = types.BytesToHash([]byte{2})
+	// latestSlot holds the latest finalized sprint, increased by one
+	latestSlot = types.BytesToHash([]byte{3})
+
+	randomnessSlotPrefix = []byte("randomness")
+)
+
+// Config is the config of the contract
+type Config struct {
+	// WriteGas is the gas cost of the contribution
+	WriteGas uint64 `json:"writeGas"`
+	// ReadGas is the gas cost of the view functions
+	ReadGas uint64 `json:"readGas"`
+}
+
+// Randomness is the randomness beacon contract
+type Randomness struct {
+	config Config
+}
+
+var _ stateful.Contract = (*Randomness)(nil)
+
+// Factory creates the contract from its config
+func Factory(rawConfig json.RawMessage) (stateful.Contract, error) {
+	config := Config{
+		WriteGas: 20000,
+		ReadGas:  2500,
+	}
+
+	if len(rawConfig) != 0 {
+		if err := json.Unmarshal(rawConfig, &config); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Randomness{config: config}, nil
+}
+
+func (r *Randomness) RequiredGas(input []byte) uint64 {
+	if len(input) >= types.SignatureSize && bytes.Equal(input[:types.SignatureSize], ContributeFunc.ID()) {
+		return r.config.WriteGas
+	}
+
+	return r.config.ReadGas
+}
+
+func (r *Randomness) Run(ctx *stateful.Context, input []byte) ([]byte, error) {
+	if len(input) < types.SignatureSize {
+		return nil, runtime.ErrInvalidInputData
+	}
+
+	sig, input := input[:types.SignatureSize], input[types.SignatureSize:]
+
+	switch {
+	case bytes.Equal(sig, ContributeFunc.ID()):
+		args, err := decodeArgs(ContributeFunc, input)
+		if err != nil {
+			return nil, err
+		}
+
+		sprint := args["sprint"].(*big.Int) //nolint:forcetypeassert
+		if !sprint.IsUint64() {
+			return nil, errInvalidSprintNumber
+		}
+
+		return nil, r.contribute(ctx, sprint.Uint64(),
+			types.Address(args["validator"].(ethgo.Address)), args["output"].([]byte)) //nolint:forcetypeassert
+
+	case bytes.Equal(sig, GetRandomnessFunc.ID()):
+		args, err := decodeArgs(GetRandomnessFunc, input)
+		if err != nil {
+			return nil, err
+		}
+
+		sprint := args["sprint"].(*big.Int) //nolint:forcetypeassert
+		if !sprint.IsUint64() {
+			return nil, errRandomnessNotFound
+		}
+
+		value, ok := Get(ctx.GetState, sprint.Uint64())
+		if !ok {
+			return nil, errRandomnessNotFound
+		}
+
+		return value.Bytes(), nil
+
+	case bytes.Equal(sig, LatestRandomnessFunc.ID()):
+		sprint, value, ok := Latest(ctx.GetState)
+		if !ok {
+			return nil, errNoRandomnessYet
+		}
+
+		return LatestRandomnessFunc.Outputs.Encode(map[string]interface{}{
+			"sprint":     new(big.Int).SetUint64(sprint),
+			"randomness": value,
+		})
+
+	default:
+		return nil, errFunctionNotFound
+	}
+}
+
+// contribute aggregates the VRF output of the validator into the randomness of the sprint,
+// finalizing the randomness of the previous sprint once the first output of the next one is contributed
+func (r *Randomness) contribute(ctx *stateful.Context, sprint uint64, validator types.Address, output []byte) error {
+	if ctx.Caller != contracts.SystemCaller {
+		return errNotSystemCaller
+	}
+
+	if len(output) == 0 {
+		return errEmptyOutput
+	}
+
+	accumulator := ctx.GetState(accumulatorSlot)
+
+	if next := toBig(ctx.GetState(sprintSlot)).Uint64(); next != 0 {
+		current := next - 1
+
+		if sprint < current {
+			return errPastSprint
+		}
+
+		if sprint > current {
+			if err := finalize(ctx, current, accumulator); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := ctx.SetState(sprintSlot, types.BytesToHash(new(big.Int).SetUint64(sprint+1).Bytes())); err != nil {
+		return err
+	}
+
+	// the aggregate keeps chaining across the sprints, so the randomness of every sprint depends on the whole history
+	accumulator = types.BytesToHash(crypto.Keccak256(accumulator.Bytes(), crypto.Keccak256(output)))
+	if err := ctx.SetState(accumulatorSlot, accumulator); err != nil {
+		return err
+	}
+
+	return ctx.EmitLog(
+		[]types.Hash{
+			types.BytesToHash(ContributedEvent.ID().Bytes()),
+			types.BytesToHash(new(big.Int).SetUint64(sprint).Bytes()),
+			types.BytesToHash(validator.Bytes()),
+		},
+		nil,
+	)
+}
+
+// finalize exposes the aggregate of the contributions as the randomness of the sprint
+func finalize(ctx *stateful.Context, sprint uint64, value types.Hash) error {
+	if err := ctx.SetState(randomnessSlot(sprint), value); err != nil {
+		return err
+	}
+
+	if err := ctx.SetState(latestSlot, types.BytesToHash(new(big.Int).SetUint64(sprint+1).Bytes())); err != nil {
+		return err
+	}
+
+	return ctx.EmitLog(
+		[]types.Hash{
+			types.BytesToHash(FinalizedEvent.ID().Bytes()),
+			types.BytesToHash(new(big.Int).SetUint64(sprint).Bytes()),
+		},
+		value.Bytes(),
+	)
+}
+
+// Get returns the randomness of the sprint from the contract state, false if it's not finalized
+func Get(getState func(types.Hash) types.Hash, sprint uint64) (types.Hash, bool) {
+	value := getState(randomnessSlot(sprint))
+
+	return value, value != types.ZeroHash
+}
+
+// Latest returns the latest finalized sprint and its randomness from the contract state,
+// false if no sprint is finalized yet
+func Latest(getState func(types.Hash) types.Hash) (uint64, types.Hash, bool) {
+	latest := toBig(getState(latestSlot)).Uint64()
+	if latest == 0 {
+		return 0, types.ZeroHash, false
+	}
+
+	return latest - 1, getState(randomnessSlot(latest - 1)), true
+}
+
+func toBig(h types.Hash) *big.Int {
+	return new(big.Int).SetBytes(h.Bytes())
+}
+
+func decodeArgs(method *abi.Method, input []byte) (map[string]interface{}, error) {
+	args, err := method.Inputs.Decode(input)
+	if err != nil {
+		return nil, runtime.ErrInvalidInputData
+	}
+
+	return args.(map[string]interface{}), nil //nolint:forcetypeassert
+}
+
+// randomnessSlot returns the slot of the contract state holding the randomness of the sprint
+func randomnessSlot(sprint uint64) types.Hash {
+	return types.BytesToHash(crypto.Keccak256(randomnessSlotPrefix, new(big.Int).SetUint64(sprint).Bytes()))
+}
@@ -0,0 +1,124 @@
+package randomness
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/stateful"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var address = types.StringToAddress("0x1002")
+
+type mockHost struct {
+	runtime.Host
+
+	storage map[types.Hash]types.Hash
+	topics  [][]types.Hash
+}
+
+func (m *mockHost) GetStorage(_ types.Address, key types.Hash) types.Hash {
+	return m.storage[key]
+}
+
+func (m *mockHost) SetState(_ types.Address, key types.Hash, value types.Hash) {
+	m.storage[key] = value
+}
+
+func (m *mockHost) EmitLog(_ types.Address, topics []types.Hash, _ []byte) {
+	m.topics = append(m.topics, topics)
+}
+
+func TestRandomness(t *testing.T) {
+	t.Parallel()
+
+	validator := types.StringToAddress("0x2000")
+
+	contract, err := Factory(nil)
+	require.NoError(t, err)
+
+	precompile := &stateful.Precompile{Name: Name, Address: address, Fork: Name, Contract: contract}
+	host := &mockHost{storage: make(map[types.Hash]types.Hash)}
+
+	call := func(caller types.Address, method *abi.Method, args ...interface{}) *runtime.ExecutionResult {
+		t.Helper()
+
+		input, err := method.Encode(args)
+		require.NoError(t, err)
+
+		return precompile.Run(
+			runtime.NewContractCall(1, caller, caller, address, big.NewInt(0), 100000, nil, input),
+			host,
+		)
+	}
+
+	contribute := func(sprint int64, output []byte) *runtime.ExecutionResult {
+		t.Helper()
+
+		return call(contracts.SystemCaller, ContributeFunc, big.NewInt(sprint), validator, output)
+	}
+
+	// nothing is finalized before the first sprint ends
+	require.ErrorIs(t, call(validator, LatestRandomnessFunc).Err, errNoRandomnessYet)
+
+	// only the consensus contributes
+	require.ErrorIs(t, call(validator, ContributeFunc, big.NewInt(0), validator, []byte{1}).Err, errNotSystemCaller)
+	require.ErrorIs(t, contribute(0, nil).Err, errEmptyOutput)
+
+	require.NoError(t, contribute(0, []byte{1}).Err)
+	require.NoError(t, contribute(0, []byte{2}).Err)
+	require.ErrorIs(t, call(validator, GetRandomnessFunc, big.NewInt(0)).Err, errRandomnessNotFound)
+
+	// the first contribution of the next sprint finalizes the previous one
+	require.NoError(t, contribute(1, []byte{3}).Err)
+	require.ErrorIs(t, contribute(0, []byte{4}).Err, errPastSprint)
+
+	expected := crypto.Keccak256(types.ZeroHash.Bytes(), crypto.Keccak256([]byte{1}))
+	expected = crypto.Keccak256(expected, crypto.Keccak256([]byte{2}))
+
+	result := call(validator, GetRandomnessFunc, big.NewInt(0))
+	require.NoError(t, result.Err)
+	require.Equal(t, expected, result.ReturnValue)
+
+	result = call(validator, LatestRandomnessFunc)
+	require.NoError(t, result.Err)
+
+	latest, err := LatestRandomnessFunc.Decode(result.ReturnValue)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), latest["sprint"].(*big.Int).Uint64())                          //nolint:forcetypeassert
+	require.Equal(t, types.BytesToHash(expected), types.Hash(latest["randomness"].([32]byte))) //nolint:forcetypeassert
+
+	getState := func(key types.Hash) types.Hash { return host.storage[key] }
+
+	// the skipped sprints have no randomness, the aggregate carries over
+	require.NoError(t, contribute(3, []byte{5}).Err)
+
+	expected = crypto.Keccak256(expected, crypto.Keccak256([]byte{3}))
+
+	value, ok := Get(getState, 1)
+	require.True(t, ok)
+	require.Equal(t, types.BytesToHash(expected), value)
+
+	_, ok = Get(getState, 2)
+	require.False(t, ok)
+
+	sprint, _, ok := Latest(getState)
+	require.True(t, ok)
+	require.Equal(t, uint64(1), sprint)
+
+	var finalized int
+
+	for _, topics := range host.topics {
+		if topics[0] == types.BytesToHash(FinalizedEvent.ID().Bytes()) {
+			finalized++
+		}
+	}
+
+	require.Equal(t, 2, finalized)
+}
@@ -0,0 +1,176 @@
+package stateful
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// ErrWriteProtection is returned when the state is modified within the static call
+	ErrWriteProtection = errors.New("write protection")
+
+	// ErrDelegateCall is returned when the contract is called with delegatecall or callcode,
+	// since it would act on behalf of the caller of the calling contract
+	ErrDelegateCall = errors.New("stateful precompile can't be called with delegatecall or callcode")
+)
+
+// Contract is the custom precompiled contract with the access to its own state
+type Contract interface {
+	// RequiredGas returns the gas required to run the contract with the given input
+	RequiredGas(input []byte) uint64
+
+	// Run runs the contract with the given input.
+	// Returning runtime.ErrExecutionReverted reverts the call without consuming the remaining gas
+	Run(ctx *Context, input []byte) ([]byte, error)
+}
+
+// Factory creates the contract from its config in the chain params
+type Factory func(config json.RawMessage) (Contract, error)
+
+// Precompile is the contract placed at the address, activated by the fork
+type Precompile struct {
+	Name     string
+	Address  types.Address
+	Fork     string
+	Contract Contract
+}
+
+// NewPrecompiles creates the contracts configured in the chain params, using the factories by the contract name
+func NewPrecompiles(params *chain.Params, factories map[string]Factory) ([]*Precompile, error) {
+	precompiles := make([]*Precompile, 0, len(params.StatefulPrecompiles))
+	addresses := make(map[types.Address]struct{}, len(params.StatefulPrecompiles))
+
+	for _, config := range params.StatefulPrecompiles {
+		factory, ok := factories[config.Name]
+		if !ok {
+			return nil, fmt.Errorf("stateful precompile %s not found", config.Name)
+		}
+
+		if _, ok := addresses[config.Address]; ok {
+			return nil, fmt.Errorf("stateful precompile %s: address %s is already used", config.Name, config.Address)
+		}
+
+		if _, ok := (*params.Forks)[config.Fork]; !ok {
+			return nil, fmt.Errorf("stateful precompile %s: fork %s is not defined", config.Name, config.Fork)
+		}
+
+		contract, err := factory(config.Config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stateful precompile %s: %w", config.Name, err)
+		}
+
+		addresses[config.Address] = struct{}{}
+
+		precompiles = append(precompiles, &Precompile{
+			Name:     config.Name,
+			Address:  config.Address,
+			Fork:     config.Fork,
+			Contract: contract,
+		})
+	}
+
+	return precompiles, nil
+}
+
+// Run runs the call of the contract
+func (p *Precompile) Run(c *runtime.Contract, host runtime.Host) *runtime.ExecutionResult {
+	if c.Address != p.Address {
+		return &runtime.ExecutionResult{
+			GasLeft: 0,
+			Err:     ErrDelegateCall,
+		}
+	}
+
+	gasCost := p.Contract.RequiredGas(c.Input)
+
+	// In the case of not enough gas for precompiled execution we return ErrOutOfGas
+	if c.Gas < gasCost {
+		return &runtime.ExecutionResult{
+			GasLeft: 0,
+			Err:     runtime.ErrOutOfGas,
+		}
+	}
+
+	ctx := &Context{
+		Address: p.Address,
+		Caller:  c.Caller,
+		Value:   c.Value,
+		Static:  c.Static,
+		host:    host,
+	}
+
+	returnValue, err := p.Contract.Run(ctx, c.Input)
+
+	result := &runtime.ExecutionResult{
+		ReturnValue: returnValue,
+		GasLeft:     c.Gas - gasCost,
+		GasUsed:     gasCost,
+		Err:         err,
+	}
+
+	if result.Failed() && !errors.Is(err, runtime.ErrExecutionReverted) {
+		result.GasLeft = 0
+		result.GasUsed = c.Gas
+		result.ReturnValue = nil
+	}
+
+	return result
+}
+
+// Context is the context of the contract call.
+// The state of the contract is the storage of its address, which isn't accessible to the other contracts
+type Context struct {
+	// Address is the address of the contract
+	Address types.Address
+	// Caller is the address calling the contract
+	Caller types.Address
+	// Value is the value sent with the call
+	Value *big.Int
+	// Static is set when the call can't modify the state
+	Static bool
+
+	host runtime.Host
+}
+
+// GetState returns the value of the contract state at the given key
+func (c *Context) GetState(key types.Hash) types.Hash {
+	return c.host.GetStorage(c.Address, key)
+}
+
+// SetState sets the value of the contract state at the given key
+func (c *Context) SetState(key types.Hash, value types.Hash) error {
+	if c.Static {
+		return ErrWriteProtection
+	}
+
+	c.host.SetState(c.Address, key, value)
+
+	return nil
+}
+
+// EmitLog emits the log of the contract
+func (c *Context) EmitLog(topics []types.Hash, data []byte) error {
+	if c.Static {
+		return ErrWriteProtection
+	}
+
+	c.host.EmitLog(c.Address, topics, data)
+
+	return nil
+}
+
+// GetBalance returns the balance of the given address
+func (c *Context) GetBalance(addr types.Address) *big.Int {
+	return c.host.GetBalance(addr)
+}
+
+// BlockNumber returns the number of the block the call is executed in
+func (c *Context) BlockNumber() uint64 {
+	return uint64(c.host.GetTxContext().Number)
+}
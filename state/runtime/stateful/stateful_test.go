@@ -0,0 +1,238 @@
+package stateful
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var errConfig = errors.New("invalid config")
+
+type mockHost struct {
+	runtime.Host
+
+	storage map[types.Address]map[types.Hash]types.Hash
+	logs    int
+}
+
+func newMockHost() *mockHost {
+	return &mockHost{storage: make(map[types.Address]map[types.Hash]types.Hash)}
+}
+
+func (m *mockHost) GetStorage(addr types.Address, key types.Hash) types.Hash {
+	return m.storage[addr][key]
+}
+
+func (m *mockHost) SetState(addr types.Address, key types.Hash, value types.Hash) {
+	if _, ok := m.storage[addr]; !ok {
+		m.storage[addr] = make(map[types.Hash]types.Hash)
+	}
+
+	m.storage[addr][key] = value
+}
+
+func (m *mockHost) EmitLog(types.Address, []types.Hash, []byte) {
+	m.logs++
+}
+
+// mockContract stores the input under the zero key, failing the calls with an empty input
+type mockContract struct{}
+
+func (mockContract) RequiredGas([]byte) uint64 {
+	return 100
+}
+
+func (mockContract) Run(ctx *Context, input []byte) ([]byte, error) {
+	if len(input) == 0 {
+		return []byte{0x1}, runtime.ErrInvalidInputData
+	}
+
+	if len(input) == 1 {
+		return []byte{0x1}, runtime.ErrExecutionReverted
+	}
+
+	if err := ctx.SetState(types.ZeroHash, types.BytesToHash(input)); err != nil {
+		return nil, err
+	}
+
+	return input, ctx.EmitLog(nil, input)
+}
+
+func mockFactory(config json.RawMessage) (Contract, error) {
+	if len(config) != 0 {
+		return nil, errConfig
+	}
+
+	return mockContract{}, nil
+}
+
+func TestNewPrecompiles(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1     = types.StringToAddress("0x1000")
+		addr2     = types.StringToAddress("0x2000")
+		factories = map[string]Factory{"mock": mockFactory}
+	)
+
+	newParams := func(configs ...*chain.StatefulPrecompileConfig) *chain.Params {
+		forks := chain.AllForksEnabled.Copy()
+		forks.SetFork("mock", chain.NewFork(10))
+
+		return &chain.Params{Forks: forks, StatefulPrecompiles: configs}
+	}
+
+	cases := []struct {
+		name   string
+		params *chain.Params
+		err    string
+	}{
+		{
+			name: "valid",
+			params: newParams(
+				&chain.StatefulPrecompileConfig{Name: "mock", Address: addr1, Fork: "mock"},
+				&chain.StatefulPrecompileConfig{Name: "mock", Address: addr2, Fork: chain.London},
+			),
+		},
+		{
+			name:   "unknown contract",
+			params: newParams(&chain.StatefulPrecompileConfig{Name: "other", Address: addr1, Fork: "mock"}),
+			err:    "stateful precompile other not found",
+		},
+		{
+			name: "duplicate address",
+			params: newParams(
+				&chain.StatefulPrecompileConfig{Name: "mock", Address: addr1, Fork: "mock"},
+				&chain.StatefulPrecompileConfig{Name: "mock", Address: addr1, Fork: "mock"},
+			),
+			err: "is already used",
+		},
+		{
+			name:   "undefined fork",
+			params: newParams(&chain.StatefulPrecompileConfig{Name: "mock", Address: addr1, Fork: "other"}),
+			err:    "fork other is not defined",
+		},
+		{
+			name: "invalid config",
+			params: newParams(&chain.StatefulPrecompileConfig{
+				Name:    "mock",
+				Address: addr1,
+				Fork:    "mock",
+				Config:  json.RawMessage(`{}`),
+			}),
+			err: errConfig.Error(),
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			precompiles, err := NewPrecompiles(c.params, factories)
+			if c.err != "" {
+				require.ErrorContains(t, err, c.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, precompiles, len(c.params.StatefulPrecompiles))
+
+			for i, precompile := range precompiles {
+				require.Equal(t, c.params.StatefulPrecompiles[i].Address, precompile.Address)
+				require.Equal(t, c.params.StatefulPrecompiles[i].Fork, precompile.Fork)
+			}
+		})
+	}
+}
+
+func TestPrecompile_Run(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr   = types.StringToAddress("0x1000")
+		caller = types.StringToAddress("0x2000")
+	)
+
+	precompile := &Precompile{Name: "mock", Address: addr, Fork: "mock", Contract: mockContract{}}
+
+	newContract := func(gas uint64, input []byte) *runtime.Contract {
+		return runtime.NewContractCall(1, caller, caller, addr, big.NewInt(0), gas, nil, input)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		host := newMockHost()
+
+		result := precompile.Run(newContract(1000, []byte{0x1, 0x2}), host)
+		require.NoError(t, result.Err)
+		require.Equal(t, uint64(100), result.GasUsed)
+		require.Equal(t, uint64(900), result.GasLeft)
+		require.Equal(t, []byte{0x1, 0x2}, result.ReturnValue)
+		require.Equal(t, types.BytesToHash([]byte{0x1, 0x2}), host.storage[addr][types.ZeroHash])
+		require.Equal(t, 1, host.logs)
+	})
+
+	t.Run("out of gas", func(t *testing.T) {
+		t.Parallel()
+
+		result := precompile.Run(newContract(99, []byte{0x1, 0x2}), newMockHost())
+		require.ErrorIs(t, result.Err, runtime.ErrOutOfGas)
+		require.Zero(t, result.GasLeft)
+	})
+
+	t.Run("failure consumes all gas", func(t *testing.T) {
+		t.Parallel()
+
+		result := precompile.Run(newContract(1000, nil), newMockHost())
+		require.ErrorIs(t, result.Err, runtime.ErrInvalidInputData)
+		require.Zero(t, result.GasLeft)
+		require.Equal(t, uint64(1000), result.GasUsed)
+		require.Nil(t, result.ReturnValue)
+	})
+
+	t.Run("revert keeps the remaining gas", func(t *testing.T) {
+		t.Parallel()
+
+		result := precompile.Run(newContract(1000, []byte{0x1}), newMockHost())
+		require.ErrorIs(t, result.Err, runtime.ErrExecutionReverted)
+		require.Equal(t, uint64(900), result.GasLeft)
+		require.Equal(t, []byte{0x1}, result.ReturnValue)
+	})
+
+	t.Run("static call", func(t *testing.T) {
+		t.Parallel()
+
+		host := newMockHost()
+		contract := newContract(1000, []byte{0x1, 0x2})
+		contract.Static = true
+
+		result := precompile.Run(contract, host)
+		require.ErrorIs(t, result.Err, ErrWriteProtection)
+		require.Empty(t, host.storage)
+	})
+
+	t.Run("delegate call", func(t *testing.T) {
+		t.Parallel()
+
+		host := newMockHost()
+
+		// the code of the precompile is executed on behalf of the calling contract
+		contract := runtime.NewContractCall(1, caller, caller, caller, big.NewInt(0), 1000, nil, []byte{0x1, 0x2})
+		contract.CodeAddress = addr
+
+		result := precompile.Run(contract, host)
+		require.ErrorIs(t, result.Err, ErrDelegateCall)
+		require.Empty(t, host.storage)
+	})
+}
@@ -0,0 +1,69 @@
+package stateful
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// ErrSupplyNotManaged is returned when the native token is minted or burned by a contract
+	// which isn't a supply hook, or by a host which doesn't support it
+	ErrSupplyNotManaged = errors.New("native token supply is not managed by the contract")
+
+	// MintedSupplySlot is the slot of the supply hook state holding the total amount of the minted native token.
+	// It's written by the host, so that the supply changes are reverted along with the call minting the token
+	MintedSupplySlot = types.BytesToHash(crypto.Keccak256([]byte("stateful.supply.minted")))
+	// BurnedSupplySlot is the slot of the supply hook state holding the total amount of the burned native token
+	BurnedSupplySlot = types.BytesToHash(crypto.Keccak256([]byte("stateful.supply.burned")))
+)
+
+// SupplyHook is implemented by the contracts managing the native token supply.
+// The total supply of the blocks in which such a contract is active changes only through it
+type SupplyHook interface {
+	Contract
+
+	// ManagesSupply marks the contract as the supply hook
+	ManagesSupply()
+}
+
+// Mint mints the amount of the native token to the account
+func (c *Context) Mint(to types.Address, amount *big.Int) error {
+	if c.Static {
+		return ErrWriteProtection
+	}
+
+	manager, ok := c.host.(runtime.SupplyManager)
+	if !ok {
+		return ErrSupplyNotManaged
+	}
+
+	return manager.MintNative(c.Address, to, amount)
+}
+
+// Burn burns the amount of the native token from the account
+func (c *Context) Burn(from types.Address, amount *big.Int) error {
+	if c.Static {
+		return ErrWriteProtection
+	}
+
+	manager, ok := c.host.(runtime.SupplyManager)
+	if !ok {
+		return ErrSupplyNotManaged
+	}
+
+	return manager.BurnNative(c.Address, from, amount)
+}
+
+// MintedSupply returns the total amount of the native token minted by the contract
+func (c *Context) MintedSupply() *big.Int {
+	return new(big.Int).SetBytes(c.GetState(MintedSupplySlot).Bytes())
+}
+
+// BurnedSupply returns the total amount of the native token burned by the contract
+func (c *Context) BurnedSupply() *big.Int {
+	return new(big.Int).SetBytes(c.GetState(BurnedSupplySlot).Bytes())
+}
@@ -0,0 +1,194 @@
+package accesslisttracer
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/precompiled"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// AccessTuple is the account along with its storage slots accessed by the transaction
+type AccessTuple struct {
+	Address     types.Address `json:"address"`
+	StorageKeys []types.Hash  `json:"storageKeys"`
+}
+
+// AccessList is the EIP-2930 access list
+type AccessList []AccessTuple
+
+// AccessListTracer collects the accounts and storage slots accessed by the transaction.
+// The sender, the recipient and the precompiled contracts are omitted unless their storage is accessed,
+// since they are accessed by every transaction (EIP-2929)
+type AccessListTracer struct {
+	excluded map[types.Address]struct{}
+	// accounts holds the indexes of the accessed accounts in the list
+	accounts map[types.Address]int
+	slots    map[types.Address]map[types.Hash]struct{}
+	list     AccessList
+
+	cancelLock sync.RWMutex
+	reason     error
+	stop       bool
+}
+
+func (a *AccessListTracer) Cancel(err error) {
+	a.cancelLock.Lock()
+	defer a.cancelLock.Unlock()
+
+	a.reason = err
+	a.stop = true
+}
+
+func (a *AccessListTracer) cancelled() bool {
+	a.cancelLock.RLock()
+	defer a.cancelLock.RUnlock()
+
+	return a.stop
+}
+
+func (a *AccessListTracer) Clear() {
+	a.excluded = nil
+	a.accounts = nil
+	a.slots = nil
+	a.list = nil
+}
+
+func (a *AccessListTracer) GetResult() (interface{}, error) {
+	a.cancelLock.RLock()
+	defer a.cancelLock.RUnlock()
+
+	if a.reason != nil {
+		return nil, a.reason
+	}
+
+	return a.AccessList(), nil
+}
+
+// AccessList returns the access list collected so far, ordered by the first access
+func (a *AccessListTracer) AccessList() AccessList {
+	list := make(AccessList, len(a.list))
+
+	for i, tuple := range a.list {
+		list[i] = AccessTuple{
+			Address:     tuple.Address,
+			StorageKeys: append([]types.Hash{}, tuple.StorageKeys...),
+		}
+	}
+
+	return list
+}
+
+// TxStartState excludes the sender and the recipient of the transaction
+func (a *AccessListTracer) TxStartState(
+	from types.Address,
+	to *types.Address,
+	_ types.Address,
+	_ tracer.StateReader,
+) {
+	a.exclude(from)
+
+	if to != nil {
+		a.exclude(*to)
+	}
+}
+
+func (a *AccessListTracer) TxStart(gasLimit uint64) {
+}
+
+func (a *AccessListTracer) TxEnd(gasLeft uint64) {
+}
+
+func (a *AccessListTracer) CallStart(depth int, from, to types.Address, callType int,
+	gas uint64, value *big.Int, input []byte) {
+	// the address of the contract created by the transaction is known once the creation starts
+	if depth == 1 &&
+		(runtime.CallType(callType) == runtime.Create || runtime.CallType(callType) == runtime.Create2) {
+		a.exclude(to)
+	}
+}
+
+func (a *AccessListTracer) CallEnd(depth int, output []byte, err error) {
+}
+
+func (a *AccessListTracer) CaptureState(memory []byte, stack []*big.Int, opCode int,
+	contractAddress types.Address, sp int, host tracer.RuntimeHost, state tracer.VMState) {
+	if a.cancelled() {
+		state.Halt()
+
+		return
+	}
+
+	switch opCode {
+	case evm.SLOAD, evm.SSTORE:
+		if sp >= 1 {
+			a.addSlot(contractAddress, types.BytesToHash(stack[sp-1].Bytes()))
+		}
+
+	case evm.BALANCE, evm.EXTCODESIZE, evm.EXTCODECOPY, evm.EXTCODEHASH, evm.SELFDESTRUCT:
+		if sp >= 1 {
+			a.addAddress(types.BytesToAddress(stack[sp-1].Bytes()))
+		}
+
+	case evm.CALL, evm.CALLCODE, evm.DELEGATECALL, evm.STATICCALL:
+		if sp >= 2 {
+			a.addAddress(types.BytesToAddress(stack[sp-2].Bytes()))
+		}
+	}
+}
+
+func (a *AccessListTracer) ExecuteState(contractAddress types.Address, ip uint64, opcode string,
+	availableGas uint64, cost uint64, lastReturnData []byte, depth int, err error, host tracer.RuntimeHost) {
+}
+
+// exclude omits the address from the access list
+func (a *AccessListTracer) exclude(addr types.Address) {
+	if a.excluded == nil {
+		a.excluded = make(map[types.Address]struct{})
+	}
+
+	a.excluded[addr] = struct{}{}
+}
+
+// addAddress adds the account to the access list unless it's omitted
+func (a *AccessListTracer) addAddress(addr types.Address) {
+	if _, ok := a.excluded[addr]; ok || precompiled.IsPrecompiled(addr) {
+		return
+	}
+
+	a.tuple(addr)
+}
+
+// addSlot adds the storage slot of the account to the access list.
+// The slots of the omitted accounts are listed as well, since they aren't accessed by every transaction
+func (a *AccessListTracer) addSlot(addr types.Address, slot types.Hash) {
+	tuple := a.tuple(addr)
+
+	if _, ok := a.slots[addr][slot]; ok {
+		return
+	}
+
+	a.slots[addr][slot] = struct{}{}
+	tuple.StorageKeys = append(tuple.StorageKeys, slot)
+}
+
+// tuple returns the entry of the account in the access list, adding it if it's not there yet
+func (a *AccessListTracer) tuple(addr types.Address) *AccessTuple {
+	if a.accounts == nil {
+		a.accounts = make(map[types.Address]int)
+		a.slots = make(map[types.Address]map[types.Hash]struct{})
+	}
+
+	if idx, ok := a.accounts[addr]; ok {
+		return &a.list[idx]
+	}
+
+	a.accounts[addr] = len(a.list)
+	a.slots[addr] = make(map[types.Hash]struct{})
+	a.list = append(a.list, AccessTuple{Address: addr, StorageKeys: []types.Hash{}})
+
+	return &a.list[len(a.list)-1]
+}
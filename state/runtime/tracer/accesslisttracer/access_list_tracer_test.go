@@ -0,0 +1,100 @@
+package accesslisttracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	from    = types.StringToAddress("0x1000")
+	to      = types.StringToAddress("0x2000")
+	other   = types.StringToAddress("0x3000")
+	created = types.StringToAddress("0x4000")
+	slot1   = types.StringToHash("0x1")
+	slot2   = types.StringToHash("0x2")
+)
+
+type mockVMState struct {
+	halted bool
+}
+
+func (m *mockVMState) Halt() {
+	m.halted = true
+}
+
+func stackOf(values ...[]byte) []*big.Int {
+	stack := make([]*big.Int, len(values))
+	for i, v := range values {
+		stack[i] = new(big.Int).SetBytes(v)
+	}
+
+	return stack
+}
+
+func TestAccessListTracer_CaptureState(t *testing.T) {
+	t.Parallel()
+
+	tracer := &AccessListTracer{}
+	tracer.TxStartState(from, &to, types.ZeroAddress, nil)
+
+	state := &mockVMState{}
+
+	// the accounts are listed in the order of the first access
+	tracer.CaptureState(nil, stackOf(other.Bytes()), evm.EXTCODESIZE, to, 1, nil, state)
+	tracer.CaptureState(nil, stackOf(slot1.Bytes()), evm.SLOAD, to, 1, nil, state)
+	tracer.CaptureState(nil, stackOf(slot2.Bytes()), evm.SSTORE, to, 1, nil, state)
+	tracer.CaptureState(nil, stackOf(slot1.Bytes()), evm.SSTORE, to, 1, nil, state)
+
+	// the sender, the recipient and the precompiles are omitted
+	tracer.CaptureState(nil, stackOf(from.Bytes()), evm.BALANCE, to, 1, nil, state)
+	tracer.CaptureState(nil, stackOf(to.Bytes(), nil), evm.CALL, other, 2, nil, state)
+	tracer.CaptureState(nil, stackOf([]byte{0x1}, nil), evm.STATICCALL, to, 2, nil, state)
+
+	require.False(t, state.halted)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+	require.Equal(t, AccessList{
+		{Address: other, StorageKeys: []types.Hash{}},
+		{Address: to, StorageKeys: []types.Hash{slot1, slot2}},
+	}, res)
+}
+
+func TestAccessListTracer_CallStart_Create(t *testing.T) {
+	t.Parallel()
+
+	tracer := &AccessListTracer{}
+	tracer.TxStartState(from, nil, types.ZeroAddress, nil)
+
+	// the contract created by the transaction is omitted, unlike the ones created by the contracts
+	tracer.CallStart(1, from, created, int(runtime.Create), 1000, big.NewInt(0), nil)
+	tracer.CallStart(2, created, other, int(runtime.Create2), 1000, big.NewInt(0), nil)
+
+	tracer.CaptureState(nil, stackOf(created.Bytes()), evm.EXTCODEHASH, other, 1, nil, &mockVMState{})
+	tracer.CaptureState(nil, stackOf(other.Bytes()), evm.EXTCODEHASH, created, 1, nil, &mockVMState{})
+
+	require.Equal(t, AccessList{{Address: other, StorageKeys: []types.Hash{}}}, tracer.AccessList())
+}
+
+func TestAccessListTracer_Cancel(t *testing.T) {
+	t.Parallel()
+
+	errCancelled := errors.New("cancelled")
+
+	tracer := &AccessListTracer{}
+	tracer.Cancel(errCancelled)
+
+	state := &mockVMState{}
+	tracer.CaptureState(nil, stackOf(other.Bytes()), evm.BALANCE, to, 1, nil, state)
+	require.True(t, state.halted)
+
+	_, err := tracer.GetResult()
+	require.ErrorIs(t, err, errCancelled)
+}
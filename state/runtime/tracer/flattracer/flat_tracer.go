@@ -0,0 +1,226 @@
+package flattracer
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	callTraceType   = "call"
+	createTraceType = "create"
+)
+
+var (
+	_ tracer.Tracer        = (*FlatTracer)(nil)
+	_ tracer.CallGasTracer = (*FlatTracer)(nil)
+
+	callTypes = map[int]string{
+		0: "call",
+		1: "callcode",
+		2: "delegatecall",
+		3: "staticcall",
+	}
+)
+
+// Action is the call or the contract creation performed by the trace
+type Action struct {
+	CallType string         `json:"callType,omitempty"`
+	From     types.Address  `json:"from"`
+	To       *types.Address `json:"to,omitempty"`
+	Gas      string         `json:"gas"`
+	Input    string         `json:"input,omitempty"`
+	Init     string         `json:"init,omitempty"`
+	Value    string         `json:"value"`
+}
+
+// Result is the outcome of the successful call or contract creation
+type Result struct {
+	GasUsed string         `json:"gasUsed"`
+	Output  string         `json:"output,omitempty"`
+	Address *types.Address `json:"address,omitempty"`
+	Code    string         `json:"code,omitempty"`
+}
+
+// Trace is a single call frame in the parity (OpenEthereum) flat trace format
+type Trace struct {
+	Action       *Action `json:"action"`
+	Result       *Result `json:"result"`
+	Error        string  `json:"error,omitempty"`
+	Subtraces    int     `json:"subtraces"`
+	TraceAddress []int   `json:"traceAddress"`
+	Type         string  `json:"type"`
+
+	callee   types.Address
+	startGas uint64
+	gasLeft  uint64
+}
+
+// FlatTracer collects the call frames of a transaction as a flat list,
+// where the position of each frame in the call tree is given by its trace address
+type FlatTracer struct {
+	traces []*Trace
+	active []*Trace
+
+	cancelLock sync.RWMutex
+	reason     error
+	stop       bool
+}
+
+func (f *FlatTracer) Cancel(err error) {
+	f.cancelLock.Lock()
+	defer f.cancelLock.Unlock()
+
+	f.reason = err
+	f.stop = true
+}
+
+func (f *FlatTracer) cancelled() bool {
+	f.cancelLock.RLock()
+	defer f.cancelLock.RUnlock()
+
+	return f.stop
+}
+
+func (f *FlatTracer) Clear() {
+	f.traces = nil
+	f.active = nil
+}
+
+func (f *FlatTracer) GetResult() (interface{}, error) {
+	f.cancelLock.RLock()
+	defer f.cancelLock.RUnlock()
+
+	if f.reason != nil {
+		return nil, f.reason
+	}
+
+	traces := f.traces
+	if traces == nil {
+		traces = []*Trace{}
+	}
+
+	return traces, nil
+}
+
+func (f *FlatTracer) TxStart(gasLimit uint64) {
+}
+
+func (f *FlatTracer) TxEnd(gasLeft uint64) {
+}
+
+func (f *FlatTracer) CallStart(depth int, from, to types.Address, callType int,
+	gas uint64, value *big.Int, input []byte) {
+	if f.cancelled() {
+		return
+	}
+
+	val := "0x0"
+	if value != nil {
+		val = hex.EncodeBig(value)
+	}
+
+	trace := &Trace{
+		Action: &Action{
+			From:  from,
+			Gas:   hex.EncodeUint64(gas),
+			Value: val,
+		},
+		TraceAddress: []int{},
+		callee:       to,
+		startGas:     gas,
+	}
+
+	if callType == int(runtime.Create) || callType == int(runtime.Create2) {
+		trace.Type = createTraceType
+		trace.Action.Init = hex.EncodeToHex(input)
+	} else {
+		typ, ok := callTypes[callType]
+		if !ok {
+			typ = "unknown"
+		}
+
+		trace.Type = callTraceType
+		trace.Action.CallType = typ
+		trace.Action.To = &to
+		trace.Action.Input = hex.EncodeToHex(input)
+	}
+
+	if len(f.active) > 0 {
+		parent := f.active[len(f.active)-1]
+
+		trace.TraceAddress = append(append(trace.TraceAddress, parent.TraceAddress...), parent.Subtraces)
+		parent.Subtraces++
+	}
+
+	f.traces = append(f.traces, trace)
+	f.active = append(f.active, trace)
+}
+
+func (f *FlatTracer) CallGasLeft(depth int, gasLeft uint64) {
+	if len(f.active) == 0 {
+		return
+	}
+
+	f.active[len(f.active)-1].gasLeft = gasLeft
+}
+
+func (f *FlatTracer) CallEnd(depth int, output []byte, err error) {
+	if len(f.active) == 0 {
+		return
+	}
+
+	trace := f.active[len(f.active)-1]
+	f.active = f.active[:len(f.active)-1]
+
+	if err != nil {
+		trace.Error = errorMessage(err)
+
+		return
+	}
+
+	gasUsed := uint64(0)
+	if trace.startGas > trace.gasLeft {
+		gasUsed = trace.startGas - trace.gasLeft
+	}
+
+	trace.Result = &Result{
+		GasUsed: hex.EncodeUint64(gasUsed),
+	}
+
+	if trace.Type == createTraceType {
+		// the address of the created contract is passed as the callee
+		trace.Result.Address = &trace.callee
+		trace.Result.Code = hex.EncodeToHex(output)
+	} else {
+		trace.Result.Output = hex.EncodeToHex(output)
+	}
+}
+
+func (f *FlatTracer) CaptureState(memory []byte, stack []*big.Int, opCode int,
+	contractAddress types.Address, sp int, host tracer.RuntimeHost, state tracer.VMState) {
+	if f.cancelled() {
+		state.Halt()
+	}
+}
+
+func (f *FlatTracer) ExecuteState(contractAddress types.Address, ip uint64, opcode string,
+	availableGas uint64, cost uint64, lastReturnData []byte, depth int, err error, host tracer.RuntimeHost) {
+}
+
+// errorMessage returns the parity style error message of the failed call
+func errorMessage(err error) string {
+	switch {
+	case errors.Is(err, runtime.ErrExecutionReverted):
+		return "Reverted"
+	case errors.Is(err, runtime.ErrOutOfGas), errors.Is(err, runtime.ErrCodeStoreOutOfGas):
+		return "Out of gas"
+	default:
+		return err.Error()
+	}
+}
@@ -0,0 +1,92 @@
+package flattracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	addr1 = types.StringToAddress("1")
+	addr2 = types.StringToAddress("2")
+	addr3 = types.StringToAddress("3")
+)
+
+func TestFlatTracer_Cancel(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("timeout")
+
+	tracer := &FlatTracer{}
+	require.False(t, tracer.cancelled())
+
+	tracer.Cancel(err)
+
+	require.True(t, tracer.cancelled())
+
+	_, resErr := tracer.GetResult()
+	require.ErrorIs(t, resErr, err)
+}
+
+func TestFlatTracer_Traces(t *testing.T) {
+	t.Parallel()
+
+	tracer := &FlatTracer{}
+
+	// top level call with a nested create and a nested reverted call
+	tracer.CallStart(1, addr1, addr2, int(runtime.Call), 1000, big.NewInt(10), []byte{0x1})
+
+	tracer.CallStart(2, addr2, addr3, int(runtime.Create), 500, nil, []byte{0x2})
+	tracer.CallGasLeft(2, 200)
+	tracer.CallEnd(2, []byte{0x3}, nil)
+
+	tracer.CallStart(2, addr2, addr1, int(runtime.StaticCall), 100, nil, nil)
+	tracer.CallGasLeft(2, 0)
+	tracer.CallEnd(2, nil, runtime.ErrExecutionReverted)
+
+	tracer.CallGasLeft(1, 100)
+	tracer.CallEnd(1, []byte{0x4}, nil)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	traces, ok := res.([]*Trace)
+	require.True(t, ok)
+	require.Len(t, traces, 3)
+
+	// top level call
+	require.Equal(t, callTraceType, traces[0].Type)
+	require.Equal(t, "call", traces[0].Action.CallType)
+	require.Equal(t, addr2, *traces[0].Action.To)
+	require.Equal(t, "0xa", traces[0].Action.Value)
+	require.Equal(t, "0x384", traces[0].Result.GasUsed)
+	require.Equal(t, "0x04", traces[0].Result.Output)
+	require.Equal(t, 2, traces[0].Subtraces)
+	require.Equal(t, []int{}, traces[0].TraceAddress)
+
+	// nested create
+	require.Equal(t, createTraceType, traces[1].Type)
+	require.Nil(t, traces[1].Action.To)
+	require.Equal(t, "0x02", traces[1].Action.Init)
+	require.Equal(t, "0x0", traces[1].Action.Value)
+	require.Equal(t, addr3, *traces[1].Result.Address)
+	require.Equal(t, "0x03", traces[1].Result.Code)
+	require.Equal(t, "0x12c", traces[1].Result.GasUsed)
+	require.Equal(t, []int{0}, traces[1].TraceAddress)
+
+	// nested reverted call
+	require.Equal(t, "staticcall", traces[2].Action.CallType)
+	require.Nil(t, traces[2].Result)
+	require.Equal(t, "Reverted", traces[2].Error)
+	require.Equal(t, []int{1}, traces[2].TraceAddress)
+
+	tracer.Clear()
+
+	res, err = tracer.GetResult()
+	require.NoError(t, err)
+	require.Empty(t, res)
+}
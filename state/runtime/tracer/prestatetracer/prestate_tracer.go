@@ -0,0 +1,194 @@
+package prestatetracer
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Account is the state of an account before the traced transaction is applied
+type Account struct {
+	Balance string                    `json:"balance"`
+	Nonce   uint64                    `json:"nonce,omitempty"`
+	Code    string                    `json:"code,omitempty"`
+	Storage map[types.Hash]types.Hash `json:"storage,omitempty"`
+}
+
+// PrestateTracer collects the accounts and storage slots touched by the transaction,
+// with the values they had before the transaction was applied
+type PrestateTracer struct {
+	state    tracer.StateReader
+	accounts map[types.Address]*Account
+
+	cancelLock sync.RWMutex
+	reason     error
+	stop       bool
+}
+
+func (p *PrestateTracer) Cancel(err error) {
+	p.cancelLock.Lock()
+	defer p.cancelLock.Unlock()
+
+	p.reason = err
+	p.stop = true
+}
+
+func (p *PrestateTracer) cancelled() bool {
+	p.cancelLock.RLock()
+	defer p.cancelLock.RUnlock()
+
+	return p.stop
+}
+
+func (p *PrestateTracer) Clear() {
+	p.state = nil
+	p.accounts = nil
+}
+
+func (p *PrestateTracer) GetResult() (interface{}, error) {
+	p.cancelLock.RLock()
+	defer p.cancelLock.RUnlock()
+
+	if p.reason != nil {
+		return nil, p.reason
+	}
+
+	if p.accounts == nil {
+		return map[types.Address]*Account{}, nil
+	}
+
+	return p.accounts, nil
+}
+
+// TxStartState snapshots the accounts the transaction touches before any of its effects are applied
+func (p *PrestateTracer) TxStartState(
+	from types.Address,
+	to *types.Address,
+	coinbase types.Address,
+	state tracer.StateReader,
+) {
+	p.state = state
+
+	p.lookupAccount(from)
+	p.lookupAccount(coinbase)
+
+	if to != nil {
+		p.lookupAccount(*to)
+	}
+}
+
+func (p *PrestateTracer) TxStart(gasLimit uint64) {
+}
+
+func (p *PrestateTracer) TxEnd(gasLeft uint64) {
+}
+
+func (p *PrestateTracer) CallStart(depth int, from, to types.Address, callType int,
+	gas uint64, value *big.Int, input []byte) {
+	if p.cancelled() || p.state == nil {
+		return
+	}
+
+	p.lookupAccount(from)
+
+	if _, ok := p.accounts[to]; ok {
+		return
+	}
+
+	// the address of the created contract is unknown until the creation starts,
+	// at which point the endowment is already transferred to it
+	if runtime.CallType(callType) == runtime.Create || runtime.CallType(callType) == runtime.Create2 {
+		balance := new(big.Int).Set(p.state.GetBalance(to))
+		if value != nil {
+			balance.Sub(balance, value)
+		}
+
+		p.accounts[to] = &Account{
+			Balance: hex.EncodeBig(balance),
+		}
+
+		return
+	}
+
+	p.lookupAccount(to)
+}
+
+func (p *PrestateTracer) CallEnd(depth int, output []byte, err error) {
+}
+
+func (p *PrestateTracer) CaptureState(memory []byte, stack []*big.Int, opCode int,
+	contractAddress types.Address, sp int, host tracer.RuntimeHost, state tracer.VMState) {
+	if p.cancelled() {
+		state.Halt()
+
+		return
+	}
+
+	if p.state == nil {
+		return
+	}
+
+	switch opCode {
+	case evm.SLOAD, evm.SSTORE:
+		if sp >= 1 {
+			p.lookupStorage(contractAddress, types.BytesToHash(stack[sp-1].Bytes()))
+		}
+
+	case evm.BALANCE, evm.EXTCODESIZE, evm.EXTCODECOPY, evm.EXTCODEHASH, evm.SELFDESTRUCT:
+		if sp >= 1 {
+			p.lookupAccount(types.BytesToAddress(stack[sp-1].Bytes()))
+		}
+
+	case evm.CALL, evm.CALLCODE, evm.DELEGATECALL, evm.STATICCALL:
+		if sp >= 2 {
+			p.lookupAccount(types.BytesToAddress(stack[sp-2].Bytes()))
+		}
+	}
+}
+
+func (p *PrestateTracer) ExecuteState(contractAddress types.Address, ip uint64, opcode string,
+	availableGas uint64, cost uint64, lastReturnData []byte, depth int, err error, host tracer.RuntimeHost) {
+}
+
+// lookupAccount records the current state of the account unless it has been recorded already
+func (p *PrestateTracer) lookupAccount(addr types.Address) {
+	if p.accounts == nil {
+		p.accounts = make(map[types.Address]*Account)
+	}
+
+	if _, ok := p.accounts[addr]; ok {
+		return
+	}
+
+	account := &Account{
+		Balance: hex.EncodeBig(p.state.GetBalance(addr)),
+		Nonce:   p.state.GetNonce(addr),
+	}
+
+	if code := p.state.GetCode(addr); len(code) > 0 {
+		account.Code = hex.EncodeToHex(code)
+	}
+
+	p.accounts[addr] = account
+}
+
+// lookupStorage records the current value of the storage slot unless it has been recorded already
+func (p *PrestateTracer) lookupStorage(addr types.Address, slot types.Hash) {
+	p.lookupAccount(addr)
+
+	account := p.accounts[addr]
+	if account.Storage == nil {
+		account.Storage = make(map[types.Hash]types.Hash)
+	}
+
+	if _, ok := account.Storage[slot]; ok {
+		return
+	}
+
+	account.Storage[slot] = p.state.GetStorage(addr, slot)
+}
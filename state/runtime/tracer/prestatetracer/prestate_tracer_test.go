@@ -0,0 +1,188 @@
+package prestatetracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	from     = types.StringToAddress("0x1")
+	to       = types.StringToAddress("0x2")
+	coinbase = types.StringToAddress("0x3")
+	other    = types.StringToAddress("0x4")
+)
+
+type mockAccount struct {
+	balance *big.Int
+	nonce   uint64
+	code    []byte
+	storage map[types.Hash]types.Hash
+}
+
+type mockState map[types.Address]*mockAccount
+
+func (m mockState) get(addr types.Address) *mockAccount {
+	if acc, ok := m[addr]; ok {
+		return acc
+	}
+
+	return &mockAccount{balance: big.NewInt(0)}
+}
+
+func (m mockState) GetBalance(addr types.Address) *big.Int {
+	return m.get(addr).balance
+}
+
+func (m mockState) GetNonce(addr types.Address) uint64 {
+	return m.get(addr).nonce
+}
+
+func (m mockState) GetCode(addr types.Address) []byte {
+	return m.get(addr).code
+}
+
+func (m mockState) GetStorage(addr types.Address, slot types.Hash) types.Hash {
+	return m.get(addr).storage[slot]
+}
+
+type mockVMState struct {
+	halted bool
+}
+
+func (m *mockVMState) Halt() {
+	m.halted = true
+}
+
+func TestPrestateTracer_TxStartState(t *testing.T) {
+	t.Parallel()
+
+	state := mockState{
+		from: {balance: big.NewInt(100), nonce: 5},
+		to:   {balance: big.NewInt(1), code: []byte{0x60, 0x00}},
+	}
+
+	tracer := &PrestateTracer{}
+	tracer.TxStartState(from, &to, coinbase, state)
+
+	// the state changes after the snapshot are not reflected
+	state[from].balance = big.NewInt(50)
+	tracer.CallStart(1, from, to, int(runtime.Call), 1000, big.NewInt(10), nil)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	require.Equal(t, map[types.Address]*Account{
+		from:     {Balance: "0x64", Nonce: 5},
+		to:       {Balance: "0x1", Code: "0x6000"},
+		coinbase: {Balance: "0x0"},
+	}, res)
+}
+
+func TestPrestateTracer_CaptureState(t *testing.T) {
+	t.Parallel()
+
+	slot := types.StringToHash("0x10")
+
+	state := mockState{
+		to: {
+			balance: big.NewInt(1),
+			storage: map[types.Hash]types.Hash{slot: types.StringToHash("0x20")},
+		},
+		other: {balance: big.NewInt(7), nonce: 1},
+	}
+
+	tracer := &PrestateTracer{}
+	tracer.TxStartState(from, &to, coinbase, state)
+
+	vmState := &mockVMState{}
+
+	slotWord := new(big.Int).SetBytes(slot.Bytes())
+
+	tracer.CaptureState(nil, []*big.Int{slotWord}, evm.SLOAD, to, 1, nil, vmState)
+
+	// value is written, but the pre-state keeps the original one
+	state[to].storage[slot] = types.StringToHash("0x30")
+	tracer.CaptureState(nil, []*big.Int{big.NewInt(0), slotWord}, evm.SSTORE, to, 2, nil, vmState)
+
+	otherWord := new(big.Int).SetBytes(other.Bytes())
+	tracer.CaptureState(nil, []*big.Int{otherWord, big.NewInt(0)}, evm.CALL, to, 2, nil, vmState)
+
+	require.False(t, vmState.halted)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	accounts, ok := res.(map[types.Address]*Account)
+	require.True(t, ok)
+
+	require.Equal(t, map[types.Hash]types.Hash{slot: types.StringToHash("0x20")}, accounts[to].Storage)
+	require.Equal(t, &Account{Balance: "0x7", Nonce: 1}, accounts[other])
+}
+
+func TestPrestateTracer_CallStart_Create(t *testing.T) {
+	t.Parallel()
+
+	created := types.StringToAddress("0x5")
+
+	state := mockState{
+		from:    {balance: big.NewInt(100), nonce: 1},
+		created: {balance: big.NewInt(15), nonce: 1},
+	}
+
+	tracer := &PrestateTracer{}
+	tracer.TxStartState(from, nil, coinbase, state)
+	tracer.CallStart(1, from, created, int(runtime.Create), 1000, big.NewInt(10), nil)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	accounts, ok := res.(map[types.Address]*Account)
+	require.True(t, ok)
+
+	// the endowment is subtracted and the nonce set on creation is not reported
+	require.Equal(t, &Account{Balance: hex.EncodeBig(big.NewInt(5))}, accounts[created])
+}
+
+func TestPrestateTracer_Cancel(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("timeout")
+
+	tracer := &PrestateTracer{}
+	tracer.TxStartState(from, &to, coinbase, mockState{})
+
+	tracer.Cancel(err)
+
+	vmState := &mockVMState{}
+	tracer.CaptureState(nil, nil, int(evm.STOP), to, 0, nil, vmState)
+
+	require.True(t, vmState.halted)
+
+	res, resErr := tracer.GetResult()
+	require.Nil(t, res)
+	require.Equal(t, err, resErr)
+}
+
+func TestPrestateTracer_Clear(t *testing.T) {
+	t.Parallel()
+
+	tracer := &PrestateTracer{}
+	tracer.TxStartState(from, &to, coinbase, mockState{})
+
+	tracer.Clear()
+
+	require.Nil(t, tracer.state)
+	require.Nil(t, tracer.accounts)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+	require.Empty(t, res)
+}
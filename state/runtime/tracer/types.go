@@ -14,6 +14,27 @@ type RuntimeHost interface {
 	GetStorage(types.Address, types.Hash) types.Hash
 }
 
+// StateReader is the interface defining the methods for reading account state by tracer
+type StateReader interface {
+	GetBalance(types.Address) *big.Int
+	GetNonce(types.Address) uint64
+	GetCode(types.Address) []byte
+	GetStorage(types.Address, types.Hash) types.Hash
+}
+
+// StateTracer is implemented by the tracers which need to read the world state
+// before the transaction modifies it (e.g. the prestate tracer)
+type StateTracer interface {
+	// TxStartState is called before the transaction is applied
+	TxStartState(from types.Address, to *types.Address, coinbase types.Address, state StateReader)
+}
+
+// CallGasTracer is implemented by the tracers which need the exact gas left after each call
+type CallGasTracer interface {
+	// CallGasLeft is called right before CallEnd with the gas left after the call
+	CallGasLeft(depth int, gasLeft uint64)
+}
+
 type VMState interface {
 	// Halt tells VM to terminate its process
 	Halt()
@@ -0,0 +1,62 @@
+package txpolicy
+
+import (
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/addresslist"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// ApplyGenesisAllocs sets the initial admins, denied selectors and maximum value of the policy
+func ApplyGenesisAllocs(chainGenesis *chain.Genesis, policyAddr types.Address, config *chain.TxPolicyConfig) {
+	addresslist.ApplyGenesisAllocs(chainGenesis, policyAddr, &chain.AddressListConfig{
+		AdminAddresses: config.AdminAddresses,
+	})
+
+	policy := NewTxPolicy(&genesisState{chainGenesis}, policyAddr)
+
+	for _, denied := range config.DeniedSelectors {
+		policy.SetSelectorDenied(denied.Target, denied.Selector, true)
+	}
+
+	if config.MaxValue != nil {
+		policy.SetMaxValue(config.MaxValue)
+	}
+}
+
+type genesisState struct {
+	chain *chain.Genesis
+}
+
+func (g *genesisState) SetState(addr types.Address, key, value types.Hash) {
+	alloc, ok := g.chain.Alloc[addr]
+	if !ok {
+		alloc = &chain.GenesisAccount{}
+		g.chain.Alloc[addr] = alloc
+	}
+
+	// initialize a balance of at least 1 since otherwise
+	// the evm understand that this account is empty
+	alloc.Balance = big.NewInt(1)
+
+	if alloc.Storage == nil {
+		alloc.Storage = map[types.Hash]types.Hash{}
+	}
+
+	alloc.Storage[key] = value
+}
+
+func (g *genesisState) GetStorage(addr types.Address, key types.Hash) types.Hash {
+	alloc, ok := g.chain.Alloc[addr]
+	if !ok {
+		return types.ZeroHash
+	}
+
+	return alloc.Storage[key]
+}
+
+func (g *genesisState) GetTxContext() runtime.TxContext {
+	return runtime.TxContext{Timestamp: int64(g.chain.Timestamp)}
+}
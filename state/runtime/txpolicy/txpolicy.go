@@ -0,0 +1,294 @@
+// Package txpolicy is the system contract denying the transactions which call the listed
+// function selectors or transfer more value than the threshold.
+// The policy is enforced both at the transaction pool admission and at the block execution
+package txpolicy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/addresslist"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// list of the contract functions and events.
+// The admins of the policy are managed with the address list functions (i.e. setAdmin)
+var (
+	DenySelectorFunc     = abi.MustNewMethod("function denySelector(address target, bytes4 selector)")
+	AllowSelectorFunc    = abi.MustNewMethod("function allowSelector(address target, bytes4 selector)")
+	IsSelectorDeniedFunc = abi.MustNewMethod(
+		"function isSelectorDenied(address target, bytes4 selector) returns (bool)")
+	SetMaxValueFunc = abi.MustNewMethod("function setMaxValue(uint256 value)")
+	MaxValueFunc    = abi.MustNewMethod("function maxValue() returns (uint256)")
+
+	TransactionDeniedEvent = abi.MustNewEvent("event TransactionDenied(address indexed from, " +
+		"address indexed to, bytes4 selector, uint256 value)")
+
+	// deniedEventDataType is the type of the non-indexed fields of the denial event
+	deniedEventDataType = abi.MustNewType("tuple(bytes4 selector, uint256 value)")
+)
+
+// list of gas costs for the operations
+var (
+	writePolicyCost = uint64(20000)
+	readPolicyCost  = uint64(5000)
+)
+
+var (
+	// ErrSelectorDenied is returned when the transaction calls the function selector denied by the policy
+	ErrSelectorDenied = errors.New("function selector denied by the transaction policy")
+	// ErrValueAboveThreshold is returned when the transaction transfers more value than allowed by the policy
+	ErrValueAboveThreshold = errors.New("value above the transaction policy threshold")
+
+	errInvalidInput    = errors.New("invalid input")
+	errWriteProtection = errors.New("write protection")
+)
+
+var (
+	// maxValueSlot holds the maximum value transferred by a transaction
+	maxValueSlot = types.BytesToHash(crypto.Keccak256([]byte("maxValue")))
+
+	deniedSelectorPrefix = []byte("deniedSelector")
+
+	// deniedFlag is the value of the denied selector slots
+	deniedFlag = types.BytesToHash([]byte{1})
+)
+
+// TxPolicy is the transaction policy contract
+type TxPolicy struct {
+	state  stateRef
+	addr   types.Address
+	admins *addresslist.AddressList
+}
+
+// NewTxPolicy creates the transaction policy kept in the state of the given address
+func NewTxPolicy(state stateRef, addr types.Address) *TxPolicy {
+	return &TxPolicy{
+		state:  state,
+		addr:   addr,
+		admins: addresslist.NewAddressList(state, addr),
+	}
+}
+
+func (p *TxPolicy) Addr() types.Address {
+	return p.addr
+}
+
+func (p *TxPolicy) Run(c *runtime.Contract, host runtime.Host, config *chain.ForksInTime) *runtime.ExecutionResult {
+	ret, gasUsed, handled, err := p.runInputCall(c.Caller, c.Input, c.Gas, c.Static)
+	if !handled {
+		// the admins are managed with the address list functions
+		return p.admins.Run(c, host, config)
+	}
+
+	return &runtime.ExecutionResult{
+		ReturnValue: ret,
+		GasUsed:     gasUsed,
+		GasLeft:     c.Gas - gasUsed,
+		Err:         err,
+	}
+}
+
+// runInputCall runs the policy function, and returns false if the input doesn't call one
+func (p *TxPolicy) runInputCall(caller types.Address, input []byte,
+	gas uint64, isStatic bool) ([]byte, uint64, bool, error) {
+	if len(input) < types.SignatureSize {
+		return nil, 0, false, nil
+	}
+
+	sig, inputBytes := input[:types.SignatureSize], input[types.SignatureSize:]
+
+	var (
+		method  *abi.Method
+		isWrite bool
+	)
+
+	switch {
+	case bytes.Equal(sig, DenySelectorFunc.ID()):
+		method, isWrite = DenySelectorFunc, true
+	case bytes.Equal(sig, AllowSelectorFunc.ID()):
+		method, isWrite = AllowSelectorFunc, true
+	case bytes.Equal(sig, SetMaxValueFunc.ID()):
+		method, isWrite = SetMaxValueFunc, true
+	case bytes.Equal(sig, IsSelectorDeniedFunc.ID()):
+		method = IsSelectorDeniedFunc
+	case bytes.Equal(sig, MaxValueFunc.ID()):
+		method = MaxValueFunc
+	default:
+		return nil, 0, false, nil
+	}
+
+	raw, err := method.Inputs.Decode(inputBytes)
+	if err != nil {
+		return nil, 0, true, errInvalidInput
+	}
+
+	args, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, 0, true, errInvalidInput
+	}
+
+	if !isWrite {
+		if gas < readPolicyCost {
+			return nil, 0, true, runtime.ErrOutOfGas
+		}
+
+		ret, err := p.runRead(method, args)
+
+		return ret, readPolicyCost, true, err
+	}
+
+	if gas < writePolicyCost {
+		return nil, 0, true, runtime.ErrOutOfGas
+	}
+
+	// we cannot perform any write operation if the call is static
+	if isStatic {
+		return nil, writePolicyCost, true, errWriteProtection
+	}
+
+	// Only Admin accounts can update the policy
+	if p.admins.GetRole(caller) != addresslist.AdminRole {
+		return nil, writePolicyCost, true, runtime.ErrNotAuth
+	}
+
+	return nil, writePolicyCost, true, p.runWrite(method, args)
+}
+
+func (p *TxPolicy) runRead(method *abi.Method, args map[string]interface{}) ([]byte, error) {
+	if method == MaxValueFunc {
+		return MaxValueFunc.Outputs.Encode([]interface{}{p.MaxValue()})
+	}
+
+	target, okTarget := args["target"].(ethgo.Address)
+	selector, okSelector := args["selector"].([4]byte)
+
+	if !okTarget || !okSelector {
+		return nil, errInvalidInput
+	}
+
+	return IsSelectorDeniedFunc.Outputs.Encode([]interface{}{
+		p.IsSelectorDenied(types.Address(target), selector),
+	})
+}
+
+func (p *TxPolicy) runWrite(method *abi.Method, args map[string]interface{}) error {
+	if method == SetMaxValueFunc {
+		value, ok := args["value"].(*big.Int)
+		if !ok {
+			return errInvalidInput
+		}
+
+		p.SetMaxValue(value)
+
+		return nil
+	}
+
+	target, okTarget := args["target"].(ethgo.Address)
+	selector, okSelector := args["selector"].([4]byte)
+
+	if !okTarget || !okSelector {
+		return errInvalidInput
+	}
+
+	p.SetSelectorDenied(types.Address(target), selector, method == DenySelectorFunc)
+
+	return nil
+}
+
+// Check returns the error if the transaction is denied by the policy
+func (p *TxPolicy) Check(to *types.Address, input []byte, value *big.Int) error {
+	if maxValue := p.MaxValue(); maxValue.Sign() > 0 && value != nil && value.Cmp(maxValue) > 0 {
+		return fmt.Errorf("%w: %s > %s", ErrValueAboveThreshold, value, maxValue)
+	}
+
+	if to == nil || len(input) < types.SignatureSize {
+		return nil
+	}
+
+	var selector [4]byte
+
+	copy(selector[:], input)
+
+	// the selectors denied on the zero address are denied on every contract
+	if p.IsSelectorDenied(*to, selector) || p.IsSelectorDenied(types.ZeroAddress, selector) {
+		return fmt.Errorf("%w: 0x%x on %s", ErrSelectorDenied, selector, to)
+	}
+
+	return nil
+}
+
+// EmitDenied logs the transaction denied by the policy
+func (p *TxPolicy) EmitDenied(emitter logEmitter, from types.Address, to *types.Address,
+	input []byte, value *big.Int) error {
+	var (
+		selector [4]byte
+		target   types.Address
+	)
+
+	copy(selector[:], input)
+
+	if to != nil {
+		target = *to
+	}
+
+	data, err := deniedEventDataType.Encode([]interface{}{selector, value})
+	if err != nil {
+		return err
+	}
+
+	emitter.EmitLog(p.addr, []types.Hash{
+		types.Hash(TransactionDeniedEvent.ID()),
+		types.BytesToHash(from.Bytes()),
+		types.BytesToHash(target.Bytes()),
+	}, data)
+
+	return nil
+}
+
+// SetSelectorDenied denies or allows the function selector on the target contract
+func (p *TxPolicy) SetSelectorDenied(target types.Address, selector [4]byte, denied bool) {
+	value := types.ZeroHash
+	if denied {
+		value = deniedFlag
+	}
+
+	p.state.SetState(p.addr, deniedSelectorSlot(target, selector), value)
+}
+
+// IsSelectorDenied returns true if the function selector is denied on the target contract
+func (p *TxPolicy) IsSelectorDenied(target types.Address, selector [4]byte) bool {
+	return p.state.GetStorage(p.addr, deniedSelectorSlot(target, selector)) != types.ZeroHash
+}
+
+// SetMaxValue sets the maximum value transferred by a transaction, zero disables the limit
+func (p *TxPolicy) SetMaxValue(value *big.Int) {
+	p.state.SetState(p.addr, maxValueSlot, types.BytesToHash(value.Bytes()))
+}
+
+// MaxValue returns the maximum value transferred by a transaction, zero if unlimited
+func (p *TxPolicy) MaxValue() *big.Int {
+	return new(big.Int).SetBytes(p.state.GetStorage(p.addr, maxValueSlot).Bytes())
+}
+
+func deniedSelectorSlot(target types.Address, selector [4]byte) types.Hash {
+	return types.BytesToHash(crypto.Keccak256(deniedSelectorPrefix, target.Bytes(), selector[:]))
+}
+
+type stateRef interface {
+	SetState(addr types.Address, key, value types.Hash)
+	GetStorage(addr types.Address, key types.Hash) types.Hash
+	GetTxContext() runtime.TxContext
+}
+
+type logEmitter interface {
+	EmitLog(addr types.Address, topics []types.Hash, data []byte)
+}
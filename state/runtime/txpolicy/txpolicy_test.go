@@ -0,0 +1,146 @@
+package txpolicy
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/addresslist"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type mockState struct {
+	state map[types.Hash]types.Hash
+}
+
+func (m *mockState) SetState(_ types.Address, key, value types.Hash) {
+	m.state[key] = value
+}
+
+func (m *mockState) GetStorage(_ types.Address, key types.Hash) types.Hash {
+	return m.state[key]
+}
+
+func (m *mockState) GetTxContext() runtime.TxContext {
+	return runtime.TxContext{}
+}
+
+func newMockTxPolicy() *TxPolicy {
+	return NewTxPolicy(&mockState{state: map[types.Hash]types.Hash{}}, types.Address{0x4})
+}
+
+func TestTxPolicy_Write(t *testing.T) {
+	t.Parallel()
+
+	var (
+		admin    = types.Address{0x1}
+		target   = types.Address{0x2}
+		selector = [4]byte{0xa9, 0x05, 0x9c, 0xbb}
+	)
+
+	p := newMockTxPolicy()
+	p.admins.SetRole(admin, addresslist.AdminRole)
+
+	deny, err := DenySelectorFunc.Encode([]interface{}{ethgo.Address(target), selector})
+	require.NoError(t, err)
+
+	_, _, handled, err := p.runInputCall(admin, deny, writePolicyCost-1, false)
+	require.True(t, handled)
+	require.Equal(t, runtime.ErrOutOfGas, err)
+
+	_, _, _, err = p.runInputCall(admin, deny, writePolicyCost, true)
+	require.Equal(t, errWriteProtection, err)
+
+	_, gasUsed, _, err := p.runInputCall(target, deny, writePolicyCost, false)
+	require.Equal(t, writePolicyCost, gasUsed)
+	require.Equal(t, runtime.ErrNotAuth, err)
+
+	_, _, _, err = p.runInputCall(admin, deny, writePolicyCost, false)
+	require.NoError(t, err)
+	require.True(t, p.IsSelectorDenied(target, selector))
+
+	isDenied, err := IsSelectorDeniedFunc.Encode([]interface{}{ethgo.Address(target), selector})
+	require.NoError(t, err)
+
+	ret, gasUsed, _, err := p.runInputCall(target, isDenied, readPolicyCost, false)
+	require.NoError(t, err)
+	require.Equal(t, readPolicyCost, gasUsed)
+	require.Equal(t, types.BytesToHash([]byte{1}).Bytes(), ret)
+
+	allow, err := AllowSelectorFunc.Encode([]interface{}{ethgo.Address(target), selector})
+	require.NoError(t, err)
+
+	_, _, _, err = p.runInputCall(admin, allow, writePolicyCost, false)
+	require.NoError(t, err)
+	require.False(t, p.IsSelectorDenied(target, selector))
+
+	setMaxValue, err := SetMaxValueFunc.Encode([]interface{}{big.NewInt(1000)})
+	require.NoError(t, err)
+
+	_, _, _, err = p.runInputCall(admin, setMaxValue, writePolicyCost, false)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1000), p.MaxValue())
+
+	// the address list functions are not handled by the policy
+	_, _, handled, _ = p.runInputCall(admin, addresslist.SetAdminFunc.ID(), writePolicyCost, false)
+	require.False(t, handled)
+}
+
+func TestTxPolicy_Check(t *testing.T) {
+	t.Parallel()
+
+	var (
+		target   = types.Address{0x2}
+		other    = types.Address{0x3}
+		transfer = [4]byte{0xa9, 0x05, 0x9c, 0xbb}
+		approve  = [4]byte{0x09, 0x5e, 0xa7, 0xb3}
+	)
+
+	p := newMockTxPolicy()
+
+	// nothing is denied by default
+	require.NoError(t, p.Check(&target, transfer[:], big.NewInt(1_000_000)))
+
+	p.SetMaxValue(big.NewInt(100))
+	p.SetSelectorDenied(target, transfer, true)
+	p.SetSelectorDenied(types.ZeroAddress, approve, true)
+
+	require.NoError(t, p.Check(&target, nil, big.NewInt(100)))
+	require.ErrorIs(t, p.Check(&target, nil, big.NewInt(101)), ErrValueAboveThreshold)
+	require.ErrorIs(t, p.Check(nil, nil, big.NewInt(101)), ErrValueAboveThreshold)
+
+	require.ErrorIs(t, p.Check(&target, transfer[:], big.NewInt(0)), ErrSelectorDenied)
+	require.NoError(t, p.Check(&other, transfer[:], big.NewInt(0)))
+
+	// the selectors denied on the zero address are denied on every contract
+	require.ErrorIs(t, p.Check(&target, approve[:], big.NewInt(0)), ErrSelectorDenied)
+	require.ErrorIs(t, p.Check(&other, approve[:], big.NewInt(0)), ErrSelectorDenied)
+}
+
+func TestTxPolicy_ApplyGenesisAllocs(t *testing.T) {
+	t.Parallel()
+
+	var (
+		admin  = types.Address{0x1}
+		target = types.Address{0x2}
+		addr   = types.Address{0x4}
+	)
+
+	genesis := &chain.Genesis{Alloc: map[types.Address]*chain.GenesisAccount{}}
+
+	ApplyGenesisAllocs(genesis, addr, &chain.TxPolicyConfig{
+		AdminAddresses:  []types.Address{admin},
+		DeniedSelectors: []*chain.DeniedSelector{{Target: target, Selector: chain.FunctionSelector{0x1}}},
+		MaxValue:        big.NewInt(100),
+	})
+
+	p := NewTxPolicy(&genesisState{genesis}, addr)
+
+	require.Equal(t, addresslist.AdminRole, p.admins.GetRole(admin))
+	require.True(t, p.IsSelectorDenied(target, [4]byte{0x1}))
+	require.Equal(t, big.NewInt(100), p.MaxValue())
+}
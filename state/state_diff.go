@@ -0,0 +1,152 @@
+package state
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// DiffKind is the kind of the change of an account or of a storage slot
+type DiffKind string
+
+const (
+	DiffCreated DiffKind = "created"
+	DiffUpdated DiffKind = "updated"
+	DiffDeleted DiffKind = "deleted"
+)
+
+// StateDiff is the change of the state made by a block
+type StateDiff struct {
+	Accounts []*AccountDiff
+}
+
+// AccountDiff is the change of an account, along with the changed slots of its storage.
+// The values of the deleted account are zero
+type AccountDiff struct {
+	Address types.Address
+	Kind    DiffKind
+
+	Balance     *big.Int
+	PrevBalance *big.Int
+	Nonce       uint64
+	PrevNonce   uint64
+	CodeHash    types.Hash
+	Code        []byte
+
+	Storage []*StorageDiff
+}
+
+// StorageDiff is the change of a storage slot, the deleted slot has the zero value
+type StorageDiff struct {
+	Key       types.Hash
+	Kind      DiffKind
+	Value     types.Hash
+	PrevValue types.Hash
+}
+
+// CollectStateDiff makes the transition compute the state diff on commit
+func (t *Transition) CollectStateDiff() {
+	t.collectStateDiff = true
+}
+
+// StateDiff returns the state diff of the committed transition, nil if it is not collected
+func (t *Transition) StateDiff() *StateDiff {
+	return t.stateDiff
+}
+
+// ComputeStateDiff returns the state diff of the transition without writing its changes to the state.
+// The changes are committed to the journal of the transition, so the transition can't be committed afterwards
+func (t *Transition) ComputeStateDiff() (*StateDiff, error) {
+	objs, err := t.state.Commit(t.config.EIP155)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.diffState(objs)
+}
+
+// diffState compares the committed objects with the parent state. The accounts which are touched,
+// but not changed, are skipped. The storage of the account recreated in the block only has the written slots,
+// since the slots of the previous account are discarded without being read
+func (t *Transition) diffState(objs []*Object) (*StateDiff, error) {
+	diff := &StateDiff{Accounts: make([]*AccountDiff, 0, len(objs))}
+
+	for _, obj := range objs {
+		prev, err := t.state.snapshot.GetAccount(obj.Address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get account %s: %w", obj.Address, err)
+		}
+
+		account := &AccountDiff{
+			Address:     obj.Address,
+			Kind:        DiffUpdated,
+			Balance:     big.NewInt(0),
+			PrevBalance: big.NewInt(0),
+		}
+
+		prevRoot := types.EmptyRootHash
+
+		if prev != nil {
+			account.PrevBalance = new(big.Int).Set(prev.Balance)
+			account.PrevNonce = prev.Nonce
+			prevRoot = prev.Root
+		}
+
+		switch {
+		case obj.Deleted && prev == nil:
+			continue
+		case obj.Deleted:
+			account.Kind = DiffDeleted
+
+			diff.Accounts = append(diff.Accounts, account)
+
+			continue
+		case prev == nil:
+			account.Kind = DiffCreated
+		}
+
+		account.Balance = new(big.Int).Set(obj.Balance)
+		account.Nonce = obj.Nonce
+		account.CodeHash = obj.CodeHash
+
+		if obj.DirtyCode {
+			account.Code = obj.Code
+		}
+
+		for _, entry := range obj.Storage {
+			key := types.BytesToHash(entry.Key)
+			slot := &StorageDiff{
+				Key:       key,
+				PrevValue: t.state.snapshot.GetStorage(obj.Address, prevRoot, key),
+			}
+
+			if !entry.Deleted {
+				slot.Value = types.BytesToHash(entry.Val)
+			}
+
+			switch {
+			case slot.Value == slot.PrevValue:
+				continue
+			case slot.PrevValue == types.ZeroHash:
+				slot.Kind = DiffCreated
+			case slot.Value == types.ZeroHash:
+				slot.Kind = DiffDeleted
+			default:
+				slot.Kind = DiffUpdated
+			}
+
+			account.Storage = append(account.Storage, slot)
+		}
+
+		if prev != nil && len(account.Storage) == 0 && account.Nonce == prev.Nonce &&
+			account.Balance.Cmp(prev.Balance) == 0 && bytes.Equal(obj.CodeHash.Bytes(), prev.CodeHash) {
+			continue
+		}
+
+		diff.Accounts = append(diff.Accounts, account)
+	}
+
+	return diff, nil
+}
@@ -0,0 +1,107 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// diffSnapshot is the snapshot without the missing accounts, like the snapshot of the state trie
+type diffSnapshot struct {
+	mockSnapshot
+}
+
+func (s *diffSnapshot) GetAccount(addr types.Address) (*Account, error) {
+	raw, ok := s.state[addr]
+	if !ok {
+		return nil, nil
+	}
+
+	return &Account{
+		Balance:  new(big.Int).SetUint64(raw.Balance),
+		Nonce:    raw.Nonce,
+		Root:     types.StringToHash("root"),
+		CodeHash: types.EmptyCodeHash.Bytes(),
+	}, nil
+}
+
+func TestTransition_StateDiff(t *testing.T) {
+	t.Parallel()
+
+	var (
+		updated   = types.StringToAddress("1")
+		destroyed = types.StringToAddress("2")
+		touched   = types.StringToAddress("3")
+		created   = types.StringToAddress("4")
+
+		slot1 = types.StringToHash("1")
+		slot2 = types.StringToHash("2")
+		slot3 = types.StringToHash("3")
+		slot4 = types.StringToHash("4")
+	)
+
+	snap := &diffSnapshot{mockSnapshot{state: map[types.Address]*PreState{
+		updated: {
+			Balance: 10,
+			State: map[types.Hash]types.Hash{
+				slot1: types.StringToHash("10"),
+				slot2: types.StringToHash("20"),
+				slot4: types.StringToHash("40"),
+			},
+		},
+		destroyed: {Balance: 5},
+		touched:   {Balance: 3, Nonce: 1},
+	}}}
+
+	tr := NewTransition(chain.AllForksEnabled.At(0), snap, newTxn(snap))
+	txn := tr.Txn()
+
+	txn.SetNonce(updated, 1)
+	txn.SetState(updated, slot1, types.StringToHash("11"))
+	txn.SetState(updated, slot2, types.ZeroHash)
+	txn.SetState(updated, slot3, types.StringToHash("30"))
+	txn.SetState(updated, slot4, types.StringToHash("40"))
+
+	txn.Suicide(destroyed)
+	txn.AddBalance(touched, big.NewInt(0))
+	txn.AddBalance(created, big.NewInt(7))
+
+	diff, err := tr.ComputeStateDiff()
+	require.NoError(t, err)
+
+	accounts := make(map[types.Address]*AccountDiff, len(diff.Accounts))
+	for _, account := range diff.Accounts {
+		accounts[account.Address] = account
+	}
+
+	// the touched account isn't changed
+	require.Len(t, accounts, 3)
+
+	account := accounts[updated]
+	assert.Equal(t, DiffUpdated, account.Kind)
+	assert.Equal(t, uint64(0), account.PrevNonce)
+	assert.Equal(t, uint64(1), account.Nonce)
+	assert.Equal(t, big.NewInt(10), account.Balance)
+
+	// the slot written with its previous value isn't changed
+	assert.ElementsMatch(t, []*StorageDiff{
+		{Key: slot1, Kind: DiffUpdated, Value: types.StringToHash("11"), PrevValue: types.StringToHash("10")},
+		{Key: slot2, Kind: DiffDeleted, PrevValue: types.StringToHash("20")},
+		{Key: slot3, Kind: DiffCreated, Value: types.StringToHash("30")},
+	}, account.Storage)
+
+	account = accounts[destroyed]
+	assert.Equal(t, DiffDeleted, account.Kind)
+	assert.Equal(t, big.NewInt(5), account.PrevBalance)
+	assert.Zero(t, account.Balance.Sign())
+
+	account = accounts[created]
+	assert.Equal(t, DiffCreated, account.Kind)
+	assert.Equal(t, big.NewInt(7), account.Balance)
+	assert.Zero(t, account.PrevBalance.Sign())
+}
@@ -28,6 +28,12 @@ var (
 
 	// refundIndex is the index of the refund
 	refundIndex = types.BytesToHash([]byte{3}).Bytes()
+
+	// transientIndex is the index of the transient storage (EIP-1153)
+	transientIndex = types.BytesToHash([]byte{4}).Bytes()
+
+	// destroyedIndex is the index of the balance destroyed by the self-destructs
+	destroyedIndex = types.BytesToHash([]byte{5}).Bytes()
 )
 
 // Txn is a reference of the state
@@ -456,6 +462,22 @@ func (txn *Txn) SubRefund(gas uint64) {
 	txn.txn.Insert(refundIndex, refund)
 }
 
+// AddDestroyed adds the balance destroyed by the self-destruct
+func (txn *Txn) AddDestroyed(amount *big.Int) {
+	txn.txn.Insert(destroyedIndex, new(big.Int).Add(txn.GetDestroyed(), amount))
+}
+
+// GetDestroyed returns the total balance destroyed by the self-destructs
+func (txn *Txn) GetDestroyed() *big.Int {
+	data, exists := txn.txn.Get(destroyedIndex)
+	if !exists {
+		return big.NewInt(0)
+	}
+
+	//nolint:forcetypeassert
+	return data.(*big.Int)
+}
+
 func (txn *Txn) Logs() []*types.Log {
 	data, exists := txn.txn.Get(logIndex)
 	if !exists {
@@ -477,6 +499,41 @@ func (txn *Txn) GetRefund() uint64 {
 	return data.(uint64)
 }
 
+// GetTransientState returns the value of the transient storage slot of the address
+func (txn *Txn) GetTransientState(addr types.Address, key types.Hash) types.Hash {
+	data, exists := txn.txn.Get(transientIndex)
+	if !exists {
+		return types.Hash{}
+	}
+
+	//nolint:forcetypeassert
+	val, exists := data.(*iradix.Tree).Get(append(addr.Bytes(), key.Bytes()...))
+	if !exists {
+		return types.Hash{}
+	}
+
+	//nolint:forcetypeassert
+	return val.(types.Hash)
+}
+
+// SetTransientState sets the value of the transient storage slot of the address.
+// The transient storage is kept in the immutable tree, so it's reverted along with the state
+func (txn *Txn) SetTransientState(addr types.Address, key types.Hash, value types.Hash) {
+	storage := iradix.New()
+	if data, exists := txn.txn.Get(transientIndex); exists {
+		//nolint:forcetypeassert
+		storage = data.(*iradix.Tree)
+	}
+
+	storage, _, _ = storage.Insert(append(addr.Bytes(), key.Bytes()...), value)
+	txn.txn.Insert(transientIndex, storage)
+}
+
+// ClearTransientStorage discards the transient storage at the end of the transaction
+func (txn *Txn) ClearTransientStorage() {
+	txn.txn.Delete(transientIndex)
+}
+
 // GetCommittedState returns the state of the address in the trie
 func (txn *Txn) GetCommittedState(addr types.Address, key types.Hash) types.Hash {
 	obj, ok := txn.getStateObject(addr)
@@ -92,3 +92,33 @@ func TestIncrNonce(t *testing.T) {
 	require.NoError(t, txn.IncrNonce(address1))
 	require.Equal(t, nonMaxUint64NonceValue+1, txn.GetNonce(address1))
 }
+
+func TestTxn_TransientStorage(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr = types.StringToAddress("0x1")
+		key  = types.StringToHash("0x2")
+		val1 = types.StringToHash("0x3")
+		val2 = types.StringToHash("0x4")
+	)
+
+	txn := newTestTxn(defaultPreState)
+
+	txn.SetTransientState(addr, key, val1)
+	require.Equal(t, val1, txn.GetTransientState(addr, key))
+
+	// the transient storage is not part of the persistent storage
+	require.Equal(t, types.Hash{}, txn.GetState(addr, key))
+
+	// the transient storage is reverted along with the snapshot
+	snapshot := txn.Snapshot()
+	txn.SetTransientState(addr, key, val2)
+	require.Equal(t, val2, txn.GetTransientState(addr, key))
+
+	require.NoError(t, txn.RevertToSnapshot(snapshot))
+	require.Equal(t, val1, txn.GetTransientState(addr, key))
+
+	txn.ClearTransientStorage()
+	require.Equal(t, types.Hash{}, txn.GetTransientState(addr, key))
+}
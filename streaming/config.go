@@ -0,0 +1,136 @@
+package streaming
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// BrokerKafka publishes to the Kafka topics over the Kafka REST proxy (v2 API)
+	BrokerKafka = "kafka"
+	// BrokerNATS publishes to the NATS subjects
+	BrokerNATS = "nats"
+
+	EncodingJSON     = "json"
+	EncodingProtobuf = "protobuf"
+)
+
+var (
+	errUnknownBroker   = errors.New("unknown streaming broker, expected kafka or nats")
+	errUnknownEncoding = errors.New("unknown streaming encoding, expected json or protobuf")
+	errInvalidURL      = errors.New("invalid streaming broker URL")
+	errNoTopic         = errors.New("the stream topic is not set")
+	errNoStreams       = errors.New("neither the streams nor the headers topic are set")
+)
+
+// Config is the broker the contract events and the block headers are published to
+type Config struct {
+	// Broker is either kafka or nats
+	Broker string `json:"broker" yaml:"broker"`
+	// URL is the Kafka REST proxy (http[s]://host:port) or the NATS server (nats://[user:pass@]host:port,
+	// tls:// for the TLS connection)
+	URL string `json:"url" yaml:"url"`
+	// Encoding of the messages, either json (default) or protobuf
+	Encoding string `json:"encoding,omitempty" yaml:"encoding,omitempty"`
+	// HeadersTopic is the topic of the new block headers, which are not published if empty
+	HeadersTopic string `json:"headers_topic,omitempty" yaml:"headers_topic,omitempty"`
+	// Streams are the contracts whose events are published
+	Streams []*Stream `json:"streams,omitempty" yaml:"streams,omitempty"`
+}
+
+// Stream publishes the events of the contract to the topic. The events are tracked by the event tracker,
+// so they are published once they have the number of the confirmations, and the progress is persisted
+type Stream struct {
+	Topic    string `json:"topic" yaml:"topic"`
+	Contract string `json:"contract" yaml:"contract"`
+	// Events are the ABI signatures of the published events (e.g. "event Transfer(address indexed from,
+	// address indexed to, uint256 value)"), which are decoded. All the logs are published undecoded if empty
+	Events        []string `json:"events,omitempty" yaml:"events,omitempty"`
+	Confirmations uint64   `json:"confirmations,omitempty" yaml:"confirmations,omitempty"`
+	StartBlock    uint64   `json:"start_block,omitempty" yaml:"start_block,omitempty"`
+}
+
+// Validate checks the broker, the encoding and the streams
+func (c *Config) Validate() error {
+	if c.Broker != BrokerKafka && c.Broker != BrokerNATS {
+		return fmt.Errorf("%w: %s", errUnknownBroker, c.Broker)
+	}
+
+	if c.Encoding != "" && c.Encoding != EncodingJSON && c.Encoding != EncodingProtobuf {
+		return fmt.Errorf("%w: %s", errUnknownEncoding, c.Encoding)
+	}
+
+	if _, err := c.brokerURL(); err != nil {
+		return err
+	}
+
+	if c.HeadersTopic == "" && len(c.Streams) == 0 {
+		return errNoStreams
+	}
+
+	for i, stream := range c.Streams {
+		if _, err := stream.parse(); err != nil {
+			return fmt.Errorf("invalid stream %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) brokerURL() (*url.URL, error) {
+	u, err := url.Parse(c.URL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("%w: %s", errInvalidURL, c.URL)
+	}
+
+	switch {
+	case c.Broker == BrokerKafka && (u.Scheme == "http" || u.Scheme == "https"):
+	case c.Broker == BrokerNATS && (u.Scheme == "nats" || u.Scheme == "tls"):
+	default:
+		return nil, fmt.Errorf("%w: unsupported scheme of the %s broker: %s", errInvalidURL, c.Broker, u.Scheme)
+	}
+
+	return u, nil
+}
+
+// parsedStream is the stream with the parsed contract and events
+type parsedStream struct {
+	*Stream
+
+	contract ethgo.Address
+	events   map[ethgo.Hash]*abi.Event
+}
+
+func (s *Stream) parse() (*parsedStream, error) {
+	if s.Topic == "" {
+		return nil, errNoTopic
+	}
+
+	var contract types.Address
+	if err := contract.UnmarshalText([]byte(s.Contract)); err != nil {
+		return nil, fmt.Errorf("invalid contract address %s: %w", s.Contract, err)
+	}
+
+	parsed := &parsedStream{
+		Stream:   s,
+		contract: ethgo.Address(contract),
+		events:   make(map[ethgo.Hash]*abi.Event, len(s.Events)),
+	}
+
+	for _, signature := range s.Events {
+		event, err := abi.NewEvent(signature)
+		if err != nil {
+			return nil, fmt.Errorf("invalid event %s: %w", signature, err)
+		}
+
+		parsed.events[event.ID()] = event
+	}
+
+	return parsed, nil
+}
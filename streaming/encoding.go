@@ -0,0 +1,203 @@
+package streaming
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/streaming/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Header is the JSON message of the block header
+type Header struct {
+	Number     uint64        `json:"number"`
+	Hash       types.Hash    `json:"hash"`
+	ParentHash types.Hash    `json:"parentHash"`
+	Timestamp  uint64        `json:"timestamp"`
+	Miner      types.Address `json:"miner"`
+	GasUsed    uint64        `json:"gasUsed"`
+	GasLimit   uint64        `json:"gasLimit"`
+	BaseFee    uint64        `json:"baseFeePerGas"`
+	StateRoot  types.Hash    `json:"stateRoot"`
+	ChainID    uint64        `json:"chainId"`
+}
+
+// Event is the JSON message of the contract log, the name and the arguments are set if the log is decoded.
+// The arguments are formatted as the strings: the integers are decimal, the addresses and the bytes are hex
+type Event struct {
+	Name        string            `json:"name,omitempty"`
+	Args        map[string]string `json:"args,omitempty"`
+	Address     types.Address     `json:"address"`
+	Topics      []types.Hash      `json:"topics"`
+	Data        string            `json:"data"`
+	BlockNumber uint64            `json:"blockNumber"`
+	BlockHash   types.Hash        `json:"blockHash"`
+	TxHash      types.Hash        `json:"transactionHash"`
+	TxIndex     uint64            `json:"transactionIndex"`
+	LogIndex    uint64            `json:"logIndex"`
+	ChainID     uint64            `json:"chainId"`
+}
+
+func newHeader(header *types.Header, chainID uint64) *Header {
+	return &Header{
+		Number:     header.Number,
+		Hash:       header.Hash,
+		ParentHash: header.ParentHash,
+		Timestamp:  header.Timestamp,
+		Miner:      types.BytesToAddress(header.Miner),
+		GasUsed:    header.GasUsed,
+		GasLimit:   header.GasLimit,
+		BaseFee:    header.BaseFee,
+		StateRoot:  header.StateRoot,
+		ChainID:    chainID,
+	}
+}
+
+// newEvent creates the message of the log, decoded by the event if it's not nil
+func newEvent(log *ethgo.Log, event *abi.Event, chainID uint64) (*Event, error) {
+	msg := &Event{
+		Address:     types.Address(log.Address),
+		Topics:      make([]types.Hash, len(log.Topics)),
+		Data:        hex.EncodeToHex(log.Data),
+		BlockNumber: log.BlockNumber,
+		BlockHash:   types.Hash(log.BlockHash),
+		TxHash:      types.Hash(log.TransactionHash),
+		TxIndex:     log.TransactionIndex,
+		LogIndex:    log.LogIndex,
+		ChainID:     chainID,
+	}
+
+	for i, topic := range log.Topics {
+		msg.Topics[i] = types.Hash(topic)
+	}
+
+	if event == nil {
+		return msg, nil
+	}
+
+	args, err := event.ParseLog(log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the %s event: %w", event.Name, err)
+	}
+
+	msg.Name = event.Name
+	msg.Args = make(map[string]string, len(args))
+
+	for name, value := range args {
+		msg.Args[name] = formatArg(value)
+	}
+
+	return msg, nil
+}
+
+// formatArg formats the decoded event argument
+func formatArg(value interface{}) string {
+	switch v := value.(type) {
+	case *big.Int:
+		return v.String()
+	case ethgo.Address:
+		return v.String()
+	case ethgo.Hash:
+		return v.String()
+	case []byte:
+		return hex.EncodeToHex(v)
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	}
+
+	rv := reflect.ValueOf(value)
+
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			buf := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(buf), rv)
+
+			return hex.EncodeToHex(buf)
+		}
+	case reflect.Map:
+		// the tuples are decoded as the maps, which are encoded with the sorted keys
+		if raw, err := json.Marshal(formatTuple(rv)); err == nil {
+			return string(raw)
+		}
+	}
+
+	if raw, err := json.Marshal(value); err == nil {
+		return string(raw)
+	}
+
+	return fmt.Sprint(value)
+}
+
+func formatTuple(rv reflect.Value) map[string]string {
+	keys := rv.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+	tuple := make(map[string]string, len(keys))
+	for _, key := range keys {
+		tuple[key.String()] = formatArg(rv.MapIndex(key).Interface())
+	}
+
+	return tuple
+}
+
+// encoder serializes the messages in the configured encoding
+type encoder struct {
+	protobuf bool
+}
+
+func (e *encoder) encodeHeader(h *Header) ([]byte, error) {
+	if !e.protobuf {
+		return json.Marshal(h)
+	}
+
+	return protobuf.Marshal(&proto.Header{
+		Number:     h.Number,
+		Hash:       h.Hash.Bytes(),
+		ParentHash: h.ParentHash.Bytes(),
+		Timestamp:  h.Timestamp,
+		Miner:      h.Miner.Bytes(),
+		GasUsed:    h.GasUsed,
+		GasLimit:   h.GasLimit,
+		BaseFee:    h.BaseFee,
+		StateRoot:  h.StateRoot.Bytes(),
+		ChainID:    h.ChainID,
+	})
+}
+
+func (e *encoder) encodeEvent(ev *Event) ([]byte, error) {
+	if !e.protobuf {
+		return json.Marshal(ev)
+	}
+
+	msg := &proto.Event{
+		Name:        ev.Name,
+		Args:        ev.Args,
+		Address:     ev.Address.Bytes(),
+		Topics:      make([][]byte, len(ev.Topics)),
+		Data:        hex.MustDecodeHex(ev.Data),
+		BlockNumber: ev.BlockNumber,
+		BlockHash:   ev.BlockHash.Bytes(),
+		TxHash:      ev.TxHash.Bytes(),
+		TxIndex:     ev.TxIndex,
+		LogIndex:    ev.LogIndex,
+		ChainID:     ev.ChainID,
+	}
+
+	for i, topic := range ev.Topics {
+		msg.Topics[i] = topic.Bytes()
+	}
+
+	return protobuf.Marshal(msg)
+}
@@ -0,0 +1,289 @@
+package streaming
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// publishTimeout is the timeout of the connection and of a single publication
+	publishTimeout = 10 * time.Second
+
+	kafkaContentType = "application/vnd.kafka.binary.v2+json"
+)
+
+var errNATSRejected = errors.New("the NATS server rejected the message")
+
+// Publisher publishes the messages to the topics of the broker
+type Publisher interface {
+	// Publish publishes the message with the key, it returns once the broker received it
+	Publish(ctx context.Context, topic string, key, value []byte) error
+	Close() error
+}
+
+// NewPublisher creates the publisher of the broker of the config
+func NewPublisher(config *Config) (Publisher, error) {
+	u, err := config.brokerURL()
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Broker == BrokerKafka {
+		return &kafkaPublisher{url: strings.TrimSuffix(u.String(), "/"), client: &http.Client{Timeout: publishTimeout}}, nil
+	}
+
+	return &natsPublisher{url: u}, nil
+}
+
+// kafkaPublisher produces the records over the Kafka REST proxy (the Confluent REST proxy,
+// or the HTTP proxy of Redpanda), which doesn't need the client of the Kafka protocol
+type kafkaPublisher struct {
+	url    string
+	client *http.Client
+}
+
+type kafkaRecord struct {
+	Key   string `json:"key,omitempty"`
+	Value string `json:"value"`
+}
+
+type kafkaProduceRequest struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaProduceResponse struct {
+	Offsets []struct {
+		Partition *int64 `json:"partition"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+func (k *kafkaPublisher) Publish(ctx context.Context, topic string, key, value []byte) error {
+	record := kafkaRecord{Value: base64.StdEncoding.EncodeToString(value)}
+	if len(key) > 0 {
+		record.Key = base64.StdEncoding.EncodeToString(key)
+	}
+
+	body, err := json.Marshal(&kafkaProduceRequest{Records: []kafkaRecord{record}})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, k.url+"/topics/"+url.PathEscape(topic), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", kafkaContentType)
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("kafka REST proxy responded with %s: %s", resp.Status, bytes.TrimSpace(raw))
+	}
+
+	var produced kafkaProduceResponse
+	if err := json.Unmarshal(raw, &produced); err != nil {
+		return fmt.Errorf("invalid kafka REST proxy response: %w", err)
+	}
+
+	for _, offset := range produced.Offsets {
+		if offset.Error != "" || offset.Partition == nil {
+			return fmt.Errorf("kafka REST proxy failed to produce the record: %s", offset.Error)
+		}
+	}
+
+	return nil
+}
+
+func (k *kafkaPublisher) Close() error {
+	k.client.CloseIdleConnections()
+
+	return nil
+}
+
+// natsPublisher publishes over the text protocol of the NATS core, reconnecting on the next publication
+// if the connection fails. Each publication is followed by the PING, so it returns once the server
+// processed the message
+type natsPublisher struct {
+	url *url.URL
+
+	lock   sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+type natsConnect struct {
+	Verbose  bool   `json:"verbose"`
+	Pedantic bool   `json:"pedantic"`
+	Name     string `json:"name"`
+	Lang     string `json:"lang"`
+	Version  string `json:"version"`
+	Protocol int    `json:"protocol"`
+	User     string `json:"user,omitempty"`
+	Pass     string `json:"pass,omitempty"`
+	TLS      bool   `json:"tls_required"`
+}
+
+func (n *natsPublisher) Publish(ctx context.Context, topic string, _, value []byte) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.conn == nil {
+		if err := n.connect(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := n.publish(topic, value); err != nil {
+		_ = n.conn.Close()
+		n.conn = nil
+
+		return err
+	}
+
+	return nil
+}
+
+func (n *natsPublisher) connect(ctx context.Context) error {
+	dialer := &net.Dialer{Timeout: publishTimeout}
+
+	conn, err := dialer.DialContext(ctx, "tcp", n.url.Host)
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(conn)
+	_ = conn.SetDeadline(time.Now().Add(publishTimeout))
+
+	// the server greets with INFO {...}
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		_ = conn.Close()
+
+		return err
+	}
+
+	if !strings.HasPrefix(line, "INFO") {
+		_ = conn.Close()
+
+		return fmt.Errorf("unexpected NATS greeting: %s", strings.TrimSpace(line))
+	}
+
+	connect := &natsConnect{Name: "polygon-edge", Lang: "go", Version: "1.0.0", Protocol: 1}
+
+	if n.url.Scheme == "tls" {
+		connect.TLS = true
+
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: n.url.Hostname(), MinVersion: tls.VersionTLS12})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+
+			return err
+		}
+
+		conn, reader = tlsConn, bufio.NewReader(tlsConn)
+	}
+
+	if n.url.User != nil {
+		connect.User = n.url.User.Username()
+		connect.Pass, _ = n.url.User.Password()
+	}
+
+	raw, err := json.Marshal(connect)
+	if err != nil {
+		_ = conn.Close()
+
+		return err
+	}
+
+	n.conn, n.reader = conn, reader
+
+	// the PING after the CONNECT is answered with the PONG once the server accepted the connection
+	if _, err := fmt.Fprintf(conn, "CONNECT %s\r\nPING\r\n", raw); err != nil {
+		_ = conn.Close()
+		n.conn = nil
+
+		return err
+	}
+
+	if err := n.awaitPong(); err != nil {
+		_ = conn.Close()
+		n.conn = nil
+
+		return err
+	}
+
+	return nil
+}
+
+func (n *natsPublisher) publish(subject string, value []byte) error {
+	_ = n.conn.SetDeadline(time.Now().Add(publishTimeout))
+
+	if _, err := fmt.Fprintf(n.conn, "PUB %s %d\r\n%s\r\nPING\r\n", subject, len(value), value); err != nil {
+		return err
+	}
+
+	return n.awaitPong()
+}
+
+// awaitPong reads until the PONG, answering the PINGs of the server
+func (n *natsPublisher) awaitPong() error {
+	for {
+		line, err := n.reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := io.WriteString(n.conn, "PONG\r\n"); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("%w: %s", errNATSRejected, strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
+
+func (n *natsPublisher) Close() error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.conn == nil {
+		return nil
+	}
+
+	err := n.conn.Close()
+	n.conn = nil
+
+	return err
+}
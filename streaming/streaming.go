@@ -0,0 +1,241 @@
+package streaming
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/tracker"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// DefaultPollInterval is the interval the event trackers poll the new blocks at, if it's not set
+	DefaultPollInterval = time.Second
+
+	// trackersDir is the directory of the data directory the progress of the streams is persisted in
+	trackersDir = "streaming"
+
+	streamingMetrics = "streaming"
+)
+
+// Blockchain is the chain the headers are published of
+type Blockchain interface {
+	SubscribeEvents() blockchain.Subscription
+	UnsubscribeEvents(sub blockchain.Subscription)
+}
+
+// Service publishes the new block headers and the events of the streams to the broker.
+// The events are delivered at least once: the events whose publication failed are published
+// again with the next block, so the consumers deduplicate them by the block hash and the log index
+type Service struct {
+	logger    hclog.Logger
+	config    *Config
+	publisher Publisher
+	encoder   *encoder
+	chain     Blockchain
+	chainID   uint64
+	trackers  []*tracker.EventTracker
+
+	subscription blockchain.Subscription
+	ctx          context.Context
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+}
+
+// NewService creates the streaming service. The events are tracked over the JSON-RPC of the node
+// at rpcEndpoint, and the progress of the streams is persisted in the data directory
+func NewService(
+	logger hclog.Logger,
+	config *Config,
+	chain Blockchain,
+	chainID uint64,
+	rpcEndpoint string,
+	dataDir string,
+	pollInterval time.Duration,
+) (*Service, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	publisher, err := NewPublisher(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if pollInterval == 0 {
+		pollInterval = DefaultPollInterval
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s := &Service{
+		logger:    logger.Named("streaming"),
+		config:    config,
+		publisher: publisher,
+		encoder:   &encoder{protobuf: config.Encoding == EncodingProtobuf},
+		chain:     chain,
+		chainID:   chainID,
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+
+	if len(config.Streams) > 0 {
+		if err := os.MkdirAll(filepath.Join(dataDir, trackersDir), 0750); err != nil {
+			cancel()
+
+			return nil, fmt.Errorf("failed to create the streaming directory: %w", err)
+		}
+	}
+
+	for _, stream := range config.Streams {
+		parsed, err := stream.parse()
+		if err != nil {
+			cancel()
+
+			return nil, err
+		}
+
+		// each stream persists its progress separately, so a new stream starts at its start block
+		dbPath := filepath.Join(
+			dataDir,
+			trackersDir,
+			fmt.Sprintf("%s_%s.db", parsed.contract, url.PathEscape(stream.Topic)),
+		)
+
+		s.trackers = append(s.trackers, tracker.NewEventTracker(
+			dbPath,
+			rpcEndpoint,
+			parsed.contract,
+			&streamSubscriber{service: s, stream: parsed},
+			stream.Confirmations,
+			stream.StartBlock,
+			s.logger.Named(stream.Topic),
+			pollInterval,
+		))
+	}
+
+	return s, nil
+}
+
+// Start starts the event trackers of the streams and publishes the new block headers
+func (s *Service) Start() error {
+	for _, t := range s.trackers {
+		if err := t.Start(s.ctx); err != nil {
+			return err
+		}
+	}
+
+	if s.config.HeadersTopic == "" {
+		return nil
+	}
+
+	s.subscription = s.chain.SubscribeEvents()
+
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+
+		for {
+			ev := s.subscription.GetEvent()
+			if ev == nil {
+				return
+			}
+
+			for _, header := range ev.NewChain {
+				s.publishHeader(header)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Close stops the event trackers and the publication of the headers
+func (s *Service) Close() {
+	if s.subscription != nil {
+		s.chain.UnsubscribeEvents(s.subscription)
+	}
+
+	s.cancel()
+	s.wg.Wait()
+
+	if err := s.publisher.Close(); err != nil {
+		s.logger.Warn("failed to close the streaming publisher", "err", err)
+	}
+}
+
+// publishHeader publishes the header, the headers are not published again if the publication fails
+func (s *Service) publishHeader(header *types.Header) {
+	value, err := s.encoder.encodeHeader(newHeader(header, s.chainID))
+	if err != nil {
+		s.logger.Error("failed to encode the header", "number", header.Number, "err", err)
+
+		return
+	}
+
+	if err := s.publisher.Publish(s.ctx, s.config.HeadersTopic, nil, value); err != nil {
+		metrics.IncrCounter([]string{streamingMetrics, "failed"}, 1)
+		s.logger.Warn("failed to publish the header", "number", header.Number, "err", err)
+
+		return
+	}
+
+	metrics.IncrCounter([]string{streamingMetrics, "headers"}, 1)
+}
+
+// streamSubscriber publishes the logs tracked by the event tracker of the stream
+type streamSubscriber struct {
+	service *Service
+	stream  *parsedStream
+}
+
+// AddLog publishes the log if it's one of the events of the stream, the error makes the event tracker
+// notify the log again with the next block
+func (s *streamSubscriber) AddLog(log *ethgo.Log) error {
+	var event *abi.Event
+
+	if len(s.stream.events) > 0 {
+		if len(log.Topics) == 0 {
+			return nil
+		}
+
+		if event = s.stream.events[log.Topics[0]]; event == nil {
+			return nil
+		}
+	}
+
+	msg, err := newEvent(log, event, s.service.chainID)
+	if err != nil {
+		// the log which can't be decoded is skipped, it won't be decoded on the next attempt either
+		s.service.logger.Warn("failed to decode the event", "topic", s.stream.Topic, "tx", log.TransactionHash, "err", err)
+
+		return nil
+	}
+
+	value, err := s.service.encoder.encodeEvent(msg)
+	if err != nil {
+		return err
+	}
+
+	if err := s.service.publisher.Publish(s.service.ctx, s.stream.Topic, log.Address.Bytes(), value); err != nil {
+		metrics.IncrCounter([]string{streamingMetrics, "failed"}, 1)
+
+		return fmt.Errorf("failed to publish the event to %s: %w", s.stream.Topic, err)
+	}
+
+	metrics.IncrCounter([]string{streamingMetrics, "events"}, 1)
+
+	return nil
+}
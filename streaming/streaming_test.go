@@ -0,0 +1,289 @@
+package streaming
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/0xPolygon/polygon-edge/streaming/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const transferSignature = "event Transfer(address indexed from, address indexed to, uint256 value)"
+
+var (
+	contract    = ethgo.HexToAddress("0x1000")
+	transferABI = abi.MustNewEvent(transferSignature)
+)
+
+type published struct {
+	topic string
+	key   []byte
+	value []byte
+}
+
+type mockPublisher struct {
+	lock     sync.Mutex
+	messages []published
+	err      error
+}
+
+func (m *mockPublisher) Publish(_ context.Context, topic string, key, value []byte) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.err != nil {
+		return m.err
+	}
+
+	m.messages = append(m.messages, published{topic: topic, key: key, value: value})
+
+	return nil
+}
+
+func (m *mockPublisher) Close() error {
+	return nil
+}
+
+// newTransferLog creates the log of the transfer of the value from 0x1 to 0x2
+func newTransferLog(t *testing.T, value int64) *ethgo.Log {
+	t.Helper()
+
+	data, err := abi.Encode([]interface{}{big.NewInt(value)}, abi.MustNewType("tuple(uint256)"))
+	require.NoError(t, err)
+
+	return &ethgo.Log{
+		Address: contract,
+		Topics: []ethgo.Hash{
+			transferABI.ID(),
+			ethgo.BytesToHash(ethgo.HexToAddress("0x1").Bytes()),
+			ethgo.BytesToHash(ethgo.HexToAddress("0x2").Bytes()),
+		},
+		Data:            data,
+		BlockNumber:     7,
+		BlockHash:       ethgo.HexToHash("0xb7"),
+		TransactionHash: ethgo.HexToHash("0x77"),
+		LogIndex:        3,
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	stream := &Stream{Topic: "transfers", Contract: contract.String(), Events: []string{transferSignature}}
+
+	require.NoError(t, (&Config{Broker: BrokerKafka, URL: "http://localhost:8082", Streams: []*Stream{stream}}).Validate())
+	require.NoError(t, (&Config{Broker: BrokerNATS, URL: "nats://localhost:4222", HeadersTopic: "headers"}).Validate())
+
+	cases := []struct {
+		config *Config
+		err    error
+	}{
+		{&Config{Broker: "pulsar", URL: "http://localhost", HeadersTopic: "h"}, errUnknownBroker},
+		{&Config{Broker: BrokerNATS, URL: "http://localhost", HeadersTopic: "h"}, errInvalidURL},
+		{&Config{Broker: BrokerKafka, URL: "http://localhost", HeadersTopic: "h", Encoding: "avro"}, errUnknownEncoding},
+		{&Config{Broker: BrokerKafka, URL: "http://localhost"}, errNoStreams},
+		{&Config{Broker: BrokerKafka, URL: "http://localhost", Streams: []*Stream{{Contract: contract.String()}}}, errNoTopic},
+	}
+
+	for _, c := range cases {
+		require.ErrorIs(t, c.config.Validate(), c.err)
+	}
+
+	invalidEvent := &Stream{Topic: "t", Contract: contract.String(), Events: []string{"event Transfer(foo)"}}
+	require.Error(t, (&Config{Broker: BrokerKafka, URL: "http://localhost", Streams: []*Stream{invalidEvent}}).Validate())
+}
+
+func TestStreamSubscriber_AddLog(t *testing.T) {
+	t.Parallel()
+
+	stream, err := (&Stream{Topic: "transfers", Contract: contract.String(), Events: []string{transferSignature}}).parse()
+	require.NoError(t, err)
+
+	publisher := &mockPublisher{}
+	service := &Service{
+		logger:    hclog.NewNullLogger(),
+		publisher: publisher,
+		encoder:   &encoder{},
+		chainID:   100,
+		ctx:       context.Background(),
+	}
+	subscriber := &streamSubscriber{service: service, stream: stream}
+
+	// the logs of the other events are skipped
+	other := newTransferLog(t, 1)
+	other.Topics[0] = ethgo.HexToHash("0xff")
+
+	require.NoError(t, subscriber.AddLog(other))
+	require.Empty(t, publisher.messages)
+
+	require.NoError(t, subscriber.AddLog(newTransferLog(t, 1000)))
+	require.Len(t, publisher.messages, 1)
+	require.Equal(t, "transfers", publisher.messages[0].topic)
+	require.Equal(t, contract.Bytes(), publisher.messages[0].key)
+
+	var event Event
+
+	require.NoError(t, json.Unmarshal(publisher.messages[0].value, &event))
+	require.Equal(t, "Transfer", event.Name)
+	require.Equal(t, map[string]string{
+		"from":  ethgo.HexToAddress("0x1").String(),
+		"to":    ethgo.HexToAddress("0x2").String(),
+		"value": "1000",
+	}, event.Args)
+	require.Equal(t, types.Address(contract), event.Address)
+	require.Equal(t, uint64(7), event.BlockNumber)
+	require.Equal(t, uint64(3), event.LogIndex)
+	require.Equal(t, uint64(100), event.ChainID)
+
+	// the failed publication is retried by the event tracker
+	publisher.err = errors.New("broker is down")
+	require.ErrorIs(t, subscriber.AddLog(newTransferLog(t, 1)), publisher.err)
+}
+
+func TestEncoder_Protobuf(t *testing.T) {
+	t.Parallel()
+
+	msg, err := newEvent(newTransferLog(t, 5), transferABI, 100)
+	require.NoError(t, err)
+
+	raw, err := (&encoder{protobuf: true}).encodeEvent(msg)
+	require.NoError(t, err)
+
+	var event proto.Event
+
+	require.NoError(t, protobuf.Unmarshal(raw, &event))
+	require.Equal(t, "Transfer", event.Name)
+	require.Equal(t, "5", event.Args["value"])
+	require.Equal(t, contract.Bytes(), event.Address)
+	require.Len(t, event.Topics, 3)
+	require.Equal(t, newTransferLog(t, 5).Data, event.Data)
+
+	header := &types.Header{Number: 9, GasLimit: 100}
+	header.ComputeHash()
+
+	raw, err = (&encoder{protobuf: true}).encodeHeader(newHeader(header, 100))
+	require.NoError(t, err)
+
+	var decoded proto.Header
+
+	require.NoError(t, protobuf.Unmarshal(raw, &decoded))
+	require.Equal(t, uint64(9), decoded.Number)
+	require.Equal(t, header.Hash.Bytes(), decoded.Hash)
+	require.Equal(t, uint64(100), decoded.ChainID)
+}
+
+func TestKafkaPublisher(t *testing.T) {
+	t.Parallel()
+
+	var request kafkaProduceRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/topics/edge.headers", r.URL.Path)
+		require.Equal(t, kafkaContentType, r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		_, _ = io.WriteString(w, `{"offsets":[{"partition":0,"offset":12}]}`)
+	}))
+	defer server.Close()
+
+	publisher, err := NewPublisher(&Config{Broker: BrokerKafka, URL: server.URL})
+	require.NoError(t, err)
+
+	require.NoError(t, publisher.Publish(context.Background(), "edge.headers", []byte("key"), []byte("value")))
+	require.Len(t, request.Records, 1)
+	require.Equal(t, base64.StdEncoding.EncodeToString([]byte("key")), request.Records[0].Key)
+	require.Equal(t, base64.StdEncoding.EncodeToString([]byte("value")), request.Records[0].Value)
+}
+
+// newMockNATSServer accepts a single connection and passes on the published messages,
+// the messages of the subject "rejected" are rejected
+func newMockNATSServer(t *testing.T) (string, <-chan string) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = listener.Close() })
+
+	msgCh := make(chan string, 8)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+
+		_, _ = io.WriteString(conn, "INFO {\"server_id\":\"mock\"}\r\n")
+
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			fields := strings.Fields(line)
+
+			switch {
+			case len(fields) == 0:
+			case fields[0] == "PING":
+				_, _ = io.WriteString(conn, "PONG\r\n")
+			case fields[0] == "PUB" && len(fields) == 3:
+				size, _ := strconv.Atoi(fields[2])
+
+				payload := make([]byte, size+2)
+				if _, err := io.ReadFull(reader, payload); err != nil {
+					return
+				}
+
+				if fields[1] == "rejected" {
+					_, _ = io.WriteString(conn, "-ERR 'Permissions Violation'\r\n")
+
+					continue
+				}
+
+				msgCh <- fmt.Sprintf("%s %s", fields[1], payload[:size])
+			}
+		}
+	}()
+
+	return "nats://" + listener.Addr().String(), msgCh
+}
+
+func TestNATSPublisher(t *testing.T) {
+	t.Parallel()
+
+	url, msgCh := newMockNATSServer(t)
+
+	publisher, err := NewPublisher(&Config{Broker: BrokerNATS, URL: url})
+	require.NoError(t, err)
+
+	defer publisher.Close()
+
+	require.NoError(t, publisher.Publish(context.Background(), "edge.headers", nil, []byte("header")))
+	require.Equal(t, "edge.headers header", <-msgCh)
+
+	require.ErrorIs(t, publisher.Publish(context.Background(), "rejected", nil, []byte("x")), errNATSRejected)
+}
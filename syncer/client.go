@@ -24,6 +24,8 @@ const (
 	SyncPeerClientLoggerName = "sync-peer-client"
 	statusTopicName          = "syncer/status/0.1"
 	defaultTimeoutForStatus  = 10 * time.Second
+	defaultTimeoutForState   = 30 * time.Second
+	defaultTimeoutForBlocks  = 20 * time.Second
 )
 
 type syncPeerClient struct {
@@ -33,6 +35,8 @@ type syncPeerClient struct {
 
 	subscription           blockchain.Subscription // reference to the blockchain subscription
 	topic                  *network.Topic          // reference to the network topic
+	compactTopic           *network.Topic          // reference to the topic of the compact blocks
+	compactBlockCh         chan *CompactBlock      // compact blocks relayed by the peers
 	id                     string                  // node id
 	peerStatusUpdateCh     chan *NoForkPeer        // peer status update channel
 	peerConnectionUpdateCh chan *event.PeerEvent   // peer connection update channel
@@ -57,6 +61,7 @@ func NewSyncPeerClient(
 		id:                     network.AddrInfo().ID.String(),
 		peerStatusUpdateCh:     make(chan *NoForkPeer, 1),
 		peerConnectionUpdateCh: make(chan *event.PeerEvent, 1),
+		compactBlockCh:         make(chan *CompactBlock, 1),
 		shouldEmitBlocks:       true,
 		closeCh:                make(chan struct{}),
 
@@ -91,6 +96,10 @@ func (m *syncPeerClient) Close() {
 		m.topic.Close()
 	}
 
+	if m.compactTopic != nil {
+		m.compactTopic.Close()
+	}
+
 	if m.subscription != nil {
 		m.blockchain.UnsubscribeEvents(m.subscription)
 
@@ -201,9 +210,70 @@ func (m *syncPeerClient) startGossip() error {
 
 	m.topic = topic
 
+	compactTopic, err := m.network.NewTopic(compactTopicName, &proto.CompactBlock{})
+	if err != nil {
+		return err
+	}
+
+	if err := compactTopic.Subscribe(m.handleCompactBlock); err != nil {
+		return fmt.Errorf("unable to subscribe to compact block topic, %w", err)
+	}
+
+	m.compactTopic = compactTopic
+
 	return nil
 }
 
+// GetCompactBlockCh returns a channel of the compact blocks relayed by the peers
+func (m *syncPeerClient) GetCompactBlockCh() <-chan *CompactBlock {
+	return m.compactBlockCh
+}
+
+// handleCompactBlock is a handler of the compact blocks gossip,
+// the block is dropped if the previous one is not consumed yet
+func (m *syncPeerClient) handleCompactBlock(obj interface{}, from peer.ID) {
+	msg, ok := obj.(*proto.CompactBlock)
+	if !ok {
+		m.logger.Error("failed to cast gossiped message to compact block")
+
+		return
+	}
+
+	if !m.network.IsConnected(from) {
+		return
+	}
+
+	compact, err := fromProtoCompactBlock(from, msg)
+	if err != nil {
+		m.logger.Debug("received invalid compact block", "id", from, "err", err)
+		m.network.ReportPeer(from, network.InvalidMessage)
+
+		return
+	}
+
+	// the relayed block is useful only if it is the next one
+	if compact.Header.Number != m.blockchain.Header().Number+1 {
+		return
+	}
+
+	select {
+	case m.compactBlockCh <- compact:
+	default:
+	}
+}
+
+// publishCompactBlock relays the new block as its header and the hashes of its transactions
+func (m *syncPeerClient) publishCompactBlock(header *types.Header) {
+	body, ok := m.blockchain.GetBodyByHash(header.Hash)
+	if !ok || len(body.Uncles) > 0 {
+		return
+	}
+
+	if err := m.compactTopic.Publish(toProtoCompactBlock(header, body.Transactions)); err != nil {
+		m.logger.Warn("failed to publish compact block", "err", err)
+	}
+}
+
 // handleStatusUpdate is a handler of gossip
 func (m *syncPeerClient) handleStatusUpdate(obj interface{}, from peer.ID) {
 	status, ok := obj.(*proto.SyncPeerStatus)
@@ -259,6 +329,8 @@ func (m *syncPeerClient) startNewBlockProcess() {
 			}); err != nil {
 				m.logger.Warn("failed to publish status", "err", err)
 			}
+
+			m.publishCompactBlock(latest)
 		}
 	}
 }
@@ -306,7 +378,8 @@ func (m *syncPeerClient) GetBlocks(
 	ctx, cancel := context.WithCancel(context.Background())
 
 	stream, err := clt.GetBlocks(ctx, &proto.GetBlocksRequest{
-		From: from,
+		From:     from,
+		Compress: true,
 	})
 	if err != nil {
 		cancel()
@@ -347,6 +420,159 @@ func (m *syncPeerClient) GetBlocks(
 	return blockCh, nil
 }
 
+// GetTrieNodes returns the trie nodes or the contract codes by their hashes, nil if not served by the peer
+func (m *syncPeerClient) GetTrieNodes(peerID peer.ID, hashes []types.Hash) ([][]byte, error) {
+	clt, err := m.newSyncPeerClient(peerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sync peer client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), defaultTimeoutForState)
+	defer cancel()
+
+	resp, err := clt.GetTrieNodes(timeoutCtx, &proto.GetTrieNodesRequest{
+		Hashes: hashesToBytes(hashes),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Data) != len(hashes) {
+		return nil, fmt.Errorf("peer returned %d trie nodes, while %d were requested", len(resp.Data), len(hashes))
+	}
+
+	return resp.Data, nil
+}
+
+// GetReceipts returns the receipts of the blocks by their hashes, nil if not served by the peer
+func (m *syncPeerClient) GetReceipts(peerID peer.ID, hashes []types.Hash) ([][]*types.Receipt, error) {
+	clt, err := m.newSyncPeerClient(peerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sync peer client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), defaultTimeoutForState)
+	defer cancel()
+
+	resp, err := clt.GetReceipts(timeoutCtx, &proto.GetReceiptsRequest{
+		Hashes: hashesToBytes(hashes),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Receipts) != len(hashes) {
+		return nil, fmt.Errorf("peer returned receipts of %d blocks, while %d were requested",
+			len(resp.Receipts), len(hashes))
+	}
+
+	receipts := make([][]*types.Receipt, len(hashes))
+
+	for i, data := range resp.Receipts {
+		if len(data) == 0 {
+			continue
+		}
+
+		var rr types.Receipts
+		if err := rr.UnmarshalStoreRLP(data); err != nil {
+			return nil, fmt.Errorf("failed to decode receipts of block %s: %w", hashes[i], err)
+		}
+
+		receipts[i] = rr
+	}
+
+	return receipts, nil
+}
+
+// GetHeaders returns up to the given amount of the consecutive headers from the given height
+func (m *syncPeerClient) GetHeaders(peerID peer.ID, from, amount uint64) ([]*types.Header, error) {
+	clt, err := m.newSyncPeerClient(peerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sync peer client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), defaultTimeoutForBlocks)
+	defer cancel()
+
+	resp, err := clt.GetHeaders(timeoutCtx, &proto.GetHeadersRequest{
+		From:   from,
+		Amount: amount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if uint64(len(resp.Headers)) > amount {
+		return nil, fmt.Errorf("peer returned %d headers, while %d were requested", len(resp.Headers), amount)
+	}
+
+	headers := make([]*types.Header, len(resp.Headers))
+
+	for i, data := range resp.Headers {
+		header := &types.Header{}
+		if err := header.UnmarshalRLP(data); err != nil {
+			return nil, fmt.Errorf("%w: failed to decode header: %w", errInvalidBody, err)
+		}
+
+		headers[i] = header
+	}
+
+	return headers, nil
+}
+
+// GetBodies returns the bodies of the blocks by their hashes, nil if not served by the peer
+func (m *syncPeerClient) GetBodies(peerID peer.ID, hashes []types.Hash) ([]*types.Body, error) {
+	clt, err := m.newSyncPeerClient(peerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sync peer client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), defaultTimeoutForBlocks)
+	defer cancel()
+
+	resp, err := clt.GetBodies(timeoutCtx, &proto.GetBodiesRequest{
+		Hashes:   hashesToBytes(hashes),
+		Compress: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Bodies) != len(hashes) {
+		return nil, fmt.Errorf("peer returned %d bodies, while %d were requested", len(resp.Bodies), len(hashes))
+	}
+
+	bodies := make([]*types.Body, len(hashes))
+
+	for i, data := range resp.Bodies {
+		if len(data) == 0 {
+			continue
+		}
+
+		if resp.Compressed {
+			if data, err = decompress(data); err != nil {
+				return nil, fmt.Errorf("%w: failed to decompress body of block %s: %w", errInvalidBody, hashes[i], err)
+			}
+		}
+
+		body := &types.Body{}
+		if err := body.UnmarshalRLP(data); err != nil {
+			return nil, fmt.Errorf("%w: failed to decode body of block %s: %w", errInvalidBody, hashes[i], err)
+		}
+
+		for _, tx := range body.Transactions {
+			// the senders are recovered locally, only the ones of the state transactions are part of their data
+			if tx.Type != types.StateTx {
+				tx.From = types.ZeroAddress
+			}
+		}
+
+		bodies[i] = body
+	}
+
+	return bodies, nil
+}
+
 // newSyncPeerClient creates gRPC client
 func (m *syncPeerClient) newSyncPeerClient(peerID peer.ID) (proto.SyncPeerClient, error) {
 	conn, err := m.network.NewProtoConnection(syncerProto, peerID)
@@ -361,14 +587,33 @@ func (m *syncPeerClient) newSyncPeerClient(peerID peer.ID) (proto.SyncPeerClient
 
 // fromProto gets block from gRPC response data
 func fromProto(protoBlock *proto.Block) (*types.Block, error) {
+	data := protoBlock.Block
+
+	if protoBlock.Compressed {
+		var err error
+
+		if data, err = decompress(data); err != nil {
+			return nil, err
+		}
+	}
+
 	block := &types.Block{}
-	if err := block.UnmarshalRLP(protoBlock.Block); err != nil {
+	if err := block.UnmarshalRLP(data); err != nil {
 		return nil, err
 	}
 
 	return block, nil
 }
 
+func hashesToBytes(hashes []types.Hash) [][]byte {
+	res := make([][]byte, len(hashes))
+	for i, hash := range hashes {
+		res[i] = hash.Bytes()
+	}
+
+	return res
+}
+
 func blockStreamToChannel(stream proto.SyncPeer_GetBlocksClient) (<-chan *types.Block, <-chan error) {
 	blockCh := make(chan *types.Block)
 	errorCh := make(chan error, 1)
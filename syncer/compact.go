@@ -0,0 +1,130 @@
+package syncer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/snappy"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/0xPolygon/polygon-edge/syncer/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// compactTopicName is the topic the new blocks are relayed over as the headers and the transaction hashes
+	compactTopicName = "syncer/compact/0.1"
+
+	// maxDecompressedSize bounds the size of the decompressed block data
+	maxDecompressedSize = 32 * 1024 * 1024
+
+	// maxCompactBlockTxs bounds the number of the transactions of a relayed compact block
+	maxCompactBlockTxs = 64 * 1024
+)
+
+var (
+	errDecompressedTooLarge = errors.New("decompressed data is too large")
+	errInvalidCompactBlock  = errors.New("invalid compact block")
+)
+
+// CompactBlock is the block relayed without its transactions, which are looked up by their hashes
+type CompactBlock struct {
+	From     peer.ID
+	Header   *types.Header
+	TxHashes []types.Hash
+}
+
+// TxSource looks up the transactions the compact blocks are reconstructed from
+type TxSource interface {
+	// GetPendingTx returns the transaction by its hash
+	GetPendingTx(types.Hash) (*types.Transaction, bool)
+}
+
+// compress returns the snappy compressed data
+func compress(data []byte) []byte {
+	return snappy.Encode(nil, data)
+}
+
+// decompress returns the data decompressed by snappy, the data decompressed to the size
+// above maxDecompressedSize is rejected before it is decompressed
+func decompress(data []byte) ([]byte, error) {
+	size, err := snappy.DecodedLen(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if size > maxDecompressedSize {
+		return nil, fmt.Errorf("%w: %d bytes", errDecompressedTooLarge, size)
+	}
+
+	return snappy.Decode(nil, data)
+}
+
+// toProtoCompactBlock converts the block into the compact block relayed to the peers
+func toProtoCompactBlock(header *types.Header, txs []*types.Transaction) *proto.CompactBlock {
+	hashes := make([][]byte, len(txs))
+	for i, tx := range txs {
+		hashes[i] = tx.Hash.Bytes()
+	}
+
+	return &proto.CompactBlock{
+		Header:   header.MarshalRLP(),
+		TxHashes: hashes,
+	}
+}
+
+// fromProtoCompactBlock decodes the compact block relayed by the peer
+func fromProtoCompactBlock(from peer.ID, msg *proto.CompactBlock) (*CompactBlock, error) {
+	if len(msg.TxHashes) > maxCompactBlockTxs {
+		return nil, fmt.Errorf("%w: %d transactions", errInvalidCompactBlock, len(msg.TxHashes))
+	}
+
+	header := &types.Header{}
+	if err := header.UnmarshalRLP(msg.Header); err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidCompactBlock, err)
+	}
+
+	hashes := make([]types.Hash, len(msg.TxHashes))
+
+	for i, hash := range msg.TxHashes {
+		if len(hash) != types.HashLength {
+			return nil, fmt.Errorf("%w: invalid transaction hash", errInvalidCompactBlock)
+		}
+
+		hashes[i] = types.BytesToHash(hash)
+	}
+
+	return &CompactBlock{
+		From:     from,
+		Header:   header.ComputeHash(),
+		TxHashes: hashes,
+	}, nil
+}
+
+// reconstructBlock looks up the transactions of the compact block, and returns false
+// if any of them is missing, so the body has to be requested from the peer
+func reconstructBlock(compact *CompactBlock, source TxSource) (*types.Block, bool) {
+	txs := make([]*types.Transaction, len(compact.TxHashes))
+
+	for i, hash := range compact.TxHashes {
+		if source == nil {
+			return nil, false
+		}
+
+		tx, ok := source.GetPendingTx(hash)
+		if !ok {
+			return nil, false
+		}
+
+		// the senders are recovered during the verification, as the ones of the synced blocks
+		txs[i] = tx.Copy()
+		if txs[i].Type != types.StateTx {
+			txs[i].From = types.ZeroAddress
+		}
+	}
+
+	return &types.Block{
+		Header:       compact.Header,
+		Transactions: txs,
+	}, true
+}
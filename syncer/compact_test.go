@@ -0,0 +1,233 @@
+package syncer
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/syncer/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type mockTxSource map[types.Hash]*types.Transaction
+
+func (m mockTxSource) GetPendingTx(hash types.Hash) (*types.Transaction, bool) {
+	tx, ok := m[hash]
+
+	return tx, ok
+}
+
+func newCompactTestTxs(num int) []*types.Transaction {
+	txs := make([]*types.Transaction, num)
+
+	for i := range txs {
+		txs[i] = &types.Transaction{
+			Nonce:    uint64(i),
+			GasPrice: big.NewInt(1),
+			Value:    big.NewInt(1),
+			From:     types.StringToAddress("1"),
+		}
+		txs[i].ComputeHash(1)
+	}
+
+	return txs
+}
+
+func Test_compress(t *testing.T) {
+	t.Parallel()
+
+	data := make([]byte, 1024)
+
+	decompressed, err := decompress(compress(data))
+	require.NoError(t, err)
+	require.Equal(t, data, decompressed)
+
+	// the size is checked before the data is decompressed
+	bomb := snappy.Encode(nil, make([]byte, maxDecompressedSize+1))
+
+	_, err = decompress(bomb)
+	require.ErrorIs(t, err, errDecompressedTooLarge)
+
+	_, err = decompress([]byte{0xff, 0xff})
+	require.Error(t, err)
+}
+
+func Test_fromProtoCompactBlock(t *testing.T) {
+	t.Parallel()
+
+	header := (&types.Header{Number: 5}).ComputeHash()
+	txs := newCompactTestTxs(3)
+
+	compact, err := fromProtoCompactBlock("A", toProtoCompactBlock(header, txs))
+	require.NoError(t, err)
+	require.Equal(t, peer.ID("A"), compact.From)
+	require.Equal(t, header.Hash, compact.Header.Hash)
+	require.Equal(t, []types.Hash{txs[0].Hash, txs[1].Hash, txs[2].Hash}, compact.TxHashes)
+
+	msg := toProtoCompactBlock(header, txs)
+	msg.TxHashes[1] = []byte{0x1}
+
+	_, err = fromProtoCompactBlock("A", msg)
+	require.ErrorIs(t, err, errInvalidCompactBlock)
+
+	msg = toProtoCompactBlock(header, txs)
+	msg.Header = []byte{0x1}
+
+	_, err = fromProtoCompactBlock("A", msg)
+	require.ErrorIs(t, err, errInvalidCompactBlock)
+}
+
+func Test_reconstructBlock(t *testing.T) {
+	t.Parallel()
+
+	txs := newCompactTestTxs(2)
+	compact := &CompactBlock{
+		Header:   &types.Header{Number: 1},
+		TxHashes: []types.Hash{txs[0].Hash, txs[1].Hash},
+	}
+
+	block, ok := reconstructBlock(compact, mockTxSource{txs[0].Hash: txs[0], txs[1].Hash: txs[1]})
+	require.True(t, ok)
+	require.Len(t, block.Transactions, 2)
+	require.Equal(t, txs[1].Hash, block.Transactions[1].Hash)
+
+	// the senders are recovered during the verification, the pooled transactions are not modified
+	require.Equal(t, types.ZeroAddress, block.Transactions[0].From)
+	require.Equal(t, types.StringToAddress("1"), txs[0].From)
+
+	_, ok = reconstructBlock(compact, mockTxSource{txs[0].Hash: txs[0]})
+	require.False(t, ok)
+
+	_, ok = reconstructBlock(compact, nil)
+	require.False(t, ok)
+}
+
+func TestSyncer_applyCompactBlock(t *testing.T) {
+	t.Parallel()
+
+	txs := newCompactTestTxs(2)
+
+	newCompactBlock := func(number uint64) *CompactBlock {
+		return &CompactBlock{
+			From:     "A",
+			Header:   (&types.Header{Number: number}).ComputeHash(),
+			TxHashes: []types.Hash{txs[0].Hash, txs[1].Hash},
+		}
+	}
+
+	newSyncer := func(source TxSource, bodies []*types.Body) (*syncer, *[]*types.Block, *int) {
+		var (
+			written  []*types.Block
+			requests int
+		)
+
+		s := NewTestSyncer(
+			nil,
+			&mockBlockchain{
+				headerHandler: newSimpleHeaderHandler(1),
+				verifyFinalizedBlockHandler: func(b *types.Block) (*types.FullBlock, error) {
+					return &types.FullBlock{Block: b}, nil
+				},
+				writeFullBlockHandler: func(b *types.FullBlock) error {
+					written = append(written, b.Block)
+
+					return nil
+				},
+			},
+			time.Second,
+			&mockSyncPeerClient{
+				getBodiesHandler: func(id peer.ID, hashes []types.Hash) ([]*types.Body, error) {
+					requests++
+
+					return bodies, nil
+				},
+			},
+			&mockProgression{},
+		)
+		s.txSource = source
+
+		return s, &written, &requests
+	}
+
+	callback := func(*types.FullBlock) bool { return false }
+
+	t.Run("reconstructed from the local transactions", func(t *testing.T) {
+		t.Parallel()
+
+		s, written, requests := newSyncer(mockTxSource{txs[0].Hash: txs[0], txs[1].Hash: txs[1]}, nil)
+
+		_, err := s.applyCompactBlock(newCompactBlock(2), callback)
+		require.NoError(t, err)
+		require.Len(t, *written, 1)
+		require.Len(t, (*written)[0].Transactions, 2)
+		require.Equal(t, 0, *requests)
+	})
+
+	t.Run("body requested for the missing transactions", func(t *testing.T) {
+		t.Parallel()
+
+		s, written, requests := newSyncer(mockTxSource{txs[0].Hash: txs[0]}, []*types.Body{{Transactions: txs}})
+
+		_, err := s.applyCompactBlock(newCompactBlock(2), callback)
+		require.NoError(t, err)
+		require.Len(t, *written, 1)
+		require.Equal(t, 1, *requests)
+	})
+
+	t.Run("body not served by the peer", func(t *testing.T) {
+		t.Parallel()
+
+		s, written, _ := newSyncer(nil, []*types.Body{nil})
+
+		_, err := s.applyCompactBlock(newCompactBlock(2), callback)
+		require.ErrorIs(t, err, errInvalidBody)
+		require.Empty(t, *written)
+	})
+
+	t.Run("not the next block", func(t *testing.T) {
+		t.Parallel()
+
+		s, written, requests := newSyncer(nil, nil)
+
+		_, err := s.applyCompactBlock(newCompactBlock(5), callback)
+		require.NoError(t, err)
+		require.Empty(t, *written)
+		require.Equal(t, 0, *requests)
+	})
+}
+
+func Test_syncPeerClient_handleCompactBlock(t *testing.T) {
+	t.Parallel()
+
+	header := (&types.Header{Number: 2}).ComputeHash()
+
+	mockNet := &mockNetwork{connected: map[peer.ID]bool{"A": true}}
+	client := &syncPeerClient{
+		logger:         hclog.NewNullLogger(),
+		network:        mockNet,
+		blockchain:     &mockBlockchain{headerHandler: newSimpleHeaderHandler(1)},
+		compactBlockCh: make(chan *CompactBlock, 1),
+	}
+
+	// the blocks of the non-connected peers and the blocks other than the next one are dropped
+	client.handleCompactBlock(toProtoCompactBlock(header, nil), "B")
+	client.handleCompactBlock(toProtoCompactBlock((&types.Header{Number: 3}).ComputeHash(), nil), "A")
+	require.Len(t, client.compactBlockCh, 0)
+
+	client.handleCompactBlock(toProtoCompactBlock(header, nil), "A")
+	require.Len(t, client.compactBlockCh, 1)
+
+	compact := <-client.GetCompactBlockCh()
+	require.Equal(t, header.Hash, compact.Header.Hash)
+
+	// the invalid block is reported
+	client.handleCompactBlock(&proto.CompactBlock{Header: []byte{0x1}}, "A")
+	require.Len(t, client.compactBlockCh, 0)
+	require.Equal(t, []network.PeerScoreEvent{network.InvalidMessage}, mockNet.events["A"])
+}
@@ -0,0 +1,334 @@
+package syncer
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// SyncMode is the way the syncer catches up with the peers
+type SyncMode string
+
+const (
+	// FullSync executes all blocks received from the peers
+	FullSync SyncMode = "full"
+	// FastSync downloads the state of a recent block from the peers, and executes only the blocks after it
+	FastSync SyncMode = "fast"
+)
+
+const (
+	// fastSyncPivotDistance is the number of the latest blocks of the peer after the pivot block,
+	// which are executed by the bulk sync. The fast sync is used only when the node is further behind
+	fastSyncPivotDistance = 64
+
+	// fastSyncLogInterval is the interval of logging the progress of the state sync
+	fastSyncLogInterval = 10 * time.Second
+)
+
+var (
+	errPivotNotFound     = errors.New("pivot block not received from peer")
+	errPivotMismatch     = errors.New("block doesn't match pivot block")
+	errPivotNotReached   = errors.New("peer stopped sending blocks before pivot block")
+	errStateNotServed    = errors.New("peer doesn't serve state of pivot block")
+	errReceiptsNotServed = errors.New("peer doesn't serve receipts of block")
+)
+
+// ParseSyncMode validates the sync mode name, the empty name selects the full sync
+func ParseSyncMode(name string) (SyncMode, error) {
+	switch mode := SyncMode(name); mode {
+	case "":
+		return FullSync, nil
+	case FullSync, FastSync:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown sync mode %s, expected %s or %s", name, FullSync, FastSync)
+	}
+}
+
+// fastSyncPivot returns the number of the block whose state is downloaded,
+// the bool is false if the blocks are synced by the bulk sync only
+func (s *syncer) fastSyncPivot(peerLatest uint64) (uint64, bool) {
+	if s.syncMode != FastSync || s.stateStorage == nil {
+		return 0, false
+	}
+
+	header := s.blockchain.Header()
+
+	if peerLatest > header.Number+fastSyncPivotDistance {
+		return peerLatest - fastSyncPivotDistance, true
+	}
+
+	// the interrupted fast sync left the head without its state, which is downloaded again
+	if !s.hasState(header.StateRoot) {
+		return header.Number, true
+	}
+
+	return 0, false
+}
+
+// fastSyncWithPeer downloads the state of the pivot block from the peer,
+// and then the blocks until the pivot block along with their receipts, which are not executed.
+// The blocks are verified against their parents and the pivot block has to match the downloaded state,
+// so the state is stored before the blocks and the head never moves past a block whose state is missing
+func (s *syncer) fastSyncWithPeer(
+	peerID peer.ID,
+	pivotNumber uint64,
+	callback func(*types.FullBlock) bool,
+) (bool, error) {
+	header := s.blockchain.Header()
+	pivot := header
+
+	if pivotNumber > header.Number {
+		block, err := s.getBlock(peerID, pivotNumber)
+		if err != nil {
+			return false, err
+		}
+
+		pivot = block.Header
+	}
+
+	// Create a blockchain subscription for the sync progression and start tracking
+	subscription := s.blockchain.SubscribeEvents()
+	s.syncProgression.StartProgression(header.Number+1, subscription)
+	s.syncProgression.UpdateHighestProgression(pivot.Number)
+	s.syncProgression.UpdateStage(progress.ChainSyncStageStateDownload)
+
+	defer func() {
+		s.syncProgression.StopProgression()
+		s.blockchain.UnsubscribeEvents(subscription)
+	}()
+
+	s.logger.Info("fast syncing state of pivot block", "peer", peerID, "pivot", pivot.Number, "root", pivot.StateRoot)
+
+	if err := s.syncState(peerID, pivot.StateRoot); err != nil {
+		return false, fmt.Errorf("failed to sync state of block %d: %w", pivot.Number, err)
+	}
+
+	if pivot.Number == header.Number {
+		return false, nil
+	}
+
+	s.syncProgression.UpdateStage(progress.ChainSyncStageBlockDownload)
+
+	return s.syncBlocksUntil(peerID, pivot, callback)
+}
+
+// getBlock returns the block with the given number from the peer
+func (s *syncer) getBlock(peerID peer.ID, number uint64) (*types.Block, error) {
+	blockCh, err := s.syncPeerClient.GetBlocks(peerID, number, s.blockTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	defer s.closeBlockStream(peerID, blockCh)
+
+	block, ok := <-blockCh
+	if !ok || block.Number() != number {
+		return nil, errPivotNotFound
+	}
+
+	return block, nil
+}
+
+// syncState downloads the state trie with the given root from the peer
+func (s *syncer) syncState(peerID peer.ID, root types.Hash) error {
+	stateSync, err := itrie.NewStateSync(root, s.stateStorage)
+	if err != nil {
+		return err
+	}
+
+	start, lastLog := time.Now(), time.Now()
+
+	for stateSync.Pending() > 0 {
+		hashes := stateSync.Missing(maxTrieNodesPerRequest)
+
+		data, err := s.syncPeerClient.GetTrieNodes(peerID, hashes)
+		if err != nil {
+			return err
+		}
+
+		var delivered int
+
+		for i, hash := range hashes {
+			if len(data[i]) > 0 {
+				delivered++
+			}
+
+			if err := stateSync.Process(hash, data[i]); err != nil {
+				metrics.IncrCounter([]string{syncerMetrics, "bad_trie_node"}, 1)
+
+				return err
+			}
+		}
+
+		if err := stateSync.Commit(); err != nil {
+			return err
+		}
+
+		if delivered == 0 {
+			return errStateNotServed
+		}
+
+		metrics.SetGauge([]string{syncerMetrics, "state_nodes"}, float32(stateSync.Synced()))
+		s.syncProgression.UpdateStateProgression(stateSync.Synced(), stateSync.Synced()+uint64(stateSync.Pending()))
+
+		if time.Since(lastLog) > fastSyncLogInterval {
+			s.logger.Info("fast syncing state", "synced", stateSync.Synced(), "pending", stateSync.Pending())
+
+			lastLog = time.Now()
+		}
+	}
+
+	s.logger.Info("state synced", "root", root, "nodes", stateSync.Synced(), "elapsed", time.Since(start))
+
+	return nil
+}
+
+// syncBlocksUntil downloads the blocks after the local head until the pivot block,
+// and writes them along with their receipts without executing them
+func (s *syncer) syncBlocksUntil(
+	peerID peer.ID,
+	pivot *types.Header,
+	callback func(*types.FullBlock) bool,
+) (bool, error) {
+	localLatest := s.blockchain.Header().Number
+
+	blockCh, err := s.syncPeerClient.GetBlocks(peerID, localLatest+1, s.blockTimeout)
+	if err != nil {
+		return false, err
+	}
+
+	defer s.closeBlockStream(peerID, blockCh)
+
+	blocks := make([]*types.Block, 0, maxReceiptsPerRequest)
+
+	for {
+		select {
+		case block, ok := <-blockCh:
+			if !ok {
+				if _, err := s.writeBlocksWithReceipts(peerID, blocks, pivot, callback); err != nil {
+					return false, err
+				}
+
+				return false, errPivotNotReached
+			}
+
+			// safe check
+			if block.Number() == 0 {
+				continue
+			}
+
+			blocks = append(blocks, block)
+
+			if len(blocks) < cap(blocks) && block.Number() < pivot.Number {
+				continue
+			}
+
+			shouldTerminate, err := s.writeBlocksWithReceipts(peerID, blocks, pivot, callback)
+			if err != nil || block.Number() >= pivot.Number {
+				return shouldTerminate, err
+			}
+
+			blocks = blocks[:0]
+		case <-time.After(s.blockTimeout):
+			return false, errTimeout
+		}
+	}
+}
+
+// writeBlocksWithReceipts fetches the receipts of the blocks from the peer, and writes the verified blocks.
+// The callback is called for the pivot block only, since the states of the preceding blocks are not available
+func (s *syncer) writeBlocksWithReceipts(
+	peerID peer.ID,
+	blocks []*types.Block,
+	pivot *types.Header,
+	callback func(*types.FullBlock) bool,
+) (bool, error) {
+	// the blocks without the transactions have no receipts
+	hashes := make([]types.Hash, 0, len(blocks))
+
+	for _, block := range blocks {
+		if len(block.Transactions) > 0 {
+			hashes = append(hashes, block.Hash())
+		}
+	}
+
+	receipts := make(map[types.Hash][]*types.Receipt, len(hashes))
+
+	if len(hashes) > 0 {
+		peerReceipts, err := s.syncPeerClient.GetReceipts(peerID, hashes)
+		if err != nil {
+			return false, err
+		}
+
+		for i, hash := range hashes {
+			if peerReceipts[i] == nil {
+				return false, fmt.Errorf("%w %s", errReceiptsNotServed, hash)
+			}
+
+			receipts[hash] = peerReceipts[i]
+		}
+	}
+
+	for _, block := range blocks {
+		if block.Number() > pivot.Number {
+			break
+		}
+
+		if block.Number() == pivot.Number && block.Hash() != pivot.Hash {
+			return false, errPivotMismatch
+		}
+
+		fullBlock, err := s.blockchain.VerifyFinalizedBlockWithReceipts(block, receipts[block.Hash()])
+		if err != nil {
+			metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+			return false, fmt.Errorf("%w, %w", errBlockNotVerified, err)
+		}
+
+		if err := s.blockchain.WriteFullBlock(fullBlock, syncerName); err != nil {
+			metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+			return false, fmt.Errorf("failed to write block while fast syncing: %w", err)
+		}
+
+		updateMetrics(fullBlock)
+
+		if block.Number() == pivot.Number {
+			return callback(fullBlock), nil
+		}
+	}
+
+	return false, nil
+}
+
+// closeBlockStream closes the stream of the blocks which may not be read until the end
+func (s *syncer) closeBlockStream(peerID peer.ID, blockCh <-chan *types.Block) {
+	if err := s.syncPeerClient.CloseStream(peerID); err != nil {
+		s.logger.Error("Failed to close stream: ", err)
+	}
+
+	// the stream goroutine may be blocked on sending the next block
+	go func() {
+		for range blockCh {
+		}
+	}()
+}
+
+// hasState returns true if the state trie with the given root is stored
+func (s *syncer) hasState(root types.Hash) bool {
+	if root == types.EmptyRootHash {
+		return true
+	}
+
+	_, ok, err := s.stateStorage.Get(root.Bytes())
+
+	return err == nil && ok
+}
@@ -0,0 +1,168 @@
+package syncer
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestParseSyncMode(t *testing.T) {
+	t.Parallel()
+
+	mode, err := ParseSyncMode("")
+	assert.NoError(t, err)
+	assert.Equal(t, FullSync, mode)
+
+	mode, err = ParseSyncMode("fast")
+	assert.NoError(t, err)
+	assert.Equal(t, FastSync, mode)
+
+	_, err = ParseSyncMode("snap")
+	assert.Error(t, err)
+}
+
+// newFastSyncChain creates the chain of the linked blocks whose pivot block holds the state root
+func newFastSyncChain(num int, pivot uint64, stateRoot types.Hash) (*types.Header, []*types.Block) {
+	genesis := (&types.Header{Number: 0, StateRoot: types.EmptyRootHash}).ComputeHash()
+	blocks := make([]*types.Block, num)
+	parent := genesis
+
+	for i := range blocks {
+		header := &types.Header{
+			Number:     uint64(i + 1),
+			ParentHash: parent.Hash,
+			StateRoot:  types.EmptyRootHash,
+		}
+
+		if header.Number == pivot {
+			header.StateRoot = stateRoot
+		}
+
+		blocks[i] = &types.Block{Header: header.ComputeHash()}
+
+		// every other block has a transaction, whose receipts are fetched from the peer
+		if i%2 == 0 {
+			blocks[i].Transactions = []*types.Transaction{{Nonce: uint64(i)}}
+		}
+
+		parent = blocks[i].Header
+	}
+
+	return genesis, blocks
+}
+
+func Test_fastSyncWithPeer(t *testing.T) {
+	t.Parallel()
+
+	source := itrie.NewMemoryStorage()
+
+	_, root, err := itrie.NewState(source).NewSnapshot().Commit([]*state.Object{
+		{
+			Address:  types.StringToAddress("1"),
+			Balance:  big.NewInt(10),
+			Root:     types.EmptyRootHash,
+			CodeHash: types.EmptyCodeHash,
+		},
+	})
+	assert.NoError(t, err)
+
+	stateRoot := types.BytesToHash(root)
+	genesis, blocks := newFastSyncChain(20, 12, stateRoot)
+
+	var (
+		lock      sync.Mutex
+		head      = genesis
+		callbacks []uint64
+	)
+
+	chain := &mockBlockchain{
+		subscription: blockchain.NewMockSubscription(),
+		headerHandler: func() *types.Header {
+			lock.Lock()
+			defer lock.Unlock()
+
+			return head
+		},
+		verifyWithReceiptsHandler: func(b *types.Block, receipts []*types.Receipt) (*types.FullBlock, error) {
+			assert.Equal(t, head.Hash, b.ParentHash())
+			assert.Len(t, receipts, len(b.Transactions))
+
+			return &types.FullBlock{Block: b, Receipts: receipts}, nil
+		},
+		writeFullBlockHandler: func(b *types.FullBlock) error {
+			lock.Lock()
+			defer lock.Unlock()
+
+			head = b.Block.Header
+
+			return nil
+		},
+	}
+
+	client := &mockSyncPeerClient{
+		getBlocksHandler: func(_ peer.ID, from uint64, _ time.Duration) (<-chan *types.Block, error) {
+			return blocksToCh(blocks[from-1:], 0), nil
+		},
+		getTrieNodesHandler: func(_ peer.ID, hashes []types.Hash) ([][]byte, error) {
+			data := make([][]byte, len(hashes))
+			for i, hash := range hashes {
+				data[i], _, _ = source.Get(hash.Bytes())
+			}
+
+			return data, nil
+		},
+		getReceiptsHandler: func(_ peer.ID, hashes []types.Hash) ([][]*types.Receipt, error) {
+			receipts := make([][]*types.Receipt, len(hashes))
+			for i := range hashes {
+				receipts[i] = []*types.Receipt{{CumulativeGasUsed: 21000}}
+			}
+
+			return receipts, nil
+		},
+	}
+
+	syncer := NewTestSyncer(nil, chain, time.Second, client, &mockProgression{})
+	syncer.stateStorage = itrie.NewMemoryStorage()
+	syncer.syncMode = FastSync
+
+	// the node close to the peer is synced by the bulk sync
+	_, ok := syncer.fastSyncPivot(fastSyncPivotDistance)
+	assert.False(t, ok)
+
+	pivot, ok := syncer.fastSyncPivot(12 + fastSyncPivotDistance)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(12), pivot)
+
+	shouldTerminate, err := syncer.fastSyncWithPeer(peer.ID("A"), pivot, func(b *types.FullBlock) bool {
+		callbacks = append(callbacks, b.Block.Number())
+
+		return false
+	})
+	assert.NoError(t, err)
+	assert.False(t, shouldTerminate)
+
+	// the head stops at the pivot block, whose state is synced
+	assert.Equal(t, blocks[11].Hash(), head.Hash)
+	assert.Equal(t, []uint64{12}, callbacks)
+	assert.True(t, syncer.hasState(stateRoot))
+
+	// the head without its state is synced again
+	head = blocks[12].Header
+	head.StateRoot = types.StringToHash("0x1")
+
+	pivot, ok = syncer.fastSyncPivot(13)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(13), pivot)
+
+	_, err = syncer.fastSyncWithPeer(peer.ID("A"), pivot, nil)
+	assert.ErrorIs(t, err, errStateNotServed)
+}
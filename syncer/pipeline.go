@@ -0,0 +1,513 @@
+package syncer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+	"github.com/armon/go-metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// pipelineBatchSize is the number of the blocks whose bodies are requested at once
+	pipelineBatchSize = 32
+
+	// pipelineBodyWorkers is the number of the body requests sent to the peers concurrently
+	pipelineBodyWorkers = 4
+
+	// pipelineVerifyWorkers is the number of the batches whose senders are recovered concurrently
+	pipelineVerifyWorkers = 4
+
+	// pipelineQueueSize is the number of the batches queued between two stages
+	pipelineQueueSize = 8
+
+	// pipelineWindow is the max number of the batches in the pipeline, which bounds the memory
+	// used by the batches waiting for a preceding one to be executed
+	pipelineWindow = 32
+
+	// maxBodyAttempts is the number of the peers a batch of bodies is requested from before the sync fails
+	maxBodyAttempts = 3
+)
+
+// Stages of the pipeline, used as the metric labels
+const (
+	headersStage = "headers"
+	bodiesStage  = "bodies"
+	verifyStage  = "verify"
+	executeStage = "execute"
+)
+
+var (
+	errHeadersNotLinked = errors.New("headers are not linked to the local chain")
+	errInvalidBody      = errors.New("invalid block body")
+	errBodyNotFound     = errors.New("block body not found")
+	errNoBodyPeer       = errors.New("no peer served the block bodies")
+)
+
+// blockBatch is a range of the consecutive blocks moving through the pipeline
+type blockBatch struct {
+	// seq is the position of the batch in the pipeline, the batches are executed in its order
+	seq uint64
+
+	headers []*types.Header
+	blocks  []*types.Block
+}
+
+// syncPipeline downloads the headers from the best peer, fetches the bodies from multiple peers in parallel,
+// verifies them and recovers the transaction senders concurrently, while the blocks are executed in order.
+// The stages are connected by the bounded queues, so the download keeps up with the execution
+type syncPipeline struct {
+	syncer *syncer
+	peerID peer.ID // the peer the headers are downloaded from
+	target uint64  // the height the blocks are downloaded up to
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	errOnce sync.Once
+	err     error
+
+	// window is the semaphore bounding the number of the batches in the pipeline
+	window chan struct{}
+
+	headerCh   chan *blockBatch
+	bodyCh     chan *blockBatch
+	verifiedCh chan *blockBatch
+
+	// nextBodyPeer spreads the body requests over the peers
+	nextBodyPeer atomic.Uint64
+
+	// bodyPeers are the peers the bodies were requested from, whose streams are closed at the end
+	bodyPeers sync.Map
+}
+
+// pipelineSyncWithPeer syncs the blocks up to the latest block of the given peer through the pipeline,
+// the peers which don't serve the headers and the bodies separately are synced with the block stream
+func (s *syncer) pipelineSyncWithPeer(peerID peer.ID, peerLatestBlock uint64,
+	newBlockCallback func(*types.FullBlock) bool) (uint64, bool, error) {
+	head := s.blockchain.Header()
+
+	headers, err := s.syncPeerClient.GetHeaders(peerID, head.Number+1, headersAmount(head.Number+1, peerLatestBlock))
+	if status.Code(err) == codes.Unimplemented {
+		return s.bulkSyncWithPeer(peerID, peerLatestBlock, newBlockCallback)
+	}
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	// Create a blockchain subscription for the sync progression and start tracking
+	subscription := s.blockchain.SubscribeEvents()
+	s.syncProgression.StartProgression(head.Number+1, subscription)
+	s.syncProgression.UpdateHighestProgression(peerLatestBlock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	p := &syncPipeline{
+		syncer:     s,
+		peerID:     peerID,
+		target:     peerLatestBlock,
+		ctx:        ctx,
+		cancel:     cancel,
+		window:     make(chan struct{}, pipelineWindow),
+		headerCh:   make(chan *blockBatch, pipelineQueueSize),
+		bodyCh:     make(chan *blockBatch, pipelineQueueSize),
+		verifiedCh: make(chan *blockBatch, pipelineQueueSize),
+	}
+
+	defer func() {
+		cancel()
+
+		p.bodyPeers.Range(func(key, _ interface{}) bool {
+			bodyPeerID, _ := key.(peer.ID)
+
+			if err := s.syncPeerClient.CloseStream(bodyPeerID); err != nil {
+				s.logger.Error("Failed to close stream: ", err)
+			}
+
+			return true
+		})
+
+		// Stop monitoring the sync progression upon exit
+		s.syncProgression.StopProgression()
+		s.blockchain.UnsubscribeEvents(subscription)
+	}()
+
+	go p.downloadHeaders(head, headers)
+	p.runWorkers(pipelineBodyWorkers, p.fetchBodies, p.headerCh, p.bodyCh)
+	p.runWorkers(pipelineVerifyWorkers, p.verifyBatch, p.bodyCh, p.verifiedCh)
+
+	return p.execute(newBlockCallback)
+}
+
+// headersAmount returns the number of the headers requested from the given height up to the target
+func headersAmount(from, target uint64) uint64 {
+	if from > target {
+		return 0
+	}
+
+	if target-from+1 > maxHeadersPerRequest {
+		return maxHeadersPerRequest
+	}
+
+	return target - from + 1
+}
+
+// fail stops the pipeline with the given error, only the first error is kept
+func (p *syncPipeline) fail(err error) {
+	p.errOnce.Do(func() {
+		p.err = err
+		p.cancel()
+	})
+}
+
+// downloadHeaders downloads the headers from the best peer, checks they are linked to the local chain
+// and splits them into the batches
+func (p *syncPipeline) downloadHeaders(parent *types.Header, headers []*types.Header) {
+	defer close(p.headerCh)
+
+	var (
+		seq   uint64
+		batch []*types.Header
+		err   error
+	)
+
+	for len(headers) > 0 {
+		start := time.Now()
+
+		for _, header := range headers {
+			if header.Number != parent.Number+1 || header.ParentHash != parent.Hash {
+				p.fail(fmt.Errorf("%w: header %d (%s)", errHeadersNotLinked, header.Number, header.Hash))
+
+				return
+			}
+
+			parent = header
+		}
+
+		metrics.MeasureSince([]string{syncerMetrics, "pipeline", headersStage}, start)
+		metrics.IncrCounter([]string{syncerMetrics, "pipeline", headersStage, "blocks"}, float32(len(headers)))
+
+		for _, header := range headers {
+			batch = append(batch, header)
+
+			if len(batch) == pipelineBatchSize || header.Number == p.target {
+				if !p.push(seq, batch) {
+					return
+				}
+
+				seq++
+
+				batch = nil
+			}
+		}
+
+		if parent.Number >= p.target {
+			break
+		}
+
+		headers, err = p.syncer.syncPeerClient.GetHeaders(p.peerID, parent.Number+1,
+			headersAmount(parent.Number+1, p.target))
+		if err != nil {
+			p.fail(err)
+
+			return
+		}
+	}
+
+	// the peer may have returned fewer headers than it announced
+	if len(batch) > 0 {
+		p.push(seq, batch)
+	}
+}
+
+// push sends the batch of the headers to the pipeline once there is a free slot,
+// it returns false if the pipeline is stopped
+func (p *syncPipeline) push(seq uint64, headers []*types.Header) bool {
+	select {
+	case p.window <- struct{}{}:
+	case <-p.ctx.Done():
+		return false
+	}
+
+	select {
+	case p.headerCh <- &blockBatch{seq: seq, headers: headers}:
+		return true
+	case <-p.ctx.Done():
+		return false
+	}
+}
+
+// runWorkers processes the batches of the input queue concurrently and sends them to the output queue,
+// which is closed once all the workers are done
+func (p *syncPipeline) runWorkers(
+	workers int,
+	process func(*blockBatch) error,
+	inCh <-chan *blockBatch,
+	outCh chan<- *blockBatch,
+) {
+	var wg sync.WaitGroup
+
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for batch := range inCh {
+				if p.ctx.Err() != nil {
+					continue
+				}
+
+				if err := process(batch); err != nil {
+					p.fail(err)
+
+					continue
+				}
+
+				select {
+				case outCh <- batch:
+				case <-p.ctx.Done():
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(outCh)
+	}()
+}
+
+// fetchBodies requests the bodies of the batch from the peers, until the bodies matching the headers are served.
+// The peer is asked again for the bodies left out of its response, as long as it serves some of them.
+// The peers serving the invalid bodies are penalized
+func (p *syncPipeline) fetchBodies(batch *blockBatch) error {
+	var (
+		start   = time.Now()
+		last    = batch.headers[len(batch.headers)-1].Number
+		missing = batch.headers
+		tried   = make(map[peer.ID]bool)
+		peerID  peer.ID
+		err     error
+	)
+
+	batch.blocks = make([]*types.Block, 0, len(batch.headers))
+
+	for attempt := 0; len(missing) > 0; {
+		if peerID == "" {
+			if attempt == maxBodyAttempts {
+				break
+			}
+
+			if peerID = p.bodyPeer(last, tried); peerID == "" {
+				break
+			}
+
+			attempt++
+			tried[peerID] = true
+
+			p.bodyPeers.Store(peerID, struct{}{})
+		}
+
+		var blocks []*types.Block
+
+		if blocks, err = p.requestBodies(peerID, missing); err != nil {
+			p.syncer.logger.Debug("failed to fetch block bodies from peer", "peer", peerID, "from",
+				missing[0].Number, "to", last, "err", err)
+			p.syncer.reportSyncFailure(peerID, err)
+		}
+
+		if len(blocks) == 0 {
+			// try the next peer
+			peerID = ""
+
+			continue
+		}
+
+		batch.blocks = append(batch.blocks, blocks...)
+		missing = missing[len(blocks):]
+	}
+
+	if len(missing) > 0 {
+		// the header peer is not responsible for the failures of the body peers, so the error isn't wrapped
+		if err != nil {
+			return fmt.Errorf("%w, blocks %d-%d: %s", errNoBodyPeer, missing[0].Number, last, err.Error())
+		}
+
+		return fmt.Errorf("%w, blocks %d-%d", errNoBodyPeer, missing[0].Number, last)
+	}
+
+	metrics.MeasureSince([]string{syncerMetrics, "pipeline", bodiesStage}, start)
+	metrics.IncrCounter([]string{syncerMetrics, "pipeline", bodiesStage, "blocks"}, float32(len(batch.blocks)))
+
+	return nil
+}
+
+// requestBodies requests the bodies of the headers from the peer, it returns the blocks of the served bodies,
+// which are the leading ones as the peer may leave out the rest to limit the size of its response
+func (p *syncPipeline) requestBodies(peerID peer.ID, headers []*types.Header) ([]*types.Block, error) {
+	hashes := make([]types.Hash, len(headers))
+	for i, header := range headers {
+		hashes[i] = header.Hash
+	}
+
+	bodies, err := p.syncer.syncPeerClient.GetBodies(peerID, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*types.Block, 0, len(headers))
+
+	for i, header := range headers {
+		if bodies[i] == nil {
+			if i == 0 {
+				return nil, fmt.Errorf("%w: block %d", errBodyNotFound, header.Number)
+			}
+
+			break
+		}
+
+		block, err := assembleBlock(header, bodies[i])
+		if err != nil {
+			// the valid bodies served along with the invalid one are dropped as well
+			return nil, err
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	return blocks, nil
+}
+
+// bodyPeer returns the next peer which has the block of the given height and wasn't tried yet
+func (p *syncPipeline) bodyPeer(number uint64, tried map[peer.ID]bool) peer.ID {
+	candidates := make([]peer.ID, 0)
+
+	p.syncer.peerMap.Range(func(_, value interface{}) bool {
+		status, _ := value.(*NoForkPeer)
+
+		if status.Number >= number && !tried[status.ID] {
+			candidates = append(candidates, status.ID)
+		}
+
+		return true
+	})
+
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i] < candidates[j]
+	})
+
+	return candidates[p.nextBodyPeer.Add(1)%uint64(len(candidates))]
+}
+
+// assembleBlock builds the block of the header and the body,
+// making sure the transactions and the uncles of the body match the header
+func assembleBlock(header *types.Header, body *types.Body) (*types.Block, error) {
+	for _, tx := range body.Transactions {
+		tx.ComputeHash(header.Number)
+	}
+
+	if buildroot.CalculateUncleRoot(body.Uncles) != header.Sha3Uncles {
+		return nil, fmt.Errorf("%w: uncles of block %d don't match its header", errInvalidBody, header.Number)
+	}
+
+	if buildroot.CalculateTransactionsRoot(body.Transactions, header.Number) != header.TxRoot {
+		return nil, fmt.Errorf("%w: transactions of block %d don't match its header", errInvalidBody, header.Number)
+	}
+
+	return &types.Block{
+		Header:       header,
+		Transactions: body.Transactions,
+		Uncles:       body.Uncles,
+	}, nil
+}
+
+// verifyBatch recovers the senders of the transactions of the batch,
+// so the signatures aren't verified while the blocks are executed one by one
+func (p *syncPipeline) verifyBatch(batch *blockBatch) error {
+	start := time.Now()
+
+	for _, block := range batch.blocks {
+		if err := p.syncer.blockchain.RecoverSenders(block); err != nil {
+			return fmt.Errorf("%w: failed to recover senders of block %d: %w", errBlockNotVerified, block.Number(), err)
+		}
+	}
+
+	metrics.MeasureSince([]string{syncerMetrics, "pipeline", verifyStage}, start)
+	metrics.IncrCounter([]string{syncerMetrics, "pipeline", verifyStage, "blocks"}, float32(len(batch.blocks)))
+
+	return nil
+}
+
+// execute executes and writes the blocks in order, it returns the number of the last written block
+func (p *syncPipeline) execute(newBlockCallback func(*types.FullBlock) bool) (uint64, bool, error) {
+	var (
+		pending            = make(map[uint64]*blockBatch)
+		nextSeq            uint64
+		lastReceivedNumber uint64
+		shouldTerminate    bool
+	)
+
+	for batch := range p.verifiedCh {
+		pending[batch.seq] = batch
+
+		for {
+			batch, ok := pending[nextSeq]
+			if !ok {
+				break
+			}
+
+			delete(pending, nextSeq)
+			nextSeq++
+
+			start := time.Now()
+
+			for _, block := range batch.blocks {
+				fullBlock, err := p.syncer.blockchain.VerifyFinalizedBlock(block)
+				if err != nil {
+					metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+					p.fail(fmt.Errorf("%w, %w", errBlockNotVerified, err))
+
+					return lastReceivedNumber, false, p.err
+				}
+
+				if err := p.syncer.blockchain.WriteFullBlock(fullBlock, syncerName); err != nil {
+					metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+					p.fail(fmt.Errorf("failed to write block while syncing: %w", err))
+
+					return lastReceivedNumber, false, p.err
+				}
+
+				updateMetrics(fullBlock)
+				shouldTerminate = newBlockCallback(fullBlock)
+
+				lastReceivedNumber = block.Number()
+			}
+
+			metrics.MeasureSince([]string{syncerMetrics, "pipeline", executeStage}, start)
+			metrics.IncrCounter([]string{syncerMetrics, "pipeline", executeStage, "blocks"}, float32(len(batch.blocks)))
+
+			// free the slot of the executed batch
+			<-p.window
+		}
+	}
+
+	// the queues are closed once the headers are downloaded or the pipeline failed
+	p.fail(nil)
+
+	return lastReceivedNumber, shouldTerminate, p.err
+}
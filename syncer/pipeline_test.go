@@ -0,0 +1,257 @@
+package syncer
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPipelineChain creates the chain of the linked blocks whose headers commit to their transactions
+func newPipelineChain(num int) (*types.Header, []*types.Block) {
+	genesis := (&types.Header{Number: 0}).ComputeHash()
+	blocks := make([]*types.Block, num)
+	parent := genesis
+
+	for i := range blocks {
+		number := uint64(i + 1)
+		txs := []*types.Transaction{
+			(&types.Transaction{Nonce: number, Gas: 21000, V: big.NewInt(1)}).ComputeHash(number),
+		}
+
+		header := &types.Header{
+			Number:     number,
+			ParentHash: parent.Hash,
+			Sha3Uncles: types.EmptyUncleHash,
+			TxRoot:     buildroot.CalculateTransactionsRoot(txs, number),
+		}
+
+		blocks[i] = &types.Block{Header: header.ComputeHash(), Transactions: txs}
+		parent = blocks[i].Header
+	}
+
+	return genesis, blocks
+}
+
+// newPipelineSyncer creates the syncer whose peers serve the headers and the bodies of the given blocks
+func newPipelineSyncer(
+	genesis *types.Header,
+	blocks []*types.Block,
+	reporter *mockNetwork,
+	getBodiesHandler func(peer.ID, []types.Hash) ([]*types.Body, error),
+) (*syncer, *[]*types.Block) {
+	var (
+		lock    sync.Mutex
+		head    = genesis
+		written = make([]*types.Block, 0, len(blocks))
+	)
+
+	syncer := NewTestSyncer(
+		reporter,
+		&mockBlockchain{
+			headerHandler: func() *types.Header {
+				lock.Lock()
+				defer lock.Unlock()
+
+				return head
+			},
+			verifyFinalizedBlockHandler: func(b *types.Block) (*types.FullBlock, error) {
+				return &types.FullBlock{Block: b}, nil
+			},
+			writeFullBlockHandler: func(b *types.FullBlock) error {
+				lock.Lock()
+				defer lock.Unlock()
+
+				written = append(written, b.Block)
+				head = b.Block.Header
+
+				return nil
+			},
+		},
+		time.Second,
+		&mockSyncPeerClient{
+			getHeadersHandler: func(_ peer.ID, from, amount uint64) ([]*types.Header, error) {
+				headers := make([]*types.Header, 0, amount)
+
+				for i := from; i < from+amount && i <= uint64(len(blocks)); i++ {
+					headers = append(headers, blocks[i-1].Header)
+				}
+
+				return headers, nil
+			},
+			getBodiesHandler: getBodiesHandler,
+		},
+		&mockProgression{},
+	)
+
+	return syncer, &written
+}
+
+// bodiesOf returns the bodies of the blocks with the given hashes
+func bodiesOf(blocks []*types.Block, hashes []types.Hash) []*types.Body {
+	bodies := make([]*types.Body, len(hashes))
+
+	for i, hash := range hashes {
+		for _, block := range blocks {
+			if block.Hash() == hash {
+				bodies[i] = block.Body()
+			}
+		}
+	}
+
+	return bodies
+}
+
+func Test_pipelineSyncWithPeer(t *testing.T) {
+	t.Parallel()
+
+	genesis, blocks := newPipelineChain(3*maxHeadersPerRequest + 5)
+	reporter := &mockNetwork{}
+
+	var (
+		requestsLock sync.Mutex
+		requests     = make(map[peer.ID]int)
+	)
+
+	syncer, written := newPipelineSyncer(genesis, blocks, reporter, func(id peer.ID, hashes []types.Hash) ([]*types.Body, error) {
+		requestsLock.Lock()
+		requests[id]++
+		requestsLock.Unlock()
+
+		bodies := bodiesOf(blocks, hashes)
+
+		// the peer C serves the bodies which don't match the headers
+		if id == "C" {
+			bodies[0] = &types.Body{}
+		}
+
+		return bodies, nil
+	})
+
+	for _, id := range []peer.ID{"A", "B", "C"} {
+		syncer.peerMap.Put(&NoForkPeer{ID: id, Number: uint64(len(blocks)), Distance: big.NewInt(0)})
+	}
+
+	lastNumber, shouldTerminate, err := syncer.pipelineSyncWithPeer("A", uint64(len(blocks)), func(b *types.FullBlock) bool {
+		return b.Block.Number() == uint64(len(blocks))
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(len(blocks)), lastNumber)
+	assert.True(t, shouldTerminate)
+	assert.Equal(t, blocks, *written)
+
+	// the bodies are fetched from all the peers, the one serving the invalid bodies is penalized
+	assert.Positive(t, requests["A"])
+	assert.Positive(t, requests["B"])
+	assert.Positive(t, requests["C"])
+	assert.Contains(t, reporter.events["C"], network.InvalidMessage)
+	assert.Empty(t, reporter.events["A"])
+	assert.Empty(t, reporter.events["B"])
+}
+
+func Test_pipelineSyncWithPeer_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("headers not linked", func(t *testing.T) {
+		t.Parallel()
+
+		genesis, blocks := newPipelineChain(10)
+		reporter := &mockNetwork{}
+
+		// the header of the block 5 doesn't follow the block 4
+		blocks[4] = &types.Block{Header: (&types.Header{Number: 5}).ComputeHash()}
+
+		syncer, written := newPipelineSyncer(genesis, blocks, reporter, func(_ peer.ID, hashes []types.Hash) ([]*types.Body, error) {
+			return bodiesOf(blocks, hashes), nil
+		})
+		syncer.peerMap.Put(&NoForkPeer{ID: "A", Number: 10, Distance: big.NewInt(0)})
+
+		_, _, err := syncer.pipelineSyncWithPeer("A", 10, func(*types.FullBlock) bool { return false })
+		require.ErrorIs(t, err, errHeadersNotLinked)
+
+		// the batch of the blocks before the broken link isn't pushed
+		assert.Empty(t, *written)
+
+		syncer.reportSyncFailure("A", err)
+		assert.Equal(t, []network.PeerScoreEvent{network.ProtocolViolation}, reporter.events["A"])
+	})
+
+	t.Run("no peer serves the bodies", func(t *testing.T) {
+		t.Parallel()
+
+		genesis, blocks := newPipelineChain(10)
+		reporter := &mockNetwork{}
+
+		syncer, written := newPipelineSyncer(genesis, blocks, reporter, func(_ peer.ID, hashes []types.Hash) ([]*types.Body, error) {
+			return make([]*types.Body, len(hashes)), nil
+		})
+		syncer.peerMap.Put(&NoForkPeer{ID: "A", Number: 10, Distance: big.NewInt(0)})
+
+		_, _, err := syncer.pipelineSyncWithPeer("A", 10, func(*types.FullBlock) bool { return false })
+		require.ErrorIs(t, err, errNoBodyPeer)
+		assert.Empty(t, *written)
+
+		// the missing bodies aren't penalized
+		syncer.reportSyncFailure("A", err)
+		assert.Empty(t, reporter.events["A"])
+	})
+}
+
+func Test_assembleBlock(t *testing.T) {
+	t.Parallel()
+
+	_, blocks := newPipelineChain(2)
+
+	block, err := assembleBlock(blocks[0].Header, blocks[0].Body())
+	require.NoError(t, err)
+	assert.Equal(t, blocks[0], block)
+
+	_, err = assembleBlock(blocks[0].Header, blocks[1].Body())
+	assert.ErrorIs(t, err, errInvalidBody)
+
+	_, err = assembleBlock(blocks[0].Header, &types.Body{
+		Transactions: blocks[0].Transactions,
+		Uncles:       []*types.Header{blocks[1].Header},
+	})
+	assert.ErrorIs(t, err, errInvalidBody)
+}
+
+func Test_requestBodies_Partial(t *testing.T) {
+	t.Parallel()
+
+	genesis, blocks := newPipelineChain(pipelineBatchSize)
+
+	var (
+		requestsLock sync.Mutex
+		requests     int
+	)
+
+	// the peer serves at most 5 bodies per response, leaving out the rest
+	syncer, written := newPipelineSyncer(genesis, blocks, &mockNetwork{}, func(_ peer.ID, hashes []types.Hash) ([]*types.Body, error) {
+		requestsLock.Lock()
+		requests++
+		requestsLock.Unlock()
+
+		bodies := bodiesOf(blocks, hashes)
+		for i := 5; i < len(bodies); i++ {
+			bodies[i] = nil
+		}
+
+		return bodies, nil
+	})
+	syncer.peerMap.Put(&NoForkPeer{ID: "A", Number: uint64(len(blocks)), Distance: big.NewInt(0)})
+
+	_, _, err := syncer.pipelineSyncWithPeer("A", uint64(len(blocks)), func(*types.FullBlock) bool { return false })
+	require.NoError(t, err)
+
+	assert.Equal(t, blocks, *written)
+	assert.Equal(t, (pipelineBatchSize+4)/5, requests)
+}
@@ -5,31 +5,55 @@ import (
 	"errors"
 
 	"github.com/0xPolygon/polygon-edge/network/grpc"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/syncer/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/armon/go-metrics"
 	"github.com/golang/protobuf/ptypes/empty"
 )
 
+const (
+	// maxTrieNodesPerRequest is the maximum number of the trie nodes served by a single request
+	maxTrieNodesPerRequest = 512
+
+	// maxReceiptsPerRequest is the maximum number of the blocks whose receipts are served by a single request
+	maxReceiptsPerRequest = 128
+
+	// maxHeadersPerRequest is the maximum number of the headers served by a single request
+	maxHeadersPerRequest = 192
+
+	// maxBodiesPerRequest is the maximum number of the blocks whose bodies are served by a single request
+	maxBodiesPerRequest = 128
+
+	// maxBodiesResponseSize is the size of the bodies after which the rest of the requested bodies are left out,
+	// so the response stays within the message size limit of the client
+	maxBodiesResponseSize = 2 * 1024 * 1024
+)
+
 var (
-	ErrBlockNotFound = errors.New("block not found")
+	ErrBlockNotFound  = errors.New("block not found")
+	ErrTooManyHashes  = errors.New("too many hashes requested")
+	ErrTooManyHeaders = errors.New("too many headers requested")
 )
 
 type syncPeerService struct {
 	proto.UnimplementedSyncPeerServer
 
-	blockchain Blockchain       // reference to the blockchain module
-	network    Network          // reference to the network module
-	stream     *grpc.GrpcStream // reference to the grpc stream
+	blockchain   Blockchain       // reference to the blockchain module
+	network      Network          // reference to the network module
+	stateStorage itrie.Storage    // reference to the state storage, nil if the state isn't served
+	stream       *grpc.GrpcStream // reference to the grpc stream
 }
 
 func NewSyncPeerService(
 	network Network,
 	blockchain Blockchain,
+	stateStorage itrie.Storage,
 ) SyncPeerService {
 	return &syncPeerService{
-		blockchain: blockchain,
-		network:    network,
+		blockchain:   blockchain,
+		network:      network,
+		stateStorage: stateStorage,
 	}
 }
 
@@ -64,7 +88,7 @@ func (s *syncPeerService) GetBlocks(
 			return ErrBlockNotFound
 		}
 
-		resp := toProtoBlock(block)
+		resp := toProtoBlock(block, req.Compress)
 		metrics.SetGauge([]string{syncerMetrics, "egress_bytes"}, float32(len(resp.Block)))
 
 		// if client closes stream, context.Canceled is given
@@ -91,9 +115,154 @@ func (s *syncPeerService) GetStatus(
 	}, nil
 }
 
-// toProtoBlock converts type.Block -> proto.Block
-func toProtoBlock(block *types.Block) *proto.Block {
+// GetTrieNodes is a gRPC endpoint to return the trie nodes or the contract codes by their hashes
+func (s *syncPeerService) GetTrieNodes(
+	ctx context.Context,
+	req *proto.GetTrieNodesRequest,
+) (*proto.TrieNodes, error) {
+	if len(req.Hashes) > maxTrieNodesPerRequest {
+		return nil, ErrTooManyHashes
+	}
+
+	resp := &proto.TrieNodes{
+		Data: make([][]byte, len(req.Hashes)),
+	}
+
+	if s.stateStorage == nil {
+		return resp, nil
+	}
+
+	var size int
+
+	for i, hash := range req.Hashes {
+		if len(hash) != types.HashLength {
+			continue
+		}
+
+		data, ok, err := s.stateStorage.Get(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			data, _ = s.stateStorage.GetCode(types.BytesToHash(hash))
+		}
+
+		resp.Data[i] = data
+		size += len(data)
+	}
+
+	metrics.SetGauge([]string{syncerMetrics, "egress_bytes"}, float32(size))
+
+	return resp, nil
+}
+
+// GetReceipts is a gRPC endpoint to return the receipts of the blocks by their hashes
+func (s *syncPeerService) GetReceipts(
+	ctx context.Context,
+	req *proto.GetReceiptsRequest,
+) (*proto.Receipts, error) {
+	if len(req.Hashes) > maxReceiptsPerRequest {
+		return nil, ErrTooManyHashes
+	}
+
+	resp := &proto.Receipts{
+		Receipts: make([][]byte, len(req.Hashes)),
+	}
+
+	for i, hash := range req.Hashes {
+		receipts, err := s.blockchain.GetReceiptsByHash(types.BytesToHash(hash))
+		if err != nil {
+			// the receipts which are not found are left empty
+			continue
+		}
+
+		resp.Receipts[i] = types.Receipts(receipts).MarshalStoreRLPTo(nil)
+	}
+
+	return resp, nil
+}
+
+// GetHeaders is a gRPC endpoint to return the consecutive headers from the specific height,
+// the headers above the latest one are not returned
+func (s *syncPeerService) GetHeaders(
+	ctx context.Context,
+	req *proto.GetHeadersRequest,
+) (*proto.Headers, error) {
+	if req.Amount > maxHeadersPerRequest {
+		return nil, ErrTooManyHeaders
+	}
+
+	resp := &proto.Headers{
+		Headers: make([][]byte, 0, req.Amount),
+	}
+
+	latest := s.blockchain.Header().Number
+
+	for i := req.From; i < req.From+req.Amount && i <= latest; i++ {
+		block, ok := s.blockchain.GetBlockByNumber(i, false)
+		if !ok {
+			return nil, ErrBlockNotFound
+		}
+
+		resp.Headers = append(resp.Headers, block.Header.MarshalRLP())
+	}
+
+	return resp, nil
+}
+
+// GetBodies is a gRPC endpoint to return the bodies of the blocks by their hashes
+func (s *syncPeerService) GetBodies(
+	ctx context.Context,
+	req *proto.GetBodiesRequest,
+) (*proto.Bodies, error) {
+	if len(req.Hashes) > maxBodiesPerRequest {
+		return nil, ErrTooManyHashes
+	}
+
+	resp := &proto.Bodies{
+		Bodies: make([][]byte, len(req.Hashes)),
+	}
+
+	var size int
+
+	for i, hash := range req.Hashes {
+		if size >= maxBodiesResponseSize {
+			break
+		}
+
+		body, ok := s.blockchain.GetBodyByHash(types.BytesToHash(hash))
+		if !ok {
+			// the bodies which are not found are left empty
+			continue
+		}
+
+		data := body.MarshalRLPTo(nil)
+		size += len(data)
+
+		if req.Compress {
+			data = compress(data)
+		}
+
+		resp.Bodies[i] = data
+	}
+
+	resp.Compressed = req.Compress
+
+	metrics.SetGauge([]string{syncerMetrics, "egress_bytes"}, float32(size))
+
+	return resp, nil
+}
+
+// toProtoBlock converts type.Block -> proto.Block, the block data is snappy compressed if requested
+func toProtoBlock(block *types.Block, compressed bool) *proto.Block {
+	data := block.MarshalRLP()
+	if compressed {
+		data = compress(data)
+	}
+
 	return &proto.Block{
-		Block: block.MarshalRLP(),
+		Block:      data,
+		Compressed: compressed,
 	}
 }
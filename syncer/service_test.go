@@ -7,6 +7,8 @@ import (
 	"net"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/crypto"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/syncer/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
@@ -154,3 +156,156 @@ func TestGetStatus(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, headerNumber, status.Number)
 }
+
+func TestGetTrieNodes(t *testing.T) {
+	t.Parallel()
+
+	var (
+		storage  = itrie.NewMemoryStorage()
+		node     = []byte{0xc2, 0x1, 0x2}
+		code     = []byte{0x60, 0x0}
+		nodeHash = types.BytesToHash(crypto.Keccak256(node))
+		codeHash = types.BytesToHash(crypto.Keccak256(code))
+	)
+
+	assert.NoError(t, storage.Put(nodeHash.Bytes(), node))
+	assert.NoError(t, storage.SetCode(codeHash, code))
+
+	client := newMockGrpcClient(t, &syncPeerService{stateStorage: storage})
+
+	resp, err := client.GetTrieNodes(context.Background(), &proto.GetTrieNodesRequest{
+		Hashes: [][]byte{nodeHash.Bytes(), types.StringToHash("0x1").Bytes(), codeHash.Bytes()},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Data, 3)
+	assert.Equal(t, node, resp.Data[0])
+	assert.Empty(t, resp.Data[1])
+	assert.Equal(t, code, resp.Data[2])
+
+	_, err = client.GetTrieNodes(context.Background(), &proto.GetTrieNodesRequest{
+		Hashes: make([][]byte, maxTrieNodesPerRequest+1),
+	})
+
+	assert.ErrorContains(t, err, ErrTooManyHashes.Error())
+}
+
+func TestGetReceipts(t *testing.T) {
+	t.Parallel()
+
+	receipts := []*types.Receipt{
+		{CumulativeGasUsed: 21000, GasUsed: 21000, TxHash: types.StringToHash("0x2")},
+	}
+
+	service := &syncPeerService{
+		blockchain: &mockBlockchain{
+			getReceiptsByHashHandler: func(hash types.Hash) ([]*types.Receipt, error) {
+				if hash == types.StringToHash("0x1") {
+					return receipts, nil
+				}
+
+				return nil, ErrBlockNotFound
+			},
+		},
+	}
+
+	client := newMockGrpcClient(t, service)
+
+	resp, err := client.GetReceipts(context.Background(), &proto.GetReceiptsRequest{
+		Hashes: [][]byte{types.StringToHash("0x1").Bytes(), types.StringToHash("0x3").Bytes()},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Receipts, 2)
+	assert.Empty(t, resp.Receipts[1])
+
+	var decoded types.Receipts
+
+	assert.NoError(t, decoded.UnmarshalStoreRLP(resp.Receipts[0]))
+	assert.Equal(t, types.Receipts(receipts), decoded)
+}
+
+func TestGetHeaders(t *testing.T) {
+	t.Parallel()
+
+	_, blocks := newPipelineChain(10)
+
+	service := &syncPeerService{
+		blockchain: &mockBlockchain{
+			headerHandler: newSimpleHeaderHandler(10),
+			getBlockByNumberHandler: func(number uint64, _ bool) (*types.Block, bool) {
+				return &types.Block{Header: blocks[number-1].Header}, true
+			},
+		},
+	}
+
+	client := newMockGrpcClient(t, service)
+
+	// the headers above the latest one are not returned
+	resp, err := client.GetHeaders(context.Background(), &proto.GetHeadersRequest{From: 8, Amount: 5})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Headers, 3)
+
+	for i, data := range resp.Headers {
+		header := &types.Header{}
+
+		assert.NoError(t, header.UnmarshalRLP(data))
+		assert.Equal(t, blocks[7+i].Header, header)
+	}
+
+	_, err = client.GetHeaders(context.Background(), &proto.GetHeadersRequest{From: 1, Amount: maxHeadersPerRequest + 1})
+	assert.ErrorContains(t, err, ErrTooManyHeaders.Error())
+}
+
+func TestGetBodies(t *testing.T) {
+	t.Parallel()
+
+	_, blocks := newPipelineChain(1)
+
+	service := &syncPeerService{
+		blockchain: &mockBlockchain{
+			getBodyByHashHandler: func(hash types.Hash) (*types.Body, bool) {
+				if hash == blocks[0].Hash() {
+					return blocks[0].Body(), true
+				}
+
+				return nil, false
+			},
+		},
+	}
+
+	client := newMockGrpcClient(t, service)
+
+	resp, err := client.GetBodies(context.Background(), &proto.GetBodiesRequest{
+		Hashes: [][]byte{blocks[0].Hash().Bytes(), types.StringToHash("0x1").Bytes()},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Bodies, 2)
+	assert.Empty(t, resp.Bodies[1])
+
+	body := &types.Body{}
+
+	assert.NoError(t, body.UnmarshalRLP(resp.Bodies[0]))
+	assert.Len(t, body.Transactions, 1)
+	assert.Equal(t, blocks[0].Transactions[0].Nonce, body.Transactions[0].Nonce)
+
+	// the bodies are snappy compressed if requested
+	resp, err = client.GetBodies(context.Background(), &proto.GetBodiesRequest{
+		Hashes:   [][]byte{blocks[0].Hash().Bytes(), types.StringToHash("0x1").Bytes()},
+		Compress: true,
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, resp.Compressed)
+	assert.Empty(t, resp.Bodies[1])
+
+	data, err := decompress(resp.Bodies[0])
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[0].Body().MarshalRLPTo(nil), data)
+
+	_, err = client.GetBodies(context.Background(), &proto.GetBodiesRequest{
+		Hashes: make([][]byte, maxBodiesPerRequest+1),
+	})
+	assert.ErrorContains(t, err, ErrTooManyHashes.Error())
+}
@@ -1,16 +1,21 @@
 package syncer
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/network/event"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -19,14 +24,17 @@ const (
 )
 
 var (
-	errTimeout = errors.New("timeout awaiting block from peer")
+	errTimeout          = errors.New("timeout awaiting block from peer")
+	errBlockNotVerified = errors.New("unable to verify block")
 )
 
 // XXX: Don't use this syncer for the consensus that may cause fork.
 // This syncer doesn't assume forks
 type syncer struct {
 	logger          hclog.Logger
+	network         Network
 	blockchain      Blockchain
+	stateStorage    itrie.Storage
 	syncProgression Progression
 
 	peerMap         *PeerMap
@@ -38,22 +46,35 @@ type syncer struct {
 
 	// Channel to notify Sync that a new status arrived
 	newStatusCh chan struct{}
+
+	// The way the syncer catches up with the peers
+	syncMode SyncMode
+
+	// The transactions the relayed compact blocks are reconstructed from, nil if the blocks are always requested
+	txSource TxSource
 }
 
 func NewSyncer(
 	logger hclog.Logger,
 	network Network,
 	blockchain Blockchain,
+	stateStorage itrie.Storage,
 	blockTimeout time.Duration,
+	syncMode SyncMode,
+	txSource TxSource,
 ) Syncer {
 	return &syncer{
 		logger:          logger.Named(syncerName),
+		network:         network,
 		blockchain:      blockchain,
+		stateStorage:    stateStorage,
 		syncProgression: progress.NewProgressionWrapper(progress.ChainSyncBulk),
-		syncPeerService: NewSyncPeerService(network, blockchain),
+		syncPeerService: NewSyncPeerService(network, blockchain, stateStorage),
 		syncPeerClient:  NewSyncPeerClient(logger, network, blockchain),
 		blockTimeout:    blockTimeout,
 		newStatusCh:     make(chan struct{}),
+		syncMode:        syncMode,
+		txSource:        txSource,
 		peerMap:         new(PeerMap),
 	}
 }
@@ -165,8 +186,23 @@ func (s *syncer) Sync(callback func(*types.FullBlock) bool) error {
 	skipList := make(map[peer.ID]bool)
 
 	for {
-		// Wait for a new event to arrive
-		<-s.newStatusCh
+		// Wait for a new event to arrive, the next block relayed by a peer is applied right away
+		select {
+		case <-s.newStatusCh:
+		case compact := <-s.syncPeerClient.GetCompactBlockCh():
+			shouldTerminate, err := s.applyCompactBlock(compact, callback)
+			if err != nil {
+				s.logger.Debug("failed to apply compact block", "peer ID", compact.From, "error", err)
+
+				s.reportSyncFailure(compact.From, err)
+			}
+
+			if shouldTerminate {
+				return nil
+			}
+
+			continue
+		}
 
 		// fetch local latest block
 		if header := s.blockchain.Header(); header != nil {
@@ -187,10 +223,34 @@ func (s *syncer) Sync(callback func(*types.FullBlock) bool) error {
 			continue
 		}
 
-		// fetch block from the peer
-		lastNumber, shouldTerminate, err := s.bulkSyncWithPeer(bestPeer.ID, bestPeer.Number, callback)
+		// download the state of the pivot block instead of executing all blocks, if the node is far behind
+		if pivot, ok := s.fastSyncPivot(bestPeer.Number); ok {
+			shouldTerminate, err := s.fastSyncWithPeer(bestPeer.ID, pivot, callback)
+			if err != nil {
+				s.logger.Warn("failed to complete fast sync with peer, try to next one", "peer ID", bestPeer.ID, "error", err)
+
+				s.reportSyncFailure(bestPeer.ID, err)
+
+				skipList[bestPeer.ID] = true
+
+				continue
+			}
+
+			if shouldTerminate {
+				break
+			}
+		}
+
+		// fetch blocks from the peer and the bodies from the other peers as well
+		lastNumber, shouldTerminate, err := s.pipelineSyncWithPeer(bestPeer.ID, bestPeer.Number, callback)
 		if err != nil {
 			s.logger.Warn("failed to complete bulk sync with peer, try to next one", "peer ID", "error", bestPeer.ID, err)
+
+			s.reportSyncFailure(bestPeer.ID, err)
+		}
+
+		if lastNumber > localLatest {
+			s.network.ReportPeer(bestPeer.ID, network.UsefulResponse)
 		}
 
 		if lastNumber < bestPeer.Number {
@@ -253,7 +313,7 @@ func (s *syncer) bulkSyncWithPeer(peerID peer.ID, peerLatestBlock uint64,
 			if err != nil {
 				metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
 
-				return lastReceivedNumber, false, fmt.Errorf("unable to verify block, %w", err)
+				return lastReceivedNumber, false, fmt.Errorf("%w, %w", errBlockNotVerified, err)
 			}
 
 			if err := s.blockchain.WriteFullBlock(fullBlock, syncerName); err != nil {
@@ -272,6 +332,68 @@ func (s *syncer) bulkSyncWithPeer(peerID peer.ID, peerLatestBlock uint64,
 	}
 }
 
+// applyCompactBlock reconstructs the relayed block from the local transactions, or requests its body
+// from the relaying peer if any of them is missing, and writes it if it is the next block
+func (s *syncer) applyCompactBlock(compact *CompactBlock, callback func(*types.FullBlock) bool) (bool, error) {
+	if compact.Header.Number != s.blockchain.Header().Number+1 {
+		return false, nil
+	}
+
+	block, ok := reconstructBlock(compact, s.txSource)
+	if !ok {
+		metrics.IncrCounter([]string{syncerMetrics, "compact_block_misses"}, 1)
+
+		bodies, err := s.syncPeerClient.GetBodies(compact.From, []types.Hash{compact.Header.Hash})
+		if err != nil {
+			return false, err
+		}
+
+		if len(bodies) != 1 || bodies[0] == nil {
+			return false, fmt.Errorf("%w: body of block %s not found", errInvalidBody, compact.Header.Hash)
+		}
+
+		block = &types.Block{
+			Header:       compact.Header,
+			Transactions: bodies[0].Transactions,
+			Uncles:       bodies[0].Uncles,
+		}
+	}
+
+	fullBlock, err := s.blockchain.VerifyFinalizedBlock(block)
+	if err != nil {
+		metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+		return false, fmt.Errorf("%w, %w", errBlockNotVerified, err)
+	}
+
+	if err := s.blockchain.WriteFullBlock(fullBlock, syncerName); err != nil {
+		return false, fmt.Errorf("failed to write compact block: %w", err)
+	}
+
+	metrics.IncrCounter([]string{syncerMetrics, "compact_blocks"}, 1)
+	updateMetrics(fullBlock)
+
+	return callback(fullBlock), nil
+}
+
+// reportSyncFailure lowers the score of the peer the sync failed with,
+// the failures the peer is not responsible for are not reported
+func (s *syncer) reportSyncFailure(peerID peer.ID, err error) {
+	switch {
+	case errors.Is(err, errTimeout),
+		errors.Is(err, context.DeadlineExceeded),
+		status.Code(err) == codes.DeadlineExceeded:
+		s.network.ReportPeer(peerID, network.Timeout)
+	case errors.Is(err, errBlockNotVerified),
+		errors.Is(err, errInvalidBody),
+		errors.Is(err, itrie.ErrSyncHashMismatch):
+		s.network.ReportPeer(peerID, network.InvalidMessage)
+	case errors.Is(err, errPivotMismatch),
+		errors.Is(err, errHeadersNotLinked):
+		s.network.ReportPeer(peerID, network.ProtocolViolation)
+	}
+}
+
 func updateMetrics(fullBlock *types.FullBlock) {
 	metrics.SetGauge([]string{syncerMetrics, "tx_num"}, float32(len(fullBlock.Block.Transactions)))
 	metrics.SetGauge([]string{syncerMetrics, "receipts_num"}, float32(len(fullBlock.Receipts)))
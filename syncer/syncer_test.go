@@ -5,18 +5,47 @@ import (
 	"fmt"
 	"math/big"
 	"sort"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/network/event"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// mockNetwork records the peer score events reported by the syncer
+type mockNetwork struct {
+	Network
+
+	lock      sync.Mutex
+	events    map[peer.ID][]network.PeerScoreEvent
+	connected map[peer.ID]bool
+}
+
+func (m *mockNetwork) IsConnected(peerID peer.ID) bool {
+	return m.connected[peerID]
+}
+
+func (m *mockNetwork) ReportPeer(peerID peer.ID, event network.PeerScoreEvent) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.events == nil {
+		m.events = make(map[peer.ID][]network.PeerScoreEvent)
+	}
+
+	m.events[peerID] = append(m.events[peerID], event)
+}
+
 type mockProgression struct {
 	startingBlock uint64
 	highestBlock  uint64
@@ -42,6 +71,10 @@ type mockBlockchain struct {
 	verifyFinalizedBlockHandler func(*types.Block) (*types.FullBlock, error)
 	writeBlockHandler           func(*types.Block) error
 	writeFullBlockHandler       func(*types.FullBlock) error
+	verifyWithReceiptsHandler   func(*types.Block, []*types.Receipt) (*types.FullBlock, error)
+	getReceiptsByHashHandler    func(types.Hash) ([]*types.Receipt, error)
+	getBodyByHashHandler        func(types.Hash) (*types.Body, bool)
+	recoverSendersHandler       func(*types.Block) error
 }
 
 func (m *mockBlockchain) SubscribeEvents() blockchain.Subscription {
@@ -71,6 +104,33 @@ func (m *mockBlockchain) WriteFullBlock(b *types.FullBlock, s string) error {
 	return m.writeFullBlockHandler(b)
 }
 
+func (m *mockBlockchain) VerifyFinalizedBlockWithReceipts(
+	b *types.Block,
+	receipts []*types.Receipt,
+) (*types.FullBlock, error) {
+	return m.verifyWithReceiptsHandler(b, receipts)
+}
+
+func (m *mockBlockchain) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	return m.getReceiptsByHashHandler(hash)
+}
+
+func (m *mockBlockchain) GetBodyByHash(hash types.Hash) (*types.Body, bool) {
+	if m.getBodyByHashHandler == nil {
+		return nil, false
+	}
+
+	return m.getBodyByHashHandler(hash)
+}
+
+func (m *mockBlockchain) RecoverSenders(b *types.Block) error {
+	if m.recoverSendersHandler == nil {
+		return nil
+	}
+
+	return m.recoverSendersHandler(b)
+}
+
 func newSimpleHeaderHandler(num uint64) func() *types.Header {
 	return func() *types.Header {
 		return &types.Header{
@@ -89,12 +149,21 @@ func (m *mockSyncPeerService) Close() error {
 
 func (m *mockProgression) StopProgression() {}
 
+func (m *mockProgression) UpdateStage(stage progress.ChainSyncStage) {}
+
+func (m *mockProgression) UpdateStateProgression(pulledStates, knownStates uint64) {}
+
 type mockSyncPeerClient struct {
 	getPeerStatusHandler                  func(peer.ID) (*NoForkPeer, error)
 	getConnectedPeerStatusesHandler       func() []*NoForkPeer
 	getBlocksHandler                      func(peer.ID, uint64, time.Duration) (<-chan *types.Block, error)
 	getPeerStatusUpdateChHandler          func() <-chan *NoForkPeer
 	getPeerConnectionUpdateEventChHandler func() <-chan *event.PeerEvent
+	getTrieNodesHandler                   func(peer.ID, []types.Hash) ([][]byte, error)
+	getReceiptsHandler                    func(peer.ID, []types.Hash) ([][]*types.Receipt, error)
+	getHeadersHandler                     func(peer.ID, uint64, uint64) ([]*types.Header, error)
+	getBodiesHandler                      func(peer.ID, []types.Hash) ([]*types.Body, error)
+	compactBlockCh                        chan *CompactBlock
 }
 
 func (m *mockSyncPeerClient) DisablePublishingPeerStatus() {}
@@ -123,6 +192,27 @@ func (m *mockSyncPeerClient) GetBlocks(
 	return m.getBlocksHandler(id, start, timeoutPerBlock)
 }
 
+func (m *mockSyncPeerClient) GetTrieNodes(id peer.ID, hashes []types.Hash) ([][]byte, error) {
+	return m.getTrieNodesHandler(id, hashes)
+}
+
+func (m *mockSyncPeerClient) GetReceipts(id peer.ID, hashes []types.Hash) ([][]*types.Receipt, error) {
+	return m.getReceiptsHandler(id, hashes)
+}
+
+// GetHeaders fails as the old peers do unless the handler is set, so the blocks are synced with the block stream
+func (m *mockSyncPeerClient) GetHeaders(id peer.ID, from, amount uint64) ([]*types.Header, error) {
+	if m.getHeadersHandler == nil {
+		return nil, status.Error(codes.Unimplemented, "method GetHeaders not implemented")
+	}
+
+	return m.getHeadersHandler(id, from, amount)
+}
+
+func (m *mockSyncPeerClient) GetBodies(id peer.ID, hashes []types.Hash) ([]*types.Body, error) {
+	return m.getBodiesHandler(id, hashes)
+}
+
 func (m *mockSyncPeerClient) GetPeerStatusUpdateCh() <-chan *NoForkPeer {
 	return m.getPeerStatusUpdateChHandler()
 }
@@ -131,6 +221,10 @@ func (m *mockSyncPeerClient) GetPeerConnectionUpdateEventCh() <-chan *event.Peer
 	return m.getPeerConnectionUpdateEventChHandler()
 }
 
+func (m *mockSyncPeerClient) GetCompactBlockCh() <-chan *CompactBlock {
+	return m.compactBlockCh
+}
+
 func (m *mockSyncPeerClient) CloseStream(peerID peer.ID) error {
 	return nil
 }
@@ -167,8 +261,13 @@ func NewTestSyncer(
 	mockSyncPeerClient *mockSyncPeerClient,
 	mockProgression Progression,
 ) *syncer {
+	if network == nil {
+		network = &mockNetwork{}
+	}
+
 	return &syncer{
 		logger:          hclog.NewNullLogger(),
+		network:         network,
 		blockchain:      blockchain,
 		syncProgression: mockProgression,
 		syncPeerService: &mockSyncPeerService{},
@@ -858,3 +957,29 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 		})
 	}
 }
+
+func Test_reportSyncFailure(t *testing.T) {
+	t.Parallel()
+
+	var (
+		peerID   = peer.ID("X")
+		reporter = &mockNetwork{}
+		syncer   = NewTestSyncer(reporter, nil, time.Second, &mockSyncPeerClient{}, &mockProgression{})
+	)
+
+	syncer.reportSyncFailure(peerID, errTimeout)
+	syncer.reportSyncFailure(peerID, fmt.Errorf("%w, %w", errBlockNotVerified, errors.New("invalid seal")))
+	syncer.reportSyncFailure(peerID, fmt.Errorf("failed to sync state: %w", itrie.ErrSyncHashMismatch))
+	syncer.reportSyncFailure(peerID, errPivotMismatch)
+
+	// the failures the peer is not responsible for aren't reported
+	syncer.reportSyncFailure(peerID, errors.New("failed to write block"))
+	syncer.reportSyncFailure(peerID, errReceiptsNotServed)
+
+	assert.Equal(t, []network.PeerScoreEvent{
+		network.Timeout,
+		network.InvalidMessage,
+		network.InvalidMessage,
+		network.ProtocolViolation,
+	}, reporter.events[peerID])
+}
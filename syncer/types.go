@@ -33,6 +33,14 @@ type Blockchain interface {
 	WriteBlock(*types.Block, string) error
 	// WriteFullBlock writes a given block to chain and saves its receipts to cache
 	WriteFullBlock(*types.FullBlock, string) error
+	// VerifyFinalizedBlockWithReceipts verifies finalized block along with its receipts without executing it
+	VerifyFinalizedBlockWithReceipts(*types.Block, []*types.Receipt) (*types.FullBlock, error)
+	// GetReceiptsByHash returns the receipts of the block
+	GetReceiptsByHash(types.Hash) ([]*types.Receipt, error)
+	// GetBodyByHash returns the body of the block
+	GetBodyByHash(types.Hash) (*types.Body, bool)
+	// RecoverSenders recovers the senders of the transactions of the block
+	RecoverSenders(*types.Block) error
 }
 
 type Network interface {
@@ -50,13 +58,15 @@ type Network interface {
 	// and returns a reference to the connection
 	NewProtoConnection(protocol string, peerID peer.ID) (*rawGrpc.ClientConn, error)
 	// NewTopic Creates New Topic for gossip
-	NewTopic(protoID string, obj proto.Message) (*network.Topic, error)
+	NewTopic(protoID string, obj proto.Message, opts ...network.TopicOption) (*network.Topic, error)
 	// IsConnected returns the node is connecting to the peer associated with the given ID
 	IsConnected(peerID peer.ID) bool
 	// SaveProtocolStream saves stream
 	SaveProtocolStream(protocol string, stream *rawGrpc.ClientConn, peerID peer.ID)
 	// CloseProtocolStream closes stream
 	CloseProtocolStream(protocol string, peerID peer.ID) error
+	// ReportPeer applies the event to the score of the peer
+	ReportPeer(peerID peer.ID, event network.PeerScoreEvent)
 }
 
 type Syncer interface {
@@ -77,6 +87,10 @@ type Progression interface {
 	StartProgression(startingBlock uint64, subscription blockchain.Subscription)
 	// UpdateHighestProgression updates highest block number
 	UpdateHighestProgression(highestBlock uint64)
+	// UpdateStage updates the stage of the sync
+	UpdateStage(stage progress.ChainSyncStage)
+	// UpdateStateProgression updates the numbers of the downloaded and of the known state trie nodes
+	UpdateStateProgression(pulledStates, knownStates uint64)
 	// GetProgression returns Progression
 	GetProgression() *progress.Progression
 	// StopProgression finishes progression
@@ -101,10 +115,20 @@ type SyncPeerClient interface {
 	GetConnectedPeerStatuses() []*NoForkPeer
 	// GetBlocks returns a stream of blocks from given height to peer's latest
 	GetBlocks(peer.ID, uint64, time.Duration) (<-chan *types.Block, error)
+	// GetTrieNodes returns the trie nodes or the contract codes by their hashes, nil if not served by the peer
+	GetTrieNodes(peer.ID, []types.Hash) ([][]byte, error)
+	// GetReceipts returns the receipts of the blocks by their hashes
+	GetReceipts(peer.ID, []types.Hash) ([][]*types.Receipt, error)
+	// GetHeaders returns up to the given amount of the consecutive headers from the given height
+	GetHeaders(peer.ID, uint64, uint64) ([]*types.Header, error)
+	// GetBodies returns the bodies of the blocks by their hashes, nil if not served by the peer
+	GetBodies(peer.ID, []types.Hash) ([]*types.Body, error)
 	// GetPeerStatusUpdateCh returns a channel of peer's status update
 	GetPeerStatusUpdateCh() <-chan *NoForkPeer
 	// GetPeerConnectionUpdateEventCh returns peer's connection change event
 	GetPeerConnectionUpdateEventCh() <-chan *event.PeerEvent
+	// GetCompactBlockCh returns a channel of the compact blocks relayed by the peers
+	GetCompactBlockCh() <-chan *CompactBlock
 	// CloseStream close a stream
 	CloseStream(peerID peer.ID) error
 	// DisablePublishingPeerStatus disables publishing status in syncer topic
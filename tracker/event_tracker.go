@@ -2,6 +2,8 @@ package tracker
 
 import (
 	"context"
+	"errors"
+	"sync/atomic"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/helper/common"
@@ -14,6 +16,8 @@ import (
 
 const minBlockMaxBacklog = 96
 
+var errTrackerNotStarted = errors.New("event tracker is not started")
+
 type eventSubscription interface {
 	AddLog(log *ethgo.Log) error
 }
@@ -25,8 +29,15 @@ type EventTracker struct {
 	startBlock            uint64
 	subscriber            eventSubscription
 	logger                hcf.Logger
-	numBlockConfirmations uint64 // minimal number of child blocks required for the parent block to be considered final
-	pollInterval          time.Duration
+	numBlockConfirmations uint64       // minimal number of child blocks required for the parent block to be considered final
+	pollInterval          atomic.Int64 // interval of polling the new blocks, updated on the config reload
+
+	// head is the latest polled block of the tracked chain, synced is the latest block whose events are tracked
+	head   atomic.Uint64
+	synced atomic.Uint64
+
+	// store is the store of the tracked events, nil until the tracker is started
+	store atomic.Pointer[EventTrackerStore]
 }
 
 func NewEventTracker(
@@ -39,7 +50,7 @@ func NewEventTracker(
 	logger hcf.Logger,
 	pollInterval time.Duration,
 ) *EventTracker {
-	return &EventTracker{
+	e := &EventTracker{
 		dbPath:                dbPath,
 		rpcEndpoint:           rpcEndpoint,
 		contractAddr:          contractAddr,
@@ -47,8 +58,36 @@ func NewEventTracker(
 		numBlockConfirmations: numBlockConfirmations,
 		startBlock:            startBlock,
 		logger:                logger.Named("event_tracker"),
-		pollInterval:          pollInterval,
 	}
+
+	e.SetPollInterval(pollInterval)
+
+	return e
+}
+
+// SetPollInterval sets the interval of polling the new blocks, applied after the ongoing wait
+func (e *EventTracker) SetPollInterval(pollInterval time.Duration) {
+	e.pollInterval.Store(int64(pollInterval))
+}
+
+// Progress returns the latest polled block of the tracked chain and the latest block whose events are tracked
+func (e *EventTracker) Progress() (head uint64, synced uint64) {
+	return e.head.Load(), e.synced.Load()
+}
+
+// PollInterval returns the interval of polling the new blocks
+func (e *EventTracker) PollInterval() time.Duration {
+	return time.Duration(e.pollInterval.Load())
+}
+
+// GetLogsByTxHash returns the tracked logs emitted by the transaction of the tracked chain
+func (e *EventTracker) GetLogsByTxHash(txHash ethgo.Hash) ([]*ethgo.Log, error) {
+	store := e.store.Load()
+	if store == nil {
+		return nil, errTrackerNotStarted
+	}
+
+	return store.GetLogsByTxHash(txHash)
 }
 
 func (e *EventTracker) Start(ctx context.Context) error {
@@ -57,7 +96,7 @@ func (e *EventTracker) Start(ctx context.Context) error {
 		"JSON RPC address", e.rpcEndpoint,
 		"num block confirmations", e.numBlockConfirmations,
 		"start block", e.startBlock,
-		"poll interval", e.pollInterval)
+		"poll interval", e.PollInterval())
 
 	provider, err := jsonrpc.NewClient(e.rpcEndpoint)
 	if err != nil {
@@ -69,17 +108,22 @@ func (e *EventTracker) Start(ctx context.Context) error {
 		return err
 	}
 
+	store.onSynced = e.synced.Store
+	e.store.Store(store)
+
 	blockMaxBacklog := e.numBlockConfirmations * 2
 	if blockMaxBacklog < minBlockMaxBacklog {
 		blockMaxBacklog = minBlockMaxBacklog
 	}
 
-	jsonBlockTracker := blocktracker.NewJSONBlockTracker(provider.Eth())
-	jsonBlockTracker.PollInterval = e.pollInterval
 	blockTracker := blocktracker.NewBlockTracker(
 		provider.Eth(),
 		blocktracker.WithBlockMaxBacklog(blockMaxBacklog),
-		blocktracker.WithTracker(jsonBlockTracker),
+		blocktracker.WithTracker(&pollingBlockTracker{
+			provider:     provider.Eth(),
+			pollInterval: e.PollInterval,
+			onHead:       e.head.Store,
+		}),
 	)
 
 	go func() {
@@ -31,6 +31,9 @@ type EventTrackerStore struct {
 	numBlockConfirmations uint64
 	subscriber            eventSubscription
 	logger                hcf.Logger
+
+	// onSynced is notified of the blocks whose events have been tracked, if set
+	onSynced func(blockNumber uint64)
 }
 
 // NewEventTrackerStore creates a new EventTrackerStore
@@ -144,6 +147,10 @@ func (b *EventTrackerStore) onNewBlock(filterHash, blockData string) error {
 		return err
 	}
 
+	if b.onSynced != nil {
+		b.onSynced(block.Number)
+	}
+
 	if block.Number <= b.numBlockConfirmations {
 		return nil // there is nothing to process yet
 	}
@@ -180,6 +187,36 @@ func (b *EventTrackerStore) onNewBlock(filterHash, blockData string) error {
 	return nil
 }
 
+// GetLogsByTxHash returns the tracked logs emitted by the transaction, in the order they were stored
+func (b *EventTrackerStore) GetLogsByTxHash(txHash ethgo.Hash) ([]*ethgo.Log, error) {
+	var logs []*ethgo.Log
+
+	if err := b.conn.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, bucket *bolt.Bucket) error {
+			if !bytes.HasPrefix(name, dbLogs) {
+				return nil
+			}
+
+			return bucket.ForEach(func(_, value []byte) error {
+				log := &ethgo.Log{}
+				if err := log.UnmarshalJSON(value); err != nil {
+					return err
+				}
+
+				if log.TransactionHash == txHash {
+					logs = append(logs, log)
+				}
+
+				return nil
+			})
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
 // GetEntry implements the store interface
 func (b *EventTrackerStore) GetEntry(hash string) (store.Entry, error) {
 	return b.getImplEntry(hash)
@@ -0,0 +1,250 @@
+package tracker
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/umbracle/ethgo"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// openStoreTimeout is the timeout of opening the store, which is locked while the tracker is running
+	openStoreTimeout = time.Second
+	// compactTxMaxSize is the size of the transaction in which the buckets are copied to the compacted store
+	compactTxMaxSize = 64 * 1024 * 1024
+)
+
+// BucketStats are the statistics of the bucket of the tracker store
+type BucketStats struct {
+	Name string `json:"name"`
+	Keys int    `json:"keys"`
+	// Size is the number of the bytes used by the pages of the bucket
+	Size int `json:"size"`
+}
+
+// ContractLogStats are the statistics of the logs of the contract stored by the tracker
+type ContractLogStats struct {
+	Address ethgo.Address `json:"address"`
+	Logs    uint64        `json:"logs"`
+	// Processed is the number of the logs notified to the subscriber already
+	Processed uint64 `json:"processed"`
+}
+
+// StoreStats are the statistics of the tracker store
+type StoreStats struct {
+	FileSize  int64               `json:"fileSize"`
+	Buckets   []*BucketStats      `json:"buckets"`
+	Contracts []*ContractLogStats `json:"contracts"`
+}
+
+// CompactionResult is the result of the compaction of the tracker store
+type CompactionResult struct {
+	SizeBefore int64  `json:"sizeBefore"`
+	SizeAfter  int64  `json:"sizeAfter"`
+	PrunedLogs uint64 `json:"prunedLogs"`
+}
+
+// GetStoreStats returns the statistics of the tracker store at the path.
+// The store can't be opened while the tracker using it is running
+func GetStoreStats(path string) (*StoreStats, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := openStore(path, true)
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.Close()
+
+	stats := &StoreStats{FileSize: info.Size()}
+	contracts := map[ethgo.Address]*ContractLogStats{}
+
+	if err := db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, bucket *bolt.Bucket) error {
+			bucketStats := bucket.Stats()
+
+			stats.Buckets = append(stats.Buckets, &BucketStats{
+				Name: string(name),
+				Keys: bucketStats.KeyN,
+				Size: bucketStats.BranchInuse + bucketStats.LeafInuse,
+			})
+
+			if !bytes.HasPrefix(name, dbLogs) {
+				return nil
+			}
+
+			nextToProcess := getNextToProcessIndex(tx, name[len(dbLogs):])
+
+			return bucket.ForEach(func(key, value []byte) error {
+				log := &ethgo.Log{}
+				if err := log.UnmarshalJSON(value); err != nil {
+					return err
+				}
+
+				contract, ok := contracts[log.Address]
+				if !ok {
+					contract = &ContractLogStats{Address: log.Address}
+					contracts[log.Address] = contract
+				}
+
+				contract.Logs++
+
+				if common.EncodeBytesToUint64(key) < nextToProcess {
+					contract.Processed++
+				}
+
+				return nil
+			})
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, contract := range contracts {
+		stats.Contracts = append(stats.Contracts, contract)
+	}
+
+	sort.Slice(stats.Contracts, func(i, j int) bool {
+		return bytes.Compare(stats.Contracts[i].Address[:], stats.Contracts[j].Address[:]) < 0
+	})
+
+	return stats, nil
+}
+
+// CompactStore rewrites the tracker store at the path, releasing the space of the deleted data.
+// If pruneProcessed is set, the logs notified to the subscriber are deleted beforehand, except the last one,
+// which the tracker looks back at on the reorganization of the tracked chain.
+// The store can't be compacted while the tracker using it is running
+func CompactStore(path string, pruneProcessed bool) (*CompactionResult, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CompactionResult{SizeBefore: info.Size()}
+
+	src, err := openStore(path, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if pruneProcessed {
+		if result.PrunedLogs, err = pruneProcessedLogs(src); err != nil {
+			_ = src.Close()
+
+			return nil, err
+		}
+	}
+
+	compactedPath := path + ".compact"
+
+	dst, err := bolt.Open(compactedPath, 0600, nil)
+	if err != nil {
+		_ = src.Close()
+
+		return nil, err
+	}
+
+	if err := bolt.Compact(dst, src, compactTxMaxSize); err != nil {
+		_ = dst.Close()
+		_ = src.Close()
+		_ = os.Remove(compactedPath)
+
+		return nil, fmt.Errorf("failed to compact the tracker store: %w", err)
+	}
+
+	if err := dst.Close(); err != nil {
+		_ = src.Close()
+
+		return nil, err
+	}
+
+	if err := src.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := os.Rename(compactedPath, path); err != nil {
+		return nil, err
+	}
+
+	if info, err = os.Stat(path); err != nil {
+		return nil, err
+	}
+
+	result.SizeAfter = info.Size()
+
+	return result, nil
+}
+
+// pruneProcessedLogs deletes the logs notified to the subscriber, except the last one of each filter
+func pruneProcessedLogs(db *bolt.DB) (uint64, error) {
+	var pruned uint64
+
+	err := db.Update(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, bucket *bolt.Bucket) error {
+			if !bytes.HasPrefix(name, dbLogs) {
+				return nil
+			}
+
+			nextToProcess := getNextToProcessIndex(tx, name[len(dbLogs):])
+			if nextToProcess < 2 {
+				return nil
+			}
+
+			// the keys are collected first, since deleting under the cursor skips the next key
+			var keys [][]byte
+
+			cursor := bucket.Cursor()
+			keepFrom := common.EncodeUint64ToBytes(nextToProcess - 1)
+
+			for k, _ := cursor.First(); k != nil && bytes.Compare(k, keepFrom) < 0; k, _ = cursor.Next() {
+				keys = append(keys, append([]byte{}, k...))
+			}
+
+			for _, key := range keys {
+				if err := bucket.Delete(key); err != nil {
+					return err
+				}
+			}
+
+			pruned += uint64(len(keys))
+
+			return nil
+		})
+	})
+
+	return pruned, err
+}
+
+// getNextToProcessIndex returns the index of the first log of the filter not notified to the subscriber yet
+func getNextToProcessIndex(tx *bolt.Tx, filterHash []byte) uint64 {
+	bucket := tx.Bucket(append(append([]byte{}, dbNextToProcess...), filterHash...))
+	if bucket == nil {
+		return 0
+	}
+
+	if value := bucket.Get(nextToProcessKey); value != nil {
+		return common.EncodeBytesToUint64(value)
+	}
+
+	return 0
+}
+
+// openStore opens the tracker store at the path, failing instead of waiting if the store is in use
+func openStore(path string, readOnly bool) (*bolt.DB, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: openStoreTimeout, ReadOnly: readOnly})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the tracker store %s, it can't be opened while the node is running: %w",
+			path, err)
+	}
+
+	return db, nil
+}
@@ -0,0 +1,66 @@
+package tracker
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+)
+
+func TestEventTrackerStore_StatsAndCompaction(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "test.db")
+	contractA := ethgo.HexToAddress("0x1")
+	contractB := ethgo.HexToAddress("0x2")
+
+	tstore, err := NewEventTrackerStore(path, 2, nil, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	entry, err := tstore.getImplEntry("test")
+	require.NoError(t, err)
+
+	require.NoError(t, entry.StoreLogs([]*ethgo.Log{
+		{BlockNumber: 1, Address: contractA}, {BlockNumber: 2, Address: contractB}, {BlockNumber: 3, Address: contractA},
+		{BlockNumber: 4, Address: contractA}, {BlockNumber: 5, Address: contractB},
+	}))
+	require.NoError(t, entry.saveNextToProcessIndx(4))
+	require.NoError(t, tstore.Close())
+
+	stats, err := GetStoreStats(path)
+	require.NoError(t, err)
+	require.Equal(t, []*ContractLogStats{
+		{Address: contractA, Logs: 3, Processed: 3},
+		{Address: contractB, Logs: 2, Processed: 1},
+	}, stats.Contracts)
+	require.Len(t, stats.Buckets, 3)
+
+	result, err := CompactStore(path, true)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), result.PrunedLogs)
+
+	// the last processed log is kept, so the tracker can look back at it on the reorganization
+	stats, err = GetStoreStats(path)
+	require.NoError(t, err)
+	require.Equal(t, []*ContractLogStats{
+		{Address: contractA, Logs: 1, Processed: 1},
+		{Address: contractB, Logs: 1, Processed: 0},
+	}, stats.Contracts)
+
+	// the new logs are appended after the kept ones
+	tstore, err = NewEventTrackerStore(path, 2, nil, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	entry, err = tstore.getImplEntry("test")
+	require.NoError(t, err)
+
+	lastIndex, err := entry.LastIndex()
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), lastIndex)
+	require.NoError(t, tstore.Close())
+
+	_, err = GetStoreStats(filepath.Join(t.TempDir(), "missing.db"))
+	require.Error(t, err)
+}
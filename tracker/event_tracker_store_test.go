@@ -84,6 +84,33 @@ func TestEntry_saveNextToProcessIndx(t *testing.T) {
 	}
 }
 
+func TestEventTrackerStore_GetLogsByTxHash(t *testing.T) {
+	txHash := ethgo.HexToHash("0x1")
+	otherTxHash := ethgo.HexToHash("0x2")
+
+	tstore, closeFn := createSetupDB(nil, 2)(t)
+	defer closeFn()
+
+	entry, err := tstore.GetEntry("test")
+	require.NoError(t, err)
+
+	require.NoError(t, entry.StoreLogs([]*ethgo.Log{
+		{BlockNumber: 1, TransactionHash: txHash, LogIndex: 0},
+		{BlockNumber: 1, TransactionHash: otherTxHash, LogIndex: 1},
+		{BlockNumber: 1, TransactionHash: txHash, LogIndex: 2},
+	}))
+
+	logs, err := tstore.(*EventTrackerStore).GetLogsByTxHash(txHash)
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	assert.Equal(t, uint64(0), logs[0].LogIndex)
+	assert.Equal(t, uint64(2), logs[1].LogIndex)
+
+	logs, err = tstore.(*EventTrackerStore).GetLogsByTxHash(ethgo.HexToHash("0x3"))
+	require.NoError(t, err)
+	assert.Empty(t, logs)
+}
+
 func TestEventTrackerStore_SetNotLastBlock(t *testing.T) {
 	subs := &mockEventSubscriber{}
 
@@ -82,8 +82,8 @@ func TestEventTracker_TrackSyncEvents(t *testing.T) {
 		rpcEndpoint:           server.HTTPAddr(),
 		contractAddr:          addr,
 		numBlockConfirmations: numBlockConfirmations,
-		pollInterval:          time.Second,
 	}
+	tracker.SetPollInterval(time.Second)
 
 	err = tracker.Start(context.Background())
 	require.NoError(t, err)
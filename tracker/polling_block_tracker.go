@@ -0,0 +1,51 @@
+package tracker
+
+import (
+	"context"
+	"time"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/blocktracker"
+)
+
+// pollingBlockTracker tracks the new blocks by polling the head of the chain,
+// reading the poll interval before each wait so that it can be changed while tracking
+type pollingBlockTracker struct {
+	provider     blocktracker.BlockProvider
+	pollInterval func() time.Duration
+
+	// onHead is notified of the number of each polled head, if set
+	onHead func(blockNumber uint64)
+}
+
+// Track implements the blocktracker.BlockTrackerInterface
+func (p *pollingBlockTracker) Track(ctx context.Context, handle func(block *ethgo.Block) error) error {
+	var lastBlock *ethgo.Block
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-time.After(p.pollInterval()):
+			block, err := p.provider.GetBlockByNumber(ethgo.Latest, false)
+			if err != nil {
+				return err
+			}
+
+			if p.onHead != nil {
+				p.onHead(block.Number)
+			}
+
+			if lastBlock != nil && lastBlock.Hash == block.Hash {
+				continue
+			}
+
+			if err := handle(block); err != nil {
+				return err
+			}
+
+			lastBlock = block
+		}
+	}
+}
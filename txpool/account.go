@@ -4,6 +4,7 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -163,6 +164,19 @@ func (m *nonceToTxLookup) set(tx *types.Transaction) {
 	m.mapping[tx.Nonce] = tx
 }
 
+// countGasless returns the number of the gasless transactions, which are sponsored by the paymasters
+func (m *nonceToTxLookup) countGasless() int {
+	count := 0
+
+	for _, tx := range m.mapping {
+		if state.IsGasless(tx) {
+			count++
+		}
+	}
+
+	return count
+}
+
 func (m *nonceToTxLookup) reset() {
 	m.mapping = make(map[uint64]*types.Transaction)
 }
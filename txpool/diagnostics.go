@@ -0,0 +1,122 @@
+package txpool
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// maxReportedMissingNonces limits the number of missing nonces reported
+// for a single account, since the gap between nonces can be arbitrarily large
+const maxReportedMissingNonces = 128
+
+// AccountDiagnostics describes why the account's transactions are (not) being executed
+type AccountDiagnostics struct {
+	// Nonce is the next nonce the pool expects from the account
+	Nonce uint64
+
+	// Chained are the transactions with consecutive nonces starting from Nonce,
+	// which will be executed in order once included into a block
+	Chained []*types.Transaction
+
+	// Gapped are the enqueued transactions which can't be executed
+	// until the missing nonces are filled in
+	Gapped []*types.Transaction
+
+	// MissingNonces are the nonces needed to unstick the gapped transactions
+	MissingNonces []uint64
+
+	// MissingNoncesTruncated is set if there were more than maxReportedMissingNonces missing nonces
+	MissingNoncesTruncated bool
+
+	// BlockingTx is the lowest nonce transaction of the account, which blocks all the other ones
+	BlockingTx *types.Transaction
+
+	// MinReplacementGasPrice is the minimal gas price a transaction
+	// needs to have in order to replace BlockingTx
+	MinReplacementGasPrice *big.Int
+}
+
+// GetAccountDiagnostics reports chained and gapped transactions of the given account,
+// the nonces missing in between and the fee needed to replace the blocking transaction
+func (p *TxPool) GetAccountDiagnostics(addr types.Address) *AccountDiagnostics {
+	account := p.accounts.get(addr)
+	if account == nil {
+		return &AccountDiagnostics{
+			Nonce:         p.GetNonce(addr),
+			Chained:       []*types.Transaction{},
+			Gapped:        []*types.Transaction{},
+			MissingNonces: []uint64{},
+		}
+	}
+
+	account.promoted.lock(false)
+	account.enqueued.lock(false)
+
+	promoted := sortedByNonce(account.promoted.queue)
+	enqueued := sortedByNonce(account.enqueued.queue)
+	nonce := account.getNonce()
+
+	account.enqueued.unlock()
+	account.promoted.unlock()
+
+	diagnostics := &AccountDiagnostics{
+		Nonce:         nonce,
+		Chained:       promoted,
+		Gapped:        []*types.Transaction{},
+		MissingNonces: []uint64{},
+	}
+
+	// enqueued transactions continuing the promoted ones are chained as well,
+	// everything after the first gap is stuck until the gap is filled
+	expected := nonce
+
+	for _, tx := range enqueued {
+		if tx.Nonce < expected {
+			continue
+		}
+
+		if tx.Nonce == expected && len(diagnostics.Gapped) == 0 {
+			diagnostics.Chained = append(diagnostics.Chained, tx)
+			expected++
+
+			continue
+		}
+
+		for missing := expected; missing < tx.Nonce; missing++ {
+			if len(diagnostics.MissingNonces) == maxReportedMissingNonces {
+				diagnostics.MissingNoncesTruncated = true
+
+				break
+			}
+
+			diagnostics.MissingNonces = append(diagnostics.MissingNonces, missing)
+		}
+
+		diagnostics.Gapped = append(diagnostics.Gapped, tx)
+		expected = tx.Nonce + 1
+	}
+
+	if len(diagnostics.Chained) > 0 {
+		diagnostics.BlockingTx = diagnostics.Chained[0]
+		diagnostics.MinReplacementGasPrice = new(big.Int).Add(
+			diagnostics.BlockingTx.GetGasPrice(p.GetBaseFee()),
+			big.NewInt(1),
+		)
+	}
+
+	return diagnostics
+}
+
+// sortedByNonce returns a copy of the given transactions ordered by nonce
+func sortedByNonce(txs []*types.Transaction) []*types.Transaction {
+	sorted := make([]*types.Transaction, len(txs))
+	copy(sorted, txs)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Nonce < sorted[j].Nonce
+	})
+
+	return sorted
+}
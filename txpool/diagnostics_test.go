@@ -0,0 +1,85 @@
+package txpool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestGetAccountDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown account", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPool()
+		require.NoError(t, err)
+
+		diagnostics := pool.GetAccountDiagnostics(addr1)
+
+		assert.Equal(t, uint64(0), diagnostics.Nonce)
+		assert.Empty(t, diagnostics.Chained)
+		assert.Empty(t, diagnostics.Gapped)
+		assert.Empty(t, diagnostics.MissingNonces)
+		assert.Nil(t, diagnostics.BlockingTx)
+		assert.Nil(t, diagnostics.MinReplacementGasPrice)
+	})
+
+	t.Run("chained and gapped transactions", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPool()
+		require.NoError(t, err)
+
+		acc := pool.getOrCreateAccount(addr1)
+
+		// nonces 0 and 1 are promoted, 2 is enqueued and 3, 4 and 6 are missing
+		promoted := []*types.Transaction{newTx(addr1, 1, 1), newTx(addr1, 0, 1)}
+		enqueued := []*types.Transaction{newTx(addr1, 7, 1), newTx(addr1, 2, 1), newTx(addr1, 5, 1)}
+
+		for _, tx := range promoted {
+			acc.promoted.push(tx)
+		}
+
+		for _, tx := range enqueued {
+			acc.enqueued.push(tx)
+		}
+
+		acc.setNonce(2)
+
+		diagnostics := pool.GetAccountDiagnostics(addr1)
+
+		assert.Equal(t, uint64(2), diagnostics.Nonce)
+		assert.Equal(t, []*types.Transaction{promoted[1], promoted[0], enqueued[1]}, diagnostics.Chained)
+		assert.Equal(t, []*types.Transaction{enqueued[2], enqueued[0]}, diagnostics.Gapped)
+		assert.Equal(t, []uint64{3, 4, 6}, diagnostics.MissingNonces)
+		assert.False(t, diagnostics.MissingNoncesTruncated)
+		assert.Equal(t, promoted[1], diagnostics.BlockingTx)
+		assert.Equal(t,
+			new(big.Int).Add(promoted[1].GasPrice, big.NewInt(1)),
+			diagnostics.MinReplacementGasPrice,
+		)
+	})
+
+	t.Run("missing nonces are truncated", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPool()
+		require.NoError(t, err)
+
+		acc := pool.getOrCreateAccount(addr1)
+		acc.enqueued.push(newTx(addr1, maxReportedMissingNonces+10, 1))
+
+		diagnostics := pool.GetAccountDiagnostics(addr1)
+
+		assert.Empty(t, diagnostics.Chained)
+		assert.Len(t, diagnostics.Gapped, 1)
+		assert.Len(t, diagnostics.MissingNonces, maxReportedMissingNonces)
+		assert.True(t, diagnostics.MissingNoncesTruncated)
+		assert.Nil(t, diagnostics.BlockingTx)
+	})
+}
@@ -0,0 +1,498 @@
+package txpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/golang/protobuf/ptypes/any"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/network/grpc"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// The transactions up to txBroadcastMaxSize are broadcast in full over the txpool topic,
+// or over the batch topic if the broadcasts are batched,
+// the bigger ones are announced by their hashes and pulled by the peers which don't know them yet
+const (
+	topicNameAnnounceV1 = "txpool/announce/0.1"
+	topicNameBatchV1    = "txpool/batch/0.1"
+	txPullProto         = "/txpool/pull/0.1"
+
+	// txBroadcastMaxSize is the encoded size up to which the transactions are broadcast in full
+	txBroadcastMaxSize = 4 * 1024
+
+	// knownTxsCacheSize is the number of the recently seen transaction hashes
+	knownTxsCacheSize = 32 * 1024
+
+	// announceInterval is the interval in which the hashes of the local transactions are announced
+	announceInterval = 100 * time.Millisecond
+
+	// maxAnnouncedHashes bounds the number of the hashes of a single announcement and of a single pull
+	maxAnnouncedHashes = 256
+
+	// maxPulledTxsSize bounds the size of the transactions returned for a single pull
+	maxPulledTxsSize = 2 * 1024 * 1024
+
+	// maxBatchedTxs bounds the number of the transactions of a single batch broadcast
+	maxBatchedTxs = 256
+
+	// maxBatchedTxsSize bounds the size of the transactions of a single batch broadcast
+	maxBatchedTxsSize = 512 * 1024
+
+	// txPullTimeout bounds a single pull of the transactions
+	txPullTimeout = 5 * time.Second
+)
+
+var (
+	errTooManyHashes = errors.New("too many hashes requested")
+	errTooManyTxs    = errors.New("too many txs in the batch")
+	errUnrequestedTx = errors.New("unrequested transaction returned")
+)
+
+// txFetcher keeps track of the recently seen transactions and of the announced transactions being pulled
+type txFetcher struct {
+	known *lru.Cache // hashes of the recently seen transactions
+
+	lock    sync.Mutex
+	pulling map[types.Hash]struct{} // hashes of the transactions being pulled
+
+	// request pulls the encoded transactions by their hashes from the peer
+	request func(peerID peer.ID, hashes []types.Hash) ([][]byte, error)
+}
+
+func newTxFetcher() *txFetcher {
+	known, _ := lru.New(knownTxsCacheSize)
+
+	return &txFetcher{
+		known:   known,
+		pulling: make(map[types.Hash]struct{}),
+	}
+}
+
+// markKnown marks the transaction as seen, returns false if it was seen already
+func (f *txFetcher) markKnown(hash types.Hash) bool {
+	contains, _ := f.known.ContainsOrAdd(hash, struct{}{})
+
+	return !contains
+}
+
+// startPulling returns the hashes which are neither seen nor being pulled yet, and marks them being pulled
+func (f *txFetcher) startPulling(hashes []types.Hash, isPooled func(types.Hash) bool) []types.Hash {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	unknown := make([]types.Hash, 0, len(hashes))
+
+	for _, hash := range hashes {
+		if _, ok := f.pulling[hash]; ok || f.known.Contains(hash) || isPooled(hash) {
+			continue
+		}
+
+		f.pulling[hash] = struct{}{}
+
+		unknown = append(unknown, hash)
+	}
+
+	return unknown
+}
+
+// donePulling unmarks the hashes being pulled, so the ones not returned by the peer can be pulled again
+func (f *txFetcher) donePulling(hashes []types.Hash) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for _, hash := range hashes {
+		delete(f.pulling, hash)
+	}
+}
+
+// txAnnouncer batches the hashes of the local transactions and announces them in the given interval
+type txAnnouncer struct {
+	lock   sync.Mutex
+	hashes []types.Hash
+
+	publish func(*proto.TxnHashes) error
+}
+
+// enqueue queues the hash for the next announcement
+func (a *txAnnouncer) enqueue(hash types.Hash) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.hashes = append(a.hashes, hash)
+}
+
+// flush announces the queued hashes, in the announcements of up to maxAnnouncedHashes hashes
+func (a *txAnnouncer) flush() error {
+	a.lock.Lock()
+	hashes := a.hashes
+	a.hashes = nil
+	a.lock.Unlock()
+
+	for len(hashes) > 0 {
+		n := len(hashes)
+		if n > maxAnnouncedHashes {
+			n = maxAnnouncedHashes
+		}
+
+		if err := a.publish(&proto.TxnHashes{Hashes: hashesToBytes(hashes[:n])}); err != nil {
+			return err
+		}
+
+		metrics.IncrCounter([]string{txPoolMetrics, "announced_txs"}, float32(n))
+
+		hashes = hashes[n:]
+	}
+
+	return nil
+}
+
+// txBatcher batches the local transactions broadcast in full and publishes them in the given interval,
+// so that the peers receive a single message for all the transactions added in the meantime
+type txBatcher struct {
+	lock sync.Mutex
+	raws [][]byte
+
+	publish func(*proto.Txns) error
+}
+
+// enqueue queues the encoded transaction for the next batch
+func (b *txBatcher) enqueue(raw []byte) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.raws = append(b.raws, raw)
+}
+
+// flush publishes the queued transactions, in the batches of up to maxBatchedTxs transactions
+// and up to maxBatchedTxsSize bytes
+func (b *txBatcher) flush() error {
+	b.lock.Lock()
+	raws := b.raws
+	b.raws = nil
+	b.lock.Unlock()
+
+	for len(raws) > 0 {
+		n, size := 0, 0
+
+		for n < len(raws) && n < maxBatchedTxs {
+			// a batch holds at least one transaction
+			if n > 0 && size+len(raws[n]) > maxBatchedTxsSize {
+				break
+			}
+
+			size += len(raws[n])
+			n++
+		}
+
+		if err := b.publish(&proto.Txns{Raw: raws[:n]}); err != nil {
+			return err
+		}
+
+		metrics.IncrCounter([]string{txPoolMetrics, "batched_txs"}, float32(n))
+
+		raws = raws[n:]
+	}
+
+	return nil
+}
+
+// flushPeriodically calls flush in the given interval until the close channel is closed
+func flushPeriodically(interval time.Duration, closeCh <-chan struct{}, flush func() error, logger func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-closeCh:
+			return
+		case <-ticker.C:
+			if err := flush(); err != nil {
+				logger(err)
+			}
+		}
+	}
+}
+
+// setupGossip subscribes to the topics of the full transactions, of the batches and of the announcements,
+// and serves the announced transactions to the peers. The local transactions are broadcast in batches
+// if the batch interval is set
+func (p *TxPool) setupGossip(server *network.Server, batchInterval time.Duration) error {
+	topic, err := server.NewTopic(topicNameV1, &proto.Txn{}, network.WithContentMessageID())
+	if err != nil {
+		return err
+	}
+
+	if err := topic.Subscribe(p.addGossipTx); err != nil {
+		return fmt.Errorf("unable to subscribe to gossip topic, %w", err)
+	}
+
+	announceTopic, err := server.NewTopic(topicNameAnnounceV1, &proto.TxnHashes{}, network.WithContentMessageID())
+	if err != nil {
+		return err
+	}
+
+	if err := announceTopic.Subscribe(p.addGossipAnnouncement); err != nil {
+		return fmt.Errorf("unable to subscribe to announcement topic, %w", err)
+	}
+
+	// the batches are received regardless of the batch interval, so the nodes broadcasting
+	// the transactions one by one still accept the transactions of the batching ones
+	batchTopic, err := server.NewTopic(topicNameBatchV1, &proto.Txns{}, network.WithContentMessageID())
+	if err != nil {
+		return err
+	}
+
+	if err := batchTopic.Subscribe(p.addGossipBatch); err != nil {
+		return fmt.Errorf("unable to subscribe to batch topic, %w", err)
+	}
+
+	p.pullStream = grpc.NewGrpcStream()
+
+	proto.RegisterTxnPullServer(p.pullStream.GrpcServer(), &txPullService{pool: p})
+	p.pullStream.Serve()
+	server.RegisterProtocol(txPullProto, p.pullStream)
+
+	p.topic = topic
+	p.announcer = &txAnnouncer{publish: func(hashes *proto.TxnHashes) error {
+		return announceTopic.Publish(hashes)
+	}}
+	p.fetcher.request = func(peerID peer.ID, hashes []types.Hash) ([][]byte, error) {
+		return requestTxs(server, peerID, hashes)
+	}
+
+	if batchInterval > 0 {
+		p.batchInterval = batchInterval
+		p.batcher = &txBatcher{publish: func(txs *proto.Txns) error {
+			return batchTopic.Publish(txs)
+		}}
+	}
+
+	return nil
+}
+
+// broadcastTx broadcasts the small transaction in full, and queues the hash of the bigger one for the announcement
+func (p *TxPool) broadcastTx(tx *types.Transaction) {
+	raw := tx.MarshalRLP()
+
+	if len(raw) > txBroadcastMaxSize && p.announcer != nil {
+		p.announcer.enqueue(tx.Hash)
+
+		return
+	}
+
+	if p.batcher != nil {
+		p.batcher.enqueue(raw)
+
+		return
+	}
+
+	if err := p.topic.Publish(&proto.Txn{Raw: &any.Any{Value: raw}}); err != nil {
+		p.logger.Error("failed to topic tx", "err", err)
+	}
+}
+
+// addGossipBatch handles receiving the batches of the transactions gossiped by the network
+func (p *TxPool) addGossipBatch(obj interface{}, peerID peer.ID) {
+	if !p.sealing.Load() {
+		return
+	}
+
+	if p.peerReputation.isThrottled(peerID.String()) {
+		metrics.IncrCounter([]string{txPoolMetrics, "throttled_peer_txs"}, 1)
+
+		return
+	}
+
+	batch, ok := obj.(*proto.Txns)
+	if !ok || batch == nil {
+		p.logger.Error("failed to cast gossiped message to txn batch")
+
+		return
+	}
+
+	if len(batch.Raw) > maxBatchedTxs {
+		p.logger.Debug("dropping oversized txn batch", "peer", peerID, "err", errTooManyTxs, "txs", len(batch.Raw))
+
+		return
+	}
+
+	for _, raw := range batch.Raw {
+		tx, err := decodeTx(raw)
+		if err != nil {
+			p.logger.Error("failed to decode batched tx", "err", err)
+
+			continue
+		}
+
+		tx.ComputeHash(p.store.Header().Number)
+
+		p.addGossipedTx(tx, peerID)
+	}
+}
+
+// addGossipAnnouncement pulls the announced transactions, which are not known yet, from the announcing peer
+func (p *TxPool) addGossipAnnouncement(obj interface{}, peerID peer.ID) {
+	if !p.sealing.Load() {
+		return
+	}
+
+	if p.peerReputation.isThrottled(peerID.String()) {
+		metrics.IncrCounter([]string{txPoolMetrics, "throttled_peer_txs"}, 1)
+
+		return
+	}
+
+	announcement, ok := obj.(*proto.TxnHashes)
+	if !ok || announcement == nil {
+		p.logger.Error("failed to cast gossiped message to txn announcement")
+
+		return
+	}
+
+	if len(announcement.Hashes) > maxAnnouncedHashes {
+		p.logger.Debug("dropping oversized txn announcement", "peer", peerID, "hashes", len(announcement.Hashes))
+
+		return
+	}
+
+	hashes := make([]types.Hash, 0, len(announcement.Hashes))
+
+	for _, raw := range announcement.Hashes {
+		if len(raw) != types.HashLength {
+			p.logger.Debug("dropping malformed txn announcement", "peer", peerID)
+
+			return
+		}
+
+		hashes = append(hashes, types.BytesToHash(raw))
+	}
+
+	unknown := p.fetcher.startPulling(hashes, func(hash types.Hash) bool {
+		_, ok := p.index.get(hash)
+
+		return ok
+	})
+
+	if len(unknown) == 0 {
+		return
+	}
+
+	go p.pullTxs(peerID, unknown)
+}
+
+// pullTxs pulls the transactions from the peer and adds them to the pool
+func (p *TxPool) pullTxs(peerID peer.ID, hashes []types.Hash) {
+	defer p.fetcher.donePulling(hashes)
+
+	raws, err := p.fetcher.request(peerID, hashes)
+	if err != nil {
+		p.logger.Debug("failed to pull announced txs", "peer", peerID, "err", err)
+
+		return
+	}
+
+	metrics.IncrCounter([]string{txPoolMetrics, "pulled_txs"}, float32(len(raws)))
+
+	requested := make(map[types.Hash]struct{}, len(hashes))
+	for _, hash := range hashes {
+		requested[hash] = struct{}{}
+	}
+
+	for _, raw := range raws {
+		tx, err := decodeTx(raw)
+		if err != nil {
+			p.logger.Error("failed to decode pulled tx", "err", err)
+			p.peerReputation.penalize(peerID.String(), invalidTxPenalty)
+
+			continue
+		}
+
+		tx.ComputeHash(p.store.Header().Number)
+
+		// the peer returns only the requested transactions, each of them at most once
+		if _, ok := requested[tx.Hash]; !ok {
+			p.logger.Debug("dropping pulled tx", "peer", peerID, "hash", tx.Hash, "err", errUnrequestedTx)
+			p.peerReputation.penalize(peerID.String(), invalidTxPenalty)
+
+			continue
+		}
+
+		delete(requested, tx.Hash)
+
+		p.addGossipedTx(tx, peerID)
+	}
+}
+
+// requestTxs pulls the encoded transactions by their hashes from the peer
+func requestTxs(server *network.Server, peerID peer.ID, hashes []types.Hash) ([][]byte, error) {
+	conn, err := server.NewProtoConnection(txPullProto, peerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open a stream, err %w", err)
+	}
+
+	server.SaveProtocolStream(txPullProto, conn, peerID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), txPullTimeout)
+	defer cancel()
+
+	resp, err := proto.NewTxnPullClient(conn).GetTxns(ctx, &proto.TxnHashes{Hashes: hashesToBytes(hashes)})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Raw, nil
+}
+
+// txPullService serves the transactions of the pool to the peers pulling the announced transactions
+type txPullService struct {
+	proto.UnimplementedTxnPullServer
+
+	pool *TxPool
+}
+
+// GetTxns returns the transactions of the pool by their hashes, the unknown ones are skipped
+func (s *txPullService) GetTxns(_ context.Context, req *proto.TxnHashes) (*proto.Txns, error) {
+	if len(req.Hashes) > maxAnnouncedHashes {
+		return nil, fmt.Errorf("%w: %d > %d", errTooManyHashes, len(req.Hashes), maxAnnouncedHashes)
+	}
+
+	var (
+		resp = &proto.Txns{Raw: make([][]byte, 0, len(req.Hashes))}
+		size = 0
+	)
+
+	for _, raw := range req.Hashes {
+		tx, ok := s.pool.index.get(types.BytesToHash(raw))
+		if !ok {
+			continue
+		}
+
+		data := tx.MarshalRLP()
+		if size+len(data) > maxPulledTxsSize {
+			break
+		}
+
+		size += len(data)
+		resp.Raw = append(resp.Raw, data)
+	}
+
+	return resp, nil
+}
+
+func hashesToBytes(hashes []types.Hash) [][]byte {
+	res := make([][]byte, len(hashes))
+	for i, hash := range hashes {
+		res[i] = hash.Bytes()
+	}
+
+	return res
+}
@@ -0,0 +1,219 @@
+package txpool
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func Test_txAnnouncer_flush(t *testing.T) {
+	t.Parallel()
+
+	var announced [][][]byte
+
+	announcer := &txAnnouncer{publish: func(hashes *proto.TxnHashes) error {
+		announced = append(announced, hashes.Hashes)
+
+		return nil
+	}}
+
+	for i := 0; i < maxAnnouncedHashes+1; i++ {
+		announcer.enqueue(types.BytesToHash([]byte{byte(i), byte(i >> 8)}))
+	}
+
+	require.NoError(t, announcer.flush())
+	require.Len(t, announced, 2)
+	assert.Len(t, announced[0], maxAnnouncedHashes)
+	assert.Len(t, announced[1], 1)
+
+	// the queue is empty once flushed
+	require.NoError(t, announcer.flush())
+	require.Len(t, announced, 2)
+}
+
+func Test_txBatcher_flush(t *testing.T) {
+	t.Parallel()
+
+	var batches [][][]byte
+
+	batcher := &txBatcher{publish: func(txs *proto.Txns) error {
+		batches = append(batches, txs.Raw)
+
+		return nil
+	}}
+
+	for i := 0; i < maxBatchedTxs+1; i++ {
+		batcher.enqueue([]byte{byte(i)})
+	}
+
+	require.NoError(t, batcher.flush())
+	require.Len(t, batches, 2)
+	assert.Len(t, batches[0], maxBatchedTxs)
+	assert.Len(t, batches[1], 1)
+
+	// the batches are bounded by the size of the transactions
+	big := make([]byte, maxBatchedTxsSize/2+1)
+
+	batcher.enqueue(big)
+	batcher.enqueue(big)
+	batcher.enqueue([]byte{0x1})
+
+	require.NoError(t, batcher.flush())
+	require.Len(t, batches, 4)
+	assert.Len(t, batches[2], 1)
+	assert.Len(t, batches[3], 2)
+
+	// the queue is empty once flushed
+	require.NoError(t, batcher.flush())
+	require.Len(t, batches, 4)
+}
+
+func TestAddGossipBatch(t *testing.T) {
+	t.Parallel()
+
+	key, sender := tests.GenerateKeyAndAddr(t)
+	signer := crypto.NewEIP155Signer(100, true)
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+
+	pool.SetSigner(signer)
+	pool.SetSealing(true)
+
+	batch := &proto.Txns{}
+
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		tx, err := signer.SignTx(newTx(types.ZeroAddress, nonce, 1), key)
+		require.NoError(t, err)
+
+		batch.Raw = append(batch.Raw, tx.MarshalRLP())
+	}
+
+	// the malformed transactions are skipped
+	batch.Raw = append(batch.Raw, []byte{0x1})
+
+	pool.addGossipBatch(batch, "peer")
+
+	require.NotNil(t, pool.accounts.get(sender))
+	assert.Equal(t, uint64(3), pool.accounts.get(sender).enqueued.length())
+
+	// the oversized batches are dropped
+	pool.addGossipBatch(&proto.Txns{Raw: make([][]byte, maxBatchedTxs+1)}, "peer")
+}
+
+func TestAddGossipAnnouncement(t *testing.T) {
+	t.Parallel()
+
+	key, _ := tests.GenerateKeyAndAddr(t)
+	signer := crypto.NewEIP155Signer(100, true)
+
+	signTx := func(nonce uint64) *types.Transaction {
+		tx, err := signer.SignTx(newTx(types.ZeroAddress, nonce, 1), key)
+		require.NoError(t, err)
+
+		tx.ComputeHash(0)
+
+		return tx
+	}
+
+	announced, unrequested := signTx(0), signTx(1)
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+
+	pool.SetSigner(signer)
+	pool.SetSealing(true)
+
+	var (
+		lock     sync.Mutex
+		requests [][]types.Hash
+	)
+
+	pool.fetcher.request = func(_ peer.ID, hashes []types.Hash) ([][]byte, error) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		requests = append(requests, hashes)
+
+		return [][]byte{announced.MarshalRLP(), unrequested.MarshalRLP()}, nil
+	}
+
+	announcement := &proto.TxnHashes{Hashes: hashesToBytes([]types.Hash{announced.Hash})}
+
+	pool.addGossipAnnouncement(announcement, "peer")
+
+	require.Eventually(t, func() bool {
+		_, ok := pool.index.get(announced.Hash)
+
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// only the requested transaction is added
+	_, ok := pool.index.get(unrequested.Hash)
+	assert.False(t, ok)
+
+	// the known transaction isn't pulled again
+	pool.addGossipAnnouncement(announcement, "peer")
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	require.Len(t, requests, 1)
+	assert.Equal(t, []types.Hash{announced.Hash}, requests[0])
+}
+
+func TestAddGossipAnnouncement_Malformed(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+
+	pool.SetSealing(true)
+
+	pool.fetcher.request = func(peer.ID, []types.Hash) ([][]byte, error) {
+		t.Fatal("malformed announcement shouldn't be pulled")
+
+		return nil, nil
+	}
+
+	// invalid hash length
+	pool.addGossipAnnouncement(&proto.TxnHashes{Hashes: [][]byte{{0x1}}}, "peer")
+
+	// too many hashes
+	pool.addGossipAnnouncement(&proto.TxnHashes{Hashes: make([][]byte, maxAnnouncedHashes+1)}, "peer")
+}
+
+func Test_txPullService_GetTxns(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+
+	pool.SetSigner(&mockSigner{})
+
+	tx := newTx(addr1, 0, 1)
+	require.NoError(t, pool.addTx(local, tx))
+
+	service := &txPullService{pool: pool}
+
+	resp, err := service.GetTxns(context.Background(), &proto.TxnHashes{
+		Hashes: hashesToBytes([]types.Hash{types.StringToHash("unknown"), tx.Hash}),
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{tx.MarshalRLP()}, resp.Raw)
+
+	_, err = service.GetTxns(context.Background(), &proto.TxnHashes{
+		Hashes: make([][]byte, maxAnnouncedHashes+1),
+	})
+	require.ErrorIs(t, err, errTooManyHashes)
+}
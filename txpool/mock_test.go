@@ -1,9 +1,11 @@
 package txpool
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 
+	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -18,6 +20,7 @@ type defaultMockStore struct {
 
 	getBlockByHashFn   func(types.Hash, bool) (*types.Block, bool)
 	calculateBaseFeeFn func(*types.Header) uint64
+	subscription       *blockchain.MockSubscription
 	nonce              uint64
 }
 
@@ -46,6 +49,16 @@ func (m defaultMockStore) GetBlockByHash(hash types.Hash, full bool) (*types.Blo
 	return nil, false
 }
 
+func (m defaultMockStore) SubscribeEvents() blockchain.Subscription {
+	if m.subscription != nil {
+		return m.subscription
+	}
+
+	return blockchain.NewMockSubscription()
+}
+
+func (m defaultMockStore) UnsubscribeEvents(blockchain.Subscription) {}
+
 func (m defaultMockStore) GetBalance(types.Hash, types.Address) (*big.Int, error) {
 	balance := big.NewInt(0).SetUint64(100000000000000)
 
@@ -83,9 +96,35 @@ func (fms faultyMockStore) CalculateBaseFee(*types.Header) uint64 {
 	return 0
 }
 
+func (fms faultyMockStore) SubscribeEvents() blockchain.Subscription {
+	return blockchain.NewMockSubscription()
+}
+
+func (fms faultyMockStore) UnsubscribeEvents(blockchain.Subscription) {}
+
 type mockSigner struct {
 }
 
 func (s *mockSigner) Sender(tx *types.Transaction) (types.Address, error) {
 	return tx.From, nil
 }
+
+// mockPaymasters are the paymasters approving the gasless transactions, regardless of the state
+type mockPaymasters map[types.Address]struct{}
+
+func (m mockPaymasters) IsSponsored(_ *types.Header, tx *types.Transaction) bool {
+	_, ok := m[*tx.To]
+
+	return ok
+}
+
+// mockTxPolicy denies the transactions to the given addresses, regardless of the state root
+type mockTxPolicy map[types.Address]struct{}
+
+func (m mockTxPolicy) CheckTxPolicy(_ types.Hash, tx *types.Transaction) error {
+	if _, ok := m[*tx.To]; ok {
+		return errors.New("denied")
+	}
+
+	return nil
+}
@@ -24,8 +24,8 @@ func (p *TxPool) AddTxn(ctx context.Context, raw *proto.AddTxnReq) (*proto.AddTx
 		return nil, fmt.Errorf("transaction's field raw is empty")
 	}
 
-	txn := new(types.Transaction)
-	if err := txn.UnmarshalRLP(raw.Raw.Value); err != nil {
+	txn, err := decodeTx(raw.Raw.Value)
+	if err != nil {
 		return nil, err
 	}
 
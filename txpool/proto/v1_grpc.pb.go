@@ -0,0 +1,99 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion7
+
+// TxnPullClient is the client API for TxnPull service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TxnPullClient interface {
+	// Returns the transactions of the pool by their hashes
+	GetTxns(ctx context.Context, in *TxnHashes, opts ...grpc.CallOption) (*Txns, error)
+}
+
+type txnPullClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTxnPullClient(cc grpc.ClientConnInterface) TxnPullClient {
+	return &txnPullClient{cc}
+}
+
+func (c *txnPullClient) GetTxns(ctx context.Context, in *TxnHashes, opts ...grpc.CallOption) (*Txns, error) {
+	out := new(Txns)
+	err := c.cc.Invoke(ctx, "/v1.TxnPull/GetTxns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TxnPullServer is the server API for TxnPull service.
+// All implementations must embed UnimplementedTxnPullServer
+// for forward compatibility
+type TxnPullServer interface {
+	// Returns the transactions of the pool by their hashes
+	GetTxns(context.Context, *TxnHashes) (*Txns, error)
+	mustEmbedUnimplementedTxnPullServer()
+}
+
+// UnimplementedTxnPullServer must be embedded to have forward compatible implementations.
+type UnimplementedTxnPullServer struct {
+}
+
+func (UnimplementedTxnPullServer) GetTxns(context.Context, *TxnHashes) (*Txns, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTxns not implemented")
+}
+func (UnimplementedTxnPullServer) mustEmbedUnimplementedTxnPullServer() {}
+
+// UnsafeTxnPullServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TxnPullServer will
+// result in compilation errors.
+type UnsafeTxnPullServer interface {
+	mustEmbedUnimplementedTxnPullServer()
+}
+
+func RegisterTxnPullServer(s grpc.ServiceRegistrar, srv TxnPullServer) {
+	s.RegisterService(&_TxnPull_serviceDesc, srv)
+}
+
+func _TxnPull_GetTxns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxnHashes)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPullServer).GetTxns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPull/GetTxns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPullServer).GetTxns(ctx, req.(*TxnHashes))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _TxnPull_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "v1.TxnPull",
+	HandlerType: (*TxnPullServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTxns",
+			Handler:    _TxnPull_GetTxns_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "txpool/proto/v1.proto",
+}
@@ -0,0 +1,175 @@
+package txpool
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+)
+
+const (
+	// reputationHalfLife is the period after which a penalty score is halved
+	reputationHalfLife = 1 * time.Minute
+
+	// reputationThrottleThreshold is the penalty score above which a source gets throttled
+	reputationThrottleThreshold = 100
+
+	// maxReputationEntries is the number of tracked sources after which
+	// the sources with fully decayed scores are pruned
+	maxReputationEntries = 8192
+
+	// minReputationScore is the score below which a source is considered clean
+	minReputationScore = 1
+
+	// penalties per rejection reason
+	invalidTxPenalty     = 10
+	underpricedTxPenalty = 1
+)
+
+var (
+	ErrSenderThrottled = errors.New("sender is throttled due to repeated invalid transactions")
+)
+
+// rejectionPenalty returns the penalty score the peer gossiping the rejected transaction gets.
+// Malformed transactions are cheap to produce and expensive to verify, so they are punished harder
+// than the economically invalid ones. Rejections the source is not responsible for are not penalized
+func rejectionPenalty(err error) float64 {
+	switch {
+	case errors.Is(err, ErrExtractSignature),
+		errors.Is(err, ErrInvalidSender),
+		errors.Is(err, ErrOversizedData),
+		errors.Is(err, ErrNegativeValue),
+		errors.Is(err, ErrInvalidTxType),
+		errors.Is(err, ErrTipVeryHigh),
+		errors.Is(err, ErrFeeCapVeryHigh):
+		return invalidTxPenalty
+	case errors.Is(err, ErrUnderpriced),
+		errors.Is(err, ErrReplacementUnderpriced),
+		errors.Is(err, ErrTipAboveFeeCap),
+		errors.Is(err, ErrIntrinsicGas),
+		errors.Is(err, ErrInsufficientFunds),
+		errors.Is(err, ErrNonceTooLow),
+		errors.Is(err, ErrBlockLimitExceeded),
+		errors.Is(err, ErrTxTypeNotSupported),
+		errors.Is(err, runtime.ErrMaxCodeSizeExceeded):
+		return underpricedTxPenalty
+	default:
+		return 0
+	}
+}
+
+// senderPenalty returns the penalty score the sender of the rejected transaction gets.
+// Only the rejections of the signed contents of the transaction are charged to its sender:
+// anyone can replay a signed transaction once its nonce is used or the sender runs out of funds,
+// and the sender of the transaction failing the signature check is unknown
+func senderPenalty(err error) float64 {
+	switch {
+	case errors.Is(err, ErrTipVeryHigh),
+		errors.Is(err, ErrFeeCapVeryHigh),
+		errors.Is(err, ErrTipAboveFeeCap),
+		errors.Is(err, ErrIntrinsicGas),
+		errors.Is(err, runtime.ErrMaxCodeSizeExceeded):
+		return invalidTxPenalty
+	default:
+		return 0
+	}
+}
+
+// reputationScore is the penalty score of a single source at the time of the last update
+type reputationScore struct {
+	score     float64
+	updatedAt time.Time
+}
+
+// reputationTracker keeps exponentially decaying penalty scores of transaction sources
+// (senders or gossip peers) and tells which of them should be throttled
+type reputationTracker struct {
+	sync.Mutex
+
+	// name of the tracked source kind, used as a metrics label
+	name string
+
+	scores map[string]*reputationScore
+
+	// now returns the current time (overridden in tests)
+	now func() time.Time
+}
+
+func newReputationTracker(name string) *reputationTracker {
+	return &reputationTracker{
+		name:   name,
+		scores: make(map[string]*reputationScore),
+		now:    time.Now,
+	}
+}
+
+// decayedScore returns the score decayed to the given moment
+func decayedScore(s *reputationScore, now time.Time) float64 {
+	elapsed := now.Sub(s.updatedAt)
+	if elapsed <= 0 {
+		return s.score
+	}
+
+	return s.score * math.Pow(0.5, float64(elapsed)/float64(reputationHalfLife))
+}
+
+// penalize adds the penalty to the score of the given source
+func (r *reputationTracker) penalize(source string, penalty float64) {
+	if penalty <= 0 {
+		return
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	now := r.now()
+
+	s, ok := r.scores[source]
+	if !ok {
+		if len(r.scores) >= maxReputationEntries {
+			r.prune(now)
+		}
+
+		s = &reputationScore{updatedAt: now}
+		r.scores[source] = s
+	}
+
+	current := decayedScore(s, now)
+
+	s.score = current + penalty
+	s.updatedAt = now
+
+	metrics.IncrCounter([]string{txPoolMetrics, r.name + "_penalties"}, float32(penalty))
+
+	if current < reputationThrottleThreshold && s.score >= reputationThrottleThreshold {
+		metrics.IncrCounter([]string{txPoolMetrics, "throttled_" + r.name + "s"}, 1)
+	}
+}
+
+// isThrottled checks if the source reached the throttling threshold
+func (r *reputationTracker) isThrottled(source string) bool {
+	r.Lock()
+	defer r.Unlock()
+
+	s, ok := r.scores[source]
+	if !ok {
+		return false
+	}
+
+	return decayedScore(s, r.now()) >= reputationThrottleThreshold
+}
+
+// prune removes the sources whose scores have decayed enough. Must be called with the lock held
+func (r *reputationTracker) prune(now time.Time) {
+	for source, s := range r.scores {
+		if decayedScore(s, now) < minReputationScore {
+			delete(r.scores, source)
+		}
+	}
+
+	metrics.SetGauge([]string{txPoolMetrics, r.name + "_reputation_entries"}, float32(len(r.scores)))
+}
@@ -0,0 +1,144 @@
+package txpool
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
+)
+
+func TestReputationTracker_ThrottleAndDecay(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	tracker := newReputationTracker("sender")
+	tracker.now = func() time.Time { return now }
+
+	for i := 0; i < reputationThrottleThreshold/invalidTxPenalty-1; i++ {
+		tracker.penalize("spammer", invalidTxPenalty)
+	}
+
+	assert.False(t, tracker.isThrottled("spammer"))
+
+	tracker.penalize("spammer", invalidTxPenalty)
+	assert.True(t, tracker.isThrottled("spammer"))
+	assert.False(t, tracker.isThrottled("other"))
+
+	// after a half life the score is halved, and the sender is not throttled anymore
+	now = now.Add(reputationHalfLife)
+	assert.False(t, tracker.isThrottled("spammer"))
+}
+
+func TestReputationTracker_Prune(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	tracker := newReputationTracker("peer")
+	tracker.now = func() time.Time { return now }
+
+	for i := 0; i < maxReputationEntries; i++ {
+		tracker.penalize(fmt.Sprintf("source-%d", i), underpricedTxPenalty)
+	}
+
+	require.Len(t, tracker.scores, maxReputationEntries)
+
+	// scores decayed below the min score get pruned when a new source is added
+	now = now.Add(reputationHalfLife)
+	tracker.penalize("new", underpricedTxPenalty)
+
+	assert.Len(t, tracker.scores, 1)
+}
+
+func TestRejectionPenalty(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, float64(invalidTxPenalty), rejectionPenalty(ErrExtractSignature))
+	assert.Equal(t, float64(underpricedTxPenalty), rejectionPenalty(ErrUnderpriced))
+	assert.Equal(t, float64(underpricedTxPenalty), rejectionPenalty(fmt.Errorf("wrapped: %w", ErrNonceTooLow)))
+	assert.Zero(t, rejectionPenalty(ErrAlreadyKnown))
+	assert.Zero(t, rejectionPenalty(ErrTxPoolOverflow))
+	assert.Zero(t, rejectionPenalty(ErrSenderThrottled))
+}
+
+func TestSenderPenalty(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, float64(invalidTxPenalty), senderPenalty(ErrIntrinsicGas))
+	assert.Equal(t, float64(invalidTxPenalty), senderPenalty(ErrTipAboveFeeCap))
+
+	// the replayed transactions and the transactions of unknown senders are not charged to the sender
+	assert.Zero(t, senderPenalty(ErrNonceTooLow))
+	assert.Zero(t, senderPenalty(ErrInsufficientFunds))
+	assert.Zero(t, senderPenalty(ErrUnderpriced))
+	assert.Zero(t, senderPenalty(ErrExtractSignature))
+	assert.Zero(t, senderPenalty(ErrInvalidSender))
+}
+
+func TestAddTx_ThrottledSender(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	now := time.Now()
+	pool.senderReputation.now = func() time.Time { return now }
+
+	pool.getOrCreateAccount(addr1).setNonce(10)
+
+	// the replayed low nonce txs don't affect the sender
+	for i := 0; i < reputationThrottleThreshold/underpricedTxPenalty; i++ {
+		assert.ErrorIs(t, pool.addTx(local, newTx(addr1, 1, 1)), ErrNonceTooLow)
+	}
+
+	assert.False(t, pool.senderReputation.isThrottled(addr1.String()))
+
+	// keep sending txs without enough gas until the sender gets throttled
+	for i := 0; i < reputationThrottleThreshold/invalidTxPenalty; i++ {
+		tx := newTx(addr1, 10, 1)
+		tx.Gas = 1
+
+		assert.ErrorIs(t, pool.addTx(local, tx), ErrIntrinsicGas)
+	}
+
+	// even a valid transaction is rejected now
+	assert.ErrorIs(t, pool.addTx(local, newTx(addr1, 10, 1)), ErrSenderThrottled)
+
+	// other senders are not affected
+	go func() {
+		<-pool.promoteReqCh
+	}()
+
+	assert.NoError(t, pool.addTx(local, newTx(addr2, 0, 1)))
+}
+
+func TestAddGossipTx_ThrottledPeer(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+	pool.SetSealing(true)
+
+	now := time.Now()
+	pool.peerReputation.now = func() time.Time { return now }
+
+	peerID := peer.ID("spammer")
+	pool.peerReputation.penalize(peerID.String(), reputationThrottleThreshold)
+
+	pool.addGossipTx(&proto.Txn{
+		Raw: &any.Any{
+			Value: newTx(addr1, 0, 1).MarshalRLP(),
+		},
+	}, peerID)
+
+	// the transaction is dropped before it reaches the pool
+	assert.False(t, pool.accounts.exists(addr1))
+	assert.False(t, pool.peerReputation.isThrottled("honest"))
+}
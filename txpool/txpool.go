@@ -1,22 +1,26 @@
 package txpool
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/armon/go-metrics"
-	"github.com/golang/protobuf/ptypes/any"
 	"github.com/hashicorp/go-hclog"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/grpc"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/forkmanager"
+	"github.com/0xPolygon/polygon-edge/helper/tracing"
 	"github.com/0xPolygon/polygon-edge/network"
+	libp2pGrpc "github.com/0xPolygon/polygon-edge/network/grpc"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
@@ -35,8 +39,14 @@ const (
 	// maximum allowed number of consecutive blocks that don't have the account's transaction
 	maxAccountSkips = uint64(10)
 
+	// maximum allowed number of the account's transactions sponsored by the paymasters
+	maxAccountSponsored = 16
+
 	pruningCooldown = 5000 * time.Millisecond
 
+	// chainHeadTimeout is the max time the block building waits for the pool to be reset with the chain head
+	chainHeadTimeout = 2 * time.Second
+
 	// txPoolMetrics is a prefix used for txpool-related metrics
 	txPoolMetrics = "txpool"
 )
@@ -65,6 +75,16 @@ var (
 	ErrNonceExistsInPool       = errors.New("tx with the same nonce is already present")
 	ErrReplacementUnderpriced  = errors.New("replacement tx underpriced")
 	ErrDynamicTxNotAllowed     = errors.New("dynamic tx not allowed currently")
+	ErrTxPoolPaused            = errors.New("txpool is paused")
+
+	// ErrInsufficientPaymasterDeposit is returned when the paymaster can't cover the gas of the sponsored transaction
+	ErrInsufficientPaymasterDeposit = errors.New("insufficient paymaster deposit")
+
+	// ErrMaxSponsoredLimitReached is returned when the account has too many sponsored transactions in the pool
+	ErrMaxSponsoredLimitReached = errors.New("maximum number of sponsored transactions reached")
+
+	// ErrDeniedByTxPolicy is returned when the transaction is denied by the transaction policy
+	ErrDeniedByTxPolicy = errors.New("denied by the transaction policy")
 )
 
 // indicates origin of a transaction
@@ -93,17 +113,37 @@ type store interface {
 	GetBalance(root types.Hash, addr types.Address) (*big.Int, error)
 	GetBlockByHash(types.Hash, bool) (*types.Block, bool)
 	CalculateBaseFee(parent *types.Header) uint64
+	SubscribeEvents() blockchain.Subscription
+	UnsubscribeEvents(blockchain.Subscription)
 }
 
 type signer interface {
 	Sender(tx *types.Transaction) (types.Address, error)
 }
 
+// txPolicy checks whether the transaction is denied by the transaction policy
+type txPolicy interface {
+	CheckTxPolicy(root types.Hash, tx *types.Transaction) error
+}
+
+// paymasters tells whether the gasless transaction is approved by the allowlisted paymaster it calls
+type paymasters interface {
+	IsSponsored(header *types.Header, tx *types.Transaction) bool
+}
+
 type Config struct {
 	PriceLimit         uint64
 	MaxSlots           uint64
 	MaxAccountEnqueued uint64
 	ChainID            *big.Int
+
+	// UserOperationEntryPoints are ERC-4337 entry points user operations can be routed to.
+	// The user operations lane is disabled if empty.
+	UserOperationEntryPoints []types.Address
+
+	// GossipBatchInterval is the interval in which the local transactions are broadcast in batches.
+	// Every transaction is broadcast on its own if zero.
+	GossipBatchInterval time.Duration
 }
 
 /* All requests are passed to the main loop
@@ -160,13 +200,29 @@ type TxPool struct {
 	index lookupMap
 
 	// networking stack
-	topic *network.Topic
+	topic      *network.Topic
+	pullStream *libp2pGrpc.GrpcStream
+
+	// announcer announces the hashes of the local transactions too big to be broadcast in full,
+	// fetcher pulls the announced transactions and deduplicates the gossiped ones
+	announcer *txAnnouncer
+	fetcher   *txFetcher
+
+	// batcher batches the broadcasts of the local transactions in the batchInterval, nil if they aren't batched
+	batcher       *txBatcher
+	batchInterval time.Duration
 
 	// gauge for measuring pool capacity
 	gauge slotGauge
 
-	// priceLimit is a lower threshold for gas price
-	priceLimit uint64
+	// priceLimit is a lower threshold for gas price, updated on the config reload
+	priceLimit atomic.Uint64
+
+	// paymasters admits the gasless transactions sponsored by the allowlisted paymasters, nil if disabled
+	paymasters paymasters
+
+	// txPolicy rejects the transactions denied by the transaction policy, nil if disabled
+	txPolicy txPolicy
 
 	// channels on which the pool's event loop
 	// does dispatching/handling requests.
@@ -180,6 +236,9 @@ type TxPool struct {
 	// and should therefore gossip transactions
 	sealing atomic.Bool
 
+	// paused rejects the new transactions, while the ones in the pool are still sealed
+	paused atomic.Bool
+
 	// baseFee is the base fee of the current head.
 	// This is needed to sort transactions by price
 	baseFee uint64
@@ -196,6 +255,22 @@ type TxPool struct {
 
 	// chain id
 	chainID *big.Int
+
+	// user operations (ERC-4337) lane
+	userOperations *userOperationPool
+
+	// penalty scores of the senders and gossip peers submitting invalid transactions
+	senderReputation *reputationTracker
+	peerReputation   *reputationTracker
+
+	// chainSubscription delivers the chain events the pool is reset with
+	chainSubscription blockchain.Subscription
+
+	// chainHead is the number of the latest chain head the pool is reset with,
+	// chainHeadCh is closed and replaced whenever it changes
+	chainHeadLock sync.Mutex
+	chainHead     uint64
+	chainHeadCh   chan struct{}
 }
 
 // NewTxPool returns a new pool for processing incoming transactions.
@@ -215,30 +290,32 @@ func NewTxPool(
 		accounts:    accountsMap{maxEnqueuedLimit: config.MaxAccountEnqueued},
 		index:       lookupMap{all: make(map[types.Hash]*types.Transaction)},
 		gauge:       slotGauge{height: 0, max: config.MaxSlots},
-		priceLimit:  config.PriceLimit,
 		chainID:     config.ChainID,
 
+		userOperations: newUserOperationPool(config.UserOperationEntryPoints),
+
+		senderReputation: newReputationTracker("sender"),
+		peerReputation:   newReputationTracker("peer"),
+
+		fetcher: newTxFetcher(),
+
 		//	main loop channels
 		promoteReqCh: make(chan promoteRequest),
 		pruneCh:      make(chan struct{}),
 		shutdownCh:   make(chan struct{}),
+		chainHeadCh:  make(chan struct{}),
 	}
 
+	pool.priceLimit.Store(config.PriceLimit)
+
 	// Attach the event manager
 	pool.eventManager = newEventManager(pool.logger)
 
 	if network != nil {
 		// subscribe to the gossip protocol
-		topic, err := network.NewTopic(topicNameV1, &proto.Txn{})
-		if err != nil {
+		if err := pool.setupGossip(network, config.GossipBatchInterval); err != nil {
 			return nil, err
 		}
-
-		if subscribeErr := topic.Subscribe(pool.addGossipTx); subscribeErr != nil {
-			return nil, fmt.Errorf("unable to subscribe to gossip topic, %w", subscribeErr)
-		}
-
-		pool.topic = topic
 	}
 
 	if grpcServer != nil {
@@ -260,6 +337,12 @@ func (p *TxPool) Start() {
 	// set default value of txpool pending transactions gauge
 	p.updatePending(0)
 
+	// reset the pool with the blocks written to the chain
+	p.chainSubscription = p.store.SubscribeEvents()
+	p.setChainHead(p.store.Header().Number)
+
+	go p.handleChainEvents()
+
 	//	run the handler for high gauge level pruning
 	go func() {
 		for {
@@ -276,6 +359,20 @@ func (p *TxPool) Start() {
 		}
 	}()
 
+	// announce the local transactions too big to be broadcast in full
+	if p.announcer != nil {
+		go flushPeriodically(announceInterval, p.shutdownCh, p.announcer.flush, func(err error) {
+			p.logger.Error("failed to announce txs", "err", err)
+		})
+	}
+
+	// broadcast the batched local transactions
+	if p.batcher != nil {
+		go flushPeriodically(p.batchInterval, p.shutdownCh, p.batcher.flush, func(err error) {
+			p.logger.Error("failed to broadcast tx batch", "err", err)
+		})
+	}
+
 	//	run the handler for the tx pipeline
 	go func() {
 		for {
@@ -293,6 +390,16 @@ func (p *TxPool) Start() {
 func (p *TxPool) Close() {
 	p.eventManager.Close()
 	close(p.shutdownCh)
+
+	if p.chainSubscription != nil {
+		p.store.UnsubscribeEvents(p.chainSubscription)
+	}
+
+	if p.pullStream != nil {
+		if err := p.pullStream.Close(); err != nil {
+			p.logger.Error("failed to close txpool pull stream", "err", err)
+		}
+	}
 }
 
 // SetSigner sets the signer the pool will use
@@ -301,11 +408,64 @@ func (p *TxPool) SetSigner(s signer) {
 	p.signer = s
 }
 
+// SetPaymasters enables the admission of the gasless transactions sponsored by the paymasters
+func (p *TxPool) SetPaymasters(paymasters paymasters) {
+	p.paymasters = paymasters
+}
+
+// SetTxPolicy enables the rejection of the transactions denied by the transaction policy
+func (p *TxPool) SetTxPolicy(txPolicy txPolicy) {
+	p.txPolicy = txPolicy
+}
+
+// SetPriceLimit sets the lower threshold for gas price of the transactions added from now on
+func (p *TxPool) SetPriceLimit(priceLimit uint64) {
+	p.priceLimit.Store(priceLimit)
+}
+
 // SetSealing sets the sealing flag
 func (p *TxPool) SetSealing(sealing bool) {
 	p.sealing.CompareAndSwap(p.sealing.Load(), sealing)
 }
 
+// SetPaused pauses or resumes accepting the new transactions, both local and gossiped
+func (p *TxPool) SetPaused(paused bool) {
+	p.paused.Store(paused)
+}
+
+// Paused returns whether the pool rejects the new transactions
+func (p *TxPool) Paused() bool {
+	return p.paused.Load()
+}
+
+// Flush drops all the transactions of the pool and resets the next nonces of the accounts to their state nonces.
+// It returns the number of the dropped transactions
+func (p *TxPool) Flush() uint64 {
+	var (
+		stateRoot = p.store.Header().StateRoot
+		dropped   uint64
+	)
+
+	p.accounts.Range(
+		func(_, value interface{}) bool {
+			account, _ := value.(*account)
+
+			firstTx := account.getLowestTx()
+			if firstTx == nil {
+				return true
+			}
+
+			dropped += p.dropAccount(account, p.store.GetNonce(stateRoot, firstTx.From), firstTx)
+
+			return true
+		},
+	)
+
+	p.logger.Info("flushed the pool", "dropped", dropped)
+
+	return dropped
+}
+
 // AddTx adds a new transaction to the pool (sent from json-RPC/gRPC endpoints)
 // and broadcasts it to the network (if enabled).
 func (p *TxPool) AddTx(tx *types.Transaction) error {
@@ -315,18 +475,12 @@ func (p *TxPool) AddTx(tx *types.Transaction) error {
 		return err
 	}
 
+	p.fetcher.markKnown(tx.Hash)
+
 	// broadcast the transaction only if a topic
 	// subscription is present
 	if p.topic != nil {
-		tx := &proto.Txn{
-			Raw: &any.Any{
-				Value: tx.MarshalRLP(),
-			},
-		}
-
-		if err := p.topic.Publish(tx); err != nil {
-			p.logger.Error("failed to topic tx", "err", err)
-		}
+		p.broadcastTx(tx)
 	}
 
 	return nil
@@ -335,6 +489,9 @@ func (p *TxPool) AddTx(tx *types.Transaction) error {
 // Prepare generates all the transactions
 // ready for execution. (primaries)
 func (p *TxPool) Prepare() {
+	// make sure the transactions of the latest block are not selected again
+	p.waitForChainHead()
+
 	// fetch primary from each account
 	primaries := p.accounts.getPrimaries()
 
@@ -400,8 +557,8 @@ func (p *TxPool) Drop(tx *types.Transaction) {
 
 // dropAccount clears all promoted and enqueued tx from the account
 // signals EventType_DROPPED for provided hash, clears all the slots and metrics
-// and sets nonce to provided nonce
-func (p *TxPool) dropAccount(account *account, nextNonce uint64, tx *types.Transaction) {
+// and sets nonce to provided nonce. It returns the number of the dropped transactions
+func (p *TxPool) dropAccount(account *account, nextNonce uint64, tx *types.Transaction) uint64 {
 	account.promoted.lock(true)
 	account.enqueued.lock(true)
 	account.nonceToTx.lock()
@@ -413,7 +570,7 @@ func (p *TxPool) dropAccount(account *account, nextNonce uint64, tx *types.Trans
 	}()
 
 	// num of all txs dropped
-	droppedCount := 0
+	var droppedCount uint64
 
 	// pool resource cleanup
 	clearAccountQueue := func(txs []*types.Transaction) {
@@ -421,7 +578,7 @@ func (p *TxPool) dropAccount(account *account, nextNonce uint64, tx *types.Trans
 		p.gauge.decrease(slotsRequired(txs...))
 
 		// increase counter
-		droppedCount += len(txs)
+		droppedCount += uint64(len(txs))
 	}
 
 	// rollback nonce
@@ -450,6 +607,8 @@ func (p *TxPool) dropAccount(account *account, nextNonce uint64, tx *types.Trans
 			"address", tx.From.String(),
 		)
 	}
+
+	return droppedCount
 }
 
 // Demote excludes an account from being further processed during block building
@@ -478,6 +637,69 @@ func (p *TxPool) Demote(tx *types.Transaction) {
 	p.eventManager.signalEvent(proto.EventType_DEMOTED, tx.Hash)
 }
 
+// handleChainEvents resets the pool with the canonical blocks in the order they are written to the chain,
+// either by the consensus or by the syncer
+func (p *TxPool) handleChainEvents() {
+	eventCh := p.chainSubscription.GetEventCh()
+
+	for {
+		select {
+		case <-p.shutdownCh:
+			return
+		case event := <-eventCh:
+			// the fork doesn't change the canonical chain
+			if event.Type == blockchain.EventFork || len(event.NewChain) == 0 {
+				continue
+			}
+
+			p.processEvent(event)
+			p.setChainHead(event.Header().Number)
+		}
+	}
+}
+
+// setChainHead sets the number of the latest chain head the pool is reset with
+func (p *TxPool) setChainHead(number uint64) {
+	p.chainHeadLock.Lock()
+	defer p.chainHeadLock.Unlock()
+
+	p.chainHead = number
+
+	close(p.chainHeadCh)
+	p.chainHeadCh = make(chan struct{})
+}
+
+// waitForChainHead waits until the pool is reset with the current chain head,
+// since the chain events are handled in the background
+func (p *TxPool) waitForChainHead() {
+	if p.chainSubscription == nil {
+		return
+	}
+
+	head := p.store.Header().Number
+	timeoutCh := time.After(chainHeadTimeout)
+
+	for {
+		p.chainHeadLock.Lock()
+		chainHead, chainHeadCh := p.chainHead, p.chainHeadCh
+		p.chainHeadLock.Unlock()
+
+		if chainHead >= head {
+			return
+		}
+
+		select {
+		case <-chainHeadCh:
+		case <-timeoutCh:
+			p.logger.Warn("pool is not reset with the chain head in time", "head", head, "pool", chainHead)
+
+			return
+		case <-p.shutdownCh:
+			return
+		}
+	}
+}
+
 // ResetWithHeaders processes the transactions from the new
 // headers to sync the pool with the new state.
 func (p *TxPool) ResetWithHeaders(headers ...*types.Header) {
@@ -511,6 +733,9 @@ func (p *TxPool) processEvent(event *blockchain.Event) {
 		for _, tx := range block.Transactions {
 			var err error
 
+			// remove user operations included by the mined handleOps bundles
+			p.pruneBundledUserOperations(tx)
+
 			addr := tx.From
 			if addr == types.ZeroAddress {
 				// From field is not set, extract the signer
@@ -599,6 +824,13 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		tx.From = from
 	}
 
+	// Reject transactions of the senders which keep sending invalid transactions
+	if p.senderReputation.isThrottled(from.String()) {
+		metrics.IncrCounter([]string{txPoolMetrics, "throttled_sender_txs"}, 1)
+
+		return ErrSenderThrottled
+	}
+
 	// Grab current block number
 	currentHeader := p.store.Header()
 	currentBlockNumber := currentHeader.Number
@@ -607,7 +839,8 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 	forks := p.forks.At(currentBlockNumber)
 
 	// Check if transaction can deploy smart contract
-	if tx.IsContractCreation() && forks.EIP158 && len(tx.Input) > state.TxPoolMaxInitCodeSize {
+	if tx.IsContractCreation() && forks.EIP158 &&
+		uint64(len(tx.Input)) > p.forks.CodeSizeLimitsAt(currentBlockNumber).MaxInitCodeSize {
 		metrics.IncrCounter([]string{txPoolMetrics, "contract_deploy_too_large_txs"}, 1)
 
 		return runtime.ErrMaxCodeSizeExceeded
@@ -618,6 +851,9 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 	latestBlockGasLimit := currentHeader.GasLimit
 	baseFee := p.GetBaseFee() // base fee is calculated for the next block
 
+	// the gasless transactions sponsored by the paymasters are admitted with zero gas price
+	sponsored := p.paymasters != nil && state.IsGasless(tx) && p.paymasters.IsSponsored(currentHeader, tx)
+
 	if tx.Type == types.DynamicFeeTx {
 		// Reject dynamic fee tx if london hardfork is not enabled
 		if !forks.London {
@@ -660,14 +896,14 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		}
 
 		// Reject underpriced transactions
-		if tx.GasFeeCap.Cmp(new(big.Int).SetUint64(baseFee)) < 0 {
+		if !sponsored && tx.GasFeeCap.Cmp(new(big.Int).SetUint64(baseFee)) < 0 {
 			metrics.IncrCounter([]string{txPoolMetrics, "underpriced_tx"}, 1)
 
 			return ErrUnderpriced
 		}
 	} else {
 		// Legacy approach to check if the given tx is not underpriced when london hardfork is enabled
-		if forks.London && tx.GasPrice.Cmp(new(big.Int).SetUint64(baseFee)) < 0 {
+		if !sponsored && forks.London && tx.GasPrice.Cmp(new(big.Int).SetUint64(baseFee)) < 0 {
 			metrics.IncrCounter([]string{txPoolMetrics, "underpriced_tx"}, 1)
 
 			return ErrUnderpriced
@@ -675,7 +911,7 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 	}
 
 	// Check if the given tx is not underpriced
-	if tx.GetGasPrice(baseFee).Cmp(new(big.Int).SetUint64(p.priceLimit)) < 0 {
+	if !sponsored && tx.GetGasPrice(baseFee).Cmp(new(big.Int).SetUint64(p.priceLimit.Load())) < 0 {
 		metrics.IncrCounter([]string{txPoolMetrics, "underpriced_tx"}, 1)
 
 		return ErrUnderpriced
@@ -702,6 +938,22 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		return ErrInsufficientFunds
 	}
 
+	// Check if the paymaster has enough deposit to sponsor the transaction at the base fee
+	if sponsored {
+		deposit, err := p.store.GetBalance(stateRoot, *tx.To)
+		if err != nil {
+			metrics.IncrCounter([]string{txPoolMetrics, "invalid_account_state_tx"}, 1)
+
+			return ErrInvalidAccountState
+		}
+
+		if deposit.Cmp(new(big.Int).Mul(new(big.Int).SetUint64(tx.Gas), new(big.Int).SetUint64(baseFee))) < 0 {
+			metrics.IncrCounter([]string{txPoolMetrics, "insufficient_paymaster_deposit_tx"}, 1)
+
+			return ErrInsufficientPaymasterDeposit
+		}
+	}
+
 	// Make sure the transaction has more gas than the basic transaction fee
 	intrinsicGas, err := state.TransactionGasCost(tx, forks.Homestead, forks.Istanbul)
 	if err != nil {
@@ -722,6 +974,17 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		return ErrBlockLimitExceeded
 	}
 
+	// Reject the transactions denied by the transaction policy
+	if p.txPolicy != nil {
+		if err := p.txPolicy.CheckTxPolicy(stateRoot, tx); err != nil {
+			metrics.IncrCounter([]string{txPoolMetrics, "denied_by_tx_policy_tx"}, 1)
+
+			p.logger.Debug("transaction denied by the transaction policy", "hash", tx.Hash, "err", err)
+
+			return fmt.Errorf("%w: %w", ErrDeniedByTxPolicy, err)
+		}
+	}
+
 	return nil
 }
 
@@ -768,11 +1031,28 @@ func (p *TxPool) pruneAccountsWithNonceHoles() {
 // for all new transactions. If the call is
 // successful, an account is created for this address
 // (only once) and an enqueueRequest is signaled.
-func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
+func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) (err error) {
 	if p.logger.IsDebug() {
 		p.logger.Debug("add tx", "origin", origin.String(), "hash", tx.Hash.String())
 	}
 
+	_, span := tracing.Start(context.Background(), "txpool.add_tx",
+		attribute.String("tx.origin", origin.String()))
+
+	// penalize the sender for the rejected transaction it has signed
+	defer func() {
+		span.SetAttributes(attribute.String("tx.hash", tx.Hash.String()))
+		tracing.EndSpan(span, err)
+
+		if err != nil && tx.From != types.ZeroAddress {
+			p.senderReputation.penalize(tx.From.String(), senderPenalty(err))
+		}
+	}()
+
+	if p.paused.Load() {
+		return ErrTxPoolPaused
+	}
+
 	// validate incoming tx
 	if err := p.validateTx(tx); err != nil {
 		return err
@@ -831,6 +1111,13 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 			return ErrMaxEnqueuedLimitReached
 		}
 
+		// the sponsored transactions are paid by the paymasters, so the senders can't flood the pool with them
+		if state.IsGasless(tx) && account.nonceToTx.countGasless() >= maxAccountSponsored {
+			metrics.IncrCounter([]string{txPoolMetrics, "sponsored_limit_reached_tx"}, 1)
+
+			return ErrMaxSponsoredLimitReached
+		}
+
 		// reject low nonce tx
 		if tx.Nonce < accountNonce {
 			metrics.IncrCounter([]string{txPoolMetrics, "nonce_too_low_tx"}, 1)
@@ -923,11 +1210,18 @@ func (p *TxPool) handlePromoteRequest(req promoteRequest) {
 
 // addGossipTx handles receiving transactions
 // gossiped by the network.
-func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
+func (p *TxPool) addGossipTx(obj interface{}, peerID peer.ID) {
 	if !p.sealing.Load() {
 		return
 	}
 
+	// drop transactions gossiped by the peers which keep relaying invalid transactions
+	if p.peerReputation.isThrottled(peerID.String()) {
+		metrics.IncrCounter([]string{txPoolMetrics, "throttled_peer_txs"}, 1)
+
+		return
+	}
+
 	raw, ok := obj.(*proto.Txn)
 	if !ok {
 		p.logger.Error("failed to cast gossiped message to txn")
@@ -942,17 +1236,37 @@ func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
 		return
 	}
 
-	tx := new(types.Transaction)
-
 	// decode tx
-	if err := tx.UnmarshalRLP(raw.Raw.Value); err != nil {
+	tx, err := decodeTx(raw.Raw.Value)
+	if err != nil {
 		p.logger.Error("failed to decode broadcast tx", "err", err)
 
 		return
 	}
 
+	tx.ComputeHash(p.store.Header().Number)
+
+	p.addGossipedTx(tx, peerID)
+}
+
+// addGossipedTx adds the transaction broadcast or pulled from the peer,
+// the recently seen transactions are dropped without being validated again
+func (p *TxPool) addGossipedTx(tx *types.Transaction, peerID peer.ID) {
+	// the paused pool ignores the gossip, without marking the transactions as seen
+	if p.paused.Load() {
+		return
+	}
+
+	if !p.fetcher.markKnown(tx.Hash) {
+		metrics.IncrCounter([]string{txPoolMetrics, "duplicate_gossip_txs"}, 1)
+
+		return
+	}
+
 	// add tx
 	if err := p.addTx(gossip, tx); err != nil {
+		p.peerReputation.penalize(peerID.String(), rejectionPenalty(err))
+
 		if errors.Is(err, ErrAlreadyKnown) {
 			if p.logger.IsDebug() {
 				p.logger.Debug("rejecting known tx (gossip)", "hash", tx.Hash.String())
@@ -1081,6 +1395,21 @@ func (p *TxPool) Length() uint64 {
 }
 
 // toHash returns the hash(es) of given transaction(s)
+// decodeTx decodes the raw transaction received from the outside of the node,
+// rejecting the oversized one before it is parsed
+func decodeTx(raw []byte) (*types.Transaction, error) {
+	if uint64(len(raw)) > txMaxSize {
+		return nil, ErrOversizedData
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalRLP(raw); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
 func toHash(txs ...*types.Transaction) (hashes []types.Hash) {
 	for _, tx := range txs {
 		hashes = append(hashes, tx.Hash)
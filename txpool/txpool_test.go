@@ -17,6 +17,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/helper/tests"
@@ -235,7 +236,7 @@ func TestAddTxErrors(t *testing.T) {
 	t.Run("ErrUnderpriced", func(t *testing.T) {
 		t.Parallel()
 		pool := setupPool()
-		pool.priceLimit = 1000000
+		pool.priceLimit.Store(1000000)
 
 		tx := newTx(defaultAddr, 0, 1) // gasPrice == 1
 		tx = signTx(tx)
@@ -246,6 +247,88 @@ func TestAddTxErrors(t *testing.T) {
 		)
 	})
 
+	t.Run("gasless tx sponsored by the paymaster", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool()
+		pool.baseFee = 100
+
+		paymaster := types.StringToAddress("0x1000")
+
+		tx := newTx(defaultAddr, 0, 1)
+		tx.To = &paymaster
+		tx.GasPrice = big.NewInt(0)
+		tx = signTx(tx)
+
+		// the gasless transactions are underpriced unless the paymasters are enabled
+		assert.ErrorIs(t, pool.addTx(local, tx), ErrUnderpriced)
+
+		pool.SetPaymasters(mockPaymasters{paymaster: {}})
+
+		other := newTx(defaultAddr, 0, 1)
+		other.To = &addr1
+		other.GasPrice = big.NewInt(0)
+
+		assert.ErrorIs(t, pool.addTx(local, signTx(other)), ErrUnderpriced)
+		assert.NoError(t, pool.addTx(local, tx))
+	})
+
+	t.Run("ErrMaxSponsoredLimitReached", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool()
+
+		paymaster := types.StringToAddress("0x1000")
+		pool.SetPaymasters(mockPaymasters{paymaster: {}})
+
+		sponsoredTx := func(nonce uint64) *types.Transaction {
+			tx := newTx(defaultAddr, nonce, 1)
+			tx.To = &paymaster
+			tx.GasPrice = big.NewInt(0)
+
+			return signTx(tx)
+		}
+
+		for nonce := uint64(0); nonce < maxAccountSponsored; nonce++ {
+			assert.NoError(t, pool.addTx(local, sponsoredTx(nonce)))
+		}
+
+		assert.ErrorIs(t, pool.addTx(local, sponsoredTx(maxAccountSponsored)), ErrMaxSponsoredLimitReached)
+
+		// the sender still pays for its own transactions
+		assert.NoError(t, pool.addTx(local, signTx(newTx(defaultAddr, maxAccountSponsored, 1))))
+	})
+
+	t.Run("ErrInsufficientPaymasterDeposit", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool()
+		pool.baseFee = 100000000
+
+		paymaster := types.StringToAddress("0x1000")
+		pool.SetPaymasters(mockPaymasters{paymaster: {}})
+
+		// the paymaster can't cover the gas limit at the base fee
+		tx := newTx(defaultAddr, 0, 1)
+		tx.To = &paymaster
+		tx.GasPrice = big.NewInt(0)
+
+		assert.ErrorIs(t, pool.addTx(local, signTx(tx)), ErrInsufficientPaymasterDeposit)
+	})
+
+	t.Run("ErrDeniedByTxPolicy", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool()
+		pool.SetTxPolicy(mockTxPolicy{addr1: {}})
+
+		tx := newTx(defaultAddr, 0, 1)
+		tx.To = &addr1
+
+		assert.ErrorIs(t, pool.addTx(local, signTx(tx)), ErrDeniedByTxPolicy)
+
+		tx = newTx(defaultAddr, 0, 1)
+		tx.To = &addr2
+
+		assert.NoError(t, pool.addTx(local, signTx(tx)))
+	})
+
 	t.Run("ErrInvalidAccountState", func(t *testing.T) {
 		t.Parallel()
 		pool := setupPool()
@@ -635,6 +718,33 @@ func TestAddGossipTx(t *testing.T) {
 
 		assert.Equal(t, uint64(0), pool.accounts.get(sender).enqueued.length())
 	})
+
+	t.Run("oversized tx is not decoded", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+		pool.SetSigner(signer)
+
+		pool.SetSealing(true)
+
+		oversizedTx := tx.Copy()
+		oversizedTx.Input = make([]byte, txMaxSize)
+
+		signedTx, err := signer.SignTx(oversizedTx, key)
+		if err != nil {
+			t.Fatalf("cannot sign transaction - err: %v", err)
+		}
+
+		raw := signedTx.MarshalRLP()
+
+		_, err = decodeTx(raw)
+		assert.ErrorIs(t, err, ErrOversizedData)
+
+		pool.addGossipTx(&proto.Txn{Raw: &any.Any{Value: raw}}, "")
+
+		assert.Nil(t, pool.accounts.get(sender))
+	})
 }
 
 func TestDropKnownGossipTx(t *testing.T) {
@@ -1873,6 +1983,32 @@ func TestDrop(t *testing.T) {
 	assert.Equal(t, (*types.Transaction)(nil), acc.nonceToTx.get(tx1.Nonce))
 }
 
+func TestPauseAndFlush(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	// one promoted and one enqueued transaction
+	require.NoError(t, pool.addTx(local, newTx(addr1, 0, 1)))
+	pool.handlePromoteRequest(<-pool.promoteReqCh)
+	require.NoError(t, pool.addTx(local, newTx(addr2, 1, 1)))
+
+	pool.SetPaused(true)
+	require.True(t, pool.Paused())
+	require.ErrorIs(t, pool.addTx(local, newTx(addr1, 1, 1)), ErrTxPoolPaused)
+
+	require.Equal(t, uint64(2), pool.Flush())
+	require.Equal(t, uint64(0), pool.gauge.read())
+	require.Equal(t, uint64(0), pool.Length())
+	require.Equal(t, uint64(0), pool.accounts.get(addr1).getNonce())
+	require.Equal(t, uint64(0), pool.accounts.get(addr2).enqueued.length())
+
+	pool.SetPaused(false)
+	require.NoError(t, pool.addTx(local, newTx(addr1, 0, 1)))
+}
+
 func TestDemote(t *testing.T) {
 	t.Parallel()
 
@@ -3615,6 +3751,45 @@ func TestResetWithHeadersSetsBaseFee(t *testing.T) {
 	assert.Equal(t, blocks[len(blocks)-1].Header.BaseFee, pool.GetBaseFee())
 }
 
+func TestChainEvents_ResetPool(t *testing.T) {
+	t.Parallel()
+
+	header := &types.Header{Number: 0, BaseFee: 100}
+	block := &types.Block{Header: &types.Header{Number: 1, BaseFee: 200, Hash: types.Hash{1}}}
+
+	store := NewDefaultMockStore(header)
+	store.subscription = blockchain.NewMockSubscription()
+	store.getBlockByHashFn = func(h types.Hash, _ bool) (*types.Block, bool) {
+		return block, h == block.Hash()
+	}
+
+	pool, err := newTestPool(store)
+	require.NoError(t, err)
+
+	pool.SetSigner(&mockSigner{})
+	pool.Start()
+
+	t.Cleanup(pool.Close)
+
+	// the forks are ignored
+	store.subscription.Push(&blockchain.Event{Type: blockchain.EventFork, NewChain: []*types.Header{block.Header}})
+
+	// the block is written to the chain before its event is delivered
+	*header = *block.Header
+
+	go store.subscription.Push(&blockchain.Event{Type: blockchain.EventHead, NewChain: []*types.Header{block.Header}})
+
+	// the block building waits for the pool to be reset with the chain head
+	pool.Prepare()
+
+	assert.Equal(t, block.Header.BaseFee, pool.GetBaseFee())
+
+	pool.chainHeadLock.Lock()
+	defer pool.chainHeadLock.Unlock()
+
+	assert.Equal(t, uint64(1), pool.chainHead)
+}
+
 func TestAddTx_TxReplacement(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,350 @@
+package txpool
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/armon/go-metrics"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// maxUserOperationsPerSender is the maximum number of user operations
+	// a single sender (smart contract wallet) can have in the pool at once
+	maxUserOperationsPerSender = 4
+
+	// maxUserOperations is the maximum number of user operations in the pool,
+	// once it is full the cheapest operations are evicted by the better paying ones
+	maxUserOperations = 4096
+
+	// userOperationReplacementBump is the minimal fee increase (in percents)
+	// required for replacing a user operation with the same sender and nonce
+	userOperationReplacementBump = 10
+)
+
+var (
+	ErrUserOperationsDisabled   = errors.New("user operations are not enabled")
+	ErrUnsupportedEntryPoint    = errors.New("unsupported entry point")
+	ErrInvalidUserOperation     = errors.New("invalid user operation")
+	ErrMaxUserOperationsReached = errors.New("maximum number of user operations per sender reached")
+	ErrUserOperationPoolFull    = errors.New("user operations pool is full")
+	ErrInsufficientPrefund      = errors.New("insufficient funds for the user operation prefund")
+)
+
+// userOperation is a user operation accepted into the pool,
+// bound to the entry point it should be executed through
+type userOperation struct {
+	op         *types.UserOperation
+	entryPoint types.Address
+	hash       types.Hash
+}
+
+// userOperationKey uniquely identifies a user operation slot (sender + nonce) for an entry point
+type userOperationKey struct {
+	entryPoint types.Address
+	sender     types.Address
+	nonce      string
+}
+
+func newUserOperationKey(entryPoint types.Address, op *types.UserOperation) userOperationKey {
+	return userOperationKey{entryPoint: entryPoint, sender: op.Sender, nonce: op.Nonce.String()}
+}
+
+// userOperationPool is an alternative mempool lane which keeps ERC-4337 user operations
+// until a colocated bundler includes them into a handleOps bundle
+type userOperationPool struct {
+	sync.RWMutex
+
+	entryPoints map[types.Address]struct{}
+	all         map[types.Hash]*userOperation
+	bySlot      map[userOperationKey]*userOperation
+	perSender   map[types.Address]int
+
+	// capacity is the maximum number of user operations in the pool
+	capacity int
+}
+
+func newUserOperationPool(entryPoints []types.Address) *userOperationPool {
+	pool := &userOperationPool{
+		entryPoints: make(map[types.Address]struct{}, len(entryPoints)),
+		all:         make(map[types.Hash]*userOperation),
+		bySlot:      make(map[userOperationKey]*userOperation),
+		perSender:   make(map[types.Address]int),
+		capacity:    maxUserOperations,
+	}
+
+	for _, entryPoint := range entryPoints {
+		pool.entryPoints[entryPoint] = struct{}{}
+	}
+
+	return pool
+}
+
+// isEntryPoint checks if the given address is one of the configured entry points
+func (p *userOperationPool) isEntryPoint(addr types.Address) bool {
+	_, ok := p.entryPoints[addr]
+
+	return ok
+}
+
+// add inserts the user operation into the pool, replacing the existing one
+// with the same sender and nonce if the fee bump is sufficient
+func (p *userOperationPool) add(uop *userOperation) error {
+	p.Lock()
+	defer p.Unlock()
+
+	if _, exists := p.all[uop.hash]; exists {
+		return ErrAlreadyKnown
+	}
+
+	key := newUserOperationKey(uop.entryPoint, uop.op)
+
+	if old, exists := p.bySlot[key]; exists {
+		if !isUserOperationReplacement(old.op, uop.op) {
+			return ErrReplacementUnderpriced
+		}
+
+		delete(p.all, old.hash)
+	} else {
+		if p.perSender[uop.op.Sender] >= maxUserOperationsPerSender {
+			return ErrMaxUserOperationsReached
+		}
+
+		if len(p.all) >= p.capacity {
+			cheapest := p.cheapest()
+			if !isCheaperUserOperation(cheapest.op, uop.op) {
+				return ErrUserOperationPoolFull
+			}
+
+			p.removeLocked(cheapest)
+
+			metrics.IncrCounter([]string{txPoolMetrics, "evicted_user_operations"}, 1)
+		}
+
+		p.perSender[uop.op.Sender]++
+	}
+
+	p.all[uop.hash] = uop
+	p.bySlot[key] = uop
+
+	return nil
+}
+
+// remove removes user operations which occupy the same slot as the given ones
+func (p *userOperationPool) remove(entryPoint types.Address, ops ...*types.UserOperation) int {
+	p.Lock()
+	defer p.Unlock()
+
+	removed := 0
+
+	for _, op := range ops {
+		existing, ok := p.bySlot[newUserOperationKey(entryPoint, op)]
+		if !ok {
+			continue
+		}
+
+		p.removeLocked(existing)
+
+		removed++
+	}
+
+	return removed
+}
+
+// removeLocked removes the user operation, the pool lock has to be held
+func (p *userOperationPool) removeLocked(uop *userOperation) {
+	delete(p.bySlot, newUserOperationKey(uop.entryPoint, uop.op))
+	delete(p.all, uop.hash)
+
+	if p.perSender[uop.op.Sender]--; p.perSender[uop.op.Sender] <= 0 {
+		delete(p.perSender, uop.op.Sender)
+	}
+}
+
+// cheapest returns the user operation paying the lowest fees, the pool lock has to be held
+func (p *userOperationPool) cheapest() *userOperation {
+	var cheapest *userOperation
+
+	for _, uop := range p.all {
+		if cheapest == nil || isCheaperUserOperation(uop.op, cheapest.op) {
+			cheapest = uop
+		}
+	}
+
+	return cheapest
+}
+
+// get returns the user operation associated with the given hash
+func (p *userOperationPool) get(hash types.Hash) (*userOperation, bool) {
+	p.RLock()
+	defer p.RUnlock()
+
+	uop, ok := p.all[hash]
+
+	return uop, ok
+}
+
+// isUserOperationReplacement checks that both fee fields of the new operation
+// are increased by at least userOperationReplacementBump percents
+func isUserOperationReplacement(oldOp, newOp *types.UserOperation) bool {
+	bumped := func(oldFee, newFee *big.Int) bool {
+		threshold := new(big.Int).Mul(oldFee, big.NewInt(100+userOperationReplacementBump))
+		threshold.Div(threshold, big.NewInt(100))
+
+		return newFee.Cmp(threshold) >= 0
+	}
+
+	return bumped(oldOp.MaxFeePerGas, newOp.MaxFeePerGas) &&
+		bumped(oldOp.MaxPriorityFeePerGas, newOp.MaxPriorityFeePerGas)
+}
+
+// isCheaperUserOperation checks whether the first user operation pays lower fees than the second one
+func isCheaperUserOperation(op, other *types.UserOperation) bool {
+	if c := op.MaxFeePerGas.Cmp(other.MaxFeePerGas); c != 0 {
+		return c < 0
+	}
+
+	return op.MaxPriorityFeePerGas.Cmp(other.MaxPriorityFeePerGas) < 0
+}
+
+// AddUserOperation validates the user operation against the pool admission rules
+// and adds it to the user operations lane. Returns the user operation hash.
+func (p *TxPool) AddUserOperation(op *types.UserOperation, entryPoint types.Address) (types.Hash, error) {
+	if err := p.validateUserOperation(op, entryPoint); err != nil {
+		metrics.IncrCounter([]string{txPoolMetrics, "rejected_user_operations"}, 1)
+
+		return types.ZeroHash, err
+	}
+
+	chainID := p.chainID
+	if chainID == nil {
+		chainID = big.NewInt(0)
+	}
+
+	hash, err := op.Hash(entryPoint, chainID)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	if err := p.userOperations.add(&userOperation{
+		op:         op.Copy(),
+		entryPoint: entryPoint,
+		hash:       hash,
+	}); err != nil {
+		metrics.IncrCounter([]string{txPoolMetrics, "rejected_user_operations"}, 1)
+
+		return types.ZeroHash, err
+	}
+
+	metrics.IncrCounter([]string{txPoolMetrics, "added_user_operations"}, 1)
+
+	if p.logger.IsDebug() {
+		p.logger.Debug("add user operation", "hash", hash, "sender", op.Sender, "entryPoint", entryPoint)
+	}
+
+	return hash, nil
+}
+
+// GetUserOperation returns the pending user operation and its entry point by the user operation hash
+func (p *TxPool) GetUserOperation(hash types.Hash) (*types.UserOperation, types.Address, bool) {
+	uop, ok := p.userOperations.get(hash)
+	if !ok {
+		return nil, types.ZeroAddress, false
+	}
+
+	return uop.op.Copy(), uop.entryPoint, true
+}
+
+// SupportedEntryPoints returns the entry points the user operations lane routes operations to
+func (p *TxPool) SupportedEntryPoints() []types.Address {
+	entryPoints := make([]types.Address, 0, len(p.userOperations.entryPoints))
+	for entryPoint := range p.userOperations.entryPoints {
+		entryPoints = append(entryPoints, entryPoint)
+	}
+
+	sort.Slice(entryPoints, func(i, j int) bool {
+		return bytes.Compare(entryPoints[i].Bytes(), entryPoints[j].Bytes()) < 0
+	})
+
+	return entryPoints
+}
+
+// validateUserOperation ensures the user operation conforms to the pool admission rules
+func (p *TxPool) validateUserOperation(op *types.UserOperation, entryPoint types.Address) error {
+	if len(p.userOperations.entryPoints) == 0 {
+		return ErrUserOperationsDisabled
+	}
+
+	if !p.userOperations.isEntryPoint(entryPoint) {
+		return ErrUnsupportedEntryPoint
+	}
+
+	if op.Sender == types.ZeroAddress || op.Nonce == nil ||
+		op.CallGasLimit == nil || op.VerificationGasLimit == nil || op.PreVerificationGas == nil ||
+		op.MaxFeePerGas == nil || op.MaxPriorityFeePerGas == nil {
+		return ErrInvalidUserOperation
+	}
+
+	size := len(op.InitCode) + len(op.CallData) + len(op.PaymasterAndData) + len(op.Signature)
+	if size > txMaxSize {
+		return ErrOversizedData
+	}
+
+	if op.MaxFeePerGas.Cmp(op.MaxPriorityFeePerGas) < 0 {
+		return ErrTipAboveFeeCap
+	}
+
+	if op.MaxFeePerGas.Cmp(new(big.Int).SetUint64(p.GetBaseFee())) < 0 ||
+		op.MaxFeePerGas.Cmp(new(big.Int).SetUint64(p.priceLimit.Load())) < 0 {
+		return ErrUnderpriced
+	}
+
+	totalGas := new(big.Int).Add(op.CallGasLimit, op.VerificationGasLimit)
+	totalGas.Add(totalGas, op.PreVerificationGas)
+
+	header := p.store.Header()
+
+	if totalGas.Cmp(new(big.Int).SetUint64(header.GasLimit)) > 0 {
+		return ErrBlockLimitExceeded
+	}
+
+	// the prefund is paid by the paymaster if the operation has one, otherwise by the sender.
+	// The balance is checked instead of the entry point deposit, which the pool doesn't track
+	payer := op.Sender
+	if len(op.PaymasterAndData) >= types.AddressLength {
+		payer = types.BytesToAddress(op.PaymasterAndData[:types.AddressLength])
+	}
+
+	balance, err := p.store.GetBalance(header.StateRoot, payer)
+	if err != nil {
+		return ErrInvalidAccountState
+	}
+
+	if balance.Cmp(op.RequiredPrefund()) < 0 {
+		return ErrInsufficientPrefund
+	}
+
+	return nil
+}
+
+// pruneBundledUserOperations removes the user operations included
+// by the handleOps bundle transaction sent to one of the entry points
+func (p *TxPool) pruneBundledUserOperations(tx *types.Transaction) {
+	if tx.To == nil || !p.userOperations.isEntryPoint(*tx.To) {
+		return
+	}
+
+	ops, err := types.DecodeHandleOps(tx.Input)
+	if err != nil {
+		// not a handleOps bundle, nothing to prune
+		return
+	}
+
+	if removed := p.userOperations.remove(*tx.To, ops...); removed > 0 {
+		metrics.IncrCounter([]string{txPoolMetrics, "bundled_user_operations"}, float32(removed))
+	}
+}
@@ -0,0 +1,280 @@
+package txpool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	testEntryPoint = types.StringToAddress("0x4337")
+	testOpSender   = types.StringToAddress("0xabcd")
+)
+
+func newTestUserOperation(sender types.Address, nonce int64) *types.UserOperation {
+	return &types.UserOperation{
+		Sender:               sender,
+		Nonce:                big.NewInt(nonce),
+		CallData:             []byte{0x1},
+		CallGasLimit:         big.NewInt(100000),
+		VerificationGasLimit: big.NewInt(50000),
+		PreVerificationGas:   big.NewInt(21000),
+		MaxFeePerGas:         big.NewInt(1000),
+		MaxPriorityFeePerGas: big.NewInt(100),
+		Signature:            []byte{0x2},
+	}
+}
+
+func newTestUserOperationsPool(t *testing.T, entryPoints ...types.Address) *TxPool {
+	t.Helper()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+
+	pool.userOperations = newUserOperationPool(entryPoints)
+
+	return pool
+}
+
+func TestAddUserOperation_Validation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		entryPoints []types.Address
+		entryPoint  types.Address
+		modify      func(op *types.UserOperation)
+		expectedErr error
+	}{
+		{
+			name:        "lane disabled",
+			entryPoint:  testEntryPoint,
+			expectedErr: ErrUserOperationsDisabled,
+		},
+		{
+			name:        "unsupported entry point",
+			entryPoints: []types.Address{testEntryPoint},
+			entryPoint:  types.StringToAddress("0x1"),
+			expectedErr: ErrUnsupportedEntryPoint,
+		},
+		{
+			name:        "missing fields",
+			entryPoints: []types.Address{testEntryPoint},
+			entryPoint:  testEntryPoint,
+			modify: func(op *types.UserOperation) {
+				op.MaxFeePerGas = nil
+			},
+			expectedErr: ErrInvalidUserOperation,
+		},
+		{
+			name:        "oversized data",
+			entryPoints: []types.Address{testEntryPoint},
+			entryPoint:  testEntryPoint,
+			modify: func(op *types.UserOperation) {
+				op.CallData = make([]byte, txMaxSize+1)
+			},
+			expectedErr: ErrOversizedData,
+		},
+		{
+			name:        "tip above fee cap",
+			entryPoints: []types.Address{testEntryPoint},
+			entryPoint:  testEntryPoint,
+			modify: func(op *types.UserOperation) {
+				op.MaxPriorityFeePerGas = big.NewInt(1001)
+			},
+			expectedErr: ErrTipAboveFeeCap,
+		},
+		{
+			name:        "gas above block limit",
+			entryPoints: []types.Address{testEntryPoint},
+			entryPoint:  testEntryPoint,
+			modify: func(op *types.UserOperation) {
+				op.CallGasLimit = new(big.Int).SetUint64(mockHeader.GasLimit)
+			},
+			expectedErr: ErrBlockLimitExceeded,
+		},
+		{
+			name:        "insufficient prefund",
+			entryPoints: []types.Address{testEntryPoint},
+			entryPoint:  testEntryPoint,
+			modify: func(op *types.UserOperation) {
+				op.MaxFeePerGas = big.NewInt(1_000_000_000_000)
+			},
+			expectedErr: ErrInsufficientPrefund,
+		},
+		{
+			name:        "valid",
+			entryPoints: []types.Address{testEntryPoint},
+			entryPoint:  testEntryPoint,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			pool := newTestUserOperationsPool(t, tc.entryPoints...)
+
+			op := newTestUserOperation(testOpSender, 0)
+			if tc.modify != nil {
+				tc.modify(op)
+			}
+
+			hash, err := pool.AddUserOperation(op, tc.entryPoint)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			stored, entryPoint, ok := pool.GetUserOperation(hash)
+			require.True(t, ok)
+			assert.Equal(t, tc.entryPoint, entryPoint)
+			assert.Equal(t, op, stored)
+		})
+	}
+}
+
+func TestAddUserOperation_Replacement(t *testing.T) {
+	t.Parallel()
+
+	pool := newTestUserOperationsPool(t, testEntryPoint)
+
+	op := newTestUserOperation(testOpSender, 0)
+
+	hash, err := pool.AddUserOperation(op, testEntryPoint)
+	require.NoError(t, err)
+
+	_, err = pool.AddUserOperation(op, testEntryPoint)
+	assert.ErrorIs(t, err, ErrAlreadyKnown)
+
+	// fee bump is not sufficient
+	underpriced := op.Copy()
+	underpriced.MaxFeePerGas = big.NewInt(1050)
+	underpriced.MaxPriorityFeePerGas = big.NewInt(200)
+
+	_, err = pool.AddUserOperation(underpriced, testEntryPoint)
+	assert.ErrorIs(t, err, ErrReplacementUnderpriced)
+
+	replacement := op.Copy()
+	replacement.MaxFeePerGas = big.NewInt(1100)
+	replacement.MaxPriorityFeePerGas = big.NewInt(110)
+
+	replacementHash, err := pool.AddUserOperation(replacement, testEntryPoint)
+	require.NoError(t, err)
+
+	_, _, ok := pool.GetUserOperation(hash)
+	assert.False(t, ok)
+
+	_, _, ok = pool.GetUserOperation(replacementHash)
+	assert.True(t, ok)
+
+	assert.Len(t, pool.userOperations.all, 1)
+}
+
+func TestAddUserOperation_MaxPerSender(t *testing.T) {
+	t.Parallel()
+
+	pool := newTestUserOperationsPool(t, testEntryPoint)
+
+	for i := 0; i < maxUserOperationsPerSender; i++ {
+		_, err := pool.AddUserOperation(newTestUserOperation(testOpSender, int64(i)), testEntryPoint)
+		require.NoError(t, err)
+	}
+
+	_, err := pool.AddUserOperation(
+		newTestUserOperation(testOpSender, maxUserOperationsPerSender), testEntryPoint)
+	assert.ErrorIs(t, err, ErrMaxUserOperationsReached)
+
+	// other senders are not affected
+	_, err = pool.AddUserOperation(newTestUserOperation(types.StringToAddress("0x1"), 0), testEntryPoint)
+	assert.NoError(t, err)
+}
+
+func TestAddUserOperation_PoolFull(t *testing.T) {
+	t.Parallel()
+
+	pool := newTestUserOperationsPool(t, testEntryPoint)
+	pool.userOperations.capacity = 2
+
+	cheap := newTestUserOperation(types.StringToAddress("0x1"), 0)
+
+	cheapHash, err := pool.AddUserOperation(cheap, testEntryPoint)
+	require.NoError(t, err)
+
+	_, err = pool.AddUserOperation(newTestUserOperation(types.StringToAddress("0x2"), 0), testEntryPoint)
+	require.NoError(t, err)
+
+	// the operation paying the same fees doesn't evict the pending ones
+	_, err = pool.AddUserOperation(newTestUserOperation(types.StringToAddress("0x3"), 0), testEntryPoint)
+	assert.ErrorIs(t, err, ErrUserOperationPoolFull)
+
+	// the better paying operation evicts the cheapest one
+	pool.userOperations.all[cheapHash].op.MaxPriorityFeePerGas = big.NewInt(50)
+
+	_, err = pool.AddUserOperation(newTestUserOperation(types.StringToAddress("0x3"), 0), testEntryPoint)
+	require.NoError(t, err)
+
+	_, _, ok := pool.GetUserOperation(cheapHash)
+	assert.False(t, ok)
+	assert.Len(t, pool.userOperations.all, 2)
+	assert.NotContains(t, pool.userOperations.perSender, types.StringToAddress("0x1"))
+}
+
+func TestPruneBundledUserOperations(t *testing.T) {
+	t.Parallel()
+
+	pool := newTestUserOperationsPool(t, testEntryPoint)
+
+	bundled := newTestUserOperation(testOpSender, 0)
+	pending := newTestUserOperation(testOpSender, 1)
+
+	bundledHash, err := pool.AddUserOperation(bundled, testEntryPoint)
+	require.NoError(t, err)
+
+	pendingHash, err := pool.AddUserOperation(pending, testEntryPoint)
+	require.NoError(t, err)
+
+	input, err := types.HandleOpsABIMethod.Encode(map[string]interface{}{
+		"ops": []map[string]interface{}{
+			{
+				"sender":               ethgo.Address(bundled.Sender),
+				"nonce":                bundled.Nonce,
+				"initCode":             bundled.InitCode,
+				"callData":             bundled.CallData,
+				"callGasLimit":         bundled.CallGasLimit,
+				"verificationGasLimit": bundled.VerificationGasLimit,
+				"preVerificationGas":   bundled.PreVerificationGas,
+				"maxFeePerGas":         bundled.MaxFeePerGas,
+				"maxPriorityFeePerGas": bundled.MaxPriorityFeePerGas,
+				"paymasterAndData":     bundled.PaymasterAndData,
+				"signature":            bundled.Signature,
+			},
+		},
+		"beneficiary": ethgo.ZeroAddress,
+	})
+	require.NoError(t, err)
+
+	// bundles sent to other addresses are ignored
+	pool.pruneBundledUserOperations(&types.Transaction{To: &addr1, Input: input})
+
+	_, _, ok := pool.GetUserOperation(bundledHash)
+	require.True(t, ok)
+
+	pool.pruneBundledUserOperations(&types.Transaction{To: &testEntryPoint, Input: input})
+
+	_, _, ok = pool.GetUserOperation(bundledHash)
+	assert.False(t, ok)
+
+	_, _, ok = pool.GetUserOperation(pendingHash)
+	assert.True(t, ok)
+}
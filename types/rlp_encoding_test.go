@@ -175,6 +175,13 @@ func TestRLPMarshall_And_Unmarshall_TypedTransaction(t *testing.T) {
 	}
 }
 
+func TestRLPUnmarshal_Block_TypedTransactionWithoutData(t *testing.T) {
+	t.Parallel()
+
+	block := &Block{}
+	require.ErrorContains(t, block.UnmarshalRLP(blockWithTxTypeOnly()), "missing data of transaction")
+}
+
 func TestRLPMarshall_Unmarshall_Missing_Data(t *testing.T) {
 	t.Parallel()
 
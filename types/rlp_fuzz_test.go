@@ -0,0 +1,92 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/fastrlp"
+)
+
+func FuzzTransactionUnmarshalRLP(f *testing.F) {
+	to := StringToAddress("11")
+
+	for _, txType := range []TxType{LegacyTx, StateTx, DynamicFeeTx} {
+		tx := &Transaction{
+			Type:      txType,
+			Nonce:     1,
+			GasPrice:  big.NewInt(11),
+			GasFeeCap: big.NewInt(12),
+			GasTipCap: big.NewInt(13),
+			Gas:       21000,
+			To:        &to,
+			Value:     big.NewInt(1),
+			Input:     []byte{1, 2},
+			V:         big.NewInt(25),
+			R:         big.NewInt(26),
+			S:         big.NewInt(27),
+			ChainID:   big.NewInt(100),
+		}
+
+		f.Add(tx.MarshalRLP())
+	}
+
+	f.Add([]byte{})
+	f.Add([]byte{byte(DynamicFeeTx)})
+	f.Add([]byte{0xc0})
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		tx := &Transaction{}
+		if err := tx.UnmarshalRLP(input); err != nil {
+			return
+		}
+
+		// the decoded transaction survives the round trip
+		decoded := &Transaction{}
+		require.NoError(t, decoded.UnmarshalRLP(tx.MarshalRLP()))
+		require.Equal(t, tx.Type, decoded.Type)
+		require.Equal(t, tx.ComputeHash(1).Hash, decoded.ComputeHash(1).Hash)
+	})
+}
+
+func FuzzBlockUnmarshalRLP(f *testing.F) {
+	to := StringToAddress("11")
+	block := &Block{
+		Header: &Header{Number: 1, ExtraData: []byte{1, 2, 3}},
+		Transactions: []*Transaction{
+			{Type: LegacyTx, GasPrice: big.NewInt(1), To: &to, Value: big.NewInt(1), V: big.NewInt(1),
+				R: big.NewInt(1), S: big.NewInt(1)},
+			{Type: DynamicFeeTx, GasFeeCap: big.NewInt(2), GasTipCap: big.NewInt(1), Value: big.NewInt(0),
+				V: big.NewInt(0), R: big.NewInt(1), S: big.NewInt(1), ChainID: big.NewInt(100)},
+		},
+	}
+
+	f.Add(block.MarshalRLP())
+	f.Add((&Block{Header: &Header{}}).MarshalRLP())
+	f.Add([]byte{0xc3, 0xc0, 0xc1, 0x02})
+	f.Add(blockWithTxTypeOnly())
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		decoded := &Block{}
+		if err := decoded.UnmarshalRLP(input); err != nil {
+			return
+		}
+
+		require.NoError(t, (&Block{}).UnmarshalRLP(decoded.MarshalRLP()))
+	})
+}
+
+// blockWithTxTypeOnly returns the encoded block whose transactions end with the type of the typed transaction
+func blockWithTxTypeOnly() []byte {
+	arena := &fastrlp.Arena{}
+
+	txs := arena.NewArray()
+	txs.Set(arena.NewBytes([]byte{byte(DynamicFeeTx)}))
+
+	block := arena.NewArray()
+	block.Set((&Header{}).MarshalRLPWith(arena))
+	block.Set(txs)
+	block.Set(arena.NewArray())
+
+	return block.MarshalTo(nil)
+}
@@ -53,6 +53,10 @@ func unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value, cb unmarshalRLPFromFu
 
 			// Then we increment element number in order to go to the actual tx data raw below.
 			i++
+
+			if i == len(elems) {
+				return fmt.Errorf("missing data of transaction of type %s", txType)
+			}
 		}
 
 		if err = cb(txType, p, elems[i]); err != nil {
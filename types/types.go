@@ -200,6 +200,66 @@ type Proof struct {
 	Metadata map[string]interface{}
 }
 
+// BridgeTokenMapping is the mapping of the rootchain token to the child chain token, kept by the predicate
+type BridgeTokenMapping struct {
+	// TokenType is the standard of the token, erc20, erc721 or erc1155
+	TokenType  string  `json:"tokenType"`
+	Predicate  Address `json:"predicate"`
+	RootToken  Address `json:"rootToken"`
+	ChildToken Address `json:"childToken"`
+}
+
+// BridgeTxStatus is the stage of the bridge transfer
+type BridgeTxStatus string
+
+const (
+	// BridgeTxPending is the transfer not yet committed (deposit) or checkpointed (withdrawal)
+	BridgeTxPending BridgeTxStatus = "pending"
+	// BridgeTxCommitted is the deposit committed to the child chain, but not yet executed
+	BridgeTxCommitted BridgeTxStatus = "committed"
+	// BridgeTxExecuted is the deposit executed on the child chain
+	BridgeTxExecuted BridgeTxStatus = "executed"
+	// BridgeTxExitReady is the withdrawal checkpointed to the rootchain, so that it can be exited
+	BridgeTxExitReady BridgeTxStatus = "exit-ready"
+	// BridgeTxExited is the withdrawal exited on the rootchain
+	BridgeTxExited BridgeTxStatus = "exited"
+)
+
+// BridgeTransferStatus is the status of the bridge event (the state sync of the deposit or the exit of
+// the withdrawal) emitted by the bridge transaction
+type BridgeTransferStatus struct {
+	// ID is the id of the state sync or of the exit event
+	ID uint64 `json:"id"`
+	// BlockNumber is the number of the block the event was emitted in, on the chain the transfer originates from
+	BlockNumber uint64         `json:"blockNumber"`
+	Status      BridgeTxStatus `json:"status"`
+}
+
+// ValidatorPerformance is the participation of the validator in the consensus, recorded from the finalized blocks
+type ValidatorPerformance struct {
+	Address Address `json:"address"`
+	// ProposedBlocks is the number of the finalized blocks proposed by the validator
+	ProposedBlocks uint64 `json:"proposedBlocks"`
+	// SignedBlocks is the number of the blocks the validator committed seal was included for
+	SignedBlocks uint64 `json:"signedBlocks"`
+	// MissedBlocks is the number of the blocks the validator was expected to seal, but its seal is missing
+	MissedBlocks uint64 `json:"missedBlocks"`
+	// LastSignedBlock is the number of the last block sealed by the validator
+	LastSignedBlock uint64 `json:"lastSignedBlock"`
+	// Messages is the number of the consensus messages of the validator the node received
+	Messages uint64 `json:"messages"`
+}
+
+// Uptime returns the share of the blocks sealed by the validator in the blocks it was expected to seal
+func (v *ValidatorPerformance) Uptime() float64 {
+	expected := v.SignedBlocks + v.MissedBlocks
+	if expected == 0 {
+		return 0
+	}
+
+	return float64(v.SignedBlocks) / float64(expected)
+}
+
 type OverrideAccount struct {
 	Nonce     *uint64
 	Code      []byte
@@ -209,3 +269,12 @@ type OverrideAccount struct {
 }
 
 type StateOverride map[Address]OverrideAccount
+
+// BlockOverride holds the fields overriding the block context of the executed transactions
+type BlockOverride struct {
+	Number    *uint64
+	Timestamp *uint64
+	GasLimit  *uint64
+	Coinbase  *Address
+	BaseFee   *big.Int
+}
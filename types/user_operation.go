@@ -0,0 +1,166 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+)
+
+var (
+	userOperationPackedABIType = abi.MustNewType("tuple(address sender, uint256 nonce, " +
+		"bytes32 initCode, bytes32 callData, uint256 callGasLimit, uint256 verificationGasLimit, " +
+		"uint256 preVerificationGas, uint256 maxFeePerGas, uint256 maxPriorityFeePerGas, bytes32 paymasterAndData)")
+
+	userOperationHashABIType = abi.MustNewType(
+		"tuple(bytes32 userOpHash, address entryPoint, uint256 chainId)")
+
+	// HandleOpsABIMethod is the ERC-4337 (v0.6) EntryPoint bundle submission method
+	HandleOpsABIMethod, _ = abi.NewMethod("function handleOps(" +
+		"tuple(address sender, uint256 nonce, bytes initCode, bytes callData, uint256 callGasLimit, " +
+		"uint256 verificationGasLimit, uint256 preVerificationGas, uint256 maxFeePerGas, " +
+		"uint256 maxPriorityFeePerGas, bytes paymasterAndData, bytes signature)[] ops, " +
+		"address beneficiary)")
+)
+
+// UserOperation is an ERC-4337 user operation, as submitted by smart contract wallets
+// to a bundler and executed by the EntryPoint contract through handleOps bundles
+type UserOperation struct {
+	Sender               Address
+	Nonce                *big.Int
+	InitCode             []byte
+	CallData             []byte
+	CallGasLimit         *big.Int
+	VerificationGasLimit *big.Int
+	PreVerificationGas   *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	PaymasterAndData     []byte
+	Signature            []byte
+}
+
+// Hash calculates the user operation hash, as defined by the EntryPoint contract (getUserOpHash)
+func (op *UserOperation) Hash(entryPoint Address, chainID *big.Int) (Hash, error) {
+	packed, err := userOperationPackedABIType.Encode(map[string]interface{}{
+		"sender":               ethgo.Address(op.Sender),
+		"nonce":                op.Nonce,
+		"initCode":             BytesToHash(keccak.Keccak256(nil, op.InitCode)),
+		"callData":             BytesToHash(keccak.Keccak256(nil, op.CallData)),
+		"callGasLimit":         op.CallGasLimit,
+		"verificationGasLimit": op.VerificationGasLimit,
+		"preVerificationGas":   op.PreVerificationGas,
+		"maxFeePerGas":         op.MaxFeePerGas,
+		"maxPriorityFeePerGas": op.MaxPriorityFeePerGas,
+		"paymasterAndData":     BytesToHash(keccak.Keccak256(nil, op.PaymasterAndData)),
+	})
+	if err != nil {
+		return ZeroHash, err
+	}
+
+	encoded, err := userOperationHashABIType.Encode(map[string]interface{}{
+		"userOpHash": BytesToHash(keccak.Keccak256(nil, packed)),
+		"entryPoint": ethgo.Address(entryPoint),
+		"chainId":    chainID,
+	})
+	if err != nil {
+		return ZeroHash, err
+	}
+
+	return BytesToHash(keccak.Keccak256(nil, encoded)), nil
+}
+
+// RequiredPrefund returns the maximum amount of native tokens the user operation can be charged with
+func (op *UserOperation) RequiredPrefund() *big.Int {
+	gas := new(big.Int).Add(op.CallGasLimit, op.VerificationGasLimit)
+	gas.Add(gas, op.PreVerificationGas)
+
+	return gas.Mul(gas, op.MaxFeePerGas)
+}
+
+// Copy returns a deep copy of the user operation
+func (op *UserOperation) Copy() *UserOperation {
+	copyBig := func(b *big.Int) *big.Int {
+		if b == nil {
+			return nil
+		}
+
+		return new(big.Int).Set(b)
+	}
+
+	return &UserOperation{
+		Sender:               op.Sender,
+		Nonce:                copyBig(op.Nonce),
+		InitCode:             append([]byte(nil), op.InitCode...),
+		CallData:             append([]byte(nil), op.CallData...),
+		CallGasLimit:         copyBig(op.CallGasLimit),
+		VerificationGasLimit: copyBig(op.VerificationGasLimit),
+		PreVerificationGas:   copyBig(op.PreVerificationGas),
+		MaxFeePerGas:         copyBig(op.MaxFeePerGas),
+		MaxPriorityFeePerGas: copyBig(op.MaxPriorityFeePerGas),
+		PaymasterAndData:     append([]byte(nil), op.PaymasterAndData...),
+		Signature:            append([]byte(nil), op.Signature...),
+	}
+}
+
+// DecodeHandleOps decodes user operations from the handleOps bundle transaction input
+func DecodeHandleOps(input []byte) ([]*UserOperation, error) {
+	if len(input) < abiMethodIDLength {
+		return nil, fmt.Errorf("invalid handleOps data, len = %d", len(input))
+	}
+
+	rawResult, err := HandleOpsABIMethod.Inputs.Decode(input[abiMethodIDLength:])
+	if err != nil {
+		return nil, err
+	}
+
+	result, isOk := rawResult.(map[string]interface{})
+	if !isOk {
+		return nil, fmt.Errorf("invalid handleOps data")
+	}
+
+	rawOps, isOk := result["ops"].([]map[string]interface{})
+	if !isOk {
+		return nil, fmt.Errorf("invalid handleOps ops field")
+	}
+
+	ops := make([]*UserOperation, len(rawOps))
+
+	for i, rawOp := range rawOps {
+		sender, isOk := rawOp["sender"].(ethgo.Address)
+		if !isOk {
+			return nil, fmt.Errorf("invalid user operation sender field")
+		}
+
+		op := &UserOperation{Sender: Address(sender)}
+
+		for name, dst := range map[string]**big.Int{
+			"nonce":                &op.Nonce,
+			"callGasLimit":         &op.CallGasLimit,
+			"verificationGasLimit": &op.VerificationGasLimit,
+			"preVerificationGas":   &op.PreVerificationGas,
+			"maxFeePerGas":         &op.MaxFeePerGas,
+			"maxPriorityFeePerGas": &op.MaxPriorityFeePerGas,
+		} {
+			if *dst, isOk = rawOp[name].(*big.Int); !isOk {
+				return nil, fmt.Errorf("invalid user operation %s field", name)
+			}
+		}
+
+		for name, dst := range map[string]*[]byte{
+			"initCode":         &op.InitCode,
+			"callData":         &op.CallData,
+			"paymasterAndData": &op.PaymasterAndData,
+			"signature":        &op.Signature,
+		} {
+			if *dst, isOk = rawOp[name].([]byte); !isOk {
+				return nil, fmt.Errorf("invalid user operation %s field", name)
+			}
+		}
+
+		ops[i] = op
+	}
+
+	return ops, nil
+}
@@ -0,0 +1,100 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+)
+
+func newTestUserOperation(sender Address, nonce int64) *UserOperation {
+	return &UserOperation{
+		Sender:               sender,
+		Nonce:                big.NewInt(nonce),
+		InitCode:             []byte{},
+		CallData:             []byte{0x1, 0x2, 0x3},
+		CallGasLimit:         big.NewInt(100000),
+		VerificationGasLimit: big.NewInt(50000),
+		PreVerificationGas:   big.NewInt(21000),
+		MaxFeePerGas:         big.NewInt(1000),
+		MaxPriorityFeePerGas: big.NewInt(100),
+		PaymasterAndData:     []byte{},
+		Signature:            []byte{0xa, 0xb},
+	}
+}
+
+func TestUserOperation_Hash(t *testing.T) {
+	t.Parallel()
+
+	op := newTestUserOperation(StringToAddress("0x1"), 0)
+	entryPoint := StringToAddress("0x2")
+
+	hash, err := op.Hash(entryPoint, big.NewInt(100))
+	require.NoError(t, err)
+	require.NotEqual(t, ZeroHash, hash)
+
+	// signature is not part of the hash
+	signed := op.Copy()
+	signed.Signature = []byte{0xc}
+
+	signedHash, err := signed.Hash(entryPoint, big.NewInt(100))
+	require.NoError(t, err)
+	require.Equal(t, hash, signedHash)
+
+	// hash is bound to the entry point and the chain id
+	otherHash, err := op.Hash(StringToAddress("0x3"), big.NewInt(100))
+	require.NoError(t, err)
+	require.NotEqual(t, hash, otherHash)
+
+	otherHash, err = op.Hash(entryPoint, big.NewInt(101))
+	require.NoError(t, err)
+	require.NotEqual(t, hash, otherHash)
+}
+
+func TestUserOperation_RequiredPrefund(t *testing.T) {
+	t.Parallel()
+
+	op := newTestUserOperation(StringToAddress("0x1"), 0)
+
+	require.Equal(t, big.NewInt((100000+50000+21000)*1000), op.RequiredPrefund())
+}
+
+func TestUserOperation_DecodeHandleOps(t *testing.T) {
+	t.Parallel()
+
+	ops := []*UserOperation{
+		newTestUserOperation(StringToAddress("0x1"), 1),
+		newTestUserOperation(StringToAddress("0x2"), 5),
+	}
+
+	rawOps := make([]map[string]interface{}, len(ops))
+	for i, op := range ops {
+		rawOps[i] = map[string]interface{}{
+			"sender":               ethgo.Address(op.Sender),
+			"nonce":                op.Nonce,
+			"initCode":             op.InitCode,
+			"callData":             op.CallData,
+			"callGasLimit":         op.CallGasLimit,
+			"verificationGasLimit": op.VerificationGasLimit,
+			"preVerificationGas":   op.PreVerificationGas,
+			"maxFeePerGas":         op.MaxFeePerGas,
+			"maxPriorityFeePerGas": op.MaxPriorityFeePerGas,
+			"paymasterAndData":     op.PaymasterAndData,
+			"signature":            op.Signature,
+		}
+	}
+
+	input, err := HandleOpsABIMethod.Encode(map[string]interface{}{
+		"ops":         rawOps,
+		"beneficiary": ethgo.ZeroAddress,
+	})
+	require.NoError(t, err)
+
+	decoded, err := DecodeHandleOps(input)
+	require.NoError(t, err)
+	require.Equal(t, ops, decoded)
+
+	_, err = DecodeHandleOps([]byte{0x1})
+	require.Error(t, err)
+}
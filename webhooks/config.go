@@ -0,0 +1,127 @@
+package webhooks
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// EventBlock is the new block, or the block removed from the canonical chain by a reorg
+	EventBlock = "block"
+	// EventLog is the log emitted in a new block
+	EventLog = "log"
+	// EventReceipt is the receipt of a transaction in a new block
+	EventReceipt = "receipt"
+
+	// DefaultMaxRetries is the number of the delivery retries if the endpoint doesn't set it
+	DefaultMaxRetries = 5
+)
+
+var (
+	errNoURL        = errors.New("the webhook URL is not set")
+	errInvalidURL   = errors.New("the webhook URL has to be an http or https URL")
+	errUnknownEvent = errors.New("unknown webhook event")
+)
+
+// Endpoint is the HTTP endpoint the events are posted to. The addresses and the topics filter the logs
+// and the receipts, the empty filters match all of them
+type Endpoint struct {
+	URL string `json:"url" yaml:"url"`
+	// Secret is the key of the HMAC-SHA256 signature of the payloads, the payloads are not signed if empty
+	Secret string `json:"secret,omitempty" yaml:"secret,omitempty"`
+	// Events are the kinds of the posted events, all of them if empty
+	Events []string `json:"events,omitempty" yaml:"events,omitempty"`
+	// Addresses are the emitters of the posted logs, and the senders, recipients or created contracts
+	// of the posted receipts
+	Addresses []string `json:"addresses,omitempty" yaml:"addresses,omitempty"`
+	// Topics are the first topics (the event signatures) of the posted logs
+	Topics []string `json:"topics,omitempty" yaml:"topics,omitempty"`
+	// MaxRetries is the number of the retries of a failed delivery, DefaultMaxRetries if 0
+	MaxRetries uint64 `json:"max_retries,omitempty" yaml:"max_retries,omitempty"`
+}
+
+// Validate checks the URL, the events and the filters of the endpoint
+func (e *Endpoint) Validate() error {
+	_, err := e.filter()
+
+	return err
+}
+
+// filter is the parsed filter of the endpoint
+type filter struct {
+	events    map[string]bool
+	addresses map[types.Address]bool
+	topics    map[types.Hash]bool
+}
+
+func (e *Endpoint) filter() (*filter, error) {
+	if e.URL == "" {
+		return nil, errNoURL
+	}
+
+	u, err := url.Parse(e.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w: %s", errInvalidURL, e.URL)
+	}
+
+	f := &filter{
+		events:    make(map[string]bool, len(e.Events)),
+		addresses: make(map[types.Address]bool, len(e.Addresses)),
+		topics:    make(map[types.Hash]bool, len(e.Topics)),
+	}
+
+	for _, event := range e.Events {
+		if event != EventBlock && event != EventLog && event != EventReceipt {
+			return nil, fmt.Errorf("%w: %s", errUnknownEvent, event)
+		}
+
+		f.events[event] = true
+	}
+
+	for _, raw := range e.Addresses {
+		var address types.Address
+		if err := address.UnmarshalText([]byte(raw)); err != nil {
+			return nil, fmt.Errorf("invalid webhook address %s: %w", raw, err)
+		}
+
+		f.addresses[address] = true
+	}
+
+	for _, raw := range e.Topics {
+		var topic types.Hash
+		if err := topic.UnmarshalText([]byte(raw)); err != nil {
+			return nil, fmt.Errorf("invalid webhook topic %s: %w", raw, err)
+		}
+
+		f.topics[topic] = true
+	}
+
+	return f, nil
+}
+
+func (f *filter) hasEvent(event string) bool {
+	return len(f.events) == 0 || f.events[event]
+}
+
+func (f *filter) matchesLog(log *types.Log) bool {
+	if len(f.addresses) > 0 && !f.addresses[log.Address] {
+		return false
+	}
+
+	return len(f.topics) == 0 || (len(log.Topics) > 0 && f.topics[log.Topics[0]])
+}
+
+func (f *filter) matchesReceipt(tx *types.Transaction, receipt *types.Receipt) bool {
+	if len(f.addresses) == 0 || f.addresses[tx.From] {
+		return true
+	}
+
+	if tx.To != nil && f.addresses[*tx.To] {
+		return true
+	}
+
+	return receipt.ContractAddress != nil && f.addresses[*receipt.ContractAddress]
+}
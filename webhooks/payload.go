@@ -0,0 +1,121 @@
+package webhooks
+
+import (
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Payload is the JSON body posted to the endpoints, it holds one of the block, the log and the receipt
+type Payload struct {
+	Event   string `json:"event"`
+	ChainID int64  `json:"chainId"`
+	// Removed is set if the block was removed from the canonical chain by a reorg
+	Removed bool            `json:"removed,omitempty"`
+	Block   *BlockPayload   `json:"block,omitempty"`
+	Log     *LogPayload     `json:"log,omitempty"`
+	Receipt *ReceiptPayload `json:"receipt,omitempty"`
+}
+
+type BlockPayload struct {
+	Number       uint64        `json:"number"`
+	Hash         types.Hash    `json:"hash"`
+	ParentHash   types.Hash    `json:"parentHash"`
+	Timestamp    uint64        `json:"timestamp"`
+	Miner        types.Address `json:"miner"`
+	GasUsed      uint64        `json:"gasUsed"`
+	GasLimit     uint64        `json:"gasLimit"`
+	BaseFee      uint64        `json:"baseFeePerGas"`
+	Transactions []types.Hash  `json:"transactions"`
+}
+
+type LogPayload struct {
+	Address     types.Address `json:"address"`
+	Topics      []types.Hash  `json:"topics"`
+	Data        string        `json:"data"`
+	BlockNumber uint64        `json:"blockNumber"`
+	BlockHash   types.Hash    `json:"blockHash"`
+	TxHash      types.Hash    `json:"transactionHash"`
+	TxIndex     uint64        `json:"transactionIndex"`
+	LogIndex    uint64        `json:"logIndex"`
+}
+
+type ReceiptPayload struct {
+	TxHash            types.Hash     `json:"transactionHash"`
+	TxIndex           uint64         `json:"transactionIndex"`
+	BlockNumber       uint64         `json:"blockNumber"`
+	BlockHash         types.Hash     `json:"blockHash"`
+	From              types.Address  `json:"from"`
+	To                *types.Address `json:"to"`
+	ContractAddress   *types.Address `json:"contractAddress"`
+	Status            uint64         `json:"status"`
+	GasUsed           uint64         `json:"gasUsed"`
+	CumulativeGasUsed uint64         `json:"cumulativeGasUsed"`
+	Logs              []*LogPayload  `json:"logs"`
+}
+
+func newBlockPayload(block *types.Block) *BlockPayload {
+	header := block.Header
+
+	payload := &BlockPayload{
+		Number:       header.Number,
+		Hash:         header.Hash,
+		ParentHash:   header.ParentHash,
+		Timestamp:    header.Timestamp,
+		Miner:        types.BytesToAddress(header.Miner),
+		GasUsed:      header.GasUsed,
+		GasLimit:     header.GasLimit,
+		BaseFee:      header.BaseFee,
+		Transactions: make([]types.Hash, len(block.Transactions)),
+	}
+
+	for i, tx := range block.Transactions {
+		payload.Transactions[i] = tx.Hash
+	}
+
+	return payload
+}
+
+func newLogPayload(log *types.Log, header *types.Header, tx *types.Transaction, txIndex, logIndex uint64) *LogPayload {
+	topics := log.Topics
+	if topics == nil {
+		topics = []types.Hash{}
+	}
+
+	return &LogPayload{
+		Address:     log.Address,
+		Topics:      topics,
+		Data:        hex.EncodeToHex(log.Data),
+		BlockNumber: header.Number,
+		BlockHash:   header.Hash,
+		TxHash:      tx.Hash,
+		TxIndex:     txIndex,
+		LogIndex:    logIndex,
+	}
+}
+
+func newReceiptPayload(
+	receipt *types.Receipt,
+	header *types.Header,
+	tx *types.Transaction,
+	txIndex uint64,
+	logs []*LogPayload,
+) *ReceiptPayload {
+	payload := &ReceiptPayload{
+		TxHash:            tx.Hash,
+		TxIndex:           txIndex,
+		BlockNumber:       header.Number,
+		BlockHash:         header.Hash,
+		From:              tx.From,
+		To:                tx.To,
+		ContractAddress:   receipt.ContractAddress,
+		GasUsed:           receipt.GasUsed,
+		CumulativeGasUsed: receipt.CumulativeGasUsed,
+		Logs:              logs,
+	}
+
+	if receipt.Status != nil {
+		payload.Status = uint64(*receipt.Status)
+	}
+
+	return payload
+}
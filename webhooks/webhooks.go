@@ -0,0 +1,372 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// queueSize is the number of the pending deliveries of an endpoint, the new deliveries are dropped if it's full
+	queueSize = 1024
+	// requestTimeout is the timeout of a single delivery attempt
+	requestTimeout = 10 * time.Second
+
+	// initialBackoff and maxBackoff bound the exponential backoff between the delivery attempts
+	initialBackoff = time.Second
+	maxBackoff     = time.Minute
+
+	// the headers of the requests, the signature is the HMAC-SHA256 of "<timestamp>.<body>"
+	// with the secret of the endpoint, hex encoded and prefixed with "sha256="
+	headerEvent     = "X-Edge-Event"
+	headerDelivery  = "X-Edge-Delivery"
+	headerTimestamp = "X-Edge-Timestamp"
+	headerSignature = "X-Edge-Signature"
+
+	webhooksMetrics = "webhooks"
+)
+
+// Blockchain is the chain the events are posted of
+type Blockchain interface {
+	GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool)
+	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
+	SubscribeEvents() blockchain.Subscription
+	UnsubscribeEvents(sub blockchain.Subscription)
+}
+
+// Dispatcher posts the new blocks, the logs and the receipts to the configured endpoints
+type Dispatcher struct {
+	logger  hclog.Logger
+	chain   Blockchain
+	chainID int64
+	workers []*worker
+
+	subscription blockchain.Subscription
+	ctx          context.Context
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+}
+
+// NewDispatcher creates the dispatcher of the endpoints, which have to be valid
+func NewDispatcher(logger hclog.Logger, endpoints []*Endpoint, chain Blockchain, chainID int64) (*Dispatcher, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	d := &Dispatcher{
+		logger:  logger.Named("webhooks"),
+		chain:   chain,
+		chainID: chainID,
+		workers: make([]*worker, 0, len(endpoints)),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+
+	for _, endpoint := range endpoints {
+		f, err := endpoint.filter()
+		if err != nil {
+			cancel()
+
+			return nil, err
+		}
+
+		maxRetries := endpoint.MaxRetries
+		if maxRetries == 0 {
+			maxRetries = DefaultMaxRetries
+		}
+
+		d.workers = append(d.workers, &worker{
+			logger:     d.logger.With("url", endpoint.URL),
+			url:        endpoint.URL,
+			secret:     []byte(endpoint.Secret),
+			maxRetries: maxRetries,
+			filter:     f,
+			client:     &http.Client{Timeout: requestTimeout},
+			queue:      make(chan *delivery, queueSize),
+		})
+	}
+
+	return d, nil
+}
+
+// Start starts the workers of the endpoints and posts the events of the new blocks
+func (d *Dispatcher) Start() {
+	d.subscription = d.chain.SubscribeEvents()
+
+	for _, w := range d.workers {
+		d.wg.Add(1)
+
+		go func(w *worker) {
+			defer d.wg.Done()
+
+			w.run(d.ctx)
+		}(w)
+	}
+
+	d.wg.Add(1)
+
+	go func() {
+		defer d.wg.Done()
+
+		for {
+			ev := d.subscription.GetEvent()
+			if ev == nil {
+				return
+			}
+
+			if len(ev.NewChain) == 0 {
+				continue
+			}
+
+			for _, header := range ev.OldChain {
+				d.dispatchRemoved(header)
+			}
+
+			for _, header := range ev.NewChain {
+				d.dispatchBlock(header)
+			}
+		}
+	}()
+}
+
+// Close stops posting the events, the pending deliveries are dropped
+func (d *Dispatcher) Close() {
+	if d.subscription != nil {
+		d.chain.UnsubscribeEvents(d.subscription)
+	}
+
+	d.cancel()
+	d.wg.Wait()
+}
+
+// dispatchRemoved posts the block removed from the canonical chain to the endpoints of the block events
+func (d *Dispatcher) dispatchRemoved(header *types.Header) {
+	block := &types.Block{Header: header}
+	if full, ok := d.chain.GetBlockByHash(header.Hash, true); ok {
+		block = full
+	}
+
+	payload := &Payload{Event: EventBlock, ChainID: d.chainID, Removed: true, Block: newBlockPayload(block)}
+
+	for _, w := range d.workers {
+		if w.filter.hasEvent(EventBlock) {
+			w.enqueue(payload)
+		}
+	}
+}
+
+// dispatchBlock posts the block, and its logs and receipts matching the filters of the endpoints
+func (d *Dispatcher) dispatchBlock(header *types.Header) {
+	block, ok := d.chain.GetBlockByHash(header.Hash, true)
+	if !ok {
+		d.logger.Warn("block of the event not found", "number", header.Number, "hash", header.Hash)
+
+		return
+	}
+
+	blockPayload := &Payload{Event: EventBlock, ChainID: d.chainID, Block: newBlockPayload(block)}
+
+	var receipts []*types.Receipt
+
+	if len(block.Transactions) > 0 && d.needsReceipts() {
+		var err error
+
+		if receipts, err = d.chain.GetReceiptsByHash(header.Hash); err != nil {
+			d.logger.Warn("receipts of the block not found", "number", header.Number, "hash", header.Hash, "err", err)
+		}
+	}
+
+	for _, w := range d.workers {
+		if w.filter.hasEvent(EventBlock) {
+			w.enqueue(blockPayload)
+		}
+	}
+
+	if len(receipts) != len(block.Transactions) {
+		return
+	}
+
+	logIndex := uint64(0)
+
+	for i, receipt := range receipts {
+		tx := block.Transactions[i]
+
+		logs := make([]*LogPayload, len(receipt.Logs))
+		for j, log := range receipt.Logs {
+			logs[j] = newLogPayload(log, block.Header, tx, uint64(i), logIndex)
+			logIndex++
+		}
+
+		receiptPayload := &Payload{
+			Event:   EventReceipt,
+			ChainID: d.chainID,
+			Receipt: newReceiptPayload(receipt, block.Header, tx, uint64(i), logs),
+		}
+
+		for _, w := range d.workers {
+			if w.filter.hasEvent(EventReceipt) && w.filter.matchesReceipt(tx, receipt) {
+				w.enqueue(receiptPayload)
+			}
+
+			if !w.filter.hasEvent(EventLog) {
+				continue
+			}
+
+			for j, log := range receipt.Logs {
+				if w.filter.matchesLog(log) {
+					w.enqueue(&Payload{Event: EventLog, ChainID: d.chainID, Log: logs[j]})
+				}
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) needsReceipts() bool {
+	for _, w := range d.workers {
+		if w.filter.hasEvent(EventLog) || w.filter.hasEvent(EventReceipt) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// delivery is the payload pending to be posted to an endpoint
+type delivery struct {
+	id    string
+	event string
+	body  []byte
+}
+
+// worker posts the deliveries of an endpoint in order, retrying the failed ones
+type worker struct {
+	logger     hclog.Logger
+	url        string
+	secret     []byte
+	maxRetries uint64
+	filter     *filter
+	client     *http.Client
+	queue      chan *delivery
+}
+
+func (w *worker) enqueue(payload *Payload) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		w.logger.Error("failed to encode the webhook payload", "event", payload.Event, "err", err)
+
+		return
+	}
+
+	select {
+	case w.queue <- &delivery{id: uuid.NewString(), event: payload.Event, body: body}:
+	default:
+		metrics.IncrCounter([]string{webhooksMetrics, "dropped"}, 1)
+		w.logger.Warn("webhook queue is full, the event is dropped", "event", payload.Event)
+	}
+}
+
+func (w *worker) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case d := <-w.queue:
+			w.deliver(ctx, d)
+		}
+	}
+}
+
+// deliver posts the delivery until it succeeds, the retries are exhausted or the dispatcher is closed
+func (w *worker) deliver(ctx context.Context, d *delivery) {
+	backoff := initialBackoff
+
+	for attempt := uint64(0); ; attempt++ {
+		err := w.post(ctx, d)
+		if err == nil {
+			metrics.IncrCounter([]string{webhooksMetrics, "delivered"}, 1)
+
+			return
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if attempt >= w.maxRetries {
+			metrics.IncrCounter([]string{webhooksMetrics, "failed"}, 1)
+			w.logger.Warn("webhook delivery failed", "event", d.event, "delivery", d.id, "attempts", attempt+1, "err", err)
+
+			return
+		}
+
+		w.logger.Debug("webhook delivery attempt failed", "event", d.event, "delivery", d.id, "err", err, "retry_in", backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+func (w *worker) post(ctx context.Context, d *delivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(d.body))
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(headerEvent, d.event)
+	req.Header.Set(headerDelivery, d.id)
+	req.Header.Set(headerTimestamp, timestamp)
+
+	if len(w.secret) > 0 {
+		req.Header.Set(headerSignature, "sha256="+Sign(w.secret, timestamp, d.body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+
+	return nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of "<timestamp>.<body>", which the receivers verify the
+// X-Edge-Signature header against
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
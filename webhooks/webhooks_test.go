@@ -0,0 +1,205 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	sender    = types.StringToAddress("0x1")
+	recipient = types.StringToAddress("0x2")
+	other     = types.StringToAddress("0x3")
+	topic     = types.StringToHash("0xaa")
+)
+
+type mockChain struct {
+	block    *types.Block
+	receipts []*types.Receipt
+	sub      *blockchain.MockSubscription
+}
+
+// newMockChain creates the chain of a block with the transactions from the sender to the recipient
+// and from the other address to itself, both of which emit a log of the topic
+func newMockChain() *mockChain {
+	header := &types.Header{Number: 1, GasLimit: 1000, GasUsed: 42}
+	header.ComputeHash()
+
+	txs := []*types.Transaction{
+		{From: sender, To: &recipient, Hash: types.StringToHash("0x10")},
+		{From: other, To: &other, Hash: types.StringToHash("0x11")},
+	}
+
+	receipts := make([]*types.Receipt, len(txs))
+	for i, tx := range txs {
+		receipts[i] = &types.Receipt{
+			TxHash: tx.Hash,
+			Logs:   []*types.Log{{Address: *tx.To, Topics: []types.Hash{topic}, Data: []byte{byte(i)}}},
+		}
+		receipts[i].SetStatus(types.ReceiptSuccess)
+	}
+
+	return &mockChain{
+		block:    &types.Block{Header: header, Transactions: txs},
+		receipts: receipts,
+		sub:      blockchain.NewMockSubscription(),
+	}
+}
+
+func (m *mockChain) GetBlockByHash(hash types.Hash, _ bool) (*types.Block, bool) {
+	return m.block, hash == m.block.Hash()
+}
+
+func (m *mockChain) GetReceiptsByHash(types.Hash) ([]*types.Receipt, error) {
+	return m.receipts, nil
+}
+
+func (m *mockChain) SubscribeEvents() blockchain.Subscription {
+	return m.sub
+}
+
+func (m *mockChain) UnsubscribeEvents(blockchain.Subscription) {
+	m.sub.Push(nil)
+}
+
+// newMockEndpoint starts the endpoint which fails the first failures requests, and passes on the others
+func newMockEndpoint(t *testing.T, failures int32) (*httptest.Server, <-chan *http.Request, <-chan *Payload) {
+	t.Helper()
+
+	var (
+		requests  atomic.Int32
+		reqCh     = make(chan *http.Request, 16)
+		payloadCh = make(chan *Payload, 16)
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= failures {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var payload Payload
+		require.NoError(t, json.Unmarshal(body, &payload))
+
+		// the body is checked against the signature
+		r.Header.Set("X-Body", string(body))
+
+		reqCh <- r
+		payloadCh <- &payload
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server, reqCh, payloadCh
+}
+
+func TestEndpoint_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, (&Endpoint{URL: "https://example.com/hook", Events: []string{EventLog}}).Validate())
+	require.ErrorIs(t, (&Endpoint{}).Validate(), errNoURL)
+	require.ErrorIs(t, (&Endpoint{URL: "ftp://example.com"}).Validate(), errInvalidURL)
+	require.ErrorIs(t, (&Endpoint{URL: "http://example.com", Events: []string{"tx"}}).Validate(), errUnknownEvent)
+	require.Error(t, (&Endpoint{URL: "http://example.com", Addresses: []string{"0x12"}}).Validate())
+}
+
+func TestDispatcher_Filters(t *testing.T) {
+	t.Parallel()
+
+	server, reqCh, payloadCh := newMockEndpoint(t, 0)
+	chain := newMockChain()
+
+	dispatcher, err := NewDispatcher(hclog.NewNullLogger(), []*Endpoint{
+		{
+			URL:       server.URL,
+			Secret:    "s3cret",
+			Events:    []string{EventReceipt, EventLog},
+			Addresses: []string{recipient.String()},
+			Topics:    []string{topic.String()},
+		},
+	}, chain, 100)
+	require.NoError(t, err)
+
+	dispatcher.Start()
+	defer dispatcher.Close()
+
+	chain.sub.Push(&blockchain.Event{NewChain: []*types.Header{chain.block.Header}})
+
+	received := map[string]*Payload{}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case payload := <-payloadCh:
+			req := <-reqCh
+
+			require.Equal(t, payload.Event, req.Header.Get(headerEvent))
+			require.NotEmpty(t, req.Header.Get(headerDelivery))
+			require.Equal(t,
+				"sha256="+Sign([]byte("s3cret"), req.Header.Get(headerTimestamp), []byte(req.Header.Get("X-Body"))),
+				req.Header.Get(headerSignature),
+			)
+
+			received[payload.Event] = payload
+		case <-time.After(5 * time.Second):
+			t.Fatal("the events were not posted")
+		}
+	}
+
+	// only the transaction to the recipient and its log match
+	require.Len(t, received, 2)
+	require.Equal(t, int64(100), received[EventReceipt].ChainID)
+	require.Equal(t, chain.block.Transactions[0].Hash, received[EventReceipt].Receipt.TxHash)
+	require.Equal(t, sender, received[EventReceipt].Receipt.From)
+	require.Equal(t, uint64(1), received[EventReceipt].Receipt.Status)
+	require.Equal(t, recipient, received[EventLog].Log.Address)
+	require.Equal(t, "0x00", received[EventLog].Log.Data)
+
+	select {
+	case payload := <-payloadCh:
+		t.Fatalf("unexpected %s event", payload.Event)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestDispatcher_Retry(t *testing.T) {
+	t.Parallel()
+
+	server, reqCh, payloadCh := newMockEndpoint(t, 1)
+	chain := newMockChain()
+
+	dispatcher, err := NewDispatcher(hclog.NewNullLogger(), []*Endpoint{
+		{URL: server.URL, Events: []string{EventBlock}},
+	}, chain, 100)
+	require.NoError(t, err)
+
+	dispatcher.Start()
+	defer dispatcher.Close()
+
+	chain.sub.Push(&blockchain.Event{NewChain: []*types.Header{chain.block.Header}})
+
+	select {
+	case payload := <-payloadCh:
+		req := <-reqCh
+
+		require.Empty(t, req.Header.Get(headerSignature))
+		require.Equal(t, EventBlock, payload.Event)
+		require.Equal(t, chain.block.Hash(), payload.Block.Hash)
+		require.Len(t, payload.Block.Transactions, 2)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the block was not posted")
+	}
+}